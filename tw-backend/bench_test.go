@@ -65,6 +65,14 @@ func (m *MockEventStore) AppendEvent(ctx context.Context, event eventstore.Event
 	return nil
 }
 
+func (m *MockEventStore) AppendEventExpectingVersion(ctx context.Context, event eventstore.Event, expectedVersion int) error {
+	return nil
+}
+
+func (m *MockEventStore) AppendEvents(ctx context.Context, events []eventstore.Event) error {
+	return nil
+}
+
 func (m *MockEventStore) GetEventsByAggregate(ctx context.Context, aggregateID string, fromVersion int64) ([]eventstore.Event, error) {
 	return m.events, nil
 }
@@ -77,6 +85,18 @@ func (m *MockEventStore) GetAllEvents(ctx context.Context, from time.Time, limit
 	return nil, nil
 }
 
+func (m *MockEventStore) SaveSnapshot(ctx context.Context, snapshot eventstore.Snapshot) error {
+	return nil
+}
+
+func (m *MockEventStore) GetLatestSnapshot(ctx context.Context, aggregateID string) (*eventstore.Snapshot, error) {
+	return nil, nil
+}
+
+func (m *MockEventStore) Subscribe(ctx context.Context, fromPosition int64) (<-chan eventstore.Event, error) {
+	return nil, nil
+}
+
 // BenchmarkSpatialQuery benchmarks spatial queries.
 // Note: This requires a real DB connection, so we might skip if TEST_DB_URL is not set.
 // For now, we'll just define it and let it fail or skip if env not set.
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 	"tw-backend/internal/auth"
@@ -19,6 +20,8 @@ type Publisher interface {
 // Interfaces for dependency injection and testing
 type TokenManager interface {
 	GenerateToken(userID, username string, roles []string) (string, error)
+	IssueRefreshToken(ctx context.Context, userID, username string, roles []string) (string, error)
+	Refresh(ctx context.Context, refreshToken string) (accessToken, nextRefreshToken string, err error)
 }
 
 type PasswordHasher interface {
@@ -28,10 +31,11 @@ type PasswordHasher interface {
 
 type SessionManager interface {
 	CreateSession(ctx context.Context, userID, username string) (*auth.Session, error)
+	RevokeRefreshFamily(ctx context.Context, refreshToken string) error
 }
 
 type RateLimiter interface {
-	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error)
+	Allow(ctx context.Context, bucket, key string) (bool, time.Duration, error)
 }
 
 type AuthHandler struct {
@@ -58,9 +62,11 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token    string `json:"token,omitempty"`
-	Username string `json:"username,omitempty"`
-	Error    string `json:"error,omitempty"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Error        string `json:"error,omitempty"`
+	RetryAfter   int    `json:"retry_after,omitempty"` // Seconds until another attempt is allowed
 }
 
 func (h *AuthHandler) HandleLogin(ctx context.Context, msg *nats.Msg) error {
@@ -74,14 +80,17 @@ func (h *AuthHandler) HandleLogin(ctx context.Context, msg *nats.Msg) error {
 	}
 
 	// Rate limit by username to prevent brute force on specific account
-	allowed, err := h.rateLimiter.Allow(ctx, "login:"+req.Username, 5, 1*time.Minute)
+	allowed, retryAfter, err := h.rateLimiter.Allow(ctx, auth.BucketLogin, req.Username)
 	if err != nil {
 		log.Error().Err(err).Msg("Rate limiter error")
 		// Fail open or closed? Fail open for now to avoid lockout on redis error, or closed for security.
 		// Let's fail closed but log it.
 	}
 	if !allowed {
-		resp := LoginResponse{Error: "Too many login attempts. Please try again later."}
+		resp := LoginResponse{
+			Error:      "Too many login attempts. Please try again later.",
+			RetryAfter: int((retryAfter + time.Second - 1) / time.Second),
+		}
 		return h.sendReply(msg.Reply, resp)
 	}
 
@@ -133,10 +142,19 @@ func (h *AuthHandler) HandleLogin(ctx context.Context, msg *nats.Msg) error {
 		return h.sendReply(msg.Reply, resp)
 	}
 
+	// 5. Start a refresh token family, so the session outlives the token
+	refreshToken, err := h.tokenManager.IssueRefreshToken(ctx, userID, req.Username, []string{"admin"})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to issue refresh token")
+		resp := LoginResponse{Error: "Internal server error"}
+		return h.sendReply(msg.Reply, resp)
+	}
+
 	// Return success
 	resp := LoginResponse{
-		Token:    token,
-		Username: req.Username,
+		Token:        token,
+		RefreshToken: refreshToken,
+		Username:     req.Username,
 	}
 
 	// Log session creation
@@ -145,6 +163,67 @@ func (h *AuthHandler) HandleLogin(ctx context.Context, msg *nats.Msg) error {
 	return h.sendReply(msg.Reply, resp)
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshResponse struct {
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// HandleRefresh exchanges a refresh token for a new access token and the
+// next refresh token. A reused token has already revoked its family, so the
+// client must log in again.
+func (h *AuthHandler) HandleRefresh(ctx context.Context, msg *nats.Msg) error {
+	var req RefreshRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		return fmt.Errorf("auth.HandleRefresh: unmarshal: %w", err)
+	}
+
+	token, refreshToken, err := h.tokenManager.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrRefreshTokenReused):
+			log.Warn().Msg("Refresh token reused; token family revoked")
+			return h.sendReply(msg.Reply, RefreshResponse{Error: "Invalid refresh token"})
+		case errors.Is(err, auth.ErrInvalidRefreshToken):
+			return h.sendReply(msg.Reply, RefreshResponse{Error: "Invalid refresh token"})
+		}
+		log.Error().Err(err).Msg("Failed to refresh token")
+		return h.sendReply(msg.Reply, RefreshResponse{Error: "Internal server error"})
+	}
+
+	return h.sendReply(msg.Reply, RefreshResponse{Token: token, RefreshToken: refreshToken})
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// HandleLogout revokes the refresh token's whole family, so no token
+// rotated from the same login can be used again
+func (h *AuthHandler) HandleLogout(ctx context.Context, msg *nats.Msg) error {
+	var req LogoutRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		return fmt.Errorf("auth.HandleLogout: unmarshal: %w", err)
+	}
+
+	// An unknown token has nothing left to revoke
+	if err := h.sessionManager.RevokeRefreshFamily(ctx, req.RefreshToken); err != nil &&
+		!errors.Is(err, auth.ErrInvalidRefreshToken) {
+		log.Error().Err(err).Msg("Failed to revoke refresh tokens")
+		return h.sendReply(msg.Reply, LogoutResponse{Error: "Internal server error"})
+	}
+
+	return h.sendReply(msg.Reply, LogoutResponse{})
+}
+
 func (h *AuthHandler) sendReply(subject string, resp interface{}) error {
 	data, err := json.Marshal(resp)
 	if err != nil {
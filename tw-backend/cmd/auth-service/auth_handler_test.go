@@ -28,6 +28,16 @@ func (m *MockTokenManager) GenerateToken(userID, username string, roles []string
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockTokenManager) IssueRefreshToken(ctx context.Context, userID, username string, roles []string) (string, error) {
+	args := m.Called(ctx, userID, username, roles)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenManager) Refresh(ctx context.Context, refreshToken string) (string, string, error) {
+	args := m.Called(ctx, refreshToken)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
 type MockPasswordHasher struct{ mock.Mock }
 
 func (m *MockPasswordHasher) ComparePassword(password, encodedHash string) (bool, error) {
@@ -49,11 +59,16 @@ func (m *MockSessionManager) CreateSession(ctx context.Context, userID, username
 	return args.Get(0).(*auth.Session), args.Error(1)
 }
 
+func (m *MockSessionManager) RevokeRefreshFamily(ctx context.Context, refreshToken string) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
 type MockRateLimiter struct{ mock.Mock }
 
-func (m *MockRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
-	args := m.Called(ctx, key, limit, window)
-	return args.Bool(0), args.Error(1)
+func (m *MockRateLimiter) Allow(ctx context.Context, bucket, key string) (bool, time.Duration, error) {
+	args := m.Called(ctx, bucket, key)
+	return args.Bool(0), args.Get(1).(time.Duration), args.Error(2)
 }
 
 func TestHandleLogin_Success(t *testing.T) {
@@ -76,17 +91,19 @@ func TestHandleLogin_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Expectations
-	mockRL.On("Allow", ctx, "login:admin", 5, time.Minute).Return(true, nil)
+	mockRL.On("Allow", ctx, auth.BucketLogin, "admin").Return(true, time.Duration(0), nil)
 	mockPH.On("HashPassword", "password123").Return("hashed", nil)
 	mockPH.On("ComparePassword", "password123", "hashed").Return(true, nil)
 	mockSM.On("CreateSession", ctx, "user-admin-id", "admin").Return(&auth.Session{ID: "sess-1"}, nil)
 	mockTM.On("GenerateToken", "user-admin-id", "admin", []string{"admin"}).Return("valid-token", nil)
+	mockTM.On("IssueRefreshToken", ctx, "user-admin-id", "admin", []string{"admin"}).Return("refresh-1", nil)
 
 	// Expect response publish
 	mockPub.On("Publish", "reply-subject", mock.MatchedBy(func(data []byte) bool {
 		var resp LoginResponse
 		json.Unmarshal(data, &resp)
-		return resp.Token == "valid-token" && resp.Username == "admin" && resp.Error == ""
+		return resp.Token == "valid-token" && resp.RefreshToken == "refresh-1" &&
+			resp.Username == "admin" && resp.Error == ""
 	})).Return(nil)
 
 	// Execute
@@ -106,13 +123,74 @@ func TestHandleLogin_RateLimit(t *testing.T) {
 	reqData, _ := json.Marshal(req)
 	msg := &nats.Msg{Data: reqData, Reply: "reply"}
 
-	mockRL.On("Allow", mock.Anything, "login:admin", 5, time.Minute).Return(false, nil)
+	mockRL.On("Allow", mock.Anything, auth.BucketLogin, "admin").Return(false, 1500*time.Millisecond, nil)
 	mockPub.On("Publish", "reply", mock.MatchedBy(func(data []byte) bool {
 		var resp LoginResponse
 		json.Unmarshal(data, &resp)
-		return resp.Error != ""
+		return resp.Error != "" && resp.RetryAfter == 2
 	})).Return(nil)
 
 	err := handler.HandleLogin(context.Background(), msg)
 	assert.NoError(t, err)
 }
+
+func TestHandleRefresh_Rotates(t *testing.T) {
+	mockPub := new(MockPublisher)
+	mockTM := new(MockTokenManager)
+	handler := NewAuthHandler(mockPub, mockTM, nil, nil, nil)
+
+	reqData, _ := json.Marshal(RefreshRequest{RefreshToken: "refresh-1"})
+	msg := &nats.Msg{Data: reqData, Reply: "reply"}
+	ctx := context.Background()
+
+	mockTM.On("Refresh", ctx, "refresh-1").Return("new-token", "refresh-2", nil)
+	mockPub.On("Publish", "reply", mock.MatchedBy(func(data []byte) bool {
+		var resp RefreshResponse
+		json.Unmarshal(data, &resp)
+		return resp.Token == "new-token" && resp.RefreshToken == "refresh-2" && resp.Error == ""
+	})).Return(nil)
+
+	assert.NoError(t, handler.HandleRefresh(ctx, msg))
+	mockPub.AssertExpectations(t)
+}
+
+func TestHandleRefresh_ReusedToken(t *testing.T) {
+	mockPub := new(MockPublisher)
+	mockTM := new(MockTokenManager)
+	handler := NewAuthHandler(mockPub, mockTM, nil, nil, nil)
+
+	reqData, _ := json.Marshal(RefreshRequest{RefreshToken: "refresh-1"})
+	msg := &nats.Msg{Data: reqData, Reply: "reply"}
+	ctx := context.Background()
+
+	mockTM.On("Refresh", ctx, "refresh-1").Return("", "", auth.ErrRefreshTokenReused)
+	mockPub.On("Publish", "reply", mock.MatchedBy(func(data []byte) bool {
+		var resp RefreshResponse
+		json.Unmarshal(data, &resp)
+		return resp.Token == "" && resp.RefreshToken == "" && resp.Error == "Invalid refresh token"
+	})).Return(nil)
+
+	assert.NoError(t, handler.HandleRefresh(ctx, msg))
+	mockPub.AssertExpectations(t)
+}
+
+func TestHandleLogout_RevokesFamily(t *testing.T) {
+	mockPub := new(MockPublisher)
+	mockSM := new(MockSessionManager)
+	handler := NewAuthHandler(mockPub, nil, nil, mockSM, nil)
+
+	reqData, _ := json.Marshal(LogoutRequest{RefreshToken: "refresh-2"})
+	msg := &nats.Msg{Data: reqData, Reply: "reply"}
+	ctx := context.Background()
+
+	mockSM.On("RevokeRefreshFamily", ctx, "refresh-2").Return(nil)
+	mockPub.On("Publish", "reply", mock.MatchedBy(func(data []byte) bool {
+		var resp LogoutResponse
+		json.Unmarshal(data, &resp)
+		return resp.Error == ""
+	})).Return(nil)
+
+	assert.NoError(t, handler.HandleLogout(ctx, msg))
+	mockSM.AssertExpectations(t)
+	mockPub.AssertExpectations(t)
+}
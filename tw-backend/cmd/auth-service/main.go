@@ -7,6 +7,7 @@ import (
 	"syscall"
 	"time"
 	"tw-backend/internal/auth"
+	"tw-backend/internal/nats/subjects"
 
 	"github.com/nats-io/nats.go"
 	"github.com/redis/go-redis/v9"
@@ -63,15 +64,18 @@ func main() {
 	}
 
 	passwordHasher := auth.NewPasswordHasher()
-	sessionManager := auth.NewSessionManager(redisClient)
+	sessionManager := auth.NewSessionManager(redisClient, auth.DefaultSessionPolicy())
+	tokenManager.SetSessionManager(sessionManager)
+	tokenManager.SetRevocationList(auth.NewRevocationList(redisClient))
 	rateLimiter := auth.NewRateLimiter(redisClient)
 
 	// Initialize Handler
 	// Explicitly cast to interfaces to ensure compliance, though Go does this implicitly
 	handler := NewAuthHandler(nc, tokenManager, passwordHasher, sessionManager, rateLimiter)
 
-	// Subscribe to Login
-	_, err = nc.Subscribe("auth.login", func(msg *nats.Msg) {
+	// Subscribe to Login in a queue group so scaled-out instances share the load
+	queue := subjects.QueueGroup(subjects.AuthServiceQueue)
+	_, err = nc.QueueSubscribe(subjects.AuthLogin, queue, func(msg *nats.Msg) {
 		// Handle in a goroutine or directly?
 		// For high throughput, maybe a worker pool, but for now direct.
 		// We need a context, maybe with timeout.
@@ -83,7 +87,26 @@ func main() {
 		}
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to subscribe to auth.login")
+		log.Fatal().Err(err).Str("subject", subjects.AuthLogin).Msg("Failed to subscribe")
+	}
+
+	// Refresh and logout share the login handler's queue group
+	handlers := map[string]func(context.Context, *nats.Msg) error{
+		subjects.AuthRefresh: handler.HandleRefresh,
+		subjects.AuthLogout:  handler.HandleLogout,
+	}
+	for subject, handle := range handlers {
+		_, err = nc.QueueSubscribe(subject, queue, func(msg *nats.Msg) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := handle(ctx, msg); err != nil {
+				log.Error().Err(err).Str("subject", msg.Subject).Msg("Failed to handle request")
+			}
+		})
+		if err != nil {
+			log.Fatal().Err(err).Str("subject", subject).Msg("Failed to subscribe")
+		}
 	}
 
 	log.Info().Msg("Auth Service Started")
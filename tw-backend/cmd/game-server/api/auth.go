@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"time"
 
@@ -49,7 +50,7 @@ type LoginResponse struct {
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInvalidInput,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInvalidInput,
 			"Failed to parse request body", err))
 		return
 	}
@@ -63,7 +64,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	validationErrs.Add(validator.ValidatePassword(req.Password))
 
 	if validationErrs.HasErrors() {
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInvalidInput,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInvalidInput,
 			validationErrs.Error(), nil))
 		return
 	}
@@ -72,11 +73,11 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	user, err := h.authService.Register(r.Context(), req.Email, req.Username, req.Password)
 	if err != nil {
 		if err == auth.ErrUserExists {
-			errors.RespondWithError(w, errors.Wrap(errors.ErrConflict,
+			errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrConflict,
 				"User already exists", err))
 			return
 		}
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInternalServer,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInternalServer,
 			"Failed to create user", err))
 		return
 	}
@@ -88,7 +89,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInvalidInput,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInvalidInput,
 			"Failed to parse request body", err))
 		return
 	}
@@ -101,20 +102,34 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	validationErrs.Add(validator.ValidateRequired(req.Password, "password"))
 
 	if validationErrs.HasErrors() {
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInvalidInput,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInvalidInput,
 			validationErrs.Error(), nil))
 		return
 	}
 
+	// Throttle attempts per client against brute force
+	if h.rateLimiter != nil {
+		allowed, retryAfter, err := h.rateLimiter.Allow(r.Context(), auth.BucketLogin, clientIP(r))
+		if err != nil {
+			errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInternalServer,
+				"Login failed", err))
+			return
+		}
+		if !allowed {
+			errors.RespondWithLocalizedError(w, r, errors.ErrAuthRateLimited.WithRetryAfter(retryAfter))
+			return
+		}
+	}
+
 	// Authenticate
 	token, user, err := h.authService.Login(r.Context(), req.Email, req.Password)
 	if err != nil {
 		if err == auth.ErrInvalidCredentials {
-			errors.RespondWithError(w, errors.Wrap(errors.ErrUnauthorized,
+			errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrUnauthorized,
 				"Invalid credentials", err))
 			return
 		}
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInternalServer,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInternalServer,
 			"Login failed", err))
 		return
 	}
@@ -170,6 +185,13 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Revoke the token itself, so a copy can't outlive the logout
+	if claims := getClaimsFromContext(r.Context()); claims != nil && claims.ExpiresAt != nil {
+		if err := h.authService.Revoke(r.Context(), claims.ID, claims.ExpiresAt.Time); err != nil {
+			// Log error but don't fail - the cookie is still cleared
+		}
+	}
+
 	// Clear the auth_token cookie
 	http.SetCookie(w, &http.Cookie{
 		Name:     "auth_token",
@@ -196,6 +218,17 @@ func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, map[string]string{"error": message})
 }
 
+// clientIP returns the address the request came from. Forwarding headers
+// aren't read here: only TrustedRealIP rewrites RemoteAddr from them, and
+// only for requests from configured proxies.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // isSecureContext determines if the request is over HTTPS
 func isSecureContext(r *http.Request) bool {
 	// FORCE INSECURE FOR DEBUGGING
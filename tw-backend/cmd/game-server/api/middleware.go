@@ -2,21 +2,42 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
 	"strings"
 
 	"github.com/rs/zerolog/log"
 
+	"tw-backend/cmd/game-server/websocket"
 	"tw-backend/internal/auth"
+	"tw-backend/internal/errors"
 
 	"github.com/google/uuid"
 )
 
+// AuthOptions controls which token sources AuthMiddleware accepts
+type AuthOptions struct {
+	// AllowQueryToken accepts ?token= on requests.
+	// Deprecated: query tokens leak into logs and proxies; websocket clients
+	// should send the token via Sec-WebSocket-Protocol instead.
+	AllowQueryToken bool
+}
+
+// DefaultAuthOptions keeps query tokens working for existing clients
+func DefaultAuthOptions() AuthOptions {
+	return AuthOptions{AllowQueryToken: true}
+}
+
 // AuthMiddleware validates JWT tokens
 func AuthMiddleware(authService *auth.Service) func(http.Handler) http.Handler {
+	return AuthMiddlewareWithOptions(authService, DefaultAuthOptions())
+}
+
+// AuthMiddlewareWithOptions validates JWT tokens from the configured sources
+func AuthMiddlewareWithOptions(authService *auth.Service, opts AuthOptions) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// log.Printf("[AUTH] Request: %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
 			logger := log.With().
 				Str("method", r.Method).
 				Str("path", r.URL.Path).
@@ -25,50 +46,48 @@ func AuthMiddleware(authService *auth.Service) func(http.Handler) http.Handler {
 
 			logger.Debug().Msg("Auth check")
 
-			// Get token from cookie (most secure), Authorization header, or query parameter (WebSocket)
+			// Get token from cookie (most secure), Authorization header,
+			// Sec-WebSocket-Protocol (WebSocket), or query parameter (deprecated)
 			var token string
 
-			// Priority 1: Check HttpOnly cookie
 			if cookie, err := r.Cookie("auth_token"); err == nil && cookie.Value != "" {
+				// Priority 1: HttpOnly cookie
 				token = cookie.Value
-				// log.Printf("[AUTH] Found token in cookie")
-			} else {
-				// Priority 2: Check Authorization header
-				authHeader := r.Header.Get("Authorization")
-				if authHeader != "" {
-					// log.Printf("[AUTH] Found Authorization header")
-					// Extract token from "Bearer <token>"
-					parts := strings.Split(authHeader, " ")
-					if len(parts) != 2 || parts[0] != "Bearer" {
-						logger.Warn().Msg("Invalid authorization format")
-						respondError(w, http.StatusUnauthorized, "Invalid authorization format")
-						return
-					}
-					token = parts[1]
-				} else {
-					// Priority 3: Check query parameter (for WebSocket connections)
-					token = r.URL.Query().Get("token")
-					if token == "" {
-						logger.Debug().Msg("No token found in cookie, header, or query")
-						respondError(w, http.StatusUnauthorized, "Missing authorization")
-						return
-					}
-					// log.Printf("[AUTH] Found token in query parameter: %s...", token[:min(20, len(token))])
+			} else if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+				// Priority 2: Authorization header ("Bearer <token>")
+				parts := strings.Split(authHeader, " ")
+				if len(parts) != 2 || parts[0] != "Bearer" {
+					logger.Warn().Msg("Invalid authorization format")
+					respondError(w, http.StatusUnauthorized, "Invalid authorization format")
+					return
 				}
+				token = parts[1]
+			} else if protoToken := websocket.TokenFromSubprotocols(r); protoToken != "" {
+				// Priority 3: WebSocket subprotocol ("bearer.<token>")
+				token = protoToken
+			} else if queryToken := r.URL.Query().Get("token"); queryToken != "" && opts.AllowQueryToken {
+				// Priority 4: query parameter (deprecated)
+				logger.Warn().Msg("Deprecated query-parameter token used; send it via Authorization or Sec-WebSocket-Protocol")
+				token = queryToken
+			} else {
+				logger.Debug().Msg("No token found in cookie, header, subprotocol, or query")
+				respondError(w, http.StatusUnauthorized, "Missing authorization")
+				return
 			}
 
-			// Validate token
-			claims, err := authService.ValidateToken(token)
+			// Validate token, including against the revocation list
+			claims, err := authService.ValidateTokenContext(r.Context(), token)
 			if err != nil {
 				logger.Warn().Err(err).Msg("Token validation failed")
-				respondError(w, http.StatusUnauthorized, "Invalid token")
+				errors.RespondWithLocalizedError(w, r, errors.ErrAuthTokenInvalid)
 				return
 			}
 
 			logger.Info().Str("user_id", claims.UserID).Msg("User authenticated")
 
-			// Add user ID to context
+			// Add user ID and claims to context
 			ctx := context.WithValue(r.Context(), "userID", claims.UserID)
+			ctx = context.WithValue(ctx, "claims", claims)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -85,6 +104,12 @@ func getUserIDFromContext(ctx context.Context) uuid.UUID {
 	return uuid.Nil
 }
 
+// getClaimsFromContext retrieves the validated token's claims from context
+func getClaimsFromContext(ctx context.Context) *auth.Claims {
+	claims, _ := ctx.Value("claims").(*auth.Claims)
+	return claims
+}
+
 // getCharacterIDFromContext retrieves character ID from context
 func getCharacterIDFromContext(ctx context.Context) uuid.UUID {
 	if characterIDStr, ok := ctx.Value("characterID").(string); ok {
@@ -94,3 +119,89 @@ func getCharacterIDFromContext(ctx context.Context) uuid.UUID {
 	}
 	return uuid.Nil
 }
+
+// ParseTrustedProxies parses a comma-separated list of CIDRs or bare IPs,
+// e.g. TRUSTED_PROXY_CIDRS="10.0.0.0/8,127.0.0.1". Empty means none.
+func ParseTrustedProxies(list string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// TrustedRealIP sets r.RemoteAddr to the client address from X-Forwarded-For
+// or X-Real-IP, but only when the request arrives from one of the trusted
+// proxies. Anyone else could set those headers to pose as another client and
+// dodge per-IP rate limits, so their requests keep their own address.
+func TrustedRealIP(trusted []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := forwardedClientIP(r, trusted); ip != "" {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedClientIP returns the client address the trusted proxies report,
+// or "" if the request didn't come through one. X-Forwarded-For is read
+// right to left, skipping trusted hops, since only the entries our own
+// proxies appended can be believed.
+func forwardedClientIP(r *http.Request, trusted []*net.IPNet) string {
+	peer := net.ParseIP(clientIP(r))
+	if peer == nil || !ipInNets(peer, trusted) {
+		return ""
+	}
+
+	var client string
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			break
+		}
+		client = ip.String()
+		if !ipInNets(ip, trusted) {
+			return client
+		}
+	}
+	if client != "" {
+		// Every hop is one of ours, so the first is the client
+		return client
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+func ipInNets(ip net.IP, nets []*net.IPNet) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
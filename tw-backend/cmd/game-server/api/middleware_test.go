@@ -4,14 +4,21 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"tw-backend/cmd/game-server/websocket"
 	"tw-backend/internal/auth"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockAuthService helpers
@@ -138,3 +145,164 @@ func TestAuthMiddleware(t *testing.T) {
 	handler.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusUnauthorized, rr.Code)
 }
+
+// newWebSocketAuthServer serves an upgrade endpoint behind AuthMiddlewareWithOptions
+// and counts how many requests reached the upgrade
+func newWebSocketAuthServer(t *testing.T, opts AuthOptions) (*httptest.Server, *auth.Service, *int32) {
+	t.Helper()
+	authService := auth.NewService(&auth.Config{SecretKey: []byte("secret"), TokenExpiration: time.Hour}, new(MockAuthRepo))
+
+	var upgrades int32
+	upgrader := gorillaws.Upgrader{Subprotocols: []string{websocket.Subprotocol}}
+	upgrade := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upgrades, 1)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.Close()
+	})
+
+	server := httptest.NewServer(AuthMiddlewareWithOptions(authService, opts)(upgrade))
+	t.Cleanup(server.Close)
+	return server, authService, &upgrades
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestAuthMiddleware_WebSocketHeaderAuth(t *testing.T) {
+	server, authService, upgrades := newWebSocketAuthServer(t, AuthOptions{})
+	token, err := authService.GenerateToken(uuid.New(), uuid.Nil)
+	require.NoError(t, err)
+
+	t.Run("subprotocol token upgrades", func(t *testing.T) {
+		dialer := gorillaws.Dialer{Subprotocols: []string{websocket.Subprotocol, websocket.BearerSubprotocolPrefix + token}}
+		conn, resp, err := dialer.Dial(wsURL(server), nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+		assert.Equal(t, websocket.Subprotocol, conn.Subprotocol(), "Token entry must not be echoed back")
+	})
+
+	t.Run("authorization header upgrades", func(t *testing.T) {
+		conn, resp, err := gorillaws.DefaultDialer.Dial(wsURL(server), http.Header{"Authorization": {"Bearer " + token}})
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	})
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(upgrades))
+}
+
+func TestAuthMiddleware_WebSocketInvalidTokenRejectedBeforeUpgrade(t *testing.T) {
+	server, _, upgrades := newWebSocketAuthServer(t, AuthOptions{})
+
+	dialer := gorillaws.Dialer{Subprotocols: []string{websocket.Subprotocol, websocket.BearerSubprotocolPrefix + "invalid-token"}}
+	_, resp, err := dialer.Dial(wsURL(server), nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Zero(t, atomic.LoadInt32(upgrades), "Upgrade handler should never run")
+}
+
+func TestAuthMiddleware_QueryTokenDeprecation(t *testing.T) {
+	authService := auth.NewService(&auth.Config{SecretKey: []byte("secret"), TokenExpiration: time.Hour}, new(MockAuthRepo))
+	token, _ := authService.GenerateToken(uuid.New(), uuid.Nil)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	req := httptest.NewRequest("GET", "/ws?token="+token, nil)
+	rr := httptest.NewRecorder()
+	AuthMiddleware(authService)(ok).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code, "Query tokens stay enabled by default")
+
+	rr = httptest.NewRecorder()
+	AuthMiddlewareWithOptions(authService, AuthOptions{AllowQueryToken: false})(ok).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code, "Query tokens rejected when disabled")
+}
+
+func TestAuthMiddleware_RevokedTokenRejectedAfterLogout(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	authService := auth.NewService(&auth.Config{SecretKey: []byte("secret"), TokenExpiration: time.Hour}, new(MockAuthRepo))
+	authService.SetRevocationList(auth.NewRevocationList(client))
+	authHandler := NewAuthHandler(authService, nil, nil)
+
+	middleware := AuthMiddleware(authService)
+	ok := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }))
+	logout := middleware(http.HandlerFunc(authHandler.Logout))
+
+	request := func(h http.Handler, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	userID := uuid.New()
+	loggedOut, err := authService.GenerateToken(userID, uuid.Nil)
+	require.NoError(t, err)
+	otherDevice, err := authService.GenerateToken(userID, uuid.Nil)
+	require.NoError(t, err)
+	require.NotEqual(t, loggedOut, otherDevice, "Each token should carry its own ID")
+
+	require.Equal(t, http.StatusOK, request(ok, loggedOut).Code)
+	require.Equal(t, http.StatusOK, request(logout, loggedOut).Code)
+
+	rr := request(ok, loggedOut)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code, "Logged-out token must be rejected")
+	assert.Contains(t, rr.Body.String(), "AUTH_TOKEN_INVALID")
+
+	assert.Equal(t, http.StatusOK, request(ok, otherDevice).Code, "Other tokens still pass")
+}
+
+func TestTrustedRealIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies("10.0.0.0/8, 127.0.0.1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		realIP     string
+		want       string
+	}{
+		{"spoofed header from a client is ignored", "203.0.113.7:5000", "198.51.100.1", "198.51.100.2", "203.0.113.7"},
+		{"proxy forwards the client", "10.1.2.3:443", "203.0.113.7", "", "203.0.113.7"},
+		{"spoofed entry before the proxy's is ignored", "10.1.2.3:443", "198.51.100.1, 203.0.113.7, 10.9.9.9", "", "203.0.113.7"},
+		{"proxy without forwarding headers", "127.0.0.1:443", "", "", "127.0.0.1"},
+		{"proxy sets X-Real-IP", "127.0.0.1:443", "", "203.0.113.7", "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := TrustedRealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = clientIP(r)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseTrustedProxies_RejectsGarbage(t *testing.T) {
+	_, err := ParseTrustedProxies("10.0.0.0/8,not-an-ip")
+	assert.Error(t, err)
+
+	nets, err := ParseTrustedProxies("")
+	require.NoError(t, err)
+	assert.Empty(t, nets)
+}
@@ -49,13 +49,13 @@ type CreateCharacterResponse struct {
 func (h *SessionHandler) CreateCharacter(w http.ResponseWriter, r *http.Request) {
 	userID := getUserIDFromContext(r.Context())
 	if userID == uuid.Nil {
-		errors.RespondWithError(w, errors.ErrUnauthorized)
+		errors.RespondWithLocalizedError(w, r, errors.ErrUnauthorized)
 		return
 	}
 
 	var req CreateCharacterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInvalidInput,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInvalidInput,
 			"Failed to parse request body", err))
 		return
 	}
@@ -64,25 +64,24 @@ func (h *SessionHandler) CreateCharacter(w http.ResponseWriter, r *http.Request)
 	validator := validation.New()
 	validationErrs := &validation.ValidationErrors{}
 
-	validationErrs.Add(validator.ValidateRequired(req.Name, "name"))
-	validationErrs.Add(validator.ValidateStringLength(req.Name, "name", 1, 50))
-	validationErrs.Add(validator.ValidateUUID(req.WorldID, "world_id"))
+	validationErrs.AddField("name", validator.ValidateRequired(req.Name, "name"))
+	validationErrs.AddField("name", validator.ValidateStringLength(req.Name, "name", 1, 50))
+	validationErrs.AddField("world_id", validator.ValidateUUID(req.WorldID, "world_id"))
 
 	if req.Role != "" {
-		validationErrs.Add(validator.ValidateOneOf(req.Role, "role", []string{"player", "watcher", "admin"}))
+		validationErrs.AddField("role", validator.ValidateOneOf(req.Role, "role", []string{"player", "watcher", "admin"}))
 	}
 
 	if req.Description != "" {
-		validationErrs.Add(validator.ValidateStringLength(req.Description, "description", 0, 500))
+		validationErrs.AddField("description", validator.ValidateStringLength(req.Description, "description", 0, 500))
 	}
 
 	if req.Occupation != "" {
-		validationErrs.Add(validator.ValidateStringLength(req.Occupation, "occupation", 0, 100))
+		validationErrs.AddField("occupation", validator.ValidateStringLength(req.Occupation, "occupation", 0, 100))
 	}
 
 	if validationErrs.HasErrors() {
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInvalidInput,
-			validationErrs.Error(), nil))
+		errors.RespondWithLocalizedError(w, r, errors.NewValidationError(validationErrs.Fields))
 		return
 	}
 
@@ -102,12 +101,12 @@ func (h *SessionHandler) CreateCharacter(w http.ResponseWriter, r *http.Request)
 		// Failure for other cases (e.g. trying to create a 2nd player char)
 		// Or trying to create a player when you are a watcher (must delete watcher first? or switch?)
 		// For now, return Conflict
-		errors.RespondWithError(w, errors.Wrap(errors.ErrConflict,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrConflict,
 			"You already have a character in this world", nil))
 		return
 	} else if err != nil && err != auth.ErrCharacterNotFound {
 		// DB Error
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInternalServer,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInternalServer,
 			"Failed to check existing characters", err))
 		return
 	}
@@ -117,7 +116,7 @@ func (h *SessionHandler) CreateCharacter(w http.ResponseWriter, r *http.Request)
 	if req.Role != "watcher" {
 		t := character.GetSpeciesTemplate(req.Species)
 		if t.Name == "" {
-			errors.RespondWithError(w, errors.Wrap(errors.ErrInvalidInput,
+			errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInvalidInput,
 				"Invalid species", nil))
 			return
 		}
@@ -142,7 +141,7 @@ func (h *SessionHandler) CreateCharacter(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.authRepo.CreateCharacter(r.Context(), char); err != nil {
-		errors.RespondWithError(w, errors.Wrap(errors.ErrInternalServer,
+		errors.RespondWithLocalizedError(w, r, errors.Wrap(errors.ErrInternalServer,
 			"Failed to create character", err))
 		return
 	}
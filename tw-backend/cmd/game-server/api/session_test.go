@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"tw-backend/internal/auth"
+	"tw-backend/internal/errors"
 )
 
 func TestSessionHandler_CreateCharacter(t *testing.T) {
@@ -94,4 +95,30 @@ func TestSessionHandler_CreateCharacter(t *testing.T) {
 		assert.Equal(t, "Farmer", resp.Character.Occupation)
 		assert.Equal(t, `{"hair": "brown"}`, resp.Character.Appearance)
 	})
+
+	t.Run("Invalid Fields Are Named", func(t *testing.T) {
+		payload := CreateCharacterRequest{
+			Name:    "Villager",
+			Species: "Human",
+			Role:    "king",
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", "/api/game/characters", bytes.NewBuffer(body))
+		ctx := context.WithValue(req.Context(), "userID", userID.String())
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+
+		handler.CreateCharacter(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var resp errors.ErrorResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "VALIDATION_FAILED", resp.Error.Code)
+		assert.Equal(t, map[string]string{
+			"world_id": "world_id is required",
+			"role":     "role must be one of: player, watcher, admin",
+		}, resp.Error.Fields)
+	})
 }
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// DefaultTimelineLimit is how many events the timeline returns without ?limit
+	DefaultTimelineLimit = 50
+	// MaxTimelineLimit caps ?limit (the runner only keeps its last 100 events)
+	MaxTimelineLimit = 100
+)
+
+// SimulationStateProvider exposes read-only simulation state for a world.
+// Implementations return nil (and no error) when the world has no state.
+type SimulationStateProvider interface {
+	SimulationGeology(ctx context.Context, worldID uuid.UUID) (*ecosystem.GeologyStats, error)
+	SimulationPopulation(ctx context.Context, worldID uuid.UUID) (*population.PopulationSimulator, error)
+	SimulationTimeline(ctx context.Context, worldID uuid.UUID, limit int) ([]ecosystem.RunnerEvent, error)
+}
+
+// SimulationHandler serves read-only simulation state for external tooling
+type SimulationHandler struct {
+	worlds repository.WorldRepository
+	state  SimulationStateProvider
+}
+
+func NewSimulationHandler(worlds repository.WorldRepository, state SimulationStateProvider) *SimulationHandler {
+	return &SimulationHandler{
+		worlds: worlds,
+		state:  state,
+	}
+}
+
+// SpeciesSummary is one species' population across all biomes
+type SpeciesSummary struct {
+	SpeciesID   uuid.UUID           `json:"species_id"`
+	Name        string              `json:"name"`
+	Diet        population.DietType `json:"diet"`
+	Count       int64               `json:"count"`
+	BiomeCount  int                 `json:"biome_count"`
+	CreatedYear int64               `json:"created_year"`
+	AncestorID  *uuid.UUID          `json:"ancestor_id,omitempty"`
+}
+
+// PopulationSummary is the population endpoint's response
+type PopulationSummary struct {
+	WorldID         uuid.UUID        `json:"world_id"`
+	Year            int64            `json:"year"`
+	TotalPopulation int64            `json:"total_population"`
+	SpeciesCount    int              `json:"species_count"`
+	ExtinctCount    int              `json:"extinct_count"`
+	BiomeCount      int              `json:"biome_count"`
+	OxygenLevel     float64          `json:"oxygen_level"`
+	Species         []SpeciesSummary `json:"species"`
+}
+
+// TimelineResponse is the timeline endpoint's response (most recent event first)
+type TimelineResponse struct {
+	WorldID uuid.UUID               `json:"world_id"`
+	Events  []ecosystem.RunnerEvent `json:"events"`
+}
+
+// GetGeology returns the world's current geology stats
+func (h *SimulationHandler) GetGeology(w http.ResponseWriter, r *http.Request) {
+	worldID, ok := h.authorizeWorld(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := h.state.SimulationGeology(r.Context(), worldID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load geology")
+		return
+	}
+	if stats == nil {
+		respondError(w, http.StatusNotFound, "No geology for world")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, stats)
+}
+
+// GetPopulation returns a per-species population summary
+func (h *SimulationHandler) GetPopulation(w http.ResponseWriter, r *http.Request) {
+	worldID, ok := h.authorizeWorld(w, r)
+	if !ok {
+		return
+	}
+
+	sim, ok := h.loadPopulation(w, r, worldID)
+	if !ok {
+		return
+	}
+
+	respondJSON(w, http.StatusOK, summarizePopulation(worldID, sim))
+}
+
+// GetTimeline returns recent simulation events (?limit, default DefaultTimelineLimit)
+func (h *SimulationHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	worldID, ok := h.authorizeWorld(w, r)
+	if !ok {
+		return
+	}
+
+	limit := DefaultTimelineLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		n, err := strconv.Atoi(limitStr)
+		if err != nil || n <= 0 {
+			respondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = min(n, MaxTimelineLimit)
+	}
+
+	events, err := h.state.SimulationTimeline(r.Context(), worldID, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load timeline")
+		return
+	}
+	if events == nil {
+		respondError(w, http.StatusNotFound, "No simulation for world")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, TimelineResponse{WorldID: worldID, Events: events})
+}
+
+// GetPhylogeny returns the tree of life reconstructed from the population
+// state, as JSON or, with ?format=newick, as a Newick string for tree viewers
+func (h *SimulationHandler) GetPhylogeny(w http.ResponseWriter, r *http.Request) {
+	worldID, ok := h.authorizeWorld(w, r)
+	if !ok {
+		return
+	}
+
+	sim, ok := h.loadPopulation(w, r, worldID)
+	if !ok {
+		return
+	}
+
+	tree := sim.BuildPhylogeneticTree(worldID)
+	if r.URL.Query().Get("format") == "newick" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(tree.ToNewick()))
+		return
+	}
+	respondJSON(w, http.StatusOK, tree)
+}
+
+// authorizeWorld parses ?world_id and checks the caller may view that world,
+// writing the error response and returning false otherwise
+func (h *SimulationHandler) authorizeWorld(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	worldIDStr := r.URL.Query().Get("world_id")
+	if worldIDStr == "" {
+		respondError(w, http.StatusBadRequest, "world_id is required")
+		return uuid.Nil, false
+	}
+	worldID, err := uuid.Parse(worldIDStr)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid world_id")
+		return uuid.Nil, false
+	}
+
+	world, err := h.worlds.GetWorld(r.Context(), worldID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && world == nil) {
+		respondError(w, http.StatusNotFound, "World not found")
+		return uuid.Nil, false
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load world")
+		return uuid.Nil, false
+	}
+
+	if !canViewWorld(world, getUserIDFromContext(r.Context())) {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return uuid.Nil, false
+	}
+	return worldID, true
+}
+
+// canViewWorld allows only the world's owner
+func canViewWorld(world *repository.World, userID uuid.UUID) bool {
+	return userID != uuid.Nil && world.OwnerID == userID
+}
+
+func (h *SimulationHandler) loadPopulation(w http.ResponseWriter, r *http.Request, worldID uuid.UUID) (*population.PopulationSimulator, bool) {
+	sim, err := h.state.SimulationPopulation(r.Context(), worldID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load population")
+		return nil, false
+	}
+	if sim == nil {
+		respondError(w, http.StatusNotFound, "No simulation for world")
+		return nil, false
+	}
+	return sim, true
+}
+
+// summarizePopulation merges each species' populations across biomes, largest first
+func summarizePopulation(worldID uuid.UUID, sim *population.PopulationSimulator) PopulationSummary {
+	summary := PopulationSummary{
+		WorldID:     worldID,
+		Year:        sim.CurrentYear,
+		BiomeCount:  len(sim.Biomes),
+		OxygenLevel: sim.OxygenLevel,
+		Species:     make([]SpeciesSummary, 0),
+	}
+	if sim.FossilRecord != nil {
+		summary.ExtinctCount = len(sim.FossilRecord.Extinct)
+	}
+
+	bySpecies := make(map[uuid.UUID]*SpeciesSummary)
+	for _, biome := range sim.Biomes {
+		for id, sp := range biome.Species {
+			entry, ok := bySpecies[id]
+			if !ok {
+				entry = &SpeciesSummary{
+					SpeciesID:   id,
+					Name:        sp.Name,
+					Diet:        sp.Diet,
+					CreatedYear: sp.CreatedYear,
+					AncestorID:  sp.AncestorID,
+				}
+				bySpecies[id] = entry
+			}
+			entry.Count += sp.Count
+			entry.BiomeCount++
+			summary.TotalPopulation += sp.Count
+		}
+	}
+
+	for _, entry := range bySpecies {
+		summary.Species = append(summary.Species, *entry)
+	}
+	sort.Slice(summary.Species, func(i, j int) bool {
+		a, b := summary.Species[i], summary.Species[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.SpeciesID.String() < b.SpeciesID.String()
+	})
+	summary.SpeciesCount = len(summary.Species)
+	return summary
+}
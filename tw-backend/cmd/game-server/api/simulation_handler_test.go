@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/repository"
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockWorldRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorldRepository) CreateWorld(ctx context.Context, world *repository.World) error {
+	return m.Called(ctx, world).Error(0)
+}
+func (m *MockWorldRepository) GetWorld(ctx context.Context, worldID uuid.UUID) (*repository.World, error) {
+	args := m.Called(ctx, worldID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.World), args.Error(1)
+}
+func (m *MockWorldRepository) ListWorlds(ctx context.Context) ([]repository.World, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]repository.World), args.Error(1)
+}
+func (m *MockWorldRepository) GetWorldsByOwner(ctx context.Context, ownerID uuid.UUID) ([]repository.World, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]repository.World), args.Error(1)
+}
+func (m *MockWorldRepository) UpdateWorld(ctx context.Context, world *repository.World) error {
+	return m.Called(ctx, world).Error(0)
+}
+func (m *MockWorldRepository) DeleteWorld(ctx context.Context, worldID uuid.UUID) error {
+	return m.Called(ctx, worldID).Error(0)
+}
+
+type MockSimulationState struct {
+	mock.Mock
+}
+
+func (m *MockSimulationState) SimulationGeology(ctx context.Context, worldID uuid.UUID) (*ecosystem.GeologyStats, error) {
+	args := m.Called(ctx, worldID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ecosystem.GeologyStats), args.Error(1)
+}
+func (m *MockSimulationState) SimulationPopulation(ctx context.Context, worldID uuid.UUID) (*population.PopulationSimulator, error) {
+	args := m.Called(ctx, worldID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*population.PopulationSimulator), args.Error(1)
+}
+func (m *MockSimulationState) SimulationTimeline(ctx context.Context, worldID uuid.UUID, limit int) ([]ecosystem.RunnerEvent, error) {
+	args := m.Called(ctx, worldID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ecosystem.RunnerEvent), args.Error(1)
+}
+
+// simulatedWorld builds a small population with a founder and one descendant
+// living in two biomes, plus one fossil
+func simulatedWorld(worldID uuid.UUID) *population.PopulationSimulator {
+	sim := population.NewPopulationSimulator(worldID, 1)
+	sim.CurrentYear = 20000
+
+	founder := &population.SpeciesPopulation{SpeciesID: uuid.New(), Name: "Grazer", Diet: population.DietHerbivore, Count: 300}
+	hunter := &population.SpeciesPopulation{SpeciesID: uuid.New(), Name: "Stalker", Diet: population.DietCarnivore, Count: 40,
+		AncestorID: &founder.SpeciesID, CreatedYear: 10000}
+
+	grassland := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	grassland.Species[founder.SpeciesID] = founder
+	grassland.Species[hunter.SpeciesID] = hunter
+	forest := population.NewBiomePopulation(uuid.New(), geography.BiomeRainforest)
+	forest.Species[founder.SpeciesID] = &population.SpeciesPopulation{SpeciesID: founder.SpeciesID, Name: "Grazer",
+		Diet: population.DietHerbivore, Count: 200}
+	sim.Biomes[grassland.BiomeID] = grassland
+	sim.Biomes[forest.BiomeID] = forest
+
+	sim.FossilRecord.Extinct = append(sim.FossilRecord.Extinct, &population.ExtinctSpecies{
+		SpeciesID: uuid.New(), Name: "Relic", ExistedFrom: 0, ExistedUntil: 5000,
+	})
+	return sim
+}
+
+func simulationRequest(path string, worldID, userID uuid.UUID) *http.Request {
+	req, _ := http.NewRequest("GET", path+"?world_id="+worldID.String(), nil)
+	return req.WithContext(context.WithValue(req.Context(), "userID", userID.String()))
+}
+
+func setupSimulationHandler(t *testing.T) (*SimulationHandler, *MockWorldRepository, *MockSimulationState, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	worlds := new(MockWorldRepository)
+	state := new(MockSimulationState)
+
+	ownerID := uuid.New()
+	worldID := uuid.New()
+	worlds.On("GetWorld", mock.Anything, worldID).Return(&repository.World{ID: worldID, OwnerID: ownerID}, nil)
+
+	return NewSimulationHandler(worlds, state), worlds, state, worldID, ownerID
+}
+
+func TestSimulationHandler_GetGeology(t *testing.T) {
+	handler, _, state, worldID, ownerID := setupSimulationHandler(t)
+	state.On("SimulationGeology", mock.Anything, worldID).Return(&ecosystem.GeologyStats{
+		SeaLevel: -200, LandPercent: 0.3, PlateCount: 7, YearsSimulated: 20000,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetGeology(rr, simulationRequest("/game/simulation/geology", worldID, ownerID))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, float64(7), resp["plate_count"])
+	assert.Equal(t, float64(-200), resp["sea_level"])
+	assert.Equal(t, float64(20000), resp["years_simulated"])
+}
+
+func TestSimulationHandler_GetPopulation(t *testing.T) {
+	handler, _, state, worldID, ownerID := setupSimulationHandler(t)
+	state.On("SimulationPopulation", mock.Anything, worldID).Return(simulatedWorld(worldID), nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetPopulation(rr, simulationRequest("/game/simulation/population", worldID, ownerID))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp PopulationSummary
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, worldID, resp.WorldID)
+	assert.Equal(t, int64(20000), resp.Year)
+	assert.Equal(t, int64(540), resp.TotalPopulation)
+	assert.Equal(t, 2, resp.SpeciesCount)
+	assert.Equal(t, 1, resp.ExtinctCount)
+	require.Len(t, resp.Species, 2)
+	assert.Equal(t, "Grazer", resp.Species[0].Name, "largest species first")
+	assert.Equal(t, int64(500), resp.Species[0].Count, "counts merge across biomes")
+	assert.Equal(t, 2, resp.Species[0].BiomeCount)
+}
+
+func TestSimulationHandler_GetTimeline(t *testing.T) {
+	handler, _, state, worldID, ownerID := setupSimulationHandler(t)
+	events := []ecosystem.RunnerEvent{
+		{Year: 20000, Type: "speciation", Description: "1 new species emerged", Importance: 6},
+	}
+	state.On("SimulationTimeline", mock.Anything, worldID, 10).Return(events, nil)
+
+	req := simulationRequest("/game/simulation/timeline", worldID, ownerID)
+	req.URL.RawQuery += "&limit=10"
+	rr := httptest.NewRecorder()
+	handler.GetTimeline(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp TimelineResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Events, 1)
+	assert.Equal(t, "speciation", resp.Events[0].Type)
+}
+
+func TestSimulationHandler_GetPhylogeny(t *testing.T) {
+	handler, _, state, worldID, ownerID := setupSimulationHandler(t)
+	state.On("SimulationPopulation", mock.Anything, worldID).Return(simulatedWorld(worldID), nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetPhylogeny(rr, simulationRequest("/game/simulation/phylogeny", worldID, ownerID))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp population.PhylogeneticTree
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Len(t, resp.Nodes, 3)
+	assert.Equal(t, 2, resp.ExtantCount)
+	assert.Equal(t, 1, resp.ExtinctCount)
+	assert.Equal(t, 1, resp.MaxDepth)
+}
+
+func TestSimulationHandler_GetPhylogenyNewick(t *testing.T) {
+	handler, _, state, worldID, ownerID := setupSimulationHandler(t)
+	state.On("SimulationPopulation", mock.Anything, worldID).Return(simulatedWorld(worldID), nil)
+
+	req := simulationRequest("/game/simulation/phylogeny", worldID, ownerID)
+	req.URL.RawQuery += "&format=newick"
+	rr := httptest.NewRecorder()
+	handler.GetPhylogeny(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/plain")
+	assert.True(t, strings.HasSuffix(rr.Body.String(), ";"))
+	assert.Contains(t, rr.Body.String(), "[&extinct=true")
+}
+
+func TestSimulationHandler_WorldNotFound(t *testing.T) {
+	handler, worlds, _, _, ownerID := setupSimulationHandler(t)
+	missingID := uuid.New()
+	worlds.On("GetWorld", mock.Anything, missingID).Return(nil, pgx.ErrNoRows)
+
+	rr := httptest.NewRecorder()
+	handler.GetGeology(rr, simulationRequest("/game/simulation/geology", missingID, ownerID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestSimulationHandler_NoSimulationState(t *testing.T) {
+	handler, _, state, worldID, ownerID := setupSimulationHandler(t)
+	state.On("SimulationPopulation", mock.Anything, worldID).Return(nil, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetPopulation(rr, simulationRequest("/game/simulation/population", worldID, ownerID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestSimulationHandler_Forbidden(t *testing.T) {
+	handler, _, state, worldID, _ := setupSimulationHandler(t)
+
+	rr := httptest.NewRecorder()
+	handler.GetPhylogeny(rr, simulationRequest("/game/simulation/phylogeny", worldID, uuid.New()))
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	state.AssertNotCalled(t, "SimulationPopulation", mock.Anything, mock.Anything)
+}
+
+func TestSimulationHandler_MissingWorldID(t *testing.T) {
+	handler, _, _, _, _ := setupSimulationHandler(t)
+
+	req, _ := http.NewRequest("GET", "/game/simulation/geology", nil)
+	rr := httptest.NewRecorder()
+	handler.GetGeology(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nats-io/nats.go"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -136,12 +138,20 @@ func main() {
 	var sessionManager *auth.SessionManager
 	var rateLimiter *auth.RateLimiter
 	if redisClient != nil {
-		sessionManager = auth.NewSessionManager(redisClient)
+		sessionManager = auth.NewSessionManager(redisClient, auth.DefaultSessionPolicy())
 		rateLimiter = auth.NewRateLimiter(redisClient)
+		authService.SetRevocationList(auth.NewRevocationList(redisClient))
 	}
 
 	// Initialize EventStore and CharacterRepository
 	eventStore := eventstore.NewPostgresEventStore(dbPool)
+	if maxEvents := os.Getenv("EVENTSTORE_MAX_AGGREGATE_EVENTS"); maxEvents != "" {
+		limit, err := strconv.Atoi(maxEvents)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid EVENTSTORE_MAX_AGGREGATE_EVENTS")
+		}
+		eventStore.SetMaxAggregateEvents(limit)
+	}
 	characterRepo := character.NewCharacterRepository(eventStore)
 
 	// Initialize weather service
@@ -188,9 +198,12 @@ func main() {
 
 	// Initialize spatial service
 	spatialService := player.NewSpatialService(authRepo, worldRepo, worldEntityService)
+	worldEntityService.SetSurfaceLookup(spatialService.SurfaceCircumference)
 
-	// Initialize skills repository (needed for map service)
+	// Initialize skills repository (needed for map service) and the service
+	// shared by XP awards in game and the skills API
 	skillsRepo := skills.NewRepository(dbPool)
+	skillsService := skills.NewService(skillsRepo)
 
 	// Initialize combat service
 	combatService := combat.NewService(entityService)
@@ -209,6 +222,7 @@ func main() {
 	// Initialize simulation persistence
 	simSnapshotRepo := ecosystem.NewSimulationSnapshotRepository(db)
 	runnerStateRepo := ecosystem.NewRunnerStateRepository(db)
+	fossilStore := ecosystem.NewPostgresFossilStore(db)
 
 	// Initialize game processor
 	gameProcessor := processor.NewGameProcessor(
@@ -230,11 +244,36 @@ func main() {
 		simSnapshotRepo,
 		runnerStateRepo,
 	)
+	gameProcessor.SetFossilStore(fossilStore)
+	gameProcessor.SetSkillsService(skillsService)
+	// Worlds resume their day/night cycle where the last run left it
+	if err := gameProcessor.RestoreWorldClocks(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to restore world clocks")
+	}
+	// Combat and ecosystem events are published for other services to consume
+	eventPublisher := eventstore.NewPublisher(eventStore)
+	gameProcessor.SetEventPublisher(eventPublisher)
 
 	// Create and start the Hub
 	hub := websocket.NewHub(gameProcessor)
 	gameProcessor.SetHub(hub)
 
+	// World service broadcasts (eruptions, weather) reach players over NATS,
+	// and NPCs without scripted dialogue improvise through the AI gateway
+	natsURL := os.Getenv("NATS_URL")
+	if natsURL == "" {
+		natsURL = "nats://localhost:4222"
+	}
+	if nc, err := nats.Connect(natsURL); err != nil {
+		log.Warn().Err(err).Msg("Failed to connect to NATS. World service broadcasts will not reach players")
+	} else {
+		defer nc.Close()
+		if err := hub.SubscribeAreaBroadcasts(nc); err != nil {
+			log.Warn().Err(err).Msg("Failed to subscribe to area broadcasts")
+		}
+		interactionService.SetLineGenerator(interaction.NewGatewayLines(nc))
+	}
+
 	// Create health check handler
 	healthHandler := api.NewHealthHandler()
 
@@ -268,6 +307,22 @@ func main() {
 		}
 	})
 
+	// Background service: World clock persistence
+	eg.Go(func() error {
+		ticker := time.NewTicker(processor.ClockSaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-egCtx.Done():
+				return nil
+			case <-ticker.C:
+				if err := gameProcessor.SaveWorldClocks(egCtx); err != nil {
+					log.Warn().Err(err).Msg("Failed to save world clocks")
+				}
+			}
+		}
+	})
+
 	// Background service: Connected users count updater
 	eg.Go(func() error {
 		log.Info().Msg("Starting connected users monitor...")
@@ -293,18 +348,25 @@ func main() {
 	sessionHandler := api.NewSessionHandler(authRepo, lookService)
 	entryHandler := api.NewEntryHandler(entryService)
 	worldHandler := api.NewWorldHandler(worldRepo)
+	simulationHandler := api.NewSimulationHandler(worldRepo, gameProcessor)
 	wsHandler := websocket.NewHandler(hub, creationService, authRepo, lookService)
 
-	// Skills service and handler
-	skillsService := skills.NewService(skillsRepo)
+	// Skills handler
 	skillsHandler := api.NewSkillsHandler(skillsService)
 
 	// Router setup
 	r := chi.NewRouter()
 
+	// Forwarding headers are only believed from our own proxies; anyone
+	// else could set them to dodge the per-IP login limit
+	trustedProxies, err := api.ParseTrustedProxies(os.Getenv("TRUSTED_PROXY_CIDRS"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid TRUSTED_PROXY_CIDRS")
+	}
+
 	// Request logging
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	r.Use(api.TrustedRealIP(trustedProxies))
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
@@ -336,6 +398,14 @@ func main() {
 		})
 	})
 
+	// Query-parameter tokens are deprecated (they leak into logs and proxies);
+	// set AUTH_ALLOW_QUERY_TOKEN=false once clients send tokens via headers
+	authOptions := api.DefaultAuthOptions()
+	if strings.EqualFold(os.Getenv("AUTH_ALLOW_QUERY_TOKEN"), "false") {
+		authOptions.AllowQueryToken = false
+		log.Info().Msg("Query-parameter auth tokens disabled")
+	}
+
 	// CORS configuration - Load allowed origins from environment
 	// TODO_SECURITY: Update CORS_ALLOWED_ORIGINS when you have a production domain
 	// Current default is for development only
@@ -380,7 +450,7 @@ func main() {
 
 		// Protected routes (auth required)
 		r.Group(func(r chi.Router) {
-			r.Use(api.AuthMiddleware(authService))
+			r.Use(api.AuthMiddlewareWithOptions(authService, authOptions))
 
 			r.Get("/auth/me", authHandler.GetMe)
 			r.Post("/auth/logout", authHandler.Logout)
@@ -398,6 +468,12 @@ func main() {
 			r.Get("/game/entry-options", entryHandler.GetEntryOptions)
 			r.Get("/game/worlds", worldHandler.ListWorlds)
 
+			// Read-only simulation state (?world_id=)
+			r.Get("/game/simulation/geology", simulationHandler.GetGeology)
+			r.Get("/game/simulation/population", simulationHandler.GetPopulation)
+			r.Get("/game/simulation/timeline", simulationHandler.GetTimeline)
+			r.Get("/game/simulation/phylogeny", simulationHandler.GetPhylogeny)
+
 			// Skills
 			r.Get("/game/skills", skillsHandler.HandleGetSkills)
 
@@ -448,5 +524,10 @@ func main() {
 		log.Fatal().Err(err).Msg("Server error")
 	}
 
+	// Write the domain events still queued before exiting
+	eventPublisher.Close()
+	if err := gameProcessor.SaveWorldClocks(context.Background()); err != nil {
+		log.Warn().Err(err).Msg("Failed to save world clocks")
+	}
 	log.Info().Msg("Server stopped")
 }
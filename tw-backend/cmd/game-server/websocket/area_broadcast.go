@@ -0,0 +1,38 @@
+package websocket
+
+import (
+	"fmt"
+	"log"
+
+	"tw-backend/internal/nats/broadcast"
+	"tw-backend/internal/nats/subjects"
+
+	"github.com/nats-io/nats.go"
+)
+
+// SubscribeAreaBroadcasts delivers the world service's area broadcasts
+// (eruptions, weather, ...) to this hub's players in range. Every game
+// server subscribes outside any queue group, since each holds only its own
+// players.
+func (h *Hub) SubscribeAreaBroadcasts(nc subjects.Subscriber) error {
+	_, err := nc.Subscribe(subjects.WorldBroadcastArea, func(msg *nats.Msg) {
+		if err := h.HandleAreaBroadcast(msg.Data); err != nil {
+			log.Printf("[WS] Dropped area broadcast: %v", err)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("hub.SubscribeAreaBroadcasts: subscribe failed: %w", err)
+	}
+	return nil
+}
+
+// HandleAreaBroadcast delivers one published broadcast.AreaBroadcast to the
+// players in its world within its radius
+func (h *Hub) HandleAreaBroadcast(payload []byte) error {
+	b, err := broadcast.DecodeAreaBroadcast(payload)
+	if err != nil {
+		return err
+	}
+	h.BroadcastToArea(b.WorldID, b.Center, b.Radius, b.Type, b.Data)
+	return nil
+}
@@ -0,0 +1,87 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"tw-backend/internal/nats/broadcast"
+	"tw-backend/internal/nats/subjects"
+	"tw-backend/internal/spatial"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSubscriber records the handler subscribed to each subject
+type fakeSubscriber struct {
+	handlers map[string]nats.MsgHandler
+}
+
+func (f *fakeSubscriber) Subscribe(subj string, cb nats.MsgHandler) (*nats.Subscription, error) {
+	f.handlers[subj] = cb
+	return nil, nil
+}
+
+func TestHub_AreaBroadcastReachesOnlyPlayersInRange(t *testing.T) {
+	hub := NewHub(&MockMessageProcessor{})
+	worldID := uuid.New()
+
+	near := &Client{CharacterID: uuid.New(), WorldID: worldID, Send: make(chan []byte, 256)}
+	far := &Client{CharacterID: uuid.New(), WorldID: worldID, Send: make(chan []byte, 256)}
+	elsewhere := &Client{CharacterID: uuid.New(), WorldID: uuid.New(), Send: make(chan []byte, 256)}
+	positions := map[*Client]spatial.Position{
+		near:      {X: 120, Y: 80},
+		far:       {X: 5000, Y: 5000},
+		elsewhere: {X: 100, Y: 100},
+	}
+	for c, pos := range positions {
+		hub.Clients[c.CharacterID] = c
+		hub.SpatialIndex.Insert(c.CharacterID, pos)
+	}
+
+	sub := &fakeSubscriber{handlers: make(map[string]nats.MsgHandler)}
+	require.NoError(t, hub.SubscribeAreaBroadcasts(sub))
+	handle, ok := sub.handlers[subjects.WorldBroadcastArea]
+	require.True(t, ok, "hub should subscribe to area broadcasts")
+
+	// As published by the world service
+	b, err := broadcast.NewAreaBroadcast(worldID, spatial.Position{X: 100, Y: 100}, 500, MessageTypeGameMessage,
+		GameMessageData{Type: "system", Text: "The volcano erupts!"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(b)
+	require.NoError(t, err)
+	handle(&nats.Msg{Subject: subjects.WorldBroadcastArea, Data: payload})
+
+	require.Len(t, near.Send, 1, "player in range should receive the broadcast")
+	assert.Empty(t, far.Send, "player out of range should not")
+	assert.Empty(t, elsewhere.Send, "player in another world should not")
+
+	var msg struct {
+		Type string          `json:"type"`
+		Data GameMessageData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(<-near.Send, &msg))
+	assert.Equal(t, MessageTypeGameMessage, msg.Type)
+	assert.Equal(t, "The volcano erupts!", msg.Data.Text)
+}
+
+func TestHub_HandleAreaBroadcast_RejectsMalformed(t *testing.T) {
+	hub := NewHub(&MockMessageProcessor{})
+	assert.Error(t, hub.HandleAreaBroadcast([]byte("{")))
+}
+
+func TestGameMessage_MatchesGameMessageData(t *testing.T) {
+	sent := broadcast.NewGameMessage("weather", "Thunder rumbles overhead.")
+	raw, err := json.Marshal(sent)
+	require.NoError(t, err)
+
+	var got GameMessageData
+	require.NoError(t, json.Unmarshal(raw, &got))
+	assert.Equal(t, sent.ID, got.ID)
+	assert.Equal(t, "weather", got.Type)
+	assert.Equal(t, "Thunder rumbles overhead.", got.Text)
+	assert.True(t, sent.Timestamp.Equal(got.Timestamp))
+	assert.Equal(t, MessageTypeGameMessage, broadcast.MessageTypeGameMessage)
+}
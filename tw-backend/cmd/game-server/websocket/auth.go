@@ -0,0 +1,42 @@
+package websocket
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Subprotocol is the application protocol clients should offer alongside
+	// their token so the server has a non-secret protocol to select
+	Subprotocol = "thousand-worlds"
+
+	// BearerSubprotocolPrefix marks a Sec-WebSocket-Protocol entry carrying a JWT,
+	// e.g. "bearer.<token>". Browsers can't set Authorization on websocket
+	// upgrades, so this keeps the token out of the URL.
+	BearerSubprotocolPrefix = "bearer."
+)
+
+// TokenFromSubprotocols returns the JWT offered via Sec-WebSocket-Protocol, if any
+func TokenFromSubprotocols(r *http.Request) string {
+	for _, proto := range websocket.Subprotocols(r) {
+		if strings.HasPrefix(proto, BearerSubprotocolPrefix) {
+			return strings.TrimPrefix(proto, BearerSubprotocolPrefix)
+		}
+	}
+	return ""
+}
+
+// upgradeHeader selects the subprotocol to answer the upgrade with: Subprotocol
+// if the client offered it. The bearer entry is never echoed, since that would
+// send the token back in the response, so a client that offers only its token
+// gets no subprotocol and browsers will refuse the connection.
+func upgradeHeader(r *http.Request) http.Header {
+	for _, proto := range websocket.Subprotocols(r) {
+		if proto == Subprotocol {
+			return http.Header{"Sec-Websocket-Protocol": {Subprotocol}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package websocket
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenFromSubprotocols(t *testing.T) {
+	req := httptest.NewRequest("GET", "/ws", nil)
+	req.Header.Set("Sec-WebSocket-Protocol", Subprotocol+", "+BearerSubprotocolPrefix+"abc.def.ghi")
+	assert.Equal(t, "abc.def.ghi", TokenFromSubprotocols(req))
+
+	req = httptest.NewRequest("GET", "/ws", nil)
+	assert.Empty(t, TokenFromSubprotocols(req))
+}
+
+func TestUpgradeHeader(t *testing.T) {
+	t.Run("prefers the application protocol over the token", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ws", nil)
+		req.Header.Set("Sec-WebSocket-Protocol", BearerSubprotocolPrefix+"tok, "+Subprotocol)
+		assert.Equal(t, Subprotocol, upgradeHeader(req).Get("Sec-WebSocket-Protocol"))
+	})
+
+	t.Run("never echoes the token entry", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ws", nil)
+		req.Header.Set("Sec-WebSocket-Protocol", BearerSubprotocolPrefix+"tok")
+		assert.Nil(t, upgradeHeader(req))
+	})
+
+	t.Run("no subprotocols", func(t *testing.T) {
+		assert.Nil(t, upgradeHeader(httptest.NewRequest("GET", "/ws", nil)))
+	})
+}
@@ -104,8 +104,9 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Upgrade connection
-	conn, err := upgrader.Upgrade(w, r, nil)
+	// Upgrade connection, answering with Subprotocol only if the client offered
+	// it; the bearer token subprotocol is never echoed back
+	conn, err := upgrader.Upgrade(w, r, upgradeHeader(r))
 	if err != nil {
 		log.Printf("[WS] Failed to upgrade connection: %v", err)
 		return
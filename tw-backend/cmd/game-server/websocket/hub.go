@@ -47,6 +47,9 @@ type Hub struct {
 type MessageProcessor interface {
 	ProcessCommand(ctx context.Context, client GameClient, cmd *CommandData) error
 	OnClientConnected(ctx context.Context, client GameClient)
+	// OnClientDisconnected is called when a character's active connection goes away.
+	// It is not called for connections superseded by a reconnect.
+	OnClientDisconnected(ctx context.Context, client GameClient)
 }
 
 // NewHub creates a new WebSocket hub
@@ -71,6 +74,10 @@ func (h *Hub) Run(ctx context.Context) {
 
 		case client := <-h.Register:
 			h.mu.Lock()
+			// A reconnecting character replaces its stale connection
+			if old, ok := h.Clients[client.CharacterID]; ok && old != client {
+				old.SafeClose()
+			}
 			h.Clients[client.CharacterID] = client
 			// Add to spatial index with default position (0, 0)
 			// Position will be updated when character moves
@@ -86,16 +93,24 @@ func (h *Hub) Run(ctx context.Context) {
 
 		case client := <-h.Unregister:
 			h.mu.Lock()
-			if _, ok := h.Clients[client.CharacterID]; ok {
+			// Only the character's current connection is removed; a stale one
+			// unregistering after a reconnect must not evict its replacement
+			current, ok := h.Clients[client.CharacterID]
+			active := ok && current == client
+			if active {
 				delete(h.Clients, client.CharacterID)
 				// Remove from spatial index
 				h.SpatialIndex.Remove(client.CharacterID)
-				client.SafeClose()
 			}
+			client.SafeClose()
 			h.mu.Unlock()
 			metrics.SetActiveConnections(len(h.Clients))
 			log.Printf("Client unregistered: %s", client.ID)
 
+			if active && h.Processor != nil {
+				h.Processor.OnClientDisconnected(ctx, client)
+			}
+
 		case wrapper := <-h.HandleMessage:
 			h.handleClientMessage(ctx, wrapper)
 		}
@@ -162,10 +177,19 @@ func (h *Hub) UpdateCharacterPosition(characterID uuid.UUID, x, y float64) {
 	h.SpatialIndex.Insert(characterID, spatial.Position{X: x, Y: y})
 }
 
-// BroadcastToArea sends a message to all clients within a radius
+// GetCharacterPosition returns a character's last known position in the spatial index
+func (h *Hub) GetCharacterPosition(characterID uuid.UUID) (spatial.Position, bool) {
+	return h.SpatialIndex.GetPosition(characterID)
+}
+
+// RecipientFilter decides whether an in-range client receives an area broadcast.
+// Used for checks the spatial index can't answer, such as line-of-sight.
+type RecipientFilter func(client *Client, pos spatial.Position) bool
+
+// BroadcastToArea sends a message to all clients in a world within a radius
 // Performance: O(k/W) where k = clients in area, W = worker count
 // Uses concurrent workers for parallel message sending
-func (h *Hub) BroadcastToArea(center spatial.Position, radius float64, msgType string, data interface{}) {
+func (h *Hub) BroadcastToArea(worldID uuid.UUID, center spatial.Position, radius float64, msgType string, data interface{}, filters ...RecipientFilter) {
 	start := time.Now()
 	defer func() {
 		metrics.RecordHubBroadcast(time.Since(start))
@@ -173,18 +197,32 @@ func (h *Hub) BroadcastToArea(center spatial.Position, radius float64, msgType s
 	}()
 
 	// Query spatial index for nearby entities (O(k))
+	// The index spans all worlds, so recipients are also filtered by world
 	entityIDs := h.SpatialIndex.QueryRadius(center, radius)
 
 	h.mu.RLock()
 	// Build list of clients to notify
 	clients := make([]*Client, 0, len(entityIDs))
 	for _, entityID := range entityIDs {
-		if client, ok := h.Clients[entityID]; ok {
-			clients = append(clients, client)
+		client, ok := h.Clients[entityID]
+		if !ok || client.WorldID != worldID {
+			continue
 		}
+		clients = append(clients, client)
 	}
 	h.mu.RUnlock()
 
+	if len(filters) > 0 {
+		visible := clients[:0]
+		for _, client := range clients {
+			pos, _ := h.SpatialIndex.GetPosition(client.CharacterID)
+			if passesFilters(client, pos, filters) {
+				visible = append(visible, client)
+			}
+		}
+		clients = visible
+	}
+
 	if len(clients) == 0 {
 		return
 	}
@@ -203,6 +241,16 @@ func (h *Hub) BroadcastToArea(center spatial.Position, radius float64, msgType s
 	h.broadcastConcurrent(clients, msgType, data)
 }
 
+// passesFilters reports whether a client satisfies every recipient filter
+func passesFilters(client *Client, pos spatial.Position, filters []RecipientFilter) bool {
+	for _, filter := range filters {
+		if !filter(client, pos) {
+			return false
+		}
+	}
+	return true
+}
+
 // broadcastConcurrent sends messages to clients using a worker pool
 // Parallelizes message sending to reduce total broadcast time
 func (h *Hub) broadcastConcurrent(clients []*Client, msgType string, data interface{}) {
@@ -208,7 +208,7 @@ func TestHub_LoadTest_1000Clients(t *testing.T) {
 
 		start := time.Now()
 		for i := 0; i < numMessages; i++ {
-			hub.BroadcastToArea(center, radius, "area_test", map[string]interface{}{"iteration": i})
+			hub.BroadcastToArea(uuid.Nil, center, radius, "area_test", map[string]interface{}{"iteration": i})
 		}
 		duration := time.Since(start)
 
@@ -231,6 +231,7 @@ func TestHub_LoadTest_1000Clients(t *testing.T) {
 		for i := 0; i < 100; i++ {
 			go func() {
 				hub.BroadcastToArea(
+					uuid.Nil,
 					spatial.Position{X: 500, Y: 500},
 					150.0,
 					"concurrent_test",
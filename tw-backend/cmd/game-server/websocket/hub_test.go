@@ -26,6 +26,10 @@ func (m *MockMessageProcessor) OnClientConnected(ctx context.Context, client Gam
 	m.Called(ctx, client)
 }
 
+func (m *MockMessageProcessor) OnClientDisconnected(ctx context.Context, client GameClient) {
+	m.Called(ctx, client)
+}
+
 func TestNewHub(t *testing.T) {
 	processor := &MockMessageProcessor{}
 	hub := NewHub(processor)
@@ -139,7 +143,7 @@ func TestHub_BroadcastToArea(t *testing.T) {
 	hub.SpatialIndex.Insert(client2.CharacterID, spatial.Position{X: 1000, Y: 1000})
 
 	// Broadcast at (0,0) with radius 100
-	hub.BroadcastToArea(spatial.Position{X: 0, Y: 0}, 100, "area_msg", "nearby")
+	hub.BroadcastToArea(uuid.Nil, spatial.Position{X: 0, Y: 0}, 100, "area_msg", "nearby")
 
 	// Client 1 should receive
 	select {
@@ -158,6 +162,57 @@ func TestHub_BroadcastToArea(t *testing.T) {
 	}
 }
 
+func TestHub_BroadcastToArea_FiltersByWorld(t *testing.T) {
+	hub := NewHub(&MockMessageProcessor{})
+	worldID := uuid.New()
+
+	sameWorld := &Client{CharacterID: uuid.New(), WorldID: worldID, Send: make(chan []byte, 256)}
+	otherWorld := &Client{CharacterID: uuid.New(), WorldID: uuid.New(), Send: make(chan []byte, 256)}
+	for _, c := range []*Client{sameWorld, otherWorld} {
+		hub.Clients[c.CharacterID] = c
+		hub.SpatialIndex.Insert(c.CharacterID, spatial.Position{X: 5, Y: 5})
+	}
+
+	hub.BroadcastToArea(worldID, spatial.Position{X: 0, Y: 0}, 50, "area_msg", "hello")
+
+	assert.Len(t, sameWorld.Send, 1, "Client in the same world should receive")
+	assert.Len(t, otherWorld.Send, 0, "Client at the same coordinates in another world should not")
+}
+
+func TestHub_BroadcastToArea_NoClientsInRange(t *testing.T) {
+	hub := NewHub(&MockMessageProcessor{})
+	worldID := uuid.New()
+
+	far := &Client{CharacterID: uuid.New(), WorldID: worldID, Send: make(chan []byte, 256)}
+	hub.Clients[far.CharacterID] = far
+	hub.SpatialIndex.Insert(far.CharacterID, spatial.Position{X: 5000, Y: 5000})
+
+	assert.NotPanics(t, func() {
+		hub.BroadcastToArea(worldID, spatial.Position{X: 0, Y: 0}, 100, "area_msg", "nobody")
+	})
+	assert.Len(t, far.Send, 0)
+}
+
+func TestHub_BroadcastToArea_RecipientFilter(t *testing.T) {
+	hub := NewHub(&MockMessageProcessor{})
+	worldID := uuid.New()
+
+	visible := &Client{CharacterID: uuid.New(), WorldID: worldID, Send: make(chan []byte, 256)}
+	hidden := &Client{CharacterID: uuid.New(), WorldID: worldID, Send: make(chan []byte, 256)}
+	hub.Clients[visible.CharacterID] = visible
+	hub.Clients[hidden.CharacterID] = hidden
+	hub.SpatialIndex.Insert(visible.CharacterID, spatial.Position{X: -10, Y: 0})
+	hub.SpatialIndex.Insert(hidden.CharacterID, spatial.Position{X: 10, Y: 0})
+
+	// Wall along x=5 blocks line-of-sight to the east
+	lineOfSight := func(_ *Client, pos spatial.Position) bool { return pos.X < 5 }
+
+	hub.BroadcastToArea(worldID, spatial.Position{X: 0, Y: 0}, 50, "area_msg", "seen", lineOfSight)
+
+	assert.Len(t, visible.Send, 1)
+	assert.Len(t, hidden.Send, 0, "Client behind the wall should be filtered out")
+}
+
 func TestHub_GetClientsByWorldID(t *testing.T) {
 	processor := &MockMessageProcessor{}
 	hub := NewHub(processor)
@@ -278,7 +333,7 @@ func TestHub_BroadcastConcurrent(t *testing.T) {
 	}
 
 	// Broadcast to area
-	hub.BroadcastToArea(spatial.Position{X: 0, Y: 0}, 100, "test_msg", "data")
+	hub.BroadcastToArea(uuid.Nil, spatial.Position{X: 0, Y: 0}, 100, "test_msg", "data")
 
 	// Verify all clients received message
 	for i, client := range clients {
@@ -290,3 +345,36 @@ func TestHub_BroadcastConcurrent(t *testing.T) {
 		}
 	}
 }
+
+func TestHub_ReconnectSupersedesStaleConnection(t *testing.T) {
+	processor := &MockMessageProcessor{}
+	processor.On("OnClientConnected", mock.Anything, mock.Anything).Return()
+	processor.On("OnClientDisconnected", mock.Anything, mock.Anything).Return()
+	hub := NewHub(processor)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go hub.Run(ctx)
+
+	characterID := uuid.New()
+	stale := &Client{ID: uuid.New(), CharacterID: characterID, Send: make(chan []byte, 1)}
+	fresh := &Client{ID: uuid.New(), CharacterID: characterID, Send: make(chan []byte, 1)}
+
+	hub.Register <- stale
+	hub.Register <- fresh
+	// The old connection's read pump exits after the reconnect
+	hub.Unregister <- stale
+	time.Sleep(10 * time.Millisecond)
+
+	current, ok := hub.GetClientByCharacter(characterID)
+	assert.True(t, ok, "Stale unregister must not evict the reconnected client")
+	assert.Equal(t, fresh, current)
+	processor.AssertNotCalled(t, "OnClientDisconnected", mock.Anything, stale)
+
+	// A real logout notifies the processor
+	hub.Unregister <- fresh
+	time.Sleep(10 * time.Millisecond)
+
+	assert.Equal(t, 0, hub.GetClientCount())
+	processor.AssertCalled(t, "OnClientDisconnected", mock.Anything, fresh)
+}
@@ -34,6 +34,8 @@ type CommandData struct {
 	Quantity  *int    `json:"quantity,omitempty"`
 	Message   *string `json:"message,omitempty"`   // For say, whisper, tell commands
 	Recipient *string `json:"recipient,omitempty"` // For whisper, tell commands
+	// DetailLevel asks look for more detail: 1=Basic, 2=Detailed, 3=Deep
+	DetailLevel *int `json:"detail_level,omitempty"`
 }
 
 // ServerMessage represents a message from server to client
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"tw-backend/internal/nats/subjects"
+	"tw-backend/internal/world"
+	"tw-backend/internal/worldentity"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+)
+
+// Publisher interface to decouple from NATS for testing
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// EntityLister is the part of worldentity.Repository used to count a
+// world's entities
+type EntityLister interface {
+	GetByWorldID(ctx context.Context, worldID uuid.UUID) ([]*worldentity.WorldEntity, error)
+}
+
+// CommandSubscriber is the part of *nats.Conn the handler subscribes with:
+// world.create in a queue group, and each world's own commands directly
+type CommandSubscriber interface {
+	subjects.QueueSubscriber
+	subjects.Subscriber
+}
+
+// WorldCommandHandler serves the world.create requests shared by all
+// instances, and the pause, resume and query requests of the worlds this
+// instance runs, replying to each with the world's status
+type WorldCommandHandler struct {
+	publisher Publisher
+	registry  *world.Registry
+	tickers   *world.TickerManager
+	entities  EntityLister
+
+	mu     sync.Mutex
+	bus    CommandSubscriber
+	served map[uuid.UUID]bool // Worlds whose commands are subscribed
+}
+
+func NewWorldCommandHandler(pub Publisher, registry *world.Registry, tickers *world.TickerManager, entities EntityLister) *WorldCommandHandler {
+	return &WorldCommandHandler{
+		publisher: pub,
+		registry:  registry,
+		tickers:   tickers,
+		entities:  entities,
+		served:    make(map[uuid.UUID]bool),
+	}
+}
+
+// WorldCommandRequest is the body of a world.create request, which
+// generates an ID if none is given. Pause, resume and query name their world
+// in the subject and need no body.
+type WorldCommandRequest struct {
+	WorldID        string  `json:"world_id"`
+	Name           string  `json:"name,omitempty"`
+	DilationFactor float64 `json:"dilation_factor,omitempty"`
+}
+
+// WorldStatusResponse is the reply to every world command
+type WorldStatusResponse struct {
+	WorldID        string  `json:"world_id,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	Status         string  `json:"status,omitempty"`
+	CurrentYear    int64   `json:"current_year"`
+	TickCount      int64   `json:"tick_count"`
+	TickRate       float64 `json:"tick_rate"` // Ticks per real second; 0 while paused
+	DilationFactor float64 `json:"dilation_factor,omitempty"`
+	EntityCount    int     `json:"entity_count"`
+	Error          string  `json:"error,omitempty"`
+}
+
+// Subscribe registers the world.create handler in a queue group. Each
+// world's pause, resume and query handlers are registered by the instance
+// that creates it, since only that instance's registry holds the world.
+func (h *WorldCommandHandler) Subscribe(nc CommandSubscriber, queue string) error {
+	h.mu.Lock()
+	h.bus = nc
+	h.mu.Unlock()
+
+	_, err := nc.QueueSubscribe(subjects.WorldCreate, queue, commandCallback(h.HandleCreate))
+	if err != nil {
+		return fmt.Errorf("subscribe %s: %w", subjects.WorldCreate, err)
+	}
+	return nil
+}
+
+// serveWorld registers the pause, resume and query handlers of a world this
+// instance runs
+func (h *WorldCommandHandler) serveWorld(worldID uuid.UUID) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.served[worldID] || h.bus == nil {
+		return nil
+	}
+
+	handlers := map[string]func(context.Context, uuid.UUID, *nats.Msg) error{
+		subjects.WorldPause(worldID):  h.HandlePause,
+		subjects.WorldResume(worldID): h.HandleResume,
+		subjects.WorldQuery(worldID):  h.HandleQuery,
+	}
+	for subject, handle := range handlers {
+		callback := commandCallback(func(ctx context.Context, msg *nats.Msg) error {
+			return handle(ctx, worldID, msg)
+		})
+		if _, err := h.bus.Subscribe(subject, callback); err != nil {
+			return fmt.Errorf("subscribe %s: %w", subject, err)
+		}
+	}
+	h.served[worldID] = true
+	return nil
+}
+
+// commandCallback runs a command handler with a timeout, logging its error
+func commandCallback(handle func(context.Context, *nats.Msg) error) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := handle(ctx, msg); err != nil {
+			log.Error().Err(err).Str("subject", msg.Subject).Msg("Failed to handle world command")
+		}
+	}
+}
+
+// HandleCreate registers a new world, starts its ticker and subscribes to
+// its commands
+func (h *WorldCommandHandler) HandleCreate(ctx context.Context, msg *nats.Msg) error {
+	var req WorldCommandRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		return h.replyError(msg, "invalid request")
+	}
+
+	worldID := uuid.New()
+	if req.WorldID != "" {
+		parsed, err := uuid.Parse(req.WorldID)
+		if err != nil {
+			return h.replyError(msg, "invalid world_id")
+		}
+		worldID = parsed
+	}
+	if _, err := h.registry.GetWorld(worldID); err == nil {
+		return h.replyError(msg, fmt.Sprintf("world %s already exists", worldID))
+	}
+
+	dilation := req.DilationFactor
+	if dilation <= 0 {
+		dilation = 1.0
+	}
+	if err := h.tickers.SpawnTicker(worldID, req.Name, dilation); err != nil {
+		return h.replyError(msg, err.Error())
+	}
+	if err := h.serveWorld(worldID); err != nil {
+		if stopErr := h.tickers.StopTicker(worldID); stopErr != nil {
+			log.Error().Err(stopErr).Str("world_id", worldID.String()).Msg("Failed to stop unreachable world")
+		}
+		if replyErr := h.replyError(msg, "failed to subscribe to world commands"); replyErr != nil {
+			return replyErr
+		}
+		return err
+	}
+	return h.replyStatus(ctx, msg, worldID)
+}
+
+// HandlePause stops a world's ticker; its time is caught up on resume
+func (h *WorldCommandHandler) HandlePause(ctx context.Context, worldID uuid.UUID, msg *nats.Msg) error {
+	if err := h.tickers.StopTicker(worldID); err != nil {
+		return h.replyError(msg, err.Error())
+	}
+	return h.replyStatus(ctx, msg, worldID)
+}
+
+// HandleResume restarts a paused world's ticker
+func (h *WorldCommandHandler) HandleResume(ctx context.Context, worldID uuid.UUID, msg *nats.Msg) error {
+	state, err := h.registry.GetWorld(worldID)
+	if err != nil {
+		return h.replyError(msg, err.Error())
+	}
+	if state.Status != world.StatusPaused {
+		return h.replyError(msg, fmt.Sprintf("world %s is not paused", worldID))
+	}
+	if err := h.tickers.SpawnTicker(worldID, state.Name, state.DilationFactor); err != nil {
+		return h.replyError(msg, err.Error())
+	}
+	return h.replyStatus(ctx, msg, worldID)
+}
+
+// HandleQuery replies with a world's status
+func (h *WorldCommandHandler) HandleQuery(ctx context.Context, worldID uuid.UUID, msg *nats.Msg) error {
+	if _, err := h.registry.GetWorld(worldID); err != nil {
+		return h.replyError(msg, err.Error())
+	}
+	return h.replyStatus(ctx, msg, worldID)
+}
+
+// status describes a registered world
+func (h *WorldCommandHandler) status(ctx context.Context, worldID uuid.UUID) (WorldStatusResponse, error) {
+	state, err := h.registry.GetWorld(worldID)
+	if err != nil {
+		return WorldStatusResponse{}, err
+	}
+	running, tickCount, gameTime := h.tickers.GetTickerStatus(worldID)
+
+	resp := WorldStatusResponse{
+		WorldID:        worldID.String(),
+		Name:           state.Name,
+		Status:         string(state.Status),
+		CurrentYear:    world.CalculateYear(gameTime, world.DefaultSeasonLength),
+		TickCount:      tickCount,
+		DilationFactor: state.DilationFactor,
+	}
+	if running {
+		resp.TickRate = float64(time.Second) / float64(world.DefaultTickInterval)
+	}
+	if h.entities != nil {
+		entities, err := h.entities.GetByWorldID(ctx, worldID)
+		if err != nil {
+			return WorldStatusResponse{}, fmt.Errorf("count entities: %w", err)
+		}
+		resp.EntityCount = len(entities)
+	}
+	return resp, nil
+}
+
+func (h *WorldCommandHandler) replyStatus(ctx context.Context, msg *nats.Msg, worldID uuid.UUID) error {
+	resp, err := h.status(ctx, worldID)
+	if err != nil {
+		if replyErr := h.replyError(msg, "failed to read world status"); replyErr != nil {
+			return replyErr
+		}
+		return err
+	}
+	return h.reply(msg, resp)
+}
+
+func (h *WorldCommandHandler) replyError(msg *nats.Msg, errMsg string) error {
+	return h.reply(msg, WorldStatusResponse{Error: errMsg})
+}
+
+func (h *WorldCommandHandler) reply(msg *nats.Msg, resp WorldStatusResponse) error {
+	if msg.Reply == "" {
+		return nil
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("world command: marshal response: %w", err)
+	}
+	return h.publisher.Publish(msg.Reply, data)
+}
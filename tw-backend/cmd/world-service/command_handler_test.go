@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"tw-backend/internal/nats/subjects"
+	"tw-backend/internal/world"
+	"tw-backend/internal/worldentity"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBus stands in for the NATS connection: it keeps the handlers
+// subscribed and the replies published. A queue subscription replaces an
+// earlier one, as NATS delivers each message to one member of the group.
+type fakeBus struct {
+	mu       sync.Mutex
+	handlers map[string]nats.MsgHandler
+	replies  map[string][]byte
+}
+
+func newFakeBus() *fakeBus {
+	return &fakeBus{handlers: make(map[string]nats.MsgHandler), replies: make(map[string][]byte)}
+}
+
+func (b *fakeBus) QueueSubscribe(subj, queue string, cb nats.MsgHandler) (*nats.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[subj] = cb
+	return nil, nil
+}
+
+func (b *fakeBus) Subscribe(subj string, cb nats.MsgHandler) (*nats.Subscription, error) {
+	return b.QueueSubscribe(subj, "", cb)
+}
+
+// subscribed reports whether any instance serves a subject
+func (b *fakeBus) subscribed(subject string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.handlers[subject]
+	return ok
+}
+
+func (b *fakeBus) Publish(subject string, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.replies[subject] = data
+	return nil
+}
+
+// request delivers a command and returns the reply
+func (b *fakeBus) request(t *testing.T, subject string, req WorldCommandRequest) WorldStatusResponse {
+	t.Helper()
+	b.mu.Lock()
+	handle, ok := b.handlers[subject]
+	b.mu.Unlock()
+	require.True(t, ok, "no subscription for %s", subject)
+
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	inbox := nats.NewInbox()
+	handle(&nats.Msg{Subject: subject, Reply: inbox, Data: data})
+
+	b.mu.Lock()
+	reply, ok := b.replies[inbox]
+	b.mu.Unlock()
+	require.True(t, ok, "no reply to %s", subject)
+
+	var resp WorldStatusResponse
+	require.NoError(t, json.Unmarshal(reply, &resp))
+	return resp
+}
+
+type fakeEntities struct {
+	count int
+}
+
+func (f fakeEntities) GetByWorldID(ctx context.Context, worldID uuid.UUID) ([]*worldentity.WorldEntity, error) {
+	return make([]*worldentity.WorldEntity, f.count), nil
+}
+
+func setupWorldCommands(t *testing.T) *fakeBus {
+	t.Helper()
+	bus := newFakeBus()
+	startWorldService(t, bus)
+	return bus
+}
+
+// startWorldService runs one world service instance on the bus
+func startWorldService(t *testing.T, bus *fakeBus) *world.Registry {
+	t.Helper()
+	registry := world.NewRegistry()
+	tickers := world.NewTickerManager(registry, nil, nil, nil, nil)
+	t.Cleanup(tickers.StopAll)
+
+	handler := NewWorldCommandHandler(bus, registry, tickers, fakeEntities{count: 3})
+	require.NoError(t, handler.Subscribe(bus, subjects.WorldServiceQueue))
+	return registry
+}
+
+func TestWorldCommands_Lifecycle(t *testing.T) {
+	bus := setupWorldCommands(t)
+
+	created := bus.request(t, subjects.WorldCreate, WorldCommandRequest{Name: "Test World", DilationFactor: 10})
+	require.Empty(t, created.Error)
+	worldID, err := uuid.Parse(created.WorldID)
+	require.NoError(t, err)
+	assert.Equal(t, "running", created.Status)
+	assert.Equal(t, 10.0, created.DilationFactor)
+
+	time.Sleep(250 * time.Millisecond)
+	running := bus.request(t, subjects.WorldQuery(worldID), WorldCommandRequest{})
+	require.Empty(t, running.Error)
+	assert.Equal(t, "Test World", running.Name)
+	assert.Equal(t, "running", running.Status)
+	assert.Positive(t, running.TickCount)
+	assert.Equal(t, 10.0, running.TickRate)
+	assert.Equal(t, 3, running.EntityCount)
+
+	paused := bus.request(t, subjects.WorldPause(worldID), WorldCommandRequest{})
+	require.Empty(t, paused.Error)
+	assert.Equal(t, "paused", paused.Status)
+	assert.Zero(t, paused.TickRate)
+
+	resumed := bus.request(t, subjects.WorldResume(worldID), WorldCommandRequest{})
+	require.Empty(t, resumed.Error)
+	assert.Equal(t, "running", resumed.Status)
+
+	time.Sleep(250 * time.Millisecond)
+	after := bus.request(t, subjects.WorldQuery(worldID), WorldCommandRequest{})
+	require.Empty(t, after.Error)
+	assert.Equal(t, "running", after.Status)
+	assert.Greater(t, after.TickCount, paused.TickCount, "ticks should continue after resume")
+}
+
+func TestWorldCommands_Errors(t *testing.T) {
+	bus := setupWorldCommands(t)
+	unknown := uuid.New()
+
+	// No instance answers for a world nobody runs
+	assert.False(t, bus.subscribed(subjects.WorldQuery(unknown)))
+	assert.False(t, bus.subscribed(subjects.WorldPause(unknown)))
+	assert.Equal(t, "invalid world_id", bus.request(t, subjects.WorldCreate, WorldCommandRequest{WorldID: "nope"}).Error)
+
+	created := bus.request(t, subjects.WorldCreate, WorldCommandRequest{WorldID: unknown.String(), Name: "Twice"})
+	require.Empty(t, created.Error)
+	assert.Equal(t, unknown.String(), created.WorldID)
+	assert.Contains(t, bus.request(t, subjects.WorldCreate, WorldCommandRequest{WorldID: unknown.String()}).Error, "already exists")
+	assert.Contains(t, bus.request(t, subjects.WorldResume(unknown), WorldCommandRequest{}).Error, "not paused")
+}
+
+func TestWorldCommands_ReachTheInstanceRunningTheWorld(t *testing.T) {
+	bus := newFakeBus()
+	startWorldService(t, bus)
+	created := bus.request(t, subjects.WorldCreate, WorldCommandRequest{Name: "Owned"})
+	require.Empty(t, created.Error)
+	worldID, err := uuid.Parse(created.WorldID)
+	require.NoError(t, err)
+
+	// A second instance joins the queue group and takes later creates, but
+	// the world's commands still reach the instance running it
+	other := startWorldService(t, bus)
+	_, err = other.GetWorld(worldID)
+	require.Error(t, err)
+
+	paused := bus.request(t, subjects.WorldPause(worldID), WorldCommandRequest{})
+	require.Empty(t, paused.Error)
+	assert.Equal(t, "paused", paused.Status)
+	queried := bus.request(t, subjects.WorldQuery(worldID), WorldCommandRequest{})
+	require.Empty(t, queried.Error)
+	assert.Equal(t, "Owned", queried.Name)
+}
+
+// natsRequest sends a command over a real NATS connection and returns the reply
+func natsRequest(t *testing.T, nc *nats.Conn, subject string, req WorldCommandRequest) WorldStatusResponse {
+	t.Helper()
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	msg, err := nc.Request(subject, data, 2*time.Second)
+	require.NoError(t, err, "request %s", subject)
+
+	var resp WorldStatusResponse
+	require.NoError(t, json.Unmarshal(msg.Data, &resp))
+	return resp
+}
+
+func TestWorldCommands_OverEmbeddedNATS(t *testing.T) {
+	url := startTestNATSServer(t)
+
+	// The service, wired as main wires it
+	service, err := nats.Connect(url)
+	require.NoError(t, err)
+	t.Cleanup(service.Close)
+	registry := world.NewRegistry()
+	tickers := world.NewTickerManager(registry, nil, nil, nil, nil)
+	t.Cleanup(tickers.StopAll)
+	handler := NewWorldCommandHandler(service, registry, tickers, fakeEntities{count: 2})
+	require.NoError(t, handler.Subscribe(service, subjects.WorldServiceQueue))
+	require.NoError(t, service.Flush())
+
+	client, err := nats.Connect(url)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	created := natsRequest(t, client, subjects.WorldCreate, WorldCommandRequest{Name: "Wired World", DilationFactor: 10})
+	require.Empty(t, created.Error)
+	worldID, err := uuid.Parse(created.WorldID)
+	require.NoError(t, err)
+	assert.Equal(t, "running", created.Status)
+
+	time.Sleep(250 * time.Millisecond)
+	running := natsRequest(t, client, subjects.WorldQuery(worldID), WorldCommandRequest{})
+	require.Empty(t, running.Error)
+	assert.Equal(t, "Wired World", running.Name)
+	assert.Positive(t, running.TickCount)
+	assert.Equal(t, 2, running.EntityCount)
+
+	paused := natsRequest(t, client, subjects.WorldPause(worldID), WorldCommandRequest{})
+	require.Empty(t, paused.Error)
+	assert.Equal(t, "paused", paused.Status)
+	assert.Zero(t, paused.TickRate)
+
+	resumed := natsRequest(t, client, subjects.WorldResume(worldID), WorldCommandRequest{})
+	require.Empty(t, resumed.Error)
+	assert.Equal(t, "running", resumed.Status)
+
+	time.Sleep(250 * time.Millisecond)
+	after := natsRequest(t, client, subjects.WorldQuery(worldID), WorldCommandRequest{})
+	require.Empty(t, after.Error)
+	assert.Equal(t, "running", after.Status)
+	assert.Greater(t, after.TickCount, paused.TickCount, "ticks should continue after resume")
+}
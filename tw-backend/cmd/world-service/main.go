@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog"
@@ -14,8 +16,11 @@ import (
 
 	"tw-backend/internal/auth"
 	"tw-backend/internal/eventstore"
+	"tw-backend/internal/nats/broadcast"
+	"tw-backend/internal/nats/subjects"
 	"tw-backend/internal/spatial"
 	"tw-backend/internal/world"
+	"tw-backend/internal/worldentity"
 	"tw-backend/internal/worldgen/weather"
 )
 
@@ -76,13 +81,14 @@ func main() {
 	registry := world.NewRegistry()
 	tickerManager := world.NewTickerManager(registry, eventStore, natsPublisher, weatherService, areaBroadcaster)
 
-	log.Info().Msg("World Service initialized successfully")
+	// Serve world commands (create, pause, resume, query) on NATS
+	entityRepo := worldentity.NewPostgresRepository(dbPool)
+	commandHandler := NewWorldCommandHandler(natsPublisher, registry, tickerManager, entityRepo)
+	if err := commandHandler.Subscribe(nc, subjects.QueueGroup(subjects.WorldServiceQueue)); err != nil {
+		log.Fatal().Err(err).Msg("Failed to subscribe to world commands")
+	}
 
-	// TODO: Set up NATS subscriptions for world commands
-	// - world.create -> spawn new world
-	// - world.pause -> pause ticker
-	// - world.resume -> resume ticker
-	// - world.query -> get world status
+	log.Info().Msg("World Service initialized successfully")
 
 	// Set up graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -144,12 +150,20 @@ type NATSAreaBroadcaster struct {
 	nc *nats.Conn
 }
 
-func (b *NATSAreaBroadcaster) BroadcastToArea(center spatial.Position, radius float64, msgType string, data interface{}) {
-	// Publish to NATS for game-server to pick up
-	// Subject: world.broadcast.area
-	// Payload: { Center, Radius, Type, Data }
-	// For now, just logging or simple publish
-	// TODO: Define shared struct for this payload
+// BroadcastToArea publishes a broadcast.AreaBroadcast for game servers to
+// deliver to the players in range. Failures are logged; broadcasts are
+// best-effort.
+func (b *NATSAreaBroadcaster) BroadcastToArea(worldID uuid.UUID, center spatial.Position, radius float64, msgType string, data interface{}) {
+	msg, err := broadcast.NewAreaBroadcast(worldID, center, radius, msgType, data)
+	if err == nil {
+		var payload []byte
+		if payload, err = json.Marshal(msg); err == nil {
+			err = b.nc.Publish(subjects.WorldBroadcastArea, payload)
+		}
+	}
+	if err != nil {
+		log.Error().Err(err).Str("world_id", worldID.String()).Str("type", msgType).Msg("Failed to publish area broadcast")
+	}
 }
 
 func maskPassword(dbURL string) string {
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testNATSServer is a NATS server embedded in the test process. It speaks
+// enough of the client protocol (INFO, CONNECT, PING, SUB, UNSUB, PUB and
+// MSG) for nats.go to subscribe, join queue groups, publish and make
+// requests. nats-server itself isn't a module dependency.
+type testNATSServer struct {
+	ln net.Listener
+	wg sync.WaitGroup
+
+	mu      sync.Mutex
+	clients map[*testNATSClient]bool
+	subs    []*testNATSSub
+}
+
+type testNATSClient struct {
+	conn net.Conn
+	mu   sync.Mutex // Serializes writes
+}
+
+type testNATSSub struct {
+	client    *testNATSClient
+	subject   string
+	queue     string
+	sid       string
+	max       int // Messages before an auto-unsubscribe; 0 for no limit
+	delivered int
+}
+
+// delivery is one message bound for one subscription
+type delivery struct {
+	sub     *testNATSSub
+	subject string
+	reply   string
+	payload []byte
+}
+
+// startTestNATSServer serves on a free local port until the test ends, and
+// returns the URL to connect to
+func startTestNATSServer(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &testNATSServer{ln: ln, clients: make(map[*testNATSClient]bool)}
+	s.wg.Add(1)
+	go s.accept()
+	t.Cleanup(s.close)
+	return "nats://" + ln.Addr().String()
+}
+
+func (s *testNATSServer) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		c := &testNATSClient{conn: conn}
+		s.mu.Lock()
+		s.clients[c] = true
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.serve(c)
+	}
+}
+
+// close stops accepting, disconnects every client and waits for them
+func (s *testNATSServer) close() {
+	s.ln.Close()
+	s.mu.Lock()
+	for c := range s.clients {
+		c.conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// serve reads a client's protocol lines until it disconnects
+func (s *testNATSServer) serve(c *testNATSClient) {
+	defer s.wg.Done()
+	defer s.drop(c)
+
+	port := s.ln.Addr().(*net.TCPAddr).Port
+	c.write(fmt.Sprintf(`INFO {"server_id":"test","server_name":"test","version":"2.10.0","proto":1,"headers":false,"max_payload":1048576,"host":"127.0.0.1","port":%d}`+"\r\n", port))
+
+	r := bufio.NewReader(c.conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "PING":
+			c.write("PONG\r\n")
+		case "SUB": // SUB <subject> [queue] <sid>
+			sub := &testNATSSub{client: c, subject: fields[1], sid: fields[len(fields)-1]}
+			if len(fields) == 4 {
+				sub.queue = fields[2]
+			}
+			s.mu.Lock()
+			s.subs = append(s.subs, sub)
+			s.mu.Unlock()
+		case "UNSUB": // UNSUB <sid> [max]
+			max := 0
+			if len(fields) == 3 {
+				max, _ = strconv.Atoi(fields[2])
+			}
+			s.unsubscribe(c, fields[1], max)
+		case "PUB": // PUB <subject> [reply] <size>, then the payload line
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return
+			}
+			payload := make([]byte, size+2) // With its CRLF
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			reply := ""
+			if len(fields) == 4 {
+				reply = fields[2]
+			}
+			s.publish(fields[1], reply, payload[:size])
+		}
+		// CONNECT's options and PONG need no answer
+	}
+}
+
+// drop forgets a disconnected client and its subscriptions
+func (s *testNATSServer) drop(c *testNATSClient) {
+	c.conn.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, c)
+	s.subs = removeSubs(s.subs, func(sub *testNATSSub) bool { return sub.client == c })
+}
+
+// unsubscribe removes a subscription now, or after max messages in all
+func (s *testNATSServer) unsubscribe(c *testNATSClient, sid string, max int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs = removeSubs(s.subs, func(sub *testNATSSub) bool {
+		if sub.client != c || sub.sid != sid {
+			return false
+		}
+		sub.max = max
+		return max == 0 || sub.delivered >= max
+	})
+}
+
+// publish delivers a message to every matching plain subscription, and to
+// one member of each matching queue group
+func (s *testNATSServer) publish(subject, reply string, payload []byte) {
+	s.mu.Lock()
+	var deliveries []delivery
+	groups := make(map[string][]*testNATSSub)
+	for _, sub := range s.subs {
+		if !subjectMatches(sub.subject, subject) {
+			continue
+		}
+		if sub.queue != "" {
+			groups[sub.queue] = append(groups[sub.queue], sub)
+			continue
+		}
+		deliveries = append(deliveries, delivery{sub: sub, subject: subject, reply: reply, payload: payload})
+	}
+	for _, members := range groups {
+		sub := members[rand.Intn(len(members))]
+		deliveries = append(deliveries, delivery{sub: sub, subject: subject, reply: reply, payload: payload})
+	}
+	for _, d := range deliveries {
+		d.sub.delivered++
+	}
+	s.subs = removeSubs(s.subs, func(sub *testNATSSub) bool { return sub.max > 0 && sub.delivered >= sub.max })
+	s.mu.Unlock()
+
+	// Written after unlocking, so a slow reader can't stall the server
+	for _, d := range deliveries {
+		header := "MSG " + d.subject + " " + d.sub.sid
+		if d.reply != "" {
+			header += " " + d.reply
+		}
+		d.sub.client.write(fmt.Sprintf("%s %d\r\n%s\r\n", header, len(d.payload), d.payload))
+	}
+}
+
+func (c *testNATSClient) write(s string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = io.WriteString(c.conn, s)
+}
+
+func removeSubs(subs []*testNATSSub, remove func(*testNATSSub) bool) []*testNATSSub {
+	kept := subs[:0]
+	for _, sub := range subs {
+		if !remove(sub) {
+			kept = append(kept, sub)
+		}
+	}
+	return kept
+}
+
+// subjectMatches reports whether subject matches a subscription's pattern,
+// where "*" stands for one token and a final ">" for one or more
+func subjectMatches(pattern, subject string) bool {
+	want, got := strings.Split(pattern, "."), strings.Split(subject, ".")
+	for i, token := range want {
+		if token == ">" {
+			return len(got) > i
+		}
+		if i >= len(got) || (token != "*" && token != got[i]) {
+			return false
+		}
+	}
+	return len(want) == len(got)
+}
@@ -10,7 +10,9 @@ CREATE TABLE IF NOT EXISTS events (
     version BIGINT NOT NULL,
     timestamp TIMESTAMPTZ NOT NULL,
     payload JSONB NOT NULL,
+    schema_version INT NOT NULL DEFAULT 1,
     metadata JSONB,
+    sequence BIGSERIAL,
     UNIQUE(aggregate_id, version)
 );
 
@@ -19,6 +21,29 @@ CREATE INDEX IF NOT EXISTS idx_events_aggregate_id_version ON events (aggregate_
 CREATE INDEX IF NOT EXISTS idx_events_event_type ON events (event_type);
 CREATE INDEX IF NOT EXISTS idx_events_timestamp ON events (timestamp);
 
+-- Wake event subscribers after each insert
+CREATE OR REPLACE FUNCTION notify_event_appended() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('events_appended', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS events_notify_appended ON events;
+CREATE TRIGGER events_notify_appended
+    AFTER INSERT ON events
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_event_appended();
+
+-- Create snapshots table
+CREATE TABLE IF NOT EXISTS snapshots (
+    aggregate_id VARCHAR(255) NOT NULL,
+    aggregate_type VARCHAR(255) NOT NULL,
+    version BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (aggregate_id, version)
+);
+
 -- Create worlds table
 CREATE TABLE IF NOT EXISTS worlds (
     id UUID PRIMARY KEY,
@@ -9,6 +9,7 @@ import (
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog/log"
 
+	"tw-backend/internal/nats/subjects"
 	"tw-backend/internal/repository"
 )
 
@@ -42,16 +43,19 @@ type AIResponse struct {
 	EntityID  string `json:"entityID"`
 }
 
-// ListenForDecisions subscribes to npc.command.decide_action and ai.response.decision.*
+// ListenForDecisions subscribes to decision commands and AI decision responses.
+// Engine instances share a queue group so each NPC decides once.
 func (e *DesireEngine) ListenForDecisions() error {
+	queue := subjects.QueueGroup(subjects.DesireEngineQueue)
+
 	// Listener 1: Decide Action
-	_, err := e.nc.Subscribe("npc.command.decide_action", e.handleDecideAction)
+	_, err := e.nc.QueueSubscribe(subjects.NPCDecideAction, queue, e.handleDecideAction)
 	if err != nil {
 		return fmt.Errorf("subscribe decide_action failed: %w", err)
 	}
 
 	// Listener 2: AI Response
-	_, err = e.nc.Subscribe("ai.response.decision.*", e.handleAIResponse)
+	_, err = e.nc.QueueSubscribe(subjects.AIResponseDecisionAll, queue, e.handleAIResponse)
 	if err != nil {
 		return fmt.Errorf("subscribe ai_response failed: %w", err)
 	}
@@ -103,7 +107,7 @@ Decide your next action. Output ONLY the action command (e.g., "MOVE NORTH", "SA
 	}
 	data, _ := json.Marshal(req)
 
-	if err := e.nc.Publish("ai.request.decision", data); err != nil {
+	if err := e.nc.Publish(subjects.AIRequestDecision, data); err != nil {
 		log.Error().Err(err).Msg("Failed to publish AI request")
 	}
 }
@@ -125,8 +129,8 @@ func (e *DesireEngine) handleAIResponse(msg *nats.Msg) {
 	// Since we don't have a parser yet, we'll publish to 'npc.action.performed' which could be picked up.
 	// OR we can assume the LLM outputs JSON with coords? No, prompt says "MOVE NORTH".
 	// I'll publish to `spatial.command.action` for now.
-	
-	if err := e.nc.Publish("spatial.command.action", []byte(resp.Response)); err != nil {
+
+	if err := e.nc.Publish(subjects.SpatialAction, []byte(resp.Response)); err != nil {
 		log.Error().Err(err).Msg("Failed to publish final action")
 	}
 }
@@ -6,15 +6,15 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog/log"
-)
 
-// Subscriber defines the interface for subscribing to messages.
-type Subscriber interface {
-	Subscribe(subj string, cb nats.MsgHandler) (*nats.Subscription, error)
-}
+	"tw-backend/internal/nats/subjects"
+)
 
-func StartListener(nc Subscriber) error {
-	_, err := nc.Subscribe("ai.request.>", func(msg *nats.Msg) {
+// StartListener queues AI requests for the workers. Gateway instances share a
+// queue group so each request is handled once.
+func StartListener(nc subjects.QueueSubscriber) error {
+	queue := subjects.QueueGroup(subjects.AIGatewayQueue)
+	_, err := nc.QueueSubscribe(subjects.AIRequestAll, queue, func(msg *nats.Msg) {
 		var req AIRequest
 		if err := json.Unmarshal(msg.Data, &req); err != nil {
 			log.Error().Err(err).Msg("Failed to unmarshal AI request")
@@ -25,13 +25,13 @@ func StartListener(nc Subscriber) error {
 		if msg.Reply != "" {
 			req.ResponseSubject = msg.Reply
 		} else if req.ID != "" {
-			req.ResponseSubject = "ai.response." + req.ID
+			req.ResponseSubject = subjects.AIResponse(req.ID)
 		} else {
 			// Try to extract ID from subject if missing in body
 			tokens := strings.Split(msg.Subject, ".")
 			if len(tokens) >= 3 {
 				req.ID = tokens[2]
-				req.ResponseSubject = "ai.response." + req.ID
+				req.ResponseSubject = subjects.AIResponse(req.ID)
 			}
 		}
 
@@ -48,6 +48,6 @@ func StartListener(nc Subscriber) error {
 		return err
 	}
 
-	log.Info().Msg("Listening on ai.request.>")
+	log.Info().Str("subject", subjects.AIRequestAll).Str("queue", queue).Msg("Listening for AI requests")
 	return nil
 }
@@ -10,15 +10,15 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// MockSubscriber is a mock implementation of Subscriber
+// MockSubscriber is a mock implementation of subjects.QueueSubscriber
 type MockSubscriber struct {
 	mock.Mock
 	callback nats.MsgHandler
 }
 
-func (m *MockSubscriber) Subscribe(subj string, cb nats.MsgHandler) (*nats.Subscription, error) {
+func (m *MockSubscriber) QueueSubscribe(subj, queue string, cb nats.MsgHandler) (*nats.Subscription, error) {
 	m.callback = cb
-	args := m.Called(subj, cb)
+	args := m.Called(subj, queue, cb)
 	return nil, args.Error(1)
 }
 
@@ -34,7 +34,7 @@ loop:
 	}
 
 	mockSub := new(MockSubscriber)
-	mockSub.On("Subscribe", "ai.request.>", mock.Anything).Return(nil, nil)
+	mockSub.On("QueueSubscribe", "ai.request.>", "ai-gateway", mock.Anything).Return(nil, nil)
 
 	err := StartListener(mockSub)
 	assert.NoError(t, err)
@@ -71,7 +71,7 @@ loop:
 	}
 
 	mockSub := new(MockSubscriber)
-	mockSub.On("Subscribe", "ai.request.>", mock.Anything).Return(nil, nil)
+	mockSub.On("QueueSubscribe", "ai.request.>", "ai-gateway", mock.Anything).Return(nil, nil)
 	StartListener(mockSub)
 
 	req := AIRequest{ID: "123", Prompt: "test"}
@@ -94,7 +94,7 @@ loop:
 
 func TestStartListener_InvalidJSON(t *testing.T) {
 	mockSub := new(MockSubscriber)
-	mockSub.On("Subscribe", "ai.request.>", mock.Anything).Return(nil, nil)
+	mockSub.On("QueueSubscribe", "ai.request.>", "ai-gateway", mock.Anything).Return(nil, nil)
 
 	StartListener(mockSub)
 
@@ -122,7 +122,7 @@ func TestStartListener_QueueFull(t *testing.T) {
 	}
 
 	mockSub := new(MockSubscriber)
-	mockSub.On("Subscribe", "ai.request.>", mock.Anything).Return(nil, nil)
+	mockSub.On("QueueSubscribe", "ai.request.>", "ai-gateway", mock.Anything).Return(nil, nil)
 
 	StartListener(mockSub)
 
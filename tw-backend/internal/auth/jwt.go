@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // Claims extends jwt.RegisteredClaims with custom fields.
@@ -26,6 +28,9 @@ type Claims struct {
 type TokenManager struct {
 	signingKey    []byte
 	encryptionKey []byte
+
+	sessions *SessionManager // Refresh token state; nil disables refresh
+	revoked  *RevocationList // Tokens revoked early; nil disables revocation
 }
 
 // NewTokenManager creates a new TokenManager.
@@ -68,6 +73,7 @@ func (tm *TokenManager) GenerateToken(userID, username string, roles []string) (
 		"sub":      userID,
 		"exp":      time.Now().Add(24 * time.Hour).Unix(),
 		"iat":      time.Now().Unix(),
+		"jti":      uuid.New().String(),
 		"enc_data": base64.StdEncoding.EncodeToString(encryptedData),
 	}
 
@@ -137,11 +143,14 @@ func (tm *TokenManager) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, errors.New("invalid issued-at claim")
 	}
 
+	jti, _ := mapClaims["jti"].(string) // Absent from tokens issued before revocation
+
 	claims := &Claims{
 		UserID:   sub,
 		Username: sensitiveData.Username,
 		Roles:    sensitiveData.Roles,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			Issuer:    "mud-platform",
 			Subject:   sub,
 			ExpiresAt: jwt.NewNumericDate(time.Unix(int64(exp), 0)),
@@ -152,6 +161,32 @@ func (tm *TokenManager) ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// ValidateTokenContext validates a JWT like ValidateToken and also rejects
+// tokens that have been revoked (see Revoke).
+func (tm *TokenManager) ValidateTokenContext(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := tm.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevoked(ctx, tm.revoked, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// SetRevocationList enables token revocation
+func (tm *TokenManager) SetRevocationList(rl *RevocationList) {
+	tm.revoked = rl
+}
+
+// Revoke invalidates a token (by its ID) for the rest of its lifetime
+func (tm *TokenManager) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if tm.revoked == nil {
+		return ErrRevocationUnavailable
+	}
+	return tm.revoked.Revoke(ctx, tokenID, expiresAt)
+}
+
 func (tm *TokenManager) encrypt(plaintext []byte) ([]byte, error) {
 	block, err := aes.NewCipher(tm.encryptionKey)
 	if err != nil {
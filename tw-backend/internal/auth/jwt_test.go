@@ -1,6 +1,7 @@
 package auth_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -76,3 +77,32 @@ func TestTokenManager_EncryptionErrors(t *testing.T) {
 		// assert.Equal(t, "malformed ciphertext", err.Error())
 	})
 }
+
+func TestTokenManager_Revoke(t *testing.T) {
+	_, client := setupMiniRedis(t)
+	tm, err := auth.NewTokenManager([]byte("secret-signing-key-must-be-long-enough"),
+		[]byte("01234567890123456789012345678901"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	token, err := tm.GenerateToken("user-123", "testuser", nil)
+	require.NoError(t, err)
+	claims, err := tm.ValidateToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, claims.ID, "tokens should carry a jti")
+
+	assert.ErrorIs(t, tm.Revoke(ctx, claims.ID, claims.ExpiresAt.Time), auth.ErrRevocationUnavailable)
+
+	tm.SetRevocationList(auth.NewRevocationList(client))
+	_, err = tm.ValidateTokenContext(ctx, token)
+	require.NoError(t, err)
+
+	require.NoError(t, tm.Revoke(ctx, claims.ID, claims.ExpiresAt.Time))
+	_, err = tm.ValidateTokenContext(ctx, token)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	other, err := tm.GenerateToken("user-123", "testuser", nil)
+	require.NoError(t, err)
+	_, err = tm.ValidateTokenContext(ctx, other)
+	assert.NoError(t, err, "other tokens still validate")
+}
@@ -9,30 +9,70 @@ import (
 	"strings"
 
 	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordAlgorithm is the scheme new password hashes are made with.
+// Hashes name their own algorithm and parameters, so any supported scheme
+// can still be verified.
+type PasswordAlgorithm string
+
+const (
+	AlgorithmArgon2id PasswordAlgorithm = "argon2id" // $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+	AlgorithmBcrypt   PasswordAlgorithm = "bcrypt"   // $2a$12$<salt+hash>
 )
 
 // PasswordHasher handles password hashing and verification.
 type PasswordHasher struct {
+	algorithm PasswordAlgorithm
+
 	memory      uint32
 	iterations  uint32
 	parallelism uint8
 	saltLength  uint32
 	keyLength   uint32
+
+	bcryptCost int
 }
 
 // NewPasswordHasher creates a new PasswordHasher with recommended defaults.
 func NewPasswordHasher() *PasswordHasher {
 	return &PasswordHasher{
+		algorithm:   AlgorithmArgon2id,
 		memory:      64 * 1024, // 64MB
 		iterations:  3,
 		parallelism: 4,
 		saltLength:  16,
 		keyLength:   32,
+		bcryptCost:  12,
 	}
 }
 
-// HashPassword hashes a password using Argon2id.
+// NewPasswordHasherWithAlgorithm creates a PasswordHasher that hashes new
+// passwords with algorithm, at the recommended parameters
+func NewPasswordHasherWithAlgorithm(algorithm PasswordAlgorithm) (*PasswordHasher, error) {
+	switch algorithm {
+	case AlgorithmArgon2id, AlgorithmBcrypt:
+	default:
+		return nil, fmt.Errorf("unsupported password algorithm %q", algorithm)
+	}
+	ph := NewPasswordHasher()
+	ph.algorithm = algorithm
+	return ph, nil
+}
+
+// Algorithm returns the scheme new hashes are made with
+func (ph *PasswordHasher) Algorithm() PasswordAlgorithm {
+	return ph.algorithm
+}
+
+// HashPassword hashes a password with the hasher's algorithm.
 func (ph *PasswordHasher) HashPassword(password string) (string, error) {
+	if ph.algorithm == AlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), ph.bcryptCost)
+		return string(hash), err
+	}
+
 	salt := make([]byte, ph.saltLength)
 	if _, err := rand.Read(salt); err != nil {
 		return "", err
@@ -50,47 +90,102 @@ func (ph *PasswordHasher) HashPassword(password string) (string, error) {
 	return encodedHash, nil
 }
 
-// ComparePassword checks if a password matches a hash.
+// ComparePassword checks if a password matches a hash, whichever supported
+// algorithm made it.
 func (ph *PasswordHasher) ComparePassword(password, encodedHash string) (bool, error) {
+	if isBcryptHash(encodedHash) {
+		err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	params, salt, decodedHash, err := decodeArgon2Hash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(decodedHash)))
+
+	if subtle.ConstantTimeCompare(hash, decodedHash) == 1 {
+		return true, nil
+	}
+	return false, nil
+}
+
+// NeedsRehash reports whether a hash was made with another algorithm or
+// weaker parameters than the hasher's, so that after a successful login
+// the password should be hashed again and stored. Hashes it can't parse
+// need rehashing too.
+func (ph *PasswordHasher) NeedsRehash(encodedHash string) bool {
+	if isBcryptHash(encodedHash) {
+		if ph.algorithm != AlgorithmBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(encodedHash))
+		return err != nil || cost < ph.bcryptCost
+	}
+
+	if ph.algorithm != AlgorithmArgon2id {
+		return true
+	}
+	params, _, decodedHash, err := decodeArgon2Hash(encodedHash)
+	if err != nil {
+		return true
+	}
+	return params.memory < ph.memory ||
+		params.iterations < ph.iterations ||
+		params.parallelism != ph.parallelism ||
+		uint32(len(decodedHash)) < ph.keyLength
+}
+
+// argon2Params are the cost parameters recorded in an Argon2id hash
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// decodeArgon2Hash splits an encoded Argon2id hash into its parameters,
+// salt and key
+func decodeArgon2Hash(encodedHash string) (params argon2Params, salt, hash []byte, err error) {
 	parts := strings.Split(encodedHash, "$")
 	if len(parts) != 6 {
-		return false, errors.New("invalid hash format")
+		return params, nil, nil, errors.New("invalid hash format")
 	}
 
-	if parts[1] != "argon2id" {
-		return false, errors.New("incompatible variant")
+	if parts[1] != string(AlgorithmArgon2id) {
+		return params, nil, nil, errors.New("incompatible variant")
 	}
 
 	var version int
-	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
-	if err != nil {
-		return false, err
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, err
 	}
 	if version != argon2.Version {
-		return false, errors.New("incompatible version")
+		return params, nil, nil, errors.New("incompatible version")
 	}
 
-	var memory, iterations uint32
-	var parallelism uint8
-	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism)
-	if err != nil {
-		return false, err
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return params, nil, nil, err
 	}
 
-	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
-	if err != nil {
-		return false, err
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, err
 	}
-
-	decodedHash, err := base64.RawStdEncoding.DecodeString(parts[5])
-	if err != nil {
-		return false, err
+	if hash, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return params, nil, nil, err
 	}
+	return params, salt, hash, nil
+}
 
-	hash := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, uint32(len(decodedHash)))
-
-	if subtle.ConstantTimeCompare(hash, decodedHash) == 1 {
-		return true, nil
+// isBcryptHash reports whether a hash is in bcrypt's modular crypt format
+func isBcryptHash(encodedHash string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(encodedHash, prefix) {
+			return true
+		}
 	}
-	return false, nil
+	return false
 }
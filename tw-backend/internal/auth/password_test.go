@@ -1,13 +1,17 @@
 package auth_test
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"tw-backend/internal/auth"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestPasswordHasher_HashAndCompare(t *testing.T) {
@@ -55,3 +59,85 @@ func TestPasswordHasher_HashAndCompare(t *testing.T) {
 		}
 	})
 }
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	ph := auth.NewPasswordHasher()
+	require.Equal(t, auth.AlgorithmArgon2id, ph.Algorithm())
+
+	t.Run("bcrypt hashes still verify but are flagged under argon2id", func(t *testing.T) {
+		legacy, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		match, err := ph.ComparePassword("old-password", string(legacy))
+		require.NoError(t, err)
+		assert.True(t, match)
+
+		match, err = ph.ComparePassword("wrong-password", string(legacy))
+		require.NoError(t, err)
+		assert.False(t, match)
+
+		assert.True(t, ph.NeedsRehash(string(legacy)))
+	})
+
+	t.Run("current hashes are not flagged", func(t *testing.T) {
+		hash, err := ph.HashPassword("password")
+		require.NoError(t, err)
+		assert.False(t, ph.NeedsRehash(hash))
+	})
+
+	t.Run("weaker argon2id parameters are flagged", func(t *testing.T) {
+		weak := "$argon2id$v=19$m=16384,t=1,p=4$c2FsdHNhbHRzYWx0c2FsdA$aGFzaGhhc2hoYXNoaGFzaGhhc2hoYXNoaGFzaGhhc2g"
+		assert.True(t, ph.NeedsRehash(weak))
+	})
+
+	t.Run("bcrypt policy flags argon2id hashes", func(t *testing.T) {
+		bcryptHasher, err := auth.NewPasswordHasherWithAlgorithm(auth.AlgorithmBcrypt)
+		require.NoError(t, err)
+
+		argonHash, err := ph.HashPassword("password")
+		require.NoError(t, err)
+		assert.True(t, bcryptHasher.NeedsRehash(argonHash))
+
+		hash, err := bcryptHasher.HashPassword("password")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$2a$"))
+		assert.False(t, bcryptHasher.NeedsRehash(hash))
+
+		// Either hasher verifies the other's hashes
+		match, err := ph.ComparePassword("password", hash)
+		require.NoError(t, err)
+		assert.True(t, match)
+	})
+
+	t.Run("rejects unknown algorithms", func(t *testing.T) {
+		_, err := auth.NewPasswordHasherWithAlgorithm("md5")
+		assert.Error(t, err)
+	})
+}
+
+func TestService_LoginRehashesLegacyPassword(t *testing.T) {
+	repo := auth.NewMockRepository()
+	svc := auth.NewService(&auth.Config{SecretKey: []byte("secret"), TokenExpiration: time.Hour}, repo)
+	ctx := context.Background()
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &auth.User{
+		UserID:       uuid.New(),
+		Email:        "legacy@example.com",
+		Username:     "legacy",
+		PasswordHash: string(legacy),
+	}
+	require.NoError(t, repo.CreateUser(ctx, user))
+
+	_, _, err = svc.Login(ctx, "legacy@example.com", "old-password")
+	require.NoError(t, err)
+
+	stored, err := repo.GetUserByEmail(ctx, "legacy@example.com")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stored.PasswordHash, "$argon2id$"), "password should be rehashed on login")
+
+	// The new hash still logs the user in
+	_, _, err = svc.Login(ctx, "legacy@example.com", "old-password")
+	assert.NoError(t, err)
+}
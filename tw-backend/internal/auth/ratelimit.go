@@ -3,79 +3,113 @@ package auth
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
-// RateLimiter handles request rate limiting.
-type RateLimiter struct {
-	client *redis.Client
+// Rate limit buckets. Each has its own limit and window, so a key is
+// counted separately in every bucket.
+const (
+	BucketLogin   = "auth.login"   // Login attempts, keyed by client IP or username
+	BucketCommand = "game.command" // Gameplay commands, keyed by character
+)
+
+// RateLimit allows Limit requests in any Window
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
 }
 
-// NewRateLimiter creates a new RateLimiter.
-func NewRateLimiter(client *redis.Client) *RateLimiter {
-	return &RateLimiter{
-		client: client,
+// DefaultRateLimits returns the standard buckets: logins are held to a few a
+// minute against brute force, while gameplay allows bursts of commands
+func DefaultRateLimits() map[string]RateLimit {
+	return map[string]RateLimit{
+		BucketLogin:   {Limit: 5, Window: time.Minute},
+		BucketCommand: {Limit: 20, Window: time.Second},
 	}
 }
 
-// Allow checks if a request is allowed.
-// key: unique identifier (e.g., "ip:127.0.0.1:login")
-// limit: max requests allowed
-// window: time window
-func (rl *RateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
-	rateLimitKey := "ratelimit:" + key
+// slidingWindowScript counts a request against a sliding window log: a
+// sorted set of request times (ms, by the Redis clock so every server
+// agrees). ARGV: window ms, limit, unique request ID. Returns {1, 0} if the
+// request is allowed, or {0, ms until the oldest request leaves the window}.
+var slidingWindowScript = redis.NewScript(`
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local window = tonumber(ARGV[1])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+if redis.call("ZCARD", KEYS[1]) < tonumber(ARGV[2]) then
+	redis.call("ZADD", KEYS[1], now, ARGV[3])
+	redis.call("PEXPIRE", KEYS[1], window)
+	return {1, 0}
+end
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+return {0, tonumber(oldest[2]) + window - now}
+`)
 
-	// Increment the counter
-	count, err := rl.client.Incr(ctx, rateLimitKey).Result()
-	if err != nil {
-		return false, err
-	}
+// RateLimiter handles request rate limiting.
+type RateLimiter struct {
+	client *redis.Client
 
-	// If it's the first request, set the expiration
-	if count == 1 {
-		if err := rl.client.Expire(ctx, rateLimitKey, window).Err(); err != nil {
-			return false, err
-		}
-	}
+	buckets map[string]RateLimit
+	mu      sync.RWMutex
+}
 
-	// Check if limit exceeded
-	if count > int64(limit) {
-		return false, nil
+// NewRateLimiter creates a new RateLimiter with the DefaultRateLimits
+// buckets (see SetLimit).
+func NewRateLimiter(client *redis.Client) *RateLimiter {
+	return &RateLimiter{
+		client:  client,
+		buckets: DefaultRateLimits(),
 	}
+}
 
-	return true, nil
+// SetLimit adds a bucket or changes its limit
+func (rl *RateLimiter) SetLimit(bucket string, limit RateLimit) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.buckets[bucket] = limit
 }
 
-// AllowCommand checks if a command from a specific client is allowed
-// Implements token bucket algorithm: 10 commands per second with burst of 20
-// Complexity: O(1) Redis operations
-func (rl *RateLimiter) AllowCommand(ctx context.Context, characterID uuid.UUID) (bool, error) {
-	key := fmt.Sprintf("ratelimit:command:%s", characterID.String())
+// Limit returns a bucket's limit
+func (rl *RateLimiter) Limit(bucket string) (RateLimit, bool) {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	limit, ok := rl.buckets[bucket]
+	return limit, ok
+}
 
-	// Token bucket parameters
-	const (
-		maxTokens  = 20 // Burst capacity
-		refillRate = 10 // Tokens per second
-		window     = time.Second
-	)
+// Allow checks if a request is allowed.
+// bucket: the limit to apply (e.g., BucketLogin)
+// key: unique identifier within the bucket (e.g., "127.0.0.1")
+// When the request is refused, retryAfter is how long until the bucket
+// admits another one for the key. Requests are counted over a sliding
+// window, so there's no burst at a fixed window's edge.
+func (rl *RateLimiter) Allow(ctx context.Context, bucket, key string) (allowed bool, retryAfter time.Duration, err error) {
+	limit, ok := rl.Limit(bucket)
+	if !ok {
+		return false, 0, fmt.Errorf("rate limit: unknown bucket %q", bucket)
+	}
 
-	// Use Redis INCR with expiration as simple token bucket
-	// For more precise token bucket, would use Lua script
-	count, err := rl.client.Incr(ctx, key).Result()
+	rateLimitKey := "ratelimit:" + bucket + ":" + key
+	result, err := slidingWindowScript.Run(ctx, rl.client, []string{rateLimitKey},
+		limit.Window.Milliseconds(), limit.Limit, uuid.NewString()).Int64Slice()
 	if err != nil {
-		return false, fmt.Errorf("rate limit check failed: %w", err)
+		return false, 0, fmt.Errorf("rate limit check failed: %w", err)
 	}
-
-	// Set expiration on first increment (sliding window)
-	if count == 1 {
-		rl.client.Expire(ctx, key, window)
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("rate limit check failed: unexpected reply %v", result)
 	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
 
-	// Allow if under max tokens
-	allowed := count <= maxTokens
-
-	return allowed, nil
+// AllowCommand checks if a command from a specific client is allowed under
+// the BucketCommand limit (by default 20 commands per second)
+// Complexity: O(log N) Redis operations for N recent commands
+func (rl *RateLimiter) AllowCommand(ctx context.Context, characterID uuid.UUID) (bool, error) {
+	allowed, _, err := rl.Allow(ctx, BucketCommand, characterID.String())
+	return allowed, err
 }
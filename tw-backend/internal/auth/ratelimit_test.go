@@ -13,62 +13,103 @@ import (
 )
 
 func TestRateLimiter_Allow(t *testing.T) {
-	client := setupTestRedis(t)
-	defer client.Close()
+	mr, client := setupMiniRedis(t)
+	now := time.Now()
+	mr.SetTime(now)
 
 	rl := auth.NewRateLimiter(client)
+	rl.SetLimit("test", auth.RateLimit{Limit: 3, Window: time.Minute})
 	ctx := context.Background()
 
 	t.Run("allows requests within limit", func(t *testing.T) {
-		key := "test:allow:" + uuid.New().String()
-		limit := 5
-		window := 1 * time.Minute
-
-		for i := 0; i < limit; i++ {
-			allowed, err := rl.Allow(ctx, key, limit, window)
+		key := uuid.New().String()
+		for i := 0; i < 3; i++ {
+			allowed, retryAfter, err := rl.Allow(ctx, "test", key)
 			require.NoError(t, err)
 			assert.True(t, allowed, "request %d should be allowed", i+1)
+			assert.Zero(t, retryAfter)
 		}
 	})
 
 	t.Run("blocks requests exceeding limit", func(t *testing.T) {
-		key := "test:block:" + uuid.New().String()
-		limit := 3
-		window := 1 * time.Minute
-
-		// Consume limit
-		for i := 0; i < limit; i++ {
-			allowed, err := rl.Allow(ctx, key, limit, window)
+		key := uuid.New().String()
+		for i := 0; i < 3; i++ {
+			allowed, _, err := rl.Allow(ctx, "test", key)
 			require.NoError(t, err)
 			require.True(t, allowed)
 		}
 
-		// Exceed limit
-		allowed, err := rl.Allow(ctx, key, limit, window)
+		allowed, retryAfter, err := rl.Allow(ctx, "test", key)
 		require.NoError(t, err)
 		assert.False(t, allowed, "request exceeding limit should be blocked")
+		assert.Equal(t, time.Minute, retryAfter)
 	})
 
-	t.Run("resets after window", func(t *testing.T) {
-		key := "test:reset:" + uuid.New().String()
-		limit := 2
-		window := 1 * time.Second // Short window for test
-
-		// Consume limit
-		rl.Allow(ctx, key, limit, window)
-		rl.Allow(ctx, key, limit, window)
+	t.Run("retry after counts down to the oldest request leaving the window", func(t *testing.T) {
+		key := uuid.New().String()
+		for i := 0; i < 3; i++ {
+			mr.SetTime(now.Add(time.Duration(i) * 10 * time.Second))
+			allowed, _, err := rl.Allow(ctx, "test", key)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		}
 
-		// Verify blocked
-		allowed, err := rl.Allow(ctx, key, limit, window)
+		mr.SetTime(now.Add(45 * time.Second))
+		allowed, retryAfter, err := rl.Allow(ctx, "test", key)
 		require.NoError(t, err)
 		assert.False(t, allowed)
+		assert.Equal(t, 15*time.Second, retryAfter)
 
-		// Wait for window to expire
-		time.Sleep(1100 * time.Millisecond)
+		// The window slides: only the oldest request has left it
+		mr.SetTime(now.Add(60*time.Second + time.Millisecond))
+		allowed, _, err = rl.Allow(ctx, "test", key)
+		require.NoError(t, err)
+		assert.True(t, allowed, "request should be allowed once the oldest leaves the window")
 
-		// Should be allowed again
-		allowed, err = rl.Allow(ctx, key, limit, window)
+		allowed, retryAfter, err = rl.Allow(ctx, "test", key)
 		require.NoError(t, err)
-		assert.True(t, allowed, "request should be allowed after window reset")
+		assert.False(t, allowed)
+		assert.Equal(t, 10*time.Second-time.Millisecond, retryAfter)
+	})
+
+	t.Run("rejects unknown buckets", func(t *testing.T) {
+		_, _, err := rl.Allow(ctx, "no.such.bucket", "key")
+		assert.Error(t, err)
 	})
 }
+
+func TestRateLimiter_IndependentBuckets(t *testing.T) {
+	mr, client := setupMiniRedis(t)
+	mr.SetTime(time.Now())
+
+	rl := auth.NewRateLimiter(client)
+	ctx := context.Background()
+
+	login, ok := rl.Limit(auth.BucketLogin)
+	require.True(t, ok)
+	for i := 0; i < login.Limit; i++ {
+		allowed, _, err := rl.Allow(ctx, auth.BucketLogin, "10.0.0.1")
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+	allowed, retryAfter, err := rl.Allow(ctx, auth.BucketLogin, "10.0.0.1")
+	require.NoError(t, err)
+	assert.False(t, allowed, "login bucket should be exhausted")
+	assert.Equal(t, login.Window, retryAfter)
+
+	// Another client still gets its own allowance
+	allowed, _, err = rl.Allow(ctx, auth.BucketLogin, "10.0.0.2")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// And the same key is counted separately in another bucket
+	allowed, _, err = rl.Allow(ctx, auth.BucketCommand, "10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// Limits can be changed per bucket at runtime
+	rl.SetLimit(auth.BucketLogin, auth.RateLimit{Limit: 10, Window: time.Minute})
+	allowed, _, err = rl.Allow(ctx, auth.BucketLogin, "10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, allowed, "raised limit should admit more logins")
+}
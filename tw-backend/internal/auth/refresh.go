@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// RefreshTokenTTL is how long a refresh token stays valid if it isn't used.
+// Each rotation starts the clock again.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	// ErrInvalidRefreshToken is returned for refresh tokens that are unknown,
+	// expired or revoked
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+	// ErrRefreshTokenReused is returned when a refresh token is presented a
+	// second time. Only a stolen copy can explain it, so the token's whole
+	// family has been revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+
+	// ErrRefreshUnavailable is returned by a TokenManager with nowhere to
+	// keep refresh tokens (see SetSessionManager)
+	ErrRefreshUnavailable = errors.New("refresh tokens not enabled")
+)
+
+// refreshRecord is what a refresh token stands for. Every token rotated
+// from the same login shares a family; only the family's latest token may
+// be used.
+type refreshRecord struct {
+	FamilyID string   `json:"family_id"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+}
+
+// rotateScript replaces a family's current token. KEYS: family, new token.
+// ARGV: the presented token's hash, the new token's hash, the new token's
+// record, TTL ms. Returns 1 if rotated, 0 if the family is gone, or -1 if
+// the presented token was already rotated out, in which case the family is
+// revoked.
+var rotateScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	return -1
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[4])
+redis.call("SET", KEYS[2], ARGV[3], "PX", ARGV[4])
+return 1
+`)
+
+// SetSessionManager enables refresh tokens, whose rotation state sm keeps
+// in Redis
+func (tm *TokenManager) SetSessionManager(sm *SessionManager) {
+	tm.sessions = sm
+}
+
+// IssueRefreshToken starts a new token family for a login and returns its
+// first refresh token. The token is opaque; only its hash is stored.
+func (tm *TokenManager) IssueRefreshToken(ctx context.Context, userID, username string, roles []string) (string, error) {
+	if tm.sessions == nil {
+		return "", ErrRefreshUnavailable
+	}
+	token, err := newRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	record := refreshRecord{
+		FamilyID: uuid.New().String(),
+		UserID:   userID,
+		Username: username,
+		Roles:    roles,
+	}
+	if err := tm.sessions.createRefreshFamily(ctx, hashRefreshToken(token), record); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new
+// refresh token. The presented token is used up: presenting it again
+// revokes every token in its family and returns ErrRefreshTokenReused.
+func (tm *TokenManager) Refresh(ctx context.Context, refreshToken string) (accessToken, nextRefreshToken string, err error) {
+	if tm.sessions == nil {
+		return "", "", ErrRefreshUnavailable
+	}
+	oldHash := hashRefreshToken(refreshToken)
+	record, err := tm.sessions.loadRefreshToken(ctx, oldHash)
+	if err != nil {
+		return "", "", err
+	}
+
+	next, err := newRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+	if err := tm.sessions.rotateRefreshToken(ctx, record, oldHash, hashRefreshToken(next)); err != nil {
+		return "", "", err
+	}
+
+	accessToken, err = tm.GenerateToken(record.UserID, record.Username, record.Roles)
+	if err != nil {
+		return "", "", err
+	}
+	return accessToken, next, nil
+}
+
+// newRefreshToken returns 256 random bits, URL-safe
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashRefreshToken returns the key a refresh token is stored under, so a
+// leaked Redis dump holds no usable tokens
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// createRefreshFamily stores a family's first token
+func (sm *SessionManager) createRefreshFamily(ctx context.Context, tokenHash string, record refreshRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	// The token is useless until its family points at it
+	if err := sm.client.Set(ctx, "refresh:"+tokenHash, data, RefreshTokenTTL).Err(); err != nil {
+		return err
+	}
+	return sm.client.Set(ctx, "refresh_family:"+record.FamilyID, tokenHash, RefreshTokenTTL).Err()
+}
+
+// loadRefreshToken reads the record for a token hash
+func (sm *SessionManager) loadRefreshToken(ctx context.Context, tokenHash string) (*refreshRecord, error) {
+	data, err := sm.client.Get(ctx, "refresh:"+tokenHash).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	var record refreshRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// rotateRefreshToken makes newHash its family's current token in place of
+// oldHash. Used tokens are kept until they expire so reuse can be caught.
+func (sm *SessionManager) rotateRefreshToken(ctx context.Context, record *refreshRecord, oldHash, newHash string) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	keys := []string{"refresh_family:" + record.FamilyID, "refresh:" + newHash}
+	result, err := rotateScript.Run(ctx, sm.client, keys,
+		oldHash, newHash, data, RefreshTokenTTL.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	switch result {
+	case 0:
+		return ErrInvalidRefreshToken
+	case -1:
+		return ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RevokeRefreshFamily invalidates every refresh token descended from the
+// same login as refreshToken, e.g. on logout
+func (sm *SessionManager) RevokeRefreshFamily(ctx context.Context, refreshToken string) error {
+	record, err := sm.loadRefreshToken(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	return sm.client.Del(ctx, "refresh_family:"+record.FamilyID).Err()
+}
@@ -0,0 +1,88 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"tw-backend/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRefreshTokens(t *testing.T) *auth.TokenManager {
+	_, client := setupMiniRedis(t)
+	tm, err := auth.NewTokenManager([]byte("secret-signing-key-must-be-long-enough"),
+		[]byte("01234567890123456789012345678901"))
+	require.NoError(t, err)
+
+	sm := auth.NewSessionManager(client, auth.DefaultSessionPolicy())
+	t.Cleanup(func() { sm.Close(context.Background()) })
+	tm.SetSessionManager(sm)
+	return tm
+}
+
+func TestTokenManager_RefreshRotation(t *testing.T) {
+	tm := setupRefreshTokens(t)
+	ctx := context.Background()
+
+	refresh, err := tm.IssueRefreshToken(ctx, "user-123", "testuser", []string{"player"})
+	require.NoError(t, err)
+
+	seen := map[string]bool{refresh: true}
+	for i := 0; i < 3; i++ {
+		access, next, err := tm.Refresh(ctx, refresh)
+		require.NoError(t, err, "rotation %d", i+1)
+		assert.False(t, seen[next], "each rotation should issue a fresh refresh token")
+		seen[next] = true
+
+		claims, err := tm.ValidateToken(access)
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", claims.UserID)
+		assert.Equal(t, "testuser", claims.Username)
+		assert.Equal(t, []string{"player"}, claims.Roles)
+
+		refresh = next
+	}
+
+	t.Run("rejects unknown tokens", func(t *testing.T) {
+		_, _, err := tm.Refresh(ctx, "not-a-refresh-token")
+		assert.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+}
+
+func TestTokenManager_RefreshReuseRevokesFamily(t *testing.T) {
+	tm := setupRefreshTokens(t)
+	ctx := context.Background()
+
+	stolen, err := tm.IssueRefreshToken(ctx, "user-123", "testuser", nil)
+	require.NoError(t, err)
+
+	// The legitimate client rotates first...
+	_, current, err := tm.Refresh(ctx, stolen)
+	require.NoError(t, err)
+
+	// ...so the attacker's copy is a reuse
+	_, _, err = tm.Refresh(ctx, stolen)
+	assert.ErrorIs(t, err, auth.ErrRefreshTokenReused)
+
+	// The whole family is gone, including the legitimate client's token
+	_, _, err = tm.Refresh(ctx, current)
+	assert.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+
+	t.Run("other families are unaffected", func(t *testing.T) {
+		other, err := tm.IssueRefreshToken(ctx, "user-123", "testuser", nil)
+		require.NoError(t, err)
+		_, _, err = tm.Refresh(ctx, other)
+		assert.NoError(t, err)
+	})
+}
+
+func TestTokenManager_RefreshDisabled(t *testing.T) {
+	tm, err := auth.NewTokenManager([]byte("secret-signing-key-must-be-long-enough"),
+		[]byte("01234567890123456789012345678901"))
+	require.NoError(t, err)
+
+	_, err = tm.IssueRefreshToken(context.Background(), "user-123", "testuser", nil)
+	assert.ErrorIs(t, err, auth.ErrRefreshUnavailable)
+}
@@ -30,8 +30,10 @@ type Config struct {
 
 // Service handles authentication logic
 type Service struct {
-	config *Config
-	repo   Repository
+	config  *Config
+	repo    Repository
+	hasher  *PasswordHasher
+	revoked *RevocationList
 }
 
 // NewService creates a new auth service
@@ -39,9 +41,16 @@ func NewService(config *Config, repo Repository) *Service {
 	return &Service{
 		config: config,
 		repo:   repo,
+		hasher: NewPasswordHasher(),
 	}
 }
 
+// SetPasswordHasher changes how passwords are hashed. Users whose stored
+// hashes don't match it are rehashed as they log in.
+func (s *Service) SetPasswordHasher(hasher *PasswordHasher) {
+	s.hasher = hasher
+}
+
 // Register creates a new user account
 func (s *Service) Register(ctx context.Context, email, username, password string) (*User, error) {
 	// Check if user exists by email
@@ -58,9 +67,8 @@ func (s *Service) Register(ctx context.Context, email, username, password string
 		return nil, err
 	}
 
-	// Hash password using Argon2id
-	hasher := NewPasswordHasher()
-	hashedPassword, err := hasher.HashPassword(password)
+	// Hash password (Argon2id by default)
+	hashedPassword, err := s.hasher.HashPassword(password)
 	if err != nil {
 		return nil, apperrors.Wrap(apperrors.ErrInternalServer, "failed to hash password", err)
 	}
@@ -91,20 +99,26 @@ func (s *Service) Login(ctx context.Context, email, password string) (string, *U
 		return "", nil, ErrInvalidCredentials
 	}
 
-	// Verify password using Argon2id
-	hasher := NewPasswordHasher()
-	match, err := hasher.ComparePassword(password, user.PasswordHash)
+	// Verify password against whichever algorithm made the stored hash
+	match, err := s.hasher.ComparePassword(password, user.PasswordHash)
 	if err != nil || !match {
 		return "", nil, ErrInvalidCredentials
 	}
 
+	// Upgrade old hashes while the plaintext is at hand
+	if s.hasher.NeedsRehash(user.PasswordHash) {
+		if rehashed, err := s.hasher.HashPassword(password); err == nil {
+			user.PasswordHash = rehashed
+		}
+	}
+
 	// Generate token
 	token, err := s.GenerateToken(user.UserID, uuid.Nil)
 	if err != nil {
 		return "", nil, err
 	}
 
-	// Update last login
+	// Update last login (and any rehashed password)
 	user.LastLogin = timePtr(time.Now().UTC())
 	_ = s.repo.UpdateUser(ctx, user) // Best-effort update, login succeeds regardless
 
@@ -116,11 +130,18 @@ func (s *Service) GetUserByID(ctx context.Context, userID uuid.UUID) (*User, err
 	return s.repo.GetUserByID(ctx, userID)
 }
 
+// SetRevocationList lets tokens be revoked before they expire (see
+// Revoke)
+func (s *Service) SetRevocationList(rl *RevocationList) {
+	s.revoked = rl
+}
+
 // GenerateToken creates a new JWT token
 func (s *Service) GenerateToken(userID, characterID uuid.UUID) (string, error) {
 	claims := &Claims{
 		UserID: userID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.config.TokenExpiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -147,6 +168,27 @@ func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
 	return nil, ErrInvalidToken
 }
 
+// ValidateTokenContext validates a JWT token like ValidateToken and also
+// rejects revoked tokens
+func (s *Service) ValidateTokenContext(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevoked(ctx, s.revoked, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Revoke invalidates a token (by its ID) until expiresAt, e.g. on logout
+func (s *Service) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if s.revoked == nil {
+		return ErrRevocationUnavailable
+	}
+	return s.revoked.Revoke(ctx, tokenID, expiresAt)
+}
+
 // GenerateSecretKey generates a random secret key
 func GenerateSecretKey() ([]byte, error) {
 	key := make([]byte, 32)
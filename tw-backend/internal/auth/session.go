@@ -20,11 +20,63 @@ type Session struct {
 	LastAccess time.Time `json:"last_access"`
 }
 
+// ErrSessionNotFound is returned for sessions that don't exist or have expired
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionPolicy controls how long sessions live. A session expires at the
+// earliest of its TTL, its idle timeout and its max lifetime.
+type SessionPolicy struct {
+	TTL         time.Duration // Lifetime from login, or from the last touch when Sliding
+	Sliding     bool          // Each validated request (see Touch) extends the session by TTL
+	IdleTimeout time.Duration // Sessions untouched this long expire (0 = no limit)
+	MaxLifetime time.Duration // Sessions expire this long after login, however active (0 = no limit)
+}
+
+// DefaultSessionPolicy keeps active sessions alive a day at a time, for at
+// most a week
+func DefaultSessionPolicy() SessionPolicy {
+	return SessionPolicy{
+		TTL:         24 * time.Hour,
+		Sliding:     true,
+		MaxLifetime: 7 * 24 * time.Hour,
+	}
+}
+
+// remaining returns how long a session that logged in at login has left
+// after being touched at now
+func (p SessionPolicy) remaining(login, now time.Time) time.Duration {
+	expires := login.Add(p.TTL)
+	if p.Sliding {
+		expires = now.Add(p.TTL)
+	}
+	if idle := now.Add(p.IdleTimeout); p.IdleTimeout > 0 && idle.Before(expires) {
+		expires = idle
+	}
+	if limit := login.Add(p.MaxLifetime); p.MaxLifetime > 0 && limit.Before(expires) {
+		expires = limit
+	}
+	return expires.Sub(now)
+}
+
+// extendScript pushes a session's expiry out to ARGV[1] milliseconds from
+// now, never pulling it in, so concurrent touches can't shorten each
+// other's extension. Returns 0 if the session is gone.
+var extendScript = redis.NewScript(`
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl == -2 then
+	return 0
+end
+if ttl >= 0 and ttl < tonumber(ARGV[1]) then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return 1
+`)
+
 // SessionManager handles session storage in Redis.
 // Implements batch updates to reduce Redis write frequency
 type SessionManager struct {
 	client *redis.Client
-	ttl    time.Duration
+	policy SessionPolicy
 
 	// In-memory cache for LastAccess times
 	// Flushed to Redis periodically to reduce write ops
@@ -37,12 +89,16 @@ type SessionManager struct {
 	flushDone     chan struct{}
 }
 
-// NewSessionManager creates a new SessionManager.
+// NewSessionManager creates a new SessionManager whose sessions expire
+// according to policy.
 // Starts background goroutine for periodic session flush
-func NewSessionManager(client *redis.Client) *SessionManager {
+func NewSessionManager(client *redis.Client, policy SessionPolicy) *SessionManager {
+	if policy.TTL <= 0 {
+		policy.TTL = DefaultSessionPolicy().TTL
+	}
 	sm := &SessionManager{
 		client:          client,
-		ttl:             24 * time.Hour,
+		policy:          policy,
 		lastAccessCache: make(map[string]time.Time),
 		flushInterval:   5 * time.Minute,
 		stopFlush:       make(chan struct{}),
@@ -74,21 +130,43 @@ func (sm *SessionManager) CreateSession(ctx context.Context, userID, username st
 	}
 
 	key := "session:" + sessionID
-	if err := sm.client.Set(ctx, key, data, sm.ttl).Err(); err != nil {
+	if err := sm.client.Set(ctx, key, data, sm.policy.remaining(now, now)).Err(); err != nil {
 		return nil, err
 	}
 
 	return session, nil
 }
 
-// GetSession retrieves a session by ID and extends its TTL.
+// GetSession retrieves a session by ID and touches it (see Touch).
 // LastAccess is tracked in-memory and flushed periodically
 func (sm *SessionManager) GetSession(ctx context.Context, sessionID string) (*Session, error) {
-	key := "session:" + sessionID
-	data, err := sm.client.Get(ctx, key).Bytes()
+	session, err := sm.loadSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := sm.touch(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Touch records activity on a session, restarting its idle timeout and,
+// under a sliding policy, extending it by the TTL (never past its max
+// lifetime). Concurrent touches only ever lengthen the session.
+func (sm *SessionManager) Touch(ctx context.Context, sessionID string) error {
+	session, err := sm.loadSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	return sm.touch(ctx, session)
+}
+
+// loadSession reads a session from Redis
+func (sm *SessionManager) loadSession(ctx context.Context, sessionID string) (*Session, error) {
+	data, err := sm.client.Get(ctx, "session:"+sessionID).Bytes()
 	if err != nil {
 		if err == redis.Nil {
-			return nil, errors.New("session not found")
+			return nil, ErrSessionNotFound
 		}
 		return nil, err
 	}
@@ -97,15 +175,36 @@ func (sm *SessionManager) GetSession(ctx context.Context, sessionID string) (*Se
 	if err := json.Unmarshal(data, &session); err != nil {
 		return nil, err
 	}
+	return &session, nil
+}
 
-	// Update LastAccess in memory only (batched to Redis later)
+// touch extends a loaded session's expiry under the policy and records the
+// access
+func (sm *SessionManager) touch(ctx context.Context, session *Session) error {
 	now := time.Now().UTC()
+	key := "session:" + session.ID
+
+	remaining := sm.policy.remaining(session.LoginTime, now)
+	if remaining <= 0 {
+		// Past its max lifetime: Redis may not have caught up yet
+		sm.InvalidateSession(ctx, session.ID)
+		return ErrSessionNotFound
+	}
+	found, err := extendScript.Run(ctx, sm.client, []string{key}, remaining.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if found == 0 {
+		return ErrSessionNotFound
+	}
+
+	// Update LastAccess in memory only (batched to Redis later)
 	sm.cacheMu.Lock()
-	sm.lastAccessCache[sessionID] = now
+	sm.lastAccessCache[session.ID] = now
 	sm.cacheMu.Unlock()
 
 	session.LastAccess = now
-	return &session, nil
+	return nil
 }
 
 // InvalidateSession removes a session.
@@ -193,8 +292,9 @@ func (sm *SessionManager) flushSessions(ctx context.Context) error {
 			continue
 		}
 
-		// Write back with extended TTL
-		if err := sm.client.Set(ctx, key, updatedData, sm.ttl).Err(); err != nil {
+		// Write back, leaving the expiry to the policy (see touch); XX so a
+		// session that expired since the read stays gone
+		if err := sm.client.SetXX(ctx, key, updatedData, redis.KeepTTL).Err(); err != nil {
 			return err
 		}
 	}
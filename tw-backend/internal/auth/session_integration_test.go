@@ -56,7 +56,7 @@ func TestSessionManager_BatchUpdates(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create SessionManager with short flush interval for testing
-	sm := auth.NewSessionManager(client)
+	sm := auth.NewSessionManager(client, auth.DefaultSessionPolicy())
 	defer sm.Close(ctx)
 
 	t.Run("CreateAndGetSession", func(t *testing.T) {
@@ -2,7 +2,9 @@ package auth_test
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"tw-backend/internal/auth"
 
@@ -14,7 +16,7 @@ func TestSessionManager_Lifecycle(t *testing.T) {
 	client := setupTestRedis(t)
 	defer client.Close()
 
-	sm := auth.NewSessionManager(client)
+	sm := auth.NewSessionManager(client, auth.DefaultSessionPolicy())
 	ctx := context.Background()
 
 	t.Run("creates, retrieves, and invalidates session", func(t *testing.T) {
@@ -42,3 +44,98 @@ func TestSessionManager_Lifecycle(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSessionManager_SlidingExpiration(t *testing.T) {
+	mr, client := setupMiniRedis(t)
+	ctx := context.Background()
+
+	sm := auth.NewSessionManager(client, auth.SessionPolicy{
+		TTL:         time.Hour,
+		Sliding:     true,
+		IdleTimeout: 10 * time.Minute,
+	})
+	defer sm.Close(ctx)
+
+	session, err := sm.CreateSession(ctx, "user-sliding", "slider")
+	require.NoError(t, err)
+
+	t.Run("survives with activity", func(t *testing.T) {
+		// Half an hour of requests every five minutes, past the idle timeout
+		for i := 0; i < 6; i++ {
+			mr.FastForward(5 * time.Minute)
+			require.NoError(t, sm.Touch(ctx, session.ID), "touch %d", i+1)
+		}
+		_, err := sm.GetSession(ctx, session.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("dies after idle timeout", func(t *testing.T) {
+		mr.FastForward(11 * time.Minute)
+		_, err := sm.GetSession(ctx, session.ID)
+		assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+		assert.ErrorIs(t, sm.Touch(ctx, session.ID), auth.ErrSessionNotFound)
+	})
+}
+
+func TestSessionManager_FixedExpiration(t *testing.T) {
+	mr, client := setupMiniRedis(t)
+	ctx := context.Background()
+
+	sm := auth.NewSessionManager(client, auth.SessionPolicy{TTL: 15 * time.Minute})
+	defer sm.Close(ctx)
+
+	session, err := sm.CreateSession(ctx, "user-fixed", "fixed")
+	require.NoError(t, err)
+
+	// Activity doesn't extend a session without sliding expiration
+	for i := 0; i < 3; i++ {
+		mr.FastForward(4 * time.Minute)
+		require.NoError(t, sm.Touch(ctx, session.ID))
+	}
+	mr.FastForward(4 * time.Minute)
+	_, err = sm.GetSession(ctx, session.ID)
+	assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+}
+
+func TestSessionManager_MaxLifetime(t *testing.T) {
+	mr, client := setupMiniRedis(t)
+	ctx := context.Background()
+
+	sm := auth.NewSessionManager(client, auth.SessionPolicy{
+		TTL:         time.Hour,
+		Sliding:     true,
+		MaxLifetime: 10 * time.Minute,
+	})
+	defer sm.Close(ctx)
+
+	session, err := sm.CreateSession(ctx, "user-capped", "capped")
+	require.NoError(t, err)
+	require.NoError(t, sm.Touch(ctx, session.ID))
+
+	assert.LessOrEqual(t, mr.TTL("session:"+session.ID), 10*time.Minute, "sliding must not extend past the max lifetime")
+}
+
+func TestSessionManager_ConcurrentTouches(t *testing.T) {
+	mr, client := setupMiniRedis(t)
+	ctx := context.Background()
+
+	sm := auth.NewSessionManager(client, auth.SessionPolicy{TTL: 30 * time.Minute, Sliding: true})
+	defer sm.Close(ctx)
+
+	session, err := sm.CreateSession(ctx, "user-busy", "busy")
+	require.NoError(t, err)
+	mr.FastForward(20 * time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, sm.Touch(ctx, session.ID))
+		}()
+	}
+	wg.Wait()
+
+	// Every touch extended the session; none cut another's extension short
+	assert.Greater(t, mr.TTL("session:"+session.ID), 29*time.Minute)
+}
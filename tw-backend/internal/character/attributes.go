@@ -1,5 +1,11 @@
 package character
 
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
 // CalculateSecondaryAttributes derives secondary stats from primary attributes
 func CalculateSecondaryAttributes(attrs Attributes) SecondaryAttributes {
 	return SecondaryAttributes{
@@ -10,3 +16,101 @@ func CalculateSecondaryAttributes(attrs Attributes) SecondaryAttributes {
 		MaxNerve:   (attrs.Willpower * 5) + (attrs.Presence * 3) + (attrs.Reflexes * 2),
 	}
 }
+
+// RecalculateSecondaryAttributes re-derives the secondary stats from the
+// character's current base attributes. Call it whenever BaseAttrs change.
+func (c *Character) RecalculateSecondaryAttributes() {
+	c.SecAttrs = CalculateSecondaryAttributes(c.BaseAttrs)
+}
+
+// BaseAttribute returns the named base attribute's value
+func (c *Character) BaseAttribute(attr string) (int, bool) {
+	if field := c.baseAttributeField(attr); field != nil {
+		return *field, true
+	}
+	return 0, false
+}
+
+// SetBaseAttribute changes a base attribute (leveling, equipment, mutation),
+// recalculates the secondary attributes, and returns the event to persist.
+// Use ModifyBaseAttribute to make the change and save it in one step.
+func (c *Character) SetBaseAttribute(attr string, value int, reason string) (AttributeModifiedEvent, error) {
+	field := c.baseAttributeField(attr)
+	if field == nil {
+		return AttributeModifiedEvent{}, fmt.Errorf("unknown attribute: %s", attr)
+	}
+
+	event := AttributeModifiedEvent{
+		CharacterID: c.ID,
+		Attribute:   attr,
+		OldValue:    *field,
+		NewValue:    value,
+		Reason:      reason,
+		Timestamp:   time.Now(),
+	}
+	*field = value
+	c.RecalculateSecondaryAttributes()
+	c.UpdatedAt = event.Timestamp
+	return event, nil
+}
+
+// ModifyBaseAttribute changes a character's base attribute and saves the
+// AttributeModifiedEvent through repo, so the change survives a reload. If
+// the save fails the character is left as it was.
+func ModifyBaseAttribute(ctx context.Context, repo CharacterRepository, char *Character, attr string, value int, reason string) error {
+	updatedAt := char.UpdatedAt
+	event, err := char.SetBaseAttribute(attr, value, reason)
+	if err != nil {
+		return err
+	}
+	if err := repo.Save(ctx, char, []interface{}{event}); err != nil {
+		*char.baseAttributeField(attr) = event.OldValue
+		char.RecalculateSecondaryAttributes()
+		char.UpdatedAt = updatedAt
+		return fmt.Errorf("save %s change: %w", attr, err)
+	}
+	return nil
+}
+
+// baseAttributeField maps an attribute name to its field, or nil if unknown
+func (c *Character) baseAttributeField(attr string) *int {
+	return c.BaseAttrs.Field(attr)
+}
+
+// Field maps an attribute name to its field, or nil if unknown
+func (a *Attributes) Field(attr string) *int {
+	switch attr {
+	case AttrMight:
+		return &a.Might
+	case AttrAgility:
+		return &a.Agility
+	case AttrEndurance:
+		return &a.Endurance
+	case AttrReflexes:
+		return &a.Reflexes
+	case AttrVitality:
+		return &a.Vitality
+	case AttrIntellect:
+		return &a.Intellect
+	case AttrCunning:
+		return &a.Cunning
+	case AttrWillpower:
+		return &a.Willpower
+	case AttrPresence:
+		return &a.Presence
+	case AttrIntuition:
+		return &a.Intuition
+	case AttrSight:
+		return &a.Sight
+	case AttrHearing:
+		return &a.Hearing
+	case AttrSmell:
+		return &a.Smell
+	case AttrTaste:
+		return &a.Taste
+	case AttrTouch:
+		return &a.Touch
+	default:
+		return nil
+	}
+}
@@ -1,9 +1,16 @@
 package character
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
+	"tw-backend/internal/eventstore"
+
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCalculateSecondaryAttributes(t *testing.T) {
@@ -68,3 +75,79 @@ func TestCalculateSecondaryAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestSetBaseAttribute_RecalculatesSecondary(t *testing.T) {
+	char := &Character{ID: uuid.New(), BaseAttrs: Attributes{Vitality: 50, Endurance: 40, Might: 30}}
+	char.RecalculateSecondaryAttributes()
+	assert.Equal(t, 500, char.SecAttrs.MaxHP)
+
+	event, err := char.SetBaseAttribute(AttrVitality, 60, "level up")
+	assert.NoError(t, err)
+	assert.Equal(t, 600, char.SecAttrs.MaxHP, "MaxHP follows Vitality")
+	assert.Equal(t, (40*7)+(30*3), char.SecAttrs.MaxStamina, "Unrelated stats are unchanged")
+
+	assert.Equal(t, char.ID, event.CharacterID)
+	assert.Equal(t, AttrVitality, event.Attribute)
+	assert.Equal(t, 50, event.OldValue)
+	assert.Equal(t, 60, event.NewValue)
+	assert.Equal(t, "level up", event.Reason)
+
+	_, err = char.SetBaseAttribute(AttrMight, 45, "mutation")
+	assert.NoError(t, err)
+	assert.Equal(t, (40*7)+(45*3), char.SecAttrs.MaxStamina, "MaxStamina follows Might")
+}
+
+func TestSetBaseAttribute_UnknownAttribute(t *testing.T) {
+	char := &Character{BaseAttrs: Attributes{Vitality: 50}}
+	_, err := char.SetBaseAttribute("Charm", 90, "potion")
+	assert.Error(t, err)
+
+	_, ok := char.BaseAttribute("Charm")
+	assert.False(t, ok)
+	vitality, ok := char.BaseAttribute(AttrVitality)
+	assert.True(t, ok)
+	assert.Equal(t, 50, vitality)
+}
+
+func TestModifyBaseAttribute_SavesTheEvent(t *testing.T) {
+	mockStore := new(MockEventStore)
+	repo := NewCharacterRepository(mockStore)
+	ctx := context.Background()
+	char := &Character{ID: uuid.New(), Version: 3, BaseAttrs: Attributes{Vitality: 50}}
+
+	var saved eventstore.Event
+	mockStore.On("AppendEvent", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(eventstore.Event)
+	}).Return(nil)
+
+	assert.NoError(t, ModifyBaseAttribute(ctx, repo, char, AttrVitality, 60, "level up"))
+	assert.Equal(t, 600, char.SecAttrs.MaxHP)
+	assert.Equal(t, int64(4), char.Version)
+
+	assert.Equal(t, eventstore.EventType(EventTypeAttributeModified), saved.EventType)
+	assert.Equal(t, char.ID.String(), saved.AggregateID)
+	var event AttributeModifiedEvent
+	assert.NoError(t, json.Unmarshal(saved.Payload, &event))
+	assert.Equal(t, AttrVitality, event.Attribute)
+	assert.Equal(t, 50, event.OldValue)
+	assert.Equal(t, 60, event.NewValue)
+	assert.Equal(t, "level up", event.Reason)
+}
+
+func TestModifyBaseAttribute_FailedSaveLeavesCharacter(t *testing.T) {
+	mockStore := new(MockEventStore)
+	repo := NewCharacterRepository(mockStore)
+	ctx := context.Background()
+	char := &Character{ID: uuid.New(), BaseAttrs: Attributes{Vitality: 50}}
+	char.RecalculateSecondaryAttributes()
+
+	mockStore.On("AppendEvent", ctx, mock.Anything).Return(errors.New("store down"))
+
+	assert.Error(t, ModifyBaseAttribute(ctx, repo, char, AttrVitality, 60, "level up"))
+	assert.Equal(t, 50, char.BaseAttrs.Vitality)
+	assert.Equal(t, 500, char.SecAttrs.MaxHP)
+	assert.Zero(t, char.Version)
+
+	assert.Error(t, ModifyBaseAttribute(ctx, repo, char, "Charm", 90, "potion"))
+	mockStore.AssertNumberOfCalls(t, "AppendEvent", 1)
+}
@@ -19,16 +19,29 @@ type CharacterRepository interface {
 	Load(ctx context.Context, id uuid.UUID) (*Character, error)
 }
 
+// DefaultSnapshotInterval is how many events a character accumulates between
+// snapshots, bounding how many Load has to replay
+const DefaultSnapshotInterval = 100
+
 type eventSourcedRepository struct {
-	store eventstore.EventStore
+	store            eventstore.EventStore
+	replay           *eventstore.PostgresReplayEngine
+	snapshotInterval int64
 }
 
 // NewCharacterRepository creates a new event-sourced repository
 func NewCharacterRepository(store eventstore.EventStore) CharacterRepository {
-	return &eventSourcedRepository{store: store}
+	return &eventSourcedRepository{
+		store:            store,
+		replay:           eventstore.NewPostgresReplayEngine(store),
+		snapshotInterval: DefaultSnapshotInterval,
+	}
 }
 
+// Save appends newEvents after char.Version, advancing it. char must already
+// reflect newEvents, since it is what gets snapshotted.
 func (r *eventSourcedRepository) Save(ctx context.Context, char *Character, newEvents []interface{}) error {
+	startVersion := char.Version
 	for _, e := range newEvents {
 		// Determine event type and marshal payload
 		var eventType eventstore.EventType
@@ -58,7 +71,7 @@ func (r *eventSourcedRepository) Save(ctx context.Context, char *Character, newE
 			EventType:     eventType,
 			AggregateID:   char.ID.String(),
 			AggregateType: "Character",
-			Version:       0, // TODO: Handle optimistic concurrency
+			Version:       char.Version + 1,
 			Timestamp:     time.Now(),
 			Payload:       json.RawMessage(payload),
 		}
@@ -66,27 +79,54 @@ func (r *eventSourcedRepository) Save(ctx context.Context, char *Character, newE
 		if err := r.store.AppendEvent(ctx, event); err != nil {
 			return err
 		}
+		char.Version = event.Version
+	}
+
+	if r.snapshotInterval > 0 && char.Version/r.snapshotInterval > startVersion/r.snapshotInterval {
+		// Best effort: without a snapshot Load just replays more events
+		_ = r.saveSnapshot(ctx, char)
 	}
 	return nil
 }
 
-func (r *eventSourcedRepository) Load(ctx context.Context, id uuid.UUID) (*Character, error) {
-	events, err := r.store.GetEventsByAggregate(ctx, id.String(), 0)
+func (r *eventSourcedRepository) saveSnapshot(ctx context.Context, char *Character) error {
+	payload, err := json.Marshal(char)
 	if err != nil {
-		return nil, err
-	}
-
-	if len(events) == 0 {
-		return nil, apperrors.NewNotFound("character not found: %s", id)
+		return err
 	}
+	return r.store.SaveSnapshot(ctx, eventstore.Snapshot{
+		AggregateID:   char.ID.String(),
+		AggregateType: "Character",
+		Version:       char.Version,
+		Payload:       payload,
+		Timestamp:     time.Now(),
+	})
+}
 
+// Load rebuilds a character from its latest snapshot and the events after it
+func (r *eventSourcedRepository) Load(ctx context.Context, id uuid.UUID) (*Character, error) {
 	char := &Character{ID: id}
-	for _, event := range events {
-		if err := r.applyEvent(char, event); err != nil {
-			return nil, err
+	version, err := r.replay.LoadAggregate(ctx, id.String(), func(snapshot *eventstore.Snapshot, events []eventstore.Event) error {
+		if snapshot == nil && len(events) == 0 {
+			return apperrors.NewNotFound("character not found: %s", id)
+		}
+		if snapshot != nil {
+			if err := json.Unmarshal(snapshot.Payload, char); err != nil {
+				return fmt.Errorf("failed to restore character snapshot: %w", err)
+			}
+		}
+		for _, event := range events {
+			if err := r.applyEvent(char, event); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	char.Version = version
 	return char, nil
 }
 
@@ -109,7 +149,7 @@ func (r *eventSourcedRepository) applyEvent(char *Character, event eventstore.Ev
 		char.Name = e.Name
 		char.Species = e.Species
 		char.BaseAttrs = e.FinalAttributes // Use final attributes as base for simplicity in current model
-		char.SecAttrs = CalculateSecondaryAttributes(char.BaseAttrs)
+		char.RecalculateSecondaryAttributes()
 		char.CreatedAt = e.Timestamp
 		char.UpdatedAt = e.Timestamp
 
@@ -131,49 +171,12 @@ func (r *eventSourcedRepository) applyEvent(char *Character, event eventstore.Ev
 		if err := json.Unmarshal(event.Payload, &e); err != nil {
 			return err
 		}
-		// Apply modification logic
-		// This requires mapping string attribute names to struct fields
-		// We can use a helper or reflection, or a big switch
-		r.applyAttributeModification(char, e.Attribute, e.NewValue)
+		if field := char.baseAttributeField(e.Attribute); field != nil {
+			*field = e.NewValue
+		}
+		char.RecalculateSecondaryAttributes()
 		char.UpdatedAt = e.Timestamp
 	}
 
 	return nil
 }
-
-func (r *eventSourcedRepository) applyAttributeModification(char *Character, attr string, value int) {
-	switch attr {
-	case AttrMight:
-		char.BaseAttrs.Might = value
-	case AttrAgility:
-		char.BaseAttrs.Agility = value
-	case AttrEndurance:
-		char.BaseAttrs.Endurance = value
-	case AttrReflexes:
-		char.BaseAttrs.Reflexes = value
-	case AttrVitality:
-		char.BaseAttrs.Vitality = value
-	case AttrIntellect:
-		char.BaseAttrs.Intellect = value
-	case AttrCunning:
-		char.BaseAttrs.Cunning = value
-	case AttrWillpower:
-		char.BaseAttrs.Willpower = value
-	case AttrPresence:
-		char.BaseAttrs.Presence = value
-	case AttrIntuition:
-		char.BaseAttrs.Intuition = value
-	case AttrSight:
-		char.BaseAttrs.Sight = value
-	case AttrHearing:
-		char.BaseAttrs.Hearing = value
-	case AttrSmell:
-		char.BaseAttrs.Smell = value
-	case AttrTaste:
-		char.BaseAttrs.Taste = value
-	case AttrTouch:
-		char.BaseAttrs.Touch = value
-	}
-	// Recalculate secondary attributes
-	char.SecAttrs = CalculateSecondaryAttributes(char.BaseAttrs)
-}
@@ -23,6 +23,16 @@ func (m *MockEventStore) AppendEvent(ctx context.Context, event eventstore.Event
 	return args.Error(0)
 }
 
+func (m *MockEventStore) AppendEventExpectingVersion(ctx context.Context, event eventstore.Event, expectedVersion int) error {
+	args := m.Called(ctx, event, expectedVersion)
+	return args.Error(0)
+}
+
+func (m *MockEventStore) AppendEvents(ctx context.Context, events []eventstore.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
 func (m *MockEventStore) GetEventsByAggregate(ctx context.Context, aggregateID string, fromVersion int64) ([]eventstore.Event, error) {
 	args := m.Called(ctx, aggregateID, fromVersion)
 	return args.Get(0).([]eventstore.Event), args.Error(1)
@@ -38,6 +48,23 @@ func (m *MockEventStore) GetAllEvents(ctx context.Context, fromTimestamp time.Ti
 	return args.Get(0).([]eventstore.Event), args.Error(1)
 }
 
+func (m *MockEventStore) SaveSnapshot(ctx context.Context, snapshot eventstore.Snapshot) error {
+	args := m.Called(ctx, snapshot)
+	return args.Error(0)
+}
+
+func (m *MockEventStore) GetLatestSnapshot(ctx context.Context, aggregateID string) (*eventstore.Snapshot, error) {
+	args := m.Called(ctx, aggregateID)
+	snapshot, _ := args.Get(0).(*eventstore.Snapshot)
+	return snapshot, args.Error(1)
+}
+
+func (m *MockEventStore) Subscribe(ctx context.Context, fromPosition int64) (<-chan eventstore.Event, error) {
+	args := m.Called(ctx, fromPosition)
+	ch, _ := args.Get(0).(<-chan eventstore.Event)
+	return ch, args.Error(1)
+}
+
 func TestRepository_Save(t *testing.T) {
 	mockStore := new(MockEventStore)
 	repo := NewCharacterRepository(mockStore)
@@ -88,6 +115,7 @@ func TestRepository_Load(t *testing.T) {
 		},
 	}
 
+	mockStore.On("GetLatestSnapshot", ctx, charID.String()).Return(nil, nil)
 	mockStore.On("GetEventsByAggregate", ctx, charID.String(), int64(0)).Return(storedEvents, nil)
 
 	char, err := repo.Load(ctx, charID)
@@ -148,6 +176,7 @@ func TestRepository_Load_AttributeModified(t *testing.T) {
 		{EventType: eventstore.EventType(EventTypeAttributeModified), Payload: json.RawMessage(modData)},
 	}
 
+	mockStore.On("GetLatestSnapshot", ctx, charID.String()).Return(nil, nil)
 	mockStore.On("GetEventsByAggregate", ctx, charID.String(), int64(0)).Return(storedEvents, nil)
 
 	char, err := repo.Load(ctx, charID)
@@ -174,6 +203,7 @@ func TestRepository_Load_Inhabitance(t *testing.T) {
 		{EventType: eventstore.EventType(EventTypeCharacterCreatedViaInhabitance), Payload: json.RawMessage(data)},
 	}
 
+	mockStore.On("GetLatestSnapshot", ctx, charID.String()).Return(nil, nil)
 	mockStore.On("GetEventsByAggregate", ctx, charID.String(), int64(0)).Return(storedEvents, nil)
 
 	char, err := repo.Load(ctx, charID)
@@ -181,3 +211,68 @@ func TestRepository_Load_Inhabitance(t *testing.T) {
 	assert.Equal(t, "Inhabited NPC", char.Name)
 	mockStore.AssertExpectations(t)
 }
+
+func TestRepository_Load_FromSnapshot(t *testing.T) {
+	mockStore := new(MockEventStore)
+	repo := NewCharacterRepository(mockStore)
+	ctx := context.Background()
+
+	charID := uuid.New()
+	snapshotData, _ := json.Marshal(&Character{ID: charID, Name: "Snapshotted Hero", BaseAttrs: Attributes{Might: 40}})
+	snapshot := &eventstore.Snapshot{AggregateID: charID.String(), AggregateType: "Character", Version: 100, Payload: snapshotData}
+
+	modData, _ := json.Marshal(AttributeModifiedEvent{CharacterID: charID, Attribute: AttrMight, NewValue: 45})
+	tail := []eventstore.Event{
+		{EventType: eventstore.EventType(EventTypeAttributeModified), Version: 101, Payload: json.RawMessage(modData)},
+	}
+
+	mockStore.On("GetLatestSnapshot", ctx, charID.String()).Return(snapshot, nil)
+	mockStore.On("GetEventsByAggregate", ctx, charID.String(), int64(101)).Return(tail, nil)
+
+	char, err := repo.Load(ctx, charID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Snapshotted Hero", char.Name)
+	assert.Equal(t, 45, char.BaseAttrs.Might, "Events after the snapshot are applied")
+	assert.Equal(t, int64(101), char.Version)
+	mockStore.AssertExpectations(t)
+}
+
+func TestRepository_Load_NotFound(t *testing.T) {
+	mockStore := new(MockEventStore)
+	repo := NewCharacterRepository(mockStore)
+	ctx := context.Background()
+
+	charID := uuid.New()
+	mockStore.On("GetLatestSnapshot", ctx, charID.String()).Return(nil, nil)
+	mockStore.On("GetEventsByAggregate", ctx, charID.String(), int64(0)).Return([]eventstore.Event{}, nil)
+
+	_, err := repo.Load(ctx, charID)
+	assert.Error(t, err)
+}
+
+func TestRepository_Save_VersionsAndSnapshots(t *testing.T) {
+	mockStore := new(MockEventStore)
+	repo := NewCharacterRepository(mockStore).(*eventSourcedRepository)
+	repo.snapshotInterval = 2
+	ctx := context.Background()
+
+	charID := uuid.New()
+	char := &Character{ID: charID, Name: "Versioned Hero", Version: 1}
+
+	var versions []int64
+	mockStore.On("AppendEvent", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		versions = append(versions, args.Get(1).(eventstore.Event).Version)
+	}).Return(nil)
+	mockStore.On("SaveSnapshot", ctx, mock.MatchedBy(func(s eventstore.Snapshot) bool {
+		return s.AggregateID == charID.String() && s.Version == 3
+	})).Return(nil).Once()
+
+	err := repo.Save(ctx, char, []interface{}{
+		AttributeModifiedEvent{CharacterID: charID, Attribute: AttrMight, NewValue: 10},
+		AttributeModifiedEvent{CharacterID: charID, Attribute: AttrMight, NewValue: 11},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, versions)
+	assert.Equal(t, int64(3), char.Version)
+	mockStore.AssertExpectations(t)
+}
@@ -102,6 +102,7 @@ type Character struct {
 	PositionZ float64             `json:"position_z"`
 	CreatedAt time.Time           `json:"created_at"`
 	UpdatedAt time.Time           `json:"updated_at"`
+	Version   int64               `json:"-"` // Version of the last event applied
 }
 
 // SpeciesTemplate defines the baseline attributes for a species
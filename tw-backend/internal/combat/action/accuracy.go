@@ -0,0 +1,53 @@
+package action
+
+import (
+	"tw-backend/internal/combat/config"
+)
+
+// HitOutcome describes whether a resolved attack connected
+type HitOutcome string
+
+const (
+	OutcomeHit  HitOutcome = "hit"
+	OutcomeMiss HitOutcome = "miss" // Attack failed to connect; CombatAction.Reaction tells a dodge apart
+)
+
+// HitChanceFunc returns the probability (0.0-1.0) that an attack from
+// attacker connects with target. target may be nil for entities that have
+// not joined combat.
+type HitChanceFunc func(attacker, target *Combatant) float64
+
+// NewHitChanceFunc returns the standard to-hit formula using cfg's coefficients:
+//
+//	chance = base + (attacker accuracy - target evasion) * perPoint
+//
+// where accuracy = Accuracy + Agility and evasion = Evasion + Agility,
+// clamped to [MinHitChance, MaxHitChance].
+func NewHitChanceFunc(cfg *config.CombatConfig) HitChanceFunc {
+	return func(attacker, target *Combatant) float64 {
+		accuracy := attacker.Accuracy + attacker.Agility
+		evasion := 0
+		if target != nil {
+			evasion = target.Evasion + target.Agility
+		}
+
+		chance := cfg.GetBaseHitChance() + float64(accuracy-evasion)*cfg.GetHitChancePerPoint()
+		if chance < cfg.GetMinHitChance() {
+			chance = cfg.GetMinHitChance()
+		}
+		if chance > cfg.GetMaxHitChance() {
+			chance = cfg.GetMaxHitChance()
+		}
+		return chance
+	}
+}
+
+// rollToHit resolves an attack's outcome against the resolver's hit chance formula
+func (cr *CombatResolver) rollToHit(attacker, target *Combatant) HitOutcome {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	if cr.rng.Float64() < cr.hitChance(attacker, target) {
+		return OutcomeHit
+	}
+	return OutcomeMiss
+}
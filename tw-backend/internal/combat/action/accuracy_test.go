@@ -0,0 +1,119 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"tw-backend/internal/combat/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHitChance_ClampedAndScaled(t *testing.T) {
+	hitChance := NewHitChanceFunc(config.Default())
+
+	even := hitChance(&Combatant{Agility: 50}, &Combatant{Agility: 50})
+	assert.InDelta(t, 0.85, even, 0.0001, "Evenly matched combatants use the base hit chance")
+
+	weak := hitChance(&Combatant{Agility: 10}, &Combatant{Agility: 90, Evasion: 80})
+	assert.InDelta(t, 0.05, weak, 0.0001, "Hit chance should clamp to the configured minimum")
+
+	strong := hitChance(&Combatant{Agility: 90, Accuracy: 80}, &Combatant{Agility: 10})
+	assert.InDelta(t, 0.95, strong, 0.0001, "Hit chance should clamp to the configured maximum")
+
+	assert.InDelta(t, 0.95, hitChance(&Combatant{Agility: 50}, nil), 0.0001, "A target outside combat has no evasion")
+}
+
+func TestHitChance_CustomFormula(t *testing.T) {
+	cfg := config.Default()
+	cfg.BaseHitChance = 0.5
+	cfg.HitChancePerPoint = 0.01
+
+	hitChance := NewHitChanceFunc(cfg)
+	assert.InDelta(t, 0.6, hitChance(&Combatant{Agility: 60}, &Combatant{Agility: 50}), 0.0001)
+}
+
+func TestProcessTick_LowAccuracyMissesAtExpectedRate(t *testing.T) {
+	resolver := NewCombatResolver()
+	resolver.SetSeed(42)
+	now := time.Now()
+
+	attackerID := uuid.New()
+	targetID := uuid.New()
+	attacker := &Combatant{
+		EntityID:       attackerID,
+		CurrentStamina: 1_000_000,
+		MaxStamina:     1_000_000,
+		CurrentHP:      100,
+		MaxHP:          100,
+		Agility:        20,
+		CombatState:    StateInCombat,
+	}
+	target := &Combatant{
+		EntityID:    targetID,
+		CurrentHP:   100,
+		MaxHP:       100,
+		Agility:     60,
+		Evasion:     40,
+		CombatState: StateInCombat,
+	}
+	resolver.AddCombatant(attacker)
+	resolver.AddCombatant(target)
+
+	expected := NewHitChanceFunc(config.Default())(attacker, target) // 0.85 - 80*0.005 = 0.45
+
+	const attacks = 2000
+	misses := 0
+	for i := 0; i < attacks; i++ {
+		resolver.Queue.Enqueue(&CombatAction{
+			ActionID:   uuid.New(),
+			ActorID:    attackerID,
+			TargetID:   targetID,
+			ActionType: ActionAttack,
+			ExecuteAt:  now.Add(-time.Millisecond),
+		})
+		resolved := resolver.ProcessTick(now)
+		require.Len(t, resolved, 1)
+		if resolved[0].Outcome == OutcomeMiss {
+			misses++
+		}
+	}
+
+	assert.InDelta(t, 1-expected, float64(misses)/attacks, 0.04, "Miss rate should track the to-hit formula")
+}
+
+func TestProcessTick_MissStillCostsStaminaAndAdvances(t *testing.T) {
+	resolver := NewCombatResolver()
+	resolver.SetHitChanceFunc(func(attacker, target *Combatant) float64 { return 0 })
+	now := time.Now()
+
+	attackerID := uuid.New()
+	attacker := &Combatant{
+		EntityID:       attackerID,
+		CurrentStamina: 100,
+		MaxStamina:     100,
+		CurrentHP:      100,
+		MaxHP:          100,
+		LastActionTime: now.Add(-time.Second),
+		CombatState:    StateInCombat,
+	}
+	resolver.AddCombatant(attacker)
+
+	resolver.Queue.Enqueue(&CombatAction{
+		ActionID:   uuid.New(),
+		ActorID:    attackerID,
+		TargetID:   uuid.New(),
+		ActionType: ActionAttack,
+		ExecuteAt:  now.Add(-time.Millisecond),
+	})
+
+	resolved := resolver.ProcessTick(now)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, OutcomeMiss, resolved[0].Outcome)
+	assert.True(t, resolved[0].Resolved, "A miss still resolves the action")
+	assert.Equal(t, 100-StaminaCostNormalAttack, attacker.CurrentStamina, "A miss still costs stamina")
+	assert.Equal(t, now, attacker.LastActionTime, "A miss still advances the combatant's round")
+	assert.Equal(t, 0, resolver.Queue.Len())
+}
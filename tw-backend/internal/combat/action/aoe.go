@@ -0,0 +1,108 @@
+package action
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"tw-backend/internal/combat/damage"
+
+	"github.com/google/uuid"
+)
+
+// AOEHit is one target's share of an area-of-effect attack
+type AOEHit struct {
+	TargetID uuid.UUID
+	Distance float64             // From the area's center
+	Falloff  float64             // Damage multiplier for Distance: 1 at the center, AOEEdgeDamage at the radius
+	Damage   damage.DamageResult // After falloff and the target's armor
+}
+
+// AOEFalloff returns the damage multiplier at distance from the center of an
+// area with the given radius: linear from 1 at the center to edgeDamage at
+// the radius, and 0 beyond it.
+func AOEFalloff(distance, radius, edgeDamage float64) float64 {
+	if distance > radius {
+		return 0
+	}
+	if radius <= 0 {
+		return 1
+	}
+	return 1 - (1-edgeDamage)*distance/radius
+}
+
+// resolveAOE damages every eligible combatant within act's area. Area attacks
+// don't roll to hit; each target rolls its own damage against its own armor,
+// which loses durability when damaged. The attacker's weapon wears once per
+// attack that damages anyone; a nil or broken weapon deals unarmed damage.
+func (cr *CombatResolver) resolveAOE(attacker *Combatant, act *CombatAction) []AOEHit {
+	area := act.AOE
+	targets := cr.aoeTargets(attacker, area)
+
+	var hits []AOEHit
+	for _, target := range targets {
+		distance := area.distanceTo(target)
+		falloff := AOEFalloff(distance, area.Radius, cr.aoeEdgeDamage)
+
+		result := damage.CalculateEquippedDamage(attacker.Attributes, attacker.Weapon, 0, attacker.Bonuses, target.Armor, target.Bonuses, cr.damageRoll(), false)
+		result.RawDamage = int(float64(result.RawDamage) * falloff)
+		result.FinalDamage = int(float64(result.FinalDamage) * falloff)
+		result.Blocked = result.RawDamage - result.FinalDamage
+
+		if result.FinalDamage > 0 {
+			target.CurrentHP = max(target.CurrentHP-result.FinalDamage, 0)
+			if target.Armor != nil {
+				damage.ReduceArmorDurability(target.Armor, 1)
+			}
+		}
+		hits = append(hits, AOEHit{TargetID: target.EntityID, Distance: distance, Falloff: falloff, Damage: result})
+	}
+
+	damaged := slices.ContainsFunc(hits, func(h AOEHit) bool { return h.Damage.FinalDamage > 0 })
+	if attacker.Weapon != nil && !attacker.Weapon.IsBroken() && damaged {
+		act.WeaponBroke = damage.ReduceDurability(attacker.Weapon, 1).Broke
+	}
+	return hits
+}
+
+// aoeTargets lists the living combatants within area that attacker may hit,
+// nearest first (ties by ID) so seeded resolvers roll in a stable order
+func (cr *CombatResolver) aoeTargets(attacker *Combatant, area *AreaOfEffect) []*Combatant {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	var targets []*Combatant
+	for _, c := range cr.Combatants {
+		if c.EntityID == attacker.EntityID || c.CurrentHP <= 0 {
+			continue
+		}
+		if !area.FriendlyFire && attacker.Team != "" && c.Team == attacker.Team {
+			continue
+		}
+		if area.distanceTo(c) > area.Radius {
+			continue
+		}
+		targets = append(targets, c)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		di, dj := area.distanceTo(targets[i]), area.distanceTo(targets[j])
+		if di != dj {
+			return di < dj
+		}
+		return targets[i].EntityID.String() < targets[j].EntityID.String()
+	})
+	return targets
+}
+
+// distanceTo returns how far c stands from the area's center
+func (a *AreaOfEffect) distanceTo(c *Combatant) float64 {
+	return math.Hypot(c.PositionX-a.CenterX, c.PositionY-a.CenterY)
+}
+
+// damageRoll returns a 1-100 damage roll from the resolver's RNG
+func (cr *CombatResolver) damageRoll() int {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	return cr.rng.Intn(100) + 1
+}
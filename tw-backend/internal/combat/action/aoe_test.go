@@ -0,0 +1,157 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"tw-backend/internal/character"
+	"tw-backend/internal/combat/damage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aoeCombatant is a healthy, unarmored combatant standing at (x, y)
+func aoeCombatant(x, y float64, team string) *Combatant {
+	return &Combatant{
+		EntityID:       uuid.New(),
+		CurrentStamina: 100,
+		MaxStamina:     100,
+		CurrentHP:      1000,
+		MaxHP:          1000,
+		Agility:        50,
+		CombatState:    StateInCombat,
+		PositionX:      x,
+		PositionY:      y,
+		Team:           team,
+		Attributes:     character.Attributes{Might: 50},
+	}
+}
+
+// castAOE resolves one area attack by attacker over area
+func castAOE(t *testing.T, resolver *CombatResolver, attacker *Combatant, area AreaOfEffect) *CombatAction {
+	t.Helper()
+	now := time.Now()
+	resolver.Queue.Enqueue(&CombatAction{
+		ActionID:   uuid.New(),
+		ActorID:    attacker.EntityID,
+		ActionType: ActionAttackAOE,
+		ExecuteAt:  now.Add(-time.Millisecond),
+		AOE:        &area,
+	})
+	resolved := resolver.ProcessTick(now)
+	require.Len(t, resolved, 1)
+	return resolved[0]
+}
+
+func TestAOEFalloff(t *testing.T) {
+	assert.InDelta(t, 1.0, AOEFalloff(0, 10, 0.5), 0.0001, "Full damage at the center")
+	assert.InDelta(t, 0.75, AOEFalloff(5, 10, 0.5), 0.0001, "Halfway out is halfway between center and edge")
+	assert.InDelta(t, 0.5, AOEFalloff(10, 10, 0.5), 0.0001, "Edge damage at the radius")
+	assert.Zero(t, AOEFalloff(10.01, 10, 0.5), "Nothing beyond the radius")
+	assert.InDelta(t, 1.0, AOEFalloff(0, 0, 0.5), 0.0001, "A zero radius only hits its center")
+}
+
+func TestProcessTick_AOEEdgeTakesHalfOfCenterDamage(t *testing.T) {
+	// Same seed, same roll: only the target's distance differs
+	damageAt := func(distance float64) int {
+		resolver := NewCombatResolver()
+		resolver.SetSeed(7)
+		attacker := aoeCombatant(-20, 0, "")
+		attacker.Weapon = &damage.Weapon{Name: "maul", Type: damage.WeaponBludgeoning, BaseDamage: 40, Durability: 100, MaxDurability: 100}
+		target := aoeCombatant(distance, 0, "")
+		resolver.AddCombatant(attacker)
+		resolver.AddCombatant(target)
+
+		act := castAOE(t, resolver, attacker, AreaOfEffect{Radius: 10})
+		require.Len(t, act.Hits, 1)
+		assert.Equal(t, OutcomeHit, act.Outcome)
+		assert.Equal(t, target.MaxHP-act.Hits[0].Damage.FinalDamage, target.CurrentHP)
+		return act.Hits[0].Damage.FinalDamage
+	}
+
+	center, edge := damageAt(0), damageAt(10)
+	require.Positive(t, center)
+	assert.InDelta(t, float64(center)/2, float64(edge), 1, "Edge damage should be half of center damage")
+}
+
+func TestProcessTick_AOEWithNoTargetsInRange(t *testing.T) {
+	resolver := NewCombatResolver()
+	attacker := aoeCombatant(0, 0, "")
+	bystander := aoeCombatant(15, 15, "")
+	resolver.AddCombatant(attacker)
+	resolver.AddCombatant(bystander)
+
+	act := castAOE(t, resolver, attacker, AreaOfEffect{Radius: 5})
+	assert.Empty(t, act.Hits)
+	assert.Equal(t, OutcomeMiss, act.Outcome)
+	assert.Equal(t, bystander.MaxHP, bystander.CurrentHP)
+	assert.Equal(t, attacker.MaxHP, attacker.CurrentHP, "The attacker never hits themselves")
+}
+
+func TestProcessTick_AOEFriendlyFire(t *testing.T) {
+	for _, friendlyFire := range []bool{false, true} {
+		resolver := NewCombatResolver()
+		resolver.SetSeed(11)
+		attacker := aoeCombatant(0, 0, "red")
+		ally := aoeCombatant(1, 0, "red")
+		enemy := aoeCombatant(2, 0, "blue")
+		for _, c := range []*Combatant{attacker, ally, enemy} {
+			resolver.AddCombatant(c)
+		}
+
+		act := castAOE(t, resolver, attacker, AreaOfEffect{Radius: 5, FriendlyFire: friendlyFire})
+		var hit []uuid.UUID
+		for _, h := range act.Hits {
+			hit = append(hit, h.TargetID)
+		}
+
+		if friendlyFire {
+			assert.Equal(t, []uuid.UUID{ally.EntityID, enemy.EntityID}, hit, "Friendly fire hits allies too, nearest first")
+		} else {
+			assert.Equal(t, []uuid.UUID{enemy.EntityID}, hit, "Allies are spared without friendly fire")
+			assert.Equal(t, ally.MaxHP, ally.CurrentHP)
+		}
+	}
+}
+
+func TestProcessTick_AOEWearsArmorAndWeapon(t *testing.T) {
+	resolver := NewCombatResolver()
+	resolver.SetSeed(3)
+	attacker := aoeCombatant(0, 0, "")
+	attacker.Weapon = &damage.Weapon{Name: "flail", Type: damage.WeaponBludgeoning, BaseDamage: 40, Durability: 50, MaxDurability: 50}
+	targets := []*Combatant{aoeCombatant(1, 0, ""), aoeCombatant(0, 2, "")}
+	resolver.AddCombatant(attacker)
+	for _, target := range targets {
+		target.Armor = &damage.Armor{Name: "hauberk", Type: damage.ArmorChainMail, Durability: 30, MaxDurability: 30}
+		resolver.AddCombatant(target)
+	}
+
+	act := castAOE(t, resolver, attacker, AreaOfEffect{Radius: 5})
+	require.Len(t, act.Hits, 2)
+	for i, target := range targets {
+		require.Positive(t, act.Hits[i].Damage.FinalDamage)
+		assert.Equal(t, 29, target.Armor.Durability, "Each damaged target's armor wears")
+	}
+	assert.Equal(t, 49, attacker.Weapon.Durability, "The weapon wears once per attack, not per target")
+}
+
+func TestProcessTick_AOEReportsWeaponBreaking(t *testing.T) {
+	resolver := NewCombatResolver()
+	resolver.SetSeed(3)
+	attacker := aoeCombatant(0, 0, "")
+	attacker.Weapon = &damage.Weapon{WeaponID: uuid.New(), Name: "cracked maul", Type: damage.WeaponBludgeoning, BaseDamage: 40, Durability: 1, MaxDurability: 50}
+	resolver.AddCombatant(attacker)
+	resolver.AddCombatant(aoeCombatant(1, 0, ""))
+
+	act := castAOE(t, resolver, attacker, AreaOfEffect{Radius: 5})
+	require.NotNil(t, act.WeaponBroke, "The attack that wears the weapon to zero should report it")
+	assert.Equal(t, attacker.Weapon.WeaponID, act.WeaponBroke.WeaponID)
+	assert.True(t, attacker.Weapon.IsBroken())
+
+	act = castAOE(t, resolver, attacker, AreaOfEffect{Radius: 5})
+	assert.Nil(t, act.WeaponBroke)
+	require.Len(t, act.Hits, 1)
+	assert.Positive(t, act.Hits[0].Damage.FinalDamage, "A broken weapon still hits unarmed")
+}
@@ -0,0 +1,60 @@
+package action
+
+import (
+	"tw-backend/internal/combat/config"
+
+	"github.com/google/uuid"
+)
+
+// DefenseProfile lets a combatant react to attacks that would hit it
+type DefenseProfile struct {
+	DodgeChancePerAgility float64 // Dodge chance per point of Agility
+	MaxDodgeChance        float64 // Upper bound on dodge chance
+	StaminaCost           int     // Spent per dodge; a defender with less can't dodge
+}
+
+// NewDefenseProfile returns a defense profile using cfg's dodge settings
+func NewDefenseProfile(cfg *config.CombatConfig) *DefenseProfile {
+	return &DefenseProfile{
+		DodgeChancePerAgility: cfg.GetDodgeChancePerAgility(),
+		MaxDodgeChance:        cfg.GetMaxDodgeChance(),
+		StaminaCost:           cfg.GetDodgeStaminaCost(),
+	}
+}
+
+// DodgeChance returns the probability (0.0-1.0) that defender dodges a hit
+func (p *DefenseProfile) DodgeChance(defender *Combatant) float64 {
+	chance := float64(defender.Agility) * p.DodgeChancePerAgility
+	return max(0, min(chance, p.MaxDodgeChance, 1))
+}
+
+// ReactionResult records a defender's attempt to dodge an attack
+type ReactionResult struct {
+	DefenderID   uuid.UUID
+	Chance       float64 // Dodge chance rolled against
+	Dodged       bool    // The hit was converted to a miss
+	StaminaSpent int
+}
+
+// rollReaction gives target a chance to dodge a hit. It returns nil when the
+// target can't react: no DefenseProfile, or too little stamina to dodge.
+func (cr *CombatResolver) rollReaction(target *Combatant) *ReactionResult {
+	if target == nil || target.Defense == nil || target.CurrentHP <= 0 {
+		return nil
+	}
+	profile := target.Defense
+	if target.CurrentStamina < profile.StaminaCost {
+		return nil
+	}
+
+	result := &ReactionResult{DefenderID: target.EntityID, Chance: profile.DodgeChance(target)}
+	cr.mu.Lock()
+	result.Dodged = cr.rng.Float64() < result.Chance
+	cr.mu.Unlock()
+
+	if result.Dodged {
+		target.CurrentStamina -= profile.StaminaCost
+		result.StaminaSpent = profile.StaminaCost
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"tw-backend/internal/combat/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attackDefender resolves attacks against a defender with the given profile
+// and stamina, with every attack rolling a hit before the defender reacts
+func attackDefender(t *testing.T, profile *DefenseProfile, stamina, attacks int) (*Combatant, []*CombatAction) {
+	t.Helper()
+	resolver := NewCombatResolver()
+	resolver.SetSeed(42)
+	resolver.SetHitChanceFunc(func(attacker, target *Combatant) float64 { return 1 })
+
+	now := time.Now()
+	attacker := &Combatant{EntityID: uuid.New(), CurrentStamina: 10000, MaxStamina: 10000, CurrentHP: 100, MaxHP: 100, Agility: 50}
+	defender := &Combatant{
+		EntityID:       uuid.New(),
+		CurrentStamina: stamina,
+		MaxStamina:     100,
+		CurrentHP:      100,
+		MaxHP:          100,
+		Agility:        100,
+		Defense:        profile,
+	}
+	resolver.AddCombatant(attacker)
+	resolver.AddCombatant(defender)
+
+	var resolved []*CombatAction
+	for i := 0; i < attacks; i++ {
+		resolver.Queue.Enqueue(&CombatAction{
+			ActionID:   uuid.New(),
+			ActorID:    attacker.EntityID,
+			TargetID:   defender.EntityID,
+			ActionType: ActionAttack,
+			ExecuteAt:  now.Add(-time.Millisecond),
+		})
+		tick := resolver.ProcessTick(now)
+		require.Len(t, tick, 1)
+		resolved = append(resolved, tick[0])
+	}
+	return defender, resolved
+}
+
+func TestDefenseProfile_DodgeChance(t *testing.T) {
+	profile := NewDefenseProfile(config.Default())
+	assert.InDelta(t, 0.15, profile.DodgeChance(&Combatant{Agility: 50}), 0.0001)
+	assert.InDelta(t, 0.3, profile.DodgeChance(&Combatant{Agility: 150}), 0.0001, "Dodge chance should clamp to the configured maximum")
+}
+
+func TestProcessTick_ZeroDodgeChanceNeverDodges(t *testing.T) {
+	profile := &DefenseProfile{DodgeChancePerAgility: 0, MaxDodgeChance: 1, StaminaCost: 5}
+	defender, resolved := attackDefender(t, profile, 100, 50)
+
+	for _, act := range resolved {
+		assert.Equal(t, OutcomeHit, act.Outcome)
+		require.NotNil(t, act.Reaction, "A defender with stamina should get to react")
+		assert.False(t, act.Reaction.Dodged)
+		assert.Zero(t, act.Reaction.StaminaSpent)
+	}
+	assert.Equal(t, 100, defender.CurrentStamina, "Failed dodges cost no stamina")
+}
+
+func TestProcessTick_FullDodgeChanceAlwaysDodges(t *testing.T) {
+	profile := &DefenseProfile{DodgeChancePerAgility: 0.01, MaxDodgeChance: 1, StaminaCost: 5}
+	defender, resolved := attackDefender(t, profile, 100, 10)
+
+	for _, act := range resolved {
+		assert.Equal(t, OutcomeMiss, act.Outcome, "A dodged hit becomes a miss")
+		require.NotNil(t, act.Reaction)
+		assert.True(t, act.Reaction.Dodged)
+		assert.Equal(t, defender.EntityID, act.Reaction.DefenderID)
+		assert.Equal(t, 5, act.Reaction.StaminaSpent)
+	}
+	assert.Equal(t, 50, defender.CurrentStamina, "Each dodge spends stamina")
+}
+
+func TestProcessTick_ExhaustedDefenderCantDodge(t *testing.T) {
+	profile := &DefenseProfile{DodgeChancePerAgility: 0.01, MaxDodgeChance: 1, StaminaCost: 5}
+
+	// Stamina for two dodges; the rest of the attacks land
+	defender, resolved := attackDefender(t, profile, 12, 4)
+
+	for i, act := range resolved {
+		if i < 2 {
+			assert.Equal(t, OutcomeMiss, act.Outcome)
+			continue
+		}
+		assert.Equal(t, OutcomeHit, act.Outcome, "attack %d should land on an exhausted defender", i)
+		assert.Nil(t, act.Reaction)
+	}
+	assert.Equal(t, 2, defender.CurrentStamina)
+}
+
+func TestProcessTick_DodgeRollIsSeeded(t *testing.T) {
+	profile := &DefenseProfile{DodgeChancePerAgility: 0.005, MaxDodgeChance: 1, StaminaCost: 0}
+	outcomes := func() []HitOutcome {
+		_, resolved := attackDefender(t, profile, 100, 40)
+		var out []HitOutcome
+		for _, act := range resolved {
+			out = append(out, act.Outcome)
+		}
+		return out
+	}
+
+	first := outcomes()
+	assert.Contains(t, first, OutcomeHit)
+	assert.Contains(t, first, OutcomeMiss)
+	assert.Equal(t, first, outcomes(), "The same seed should dodge the same attacks")
+}
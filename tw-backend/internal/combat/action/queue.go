@@ -3,6 +3,8 @@ package action
 import (
 	"container/heap"
 	"sync"
+
+	"github.com/google/uuid"
 )
 
 // ActionHeap implements heap.Interface for CombatAction
@@ -12,6 +14,29 @@ func (h ActionHeap) Len() int           { return len(h) }
 func (h ActionHeap) Less(i, j int) bool { return h[i].ExecuteAt.Before(h[j].ExecuteAt) }
 func (h ActionHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
 
+// tieBrokenHeap orders actions by ExecuteAt, then by the tie breaker, then by
+// enqueue order, so simultaneous actions always resolve the same way
+type tieBrokenHeap struct {
+	*ActionHeap
+	tieBreaker TieBreaker
+}
+
+func (h tieBrokenHeap) Less(i, j int) bool {
+	a, b := (*h.ActionHeap)[i], (*h.ActionHeap)[j]
+	if !a.ExecuteAt.Equal(b.ExecuteAt) {
+		return a.ExecuteAt.Before(b.ExecuteAt)
+	}
+	if h.tieBreaker != nil {
+		if h.tieBreaker.Before(a, b) {
+			return true
+		}
+		if h.tieBreaker.Before(b, a) {
+			return false
+		}
+	}
+	return a.sequence < b.sequence
+}
+
 func (h *ActionHeap) Push(x interface{}) {
 	action, _ := x.(*CombatAction) // Type assertion guaranteed by heap.Push caller
 	*h = append(*h, action)
@@ -25,26 +50,51 @@ func (h *ActionHeap) Pop() interface{} {
 	return item
 }
 
-// CombatQueue manages the priority queue of actions
+// CombatQueue manages the priority queue of actions. Actions executing at the
+// same instant resolve by the queue's TieBreaker, then in enqueue order.
 type CombatQueue struct {
-	actions ActionHeap
-	mu      sync.RWMutex
+	actions    ActionHeap
+	tieBreaker TieBreaker
+	enqueued   uint64
+	mu         sync.RWMutex
 }
 
-// NewCombatQueue creates a new queue
+// NewCombatQueue creates a new queue that resolves ties in enqueue order
 func NewCombatQueue() *CombatQueue {
+	return NewCombatQueueWithTieBreaker(nil)
+}
+
+// NewCombatQueueWithTieBreaker creates a queue that resolves ties with tb
+func NewCombatQueueWithTieBreaker(tb TieBreaker) *CombatQueue {
 	q := &CombatQueue{
-		actions: make(ActionHeap, 0),
+		actions:    make(ActionHeap, 0),
+		tieBreaker: tb,
 	}
-	heap.Init(&q.actions)
+	heap.Init(q.heap())
 	return q
 }
 
+// heap returns the queue's actions ordered with its tie breaker
+func (q *CombatQueue) heap() heap.Interface {
+	return tieBrokenHeap{ActionHeap: &q.actions, tieBreaker: q.tieBreaker}
+}
+
+// SetTieBreaker changes how simultaneous actions are ordered, re-sorting any
+// already queued. Nil restores enqueue order.
+func (q *CombatQueue) SetTieBreaker(tb TieBreaker) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tieBreaker = tb
+	heap.Init(q.heap())
+}
+
 // Enqueue adds an action to the queue
 func (q *CombatQueue) Enqueue(action *CombatAction) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	heap.Push(&q.actions, action)
+	q.enqueued++
+	action.sequence = q.enqueued
+	heap.Push(q.heap(), action)
 }
 
 // Dequeue removes and returns the next action
@@ -54,7 +104,7 @@ func (q *CombatQueue) Dequeue() *CombatAction {
 	if len(q.actions) == 0 {
 		return nil
 	}
-	action, _ := heap.Pop(&q.actions).(*CombatAction) // Type assertion guaranteed by heap implementation
+	action, _ := heap.Pop(q.heap()).(*CombatAction) // Type assertion guaranteed by heap implementation
 	return action
 }
 
@@ -68,6 +118,23 @@ func (q *CombatQueue) Peek() *CombatAction {
 	return q.actions[0]
 }
 
+// RemoveActor drops every queued action belonging to actorID and returns how many were removed
+func (q *CombatQueue) RemoveActor(actorID uuid.UUID) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	kept := q.actions[:0]
+	for _, action := range q.actions {
+		if action.ActorID != actorID {
+			kept = append(kept, action)
+		}
+	}
+	removed := len(q.actions) - len(kept)
+	q.actions = kept
+	heap.Init(q.heap())
+	return removed
+}
+
 // Len returns the number of actions in the queue
 func (q *CombatQueue) Len() int {
 	q.mu.RLock()
@@ -153,3 +153,25 @@ func TestCombatQueue_MultiCombatantScenario(t *testing.T) {
 		t.Error("Second action should execute before third")
 	}
 }
+
+func TestCombatQueue_RemoveActor(t *testing.T) {
+	q := NewCombatQueue()
+	leaver := uuid.New()
+	stayer := uuid.New()
+	targetID := uuid.New()
+
+	q.Enqueue(NewCombatAction(leaver, targetID, ActionAttack, 100*time.Millisecond))
+	kept := NewCombatAction(stayer, targetID, ActionAttack, 200*time.Millisecond)
+	q.Enqueue(kept)
+	q.Enqueue(NewCombatAction(leaver, targetID, ActionDefend, 300*time.Millisecond))
+
+	if removed := q.RemoveActor(leaver); removed != 2 {
+		t.Errorf("Expected 2 actions removed, got %d", removed)
+	}
+	if q.Len() != 1 {
+		t.Fatalf("Expected 1 action left, got %d", q.Len())
+	}
+	if next := q.Dequeue(); next != kept {
+		t.Errorf("Expected the other actor's action to remain")
+	}
+}
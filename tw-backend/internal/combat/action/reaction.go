@@ -2,9 +2,12 @@ package action
 
 import (
 	"time"
+
+	"tw-backend/internal/combat/config"
 )
 
-// Base reaction times
+// Base reaction times used by CalculateReactionTime. The live game's
+// rule-set, config.Default, is slower.
 const (
 	BaseTimeQuickAttack  = 800 * time.Millisecond
 	BaseTimeNormalAttack = 1000 * time.Millisecond
@@ -24,46 +27,121 @@ const (
 	AttackHeavy  AttackType = "heavy"
 )
 
-// CalculateReactionTime determines the delay before an action executes
-func CalculateReactionTime(actionType ActionType, attackVariant AttackType, agility int, modifiers float64) time.Duration {
-	var base time.Duration
+// ReactionModifiers carries the status effects that change a reaction time
+type ReactionModifiers struct {
+	// Speed scales the reaction time, e.g. 1.5 for Slow, 0.7 for Haste.
+	// Zero means no effect.
+	Speed float64
+	// StunRemaining is added on top: a stunned actor waits out the stun
+	StunRemaining time.Duration
+}
+
+// StatusReactionModifiers combines a combatant's active slow, haste and stun
+// effects, and any encumbrance, into reaction modifiers
+func StatusReactionModifiers(combatant *Combatant, now time.Time) ReactionModifiers {
+	mods := ReactionModifiers{Speed: 1.0}
+	if combatant.Encumbrance > 1 {
+		mods.Speed *= combatant.Encumbrance
+	}
+	for _, effect := range combatant.StatusEffects {
+		if !now.Before(effect.ExpiresAt) {
+			continue
+		}
+		switch effect.EffectType {
+		case EffectSlow, EffectHaste:
+			if effect.Magnitude > 0 {
+				mods.Speed *= effect.Magnitude
+			}
+		case EffectStun:
+			if remaining := effect.ExpiresAt.Sub(now); remaining > mods.StunRemaining {
+				mods.StunRemaining = remaining
+			}
+		}
+	}
+	return mods
+}
+
+// ReactionModel computes reaction times from a combat config's rule-set
+type ReactionModel struct {
+	cfg *config.CombatConfig
+}
 
+// NewReactionModel returns a reaction model reading cfg's reaction times
+func NewReactionModel(cfg *config.CombatConfig) *ReactionModel {
+	return &ReactionModel{cfg: cfg}
+}
+
+// legacyReactionModel is CalculateReactionTime's rule-set, from the constants above
+var legacyReactionModel = func() *ReactionModel {
+	cfg := config.Default()
+	cfg.Reaction = config.ReactionTimes{
+		QuickAttackMs:  int(BaseTimeQuickAttack / time.Millisecond),
+		NormalAttackMs: int(BaseTimeNormalAttack / time.Millisecond),
+		HeavyAttackMs:  int(BaseTimeHeavyAttack / time.Millisecond),
+		DefendMs:       int(BaseTimeDefend / time.Millisecond),
+		FleeMs:         int(BaseTimeFlee / time.Millisecond),
+		UseItemMs:      int(BaseTimeUseItem / time.Millisecond),
+		MinimumMs:      int(MinReactionTime / time.Millisecond),
+		AgilityScaling: 0.3,
+	}
+	return NewReactionModel(cfg)
+}()
+
+// BaseTime returns the reaction time of an action before agility and effects
+func (m *ReactionModel) BaseTime(actionType ActionType, attackVariant AttackType) time.Duration {
+	times := m.cfg.GetReaction()
+
+	var ms int
 	switch actionType {
 	case ActionAttack:
 		switch attackVariant {
 		case AttackQuick:
-			base = BaseTimeQuickAttack
+			ms = times.QuickAttackMs
 		case AttackHeavy:
-			base = BaseTimeHeavyAttack
+			ms = times.HeavyAttackMs
 		default:
-			base = BaseTimeNormalAttack
+			ms = times.NormalAttackMs
 		}
 	case ActionDefend:
-		base = BaseTimeDefend
+		ms = times.DefendMs
 	case ActionFlee:
-		base = BaseTimeFlee
+		ms = times.FleeMs
 	case ActionUseItem:
-		base = BaseTimeUseItem
+		ms = times.UseItemMs
 	default:
-		base = BaseTimeNormalAttack
+		ms = times.NormalAttackMs
 	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// ReactionTime determines the delay before an action executes:
+//
+//	max(base * (1 - Agility/100 * scaling) * speed, minimum) + stun remaining
+func (m *ReactionModel) ReactionTime(actionType ActionType, attackVariant AttackType, agility int, mods ReactionModifiers) time.Duration {
+	times := m.cfg.GetReaction()
+	base := m.BaseTime(actionType, attackVariant)
 
-	// Agility modifier: final = base * (1 - (Agility / 100) * 0.3)
-	// Max reduction is 30% at 100 Agility
-	agilityFactor := (float64(agility) / 100.0) * 0.3
-	multiplier := 1.0 - agilityFactor
+	// With the default scaling of 0.5, the max reduction is 50% at 100 Agility
+	multiplier := 1.0 - (float64(agility)/100.0)*times.AgilityScaling
 
-	// Apply status effect modifiers (passed as float, e.g., 1.5 for Slow, 0.7 for Haste)
-	// If modifiers is 0 (no effect), treat as 1.0
-	if modifiers == 0 {
-		modifiers = 1.0
+	speed := mods.Speed
+	if speed == 0 {
+		speed = 1.0
 	}
 
-	finalDuration := time.Duration(float64(base) * multiplier * modifiers)
+	finalDuration := time.Duration(float64(base) * multiplier * speed)
 
-	if finalDuration < MinReactionTime {
-		return MinReactionTime
+	minimum := time.Duration(times.MinimumMs) * time.Millisecond
+	if finalDuration < minimum {
+		finalDuration = minimum
 	}
 
-	return finalDuration
+	return finalDuration + mods.StunRemaining
+}
+
+// CalculateReactionTime determines the delay before an action executes using
+// the base times above, 30% faster at 100 Agility. modifiers is the status effect speed multiplier
+// (e.g. 1.5 for Slow, 0.7 for Haste); 0 means no effect.
+func CalculateReactionTime(actionType ActionType, attackVariant AttackType, agility int, modifiers float64) time.Duration {
+	return legacyReactionModel.ReactionTime(actionType, attackVariant, agility, ReactionModifiers{Speed: modifiers})
 }
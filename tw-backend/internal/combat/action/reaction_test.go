@@ -3,6 +3,8 @@ package action
 import (
 	"testing"
 	"time"
+
+	"tw-backend/internal/combat/config"
 )
 
 func TestCalculateReactionTime(t *testing.T) {
@@ -102,3 +104,82 @@ func TestCalculateReactionTime(t *testing.T) {
 		})
 	}
 }
+
+func TestReactionModel_HigherAgilityIsFaster(t *testing.T) {
+	model := NewReactionModel(config.Default())
+
+	previous := model.ReactionTime(ActionAttack, AttackNormal, 0, ReactionModifiers{})
+	for agility := 10; agility <= 100; agility += 10 {
+		got := model.ReactionTime(ActionAttack, AttackNormal, agility, ReactionModifiers{})
+		if got >= previous {
+			t.Errorf("Agility %d: %v should be faster than %v", agility, got, previous)
+		}
+		previous = got
+	}
+}
+
+func TestReactionModel_StatusEffects(t *testing.T) {
+	model := NewReactionModel(config.Default())
+	now := time.Now()
+	base := model.ReactionTime(ActionAttack, AttackNormal, 0, ReactionModifiers{})
+
+	stunned := &Combatant{StatusEffects: []StatusEffect{
+		{EffectType: EffectStun, ExpiresAt: now.Add(time.Second)},
+	}}
+	if got := model.ReactionTime(ActionAttack, AttackNormal, 0, StatusReactionModifiers(stunned, now)); got != base+time.Second {
+		t.Errorf("Stunned reaction = %v, want %v plus the remaining stun", got, base)
+	}
+
+	hasted := &Combatant{StatusEffects: []StatusEffect{
+		{EffectType: EffectHaste, ExpiresAt: now.Add(time.Second), Magnitude: 0.7},
+	}}
+	if got := model.ReactionTime(ActionAttack, AttackNormal, 0, StatusReactionModifiers(hasted, now)); got != base*7/10 {
+		t.Errorf("Hasted reaction = %v, want %v", got, base*7/10)
+	}
+
+	expired := &Combatant{StatusEffects: []StatusEffect{
+		{EffectType: EffectStun, ExpiresAt: now.Add(-time.Second)},
+		{EffectType: EffectSlow, ExpiresAt: now.Add(-time.Second), Magnitude: 2.0},
+	}}
+	if got := model.ReactionTime(ActionAttack, AttackNormal, 0, StatusReactionModifiers(expired, now)); got != base {
+		t.Errorf("Expired effects should not apply: %v, want %v", got, base)
+	}
+}
+
+func TestReactionModel_EncumbranceSlows(t *testing.T) {
+	model := NewReactionModel(config.Default())
+	now := time.Now()
+	base := model.ReactionTime(ActionAttack, AttackNormal, 0, ReactionModifiers{})
+
+	light := &Combatant{Encumbrance: 1.0}
+	if got := model.ReactionTime(ActionAttack, AttackNormal, 0, StatusReactionModifiers(light, now)); got != base {
+		t.Errorf("Unencumbered reaction = %v, want %v", got, base)
+	}
+
+	laden := &Combatant{Encumbrance: 1.5}
+	if got := model.ReactionTime(ActionAttack, AttackNormal, 0, StatusReactionModifiers(laden, now)); got != base*3/2 {
+		t.Errorf("Encumbered reaction = %v, want %v", got, base*3/2)
+	}
+}
+
+func TestReactionModel_ConfiguredBaseTimes(t *testing.T) {
+	cfg := config.Default()
+	cfg.Reaction.FleeMs = 3000
+	cfg.Reaction.DefendMs = 100
+	cfg.Reaction.MinimumMs = 150
+	cfg.Reaction.AgilityScaling = 0
+	model := NewReactionModel(cfg)
+
+	if got := model.BaseTime(ActionFlee, ""); got != 3000*time.Millisecond {
+		t.Errorf("Flee base = %v, want 3s", got)
+	}
+	if got := model.ReactionTime(ActionFlee, "", 100, ReactionModifiers{}); got != 3000*time.Millisecond {
+		t.Errorf("With no agility scaling, flee = %v, want 3s", got)
+	}
+	if got := model.ReactionTime(ActionDefend, "", 0, ReactionModifiers{}); got != 150*time.Millisecond {
+		t.Errorf("Defend below the minimum = %v, want 150ms", got)
+	}
+	if got := model.BaseTime(ActionAttack, AttackHeavy); got != 3000*time.Millisecond {
+		t.Errorf("Unchanged heavy attack base = %v, want 3s", got)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"tw-backend/internal/combat/config"
+
 	"github.com/google/uuid"
 )
 
@@ -13,16 +15,68 @@ type CombatResolver struct {
 	Queue      *CombatQueue
 	Combatants map[uuid.UUID]*Combatant
 	mu         sync.RWMutex
+
+	hitChance     HitChanceFunc
+	aoeEdgeDamage float64
+	rng           *rand.Rand
 }
 
-// NewCombatResolver creates a new combat resolver
+// NewCombatResolver creates a new combat resolver using the default combat
+// config
 func NewCombatResolver() *CombatResolver {
+	return NewCombatResolverWithConfig(config.Default())
+}
+
+// NewCombatResolverWithConfig creates a combat resolver whose to-hit formula
+// and area damage follow cfg
+func NewCombatResolverWithConfig(cfg *config.CombatConfig) *CombatResolver {
 	return &CombatResolver{
-		Queue:      NewCombatQueue(),
-		Combatants: make(map[uuid.UUID]*Combatant),
+		Queue:         NewCombatQueue(),
+		Combatants:    make(map[uuid.UUID]*Combatant),
+		hitChance:     NewHitChanceFunc(cfg),
+		aoeEdgeDamage: cfg.GetAOEEdgeDamage(),
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+// SetHitChanceFunc replaces the to-hit formula used when attacks resolve
+func (cr *CombatResolver) SetHitChanceFunc(fn HitChanceFunc) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.hitChance = fn
+}
+
+// SetAOEEdgeDamage sets the fraction of area damage dealt at the edge of the
+// radius (full damage at the center)
+func (cr *CombatResolver) SetAOEEdgeDamage(fraction float64) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.aoeEdgeDamage = fraction
+}
+
+// SetSeed reseeds the resolver's RNG for reproducible combat rolls
+func (cr *CombatResolver) SetSeed(seed int64) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.rng = rand.New(rand.NewSource(seed))
+}
+
+// SetTieBreaker sets how actions executing at the same instant are ordered
+func (cr *CombatResolver) SetTieBreaker(tb TieBreaker) {
+	cr.Queue.SetTieBreaker(tb)
+}
+
+// AgilityTieBreaker resolves ties in favour of the combatant with the higher
+// Agility, looked up when the tie is broken
+func (cr *CombatResolver) AgilityTieBreaker() TieBreaker {
+	return NewAgilityTieBreaker(func(actorID uuid.UUID) int {
+		if combatant := cr.GetCombatant(actorID); combatant != nil {
+			return combatant.Agility
+		}
+		return 0
+	})
+}
+
 // AddCombatant adds a combatant to the resolver
 func (cr *CombatResolver) AddCombatant(combatant *Combatant) {
 	cr.mu.Lock()
@@ -37,6 +91,26 @@ func (cr *CombatResolver) GetCombatant(id uuid.UUID) *Combatant {
 	return cr.Combatants[id]
 }
 
+// UpdateCombatant calls fn with the combatant under the resolver's lock, for
+// changes that must not race the tick. False if there is no such combatant.
+func (cr *CombatResolver) UpdateCombatant(id uuid.UUID, fn func(*Combatant)) bool {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	combatant, ok := cr.Combatants[id]
+	if ok {
+		fn(combatant)
+	}
+	return ok
+}
+
+// RemoveCombatant removes a combatant and any actions it still has queued
+func (cr *CombatResolver) RemoveCombatant(id uuid.UUID) {
+	cr.mu.Lock()
+	delete(cr.Combatants, id)
+	cr.mu.Unlock()
+	cr.Queue.RemoveActor(id)
+}
+
 // ProcessTick processes all actions ready to execute at the current time
 func (cr *CombatResolver) ProcessTick(now time.Time) []*CombatAction {
 	var resolvedActions []*CombatAction
@@ -72,12 +146,30 @@ func (cr *CombatResolver) ProcessTick(now time.Time) []*CombatAction {
 			continue
 		}
 
-		// Consume stamina
+		// Consume stamina (a miss still costs stamina)
 		staminaCost := GetStaminaCost(action.ActionType, AttackNormal) // TODO: Get actual attack variant
 		combatant.CurrentStamina -= staminaCost
 
+		// Roll to hit for attacks; area attacks damage everything in range
+		switch {
+		case action.ActionType == ActionAttack:
+			target := cr.GetCombatant(action.TargetID)
+			action.Outcome = cr.rollToHit(combatant, target)
+			if action.Outcome == OutcomeHit {
+				action.Reaction = cr.rollReaction(target)
+				if action.Reaction != nil && action.Reaction.Dodged {
+					action.Outcome = OutcomeMiss
+				}
+			}
+		case action.ActionType == ActionAttackAOE && action.AOE != nil:
+			action.Hits = cr.resolveAOE(combatant, action)
+			action.Outcome = OutcomeMiss
+			if len(action.Hits) > 0 {
+				action.Outcome = OutcomeHit
+			}
+		}
+
 		// Execute action (stub for Phase 7.2 - actual damage/effects)
-		// For now, just mark as resolved
 		action.Resolved = true
 
 		// Update last action time
@@ -389,3 +389,18 @@ func TestMultipleCombatants_ProcessOrder(t *testing.T) {
 	assert.Equal(t, combatant1.EntityID, resolved[1].ActorID, "Action 1 should be second")
 	assert.Equal(t, combatant3.EntityID, resolved[2].ActorID, "Action 3 should be third")
 }
+
+func TestUpdateCombatant(t *testing.T) {
+	resolver := NewCombatResolver()
+	combatant := &Combatant{EntityID: uuid.New(), MaxHP: 100, CurrentHP: 100}
+	resolver.AddCombatant(combatant)
+
+	ok := resolver.UpdateCombatant(combatant.EntityID, func(c *Combatant) { c.CurrentHP -= 30 })
+	assert.True(t, ok)
+	assert.Equal(t, 70, combatant.CurrentHP)
+
+	called := false
+	ok = resolver.UpdateCombatant(uuid.New(), func(c *Combatant) { called = true })
+	assert.False(t, ok, "Unknown combatants aren't updated")
+	assert.False(t, called)
+}
@@ -0,0 +1,49 @@
+package action
+
+import "github.com/google/uuid"
+
+// TieBreaker orders actions that execute at the same instant
+type TieBreaker interface {
+	// Before reports whether a should resolve before b. When neither goes
+	// first, the queue falls back to enqueue order.
+	Before(a, b *CombatAction) bool
+}
+
+// TieBreakerFunc adapts a function to TieBreaker
+type TieBreakerFunc func(a, b *CombatAction) bool
+
+// Before implements TieBreaker
+func (f TieBreakerFunc) Before(a, b *CombatAction) bool { return f(a, b) }
+
+// NewAgilityTieBreaker resolves the more agile actor first. agility looks up
+// an actor's Agility; unknown actors should report 0.
+func NewAgilityTieBreaker(agility func(actorID uuid.UUID) int) TieBreaker {
+	return TieBreakerFunc(func(a, b *CombatAction) bool {
+		return agility(a.ActorID) > agility(b.ActorID)
+	})
+}
+
+// NewPlayerFirstTieBreaker resolves players' actions before NPCs'
+func NewPlayerFirstTieBreaker(isPlayer func(actorID uuid.UUID) bool) TieBreaker {
+	return TieBreakerFunc(func(a, b *CombatAction) bool {
+		return isPlayer(a.ActorID) && !isPlayer(b.ActorID)
+	})
+}
+
+// NewSeededTieBreaker shuffles tied actions pseudo-randomly. The order depends
+// only on seed and the order actions were enqueued, so a replay that enqueues
+// the same actions with the same seed resolves ties identically.
+func NewSeededTieBreaker(seed int64) TieBreaker {
+	return TieBreakerFunc(func(a, b *CombatAction) bool {
+		return tieRank(seed, a.sequence) < tieRank(seed, b.sequence)
+	})
+}
+
+// tieRank mixes seed and an enqueue sequence into a well-distributed rank
+// (SplitMix64 finalizer)
+func tieRank(seed int64, sequence uint64) uint64 {
+	z := uint64(seed) + sequence*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,118 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tiedActions returns one action per actor, all executing at the same instant
+func tiedActions(actors ...uuid.UUID) []*CombatAction {
+	now := time.Now()
+	actions := make([]*CombatAction, len(actors))
+	for i, actor := range actors {
+		actions[i] = &CombatAction{
+			ActionID:     uuid.New(),
+			ActorID:      actor,
+			TargetID:     uuid.New(),
+			ActionType:   ActionAttack,
+			ReactionTime: 800 * time.Millisecond,
+			QueuedAt:     now,
+			ExecuteAt:    now.Add(800 * time.Millisecond),
+		}
+	}
+	return actions
+}
+
+// drainActors enqueues actions and returns their actors in dequeue order
+func drainActors(q *CombatQueue, actions []*CombatAction) []uuid.UUID {
+	for _, a := range actions {
+		q.Enqueue(a)
+	}
+	var order []uuid.UUID
+	for a := q.Dequeue(); a != nil; a = q.Dequeue() {
+		order = append(order, a.ActorID)
+	}
+	return order
+}
+
+func TestCombatQueue_TiesDefaultToEnqueueOrder(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	for i := 0; i < 20; i++ {
+		order := drainActors(NewCombatQueue(), tiedActions(a, b, c))
+		require.Equal(t, []uuid.UUID{a, b, c}, order)
+	}
+}
+
+func TestCombatQueue_AgilityTieBreaker(t *testing.T) {
+	slow, quick, middling := uuid.New(), uuid.New(), uuid.New()
+	agility := map[uuid.UUID]int{slow: 20, quick: 90, middling: 55}
+	q := NewCombatQueueWithTieBreaker(NewAgilityTieBreaker(func(id uuid.UUID) int { return agility[id] }))
+
+	order := drainActors(q, tiedActions(slow, quick, middling))
+	assert.Equal(t, []uuid.UUID{quick, middling, slow}, order)
+}
+
+func TestCombatQueue_PlayerFirstTieBreaker(t *testing.T) {
+	npc1, player, npc2 := uuid.New(), uuid.New(), uuid.New()
+	q := NewCombatQueueWithTieBreaker(NewPlayerFirstTieBreaker(func(id uuid.UUID) bool { return id == player }))
+
+	order := drainActors(q, tiedActions(npc1, player, npc2))
+	assert.Equal(t, []uuid.UUID{player, npc1, npc2}, order, "NPCs keep enqueue order among themselves")
+}
+
+func TestCombatQueue_SeededTieBreaker(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	order := func(seed int64) []uuid.UUID {
+		return drainActors(NewCombatQueueWithTieBreaker(NewSeededTieBreaker(seed)), tiedActions(a, b, c))
+	}
+
+	first := order(42)
+	assert.ElementsMatch(t, []uuid.UUID{a, b, c}, first)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, order(42), "The same seed must replay the same order")
+	}
+
+	seen := map[[3]uuid.UUID]bool{}
+	for seed := int64(0); seed < 50; seed++ {
+		o := order(seed)
+		seen[[3]uuid.UUID{o[0], o[1], o[2]}] = true
+	}
+	assert.Greater(t, len(seen), 1, "Different seeds should shuffle ties differently")
+}
+
+func TestCombatQueue_TieBreakerOnlyAppliesToTies(t *testing.T) {
+	quick, slow := uuid.New(), uuid.New()
+	agility := map[uuid.UUID]int{quick: 90, slow: 10}
+	q := NewCombatQueueWithTieBreaker(NewAgilityTieBreaker(func(id uuid.UUID) int { return agility[id] }))
+
+	actions := tiedActions(quick, slow)
+	actions[0].ExecuteAt = actions[0].ExecuteAt.Add(time.Millisecond)
+	assert.Equal(t, []uuid.UUID{slow, quick}, drainActors(q, actions))
+}
+
+func TestCombatResolver_AgilityTieBreaker(t *testing.T) {
+	resolver := NewCombatResolver()
+	resolver.SetTieBreaker(resolver.AgilityTieBreaker())
+	now := time.Now()
+
+	slow, quick, middling := uuid.New(), uuid.New(), uuid.New()
+	for id, agility := range map[uuid.UUID]int{slow: 10, quick: 80, middling: 40} {
+		resolver.AddCombatant(&Combatant{
+			EntityID: id, CurrentStamina: 100, MaxStamina: 100, CurrentHP: 100, MaxHP: 100,
+			Agility: agility, CombatState: StateInCombat,
+		})
+	}
+	for _, a := range tiedActions(slow, quick, middling) {
+		a.ActionType = ActionDefend
+		a.ExecuteAt = now
+		resolver.Queue.Enqueue(a)
+	}
+
+	resolved := resolver.ProcessTick(now)
+	require.Len(t, resolved, 3)
+	assert.Equal(t, []uuid.UUID{quick, middling, slow}, []uuid.UUID{resolved[0].ActorID, resolved[1].ActorID, resolved[2].ActorID})
+}
@@ -3,6 +3,9 @@ package action
 import (
 	"time"
 
+	"tw-backend/internal/character"
+	"tw-backend/internal/combat/damage"
+
 	"github.com/google/uuid"
 )
 
@@ -10,10 +13,11 @@ import (
 type ActionType string
 
 const (
-	ActionAttack  ActionType = "attack"
-	ActionDefend  ActionType = "defend"
-	ActionFlee    ActionType = "flee"
-	ActionUseItem ActionType = "use_item"
+	ActionAttack    ActionType = "attack"
+	ActionAttackAOE ActionType = "attack_aoe" // Hits everything within an AreaOfEffect
+	ActionDefend    ActionType = "defend"
+	ActionFlee      ActionType = "flee"
+	ActionUseItem   ActionType = "use_item"
 )
 
 // CombatAction represents a queued action in combat
@@ -26,6 +30,21 @@ type CombatAction struct {
 	QueuedAt     time.Time
 	ExecuteAt    time.Time // QueuedAt + ReactionTime
 	Resolved     bool
+	Outcome      HitOutcome          // Set when an attack resolves
+	AOE          *AreaOfEffect       // Required for ActionAttackAOE
+	Hits         []AOEHit            // Per-target results, set when an AOE attack resolves
+	Reaction     *ReactionResult     // Set when the target rolled to dodge a hit
+	WeaponBroke  *damage.WeaponBroke // Set when this action broke the actor's weapon
+
+	sequence uint64 // Enqueue order, assigned by CombatQueue
+}
+
+// AreaOfEffect is the area an ActionAttackAOE hits
+type AreaOfEffect struct {
+	CenterX      float64
+	CenterY      float64
+	Radius       float64
+	FriendlyFire bool // Also hit combatants on the attacker's Team
 }
 
 // NewCombatAction creates a new action with calculated execution time
@@ -79,9 +98,22 @@ type Combatant struct {
 	CurrentHP      int
 	MaxHP          int
 	Agility        int
+	Accuracy       int // Weapon skill contributing to to-hit
+	Evasion        int // Defensive skill reducing attackers' to-hit
 	LastActionTime time.Time
 	CurrentAction  *CombatAction
 	DefendingUntil time.Time
 	StatusEffects  []StatusEffect
 	CombatState    CombatState
+	Defense        *DefenseProfile // Nil never dodges
+	Encumbrance    float64         // Slows reactions when above 1; see player.EncumbrancePenalty
+
+	// Area attacks use these to find and damage targets
+	PositionX  float64
+	PositionY  float64
+	Team       string               // Combatants sharing a non-empty Team are allies
+	Attributes character.Attributes // Feeds damage calculation
+	Weapon     *damage.Weapon       // Nil or broken fights unarmed
+	Armor      *damage.Armor        // Nil is unarmored
+	Bonuses    damage.Bonuses       // From equipment
 }
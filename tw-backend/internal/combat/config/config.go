@@ -28,6 +28,36 @@ type CombatConfig struct {
 	HeavyAttackBonus         int     `json:"heavy_attack_bonus"`
 	CriticalMultiplier       float64 `json:"critical_multiplier"`
 	CriticalIgnoreArmor      float64 `json:"critical_ignore_armor"`
+
+	// To-hit settings
+	BaseHitChance     float64 `json:"base_hit_chance"`      // Chance to hit when accuracy equals evasion
+	HitChancePerPoint float64 `json:"hit_chance_per_point"` // Change in hit chance per point of accuracy-evasion difference
+	MinHitChance      float64 `json:"min_hit_chance"`
+	MaxHitChance      float64 `json:"max_hit_chance"`
+
+	// Area-of-effect settings
+	AOEEdgeDamage float64 `json:"aoe_edge_damage"` // Fraction of damage dealt at the edge of the radius (full at the center)
+
+	// Dodge settings for defenders with a DefenseProfile
+	DodgeChancePerAgility float64 `json:"dodge_chance_per_agility"`
+	MaxDodgeChance        float64 `json:"max_dodge_chance"`
+	DodgeStaminaCost      int     `json:"dodge_stamina_cost"` // Spent per successful dodge
+
+	// Reaction time rule-set
+	Reaction ReactionTimes `json:"reaction"`
+}
+
+// ReactionTimes holds the base reaction time of each action (milliseconds)
+// and how agility shortens it.
+type ReactionTimes struct {
+	QuickAttackMs  int     `json:"quick_attack_ms"`
+	NormalAttackMs int     `json:"normal_attack_ms"`
+	HeavyAttackMs  int     `json:"heavy_attack_ms"`
+	DefendMs       int     `json:"defend_ms"`
+	FleeMs         int     `json:"flee_ms"`
+	UseItemMs      int     `json:"use_item_ms"`
+	MinimumMs      int     `json:"minimum_ms"`
+	AgilityScaling float64 `json:"agility_scaling"` // Fraction of the base time removed at 100 Agility
 }
 
 // Default returns a CombatConfig with values matching the original hardcoded constants.
@@ -47,6 +77,33 @@ func Default() *CombatConfig {
 		HeavyAttackBonus:         5,
 		CriticalMultiplier:       2.0,
 		CriticalIgnoreArmor:      0.5,
+
+		// To-hit settings: evenly matched combatants connect 85% of the time
+		BaseHitChance:     0.85,
+		HitChancePerPoint: 0.005,
+		MinHitChance:      0.05,
+		MaxHitChance:      0.95,
+
+		// Area attacks deal half damage at the edge of their radius
+		AOEEdgeDamage: 0.5,
+
+		// Dodge settings: 100 Agility dodges 30% of hits
+		DodgeChancePerAgility: 0.003,
+		MaxDodgeChance:        0.3,
+		DodgeStaminaCost:      10,
+
+		// Reaction times: the live game's 2000ms - Agility*10ms with a 500ms
+		// floor for a normal attack, other actions in proportion
+		Reaction: ReactionTimes{
+			QuickAttackMs:  1600,
+			NormalAttackMs: 2000,
+			HeavyAttackMs:  3000,
+			DefendMs:       1000,
+			FleeMs:         4000,
+			UseItemMs:      1400,
+			MinimumMs:      500,
+			AgilityScaling: 0.5,
+		},
 	}
 }
 
@@ -106,6 +163,15 @@ func (c *CombatConfig) Reload(path string) error {
 	c.HeavyAttackBonus = temp.HeavyAttackBonus
 	c.CriticalMultiplier = temp.CriticalMultiplier
 	c.CriticalIgnoreArmor = temp.CriticalIgnoreArmor
+	c.BaseHitChance = temp.BaseHitChance
+	c.HitChancePerPoint = temp.HitChancePerPoint
+	c.MinHitChance = temp.MinHitChance
+	c.MaxHitChance = temp.MaxHitChance
+	c.AOEEdgeDamage = temp.AOEEdgeDamage
+	c.DodgeChancePerAgility = temp.DodgeChancePerAgility
+	c.MaxDodgeChance = temp.MaxDodgeChance
+	c.DodgeStaminaCost = temp.DodgeStaminaCost
+	c.Reaction = temp.Reaction
 
 	return nil
 }
@@ -188,3 +254,66 @@ func (c *CombatConfig) GetCriticalIgnoreArmor() float64 {
 	defer c.mu.RUnlock()
 	return c.CriticalIgnoreArmor
 }
+
+// GetBaseHitChance returns the hit chance for evenly matched combatants (thread-safe).
+func (c *CombatConfig) GetBaseHitChance() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.BaseHitChance
+}
+
+// GetHitChancePerPoint returns the hit chance change per point of accuracy over evasion (thread-safe).
+func (c *CombatConfig) GetHitChancePerPoint() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.HitChancePerPoint
+}
+
+// GetMinHitChance returns the lower bound on hit chance (thread-safe).
+func (c *CombatConfig) GetMinHitChance() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MinHitChance
+}
+
+// GetMaxHitChance returns the upper bound on hit chance (thread-safe).
+func (c *CombatConfig) GetMaxHitChance() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MaxHitChance
+}
+
+// GetAOEEdgeDamage returns the fraction of area damage dealt at the edge of the radius (thread-safe).
+func (c *CombatConfig) GetAOEEdgeDamage() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AOEEdgeDamage
+}
+
+// GetDodgeChancePerAgility returns the dodge chance gained per point of Agility (thread-safe).
+func (c *CombatConfig) GetDodgeChancePerAgility() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.DodgeChancePerAgility
+}
+
+// GetMaxDodgeChance returns the upper bound on dodge chance (thread-safe).
+func (c *CombatConfig) GetMaxDodgeChance() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MaxDodgeChance
+}
+
+// GetDodgeStaminaCost returns the stamina a successful dodge costs (thread-safe).
+func (c *CombatConfig) GetDodgeStaminaCost() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.DodgeStaminaCost
+}
+
+// GetReaction returns the reaction time rule-set (thread-safe).
+func (c *CombatConfig) GetReaction() ReactionTimes {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Reaction
+}
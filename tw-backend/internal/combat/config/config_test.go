@@ -26,6 +26,20 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 5, cfg.HeavyAttackBonus)
 	assert.Equal(t, 2.0, cfg.CriticalMultiplier)
 	assert.Equal(t, 0.5, cfg.CriticalIgnoreArmor)
+
+	// To-hit settings
+	assert.Equal(t, 0.85, cfg.BaseHitChance)
+	assert.Equal(t, 0.005, cfg.HitChancePerPoint)
+	assert.Equal(t, 0.05, cfg.MinHitChance)
+	assert.Equal(t, 0.95, cfg.MaxHitChance)
+
+	// Area-of-effect settings
+	assert.Equal(t, 0.5, cfg.AOEEdgeDamage)
+
+	// Dodge settings
+	assert.Equal(t, 0.003, cfg.DodgeChancePerAgility)
+	assert.Equal(t, 0.3, cfg.MaxDodgeChance)
+	assert.Equal(t, 10, cfg.DodgeStaminaCost)
 }
 
 func TestLoadFromFile(t *testing.T) {
@@ -105,3 +119,19 @@ func TestReload(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 300.0, cfg.SkillDivisor)
 }
+
+func TestLoadFromFile_PartialReaction(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "combat_config.json")
+	err := os.WriteFile(configPath, []byte(`{"reaction": {"flee_ms": 3000, "agility_scaling": 0.5}}`), 0644)
+	require.NoError(t, err)
+
+	cfg, err := LoadFromFile(configPath)
+	require.NoError(t, err)
+
+	reaction := cfg.GetReaction()
+	assert.Equal(t, 3000, reaction.FleeMs)
+	assert.Equal(t, 0.5, reaction.AgilityScaling)
+	assert.Equal(t, 2000, reaction.NormalAttackMs, "Unset reaction times keep their defaults")
+	assert.Equal(t, 500, reaction.MinimumMs)
+}
@@ -0,0 +1,70 @@
+package damage
+
+import "tw-backend/internal/character"
+
+// Bonuses are the stat bonuses a combatant's equipment gives them
+type Bonuses struct {
+	Attributes map[string]int // Added to the named attributes, e.g. character.AttrMight
+	Damage     int            // Added to the weapon's base damage
+	Defense    float64        // Share of incoming damage blocked on top of armor, 0-1
+}
+
+// Add returns the sum of two sets of bonuses
+func (b Bonuses) Add(other Bonuses) Bonuses {
+	sum := Bonuses{
+		Damage:  b.Damage + other.Damage,
+		Defense: b.Defense + other.Defense,
+	}
+	if len(b.Attributes)+len(other.Attributes) > 0 {
+		sum.Attributes = make(map[string]int, len(b.Attributes)+len(other.Attributes))
+		for attr, v := range b.Attributes {
+			sum.Attributes[attr] += v
+		}
+		for attr, v := range other.Attributes {
+			sum.Attributes[attr] += v
+		}
+	}
+	return sum
+}
+
+// Apply returns attrs raised by the attribute bonuses. Unknown attribute
+// names are ignored.
+func (b Bonuses) Apply(attrs character.Attributes) character.Attributes {
+	for attr, v := range b.Attributes {
+		if field := attrs.Field(attr); field != nil {
+			*field += v
+		}
+	}
+	return attrs
+}
+
+// CalculateEquippedDamage is CalculateDamage with both sides' equipment
+// bonuses: the attacker's raise their attributes and the weapon's damage,
+// and the target's defense blocks a share of what gets through their armor.
+func CalculateEquippedDamage(
+	attackerAttrs character.Attributes,
+	weapon *Weapon,
+	weaponSkill int,
+	attackerBonuses Bonuses,
+	targetArmor *Armor,
+	targetBonuses Bonuses,
+	roll int,
+	isHeavyAttack bool,
+) DamageResult {
+	if weapon == nil || weapon.IsBroken() {
+		weapon = Unarmed()
+	}
+	if attackerBonuses.Damage != 0 {
+		boosted := *weapon
+		boosted.BaseDamage = max(0, boosted.BaseDamage+attackerBonuses.Damage)
+		weapon = &boosted
+	}
+
+	result := CalculateDamage(attackerBonuses.Apply(attackerAttrs), weapon, weaponSkill, targetArmor, roll, isHeavyAttack)
+	if targetBonuses.Defense > 0 && !result.IsFumble {
+		blocked := int(float64(result.FinalDamage) * min(1, targetBonuses.Defense))
+		result.FinalDamage -= blocked
+		result.Blocked += blocked
+	}
+	return result
+}
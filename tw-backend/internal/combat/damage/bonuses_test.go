@@ -0,0 +1,48 @@
+package damage
+
+import (
+	"testing"
+
+	"tw-backend/internal/character"
+)
+
+func TestCalculateEquippedDamage(t *testing.T) {
+	attrs := character.Attributes{Might: 50, Agility: 50, Cunning: 50}
+	sword := &Weapon{Name: "Iron Sword", Type: WeaponSlashing, BaseDamage: 20, Durability: 100, MaxDurability: 100}
+	const roll = 50
+
+	unarmed := CalculateEquippedDamage(attrs, nil, 0, Bonuses{}, nil, Bonuses{}, roll, false)
+	armed := CalculateEquippedDamage(attrs, sword, 0, Bonuses{}, nil, Bonuses{}, roll, false)
+	if armed.FinalDamage <= unarmed.FinalDamage {
+		t.Errorf("sword damage %d, want more than unarmed %d", armed.FinalDamage, unarmed.FinalDamage)
+	}
+
+	boosted := CalculateEquippedDamage(attrs, sword, 0, Bonuses{Damage: 5, Attributes: map[string]int{character.AttrMight: 20}}, nil, Bonuses{}, roll, false)
+	if boosted.FinalDamage <= armed.FinalDamage {
+		t.Errorf("boosted damage %d, want more than %d", boosted.FinalDamage, armed.FinalDamage)
+	}
+	if sword.BaseDamage != 20 {
+		t.Errorf("damage bonus changed the weapon's base damage to %d", sword.BaseDamage)
+	}
+
+	defended := CalculateEquippedDamage(attrs, sword, 0, Bonuses{}, nil, Bonuses{Defense: 0.5}, roll, false)
+	if want := armed.FinalDamage - armed.FinalDamage/2; defended.FinalDamage != want {
+		t.Errorf("defended damage %d, want %d", defended.FinalDamage, want)
+	}
+	if defended.Blocked <= armed.Blocked {
+		t.Errorf("defense blocked %d, want more than %d", defended.Blocked, armed.Blocked)
+	}
+}
+
+func TestBonuses_Add(t *testing.T) {
+	ring := Bonuses{Attributes: map[string]int{character.AttrMight: 2}}
+	shield := Bonuses{Attributes: map[string]int{character.AttrMight: 1}, Defense: 0.1}
+
+	sum := ring.Add(shield)
+	if sum.Attributes[character.AttrMight] != 3 || sum.Defense != 0.1 {
+		t.Errorf("sum = %+v, want Might 3 and Defense 0.1", sum)
+	}
+	if ring.Attributes[character.AttrMight] != 2 {
+		t.Error("Add modified its receiver")
+	}
+}
@@ -29,7 +29,8 @@ type DamageResult struct {
 	Blocked     int
 }
 
-// CalculateDamage computes the final damage dealt
+// CalculateDamage computes the final damage dealt. A nil or broken weapon
+// deals unarmed damage (see Unarmed).
 func CalculateDamage(
 	attackerAttrs character.Attributes,
 	weapon *Weapon,
@@ -44,6 +45,10 @@ func CalculateDamage(
 		return DamageResult{IsFumble: true, FinalDamage: 0}
 	}
 
+	if weapon == nil || weapon.IsBroken() {
+		weapon = Unarmed()
+	}
+
 	// 2. Base Modifiers
 	// Skill Modifier (uses config divisor)
 	skillMod := 1.0 + (float64(weaponSkill) / defaultConfig.GetSkillDivisor())
@@ -63,9 +68,6 @@ func CalculateDamage(
 
 	// Durability Modifier
 	durabilityStatus := GetDurabilityStatus(weapon)
-	if durabilityStatus.IsBroken {
-		return DamageResult{FinalDamage: 0}
-	}
 
 	// 3. Raw Damage Calculation
 	// raw = base * skillMod * attrMod * (roll / 100) * critMult * durabilityMod
@@ -1,27 +1,54 @@
 package damage
 
+import "github.com/google/uuid"
+
 // DurabilityResult holds the outcome of durability loss
 type DurabilityResult struct {
 	CurrentDurability int
 	IsBroken          bool
-	DamageModifier    float64 // Multiplier for damage output (e.g., 0.9 for <50%)
+	DamageModifier    float64      // Multiplier for damage output (e.g., 0.9 for <50%)
+	Broke             *WeaponBroke // Set only by the reduction that broke the weapon
+}
+
+// WeaponBroke is emitted when a weapon's durability reaches zero. Attacks
+// with it deal unarmed damage until it's repaired.
+type WeaponBroke struct {
+	WeaponID uuid.UUID
+	Name     string
 }
 
 // ReduceDurability reduces weapon durability based on action
 func ReduceDurability(weapon *Weapon, amount int) DurabilityResult {
+	wasBroken := weapon.IsBroken()
 	weapon.Durability -= amount
 	if weapon.Durability < 0 {
 		weapon.Durability = 0
 	}
 
-	return GetDurabilityStatus(weapon)
+	result := GetDurabilityStatus(weapon)
+	if result.IsBroken {
+		weapon.Broken = true
+		if !wasBroken {
+			result.Broke = &WeaponBroke{WeaponID: weapon.WeaponID, Name: weapon.Name}
+		}
+	}
+	return result
+}
+
+// Repair restores weapon durability, up to its maximum, making a broken
+// weapon usable again
+func Repair(weapon *Weapon, amount int) {
+	weapon.Durability = min(weapon.Durability+amount, weapon.MaxDurability)
+	if weapon.Durability > 0 {
+		weapon.Broken = false
+	}
 }
 
 // GetDurabilityStatus calculates the current status and modifiers
 func GetDurabilityStatus(weapon *Weapon) DurabilityResult {
-	if weapon.Durability == 0 {
+	if weapon.IsBroken() {
 		return DurabilityResult{
-			CurrentDurability: 0,
+			CurrentDurability: weapon.Durability,
 			IsBroken:          true,
 			DamageModifier:    0.0,
 		}
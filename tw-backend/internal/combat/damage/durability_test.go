@@ -0,0 +1,78 @@
+package damage
+
+import (
+	"testing"
+
+	"tw-backend/internal/character"
+
+	"github.com/google/uuid"
+)
+
+func TestWeaponBreaksAndFallsBackToUnarmed(t *testing.T) {
+	attrs := character.Attributes{Might: 50}
+	const swings = 5
+	weapon := &Weapon{
+		WeaponID:      uuid.New(),
+		Name:          "Rusty Axe",
+		Type:          WeaponSlashing,
+		BaseDamage:    40,
+		Durability:    swings,
+		MaxDurability: swings,
+	}
+
+	armed := CalculateDamage(attrs, weapon, 0, nil, 50, false)
+	unarmed := CalculateDamage(attrs, Unarmed(), 0, nil, 50, false)
+	if unarmed.FinalDamage >= armed.FinalDamage {
+		t.Fatalf("Expected unarmed damage %d below armed damage %d", unarmed.FinalDamage, armed.FinalDamage)
+	}
+
+	// Each swing wears the weapon; only the last one breaks it
+	var broke []*WeaponBroke
+	for i := 1; i <= swings; i++ {
+		if weapon.IsBroken() {
+			t.Fatalf("Weapon broke early, before swing %d", i)
+		}
+		CalculateDamage(attrs, weapon, 0, nil, 50, false)
+		if res := ReduceDurability(weapon, 1); res.Broke != nil {
+			broke = append(broke, res.Broke)
+		}
+	}
+	if len(broke) != 1 {
+		t.Fatalf("Expected exactly one WeaponBroke event, got %d", len(broke))
+	}
+	if broke[0].WeaponID != weapon.WeaponID || broke[0].Name != "Rusty Axe" {
+		t.Errorf("WeaponBroke = %+v, want the axe", broke[0])
+	}
+	if !weapon.IsBroken() || !weapon.Broken {
+		t.Error("Expected the weapon to be broken")
+	}
+
+	// The next swing deals unarmed damage
+	next := CalculateDamage(attrs, weapon, 0, nil, 50, false)
+	if next.FinalDamage != unarmed.FinalDamage {
+		t.Errorf("Broken weapon dealt %d, want unarmed %d", next.FinalDamage, unarmed.FinalDamage)
+	}
+
+	// Wearing a broken weapon doesn't emit again
+	if res := ReduceDurability(weapon, 1); res.Broke != nil {
+		t.Error("Expected no WeaponBroke event for an already broken weapon")
+	}
+
+	// Repaired, it hits like new
+	Repair(weapon, swings)
+	if weapon.IsBroken() {
+		t.Fatal("Expected repair to fix the weapon")
+	}
+	if got := CalculateDamage(attrs, weapon, 0, nil, 50, false); got.FinalDamage != armed.FinalDamage {
+		t.Errorf("Repaired weapon dealt %d, want %d", got.FinalDamage, armed.FinalDamage)
+	}
+}
+
+func TestCalculateDamage_NilWeaponIsUnarmed(t *testing.T) {
+	attrs := character.Attributes{Might: 30}
+	got := CalculateDamage(attrs, nil, 0, nil, 60, false)
+	want := CalculateDamage(attrs, Unarmed(), 0, nil, 60, false)
+	if got != want || got.FinalDamage == 0 {
+		t.Errorf("Nil weapon = %+v, want unarmed %+v", got, want)
+	}
+}
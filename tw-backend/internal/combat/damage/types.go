@@ -29,7 +29,24 @@ type Weapon struct {
 	BaseDamage    int
 	Durability    int
 	MaxDurability int
-	SkillRequired int // Minimum skill to use effectively
+	SkillRequired int  // Minimum skill to use effectively
+	Broken        bool // Set when durability reaches zero, cleared by Repair
+}
+
+// IsBroken reports whether the weapon is unusable until repaired
+func (w *Weapon) IsBroken() bool {
+	return w.Broken || w.Durability <= 0
+}
+
+// Unarmed returns the weapon attacks fall back to without a usable weapon
+func Unarmed() *Weapon {
+	return &Weapon{
+		Name:          "fists",
+		Type:          WeaponBludgeoning,
+		BaseDamage:    10,
+		Durability:    1,
+		MaxDurability: 1,
+	}
 }
 
 // Armor represents defensive gear
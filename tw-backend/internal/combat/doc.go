@@ -10,12 +10,21 @@
 //
 // # Combat Flow
 //
-//  1. Actions are queued via action.NewCombatQueue()
+//  1. Actions are queued via action.NewCombatQueue(); simultaneous actions
+//     resolve by its TieBreaker (see NewCombatQueueWithTieBreaker), then FIFO
 //  2. Actions are validated against stamina, cooldowns, stun status
-//  3. Reaction times are calculated based on agility and action type
-//  4. Damage is calculated with type resistances and critical multipliers
-//  5. Effects are applied (poison, stun, buffs)
-//  6. Equipment durability is reduced
+//  3. Reaction times are calculated based on agility and action type, scaled by
+//     slow/haste and delayed by stun (action.ReactionModel, tuned via config)
+//  4. Hits may be dodged by targets with a DefenseProfile (action.ReactionResult)
+//  5. Damage is calculated with type resistances and critical multipliers;
+//     area attacks damage each target in range, scaled by distance (action.AOEFalloff)
+//  6. Effects are applied (poison, stun, buffs)
+//  7. Equipment durability is reduced
+//
+// # Balance Testing
+//
+// SimulateDuel fights two combatants headlessly with a seeded RNG and a
+// simulated clock, so the same seed reproduces the same DuelResult.
 //
 // # Usage
 //
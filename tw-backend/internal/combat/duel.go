@@ -0,0 +1,121 @@
+package combat
+
+import (
+	"math/rand"
+	"slices"
+	"time"
+
+	"tw-backend/internal/combat/action"
+	"tw-backend/internal/combat/config"
+	"tw-backend/internal/combat/damage"
+
+	"github.com/google/uuid"
+)
+
+// DuelResult summarizes a simulated duel
+type DuelResult struct {
+	Winner         uuid.UUID // uuid.Nil if both still stand after maxTurns
+	Turns          int       // Rounds fought; each living side attacks once per round
+	AttackerDamage int       // Total damage dealt by the attacker
+	DefenderDamage int       // Total damage dealt by the defender
+}
+
+// duelEpoch is the simulated clock's start, so results never depend on the wall clock
+var duelEpoch = time.Unix(0, 0).UTC()
+
+// SimulateDuel fights attacker against defender headlessly through the
+// action queue and resolver, for up to maxTurns rounds. Each round both sides
+// queue an attack; the quicker one resolves first, so a kill can pre-empt the
+// reply. Every round restores one normal attack's worth of stamina, so duels
+// are decided by damage rather than exhaustion.
+//
+// The result depends only on the combatants, seed and maxTurns: all rolls
+// come from seed and time is simulated. The combatants are copied, so the
+// caller's values (including weapons and armor) are left untouched. They
+// must have distinct EntityIDs.
+func SimulateDuel(attacker, defender action.Combatant, seed int64, maxTurns int) DuelResult {
+	a, d := duelCopy(attacker), duelCopy(defender)
+
+	resolver := action.NewCombatResolver()
+	resolver.SetSeed(seed)
+	resolver.AddCombatant(a)
+	resolver.AddCombatant(d)
+	reaction := action.NewReactionModel(config.Default())
+	// Damage rolls use their own stream so they don't shift hit rolls
+	damageRNG := rand.New(rand.NewSource(seed + 1))
+
+	result := DuelResult{}
+	byID := map[uuid.UUID]*action.Combatant{a.EntityID: a, d.EntityID: d}
+	dealt := map[uuid.UUID]*int{a.EntityID: &result.AttackerDamage, d.EntityID: &result.DefenderDamage}
+
+	now := duelEpoch
+	for result.Turns < maxTurns && a.CurrentHP > 0 && d.CurrentHP > 0 {
+		result.Turns++
+		for _, pair := range [][2]*action.Combatant{{a, d}, {d, a}} {
+			c, target := pair[0], pair[1]
+			c.CurrentStamina = min(c.CurrentStamina+action.StaminaCostNormalAttack, c.MaxStamina)
+			delay := reaction.ReactionTime(action.ActionAttack, action.AttackNormal, c.Agility, action.StatusReactionModifiers(c, now))
+			act := action.NewCombatAction(c.EntityID, target.EntityID, action.ActionAttack, delay)
+			act.QueuedAt, act.ExecuteAt = now, now.Add(delay)
+			resolver.Queue.Enqueue(act)
+		}
+
+		for next := resolver.Queue.Peek(); next != nil; next = resolver.Queue.Peek() {
+			now = next.ExecuteAt
+			for _, act := range resolver.ProcessTick(now) {
+				if act.Outcome != action.OutcomeHit {
+					continue
+				}
+				target := byID[act.TargetID]
+				*dealt[act.ActorID] += duelHit(byID[act.ActorID], target, damageRNG.Intn(100)+1)
+				if target.CurrentHP == 0 {
+					resolver.Queue.RemoveActor(target.EntityID)
+				}
+			}
+		}
+	}
+
+	switch {
+	case d.CurrentHP == 0:
+		result.Winner = a.EntityID
+	case a.CurrentHP == 0:
+		result.Winner = d.EntityID
+	}
+	return result
+}
+
+// duelHit applies one landed attack and returns the damage dealt
+func duelHit(actor, target *action.Combatant, roll int) int {
+	dmg := damage.CalculateDamage(actor.Attributes, actor.Weapon, 0, target.Armor, roll, false)
+	if dmg.FinalDamage <= 0 {
+		return 0
+	}
+	dealt := min(dmg.FinalDamage, target.CurrentHP)
+	target.CurrentHP -= dealt
+	if actor.Weapon != nil && !actor.Weapon.IsBroken() {
+		damage.ReduceDurability(actor.Weapon, 1)
+	}
+	if target.Armor != nil {
+		damage.ReduceArmorDurability(target.Armor, 1)
+	}
+	return dealt
+}
+
+// duelCopy copies c deeply enough that a duel can't modify the original
+func duelCopy(c action.Combatant) *action.Combatant {
+	c.CurrentAction = nil
+	c.StatusEffects = slices.Clone(c.StatusEffects)
+	if c.Weapon != nil {
+		weapon := *c.Weapon
+		c.Weapon = &weapon
+	}
+	if c.Armor != nil {
+		armor := *c.Armor
+		c.Armor = &armor
+	}
+	if c.Defense != nil {
+		defense := *c.Defense
+		c.Defense = &defense
+	}
+	return &c
+}
@@ -0,0 +1,99 @@
+package combat
+
+import (
+	"testing"
+
+	"tw-backend/internal/character"
+	"tw-backend/internal/combat/action"
+	"tw-backend/internal/combat/damage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// duelist returns a combatant ready to duel with the given Might and Agility
+func duelist(might, agility int) action.Combatant {
+	return action.Combatant{
+		EntityID:       uuid.New(),
+		CurrentStamina: 100,
+		MaxStamina:     100,
+		CurrentHP:      100,
+		MaxHP:          100,
+		Agility:        agility,
+		CombatState:    action.StateInCombat,
+		Attributes:     character.Attributes{Might: might, Agility: agility},
+		Weapon:         &damage.Weapon{Name: "sword", Type: damage.WeaponSlashing, BaseDamage: 20, Durability: 200, MaxDurability: 200},
+		Armor:          &damage.Armor{Name: "jerkin", Type: damage.ArmorLeather, Durability: 200, MaxDurability: 200},
+	}
+}
+
+func TestSimulateDuel_SameSeedIsReproducible(t *testing.T) {
+	a, b := duelist(60, 50), duelist(50, 60)
+
+	first := SimulateDuel(a, b, 1234, 500)
+	require.NotEqual(t, uuid.Nil, first.Winner, "The duel should finish within the turn limit")
+	assert.Contains(t, []uuid.UUID{a.EntityID, b.EntityID}, first.Winner)
+	assert.Positive(t, first.Turns)
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, first, SimulateDuel(a, b, 1234, 500))
+	}
+}
+
+func TestSimulateDuel_DifferentSeedsVary(t *testing.T) {
+	a, b := duelist(55, 50), duelist(50, 55)
+
+	outcomes := make(map[DuelResult]bool)
+	winners := make(map[uuid.UUID]bool)
+	for seed := int64(0); seed < 50; seed++ {
+		result := SimulateDuel(a, b, seed, 500)
+		outcomes[result] = true
+		winners[result.Winner] = true
+	}
+	assert.Greater(t, len(outcomes), 10, "Seeds should produce different duels")
+	assert.Len(t, winners, 2, "Evenly matched duelists should each win some duels")
+}
+
+func TestSimulateDuel_LeavesCombatantsUntouched(t *testing.T) {
+	a, b := duelist(60, 50), duelist(50, 50)
+	weapon, armor := *a.Weapon, *b.Armor
+
+	SimulateDuel(a, b, 7, 500)
+	assert.Equal(t, 100, a.CurrentHP)
+	assert.Equal(t, 100, b.CurrentHP)
+	assert.Equal(t, weapon, *a.Weapon)
+	assert.Equal(t, armor, *b.Armor)
+}
+
+func TestSimulateDuel_TurnLimit(t *testing.T) {
+	a, b := duelist(50, 50), duelist(50, 50)
+	a.MaxHP, a.CurrentHP = 100000, 100000
+	b.MaxHP, b.CurrentHP = 100000, 100000
+
+	result := SimulateDuel(a, b, 3, 10)
+	assert.Equal(t, uuid.Nil, result.Winner, "Nobody falls, so there's no winner")
+	assert.Equal(t, 10, result.Turns)
+	assert.Positive(t, result.AttackerDamage)
+	assert.Positive(t, result.DefenderDamage)
+}
+
+func TestSimulateDuel_StrongerWinsMore(t *testing.T) {
+	strong, weak := duelist(100, 50), duelist(10, 50)
+
+	wins := 0
+	for seed := int64(0); seed < 100; seed++ {
+		if SimulateDuel(strong, weak, seed, 500).Winner == strong.EntityID {
+			wins++
+		}
+	}
+	assert.Greater(t, wins, 70)
+}
+
+func BenchmarkSimulateDuel(b *testing.B) {
+	a, d := duelist(60, 50), duelist(50, 60)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SimulateDuel(a, d, int64(i), 500)
+	}
+}
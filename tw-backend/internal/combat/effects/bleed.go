@@ -12,23 +12,9 @@ const (
 	BleedDuration      = 20 * time.Second
 )
 
-// ApplyBleed adds bleed
+// ApplyBleed adds bleed, or refreshes it to full strength
 func (m *EffectManager) ApplyBleed(targetID uuid.UUID, now time.Time) {
-	// Prompt doesn't specify stacking for bleed, usually just refreshes or separate stacks.
-	// "Bleed applies 5 damage per 3 seconds" - implies single instance for simplicity unless specified.
-	// "Encourages stationary combat"
-
-	// Let's assume refresh for now to keep it simple, or new instance replaces.
-	m.Bleed = &BleedEffect{
-		EffectID:        uuid.New(),
-		TargetID:        targetID,
-		DamagePerTick:   BleedDamagePerTick,
-		TickInterval:    BleedTickInterval,
-		Duration:        BleedDuration,
-		AppliedAt:       now,
-		LastTickAt:      now,
-		MovementCounter: 0,
-	}
+	m.ApplyEffect(targetID, BleedDefinition, now)
 }
 
 // OnMovement handles movement reduction logic
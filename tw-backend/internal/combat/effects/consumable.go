@@ -0,0 +1,14 @@
+package effects
+
+// Consumable is what consuming an item, such as a potion or food, does to
+// whoever consumes it
+type Consumable struct {
+	Heal    int                 // HP restored at once
+	Stamina int                 // Stamina restored at once
+	Buff    *ModifierDefinition // Temporary stat change; nil for none
+}
+
+// IsEmpty reports whether consuming does nothing
+func (c Consumable) IsEmpty() bool {
+	return c.Heal <= 0 && c.Stamina <= 0 && c.Buff == nil
+}
@@ -13,6 +13,7 @@ func NewManager() *EffectManager {
 type TickResult struct {
 	PoisonDamage int
 	BleedDamage  int
+	EffectDamage map[EffectType]int // From effects applied via ApplyEffect
 }
 
 // Tick processes all active effects
@@ -51,6 +52,9 @@ func (m *EffectManager) Tick(now time.Time) TickResult {
 		}
 	}
 
+	// Effects applied via ApplyEffect
+	res.EffectDamage = m.tickEffects(now)
+
 	// Modifiers cleanup
 	activeModifiers := make([]*StatModifier, 0, len(m.Modifiers))
 	for _, mod := range m.Modifiers {
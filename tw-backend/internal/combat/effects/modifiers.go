@@ -20,6 +20,65 @@ func (m *EffectManager) AddModifier(targetID uuid.UUID, stat string, val int, is
 	m.Modifiers = append(m.Modifiers, mod)
 }
 
+// ModifierDefinition describes a stat modifier applied through ApplyModifier
+type ModifierDefinition struct {
+	Source      string // Re-applications from the same source follow StackPolicy
+	Stat        string
+	Modifier    int
+	IsPercent   bool
+	Duration    time.Duration
+	StackPolicy StackPolicy
+	MaxStacks   int // PolicyStack only; 0 is uncapped
+}
+
+// ApplyModifier adds the modifier described by def, honoring def.StackPolicy
+// when a modifier from the same source on the same stat is still active. A
+// stack at MaxStacks replaces its oldest modifier. Returns false if the
+// application was ignored.
+func (m *EffectManager) ApplyModifier(targetID uuid.UUID, def ModifierDefinition, now time.Time) bool {
+	var active []*StatModifier
+	kept := m.Modifiers[:0]
+	for _, mod := range m.Modifiers {
+		if mod.Source == def.Source && mod.Stat == def.Stat && now.Sub(mod.AppliedAt) < mod.Duration {
+			active = append(active, mod)
+			continue
+		}
+		kept = append(kept, mod)
+	}
+
+	if len(active) > 0 {
+		switch def.StackPolicy {
+		case PolicyIgnoreIfPresent:
+			m.Modifiers = append(kept, active...)
+			return false
+		case PolicyRefresh:
+			mod := active[len(active)-1]
+			mod.Modifier = def.Modifier
+			mod.IsPercent = def.IsPercent
+			mod.Duration = def.Duration
+			mod.AppliedAt = now
+			m.Modifiers = append(kept, mod)
+			return true
+		default: // PolicyStack
+			if def.MaxStacks > 0 && len(active) >= def.MaxStacks {
+				active = active[len(active)-def.MaxStacks+1:]
+			}
+		}
+	}
+
+	m.Modifiers = append(append(kept, active...), &StatModifier{
+		EffectID:  uuid.New(),
+		TargetID:  targetID,
+		Source:    def.Source,
+		Stat:      def.Stat,
+		Modifier:  def.Modifier,
+		IsPercent: def.IsPercent,
+		Duration:  def.Duration,
+		AppliedAt: now,
+	})
+	return true
+}
+
 // CalculateStat applies all active modifiers to a base stat value
 func (m *EffectManager) CalculateStat(stat string, baseValue int, now time.Time) int {
 	flatMod := 0
@@ -42,3 +42,57 @@ func TestModifierCancellation(t *testing.T) {
 		t.Errorf("Expected 55, got %d", val)
 	}
 }
+
+func TestApplyModifier_StackPolicies(t *testing.T) {
+	targetID := uuid.New()
+	start := time.Now()
+	potion := ModifierDefinition{Source: "potion of might", Stat: "Might", Modifier: 10, Duration: time.Minute}
+
+	// Drinks at 0s and 30s, measured at 45s
+	mightAt45 := func(policy StackPolicy, maxStacks int) int {
+		m := NewManager()
+		def := potion
+		def.StackPolicy = policy
+		def.MaxStacks = maxStacks
+		m.ApplyModifier(targetID, def, start)
+		m.ApplyModifier(targetID, def, start.Add(30*time.Second))
+		return m.CalculateStat("Might", 50, start.Add(45*time.Second))
+	}
+
+	if got := mightAt45(PolicyStack, 0); got != 70 {
+		t.Errorf("Stack Might = %d, want 70", got)
+	}
+	if got := mightAt45(PolicyStack, 1); got != 60 {
+		t.Errorf("Stack capped at 1 Might = %d, want 60", got)
+	}
+	if got := mightAt45(PolicyIgnoreIfPresent, 0); got != 60 {
+		t.Errorf("IgnoreIfPresent Might = %d, want 60", got)
+	}
+
+	// A refresh runs a full minute from the second drink
+	m := NewManager()
+	def := potion
+	def.StackPolicy = PolicyRefresh
+	m.ApplyModifier(targetID, def, start)
+	m.ApplyModifier(targetID, def, start.Add(30*time.Second))
+	if got := m.CalculateStat("Might", 50, start.Add(80*time.Second)); got != 60 {
+		t.Errorf("Refreshed Might at 80s = %d, want 60", got)
+	}
+	if len(m.Modifiers) != 1 {
+		t.Errorf("Refresh left %d modifiers, want 1", len(m.Modifiers))
+	}
+}
+
+func TestApplyModifier_SourcesAreIndependent(t *testing.T) {
+	m := NewManager()
+	targetID := uuid.New()
+	now := time.Now()
+
+	m.AddModifier(targetID, "Might", 5, false, time.Minute, now)
+	m.ApplyModifier(targetID, ModifierDefinition{Source: "potion", Stat: "Might", Modifier: 10, Duration: time.Minute, StackPolicy: PolicyRefresh}, now)
+	m.ApplyModifier(targetID, ModifierDefinition{Source: "bread", Stat: "Might", Modifier: 1, Duration: time.Minute, StackPolicy: PolicyRefresh}, now)
+
+	if got := m.CalculateStat("Might", 50, now); got != 66 {
+		t.Errorf("Might = %d, want 66", got)
+	}
+}
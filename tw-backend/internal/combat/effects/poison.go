@@ -13,29 +13,10 @@ const (
 	PoisonDuration     = 30 * time.Second
 )
 
-// ApplyPoison adds or updates poison on the manager
+// ApplyPoison adds a stack of poison, up to MaxPoisonStacks, and refreshes
+// its duration
 func (m *EffectManager) ApplyPoison(targetID uuid.UUID, now time.Time) {
-	if m.Poison == nil {
-		m.Poison = &PoisonEffect{
-			EffectID:      uuid.New(),
-			TargetID:      targetID,
-			Stacks:        1,
-			DamagePerTick: PoisonBaseDamage,
-			TickInterval:  PoisonTickInterval,
-			Duration:      PoisonDuration,
-			AppliedAt:     now,
-			LastTickAt:    now,
-		}
-	} else {
-		// Stack up to max
-		if m.Poison.Stacks < MaxPoisonStacks {
-			m.Poison.Stacks++
-			m.Poison.DamagePerTick = PoisonBaseDamage * m.Poison.Stacks
-		}
-		// Refresh duration
-		m.Poison.Duration = PoisonDuration
-		m.Poison.AppliedAt = now
-	}
+	m.ApplyEffect(targetID, PoisonDefinition, now)
 }
 
 // TickPoison processes poison damage
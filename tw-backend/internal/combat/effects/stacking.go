@@ -0,0 +1,240 @@
+package effects
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StackPolicy decides what re-applying an effect to an entity already
+// carrying it does
+type StackPolicy string
+
+const (
+	// PolicyStack adds an independent instance that ticks and expires on its
+	// own; for poison and bleed, another stack on the single effect
+	PolicyStack StackPolicy = "stack"
+	// PolicyRefresh resets the duration of the single existing instance
+	PolicyRefresh StackPolicy = "refresh"
+	// PolicyIgnoreIfPresent leaves the existing instance untouched
+	PolicyIgnoreIfPresent StackPolicy = "ignore_if_present"
+)
+
+// EffectDefinition describes an effect applied through ApplyEffect
+type EffectDefinition struct {
+	Type          EffectType
+	StackPolicy   StackPolicy
+	MaxStacks     int // PolicyStack only; 0 is uncapped
+	DamagePerTick int
+	TickInterval  time.Duration
+	Duration      time.Duration
+}
+
+// Definitions for the damage-over-time effects
+var (
+	PoisonDefinition = EffectDefinition{
+		Type:          EffectPoison,
+		StackPolicy:   PolicyStack,
+		MaxStacks:     MaxPoisonStacks,
+		DamagePerTick: PoisonBaseDamage,
+		TickInterval:  PoisonTickInterval,
+		Duration:      PoisonDuration,
+	}
+	BleedDefinition = EffectDefinition{
+		Type:          EffectBleed,
+		StackPolicy:   PolicyRefresh,
+		MaxStacks:     1,
+		DamagePerTick: BleedDamagePerTick,
+		TickInterval:  BleedTickInterval,
+		Duration:      BleedDuration,
+	}
+)
+
+// EffectInstance is one application of an EffectDefinition
+type EffectInstance struct {
+	EffectID      uuid.UUID
+	TargetID      uuid.UUID
+	Type          EffectType
+	DamagePerTick int
+	TickInterval  time.Duration
+	Duration      time.Duration
+	AppliedAt     time.Time
+	LastTickAt    time.Time
+}
+
+// ApplyEffect applies def to the entity, honoring def.StackPolicy when an
+// instance of def.Type is already active. A stack at MaxStacks replaces its
+// oldest instance. Poison and bleed live in the manager's Poison and Bleed
+// fields instead: their stacks add damage and share one timer. Returns false
+// if the application was ignored.
+func (m *EffectManager) ApplyEffect(targetID uuid.UUID, def EffectDefinition, now time.Time) bool {
+	switch def.Type {
+	case EffectPoison:
+		return m.applyPoison(targetID, def, now)
+	case EffectBleed:
+		return m.applyBleed(targetID, def, now)
+	}
+
+	if m.Effects == nil {
+		m.Effects = make(map[EffectType][]*EffectInstance)
+	}
+	active := m.Effects[def.Type]
+
+	if len(active) > 0 {
+		switch def.StackPolicy {
+		case PolicyIgnoreIfPresent:
+			return false
+		case PolicyRefresh:
+			// Keep the tick cadence; only the remaining duration resets
+			inst := active[len(active)-1]
+			inst.DamagePerTick = def.DamagePerTick
+			inst.TickInterval = def.TickInterval
+			inst.Duration = def.Duration
+			inst.AppliedAt = now
+			m.Effects[def.Type] = []*EffectInstance{inst}
+			return true
+		default: // PolicyStack
+			if def.MaxStacks > 0 && len(active) >= def.MaxStacks {
+				active = active[len(active)-def.MaxStacks+1:]
+			}
+		}
+	}
+
+	m.Effects[def.Type] = append(active, &EffectInstance{
+		EffectID:      uuid.New(),
+		TargetID:      targetID,
+		Type:          def.Type,
+		DamagePerTick: def.DamagePerTick,
+		TickInterval:  def.TickInterval,
+		Duration:      def.Duration,
+		AppliedAt:     now,
+		LastTickAt:    now,
+	})
+	return true
+}
+
+// applyPoison applies def to the Poison field. A stack raises the damage by
+// def.DamagePerTick, up to MaxStacks, and resets the duration.
+func (m *EffectManager) applyPoison(targetID uuid.UUID, def EffectDefinition, now time.Time) bool {
+	if m.Poison == nil {
+		m.Poison = &PoisonEffect{
+			EffectID:      uuid.New(),
+			TargetID:      targetID,
+			Stacks:        1,
+			DamagePerTick: def.DamagePerTick,
+			TickInterval:  def.TickInterval,
+			Duration:      def.Duration,
+			AppliedAt:     now,
+			LastTickAt:    now,
+		}
+		return true
+	}
+
+	switch def.StackPolicy {
+	case PolicyIgnoreIfPresent:
+		return false
+	case PolicyStack:
+		if def.MaxStacks <= 0 || m.Poison.Stacks < def.MaxStacks {
+			m.Poison.Stacks++
+		}
+	}
+	// Keep the tick cadence; the damage follows the stacks
+	m.Poison.DamagePerTick = def.DamagePerTick * m.Poison.Stacks
+	m.Poison.TickInterval = def.TickInterval
+	m.Poison.Duration = def.Duration
+	m.Poison.AppliedAt = now
+	return true
+}
+
+// applyBleed applies def to the Bleed field. Fresh wounds undo the healing
+// from moving; a stack raises the damage by def.DamagePerTick, up to
+// MaxStacks.
+func (m *EffectManager) applyBleed(targetID uuid.UUID, def EffectDefinition, now time.Time) bool {
+	if m.Bleed == nil {
+		m.Bleed = &BleedEffect{
+			EffectID:      uuid.New(),
+			TargetID:      targetID,
+			Stacks:        1,
+			DamagePerTick: def.DamagePerTick,
+			TickInterval:  def.TickInterval,
+			Duration:      def.Duration,
+			AppliedAt:     now,
+			LastTickAt:    now,
+		}
+		return true
+	}
+
+	switch def.StackPolicy {
+	case PolicyIgnoreIfPresent:
+		return false
+	case PolicyStack:
+		if def.MaxStacks <= 0 || m.Bleed.Stacks < def.MaxStacks {
+			m.Bleed.Stacks++
+		}
+	}
+	m.Bleed.DamagePerTick = def.DamagePerTick * m.Bleed.Stacks
+	m.Bleed.TickInterval = def.TickInterval
+	m.Bleed.Duration = def.Duration
+	m.Bleed.AppliedAt = now
+	m.Bleed.MovementCounter = 0
+	return true
+}
+
+// StackCount returns how many stacks of effectType are active
+func (m *EffectManager) StackCount(effectType EffectType) int {
+	switch effectType {
+	case EffectPoison:
+		if m.Poison == nil {
+			return 0
+		}
+		return m.Poison.Stacks
+	case EffectBleed:
+		if m.Bleed == nil {
+			return 0
+		}
+		return m.Bleed.Stacks
+	}
+	return len(m.Effects[effectType])
+}
+
+// Tick processes instance damage
+// Returns damage amount if tick occurred, 0 otherwise
+func (e *EffectInstance) Tick(now time.Time) int {
+	if now.Sub(e.LastTickAt) >= e.TickInterval {
+		e.LastTickAt = now
+		return e.DamagePerTick
+	}
+	return 0
+}
+
+// IsExpired checks if the instance has run its course
+func (e *EffectInstance) IsExpired(now time.Time) bool {
+	return now.Sub(e.AppliedAt) >= e.Duration
+}
+
+// tickEffects ticks every instance applied through ApplyEffect, dropping
+// expired ones, and returns the damage dealt per effect type
+func (m *EffectManager) tickEffects(now time.Time) map[EffectType]int {
+	var dealt map[EffectType]int
+	for effectType, instances := range m.Effects {
+		active := instances[:0]
+		for _, inst := range instances {
+			if inst.IsExpired(now) {
+				continue
+			}
+			if dmg := inst.Tick(now); dmg > 0 {
+				if dealt == nil {
+					dealt = make(map[EffectType]int)
+				}
+				dealt[effectType] += dmg
+			}
+			active = append(active, inst)
+		}
+		if len(active) == 0 {
+			delete(m.Effects, effectType)
+		} else {
+			m.Effects[effectType] = active
+		}
+	}
+	return dealt
+}
@@ -0,0 +1,154 @@
+package effects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// totalDoT applies def at each offset from start, ticks every second for a
+// minute, and returns the total damage over time dealt
+func totalDoT(def EffectDefinition, offsets ...time.Duration) int {
+	m := NewManager()
+	targetID := uuid.New()
+	start := time.Now()
+
+	total := 0
+	for s := time.Duration(0); s <= time.Minute; s += time.Second {
+		now := start.Add(s)
+		for _, offset := range offsets {
+			if offset == s {
+				m.ApplyEffect(targetID, def, now)
+			}
+		}
+		res := m.Tick(now)
+		total += res.PoisonDamage + res.BleedDamage + res.EffectDamage[def.Type]
+	}
+	return total
+}
+
+func TestApplyEffect_StackVersusRefreshDamage(t *testing.T) {
+	stacking := PoisonDefinition
+	stacking.StackPolicy = PolicyStack
+	refreshing := PoisonDefinition
+	refreshing.StackPolicy = PolicyRefresh
+
+	// 3 at 5s, then two stacks for 6 every 5s from 10s until they expire at 40s
+	if got := totalDoT(stacking, 0, 10*time.Second); got != 39 {
+		t.Errorf("Stack total damage = %d, want 39", got)
+	}
+	// One instance refreshed at 10s runs until 40s: 7 ticks
+	if got := totalDoT(refreshing, 0, 10*time.Second); got != 21 {
+		t.Errorf("Refresh total damage = %d, want 21", got)
+	}
+	// The second application is dropped: 5 ticks
+	ignoring := PoisonDefinition
+	ignoring.StackPolicy = PolicyIgnoreIfPresent
+	if got := totalDoT(ignoring, 0, 10*time.Second); got != 15 {
+		t.Errorf("IgnoreIfPresent total damage = %d, want 15", got)
+	}
+}
+
+func TestApplyEffect_Policies(t *testing.T) {
+	targetID := uuid.New()
+	now := time.Now()
+
+	m := NewManager()
+	for i := 0; i < 3; i++ {
+		m.ApplyEffect(targetID, BleedDefinition, now.Add(time.Duration(i)*time.Second))
+	}
+	if got := m.StackCount(EffectBleed); got != 1 {
+		t.Errorf("Refresh should keep 1 instance, got %d", got)
+	}
+	if got := m.Bleed.AppliedAt; !got.Equal(now.Add(2 * time.Second)) {
+		t.Errorf("Refresh should reset duration from the latest application, applied at %v", got)
+	}
+
+	def := EffectDefinition{Type: EffectDebuff, StackPolicy: PolicyIgnoreIfPresent, Duration: time.Minute}
+	if !m.ApplyEffect(targetID, def, now) {
+		t.Error("First application should apply")
+	}
+	if m.ApplyEffect(targetID, def, now.Add(time.Second)) {
+		t.Error("Second application should be ignored")
+	}
+	if got := m.Effects[EffectDebuff][0].AppliedAt; !got.Equal(now) {
+		t.Errorf("Ignored application changed the instance, applied at %v", got)
+	}
+}
+
+func TestApplyEffect_MaxStacks(t *testing.T) {
+	m := NewManager()
+	targetID := uuid.New()
+	now := time.Now()
+
+	for i := 0; i < MaxPoisonStacks+2; i++ {
+		m.ApplyEffect(targetID, PoisonDefinition, now.Add(time.Duration(i)*time.Second))
+	}
+	if got := m.StackCount(EffectPoison); got != MaxPoisonStacks {
+		t.Fatalf("Expected %d stacks, got %d", MaxPoisonStacks, got)
+	}
+	if got := m.Poison.DamagePerTick; got != PoisonBaseDamage*MaxPoisonStacks {
+		t.Errorf("Capped poison deals %d per tick, want %d", got, PoisonBaseDamage*MaxPoisonStacks)
+	}
+
+	uncapped := PoisonDefinition
+	uncapped.MaxStacks = 0
+	for i := 0; i < 10; i++ {
+		m.ApplyEffect(targetID, uncapped, now)
+	}
+	if got := m.StackCount(EffectPoison); got != MaxPoisonStacks+10 {
+		t.Errorf("MaxStacks 0 should be uncapped, got %d stacks", got)
+	}
+}
+
+func TestTick_DropsExpiredEffects(t *testing.T) {
+	m := NewManager()
+	now := time.Now()
+	m.ApplyEffect(uuid.New(), PoisonDefinition, now)
+
+	m.Tick(now.Add(PoisonDuration))
+	if got := m.StackCount(EffectPoison); got != 0 {
+		t.Errorf("Expected expired poison to be dropped, %d stacks remain", got)
+	}
+}
+
+func TestApplyEffect_RoutesPoisonAndBleedToTheirFields(t *testing.T) {
+	m := NewManager()
+	targetID := uuid.New()
+	now := time.Now()
+
+	// ApplyPoison and ApplyEffect share one poison, so stacks aren't counted twice
+	m.ApplyPoison(targetID, now)
+	m.ApplyEffect(targetID, PoisonDefinition, now)
+	if m.Poison == nil || m.Poison.Stacks != 2 {
+		t.Fatalf("Expected one poison with 2 stacks, got %+v", m.Poison)
+	}
+	if len(m.Effects[EffectPoison]) != 0 {
+		t.Error("Poison should not also be tracked as an instance")
+	}
+
+	// Bleed honors its definition's policy
+	stacking := BleedDefinition
+	stacking.StackPolicy = PolicyStack
+	stacking.MaxStacks = 2
+	for i := 0; i < 3; i++ {
+		m.ApplyEffect(targetID, stacking, now)
+	}
+	if got := m.Bleed.DamagePerTick; got != 2*BleedDamagePerTick {
+		t.Errorf("Two bleed stacks deal %d per tick, want %d", got, 2*BleedDamagePerTick)
+	}
+	ignoring := BleedDefinition
+	ignoring.StackPolicy = PolicyIgnoreIfPresent
+	if m.ApplyEffect(targetID, ignoring, now) {
+		t.Error("Bleed with IgnoreIfPresent should not re-apply")
+	}
+
+	res := m.Tick(now.Add(5 * time.Second))
+	if res.PoisonDamage != 2*PoisonBaseDamage || res.BleedDamage != 2*BleedDamagePerTick {
+		t.Errorf("Tick dealt poison %d, bleed %d", res.PoisonDamage, res.BleedDamage)
+	}
+	if len(res.EffectDamage) != 0 {
+		t.Errorf("Poison and bleed damage counted twice: %v", res.EffectDamage)
+	}
+}
@@ -50,3 +50,11 @@ func (m *EffectManager) IsStunned(now time.Time) bool {
 	}
 	return now.Before(m.Stun.EndsAt)
 }
+
+// StunRemaining returns how long the active stun has left, or 0 if none
+func (m *EffectManager) StunRemaining(now time.Time) time.Duration {
+	if !m.IsStunned(now) {
+		return 0
+	}
+	return m.Stun.EndsAt.Sub(now)
+}
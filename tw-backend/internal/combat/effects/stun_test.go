@@ -47,3 +47,19 @@ func TestStunCap(t *testing.T) {
 		t.Error("Stun exceeded max duration cap")
 	}
 }
+
+func TestStunRemaining(t *testing.T) {
+	m := NewManager()
+	now := time.Now()
+	if got := m.StunRemaining(now); got != 0 {
+		t.Errorf("Expected no stun remaining, got %v", got)
+	}
+
+	m.ApplyStun(uuid.New(), 3*time.Second, now)
+	if got := m.StunRemaining(now.Add(time.Second)); got != 2*time.Second {
+		t.Errorf("Expected 2s remaining, got %v", got)
+	}
+	if got := m.StunRemaining(now.Add(4 * time.Second)); got != 0 {
+		t.Errorf("Expected expired stun to leave 0, got %v", got)
+	}
+}
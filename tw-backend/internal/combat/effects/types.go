@@ -52,6 +52,7 @@ type SlowEffect struct {
 type BleedEffect struct {
 	EffectID        uuid.UUID
 	TargetID        uuid.UUID
+	Stacks          int           // 1 with BleedDefinition
 	DamagePerTick   int           // 5 damage
 	TickInterval    time.Duration // 3 seconds
 	Duration        time.Duration // 20 seconds
@@ -64,6 +65,7 @@ type BleedEffect struct {
 type StatModifier struct {
 	EffectID  uuid.UUID
 	TargetID  uuid.UUID
+	Source    string // What granted it, e.g. a potion; empty for AddModifier
 	Stat      string // e.g., "Might", "Agility"
 	Modifier  int    // +10, -15
 	IsPercent bool   // True = percentage, false = flat
@@ -78,4 +80,5 @@ type EffectManager struct {
 	Slow      *SlowEffect
 	Bleed     *BleedEffect
 	Modifiers []*StatModifier
+	Effects   map[EffectType][]*EffectInstance // Applied via ApplyEffect
 }
@@ -2,10 +2,12 @@ package crafting
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"tw-backend/internal/errors"
 	"tw-backend/internal/game/services/inventory"
+	"tw-backend/internal/skills"
 	"tw-backend/internal/worldentity"
 
 	"github.com/google/uuid"
@@ -27,69 +29,62 @@ func NewService(repo Repository, invService *inventory.Service, worldEntService
 	}
 }
 
-// Craft attempts to craft an item using a recipe
-func (s *Service) Craft(ctx context.Context, characterID uuid.UUID, recipeID uuid.UUID, stationEntityID *uuid.UUID) (*CraftResult, error) {
+// Craft attempts to craft an item using a recipe. A recipe needing a station
+// must be given one that satisfies it, and the character must carry every
+// ingredient (or a substitute) and have room for the output before any is
+// consumed. The crafter's level in the recipe's skill sets the output quality.
+func (s *Service) Craft(ctx context.Context, characterID uuid.UUID, recipeID uuid.UUID, stationEntityID *uuid.UUID, skillLevel int) (*CraftResult, error) {
 	// 1. Get the recipe
 	recipe, err := s.repo.GetRecipe(recipeID)
-	if err != nil {
-		return nil, errors.NewNotFound("recipe not found")
+	if err != nil || recipe == nil {
+		return nil, errors.Wrap(errors.ErrRecipeNotFound, "recipe not found", err)
 	}
 
 	// 2. Validate station (if required)
 	if recipe.RequiredStation != nil {
 		if stationEntityID == nil {
-			return nil, errors.NewInvalidInput("crafting station required: %s", recipe.RequiredStation.StationType)
+			return nil, errors.Wrap(errors.ErrCraftingStationNeeded, fmt.Sprintf("crafting station required: %s", recipe.RequiredStation.StationType), nil)
 		}
-
-		// Verify station type and tier via WorldEntityService
-		// This presumes WorldEntity has properties describing its crafting capabilities
 		station, err := s.worldEntityService.GetByID(ctx, *stationEntityID)
-		if err != nil {
-			return nil, errors.NewNotFound("crafting station not found")
+		if err != nil || station == nil {
+			return nil, errors.Wrap(errors.ErrCraftingStationNeeded, "crafting station not found", err)
 		}
-
-		// TODO: Check if station.Properties matches recipe.RequiredStation
-		// For now, we assume if the entity exists and was passed, it's valid,
-		// but in a real implementation we would strictly check tags/properties.
-		_ = station
-	}
-
-	// 3. Get character constraints (inventory)
-	// We need to check if the user has the ingredients.
-	// The InventoryService generic 'RemoveItem' might need to be called multiple times,
-	// or we might need a transaction. For now, we'll check availability first.
-
-	// Get all items to check quantities (Optimization: InventoryService should have HasItems method)
-	// For MVP, we will optimistically attempt to remove ingredients.
-	// Ideally, InventoryService should support atomic bulk removal.
-
-	// 4. Check & Remove Ingredients
-	for _, ing := range recipe.Ingredients {
-		// Try to remove primary ingredient
-		err := s.inventoryService.RemoveItem(ctx, characterID, ing.ResourceID, ing.Quantity)
-		if err != nil {
-			// If failed, we should rollback previous removals.
-			// Since InventoryService doesn't expose a transaction yet, this is risky.
-			// TODO: Add Transactional Inventory Support.
-			return nil, errors.NewInvalidInput("missing ingredient: %s", ing.ResourceID) // Ideally return better error
+		if !recipe.RequiredStation.SatisfiedBy(station) {
+			return nil, errors.Wrap(errors.ErrCraftingStationNeeded, fmt.Sprintf("%s is not a suitable %s", station.Name, recipe.RequiredStation.StationType), nil)
 		}
 	}
 
-	// 5. Calculate Result Quality
-	// TODO: Use character skills and station modifiers
-	quality := ItemQuality(1) // Default to standard quality for now
-
-	// 6. Add Result to Inventory
-	// If output is a specific ItemID (like a unique sword), we might need to "create" it first?
-	// Or is Recipe.Output.ItemID a reference to an ItemTemplate?
-	// Assuming ItemID in Output is a Template ID if it's generic, or we create a new instance.
-	// For resource crafting (wood -> plank), it's just an ID.
+	// 3. Check ingredients against the inventory before removing any, since
+	// the inventory has no transactions to roll back a partial removal
+	items, err := s.inventoryService.GetInventory(ctx, characterID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to read inventory: %v", err)
+	}
+	consume, err := planIngredients(recipe.Ingredients, items)
+	if err != nil {
+		return nil, err
+	}
 
-	err = s.inventoryService.AddItem(ctx, characterID, recipe.Output.ItemID, recipe.Output.Quantity, map[string]interface{}{
+	// 4. Make sure the output fits, for the same reason
+	quality := QualityForSkill(skillLevel)
+	output := map[string]interface{}{
 		"quality":    quality,
 		"crafted_at": time.Now(),
 		"crafter_id": characterID,
-	})
+	}
+	if err := s.inventoryService.CheckRoom(ctx, characterID, recipe.Output.ItemID, inventory.WeightOf(output)*float64(recipe.Output.Quantity)); err != nil {
+		return nil, err
+	}
+
+	// 5. Remove ingredients
+	for itemID, quantity := range consume {
+		if err := s.inventoryService.RemoveItem(ctx, characterID, itemID, quantity); err != nil {
+			return nil, errors.NewInternalError("failed to consume ingredient %s: %v", itemID, err)
+		}
+	}
+
+	// 6. Add Result to Inventory
+	err = s.inventoryService.AddItem(ctx, characterID, recipe.Output.ItemID, recipe.Output.Quantity, output)
 	if err != nil {
 		// Big problem: we removed ingredients but failed to add result.
 		// Log this critically.
@@ -112,6 +107,39 @@ func (s *Service) Craft(ctx context.Context, characterID uuid.UUID, recipeID uui
 	}, nil
 }
 
+// planIngredients works out how much of each inventory item a recipe
+// consumes, taking an ingredient's substitutes when the primary resource
+// runs short. It returns ErrMissingIngredients if any ingredient can't be met.
+func planIngredients(ingredients []Ingredient, items []inventory.InventoryItem) (map[uuid.UUID]int, error) {
+	available := make(map[uuid.UUID]int)
+	for _, item := range items {
+		available[item.ItemID] += item.Quantity
+	}
+
+	consume := make(map[uuid.UUID]int)
+	for _, ing := range ingredients {
+		met := false
+		for _, id := range append([]uuid.UUID{ing.ResourceID}, ing.Substitute...) {
+			if available[id] >= ing.Quantity {
+				available[id] -= ing.Quantity
+				consume[id] += ing.Quantity
+				met = true
+				break
+			}
+		}
+		if !met {
+			return nil, errors.Wrap(errors.ErrMissingIngredients, fmt.Sprintf("missing ingredient: %s x%d", ing.ResourceID, ing.Quantity), nil)
+		}
+	}
+	return consume, nil
+}
+
+// QualityForSkill maps a crafter's skill level (0-100) to the quality of
+// what they make, one tier per 20 levels
+func QualityForSkill(skillLevel int) ItemQuality {
+	return ItemQuality(skills.GetQualityTier(skillLevel))
+}
+
 // GetAvailableRecipes returns recipes the character can craft
 func (s *Service) GetAvailableRecipes(ctx context.Context, characterID uuid.UUID) ([]*Recipe, error) {
 	// 1. Get recipes known by the character
@@ -139,7 +167,7 @@ func (s *Service) FindRecipeByName(ctx context.Context, name string) (*Recipe, e
 
 	// Return first exact match or first result
 	if len(recipes) == 0 {
-		return nil, errors.NewNotFound("recipe '%s' not found", name)
+		return nil, errors.Wrap(errors.ErrRecipeNotFound, fmt.Sprintf("recipe '%s' not found", name), nil)
 	}
 
 	return recipes[0], nil
@@ -0,0 +1,44 @@
+package crafting
+
+import (
+	"strings"
+
+	"tw-backend/internal/worldentity"
+)
+
+// Metadata keys marking a world entity as a crafting station
+const (
+	MetaStationType = "station_type" // e.g. "forge", matching CraftingStation.StationType
+	MetaStationTier = "station_tier" // 1-5; unset counts as 1
+)
+
+// StationType returns the kind of crafting station an entity is, or "" if
+// it isn't one
+func StationType(e *worldentity.WorldEntity) string {
+	if e == nil || e.Metadata == nil {
+		return ""
+	}
+	kind, _ := e.Metadata[MetaStationType].(string)
+	return kind
+}
+
+// StationTier returns a crafting station's tier
+func StationTier(e *worldentity.WorldEntity) int {
+	switch v := e.Metadata[MetaStationTier].(type) {
+	case float64: // Decoded from JSON
+		return int(v)
+	case int:
+		return v
+	}
+	return 1
+}
+
+// SatisfiedBy reports whether a world entity is a station of the required
+// type and at least the required tier
+func (cs *CraftingStation) SatisfiedBy(e *worldentity.WorldEntity) bool {
+	kind := StationType(e)
+	if kind == "" || !strings.EqualFold(kind, cs.StationType) {
+		return false
+	}
+	return StationTier(e) >= cs.MinStationTier
+}
@@ -3,6 +3,8 @@ package atmosphere
 import (
 	"math"
 	"testing"
+
+	"tw-backend/internal/worldgen/weather"
 )
 
 // TestNewAtmosphere verifies initial atmospheric composition
@@ -205,3 +207,108 @@ func TestCarbonCycle_NegativeFeedback(t *testing.T) {
 		t.Errorf("Negative feedback too weak: ratio=%f, want >3", ratio)
 	}
 }
+
+// TestCarbonCycle_WetterWorldDrawsDownFaster verifies weathering uses the
+// weather system's land rainfall: at the same temperature, rain removes CO2
+func TestCarbonCycle_WetterWorldDrawsDownFaster(t *testing.T) {
+	landClimate := func(rainfall float64) []weather.ClimateData {
+		climate := make([]weather.ClimateData, 8*4)
+		for i := range climate {
+			climate[i] = weather.ClimateData{Temperature: 20, AnnualRainfall: rainfall, SoilDrainage: 0.6}
+		}
+		return climate
+	}
+
+	drawDown := func(climate []weather.ClimateData) float64 {
+		atm := NewAtmosphere(0)
+		precip := weather.GlobalAveragePrecipitation(climate, 8)
+		for step := 0; step < 10; step++ {
+			rate := CalculateWeatheringRate(20, precip, 0.3, atm.CO2Mass)
+			atm.SimulateCarbonCycle(100_000, 0, rate)
+		}
+		return atm.CO2Mass
+	}
+
+	wetCO2 := drawDown(landClimate(2000))
+	dryCO2 := drawDown(landClimate(250))
+	if wetCO2 >= dryCO2 {
+		t.Errorf("Wet world should hold less CO2: wet=%f, dry=%f", wetCO2, dryCO2)
+	}
+}
+
+// TestGreenhouseSensitivity verifies a higher sensitivity yields a larger offset for the same CO2
+func TestGreenhouseSensitivity(t *testing.T) {
+	earth := DefaultConfig(0)
+	runaway := earth
+	runaway.DegreesPerDoubling = 8.0
+
+	earthAtm, err := NewAtmosphereWithConfig(0, earth)
+	if err != nil {
+		t.Fatalf("Earth config should be valid: %v", err)
+	}
+	runawayAtm, err := NewAtmosphereWithConfig(0, runaway)
+	if err != nil {
+		t.Fatalf("Runaway config should be valid: %v", err)
+	}
+
+	if runawayAtm.GreenhouseFactor <= earthAtm.GreenhouseFactor {
+		t.Errorf("High sensitivity should warm more: got %f, Earth %f",
+			runawayAtm.GreenhouseFactor, earthAtm.GreenhouseFactor)
+	}
+	want := earthAtm.GreenhouseFactor * 8.0 / DefaultDegreesPerDoubling
+	if math.Abs(runawayAtm.GreenhouseFactor-want) > 0.01 {
+		t.Errorf("Offset should scale with sensitivity: got %f, want %f", runawayAtm.GreenhouseFactor, want)
+	}
+}
+
+// TestCustomCompositionStats verifies a custom starting composition flows into GetStats
+func TestCustomCompositionStats(t *testing.T) {
+	atm, err := NewAtmosphereWithConfig(0, MarsConfig())
+	if err != nil {
+		t.Fatalf("Mars config should be valid: %v", err)
+	}
+
+	stats := atm.GetStats()
+	mars := MarsConfig()
+	wantPressure := mars.CO2Mass + mars.N2Mass + mars.O2Mass
+	if math.Abs(stats.TotalPressure-wantPressure) > 1e-9 {
+		t.Errorf("TotalPressure = %f, want %f", stats.TotalPressure, wantPressure)
+	}
+	wantPPM := mars.CO2Mass / wantPressure * 1_000_000
+	if math.Abs(stats.CO2_ppm-wantPPM) > 1 {
+		t.Errorf("CO2_ppm = %f, want %f", stats.CO2_ppm, wantPPM)
+	}
+	if stats.GreenhouseOffset != atm.GreenhouseFactor {
+		t.Errorf("GreenhouseOffset = %f, want %f", stats.GreenhouseOffset, atm.GreenhouseFactor)
+	}
+}
+
+// TestConfigValidation rejects physically impossible configs
+func TestConfigValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr bool
+	}{
+		{"default is valid", func(c *Config) {}, false},
+		{"negative CO2", func(c *Config) { c.CO2Mass = -1 }, true},
+		{"negative N2", func(c *Config) { c.N2Mass = -0.1 }, true},
+		{"negative O2", func(c *Config) { c.O2Mass = -0.1 }, true},
+		{"zero sensitivity", func(c *Config) { c.DegreesPerDoubling = 0 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig(0)
+			tt.mutate(&cfg)
+			_, err := NewAtmosphereWithConfig(0, cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAtmosphereWithConfig error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ConfigForPreset("jupiter", 0); err == nil {
+		t.Error("Unknown preset should be rejected")
+	}
+}
@@ -0,0 +1,98 @@
+package atmosphere
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultDegreesPerDoubling is Earth's climate sensitivity (°C per CO2 doubling, IPCC consensus)
+const DefaultDegreesPerDoubling = 3.0
+
+// Config sets an atmosphere's starting composition and greenhouse sensitivity
+type Config struct {
+	// Starting masses (in Earth atmospheres - 1.0 = 1 atm)
+	CO2Mass float64
+	N2Mass  float64
+	O2Mass  float64
+
+	// DegreesPerDoubling is the greenhouse offset added per doubling of CO2 (°C).
+	// Higher values model runaway-prone worlds.
+	DegreesPerDoubling float64
+}
+
+// DefaultConfig returns Earth's composition for the given starting year
+//
+// Early Earth (Hadean/Archean): Volcanic CO2-rich reducing atmosphere
+// - High CO2 (~50× modern) to compensate for faint young Sun
+// - Minimal N2, no O2 (pre-oxygenic)
+//
+// Modern Earth: N2-O2 atmosphere with trace CO2
+// - Trace CO2 (~400 ppm)
+// - N2-dominated (78%)
+// - O2 from photosynthesis (21%)
+func DefaultConfig(startYear int64) Config {
+	if startYear < 2_000_000_000 {
+		// Early Earth (before 2B years ago): High CO2
+		// Need strong greenhouse to compensate for 70-80% solar luminosity
+		return Config{
+			CO2Mass:            50.0, // 50 atm CO2 (50× total modern atmosphere!)
+			N2Mass:             0.5,  // Minimal N2
+			O2Mass:             0.0,  // Pre-oxygenic photosynthesis
+			DegreesPerDoubling: DefaultDegreesPerDoubling,
+		}
+	}
+	// Modern-like Earth: Post-Great Oxidation Event
+	return Config{
+		CO2Mass:            0.0006, // ~400 ppm (0.06% of 1 atm)
+		N2Mass:             0.78,   // 78% of 1 atm
+		O2Mass:             0.21,   // 21% of 1 atm
+		DegreesPerDoubling: DefaultDegreesPerDoubling,
+	}
+}
+
+// VenusConfig returns a dense CO2 atmosphere prone to runaway greenhouse
+// (~92 atm, 96.5% CO2, 3.5% N2)
+func VenusConfig() Config {
+	return Config{
+		CO2Mass:            89.0,
+		N2Mass:             3.2,
+		O2Mass:             0.0,
+		DegreesPerDoubling: 8.0, // Water vapor and cloud feedbacks amplify CO2 forcing
+	}
+}
+
+// MarsConfig returns a thin CO2 atmosphere (~0.006 atm, 95% CO2, 3% N2)
+func MarsConfig() Config {
+	return Config{
+		CO2Mass:            0.0057,
+		N2Mass:             0.0002,
+		O2Mass:             0.00001,
+		DegreesPerDoubling: DefaultDegreesPerDoubling,
+	}
+}
+
+// ConfigForPreset returns the named preset ("earth", "venus", "mars").
+// Earth uses DefaultConfig for the starting year.
+func ConfigForPreset(name string, startYear int64) (Config, error) {
+	switch strings.ToLower(name) {
+	case "", "earth":
+		return DefaultConfig(startYear), nil
+	case "venus":
+		return VenusConfig(), nil
+	case "mars":
+		return MarsConfig(), nil
+	default:
+		return Config{}, fmt.Errorf("unknown atmosphere preset %q (use earth, venus, or mars)", name)
+	}
+}
+
+// Validate checks the config is physically sane
+func (c Config) Validate() error {
+	if c.CO2Mass < 0 || c.N2Mass < 0 || c.O2Mass < 0 {
+		return fmt.Errorf("atmospheric masses must be non-negative (CO2=%g, N2=%g, O2=%g)", c.CO2Mass, c.N2Mass, c.O2Mass)
+	}
+	if c.DegreesPerDoubling <= 0 {
+		return fmt.Errorf("greenhouse sensitivity must be positive, got %g", c.DegreesPerDoubling)
+	}
+	return nil
+}
@@ -14,6 +14,9 @@ type Atmosphere struct {
 	N2Mass  float64 // Nitrogen (inert filler gas)
 	O2Mass  float64 // Oxygen (from photosynthesis, not yet modeled)
 
+	// Greenhouse sensitivity (°C per CO2 doubling, 0 = DefaultDegreesPerDoubling)
+	DegreesPerDoubling float64
+
 	// Derived properties
 	TotalMass        float64 // Total atmospheric mass (atm)
 	GreenhouseFactor float64 // Temperature offset from greenhouse effect (°C)
@@ -29,44 +32,37 @@ type AtmosphereStats struct {
 	GreenhouseOffset float64 // Temperature contribution from greenhouse effect (°C)
 }
 
-// NewAtmosphere creates initial atmospheric composition based on planetary age
-//
-// Early Earth (Hadean/Archean): Volcanic CO2-rich reducing atmosphere
-// - High CO2 (~50× modern) to compensate for faint young Sun
-// - Minimal N2, no O2 (pre-oxygenic)
-//
-// Modern Earth: N2-O2 atmosphere with trace CO2
-// - Trace CO2 (~400 ppm)
-// - N2-dominated (78%)
-// - O2 from photosynthesis (21%)
+// NewAtmosphere creates Earth's atmospheric composition for the given starting
+// year (see DefaultConfig)
 func NewAtmosphere(startYear int64) *Atmosphere {
-	atm := &Atmosphere{
-		TotalYearsSimulated: startYear,
+	atm, _ := NewAtmosphereWithConfig(startYear, DefaultConfig(startYear))
+	return atm
+}
+
+// NewAtmosphereWithConfig creates an atmosphere with a custom starting
+// composition and greenhouse sensitivity
+func NewAtmosphereWithConfig(startYear int64, cfg Config) (*Atmosphere, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
 
-	// Initialize based on starting year
-	if startYear < 2_000_000_000 {
-		// Early Earth (before 2B years ago): High CO2
-		// Need strong greenhouse to compensate for 70-80% solar luminosity
-		atm.CO2Mass = 50.0 // 50 atm CO2 (50× total modern atmosphere!)
-		atm.N2Mass = 0.5   // Minimal N2
-		atm.O2Mass = 0.0   // Pre-oxygenic photosynthesis
-	} else {
-		// Modern-like Earth: Post-Great Oxidation Event
-		atm.CO2Mass = 0.0006 // ~400 ppm (0.06% of 1 atm)
-		atm.N2Mass = 0.78    // 78% of 1 atm
-		atm.O2Mass = 0.21    // 21% of 1 atm
+	atm := &Atmosphere{
+		CO2Mass:             cfg.CO2Mass,
+		N2Mass:              cfg.N2Mass,
+		O2Mass:              cfg.O2Mass,
+		DegreesPerDoubling:  cfg.DegreesPerDoubling,
+		TotalYearsSimulated: startYear,
 	}
 
 	atm.updateDerivedProperties()
-	return atm
+	return atm, nil
 }
 
 // updateDerivedProperties calculates greenhouse factor and total mass from composition
 //
 // Greenhouse Effect Formula:
 // Using logarithmic CO2 sensitivity: ΔT = α × ln(C/C₀)
-// Simplified to: ΔT ≈ 3°C per doubling of CO2
+// Simplified to: ΔT ≈ DegreesPerDoubling (3°C for Earth) per doubling of CO2
 //
 // Physics: CO2 absorbs infrared radiation, trapping heat
 // More CO2 → stronger absorption → higher surface temperature
@@ -76,7 +72,10 @@ func (a *Atmosphere) updateDerivedProperties() {
 	// Calculate greenhouse warming from CO2
 	// Reference: Modern CO2 = 400 ppm = 0.0006 atm
 	const modernCO2 = 0.0006
-	const degreesPerDoubling = 3.0 // IPCC consensus value
+	degreesPerDoubling := a.DegreesPerDoubling
+	if degreesPerDoubling <= 0 {
+		degreesPerDoubling = DefaultDegreesPerDoubling
+	}
 
 	if a.CO2Mass <= 0 {
 		a.GreenhouseFactor = 0.0
@@ -129,6 +128,18 @@ func (a *Atmosphere) SimulateCarbonCycle(dt int64, volcanicRate, weatheringRate
 	a.TotalYearsSimulated += dt
 }
 
+// AddCO2 releases CO2 (atm) from non-volcanic sources such as wildfires
+func (a *Atmosphere) AddCO2(amount float64) {
+	if amount <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.CO2Mass += amount
+	a.updateDerivedProperties()
+}
+
 // GetStats returns current atmospheric state for display/logging
 func (a *Atmosphere) GetStats() AtmosphereStats {
 	a.mu.RLock()
@@ -7,7 +7,9 @@
 //   - Spawner: Creates creatures appropriate for each biome
 //   - EvolutionManager: Handles creature reproduction and genetic inheritance
 //   - WorldGeology: Simulates geological events over time (volcanoes, earthquakes)
-//   - FindPath: A* pathfinding for entity movement
+//   - DetectContinents / DetectMountainRanges: Named-feature candidates from the heightmap
+//   - FindPath: A* across the planet surface, optionally with terrain costs (WithCost, TerrainCost)
+//   - PlanMigration: Herd migration routes along rivers and preferred biomes
 //
 // # Usage
 //
@@ -0,0 +1,206 @@
+package ecosystem
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"tw-backend/internal/ecosystem/population"
+
+	"github.com/google/uuid"
+)
+
+// FossilFilter narrows a fossil record query. Zero values match everything.
+type FossilFilter struct {
+	// FromYear and ToYear select an era: species alive at any point in it
+	FromYear *int64
+	ToYear   *int64
+	Cause    string     // Extinction cause, e.g. "population_collapse"
+	BiomeID  *uuid.UUID // Biome where fossils can be found
+}
+
+// Matches reports whether an extinct species passes the filter
+func (f FossilFilter) Matches(ext *population.ExtinctSpecies) bool {
+	if f.FromYear != nil && ext.ExistedUntil < *f.FromYear {
+		return false
+	}
+	if f.ToYear != nil && ext.ExistedFrom > *f.ToYear {
+		return false
+	}
+	if f.Cause != "" && ext.ExtinctionCause != f.Cause {
+		return false
+	}
+	if f.BiomeID != nil {
+		for _, id := range ext.FossilBiomes {
+			if id == *f.BiomeID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// FilterFossils returns the extinct species passing the filter, in record order
+func FilterFossils(extinct []*population.ExtinctSpecies, filter FossilFilter) []*population.ExtinctSpecies {
+	var matched []*population.ExtinctSpecies
+	for _, ext := range extinct {
+		if filter.Matches(ext) {
+			matched = append(matched, ext)
+		}
+	}
+	return matched
+}
+
+// FossilStore persists extinct species so the fossil record outlives a run
+type FossilStore interface {
+	// SaveFossils stores extinct species; saving a species again replaces it
+	SaveFossils(ctx context.Context, worldID uuid.UUID, fossils []*population.ExtinctSpecies) error
+	// GetFossilRecord returns a world's extinct species ordered by extinction year
+	GetFossilRecord(ctx context.Context, worldID uuid.UUID, filter FossilFilter) ([]*population.ExtinctSpecies, error)
+}
+
+// FossilArchiver saves a simulator's new extinctions to a store as they happen
+type FossilArchiver struct {
+	store   FossilStore
+	worldID uuid.UUID
+	saved   int // Fossils of the record already saved
+}
+
+// NewFossilArchiver creates an archiver for a world's fossil record
+func NewFossilArchiver(store FossilStore, worldID uuid.UUID) *FossilArchiver {
+	return &FossilArchiver{store: store, worldID: worldID}
+}
+
+// Archive saves extinctions recorded since the last call and returns how many
+// were saved. Fossils that fail to save are retried on the next call.
+func (a *FossilArchiver) Archive(ctx context.Context, sim *population.PopulationSimulator) (int, error) {
+	if sim == nil || sim.FossilRecord == nil {
+		return 0, nil
+	}
+	extinct := sim.FossilRecord.Extinct
+	if a.saved >= len(extinct) {
+		return 0, nil
+	}
+	fresh := extinct[a.saved:]
+	if err := a.store.SaveFossils(ctx, a.worldID, fresh); err != nil {
+		return 0, err
+	}
+	a.saved = len(extinct)
+	return len(fresh), nil
+}
+
+// PostgresFossilStore implements FossilStore for PostgreSQL
+type PostgresFossilStore struct {
+	db *sql.DB
+}
+
+// NewPostgresFossilStore creates a new PostgreSQL fossil store
+func NewPostgresFossilStore(db *sql.DB) *PostgresFossilStore {
+	return &PostgresFossilStore{db: db}
+}
+
+// SaveFossils stores extinct species in the database
+func (p *PostgresFossilStore) SaveFossils(ctx context.Context, worldID uuid.UUID, fossils []*population.ExtinctSpecies) error {
+	query := `
+		INSERT INTO fossil_record (world_id, species_id, name, diet, traits, peak_population,
+			existed_from, existed_until, extinction_cause, extinction_details, fossil_biomes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (world_id, species_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			diet = EXCLUDED.diet,
+			traits = EXCLUDED.traits,
+			peak_population = EXCLUDED.peak_population,
+			existed_from = EXCLUDED.existed_from,
+			existed_until = EXCLUDED.existed_until,
+			extinction_cause = EXCLUDED.extinction_cause,
+			extinction_details = EXCLUDED.extinction_details,
+			fossil_biomes = EXCLUDED.fossil_biomes
+	`
+	for _, ext := range fossils {
+		traits, err := json.Marshal(ext.Traits)
+		if err != nil {
+			return fmt.Errorf("failed to marshal fossil traits: %w", err)
+		}
+		biomes, err := json.Marshal(ext.FossilBiomes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal fossil biomes: %w", err)
+		}
+		_, err = p.db.ExecContext(ctx, query,
+			worldID,
+			ext.SpeciesID,
+			ext.Name,
+			string(ext.Diet),
+			traits,
+			ext.PeakPopulation,
+			ext.ExistedFrom,
+			ext.ExistedUntil,
+			ext.ExtinctionCause,
+			ext.ExtinctionDetails,
+			biomes,
+			time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save fossil %s: %w", ext.Name, err)
+		}
+	}
+	return nil
+}
+
+// GetFossilRecord retrieves a world's extinct species matching the filter
+func (p *PostgresFossilStore) GetFossilRecord(ctx context.Context, worldID uuid.UUID, filter FossilFilter) ([]*population.ExtinctSpecies, error) {
+	conditions := []string{"world_id = $1"}
+	args := []interface{}{worldID}
+	addCondition := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(clause, len(args)))
+	}
+	if filter.FromYear != nil {
+		addCondition("existed_until >= $%d", *filter.FromYear)
+	}
+	if filter.ToYear != nil {
+		addCondition("existed_from <= $%d", *filter.ToYear)
+	}
+	if filter.Cause != "" {
+		addCondition("extinction_cause = $%d", filter.Cause)
+	}
+	if filter.BiomeID != nil {
+		addCondition("fossil_biomes @> $%d::jsonb", fmt.Sprintf(`[%q]`, filter.BiomeID.String()))
+	}
+
+	query := `
+		SELECT species_id, name, diet, traits, peak_population, existed_from, existed_until,
+			extinction_cause, extinction_details, fossil_biomes
+		FROM fossil_record
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY existed_until ASC, name ASC
+	`
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fossils []*population.ExtinctSpecies
+	for rows.Next() {
+		var ext population.ExtinctSpecies
+		var diet string
+		var traits, biomes []byte
+		if err := rows.Scan(&ext.SpeciesID, &ext.Name, &diet, &traits, &ext.PeakPopulation,
+			&ext.ExistedFrom, &ext.ExistedUntil, &ext.ExtinctionCause, &ext.ExtinctionDetails, &biomes); err != nil {
+			return nil, err
+		}
+		ext.Diet = population.DietType(diet)
+		if err := json.Unmarshal(traits, &ext.Traits); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal fossil traits: %w", err)
+		}
+		if err := json.Unmarshal(biomes, &ext.FossilBiomes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal fossil biomes: %w", err)
+		}
+		fossils = append(fossils, &ext)
+	}
+	return fossils, rows.Err()
+}
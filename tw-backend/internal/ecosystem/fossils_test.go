@@ -0,0 +1,158 @@
+package ecosystem
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memFossilStore is an in-memory FossilStore for tests
+type memFossilStore struct {
+	fossils map[uuid.UUID]*population.ExtinctSpecies
+	order   []uuid.UUID
+	saves   int
+	err     error
+}
+
+func newMemFossilStore() *memFossilStore {
+	return &memFossilStore{fossils: make(map[uuid.UUID]*population.ExtinctSpecies)}
+}
+
+func (m *memFossilStore) SaveFossils(_ context.Context, _ uuid.UUID, fossils []*population.ExtinctSpecies) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.saves++
+	for _, ext := range fossils {
+		if _, ok := m.fossils[ext.SpeciesID]; !ok {
+			m.order = append(m.order, ext.SpeciesID)
+		}
+		m.fossils[ext.SpeciesID] = ext
+	}
+	return nil
+}
+
+func (m *memFossilStore) GetFossilRecord(_ context.Context, _ uuid.UUID, filter FossilFilter) ([]*population.ExtinctSpecies, error) {
+	var all []*population.ExtinctSpecies
+	for _, id := range m.order {
+		all = append(all, m.fossils[id])
+	}
+	return FilterFossils(all, filter), nil
+}
+
+func fossil(name, cause string, from, until int64, biomes ...uuid.UUID) *population.ExtinctSpecies {
+	return &population.ExtinctSpecies{
+		SpeciesID:       uuid.New(),
+		Name:            name,
+		Diet:            population.DietHerbivore,
+		ExistedFrom:     from,
+		ExistedUntil:    until,
+		ExtinctionCause: cause,
+		FossilBiomes:    biomes,
+	}
+}
+
+func names(fossils []*population.ExtinctSpecies) []string {
+	result := make([]string, 0, len(fossils))
+	for _, ext := range fossils {
+		result = append(result, ext.Name)
+	}
+	return result
+}
+
+func TestFilterFossils_CauseAndEra(t *testing.T) {
+	marsh := uuid.New()
+	record := []*population.ExtinctSpecies{
+		fossil("Early Grazer", "population_collapse", 0, 1000, marsh),
+		fossil("Ash Beetle", "volcanic_winter", 500, 2500),
+		fossil("Late Grazer", "population_collapse", 3000, 5000),
+	}
+	year := func(y int64) *int64 { return &y }
+
+	tests := []struct {
+		name   string
+		filter FossilFilter
+		want   []string
+	}{
+		{"no filter", FossilFilter{}, []string{"Early Grazer", "Ash Beetle", "Late Grazer"}},
+		{"cause", FossilFilter{Cause: "population_collapse"}, []string{"Early Grazer", "Late Grazer"}},
+		{"era overlaps lifespan", FossilFilter{FromYear: year(2000), ToYear: year(3000)}, []string{"Ash Beetle", "Late Grazer"}},
+		{"open-ended era", FossilFilter{ToYear: year(400)}, []string{"Early Grazer"}},
+		{"cause and era", FossilFilter{Cause: "population_collapse", FromYear: year(1500)}, []string{"Late Grazer"}},
+		{"biome", FossilFilter{BiomeID: &marsh}, []string{"Early Grazer"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(FilterFossils(record, tt.filter)))
+		})
+	}
+}
+
+func TestFossilArchiver_SavesOnlyNewExtinctions(t *testing.T) {
+	store := newMemFossilStore()
+	worldID := uuid.New()
+	sim := population.NewPopulationSimulator(worldID, 1)
+	archiver := NewFossilArchiver(store, worldID)
+	ctx := context.Background()
+
+	sim.FossilRecord.Extinct = append(sim.FossilRecord.Extinct, fossil("First", "population_collapse", 0, 10))
+	n, err := archiver.Archive(ctx, sim)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = archiver.Archive(ctx, sim)
+	require.NoError(t, err)
+	assert.Zero(t, n, "Nothing new to save")
+	assert.Equal(t, 1, store.saves)
+
+	// A failed save is retried with the next extinction
+	sim.FossilRecord.Extinct = append(sim.FossilRecord.Extinct, fossil("Second", "population_collapse", 5, 20))
+	store.err = errors.New("database unavailable")
+	_, err = archiver.Archive(ctx, sim)
+	require.Error(t, err)
+
+	store.err = nil
+	sim.FossilRecord.Extinct = append(sim.FossilRecord.Extinct, fossil("Third", "population_collapse", 5, 30))
+	n, err = archiver.Archive(ctx, sim)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	saved, err := store.GetFossilRecord(ctx, worldID, FossilFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"First", "Second", "Third"}, names(saved))
+}
+
+func TestSimulationRunner_ArchivesExtinctionsDuringRun(t *testing.T) {
+	store := newMemFossilStore()
+	worldID := uuid.New()
+	runner := NewSimulationRunner(DefaultConfig(worldID), nil, nil)
+	runner.InitializePopulationSimulator(42)
+	runner.SetFossilStore(store)
+
+	// A biome that can support nothing wipes out its grazers in the first year
+	sim := runner.GetPopulationSimulator()
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.CarryingCapacity = 0
+	biome.AddSpecies(&population.SpeciesPopulation{
+		SpeciesID: uuid.New(),
+		Name:      "Doomed Grazer",
+		Count:     1000,
+		Diet:      population.DietHerbivore,
+		Traits:    population.DefaultTraitsForDiet(population.DietHerbivore),
+	})
+	sim.Biomes[biome.BiomeID] = biome
+
+	require.NoError(t, runner.Step(1))
+
+	fossils, err := store.GetFossilRecord(context.Background(), worldID, FossilFilter{Cause: "population_collapse"})
+	require.NoError(t, err)
+	require.Contains(t, names(fossils), "Doomed Grazer")
+	assert.Equal(t, len(sim.FossilRecord.Extinct), len(store.fossils), "Every extinction should be archived")
+}
@@ -1,6 +1,7 @@
 package ecosystem
 
 import (
+	"fmt"
 	"log"
 	"math"
 	"math/rand"
@@ -37,15 +38,27 @@ type WorldGeology struct {
 	Caves       []*underground.Cave     // Cave networks
 	Composition string                  // "volcanic", "continental", "oceanic", "ancient"
 
+	// Erosion overrides the composition's erosion profile when set
+	Erosion *ErosionProfile
+
+	// HeightmapResolution overrides the default heightmap sizing when set
+	HeightmapResolution *HeightmapResolution
+
 	// Dynamic geographic features
 	Hotspots   []geography.Point // Fixed mantle plume locations
 	Rivers     [][]geography.Point
+	Lakes      []geography.Lake // Basins the rivers have filled (spherical only)
 	Biomes     []geography.Biome
 	Satellites []astronomy.Satellite // Natural satellites
+	AxialTilt  float64               // degrees; sets biome climate seasons (see weather.SeasonalTemperature)
+
+	// What the biomes were last classified from (see UpdateBiomesInto)
+	biomeBasis biomeBasis
 
 	// Simulation state
 	TotalYearsSimulated int64
 	rng                 *rand.Rand
+	rngSource           *countingSource // Counts rng draws so Serialize can save its state
 
 	// Scale factors (pixels to real-world)
 	PixelsPerKm float64 // How many heightmap pixels per real km
@@ -64,8 +77,24 @@ type WorldGeology struct {
 
 	// Ocean phase state (Hadean vapor → Modern liquid transition)
 	OceanVaporFraction float64 // 0.0 = all liquid (cool planet), 1.0 = all vapor (hot planet)
+
+	// Glaciation (see UpdateGlaciers); ice thickness is kept per column
+	IceCoverFraction    float64 // Share of the surface under ice, for albedo
+	GlacialSeaLevelDrop float64 // meters of sea level locked up as ice
+
+	// TidalHeating is interior heat raised by close or massive moons, added
+	// to the planet's age-based heat (see SetTidalHeating)
+	TidalHeating float64
+
+	// Progress reporting (see SetProgressFunc)
+	progressFunc     GeologyProgressFunc
+	progressInterval int64
 }
 
+// GeologyProgressFunc receives progress from SimulateGeology. It's called
+// without the geology's lock held, so it may call back into the geology.
+type GeologyProgressFunc func(yearsSimulated int64, stats GeologyStats)
+
 // PhaseTransitionEvent represents a major planetary phase change
 type PhaseTransitionEvent struct {
 	Type        string // "GreatDeluge", etc.
@@ -75,29 +104,176 @@ type PhaseTransitionEvent struct {
 
 // GeologyStats contains summary statistics for world info display
 type GeologyStats struct {
-	AverageElevation   float64
-	AverageTemperature float64
-	MaxElevation       float64
-	MinElevation       float64
-	SeaLevel           float64
-	LandPercent        float64
-	PlateCount         int
-	HotspotCount       int
-	RiverCount         int
-	BiomeCount         int
-	YearsSimulated     int64
+	AverageElevation   float64 `json:"average_elevation"`
+	AverageTemperature float64 `json:"average_temperature"`
+	MaxElevation       float64 `json:"max_elevation"`
+	MinElevation       float64 `json:"min_elevation"`
+	SeaLevel           float64 `json:"sea_level"`
+	LandPercent        float64 `json:"land_percent"`
+	PlateCount         int     `json:"plate_count"`
+	HotspotCount       int     `json:"hotspot_count"`
+	RiverCount         int     `json:"river_count"`
+	LakeCount          int     `json:"lake_count"`
+	BiomeCount         int     `json:"biome_count"`
+	YearsSimulated     int64   `json:"years_simulated"`
+}
+
+const (
+	// DefaultCircumference replaces a missing or invalid circumference
+	// (Earth-like: 40,000 km)
+	DefaultCircumference = 40_000_000.0
+
+	// MinCircumference is the smallest world geology models: 640 km fills
+	// the 64-pixel minimum heightmap width at 10 km per pixel
+	MinCircumference = 640_000.0
+)
+
+// ValidateCircumference reports why a circumference in meters can't describe
+// a world, or nil if it can. Positive values below MinCircumference are valid
+// but get clamped by SafeCircumference.
+func ValidateCircumference(circumferenceMeters float64) error {
+	if math.IsNaN(circumferenceMeters) || math.IsInf(circumferenceMeters, 0) || circumferenceMeters <= 0 {
+		return fmt.Errorf("circumference must be a positive number of meters, got %v", circumferenceMeters)
+	}
+	return nil
+}
+
+// SafeCircumference returns a circumference geology can work with: invalid
+// values fall back to DefaultCircumference and tiny ones are raised to
+// MinCircumference
+func SafeCircumference(circumferenceMeters float64) float64 {
+	if ValidateCircumference(circumferenceMeters) != nil {
+		return DefaultCircumference
+	}
+	return math.Max(circumferenceMeters, MinCircumference)
+}
+
+const (
+	// DefaultHeightmapMemoryBudget caps the elevation data of a configured
+	// HeightmapResolution: the flat map plus the six cube-sphere faces
+	DefaultHeightmapMemoryBudget = int64(64 << 20)
+
+	// Without a HeightmapResolution, maps are 10 km per pixel between these
+	// widths (heights are half the width)
+	defaultKmPerPixel      = 10.0
+	defaultMaxMapWidth     = 512
+	minMapWidth            = 64
+	heightmapBytesPerPixel = 8 // float64 elevations
+)
+
+// HeightmapResolution sets the size of the heightmaps InitializeGeology
+// builds. The flat map is Width x Width/2 and each cube-sphere face is
+// Width/2 square, so the two stay in step.
+type HeightmapResolution struct {
+	Width       int     // Flat map width in pixels; 0 derives it from PixelsPerKm
+	PixelsPerKm float64 // Detail when Width is 0; the width shrinks to fit MaxBytes
+	MaxBytes    int64   // Elevation memory budget; 0 uses DefaultHeightmapMemoryBudget
+}
+
+// Validate reports why r can't be used, or nil if it can
+func (r HeightmapResolution) Validate() error {
+	switch {
+	case r.Width < 0 || r.MaxBytes < 0:
+		return fmt.Errorf("heightmap resolution must not be negative (width %d, max bytes %d)", r.Width, r.MaxBytes)
+	case r.Width == 0 && (!(r.PixelsPerKm > 0) || math.IsInf(r.PixelsPerKm, 1)):
+		return fmt.Errorf("heightmap resolution needs a width or a positive pixels per km, got %v", r.PixelsPerKm)
+	case r.budget() < heightmapBytes(minMapWidth):
+		return fmt.Errorf("heightmap budget of %d bytes is below the %d the smallest map needs", r.budget(), heightmapBytes(minMapWidth))
+	case r.Width > 0 && r.Width < minMapWidth:
+		return fmt.Errorf("heightmap width %d is below the minimum of %d", r.Width, minMapWidth)
+	case r.Width > 0 && heightmapBytes(r.Width) > r.budget():
+		return fmt.Errorf("heightmap width %d needs %d bytes, over the %d byte budget", r.Width, heightmapBytes(r.Width), r.budget())
+	}
+	return nil
+}
+
+// budget returns MaxBytes or the default budget
+func (r HeightmapResolution) budget() int64 {
+	if r.MaxBytes > 0 {
+		return r.MaxBytes
+	}
+	return DefaultHeightmapMemoryBudget
+}
+
+// heightmapBytes estimates the elevation memory for a flat map width pixels
+// wide and the cube sphere that goes with it
+func heightmapBytes(width int) int64 {
+	w, h := int64(width), int64(width/2)
+	return (w*h + 6*h*h) * heightmapBytesPerPixel
+}
+
+// maxMapWidth returns the widest map whose elevations fit in budget bytes
+func maxMapWidth(budget int64) int {
+	// heightmapBytes is 16*width^2 for even widths
+	width := int(math.Sqrt(float64(budget/(2*heightmapBytesPerPixel)))) &^ 1
+	for width > 0 && heightmapBytes(width) > budget {
+		width -= 2
+	}
+	return width
+}
+
+// heightmapSize returns the flat heightmap dimensions for a world of
+// circumKm. A nil resolution keeps the default sizing.
+func heightmapSize(circumKm float64, res *HeightmapResolution) (width, height int) {
+	if res == nil {
+		// 10 km per pixel, capped at 512x256 for memory
+		width = min(max(int(circumKm/defaultKmPerPixel), minMapWidth), defaultMaxMapWidth)
+		height = min(max(int(circumKm/(2*defaultKmPerPixel)), minMapWidth/2), defaultMaxMapWidth/2)
+		return width, height
+	}
+
+	width = res.Width
+	if width == 0 {
+		width = max(min(int(circumKm*res.PixelsPerKm), maxMapWidth(res.budget())), minMapWidth)
+	}
+	width -= width % 2
+	return width, width / 2
+}
+
+// ErosionProfile controls how quickly a world's surface wears down and how
+// fast sediment builds up in its columns
+type ErosionProfile struct {
+	Interval          float64 // Years between erosion passes
+	ThermalIterations int     // Slope-collapse iterations per pass
+	HydraulicDrops    int     // Water droplets traced per pass
+	SedimentRate      float64 // Multiplier on the default sediment deposition rate
+}
+
+// ErosionProfileFor returns the erosion profile for a world composition.
+// Ancient worlds have had the longest to wear down and erode often and hard;
+// young volcanic crust erodes slowly and stays rugged. Unknown compositions
+// get the continental profile.
+func ErosionProfileFor(composition string) ErosionProfile {
+	switch composition {
+	case "ancient":
+		return ErosionProfile{Interval: 5_000_000, ThermalIterations: 6, HydraulicDrops: 1000, SedimentRate: 1.5}
+	case "volcanic":
+		return ErosionProfile{Interval: 20_000_000, ThermalIterations: 1, HydraulicDrops: 200, SedimentRate: 0.5}
+	case "oceanic":
+		return ErosionProfile{Interval: 10_000_000, ThermalIterations: 3, HydraulicDrops: 800, SedimentRate: 1.5}
+	default: // continental
+		return ErosionProfile{Interval: 10_000_000, ThermalIterations: 3, HydraulicDrops: 500, SedimentRate: 1.0}
+	}
 }
 
 // NewWorldGeology creates a new geology manager for a world
 // composition: "volcanic", "continental", "oceanic", or "ancient"
+// The circumference is passed through SafeCircumference, so a zero or
+// negative value yields an Earth-sized world rather than a degenerate one.
 func NewWorldGeology(worldID uuid.UUID, seed int64, circumferenceMeters float64) *WorldGeology {
+	if err := ValidateCircumference(circumferenceMeters); err != nil {
+		log.Printf("[GEOLOGY] World %s: %v; using %.0f m", worldID, err, DefaultCircumference)
+	}
+	rng, rngSource := newGeologyRand(seed)
 	return &WorldGeology{
 		WorldID:       worldID,
 		Seed:          seed,
-		Circumference: circumferenceMeters,
+		Circumference: SafeCircumference(circumferenceMeters),
 		SeaLevel:      0,             // Baseline sea level
 		Composition:   "continental", // Default composition
-		rng:           rand.New(rand.NewSource(seed)),
+		AxialTilt:     weather.DefaultAxialTilt,
+		rng:           rng,
+		rngSource:     rngSource,
 	}
 }
 
@@ -109,6 +285,64 @@ func (g *WorldGeology) SetComposition(composition string) {
 	g.Composition = composition
 }
 
+// SetErosionProfile overrides the erosion profile derived from the
+// composition. A nil profile restores the composition default.
+func (g *WorldGeology) SetErosionProfile(profile *ErosionProfile) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.Erosion = profile
+}
+
+// SetHeightmapResolution sets the heightmap size InitializeGeology uses.
+// A nil resolution restores the default: 10 km per pixel, at most 512x256.
+func (g *WorldGeology) SetHeightmapResolution(res *HeightmapResolution) error {
+	if res != nil {
+		if err := res.Validate(); err != nil {
+			return err
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.HeightmapResolution = res
+	return nil
+}
+
+// SetTidalHeating sets the extra heat, in GetPlanetaryHeat units, that tides
+// from the world's moons pump into its interior. See TidalHeatingFromStress.
+func (g *WorldGeology) SetTidalHeating(factor float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.TidalHeating = math.Max(factor, 0)
+}
+
+// PlanetaryHeat returns the heat driving tectonics and volcanism: the
+// planet's age-based heat plus tidal heating
+func (g *WorldGeology) PlanetaryHeat() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.planetaryHeat()
+}
+
+// planetaryHeat does PlanetaryHeat's work (caller holds the lock)
+func (g *WorldGeology) planetaryHeat() float64 {
+	return GetPlanetaryHeat(g.TotalYearsSimulated) + g.TidalHeating
+}
+
+// ErosionProfile returns the erosion profile currently in effect
+func (g *WorldGeology) ErosionProfile() ErosionProfile {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.erosionProfile()
+}
+
+// erosionProfile resolves the override or composition profile (caller holds the lock)
+func (g *WorldGeology) erosionProfile() ErosionProfile {
+	if g.Erosion != nil {
+		return *g.Erosion
+	}
+	return ErosionProfileFor(g.Composition)
+}
+
 // GetPlanetaryHeat returns a heat multiplier based on planetary age.
 // Models Earth's thermal evolution from Hadean magma ocean to modern stable planet.
 //
@@ -181,39 +415,40 @@ func GetPlanetaryHeat(year int64) float64 {
 	return heat
 }
 
+const (
+	// TidalHeatingPerStress is the heat added per unit of tidal stress
+	// beyond what Earth's Moon raises
+	TidalHeatingPerStress = 0.5
+
+	// MaxTidalHeating caps tidal heating at a newborn planet's heat; an
+	// Io-like world is about as volcanic as the Hadean Earth
+	MaxTidalHeating = 10.0
+)
+
+// TidalHeatingFromStress converts astronomy.CalculateTidalStress (1.0 =
+// Earth's Moon) to heat for SetTidalHeating. Earth's Moon is already part of
+// GetPlanetaryHeat's modern baseline, so only stress above it adds heat.
+func TidalHeatingFromStress(tidalStress float64) float64 {
+	excess := tidalStress - 1
+	if excess <= 0 {
+		return 0
+	}
+	return math.Min(excess*TidalHeatingPerStress, MaxTidalHeating)
+}
+
 // InitializeGeology creates the baseline terrain from scratch
 // This should be called when a world is first simulated
 func (g *WorldGeology) InitializeGeology() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	// Guard against a circumference set directly on the struct
+	g.Circumference = SafeCircumference(g.Circumference)
+
 	// Calculate map dimensions based on circumference
 	// Circumference in meters -> convert to km for our scale
 	circumKm := g.Circumference / 1000.0
-
-	// Target: ~10 km per pixel for reasonable detail
-	// For Earth-like (40,000 km), this gives 4000x2000 (too large for memory)
-	// Let's cap at 512x256 and adjust scale
-	maxWidth := 512
-	maxHeight := 256
-
-	// Calculate pixels per km based on circumference
-	// width = circumference, height = circumference/2 (latitude)
-	width := int(circumKm / 10)  // 10km per pixel
-	height := int(circumKm / 20) // latitude is half
-
-	if width > maxWidth {
-		width = maxWidth
-	}
-	if height > maxHeight {
-		height = maxHeight
-	}
-	if width < 64 {
-		width = 64
-	}
-	if height < 32 {
-		height = 32
-	}
+	width, height := heightmapSize(circumKm, g.HeightmapResolution)
 
 	g.PixelsPerKm = float64(width) / circumKm
 
@@ -245,7 +480,8 @@ func (g *WorldGeology) InitializeGeology() {
 
 	// Generate initial rivers using spherical algorithm
 	if g.SphereHeightmap != nil {
-		sphereRivers := geography.GenerateRiversSpherical(g.SphereHeightmap, g.SeaLevel, g.Seed)
+		sphereRivers, lakes := geography.GenerateHydrologySpherical(g.SphereHeightmap, g.SeaLevel, g.Seed)
+		g.Lakes = lakes
 		g.Rivers = geography.ConvertSphericalRiversToFlat(sphereRivers, g.Topology.Resolution())
 		// Sync sphere heightmap changes from river erosion
 		g.markSphereNeedsSync()
@@ -633,6 +869,7 @@ func (g *WorldGeology) simulateDepositEvolution(yearsElapsed int64) {
 	}
 
 	config := underground.DefaultDepositConfig()
+	config.SedimentRatePerYear *= g.erosionProfile().SedimentRate
 
 	underground.SimulateDepositEvolution(
 		g.Columns,
@@ -643,14 +880,43 @@ func (g *WorldGeology) simulateDepositEvolution(yearsElapsed int64) {
 	)
 }
 
+// SetProgressFunc has SimulateGeology call fn each time TotalYearsSimulated
+// passes a multiple of interval years. A nil fn or non-positive interval
+// turns progress reporting off.
+func (g *WorldGeology) SetProgressFunc(interval int64, fn GeologyProgressFunc) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if interval <= 0 {
+		fn = nil
+	}
+	g.progressFunc = fn
+	g.progressInterval = interval
+}
+
 // SimulateGeology advances geological processes over time
 // dt is the time step in years (Delta Time)
 // globalTempMod is the current global temperature offset (e.g. from volcanic winter)
 // Returns a PhaseTransitionEvent if a major phase change occurred (e.g., Great Deluge)
 func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTransitionEvent {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	before := g.TotalYearsSimulated
+	phaseEvent := g.simulateGeology(dt, globalTempMod)
+
+	// Snapshot the stats, then report after unlocking
+	report := g.progressFunc
+	if report == nil || g.TotalYearsSimulated/g.progressInterval == before/g.progressInterval {
+		g.mu.Unlock()
+		return phaseEvent
+	}
+	years, stats := g.TotalYearsSimulated, g.stats()
+	g.mu.Unlock()
 
+	report(years, stats)
+	return phaseEvent
+}
+
+// simulateGeology does SimulateGeology's work (caller holds the lock)
+func (g *WorldGeology) simulateGeology(dt int64, globalTempMod float64) *PhaseTransitionEvent {
 	if g.Heightmap == nil {
 		return nil // Not initialized
 	}
@@ -664,7 +930,7 @@ func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTr
 
 	// Calculate planetary heat multiplier for this time period
 	// This drives tectonic and volcanic activity rates
-	heat := GetPlanetaryHeat(g.TotalYearsSimulated)
+	heat := g.planetaryHeat()
 
 	// Accumulate time for variable step processing
 	// Tectonic stress scales with planetary heat (10x faster in early Earth)
@@ -684,8 +950,10 @@ func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTr
 	if g.TectonicStressAccumulator > maxAccumulatorValue*10 {
 		g.TectonicStressAccumulator = maxAccumulatorValue * 10
 	}
-	if g.ErosionAccumulator > maxAccumulatorValue {
-		g.ErosionAccumulator = maxAccumulatorValue
+	// Erosion must be able to bank a full interval or it never fires
+	erosion := g.erosionProfile()
+	if maxErosion := math.Max(maxAccumulatorValue, erosion.Interval); g.ErosionAccumulator > maxErosion {
+		g.ErosionAccumulator = maxErosion
 	}
 	if g.RiverAccumulator > maxAccumulatorValue {
 		g.RiverAccumulator = maxAccumulatorValue
@@ -776,18 +1044,22 @@ func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTr
 	if heat <= 4.0 {
 		erosionStart = time.Now()
 
-		// === EROSION (Throttled for deep-time - every 10M years) ===
+		// === EROSION (Throttled for deep-time - interval set by composition) ===
 		// Surface processes only matter on cooled planets with solid crust
-		erosionInterval := 10_000_000.0 // 10M years (was 10K)
-		if g.ErosionAccumulator >= erosionInterval {
-			// Thermal erosion: Limited iterations to prevent lag
-			geography.ApplyThermalErosion(g.Heightmap, 3, g.Seed+g.TotalYearsSimulated)
-
-			// Hydraulic erosion: Limited drops to prevent lag
-			geography.ApplyHydraulicErosion(g.Heightmap, 500, g.Seed+g.TotalYearsSimulated)
+		if erosion.Interval > 0 && g.ErosionAccumulator >= erosion.Interval {
+			erosionSeed := g.Seed + g.TotalYearsSimulated
+			if g.SphereHeightmap != nil {
+				// Erode the sphere so the next sync doesn't overwrite the result
+				geography.ApplyThermalErosionSpherical(g.SphereHeightmap, g.Topology, erosion.ThermalIterations, erosionSeed)
+				geography.ApplyHydraulicErosionSpherical(g.SphereHeightmap, g.Topology, erosion.HydraulicDrops, erosionSeed)
+				g.markSphereNeedsSync()
+			} else {
+				geography.ApplyThermalErosion(g.Heightmap, erosion.ThermalIterations, erosionSeed)
+				geography.ApplyHydraulicErosion(g.Heightmap, erosion.HydraulicDrops, erosionSeed)
+			}
 
 			// Reset accumulator
-			g.ErosionAccumulator -= erosionInterval
+			g.ErosionAccumulator -= erosion.Interval
 		}
 
 		// Apply hotspot activity
@@ -856,7 +1128,8 @@ func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTr
 		if g.RiverAccumulator >= riverInterval {
 			riverStart := time.Now()
 			if g.SphereHeightmap != nil {
-				sphereRivers := geography.GenerateRiversSpherical(g.SphereHeightmap, g.SeaLevel, g.Seed+g.TotalYearsSimulated)
+				sphereRivers, lakes := geography.GenerateHydrologySpherical(g.SphereHeightmap, g.SeaLevel, g.Seed+g.TotalYearsSimulated)
+				g.Lakes = lakes
 				g.Rivers = geography.ConvertSphericalRiversToFlat(sphereRivers, g.Topology.Resolution())
 				g.markSphereNeedsSync() // Sync river erosion to flat heightmap
 			} else {
@@ -919,7 +1192,7 @@ func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTr
 
 	// Fix 4: Sea level equilibrium model - sea level recovers toward baseline
 	// Recovery rate: 1% per 10k years = 0.01 / 10000 = 1e-6 per year
-	targetSeaLevel := 0.0 // Baseline sea level
+	targetSeaLevel := -g.GlacialSeaLevelDrop // Baseline sea level, less water locked in ice
 	recoveryRatePerYear := 1e-6
 	seaLevelChange := (targetSeaLevel - g.SeaLevel) * recoveryRatePerYear * dtFloat
 	g.SeaLevel += seaLevelChange
@@ -942,6 +1215,9 @@ func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTr
 	// Calculate average surface temperature
 	avgTemp := g.calculateAverageSurfaceTemp(globalTempMod)
 
+	// Ice sheets grow or retreat with the temperature
+	g.updateGlaciers(dt, avgTemp)
+
 	// Define phase transition parameters
 	const (
 		modernSeaLevel = 0.0    // Baseline sea level (meters)
@@ -976,7 +1252,7 @@ func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTr
 
 	// Calculate target sea level based on vapor fraction
 	// When water vaporizes, sea level drops as ocean basins empty
-	targetSeaLevel = modernSeaLevel - (vaporFraction * vaporDepth)
+	targetSeaLevel = modernSeaLevel - (vaporFraction * vaporDepth) - g.GlacialSeaLevelDrop
 
 	// Smooth transition (exponential relaxation)
 	// Prevents jarring jumps, simulates realistic evaporation/condensation timescales
@@ -1018,11 +1294,13 @@ func (g *WorldGeology) SimulateGeology(dt int64, globalTempMod float64) *PhaseTr
 	return phaseEvent
 }
 
-// applyHotspotActivity adds volcanic material at hotspot locations
-// Eruption frequency scales with planetary heat (early Earth has 10x more eruptions)
-func (g *WorldGeology) applyHotspotActivity(years float64) {
+// applyHotspotActivity adds volcanic material at hotspot locations and
+// returns how many eruptions there were.
+// Eruption frequency scales with planetary heat (early Earth has 10x more
+// eruptions), including tidal heating from the moons
+func (g *WorldGeology) applyHotspotActivity(years float64) int {
 	// Get current planetary heat to scale volcanic activity
-	heat := GetPlanetaryHeat(g.TotalYearsSimulated)
+	heat := g.planetaryHeat()
 
 	// Base rate: 1 eruption per 1000 years at modern Earth (heat=1.0)
 	// Early Earth (heat=10.0): 1 eruption per 100 years
@@ -1051,6 +1329,7 @@ func (g *WorldGeology) applyHotspotActivity(years float64) {
 			geography.ApplyVolcanoFlat(g.Heightmap, jx, jy, radius, height)
 		}
 	}
+	return numEruptions * len(g.Hotspots)
 }
 
 // advancePlates moves tectonic plates and recalculates boundaries
@@ -1098,20 +1377,21 @@ func (g *WorldGeology) advancePlates(years float64) {
 }
 
 // ApplyEvent handles geological events that affect terrain
-func (g *WorldGeology) ApplyEvent(event GeologicalEvent) {
+func (g *WorldGeology) ApplyEvent(event GeologicalEvent) []CatastropheSite {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
 	if g.Heightmap == nil {
-		return
+		return nil
 	}
 
+	var sites []CatastropheSite
 	switch event.Type {
 	case EventVolcanicWinter:
-		g.applyVolcanicMountains(event.Severity)
+		sites = g.applyVolcanicMountains(event.Severity)
 
 	case EventAsteroidImpact:
-		g.applyImpactCrater(event.Severity)
+		sites = g.applyImpactCrater(event.Severity)
 
 	case EventIceAge:
 		g.applyIceAgeEffects(event.Severity)
@@ -1120,7 +1400,7 @@ func (g *WorldGeology) ApplyEvent(event GeologicalEvent) {
 		g.applyContinentalDrift(event.Severity)
 
 	case EventFloodBasalt:
-		g.applyFloodBasalt(event.Severity)
+		sites = g.applyFloodBasalt(event.Severity)
 
 	// Ocean anoxia doesn't affect terrain
 	case EventOceanAnoxia:
@@ -1128,12 +1408,42 @@ func (g *WorldGeology) ApplyEvent(event GeologicalEvent) {
 	}
 
 	g.updateHeightmapStats()
+	return sites
+}
+
+// CatastropheSite is where a catastrophe struck the surface, in flat
+// heightmap cells (which are also world coordinates)
+type CatastropheSite struct {
+	Type   GeologicalEventType
+	X, Y   float64
+	Radius float64 // Cells of terrain reshaped around X, Y
 }
 
-// applyVolcanicMountains adds volcanic features during volcanic winter
-func (g *WorldGeology) applyVolcanicMountains(severity float64) {
+// sphereSite places a catastrophe centred on a sphere cell on the flat
+// equirectangular heightmap, the inverse of ToFlatHeightmapInPlace.
+// radius is in sphere cells; four faces span the flat map's width.
+func (g *WorldGeology) sphereSite(eventType GeologicalEventType, center spatial.Coordinate, radius float64) CatastropheSite {
+	x, y, z := g.Topology.ToSphere(center)
+	lat := math.Asin(max(-1, min(1, y)))
+	lon := math.Atan2(z, x)
+	if lon < 0 {
+		lon += 2 * math.Pi
+	}
+	width, height := float64(g.Heightmap.Width), float64(g.Heightmap.Height)
+	return CatastropheSite{
+		Type:   eventType,
+		X:      lon / (2 * math.Pi) * width,
+		Y:      (0.5 - lat/math.Pi) * height,
+		Radius: radius * width / float64(4*g.Topology.Resolution()),
+	}
+}
+
+// applyVolcanicMountains adds volcanic features during volcanic winter and
+// returns where each volcano rose
+func (g *WorldGeology) applyVolcanicMountains(severity float64) []CatastropheSite {
 	// Number of volcanoes based on severity
 	numVolcanoes := 1 + int(severity*3)
+	sites := make([]CatastropheSite, 0, numVolcanoes)
 
 	// Use spherical operations if available
 	if g.SphereHeightmap != nil && g.Topology != nil {
@@ -1150,6 +1460,7 @@ func (g *WorldGeology) applyVolcanicMountains(severity float64) {
 			radius := 2.0 + g.rng.Float64()*2.0
 
 			geography.ApplyVolcanoSpherical(g.SphereHeightmap, center, g.Topology, radius, height)
+			sites = append(sites, g.sphereSite(EventVolcanicWinter, center, radius))
 		}
 		// Sync to flat heightmap
 		g.markSphereNeedsSync()
@@ -1161,12 +1472,15 @@ func (g *WorldGeology) applyVolcanicMountains(severity float64) {
 			height := 200 + severity*300
 			radius := 2.0 + g.rng.Float64()*2.0
 			geography.ApplyVolcanoFlat(g.Heightmap, x, y, radius, height)
+			sites = append(sites, CatastropheSite{Type: EventVolcanicWinter, X: x, Y: y, Radius: radius})
 		}
 	}
+	return sites
 }
 
-// applyImpactCrater creates a crater from asteroid impact
-func (g *WorldGeology) applyImpactCrater(severity float64) {
+// applyImpactCrater creates a crater from asteroid impact and returns where
+// it struck
+func (g *WorldGeology) applyImpactCrater(severity float64) []CatastropheSite {
 	// Crater size based on severity (10-50 cells radius)
 	radius := int(10 + severity*40)
 
@@ -1228,31 +1542,34 @@ func (g *WorldGeology) applyImpactCrater(severity float64) {
 			}
 		}
 		g.markSphereNeedsSync()
-	} else {
-		// Fallback to flat heightmap
-		centerX := g.rng.Intn(g.Heightmap.Width)
-		centerY := g.rng.Intn(g.Heightmap.Height)
-
-		for dy := -radius * 2; dy <= radius*2; dy++ {
-			for dx := -radius * 2; dx <= radius*2; dx++ {
-				px, py := centerX+dx, centerY+dy
-				if px >= 0 && px < g.Heightmap.Width && py >= 0 && py < g.Heightmap.Height {
-					dist := math.Sqrt(float64(dx*dx + dy*dy))
-
-					if dist < float64(radius) {
-						factor := 1.0 - (dist / float64(radius))
-						current := g.Heightmap.Get(px, py)
-						g.Heightmap.Set(px, py, current-depth*factor*factor)
-					} else if dist < float64(radius)*1.3 {
-						t := (dist - float64(radius)) / (float64(radius) * 0.3)
-						factor := 1.0 - t
-						current := g.Heightmap.Get(px, py)
-						g.Heightmap.Set(px, py, current+rimHeight*factor)
-					}
+		// The rim reaches 1.3 radii out
+		return []CatastropheSite{g.sphereSite(EventAsteroidImpact, center, float64(radius)*1.3)}
+	}
+
+	// Fallback to flat heightmap
+	centerX := g.rng.Intn(g.Heightmap.Width)
+	centerY := g.rng.Intn(g.Heightmap.Height)
+
+	for dy := -radius * 2; dy <= radius*2; dy++ {
+		for dx := -radius * 2; dx <= radius*2; dx++ {
+			px, py := centerX+dx, centerY+dy
+			if px >= 0 && px < g.Heightmap.Width && py >= 0 && py < g.Heightmap.Height {
+				dist := math.Sqrt(float64(dx*dx + dy*dy))
+
+				if dist < float64(radius) {
+					factor := 1.0 - (dist / float64(radius))
+					current := g.Heightmap.Get(px, py)
+					g.Heightmap.Set(px, py, current-depth*factor*factor)
+				} else if dist < float64(radius)*1.3 {
+					t := (dist - float64(radius)) / (float64(radius) * 0.3)
+					factor := 1.0 - t
+					current := g.Heightmap.Get(px, py)
+					g.Heightmap.Set(px, py, current+rimHeight*factor)
 				}
 			}
 		}
 	}
+	return []CatastropheSite{{Type: EventAsteroidImpact, X: float64(centerX), Y: float64(centerY), Radius: float64(radius) * 1.3}}
 }
 
 // applyIceAgeEffects lowers sea level and applies glacial erosion
@@ -1399,7 +1716,7 @@ func (g *WorldGeology) applyMinorBoundaryUplift(maxChange float64) {
 }
 
 // applyFloodBasalt creates large volcanic provinces
-func (g *WorldGeology) applyFloodBasalt(severity float64) {
+func (g *WorldGeology) applyFloodBasalt(severity float64) []CatastropheSite {
 	// Radius based on severity (30-100 cells)
 	radius := 30 + int(severity*70)
 
@@ -1451,26 +1768,28 @@ func (g *WorldGeology) applyFloodBasalt(severity float64) {
 			}
 		}
 		g.markSphereNeedsSync()
-	} else {
-		// Fallback to flat heightmap
-		centerX := g.rng.Intn(g.Heightmap.Width)
-		centerY := g.rng.Intn(g.Heightmap.Height)
-
-		for dy := -radius; dy <= radius; dy++ {
-			for dx := -radius; dx <= radius; dx++ {
-				px, py := centerX+dx, centerY+dy
-				if px >= 0 && px < g.Heightmap.Width && py >= 0 && py < g.Heightmap.Height {
-					dist := math.Sqrt(float64(dx*dx + dy*dy))
-					if dist < float64(radius) {
-						factor := 1.0 - (dist / float64(radius))
-						factor = factor * factor
-						current := g.Heightmap.Get(px, py)
-						g.Heightmap.Set(px, py, current+height*factor)
-					}
+		return []CatastropheSite{g.sphereSite(EventFloodBasalt, center, float64(radius))}
+	}
+
+	// Fallback to flat heightmap
+	centerX := g.rng.Intn(g.Heightmap.Width)
+	centerY := g.rng.Intn(g.Heightmap.Height)
+
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			px, py := centerX+dx, centerY+dy
+			if px >= 0 && px < g.Heightmap.Width && py >= 0 && py < g.Heightmap.Height {
+				dist := math.Sqrt(float64(dx*dx + dy*dy))
+				if dist < float64(radius) {
+					factor := 1.0 - (dist / float64(radius))
+					factor = factor * factor
+					current := g.Heightmap.Get(px, py)
+					g.Heightmap.Set(px, py, current+height*factor)
 				}
 			}
 		}
 	}
+	return []CatastropheSite{{Type: EventFloodBasalt, X: float64(centerX), Y: float64(centerY), Radius: float64(radius)}}
 }
 
 // updateHeightmapStats recalculates min/max elevation
@@ -1513,15 +1832,22 @@ func (g *WorldGeology) calculateAverageSurfaceTemp(globalTempMod float64) float6
 		avgBiomeTemp = totalTemp / float64(len(g.Biomes))
 	}
 
+	// Ice sheets reflect sunlight, cooling the planet further
+	albedoOffset := -g.IceCoverFraction * IceAlbedoCooling
+
 	// Combine all temperature factors
-	return avgBiomeTemp + globalTempMod + geothermalOffset
+	return avgBiomeTemp + globalTempMod + geothermalOffset + albedoOffset
 }
 
 // GetStats returns current geological statistics
 func (g *WorldGeology) GetStats() GeologyStats {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
+	return g.stats()
+}
 
+// stats computes GetStats (caller holds the lock)
+func (g *WorldGeology) stats() GeologyStats {
 	if g.Heightmap == nil {
 		return GeologyStats{PlateCount: len(g.Plates)}
 	}
@@ -1560,6 +1886,7 @@ func (g *WorldGeology) GetStats() GeologyStats {
 		PlateCount:         len(g.Plates),
 		HotspotCount:       len(g.Hotspots),
 		RiverCount:         len(g.Rivers),
+		LakeCount:          len(g.Lakes),
 		BiomeCount:         len(g.Biomes),
 		YearsSimulated:     g.TotalYearsSimulated,
 	}
@@ -1652,11 +1979,12 @@ func (g *WorldGeology) ShiftTemperature(shift float64) {
 // UpdateBiomes updates the biomes based on the current heightmap and climate.
 // This is now decoupled from SimulateGeology loop to prevent excessive memory allocations.
 // Should be called periodically by the simulation orchestrator if life is enabled.
+// It allocates fresh biomes and IDs; UpdateBiomesInto refreshes them in place.
 func (g *WorldGeology) UpdateBiomes(globalTempMod float64) []geography.Biome {
 	seed := g.Seed + g.TotalYearsSimulated
 
 	// 1. Generate climate data from Weather service
-	climateData := weather.GenerateInitialClimate(g.Heightmap, g.SeaLevel, seed, globalTempMod)
+	climateData := weather.GenerateInitialClimateWithTilt(g.Heightmap, g.SeaLevel, seed, globalTempMod, g.AxialTilt)
 
 	// 2. Classify biomes using climate data
 	biomes := make([]geography.Biome, g.Heightmap.Width*g.Heightmap.Height)
@@ -1684,5 +2012,6 @@ func (g *WorldGeology) UpdateBiomes(globalTempMod float64) []geography.Biome {
 		}
 	}
 
+	g.recordBiomeBasis(seed, globalTempMod)
 	return biomes
 }
@@ -0,0 +1,107 @@
+package ecosystem
+
+import (
+	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/geography"
+	"tw-backend/internal/worldgen/weather"
+
+	"github.com/google/uuid"
+)
+
+// biomeBasis records what the biomes were last classified from, so
+// UpdateBiomesInto can tell which cells have changed since
+type biomeBasis struct {
+	seed       int64     // Moisture noise seed
+	tempMod    float64   // Global temperature modifier
+	seaLevel   float64   // meters
+	axialTilt  float64   // degrees
+	elevations []float64 // Heightmap elevations, row-major
+}
+
+// recordBiomeBasis remembers the inputs of a full biome classification
+func (g *WorldGeology) recordBiomeBasis(seed int64, globalTempMod float64) {
+	g.biomeBasis.seed = seed
+	g.biomeBasis.tempMod = globalTempMod
+	g.biomeBasis.seaLevel = g.SeaLevel
+	g.biomeBasis.axialTilt = g.AxialTilt
+	g.biomeBasis.elevations = append(g.biomeBasis.elevations[:0], g.Heightmap.Elevations...)
+}
+
+// UpdateBiomesInto is the incremental form of UpdateBiomes. It reclassifies
+// biomes in place in existing, and returns it, touching only changedCells
+// (X, Y on the flat heightmap; Face is ignored). A nil changedCells finds
+// them: the cells whose elevation changed since they were last classified,
+// or every cell if the temperature modifier, sea level or axial tilt moved.
+//
+// A reclassified cell keeps its BiomeID unless its biome type changes, and
+// unchanged cells aren't touched at all, so IDs stay stable across long
+// runs. The moisture pattern is the one from the last full UpdateBiomes, so
+// patched cells match their neighbors. If existing doesn't cover the
+// heightmap it falls back to UpdateBiomes.
+func (g *WorldGeology) UpdateBiomesInto(existing []geography.Biome, changedCells []spatial.Coordinate, globalTempMod float64) []geography.Biome {
+	width, height := g.Heightmap.Width, g.Heightmap.Height
+	if len(existing) != width*height {
+		return g.UpdateBiomes(globalTempMod)
+	}
+
+	basis := &g.biomeBasis
+	all := changedCells == nil &&
+		(basis.tempMod != globalTempMod || basis.seaLevel != g.SeaLevel || basis.axialTilt != g.AxialTilt)
+	if len(basis.elevations) != width*height {
+		// Nothing to compare against (a loaded snapshot): start from the
+		// moisture pattern UpdateBiomes would use now
+		basis.seed = g.Seed + g.TotalYearsSimulated
+		basis.elevations = make([]float64, width*height)
+		all = true
+	}
+
+	sampler := weather.NewClimateSamplerWithTilt(basis.seed, g.AxialTilt)
+	reclassify := func(x, y int) {
+		idx := y*width + x
+		elev := g.Heightmap.Get(x, y)
+		climate := sampler.ClimateAt(g.Heightmap, g.SeaLevel, globalTempMod, x, y)
+		biomeType := geography.ClassifyBiome(
+			climate.Temperature,
+			climate.AnnualRainfall,
+			climate.SoilDrainage,
+			elev,
+			g.SeaLevel,
+		)
+
+		biome := &existing[idx]
+		if biome.Type != biomeType {
+			biome.BiomeID = uuid.New()
+			biome.Name = string(biomeType)
+			biome.Type = biomeType
+		}
+		biome.Temperature = climate.Temperature
+		biome.Precipitation = climate.AnnualRainfall
+		basis.elevations[idx] = elev
+	}
+
+	switch {
+	case all:
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				reclassify(x, y)
+			}
+		}
+		basis.tempMod = globalTempMod
+		basis.seaLevel = g.SeaLevel
+		basis.axialTilt = g.AxialTilt
+	case changedCells == nil:
+		for idx, elev := range g.Heightmap.Elevations {
+			if elev != basis.elevations[idx] {
+				reclassify(idx%width, idx/width)
+			}
+		}
+	default:
+		for _, c := range changedCells {
+			if c.X >= 0 && c.X < width && c.Y >= 0 && c.Y < height {
+				reclassify(c.X, c.Y)
+			}
+		}
+	}
+
+	return existing
+}
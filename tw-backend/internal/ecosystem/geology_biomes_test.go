@@ -0,0 +1,142 @@
+package ecosystem
+
+import (
+	"testing"
+
+	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// biomeGeology returns a small initialized world with its biomes classified
+func biomeGeology() *WorldGeology {
+	g := NewWorldGeology(uuid.New(), 5, 1_000_000)
+	g.InitializeGeology()
+	return g
+}
+
+// raiseToPeak lifts the first land cell on the heightmap far above the snow
+// line and returns its index
+func raiseToPeak(t *testing.T, g *WorldGeology) int {
+	for idx, elev := range g.Heightmap.Elevations {
+		if elev > g.SeaLevel && g.Biomes[idx].Type != geography.BiomeAlpine {
+			g.Heightmap.Elevations[idx] = g.SeaLevel + 8000
+			return idx
+		}
+	}
+	t.Fatal("no lowland to raise")
+	return -1
+}
+
+func TestUpdateBiomesInto_UnchangedCellsKeepIDs(t *testing.T) {
+	g := biomeGeology()
+	require.NotEmpty(t, g.Biomes)
+	before := append([]geography.Biome(nil), g.Biomes...)
+
+	peak := raiseToPeak(t, g)
+	updated := g.UpdateBiomesInto(g.Biomes, nil, 0)
+	require.Len(t, updated, len(before))
+	assert.Same(t, &g.Biomes[0], &updated[0], "reuses the backing slice")
+
+	for idx := range updated {
+		if idx == peak {
+			continue
+		}
+		require.Equal(t, before[idx], updated[idx], "cell %d didn't change", idx)
+	}
+
+	// The raised cell is a new biome with a new ID
+	assert.Equal(t, geography.BiomeAlpine, updated[peak].Type)
+	assert.NotEqual(t, before[peak].BiomeID, updated[peak].BiomeID)
+
+	// Nothing has changed since, so another update is a no-op
+	again := append([]geography.Biome(nil), updated...)
+	assert.Equal(t, again, g.UpdateBiomesInto(updated, nil, 0))
+}
+
+func TestUpdateBiomesInto_TemperatureShiftKeepsIDs(t *testing.T) {
+	g := biomeGeology()
+	before := append([]geography.Biome(nil), g.Biomes...)
+
+	// A slight warming touches every cell's climate; most keep their biome
+	updated := g.UpdateBiomesInto(g.Biomes, nil, 0.5)
+	kept := 0
+	for idx := range updated {
+		assert.InDelta(t, before[idx].Temperature+0.5, updated[idx].Temperature, 1e-9)
+		if updated[idx].Type == before[idx].Type {
+			assert.Equal(t, before[idx].BiomeID, updated[idx].BiomeID)
+			kept++
+		} else {
+			assert.NotEqual(t, before[idx].BiomeID, updated[idx].BiomeID)
+		}
+	}
+	assert.Greater(t, kept, len(updated)*9/10)
+}
+
+func TestUpdateBiomesInto_AxialTiltChange(t *testing.T) {
+	g := biomeGeology()
+	before := append([]geography.Biome(nil), g.Biomes...)
+	width := g.Heightmap.Width
+	pole, equator := 0, (g.Heightmap.Height/2)*width
+
+	// A steeper tilt warms the poles and cools the equator
+	g.AxialTilt = 40
+	updated := g.UpdateBiomesInto(g.Biomes, nil, 0)
+	assert.Greater(t, updated[pole].Temperature, before[pole].Temperature)
+	assert.Less(t, updated[equator].Temperature, before[equator].Temperature)
+}
+
+func TestUpdateBiomesInto_ChangedCells(t *testing.T) {
+	g := biomeGeology()
+	peak := raiseToPeak(t, g)
+	width := g.Heightmap.Width
+
+	// Only the listed cells are looked at
+	original := g.Biomes[peak]
+	g.UpdateBiomesInto(g.Biomes, []spatial.Coordinate{{X: 0, Y: 0}}, 0)
+	assert.Equal(t, original, g.Biomes[peak])
+
+	g.UpdateBiomesInto(g.Biomes, []spatial.Coordinate{{X: peak % width, Y: peak / width}}, 0)
+	assert.Equal(t, geography.BiomeAlpine, g.Biomes[peak].Type)
+}
+
+func TestUpdateBiomesInto_MatchesUpdateBiomes(t *testing.T) {
+	g := biomeGeology()
+	raiseToPeak(t, g)
+	incremental := g.UpdateBiomesInto(append([]geography.Biome(nil), g.Biomes...), nil, 0)
+
+	// UpdateBiomes picks a new moisture pattern as time passes, so compare
+	// at the same simulated time
+	full := g.UpdateBiomes(0)
+	require.Len(t, incremental, len(full))
+	for idx := range full {
+		assert.Equal(t, full[idx].Type, incremental[idx].Type, "cell %d", idx)
+		assert.Equal(t, full[idx].Temperature, incremental[idx].Temperature, "cell %d", idx)
+	}
+
+	// The wrong size falls back to a full classification
+	assert.Len(t, g.UpdateBiomesInto(nil, nil, 0), len(full))
+}
+
+func BenchmarkUpdateBiomes(b *testing.B) {
+	g := biomeGeology()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Biomes = g.UpdateBiomes(0)
+	}
+}
+
+func BenchmarkUpdateBiomesInto(b *testing.B) {
+	g := biomeGeology()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// A little erosion between updates
+		g.Heightmap.Elevations[i%len(g.Heightmap.Elevations)] -= 1
+		g.Biomes = g.UpdateBiomesInto(g.Biomes, nil, 0)
+	}
+}
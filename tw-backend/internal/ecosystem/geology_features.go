@@ -0,0 +1,136 @@
+package ecosystem
+
+import (
+	"math"
+	"sort"
+
+	"tw-backend/internal/spatial"
+)
+
+const (
+	// MountainRangeMinElevation is how far above sea level (meters) a cell
+	// must rise to be part of a mountain range
+	MountainRangeMinElevation = 2000.0
+
+	// MinMountainRangeCells keeps lone peaks from counting as ranges
+	MinMountainRangeCells = 3
+
+	// MinContinentFraction is the share of the surface a landmass needs to
+	// count as a continent; smaller ones are islands
+	MinContinentFraction = 0.01
+)
+
+// SurfaceFeature is a contiguous region of the spherical heightmap
+type SurfaceFeature struct {
+	Region           []spatial.Coordinate // Every cell in the feature
+	Centroid         spatial.Coordinate   // Cell nearest the feature's center on the sphere
+	Peak             spatial.Coordinate   // Highest cell
+	PeakElevation    float64              // meters
+	AverageElevation float64              // meters
+}
+
+// Continent is a landmass above sea level
+type Continent struct {
+	SurfaceFeature
+}
+
+// MountainRange is a contiguous ridge of high ground
+type MountainRange struct {
+	SurfaceFeature
+}
+
+// DetectContinents finds the landmasses above sea level that cover at least
+// MinContinentFraction of the surface, largest first. Land connected across
+// a cube face seam is one continent.
+func (g *WorldGeology) DetectContinents() []Continent {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.SphereHeightmap == nil || g.Topology == nil {
+		return nil
+	}
+
+	res := g.Topology.Resolution()
+	minCells := int(math.Ceil(MinContinentFraction * float64(6*res*res)))
+	var continents []Continent
+	for _, feature := range g.detectFeatures(g.SeaLevel, minCells) {
+		continents = append(continents, Continent{feature})
+	}
+	return continents
+}
+
+// DetectMountainRanges finds ridges at least MountainRangeMinElevation above
+// sea level and MinMountainRangeCells in size, largest first
+func (g *WorldGeology) DetectMountainRanges() []MountainRange {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.SphereHeightmap == nil || g.Topology == nil {
+		return nil
+	}
+
+	var ranges []MountainRange
+	for _, feature := range g.detectFeatures(g.SeaLevel+MountainRangeMinElevation, MinMountainRangeCells) {
+		ranges = append(ranges, MountainRange{feature})
+	}
+	return ranges
+}
+
+// detectFeatures flood-fills the cells above threshold into regions of at
+// least minCells, largest first (caller holds the lock)
+func (g *WorldGeology) detectFeatures(threshold float64, minCells int) []SurfaceFeature {
+	res := g.Topology.Resolution()
+	visited := make(map[spatial.Coordinate]bool)
+	var features []SurfaceFeature
+
+	for face := 0; face < 6; face++ {
+		for y := 0; y < res; y++ {
+			for x := 0; x < res; x++ {
+				start := spatial.Coordinate{Face: face, X: x, Y: y}
+				if visited[start] || g.SphereHeightmap.Get(start) <= threshold {
+					continue
+				}
+
+				// Breadth-first fill; GetNeighbor carries it across face seams
+				visited[start] = true
+				region := []spatial.Coordinate{start}
+				for i := 0; i < len(region); i++ {
+					for _, dir := range pathDirections {
+						next := g.Topology.GetNeighbor(region[i], dir)
+						if !visited[next] && g.SphereHeightmap.Get(next) > threshold {
+							visited[next] = true
+							region = append(region, next)
+						}
+					}
+				}
+				if len(region) >= minCells {
+					features = append(features, g.describeFeature(region))
+				}
+			}
+		}
+	}
+
+	// Fill order makes ties deterministic, so a stable sort keeps them so
+	sort.SliceStable(features, func(i, j int) bool {
+		return len(features[i].Region) > len(features[j].Region)
+	})
+	return features
+}
+
+// describeFeature summarizes a filled region (caller holds the lock)
+func (g *WorldGeology) describeFeature(region []spatial.Coordinate) SurfaceFeature {
+	feature := SurfaceFeature{Region: region, PeakElevation: math.Inf(-1)}
+	var sum, cx, cy, cz float64
+	for _, c := range region {
+		elev := g.SphereHeightmap.Get(c)
+		sum += elev
+		if elev > feature.PeakElevation {
+			feature.Peak, feature.PeakElevation = c, elev
+		}
+		// Averaging positions on the sphere handles regions that wrap
+		// around faces, where cell coordinates can't be averaged
+		x, y, z := g.Topology.ToSphere(c)
+		cx, cy, cz = cx+x, cy+y, cz+z
+	}
+	feature.AverageElevation = sum / float64(len(region))
+	feature.Centroid = g.Topology.FromVector(cx, cy, cz)
+	return feature
+}
@@ -0,0 +1,113 @@
+package ecosystem
+
+import (
+	"testing"
+
+	"tw-backend/internal/spatial"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// oceanGeology returns a flat world 1000m under the sea
+func oceanGeology() *WorldGeology {
+	g := grasslandGeology()
+	for face := 0; face < 6; face++ {
+		for x := 0; x < pathTestResolution; x++ {
+			for y := 0; y < pathTestResolution; y++ {
+				g.SphereHeightmap.Set(spatial.Coordinate{Face: face, X: x, Y: y}, -1000)
+			}
+		}
+	}
+	return g
+}
+
+// raise sets a rectangle of cells on face to elev
+func raise(g *WorldGeology, face, x0, y0, x1, y1 int, elev float64) {
+	for x := x0; x <= x1; x++ {
+		for y := y0; y <= y1; y++ {
+			g.SphereHeightmap.Set(spatial.Coordinate{Face: face, X: x, Y: y}, elev)
+		}
+	}
+}
+
+func TestDetectContinents_FixedSeed(t *testing.T) {
+	g := NewWorldGeology(uuid.New(), 1, 1_000_000)
+	g.InitializeGeology()
+
+	continents := g.DetectContinents()
+	require.Len(t, continents, 4)
+	for i, c := range continents {
+		if i > 0 {
+			assert.LessOrEqual(t, len(c.Region), len(continents[i-1].Region), "largest first")
+		}
+		assert.Greater(t, c.AverageElevation, g.SeaLevel)
+		assert.GreaterOrEqual(t, c.PeakElevation, c.AverageElevation)
+		assert.Equal(t, c.PeakElevation, g.SphereHeightmap.Get(c.Peak))
+	}
+
+	// Detection only reads the heightmap, so it's repeatable
+	assert.Equal(t, continents, g.DetectContinents())
+}
+
+func TestDetectContinents_JoinsAcrossFaceSeams(t *testing.T) {
+	g := oceanGeology()
+
+	// One landmass straddling the seam between face 0 and its east neighbor
+	east := g.Topology.GetNeighbor(spatial.Coordinate{Face: 0, X: pathTestResolution - 1, Y: 8}, spatial.East)
+	raise(g, 0, 11, 5, 15, 10, 100)
+	g.SphereHeightmap.Set(g.Topology.GetNeighbor(east, spatial.North), 200)
+	g.SphereHeightmap.Set(g.Topology.GetNeighbor(east, spatial.South), 200)
+	g.SphereHeightmap.Set(east, 300)
+
+	// A separate landmass in the middle of face 4, and an island too small to count
+	raise(g, 4, 4, 4, 8, 8, 50)
+	raise(g, 2, 2, 2, 3, 3, 50)
+
+	continents := g.DetectContinents()
+	require.Len(t, continents, 2)
+
+	seam := continents[0]
+	assert.Len(t, seam.Region, 33, "30 cells on face 0 and 3 across the seam")
+	assert.Equal(t, east, seam.Peak)
+	assert.Equal(t, 300.0, seam.PeakElevation)
+	assert.Contains(t, seam.Region, east)
+	assert.Equal(t, 0, seam.Centroid.Face, "most of the landmass is on face 0")
+
+	inland := continents[1]
+	assert.Len(t, inland.Region, 25)
+	assert.Equal(t, spatial.Coordinate{Face: 4, X: 6, Y: 6}, inland.Centroid)
+	assert.Equal(t, 50.0, inland.AverageElevation)
+}
+
+func TestDetectMountainRanges(t *testing.T) {
+	g := oceanGeology()
+	raise(g, 0, 2, 2, 13, 13, 200)
+
+	// A ridge along face 0 and a lone peak that's too small to be a range
+	raise(g, 0, 4, 6, 11, 7, 2500)
+	g.SphereHeightmap.Set(spatial.Coordinate{Face: 0, X: 7, Y: 6}, 4200)
+	g.SphereHeightmap.Set(spatial.Coordinate{Face: 0, X: 12, Y: 12}, 3000)
+
+	ranges := g.DetectMountainRanges()
+	require.Len(t, ranges, 1)
+	ridge := ranges[0]
+	assert.Len(t, ridge.Region, 16)
+	assert.Equal(t, spatial.Coordinate{Face: 0, X: 7, Y: 6}, ridge.Peak)
+	assert.Equal(t, 4200.0, ridge.PeakElevation)
+	assert.InDelta(t, (15*2500+4200)/16.0, ridge.AverageElevation, 1e-9)
+
+	// The mountains sit on one continent
+	require.Len(t, g.DetectContinents(), 1)
+
+	// With the sea 600m higher only the summit stands 2000m above it, too small for a range
+	g.SeaLevel = 600
+	assert.Len(t, g.DetectMountainRanges(), 0)
+}
+
+func TestDetectFeatures_Uninitialized(t *testing.T) {
+	g := NewWorldGeology(uuid.New(), 1, DefaultCircumference)
+	assert.Nil(t, g.DetectContinents())
+	assert.Nil(t, g.DetectMountainRanges())
+}
@@ -0,0 +1,133 @@
+package ecosystem
+
+import (
+	"math"
+
+	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/geography"
+)
+
+const (
+	// IceAccumulationRate is meters of ice gained per year per °C below freezing
+	IceAccumulationRate = 0.0005
+	// IceMeltRate is meters of ice lost per year per °C above freezing
+	IceMeltRate = 0.002
+	// MaxIceThickness caps ice sheets (meters; Antarctica peaks near 4.8 km)
+	MaxIceThickness = 4000.0
+	// IceCoverMinThickness is how thick ice must be to count as cover (meters)
+	IceCoverMinThickness = 1.0
+	// IceAlbedoCooling is how much colder (°C) the planet runs fully iced over
+	IceAlbedoCooling = 20.0
+	// GlacialCarveRate is meters of rock a glacier scours per meter it advances
+	GlacialCarveRate = 0.01
+
+	// iceWaterDensity converts ice thickness to meters of water
+	iceWaterDensity = 0.9
+	// lapseRate is the cooling (°C) per meter of altitude
+	lapseRate = 0.0065
+)
+
+// UpdateGlaciers grows ice on land colder than freezing and melts it where
+// it's warmer, over dt years. avgTemp is the global average surface
+// temperature; latitude and altitude set each column's share of it. Advancing
+// ice carves its valleys deeper through the glacial erosion hook
+// (geography.ApplyGlacialErosionSpherical, or ApplyGlacialErosion on a flat
+// world), and water locked up as ice lowers the sea.
+func (g *WorldGeology) UpdateGlaciers(dt int64, avgTemp float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.updateGlaciers(dt, avgTemp)
+}
+
+// updateGlaciers does UpdateGlaciers' work (caller holds the lock)
+func (g *WorldGeology) updateGlaciers(dt int64, avgTemp float64) {
+	if g.Columns == nil || g.Heightmap == nil || dt <= 0 {
+		return
+	}
+	years := float64(dt)
+	width, height := g.Columns.Width, g.Columns.Height
+	if width != g.Heightmap.Width || height != g.Heightmap.Height {
+		return
+	}
+
+	// Rock scoured by advancing ice, handed to the glacial erosion hook
+	var scour []float64
+	var sphereScour map[spatial.Coordinate]float64
+	if g.SphereHeightmap != nil {
+		sphereScour = make(map[spatial.Coordinate]float64)
+	} else {
+		scour = make([]float64, width*height)
+	}
+
+	var iceWater, oceanArea, coveredArea, totalArea float64
+	for y := 0; y < height; y++ {
+		// Pixels shrink toward the poles of the equirectangular grid
+		lat := (0.5 - (float64(y)+0.5)/float64(height)) * math.Pi
+		area := math.Cos(lat)
+		sinLat := math.Sin(lat)
+		latitudeOffset := 12 - 40*sinLat*sinLat // ~27°C at the equator, ~-25°C at the poles on Earth
+
+		for x := 0; x < width; x++ {
+			col := g.Columns.Get(x, y)
+			elev := g.Heightmap.Get(x, y)
+			totalArea += area
+
+			if elev < g.SeaLevel {
+				// Ice over open ocean floats off and melts
+				col.Ice = 0
+				oceanArea += area
+				continue
+			}
+
+			temp := avgTemp + latitudeOffset - (elev-g.SeaLevel)*lapseRate
+			before := col.Ice
+			if temp < 0 {
+				col.Ice = math.Min(col.Ice-temp*IceAccumulationRate*years, MaxIceThickness)
+			} else {
+				col.Ice = math.Max(col.Ice-temp*IceMeltRate*years, 0)
+			}
+
+			if advance := col.Ice - before; advance > 0 {
+				if sphereScour != nil {
+					// Weighted by area: toward the poles several pixels share a cell
+					sphereScour[g.sphereCoordOf(x, y)] += advance * GlacialCarveRate * area
+				} else {
+					scour[y*width+x] = advance * GlacialCarveRate
+				}
+			}
+			iceWater += col.Ice * iceWaterDensity * area
+			if col.Ice >= IceCoverMinThickness {
+				coveredArea += area
+			}
+		}
+	}
+	if sphereScour != nil {
+		if len(sphereScour) > 0 {
+			geography.ApplyGlacialErosionSpherical(g.SphereHeightmap, sphereScour)
+			g.markSphereNeedsSync()
+		}
+	} else {
+		geography.ApplyGlacialErosion(g.Heightmap, scour)
+	}
+
+	g.IceCoverFraction = coveredArea / totalArea
+	g.GlacialSeaLevelDrop = 0
+	if oceanArea > 0 {
+		g.GlacialSeaLevelDrop = iceWater / oceanArea
+	}
+}
+
+// sphereCoordOf returns the sphere cell under flat pixel (x, y), projecting
+// the pixel the way ToFlatHeightmapInPlace does
+func (g *WorldGeology) sphereCoordOf(x, y int) spatial.Coordinate {
+	lon := float64(x) / float64(g.Heightmap.Width) * 2 * math.Pi
+	lat := (0.5 - float64(y)/float64(g.Heightmap.Height)) * math.Pi
+	return g.Topology.FromVector(math.Cos(lat)*math.Cos(lon), math.Sin(lat), math.Cos(lat)*math.Sin(lon))
+}
+
+// IceCoverage returns the fraction of the surface under ice
+func (g *WorldGeology) IceCoverage() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.IceCoverFraction
+}
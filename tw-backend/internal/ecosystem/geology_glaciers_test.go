@@ -0,0 +1,107 @@
+package ecosystem
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// modernGeology returns a small initialized world at the present day, when
+// geothermal heat no longer keeps the surface warm
+func modernGeology(worldID uuid.UUID) *WorldGeology {
+	g := NewWorldGeology(worldID, 3, 1_000_000)
+	g.InitializeGeology()
+	g.TotalYearsSimulated = 4_500_000_000
+	return g
+}
+
+// simulateClimate runs steps of 10,000 years at a fixed temperature offset
+func simulateClimate(g *WorldGeology, steps int, tempMod float64) {
+	for i := 0; i < steps; i++ {
+		g.SimulateGeology(10_000, tempMod)
+	}
+}
+
+// totalIce sums ice thickness over every column
+func totalIce(g *WorldGeology) float64 {
+	sum := 0.0
+	for _, col := range g.Columns.AllColumns() {
+		sum += col.Ice
+	}
+	return sum
+}
+
+func TestGlaciers_AdvanceInColdAndRetreatAfter(t *testing.T) {
+	worldID := uuid.New()
+	warm, cooled := modernGeology(worldID), modernGeology(worldID)
+	simulateClimate(warm, 20, 30)
+	simulateClimate(cooled, 20, 30)
+	require.Equal(t, warm.IceCoverage(), cooled.IceCoverage())
+
+	// A forced cold period for one world only
+	simulateClimate(warm, 20, 30)
+	simulateClimate(cooled, 20, -10)
+	assert.Greater(t, cooled.IceCoverage(), 2*warm.IceCoverage(), "ice should spread in the cold")
+	assert.Greater(t, totalIce(cooled), totalIce(warm))
+	assert.Greater(t, cooled.GlacialSeaLevelDrop, warm.GlacialSeaLevelDrop)
+	assert.Less(t, cooled.SeaLevel, warm.SeaLevel-50, "water locked up in ice lowers the sea")
+
+	// Once it warms up the extra ice melts and the sea comes back
+	simulateClimate(warm, 60, 45)
+	simulateClimate(cooled, 60, 45)
+	assert.InDelta(t, warm.IceCoverage(), cooled.IceCoverage(), 0.005)
+	assert.InDelta(t, warm.GlacialSeaLevelDrop, cooled.GlacialSeaLevelDrop, 5)
+	assert.InDelta(t, warm.SeaLevel, cooled.SeaLevel, 5)
+}
+
+func TestUpdateGlaciers_CarvesAndCools(t *testing.T) {
+	g := modernGeology(uuid.New())
+	g.UpdateGlaciers(100_000, 30)
+	warmCover := g.IceCoverage()
+	warmTemp := g.calculateAverageSurfaceTemp(0)
+
+	sphereSum := func() float64 {
+		sum := 0.0
+		for face := 0; face < 6; face++ {
+			for _, elev := range g.SphereHeightmap.GetFace(face).Elevations {
+				sum += elev
+			}
+		}
+		return sum
+	}
+	before := sphereSum()
+
+	g.UpdateGlaciers(100_000, -30)
+	cover := g.IceCoverage()
+	require.Greater(t, cover, warmCover)
+	assert.Less(t, sphereSum(), before, "advancing ice scours the land beneath it")
+	assert.InDelta(t, warmTemp-(cover-warmCover)*IceAlbedoCooling, g.calculateAverageSurfaceTemp(0), 1e-9, "ice reflects sunlight")
+
+	for _, col := range g.Columns.AllColumns() {
+		assert.LessOrEqual(t, col.Ice, MaxIceThickness)
+		if g.Heightmap.Get(col.X, col.Y) < g.SeaLevel {
+			assert.Zero(t, col.Ice, "no glaciers on open ocean")
+		}
+	}
+}
+
+func TestUpdateGlaciers_CarvesFlatWorlds(t *testing.T) {
+	g := modernGeology(uuid.New())
+	g.SphereHeightmap = nil
+	g.UpdateGlaciers(100_000, 30)
+	before := append([]float64(nil), g.Heightmap.Elevations...)
+
+	g.UpdateGlaciers(100_000, -30)
+
+	carved := false
+	for _, col := range g.Columns.AllColumns() {
+		i := col.Y*g.Heightmap.Width + col.X
+		if g.Heightmap.Elevations[i] < before[i] {
+			carved = true
+			assert.Greater(t, col.Ice, 0.0, "only ice carves")
+		}
+	}
+	assert.True(t, carved, "advancing ice scours the flat heightmap without a sphere")
+}
@@ -4,6 +4,8 @@ import (
 	"math"
 	"testing"
 
+	"tw-backend/internal/worldgen/astronomy"
+
 	"github.com/google/uuid"
 )
 
@@ -206,3 +208,49 @@ func TestPlanetaryHeat_VeryOldPlanet(t *testing.T) {
 		t.Errorf("Very old planet should have heat ≈ 1.0, got %v", heat)
 	}
 }
+
+// TestTidalHeatingFromStress verifies only tides beyond Earth's Moon add heat
+func TestTidalHeatingFromStress(t *testing.T) {
+	if heat := TidalHeatingFromStress(0); heat != 0 {
+		t.Errorf("Moonless heat = %v, want 0", heat)
+	}
+	if heat := TidalHeatingFromStress(1); heat != 0 {
+		t.Errorf("Earth-Moon heat = %v, want 0 (already in the baseline)", heat)
+	}
+	if heat := TidalHeatingFromStress(5); math.Abs(heat-4*TidalHeatingPerStress) > 1e-9 {
+		t.Errorf("Stress 5 heat = %v, want %v", heat, 4*TidalHeatingPerStress)
+	}
+	if heat := TidalHeatingFromStress(1000); heat != MaxTidalHeating {
+		t.Errorf("Extreme stress heat = %v, want cap %v", heat, MaxTidalHeating)
+	}
+}
+
+// TestTidalHeating_MoreHotspotEruptions verifies a close massive moon drives
+// extra volcanism over the same period
+func TestTidalHeating_MoreHotspotEruptions(t *testing.T) {
+	earthMoon := []astronomy.Satellite{{Mass: astronomy.MoonMassKg, Distance: astronomy.MoonDistanceMeters}}
+	// Same moon at a third of the distance: 27x the tidal stress
+	closeMoon := []astronomy.Satellite{{Mass: astronomy.MoonMassKg, Distance: astronomy.MoonDistanceMeters / 3}}
+
+	eruptions := func(moons []astronomy.Satellite) (int, float64) {
+		g := NewWorldGeology(testWorldID(), 7, 1_000_000)
+		g.InitializeGeology()
+		g.TotalYearsSimulated = 4_500_000_000
+		g.SetTidalHeating(TidalHeatingFromStress(astronomy.CalculateTidalStress(moons)))
+
+		total := 0
+		for i := 0; i < 100; i++ {
+			total += g.applyHotspotActivity(1_000)
+		}
+		return total, g.PlanetaryHeat()
+	}
+
+	calm, calmHeat := eruptions(earthMoon)
+	tidal, tidalHeat := eruptions(closeMoon)
+	if tidalHeat <= calmHeat {
+		t.Errorf("Close moon heat %v should exceed Earth-Moon heat %v", tidalHeat, calmHeat)
+	}
+	if calm == 0 || tidal < 2*calm {
+		t.Errorf("Close moon eruptions = %d, want at least 2x Earth-Moon's %d", tidal, calm)
+	}
+}
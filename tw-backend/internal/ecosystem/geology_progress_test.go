@@ -0,0 +1,56 @@
+package ecosystem
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateGeology_ProgressFiresEveryInterval(t *testing.T) {
+	g := NewWorldGeology(uuid.New(), 7, 1_000_000)
+	g.InitializeGeology()
+
+	var years []int64
+	g.SetProgressFunc(500_000, func(yearsSimulated int64, stats GeologyStats) {
+		years = append(years, yearsSimulated)
+		assert.Equal(t, yearsSimulated, stats.YearsSimulated)
+	})
+
+	for i := 0; i < 20; i++ {
+		g.SimulateGeology(100_000, 0)
+	}
+	assert.Equal(t, []int64{500_000, 1_000_000, 1_500_000, 2_000_000}, years)
+}
+
+func TestSimulateGeology_ProgressWithUnevenSteps(t *testing.T) {
+	g := NewWorldGeology(uuid.New(), 7, 1_000_000)
+	g.InitializeGeology()
+
+	calls := 0
+	g.SetProgressFunc(500_000, func(int64, GeologyStats) { calls++ })
+
+	// 300k-year steps pass a 500k boundary at 600k, 1.2M, 1.5M, 2.1M, 2.7M and 3M
+	for i := 0; i < 10; i++ {
+		g.SimulateGeology(300_000, 0)
+	}
+	assert.Equal(t, 6, calls)
+}
+
+func TestSimulateGeology_ProgressCalledWithoutLock(t *testing.T) {
+	g := NewWorldGeology(uuid.New(), 7, 1_000_000)
+	g.InitializeGeology()
+
+	var fromCallback []GeologyStats
+	g.SetProgressFunc(100_000, func(_ int64, stats GeologyStats) {
+		// Deadlocks if SimulateGeology still holds the lock
+		fromCallback = append(fromCallback, g.GetStats())
+		g.SetProgressFunc(0, nil)
+	})
+
+	g.SimulateGeology(100_000, 0)
+	g.SimulateGeology(100_000, 0)
+	require.Len(t, fromCallback, 1, "the callback turned reporting off")
+	assert.Equal(t, int64(100_000), fromCallback[0].YearsSimulated)
+}
@@ -0,0 +1,78 @@
+package ecosystem
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeightmapSize_DefaultUnchanged(t *testing.T) {
+	tests := []struct {
+		name          string
+		circumKm      float64
+		width, height int
+	}{
+		{"earth is capped at 512x256", 40_000, 512, 256},
+		{"mid-sized world at 10 km per pixel", 2_000, 200, 100},
+		{"tiny world at the minimum", 300, 64, 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := heightmapSize(tt.circumKm, nil)
+			assert.Equal(t, tt.width, width)
+			assert.Equal(t, tt.height, height)
+		})
+	}
+}
+
+func TestHeightmapSize_HugeWorldStaysInBudget(t *testing.T) {
+	res := &HeightmapResolution{PixelsPerKm: 1, MaxBytes: 8 << 20}
+	require.NoError(t, res.Validate())
+
+	// 40,000 pixels wide at 1 pixel per km would need ~12 GB
+	width, height := heightmapSize(40_000, res)
+	assert.LessOrEqual(t, heightmapBytes(width), res.MaxBytes)
+	assert.Greater(t, heightmapBytes(width+2), res.MaxBytes, "the width should shrink only as far as the budget needs")
+	assert.Equal(t, width/2, height)
+
+	width, _ = heightmapSize(40_000, &HeightmapResolution{PixelsPerKm: 1})
+	assert.LessOrEqual(t, heightmapBytes(width), DefaultHeightmapMemoryBudget)
+}
+
+func TestHeightmapResolution_Validate(t *testing.T) {
+	assert.NoError(t, HeightmapResolution{Width: 1024}.Validate())
+	assert.NoError(t, HeightmapResolution{PixelsPerKm: 0.5}.Validate())
+
+	assert.Error(t, HeightmapResolution{}.Validate(), "needs a width or detail")
+	assert.Error(t, HeightmapResolution{Width: -2}.Validate())
+	assert.Error(t, HeightmapResolution{Width: 32}.Validate(), "below the minimum width")
+	assert.Error(t, HeightmapResolution{Width: 4096, MaxBytes: 1 << 20}.Validate(), "over the memory budget")
+	assert.Error(t, HeightmapResolution{PixelsPerKm: 1, MaxBytes: 1024}.Validate(), "no map fits the budget")
+
+	g := NewWorldGeology(uuid.New(), 1, DefaultCircumference)
+	assert.Error(t, g.SetHeightmapResolution(&HeightmapResolution{PixelsPerKm: -1}))
+	assert.Nil(t, g.HeightmapResolution, "an invalid resolution isn't applied")
+}
+
+func TestInitializeGeology_SmallWorldGetsMoreDetail(t *testing.T) {
+	const circumference = 800_000.0 // 800 km
+
+	coarse := NewWorldGeology(uuid.New(), 5, circumference)
+	coarse.InitializeGeology()
+
+	fine := NewWorldGeology(uuid.New(), 5, circumference)
+	require.NoError(t, fine.SetHeightmapResolution(&HeightmapResolution{PixelsPerKm: 0.4}))
+	fine.InitializeGeology()
+
+	assert.Equal(t, 80, coarse.Heightmap.Width, "10 km per pixel by default")
+	assert.Equal(t, 320, fine.Heightmap.Width)
+	assert.Equal(t, 160, fine.Heightmap.Height)
+	assert.InDelta(t, 0.4, fine.PixelsPerKm, 1e-9)
+	assert.Greater(t, fine.PixelsPerKm, coarse.PixelsPerKm)
+
+	// The sphere and the flat map stay in step
+	assert.Equal(t, fine.Heightmap.Height, fine.Topology.Resolution())
+	assert.Equal(t, fine.Heightmap.Width, fine.Columns.Width)
+}
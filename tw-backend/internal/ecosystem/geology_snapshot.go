@@ -0,0 +1,304 @@
+package ecosystem
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/rand"
+
+	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/astronomy"
+	"tw-backend/internal/worldgen/geography"
+	"tw-backend/internal/worldgen/underground"
+	"tw-backend/internal/worldgen/weather"
+
+	"github.com/google/uuid"
+)
+
+// geologySnapshotVersion is bumped whenever geologySnapshot changes shape.
+// LoadWorldGeology also reads earlier versions, filling in what they lack:
+//
+//	1: the original format
+//	2: adds HeightmapResolution, AxialTilt, Lakes, IceCoverFraction,
+//	   GlacialSeaLevelDrop and TidalHeating, and each column's Ice and
+//	   WaterTable
+const geologySnapshotVersion = 2
+
+// geologySnapshot is the serialized form of a WorldGeology
+type geologySnapshot struct {
+	Version int
+
+	WorldID             uuid.UUID
+	Seed                int64
+	Circumference       float64
+	Composition         string
+	AxialTilt           float64
+	Erosion             *ErosionProfile
+	HeightmapResolution *HeightmapResolution
+
+	// Resolution of the cube-sphere topology; 0 if never initialized
+	Resolution    int
+	SphereFaces   [6][]float64
+	SphereMinElev float64
+	SphereMaxElev float64
+	Heightmap     *geography.Heightmap
+	Plates        []plateSnapshot
+	BoundaryCache *geography.BoundaryCache
+	SeaLevel      float64
+
+	Columns []underground.WorldColumn
+	Width   int // Column grid dimensions
+	Height  int
+	Caves   []*underground.Cave
+
+	Hotspots   []geography.Point
+	Rivers     [][]geography.Point
+	Lakes      []geography.Lake
+	Biomes     []geography.Biome
+	Satellites []astronomy.Satellite
+
+	TotalYearsSimulated int64
+	RandDraws           uint64 // Values drawn from the seeded RNG so far
+	PixelsPerKm         float64
+
+	TectonicStressAccumulator float64
+	ErosionAccumulator        float64
+	DepositAccumulator        float64
+	RiverAccumulator          float64
+	MaintenanceAccumulator    float64
+	GeneralAccumulator        float64
+
+	SphereNeedsSync     bool
+	OceanVaporFraction  float64
+	IceCoverFraction    float64
+	GlacialSeaLevelDrop float64
+	TidalHeating        float64
+}
+
+// plateSnapshot is a TectonicPlate with its region as a list, since gob
+// can't encode a set of empty structs
+type plateSnapshot struct {
+	ID        uuid.UUID
+	Type      geography.PlateType
+	Centroid  spatial.Coordinate
+	Position  spatial.Vector3D
+	Velocity  spatial.Vector3D
+	Region    []spatial.Coordinate
+	Thickness float64
+	Age       float64
+}
+
+// Serialize encodes the full geology state with gob. LoadWorldGeology
+// restores it, including the random number generator, so a reloaded world
+// simulates on exactly as the original would have.
+func (g *WorldGeology) Serialize() ([]byte, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	snap := geologySnapshot{
+		Version:                   geologySnapshotVersion,
+		WorldID:                   g.WorldID,
+		Seed:                      g.Seed,
+		Circumference:             g.Circumference,
+		Composition:               g.Composition,
+		AxialTilt:                 g.AxialTilt,
+		Erosion:                   g.Erosion,
+		HeightmapResolution:       g.HeightmapResolution,
+		Heightmap:                 g.Heightmap,
+		BoundaryCache:             g.BoundaryCache,
+		SeaLevel:                  g.SeaLevel,
+		Caves:                     g.Caves,
+		Hotspots:                  g.Hotspots,
+		Rivers:                    g.Rivers,
+		Lakes:                     g.Lakes,
+		Biomes:                    g.Biomes,
+		Satellites:                g.Satellites,
+		TotalYearsSimulated:       g.TotalYearsSimulated,
+		PixelsPerKm:               g.PixelsPerKm,
+		TectonicStressAccumulator: g.TectonicStressAccumulator,
+		ErosionAccumulator:        g.ErosionAccumulator,
+		DepositAccumulator:        g.DepositAccumulator,
+		RiverAccumulator:          g.RiverAccumulator,
+		MaintenanceAccumulator:    g.MaintenanceAccumulator,
+		GeneralAccumulator:        g.GeneralAccumulator,
+		SphereNeedsSync:           g.sphereNeedsSync,
+		OceanVaporFraction:        g.OceanVaporFraction,
+		IceCoverFraction:          g.IceCoverFraction,
+		GlacialSeaLevelDrop:       g.GlacialSeaLevelDrop,
+		TidalHeating:              g.TidalHeating,
+	}
+	if g.rngSource != nil {
+		snap.RandDraws = g.rngSource.draws
+	}
+
+	if g.Topology != nil {
+		if _, ok := g.Topology.(*spatial.CubeSphereTopology); !ok {
+			return nil, fmt.Errorf("serialize geology: unsupported topology %T", g.Topology)
+		}
+		snap.Resolution = g.Topology.Resolution()
+	}
+	if g.SphereHeightmap != nil {
+		for face := range snap.SphereFaces {
+			snap.SphereFaces[face] = g.SphereHeightmap.GetFace(face).Elevations
+		}
+		snap.SphereMinElev, snap.SphereMaxElev = g.SphereHeightmap.MinMax()
+	}
+
+	for _, plate := range g.Plates {
+		ps := plateSnapshot{
+			ID:        plate.ID,
+			Type:      plate.Type,
+			Centroid:  plate.Centroid,
+			Position:  plate.Position,
+			Velocity:  plate.Velocity,
+			Thickness: plate.Thickness,
+			Age:       plate.Age,
+		}
+		for coord := range plate.Region {
+			ps.Region = append(ps.Region, coord)
+		}
+		snap.Plates = append(snap.Plates, ps)
+	}
+
+	if g.Columns != nil {
+		snap.Width, snap.Height = g.Columns.Width, g.Columns.Height
+		for _, col := range g.Columns.AllColumns() {
+			snap.Columns = append(snap.Columns, *col)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&snap); err != nil {
+		return nil, fmt.Errorf("serialize geology: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// LoadWorldGeology restores a geology saved with Serialize. The result's
+// GetStats match the original's, and simulating it further gives the same
+// results as the original would have.
+func LoadWorldGeology(data []byte) (*WorldGeology, error) {
+	var snap geologySnapshot
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("load geology: %w", err)
+	}
+	if snap.Version < 1 || snap.Version > geologySnapshotVersion {
+		return nil, fmt.Errorf("load geology: unsupported snapshot version %d (want 1 to %d)", snap.Version, geologySnapshotVersion)
+	}
+
+	g := NewWorldGeology(snap.WorldID, snap.Seed, snap.Circumference)
+	g.Composition = snap.Composition
+	g.AxialTilt = snap.AxialTilt
+	g.Erosion = snap.Erosion
+	g.HeightmapResolution = snap.HeightmapResolution
+	g.Heightmap = snap.Heightmap
+	g.BoundaryCache = snap.BoundaryCache
+	g.SeaLevel = snap.SeaLevel
+	g.Caves = snap.Caves
+	g.Hotspots = snap.Hotspots
+	g.Rivers = snap.Rivers
+	g.Lakes = snap.Lakes
+	g.Biomes = snap.Biomes
+	g.Satellites = snap.Satellites
+	g.TotalYearsSimulated = snap.TotalYearsSimulated
+	g.PixelsPerKm = snap.PixelsPerKm
+	g.TectonicStressAccumulator = snap.TectonicStressAccumulator
+	g.ErosionAccumulator = snap.ErosionAccumulator
+	g.DepositAccumulator = snap.DepositAccumulator
+	g.RiverAccumulator = snap.RiverAccumulator
+	g.MaintenanceAccumulator = snap.MaintenanceAccumulator
+	g.GeneralAccumulator = snap.GeneralAccumulator
+	g.sphereNeedsSync = snap.SphereNeedsSync
+	g.OceanVaporFraction = snap.OceanVaporFraction
+	g.IceCoverFraction = snap.IceCoverFraction
+	g.GlacialSeaLevelDrop = snap.GlacialSeaLevelDrop
+	g.TidalHeating = snap.TidalHeating
+	if snap.Version < 2 {
+		// Version 1 worlds had Earth's tilt and no tidal heating of their
+		// own; the rest of what v2 added starts empty and is rebuilt as the
+		// geology simulates
+		g.AxialTilt = weather.DefaultAxialTilt
+		g.TidalHeating = TidalHeatingFromStress(astronomy.CalculateTidalStress(snap.Satellites))
+	}
+
+	// Replay the draws so the RNG picks up where it left off
+	for i := uint64(0); i < snap.RandDraws; i++ {
+		g.rngSource.Uint64()
+	}
+
+	if snap.Resolution > 0 {
+		g.Topology = spatial.NewCubeSphereTopology(snap.Resolution)
+		if snap.SphereFaces[0] != nil {
+			g.SphereHeightmap = geography.NewSphereHeightmap(g.Topology)
+			for face, elevations := range snap.SphereFaces {
+				dest := g.SphereHeightmap.GetFace(face)
+				if len(elevations) != len(dest.Elevations) {
+					return nil, fmt.Errorf("load geology: face %d has %d cells, want %d", face, len(elevations), len(dest.Elevations))
+				}
+				copy(dest.Elevations, elevations)
+			}
+			g.SphereHeightmap.MinElev, g.SphereHeightmap.MaxElev = snap.SphereMinElev, snap.SphereMaxElev
+		}
+	}
+
+	for _, ps := range snap.Plates {
+		plate := geography.TectonicPlate{
+			ID:        ps.ID,
+			Type:      ps.Type,
+			Centroid:  ps.Centroid,
+			Position:  ps.Position,
+			Velocity:  ps.Velocity,
+			Region:    make(map[spatial.Coordinate]struct{}, len(ps.Region)),
+			Thickness: ps.Thickness,
+			Age:       ps.Age,
+		}
+		for _, coord := range ps.Region {
+			plate.Region[coord] = struct{}{}
+		}
+		g.Plates = append(g.Plates, plate)
+	}
+
+	if snap.Width > 0 && snap.Height > 0 {
+		if len(snap.Columns) != snap.Width*snap.Height {
+			return nil, fmt.Errorf("load geology: %d columns for a %dx%d grid", len(snap.Columns), snap.Width, snap.Height)
+		}
+		g.Columns = underground.NewColumnGrid(snap.Width, snap.Height)
+		for i := range snap.Columns {
+			col := &snap.Columns[i]
+			if snap.Version < 2 {
+				col.WaterTable = col.Bedrock // Dry, as a new column starts
+			}
+			g.Columns.Set(col.X, col.Y, col)
+		}
+	}
+	return g, nil
+}
+
+// countingSource is a seeded rand source that counts the values drawn from
+// it, so a geology's RNG can be restored by replaying that many draws
+type countingSource struct {
+	src   rand.Source64
+	draws uint64
+}
+
+// newGeologyRand returns a seeded RNG and the source that counts its draws
+func newGeologyRand(seed int64) (*rand.Rand, *countingSource) {
+	seeded, _ := rand.NewSource(seed).(rand.Source64) // Type assertion guaranteed: the seeded source implements Source64
+	src := &countingSource{src: seeded}
+	return rand.New(src), src
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.draws = 0
+}
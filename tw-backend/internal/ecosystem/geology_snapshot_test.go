@@ -0,0 +1,150 @@
+package ecosystem
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"tw-backend/internal/worldgen/astronomy"
+	"tw-backend/internal/worldgen/weather"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cooledGeology returns a small initialized world past the Hadean, so
+// hotspots erupt and draw from the geology's RNG
+func cooledGeology(worldID uuid.UUID) *WorldGeology {
+	g := NewWorldGeology(worldID, 4242, 1_000_000)
+	g.InitializeGeology()
+	g.TotalYearsSimulated = 600_000_000
+	return g
+}
+
+// simulateYears advances g in 100,000 year steps
+func simulateYears(g *WorldGeology, years int64) {
+	const step = 100_000
+	for done := int64(0); done < years; done += step {
+		g.SimulateGeology(step, 0)
+	}
+}
+
+// requireSameGeology checks two geologies are in exactly the same state
+func requireSameGeology(t *testing.T, want, got *WorldGeology) {
+	t.Helper()
+	require.Equal(t, want.GetStats(), got.GetStats())
+	require.Equal(t, want.Heightmap.Elevations, got.Heightmap.Elevations)
+	for face := 0; face < 6; face++ {
+		require.Equal(t, want.SphereHeightmap.GetFace(face).Elevations, got.SphereHeightmap.GetFace(face).Elevations, "face %d", face)
+	}
+	require.Len(t, got.Plates, len(want.Plates))
+	for i := range want.Plates {
+		// Plate IDs are random, so independent runs only match in position
+		plate := got.Plates[i]
+		plate.ID = want.Plates[i].ID
+		require.Equal(t, want.Plates[i], plate, "plate %d", i)
+	}
+	require.Equal(t, want.Hotspots, got.Hotspots)
+	require.Equal(t, want.Rivers, got.Rivers)
+	assert.Equal(t, want.TectonicStressAccumulator, got.TectonicStressAccumulator)
+	assert.Equal(t, want.MaintenanceAccumulator, got.MaintenanceAccumulator)
+	assert.Equal(t, want.OceanVaporFraction, got.OceanVaporFraction)
+}
+
+func TestWorldGeology_SerializeRoundTrip(t *testing.T) {
+	g := cooledGeology(uuid.New())
+	simulateYears(g, 1_000_000)
+
+	data, err := g.Serialize()
+	require.NoError(t, err)
+
+	loaded, err := LoadWorldGeology(data)
+	require.NoError(t, err)
+	requireSameGeology(t, g, loaded)
+	assert.Equal(t, g.WorldID, loaded.WorldID)
+	assert.Equal(t, g.Seed, loaded.Seed)
+	assert.Equal(t, g.Composition, loaded.Composition)
+	assert.Equal(t, g.Plates, loaded.Plates)
+	assert.Equal(t, g.Columns.Width*g.Columns.Height, len(loaded.Columns.AllColumns()))
+	assert.Equal(t, g.Columns.Get(5, 7).Strata, loaded.Columns.Get(5, 7).Strata)
+	assert.Equal(t, g.rngSource.draws, loaded.rngSource.draws)
+}
+
+func TestWorldGeology_SerializeKeepsAxialTilt(t *testing.T) {
+	g := cooledGeology(uuid.New())
+	g.AxialTilt = 41.5
+
+	data, err := g.Serialize()
+	require.NoError(t, err)
+
+	loaded, err := LoadWorldGeology(data)
+	require.NoError(t, err)
+	assert.Equal(t, 41.5, loaded.AxialTilt)
+}
+
+func TestWorldGeology_ReloadMatchesUninterruptedRun(t *testing.T) {
+	worldID := uuid.New()
+
+	uninterrupted := cooledGeology(worldID)
+	simulateYears(uninterrupted, 1_000_000)
+	uninterrupted.TriggerCatastrophe("volcano", 0.8)
+	simulateYears(uninterrupted, 1_000_000)
+
+	first := cooledGeology(worldID)
+	simulateYears(first, 1_000_000)
+	require.Positive(t, first.rngSource.draws, "the run should draw from the RNG before saving")
+	data, err := first.Serialize()
+	require.NoError(t, err)
+
+	resumed, err := LoadWorldGeology(data)
+	require.NoError(t, err)
+	resumed.TriggerCatastrophe("volcano", 0.8)
+	simulateYears(resumed, 1_000_000)
+
+	requireSameGeology(t, uninterrupted, resumed)
+}
+
+func TestLoadWorldGeology_ReadsVersion1(t *testing.T) {
+	g := cooledGeology(uuid.New())
+	g.Satellites = []astronomy.Satellite{{Name: "Close Moon", Mass: 7.35e22, Distance: 1.9e8}}
+	data, err := g.Serialize()
+	require.NoError(t, err)
+
+	// Rewrite the snapshot as version 1 wrote it, without the later fields
+	var snap geologySnapshot
+	require.NoError(t, gob.NewDecoder(bytes.NewReader(data)).Decode(&snap))
+	snap.Version = 1
+	snap.AxialTilt, snap.TidalHeating = 0, 0
+	for i := range snap.Columns {
+		snap.Columns[i].WaterTable = 0
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(&snap))
+
+	loaded, err := LoadWorldGeology(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, weather.DefaultAxialTilt, loaded.AxialTilt)
+	assert.Positive(t, loaded.TidalHeating, "tidal heating is recomputed from the moons")
+	for _, col := range loaded.Columns.AllColumns() {
+		require.Equal(t, col.Bedrock, col.WaterTable, "columns start dry")
+	}
+	assert.Equal(t, g.GetStats(), loaded.GetStats())
+
+	snap.Version = geologySnapshotVersion + 1
+	buf.Reset()
+	require.NoError(t, gob.NewEncoder(&buf).Encode(&snap))
+	_, err = LoadWorldGeology(buf.Bytes())
+	assert.Error(t, err, "snapshots from newer versions are rejected")
+}
+
+func TestLoadWorldGeology_RejectsBadData(t *testing.T) {
+	_, err := LoadWorldGeology([]byte("not a geology"))
+	assert.Error(t, err)
+
+	data, err := NewWorldGeology(uuid.New(), 1, DefaultCircumference).Serialize()
+	require.NoError(t, err)
+	loaded, err := LoadWorldGeology(data)
+	require.NoError(t, err)
+	assert.False(t, loaded.IsInitialized(), "an uninitialized geology stays uninitialized")
+}
@@ -1,12 +1,15 @@
 package ecosystem
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 	"tw-backend/internal/spatial"
 	"tw-backend/internal/worldgen/geography"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWorldGeology_Lifecycle(t *testing.T) {
@@ -152,3 +155,130 @@ func TestPlateMovement_Realism(t *testing.T) {
 		assert.NotEqual(t, preDriftSum, postDriftSum, "SphereHeightmap should change after continental drift")
 	}
 }
+
+func TestNewWorldGeology_InvalidCircumference(t *testing.T) {
+	for _, c := range []float64{0, -1000, math.NaN(), math.Inf(1)} {
+		assert.Error(t, ValidateCircumference(c), "%v should be rejected", c)
+		geo := NewWorldGeology(uuid.New(), 1, c)
+		assert.Equal(t, DefaultCircumference, geo.Circumference, "%v should fall back to the default", c)
+	}
+
+	assert.NoError(t, ValidateCircumference(1))
+	geo := NewWorldGeology(uuid.New(), 1, 1)
+	assert.Equal(t, MinCircumference, geo.Circumference, "A 1 m world should be clamped to the minimum")
+}
+
+func TestInitializeGeology_MinimalCircumference(t *testing.T) {
+	geo := NewWorldGeology(uuid.New(), 7, MinCircumference)
+	geo.InitializeGeology()
+
+	require.True(t, geo.IsInitialized())
+	hm := geo.Heightmap
+	require.Greater(t, hm.Width, 0)
+	require.Greater(t, hm.Height, 0)
+	assert.Len(t, hm.Elevations, hm.Width*hm.Height)
+	for i, e := range hm.Elevations {
+		if math.IsNaN(e) || math.IsInf(e, 0) {
+			t.Fatalf("Elevation %d is %v", i, e)
+		}
+	}
+	assert.False(t, math.IsInf(geo.PixelsPerKm, 0) || geo.PixelsPerKm <= 0, "PixelsPerKm = %v", geo.PixelsPerKm)
+
+	require.NotNil(t, geo.Columns)
+	assert.Equal(t, hm.Width, geo.Columns.Width)
+	assert.Equal(t, hm.Height, geo.Columns.Height)
+	assert.NotNil(t, geo.Columns.Get(hm.Width-1, hm.Height-1))
+
+	// Plates must move a finite distance on a small planet
+	geo.SimulateGeology(10_000, 0.0)
+	for i, e := range geo.Heightmap.Elevations {
+		if math.IsNaN(e) || math.IsInf(e, 0) {
+			t.Fatalf("Elevation %d is %v after simulating", i, e)
+		}
+	}
+}
+
+func TestInitializeGeology_ZeroCircumferenceSetDirectly(t *testing.T) {
+	geo := &WorldGeology{WorldID: uuid.New(), Seed: 3, rng: rand.New(rand.NewSource(3))}
+	geo.InitializeGeology()
+
+	assert.Equal(t, DefaultCircumference, geo.Circumference)
+	assert.True(t, geo.IsInitialized())
+	assert.NotEmpty(t, geo.Heightmap.Elevations)
+}
+
+func TestErosionProfileFor_Compositions(t *testing.T) {
+	ancient := ErosionProfileFor("ancient")
+	volcanic := ErosionProfileFor("volcanic")
+	assert.Less(t, ancient.Interval, volcanic.Interval, "Ancient worlds erode more often")
+	assert.Greater(t, ancient.ThermalIterations, volcanic.ThermalIterations)
+	assert.Greater(t, ancient.SedimentRate, volcanic.SedimentRate)
+	assert.Equal(t, ErosionProfileFor("continental"), ErosionProfileFor("unknown"))
+
+	geo := NewWorldGeology(uuid.New(), 1, MinCircumference)
+	geo.SetComposition("ancient")
+	assert.Equal(t, ancient, geo.ErosionProfile())
+
+	override := ErosionProfile{Interval: 1_000, ThermalIterations: 1, HydraulicDrops: 10, SedimentRate: 2}
+	geo.SetErosionProfile(&override)
+	assert.Equal(t, override, geo.ErosionProfile())
+	geo.SetErosionProfile(nil)
+	assert.Equal(t, ancient, geo.ErosionProfile())
+}
+
+// meanLocalRelief averages the elevation difference between horizontally adjacent cells
+func meanLocalRelief(hm *geography.Heightmap) float64 {
+	var sum float64
+	var count int
+	for y := 0; y < hm.Height; y++ {
+		for x := 1; x < hm.Width; x++ {
+			sum += math.Abs(hm.Get(x, y) - hm.Get(x-1, y))
+			count++
+		}
+	}
+	return sum / float64(count)
+}
+
+func TestSimulateGeology_AncientErodesMoreThanVolcanic(t *testing.T) {
+	run := func(composition string) float64 {
+		geo := NewWorldGeology(uuid.New(), 99, MinCircumference)
+		geo.SetComposition(composition)
+		geo.InitializeGeology()
+		// Start after the Hadean so surface processes run
+		geo.TotalYearsSimulated = 600_000_000
+		for i := 0; i < 10; i++ {
+			geo.SimulateGeology(10_000_000, 0.0)
+		}
+		geo.mu.Lock()
+		geo.flushSync()
+		geo.mu.Unlock()
+		return meanLocalRelief(geo.Heightmap)
+	}
+
+	ancient := run("ancient")
+	volcanic := run("volcanic")
+	assert.Less(t, ancient, volcanic, "Ancient world should end with gentler relief (ancient %.1f m, volcanic %.1f m)", ancient, volcanic)
+}
+
+func TestApplyEvent_ReportsCatastropheSites(t *testing.T) {
+	geo := NewWorldGeology(uuid.New(), 11, MinCircumference)
+	geo.InitializeGeology()
+	hm := geo.Heightmap
+
+	before := append([]float64(nil), hm.Elevations...)
+	sites := geo.ApplyEvent(GeologicalEvent{Type: EventAsteroidImpact, Severity: 1})
+	require.Len(t, sites, 1)
+	site := sites[0]
+	assert.Equal(t, EventAsteroidImpact, site.Type)
+	require.True(t, site.X >= 0 && site.X < float64(hm.Width) && site.Y >= 0 && site.Y < float64(hm.Height),
+		"The impact lands on the map: %+v", site)
+	assert.Positive(t, site.Radius)
+
+	geo.flushSync()
+	idx := int(site.Y)*hm.Width + int(site.X)
+	assert.Less(t, geo.Heightmap.Elevations[idx], before[idx], "The crater is dug where the site says")
+
+	volcanoes := geo.ApplyEvent(GeologicalEvent{Type: EventVolcanicWinter, Severity: 1})
+	assert.Len(t, volcanoes, 4, "Severe volcanism raises four volcanoes")
+	assert.Empty(t, geo.ApplyEvent(GeologicalEvent{Type: EventOceanAnoxia, Severity: 1}), "Anoxia strikes no one place")
+}
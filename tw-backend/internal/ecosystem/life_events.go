@@ -0,0 +1,77 @@
+package ecosystem
+
+import (
+	"context"
+	"fmt"
+
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/ecosystem/sapience"
+	"tw-backend/internal/eventstore"
+
+	"github.com/google/uuid"
+)
+
+// LifeEventPublisher publishes a world's species_extinct and
+// sapience_achieved events for other services. Publishing is best-effort:
+// failures are logged and not retried.
+type LifeEventPublisher struct {
+	events    *eventstore.Publisher
+	worldID   uuid.UUID
+	published int // Extinctions of the fossil record already published
+}
+
+// NewLifeEventPublisher creates a publisher for a world's life events.
+// Extinctions already in sim's fossil record are not published again.
+func NewLifeEventPublisher(events *eventstore.Publisher, worldID uuid.UUID, sim *population.PopulationSimulator) *LifeEventPublisher {
+	lp := &LifeEventPublisher{events: events, worldID: worldID}
+	lp.SkipRecorded(sim)
+	return lp
+}
+
+// SkipRecorded marks every extinction already in sim's fossil record as
+// published, e.g. when the simulator was restored or handed over
+func (lp *LifeEventPublisher) SkipRecorded(sim *population.PopulationSimulator) {
+	lp.published = 0
+	if sim != nil && sim.FossilRecord != nil {
+		lp.published = len(sim.FossilRecord.Extinct)
+	}
+}
+
+// PublishExtinctions publishes extinctions recorded since the last call
+func (lp *LifeEventPublisher) PublishExtinctions(ctx context.Context, sim *population.PopulationSimulator) {
+	if sim == nil || sim.FossilRecord == nil {
+		return
+	}
+	extinct := sim.FossilRecord.Extinct
+	for _, ext := range extinct[min(lp.published, len(extinct)):] {
+		payload := eventstore.SpeciesExtinctPayload{
+			WorldID:        lp.worldID,
+			SpeciesID:      ext.SpeciesID,
+			Name:           ext.Name,
+			Diet:           string(ext.Diet),
+			PeakPopulation: ext.PeakPopulation,
+			ExistedFrom:    ext.ExistedFrom,
+			ExistedUntil:   ext.ExistedUntil,
+			Cause:          ext.ExtinctionCause,
+		}
+		if err := lp.events.Publish(ctx, eventstore.AggregateSpecies, ext.SpeciesID.String(), eventstore.EventTypeSpeciesExtinct, payload); err != nil {
+			fmt.Printf("Failed to publish extinction of %s: %v\n", ext.Name, err)
+		}
+	}
+	lp.published = len(extinct)
+}
+
+// PublishSapience publishes a species reaching sapience
+func (lp *LifeEventPublisher) PublishSapience(ctx context.Context, candidate *sapience.SapienceCandidate) {
+	payload := eventstore.SapienceAchievedPayload{
+		WorldID:       lp.worldID,
+		SpeciesID:     candidate.SpeciesID,
+		Name:          candidate.SpeciesName,
+		Year:          candidate.YearDetected,
+		Score:         candidate.Score,
+		MagicAssisted: candidate.IsMagicAssisted,
+	}
+	if err := lp.events.Publish(ctx, eventstore.AggregateSpecies, candidate.SpeciesID.String(), eventstore.EventTypeSapienceAchieved, payload); err != nil {
+		fmt.Printf("Failed to publish sapience of %s: %v\n", candidate.SpeciesName, err)
+	}
+}
@@ -6,9 +6,11 @@ package ecosystem
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,6 +34,24 @@ const (
 	LogLevelError
 )
 
+// ParseVerbosity maps a user-facing verbosity name to a log level: quiet
+// (warnings and errors), info (major events), verbose (speciation, extinction,
+// outbreaks), or debug (every year's calculations). "" selects info.
+func ParseVerbosity(name string) (LogLevel, error) {
+	switch strings.ToLower(name) {
+	case "quiet":
+		return LogLevelWarn, nil
+	case "", "info":
+		return LogLevelInfo, nil
+	case "verbose":
+		return LogLevelDebug, nil
+	case "debug":
+		return LogLevelTrace, nil
+	default:
+		return LogLevelInfo, fmt.Errorf("unknown verbosity %q (use quiet, info, verbose, or debug)", name)
+	}
+}
+
 // SimulationEventType categorizes simulation events for querying
 type SimulationEventType string
 
@@ -159,3 +159,23 @@ func TestSimulationLogger_ConvenienceMethods(t *testing.T) {
 		logger.LogYearSummary(ctx, 8000000, 100, 50000000, 1000000, 500000)
 	})
 }
+
+func TestParseVerbosity(t *testing.T) {
+	cases := map[string]LogLevel{
+		"":        LogLevelInfo,
+		"quiet":   LogLevelWarn,
+		"info":    LogLevelInfo,
+		"Verbose": LogLevelDebug,
+		"debug":   LogLevelTrace,
+	}
+	for name, want := range cases {
+		got, err := ParseVerbosity(name)
+		if err != nil || got != want {
+			t.Errorf("ParseVerbosity(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseVerbosity("loud"); err == nil {
+		t.Error("Unknown verbosity should be rejected")
+	}
+}
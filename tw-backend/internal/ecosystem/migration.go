@@ -0,0 +1,129 @@
+package ecosystem
+
+import (
+	"math"
+	"slices"
+
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/geography"
+)
+
+// DefaultMigrationMaxClimb is the steepest step (meters) a migrating herd takes
+const DefaultMigrationMaxClimb = 500.0
+
+// SpeciesPreference describes the terrain a land species migrates through.
+// Every step costs at least 1; preferences add to that.
+type SpeciesPreference struct {
+	PreferredBiomes   []geography.BiomeType // Crossed at no extra cost
+	OtherBiomePenalty float64               // Extra cost per step through any other biome
+	DesertPenalty     float64               // Further extra cost per desert step
+	RiverAffinity     float64               // 0-1; off-river steps cost 2*RiverAffinity extra
+	MaxClimb          float64               // meters per step; 0 uses DefaultMigrationMaxClimb
+	ClimbPenalty      float64               // Extra cost per meter climbed or descended
+}
+
+// PreferenceFromTraits derives a herbivore herd's migration preference from
+// its traits. Heat resistance lowers the desert penalty.
+func PreferenceFromTraits(traits population.EvolvableTraits, preferred ...geography.BiomeType) SpeciesPreference {
+	return SpeciesPreference{
+		PreferredBiomes:   preferred,
+		OtherBiomePenalty: 0.5,
+		DesertPenalty:     10 * (1 - math.Min(math.Max(traits.HeatResistance, 0), 1)),
+		RiverAffinity:     1,
+		MaxClimb:          DefaultMigrationMaxClimb,
+		ClimbPenalty:      0.005,
+	}
+}
+
+// PlanMigration plans a land species' migration from start to end across
+// geology's surface. Routes follow rivers and preferred biomes; oceans, water
+// and slopes steeper than the preference's MaxClimb are impassable. Returns
+// nil if end can't be reached.
+func PlanMigration(start, end spatial.Coordinate, species SpeciesPreference, geology *WorldGeology) []spatial.Coordinate {
+	geology.mu.RLock()
+	defer geology.mu.RUnlock()
+	if geology.Topology == nil || geology.SphereHeightmap == nil {
+		return nil
+	}
+
+	maxClimb := species.MaxClimb
+	if maxClimb <= 0 {
+		maxClimb = DefaultMigrationMaxClimb
+	}
+	terrain := TerrainCost(geology.SphereHeightmap, TerrainCostOptions{
+		SeaLevel:     geology.SeaLevel,
+		MaxClimb:     maxClimb,
+		ClimbPenalty: species.ClimbPenalty,
+	})
+	rivers := geology.riverCells()
+
+	cost := func(from, to spatial.Coordinate) float64 {
+		step := terrain(from, to)
+		if math.IsInf(step, 1) {
+			return step
+		}
+		if !rivers[to] {
+			step += 2 * species.RiverAffinity
+		}
+		if biome, ok := geology.biomeAt(to); ok {
+			switch {
+			case biome == geography.BiomeOcean:
+				return math.Inf(1)
+			case slices.Contains(species.PreferredBiomes, biome):
+				// No extra cost
+			default:
+				step += species.OtherBiomePenalty
+				if biome == geography.BiomeDesert {
+					step += species.DesertPenalty
+				}
+			}
+		}
+		return step
+	}
+	return FindPath(geology.Topology, start, end, WithCost(cost))
+}
+
+// riverCells returns the surface cells rivers run through. Rivers are stored
+// flattened as (face*resolution + x, y); see geography.ConvertSphericalRiversToFlat.
+func (g *WorldGeology) riverCells() map[spatial.Coordinate]bool {
+	res := g.Topology.Resolution()
+	cells := make(map[spatial.Coordinate]bool)
+	for _, river := range g.Rivers {
+		for _, p := range river {
+			flatX := int(p.X)
+			cells[spatial.Coordinate{Face: flatX / res, X: flatX % res, Y: int(p.Y)}] = true
+		}
+	}
+	return cells
+}
+
+// biomeAt returns the biome covering coord, looked up in the equirectangular
+// grid Biomes is laid out on (the flat Heightmap's dimensions)
+func (g *WorldGeology) biomeAt(coord spatial.Coordinate) (geography.BiomeType, bool) {
+	idx, ok := g.biomeIndex(coord)
+	if !ok {
+		return "", false
+	}
+	return g.Biomes[idx].Type, true
+}
+
+// biomeIndex maps coord to its index in Biomes, inverting the projection in
+// SphereHeightmap.ToFlatHeightmap
+func (g *WorldGeology) biomeIndex(coord spatial.Coordinate) (int, bool) {
+	if g.Heightmap == nil || len(g.Biomes) != g.Heightmap.Width*g.Heightmap.Height {
+		return 0, false
+	}
+	width, height := g.Heightmap.Width, g.Heightmap.Height
+
+	x, y, z := g.Topology.ToSphere(coord)
+	lat := math.Asin(math.Max(-1, math.Min(1, y)))
+	lon := math.Atan2(z, x)
+	if lon < 0 {
+		lon += 2 * math.Pi
+	}
+
+	px := min(int(lon/(2*math.Pi)*float64(width)), width-1)
+	py := min(max(int((0.5-lat/math.Pi)*float64(height)), 0), height-1)
+	return py*width + px, true
+}
@@ -0,0 +1,146 @@
+package ecosystem
+
+import (
+	"testing"
+
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grasslandGeology is a flat grassland world 10m above sea level
+func grasslandGeology() *WorldGeology {
+	topology, heightmap := flatWorld()
+	g := NewWorldGeology(uuid.New(), 1, DefaultCircumference)
+	g.Topology = topology
+	g.SphereHeightmap = heightmap
+	g.Heightmap = geography.NewHeightmap(64, 32)
+	g.Biomes = make([]geography.Biome, 64*32)
+	for i := range g.Biomes {
+		g.Biomes[i].Type = geography.BiomeGrassland
+	}
+	return g
+}
+
+// addRiver adds a river through cells, flattened as the geology stores rivers
+func addRiver(g *WorldGeology, cells []spatial.Coordinate) {
+	river := make([]geography.Point, len(cells))
+	for i, c := range cells {
+		river[i] = geography.Point{X: float64(c.Face*pathTestResolution + c.X), Y: float64(c.Y)}
+	}
+	g.Rivers = append(g.Rivers, river)
+}
+
+// onFace0 lists the face 0 cells from (x0, y0) to (x1, y1) along one axis
+func onFace0(x0, y0, x1, y1 int) []spatial.Coordinate {
+	var cells []spatial.Coordinate
+	for x, y := x0, y0; ; {
+		cells = append(cells, spatial.Coordinate{Face: 0, X: x, Y: y})
+		if x == x1 && y == y1 {
+			return cells
+		}
+		switch {
+		case x < x1:
+			x++
+		case x > x1:
+			x--
+		case y < y1:
+			y++
+		default:
+			y--
+		}
+	}
+}
+
+func TestPlanMigration_HugsRiver(t *testing.T) {
+	g := grasslandGeology()
+
+	// The river loops north of the straight route between start and end
+	var river []spatial.Coordinate
+	river = append(river, onFace0(2, 8, 2, 4)...)
+	river = append(river, onFace0(3, 4, 13, 4)...)
+	river = append(river, onFace0(13, 5, 13, 8)...)
+	addRiver(g, river)
+
+	start := spatial.Coordinate{Face: 0, X: 2, Y: 8}
+	end := spatial.Coordinate{Face: 0, X: 13, Y: 8}
+
+	straight := FindPath(g.Topology, start, end)
+	require.Len(t, straight, 12, "the direct route is straight along Y=8")
+
+	herd := PreferenceFromTraits(population.EvolvableTraits{HeatResistance: 0.5}, geography.BiomeGrassland)
+	path := PlanMigration(start, end, herd, g)
+	requireConnected(t, g.Topology, path, start, end)
+	assert.Equal(t, river, path, "the herd should follow the river the whole way")
+	assert.Greater(t, len(path), len(straight))
+}
+
+func TestPlanMigration_HeatResistanceCrossesDesert(t *testing.T) {
+	g := grasslandGeology()
+
+	// A desert band across the straight route with a way around either end
+	desert := make(map[spatial.Coordinate]bool)
+	for x := 6; x <= 9; x++ {
+		for y := 6; y <= 13; y++ {
+			c := spatial.Coordinate{Face: 0, X: x, Y: y}
+			idx, ok := g.biomeIndex(c)
+			require.True(t, ok)
+			g.Biomes[idx].Type = geography.BiomeDesert
+		}
+	}
+	for x := 0; x < pathTestResolution; x++ {
+		for y := 0; y < pathTestResolution; y++ {
+			c := spatial.Coordinate{Face: 0, X: x, Y: y}
+			if biome, _ := g.biomeAt(c); biome == geography.BiomeDesert {
+				desert[c] = true
+			}
+		}
+	}
+	start := spatial.Coordinate{Face: 0, X: 2, Y: 10}
+	end := spatial.Coordinate{Face: 0, X: 13, Y: 10}
+	require.True(t, desert[spatial.Coordinate{Face: 0, X: 8, Y: 10}])
+
+	desertSteps := func(path []spatial.Coordinate) int {
+		n := 0
+		for _, c := range path {
+			if desert[c] {
+				n++
+			}
+		}
+		return n
+	}
+
+	tender := PreferenceFromTraits(population.EvolvableTraits{HeatResistance: 0}, geography.BiomeGrassland)
+	avoiding := PlanMigration(start, end, tender, g)
+	requireConnected(t, g.Topology, avoiding, start, end)
+	assert.Zero(t, desertSteps(avoiding), "a heat-intolerant herd goes around the desert")
+
+	hardy := PreferenceFromTraits(population.EvolvableTraits{HeatResistance: 1}, geography.BiomeGrassland)
+	crossing := PlanMigration(start, end, hardy, g)
+	requireConnected(t, g.Topology, crossing, start, end)
+	assert.Positive(t, desertSteps(crossing), "a heat-resistant herd crosses the desert")
+	assert.Less(t, len(crossing), len(avoiding))
+}
+
+func TestPlanMigration_AvoidsOcean(t *testing.T) {
+	g := grasslandGeology()
+
+	// An inland sea between start and end, with a land bridge at its south end
+	for x := 5; x <= 10; x++ {
+		for y := 0; y < 14; y++ {
+			g.SphereHeightmap.Set(spatial.Coordinate{Face: 0, X: x, Y: y}, -200)
+		}
+	}
+	start := spatial.Coordinate{Face: 0, X: 2, Y: 6}
+	end := spatial.Coordinate{Face: 0, X: 13, Y: 6}
+
+	path := PlanMigration(start, end, PreferenceFromTraits(population.EvolvableTraits{}, geography.BiomeGrassland), g)
+	requireConnected(t, g.Topology, path, start, end)
+	for _, c := range path {
+		assert.GreaterOrEqual(t, g.SphereHeightmap.Get(c), g.SeaLevel, "the herd swam through %v", c)
+	}
+}
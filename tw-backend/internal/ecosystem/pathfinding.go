@@ -3,29 +3,104 @@ package ecosystem
 import (
 	"container/heap"
 	"math"
+
+	"tw-backend/internal/spatial"
 )
 
-// Point represents a location in the world
-type Point struct {
-	X, Y float64
+// PathCostFunc returns the cost of stepping between adjacent cells. Passable
+// steps should cost at least 1 so the distance heuristic stays admissible;
+// +Inf marks a step as impassable.
+type PathCostFunc func(from, to spatial.Coordinate) float64
+
+// UniformCost makes every step cost 1
+func UniformCost(from, to spatial.Coordinate) float64 { return 1 }
+
+// PathOption configures FindPath
+type PathOption func(*pathOptions)
+
+type pathOptions struct {
+	cost PathCostFunc
+}
+
+// WithCost prices each step with cost (e.g. TerrainCost) instead of
+// UniformCost
+func WithCost(cost PathCostFunc) PathOption {
+	return func(o *pathOptions) { o.cost = cost }
+}
+
+// pathDirections are the steps FindPath considers from each cell
+var pathDirections = []spatial.Direction{spatial.North, spatial.East, spatial.South, spatial.West}
+
+// FindPath finds the cheapest path between two cells of topology using A*.
+// Every step costs the same unless WithCost says otherwise. Neighbors come
+// from topology.GetNeighbor, so paths continue across face seams. The path
+// includes start and end; nil means end is unreachable.
+func FindPath(topology spatial.Topology, start, end spatial.Coordinate, opts ...PathOption) []spatial.Coordinate {
+	options := pathOptions{cost: UniformCost}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	cost := options.cost
+
+	// The heuristic is the great-circle distance in steps. A step spans at
+	// most 2/resolution radians (at a face's center), so it never
+	// overestimates, even where steps cross a seam onto another face.
+	stepsPerRadian := float64(topology.Resolution()) / 2
+	heuristic := func(c spatial.Coordinate) float64 {
+		return topology.Distance(c, end) * stepsPerRadian
+	}
+
+	startNode := &Node{Coord: start, Cost: 0, Heuristic: heuristic(start)}
+	openSet := &PriorityQueue{startNode}
+	nodes := map[spatial.Coordinate]*Node{start: startNode}
+	closed := make(map[spatial.Coordinate]bool)
+
+	for openSet.Len() > 0 {
+		current, _ := heap.Pop(openSet).(*Node) // Type assertion guaranteed by heap implementation
+		if current.Coord == end {
+			return reconstructPath(current)
+		}
+		closed[current.Coord] = true
+
+		for _, dir := range pathDirections {
+			next := topology.GetNeighbor(current.Coord, dir)
+			if closed[next] {
+				continue
+			}
+			step := cost(current.Coord, next)
+			if math.IsInf(step, 1) {
+				continue
+			}
+
+			newCost := current.Cost + step
+			node, seen := nodes[next]
+			if !seen {
+				node = &Node{Coord: next, Cost: math.Inf(1), Heuristic: heuristic(next)}
+				nodes[next] = node
+			}
+			if newCost < node.Cost {
+				node.Cost = newCost
+				node.Parent = current
+				if seen {
+					heap.Fix(openSet, node.Index)
+				} else {
+					heap.Push(openSet, node)
+				}
+			}
+		}
+	}
+	return nil
 }
 
-// Node represents a point in the pathfinding grid
+// Node is a cell in FindPath's search
 type Node struct {
-	Point     Point
+	Coord     spatial.Coordinate
 	Cost      float64
 	Heuristic float64
 	Parent    *Node
 	Index     int // For heap interface
 }
 
-// Pathdinder interface allows different world representations to be navigated
-type WorldMap interface {
-	GetNeighbors(p Point) []Point
-	Cost(from, to Point) float64
-	IsBlocked(p Point) bool
-}
-
 // PriorityQueue implements heap.Interface for Nodes
 type PriorityQueue []*Node
 
@@ -57,66 +132,13 @@ func (pq *PriorityQueue) Pop() interface{} {
 	return item
 }
 
-// FindPath implements A* algorithm
-func FindPath(world WorldMap, start, end Point) []Point {
-	startNode := &Node{Point: start, Cost: 0, Heuristic: distance(start, end)}
-	openSet := &PriorityQueue{startNode}
-	heap.Init(openSet)
-
-	visited := make(map[Point]bool)
-	nodeCache := make(map[Point]*Node)
-	nodeCache[start] = startNode
-
-	for openSet.Len() > 0 {
-		current, _ := heap.Pop(openSet).(*Node) // Type assertion guaranteed by heap implementation
-
-		if distance(current.Point, end) < 1.0 { // Reached destination (within tolerance)
-			return reconstructPath(current)
-		}
-
-		visited[current.Point] = true
-
-		for _, neighborPos := range world.GetNeighbors(current.Point) {
-			if visited[neighborPos] || world.IsBlocked(neighborPos) {
-				continue
-			}
-
-			newCost := current.Cost + world.Cost(current.Point, neighborPos)
-
-			neighborNode, seen := nodeCache[neighborPos]
-			if !seen {
-				neighborNode = &Node{
-					Point:     neighborPos,
-					Cost:      math.Inf(1),
-					Heuristic: distance(neighborPos, end),
-				}
-				nodeCache[neighborPos] = neighborNode
-			}
-
-			if newCost < neighborNode.Cost {
-				neighborNode.Cost = newCost
-				neighborNode.Parent = current
-				if !seen {
-					heap.Push(openSet, neighborNode)
-				} else {
-					heap.Fix(openSet, neighborNode.Index)
-				}
-			}
-		}
+func reconstructPath(node *Node) []spatial.Coordinate {
+	var path []spatial.Coordinate
+	for n := node; n != nil; n = n.Parent {
+		path = append(path, n.Coord)
 	}
-
-	return nil // No path found
-}
-
-func distance(a, b Point) float64 {
-	return math.Sqrt(math.Pow(a.X-b.X, 2) + math.Pow(a.Y-b.Y, 2))
-}
-
-func reconstructPath(node *Node) []Point {
-	var path []Point
-	for node != nil {
-		path = append([]Point{node.Point}, path...)
-		node = node.Parent
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
 	}
 	return path
 }
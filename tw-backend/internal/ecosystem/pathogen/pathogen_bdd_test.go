@@ -1,6 +1,7 @@
 package pathogen_test
 
 import (
+	"fmt"
 	"math/rand"
 	"testing"
 
@@ -212,6 +213,59 @@ func TestBDD_DiseaseSystem_Determinism(t *testing.T) {
 	assert.Len(t, ds1.Pathogens, len(ds2.Pathogens), "Pathogen count should match")
 }
 
+// -----------------------------------------------------------------------------
+// Scenario: Reproducible Outbreak Sequence
+// -----------------------------------------------------------------------------
+// Given: Two disease systems with the same seed and the same species
+// When: Spontaneous outbreaks are checked and outbreaks updated over many years
+// Then: Both produce identical outbreaks (pathogen, species, year, R₀)
+func TestBDD_DiseaseSystem_ReproducibleOutbreaks(t *testing.T) {
+	worldID := uuid.New()
+
+	speciesData := make(map[uuid.UUID]pathogen.SpeciesInfo)
+	for i := 0; i < 20; i++ {
+		speciesData[uuid.New()] = pathogen.SpeciesInfo{
+			Name:              fmt.Sprintf("Species %02d", i),
+			Population:        int64(5000 + i*1000),
+			DiseaseResistance: 0.3,
+			DietType:          "herbivore",
+			Density:           0.8,
+		}
+	}
+
+	type outbreakRecord struct {
+		PathogenID   uuid.UUID
+		PathogenName string
+		SpeciesID    uuid.UUID
+		Year         int64
+		R0           float32
+	}
+	run := func() []outbreakRecord {
+		ds := pathogen.NewDiseaseSystem(worldID, testSeed)
+		ds.OutbreakBaseChance = 0.2 // Frequent outbreaks so the sequence is non-trivial
+		var records []outbreakRecord
+		for year := int64(10000); year <= 500000; year += 10000 {
+			for _, started := range ds.CheckSpontaneousOutbreaks(year, speciesData) {
+				records = append(records, outbreakRecord{
+					PathogenID:   started.Pathogen.ID,
+					PathogenName: started.Pathogen.Name,
+					SpeciesID:    started.SpeciesID,
+					Year:         started.Year,
+					R0:           started.R0,
+				})
+			}
+			ds.Update(year, speciesData)
+		}
+		return records
+	}
+
+	first := run()
+	second := run()
+
+	require.NotEmpty(t, first, "Expected outbreaks over 500k years")
+	assert.Equal(t, first, second, "Same seed should produce the same outbreak sequence")
+}
+
 // -----------------------------------------------------------------------------
 // Scenario: Disease System Update
 // -----------------------------------------------------------------------------
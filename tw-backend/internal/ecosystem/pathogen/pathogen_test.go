@@ -72,7 +72,7 @@ func TestPathogen_Mutate(t *testing.T) {
 
 		initialVir := p.Virulence
 		for i := 0; i < 10; i++ {
-			p.Mutate(rng)
+			p.Mutate(rng.Int63())
 		}
 
 		if p.MutationsCount == 0 {
@@ -86,7 +86,7 @@ func TestPathogen_Mutate(t *testing.T) {
 		p := NewPathogen("Test Prion", PathogenPrion, uuid.New(), 0, rng)
 
 		for i := 0; i < 100; i++ {
-			p.Mutate(rng)
+			p.Mutate(rng.Int63())
 		}
 
 		if p.MutationsCount != 0 {
@@ -217,3 +217,76 @@ func TestDiseaseSystem_Update(t *testing.T) {
 	t.Logf("After 20 years - Infected: %d, Deaths: %d, Active: %v",
 		outbreak.CurrentInfected, outbreak.TotalDeaths, outbreak.IsActive)
 }
+
+// runOutbreak starts an outbreak of p in speciesID and updates the system
+// for up to maxYears or until it ends, returning it
+func runOutbreak(ds *DiseaseSystem, p *Pathogen, speciesID uuid.UUID, speciesData map[uuid.UUID]SpeciesInfo, startYear, maxYears int64) *Outbreak {
+	outbreak := NewOutbreak(p.ID, speciesID, uuid.Nil, startYear, 100)
+	ds.Outbreaks[outbreak.ID] = outbreak
+	p.ActiveOutbreaks++
+
+	for year := startYear + 1; outbreak.IsActive && year <= startYear+maxYears; year++ {
+		ds.Update(year, speciesData)
+	}
+	return outbreak
+}
+
+func TestDiseaseSystem_HerdImmunity(t *testing.T) {
+	ds := NewDiseaseSystem(uuid.New(), 42)
+	speciesID := uuid.New()
+	speciesData := map[uuid.UUID]SpeciesInfo{
+		speciesID: {Population: 100000, DiseaseResistance: 0.2, DietType: "herbivore", Density: 0.5},
+	}
+	p := ds.CreateNovelPathogen(speciesID, "Test Species", PathogenVirus)
+	p.Virulence, p.Transmissibility, p.Latency = 0.5, 0.3, 0.2
+
+	// Immunity builds as the infected recover, until the outbreak burns out
+	first := runOutbreak(ds, p, speciesID, speciesData, 0, 1000)
+	if first.IsActive {
+		t.Fatal("Herd immunity should end the first outbreak")
+	}
+	immunity := ds.Immunity[speciesID]
+	if immunity <= 0 {
+		t.Fatal("Survivors should leave the species with herd immunity")
+	}
+	if got := ds.EffectiveResistance(speciesID, 0.2); got <= 0.2 {
+		t.Errorf("Effective resistance = %f, want above the base 0.2", got)
+	}
+
+	// The same pathogen returns to a population that remembers it
+	second := runOutbreak(ds, p, speciesID, speciesData, ds.CurrentYear, ds.CurrentYear-first.StartYear)
+	if second.TotalDeaths >= first.TotalDeaths/2 {
+		t.Errorf("Second wave killed %d, want far fewer than the first's %d", second.TotalDeaths, first.TotalDeaths)
+	}
+	t.Logf("First wave killed %d, second %d (immunity %.2f)", first.TotalDeaths, second.TotalDeaths, immunity)
+
+	// Immunity fades over generations
+	ds.EradicatePathogen(p.ID)
+	before := ds.Immunity[speciesID]
+	ds.Update(ds.CurrentYear+HerdImmunityHalfLife, speciesData)
+	if got := ds.Immunity[speciesID]; got > before/2+1e-6 || got <= 0 {
+		t.Errorf("Immunity after a half-life = %f, want half of %f", got, before)
+	}
+}
+
+func TestPathogen_MutateFavorsSpread(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	p := NewPathogen("Test Virus", PathogenVirus, uuid.New(), 0, rng)
+	p.MutationRate = 1.0
+	p.Transmissibility = 0.5
+
+	for i := 0; i < 200; i++ {
+		p.Mutate(rng.Int63())
+	}
+	if p.Transmissibility <= 0.5 {
+		t.Errorf("Transmissibility = %f, want it to climb from 0.5", p.Transmissibility)
+	}
+
+	// The same seed mutates the same way
+	a, b := p.Clone(rng), p.Clone(rng)
+	a.Mutate(99)
+	b.Mutate(99)
+	if a.Virulence != b.Virulence || a.Transmissibility != b.Transmissibility {
+		t.Error("Mutate should be deterministic for a seed")
+	}
+}
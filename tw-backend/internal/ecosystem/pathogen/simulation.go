@@ -2,11 +2,23 @@
 package pathogen
 
 import (
+	"math"
 	"math/rand"
+	"sort"
 
 	"github.com/google/uuid"
 )
 
+// diseaseStreamSalt separates the disease RNG stream from other systems seeded
+// with the same world seed (population, geology)
+const diseaseStreamSalt int64 = 0x5eed_d15e_a5e0
+
+// newSeededID draws a UUID from rng so IDs (and anything ordered by them) are
+// reproducible for a given seed
+func newSeededID(rng *rand.Rand) uuid.UUID {
+	return uuid.Must(uuid.NewRandomFromReader(rng))
+}
+
 // DiseaseSystem manages all pathogens and outbreaks in a world
 type DiseaseSystem struct {
 	WorldID       uuid.UUID               `json:"world_id"`
@@ -16,20 +28,38 @@ type DiseaseSystem struct {
 	CurrentYear   int64                   `json:"current_year"`
 	rng           *rand.Rand
 
+	// Herd immunity: resistance each species has built up by surviving
+	// outbreaks, on top of its own DiseaseResistance (see EffectiveResistance)
+	Immunity     map[uuid.UUID]float32 `json:"immunity"`
+	ImmunityYear int64                 `json:"immunity_year"` // Year Immunity last decayed to
+
 	// Configuration
 	OutbreakBaseChance float64 `json:"outbreak_base_chance"` // Per species per year
 	ZoonoticChance     float64 `json:"zoonotic_chance"`      // Cross-species jump chance
 	MaxActiveOutbreaks int     `json:"max_active_outbreaks"` // Limit concurrent outbreaks
 }
 
-// NewDiseaseSystem creates a new disease management system
+// Herd immunity tuning
+const (
+	// MaxHerdImmunity caps the resistance survivors add to a species
+	MaxHerdImmunity = 0.5
+
+	// HerdImmunityHalfLife is how long (years) herd immunity takes to halve
+	// as the survivors' descendants lose it, over many generations
+	HerdImmunityHalfLife = 20000
+)
+
+// NewDiseaseSystem creates a new disease management system.
+// The system draws from its own RNG stream derived from the world seed, so the
+// same seed produces the same outbreaks regardless of other systems' RNG use.
 func NewDiseaseSystem(worldID uuid.UUID, seed int64) *DiseaseSystem {
 	return &DiseaseSystem{
 		WorldID:            worldID,
 		Pathogens:          make(map[uuid.UUID]*Pathogen),
 		Outbreaks:          make(map[uuid.UUID]*Outbreak),
 		PastOutbreaks:      make([]*Outbreak, 0),
-		rng:                rand.New(rand.NewSource(seed)),
+		Immunity:           make(map[uuid.UUID]float32),
+		rng:                rand.New(rand.NewSource(seed ^ diseaseStreamSalt)),
 		OutbreakBaseChance: 0.01,  // 1% chance per species per year (per 10k year check)
 		ZoonoticChance:     0.001, // 0.1% chance for cross-species transmission
 		MaxActiveOutbreaks: 10,
@@ -118,6 +148,7 @@ func (ds *DiseaseSystem) CheckSpontaneousOutbreak(
 	// Start outbreak
 	initialInfected := int64(1 + ds.rng.Intn(10))
 	outbreak := NewOutbreak(pathogen.ID, speciesID, uuid.Nil, ds.CurrentYear, initialInfected)
+	outbreak.ID = newSeededID(ds.rng)
 	ds.Outbreaks[outbreak.ID] = outbreak
 
 	pathogen.ActiveOutbreaks++
@@ -153,7 +184,7 @@ func (ds *DiseaseSystem) CheckZoonoticTransfer(
 
 	// Zoonotic jump occurs - pathogen may mutate
 	if ds.rng.Float64() < float64(pathogen.MutationRate) {
-		pathogen.Mutate(ds.rng)
+		pathogen.Mutate(ds.rng.Int63())
 	}
 
 	// Add the new diet to susceptible diets
@@ -162,6 +193,7 @@ func (ds *DiseaseSystem) CheckZoonoticTransfer(
 	// Start outbreak in new species
 	initialInfected := int64(1)
 	outbreak := NewOutbreak(pathogen.ID, targetSpeciesID, uuid.Nil, ds.CurrentYear, initialInfected)
+	outbreak.ID = newSeededID(ds.rng)
 	ds.Outbreaks[outbreak.ID] = outbreak
 
 	pathogen.ActiveOutbreaks++
@@ -169,15 +201,23 @@ func (ds *DiseaseSystem) CheckZoonoticTransfer(
 	return outbreak
 }
 
-// Update advances all outbreaks and pathogens by one year
+// Update advances all outbreaks and pathogens by one year.
+//
+// A population gains herd immunity as its infected recover, in proportion
+// to their share of it, which slows and finally ends the outbreak. The
+// pathogen that burned out mutates, usually toward spreading faster.
+// Immunity fades over HerdImmunityHalfLife, so the same pathogen returns in
+// weaker waves.
 func (ds *DiseaseSystem) Update(
 	year int64,
 	speciesData map[uuid.UUID]SpeciesInfo, // Species ID -> population info
 ) {
 	ds.CurrentYear = year
+	ds.decayImmunity(year)
 
-	// Update each active outbreak
-	for id, outbreak := range ds.Outbreaks {
+	// Update each active outbreak (in ID order so RNG draws are reproducible)
+	for _, id := range sortedIDs(ds.Outbreaks) {
+		outbreak := ds.Outbreaks[id]
 		if !outbreak.IsActive {
 			continue
 		}
@@ -196,8 +236,10 @@ func (ds *DiseaseSystem) Update(
 			continue
 		}
 
-		// Update the outbreak
-		outbreak.Update(pathogen, species.Population, species.DiseaseResistance, ds.rng)
+		// Update the outbreak; those who recover are immune
+		recovered := outbreak.RecoveredCount
+		outbreak.Update(pathogen, species.Population, ds.EffectiveResistance(outbreak.SpeciesID, species.DiseaseResistance), ds.rng)
+		ds.addImmunity(outbreak.SpeciesID, outbreak.RecoveredCount-recovered, species.Population)
 
 		// Check if outbreak ended
 		if !outbreak.IsActive {
@@ -210,6 +252,9 @@ func (ds *DiseaseSystem) Update(
 			pathogen.TotalInfected += outbreak.TotalInfected
 			pathogen.TotalDeaths += outbreak.TotalDeaths
 
+			// The pathogen evolves around the immunity it left behind
+			pathogen.Mutate(ds.rng.Int63())
+
 			// Check for endemic evolution
 			if pathogen.IsBecomingEndemic() && !pathogen.IsEndemic {
 				pathogen.IsEndemic = true
@@ -218,9 +263,45 @@ func (ds *DiseaseSystem) Update(
 	}
 
 	// Mutate pathogens periodically
-	for _, pathogen := range ds.Pathogens {
+	for _, id := range sortedIDs(ds.Pathogens) {
+		pathogen := ds.Pathogens[id]
 		if !pathogen.IsEradicated && ds.rng.Float64() < float64(pathogen.MutationRate)*0.1 {
-			pathogen.Mutate(ds.rng)
+			pathogen.Mutate(ds.rng.Int63())
+		}
+	}
+}
+
+// EffectiveResistance returns a species' disease resistance with its herd
+// immunity added, capped at 0.95
+func (ds *DiseaseSystem) EffectiveResistance(speciesID uuid.UUID, baseResistance float32) float32 {
+	return clamp32(baseResistance+ds.Immunity[speciesID], 0, 0.95)
+}
+
+// addImmunity grants a species herd immunity from survivors of an
+// outbreak, by their share of the population
+func (ds *DiseaseSystem) addImmunity(speciesID uuid.UUID, survivors, population int64) {
+	if survivors <= 0 || population <= 0 {
+		return
+	}
+	if ds.Immunity == nil {
+		ds.Immunity = make(map[uuid.UUID]float32)
+	}
+	gain := MaxHerdImmunity * clamp32(float32(survivors)/float32(population), 0, 1)
+	ds.Immunity[speciesID] = clamp32(ds.Immunity[speciesID]+gain, 0, MaxHerdImmunity)
+}
+
+// decayImmunity fades herd immunity for the years since it last decayed
+func (ds *DiseaseSystem) decayImmunity(year int64) {
+	elapsed := year - ds.ImmunityYear
+	ds.ImmunityYear = year
+	if elapsed <= 0 {
+		return
+	}
+	factor := float32(math.Pow(0.5, float64(elapsed)/HerdImmunityHalfLife))
+	for _, id := range sortedIDs(ds.Immunity) {
+		ds.Immunity[id] *= factor
+		if ds.Immunity[id] < 0.001 {
+			delete(ds.Immunity, id)
 		}
 	}
 }
@@ -282,10 +363,81 @@ func (ds *DiseaseSystem) EradicatePathogen(pathogenID uuid.UUID) {
 
 // SpeciesInfo provides population data for the simulation
 type SpeciesInfo struct {
+	ID                uuid.UUID // Set by the caller; the speciesData maps key it too
+	Name              string
 	Population        int64
 	DiseaseResistance float32
 	DietType          string
 	Density           float64 // 0-1
+
+	// Contact and kinship, for zoonotic jumps (see CheckZoonoticJump)
+	BiomeIDs []uuid.UUID // Biomes the species lives in
+	Prey     []uuid.UUID // Species it hunts
+	Genes    []float32   // Defined genes (0-1); nil if unknown
+}
+
+// SpontaneousOutbreak describes an outbreak started by CheckSpontaneousOutbreaks
+type SpontaneousOutbreak struct {
+	Pathogen  *Pathogen
+	Outbreak  *Outbreak
+	SpeciesID uuid.UUID
+	Year      int64
+	R0        float32
+}
+
+// CheckSpontaneousOutbreaks runs CheckSpontaneousOutbreak for every living
+// species in a stable order (name, then ID) so a seed always consumes the RNG
+// the same way, whatever order the caller's maps iterate in
+func (ds *DiseaseSystem) CheckSpontaneousOutbreaks(year int64, speciesData map[uuid.UUID]SpeciesInfo) []SpontaneousOutbreak {
+	ds.CurrentYear = year
+
+	var started []SpontaneousOutbreak
+	for _, id := range livingSpecies(speciesData) {
+		info := speciesData[id]
+		p, outbreak := ds.CheckSpontaneousOutbreak(id, info.Name, info.Population, info.Density)
+		if outbreak == nil {
+			continue
+		}
+		started = append(started, SpontaneousOutbreak{
+			Pathogen:  p,
+			Outbreak:  outbreak,
+			SpeciesID: id,
+			Year:      year,
+			R0:        p.CalculateR0(float32(info.Density), ds.EffectiveResistance(id, info.DiseaseResistance)),
+		})
+	}
+	return started
+}
+
+// livingSpecies returns the IDs of species with a population, in a stable
+// order (name, then ID)
+func livingSpecies(speciesData map[uuid.UUID]SpeciesInfo) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(speciesData))
+	for id, info := range speciesData {
+		if info.Population > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := speciesData[ids[i]], speciesData[ids[j]]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
+// sortedIDs returns a map's keys in a stable order
+func sortedIDs[T any](m map[uuid.UUID]T) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
 }
 
 // generatePathogenName creates a name for a new pathogen
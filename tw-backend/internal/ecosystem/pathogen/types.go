@@ -67,7 +67,7 @@ type Pathogen struct {
 // NewPathogen creates a new pathogen with random properties based on type
 func NewPathogen(name string, pType PathogenType, originSpeciesID uuid.UUID, originYear int64, rng *rand.Rand) *Pathogen {
 	p := &Pathogen{
-		ID:               uuid.New(),
+		ID:               newSeededID(rng),
 		Name:             name,
 		Type:             pType,
 		OriginSpeciesID:  originSpeciesID,
@@ -133,7 +133,7 @@ func NewPathogen(name string, pType PathogenType, originSpeciesID uuid.UUID, ori
 // Clone creates a mutated descendant of this pathogen
 func (p *Pathogen) Clone(rng *rand.Rand) *Pathogen {
 	clone := &Pathogen{
-		ID:               uuid.New(),
+		ID:               newSeededID(rng),
 		Name:             p.Name,
 		Type:             p.Type,
 		Transmission:     p.Transmission,
@@ -154,12 +154,18 @@ func (p *Pathogen) Clone(rng *rand.Rand) *Pathogen {
 	return clone
 }
 
-// Mutate applies random mutations to the pathogen
-func (p *Pathogen) Mutate(rng *rand.Rand) {
+// Mutate applies random mutations to the pathogen, drawn from seed.
+//
+// Selection favors strains that spread: transmissibility drifts upward,
+// raising R0, while virulence mostly falls (hosts that live longer pass it
+// on for longer). Against a population with herd immunity this gives
+// recurring, milder waves instead of one pandemic repeated.
+func (p *Pathogen) Mutate(seed int64) {
 	if p.Type == PathogenPrion {
 		return // Prions don't mutate
 	}
 
+	rng := rand.New(rand.NewSource(seed))
 	mutationChance := float64(p.MutationRate)
 
 	// Virulence-transmissibility tradeoff
@@ -174,9 +180,10 @@ func (p *Pathogen) Mutate(rng *rand.Rand) {
 		p.MutationsCount++
 	}
 
-	// Transmissibility can increase or decrease
+	// Transmissibility can increase or decrease, but strains that spread
+	// better outcompete the rest
 	if rng.Float64() < mutationChance {
-		delta := (rng.Float32() - 0.5) * 0.05
+		delta := (rng.Float32() - 0.4) * 0.05
 		p.Transmissibility = clamp32(p.Transmissibility+delta, 0.01, 1)
 		p.MutationsCount++
 	}
@@ -343,6 +350,9 @@ func (o *Outbreak) Update(
 	if recoveries > o.CurrentInfected-deaths {
 		recoveries = o.CurrentInfected - deaths
 	}
+	if newInfections == 0 && deaths == 0 && recoveries == 0 {
+		recoveries = 1 // Too few cases left for the rates to round to anything: it burns out
+	}
 
 	// Update counts
 	o.CurrentInfected = o.CurrentInfected + newInfections - deaths - recoveries
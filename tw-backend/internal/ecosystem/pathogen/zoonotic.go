@@ -0,0 +1,207 @@
+package pathogen
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Contact between two species, scaling the chance a pathogen jumps
+const (
+	contactEatsInfected = 1.0 // Target hunts the infected source: the main route
+	contactBitten       = 0.3 // Target is prey, wounded by an infected hunter
+	contactSharedBiome  = 0.2 // Same range, no predation
+)
+
+// zoonoticVirulenceShift is the most a new host can move a strain's
+// virulence, either way: without a shared history the pathogen may be far
+// deadlier or milder than in its old host
+const zoonoticVirulenceShift = 0.2
+
+// ZoonoticJump describes an outbreak started by CheckZoonoticJumps
+type ZoonoticJump struct {
+	Pathogen        *Pathogen // The strain now in the target
+	Outbreak        *Outbreak
+	SourceSpeciesID uuid.UUID
+	TargetSpeciesID uuid.UUID
+	Year            int64
+}
+
+// CheckZoonoticJump checks whether a pathogen infecting sourceSpecies jumps
+// to targetSpecies, and if so seeds an outbreak in the target.
+//
+// The chance scales ZoonoticChance by how much the species meet (a hunter
+// eating infected prey most of all, then prey bitten by an infected hunter,
+// then neighbors in a shared biome), how alike they are (genes and diet),
+// and the pathogen's host range. Species that never meet can't swap
+// pathogens. A jump founds a new strain (see Pathogen.Clone) whose
+// virulence may shift in the unfamiliar host.
+//
+// The source must have an active outbreak of the pathogen, and the target
+// mustn't already be fighting the same lineage.
+func (ds *DiseaseSystem) CheckZoonoticJump(sourceSpecies, targetSpecies SpeciesInfo, pathogen *Pathogen) bool {
+	_, outbreak := ds.zoonoticJump(sourceSpecies, targetSpecies, pathogen)
+	return outbreak != nil
+}
+
+// CheckZoonoticJumps runs CheckZoonoticJump for every active outbreak
+// against every other living species, in a stable order so a seed always
+// consumes the RNG the same way
+func (ds *DiseaseSystem) CheckZoonoticJumps(year int64, speciesData map[uuid.UUID]SpeciesInfo) []ZoonoticJump {
+	ds.CurrentYear = year
+	species := livingSpecies(speciesData)
+
+	var jumps []ZoonoticJump
+	for _, id := range sortedIDs(ds.Outbreaks) {
+		outbreak := ds.Outbreaks[id]
+		source, ok := speciesData[outbreak.SpeciesID]
+		if !outbreak.IsActive || !ok {
+			continue
+		}
+		source.ID = outbreak.SpeciesID
+
+		for _, targetID := range species {
+			target := speciesData[targetID]
+			target.ID = targetID
+			strain, started := ds.zoonoticJump(source, target, ds.Pathogens[outbreak.PathogenID])
+			if started == nil {
+				continue
+			}
+			jumps = append(jumps, ZoonoticJump{
+				Pathogen:        strain,
+				Outbreak:        started,
+				SourceSpeciesID: source.ID,
+				TargetSpeciesID: targetID,
+				Year:            year,
+			})
+		}
+	}
+	return jumps
+}
+
+// zoonoticJump is CheckZoonoticJump, returning the new strain and outbreak
+// (nil if the pathogen didn't jump)
+func (ds *DiseaseSystem) zoonoticJump(source, target SpeciesInfo, pathogen *Pathogen) (*Pathogen, *Outbreak) {
+	if pathogen == nil || pathogen.IsEradicated || source.ID == target.ID || target.Population <= 0 {
+		return nil, nil
+	}
+	if len(ds.GetActiveOutbreaks()) >= ds.MaxActiveOutbreaks {
+		return nil, nil
+	}
+	if !ds.isInfected(source.ID, pathogen) || ds.hasLineage(target.ID, pathogen) {
+		return nil, nil
+	}
+
+	contact := speciesContact(source, target)
+	if contact == 0 {
+		return nil, nil
+	}
+	chance := ds.ZoonoticChance * contact * hostSimilarity(source, target) * float64(1-pathogen.HostSpecificity)
+	if ds.rng.Float64() >= chance {
+		return nil, nil
+	}
+	if !pathogen.CanInfectHost(target.ID, target.DietType, ds.EffectiveResistance(target.ID, target.DiseaseResistance)) {
+		return nil, nil
+	}
+
+	// The jump founds a new strain, adapted (or not) to its new host
+	strain := pathogen.Clone(ds.rng)
+	shift := (ds.rng.Float32()*2 - 1) * zoonoticVirulenceShift
+	strain.Virulence = clamp32(strain.Virulence+shift, 0.01, 1)
+	if len(strain.SusceptibleDiets) > 0 && !containsDiet(strain.SusceptibleDiets, target.DietType) {
+		strain.SusceptibleDiets = append(strain.SusceptibleDiets, target.DietType)
+	}
+	ds.AddPathogen(strain)
+
+	outbreak := NewOutbreak(strain.ID, target.ID, uuid.Nil, ds.CurrentYear, 1)
+	outbreak.ID = newSeededID(ds.rng)
+	ds.Outbreaks[outbreak.ID] = outbreak
+	strain.ActiveOutbreaks++
+
+	return strain, outbreak
+}
+
+// isInfected reports whether a species has an active outbreak of pathogen
+func (ds *DiseaseSystem) isInfected(speciesID uuid.UUID, pathogen *Pathogen) bool {
+	for _, o := range ds.Outbreaks {
+		if o.IsActive && o.SpeciesID == speciesID && o.PathogenID == pathogen.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLineage reports whether a species has an active outbreak of pathogen
+// or another strain of it (strains share a name and origin)
+func (ds *DiseaseSystem) hasLineage(speciesID uuid.UUID, pathogen *Pathogen) bool {
+	for _, o := range ds.Outbreaks {
+		if !o.IsActive || o.SpeciesID != speciesID {
+			continue
+		}
+		if p := ds.Pathogens[o.PathogenID]; p != nil && p.Name == pathogen.Name && p.OriginSpeciesID == pathogen.OriginSpeciesID {
+			return true
+		}
+	}
+	return false
+}
+
+// speciesContact returns how much target is exposed to source (0-1)
+func speciesContact(source, target SpeciesInfo) float64 {
+	switch {
+	case containsID(target.Prey, source.ID):
+		return contactEatsInfected
+	case containsID(source.Prey, target.ID):
+		return contactBitten
+	}
+	for _, biome := range source.BiomeIDs {
+		if containsID(target.BiomeIDs, biome) {
+			return contactSharedBiome
+		}
+	}
+	return 0
+}
+
+// hostSimilarity returns how alike two hosts are (0-1), averaging their
+// genes and diets. Species with unknown genes count as half alike.
+func hostSimilarity(a, b SpeciesInfo) float64 {
+	genetic := 0.5
+	if len(a.Genes) > 0 && len(a.Genes) == len(b.Genes) {
+		sumSq := 0.0
+		for i := range a.Genes {
+			d := float64(a.Genes[i] - b.Genes[i])
+			sumSq += d * d
+		}
+		genetic = 1 - math.Min(1, math.Sqrt(sumSq/float64(len(a.Genes)))*2)
+	}
+
+	var diet float64
+	switch {
+	case a.DietType == b.DietType:
+		diet = 1.0
+	case a.DietType == "photosynthetic" || b.DietType == "photosynthetic":
+		diet = 0.05 // Plant and animal pathogens rarely cross
+	case a.DietType == "omnivore" || b.DietType == "omnivore":
+		diet = 0.6
+	default:
+		diet = 0.3
+	}
+	return (genetic + diet) / 2
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsDiet(diets []string, diet string) bool {
+	for _, d := range diets {
+		if d == diet {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package pathogen
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// foodChain returns a disease system with an outbreak among grazing deer,
+// the wolves that hunt them and a fox across the mountains
+func foodChain(seed int64) (ds *DiseaseSystem, plague *Pathogen, deer, wolf, fox SpeciesInfo) {
+	ds = NewDiseaseSystem(uuid.New(), seed)
+	plains, mountains := uuid.New(), uuid.New()
+
+	deer = SpeciesInfo{ID: uuid.New(), Name: "Plains Deer", Population: 10000, DietType: "herbivore", BiomeIDs: []uuid.UUID{plains}}
+	wolf = SpeciesInfo{ID: uuid.New(), Name: "Plains Wolf", Population: 500, DietType: "carnivore", BiomeIDs: []uuid.UUID{plains}, Prey: []uuid.UUID{deer.ID}}
+	fox = SpeciesInfo{ID: uuid.New(), Name: "Mountain Fox", Population: 800, DietType: "carnivore", BiomeIDs: []uuid.UUID{mountains}}
+
+	plague = ds.CreateNovelPathogen(deer.ID, deer.Name, PathogenVirus)
+	plague.HostSpecificity = 0.2
+	outbreak := NewOutbreak(plague.ID, deer.ID, uuid.Nil, 0, 100)
+	ds.Outbreaks[outbreak.ID] = outbreak
+	plague.ActiveOutbreaks++
+	return ds, plague, deer, wolf, fox
+}
+
+func TestCheckZoonoticJump_PreyToPredator(t *testing.T) {
+	ds, plague, deer, wolf, _ := foodChain(1)
+	ds.ZoonoticChance = 100 // Every contact jumps
+
+	if !ds.CheckZoonoticJump(deer, wolf, plague) {
+		t.Fatal("Expected the plague to jump from deer to the wolves that eat them")
+	}
+
+	infected, _ := ds.GetImpact(wolf.ID)
+	if infected == 0 {
+		t.Error("Wolves should have an outbreak")
+	}
+
+	// The wolves carry a new strain of the same plague
+	var strain *Pathogen
+	for _, o := range ds.GetActiveOutbreaks() {
+		if o.SpeciesID == wolf.ID {
+			strain = ds.GetPathogen(o.PathogenID)
+		}
+	}
+	if strain == nil || strain.ID == plague.ID || strain.Name != plague.Name {
+		t.Fatalf("Expected a new strain of %s, got %+v", plague.Name, strain)
+	}
+	if diff := strain.Virulence - plague.Virulence; diff > zoonoticVirulenceShift+1e-6 || diff < -zoonoticVirulenceShift-1e-6 {
+		t.Errorf("Virulence shifted by %f, want at most %f", diff, zoonoticVirulenceShift)
+	}
+
+	// The wolves already have it
+	if ds.CheckZoonoticJump(deer, wolf, plague) {
+		t.Error("A species shouldn't catch the same lineage twice at once")
+	}
+}
+
+func TestCheckZoonoticJump_ContactDrivesJumps(t *testing.T) {
+	jumps := func(pick func(deer, wolf, fox SpeciesInfo) SpeciesInfo) int {
+		count := 0
+		for seed := int64(0); seed < 500; seed++ {
+			ds, plague, deer, wolf, fox := foodChain(seed)
+			ds.ZoonoticChance = 0.5
+			if ds.CheckZoonoticJump(deer, pick(deer, wolf, fox), plague) {
+				count++
+			}
+		}
+		return count
+	}
+
+	predator := jumps(func(_, wolf, _ SpeciesInfo) SpeciesInfo { return wolf })
+	stranger := jumps(func(_, _, fox SpeciesInfo) SpeciesInfo { return fox })
+	neighbor := jumps(func(deer, wolf, _ SpeciesInfo) SpeciesInfo {
+		wolf.Prey = nil // Same biome, but doesn't hunt the deer
+		return wolf
+	})
+
+	if stranger != 0 {
+		t.Errorf("Species that never meet shouldn't swap pathogens, got %d jumps", stranger)
+	}
+	if predator <= 2*neighbor || neighbor == 0 {
+		t.Errorf("Eating infected prey should be the main route: predator %d, neighbor %d", predator, neighbor)
+	}
+}
+
+func TestCheckZoonoticJump_SourceMustBeInfected(t *testing.T) {
+	ds, plague, deer, wolf, _ := foodChain(1)
+	ds.ZoonoticChance = 100 // Every contact jumps
+
+	// Only the deer are infected, so the wolves can't pass it back
+	if ds.CheckZoonoticJump(wolf, deer, plague) {
+		t.Error("An uninfected species can't spread a pathogen")
+	}
+}
+
+func TestHostSimilarity(t *testing.T) {
+	genes := func(v float32) []float32 { return []float32{v, v, v, v} }
+	kin := SpeciesInfo{DietType: "carnivore", Genes: genes(0.5)}
+	cousin := SpeciesInfo{DietType: "carnivore", Genes: genes(0.55)}
+	distant := SpeciesInfo{DietType: "carnivore", Genes: genes(0.95)}
+	grazer := SpeciesInfo{DietType: "herbivore", Genes: genes(0.55)}
+	plant := SpeciesInfo{DietType: "photosynthetic", Genes: genes(0.55)}
+
+	if hostSimilarity(kin, cousin) <= hostSimilarity(kin, distant) {
+		t.Error("Closer genes should make hosts more alike")
+	}
+	if hostSimilarity(kin, cousin) <= hostSimilarity(kin, grazer) {
+		t.Error("A shared diet should make hosts more alike")
+	}
+	if hostSimilarity(kin, grazer) <= hostSimilarity(kin, plant) {
+		t.Error("Animal pathogens should rarely reach plants")
+	}
+}
+
+func TestCheckZoonoticJumps(t *testing.T) {
+	ds, plague, deer, wolf, fox := foodChain(3)
+	ds.ZoonoticChance = 100 // Every contact jumps
+	speciesData := map[uuid.UUID]SpeciesInfo{deer.ID: deer, wolf.ID: wolf, fox.ID: fox}
+
+	jumps := ds.CheckZoonoticJumps(10, speciesData)
+	if len(jumps) != 1 {
+		t.Fatalf("Expected one jump (deer to wolves), got %d", len(jumps))
+	}
+	jump := jumps[0]
+	if jump.SourceSpeciesID != deer.ID || jump.TargetSpeciesID != wolf.ID || jump.Year != 10 {
+		t.Errorf("Unexpected jump %+v", jump)
+	}
+	if jump.Pathogen.Name != plague.Name || jump.Outbreak.PathogenID != jump.Pathogen.ID {
+		t.Errorf("Jump should carry a strain of %s", plague.Name)
+	}
+}
@@ -70,6 +70,43 @@ func (r *SimulationSnapshotRepository) LoadSnapshot(ctx context.Context, worldID
 	return sim, nil
 }
 
+// SaveGeology persists a geology checkpoint (see WorldGeology.Serialize),
+// replacing the world's previous one
+func (r *SimulationSnapshotRepository) SaveGeology(ctx context.Context, worldID uuid.UUID, year int64, data []byte) error {
+	query := `
+		INSERT INTO world_geology_checkpoint (world_id, year, data, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (world_id) DO UPDATE SET
+			year = EXCLUDED.year,
+			data = EXCLUDED.data,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.ExecContext(ctx, query, worldID, year, data, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to save geology checkpoint: %w", err)
+	}
+	return nil
+}
+
+// LoadGeology retrieves the world's latest geology checkpoint, or nil if
+// it has none
+func (r *SimulationSnapshotRepository) LoadGeology(ctx context.Context, worldID uuid.UUID) ([]byte, error) {
+	query := `
+		SELECT data
+		FROM world_geology_checkpoint
+		WHERE world_id = $1
+	`
+	var data []byte
+	err := r.db.QueryRowContext(ctx, query, worldID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load geology checkpoint: %w", err)
+	}
+	return data, nil
+}
+
 // DeleteSnapshot removes the snapshot
 func (r *SimulationSnapshotRepository) DeleteSnapshot(ctx context.Context, worldID uuid.UUID) error {
 	query := `DELETE FROM world_simulation_snapshot WHERE world_id = $1`
@@ -0,0 +1,240 @@
+package population
+
+import (
+	"strings"
+)
+
+// DescribeAppearance turns evolved traits into a short prose description,
+// e.g. "A large, heavily-furred quadruped with vivid display markings."
+// The same traits always produce the same text.
+func DescribeAppearance(traits EvolvableTraits, diet DietType) string {
+	var adjectives, features []string
+	var body string
+
+	if diet == DietPhotosynthetic {
+		adjectives = append(adjectives, floraSizeWord(traits.Size), floraGrowthWord(traits.FloraGrowth))
+		switch {
+		case traits.FloraGrowth == FloraAquatic && traits.Size > 1.0:
+			body = "frond"
+		case traits.Size > 3.0 && traits.Covering == CoveringBark:
+			body = "tree"
+		case traits.Size > 1.0:
+			body = "shrub"
+		default:
+			body = "plant"
+		}
+		if traits.Display > 0.5 {
+			features = append(features, "bright blossoms")
+		}
+		if traits.VenomPotency > 0.5 {
+			features = append(features, "a bitter, toxic sap")
+		}
+	} else {
+		adjectives = append(adjectives, faunaSizeWord(traits.Size), buildWord(traits), coveringWord(traits))
+		body = bodyPlan(traits.Covering)
+		features = faunaFeatures(traits, diet)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(withArticle(joinAdjectives(adjectives) + body))
+	if len(features) > 0 {
+		sb.WriteString(" with ")
+		sb.WriteString(joinList(features))
+	}
+	sb.WriteString(".")
+
+	if diet != DietPhotosynthetic {
+		for _, s := range behaviorSentences(traits) {
+			sb.WriteString(" ")
+			sb.WriteString(s)
+		}
+	}
+
+	desc := sb.String()
+	return strings.ToUpper(desc[:1]) + desc[1:]
+}
+
+func faunaSizeWord(size float64) string {
+	switch {
+	case size < 0.3:
+		return "tiny"
+	case size < 1.0:
+		return "small"
+	case size < 2.0:
+		return "medium-sized"
+	case size < 4.0:
+		return "large"
+	case size < 7.0:
+		return "massive"
+	default:
+		return "colossal"
+	}
+}
+
+func floraSizeWord(size float64) string {
+	switch {
+	case size < 0.2:
+		return "microscopic"
+	case size < 0.5:
+		return "tiny"
+	case size < 1.0:
+		return "low"
+	case size < 3.0:
+		return ""
+	case size < 5.0:
+		return "tall"
+	case size < 8.0:
+		return "towering"
+	default:
+		return "giant"
+	}
+}
+
+func floraGrowthWord(growth FloraGrowthType) string {
+	switch growth {
+	case FloraEvergreen:
+		return "evergreen"
+	case FloraDeciduous:
+		return "broad-leaved"
+	case FloraAnnual:
+		return "short-lived"
+	case FloraPerennial:
+		return "hardy"
+	case FloraSucculent:
+		return "succulent"
+	case FloraAquatic:
+		return "aquatic"
+	default:
+		return ""
+	}
+}
+
+// buildWord describes strength and speed relative to body size
+func buildWord(traits EvolvableTraits) string {
+	switch {
+	case traits.Strength > 7.0 || (traits.Strength > 4.0 && traits.Strength > traits.Size*1.5):
+		return "powerfully built"
+	case traits.Speed > 7.0:
+		return "lean"
+	case traits.Size > 4.0 && traits.Speed < 3.0:
+		return "lumbering"
+	default:
+		return ""
+	}
+}
+
+func coveringWord(traits EvolvableTraits) string {
+	switch traits.Covering {
+	case CoveringFur:
+		if traits.ColdResistance > 0.7 {
+			return "heavily-furred"
+		}
+		return "furred"
+	case CoveringScales:
+		if traits.HeatResistance > 0.7 {
+			return "thick-scaled"
+		}
+		return "scaled"
+	case CoveringFeathers:
+		return "feathered"
+	case CoveringShell:
+		return "hard-shelled"
+	case CoveringSkin:
+		return "smooth-skinned"
+	default:
+		return ""
+	}
+}
+
+func bodyPlan(covering CoveringType) string {
+	switch covering {
+	case CoveringFeathers:
+		return "winged biped"
+	case CoveringScales:
+		return "reptilian quadruped"
+	case CoveringShell:
+		return "crawler"
+	case CoveringFur:
+		return "quadruped"
+	case CoveringNone:
+		return "soft-bodied creature"
+	default:
+		return "creature"
+	}
+}
+
+func faunaFeatures(traits EvolvableTraits, diet DietType) []string {
+	var features []string
+	switch {
+	case traits.Display > 0.7:
+		features = append(features, "vivid display markings")
+	case traits.Display > 0.4:
+		features = append(features, "faint display markings")
+	}
+	if traits.Camouflage > 0.7 {
+		features = append(features, "mottled, camouflaging coloration")
+	}
+	if traits.NightVision > 0.7 {
+		features = append(features, "large, reflective eyes")
+	}
+	if traits.VenomPotency > 0.5 {
+		features = append(features, "venomous fangs")
+	} else if diet == DietCarnivore || traits.CarnivoreTendency > 0.7 {
+		if traits.Covering == CoveringFeathers {
+			features = append(features, "a hooked beak")
+		} else {
+			features = append(features, "sharp teeth")
+		}
+	}
+	return features
+}
+
+// behaviorSentences adds at most two notes on temperament
+func behaviorSentences(traits EvolvableTraits) []string {
+	var notes []string
+	if traits.Aggression > 0.7 {
+		notes = append(notes, "It bristles with aggression.")
+	}
+	if traits.Intelligence > 0.7 {
+		notes = append(notes, "Its eyes follow you with unsettling intelligence.")
+	}
+	if traits.Social > 0.7 {
+		notes = append(notes, "It seems to prefer the company of its own kind.")
+	}
+	if traits.Speed > 7.0 {
+		notes = append(notes, "It looks ready to bolt at any moment.")
+	}
+	if len(notes) > 2 {
+		notes = notes[:2]
+	}
+	return notes
+}
+
+// joinAdjectives renders "large, heavily-furred " from non-empty adjectives
+func joinAdjectives(adjectives []string) string {
+	var kept []string
+	for _, a := range adjectives {
+		if a != "" {
+			kept = append(kept, a)
+		}
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	return strings.Join(kept, ", ") + " "
+}
+
+// joinList renders "a, b and c"
+func joinList(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+func withArticle(phrase string) string {
+	if phrase != "" && strings.ContainsRune("aeiou", rune(phrase[0])) {
+		return "an " + phrase
+	}
+	return "a " + phrase
+}
@@ -0,0 +1,70 @@
+package population
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescribeAppearance_DistinctTraitsDistinctText(t *testing.T) {
+	furred := DefaultTraitsForDiet(DietHerbivore)
+	furred.Size, furred.ColdResistance, furred.Display = 3.0, 0.9, 0.9
+
+	scaled := DefaultTraitsForDiet(DietCarnivore)
+	scaled.Size, scaled.Covering, scaled.VenomPotency = 0.5, CoveringScales, 0.8
+
+	tree := DefaultTraitsForDiet(DietPhotosynthetic)
+	tree.Size, tree.Covering, tree.FloraGrowth = 6.0, CoveringBark, FloraEvergreen
+
+	descriptions := map[string]string{
+		"furred": DescribeAppearance(furred, DietHerbivore),
+		"scaled": DescribeAppearance(scaled, DietCarnivore),
+		"tree":   DescribeAppearance(tree, DietPhotosynthetic),
+	}
+
+	seen := make(map[string]string)
+	for name, desc := range descriptions {
+		if other, dup := seen[desc]; dup {
+			t.Errorf("%s and %s share description %q", name, other, desc)
+		}
+		seen[desc] = name
+	}
+
+	if !strings.HasPrefix(descriptions["furred"], "A large, heavily-furred quadruped with vivid display markings") {
+		t.Errorf("Unexpected furred description: %q", descriptions["furred"])
+	}
+	if !strings.Contains(descriptions["scaled"], "venomous fangs") {
+		t.Errorf("Venomous predator should mention its fangs: %q", descriptions["scaled"])
+	}
+	if !strings.Contains(descriptions["tree"], "towering, evergreen tree") {
+		t.Errorf("Unexpected tree description: %q", descriptions["tree"])
+	}
+}
+
+func TestDescribeAppearance_Stable(t *testing.T) {
+	traits := DefaultTraitsForDiet(DietOmnivore)
+	first := DescribeAppearance(traits, DietOmnivore)
+
+	for i := 0; i < 10; i++ {
+		if got := DescribeAppearance(traits, DietOmnivore); got != first {
+			t.Fatalf("Description changed between calls: %q vs %q", first, got)
+		}
+	}
+	if !strings.HasSuffix(first, ".") {
+		t.Errorf("Description should be a full sentence: %q", first)
+	}
+}
+
+func TestDescribeAppearance_Article(t *testing.T) {
+	traits := DefaultTraitsForDiet(DietHerbivore)
+	traits.Size, traits.Covering, traits.Strength, traits.Speed = 1.5, CoveringFur, 1.0, 5.0
+
+	if got := DescribeAppearance(traits, DietHerbivore); !strings.HasPrefix(got, "A medium-sized, furred quadruped") {
+		t.Errorf("Unexpected description: %q", got)
+	}
+
+	traits.Size = 10
+	traits.Covering = ""
+	if got := DescribeAppearance(traits, DietHerbivore); !strings.HasPrefix(got, "A colossal") {
+		t.Errorf("Unexpected description: %q", got)
+	}
+}
@@ -0,0 +1,112 @@
+package population
+
+import (
+	"math"
+
+	"tw-backend/internal/worldgen/geography"
+)
+
+const (
+	// ReferenceBiomeArea is the area (km²) of a typical simulated biome
+	ReferenceBiomeArea = 1000.0
+
+	// CapacityPerProductivity is how many individuals each km² supports per
+	// g/m²/year of net primary productivity. A rainforest patch of
+	// ReferenceBiomeArea comes to about 5000.
+	CapacityPerProductivity = 0.0025
+
+	// oceanCapacityFactor scales ocean capacity up: life fills the whole
+	// water column, not just the surface
+	oceanCapacityFactor = 2.2
+
+	// MinCarryingCapacity keeps even a barren biome able to hold a few
+	// hardy species
+	MinCarryingCapacity = 100
+
+	// MaxDegradation caps how much of a biome's productivity catastrophes
+	// can destroy at once
+	MaxDegradation = 0.9
+
+	// DegradationRecoveryYears is how long (e-folding) a degraded biome
+	// takes to regrow its productivity
+	DegradationRecoveryYears = 5000.0
+)
+
+// NetPrimaryProductivity estimates plant growth (g/m²/year) from mean annual
+// temperature (°C) and rainfall (mm/year) using the Miami model: whichever
+// of warmth and water is scarcer sets the limit.
+func NetPrimaryProductivity(temperature, rainfall float64) float64 {
+	byTemperature := 3000 / (1 + math.Exp(1.315-0.119*temperature))
+	byRainfall := 3000 * (1 - math.Exp(-0.000664*math.Max(0, rainfall)))
+	return math.Min(byTemperature, byRainfall)
+}
+
+// CalculateCarryingCapacity returns the total population a biome of the given
+// area (km²) can support in a climate, from its net primary productivity.
+// Rainfall doesn't limit ocean productivity; temperature still does.
+func CalculateCarryingCapacity(biomeType geography.BiomeType, temperature, rainfall, area float64) int64 {
+	npp := NetPrimaryProductivity(temperature, rainfall)
+	factor := 1.0
+	if biomeType == geography.BiomeOcean {
+		npp = NetPrimaryProductivity(temperature, math.Inf(1))
+		factor = oceanCapacityFactor
+	}
+	capacity := int64(npp * area * CapacityPerProductivity * factor)
+	return max(capacity, MinCarryingCapacity)
+}
+
+// SetClimate gives the biome a climate and area, after which its carrying
+// capacity follows CalculateCarryingCapacity each simulated year instead of
+// staying fixed
+func (bp *BiomePopulation) SetClimate(temperature, rainfall, area float64) {
+	bp.Temperature = temperature
+	bp.Rainfall = rainfall
+	bp.Area = area
+}
+
+// updateCapacity recomputes a climate-driven biome's carrying capacity,
+// with the world's temperature anomaly, and lets degradation heal a year
+func (ps *PopulationSimulator) updateCapacity(biome *BiomePopulation) {
+	if biome.Area > 0 {
+		biome.CarryingCapacity = CalculateCarryingCapacity(biome.BiomeType,
+			biome.Temperature+ps.TemperatureAnomaly, biome.Rainfall, biome.Area)
+	}
+	if biome.Degradation > 0 {
+		biome.Degradation *= math.Exp(-1 / DegradationRecoveryYears)
+		if biome.Degradation < 0.001 {
+			biome.Degradation = 0
+		}
+	}
+}
+
+// degrade destroys a share of the biome's productivity, compounding with
+// any damage not yet healed
+func (bp *BiomePopulation) degrade(share float64) {
+	if share <= 0 {
+		return
+	}
+	bp.Degradation = math.Min(MaxDegradation, 1-(1-bp.Degradation)*(1-share))
+}
+
+// eventDegradation is the share of a biome's productivity an extinction
+// event of full severity destroys: impacts and eruptions strip vegetation
+// and poison soils, anoxia kills the seas, but climate shifts alone act
+// through the climate instead
+func eventDegradation(eventType ExtinctionEventType, biomeType geography.BiomeType) float64 {
+	ocean := biomeType == geography.BiomeOcean
+	switch eventType {
+	case EventAsteroidImpact:
+		return 0.5
+	case EventVolcanicWinter:
+		return 0.3
+	case EventFloodBasalt:
+		if !ocean {
+			return 0.4
+		}
+	case EventOceanAnoxia:
+		if ocean {
+			return 0.6
+		}
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+package population
+
+import (
+	"math/rand"
+	"testing"
+
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+func TestCalculateCarryingCapacity(t *testing.T) {
+	rainforest := CalculateCarryingCapacity(geography.BiomeRainforest, 26, 1800, ReferenceBiomeArea)
+	grassland := CalculateCarryingCapacity(geography.BiomeGrassland, 15, 800, ReferenceBiomeArea)
+	tundra := CalculateCarryingCapacity(geography.BiomeTundra, -8, 300, ReferenceBiomeArea)
+
+	if !(rainforest > grassland && grassland > tundra) {
+		t.Errorf("Expected rainforest > grassland > tundra, got %d, %d, %d", rainforest, grassland, tundra)
+	}
+	if rainforest < 4*tundra {
+		t.Errorf("A rainforest should support far more than tundra: %d vs %d", rainforest, tundra)
+	}
+
+	t.Run("climate shifts capacity", func(t *testing.T) {
+		if warmer := CalculateCarryingCapacity(geography.BiomeTundra, 2, 300, ReferenceBiomeArea); warmer <= tundra {
+			t.Errorf("Warming tundra should gain capacity: %d vs %d", warmer, tundra)
+		}
+		if drier := CalculateCarryingCapacity(geography.BiomeRainforest, 26, 600, ReferenceBiomeArea); drier >= rainforest {
+			t.Errorf("Drought should cut rainforest capacity: %d vs %d", drier, rainforest)
+		}
+	})
+
+	t.Run("scales with area", func(t *testing.T) {
+		double := CalculateCarryingCapacity(geography.BiomeRainforest, 26, 1800, 2*ReferenceBiomeArea)
+		if diff := double - 2*rainforest; diff < -1 || diff > 1 {
+			t.Errorf("Twice the area should hold twice the population: %d vs %d", double, rainforest)
+		}
+	})
+
+	t.Run("rain doesn't limit oceans", func(t *testing.T) {
+		if CalculateCarryingCapacity(geography.BiomeOcean, 15, 0, ReferenceBiomeArea) !=
+			CalculateCarryingCapacity(geography.BiomeOcean, 15, 2000, ReferenceBiomeArea) {
+			t.Error("Ocean capacity shouldn't depend on rainfall")
+		}
+	})
+
+	t.Run("barren biomes keep a floor", func(t *testing.T) {
+		if got := CalculateCarryingCapacity(geography.BiomeDesert, 30, 0, ReferenceBiomeArea); got != MinCarryingCapacity {
+			t.Errorf("Expected the %d floor, got %d", MinCarryingCapacity, got)
+		}
+	})
+}
+
+// grazingBiome returns a climate-driven biome of flora and one herbivore
+func grazingBiome(temperature, rainfall float64) (*PopulationSimulator, *BiomePopulation, *SpeciesPopulation) {
+	sim := NewPopulationSimulatorWithSource(uuid.New(), rand.NewSource(5))
+	biome := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.SetClimate(temperature, rainfall, ReferenceBiomeArea)
+
+	biome.AddSpecies(&SpeciesPopulation{
+		SpeciesID: uuid.New(), Name: "Grass", Count: 500,
+		Traits: DefaultTraitsForDiet(DietPhotosynthetic), Diet: DietPhotosynthetic,
+	})
+	grazer := &SpeciesPopulation{
+		SpeciesID: uuid.New(), Name: "Grazer", Count: 100,
+		Traits: DefaultTraitsForDiet(DietHerbivore), Diet: DietHerbivore,
+	}
+	biome.AddSpecies(grazer)
+	sim.Biomes[biome.BiomeID] = biome
+	return sim, biome, grazer
+}
+
+// equilibrium runs the simulator and returns the species' mean count over
+// the last half of the run
+func equilibrium(sim *PopulationSimulator, sp *SpeciesPopulation, years int) float64 {
+	var sum float64
+	for i := 0; i < years; i++ {
+		sim.SimulateYear()
+		if i >= years/2 {
+			sum += float64(sp.Count)
+		}
+	}
+	return sum / float64(years-years/2)
+}
+
+func TestCarryingCapacity_HerbivoresTrackClimate(t *testing.T) {
+	lushSim, lush, lushGrazer := grazingBiome(22, 1600)
+	poorSim, _, poorGrazer := grazingBiome(22, 250)
+
+	lushEq := equilibrium(lushSim, lushGrazer, 400)
+	poorEq := equilibrium(poorSim, poorGrazer, 400)
+	if lushEq <= poorEq*1.5 {
+		t.Errorf("A productive biome should support more herbivores: %.0f vs %.0f", lushEq, poorEq)
+	}
+
+	// Drought: the same biome now holds fewer
+	before := lush.CarryingCapacity
+	lush.Rainfall = 600
+	droughtEq := equilibrium(lushSim, lushGrazer, 400)
+	if lush.CarryingCapacity >= before {
+		t.Errorf("Capacity should fall with rainfall: %d vs %d", lush.CarryingCapacity, before)
+	}
+	if droughtEq >= lushEq*0.75 {
+		t.Errorf("Herbivores should decline in drought: %.0f vs %.0f", droughtEq, lushEq)
+	}
+	t.Logf("Herbivore equilibrium: lush %.0f, poor %.0f, lush in drought %.0f", lushEq, poorEq, droughtEq)
+}
+
+func TestCarryingCapacity_FixedWithoutClimate(t *testing.T) {
+	sim := NewPopulationSimulator(uuid.New(), 1)
+	biome := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.CarryingCapacity = 1234
+	sim.Biomes[biome.BiomeID] = biome
+
+	sim.SimulateYear()
+	if biome.CarryingCapacity != 1234 {
+		t.Errorf("A biome without a climate should keep its capacity, got %d", biome.CarryingCapacity)
+	}
+}
+
+func TestApplyExtinctionEvent_DegradesCapacity(t *testing.T) {
+	sim, biome, _ := grazingBiome(22, 1600)
+	sim.SimulateYear()
+	before := biome.EffectiveCapacity()
+
+	sim.ApplyExtinctionEvent(EventAsteroidImpact, 1.0)
+	if biome.Degradation <= 0 || biome.EffectiveCapacity() >= before {
+		t.Fatalf("An impact should degrade the biome: capacity %d vs %d", biome.EffectiveCapacity(), before)
+	}
+
+	// Habitat regrows over time
+	damaged := biome.Degradation
+	for i := 0; i < int(DegradationRecoveryYears); i++ {
+		sim.updateCapacity(biome)
+	}
+	if biome.Degradation >= damaged*0.5 {
+		t.Errorf("Degradation should heal: %.3f after recovery vs %.3f", biome.Degradation, damaged)
+	}
+
+	// Anoxia spares the land
+	sim.ApplyExtinctionEvent(EventOceanAnoxia, 1.0)
+	if eventDegradation(EventOceanAnoxia, geography.BiomeGrassland) != 0 {
+		t.Error("Ocean anoxia shouldn't degrade land biomes")
+	}
+}
@@ -19,6 +19,8 @@ const (
 	CascadeHabitatLoss     CascadeType = "habitat_loss"     // Ecosystem engineer dies
 	CascadeCompetitorLoss  CascadeType = "competitor_loss"  // Competitive release
 	CascadeKeystone        CascadeType = "keystone"         // Keystone species collapse
+	CascadeHostLoss        CascadeType = "host_loss"        // Parasite loses its host
+	CascadeParasiteRelease CascadeType = "parasite_release" // Host freed from a parasite
 )
 
 // EcologicalRole represents a species' role in the ecosystem
@@ -99,11 +101,49 @@ func NewCascadeSimulator() *CascadeSimulator {
 	}
 }
 
-// AddRelationship adds an ecological relationship between species
+// AddRelationship adds an ecological relationship between species. Strength
+// is clamped to 0-1 and a species can't depend on itself. Adding a
+// relationship that already exists (same species and type) updates it, so
+// relationships can be rebuilt from population data without piling up.
+//
+// Mutualism and parasitism both read from the dependent's side: for
+// mutualism the source relies on its partner (a plant on its pollinator,
+// say), for parasitism the source is the parasite and the target its host.
+// Either way, losing a species affects both ends (see CalculateCascade).
 func (cs *CascadeSimulator) AddRelationship(rel EcologicalRelationship) {
+	if rel.SourceSpeciesID == rel.TargetSpeciesID {
+		return
+	}
+	rel.Strength = clamp32(rel.Strength, 0, 1)
+	for i, existing := range cs.Relationships {
+		if existing.SourceSpeciesID == rel.SourceSpeciesID && existing.TargetSpeciesID == rel.TargetSpeciesID && existing.Type == rel.Type {
+			cs.Relationships[i] = rel
+			return
+		}
+	}
 	cs.Relationships = append(cs.Relationships, rel)
 }
 
+// hasRelationship reports whether source depends on target in this way
+func (cs *CascadeSimulator) hasRelationship(sourceID, targetID uuid.UUID, relType RelationshipType) bool {
+	for _, rel := range cs.Relationships {
+		if rel.SourceSpeciesID == sourceID && rel.TargetSpeciesID == targetID && rel.Type == relType {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRole reports whether a species plays the given ecological role
+func (cs *CascadeSimulator) hasRole(speciesID uuid.UUID, role EcologicalRole) bool {
+	for _, r := range cs.SpeciesRoles[speciesID] {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 // SetSpeciesRole sets the ecological role(s) of a species
 func (cs *CascadeSimulator) SetSpeciesRole(speciesID uuid.UUID, roles []EcologicalRole) {
 	cs.SpeciesRoles[speciesID] = roles
@@ -127,7 +167,11 @@ func (cs *CascadeSimulator) SetKeystoneImportance(speciesID uuid.UUID, importanc
 	}
 }
 
-// CalculateCascade calculates all cascade effects from a species going extinct
+// CalculateCascade calculates all cascade effects from a species going extinct.
+// Impacts carry their sign: losing a predator or a parasite lets the species
+// it preyed on grow, while losing food, a host or a mutualist partner makes
+// the dependent decline. Species pushed below a tenth of their population
+// go extinct in turn and cascade further, up to maxGenerations deep.
 func (cs *CascadeSimulator) CalculateCascade(
 	extinctSpeciesID uuid.UUID,
 	extinctSpeciesName string,
@@ -148,6 +192,7 @@ func (cs *CascadeSimulator) CalculateCascade(
 	// Track species that will go extinct this cascade
 	toProcess := []uuid.UUID{extinctSpeciesID}
 	processed := make(map[uuid.UUID]bool)
+	extinct := map[uuid.UUID]bool{extinctSpeciesID: true}
 	generation := 0
 
 	for len(toProcess) > 0 && generation < maxGenerations {
@@ -165,6 +210,9 @@ func (cs *CascadeSimulator) CalculateCascade(
 			affected := cs.findAffectedSpecies(speciesID)
 
 			for _, effect := range affected {
+				if extinct[effect.SpeciesID] {
+					continue // Already gone in this cascade
+				}
 				event := CascadeEvent{
 					Year:               year,
 					TriggerSpeciesID:   speciesID,
@@ -186,6 +234,7 @@ func (cs *CascadeSimulator) CalculateCascade(
 				// Check for secondary extinction (population impact <= -0.9)
 				if result.PopulationChanges[effect.SpeciesID] <= 0.1 {
 					if !processed[effect.SpeciesID] {
+						extinct[effect.SpeciesID] = true
 						result.SecondaryExtinctions = append(result.SecondaryExtinctions, effect.SpeciesID)
 						toProcess = append(toProcess, effect.SpeciesID)
 						cs.recentExtinctions[effect.SpeciesID] = year
@@ -225,6 +274,11 @@ func (cs *CascadeSimulator) findAffectedSpecies(extinctID uuid.UUID) []affectedI
 				Description: desc,
 			})
 		} else if rel.SourceSpeciesID == extinctID {
+			// Mutualism is two-way: a partner that also depends on the
+			// extinct species is covered by its own relationship
+			if rel.Type == RelationshipMutualism && cs.hasRelationship(rel.TargetSpeciesID, extinctID, RelationshipMutualism) {
+				continue
+			}
 			// The extinct species was doing something TO the target
 			impact, cascadeType, desc := cs.calculateReleaseImpact(rel)
 			affected = append(affected, affectedInfo{
@@ -258,12 +312,25 @@ func (cs *CascadeSimulator) calculateDependencyImpact(rel EcologicalRelationship
 		return baseImpact * 0.7, CascadeFoodLoss, "food source reduced"
 
 	case RelationshipMutualism:
-		// Partner loses mutualist
+		// Partner loses mutualist; a plant losing its pollinator can't set seed
+		if cs.hasRole(rel.TargetSpeciesID, RolePollinator) && cs.hasRole(rel.SourceSpeciesID, RolePrimaryProducer) {
+			if rel.IsObligate {
+				return -1.0, CascadePollinationLoss, "obligate pollinator extinct"
+			}
+			return baseImpact * 0.6, CascadePollinationLoss, "pollinator lost - fewer seeds set"
+		}
 		if rel.IsObligate {
 			return -1.0, CascadeCoExtinction, "obligate symbiont extinct"
 		}
 		return baseImpact * 0.5, CascadeCoExtinction, "mutualist partner lost"
 
+	case RelationshipParasitism:
+		// Parasite loses its host
+		if rel.IsObligate {
+			return -1.0, CascadeHostLoss, "obligate host extinct"
+		}
+		return baseImpact * 0.6, CascadeHostLoss, "host lost - parasite declines"
+
 	case RelationshipHabitat:
 		// Habitat provider gone
 		if rel.IsObligate {
@@ -293,9 +360,14 @@ func (cs *CascadeSimulator) calculateReleaseImpact(rel EcologicalRelationship) (
 		// Competitor release
 		return baseImpact * 0.4, CascadeCompetitorLoss, "competitive release - expansion into niche"
 
+	case RelationshipMutualism:
+		// The partner loses what the extinct species gave it, but didn't
+		// depend on it
+		return -baseImpact * 0.3, CascadeCoExtinction, "mutualist partner lost"
+
 	case RelationshipParasitism:
-		// Parasite gone - minor benefit
-		return baseImpact * 0.2, CascadeCompetitorLoss, "parasite removed"
+		// Host freed from its parasite
+		return baseImpact * 0.3, CascadeParasiteRelease, "parasite gone - host recovers"
 
 	default:
 		return 0, "", ""
@@ -434,6 +506,8 @@ func GenerateExtinctionCause(cascadeType CascadeType, triggerName string) string
 		return "ecosystem imbalance after competitor " + triggerName + " vanished"
 	case CascadeKeystone:
 		return "ecosystem collapse following loss of keystone species " + triggerName
+	case CascadeHostLoss:
+		return "loss of host species " + triggerName
 	default:
 		return "ecological cascade from extinction of " + triggerName
 	}
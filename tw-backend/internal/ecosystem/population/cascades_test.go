@@ -103,6 +103,111 @@ func TestCascadeSimulator_MutualismCollapse(t *testing.T) {
 	})
 }
 
+func TestCascadeSimulator_PollinatorLoss(t *testing.T) {
+	cs := NewCascadeSimulator()
+	beeID, orchidID, cloverID := uuid.New(), uuid.New(), uuid.New()
+
+	// The orchid has only the one pollinator; clover gets by with others
+	cs.AddRelationship(EcologicalRelationship{
+		SourceSpeciesID: orchidID, TargetSpeciesID: beeID,
+		Type: RelationshipMutualism, Strength: 0.9, IsObligate: true,
+	})
+	cs.AddRelationship(EcologicalRelationship{
+		SourceSpeciesID: cloverID, TargetSpeciesID: beeID,
+		Type: RelationshipMutualism, Strength: 0.5,
+	})
+	cs.SetSpeciesRole(beeID, []EcologicalRole{RolePollinator})
+	cs.SetSpeciesRole(orchidID, []EcologicalRole{RolePrimaryProducer})
+	cs.SetSpeciesRole(cloverID, []EcologicalRole{RolePrimaryProducer})
+
+	result := cs.CalculateCascade(beeID, "Bee", 1000, 3)
+
+	if orchid := result.PopulationChanges[orchidID]; orchid > 0.1 {
+		t.Errorf("Orchid population = %f, want collapse without its obligate pollinator", orchid)
+	}
+	if clover := result.PopulationChanges[cloverID]; clover >= 1 || clover <= 0.1 {
+		t.Errorf("Clover population = %f, want a decline short of extinction", clover)
+	}
+	for _, event := range result.Events {
+		if event.CascadeType != CascadePollinationLoss {
+			t.Errorf("Cascade type = %s, want %s", event.CascadeType, CascadePollinationLoss)
+		}
+	}
+	if len(result.SecondaryExtinctions) != 1 || result.SecondaryExtinctions[0] != orchidID {
+		t.Errorf("Only the orchid should go extinct, got %v", result.SecondaryExtinctions)
+	}
+}
+
+func TestCascadeSimulator_MutualPartnerLoss(t *testing.T) {
+	cs := NewCascadeSimulator()
+	ploverID, crocodileID := uuid.New(), uuid.New()
+
+	// The plover feeds on the crocodile's parasites; the crocodile benefits
+	// but doesn't need it
+	cs.AddRelationship(EcologicalRelationship{
+		SourceSpeciesID: ploverID, TargetSpeciesID: crocodileID,
+		Type: RelationshipMutualism, Strength: 0.6,
+	})
+
+	result := cs.CalculateCascade(ploverID, "Plover", 1000, 3)
+	if croc := result.PopulationChanges[crocodileID]; croc >= 1 || croc <= 0.5 {
+		t.Errorf("Crocodile population = %f, want a mild decline after losing its partner", croc)
+	}
+}
+
+func TestCascadeSimulator_Parasitism(t *testing.T) {
+	cs := NewCascadeSimulator()
+	tickID, deerID, flukeID, snailID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	// Ticks feed on deer among other hosts; the fluke lives only in snails
+	cs.AddRelationship(EcologicalRelationship{
+		SourceSpeciesID: tickID, TargetSpeciesID: deerID,
+		Type: RelationshipParasitism, Strength: 0.6,
+	})
+	cs.AddRelationship(EcologicalRelationship{
+		SourceSpeciesID: flukeID, TargetSpeciesID: snailID,
+		Type: RelationshipParasitism, Strength: 0.8, IsObligate: true,
+	})
+
+	t.Run("losing a host harms the parasite", func(t *testing.T) {
+		result := cs.CalculateCascade(deerID, "Deer", 1000, 3)
+		if tick := result.PopulationChanges[tickID]; tick >= 1 || tick <= 0.1 {
+			t.Errorf("Tick population = %f, want a decline", tick)
+		}
+
+		result = cs.CalculateCascade(snailID, "Snail", 1000, 3)
+		if len(result.SecondaryExtinctions) != 1 || result.SecondaryExtinctions[0] != flukeID {
+			t.Errorf("An obligate parasite should die with its host, got %v", result.SecondaryExtinctions)
+		}
+		if result.Events[0].CascadeType != CascadeHostLoss {
+			t.Errorf("Cascade type = %s, want %s", result.Events[0].CascadeType, CascadeHostLoss)
+		}
+	})
+
+	t.Run("losing a parasite benefits the host", func(t *testing.T) {
+		result := cs.CalculateCascade(tickID, "Tick", 1000, 3)
+		if deer := result.PopulationChanges[deerID]; deer <= 1 {
+			t.Errorf("Deer population = %f, want growth once freed of ticks", deer)
+		}
+	})
+}
+
+func TestCascadeSimulator_AddRelationship(t *testing.T) {
+	cs := NewCascadeSimulator()
+	wolfID, deerID := uuid.New(), uuid.New()
+
+	cs.AddRelationship(EcologicalRelationship{SourceSpeciesID: wolfID, TargetSpeciesID: deerID, Type: RelationshipPredation, Strength: 0.5})
+	cs.AddRelationship(EcologicalRelationship{SourceSpeciesID: wolfID, TargetSpeciesID: deerID, Type: RelationshipPredation, Strength: 1.7})
+	cs.AddRelationship(EcologicalRelationship{SourceSpeciesID: wolfID, TargetSpeciesID: wolfID, Type: RelationshipCompetition, Strength: 0.5})
+
+	if len(cs.Relationships) != 1 {
+		t.Fatalf("Expected one relationship, got %d", len(cs.Relationships))
+	}
+	if cs.Relationships[0].Strength != 1 {
+		t.Errorf("Re-adding should update and clamp the strength, got %f", cs.Relationships[0].Strength)
+	}
+}
+
 func TestCascadeSimulator_PredatorRelease(t *testing.T) {
 	cs := NewCascadeSimulator()
 
@@ -0,0 +1,195 @@
+package population
+
+import (
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// FactorKind names one driver of a species' population change
+type FactorKind string
+
+const (
+	FactorGrowth           FactorKind = "growth"            // Births or plant growth at neutral season and full food
+	FactorMortality        FactorKind = "mortality"         // Deaths from old age and poor biome fitness
+	FactorFood             FactorKind = "food_availability" // Births lost (or deaths added) to food or prey shortage
+	FactorPredation        FactorKind = "predation"         // Losses to predators (grazing, for flora)
+	FactorSeasonal         FactorKind = "seasonal"          // Gain or loss from the season's growth/breeding modifier
+	FactorCarryingCapacity FactorKind = "carrying_capacity" // Trimmed because the biome is over capacity
+	FactorTrophicCap       FactorKind = "trophic_cap"       // Trimmed to the trophic pyramid's limit
+	FactorDisease          FactorKind = "disease"           // Outbreak deaths
+	FactorEvents           FactorKind = "event_mortality"   // Extinction events and wildfires
+	FactorInbreeding       FactorKind = "inbreeding"        // Young lost to inbreeding depression
+	FactorSmallPopulation  FactorKind = "small_population"  // Deaths in the extinction vortex below the minimum viable population
+	FactorVariation        FactorKind = "random_variation"  // Year-to-year noise
+)
+
+// factorIndex indexes a record's impacts; records are rewritten for every
+// species every simulated year, so they avoid per-year map allocations
+type factorIndex int
+
+const (
+	factorGrowth factorIndex = iota
+	factorMortality
+	factorFood
+	factorPredation
+	factorSeasonal
+	factorCarryingCapacity
+	factorTrophicCap
+	factorDisease
+	factorEvents
+	factorInbreeding
+	factorSmallPopulation
+	factorVariation
+	numFactors
+)
+
+var factorKinds = [numFactors]FactorKind{
+	FactorGrowth, FactorMortality, FactorFood, FactorPredation, FactorSeasonal,
+	FactorCarryingCapacity, FactorTrophicCap, FactorDisease, FactorEvents, FactorInbreeding, FactorSmallPopulation,
+	FactorVariation,
+}
+
+// DiagnosticFactor is one factor's contribution to the last population step
+type DiagnosticFactor struct {
+	Kind   FactorKind `json:"kind"`
+	Impact float64    `json:"impact"` // Individuals gained (+) or lost (-)
+	Share  float64    `json:"share"`  // Fraction of all factors' absolute impact (0-1)
+}
+
+// SpeciesDiagnosis explains why a species grew or shrank in one biome during
+// the last simulateBiomeYear step (plus disease and events that followed it)
+type SpeciesDiagnosis struct {
+	SpeciesID        uuid.UUID          `json:"species_id"`
+	BiomeID          uuid.UUID          `json:"biome_id"`
+	Year             int64              `json:"year"`
+	StartCount       int64              `json:"start_count"`
+	EndCount         int64              `json:"end_count"`
+	FoodAvailability float64            `json:"food_availability"` // 0-1 (prey ratio for predators; 1 for flora)
+	SeasonalModifier float64            `json:"seasonal_modifier"` // Growth (flora) or breeding modifier
+	Factors          []DiagnosticFactor `json:"factors"`           // Largest absolute impact first
+}
+
+// Available reports whether the species has been simulated in this biome
+func (d SpeciesDiagnosis) Available() bool {
+	return len(d.Factors) > 0
+}
+
+// DominantNegative returns the factor that cost the species the most
+func (d SpeciesDiagnosis) DominantNegative() (DiagnosticFactor, bool) {
+	for _, f := range d.Factors {
+		if f.Impact < 0 {
+			return f, true
+		}
+	}
+	return DiagnosticFactor{}, false
+}
+
+// DominantPositive returns the factor that added the most individuals
+func (d SpeciesDiagnosis) DominantPositive() (DiagnosticFactor, bool) {
+	for _, f := range d.Factors {
+		if f.Impact > 0 {
+			return f, true
+		}
+	}
+	return DiagnosticFactor{}, false
+}
+
+// speciesYearRecord accumulates one species' factors for a single year
+type speciesYearRecord struct {
+	year             int64
+	startCount       int64
+	foodAvailability float64
+	seasonalModifier float64
+	impacts          [numFactors]float64
+}
+
+// DiagnoseSpecies returns the dominant factors behind the species' last
+// population step in this biome. The diagnosis has no factors if the species
+// hasn't been simulated here yet.
+func (bp *BiomePopulation) DiagnoseSpecies(id uuid.UUID) SpeciesDiagnosis {
+	diagnosis := SpeciesDiagnosis{SpeciesID: id, BiomeID: bp.BiomeID}
+	if sp, ok := bp.Species[id]; ok {
+		diagnosis.EndCount = sp.Count
+	}
+
+	rec := bp.diagnostics[id]
+	if rec == nil {
+		return diagnosis
+	}
+	diagnosis.Year = rec.year
+	diagnosis.StartCount = rec.startCount
+	diagnosis.FoodAvailability = rec.foodAvailability
+	diagnosis.SeasonalModifier = rec.seasonalModifier
+
+	var total float64
+	for _, impact := range rec.impacts {
+		total += math.Abs(impact)
+	}
+	for i, impact := range rec.impacts {
+		if impact == 0 {
+			continue
+		}
+		diagnosis.Factors = append(diagnosis.Factors, DiagnosticFactor{
+			Kind:   factorKinds[i],
+			Impact: impact,
+			Share:  math.Abs(impact) / total,
+		})
+	}
+	sort.Slice(diagnosis.Factors, func(i, j int) bool {
+		a, b := diagnosis.Factors[i], diagnosis.Factors[j]
+		if math.Abs(a.Impact) != math.Abs(b.Impact) {
+			return math.Abs(a.Impact) > math.Abs(b.Impact)
+		}
+		return a.Kind < b.Kind
+	})
+	return diagnosis
+}
+
+// beginDiagnosis starts a fresh record for the species' step this year,
+// reusing last year's record
+func (bp *BiomePopulation) beginDiagnosis(id uuid.UUID, year, startCount int64) *speciesYearRecord {
+	if bp.diagnostics == nil {
+		bp.diagnostics = make(map[uuid.UUID]*speciesYearRecord)
+	}
+	rec := bp.diagnostics[id]
+	if rec == nil {
+		rec = &speciesYearRecord{}
+		bp.diagnostics[id] = rec
+	}
+	*rec = speciesYearRecord{
+		year:             year,
+		startCount:       startCount,
+		foodAvailability: 1.0,
+		seasonalModifier: 1.0,
+	}
+	return rec
+}
+
+// recordLoss adds deaths from disease, events, inbreeding or the extinction
+// vortex to the species' record for the year, starting one if the species
+// hasn't been stepped this year
+func (bp *BiomePopulation) recordLoss(id uuid.UUID, year int64, factor factorIndex, deaths int64) {
+	if deaths <= 0 {
+		return
+	}
+	rec := bp.diagnostics[id]
+	if rec == nil || rec.year != year {
+		startCount := deaths
+		if sp, ok := bp.Species[id]; ok {
+			startCount += sp.Count
+		}
+		rec = bp.beginDiagnosis(id, year, startCount)
+	}
+	rec.impacts[factor] -= float64(deaths)
+}
+
+// pruneDiagnostics drops records for species no longer in the biome
+func (bp *BiomePopulation) pruneDiagnostics() {
+	for id := range bp.diagnostics {
+		if _, ok := bp.Species[id]; !ok {
+			delete(bp.diagnostics, id)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package population
+
+import (
+	"testing"
+
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+// newDiagnosisSim builds a simulator in spring with one grassland biome
+func newDiagnosisSim() (*PopulationSimulator, *BiomePopulation) {
+	sim := NewPopulationSimulator(uuid.New(), 7)
+	sim.CurrentYear = 4000 // Spring
+	biome := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	sim.Biomes[biome.BiomeID] = biome
+	return sim, biome
+}
+
+func TestDiagnoseSpecies_StarvingHerbivore(t *testing.T) {
+	sim, biome := newDiagnosisSim()
+	herbivore := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Grazer", Count: 1000,
+		Diet: DietHerbivore, Traits: DefaultTraitsForDiet(DietHerbivore)}
+	biome.AddSpecies(herbivore) // No flora at all
+
+	sim.simulateBiomeYear(biome)
+	d := biome.DiagnoseSpecies(herbivore.SpeciesID)
+
+	if !d.Available() {
+		t.Fatal("Diagnosis should be available after a simulated year")
+	}
+	if d.FoodAvailability != 0 {
+		t.Errorf("FoodAvailability = %.2f, want 0 with no flora", d.FoodAvailability)
+	}
+	worst, ok := d.DominantNegative()
+	if !ok || worst.Kind != FactorFood {
+		t.Errorf("Dominant negative factor = %+v, want %s (factors %+v)", worst, FactorFood, d.Factors)
+	}
+}
+
+func TestDiagnoseSpecies_BoomingFlora(t *testing.T) {
+	sim, biome := newDiagnosisSim()
+	flora := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Prairie Grass", Count: 100,
+		Diet: DietPhotosynthetic, Traits: DefaultTraitsForDiet(DietPhotosynthetic)}
+	biome.AddSpecies(flora) // Far below capacity, no grazers
+
+	sim.simulateBiomeYear(biome)
+	d := biome.DiagnoseSpecies(flora.SpeciesID)
+
+	if d.EndCount <= d.StartCount {
+		t.Fatalf("Flora should boom: %d -> %d", d.StartCount, d.EndCount)
+	}
+	best, ok := d.DominantPositive()
+	if !ok || best.Kind != FactorGrowth {
+		t.Errorf("Dominant positive factor = %+v, want %s (factors %+v)", best, FactorGrowth, d.Factors)
+	}
+	if d.Factors[0].Kind != FactorGrowth {
+		t.Errorf("Growth should outweigh every other factor, got %+v", d.Factors)
+	}
+
+	var shares float64
+	for _, f := range d.Factors {
+		shares += f.Share
+	}
+	if shares < 0.999 || shares > 1.001 {
+		t.Errorf("Factor shares should sum to 1, got %.3f", shares)
+	}
+}
+
+func TestDiagnoseSpecies_EventMortality(t *testing.T) {
+	sim, biome := newDiagnosisSim()
+	herbivore := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Grazer", Count: 1000,
+		Diet: DietHerbivore, Traits: DefaultTraitsForDiet(DietHerbivore)}
+	biome.AddSpecies(herbivore)
+
+	sim.ApplyExtinctionEvent(EventAsteroidImpact, 1.0)
+	d := biome.DiagnoseSpecies(herbivore.SpeciesID)
+
+	worst, ok := d.DominantNegative()
+	if !ok || worst.Kind != FactorEvents {
+		t.Errorf("Impact deaths should be reported as %s, got %+v", FactorEvents, d.Factors)
+	}
+}
+
+func TestDiagnoseSpecies_NotSimulated(t *testing.T) {
+	_, biome := newDiagnosisSim()
+	if d := biome.DiagnoseSpecies(uuid.New()); d.Available() {
+		t.Errorf("Unknown species should have no diagnosis, got %+v", d)
+	}
+}
@@ -1,9 +1,11 @@
 package population
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"tw-backend/internal/worldgen/geography"
 
 	ecogeography "tw-backend/internal/ecosystem/geography"
@@ -16,17 +18,22 @@ type PopulationSimulator struct {
 	Biomes                   map[uuid.UUID]*BiomePopulation
 	FossilRecord             *FossilRecord
 	CurrentYear              int64
-	OxygenLevel              float64  // Atmospheric O2 as fraction (0.21 = 21%, modern baseline)
-	ContinentalFragmentation float64  // 0.0 = supercontinent (Pangaea), 1.0 = fully fragmented
-	RecoveryPhase            bool     // True if recovering from mass extinction
-	RecoveryCounter          int64    // Years remaining in recovery phase
-	Events                   []string // Log of significant events this year
+	OxygenLevel              float64             // Atmospheric O2 as fraction (0.21 = 21%, modern baseline)
+	ContinentalFragmentation float64             // 0.0 = supercontinent (Pangaea), 1.0 = fully fragmented
+	RecoveryPhase            bool                // True if recovering from mass extinction
+	RecoveryCounter          int64               // Years remaining in recovery phase
+	Events                   []string            // Log of significant events this year
+	NamingTheme              NamingTheme         // How species-name collisions are disambiguated
+	MinViablePopulation      map[SizeClass]int64 // Overrides DefaultMinViablePopulation per size class
+	TemperatureAnomaly       float64             // °C added to every climate-driven biome's temperature (see SetClimate)
 	rng                      *rand.Rand
+	names                    *NameRegistry // World-wide species names (see UniqueSpeciesName)
 
 	// Geographic systems for isolation tracking (Phase 2)
 	HexGrid      *ecogeography.HexGrid        `json:"-"` // Hex grid for spatial distribution
 	RegionSystem *ecogeography.RegionSystem   `json:"-"` // Region tracking for isolation
 	Tectonics    *ecogeography.TectonicSystem `json:"-"` // Tectonic plate system
+	Ecotones     []*EcotoneCell               `json:"-"` // Cells bordering another biome type, by coord (see UpdateEcotones)
 }
 
 // CalculateMetabolicRate returns the metabolic rate based on size using Kleiber's Law
@@ -102,14 +109,77 @@ func CalculateMaturationRate(maturityAge float64) float64 {
 
 // NewPopulationSimulator creates a new simulator
 func NewPopulationSimulator(worldID uuid.UUID, seed int64) *PopulationSimulator {
+	return NewPopulationSimulatorWithSource(worldID, rand.NewSource(seed))
+}
+
+// NewPopulationSimulatorWithSource creates a simulator drawing all its
+// randomness from src. Biomes and species are visited in ID order and new
+// species get IDs from src, so the same source and starting species give
+// the same run, however long.
+func NewPopulationSimulatorWithSource(worldID uuid.UUID, src rand.Source) *PopulationSimulator {
 	return &PopulationSimulator{
 		Biomes:                   make(map[uuid.UUID]*BiomePopulation),
 		FossilRecord:             &FossilRecord{WorldID: worldID, Extinct: []*ExtinctSpecies{}},
 		CurrentYear:              0,
 		OxygenLevel:              0.21, // Modern Earth baseline (21%)
 		ContinentalFragmentation: 0.5,  // Start at medium fragmentation
-		rng:                      rand.New(rand.NewSource(seed)),
+		rng:                      rand.New(src),
+	}
+}
+
+// Reset clears the simulator for a world starting over: no species or
+// fossils, back to year 0 and the starting atmosphere, with every species
+// name free again. The seed, naming theme and geographic systems are kept.
+func (ps *PopulationSimulator) Reset() {
+	var worldID uuid.UUID
+	if ps.FossilRecord != nil {
+		worldID = ps.FossilRecord.WorldID
+	}
+	ps.Biomes = make(map[uuid.UUID]*BiomePopulation)
+	ps.FossilRecord = &FossilRecord{WorldID: worldID, Extinct: []*ExtinctSpecies{}}
+	ps.CurrentYear = 0
+	ps.OxygenLevel = 0.21
+	ps.ContinentalFragmentation = 0.5
+	ps.RecoveryPhase = false
+	ps.RecoveryCounter = 0
+	ps.Events = nil
+	ps.TemperatureAnomaly = 0
+	ps.ResetNames()
+}
+
+// SetSeed reseeds the simulator's randomness. The rng isn't serialized, so
+// a simulator restored from JSON needs one before it can step.
+func (ps *PopulationSimulator) SetSeed(seed int64) {
+	ps.rng = rand.New(rand.NewSource(seed))
+}
+
+// sortedBiomes returns the biomes in ID order, so anything drawing from the
+// rng per biome does so in the same order every run
+func (ps *PopulationSimulator) sortedBiomes() []*BiomePopulation {
+	biomes := make([]*BiomePopulation, 0, len(ps.Biomes))
+	for _, biome := range ps.Biomes {
+		biomes = append(biomes, biome)
 	}
+	slices.SortFunc(biomes, func(a, b *BiomePopulation) int {
+		return compareIDs(a.BiomeID, b.BiomeID)
+	})
+	return biomes
+}
+
+// newSpeciesID draws a species ID from the simulator's rng
+func (ps *PopulationSimulator) newSpeciesID() uuid.UUID {
+	return newSpeciesID(ps.rng)
+}
+
+// newSpeciesID draws a species ID from rng, so runs seeded alike give their
+// species the same IDs
+func newSpeciesID(rng *rand.Rand) uuid.UUID {
+	return uuid.Must(uuid.NewRandomFromReader(rng))
+}
+
+// compareIDs orders IDs by their bytes, the same order as their strings
+func compareIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
 }
 
 // UpdateContinentalConfiguration gradually changes continental fragmentation
@@ -146,8 +216,8 @@ func (ps *PopulationSimulator) ApplyContinentalEffects() int {
 	affectedSpecies := 0
 	frag := ps.ContinentalFragmentation
 
-	for _, biome := range ps.Biomes {
-		for _, species := range biome.Species {
+	for _, biome := range ps.sortedBiomes() {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count == 0 {
 				continue
 			}
@@ -195,10 +265,10 @@ func (ps *PopulationSimulator) ApplyContinentalEffects() int {
 func (ps *PopulationSimulator) ApplyHabitatFragmentation() int {
 	affectedSpecies := 0
 
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		frag := biome.Fragmentation
 
-		for _, species := range biome.Species {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count == 0 {
 				continue
 			}
@@ -261,8 +331,8 @@ func (ps *PopulationSimulator) ApplyRecoveryEffects() {
 		return
 	}
 
-	for _, biome := range ps.Biomes {
-		for _, species := range biome.Species {
+	for _, biome := range ps.sortedBiomes() {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count == 0 {
 				continue
 			}
@@ -320,10 +390,10 @@ func calculateNicheOverlap(s1, s2 *SpeciesPopulation) float64 {
 // ApplyNichePartitioning reduces population of overlapping species and encourages divergence
 // Character Displacement: species with overlapping niches will evolve apart
 func (ps *PopulationSimulator) ApplyNichePartitioning() {
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		// Convert map to slice for pair iteration
 		speciesList := make([]*SpeciesPopulation, 0, len(biome.Species))
-		for _, s := range biome.Species {
+		for _, s := range biome.sortedSpecies() {
 			if s.Count > 0 {
 				speciesList = append(speciesList, s)
 			}
@@ -384,12 +454,12 @@ func (ps *PopulationSimulator) ApplyNichePartitioning() {
 // - Partners get population boosts
 // - Relationships are established dynamically
 func (ps *PopulationSimulator) ApplySymbiosis() {
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		// Identify potential partners
 		var flora []*SpeciesPopulation
 		var fauna []*SpeciesPopulation
 
-		for _, s := range biome.Species {
+		for _, s := range biome.sortedSpecies() {
 			if s.Count == 0 {
 				continue
 			}
@@ -430,7 +500,7 @@ func (ps *PopulationSimulator) ApplySymbiosis() {
 		}
 
 		// Apply benefits and check for broken links
-		for _, s := range biome.Species {
+		for _, s := range biome.sortedSpecies() {
 			if s.SymbiosisPartnerID == nil {
 				continue
 			}
@@ -462,8 +532,8 @@ func (ps *PopulationSimulator) ApplySymbiosis() {
 // Survivability depends on DiseaseResistance
 func (ps *PopulationSimulator) ApplyDisease() int {
 	outbreaks := 0
-	for _, biome := range ps.Biomes {
-		for _, species := range biome.Species {
+	for _, biome := range ps.sortedBiomes() {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count < 100 {
 				continue // Too sparse for epidemics
 			}
@@ -494,6 +564,7 @@ func (ps *PopulationSimulator) ApplyDisease() int {
 
 				deaths := int64(float64(species.Count) * mortality)
 				species.Count -= deaths
+				biome.recordLoss(species.SpeciesID, ps.CurrentYear, factorDisease, deaths)
 
 				// Survivors evolve resistance proportional to severity
 				if species.Count > 0 {
@@ -508,7 +579,7 @@ func (ps *PopulationSimulator) ApplyDisease() int {
 
 // UpdateBiomeFragmentation changes fragmentation based on population and events
 func (ps *PopulationSimulator) UpdateBiomeFragmentation() {
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		// Fragmentation naturally increases slightly over time (habitat loss)
 		biome.Fragmentation += ps.rng.NormFloat64() * 0.001
 
@@ -611,8 +682,8 @@ func (ps *PopulationSimulator) ApplyOxygenEffects() int {
 	affectedSpecies := 0
 	oxygenModifier := CalculateOxygenSizeModifier(ps.OxygenLevel)
 
-	for _, biome := range ps.Biomes {
-		for _, species := range biome.Species {
+	for _, biome := range ps.sortedBiomes() {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count == 0 || species.Diet == DietPhotosynthetic {
 				continue // Plants don't breathe O2
 			}
@@ -652,8 +723,13 @@ func (ps *PopulationSimulator) ApplyOxygenEffects() int {
 // - Juveniles have higher mortality (predation targets young)
 // - Adults die based on lifespan
 // - Births add to juvenile population (not adult)
+//
+// The age classes are first reconciled with the year's change in Count: net
+// growth joins the juveniles and net losses thin both classes in proportion.
+// Before this, births not yet in a class were dropped again, capping every
+// animal species near its starting numbers whatever its capacity.
 func (ps *PopulationSimulator) ApplyAgeStructure() {
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		// Count predators for juvenile predation modifier
 		var predatorPop int64
 		for _, sp := range biome.Species {
@@ -663,7 +739,7 @@ func (ps *PopulationSimulator) ApplyAgeStructure() {
 		}
 		predatorDensity := float64(predatorPop) / float64(biome.CarryingCapacity+1)
 
-		for _, species := range biome.Species {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count == 0 || species.Diet == DietPhotosynthetic {
 				continue // Flora don't have juveniles in this model
 			}
@@ -675,6 +751,16 @@ func (ps *PopulationSimulator) ApplyAgeStructure() {
 				species.JuvenileCount = species.Count - species.AdultCount
 			}
 
+			// Reconcile with the year's dynamics: net growth arrives as
+			// juveniles, net losses fall on both classes alike
+			if tracked := species.JuvenileCount + species.AdultCount; species.Count > tracked {
+				species.JuvenileCount += species.Count - tracked
+			} else if species.Count < tracked {
+				keep := float64(species.Count) / float64(tracked)
+				species.JuvenileCount = int64(float64(species.JuvenileCount) * keep)
+				species.AdultCount = species.Count - species.JuvenileCount
+			}
+
 			// Calculate survival and maturation rates
 			juvenileSurvival := CalculateJuvenileSurvival(species.Traits)
 			maturationRate := CalculateMaturationRate(species.Traits.Maturity)
@@ -719,7 +805,7 @@ func (ps *PopulationSimulator) ApplyAgeStructure() {
 func (ps *PopulationSimulator) ApplySexualSelection() int {
 	affectedSpecies := 0
 
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		// Count predator presence for handicap cost calculation
 		var predatorPop int64
 		for _, sp := range biome.Species {
@@ -729,7 +815,7 @@ func (ps *PopulationSimulator) ApplySexualSelection() int {
 		}
 		predatorDensity := float64(predatorPop) / float64(biome.CarryingCapacity+1)
 
-		for _, species := range biome.Species {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count == 0 || species.Diet == DietPhotosynthetic {
 				continue // Plants don't have sexual selection in this model
 			}
@@ -784,11 +870,18 @@ func (ps *PopulationSimulator) SimulateYear() {
 	ps.CurrentYear++
 	ps.Events = []string{} // Clear logs from previous year
 
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		biome.YearsSimulated++
+		ps.updateCapacity(biome)
 		ps.simulateBiomeYear(biome)
 	}
 
+	// Track genetic diversity; inbred species raise fewer young
+	ps.ApplyInbreedingDepression()
+
+	// Populations below their minimum viable size spiral toward extinction
+	ps.ApplyExtinctionVortex()
+
 	// Apply age structure transitions (juveniles mature, mortality by age)
 	ps.ApplyAgeStructure()
 
@@ -830,10 +923,13 @@ func (ps *PopulationSimulator) simulateBiomeYear(biome *BiomePopulation) {
 
 	// Track extinctions this year
 	var toExtinct []uuid.UUID
+	biome.pruneDiagnostics()
 
-	for speciesID, species := range biome.Species {
+	for _, species := range biome.sortedSpecies() {
+		speciesID := species.SpeciesID
 		oldCount := species.Count
 		newCount := oldCount
+		diag := biome.beginDiagnosis(speciesID, ps.CurrentYear, oldCount)
 
 		switch species.Diet {
 		case DietPhotosynthetic:
@@ -842,7 +938,7 @@ func (ps *PopulationSimulator) simulateBiomeYear(biome *BiomePopulation) {
 			fitness := CalculateBiomeFitness(species.Traits, biome.BiomeType)
 			// Apply seasonal growth modifier - plants grow more in summer, less in winter
 			growthRate := 0.5 * species.Traits.Fertility * fitness * foodModifier
-			k := float64(biome.CarryingCapacity) * 0.4 // Flora takes 40% of capacity
+			k := float64(biome.EffectiveCapacity()) * 0.4 // Flora takes 40% of capacity
 			p := float64(oldCount)
 			growth := growthRate * p * (1 - p/k)
 			// Reduction from herbivore grazing
@@ -850,6 +946,11 @@ func (ps *PopulationSimulator) simulateBiomeYear(biome *BiomePopulation) {
 			// Seeds always survive - minimum population of 10
 			newCount = int64(math.Max(10, p+growth-grazingRate*p))
 
+			diag.seasonalModifier = foodModifier
+			diag.impacts[factorGrowth] = growth / foodModifier
+			diag.impacts[factorSeasonal] = growth - growth/foodModifier
+			diag.impacts[factorPredation] = -grazingRate * p
+
 		case DietHerbivore:
 			// Herbivores: prey dynamics with Kleiber's Law
 			// dH/dt = (birth_rate * H) - (predation_rate * H * C)
@@ -880,6 +981,15 @@ func (ps *PopulationSimulator) simulateBiomeYear(biome *BiomePopulation) {
 			growth := effectiveBirth*p - deathRate*p - predationLoss
 			newCount = int64(math.Max(1, p+growth)) // Don't drop below 1 from dynamics alone
 
+			births := birthRate * p
+			diag.foodAvailability = foodAvailability
+			diag.seasonalModifier = breedingModifier
+			diag.impacts[factorGrowth] = births / breedingModifier
+			diag.impacts[factorSeasonal] = births - births/breedingModifier
+			diag.impacts[factorFood] = births * (foodAvailability - 1)
+			diag.impacts[factorMortality] = -deathRate * p
+			diag.impacts[factorPredation] = -predationLoss
+
 		case DietCarnivore, DietOmnivore:
 			// Carnivores: predator dynamics with Kleiber's Law
 			// dC/dt = (efficiency * predation * H * C) - (death_rate * C)
@@ -907,12 +1017,22 @@ func (ps *PopulationSimulator) simulateBiomeYear(biome *BiomePopulation) {
 			growth := efficiency * predationRate * float64(preyCount) * p * preyRatio * reproModifier * breedingModifier
 			death := deathRate * p * (1 - preyRatio*0.5)  // Less death when prey available
 			newCount = int64(math.Max(1, p+growth-death)) // Don't go below 1 unless truly extinct
+
+			// Well-fed predators still suffer half the base death rate; the rest is hunger
+			fullBirths := efficiency * predationRate * float64(preyCount) * p * reproModifier * breedingModifier
+			diag.foodAvailability = preyRatio
+			diag.seasonalModifier = breedingModifier
+			diag.impacts[factorGrowth] = fullBirths / breedingModifier
+			diag.impacts[factorSeasonal] = fullBirths - fullBirths/breedingModifier
+			diag.impacts[factorFood] = fullBirths*(preyRatio-1) - deathRate*p*0.5*(1-preyRatio)
+			diag.impacts[factorMortality] = -deathRate * p * 0.5
 		}
 
 		// Apply carrying capacity limit (biome-level)
-		if biome.TotalPopulation() > biome.CarryingCapacity {
-			excess := float64(biome.TotalPopulation() - biome.CarryingCapacity)
+		if capacity := biome.EffectiveCapacity(); biome.TotalPopulation() > capacity {
+			excess := float64(biome.TotalPopulation() - capacity)
 			reduction := excess * float64(oldCount) / float64(biome.TotalPopulation())
+			diag.impacts[factorCarryingCapacity] = -math.Min(reduction, float64(newCount))
 			newCount = int64(math.Max(0, float64(newCount)-reduction))
 		}
 
@@ -921,7 +1041,7 @@ func (ps *PopulationSimulator) simulateBiomeYear(biome *BiomePopulation) {
 		var trophicCapacity int64
 		switch trophicLevel {
 		case TrophicProducer:
-			trophicCapacity = biome.CarryingCapacity // Limited by biome
+			trophicCapacity = biome.EffectiveCapacity() // Limited by biome
 		case TrophicPrimaryConsumer:
 			trophicCapacity = CalculateTrophicCapacity(trophicLevel, floraCount)
 		case TrophicSecondaryConsumer, TrophicApexPredator:
@@ -929,12 +1049,15 @@ func (ps *PopulationSimulator) simulateBiomeYear(biome *BiomePopulation) {
 		}
 		// If this species exceeds its share of trophic capacity, reduce it
 		if trophicCapacity > 0 && newCount > trophicCapacity {
+			diag.impacts[factorTrophicCap] = -float64(newCount - trophicCapacity)
 			newCount = trophicCapacity
 		}
 
 		// Add some randomness
 		variance := float64(newCount) * 0.05
-		newCount += int64(ps.rng.NormFloat64() * variance)
+		noise := int64(ps.rng.NormFloat64() * variance)
+		diag.impacts[factorVariation] = float64(max(noise, -newCount))
+		newCount += noise
 		if newCount < 0 {
 			newCount = 0
 		}
@@ -971,6 +1094,10 @@ func (ps *PopulationSimulator) recordExtinction(biome *BiomePopulation, speciesI
 		ExtinctionCause: cause,
 		FossilBiomes:    []uuid.UUID{biome.BiomeID},
 	}
+	if species.AncestorID != nil {
+		ancestorID := *species.AncestorID
+		extinct.AncestorID = &ancestorID
+	}
 
 	ps.FossilRecord.Extinct = append(ps.FossilRecord.Extinct, extinct)
 }
@@ -995,8 +1122,8 @@ func (ps *PopulationSimulator) SimulateYears(years int64) {
 // ApplyEvolution applies trait drift and selection pressure based on species-specific rates
 // Species with earlier maturity and larger litter sizes evolve faster
 func (ps *PopulationSimulator) ApplyEvolution() {
-	for _, biome := range ps.Biomes {
-		for _, species := range biome.Species {
+	for _, biome := range ps.sortedBiomes() {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count == 0 {
 				continue
 			}
@@ -1051,8 +1178,8 @@ func (ps *PopulationSimulator) ApplyEvolution() {
 func (ps *PopulationSimulator) ApplyGeneticDrift() int {
 	driftEvents := 0
 
-	for _, biome := range ps.Biomes {
-		for _, species := range biome.Species {
+	for _, biome := range ps.sortedBiomes() {
+		for _, species := range biome.sortedSpecies() {
 			if species.Count == 0 {
 				continue
 			}
@@ -1121,12 +1248,12 @@ func (ps *PopulationSimulator) ApplyGeneticDrift() int {
 func (ps *PopulationSimulator) ApplyCoEvolution() int {
 	coevolutionEvents := 0
 
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		// Count populations by trophic level
 		var preyPop, predatorPop int64
 		var preySpecies, predatorSpecies []*SpeciesPopulation
 
-		for _, species := range biome.Species {
+		for _, species := range biome.sortedSpecies() {
 			switch species.Diet {
 			case DietHerbivore:
 				preyPop += species.Count
@@ -1276,12 +1403,16 @@ func (ps *PopulationSimulator) CheckSpeciation() int {
 		}
 	}
 
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		var newSpecies []*SpeciesPopulation
 
-		for _, species := range biome.Species {
+		// Edges between biome types mix populations and drive divergence in
+		// the part of each species' range that lies in ecotone cells
+		ecotoneBonus := EcotoneSpeciationBonus * biome.ecotoneShare()
+
+		for _, species := range biome.sortedSpecies() {
 			// Base speciation chance: 10%
-			speciationChance := 0.1 + adaptiveRadiationBonus
+			speciationChance := 0.1 + adaptiveRadiationBonus + ecotoneBonus
 
 			// Large populations with high variance may speciate
 			if species.Count > 500 && species.TraitVariance > 0.3 && ps.rng.Float64() < speciationChance {
@@ -1290,20 +1421,21 @@ func (ps *PopulationSimulator) CheckSpeciation() int {
 
 				// Generate a proper name based on the new traits
 				newName := GenerateSpeciesName(newTraits, species.Diet, biome.BiomeType)
-				// Prefix with biome type for clarity
-				newName = string(biome.BiomeType) + " " + newName
+				// Prefix with biome type for clarity, then make it unique world-wide
+				newName = ps.UniqueSpeciesName(string(biome.BiomeType)+" "+newName, biome.BiomeType)
 
 				// Split into two species
 				child := &SpeciesPopulation{
-					SpeciesID:     uuid.New(),
-					Name:          newName,
-					AncestorID:    &species.SpeciesID,
-					Count:         species.Count / 3, // 1/3 goes to new species
-					Traits:        newTraits,
-					TraitVariance: species.TraitVariance * 0.8,
-					Diet:          species.Diet,
-					Generation:    species.Generation + 1,
-					CreatedYear:   ps.CurrentYear,
+					SpeciesID:        ps.newSpeciesID(),
+					Name:             newName,
+					AncestorID:       &species.SpeciesID,
+					Count:            species.Count / 3, // 1/3 goes to new species
+					Traits:           newTraits,
+					TraitVariance:    species.TraitVariance * 0.8,
+					GeneticDiversity: founderDiversity(species.GeneticDiversity, species.Count/3),
+					Diet:             species.Diet,
+					Generation:       species.Generation + 1,
+					CreatedYear:      ps.CurrentYear,
 				}
 
 				species.Count -= child.Count
@@ -1423,13 +1555,15 @@ const (
 
 // ApplyExtinctionEvent reduces populations based on event type and severity
 // severity is 0.0-1.0, where 1.0 is catastrophic
+// Events that wreck habitats also degrade biome capacity (see EffectiveCapacity)
 func (ps *PopulationSimulator) ApplyExtinctionEvent(eventType ExtinctionEventType, severity float64) int64 {
 	var totalDeaths int64
 
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		var toExtinct []uuid.UUID
 
-		for speciesID, species := range biome.Species {
+		for _, species := range biome.sortedSpecies() {
+			speciesID := species.SpeciesID
 			if species.Count == 0 {
 				continue
 			}
@@ -1492,6 +1626,7 @@ func (ps *PopulationSimulator) ApplyExtinctionEvent(eventType ExtinctionEventTyp
 			deaths := int64(float64(species.Count) * mortalityRate)
 			species.Count -= deaths
 			totalDeaths += deaths
+			biome.recordLoss(speciesID, ps.CurrentYear, factorEvents, deaths)
 
 			// Check for extinction
 			if species.Count <= 0 {
@@ -1504,6 +1639,9 @@ func (ps *PopulationSimulator) ApplyExtinctionEvent(eventType ExtinctionEventTyp
 		for _, speciesID := range toExtinct {
 			ps.recordExtinction(biome, speciesID, string(eventType))
 		}
+
+		// The survivors inherit a damaged habitat
+		biome.degrade(eventDegradation(eventType, biome.BiomeType) * severity)
 	}
 
 	return totalDeaths
@@ -1,6 +1,7 @@
 package population
 
 import (
+	"math/rand"
 	"testing"
 
 	"tw-backend/internal/worldgen/geography"
@@ -74,6 +75,84 @@ func TestSimulateYears(t *testing.T) {
 	}
 }
 
+// seededWorld builds the same small world for every call, down to the IDs
+func seededWorld(src rand.Source) *PopulationSimulator {
+	id := func(name string) uuid.UUID { return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)) }
+	sim := NewPopulationSimulatorWithSource(id("world"), src)
+
+	for _, bt := range []geography.BiomeType{geography.BiomeGrassland, geography.BiomeDeciduousForest, geography.BiomeRainforest} {
+		biome := NewBiomePopulation(id(string(bt)), bt)
+		for _, diet := range []DietType{DietPhotosynthetic, DietHerbivore, DietCarnivore} {
+			name := string(bt) + " " + string(diet)
+			biome.AddSpecies(&SpeciesPopulation{
+				SpeciesID:     id(name),
+				Name:          name,
+				Count:         2000,
+				Traits:        DefaultTraitsForDiet(diet),
+				TraitVariance: 0.5,
+				Diet:          diet,
+			})
+		}
+		sim.Biomes[biome.BiomeID] = biome
+	}
+	return sim
+}
+
+func TestNewPopulationSimulatorWithSource_Reproducible(t *testing.T) {
+	run := func() (*PopulationSimulator, [3]int64) {
+		sim := seededWorld(rand.NewSource(99))
+		for sim.CurrentYear < 100000 {
+			sim.SimulateYears(1000)
+			sim.ApplyGeneticDrift()
+			sim.ApplyMigrationCycle()
+		}
+		pop, species, extinct := sim.GetStats()
+		return sim, [3]int64{pop, species, extinct}
+	}
+
+	first, firstStats := run()
+	second, secondStats := run()
+
+	if firstStats != secondStats {
+		t.Fatalf("Same source gave different stats: %v vs %v", firstStats, secondStats)
+	}
+	for id, biome := range first.Biomes {
+		other := second.Biomes[id]
+		for speciesID, sp := range biome.Species {
+			twin, ok := other.Species[speciesID]
+			if !ok || twin.Name != sp.Name || twin.Count != sp.Count || twin.Traits != sp.Traits {
+				t.Fatalf("Species %s (%s) differs between runs", sp.Name, speciesID)
+			}
+		}
+	}
+	t.Logf("After 100k years: population %d, %d species, %d extinct", firstStats[0], firstStats[1], firstStats[2])
+}
+
+func TestApplyAgeStructure_ReconcilesYearsChange(t *testing.T) {
+	// ageOneYear runs the age structure for a herbivore tracked as 300
+	// juveniles and 700 adults whose Count the year's dynamics moved to count
+	ageOneYear := func(count int64) *SpeciesPopulation {
+		sim := NewPopulationSimulator(uuid.New(), 1)
+		biome := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+		sp := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Grazer", Diet: DietHerbivore,
+			Traits: DefaultTraitsForDiet(DietHerbivore), Count: count, JuvenileCount: 300, AdultCount: 700}
+		biome.AddSpecies(sp)
+		sim.Biomes[biome.BiomeID] = biome
+		sim.ApplyAgeStructure()
+		return sp
+	}
+	steady, grown, shrunk := ageOneYear(1000), ageOneYear(1500), ageOneYear(500)
+
+	// Births join the juveniles rather than being dropped
+	if grown.Count <= steady.Count {
+		t.Errorf("Births should carry into the age classes: grown %d, steady %d", grown.Count, steady.Count)
+	}
+	// Losses thin the classes instead of being restored from them
+	if shrunk.Count >= steady.Count || shrunk.Count > 500 {
+		t.Errorf("A shrunk population shouldn't regrow from its stale classes, got %d", shrunk.Count)
+	}
+}
+
 func TestApplyEvolution(t *testing.T) {
 	sim := NewPopulationSimulator(uuid.New(), 12345)
 
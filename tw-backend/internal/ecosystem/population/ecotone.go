@@ -0,0 +1,247 @@
+package population
+
+import (
+	"slices"
+
+	"tw-backend/internal/ecosystem/geography"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// EcotoneCapacityBonus is the extra carrying capacity of an ecotone cell
+	// over an interior cell of the same biome
+	EcotoneCapacityBonus = 0.25
+
+	// EcotoneSpeciationBonus is added to a species' speciation chance for
+	// the share of its range that lies in ecotone cells
+	EcotoneSpeciationBonus = 0.1
+
+	// ecotoneMixingChance is the chance, per ecotone cell and neighbouring
+	// species, that founders cross the edge each migration cycle
+	ecotoneMixingChance = 0.05
+
+	// ecotoneColonistShare is the fraction of a neighbouring population's
+	// individuals in one cell that crosses when mixing happens
+	ecotoneColonistShare = 0.5
+)
+
+// EcotoneCell is a cell bordering a biome of a different type. It holds more
+// life than an interior cell, and species from across the edge settle in it.
+type EcotoneCell struct {
+	Coord     geography.HexCoord
+	BiomeID   uuid.UUID
+	Neighbors []uuid.UUID        // Biomes of another type bordering the cell, in ID order
+	Colonists map[uuid.UUID]bool // Neighbouring species that have settled in the cell
+}
+
+// UpdateEcotones finds the ecotone cells from hex grid adjacency and counts
+// each biome's cells. A cell is an ecotone when a neighbouring cell belongs
+// to a biome of a different type; cells that stay ecotones keep their
+// colonists. Returns the number of ecotone cells.
+func (ps *PopulationSimulator) UpdateEcotones() int {
+	for _, biome := range ps.Biomes {
+		biome.Cells, biome.EcotoneCells = 0, 0
+	}
+	previous := make(map[geography.HexCoord]*EcotoneCell, len(ps.Ecotones))
+	for _, cell := range ps.Ecotones {
+		previous[cell.Coord] = cell
+	}
+	ps.Ecotones = nil
+	if ps.HexGrid == nil {
+		return 0
+	}
+
+	for _, cell := range ps.HexGrid.Cells {
+		biome := ps.cellBiome(cell)
+		if biome == nil {
+			continue
+		}
+		biome.Cells++
+
+		adjacent := ps.adjacentForeignBiomes(cell.Coord, biome)
+		if len(adjacent) == 0 {
+			continue
+		}
+		biome.EcotoneCells++
+		neighbors := make(map[uuid.UUID]bool, len(adjacent))
+		for _, other := range adjacent {
+			neighbors[other.BiomeID] = true
+		}
+		ecotone := &EcotoneCell{
+			Coord:     cell.Coord,
+			BiomeID:   biome.BiomeID,
+			Neighbors: sortedIDs(neighbors),
+			Colonists: make(map[uuid.UUID]bool),
+		}
+		if old := previous[cell.Coord]; old != nil && old.BiomeID == biome.BiomeID {
+			ecotone.Colonists = old.Colonists
+		}
+		ps.Ecotones = append(ps.Ecotones, ecotone)
+	}
+
+	// Mixing draws from the rng cell by cell, so visit them in a fixed order
+	slices.SortFunc(ps.Ecotones, func(a, b *EcotoneCell) int {
+		if a.Coord.Q != b.Coord.Q {
+			return a.Coord.Q - b.Coord.Q
+		}
+		return a.Coord.R - b.Coord.R
+	})
+	return len(ps.Ecotones)
+}
+
+// sortedIDs lists a set of IDs in the order sortedBiomes uses, so mixing
+// visits neighbours, and draws from the rng, in the same order every run
+func sortedIDs(set map[uuid.UUID]bool) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, compareIDs)
+	return ids
+}
+
+// IsEcotoneCell reports whether the cell borders a biome of a different type
+func (ps *PopulationSimulator) IsEcotoneCell(coord geography.HexCoord) bool {
+	if ps.HexGrid == nil {
+		return false
+	}
+	cell := ps.HexGrid.GetCell(coord)
+	if cell == nil {
+		return false
+	}
+	biome := ps.cellBiome(cell)
+	return biome != nil && len(ps.adjacentForeignBiomes(coord, biome)) > 0
+}
+
+// CellDiversity counts the living species found in a cell: its own biome's,
+// plus the neighbouring species that have settled there if it is an ecotone
+func (ps *PopulationSimulator) CellDiversity(coord geography.HexCoord) int {
+	if ps.HexGrid == nil {
+		return 0
+	}
+	cell := ps.HexGrid.GetCell(coord)
+	if cell == nil {
+		return 0
+	}
+	biome := ps.cellBiome(cell)
+	if biome == nil {
+		return 0
+	}
+
+	diversity := 0
+	for _, sp := range biome.Species {
+		if sp.Count > 0 {
+			diversity++
+		}
+	}
+	ecotone := ps.ecotoneAt(coord)
+	if ecotone == nil {
+		return diversity
+	}
+	for _, id := range ecotone.Neighbors {
+		source := ps.Biomes[id]
+		if source == nil {
+			continue
+		}
+		for speciesID := range ecotone.Colonists {
+			if sp := source.Species[speciesID]; sp != nil && sp.Count > 0 {
+				diversity++
+			}
+		}
+	}
+	return diversity
+}
+
+// ApplyEcotoneMixing lets founders from neighbouring biome types cross into
+// each ecotone cell, settling in the cell and joining its biome's population.
+// Returns the number of individuals that crossed.
+func (ps *PopulationSimulator) ApplyEcotoneMixing() int64 {
+	var total int64
+	for _, cell := range ps.Ecotones {
+		biome := ps.Biomes[cell.BiomeID]
+		if biome == nil {
+			continue
+		}
+		for _, id := range cell.Neighbors {
+			source := ps.Biomes[id]
+			if source == nil || source.Cells == 0 || !AreBiomesCompatible(source.BiomeType, biome.BiomeType) {
+				continue
+			}
+			// A cell's worth of the neighbouring population is within reach
+			share := ecotoneColonistShare / float64(source.Cells)
+			for _, sp := range source.sortedSpecies() {
+				speciesID := sp.SpeciesID
+				if sp.Count > 0 && ps.rng.Float64() < ecotoneMixingChance {
+					if crossed := migrateSpecies(source, biome, speciesID, share, ps.newSpeciesID); crossed > 0 {
+						total += crossed
+						cell.Colonists[speciesID] = true
+					}
+				}
+			}
+		}
+	}
+	return total
+}
+
+// EffectiveCapacity returns the carrying capacity summed over the biome's
+// cells, each ecotone cell holding EcotoneCapacityBonus more than an
+// interior one, less any productivity lost to catastrophes (see Degradation)
+func (bp *BiomePopulation) EffectiveCapacity() int64 {
+	capacity := float64(bp.CarryingCapacity)
+	if bp.Cells > 0 {
+		perCell := capacity / float64(bp.Cells)
+		capacity += perCell * EcotoneCapacityBonus * float64(bp.EcotoneCells)
+	}
+	return int64(capacity * (1 - bp.Degradation))
+}
+
+// ecotoneShare is the share of the biome's cells, and so of its species'
+// range, that lies in ecotone cells
+func (bp *BiomePopulation) ecotoneShare() float64 {
+	if bp.Cells == 0 {
+		return 0
+	}
+	return float64(bp.EcotoneCells) / float64(bp.Cells)
+}
+
+// ecotoneAt returns the ecotone cell at coord, or nil if the cell is interior
+func (ps *PopulationSimulator) ecotoneAt(coord geography.HexCoord) *EcotoneCell {
+	for _, cell := range ps.Ecotones {
+		if cell.Coord == coord {
+			return cell
+		}
+	}
+	return nil
+}
+
+// cellBiome returns the biome population a cell belongs to, if any
+func (ps *PopulationSimulator) cellBiome(cell *geography.HexCell) *BiomePopulation {
+	if cell.BiomeID == nil {
+		return nil
+	}
+	return ps.Biomes[*cell.BiomeID]
+}
+
+// adjacentForeignBiomes returns the distinct biomes of a different type that
+// border the cell
+func (ps *PopulationSimulator) adjacentForeignBiomes(coord geography.HexCoord, biome *BiomePopulation) []*BiomePopulation {
+	var foreign []*BiomePopulation
+	for _, n := range ps.HexGrid.GetNeighbors(coord) {
+		other := ps.cellBiome(n)
+		if other == nil || other.BiomeType == biome.BiomeType {
+			continue
+		}
+		seen := false
+		for _, f := range foreign {
+			if f == other {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			foreign = append(foreign, other)
+		}
+	}
+	return foreign
+}
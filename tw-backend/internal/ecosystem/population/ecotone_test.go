@@ -0,0 +1,221 @@
+package population
+
+import (
+	"maps"
+	"math/rand"
+	"testing"
+
+	ecogeography "tw-backend/internal/ecosystem/geography"
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+// newEcotoneSim lays out a width x height grid whose columns are split into
+// strips of stripWidth cells, alternating grassland and desert biomes. The
+// biome IDs follow the seed, so equally seeded simulators are identical.
+func newEcotoneSim(seed int64, width, height, stripWidth int) *PopulationSimulator {
+	ids := rand.New(rand.NewSource(seed))
+	sim := NewPopulationSimulator(uuid.New(), seed)
+	sim.HexGrid = ecogeography.NewHexGrid(uuid.New(), width, height, 1.0)
+
+	strips := make([]*BiomePopulation, 0)
+	for q := 0; q < width; q += stripWidth {
+		biomeType := geography.BiomeGrassland
+		if len(strips)%2 == 1 {
+			biomeType = geography.BiomeDesert
+		}
+		biome := NewBiomePopulation(newSpeciesID(ids), biomeType)
+		sim.Biomes[biome.BiomeID] = biome
+		strips = append(strips, biome)
+	}
+
+	for q := 0; q < width; q++ {
+		for r := 0; r < height; r++ {
+			cell := ecogeography.NewHexCell(ecogeography.HexCoord{Q: q, R: r}, ecogeography.TerrainPlains, 0.5)
+			cell.BiomeID = &strips[q/stripWidth].BiomeID
+			sim.HexGrid.SetCell(cell)
+		}
+	}
+	sim.UpdateEcotones()
+	return sim
+}
+
+func addGrazer(biome *BiomePopulation, name string) *SpeciesPopulation {
+	return addGrazerWithID(biome, uuid.New(), name)
+}
+
+func addGrazerWithID(biome *BiomePopulation, id uuid.UUID, name string) *SpeciesPopulation {
+	sp := &SpeciesPopulation{
+		SpeciesID:     id,
+		Name:          name,
+		Count:         1000,
+		Diet:          DietHerbivore,
+		Traits:        DefaultTraitsForDiet(DietHerbivore),
+		TraitVariance: 0.5,
+	}
+	biome.AddSpecies(sp)
+	return sp
+}
+
+func TestUpdateEcotones_EdgeCells(t *testing.T) {
+	sim := newEcotoneSim(1, 20, 10, 10) // Two halves, one shared edge
+
+	for _, biome := range sim.Biomes {
+		if biome.Cells != 100 {
+			t.Errorf("%s covers %d cells, want 100", biome.BiomeType, biome.Cells)
+		}
+		if biome.EcotoneCells <= 0 || biome.EcotoneCells >= biome.Cells/2 {
+			t.Errorf("%s has %d ecotone cells, want a thin edge", biome.BiomeType, biome.EcotoneCells)
+		}
+	}
+	edge := sim.ecotoneAt(ecogeography.HexCoord{Q: 9, R: 5})
+	if edge == nil || len(edge.Neighbors) != 1 {
+		t.Fatal("Cell on the boundary should be an ecotone bordering one other biome")
+	}
+	if !sim.IsEcotoneCell(ecogeography.HexCoord{Q: 9, R: 5}) {
+		t.Error("Cell on the boundary should be an ecotone")
+	}
+	if sim.IsEcotoneCell(ecogeography.HexCoord{Q: 3, R: 5}) {
+		t.Error("Cell deep inside a biome should not be an ecotone")
+	}
+}
+
+func TestUpdateEcotones_SameTypeIsNotAnEdge(t *testing.T) {
+	sim := newEcotoneSim(1, 20, 10, 10)
+	for _, biome := range sim.Biomes {
+		biome.BiomeType = geography.BiomeGrassland
+	}
+
+	if n := sim.UpdateEcotones(); n != 0 {
+		t.Errorf("Adjacent biomes of one type should form no ecotone, got %d cells", n)
+	}
+}
+
+func TestCellDiversity_EcotoneExceedsInterior(t *testing.T) {
+	sim := newEcotoneSim(1, 20, 10, 10)
+	for _, biome := range sim.Biomes {
+		if biome.BiomeType == geography.BiomeGrassland {
+			addGrazer(biome, "Prairie Grazer")
+			addGrazer(biome, "Prairie Browser")
+		} else {
+			addGrazer(biome, "Dune Grazer")
+		}
+	}
+	edgeCoord := ecogeography.HexCoord{Q: 10, R: 5}     // Desert side of the boundary
+	interiorCoord := ecogeography.HexCoord{Q: 16, R: 5} // Deep in the desert
+
+	if edge, interior := sim.CellDiversity(edgeCoord), sim.CellDiversity(interiorCoord); edge != interior {
+		t.Fatalf("Before any mixing the edge (%d) and interior (%d) should hold the same species", edge, interior)
+	}
+
+	for i := 0; i < 50; i++ {
+		sim.ApplyEcotoneMixing()
+	}
+
+	edge := sim.CellDiversity(edgeCoord)
+	interior := sim.CellDiversity(interiorCoord)
+	if edge <= interior {
+		t.Errorf("Ecotone diversity %d should exceed interior diversity %d once species mix", edge, interior)
+	}
+}
+
+func TestEffectiveCapacity_EcotoneBonus(t *testing.T) {
+	sim := newEcotoneSim(1, 20, 10, 1) // Every column is its own biome
+
+	for _, biome := range sim.Biomes {
+		want := int64(float64(biome.CarryingCapacity) * (1 + EcotoneCapacityBonus))
+		if biome.EffectiveCapacity() != want {
+			t.Errorf("All-edge %s should gain the bonus on every cell: %d, want %d",
+				biome.BiomeType, biome.EffectiveCapacity(), want)
+		}
+	}
+
+	wide := newEcotoneSim(1, 20, 10, 10) // Only the cells along the boundary gain
+	for _, biome := range wide.Biomes {
+		perCell := float64(biome.CarryingCapacity) / float64(biome.Cells)
+		want := int64(float64(biome.CarryingCapacity) + perCell*EcotoneCapacityBonus*float64(biome.EcotoneCells))
+		if biome.EffectiveCapacity() != want {
+			t.Errorf("%s capacity %d, want %d from its %d ecotone cells",
+				biome.BiomeType, biome.EffectiveCapacity(), want, biome.EcotoneCells)
+		}
+	}
+}
+
+func TestApplyEcotoneMixing_SeedsNeighbourSpecies(t *testing.T) {
+	sim := newEcotoneSim(3, 20, 10, 1)
+	for _, biome := range sim.Biomes {
+		if biome.BiomeType == geography.BiomeGrassland {
+			addGrazer(biome, "Prairie Grazer")
+		}
+	}
+
+	var crossed int64
+	for i := 0; i < 50; i++ {
+		crossed += sim.ApplyEcotoneMixing()
+	}
+	if crossed == 0 {
+		t.Fatal("Founders should cross ecotone edges")
+	}
+	colonized := false
+	for _, biome := range sim.Biomes {
+		if biome.BiomeType == geography.BiomeDesert && len(biome.Species) > 0 {
+			colonized = true
+		}
+	}
+	if !colonized {
+		t.Error("Desert strips should receive grassland species across the edge")
+	}
+}
+
+func TestApplyEcotoneMixing_ReproducibleFromSeed(t *testing.T) {
+	run := func() map[uuid.UUID]int64 {
+		sim := newEcotoneSim(5, 20, 10, 1)
+		ids := rand.New(rand.NewSource(5))
+		for _, biome := range sim.sortedBiomes() {
+			if biome.BiomeType == geography.BiomeGrassland {
+				addGrazerWithID(biome, newSpeciesID(ids), "Prairie Grazer")
+				addGrazerWithID(biome, newSpeciesID(ids), "Dune Runner")
+			}
+		}
+		for i := 0; i < 50; i++ {
+			sim.ApplyEcotoneMixing()
+		}
+		counts := make(map[uuid.UUID]int64)
+		for id, biome := range sim.Biomes {
+			counts[id] = biome.TotalPopulation()
+		}
+		return counts
+	}
+
+	first := run()
+	for i := 0; i < 5; i++ {
+		if got := run(); !maps.Equal(first, got) {
+			t.Fatalf("Run %d mixed differently from the first with the same seed", i+2)
+		}
+	}
+}
+
+func TestCheckSpeciation_HighEdgeRatioSpeciatesMore(t *testing.T) {
+	// Both worlds hold eight eligible populations; only the layout differs
+	speciate := func(stripWidth, perBiome int) int {
+		total := 0
+		for trial := 0; trial < 200; trial++ {
+			sim := newEcotoneSim(int64(trial+1), 16, 8, stripWidth)
+			for _, biome := range sim.Biomes {
+				for i := 0; i < perBiome; i++ {
+					addGrazer(biome, "Grazer")
+				}
+			}
+			total += sim.CheckSpeciation()
+		}
+		return total
+	}
+
+	fragmented := speciate(2, 1) // Eight narrow strips, all edge
+	coarse := speciate(8, 4)     // Two wide halves, thin edge
+
+	if fragmented <= coarse {
+		t.Errorf("Many small biomes should out-speciate few large ones: %d <= %d", fragmented, coarse)
+	}
+}
@@ -1,9 +1,9 @@
 package population
 
 import (
-	"tw-backend/internal/worldgen/geography"
+	"math/rand"
 
-	"github.com/google/uuid"
+	"tw-backend/internal/worldgen/geography"
 )
 
 // EpochType represents geological epochs for simulation starting conditions
@@ -40,8 +40,9 @@ const (
 	GoalVenom          EvolutionGoal = "venom"
 )
 
-// InitializeFromEpoch creates species appropriate for the given epoch and biome
-func InitializeFromEpoch(epoch EpochType, biome geography.BiomeType) []*SpeciesPopulation {
+// InitializeFromEpoch creates species appropriate for the given epoch and
+// biome. Their IDs are drawn from rng.
+func InitializeFromEpoch(epoch EpochType, biome geography.BiomeType, rng *rand.Rand) []*SpeciesPopulation {
 	var species []*SpeciesPopulation
 
 	switch epoch {
@@ -52,55 +53,55 @@ func InitializeFromEpoch(epoch EpochType, biome geography.BiomeType) []*SpeciesP
 	case EpochArchean:
 		// Only primitive single-cell life in oceans
 		if biome == geography.BiomeOcean {
-			species = append(species, createPrimitiveLife(biome))
+			species = append(species, createPrimitiveLife(biome, rng))
 		}
 
 	case EpochProterozoic:
 		// Multicellular life, mostly ocean
 		if biome == geography.BiomeOcean {
-			species = append(species, createSimpleFlora(biome))
-			species = append(species, createSimpleFauna(biome, DietHerbivore))
+			species = append(species, createSimpleFlora(biome, rng))
+			species = append(species, createSimpleFauna(biome, DietHerbivore, rng))
 		}
 
 	case EpochCambrian:
 		// Marine explosion - diverse ocean life
 		if biome == geography.BiomeOcean {
-			species = append(species, createDiverseMarineLife(biome)...)
+			species = append(species, createDiverseMarineLife(biome, rng)...)
 		}
 
 	case EpochDevonian:
 		// Age of fish, first land plants
 		if biome == geography.BiomeOcean {
-			species = append(species, createDiverseMarineLife(biome)...)
+			species = append(species, createDiverseMarineLife(biome, rng)...)
 		} else {
-			species = append(species, createSimpleFlora(biome))
+			species = append(species, createSimpleFlora(biome, rng))
 		}
 
 	case EpochCarboniferous:
 		// Giant insects, swamp flora, early reptiles
-		species = append(species, createCarboniferousLife(biome)...)
+		species = append(species, createCarboniferousLife(biome, rng)...)
 
 	case EpochTriassic, EpochJurassic, EpochCretaceous:
 		// Dinosaur ages
-		species = append(species, createMesozoicLife(epoch, biome)...)
+		species = append(species, createMesozoicLife(epoch, biome, rng)...)
 
 	case EpochCenozoic:
 		// Mammals dominate - modern ecosystem
-		species = append(species, createCenozoicLife(biome)...)
+		species = append(species, createCenozoicLife(biome, rng)...)
 	}
 
 	return species
 }
 
 // createPrimitiveLife creates single-cell organisms
-func createPrimitiveLife(biome geography.BiomeType) *SpeciesPopulation {
+func createPrimitiveLife(biome geography.BiomeType, rng *rand.Rand) *SpeciesPopulation {
 	traits := EvolvableTraits{
 		Size: 0.01, Speed: 0.1, Strength: 0.1,
 		Fertility: 3.0, Lifespan: 0.1, Maturity: 0.01, LitterSize: 1000,
 		Covering: CoveringNone, FloraGrowth: FloraAquatic,
 	}
 	return &SpeciesPopulation{
-		SpeciesID:     uuid.New(),
+		SpeciesID:     newSpeciesID(rng),
 		Name:          "Primitive Microbe",
 		Count:         10000,
 		Traits:        traits,
@@ -112,13 +113,13 @@ func createPrimitiveLife(biome geography.BiomeType) *SpeciesPopulation {
 }
 
 // createSimpleFlora creates basic plant life
-func createSimpleFlora(biome geography.BiomeType) *SpeciesPopulation {
+func createSimpleFlora(biome geography.BiomeType, rng *rand.Rand) *SpeciesPopulation {
 	traits := DefaultTraitsForDiet(DietPhotosynthetic)
 	traits.Size = 0.5
 	traits.FloraGrowth = GetFloraGrowthForBiome(biome)
 	traits.Covering = GetCoveringForDiet(DietPhotosynthetic, biome)
 	return &SpeciesPopulation{
-		SpeciesID:     uuid.New(),
+		SpeciesID:     newSpeciesID(rng),
 		Name:          GenerateSpeciesName(traits, DietPhotosynthetic, biome),
 		Count:         1000,
 		Traits:        traits,
@@ -130,12 +131,12 @@ func createSimpleFlora(biome geography.BiomeType) *SpeciesPopulation {
 }
 
 // createSimpleFauna creates basic animal life
-func createSimpleFauna(biome geography.BiomeType, diet DietType) *SpeciesPopulation {
+func createSimpleFauna(biome geography.BiomeType, diet DietType, rng *rand.Rand) *SpeciesPopulation {
 	traits := DefaultTraitsForDiet(diet)
 	traits.Size = 0.3
 	traits.Covering = GetCoveringForDiet(diet, biome)
 	return &SpeciesPopulation{
-		SpeciesID:     uuid.New(),
+		SpeciesID:     newSpeciesID(rng),
 		Name:          GenerateSpeciesName(traits, diet, biome),
 		Count:         500,
 		Traits:        traits,
@@ -147,18 +148,18 @@ func createSimpleFauna(biome geography.BiomeType, diet DietType) *SpeciesPopulat
 }
 
 // createDiverseMarineLife creates Cambrian-style ocean life
-func createDiverseMarineLife(biome geography.BiomeType) []*SpeciesPopulation {
+func createDiverseMarineLife(biome geography.BiomeType, rng *rand.Rand) []*SpeciesPopulation {
 	var species []*SpeciesPopulation
 
 	// Algae/kelp
-	species = append(species, createSimpleFlora(biome))
+	species = append(species, createSimpleFlora(biome, rng))
 
 	// Various marine invertebrates
 	herbTraits := DefaultTraitsForDiet(DietHerbivore)
 	herbTraits.Size = 0.5
 	herbTraits.Covering = CoveringShell
 	species = append(species, &SpeciesPopulation{
-		SpeciesID:     uuid.New(),
+		SpeciesID:     newSpeciesID(rng),
 		Name:          "Armored Grazer",
 		Count:         800,
 		Traits:        herbTraits,
@@ -171,7 +172,7 @@ func createDiverseMarineLife(biome geography.BiomeType) []*SpeciesPopulation {
 	carnTraits.Size = 1.0
 	carnTraits.Covering = CoveringShell
 	species = append(species, &SpeciesPopulation{
-		SpeciesID:     uuid.New(),
+		SpeciesID:     newSpeciesID(rng),
 		Name:          "Small Armored Hunter",
 		Count:         200,
 		Traits:        carnTraits,
@@ -183,7 +184,7 @@ func createDiverseMarineLife(biome geography.BiomeType) []*SpeciesPopulation {
 }
 
 // createCarboniferousLife creates coal age life
-func createCarboniferousLife(biome geography.BiomeType) []*SpeciesPopulation {
+func createCarboniferousLife(biome geography.BiomeType, rng *rand.Rand) []*SpeciesPopulation {
 	var species []*SpeciesPopulation
 
 	// Giant flora
@@ -192,7 +193,7 @@ func createCarboniferousLife(biome geography.BiomeType) []*SpeciesPopulation {
 	floraTraits.FloraGrowth = FloraPerennial
 	floraTraits.Covering = CoveringBark
 	species = append(species, &SpeciesPopulation{
-		SpeciesID:     uuid.New(),
+		SpeciesID:     newSpeciesID(rng),
 		Name:          "Towering Hardy Tree",
 		Count:         500,
 		Traits:        floraTraits,
@@ -206,7 +207,7 @@ func createCarboniferousLife(biome geography.BiomeType) []*SpeciesPopulation {
 		herbTraits.Size = 2.0
 		herbTraits.Covering = CoveringShell
 		species = append(species, &SpeciesPopulation{
-			SpeciesID:     uuid.New(),
+			SpeciesID:     newSpeciesID(rng),
 			Name:          "Large Armored Grazer",
 			Count:         300,
 			Traits:        herbTraits,
@@ -219,7 +220,7 @@ func createCarboniferousLife(biome geography.BiomeType) []*SpeciesPopulation {
 		carnTraits.Size = 1.5
 		carnTraits.Covering = CoveringScales
 		species = append(species, &SpeciesPopulation{
-			SpeciesID:     uuid.New(),
+			SpeciesID:     newSpeciesID(rng),
 			Name:          "Swift Scaled Hunter",
 			Count:         100,
 			Traits:        carnTraits,
@@ -232,7 +233,7 @@ func createCarboniferousLife(biome geography.BiomeType) []*SpeciesPopulation {
 }
 
 // createMesozoicLife creates dinosaur-age life
-func createMesozoicLife(epoch EpochType, biome geography.BiomeType) []*SpeciesPopulation {
+func createMesozoicLife(epoch EpochType, biome geography.BiomeType, rng *rand.Rand) []*SpeciesPopulation {
 	var species []*SpeciesPopulation
 
 	// Flora
@@ -241,7 +242,7 @@ func createMesozoicLife(epoch EpochType, biome geography.BiomeType) []*SpeciesPo
 	floraTraits.FloraGrowth = GetFloraGrowthForBiome(biome)
 	floraTraits.Covering = CoveringBark
 	species = append(species, &SpeciesPopulation{
-		SpeciesID:     uuid.New(),
+		SpeciesID:     newSpeciesID(rng),
 		Name:          GenerateSpeciesName(floraTraits, DietPhotosynthetic, biome),
 		Count:         600,
 		Traits:        floraTraits,
@@ -256,7 +257,7 @@ func createMesozoicLife(epoch EpochType, biome geography.BiomeType) []*SpeciesPo
 		herbTraits.Covering = CoveringScales
 		herbTraits.Social = 0.8
 		species = append(species, &SpeciesPopulation{
-			SpeciesID:     uuid.New(),
+			SpeciesID:     newSpeciesID(rng),
 			Name:          "Giant Herd Scaled Grazer",
 			Count:         200,
 			Traits:        herbTraits,
@@ -270,7 +271,7 @@ func createMesozoicLife(epoch EpochType, biome geography.BiomeType) []*SpeciesPo
 		carnTraits.Speed = 7.0
 		carnTraits.Covering = CoveringScales
 		species = append(species, &SpeciesPopulation{
-			SpeciesID:     uuid.New(),
+			SpeciesID:     newSpeciesID(rng),
 			Name:          "Massive Swift Scaled Hunter",
 			Count:         50,
 			Traits:        carnTraits,
@@ -285,7 +286,7 @@ func createMesozoicLife(epoch EpochType, biome geography.BiomeType) []*SpeciesPo
 			birdTraits.Speed = 8.0
 			birdTraits.Covering = CoveringFeathers
 			species = append(species, &SpeciesPopulation{
-				SpeciesID:     uuid.New(),
+				SpeciesID:     newSpeciesID(rng),
 				Name:          "Small Swift Feathered Forager",
 				Count:         150,
 				Traits:        birdTraits,
@@ -299,7 +300,7 @@ func createMesozoicLife(epoch EpochType, biome geography.BiomeType) []*SpeciesPo
 }
 
 // createCenozoicLife creates modern mammal-dominated ecosystem
-func createCenozoicLife(biome geography.BiomeType) []*SpeciesPopulation {
+func createCenozoicLife(biome geography.BiomeType, rng *rand.Rand) []*SpeciesPopulation {
 	var species []*SpeciesPopulation
 
 	// Flora
@@ -308,7 +309,7 @@ func createCenozoicLife(biome geography.BiomeType) []*SpeciesPopulation {
 	floraTraits.FloraGrowth = GetFloraGrowthForBiome(biome)
 	floraTraits.Covering = GetCoveringForDiet(DietPhotosynthetic, biome)
 	species = append(species, &SpeciesPopulation{
-		SpeciesID:     uuid.New(),
+		SpeciesID:     newSpeciesID(rng),
 		Name:          GenerateSpeciesName(floraTraits, DietPhotosynthetic, biome),
 		Count:         700,
 		Traits:        floraTraits,
@@ -324,7 +325,7 @@ func createCenozoicLife(biome geography.BiomeType) []*SpeciesPopulation {
 		herbTraits.Intelligence = 0.4
 		herbTraits.Covering = CoveringFur
 		species = append(species, &SpeciesPopulation{
-			SpeciesID:     uuid.New(),
+			SpeciesID:     newSpeciesID(rng),
 			Name:          "Large Herd Woolly Grazer",
 			Count:         300,
 			Traits:        herbTraits,
@@ -340,7 +341,7 @@ func createCenozoicLife(biome geography.BiomeType) []*SpeciesPopulation {
 		carnTraits.Intelligence = 0.6
 		carnTraits.Covering = CoveringFur
 		species = append(species, &SpeciesPopulation{
-			SpeciesID:     uuid.New(),
+			SpeciesID:     newSpeciesID(rng),
 			Name:          "Large Swift Pack Woolly Hunter",
 			Count:         80,
 			Traits:        carnTraits,
@@ -353,7 +354,7 @@ func createCenozoicLife(biome geography.BiomeType) []*SpeciesPopulation {
 		herbTraits.Size = 4.0
 		herbTraits.Covering = CoveringSkin
 		species = append(species, &SpeciesPopulation{
-			SpeciesID:     uuid.New(),
+			SpeciesID:     newSpeciesID(rng),
 			Name:          "Large Smooth Grazer",
 			Count:         200,
 			Traits:        herbTraits,
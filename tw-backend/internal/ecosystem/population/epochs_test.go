@@ -1,6 +1,8 @@
 package population
 
 import (
+	"math/rand"
+	"slices"
 	"testing"
 
 	"tw-backend/internal/worldgen/geography"
@@ -23,26 +25,47 @@ func TestEpochType(t *testing.T) {
 }
 
 func TestInitializeFromEpoch_Hadean(t *testing.T) {
-	species := InitializeFromEpoch(EpochHadean, geography.BiomeOcean)
+	species := InitializeFromEpoch(EpochHadean, geography.BiomeOcean, rand.New(rand.NewSource(1)))
 	if len(species) != 0 {
 		t.Errorf("Hadean epoch should have no life, got %d species", len(species))
 	}
 }
 
 func TestInitializeFromEpoch_Archean(t *testing.T) {
-	species := InitializeFromEpoch(EpochArchean, geography.BiomeOcean)
+	species := InitializeFromEpoch(EpochArchean, geography.BiomeOcean, rand.New(rand.NewSource(1)))
 	if len(species) == 0 {
 		t.Error("Archean ocean should have primitive life")
 	}
 	// Only ocean should have life
-	landSpecies := InitializeFromEpoch(EpochArchean, geography.BiomeDesert)
+	landSpecies := InitializeFromEpoch(EpochArchean, geography.BiomeDesert, rand.New(rand.NewSource(1)))
 	if len(landSpecies) != 0 {
 		t.Error("Archean land should have no life")
 	}
 }
 
+func TestInitializeFromEpoch_IDsFollowTheSeed(t *testing.T) {
+	ids := func(seed int64) []string {
+		var out []string
+		for _, sp := range InitializeFromEpoch(EpochCenozoic, geography.BiomeGrassland, rand.New(rand.NewSource(seed))) {
+			out = append(out, sp.SpeciesID.String())
+		}
+		return out
+	}
+
+	first := ids(7)
+	if len(first) == 0 {
+		t.Fatal("Cenozoic grassland should have species")
+	}
+	if got := ids(7); !slices.Equal(got, first) {
+		t.Errorf("Same seed gave different species IDs: %v vs %v", got, first)
+	}
+	if got := ids(8); slices.Equal(got, first) {
+		t.Error("Different seeds should give different species IDs")
+	}
+}
+
 func TestInitializeFromEpoch_Cambrian(t *testing.T) {
-	species := InitializeFromEpoch(EpochCambrian, geography.BiomeOcean)
+	species := InitializeFromEpoch(EpochCambrian, geography.BiomeOcean, rand.New(rand.NewSource(1)))
 	if len(species) < 3 {
 		t.Errorf("Cambrian ocean should have multiple species, got %d", len(species))
 	}
@@ -61,7 +84,7 @@ func TestInitializeFromEpoch_Cambrian(t *testing.T) {
 }
 
 func TestInitializeFromEpoch_Cenozoic(t *testing.T) {
-	species := InitializeFromEpoch(EpochCenozoic, geography.BiomeGrassland)
+	species := InitializeFromEpoch(EpochCenozoic, geography.BiomeGrassland, rand.New(rand.NewSource(1)))
 	if len(species) < 3 {
 		t.Errorf("Cenozoic grassland should have rich ecosystem, got %d", len(species))
 	}
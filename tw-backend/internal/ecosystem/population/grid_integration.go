@@ -32,6 +32,9 @@ func (sim *PopulationSimulator) InitializeGeographicSystems(worldID uuid.UUID, s
 
 	// Identify regions from connected landmasses
 	sim.RegionSystem.IdentifyRegions(sim.HexGrid)
+
+	// Find the edges between biome types
+	sim.UpdateEcotones()
 }
 
 // mapBiomesToGrid assigns biomes to hex cells on the grid
@@ -93,6 +96,9 @@ func (sim *PopulationSimulator) UpdateGeographicSystems(years int64) {
 
 	// Update continental fragmentation from tectonics
 	sim.ContinentalFragmentation = float64(sim.Tectonics.CalculateFragmentation())
+
+	// Biome transitions may have moved the edges between biome types
+	sim.UpdateEcotones()
 }
 
 // ApplyIsolationEffects applies island effects (gigantism/dwarfism) to isolated populations
@@ -218,6 +224,12 @@ func (sim *PopulationSimulator) ApplyRegionalMigration() int {
 								}
 								targetBiome.Species[sp.SpeciesID] = newPop
 								sp.Count -= migrants
+
+								// Founders compete with residents; failed colonies vanish
+								if !ResolveInvasion(targetBiome, newPop, migrants).Established {
+									delete(targetBiome.Species, sp.SpeciesID)
+									break
+								}
 								migrations++
 								break
 							}
@@ -0,0 +1,117 @@
+package population
+
+import "math"
+
+const (
+	// DiversityMutationRate is the share of lost heterozygosity mutation
+	// restores each generation. Against drift, which strips 1/(2N) a
+	// generation, it settles a population of N at about 4Nμ/(1+4Nμ): 0.17
+	// for 50 individuals, 0.9 for 2000.
+	DiversityMutationRate = 0.001
+
+	// CriticalGeneticDiversity is the heterozygosity below which inbreeding
+	// depression sets in
+	CriticalGeneticDiversity = 0.2
+
+	// MinGeneticDiversity keeps a tracked species' diversity above zero, so
+	// zero still means "not yet tracked"
+	MinGeneticDiversity = 0.01
+
+	// MaxInbreedingPenalty is the share of fertility (and, separately, of
+	// juvenile survival) lost when diversity is all but gone
+	MaxInbreedingPenalty = 0.7
+)
+
+// InbreedingDepression returns the fitness multiplier (0.3-1) for a species
+// with the given genetic diversity: full fitness down to
+// CriticalGeneticDiversity, falling linearly below it
+func InbreedingDepression(diversity float64) float64 {
+	if diversity >= CriticalGeneticDiversity {
+		return 1.0
+	}
+	deficit := 1 - math.Max(0, diversity)/CriticalGeneticDiversity
+	return 1 - MaxInbreedingPenalty*deficit
+}
+
+// ApplyInbreedingDepression updates every animal species' genetic diversity
+// for the year and culls the young of those too inbred to breed well.
+// Diversity drains by 1/(2N) each generation and mutation only slowly
+// refills it, so a species held small for long loses heterozygosity; once
+// it falls below CriticalGeneticDiversity, fertility and juvenile survival
+// both drop, which keeps the population small: an extinction vortex that
+// growth alone can't escape. Fresh blood from migrants restores diversity
+// (see MigrateSpecies). Returns the number of species suffering depression.
+func (ps *PopulationSimulator) ApplyInbreedingDepression() int {
+	depressed := 0
+	for _, biome := range ps.sortedBiomes() {
+		for _, species := range biome.sortedSpecies() {
+			if species.Count == 0 || species.Diet == DietPhotosynthetic {
+				continue // Flora don't have juveniles in this model
+			}
+			species.updateGeneticDiversity()
+
+			fitness := InbreedingDepression(species.GeneticDiversity)
+			if fitness >= 1 {
+				continue
+			}
+			depressed++
+
+			// The year's births are what the age classes don't yet hold
+			// (see ApplyAgeStructure): fewer are born, and fewer of those
+			// survive
+			tracked := species.JuvenileCount + species.AdultCount
+			if births := species.Count - tracked; tracked > 0 && births > 0 {
+				lost := births - int64(float64(births)*fitness*fitness)
+				species.Count -= lost
+				biome.recordLoss(species.SpeciesID, ps.CurrentYear, factorInbreeding, lost)
+			}
+		}
+	}
+	return depressed
+}
+
+// updateGeneticDiversity applies a year of drift and mutation to the
+// species' heterozygosity, starting untracked species at full diversity
+func (sp *SpeciesPopulation) updateGeneticDiversity() {
+	if sp.GeneticDiversity == 0 {
+		sp.GeneticDiversity = 1.0
+	}
+	generations := CalculateMaturationRate(sp.Traits.Maturity)
+	h := sp.GeneticDiversity
+	drift := h * generations / (2 * float64(max(sp.Count, 1)))
+	mutation := DiversityMutationRate * generations * (1 - h)
+	sp.GeneticDiversity = math.Max(MinGeneticDiversity, math.Min(1, h-drift+mutation))
+}
+
+// mixGeneticDiversity returns the diversity of residents after migrants
+// join them. Populations that drifted apart lost different alleles, so
+// mixing them restores more than the weighted average: a genetic rescue
+// largest when the two groups are of similar size.
+func mixGeneticDiversity(residents, migrants int64, residentDiversity, migrantDiversity float64) float64 {
+	if residentDiversity == 0 && migrantDiversity == 0 {
+		return 0 // Still untracked
+	}
+	if residentDiversity == 0 {
+		residentDiversity = 1.0
+	}
+	if migrantDiversity == 0 {
+		migrantDiversity = 1.0
+	}
+	total := residents + migrants
+	if total <= 0 {
+		return residentDiversity
+	}
+	share := float64(migrants) / float64(total)
+	mixed := residentDiversity*(1-share) + migrantDiversity*share
+	rescue := 2 * share * (1 - share) * (1 - mixed)
+	return math.Min(1, mixed+rescue)
+}
+
+// founderDiversity returns the diversity a new population of founders
+// carries from its source: the founders hold only part of its alleles
+func founderDiversity(sourceDiversity float64, founders int64) float64 {
+	if sourceDiversity == 0 {
+		return 0
+	}
+	return math.Max(MinGeneticDiversity, sourceDiversity*(1-1/(2*float64(max(founders, 1)))))
+}
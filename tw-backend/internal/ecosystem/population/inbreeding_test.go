@@ -0,0 +1,92 @@
+package population
+
+import (
+	"math"
+	"testing"
+
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+func TestInbreedingDepression(t *testing.T) {
+	if got := InbreedingDepression(CriticalGeneticDiversity); got != 1 {
+		t.Errorf("No depression at the critical diversity, got %f", got)
+	}
+	if got := InbreedingDepression(0); math.Abs(got-(1-MaxInbreedingPenalty)) > 1e-9 {
+		t.Errorf("Expected the full %f penalty with no diversity, got %f", MaxInbreedingPenalty, got)
+	}
+	if InbreedingDepression(0.05) >= InbreedingDepression(0.15) {
+		t.Error("Less diversity should mean more depression")
+	}
+}
+
+func TestUpdateGeneticDiversity(t *testing.T) {
+	small := &SpeciesPopulation{Count: 20, Traits: DefaultTraitsForDiet(DietHerbivore)}
+	large := &SpeciesPopulation{Count: 5000, Traits: DefaultTraitsForDiet(DietHerbivore)}
+	for i := 0; i < 200; i++ {
+		small.updateGeneticDiversity()
+		large.updateGeneticDiversity()
+	}
+
+	if small.GeneticDiversity >= CriticalGeneticDiversity {
+		t.Errorf("A population held at 20 should lose its diversity, got %f", small.GeneticDiversity)
+	}
+	if large.GeneticDiversity < 0.8 {
+		t.Errorf("A large population should keep its diversity, got %f", large.GeneticDiversity)
+	}
+}
+
+func TestApplyInbreedingDepression_BottleneckDeclinesFaster(t *testing.T) {
+	// Two identical bottlenecked herds; only one has lost its diversity
+	outbredSim, _, outbred := grazingBiome(22, 1600)
+	inbredSim, _, inbred := grazingBiome(22, 1600)
+	outbred.Count, inbred.Count = 30, 30
+	outbred.GeneticDiversity = 1.0
+	inbred.GeneticDiversity = 0.05
+
+	for i := 0; i < 20; i++ {
+		outbredSim.SimulateYear()
+		inbredSim.SimulateYear()
+	}
+
+	t.Logf("After 20 years: outbred %d, inbred %d (diversity %.2f)", outbred.Count, inbred.Count, inbred.GeneticDiversity)
+	if inbred.Count >= outbred.Count*3/4 {
+		t.Errorf("The inbred herd should decline faster: %d vs %d", inbred.Count, outbred.Count)
+	}
+	if inbred.GeneticDiversity >= CriticalGeneticDiversity {
+		t.Errorf("Without migrants the inbred herd shouldn't recover its diversity, got %f", inbred.GeneticDiversity)
+	}
+}
+
+func TestMigrateSpecies_GeneticRescue(t *testing.T) {
+	source := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	dest := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	healthy := &SpeciesPopulation{
+		SpeciesID: uuid.New(), Name: "Steppe Ox", Count: 1000, GeneticDiversity: 0.9,
+		Traits: DefaultTraitsForDiet(DietHerbivore), Diet: DietHerbivore,
+	}
+	inbred := &SpeciesPopulation{
+		SpeciesID: uuid.New(), Name: "Steppe Ox", Count: 40, GeneticDiversity: 0.05,
+		Traits: DefaultTraitsForDiet(DietHerbivore), Diet: DietHerbivore,
+	}
+	source.AddSpecies(healthy)
+	dest.AddSpecies(inbred)
+
+	if MigrateSpecies(source, dest, healthy.SpeciesID, 0.04) == 0 {
+		t.Fatal("Expected migrants")
+	}
+	if inbred.GeneticDiversity < CriticalGeneticDiversity*2 {
+		t.Errorf("Migrants should restore diversity, got %f", inbred.GeneticDiversity)
+	}
+
+	t.Run("founders carry part of their source's diversity", func(t *testing.T) {
+		empty := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+		MigrateSpecies(source, empty, healthy.SpeciesID, 0.01)
+		for _, founders := range empty.Species {
+			if founders.GeneticDiversity >= healthy.GeneticDiversity {
+				t.Errorf("Founders should lose diversity: %f vs %f", founders.GeneticDiversity, healthy.GeneticDiversity)
+			}
+		}
+	})
+}
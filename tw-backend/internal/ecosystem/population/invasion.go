@@ -0,0 +1,93 @@
+package population
+
+import (
+	"sort"
+)
+
+const (
+	// MinEstablishmentFitness is the biome fitness (see CalculateBiomeFitness)
+	// migrants need to take hold in a new biome
+	MinEstablishmentFitness = 0.85
+
+	// invasionOverlapThreshold matches the competition threshold in ApplyNichePartitioning
+	invasionOverlapThreshold = 0.4
+
+	// invasionDisplacementRate scales how much of the weaker competitor is lost per arrival
+	invasionDisplacementRate = 0.5
+)
+
+// InvasionResult summarizes what happened when migrants arrived in a biome
+type InvasionResult struct {
+	Established bool  // Migrant population survived arrival
+	Displaced   int64 // Residents lost to the invader
+	Repelled    int64 // Migrants lost to residents or a hostile biome
+}
+
+// ResolveInvasion runs newly arrived migrants through the same niche-overlap
+// competition residents face. A migrant poorly suited to the biome fails to
+// establish (callers discard failed founding populations); otherwise each
+// overlapping resident competes on biome fitness and the better-adapted side
+// displaces part of the other.
+func ResolveInvasion(dest *BiomePopulation, invader *SpeciesPopulation, arrivals int64) InvasionResult {
+	var result InvasionResult
+	if invader == nil || arrivals <= 0 {
+		return result
+	}
+
+	invaderFitness := CalculateBiomeFitness(invader.Traits, dest.BiomeType)
+
+	// Hostile biome: the new arrivals die off before they can breed
+	if invaderFitness < MinEstablishmentFitness {
+		lost := arrivals
+		if lost > invader.Count {
+			lost = invader.Count
+		}
+		invader.Count -= lost
+		result.Repelled = lost
+		result.Established = invader.Count > 0
+		return result
+	}
+
+	// Stable order so outcomes don't depend on map iteration
+	residents := make([]*SpeciesPopulation, 0, len(dest.Species))
+	for _, sp := range dest.Species {
+		if sp != invader && sp.Count > 0 {
+			residents = append(residents, sp)
+		}
+	}
+	sort.Slice(residents, func(i, j int) bool {
+		return residents[i].SpeciesID.String() < residents[j].SpeciesID.String()
+	})
+
+	for _, resident := range residents {
+		if invader.Count <= 0 {
+			break
+		}
+		overlap := calculateNicheOverlap(invader, resident)
+		if overlap <= invasionOverlapThreshold {
+			continue
+		}
+
+		advantage := invaderFitness - CalculateBiomeFitness(resident.Traits, dest.BiomeType)
+		pressure := overlap * invasionDisplacementRate
+		switch {
+		case advantage > 0:
+			// Invader takes over part of the resident's niche
+			lost := int64(float64(resident.Count) * pressure * advantage)
+			resident.Count -= lost
+			invader.Count += lost / 2
+			result.Displaced += lost
+		case advantage < 0:
+			// Entrenched, better-adapted residents crowd the newcomers out
+			lost := int64(float64(invader.Count) * pressure * -advantage)
+			invader.Count -= lost
+			result.Repelled += lost
+		}
+	}
+
+	if invader.Count < 0 {
+		invader.Count = 0
+	}
+	result.Established = invader.Count > 0
+	return result
+}
@@ -0,0 +1,128 @@
+package population
+
+import (
+	"testing"
+
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+func newTestBiome(biomeType geography.BiomeType) *BiomePopulation {
+	return &BiomePopulation{
+		BiomeID:          uuid.New(),
+		BiomeType:        biomeType,
+		Species:          make(map[uuid.UUID]*SpeciesPopulation),
+		CarryingCapacity: 10000,
+	}
+}
+
+// TestMigrateSpecies_SuperiorInvaderDisplacesResident verifies that repeated
+// arrivals of a well-adapted grazer drive down a poorly-adapted native grazer.
+func TestMigrateSpecies_SuperiorInvaderDisplacesResident(t *testing.T) {
+	source := newTestBiome(geography.BiomeGrassland)
+	dest := newTestBiome(geography.BiomeGrassland)
+
+	// Fast, social plains runner: very fit on grassland
+	invaderTraits := DefaultTraitsForDiet(DietHerbivore)
+	invaderTraits.Speed = 9.0
+	invaderTraits.Social = 0.9
+	invaderTraits.Camouflage = 0.1
+	invader := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Plains Runner", Count: 10000, Traits: invaderTraits, Diet: DietHerbivore}
+	source.AddSpecies(invader)
+
+	// Slow, solitary, camouflaged forest grazer of the same size and activity
+	nativeTraits := invaderTraits
+	nativeTraits.Speed = 1.0
+	nativeTraits.Social = 0.0
+	nativeTraits.Camouflage = 0.9
+	native := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Thicket Grazer", Count: 5000, Traits: nativeTraits, Diet: DietHerbivore}
+	dest.AddSpecies(native)
+
+	if CalculateBiomeFitness(invaderTraits, dest.BiomeType) <= CalculateBiomeFitness(nativeTraits, dest.BiomeType) {
+		t.Fatal("test setup: invader should be better adapted than native")
+	}
+
+	start := native.Count
+	prev := native.Count
+	for cycle := 0; cycle < 10; cycle++ {
+		MigrateSpecies(source, dest, invader.SpeciesID, 0.05)
+		if native.Count > prev {
+			t.Fatalf("cycle %d: native population grew from %d to %d", cycle, prev, native.Count)
+		}
+		prev = native.Count
+	}
+
+	if native.Count >= start/2 {
+		t.Errorf("native population = %d, expected invader to at least halve it from %d", native.Count, start)
+	}
+
+	var established *SpeciesPopulation
+	for _, sp := range dest.Species {
+		if sp.Name == invader.Name {
+			established = sp
+		}
+	}
+	if established == nil || established.Count == 0 {
+		t.Fatal("invader should have established in the destination")
+	}
+}
+
+// TestMigrateSpecies_PoorlySuitedMigrantFailsToEstablish verifies that a
+// cold-adapted furred grazer cannot take hold in a desert.
+func TestMigrateSpecies_PoorlySuitedMigrantFailsToEstablish(t *testing.T) {
+	source := newTestBiome(geography.BiomeTundra)
+	dest := newTestBiome(geography.BiomeDesert)
+
+	traits := DefaultTraitsForDiet(DietHerbivore)
+	traits.ColdResistance = 1.0
+	traits.HeatResistance = 0.0
+	traits.Covering = CoveringFur
+	traits.Size = 8.0
+	traits.NightVision = 0.0
+	migrant := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Woolly Grazer", Count: 2000, Traits: traits, Diet: DietHerbivore}
+	source.AddSpecies(migrant)
+
+	if CalculateBiomeFitness(traits, dest.BiomeType) >= MinEstablishmentFitness {
+		t.Fatal("test setup: migrant should be poorly suited to the desert")
+	}
+
+	resident := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Dune Hopper", Count: 800, Traits: DefaultTraitsForDiet(DietHerbivore), Diet: DietHerbivore}
+	dest.AddSpecies(resident)
+
+	MigrateSpecies(source, dest, migrant.SpeciesID, 0.1)
+
+	if len(dest.Species) != 1 {
+		t.Errorf("destination should only hold the resident, got %d species", len(dest.Species))
+	}
+	if resident.Count != 800 {
+		t.Errorf("resident should be unaffected by a failed invasion, got %d", resident.Count)
+	}
+}
+
+// TestResolveInvasion_InferiorMigrantRepelled verifies that entrenched,
+// better-adapted residents crowd out weaker newcomers.
+func TestResolveInvasion_InferiorMigrantRepelled(t *testing.T) {
+	dest := newTestBiome(geography.BiomeGrassland)
+
+	strongTraits := DefaultTraitsForDiet(DietHerbivore)
+	strongTraits.Speed = 9.0
+	strongTraits.Social = 0.9
+	resident := &SpeciesPopulation{SpeciesID: uuid.New(), Count: 5000, Traits: strongTraits, Diet: DietHerbivore}
+	dest.AddSpecies(resident)
+
+	weakTraits := strongTraits
+	weakTraits.Speed = 4.0
+	weakTraits.Social = 0.3
+	newcomer := &SpeciesPopulation{SpeciesID: uuid.New(), Count: 100, Traits: weakTraits, Diet: DietHerbivore}
+	dest.AddSpecies(newcomer)
+
+	result := ResolveInvasion(dest, newcomer, 100)
+
+	if result.Displaced != 0 || resident.Count != 5000 {
+		t.Errorf("weaker newcomer should not displace residents (displaced %d)", result.Displaced)
+	}
+	if result.Repelled == 0 || newcomer.Count >= 100 {
+		t.Errorf("newcomer should lose individuals to residents, count = %d", newcomer.Count)
+	}
+}
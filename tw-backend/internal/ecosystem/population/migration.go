@@ -7,8 +7,15 @@ import (
 )
 
 // MigrateSpecies moves a percentage of a species population to another biome
-// Returns the number of individuals that successfully migrated
+// Returns the number of individuals that successfully migrated. Migrants
+// joining a resident population of their species bring it fresh genetic
+// diversity; founders of a new one carry only part of their source's.
 func MigrateSpecies(source, dest *BiomePopulation, speciesID uuid.UUID, percentage float64) int64 {
+	return migrateSpecies(source, dest, speciesID, percentage, uuid.New)
+}
+
+// migrateSpecies is MigrateSpecies, with newID naming any founding population
+func migrateSpecies(source, dest *BiomePopulation, speciesID uuid.UUID, percentage float64, newID func() uuid.UUID) int64 {
 	species, exists := source.Species[speciesID]
 	if !exists || species.Count == 0 {
 		return 0
@@ -30,7 +37,7 @@ func MigrateSpecies(source, dest *BiomePopulation, speciesID uuid.UUID, percenta
 
 	// Check if species already exists in destination
 	var destSpecies *SpeciesPopulation
-	for _, sp := range dest.Species {
+	for _, sp := range dest.sortedSpecies() {
 		if sp.Name == species.Name && sp.Diet == species.Diet {
 			destSpecies = sp
 			break
@@ -38,22 +45,31 @@ func MigrateSpecies(source, dest *BiomePopulation, speciesID uuid.UUID, percenta
 	}
 
 	if destSpecies != nil {
-		// Add to existing population
+		// Add to existing population; reinforcements still have to compete,
+		// but bring fresh genes
+		destSpecies.GeneticDiversity = mixGeneticDiversity(destSpecies.Count, migrants, destSpecies.GeneticDiversity, species.GeneticDiversity)
 		destSpecies.Count += migrants
+		ResolveInvasion(dest, destSpecies, migrants)
 	} else {
 		// Create new population with slightly mutated traits (founder effect)
 		newSpecies := &SpeciesPopulation{
-			SpeciesID:     uuid.New(),
-			AncestorID:    &species.SpeciesID,
-			Name:          species.Name,
-			Count:         migrants,
-			Traits:        species.Traits,
-			TraitVariance: species.TraitVariance * 1.2, // Increased variance from founder effect
-			Diet:          species.Diet,
-			Generation:    species.Generation,
-			CreatedYear:   species.CreatedYear,
+			SpeciesID:        newID(),
+			AncestorID:       &species.SpeciesID,
+			Name:             species.Name,
+			Count:            migrants,
+			Traits:           species.Traits,
+			TraitVariance:    species.TraitVariance * 1.2, // Increased variance from founder effect
+			GeneticDiversity: founderDiversity(species.GeneticDiversity, migrants),
+			Diet:             species.Diet,
+			Generation:       species.Generation,
+			CreatedYear:      species.CreatedYear,
 		}
 		dest.AddSpecies(newSpecies)
+
+		// Founders face the residents; a population that fails to establish leaves no trace
+		if !ResolveInvasion(dest, newSpecies, migrants).Established {
+			delete(dest.Species, newSpecies.SpeciesID)
+		}
 	}
 
 	return migrants
@@ -81,7 +97,7 @@ func TransitionBiome(biome *BiomePopulation, newType geography.BiomeType, severi
 	biome.BiomeType = newType
 
 	// Apply stress to species based on trait/biome mismatch
-	for _, species := range biome.Species {
+	for _, species := range biome.sortedSpecies() {
 		oldFitness := CalculateBiomeFitness(species.Traits, oldType)
 		newFitness := CalculateBiomeFitness(species.Traits, newType)
 
@@ -175,15 +191,13 @@ func GetBiomeTransitionTarget(current geography.BiomeType, event string) geograp
 func (ps *PopulationSimulator) ApplyMigrationCycle() int64 {
 	var totalMigrants int64
 
-	// Get list of biome pairs for potential migration
-	biomes := make([]*BiomePopulation, 0, len(ps.Biomes))
-	for _, biome := range ps.Biomes {
-		biomes = append(biomes, biome)
-	}
+	// Get list of biome pairs for potential migration, in a stable order
+	biomes := ps.sortedBiomes()
 
 	// Check each biome for migration opportunities
 	for _, sourceBiome := range biomes {
-		for speciesID, species := range sourceBiome.Species {
+		for _, species := range sourceBiome.sortedSpecies() {
+			speciesID := species.SpeciesID
 			migrationChance := CalculateMigrationChance(species, sourceBiome.CarryingCapacity)
 
 			if ps.rng.Float64() < migrationChance {
@@ -193,7 +207,7 @@ func (ps *PopulationSimulator) ApplyMigrationCycle() int64 {
 						continue
 					}
 					if AreBiomesCompatible(sourceBiome.BiomeType, destBiome.BiomeType) {
-						migrants := MigrateSpecies(sourceBiome, destBiome, speciesID, 0.05)
+						migrants := migrateSpecies(sourceBiome, destBiome, speciesID, 0.05, ps.newSpeciesID)
 						totalMigrants += migrants
 						break
 					}
@@ -202,6 +216,9 @@ func (ps *PopulationSimulator) ApplyMigrationCycle() int64 {
 		}
 	}
 
+	// Ecotones also draw founders across biome-type edges
+	totalMigrants += ps.ApplyEcotoneMixing()
+
 	return totalMigrants
 }
 
@@ -258,7 +275,7 @@ func (ps *PopulationSimulator) ApplyBiomeTransitions(eventType ExtinctionEventTy
 	}
 
 	transitioned := 0
-	for _, biome := range ps.Biomes {
+	for _, biome := range ps.sortedBiomes() {
 		newType := GetBiomeTransitionTarget(biome.BiomeType, event)
 		if newType != biome.BiomeType {
 			TransitionBiome(biome, newType, severity)
@@ -0,0 +1,186 @@
+package population
+
+import (
+	"fmt"
+	"strings"
+
+	"tw-backend/internal/worldgen/geography"
+)
+
+// NamingTheme selects how a world disambiguates species names that collide
+type NamingTheme string
+
+const (
+	// NamingThemeClassic appends roman numerals: "Woolly Grazer II"
+	NamingThemeClassic NamingTheme = "classic"
+	// NamingThemeEpithet appends a habitat epithet first ("Woolly Grazer of the
+	// Taiga"), falling back to roman numerals once the epithet is taken too
+	NamingThemeEpithet NamingTheme = "epithet"
+)
+
+const (
+	// MaxSpeciesNameLength bounds a generated name, including any suffix
+	MaxSpeciesNameLength = 64
+
+	// maxNameSuffix caps roman numeral suffixes; later collisions fall back
+	// to a numbered suffix
+	maxNameSuffix = 3999
+)
+
+// ParseNamingTheme validates a theme name ("" selects classic)
+func ParseNamingTheme(name string) (NamingTheme, error) {
+	switch theme := NamingTheme(strings.ToLower(name)); theme {
+	case "", NamingThemeClassic:
+		return NamingThemeClassic, nil
+	case NamingThemeEpithet:
+		return theme, nil
+	default:
+		return "", fmt.Errorf("unknown naming theme %q (use classic or epithet)", name)
+	}
+}
+
+// NameRegistry guarantees species names are unique across every biome of a
+// world. Names compare case-insensitively.
+type NameRegistry struct {
+	Theme NamingTheme
+	taken map[string]bool
+}
+
+// NewNameRegistry creates an empty registry using the given theme
+func NewNameRegistry(theme NamingTheme) *NameRegistry {
+	if theme == "" {
+		theme = NamingThemeClassic
+	}
+	return &NameRegistry{
+		Theme: theme,
+		taken: make(map[string]bool),
+	}
+}
+
+// Register claims a unique name derived from base, disambiguating collisions
+// according to the theme, and returns the name claimed
+func (r *NameRegistry) Register(base string, biome geography.BiomeType) string {
+	base = strings.TrimSpace(base)
+	if base == "" {
+		base = "Unnamed Species"
+	}
+
+	candidate := truncateName(base, "")
+	if r.claim(candidate) {
+		return candidate
+	}
+
+	if r.Theme == NamingThemeEpithet && biome != "" {
+		candidate = truncateName(base, " of the "+string(biome))
+		if r.claim(candidate) {
+			return candidate
+		}
+	}
+
+	for n := 2; n <= maxNameSuffix; n++ {
+		candidate = truncateName(base, " "+romanNumeral(n))
+		if r.claim(candidate) {
+			return candidate
+		}
+	}
+
+	// Thousands of same-named species: fall back to a plain counter
+	for n := len(r.taken); ; n++ {
+		candidate = truncateName(base, fmt.Sprintf(" #%d", n))
+		if r.claim(candidate) {
+			return candidate
+		}
+	}
+}
+
+// Reserve marks an existing name as taken without altering it
+func (r *NameRegistry) Reserve(name string) {
+	r.taken[strings.ToLower(name)] = true
+}
+
+// Contains reports whether a name is already taken
+func (r *NameRegistry) Contains(name string) bool {
+	return r.taken[strings.ToLower(name)]
+}
+
+// Len returns how many names are taken
+func (r *NameRegistry) Len() int {
+	return len(r.taken)
+}
+
+// Reset forgets every registered name (the theme is kept)
+func (r *NameRegistry) Reset() {
+	r.taken = make(map[string]bool)
+}
+
+func (r *NameRegistry) claim(name string) bool {
+	key := strings.ToLower(name)
+	if r.taken[key] {
+		return false
+	}
+	r.taken[key] = true
+	return true
+}
+
+// UniqueSpeciesName registers a name derived from base that no other species in
+// this world uses. The registry is built on first use from the living species
+// and fossil record, so simulators restored from a snapshot stay unique too.
+func (ps *PopulationSimulator) UniqueSpeciesName(base string, biome geography.BiomeType) string {
+	if ps.names == nil {
+		ps.names = NewNameRegistry(ps.NamingTheme)
+		for _, b := range ps.Biomes {
+			for _, sp := range b.Species {
+				ps.names.Reserve(sp.Name)
+			}
+		}
+		if ps.FossilRecord != nil {
+			for _, ex := range ps.FossilRecord.Extinct {
+				ps.names.Reserve(ex.Name)
+			}
+		}
+	}
+	return ps.names.Register(base, biome)
+}
+
+// SetNamingTheme changes how later name collisions are disambiguated
+func (ps *PopulationSimulator) SetNamingTheme(theme NamingTheme) {
+	ps.NamingTheme = theme
+	if ps.names != nil {
+		ps.names.Theme = theme
+	}
+}
+
+// ResetNames discards the name registry; it is rebuilt from the current
+// species on the next UniqueSpeciesName call
+func (ps *PopulationSimulator) ResetNames() {
+	ps.names = nil
+}
+
+// truncateName fits base+suffix within MaxSpeciesNameLength by dropping leading
+// descriptor words, keeping the creature type at the end
+func truncateName(base, suffix string) string {
+	words := strings.Fields(base)
+	for len(words) > 1 && len(strings.Join(words, " "))+len(suffix) > MaxSpeciesNameLength {
+		words = words[1:]
+	}
+	name := strings.Join(words, " ")
+	if len(name)+len(suffix) > MaxSpeciesNameLength {
+		name = name[:max(0, MaxSpeciesNameLength-len(suffix))]
+	}
+	return name + suffix
+}
+
+// romanNumeral formats 1..3999 as a roman numeral
+func romanNumeral(n int) string {
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+
+	var sb strings.Builder
+	for i, v := range values {
+		for n >= v {
+			sb.WriteString(symbols[i])
+			n -= v
+		}
+	}
+	return sb.String()
+}
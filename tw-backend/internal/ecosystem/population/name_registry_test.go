@@ -0,0 +1,124 @@
+package population
+
+import (
+	"strings"
+	"testing"
+
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+func TestNameRegistry_CollisionsGetRomanNumerals(t *testing.T) {
+	reg := NewNameRegistry(NamingThemeClassic)
+
+	got := []string{
+		reg.Register("Woolly Grazer", geography.BiomeTaiga),
+		reg.Register("Woolly Grazer", geography.BiomeTundra),
+		reg.Register("woolly grazer", geography.BiomeTaiga),
+	}
+	want := []string{"Woolly Grazer", "Woolly Grazer II", "woolly grazer III"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Register #%d = %q, want %q", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestNameRegistry_EpithetTheme(t *testing.T) {
+	reg := NewNameRegistry(NamingThemeEpithet)
+
+	first := reg.Register("Woolly Grazer", geography.BiomeTaiga)
+	second := reg.Register("Woolly Grazer", geography.BiomeTaiga)
+	third := reg.Register("Woolly Grazer", geography.BiomeTaiga)
+
+	if first != "Woolly Grazer" || second != "Woolly Grazer of the Taiga" || third != "Woolly Grazer II" {
+		t.Errorf("Epithet theme names = %q, %q, %q", first, second, third)
+	}
+}
+
+func TestNameRegistry_BoundedLength(t *testing.T) {
+	reg := NewNameRegistry(NamingThemeClassic)
+	long := strings.Repeat("Enormous ", 10) + "Grazer"
+
+	for i := 0; i < 3; i++ {
+		name := reg.Register(long, geography.BiomeGrassland)
+		if len(name) > MaxSpeciesNameLength {
+			t.Errorf("Name %q exceeds %d characters", name, MaxSpeciesNameLength)
+		}
+		if !strings.Contains(name, "Grazer") {
+			t.Errorf("Truncation should keep the creature type, got %q", name)
+		}
+	}
+	if reg.Len() != 3 {
+		t.Errorf("Expected 3 distinct names, got %d", reg.Len())
+	}
+}
+
+func TestParseNamingTheme(t *testing.T) {
+	if theme, err := ParseNamingTheme(""); err != nil || theme != NamingThemeClassic {
+		t.Errorf("Empty theme should default to classic, got %q (%v)", theme, err)
+	}
+	if theme, err := ParseNamingTheme("Epithet"); err != nil || theme != NamingThemeEpithet {
+		t.Errorf("Theme names should be case-insensitive, got %q (%v)", theme, err)
+	}
+	if _, err := ParseNamingTheme("klingon"); err == nil {
+		t.Error("Unknown theme should be rejected")
+	}
+}
+
+func TestUniqueSpeciesName_DistinctAcrossBiomes(t *testing.T) {
+	sim := NewPopulationSimulator(uuid.New(), 1)
+	traits := DefaultTraitsForDiet(DietHerbivore)
+
+	// Identical traits, diet, and biome type generate the same base name
+	for i := 0; i < 2; i++ {
+		biome := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+		base := string(geography.BiomeGrassland) + " " + GenerateSpeciesName(traits, DietHerbivore, geography.BiomeGrassland)
+		biome.AddSpecies(&SpeciesPopulation{SpeciesID: uuid.New(), Name: sim.UniqueSpeciesName(base, biome.BiomeType), Count: 100})
+		sim.Biomes[biome.BiomeID] = biome
+	}
+
+	seen := make(map[string]bool)
+	for _, biome := range sim.Biomes {
+		for _, sp := range biome.Species {
+			if seen[sp.Name] {
+				t.Errorf("Duplicate species name %q across biomes", sp.Name)
+			}
+			seen[sp.Name] = true
+		}
+	}
+}
+
+func TestUniqueSpeciesName_SeedsFromExistingSpecies(t *testing.T) {
+	sim := NewPopulationSimulator(uuid.New(), 1)
+	biome := NewBiomePopulation(uuid.New(), geography.BiomeDesert)
+	biome.AddSpecies(&SpeciesPopulation{SpeciesID: uuid.New(), Name: "Scaled Viper", Count: 10})
+	sim.Biomes[biome.BiomeID] = biome
+	sim.FossilRecord.Extinct = append(sim.FossilRecord.Extinct, &ExtinctSpecies{Name: "Armored Grazer"})
+
+	if name := sim.UniqueSpeciesName("Scaled Viper", geography.BiomeDesert); name == "Scaled Viper" {
+		t.Error("Name of a living species should not be handed out again")
+	}
+	if name := sim.UniqueSpeciesName("Armored Grazer", geography.BiomeDesert); name == "Armored Grazer" {
+		t.Error("Name of an extinct species should not be handed out again")
+	}
+}
+
+func TestUniqueSpeciesName_ResetOnWorldReset(t *testing.T) {
+	sim := NewPopulationSimulator(uuid.New(), 1)
+	sim.UniqueSpeciesName("Prairie Grass", geography.BiomeGrassland)
+
+	// World reset clears the species, so their names are free again
+	sim.Biomes = make(map[uuid.UUID]*BiomePopulation)
+	sim.ResetNames()
+	if name := sim.UniqueSpeciesName("Prairie Grass", geography.BiomeGrassland); name != "Prairie Grass" {
+		t.Errorf("After reset the base name should be available, got %q", name)
+	}
+
+	// A fresh simulator for the same world starts with an empty registry
+	fresh := NewPopulationSimulator(uuid.New(), 1)
+	if name := fresh.UniqueSpeciesName("Prairie Grass", geography.BiomeGrassland); name != "Prairie Grass" {
+		t.Errorf("New simulator should not inherit names, got %q", name)
+	}
+}
@@ -276,6 +276,19 @@ func (o *Organism) AquaticAffinity() float64 {
 	return math.Max(0, o.Traits.Speed-o.Traits.HeatResist*0.5)
 }
 
+// MagicAffinity is the species' magic capability (0-10): expressed from its
+// genetic code if it has one, else from its V2 traits. Species with neither
+// have none.
+func (sp *SpeciesPopulation) MagicAffinity() float64 {
+	switch {
+	case sp.GeneticCode != nil:
+		return float64(sp.GeneticCode.ToPhenotype(DefaultExpressionMatrix())[TraitMagicAffinity]) * 10
+	case sp.OrganismTraits != nil:
+		return sp.OrganismTraits.MagicAffinity
+	}
+	return 0
+}
+
 // --- Conversion functions for gradual migration from EvolvableTraits ---
 
 // ToEvolvableTraits converts OrganismTraits to the legacy EvolvableTraits format
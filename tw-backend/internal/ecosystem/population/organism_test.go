@@ -436,3 +436,23 @@ func TestOrganismTraitsFromEvolvableTraitsWithDefaults(t *testing.T) {
 		}
 	})
 }
+
+func TestSpeciesPopulation_MagicAffinity(t *testing.T) {
+	legacy := &SpeciesPopulation{Traits: EvolvableTraits{Intelligence: 0.9}}
+	if got := legacy.MagicAffinity(); got != 0 {
+		t.Errorf("MagicAffinity without V2 traits = %f, want 0", got)
+	}
+
+	arcane := &SpeciesPopulation{OrganismTraits: &OrganismTraits{MagicAffinity: 8.5}}
+	if got := arcane.MagicAffinity(); got != 8.5 {
+		t.Errorf("MagicAffinity = %f, want 8.5", got)
+	}
+
+	// The genetic code is expressed the same way as for an organism
+	gc := NewGeneticCode(rand.New(rand.NewSource(7)))
+	org := NewOrganismFromGeneticCode(uuid.New(), "Seer", gc, DefaultExpressionMatrix(), 0, nil)
+	coded := &SpeciesPopulation{GeneticCode: gc}
+	if got, want := coded.MagicAffinity(), org.Traits.MagicAffinity; got != want {
+		t.Errorf("MagicAffinity from genetic code = %f, want %f", got, want)
+	}
+}
@@ -3,6 +3,10 @@
 package population
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/google/uuid"
 )
 
@@ -176,6 +180,33 @@ func (pt *PhylogeneticTree) GetDescendants(speciesID uuid.UUID) []*PhylogeneticN
 	return descendants
 }
 
+// Lineage returns the chain of descent leading to a species: its root
+// ancestor first and the species itself last, through extinct ancestors
+// too. It returns nil if the species isn't in the tree.
+func (pt *PhylogeneticTree) Lineage(speciesID uuid.UUID) []*PhylogeneticNode {
+	node := pt.Nodes[speciesID]
+	if node == nil {
+		return nil
+	}
+
+	ancestors := pt.GetAncestors(speciesID)
+	lineage := make([]*PhylogeneticNode, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		lineage = append(lineage, ancestors[i])
+	}
+	return append(lineage, node)
+}
+
+// Descendants returns every species that evolved from a species, nearest
+// generations first, through extinct ones too. Unlike GetDescendants, it
+// returns nil if the species isn't in the tree.
+func (pt *PhylogeneticTree) Descendants(speciesID uuid.UUID) []*PhylogeneticNode {
+	if pt.Nodes[speciesID] == nil {
+		return nil
+	}
+	return pt.GetDescendants(speciesID)
+}
+
 // GetCommonAncestor finds the most recent common ancestor of two species
 func (pt *PhylogeneticTree) GetCommonAncestor(species1ID, species2ID uuid.UUID) *PhylogeneticNode {
 	// Get all ancestors of species1
@@ -324,3 +355,214 @@ func (pt *PhylogeneticTree) Clone() *PhylogeneticTree {
 
 	return clone
 }
+
+// BuildPhylogeneticTree reconstructs the tree of life from a simulator's living
+// species and its fossil record, linked through AncestorID. Species whose
+// ancestor is unknown or missing from both appear as roots.
+func (ps *PopulationSimulator) BuildPhylogeneticTree(worldID uuid.UUID) *PhylogeneticTree {
+	tree := NewPhylogeneticTree(worldID)
+	tree.CurrentYear = ps.CurrentYear
+
+	// Species spread across biomes share an ID; keep the first copy seen
+	living := make(map[uuid.UUID]*SpeciesPopulation)
+	ancestors := make(map[uuid.UUID]uuid.UUID)
+	for _, biome := range ps.Biomes {
+		for id, sp := range biome.Species {
+			if _, seen := living[id]; !seen {
+				living[id] = sp
+				if sp.AncestorID != nil {
+					ancestors[id] = *sp.AncestorID
+				}
+			}
+		}
+	}
+
+	for id, sp := range living {
+		tree.Nodes[id] = &PhylogeneticNode{
+			SpeciesID:   id,
+			Name:        sp.Name,
+			ChildIDs:    make([]uuid.UUID, 0),
+			OriginYear:  sp.CreatedYear,
+			Diet:        sp.Diet,
+			GeneticCode: sp.GeneticCode,
+		}
+		tree.ExtantCount++
+	}
+	if ps.FossilRecord != nil {
+		for _, ex := range ps.FossilRecord.Extinct {
+			if _, exists := tree.Nodes[ex.SpeciesID]; exists {
+				continue
+			}
+			tree.Nodes[ex.SpeciesID] = &PhylogeneticNode{
+				SpeciesID:      ex.SpeciesID,
+				Name:           ex.Name,
+				ChildIDs:       make([]uuid.UUID, 0),
+				OriginYear:     ex.ExistedFrom,
+				ExtinctionYear: ex.ExistedUntil,
+				Diet:           ex.Diet,
+				GeneticCode:    ex.GeneticCode,
+			}
+			if ex.AncestorID != nil {
+				ancestors[ex.SpeciesID] = *ex.AncestorID
+			}
+			tree.ExtinctCount++
+		}
+	}
+
+	// Link children in a stable order so output doesn't depend on map iteration
+	ids := make([]uuid.UUID, 0, len(tree.Nodes))
+	for id := range tree.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := tree.Nodes[ids[i]], tree.Nodes[ids[j]]
+		if a.OriginYear != b.OriginYear {
+			return a.OriginYear < b.OriginYear
+		}
+		return ids[i].String() < ids[j].String()
+	})
+	for _, id := range ids {
+		node := tree.Nodes[id]
+		if parentID, ok := ancestors[id]; ok && parentID != id {
+			if parent, ok := tree.Nodes[parentID]; ok {
+				node.ParentID = &parentID
+				parent.ChildIDs = append(parent.ChildIDs, id)
+				continue
+			}
+		}
+		tree.Roots = append(tree.Roots, id)
+	}
+
+	// Depths flow down from the roots
+	var setDepth func(id uuid.UUID, depth int)
+	setDepth = func(id uuid.UUID, depth int) {
+		node := tree.Nodes[id]
+		node.Depth = depth
+		if depth > tree.MaxDepth {
+			tree.MaxDepth = depth
+		}
+		for _, childID := range node.ChildIDs {
+			setDepth(childID, depth+1)
+		}
+	}
+	for _, rootID := range tree.Roots {
+		setDepth(rootID, 0)
+	}
+
+	return tree
+}
+
+// ToNewick serializes the tree in Newick format for external tree viewers.
+// Each species is labelled with its name and its branch length is how long
+// it existed (to CurrentYear, or the latest year in the tree, if extant).
+// Extinct species carry a [&extinct=true,extinction_year=N] annotation, as
+// read by FigTree and similar tools.
+//
+// Orphans (species whose parent isn't in the tree) are treated as roots.
+// With more than one root the roots are joined under an unnamed, unlabelled
+// root, so the output is always a single tree.
+func (pt *PhylogeneticTree) ToNewick() string {
+	now := pt.latestYear()
+	visited := make(map[uuid.UUID]bool)
+
+	var write func(sb *strings.Builder, id uuid.UUID)
+	write = func(sb *strings.Builder, id uuid.UUID) {
+		visited[id] = true
+		node := pt.Nodes[id]
+
+		children := make([]uuid.UUID, 0, len(node.ChildIDs))
+		for _, childID := range node.ChildIDs {
+			if _, ok := pt.Nodes[childID]; ok && !visited[childID] {
+				children = append(children, childID)
+			}
+		}
+		if len(children) > 0 {
+			sb.WriteByte('(')
+			for i, childID := range children {
+				if i > 0 {
+					sb.WriteByte(',')
+				}
+				write(sb, childID)
+			}
+			sb.WriteByte(')')
+		}
+
+		name := node.Name
+		if name == "" {
+			name = node.SpeciesID.String()
+		}
+		sb.WriteString(newickLabel(name))
+		if !node.IsExtant() {
+			fmt.Fprintf(sb, "[&extinct=true,extinction_year=%d]", node.ExtinctionYear)
+		}
+		fmt.Fprintf(sb, ":%d", max(0, node.Duration(now)))
+	}
+
+	roots := pt.newickRoots()
+	var sb strings.Builder
+	if len(roots) > 1 {
+		sb.WriteByte('(')
+	}
+	for i, id := range roots {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		write(&sb, id)
+	}
+	if len(roots) > 1 {
+		sb.WriteByte(')')
+	}
+	sb.WriteByte(';')
+	return sb.String()
+}
+
+// newickRoots returns the tree's roots followed by any orphans, whose parent
+// is missing from the tree, in a stable order
+func (pt *PhylogeneticTree) newickRoots() []uuid.UUID {
+	roots := make([]uuid.UUID, 0, len(pt.Roots))
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range pt.Roots {
+		if _, ok := pt.Nodes[id]; ok && !seen[id] {
+			seen[id] = true
+			roots = append(roots, id)
+		}
+	}
+
+	orphans := make([]uuid.UUID, 0)
+	for id, node := range pt.Nodes {
+		if seen[id] {
+			continue
+		}
+		if node.ParentID == nil || pt.Nodes[*node.ParentID] == nil {
+			orphans = append(orphans, id)
+		}
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		a, b := pt.Nodes[orphans[i]], pt.Nodes[orphans[j]]
+		if a.OriginYear != b.OriginYear {
+			return a.OriginYear < b.OriginYear
+		}
+		return orphans[i].String() < orphans[j].String()
+	})
+	return append(roots, orphans...)
+}
+
+// latestYear returns CurrentYear, or the latest origin or extinction in the
+// tree if that's later (trees built by hand may never set CurrentYear)
+func (pt *PhylogeneticTree) latestYear() int64 {
+	year := pt.CurrentYear
+	for _, node := range pt.Nodes {
+		year = max(year, node.OriginYear, node.ExtinctionYear)
+	}
+	return year
+}
+
+// newickLabel quotes a label if it holds characters Newick reserves. A name
+// with spaces is quoted rather than having them turned into underscores, and
+// a literal underscore is quoted since unquoted it would read back as a space.
+func newickLabel(name string) string {
+	if !strings.ContainsAny(name, " \t\n()[]':;,_") {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
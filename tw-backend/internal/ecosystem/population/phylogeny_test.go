@@ -1,8 +1,12 @@
 package population
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
+	"tw-backend/internal/worldgen/geography"
+
 	"github.com/google/uuid"
 )
 
@@ -160,6 +164,71 @@ func TestPhylogeneticTree_Descendants(t *testing.T) {
 	}
 }
 
+func TestPhylogeneticTree_Lineage(t *testing.T) {
+	tree := NewPhylogeneticTree(uuid.New())
+
+	// founder -> (bridge -> (heir, sibling), offshoot); bridge dies out
+	founder := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Founder"}
+	bridge := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Bridge"}
+	heir := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Heir"}
+	sibling := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Sibling"}
+	offshoot := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Offshoot"}
+
+	tree.AddRoot(founder, 0)
+	tree.AddSpeciation(founder, bridge, SpeciationAllopatric, 1000)
+	tree.AddSpeciation(bridge, heir, SpeciationAllopatric, 2000)
+	tree.AddSpeciation(bridge, sibling, SpeciationSympatric, 2500)
+	tree.AddSpeciation(founder, offshoot, SpeciationPeripatric, 3000)
+	tree.MarkExtinct(bridge.SpeciesID, 4000)
+
+	names := func(nodes []*PhylogeneticNode) []string {
+		out := make([]string, len(nodes))
+		for i, n := range nodes {
+			out[i] = n.Name
+		}
+		return out
+	}
+
+	t.Run("lineage runs root to species through extinct ancestors", func(t *testing.T) {
+		got := strings.Join(names(tree.Lineage(heir.SpeciesID)), " > ")
+		if got != "Founder > Bridge > Heir" {
+			t.Errorf("Expected Founder > Bridge > Heir, got %s", got)
+		}
+	})
+
+	t.Run("root lineage is itself", func(t *testing.T) {
+		lineage := tree.Lineage(founder.SpeciesID)
+		if len(lineage) != 1 || lineage[0].SpeciesID != founder.SpeciesID {
+			t.Errorf("Founder's lineage should be just itself, got %v", names(lineage))
+		}
+	})
+
+	t.Run("unknown species has no lineage", func(t *testing.T) {
+		if lineage := tree.Lineage(uuid.New()); lineage != nil {
+			t.Errorf("Expected nil, got %v", names(lineage))
+		}
+	})
+
+	t.Run("descendants of an extinct species", func(t *testing.T) {
+		got := names(tree.Descendants(bridge.SpeciesID))
+		if strings.Join(got, ",") != "Heir,Sibling" {
+			t.Errorf("Bridge should have descendants Heir,Sibling, got %v", got)
+		}
+		if all := tree.Descendants(founder.SpeciesID); len(all) != 4 {
+			t.Errorf("Founder should have 4 descendants, got %v", names(all))
+		}
+		if leaf := tree.Descendants(heir.SpeciesID); leaf == nil || len(leaf) != 0 {
+			t.Errorf("Heir should have an empty list of descendants, got %v", leaf)
+		}
+	})
+
+	t.Run("unknown species has no descendants", func(t *testing.T) {
+		if descendants := tree.Descendants(uuid.New()); descendants != nil {
+			t.Errorf("Expected nil, got %v", names(descendants))
+		}
+	})
+}
+
 func TestPhylogeneticTree_CommonAncestor(t *testing.T) {
 	tree := NewPhylogeneticTree(uuid.New())
 
@@ -245,3 +314,265 @@ func TestPhylogeneticTree_PhylogeneticDistance(t *testing.T) {
 		}
 	})
 }
+
+func TestPopulationSimulator_BuildPhylogeneticTree(t *testing.T) {
+	worldID := uuid.New()
+	sim := NewPopulationSimulator(worldID, 42)
+	sim.CurrentYear = 5000
+
+	founder := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Founder", Diet: DietHerbivore, Count: 100}
+	child := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Child", Diet: DietHerbivore, Count: 50,
+		AncestorID: &founder.SpeciesID, CreatedYear: 1000}
+	grandchild := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Grandchild", Diet: DietCarnivore, Count: 10,
+		AncestorID: &child.SpeciesID, CreatedYear: 3000}
+
+	forest := NewBiomePopulation(uuid.New(), geography.BiomeRainforest)
+	forest.Species[founder.SpeciesID] = founder
+	forest.Species[child.SpeciesID] = child
+	plains := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	plains.Species[grandchild.SpeciesID] = grandchild
+	plains.Species[founder.SpeciesID] = founder // Same species in two biomes
+	sim.Biomes[forest.BiomeID] = forest
+	sim.Biomes[plains.BiomeID] = plains
+
+	fossil := &ExtinctSpecies{SpeciesID: uuid.New(), Name: "Fossil", ExistedFrom: 0, ExistedUntil: 2000}
+	sim.FossilRecord.Extinct = append(sim.FossilRecord.Extinct, fossil)
+
+	tree := sim.BuildPhylogeneticTree(worldID)
+
+	if len(tree.Nodes) != 4 {
+		t.Fatalf("Expected 4 nodes, got %d", len(tree.Nodes))
+	}
+	if tree.ExtantCount != 3 || tree.ExtinctCount != 1 {
+		t.Errorf("Expected 3 extant / 1 extinct, got %d / %d", tree.ExtantCount, tree.ExtinctCount)
+	}
+	if len(tree.Roots) != 2 {
+		t.Errorf("Expected founder and fossil as roots, got %d roots", len(tree.Roots))
+	}
+	if tree.Nodes[grandchild.SpeciesID].Depth != 2 || tree.MaxDepth != 2 {
+		t.Errorf("Expected grandchild at depth 2, got %d (max %d)", tree.Nodes[grandchild.SpeciesID].Depth, tree.MaxDepth)
+	}
+	if ancestor := tree.GetCommonAncestor(child.SpeciesID, grandchild.SpeciesID); ancestor == nil || ancestor.SpeciesID != child.SpeciesID {
+		t.Error("Child should be the common ancestor of child and grandchild")
+	}
+	if tree.Nodes[fossil.SpeciesID].IsExtant() {
+		t.Error("Fossil species should be extinct")
+	}
+	if tree.CurrentYear != 5000 {
+		t.Errorf("Expected current year 5000, got %d", tree.CurrentYear)
+	}
+}
+
+func TestPopulationSimulator_BuildPhylogeneticTree_ExtinctAncestor(t *testing.T) {
+	worldID := uuid.New()
+	sim := NewPopulationSimulator(worldID, 42)
+	sim.CurrentYear = 5000
+
+	founder := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Founder", Diet: DietHerbivore, Count: 100}
+	bridge := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Bridge", Diet: DietHerbivore, Count: 1,
+		AncestorID: &founder.SpeciesID, CreatedYear: 1000}
+	heir := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Heir", Diet: DietHerbivore, Count: 50,
+		AncestorID: &bridge.SpeciesID, CreatedYear: 2000}
+
+	forest := NewBiomePopulation(uuid.New(), geography.BiomeRainforest)
+	forest.Species[founder.SpeciesID] = founder
+	forest.Species[bridge.SpeciesID] = bridge
+	forest.Species[heir.SpeciesID] = heir
+	sim.Biomes[forest.BiomeID] = forest
+
+	// The fossil record keeps the bridge's ancestry once it dies out
+	sim.recordExtinction(forest, bridge.SpeciesID, "population_collapse")
+	tree := sim.BuildPhylogeneticTree(worldID)
+
+	if len(tree.Roots) != 1 {
+		t.Fatalf("Expected only the founder as a root, got %d roots", len(tree.Roots))
+	}
+	lineage := tree.Lineage(heir.SpeciesID)
+	if len(lineage) != 3 || lineage[0].SpeciesID != founder.SpeciesID || lineage[1].SpeciesID != bridge.SpeciesID {
+		t.Fatalf("Expected Founder > Bridge > Heir, got %d steps", len(lineage))
+	}
+	if lineage[1].IsExtant() {
+		t.Error("Bridge should be extinct")
+	}
+}
+
+// newickNode is a parsed Newick node
+type newickNode struct {
+	Label    string
+	Comment  string
+	Length   int64
+	Children []*newickNode
+}
+
+// parseNewick is a minimal Newick reader (quoted labels, [comments] and
+// integer branch lengths) for round-tripping ToNewick
+func parseNewick(t *testing.T, s string) *newickNode {
+	t.Helper()
+	pos := 0
+	var parseNode func() *newickNode
+	parseNode = func() *newickNode {
+		node := &newickNode{}
+		if pos < len(s) && s[pos] == '(' {
+			pos++
+			for {
+				node.Children = append(node.Children, parseNode())
+				if pos >= len(s) {
+					t.Fatalf("Unterminated child list in %q", s)
+				}
+				if s[pos] == ')' {
+					pos++
+					break
+				}
+				if s[pos] != ',' {
+					t.Fatalf("Expected ',' or ')' at %d in %q", pos, s)
+				}
+				pos++
+			}
+		}
+		if pos < len(s) && s[pos] == '\'' {
+			pos++
+			var label strings.Builder
+			for {
+				if pos >= len(s) {
+					t.Fatalf("Unterminated quoted label in %q", s)
+				}
+				if s[pos] == '\'' {
+					if pos+1 < len(s) && s[pos+1] == '\'' {
+						label.WriteByte('\'')
+						pos += 2
+						continue
+					}
+					pos++
+					break
+				}
+				label.WriteByte(s[pos])
+				pos++
+			}
+			node.Label = label.String()
+		} else {
+			start := pos
+			for pos < len(s) && !strings.ContainsRune("()[]':;,", rune(s[pos])) {
+				pos++
+			}
+			node.Label = strings.ReplaceAll(s[start:pos], "_", " ")
+		}
+		if pos < len(s) && s[pos] == '[' {
+			end := strings.IndexByte(s[pos:], ']')
+			if end < 0 {
+				t.Fatalf("Unterminated comment in %q", s)
+			}
+			node.Comment = s[pos+1 : pos+end]
+			pos += end + 1
+		}
+		if pos < len(s) && s[pos] == ':' {
+			pos++
+			start := pos
+			for pos < len(s) && (s[pos] == '-' || (s[pos] >= '0' && s[pos] <= '9')) {
+				pos++
+			}
+			length, err := strconv.ParseInt(s[start:pos], 10, 64)
+			if err != nil {
+				t.Fatalf("Bad branch length at %d in %q: %v", start, s, err)
+			}
+			node.Length = length
+		}
+		return node
+	}
+
+	root := parseNode()
+	if pos != len(s)-1 || s[pos] != ';' {
+		t.Fatalf("Expected a single tree ending in ';', stopped at %d in %q", pos, s)
+	}
+	return root
+}
+
+func TestPhylogeneticTree_ToNewick(t *testing.T) {
+	tree := NewPhylogeneticTree(uuid.New())
+	tree.CurrentYear = 10000
+
+	// root -> (early -> late, doomed); plus an unrelated founder
+	root := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Proto Grazer"}
+	early := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Early"}
+	late := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Late's Kin"}
+	doomed := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Doomed"}
+	other := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Other"}
+
+	tree.AddRoot(root, 0)
+	tree.AddSpeciation(root, early, SpeciationAllopatric, 2000)
+	tree.AddSpeciation(early, late, SpeciationAllopatric, 6000)
+	tree.AddSpeciation(root, doomed, SpeciationSympatric, 3000)
+	tree.MarkExtinct(doomed.SpeciesID, 4500)
+	tree.AddRoot(other, 1000)
+
+	newick := tree.ToNewick()
+	parsed := parseNewick(t, newick)
+
+	t.Run("multiple roots joined", func(t *testing.T) {
+		if parsed.Label != "" || len(parsed.Children) != 2 {
+			t.Fatalf("Expected an unnamed root over 2 roots, got %q", newick)
+		}
+		if parsed.Children[0].Label != "Proto Grazer" || parsed.Children[1].Label != "Other" {
+			t.Errorf("Unexpected roots in %q", newick)
+		}
+	})
+
+	t.Run("structure round-trips", func(t *testing.T) {
+		proto := parsed.Children[0]
+		if len(proto.Children) != 2 {
+			t.Fatalf("Proto Grazer should have 2 children in %q", newick)
+		}
+		if proto.Children[0].Label != "Early" || len(proto.Children[0].Children) != 1 ||
+			proto.Children[0].Children[0].Label != "Late's Kin" {
+			t.Errorf("Early -> Late's Kin lineage lost in %q", newick)
+		}
+		if proto.Children[1].Label != "Doomed" || len(proto.Children[1].Children) != 0 {
+			t.Errorf("Doomed should be a leaf in %q", newick)
+		}
+	})
+
+	t.Run("branch lengths are existence spans", func(t *testing.T) {
+		proto := parsed.Children[0]
+		if proto.Length != 10000 {
+			t.Errorf("Proto Grazer length should be 10000, got %d", proto.Length)
+		}
+		if late := proto.Children[0].Children[0]; late.Length != 4000 {
+			t.Errorf("Late's Kin length should be 4000, got %d", late.Length)
+		}
+		if doomed := proto.Children[1]; doomed.Length != 1500 {
+			t.Errorf("Doomed length should be 1500, got %d", doomed.Length)
+		}
+	})
+
+	t.Run("extinct lineages annotated", func(t *testing.T) {
+		doomed := parsed.Children[0].Children[1]
+		if doomed.Comment != "&extinct=true,extinction_year=4500" {
+			t.Errorf("Doomed should be annotated extinct, got %q", doomed.Comment)
+		}
+		if parsed.Children[0].Comment != "" {
+			t.Errorf("Extant species shouldn't be annotated, got %q", parsed.Children[0].Comment)
+		}
+	})
+}
+
+func TestPhylogeneticTree_ToNewick_Orphans(t *testing.T) {
+	tree := NewPhylogeneticTree(uuid.New())
+
+	single := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Loner"}
+	tree.AddRoot(single, 0)
+	tree.MarkExtinct(single.SpeciesID, 700)
+	if got, want := tree.ToNewick(), "Loner[&extinct=true,extinction_year=700]:700;"; got != want {
+		t.Errorf("Single root: got %q, want %q", got, want)
+	}
+
+	// A species whose parent was never recorded still appears, as a root
+	missing := uuid.New()
+	tree.Nodes[uuid.New()] = &PhylogeneticNode{Name: "Orphan", ParentID: &missing, OriginYear: 200}
+	parsed := parseNewick(t, tree.ToNewick())
+	if len(parsed.Children) != 2 || parsed.Children[1].Label != "Orphan" || parsed.Children[1].Length != 500 {
+		t.Errorf("Orphan should be a second root spanning 500 years, got %q", tree.ToNewick())
+	}
+
+	if got := NewPhylogeneticTree(uuid.New()).ToNewick(); got != ";" {
+		t.Errorf("Empty tree should be ';', got %q", got)
+	}
+}
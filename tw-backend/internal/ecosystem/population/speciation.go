@@ -270,7 +270,7 @@ func (sc *SpeciationChecker) createDaughterSpecies(
 	currentYear int64,
 	speciationType SpeciationType,
 ) *SpeciesPopulation {
-	newID := uuid.New()
+	newID := newSpeciesID(sc.rng)
 
 	daughter := &SpeciesPopulation{
 		SpeciesID:     newID,
@@ -64,6 +64,19 @@ func TestSpeciationChecker_Allopatric(t *testing.T) {
 	})
 }
 
+func TestSpeciationChecker_DaughterIDsFollowTheSeed(t *testing.T) {
+	parent := &SpeciesPopulation{SpeciesID: uuid.New(), Name: "Test Species", Count: 400}
+
+	first := NewSpeciationChecker(42).createDaughterSpecies(parent, 1000, SpeciationAllopatric)
+	again := NewSpeciationChecker(42).createDaughterSpecies(parent, 1000, SpeciationAllopatric)
+	if first.SpeciesID != again.SpeciesID {
+		t.Errorf("Same seed gave daughters %s and %s", first.SpeciesID, again.SpeciesID)
+	}
+	if first.SpeciesID == parent.SpeciesID {
+		t.Error("Daughter should get its own ID")
+	}
+}
+
 func TestSpeciationChecker_Sympatric(t *testing.T) {
 	rng := rand.New(rand.NewSource(42))
 	checker := NewSpeciationChecker(42)
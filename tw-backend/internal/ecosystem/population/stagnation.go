@@ -0,0 +1,134 @@
+package population
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StagnationVarianceThreshold is the trait variance at or below which a species
+	// has effectively stopped evolving (the decay floor is 0.01)
+	StagnationVarianceThreshold = 0.02
+
+	// StagnationMinYears is how long a lineage must go without producing a new
+	// species before low variance counts as stagnation
+	StagnationMinYears = 1_000_000
+
+	// MutationPulseVariance is the trait variance a mutation pulse adds back
+	MutationPulseVariance = 0.1
+
+	// StagnationCheckInterval is how often (in years) callers should check for
+	// stagnation; checking more often only repeats the same report
+	StagnationCheckInterval = 100_000
+)
+
+// StagnantSpecies describes a species stuck in an evolutionary dead-end
+type StagnantSpecies struct {
+	SpeciesID     uuid.UUID `json:"species_id"`
+	Name          string    `json:"name"`
+	TraitVariance float64   `json:"trait_variance"` // Highest variance across its populations
+	YearsStatic   int64     `json:"years_static"`   // Years since the lineage last speciated
+}
+
+// DetectStagnation returns species whose trait variance has collapsed in every
+// biome and whose lineage (the species and its living descendants) has produced
+// nothing new for StagnationMinYears. Results are ordered longest-static first.
+func (ps *PopulationSimulator) DetectStagnation() []StagnantSpecies {
+	type lineage struct {
+		name      string
+		variance  float64
+		lastSplit int64
+	}
+
+	// Merge each species across biomes, keeping its highest variance
+	bySpecies := make(map[uuid.UUID]*lineage)
+	for _, biome := range ps.Biomes {
+		for id, sp := range biome.Species {
+			if sp.Count <= 0 {
+				continue
+			}
+			entry, ok := bySpecies[id]
+			if !ok {
+				entry = &lineage{name: sp.Name, lastSplit: sp.CreatedYear}
+				bySpecies[id] = entry
+			}
+			entry.variance = math.Max(entry.variance, sp.TraitVariance)
+		}
+	}
+
+	// A living descendant resets its ancestor's clock
+	for _, biome := range ps.Biomes {
+		for id, sp := range biome.Species {
+			if sp.Count <= 0 || sp.AncestorID == nil || *sp.AncestorID == id {
+				continue
+			}
+			if parent, ok := bySpecies[*sp.AncestorID]; ok && sp.CreatedYear > parent.lastSplit {
+				parent.lastSplit = sp.CreatedYear
+			}
+		}
+	}
+
+	var stagnant []StagnantSpecies
+	for id, entry := range bySpecies {
+		yearsStatic := ps.CurrentYear - entry.lastSplit
+		if entry.variance > StagnationVarianceThreshold || yearsStatic < StagnationMinYears {
+			continue
+		}
+		stagnant = append(stagnant, StagnantSpecies{
+			SpeciesID:     id,
+			Name:          entry.name,
+			TraitVariance: entry.variance,
+			YearsStatic:   yearsStatic,
+		})
+	}
+
+	sort.Slice(stagnant, func(i, j int) bool {
+		if stagnant[i].YearsStatic != stagnant[j].YearsStatic {
+			return stagnant[i].YearsStatic > stagnant[j].YearsStatic
+		}
+		return stagnant[i].SpeciesID.String() < stagnant[j].SpeciesID.String()
+	})
+	return stagnant
+}
+
+// ApplyMutationPulse adds MutationPulseVariance to every population of the given
+// species (capped at 1.0), giving selection something to act on again
+func (ps *PopulationSimulator) ApplyMutationPulse(stagnant []StagnantSpecies) {
+	if len(stagnant) == 0 {
+		return
+	}
+	targets := make(map[uuid.UUID]bool, len(stagnant))
+	for _, s := range stagnant {
+		targets[s.SpeciesID] = true
+	}
+	for _, biome := range ps.Biomes {
+		for id, sp := range biome.Species {
+			if targets[id] {
+				sp.TraitVariance = math.Min(1.0, sp.TraitVariance+MutationPulseVariance)
+			}
+		}
+	}
+}
+
+// ApplyStagnationCheck detects stagnant species and logs them to Events. With
+// pulse set, each stagnant species also receives a mutation pulse.
+func (ps *PopulationSimulator) ApplyStagnationCheck(pulse bool) []StagnantSpecies {
+	stagnant := ps.DetectStagnation()
+	if len(stagnant) == 0 {
+		return nil
+	}
+
+	oldest := stagnant[0]
+	if pulse {
+		ps.ApplyMutationPulse(stagnant)
+		ps.Events = append(ps.Events, fmt.Sprintf("Mutation pulse revived %d stagnant species (oldest: %s, unchanged %d years)",
+			len(stagnant), oldest.Name, oldest.YearsStatic))
+	} else {
+		ps.Events = append(ps.Events, fmt.Sprintf("%d species in evolutionary stagnation (oldest: %s, unchanged %d years)",
+			len(stagnant), oldest.Name, oldest.YearsStatic))
+	}
+	return stagnant
+}
@@ -0,0 +1,96 @@
+package population
+
+import (
+	"testing"
+
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+// newStagnantSim builds a world at year 5M with one species whose variance sits
+// at the floor in two biomes and one still-variable species
+func newStagnantSim() (*PopulationSimulator, uuid.UUID, uuid.UUID) {
+	sim := NewPopulationSimulator(uuid.New(), 1)
+	sim.CurrentYear = 5_000_000
+
+	relicID, livelyID := uuid.New(), uuid.New()
+	for i := 0; i < 2; i++ {
+		biome := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+		biome.AddSpecies(&SpeciesPopulation{SpeciesID: relicID, Name: "Relic", Count: 500, TraitVariance: 0.01})
+		biome.AddSpecies(&SpeciesPopulation{SpeciesID: livelyID, Name: "Lively", Count: 500, TraitVariance: 0.4})
+		sim.Biomes[biome.BiomeID] = biome
+	}
+	return sim, relicID, livelyID
+}
+
+func TestDetectStagnation_FlagsVarianceAtFloor(t *testing.T) {
+	sim, relicID, _ := newStagnantSim()
+
+	stagnant := sim.DetectStagnation()
+	if len(stagnant) != 1 || stagnant[0].SpeciesID != relicID {
+		t.Fatalf("Expected only the floor-variance species to be stagnant, got %+v", stagnant)
+	}
+	if stagnant[0].YearsStatic != 5_000_000 {
+		t.Errorf("YearsStatic = %d, want 5000000", stagnant[0].YearsStatic)
+	}
+}
+
+func TestDetectStagnation_RecentLineageNotStagnant(t *testing.T) {
+	sim, relicID, _ := newStagnantSim()
+
+	// A young descendant means the lineage is still producing species
+	for _, biome := range sim.Biomes {
+		biome.AddSpecies(&SpeciesPopulation{SpeciesID: uuid.New(), Name: "Offshoot", Count: 100,
+			TraitVariance: 0.3, AncestorID: &relicID, CreatedYear: sim.CurrentYear - 10_000})
+		break
+	}
+	if stagnant := sim.DetectStagnation(); len(stagnant) != 0 {
+		t.Errorf("Lineage that speciated recently should not be stagnant, got %+v", stagnant)
+	}
+
+	// Likewise a young species at the floor hasn't had time to stagnate
+	young, _, _ := newStagnantSim()
+	for _, biome := range young.Biomes {
+		for _, sp := range biome.Species {
+			sp.CreatedYear = young.CurrentYear - StagnationMinYears/2
+		}
+	}
+	if stagnant := young.DetectStagnation(); len(stagnant) != 0 {
+		t.Errorf("Species younger than %d years should not be stagnant, got %+v", StagnationMinYears, stagnant)
+	}
+}
+
+func TestApplyStagnationCheck_ReportsWithoutPulse(t *testing.T) {
+	sim, relicID, _ := newStagnantSim()
+
+	if stagnant := sim.ApplyStagnationCheck(false); len(stagnant) != 1 {
+		t.Fatalf("Expected 1 stagnant species, got %d", len(stagnant))
+	}
+	if len(sim.Events) != 1 {
+		t.Errorf("Stagnation should be logged to Events, got %v", sim.Events)
+	}
+	for _, biome := range sim.Biomes {
+		if v := biome.Species[relicID].TraitVariance; v != 0.01 {
+			t.Errorf("Variance should be untouched with the pulse off, got %.3f", v)
+		}
+	}
+}
+
+func TestApplyStagnationCheck_MutationPulseRestoresVariance(t *testing.T) {
+	sim, relicID, livelyID := newStagnantSim()
+
+	sim.ApplyStagnationCheck(true)
+
+	for _, biome := range sim.Biomes {
+		if v := biome.Species[relicID].TraitVariance; v <= StagnationVarianceThreshold {
+			t.Errorf("Mutation pulse should lift variance above %.2f, got %.3f", StagnationVarianceThreshold, v)
+		}
+		if v := biome.Species[livelyID].TraitVariance; v != 0.4 {
+			t.Errorf("Pulse should only touch stagnant species, Lively variance %.3f", v)
+		}
+	}
+	if stagnant := sim.DetectStagnation(); len(stagnant) != 0 {
+		t.Errorf("Pulsed species should no longer be stagnant, got %+v", stagnant)
+	}
+}
@@ -1,6 +1,8 @@
 package population
 
 import (
+	"slices"
+
 	"tw-backend/internal/worldgen/geography"
 
 	"github.com/google/uuid"
@@ -216,6 +218,7 @@ type SpeciesPopulation struct {
 	AdultCount         int64           `json:"adult_count"`          // Reproductive adults
 	Traits             EvolvableTraits `json:"traits"`               // Average traits for population (legacy)
 	TraitVariance      float64         `json:"trait_variance"`       // Genetic diversity (0.0 to 1.0)
+	GeneticDiversity   float64         `json:"genetic_diversity"`    // Heterozygosity (0.01-1; 0 = not yet tracked, see ApplyInbreedingDepression)
 	Diet               DietType        `json:"diet"`
 	Generation         int64           `json:"generation"`   // Evolutionary generation
 	CreatedYear        int64           `json:"created_year"` // Year this species evolved
@@ -233,6 +236,16 @@ type BiomePopulation struct {
 	CarryingCapacity int64                            `json:"carrying_capacity"` // Max total population
 	Fragmentation    float64                          `json:"fragmentation"`     // 0.0 = connected, 1.0 = isolated patches
 	YearsSimulated   int64                            `json:"years_simulated"`
+	Cells            int                              `json:"cells,omitempty"`         // Hex cells the biome covers (see UpdateEcotones)
+	EcotoneCells     int                              `json:"ecotone_cells,omitempty"` // Of those, cells bordering another biome type
+
+	// Climate, when set (see SetClimate), drives CarryingCapacity
+	Temperature float64 `json:"temperature,omitempty"` // Mean annual °C
+	Rainfall    float64 `json:"rainfall,omitempty"`    // mm/year
+	Area        float64 `json:"area,omitempty"`        // km²; 0 keeps CarryingCapacity fixed
+	Degradation float64 `json:"degradation,omitempty"` // 0-1 share of productivity lost to catastrophes, healing over time
+
+	diagnostics map[uuid.UUID]*speciesYearRecord // Last step's factors per species (see DiagnoseSpecies)
 }
 
 // ExtinctSpecies records a species that has died out
@@ -248,6 +261,10 @@ type ExtinctSpecies struct {
 	ExtinctionDetails string          `json:"extinction_details"` // Rich lore, e.g., "Great Ash Winter of era 4B"
 	FossilBiomes      []uuid.UUID     `json:"fossil_biomes"`      // Biomes where fossils can be found
 
+	// Species it evolved from (nil for founders), so extinct lineages can
+	// still be traced
+	AncestorID *uuid.UUID `json:"ancestor_id,omitempty"`
+
 	// V2 Genetic System (optional - populated for V2 species)
 	GeneticCode    *GeneticCode    `json:"genetic_code,omitempty"`
 	OrganismTraits *OrganismTraits `json:"organism_traits,omitempty"`
@@ -293,6 +310,19 @@ func (bp *BiomePopulation) TotalPopulation() int64 {
 	return total
 }
 
+// sortedSpecies returns the biome's species in ID order, so anything
+// drawing from the rng per species does so in the same order every run
+func (bp *BiomePopulation) sortedSpecies() []*SpeciesPopulation {
+	species := make([]*SpeciesPopulation, 0, len(bp.Species))
+	for _, sp := range bp.Species {
+		species = append(species, sp)
+	}
+	slices.SortFunc(species, func(a, b *SpeciesPopulation) int {
+		return compareIDs(a.SpeciesID, b.SpeciesID)
+	})
+	return species
+}
+
 // AddSpecies adds a new species to the biome
 func (bp *BiomePopulation) AddSpecies(species *SpeciesPopulation) {
 	bp.Species[species.SpeciesID] = species
@@ -0,0 +1,103 @@
+package population
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// SizeClass groups species by body size for their minimum viable population
+type SizeClass string
+
+const (
+	SizeClassSmall  SizeClass = "small"  // Below size 1 (mice, songbirds)
+	SizeClassMedium SizeClass = "medium" // Size 1 to 5 (deer, wolves)
+	SizeClassLarge  SizeClass = "large"  // Size 5 and up (elephants)
+)
+
+// SizeClassOf returns the size class for a species' Size trait
+func SizeClassOf(size float64) SizeClass {
+	switch {
+	case size < 1:
+		return SizeClassSmall
+	case size < 5:
+		return SizeClassMedium
+	}
+	return SizeClassLarge
+}
+
+// DefaultMinViablePopulation is the count below which a species of each size
+// class enters the extinction vortex. Small, short-lived animals swing
+// harder from year to year, so need more individuals to ride it out.
+var DefaultMinViablePopulation = map[SizeClass]int64{
+	SizeClassSmall:  20,
+	SizeClassMedium: 10,
+	SizeClassLarge:  5,
+}
+
+const (
+	// VortexMortality is the share of a population lost each year to the
+	// extinction vortex when it is all but gone, scaling down to nothing at
+	// the minimum viable population
+	VortexMortality = 0.6
+
+	// VortexVarianceLoss is the share of trait variance and genetic
+	// diversity lost each year at the bottom of the vortex, scaled the same
+	// way
+	VortexVarianceLoss = 0.3
+)
+
+// minViablePopulation returns the MVP for a species on this simulator
+func (ps *PopulationSimulator) minViablePopulation(sp *SpeciesPopulation) int64 {
+	class := SizeClassOf(sp.Traits.Size)
+	if mvp, ok := ps.MinViablePopulation[class]; ok {
+		return mvp
+	}
+	return DefaultMinViablePopulation[class]
+}
+
+// ApplyExtinctionVortex culls animal species that have fallen below their
+// minimum viable population. Inbreeding depression and demographic chance
+// kill each individual with a probability that rises the further the
+// species falls short, and its trait variance and genetic diversity
+// collapse, so the decline feeds on itself and usually ends in extinction
+// unless migrants arrive. Returns the number of species in the vortex.
+func (ps *PopulationSimulator) ApplyExtinctionVortex() int {
+	inVortex := 0
+	for _, biome := range ps.sortedBiomes() {
+		var toExtinct []uuid.UUID
+		for _, species := range biome.sortedSpecies() {
+			if species.Count <= 0 || species.Diet == DietPhotosynthetic {
+				continue // Flora persist in seed banks and clones
+			}
+			mvp := ps.minViablePopulation(species)
+			if species.Count >= mvp {
+				continue
+			}
+			inVortex++
+
+			deficit := 1 - float64(species.Count)/float64(mvp)
+			mortality := VortexMortality * deficit
+			var deaths int64
+			for i := int64(0); i < species.Count; i++ {
+				if ps.rng.Float64() < mortality {
+					deaths++
+				}
+			}
+			species.Count -= deaths
+			biome.recordLoss(species.SpeciesID, ps.CurrentYear, factorSmallPopulation, deaths)
+
+			species.TraitVariance = math.Max(0.01, species.TraitVariance*(1-VortexVarianceLoss*deficit))
+			if species.GeneticDiversity > 0 {
+				species.GeneticDiversity = math.Max(MinGeneticDiversity, species.GeneticDiversity*(1-VortexVarianceLoss*deficit))
+			}
+			if species.Count <= 0 {
+				toExtinct = append(toExtinct, species.SpeciesID)
+			}
+		}
+		for _, id := range toExtinct {
+			ps.recordExtinction(biome, id, "extinction_vortex")
+		}
+	}
+	return inVortex
+}
@@ -0,0 +1,152 @@
+package population
+
+import (
+	"testing"
+
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+// smallHerd returns a simulator with a grassland of plenty and one herd of
+// grazers of the given size
+func smallHerd(seed int64, count int64) (*PopulationSimulator, *BiomePopulation, *SpeciesPopulation) {
+	sim := NewPopulationSimulator(uuid.New(), seed)
+	biome := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.AddSpecies(&SpeciesPopulation{
+		SpeciesID: uuid.New(), Name: "Grass", Count: 1000,
+		Traits: DefaultTraitsForDiet(DietPhotosynthetic), Diet: DietPhotosynthetic,
+	})
+	grazer := &SpeciesPopulation{
+		SpeciesID: uuid.New(), Name: "Grazer", Count: count, TraitVariance: 0.5,
+		Traits: DefaultTraitsForDiet(DietHerbivore), Diet: DietHerbivore,
+	}
+	biome.AddSpecies(grazer)
+	sim.Biomes[biome.BiomeID] = biome
+	return sim, biome, grazer
+}
+
+func TestSizeClassOf(t *testing.T) {
+	tests := []struct {
+		size float64
+		want SizeClass
+	}{
+		{0.1, SizeClassSmall},
+		{1.0, SizeClassMedium},
+		{4.9, SizeClassMedium},
+		{5.0, SizeClassLarge},
+		{10.0, SizeClassLarge},
+	}
+	for _, tt := range tests {
+		if got := SizeClassOf(tt.size); got != tt.want {
+			t.Errorf("SizeClassOf(%.1f) = %s, want %s", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestApplyExtinctionVortex_AboveMVPUnaffected(t *testing.T) {
+	mvp := DefaultMinViablePopulation[SizeClassMedium]
+	sim, _, grazer := smallHerd(1, mvp)
+
+	if n := sim.ApplyExtinctionVortex(); n != 0 {
+		t.Errorf("Expected no species in the vortex, got %d", n)
+	}
+	if grazer.Count != mvp || grazer.TraitVariance != 0.5 {
+		t.Errorf("A viable herd shouldn't be touched: count %d, variance %f", grazer.Count, grazer.TraitVariance)
+	}
+}
+
+func TestApplyExtinctionVortex_DeclineAccelerates(t *testing.T) {
+	// The smaller the herd, the larger the share it loses each year
+	lossRate := func(start int64) float64 {
+		var lost, total int64
+		for seed := int64(0); seed < 200; seed++ {
+			sim, _, grazer := smallHerd(seed, start)
+			if sim.ApplyExtinctionVortex() != 1 {
+				t.Fatalf("A herd of %d should be in the vortex", start)
+			}
+			lost += start - grazer.Count
+			total += start
+		}
+		return float64(lost) / float64(total)
+	}
+
+	near, deep := lossRate(8), lossRate(3)
+	t.Logf("Yearly loss: %.2f at 8, %.2f at 3", near, deep)
+	if near <= 0 {
+		t.Error("A herd just under the MVP should still suffer losses")
+	}
+	if deep <= near*2 {
+		t.Errorf("A herd deep in the vortex should decline much faster: %.2f vs %.2f", deep, near)
+	}
+}
+
+func TestApplyExtinctionVortex_CollapsesVariance(t *testing.T) {
+	sim, _, grazer := smallHerd(1, 5)
+
+	sim.ApplyExtinctionVortex()
+
+	if grazer.TraitVariance >= 0.5 {
+		t.Errorf("Trait variance should collapse in the vortex, got %f", grazer.TraitVariance)
+	}
+}
+
+func TestSimulateYear_VortexUsuallyFinishesSpeciesOff(t *testing.T) {
+	extinctions := func(mvp map[SizeClass]int64) int {
+		extinct := 0
+		for seed := int64(0); seed < 20; seed++ {
+			sim, biome, grazer := smallHerd(seed, 3)
+			sim.MinViablePopulation = mvp
+			for i := 0; i < 30 && biome.Species[grazer.SpeciesID] != nil; i++ {
+				sim.SimulateYear()
+			}
+			if biome.Species[grazer.SpeciesID] == nil {
+				extinct++
+			}
+		}
+		return extinct
+	}
+
+	withVortex := extinctions(nil)
+	without := extinctions(map[SizeClass]int64{SizeClassMedium: 0})
+	t.Logf("Herds of 3 gone within 30 years: %d/20 with the vortex, %d/20 without", withVortex, without)
+	if withVortex < 14 {
+		t.Errorf("The vortex should usually finish a herd of 3 off, got %d/20", withVortex)
+	}
+	if without >= withVortex {
+		t.Errorf("Disabling the vortex should spare herds: %d vs %d", without, withVortex)
+	}
+}
+
+func TestApplyExtinctionVortex_RecordsExtinction(t *testing.T) {
+	sim, biome, grazer := smallHerd(1, 1)
+	id := grazer.SpeciesID
+
+	for i := 0; i < 100 && biome.Species[id] != nil; i++ {
+		sim.ApplyExtinctionVortex()
+	}
+
+	if biome.Species[id] != nil {
+		t.Fatal("A lone survivor should die out")
+	}
+	var found bool
+	for _, e := range sim.FossilRecord.Extinct {
+		if e.SpeciesID == id {
+			found = e.ExtinctionCause == "extinction_vortex"
+		}
+	}
+	if !found {
+		t.Error("Expected the extinction to be recorded as an extinction vortex")
+	}
+}
+
+func TestApplyExtinctionVortex_CollapsesGeneticDiversity(t *testing.T) {
+	sim, _, grazer := smallHerd(1, 5)
+	grazer.GeneticDiversity = 0.5
+
+	sim.ApplyExtinctionVortex()
+
+	if grazer.GeneticDiversity >= 0.5 {
+		t.Errorf("Genetic diversity should collapse in the vortex, got %f", grazer.GeneticDiversity)
+	}
+}
@@ -0,0 +1,113 @@
+package population
+
+import (
+	"fmt"
+	"math"
+
+	"tw-backend/internal/worldgen/geography"
+)
+
+const (
+	// WildfireBaseChance is the per-biome chance of a lightning-started fire per
+	// check at modern oxygen (21%)
+	WildfireBaseChance = 0.05
+
+	// WildfireMinOxygen is the oxygen level below which fires can't sustain (~15%)
+	WildfireMinOxygen = 0.15
+
+	// wildfireBaseBurn is the fraction of a biome's flora burned by one fire at 21% O2
+	wildfireBaseBurn = 0.05
+
+	// wildfireMaxBurn caps the flora lost to a single fire
+	wildfireMaxBurn = 0.8
+
+	// CO2PerFloraBurned is the CO2 (atm) returned to the atmosphere per plant burned
+	CO2PerFloraBurned = 1.0e-10
+)
+
+// WildfireResult summarizes one round of wildfires
+type WildfireResult struct {
+	Fires       int     // Biomes that burned
+	FloraBurned int64   // Photosynthetic individuals lost
+	CO2Released float64 // Atmospheric CO2 added (atm), for the atmosphere model
+}
+
+// CalculateWildfireIgnitionChance returns the chance a lightning strike starts a
+// sustained fire. Combustion rises steeply with oxygen: none below 15%, the base
+// chance at 21%, and several times that at Carboniferous levels (>25%).
+func CalculateWildfireIgnitionChance(oxygenLevel float64) float64 {
+	if oxygenLevel <= WildfireMinOxygen {
+		return 0
+	}
+	excess := (oxygenLevel - WildfireMinOxygen) / (0.21 - WildfireMinOxygen)
+	return math.Min(1.0, WildfireBaseChance*excess*excess)
+}
+
+// CalculateWildfireSpread returns the fraction of a biome's flora one fire burns
+func CalculateWildfireSpread(oxygenLevel float64) float64 {
+	if oxygenLevel <= WildfireMinOxygen {
+		return 0
+	}
+	ratio := oxygenLevel / 0.21
+	return math.Min(wildfireMaxBurn, wildfireBaseBurn*ratio*ratio)
+}
+
+// wildfireFuelFactor scales ignition by how much dry fuel a biome holds
+func wildfireFuelFactor(biomeType geography.BiomeType) float64 {
+	switch biomeType {
+	case geography.BiomeOcean:
+		return 0
+	case geography.BiomeRainforest, geography.BiomeTundra, geography.BiomeAlpine, geography.BiomeHighMountain:
+		return 0.3 // Too wet, too cold, or too sparse to burn often
+	case geography.BiomeDesert:
+		return 0.2 // Little fuel
+	case geography.BiomeGrassland, geography.BiomeTaiga:
+		return 1.5 // Dry grass and resinous conifers burn readily
+	default:
+		return 1.0
+	}
+}
+
+// ApplyWildfires rolls for fires in each biome with flora. Ignition and spread
+// scale with OxygenLevel; burned flora is removed and its carbon reported as
+// CO2Released so callers can return it to the atmosphere model.
+func (ps *PopulationSimulator) ApplyWildfires() WildfireResult {
+	var result WildfireResult
+
+	ignition := CalculateWildfireIgnitionChance(ps.OxygenLevel)
+	if ignition <= 0 {
+		return result
+	}
+	spread := CalculateWildfireSpread(ps.OxygenLevel)
+
+	// Stable order so a seed reproduces the same fires
+	for _, biome := range ps.sortedBiomes() {
+		var flora []*SpeciesPopulation
+		for _, sp := range biome.sortedSpecies() {
+			if sp.Diet == DietPhotosynthetic && sp.Count > 0 {
+				flora = append(flora, sp)
+			}
+		}
+		if len(flora) == 0 {
+			continue
+		}
+		if ps.rng.Float64() >= ignition*wildfireFuelFactor(biome.BiomeType) {
+			continue
+		}
+
+		result.Fires++
+		for _, sp := range flora {
+			burned := int64(float64(sp.Count) * spread)
+			sp.Count -= burned
+			biome.recordLoss(sp.SpeciesID, ps.CurrentYear, factorEvents, burned)
+			result.FloraBurned += burned
+		}
+	}
+
+	result.CO2Released = float64(result.FloraBurned) * CO2PerFloraBurned
+	if result.Fires > 0 {
+		ps.Events = append(ps.Events, fmt.Sprintf("Wildfires burned %d biomes (%d plants lost, O2 %.1f%%)",
+			result.Fires, result.FloraBurned, ps.OxygenLevel*100))
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package population
+
+import (
+	"testing"
+
+	"tw-backend/internal/ecosystem/atmosphere"
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+)
+
+// newFireSim builds a simulator with several grassland biomes of flora
+func newFireSim(seed int64, oxygen float64) *PopulationSimulator {
+	sim := NewPopulationSimulator(uuid.New(), seed)
+	sim.OxygenLevel = oxygen
+	for i := 0; i < 20; i++ {
+		biome := NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+		biome.AddSpecies(&SpeciesPopulation{SpeciesID: uuid.New(), Name: "Grass", Count: 100000, Diet: DietPhotosynthetic})
+		sim.Biomes[biome.BiomeID] = biome
+	}
+	return sim
+}
+
+func totalFlora(sim *PopulationSimulator) int64 {
+	var total int64
+	for _, biome := range sim.Biomes {
+		for _, sp := range biome.Species {
+			if sp.Diet == DietPhotosynthetic {
+				total += sp.Count
+			}
+		}
+	}
+	return total
+}
+
+func TestWildfireIgnitionRisesWithOxygen(t *testing.T) {
+	low := CalculateWildfireIgnitionChance(0.18)
+	modern := CalculateWildfireIgnitionChance(0.21)
+	carboniferous := CalculateWildfireIgnitionChance(0.30)
+
+	if CalculateWildfireIgnitionChance(0.15) != 0 {
+		t.Error("Fires should not sustain at 15% O2")
+	}
+	if !(low < modern && modern < carboniferous) {
+		t.Errorf("Ignition should rise with O2: 18%%=%.3f 21%%=%.3f 30%%=%.3f", low, modern, carboniferous)
+	}
+	if CalculateWildfireSpread(0.30) <= CalculateWildfireSpread(0.21) {
+		t.Error("Fires should spread further at high O2")
+	}
+}
+
+func TestApplyWildfires_HighOxygenBurnsMore(t *testing.T) {
+	countFires := func(oxygen float64) int {
+		sim := newFireSim(7, oxygen)
+		fires := 0
+		for i := 0; i < 50; i++ {
+			fires += sim.ApplyWildfires().Fires
+		}
+		return fires
+	}
+
+	modern := countFires(0.21)
+	carboniferous := countFires(0.30)
+	if carboniferous <= modern {
+		t.Errorf("High-O2 world should burn more often: 30%% O2 %d fires, 21%% O2 %d fires", carboniferous, modern)
+	}
+}
+
+func TestApplyWildfires_ReducesFloraAndReleasesCO2(t *testing.T) {
+	sim := newFireSim(11, 0.32)
+	atm := atmosphere.NewAtmosphere(4_500_000_000)
+
+	floraBefore := totalFlora(sim)
+	co2Before := atm.GetStats().CO2_ppm
+
+	var burned int64
+	for i := 0; i < 10; i++ {
+		result := sim.ApplyWildfires()
+		burned += result.FloraBurned
+		atm.AddCO2(result.CO2Released)
+	}
+
+	if burned == 0 {
+		t.Fatal("Expected wildfires at 32% O2")
+	}
+	if got := totalFlora(sim); got != floraBefore-burned {
+		t.Errorf("Flora should drop by the burned amount: before %d, burned %d, after %d", floraBefore, burned, got)
+	}
+	if atm.GetStats().CO2_ppm <= co2Before {
+		t.Errorf("Burned carbon should raise CO2: before %.2f ppm, after %.2f ppm", co2Before, atm.GetStats().CO2_ppm)
+	}
+}
+
+func TestApplyWildfires_LowOxygenNoFires(t *testing.T) {
+	sim := newFireSim(3, 0.15)
+	if result := sim.ApplyWildfires(); result.Fires != 0 || result.CO2Released != 0 {
+		t.Errorf("No fires expected at 15%% O2, got %+v", result)
+	}
+}
@@ -0,0 +1,135 @@
+package ecosystem
+
+import (
+	"log"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultProgressSteps reports progress every 10%
+	DefaultProgressSteps = 10
+	// MaxProgressInterval caps the years between reports so very long runs stay responsive
+	MaxProgressInterval = int64(10_000_000)
+)
+
+// ProgressUpdate describes how far a simulation has advanced
+type ProgressUpdate struct {
+	Year       int64 `json:"year"`
+	StartYear  int64 `json:"start_year"`
+	TargetYear int64 `json:"target_year"`
+	Percent    int   `json:"percent"`
+
+	// Population stats, only set when the tracker has a stats source
+	HasStats   bool  `json:"has_stats"`
+	Population int64 `json:"population,omitempty"`
+	Species    int64 `json:"species,omitempty"`
+	Extinct    int64 `json:"extinct,omitempty"`
+}
+
+// ProgressReporter receives progress updates (client, logs, or a test sink)
+type ProgressReporter interface {
+	ReportProgress(update ProgressUpdate)
+}
+
+// ProgressReporterFunc adapts a function to ProgressReporter
+type ProgressReporterFunc func(update ProgressUpdate)
+
+// ReportProgress calls f(update)
+func (f ProgressReporterFunc) ReportProgress(update ProgressUpdate) {
+	f(update)
+}
+
+// LogProgressReporter writes progress to the standard logger
+type LogProgressReporter struct {
+	WorldID uuid.UUID
+}
+
+// ReportProgress logs the update
+func (r LogProgressReporter) ReportProgress(update ProgressUpdate) {
+	if update.HasStats {
+		log.Printf("[SIM %s] Progress: %d%% (Year %d, Pop: %d, Species: %d, Extinct: %d)",
+			r.WorldID, update.Percent, update.Year, update.Population, update.Species, update.Extinct)
+		return
+	}
+	log.Printf("[SIM %s] Progress: %d%% (Year %d)", r.WorldID, update.Percent, update.Year)
+}
+
+// ProgressStatsFunc supplies population stats for progress updates
+type ProgressStatsFunc func() (population, species, extinct int64)
+
+// ProgressTracker decides when a run has advanced far enough to report
+type ProgressTracker struct {
+	reporter     ProgressReporter
+	stats        ProgressStatsFunc
+	startYear    int64
+	targetYear   int64
+	interval     int64
+	lastReported int64
+}
+
+// NewProgressTracker reports roughly `steps` times between startYear and targetYear.
+// Non-positive steps use DefaultProgressSteps; the interval never exceeds MaxProgressInterval.
+func NewProgressTracker(reporter ProgressReporter, startYear, targetYear int64, steps int) *ProgressTracker {
+	if steps <= 0 {
+		steps = DefaultProgressSteps
+	}
+	interval := (targetYear - startYear) / int64(steps)
+	if interval > MaxProgressInterval {
+		interval = MaxProgressInterval
+	}
+	return &ProgressTracker{
+		reporter:     reporter,
+		startYear:    startYear,
+		targetYear:   targetYear,
+		interval:     interval,
+		lastReported: startYear,
+	}
+}
+
+// SetStatsSource attaches population stats to future updates
+func (t *ProgressTracker) SetStatsSource(stats ProgressStatsFunc) {
+	t.stats = stats
+}
+
+// Interval returns the number of years between reports (0 disables reporting)
+func (t *ProgressTracker) Interval() int64 {
+	return t.interval
+}
+
+// TrackGeology has g's SimulateGeology drive the tracker through its
+// progress callback (see WorldGeology.SetProgressFunc), observing every
+// Interval years. A nil tracker turns g's progress reporting off.
+func (t *ProgressTracker) TrackGeology(g *WorldGeology) {
+	if t == nil {
+		g.SetProgressFunc(0, nil)
+		return
+	}
+	g.SetProgressFunc(t.interval, func(yearsSimulated int64, _ GeologyStats) {
+		t.Observe(yearsSimulated)
+	})
+}
+
+// Observe records that the simulation reached year, reporting if an interval has
+// elapsed since the last report. Returns true when a report was sent.
+func (t *ProgressTracker) Observe(year int64) bool {
+	if t == nil || t.reporter == nil || t.interval <= 0 || year-t.lastReported < t.interval {
+		return false
+	}
+	t.lastReported = year
+
+	update := ProgressUpdate{
+		Year:       year,
+		StartYear:  t.startYear,
+		TargetYear: t.targetYear,
+	}
+	if span := t.targetYear - t.startYear; span > 0 {
+		update.Percent = int(((year - t.startYear) * 100) / span)
+	}
+	if t.stats != nil {
+		update.HasStats = true
+		update.Population, update.Species, update.Extinct = t.stats()
+	}
+	t.reporter.ReportProgress(update)
+	return true
+}
@@ -0,0 +1,153 @@
+package ecosystem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// progressSink records every update it receives
+type progressSink struct {
+	updates []ProgressUpdate
+}
+
+func (s *progressSink) ReportProgress(update ProgressUpdate) {
+	s.updates = append(s.updates, update)
+}
+
+func (s *progressSink) percents() []int {
+	out := make([]int, len(s.updates))
+	for i, u := range s.updates {
+		out[i] = u.Percent
+	}
+	return out
+}
+
+// observeYears feeds every year from start to target (exclusive) into the tracker
+func observeYears(tracker *ProgressTracker, start, target, step int64) {
+	for year := start; year < target; year += step {
+		tracker.Observe(year)
+	}
+}
+
+func TestProgressTracker_DefaultFiresEveryTenPercent(t *testing.T) {
+	tests := []struct {
+		name   string
+		target int64
+		step   int64
+	}{
+		{"1k years", 1_000, 1},
+		{"1M years", 1_000_000, 100},
+		{"50M years", 50_000_000, 10_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &progressSink{}
+			tracker := NewProgressTracker(sink, 0, tt.target, 0)
+			observeYears(tracker, 0, tt.target, tt.step)
+			assert.Equal(t, []int{10, 20, 30, 40, 50, 60, 70, 80, 90}, sink.percents())
+		})
+	}
+}
+
+func TestProgressTracker_IntervalCappedForLongRuns(t *testing.T) {
+	tracker := NewProgressTracker(&progressSink{}, 0, 1_000_000_000, DefaultProgressSteps)
+	assert.Equal(t, MaxProgressInterval, tracker.Interval())
+}
+
+func TestProgressTracker_CustomGranularity(t *testing.T) {
+	sink := &progressSink{}
+	tracker := NewProgressTracker(sink, 0, 1_000, 4)
+	observeYears(tracker, 0, 1_000, 1)
+	assert.Equal(t, []int{25, 50, 75}, sink.percents())
+
+	fine := &progressSink{}
+	tracker = NewProgressTracker(fine, 0, 1_000, 20)
+	observeYears(tracker, 0, 1_000, 1)
+	assert.Len(t, fine.updates, 19, "Finer granularity should report more often")
+}
+
+func TestProgressTracker_ContinuationReportsRelativeToStart(t *testing.T) {
+	sink := &progressSink{}
+	tracker := NewProgressTracker(sink, 1_000, 2_000, 2)
+	observeYears(tracker, 1_000, 2_000, 1)
+	require.Len(t, sink.updates, 1)
+	assert.Equal(t, 50, sink.updates[0].Percent)
+	assert.Equal(t, int64(1_500), sink.updates[0].Year)
+}
+
+func TestProgressTracker_IncludesStats(t *testing.T) {
+	sink := &progressSink{}
+	tracker := NewProgressTracker(sink, 0, 100, 2)
+	tracker.SetStatsSource(func() (int64, int64, int64) { return 500, 7, 2 })
+	tracker.Observe(50)
+	require.Len(t, sink.updates, 1)
+	assert.True(t, sink.updates[0].HasStats)
+	assert.Equal(t, int64(500), sink.updates[0].Population)
+	assert.Equal(t, int64(7), sink.updates[0].Species)
+	assert.Equal(t, int64(2), sink.updates[0].Extinct)
+}
+
+func TestProgressTracker_NilAndEmptyAreNoOps(t *testing.T) {
+	var tracker *ProgressTracker
+	assert.False(t, tracker.Observe(100))
+
+	sink := &progressSink{}
+	tracker = NewProgressTracker(sink, 0, 5, DefaultProgressSteps) // interval rounds to 0
+	assert.False(t, tracker.Observe(4))
+	assert.Empty(t, sink.updates)
+}
+
+func TestSimulationRunner_ProgressReporter(t *testing.T) {
+	config := DefaultConfig(uuid.New())
+	config.TickInterval = time.Millisecond
+	config.Speed = SpeedNormal // 10 years per tick
+	config.MaxYearTarget = 100
+	config.PauseOnTurning = false
+
+	runner := NewSimulationRunner(config, nil, nil)
+	runner.InitializePopulationSimulator(123)
+
+	updates := make(chan ProgressUpdate, 32)
+	runner.SetProgressReporter(ProgressReporterFunc(func(u ProgressUpdate) { updates <- u }), 5)
+
+	require.NoError(t, runner.Start(0))
+	deadline := time.After(2 * time.Second)
+	var got []int
+	for len(got) < 4 {
+		select {
+		case u := <-updates:
+			assert.True(t, u.HasStats, "Runner progress should carry population stats")
+			got = append(got, u.Percent)
+		case <-deadline:
+			t.Fatalf("timed out waiting for progress, got %v", got)
+		}
+	}
+	runner.Stop()
+	assert.Equal(t, []int{20, 40, 60, 80}, got)
+}
+
+func TestProgressTracker_TrackGeology(t *testing.T) {
+	g := NewWorldGeology(uuid.New(), 7, 1_000_000)
+	g.InitializeGeology()
+
+	sink := &progressSink{}
+	tracker := NewProgressTracker(sink, 0, 2_000_000, 4)
+	tracker.TrackGeology(g)
+
+	// The geology's own steps drive the tracker; nothing calls Observe
+	for i := 0; i < 20; i++ {
+		g.SimulateGeology(100_000, 0)
+	}
+	assert.Equal(t, []int{25, 50, 75, 100}, sink.percents())
+
+	// A nil tracker detaches the callback
+	var none *ProgressTracker
+	none.TrackGeology(g)
+	g.SimulateGeology(500_000, 0)
+	assert.Len(t, sink.updates, 4)
+}
@@ -4,15 +4,19 @@ package ecosystem
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"slices"
 	"sync"
 	"time"
 
 	"tw-backend/internal/ecosystem/pathogen"
 	"tw-backend/internal/ecosystem/population"
 	"tw-backend/internal/ecosystem/sapience"
+	"tw-backend/internal/eventstore"
 	"tw-backend/internal/worldgen/astronomy"
+	"tw-backend/internal/worldgen/geography"
 
 	"github.com/google/uuid"
 )
@@ -41,12 +45,17 @@ const (
 
 // SimulationConfig holds configuration for the runner
 type SimulationConfig struct {
-	WorldID          uuid.UUID       `json:"world_id"`
-	TickInterval     time.Duration   `json:"tick_interval"`     // Real-world time between ticks
-	SnapshotInterval int64           `json:"snapshot_interval"` // Simulation years between snapshots
-	Speed            SimulationSpeed `json:"speed"`
-	MaxYearTarget    int64           `json:"max_year_target"`  // Stop at this year (0 = no limit)
-	PauseOnTurning   bool            `json:"pause_on_turning"` // Pause when turning point triggers
+	WorldID          uuid.UUID              `json:"world_id"`
+	TickInterval     time.Duration          `json:"tick_interval"`     // Real-world time between ticks
+	SnapshotInterval int64                  `json:"snapshot_interval"` // Simulation years between snapshots
+	Speed            SimulationSpeed        `json:"speed"`
+	MaxYearTarget    int64                  `json:"max_year_target"`        // Stop at this year (0 = no limit)
+	PauseOnTurning   bool                   `json:"pause_on_turning"`       // Pause when turning point triggers
+	MutationPulse    bool                   `json:"mutation_pulse"`         // Revive stagnant species with a variance boost
+	NamingTheme      population.NamingTheme `json:"naming_theme,omitempty"` // Species-name collision style ("" = classic)
+	Seed             int64                  `json:"seed,omitempty"`         // World seed (0 = derived from WorldID)
+
+	GeologyCheckpointInterval int64 `json:"geology_checkpoint_interval,omitempty"` // Years between RunGeologyOnly checkpoints (0 = default)
 }
 
 // DefaultConfig returns a default simulation configuration
@@ -79,6 +88,7 @@ type RunnerEvent struct {
 	Type        string     `json:"type"` // "speciation", "extinction", "sapience", etc.
 	Description string     `json:"description"`
 	SpeciesID   *uuid.UUID `json:"species_id,omitempty"`
+	SpeciesName string     `json:"species_name,omitempty"`
 	Importance  int        `json:"importance"` // 1-10
 }
 
@@ -112,6 +122,9 @@ type SimulationRunner struct {
 	climateDriver    *ClimateDriver // Orbital mechanics for ice ages (Phase 3)
 	snapshotRepo     *SimulationSnapshotRepository
 	stateRepo        *RunnerStateRepository
+	geologyStore     GeologyStore // Geology-only run checkpoints
+	fossils          *FossilArchiver
+	lifeEvents       *LifeEventPublisher
 
 	// Handlers
 	tickHandler           TickHandler
@@ -119,6 +132,11 @@ type SimulationRunner struct {
 	turningPointHandler   TurningPointHandler
 	eventBroadcastHandler EventBroadcastHandler
 
+	// Progress reporting toward MaxYearTarget
+	progressReporter ProgressReporter
+	progressSteps    int
+	progress         *ProgressTracker
+
 	// Control
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -133,17 +151,18 @@ type SimulationRunner struct {
 	snapshots    []*Snapshot
 
 	// Stats
-	tickCount      int64
-	yearsSimulated int64
-	startTime      time.Time
-	lastTickTime   time.Time
+	tickCount       int64
+	yearsSimulated  int64
+	startTime       time.Time
+	lastTickTime    time.Time
+	stagnantSpecies int
 }
 
 // NewSimulationRunner creates a new simulation runner
 func NewSimulationRunner(config SimulationConfig, snapshotRepo *SimulationSnapshotRepository, stateRepo *RunnerStateRepository) *SimulationRunner {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &SimulationRunner{
+	sr := &SimulationRunner{
 		config:              config,
 		state:               RunnerIdle,
 		ctx:                 ctx,
@@ -154,6 +173,17 @@ func NewSimulationRunner(config SimulationConfig, snapshotRepo *SimulationSnapsh
 		recentEvents:        make([]RunnerEvent, 0),
 		snapshots:           make([]*Snapshot, 0),
 	}
+	if snapshotRepo != nil {
+		sr.geologyStore = snapshotRepo
+	}
+	return sr
+}
+
+// Seed returns the world seed: config.Seed when set, otherwise WorldSeed(WorldID)
+func (sr *SimulationRunner) Seed() int64 {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	return ResolveSeed(sr.config.WorldID, sr.config.Seed)
 }
 
 // InitializePopulationSimulator sets up the internal V2 engine
@@ -168,12 +198,7 @@ func (sr *SimulationRunner) InitializePopulationSimulator(seed int64) {
 		sim, err := sr.snapshotRepo.LoadSnapshot(sr.ctx, sr.config.WorldID)
 		if err == nil && sim != nil {
 			fmt.Printf("Loaded existing simulation state for world %s (Year %d)\n", sr.config.WorldID, sim.CurrentYear)
-			// Re-initialize non-serialized systems
-			sim.InitializeGeographicSystems(sr.config.WorldID, seed)
-			sr.popSim = sim
-			sr.currentYear = sim.CurrentYear
-			// Initialize subsystems (not persisted separately)
-			sr.initializeSubsystems(seed)
+			sr.adoptPopulationSimulator(sim, seed)
 			return
 		} else if err != nil {
 			fmt.Printf("Error loading snapshot: %v\n", err)
@@ -184,12 +209,60 @@ func (sr *SimulationRunner) InitializePopulationSimulator(seed int64) {
 	fmt.Printf("Creating fresh population simulator for world %s\n", sr.config.WorldID)
 	sr.popSim = population.NewPopulationSimulator(sr.config.WorldID, seed)
 	sr.popSim.InitializeGeographicSystems(sr.config.WorldID, seed)
+	sr.popSim.SetNamingTheme(sr.config.NamingTheme)
 	sr.currentYear = 0
+	sr.applyBiomeClimates()
 
 	// Initialize subsystems
 	sr.initializeSubsystems(seed)
 }
 
+// RestorePopulationSimulator replaces the runner's population with a saved
+// one (see SimulationSnapshotRepository.LoadSnapshot), continuing from its
+// year. The runner must not be running.
+func (sr *SimulationRunner) RestorePopulationSimulator(sim *population.PopulationSimulator) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.adoptPopulationSimulator(sim, ResolveSeed(sr.config.WorldID, sr.config.Seed))
+}
+
+// ResetPopulation starts the runner's population over from year 0 with no
+// species (see PopulationSimulator.Reset), and persists it so the next
+// runner for the world doesn't restore the old one. The runner must not be
+// running.
+func (sr *SimulationRunner) ResetPopulation() {
+	sr.mu.Lock()
+	if sr.popSim != nil {
+		sr.popSim.Reset()
+	}
+	sr.currentYear = 0
+	sr.initializeSubsystems(ResolveSeed(sr.config.WorldID, sr.config.Seed))
+	sr.mu.Unlock()
+
+	sr.persistState()
+}
+
+// adoptPopulationSimulator makes a deserialized simulator the runner's,
+// rebuilding what isn't serialized. Callers hold sr.mu.
+func (sr *SimulationRunner) adoptPopulationSimulator(sim *population.PopulationSimulator, seed int64) {
+	// Re-initialize non-serialized systems. Offsetting the seed by the year
+	// keeps a restored world from replaying the draws of its first years.
+	sim.SetSeed(seed + sim.CurrentYear)
+	sim.InitializeGeographicSystems(sr.config.WorldID, seed)
+	if sr.config.NamingTheme != "" {
+		sim.SetNamingTheme(sr.config.NamingTheme)
+	}
+	sr.popSim = sim
+	sr.currentYear = sim.CurrentYear
+	sr.applyBiomeClimates()
+	// Its extinctions so far were published by whoever ran it
+	if sr.lifeEvents != nil {
+		sr.lifeEvents.SkipRecorded(sim)
+	}
+	// Initialize subsystems (not persisted separately)
+	sr.initializeSubsystems(seed)
+}
+
 // initializeSubsystems sets up disease, sapience, geology, and climate systems
 func (sr *SimulationRunner) initializeSubsystems(seed int64) {
 	// Initialize Disease System
@@ -234,6 +307,39 @@ func (sr *SimulationRunner) SetEventBroadcastHandler(handler EventBroadcastHandl
 	sr.eventBroadcastHandler = handler
 }
 
+// SetProgressReporter reports progress toward MaxYearTarget roughly `steps` times per run.
+// The reporter is called with the runner locked and must not call back into the runner.
+func (sr *SimulationRunner) SetProgressReporter(reporter ProgressReporter, steps int) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.progressReporter = reporter
+	sr.progressSteps = steps
+}
+
+// SetFossilStore archives extinct species to store as they die out, so the
+// fossil record survives the runner
+func (sr *SimulationRunner) SetFossilStore(store FossilStore) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	if store == nil {
+		sr.fossils = nil
+		return
+	}
+	sr.fossils = NewFossilArchiver(store, sr.config.WorldID)
+}
+
+// SetEventPublisher publishes species_extinct and sapience_achieved events
+// for other services. Extinctions already in the fossil record are not
+// published again.
+func (sr *SimulationRunner) SetEventPublisher(publisher *eventstore.Publisher) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.lifeEvents = nil
+	if publisher != nil {
+		sr.lifeEvents = NewLifeEventPublisher(publisher, sr.config.WorldID, sr.popSim)
+	}
+}
+
 // Start begins the simulation
 func (sr *SimulationRunner) Start(startYear int64) error {
 	sr.mu.Lock()
@@ -254,6 +360,11 @@ func (sr *SimulationRunner) Start(startYear int64) error {
 	}
 	sr.lastSnapshotYear = sr.currentYear
 	sr.lastSaveYear = sr.currentYear
+	sr.progress = nil
+	if sr.progressReporter != nil && sr.config.MaxYearTarget > sr.currentYear {
+		sr.progress = NewProgressTracker(sr.progressReporter, sr.currentYear, sr.config.MaxYearTarget, sr.progressSteps)
+		sr.progress.SetStatsSource(sr.popSim.GetStats)
+	}
 	sr.startTime = time.Now()
 	sr.lastTickTime = time.Now()
 	sr.mu.Unlock()
@@ -352,6 +463,7 @@ func (sr *SimulationRunner) GetStats() SimulationStats {
 		RealTimeElapsed: elapsed,
 		YearsPerSecond:  yearsPerSecond,
 		SnapshotCount:   len(sr.snapshots),
+		StagnantSpecies: sr.stagnantSpecies,
 	}
 }
 
@@ -364,6 +476,7 @@ type SimulationStats struct {
 	RealTimeElapsed time.Duration `json:"real_time_elapsed"`
 	YearsPerSecond  float64       `json:"years_per_second"`
 	SnapshotCount   int           `json:"snapshot_count"`
+	StagnantSpecies int           `json:"stagnant_species"` // At the last stagnation check
 }
 
 // UpdateConfig updates the simulation configuration
@@ -476,6 +589,8 @@ func (sr *SimulationRunner) tickLocked(yearsToAdvance int64) error {
 		if sr.popSim.CurrentYear%10000 == 0 {
 			sr.popSim.UpdateOxygenLevel()
 			sr.popSim.ApplyOxygenEffects()
+			// Runner has no atmosphere model, so burned carbon isn't tracked here
+			sr.popSim.ApplyWildfires()
 			if newSpecies := sr.popSim.CheckSpeciation(); newSpecies > 0 {
 				sr.broadcastEvent(RunnerEvent{
 					Year:        sr.popSim.CurrentYear,
@@ -494,6 +609,11 @@ func (sr *SimulationRunner) tickLocked(yearsToAdvance int64) error {
 		if sr.popSim.CurrentYear%100000 == 0 {
 			sr.updateGeology(100000)
 
+			// Evolutionary dead-ends (reported via popSim.Events below)
+			if sr.popSim.CurrentYear%population.StagnationCheckInterval == 0 {
+				sr.stagnantSpecies = len(sr.popSim.ApplyStagnationCheck(sr.config.MutationPulse))
+			}
+
 			// Climate Driver Update (orbital mechanics for ice ages)
 			// This checks insolation and triggers/ends ice ages deterministically
 			if sr.climateDriver != nil {
@@ -529,6 +649,17 @@ func (sr *SimulationRunner) tickLocked(yearsToAdvance int64) error {
 	sr.yearsSimulated += yearsToAdvance
 	sr.tickCount++
 	sr.lastTickTime = time.Now()
+	sr.progress.Observe(sr.currentYear)
+
+	// Archive new extinctions; failures are retried next tick
+	if sr.fossils != nil {
+		if _, err := sr.fossils.Archive(sr.ctx, sr.popSim); err != nil {
+			fmt.Printf("Failed to archive fossil record: %v\n", err)
+		}
+	}
+	if sr.lifeEvents != nil {
+		sr.lifeEvents.PublishExtinctions(sr.ctx, sr.popSim)
+	}
 
 	// External Tick Handler (optional, for legacy hooks)
 	if sr.tickHandler != nil {
@@ -822,6 +953,7 @@ func (sr *SimulationRunner) updateSapienceDetection() {
 				Generation:    species.Generation,
 			}
 
+			wasSapient := slices.Contains(sr.sapienceDetector.SapientSpecies, speciesID)
 			candidate := sr.sapienceDetector.Evaluate(
 				speciesID,
 				species.Name,
@@ -836,8 +968,12 @@ func (sr *SimulationRunner) updateSapienceDetection() {
 					Type:        "sapience",
 					Description: fmt.Sprintf("Species '%s' has achieved sapience!", species.Name),
 					SpeciesID:   &speciesID,
+					SpeciesName: species.Name,
 					Importance:  10,
 				})
+				if !wasSapient && sr.lifeEvents != nil {
+					sr.lifeEvents.PublishSapience(sr.ctx, candidate)
+				}
 			} else if candidate != nil && candidate.Level == sapience.SapienceProtoSapient {
 				sr.broadcastEvent(RunnerEvent{
 					Year:        sr.popSim.CurrentYear,
@@ -864,11 +1000,101 @@ func (sr *SimulationRunner) updateGeology(yearsElapsed int64) {
 	// (The WorldGeology system handles internal events)
 }
 
-// SetGeology allows external injection of geology system
+// SetGeology allows external injection of geology system. The population's
+// biomes take their climates from its biomes (see SetBiomeClimates).
 func (sr *SimulationRunner) SetGeology(geology *WorldGeology) {
 	sr.mu.Lock()
 	defer sr.mu.Unlock()
 	sr.geology = geology
+	sr.applyBiomeClimates()
+}
+
+// applyBiomeClimates gives the population's biomes the geology's climates,
+// once the runner has both. Callers hold sr.mu.
+func (sr *SimulationRunner) applyBiomeClimates() {
+	if sr.popSim == nil || sr.geology == nil || len(sr.geology.Biomes) == 0 {
+		return
+	}
+	SetBiomeClimates(sr.popSim, sr.geology.Biomes)
+	// The geology's biomes already carry its current climate
+	sr.popSim.TemperatureAnomaly = 0
+}
+
+// SetBiomeClimates gives each simulated biome the mean temperature and
+// rainfall of the map's biomes of its type, so carrying capacity follows the
+// climate (see population.CalculateCarryingCapacity)
+func SetBiomeClimates(popSim *population.PopulationSimulator, biomes []geography.Biome) {
+	type climate struct{ temperature, rainfall, count float64 }
+	byType := make(map[geography.BiomeType]*climate)
+	for _, b := range biomes {
+		c := byType[b.Type]
+		if c == nil {
+			c = &climate{}
+			byType[b.Type] = c
+		}
+		c.temperature += b.Temperature
+		c.rainfall += b.Precipitation
+		c.count++
+	}
+	for _, bp := range popSim.Biomes {
+		if c := byType[bp.BiomeType]; c != nil {
+			bp.SetClimate(c.temperature/c.count, c.rainfall/c.count, population.ReferenceBiomeArea)
+		}
+	}
+}
+
+// GetPopulationSimulator returns the population simulator for read-only external access
+func (sr *SimulationRunner) GetPopulationSimulator() *population.PopulationSimulator {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	return sr.popSim
+}
+
+// SnapshotPopulation returns a deep copy of the population simulator, taken
+// under the runner's lock, for readers on other goroutines: the simulator
+// itself keeps changing while the runner ticks. Nil if there is none.
+func (sr *SimulationRunner) SnapshotPopulation() (*population.PopulationSimulator, error) {
+	sr.mu.RLock()
+	if sr.popSim == nil {
+		sr.mu.RUnlock()
+		return nil, nil
+	}
+	data, err := json.Marshal(sr.popSim)
+	sr.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot population: %w", err)
+	}
+
+	var sim population.PopulationSimulator
+	if err := json.Unmarshal(data, &sim); err != nil {
+		return nil, fmt.Errorf("failed to snapshot population: %w", err)
+	}
+	return &sim, nil
+}
+
+// ViewPopulation calls fn with the live population simulator while holding
+// the runner's read lock, for readers that need more than SnapshotPopulation
+// keeps, such as per-biome diagnostics. fn must not keep the simulator or
+// call back into the runner. False if there is no simulator.
+func (sr *SimulationRunner) ViewPopulation(fn func(*population.PopulationSimulator)) bool {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	if sr.popSim == nil {
+		return false
+	}
+	fn(sr.popSim)
+	return true
+}
+
+// GeologyStats returns the runner's geology stats, read under its lock;
+// false if it has no initialized geology
+func (sr *SimulationRunner) GeologyStats() (GeologyStats, bool) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	if sr.geology == nil || !sr.geology.IsInitialized() {
+		return GeologyStats{}, false
+	}
+	return sr.geology.GetStats(), true
 }
 
 // GetDiseaseSystem returns the disease system for external access
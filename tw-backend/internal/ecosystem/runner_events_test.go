@@ -0,0 +1,194 @@
+package ecosystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/eventstore"
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memEventStore is an in-memory event store that can be made to fail
+type memEventStore struct {
+	events []eventstore.Event
+	err    error
+}
+
+func (m *memEventStore) AppendEvent(_ context.Context, event eventstore.Event) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *memEventStore) AppendEventExpectingVersion(ctx context.Context, event eventstore.Event, _ int) error {
+	return m.AppendEvent(ctx, event)
+}
+
+func (m *memEventStore) AppendEvents(ctx context.Context, events []eventstore.Event) error {
+	for _, event := range events {
+		if err := m.AppendEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memEventStore) GetEventsByAggregate(_ context.Context, aggregateID string, _ int64) ([]eventstore.Event, error) {
+	var out []eventstore.Event
+	for _, e := range m.events {
+		if e.AggregateID == aggregateID {
+			out = append(out, e)
+		}
+	}
+	return out, m.err
+}
+
+func (m *memEventStore) GetEventsByType(_ context.Context, eventType eventstore.EventType, _, _ time.Time) ([]eventstore.Event, error) {
+	var out []eventstore.Event
+	for _, e := range m.events {
+		if e.EventType == eventType {
+			out = append(out, e)
+		}
+	}
+	return out, m.err
+}
+
+func (m *memEventStore) GetAllEvents(_ context.Context, _ time.Time, _ int) ([]eventstore.Event, error) {
+	return m.events, m.err
+}
+
+func (m *memEventStore) SaveSnapshot(_ context.Context, _ eventstore.Snapshot) error {
+	return m.err
+}
+
+func (m *memEventStore) GetLatestSnapshot(_ context.Context, _ string) (*eventstore.Snapshot, error) {
+	return nil, m.err
+}
+
+func (m *memEventStore) Subscribe(_ context.Context, _ int64) (<-chan eventstore.Event, error) {
+	return nil, errors.New("memEventStore does not support subscriptions")
+}
+
+// doomedRunner returns a runner with a biome that can't support its grazers
+func doomedRunner(t *testing.T) *SimulationRunner {
+	t.Helper()
+	runner := NewSimulationRunner(DefaultConfig(uuid.New()), nil, nil)
+	runner.InitializePopulationSimulator(42)
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.CarryingCapacity = 0
+	biome.AddSpecies(&population.SpeciesPopulation{
+		SpeciesID: uuid.New(),
+		Name:      "Doomed Grazer",
+		Count:     1000,
+		Diet:      population.DietHerbivore,
+		Traits:    population.DefaultTraitsForDiet(population.DietHerbivore),
+	})
+	runner.GetPopulationSimulator().Biomes[biome.BiomeID] = biome
+	return runner
+}
+
+func TestSimulationRunner_PublishesExtinctions(t *testing.T) {
+	store := &memEventStore{}
+	runner := doomedRunner(t)
+	pub := eventstore.NewPublisher(store)
+	runner.SetEventPublisher(pub)
+
+	require.NoError(t, runner.Step(1))
+	require.NoError(t, runner.Step(1))
+	pub.Flush()
+
+	events, err := store.GetEventsByType(context.Background(), eventstore.EventTypeSpeciesExtinct, time.Time{}, time.Now())
+	require.NoError(t, err)
+	extinct := runner.GetPopulationSimulator().FossilRecord.Extinct
+	require.Len(t, events, len(extinct), "Each extinction is published exactly once")
+
+	var found bool
+	for _, event := range events {
+		assert.Equal(t, eventstore.AggregateSpecies, event.AggregateType)
+		var payload eventstore.SpeciesExtinctPayload
+		require.NoError(t, json.Unmarshal(event.Payload, &payload))
+		assert.Equal(t, payload.SpeciesID.String(), event.AggregateID)
+		assert.Equal(t, runner.config.WorldID, payload.WorldID)
+		if payload.Name == "Doomed Grazer" {
+			found = true
+			assert.Equal(t, "population_collapse", payload.Cause)
+		}
+	}
+	assert.True(t, found, "The grazer's extinction should be published")
+}
+
+func TestSimulationRunner_EventStoreFailureDoesNotBlockStep(t *testing.T) {
+	store := &memEventStore{err: errors.New("database unavailable")}
+	runner := doomedRunner(t)
+	pub := eventstore.NewPublisher(store)
+	runner.SetEventPublisher(pub)
+
+	require.NoError(t, runner.Step(1))
+	pub.Flush()
+	assert.NotEmpty(t, runner.GetPopulationSimulator().FossilRecord.Extinct)
+	assert.Empty(t, store.events)
+}
+
+func TestSimulationRunner_PublishesSapienceOnce(t *testing.T) {
+	store := &memEventStore{}
+	runner := NewSimulationRunner(DefaultConfig(uuid.New()), nil, nil)
+	runner.InitializePopulationSimulator(7)
+	pub := eventstore.NewPublisher(store)
+	runner.SetEventPublisher(pub)
+
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	traits := population.DefaultTraitsForDiet(population.DietOmnivore)
+	traits.Intelligence = 1.0
+	traits.Social = 1.0
+	speciesID := uuid.New()
+	biome.AddSpecies(&population.SpeciesPopulation{
+		SpeciesID: speciesID,
+		Name:      "Thinker",
+		Count:     5000,
+		Diet:      population.DietOmnivore,
+		Traits:    traits,
+	})
+	runner.GetPopulationSimulator().Biomes[biome.BiomeID] = biome
+
+	runner.updateSapienceDetection()
+	runner.updateSapienceDetection()
+	pub.Flush()
+
+	events, err := store.GetEventsByType(context.Background(), eventstore.EventTypeSapienceAchieved, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, events, 1, "Sapience is only announced when first reached")
+	assert.Equal(t, speciesID.String(), events[0].AggregateID)
+
+	var payload eventstore.SapienceAchievedPayload
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	assert.Equal(t, "Thinker", payload.Name)
+	assert.Equal(t, speciesID, payload.SpeciesID)
+}
+
+func TestSimulationRunner_RestoredExtinctionsNotRepublished(t *testing.T) {
+	store := &memEventStore{}
+	pub := eventstore.NewPublisher(store)
+	runner := NewSimulationRunner(DefaultConfig(uuid.New()), nil, nil)
+	runner.SetEventPublisher(pub)
+
+	// A simulator run (and published) elsewhere is handed to the runner
+	doomed := doomedRunner(t)
+	require.NoError(t, doomed.Step(1))
+	sim := doomed.GetPopulationSimulator()
+	require.NotEmpty(t, sim.FossilRecord.Extinct)
+	runner.RestorePopulationSimulator(sim)
+
+	require.NoError(t, runner.Step(1))
+	pub.Flush()
+	assert.Empty(t, store.events, "Extinctions from before the handover are not published again")
+}
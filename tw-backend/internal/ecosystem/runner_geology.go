@@ -0,0 +1,202 @@
+package ecosystem
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultGeologyCheckpointInterval is how many simulated years pass between
+// geology checkpoints when SimulationConfig.GeologyCheckpointInterval is 0
+const DefaultGeologyCheckpointInterval = 10_000_000
+
+// GeologyStore persists geology checkpoints (see WorldGeology.Serialize) so
+// a geology-only run survives a restart
+type GeologyStore interface {
+	// SaveGeology stores a world's geology as of year, replacing any earlier
+	// checkpoint
+	SaveGeology(ctx context.Context, worldID uuid.UUID, year int64, data []byte) error
+	// LoadGeology returns a world's latest checkpoint, or nil if it has none
+	LoadGeology(ctx context.Context, worldID uuid.UUID) ([]byte, error)
+}
+
+// GeologyOnlyStepSize returns how many years a geology-only simulation can
+// advance in one step from year. Without life to resolve year by year,
+// steps stay large throughout: 100,000 years while the planet is hot
+// (Hadean to Proterozoic), 10,000 once it has cooled.
+func GeologyOnlyStepSize(year int64) int64 {
+	if GetPlanetaryHeat(year) > 1.5 {
+		return 100_000
+	}
+	return 10_000
+}
+
+// SetGeologyStore sets where RunGeologyOnly checkpoints. The runner's
+// SimulationSnapshotRepository is used by default.
+func (sr *SimulationRunner) SetGeologyStore(store GeologyStore) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.geologyStore = store
+}
+
+// RunGeologyOnly simulates the runner's geology, and nothing else, up to
+// targetYears in the background. Life subsystems are skipped, so steps can
+// be as large as GeologyOnlyStepSize allows: billions of years in minutes.
+//
+// The geology is checkpointed to the GeologyStore every
+// GeologyCheckpointInterval years, and when the run pauses, stops or
+// finishes. If the store holds a checkpoint further along than the
+// runner's geology, the run resumes from it, so restarting a run picks up
+// where it left off. Wait blocks until the run ends.
+func (sr *SimulationRunner) RunGeologyOnly(targetYears int64) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	if sr.state == RunnerRunning || sr.state == RunnerPaused {
+		return fmt.Errorf("simulation already running")
+	}
+
+	if sr.geologyStore != nil {
+		data, err := sr.geologyStore.LoadGeology(sr.ctx, sr.config.WorldID)
+		if err != nil {
+			return fmt.Errorf("failed to load geology checkpoint: %w", err)
+		}
+		if data != nil {
+			saved, err := LoadWorldGeology(data)
+			if err != nil {
+				return err
+			}
+			if sr.geology == nil || saved.TotalYearsSimulated > sr.geology.TotalYearsSimulated {
+				sr.geology = saved
+			}
+		}
+	}
+	if sr.geology == nil {
+		return fmt.Errorf("geology not initialized")
+	}
+
+	sr.state = RunnerRunning
+	sr.currentYear = sr.geology.TotalYearsSimulated
+	sr.progress = nil
+	if sr.progressReporter != nil && targetYears > sr.currentYear {
+		sr.progress = NewProgressTracker(sr.progressReporter, sr.currentYear, targetYears, sr.progressSteps)
+	}
+	// Only the run's loop simulates the geology, so the callback needn't lock
+	sr.progress.TrackGeology(sr.geology)
+	sr.startTime = time.Now()
+	sr.lastTickTime = time.Now()
+
+	sr.wg.Add(1)
+	go sr.runGeologyLoop(sr.geology, targetYears)
+	return nil
+}
+
+// GetGeology returns the geology the runner simulates, which
+// RunGeologyOnly may have replaced with a resumed checkpoint
+func (sr *SimulationRunner) GetGeology() *WorldGeology {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	return sr.geology
+}
+
+// Wait blocks until the background run started by Start or RunGeologyOnly
+// has ended
+func (sr *SimulationRunner) Wait() {
+	sr.wg.Wait()
+}
+
+// runGeologyLoop is RunGeologyOnly's background loop
+func (sr *SimulationRunner) runGeologyLoop(geology *WorldGeology, target int64) {
+	defer sr.wg.Done()
+	defer geology.SetProgressFunc(0, nil)
+
+	sr.mu.RLock()
+	interval := sr.config.GeologyCheckpointInterval
+	pollInterval := sr.config.TickInterval
+	sr.mu.RUnlock()
+	if interval <= 0 {
+		interval = DefaultGeologyCheckpointInterval
+	}
+	if pollInterval <= 0 {
+		pollInterval = 100 * time.Millisecond
+	}
+
+	year := geology.TotalYearsSimulated
+	lastCheckpoint := year
+	for year < target {
+		select {
+		case <-sr.ctx.Done():
+			sr.checkpointGeology(geology, year)
+			return
+		default:
+		}
+
+		sr.mu.RLock()
+		state := sr.state
+		sr.mu.RUnlock()
+		if state == RunnerPaused {
+			if lastCheckpoint != year {
+				sr.checkpointGeology(geology, year)
+				lastCheckpoint = year
+			}
+			select {
+			case <-sr.ctx.Done():
+				return
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		step := min(GeologyOnlyStepSize(year), target-year)
+		geology.SimulateGeology(step, 0.0) // No temperature modifier, as in updateGeology
+		year += step
+
+		sr.mu.Lock()
+		sr.currentYear = year
+		sr.yearsSimulated += step
+		sr.tickCount++
+		sr.lastTickTime = time.Now()
+		sr.mu.Unlock()
+
+		if year-lastCheckpoint >= interval {
+			sr.checkpointGeology(geology, year)
+			lastCheckpoint = year
+		}
+	}
+
+	if lastCheckpoint != year {
+		sr.checkpointGeology(geology, year)
+	}
+
+	sr.mu.Lock()
+	sr.state = RunnerIdle
+	sr.broadcastEvent(RunnerEvent{
+		Year:        year,
+		Type:        "system",
+		Description: "Geology simulation reached target year",
+		Importance:  1,
+	})
+	sr.mu.Unlock()
+}
+
+// checkpointGeology saves geology to the GeologyStore, if any. Failures
+// are logged; the next checkpoint tries again.
+func (sr *SimulationRunner) checkpointGeology(geology *WorldGeology, year int64) {
+	sr.mu.RLock()
+	store := sr.geologyStore
+	worldID := sr.config.WorldID
+	sr.mu.RUnlock()
+	if store == nil {
+		return
+	}
+
+	data, err := geology.Serialize()
+	if err == nil {
+		// The run's context may already be cancelled on Stop
+		err = store.SaveGeology(context.Background(), worldID, year, data)
+	}
+	if err != nil {
+		fmt.Printf("Failed to checkpoint geology at year %d: %v\n", year, err)
+	}
+}
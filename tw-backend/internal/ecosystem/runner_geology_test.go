@@ -0,0 +1,124 @@
+package ecosystem
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memGeologyStore is an in-memory GeologyStore
+type memGeologyStore struct {
+	mu    sync.Mutex
+	data  map[uuid.UUID][]byte
+	years []int64 // Year of every checkpoint saved, in order
+}
+
+func newMemGeologyStore() *memGeologyStore {
+	return &memGeologyStore{data: make(map[uuid.UUID][]byte)}
+}
+
+func (m *memGeologyStore) SaveGeology(_ context.Context, worldID uuid.UUID, year int64, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[worldID] = data
+	m.years = append(m.years, year)
+	return nil
+}
+
+func (m *memGeologyStore) LoadGeology(_ context.Context, worldID uuid.UUID) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data[worldID], nil
+}
+
+// runGeology runs a geology-only simulation of geology to target on a new
+// runner checkpointing to store, and returns the runner's final geology
+func runGeology(t *testing.T, worldID uuid.UUID, geology *WorldGeology, store GeologyStore, target int64) *WorldGeology {
+	t.Helper()
+	config := DefaultConfig(worldID)
+	config.GeologyCheckpointInterval = 1_000_000
+	runner := NewSimulationRunner(config, nil, nil)
+	runner.SetGeology(geology)
+	runner.SetGeologyStore(store)
+
+	require.NoError(t, runner.RunGeologyOnly(target))
+	runner.Wait()
+	assert.Equal(t, RunnerIdle, runner.GetState())
+	assert.Equal(t, target, runner.GetCurrentYear())
+	return runner.GetGeology()
+}
+
+func TestRunGeologyOnly_ReportsProgressFromGeology(t *testing.T) {
+	worldID := uuid.New()
+	geology := NewWorldGeology(worldID, 11, 1_000_000)
+	geology.InitializeGeology()
+
+	runner := NewSimulationRunner(DefaultConfig(worldID), nil, nil)
+	runner.SetGeology(geology)
+	sink := &progressSink{}
+	runner.SetProgressReporter(sink, 4)
+
+	require.NoError(t, runner.RunGeologyOnly(2_000_000))
+	runner.Wait()
+	assert.Equal(t, []int{25, 50, 75, 100}, sink.percents())
+}
+
+func TestGeologyOnlyStepSize(t *testing.T) {
+	assert.Equal(t, int64(100_000), GeologyOnlyStepSize(0), "Hadean")
+	assert.Equal(t, int64(100_000), GeologyOnlyStepSize(1_000_000_000), "Proterozoic")
+	assert.Equal(t, int64(10_000), GeologyOnlyStepSize(4_500_000_000), "Modern")
+}
+
+func TestRunGeologyOnly_ResumesFromCheckpoint(t *testing.T) {
+	worldID := uuid.New()
+	start := int64(600_000_000)
+	target := start + 4_000_000
+
+	uninterrupted := runGeology(t, worldID, cooledGeology(worldID), newMemGeologyStore(), target)
+
+	// Run halfway, then "restart" with a fresh runner and a fresh geology:
+	// the checkpoint is further along, so the run resumes from it
+	store := newMemGeologyStore()
+	runGeology(t, worldID, cooledGeology(worldID), store, start+2_000_000)
+	assert.Equal(t, []int64{start + 1_000_000, start + 2_000_000}, store.years, "Checkpoints every interval")
+
+	resumed := runGeology(t, worldID, cooledGeology(worldID), store, target)
+	requireSameGeology(t, uninterrupted, resumed)
+	assert.Equal(t, target, resumed.TotalYearsSimulated)
+}
+
+func TestRunGeologyOnly_PauseAndResume(t *testing.T) {
+	worldID := uuid.New()
+	target := int64(600_000_000 + 2_000_000)
+	uninterrupted := runGeology(t, worldID, cooledGeology(worldID), nil, target)
+
+	config := DefaultConfig(worldID)
+	config.TickInterval = time.Millisecond
+	runner := NewSimulationRunner(config, nil, nil)
+	runner.SetGeology(cooledGeology(worldID))
+	store := newMemGeologyStore()
+	runner.SetGeologyStore(store)
+
+	require.NoError(t, runner.RunGeologyOnly(target))
+	runner.Pause()
+	assert.Error(t, runner.RunGeologyOnly(target), "Only one run at a time")
+	time.Sleep(20 * time.Millisecond)
+	paused := runner.GetCurrentYear()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, paused, runner.GetCurrentYear(), "A paused run doesn't advance")
+
+	runner.Resume()
+	runner.Wait()
+	requireSameGeology(t, uninterrupted, runner.GetGeology())
+	assert.Equal(t, target, runner.GetCurrentYear())
+}
+
+func TestRunGeologyOnly_RequiresGeology(t *testing.T) {
+	runner := NewSimulationRunner(DefaultConfig(uuid.New()), nil, nil)
+	assert.Error(t, runner.RunGeologyOnly(1_000_000))
+}
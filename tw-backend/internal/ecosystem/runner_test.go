@@ -1,11 +1,14 @@
 package ecosystem
 
 import (
+	"encoding/json"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"tw-backend/internal/ecosystem/population"
 	"tw-backend/internal/worldgen/astronomy"
+	"tw-backend/internal/worldgen/geography"
 
 	"github.com/google/uuid"
 )
@@ -424,3 +427,148 @@ func TestPlayerViewSync_Snapshot(t *testing.T) {
 		t.Logf("Note: RestoreFromSnapshot sets internal counts, ForceUpdate reads them")
 	}
 }
+
+func TestSimulationRunner_RestoredPopulationSteps(t *testing.T) {
+	runner := NewSimulationRunner(DefaultConfig(uuid.New()), nil, nil)
+	runner.InitializePopulationSimulator(12345)
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.AddSpecies(&population.SpeciesPopulation{
+		SpeciesID: uuid.New(),
+		Name:      "Plains Grazer",
+		Count:     500,
+		Diet:      population.DietHerbivore,
+		Traits:    population.DefaultTraitsForDiet(population.DietHerbivore),
+	})
+	runner.GetPopulationSimulator().Biomes[biome.BiomeID] = biome
+	if err := runner.Step(2); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+
+	// Round-trip through JSON, as a save does; the rng doesn't survive it
+	data, err := json.Marshal(runner.GetPopulationSimulator())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var restored population.PopulationSimulator
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	runner.RestorePopulationSimulator(&restored)
+	year := runner.GetCurrentYear()
+	if err := runner.Step(1); err != nil {
+		t.Fatalf("A restored world should keep simulating: %v", err)
+	}
+	if runner.GetCurrentYear() <= year {
+		t.Errorf("Current year = %d, want > %d", runner.GetCurrentYear(), year)
+	}
+}
+
+func TestSimulationRunner_BiomesTakeGeologyClimate(t *testing.T) {
+	geology := &WorldGeology{Biomes: []geography.Biome{
+		{Type: geography.BiomeGrassland, Temperature: 14, Precipitation: 700},
+		{Type: geography.BiomeGrassland, Temperature: 16, Precipitation: 900},
+	}}
+	grassland := func() (*population.PopulationSimulator, *population.BiomePopulation) {
+		sim := population.NewPopulationSimulator(uuid.New(), 1)
+		biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+		sim.Biomes[biome.BiomeID] = biome
+		return sim, biome
+	}
+
+	runner := NewSimulationRunner(DefaultConfig(uuid.New()), nil, nil)
+	runner.InitializePopulationSimulator(12345)
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	runner.GetPopulationSimulator().Biomes[biome.BiomeID] = biome
+
+	// Handing the runner its geology drives the biomes' capacity by climate
+	runner.SetGeology(geology)
+	if biome.Area != population.ReferenceBiomeArea || biome.Temperature != 15 || biome.Rainfall != 800 {
+		t.Errorf("Biome climate = %.1f°C, %.0fmm over %.0fkm², want the grasslands' mean",
+			biome.Temperature, biome.Rainfall, biome.Area)
+	}
+
+	// So does restoring a population into a runner that has geology, with
+	// any stale anomaly cleared since the geology's climate is current
+	restored, restoredBiome := grassland()
+	restored.TemperatureAnomaly = 5
+	runner.RestorePopulationSimulator(restored)
+	if restoredBiome.Area != population.ReferenceBiomeArea || restoredBiome.Temperature != 15 {
+		t.Errorf("Restored biome climate = %.1f°C over %.0fkm², want the grasslands' mean", restoredBiome.Temperature, restoredBiome.Area)
+	}
+	if restored.TemperatureAnomaly != 0 {
+		t.Errorf("TemperatureAnomaly = %.1f, want 0", restored.TemperatureAnomaly)
+	}
+}
+
+func TestSimulationRunner_SnapshotPopulationIsACopy(t *testing.T) {
+	runner := NewSimulationRunner(DefaultConfig(uuid.New()), nil, nil)
+	runner.InitializePopulationSimulator(12345)
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.AddSpecies(&population.SpeciesPopulation{
+		SpeciesID: uuid.New(),
+		Name:      "Plains Grazer",
+		Count:     500,
+		Diet:      population.DietHerbivore,
+		Traits:    population.DefaultTraitsForDiet(population.DietHerbivore),
+	})
+	runner.GetPopulationSimulator().Biomes[biome.BiomeID] = biome
+
+	// Readers on other goroutines take snapshots while the runner steps
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			if _, err := runner.SnapshotPopulation(); err != nil {
+				t.Errorf("SnapshotPopulation failed: %v", err)
+				return
+			}
+		}
+	}()
+	if err := runner.Step(5); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	<-done
+
+	snapshot, err := runner.SnapshotPopulation()
+	if err != nil {
+		t.Fatalf("SnapshotPopulation failed: %v", err)
+	}
+	if snapshot.CurrentYear != runner.GetPopulationSimulator().CurrentYear {
+		t.Errorf("Snapshot year = %d, want %d", snapshot.CurrentYear, runner.GetPopulationSimulator().CurrentYear)
+	}
+	delete(snapshot.Biomes, biome.BiomeID)
+	if _, ok := runner.GetPopulationSimulator().Biomes[biome.BiomeID]; !ok {
+		t.Error("Editing the snapshot changed the live simulator")
+	}
+}
+
+func TestSimulationRunner_ResetPopulation(t *testing.T) {
+	runner := NewSimulationRunner(DefaultConfig(uuid.New()), nil, nil)
+	runner.InitializePopulationSimulator(12345)
+	sim := runner.GetPopulationSimulator()
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.AddSpecies(&population.SpeciesPopulation{
+		SpeciesID: uuid.New(),
+		Name:      sim.UniqueSpeciesName("Plains Grazer", geography.BiomeGrassland),
+		Count:     500,
+		Diet:      population.DietHerbivore,
+		Traits:    population.DefaultTraitsForDiet(population.DietHerbivore),
+	})
+	sim.Biomes[biome.BiomeID] = biome
+	if err := runner.Step(2); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+
+	runner.ResetPopulation()
+
+	if runner.GetCurrentYear() != 0 || sim.CurrentYear != 0 {
+		t.Errorf("Reset should return to year 0, runner at %d, population at %d", runner.GetCurrentYear(), sim.CurrentYear)
+	}
+	if len(sim.Biomes) != 0 {
+		t.Errorf("Reset should clear the species, %d biomes remain", len(sim.Biomes))
+	}
+	if name := sim.UniqueSpeciesName("Plains Grazer", geography.BiomeGrassland); name != "Plains Grazer" {
+		t.Errorf("The old species' names should be free again, got %q", name)
+	}
+}
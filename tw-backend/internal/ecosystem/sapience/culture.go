@@ -0,0 +1,210 @@
+package sapience
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// CulturalStage is how far a sapient species' technology has come
+type CulturalStage string
+
+const (
+	CultureNone        CulturalStage = "none"        // Not (yet) sapient
+	CultureStone       CulturalStage = "stone"       // Stone tools, fire, bands
+	CultureAgriculture CulturalStage = "agriculture" // Farming, villages
+	CultureMetallurgy  CulturalStage = "metallurgy"  // Bronze and iron, cities
+	CultureWriting     CulturalStage = "writing"     // Records, law, history
+)
+
+// ArcaneStage is how far a sapient species' magic has come, alongside its
+// technology, on magic-enabled worlds
+type ArcaneStage string
+
+const (
+	ArcaneNone        ArcaneStage = "none"        // No magic
+	ArcaneAnimism     ArcaneStage = "animism"     // Spirits felt, not commanded
+	ArcaneRitual      ArcaneStage = "ritual"      // Shamans and communal rites
+	ArcaneSorcery     ArcaneStage = "sorcery"     // Individuals wielding power
+	ArcaneScholarship ArcaneStage = "scholarship" // Magic studied and taught
+)
+
+// culturalStep is a stage and what it takes to reach it: progress is
+// measured in years of culture at the standard rate (see culturalRate)
+type culturalStep[S ~string] struct {
+	Stage         S
+	Progress      float64
+	MinPopulation int64
+}
+
+// culturalSteps lists the technology stages in order
+var culturalSteps = []culturalStep[CulturalStage]{
+	{CultureStone, 0, 0},
+	{CultureAgriculture, 20000, 5000},
+	{CultureMetallurgy, 25000, 50000},
+	{CultureWriting, 28000, 200000},
+}
+
+// arcaneSteps lists the magic stages in order
+var arcaneSteps = []culturalStep[ArcaneStage]{
+	{ArcaneAnimism, 0, 0},
+	{ArcaneRitual, 15000, 5000},
+	{ArcaneSorcery, 22000, 20000},
+	{ArcaneScholarship, 26000, 100000},
+}
+
+// ArcaneMinAffinity is the magic affinity (0-10) a species needs to start
+// down the arcane track
+const ArcaneMinAffinity = 3.0
+
+// Culture is a sapient species' progress along both tracks
+type Culture struct {
+	SpeciesID      uuid.UUID     `json:"species_id"`
+	SpeciesName    string        `json:"species_name"`
+	SapientSince   int64         `json:"sapient_since"`
+	Stage          CulturalStage `json:"stage"`
+	Progress       float64       `json:"progress"` // Years of culture at the standard rate
+	Arcane         ArcaneStage   `json:"arcane"`
+	ArcaneProgress float64       `json:"arcane_progress"`
+	LastYear       int64         `json:"last_year"`
+}
+
+// CultureEvent records a species reaching a new stage on one track; the
+// other track's field is empty
+type CultureEvent struct {
+	SpeciesID   uuid.UUID     `json:"species_id"`
+	SpeciesName string        `json:"species_name"`
+	Year        int64         `json:"year"`
+	Stage       CulturalStage `json:"stage,omitempty"`
+	Arcane      ArcaneStage   `json:"arcane,omitempty"`
+}
+
+// SapientSpecies is what AdvanceCulture needs to know about a species
+type SapientSpecies struct {
+	SpeciesID uuid.UUID
+	Name      string
+	Traits    SpeciesTraits
+	Stability float64 // 0-1: freedom from plague, famine and catastrophe
+}
+
+// AdvanceCulture moves a sapient species along its cultural track for the
+// years since it was last advanced (or since it became sapient) and
+// returns its stage. Species that aren't sapient stay at CultureNone.
+//
+// Culture accrues faster with a larger population, higher intelligence and
+// more stability; an unstable species stalls. Each stage also needs a
+// population to sustain it: no cities without enough people. On
+// magic-enabled worlds, species with enough magic affinity climb a
+// parallel arcane track, driven by affinity in place of intelligence.
+// Every stage reached is queued as a CultureEvent (see TakeCultureEvents).
+func (sd *SapienceDetector) AdvanceCulture(species SapientSpecies, year int64) CulturalStage {
+	if !sd.isSapient(species.SpeciesID) {
+		return CultureNone
+	}
+	if sd.Cultures == nil {
+		sd.Cultures = make(map[uuid.UUID]*Culture)
+	}
+
+	culture, ok := sd.Cultures[species.SpeciesID]
+	if !ok {
+		since := year
+		if c, found := sd.Candidates[species.SpeciesID]; found {
+			since = c.YearDetected
+		}
+		culture = &Culture{
+			SpeciesID:    species.SpeciesID,
+			SpeciesName:  species.Name,
+			SapientSince: since,
+			Arcane:       ArcaneNone,
+			LastYear:     since,
+		}
+		sd.Cultures[species.SpeciesID] = culture
+		sd.reachStage(culture, CultureStone, since)
+	}
+
+	elapsed := float64(year - culture.LastYear)
+	if elapsed <= 0 {
+		return culture.Stage
+	}
+	culture.LastYear = year
+	traits := species.Traits
+
+	culture.Progress += elapsed * culturalRate(traits.Population, traits.Intelligence/sd.Thresholds.StandardIntelligence, species.Stability)
+	for _, step := range culturalSteps {
+		if stageIndex(culturalSteps, step.Stage) > stageIndex(culturalSteps, culture.Stage) &&
+			culture.Progress >= step.Progress && traits.Population >= step.MinPopulation {
+			sd.reachStage(culture, step.Stage, year)
+		}
+	}
+
+	if sd.MagicEnabled && traits.MagicAffinity >= ArcaneMinAffinity {
+		affinity := traits.MagicAffinity / sd.Thresholds.MagicMagicAffinity
+		culture.ArcaneProgress += elapsed * culturalRate(traits.Population, affinity, species.Stability)
+		for _, step := range arcaneSteps {
+			if stageIndex(arcaneSteps, step.Stage) > stageIndex(arcaneSteps, culture.Arcane) &&
+				culture.ArcaneProgress >= step.Progress && traits.Population >= step.MinPopulation {
+				sd.reachArcane(culture, step.Stage, year)
+			}
+		}
+	}
+
+	return culture.Stage
+}
+
+// Culture returns a species' cultural progress, or nil if it has none yet
+func (sd *SapienceDetector) Culture(speciesID uuid.UUID) *Culture {
+	return sd.Cultures[speciesID]
+}
+
+// TakeCultureEvents returns the stage transitions since the last call and
+// clears them
+func (sd *SapienceDetector) TakeCultureEvents() []CultureEvent {
+	events := sd.CultureEvents
+	sd.CultureEvents = nil
+	return events
+}
+
+// culturalRate returns years of culture gained per year. A population of
+// 10,000 with the standard intelligence (aptitude 1) in full stability
+// gains one per year; each tenfold population adds a quarter.
+func culturalRate(population int64, aptitude, stability float64) float64 {
+	if population <= 0 {
+		return 0
+	}
+	popFactor := math.Max(0.25, math.Min(2, 1+(math.Log10(float64(population))-4)*0.25))
+	aptitude = math.Max(0, math.Min(2, aptitude))
+	stability = math.Max(0, math.Min(1, stability))
+	return popFactor * aptitude * stability
+}
+
+// stageIndex returns the position of stage in steps, or -1
+func stageIndex[S ~string](steps []culturalStep[S], stage S) int {
+	for i, step := range steps {
+		if step.Stage == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// reachStage moves a culture to a technology stage and records the event
+func (sd *SapienceDetector) reachStage(culture *Culture, stage CulturalStage, year int64) {
+	culture.Stage = stage
+	sd.CultureEvents = append(sd.CultureEvents, CultureEvent{
+		SpeciesID:   culture.SpeciesID,
+		SpeciesName: culture.SpeciesName,
+		Year:        year,
+		Stage:       stage,
+	})
+}
+
+// reachArcane moves a culture to an arcane stage and records the event
+func (sd *SapienceDetector) reachArcane(culture *Culture, stage ArcaneStage, year int64) {
+	culture.Arcane = stage
+	sd.CultureEvents = append(sd.CultureEvents, CultureEvent{
+		SpeciesID:   culture.SpeciesID,
+		SpeciesName: culture.SpeciesName,
+		Year:        year,
+		Arcane:      stage,
+	})
+}
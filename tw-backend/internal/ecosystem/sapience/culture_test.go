@@ -0,0 +1,133 @@
+package sapience
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// sapientTraits are comfortably past the standard sapience thresholds
+func sapientTraits(population int64, magicAffinity float64) SpeciesTraits {
+	return SpeciesTraits{
+		Intelligence:  8.0,
+		Social:        7.0,
+		ToolUse:       6.0,
+		Communication: 7.0,
+		MagicAffinity: magicAffinity,
+		Population:    population,
+	}
+}
+
+// runCulture makes a species sapient in year 0 and advances its culture
+// every millennium for the given years, its population growing tenfold
+// every 10,000 years from 10,000
+func runCulture(sd *SapienceDetector, years int64, stability, magicAffinity float64) (SapientSpecies, []CultureEvent) {
+	species := SapientSpecies{SpeciesID: uuid.New(), Name: "River Folk", Stability: stability}
+	species.Traits = sapientTraits(10000, magicAffinity)
+	sd.Evaluate(species.SpeciesID, species.Name, species.Traits, 0)
+
+	var events []CultureEvent
+	for year := int64(1000); year <= years; year += 1000 {
+		if year%10000 == 0 {
+			species.Traits.Population *= 10
+		}
+		sd.AdvanceCulture(species, year)
+		events = append(events, sd.TakeCultureEvents()...)
+	}
+	return species, events
+}
+
+func TestAdvanceCulture_ProgressesThroughStages(t *testing.T) {
+	sd := NewSapienceDetector(uuid.New(), false)
+	species, events := runCulture(sd, 40000, 1.0, 0)
+
+	culture := sd.Culture(species.SpeciesID)
+	if culture == nil {
+		t.Fatal("Expected the sapient species to have a culture")
+	}
+	if culture.Stage != CultureWriting {
+		t.Errorf("After 40 millennia stage = %s, want %s", culture.Stage, CultureWriting)
+	}
+
+	// Every stage in order, each later than the last
+	want := []CulturalStage{CultureStone, CultureAgriculture, CultureMetallurgy, CultureWriting}
+	if len(events) != len(want) {
+		t.Fatalf("Got %d culture events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, ev := range events {
+		if ev.Stage != want[i] || ev.SpeciesID != species.SpeciesID {
+			t.Errorf("Event %d = %+v, want stage %s", i, ev, want[i])
+		}
+		if i > 0 && ev.Year <= events[i-1].Year {
+			t.Errorf("%s (year %d) should come after %s (year %d)", ev.Stage, ev.Year, events[i-1].Stage, events[i-1].Year)
+		}
+	}
+	if culture.Arcane != ArcaneNone {
+		t.Errorf("A world without magic has arcane stage %s", culture.Arcane)
+	}
+}
+
+func TestAdvanceCulture_InstabilityStalls(t *testing.T) {
+	stable := NewSapienceDetector(uuid.New(), false)
+	stableSpecies, _ := runCulture(stable, 25000, 1.0, 0)
+	troubled := NewSapienceDetector(uuid.New(), false)
+	troubledSpecies, _ := runCulture(troubled, 25000, 0.3, 0)
+
+	if got := stable.Culture(stableSpecies.SpeciesID).Stage; got == CultureStone {
+		t.Errorf("A stable species should be past the stone age, got %s", got)
+	}
+	if got := troubled.Culture(troubledSpecies.SpeciesID).Stage; got != CultureStone {
+		t.Errorf("A troubled species should still be in the stone age, got %s", got)
+	}
+}
+
+func TestAdvanceCulture_SmallPopulationCapsStage(t *testing.T) {
+	sd := NewSapienceDetector(uuid.New(), false)
+	species := SapientSpecies{SpeciesID: uuid.New(), Name: "Island Folk", Stability: 1, Traits: sapientTraits(20000, 0)}
+	sd.Evaluate(species.SpeciesID, species.Name, species.Traits, 0)
+
+	// Plenty of time, but too few people for cities
+	if got := sd.AdvanceCulture(species, 100000); got != CultureAgriculture {
+		t.Errorf("Stage = %s, want %s", got, CultureAgriculture)
+	}
+}
+
+func TestAdvanceCulture_ArcaneTrack(t *testing.T) {
+	sd := NewSapienceDetector(uuid.New(), true)
+	species, events := runCulture(sd, 40000, 1.0, 8.0)
+
+	culture := sd.Culture(species.SpeciesID)
+	if culture.Arcane != ArcaneScholarship {
+		t.Errorf("Arcane stage = %s, want %s", culture.Arcane, ArcaneScholarship)
+	}
+	arcane := 0
+	for _, ev := range events {
+		if ev.Arcane != "" {
+			arcane++
+		}
+	}
+	if arcane < 2 {
+		t.Errorf("Expected arcane stage events alongside the technology ones, got %d", arcane)
+	}
+
+	// Low affinity never starts down the track
+	dull := NewSapienceDetector(uuid.New(), true)
+	dullSpecies, _ := runCulture(dull, 40000, 1.0, 1.0)
+	if got := dull.Culture(dullSpecies.SpeciesID).Arcane; got != ArcaneNone {
+		t.Errorf("Low magic affinity arcane stage = %s, want none", got)
+	}
+}
+
+func TestAdvanceCulture_NotSapient(t *testing.T) {
+	sd := NewSapienceDetector(uuid.New(), false)
+	species := SapientSpecies{SpeciesID: uuid.New(), Name: "Clever Monkey", Stability: 1}
+	species.Traits = SpeciesTraits{Intelligence: 5.5, ToolUse: 4.0, Population: 5000}
+	sd.Evaluate(species.SpeciesID, species.Name, species.Traits, 0)
+
+	if got := sd.AdvanceCulture(species, 50000); got != CultureNone {
+		t.Errorf("Proto-sapient species stage = %s, want none", got)
+	}
+	if len(sd.TakeCultureEvents()) != 0 {
+		t.Error("No culture events expected before sapience")
+	}
+}
@@ -87,6 +87,10 @@ type SapienceDetector struct {
 	MagicEnabled      bool                             `json:"magic_enabled"` // World has magic
 	CurrentYear       int64                            `json:"current_year"`
 	FirstSapienceYear int64                            `json:"first_sapience_year"`
+
+	// After sapience: each sapient species' culture (see AdvanceCulture)
+	Cultures      map[uuid.UUID]*Culture `json:"cultures"`
+	CultureEvents []CultureEvent         `json:"culture_events"` // Not yet taken
 }
 
 // NewSapienceDetector creates a new sapience detector
@@ -97,6 +101,7 @@ func NewSapienceDetector(worldID uuid.UUID, magicEnabled bool) *SapienceDetector
 		Candidates:     make(map[uuid.UUID]*SapienceCandidate),
 		SapientSpecies: make([]uuid.UUID, 0),
 		MagicEnabled:   magicEnabled,
+		Cultures:       make(map[uuid.UUID]*Culture),
 	}
 }
 
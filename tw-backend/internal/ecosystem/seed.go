@@ -0,0 +1,38 @@
+package ecosystem
+
+import (
+	"hash/fnv"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// WorldSeed derives a world's default simulation seed from all 128 bits of its
+// ID (64-bit FNV-1a). Both "world simulate" and the async runner use it, so the
+// same world produces the same terrain, life, and diseases either way.
+func WorldSeed(worldID uuid.UUID) int64 {
+	h := fnv.New64a()
+	h.Write(worldID[:])
+	return nonZeroSeed(int64(h.Sum64()))
+}
+
+// ResolveSeed returns override when set, otherwise the world's derived seed.
+// Zero means "unset" throughout, so the result is never zero.
+func ResolveSeed(worldID uuid.UUID, override int64) int64 {
+	if override != 0 {
+		return override
+	}
+	return WorldSeed(worldID)
+}
+
+// RandomSeed returns a fresh seed drawn from the full 64-bit range
+func RandomSeed() int64 {
+	return nonZeroSeed(int64(rand.Uint64()))
+}
+
+func nonZeroSeed(seed int64) int64 {
+	if seed == 0 {
+		return 1
+	}
+	return seed
+}
@@ -0,0 +1,38 @@
+package ecosystem
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorldSeed_DeterministicPerWorld(t *testing.T) {
+	worldID := uuid.New()
+	assert.Equal(t, WorldSeed(worldID), WorldSeed(worldID))
+	assert.NotZero(t, WorldSeed(worldID))
+	assert.NotEqual(t, WorldSeed(worldID), WorldSeed(uuid.New()))
+}
+
+func TestWorldSeed_UsesAllIDBytes(t *testing.T) {
+	a := uuid.MustParse("11111111-2222-3333-4444-555555555555")
+	b := uuid.MustParse("11111111-2222-3333-4444-555555555556")
+	assert.NotEqual(t, WorldSeed(a), WorldSeed(b), "IDs differing only in the last byte should get different seeds")
+}
+
+func TestResolveSeed(t *testing.T) {
+	worldID := uuid.New()
+	assert.Equal(t, int64(42), ResolveSeed(worldID, 42), "an override wins")
+	assert.Equal(t, int64(-7), ResolveSeed(worldID, -7), "negative overrides are valid 64-bit seeds")
+	assert.Equal(t, WorldSeed(worldID), ResolveSeed(worldID, 0))
+}
+
+func TestSimulationRunner_Seed(t *testing.T) {
+	worldID := uuid.New()
+	runner := NewSimulationRunner(DefaultConfig(worldID), nil, nil)
+	assert.Equal(t, WorldSeed(worldID), runner.Seed())
+
+	config := DefaultConfig(worldID)
+	config.Seed = 1234
+	assert.Equal(t, int64(1234), NewSimulationRunner(config, nil, nil).Seed())
+}
@@ -7,6 +7,7 @@ import (
 	"tw-backend/internal/ai/behaviortree"
 	goap "tw-backend/internal/ai/goap"
 	"tw-backend/internal/ecosystem/state"
+	"tw-backend/internal/world"
 	"tw-backend/internal/worldgen/geography"
 
 	"github.com/google/uuid"
@@ -24,8 +25,16 @@ type Service struct {
 
 	// Map of entity ID to its current behavior tree
 	Behaviors map[uuid.UUID]behaviortree.Node
+
+	// Per-world day/night clocks, advanced once per Tick. They have their
+	// own lock so light levels can be read while Tick holds mu.
+	clocks  map[uuid.UUID]*world.Clock
+	clockMu sync.RWMutex
 }
 
+// HuntRange is how far a predator spots prey in full daylight, in meters
+const HuntRange = 50.0
+
 func NewService(seed int64) *Service {
 	return &Service{
 		Entities:         make(map[uuid.UUID]*state.LivingEntityState),
@@ -34,7 +43,64 @@ func NewService(seed int64) *Service {
 		Planner:          goap.NewPlanner(),
 		EvolutionManager: NewEvolutionManager(),
 		Behaviors:        make(map[uuid.UUID]behaviortree.Node),
+		clocks:           make(map[uuid.UUID]*world.Clock),
+	}
+}
+
+// SetWorldClock attaches a day/night clock to a world
+func (s *Service) SetWorldClock(worldID uuid.UUID, clock *world.Clock) {
+	s.clockMu.Lock()
+	defer s.clockMu.Unlock()
+	s.clocks[worldID] = clock
+}
+
+// EnsureWorldClock returns the world's clock, creating one whose day length
+// is derived from the planet circumference if none exists yet
+func (s *Service) EnsureWorldClock(worldID uuid.UUID, circumferenceMeters float64) *world.Clock {
+	s.clockMu.Lock()
+	defer s.clockMu.Unlock()
+	if clock, ok := s.clocks[worldID]; ok {
+		return clock
 	}
+	clock := world.NewClock(world.DayLengthForCircumference(circumferenceMeters), world.DefaultTickDuration)
+	s.clocks[worldID] = clock
+	return clock
+}
+
+// GetWorldClock returns the world's clock, or nil if it has none
+func (s *Service) GetWorldClock(worldID uuid.UUID) *world.Clock {
+	s.clockMu.RLock()
+	defer s.clockMu.RUnlock()
+	return s.clocks[worldID]
+}
+
+// WorldClocks returns every world's clock, for persisting them
+func (s *Service) WorldClocks() map[uuid.UUID]*world.Clock {
+	s.clockMu.RLock()
+	defer s.clockMu.RUnlock()
+	clocks := make(map[uuid.UUID]*world.Clock, len(s.clocks))
+	for id, clock := range s.clocks {
+		clocks[id] = clock
+	}
+	return clocks
+}
+
+// abroad reports whether e is out at this time of day. Worlds without a
+// clock have no day/night cycle: they are always lit (see LightLevel) and
+// every creature is abroad.
+func (s *Service) abroad(e *state.LivingEntityState) bool {
+	clock := s.GetWorldClock(e.WorldID)
+	return clock == nil || e.IsActive(clock.IsNight())
+}
+
+// LightLevel returns the ambient light for a world.
+// Worlds without a clock are treated as permanently lit.
+func (s *Service) LightLevel(worldID uuid.UUID) float64 {
+	clock := s.GetWorldClock(worldID)
+	if clock == nil {
+		return 1.0
+	}
+	return clock.LightLevel()
 }
 
 // GetEvolutionManager returns the evolution manager for reproduction
@@ -107,6 +173,10 @@ func (s *Service) Tick() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	for _, clock := range s.WorldClocks() {
+		clock.Advance(1)
+	}
+
 	// Pre-categorize entities by diet for O(1) prey lookup
 	var flora, herbivores []*state.LivingEntityState
 	var floraIDs, herbivoreIDs []uuid.UUID
@@ -121,10 +191,15 @@ func (s *Service) Tick() {
 		}
 	}
 
+	// Removals wait until the pass ends, so prey eaten after its own turn
+	// is removed as surely as prey eaten before it
 	toRemove := make(map[uuid.UUID]bool)
 
 	// Single pass: aging, feeding, death check
 	for id, entity := range s.Entities {
+		if toRemove[id] {
+			continue // Eaten earlier this tick
+		}
 		entity.Age++
 		s.Needs.Tick(entity, nil)
 
@@ -144,11 +219,12 @@ func (s *Service) Tick() {
 				preyIDs = append(floraIDs, herbivoreIDs...)
 			}
 
-			// Random prey selection (O(1) instead of O(n))
+			// Random prey selection (O(1) instead of O(n)), caught only
+			// if the predator can see it
 			if len(preyList) > 0 {
 				idx := rand.Intn(len(preyList))
 				preyID := preyIDs[idx]
-				if !toRemove[preyID] && preyID != id {
+				if !toRemove[preyID] && preyID != id && s.spots(entity, preyList[idx]) {
 					entity.Needs.Hunger = 0
 					entity.Needs.Thirst *= 0.5
 					toRemove[preyID] = true
@@ -157,29 +233,26 @@ func (s *Service) Tick() {
 		}
 
 		// Death check
-		shouldDie := toRemove[id]
-		if !shouldDie {
-			maxAge := int64(18250) // Flora: 50 years
-			switch entity.Diet {
-			case state.DietHerbivore:
-				maxAge = 3650
-			case state.DietCarnivore, state.DietOmnivore:
-				maxAge = 5475
-			}
-			if entity.Age > maxAge {
-				shouldDie = true
-			}
-			if entity.Diet != state.DietPhotosynthetic {
-				if entity.Needs.Hunger >= 100 || entity.Needs.Thirst >= 100 {
-					shouldDie = true
-				}
+		maxAge := int64(18250) // Flora: 50 years
+		switch entity.Diet {
+		case state.DietHerbivore:
+			maxAge = 3650
+		case state.DietCarnivore, state.DietOmnivore:
+			maxAge = 5475
+		}
+		if entity.Age > maxAge {
+			toRemove[id] = true
+		}
+		if entity.Diet != state.DietPhotosynthetic {
+			if entity.Needs.Hunger >= 100 || entity.Needs.Thirst >= 100 {
+				toRemove[id] = true
 			}
 		}
+	}
 
-		if shouldDie {
-			delete(s.Entities, id)
-			delete(s.Behaviors, id)
-		}
+	for id := range toRemove {
+		delete(s.Entities, id)
+		delete(s.Behaviors, id)
 	}
 }
 
@@ -205,6 +278,9 @@ func (s *Service) TickWithStats() (flora, herbivores, carnivores, deaths int) {
 	toRemove := make(map[uuid.UUID]bool)
 
 	for id, entity := range s.Entities {
+		if toRemove[id] {
+			continue
+		}
 		entity.Age++
 		s.Needs.Tick(entity, nil)
 
@@ -223,7 +299,7 @@ func (s *Service) TickWithStats() (flora, herbivores, carnivores, deaths int) {
 			if len(preyList) > 0 {
 				idx := rand.Intn(len(preyList))
 				preyID := preyIDs[idx]
-				if !toRemove[preyID] && preyID != id {
+				if !toRemove[preyID] && preyID != id && s.spots(entity, preyList[idx]) {
 					entity.Needs.Hunger = 0
 					entity.Needs.Thirst *= 0.5
 					toRemove[preyID] = true
@@ -231,30 +307,27 @@ func (s *Service) TickWithStats() (flora, herbivores, carnivores, deaths int) {
 			}
 		}
 
-		shouldDie := toRemove[id]
-		if !shouldDie {
-			maxAge := int64(18250)
-			switch entity.Diet {
-			case state.DietHerbivore:
-				maxAge = 3650
-			case state.DietCarnivore, state.DietOmnivore:
-				maxAge = 5475
-			}
-			if entity.Age > maxAge {
-				shouldDie = true
-			}
-			if entity.Diet != state.DietPhotosynthetic {
-				if entity.Needs.Hunger >= 100 || entity.Needs.Thirst >= 100 {
-					shouldDie = true
-				}
+		maxAge := int64(18250)
+		switch entity.Diet {
+		case state.DietHerbivore:
+			maxAge = 3650
+		case state.DietCarnivore, state.DietOmnivore:
+			maxAge = 5475
+		}
+		if entity.Age > maxAge {
+			toRemove[id] = true
+		}
+		if entity.Diet != state.DietPhotosynthetic {
+			if entity.Needs.Hunger >= 100 || entity.Needs.Thirst >= 100 {
+				toRemove[id] = true
 			}
 		}
+	}
 
-		if shouldDie {
-			deaths++
-			delete(s.Entities, id)
-			delete(s.Behaviors, id)
-		}
+	for id := range toRemove {
+		deaths++
+		delete(s.Entities, id)
+		delete(s.Behaviors, id)
 	}
 
 	// Count remaining
@@ -281,7 +354,30 @@ func (s *Service) GetEntity(id uuid.UUID) *state.LivingEntityState {
 	return s.Entities[id]
 }
 
-// GetEntitiesAt returns entities within a radius of a location
+// SightRange returns how far an entity can see given its world's light level
+func (s *Service) SightRange(e *state.LivingEntityState, baseRange float64) float64 {
+	return baseRange * world.VisibilityMultiplier(s.LightLevel(e.WorldID), e.NightVision)
+}
+
+// spots reports whether a feeding creature can see its prey. In a world
+// with a day/night clock the prey must be in the same world, abroad at this
+// time of day, and within the hunter's SightRange of HuntRange; elsewhere
+// any prey is caught as before. Plants are grazed without hunting for them.
+func (s *Service) spots(e, prey *state.LivingEntityState) bool {
+	if prey.Diet == state.DietPhotosynthetic || s.GetWorldClock(e.WorldID) == nil {
+		return true
+	}
+	if e.WorldID != prey.WorldID || !s.abroad(prey) {
+		return false
+	}
+	dx := prey.PositionX - e.PositionX
+	dy := prey.PositionY - e.PositionY
+	sight := s.SightRange(e, HuntRange)
+	return dx*dx+dy*dy <= sight*sight
+}
+
+// GetEntitiesAt returns active entities within a radius of a location.
+// Nocturnal entities are hidden while it is day in a world with a clock.
 func (s *Service) GetEntitiesAt(worldID uuid.UUID, x, y, radius float64) []*state.LivingEntityState {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -290,7 +386,7 @@ func (s *Service) GetEntitiesAt(worldID uuid.UUID, x, y, radius float64) []*stat
 	rSq := radius * radius
 
 	for _, e := range s.Entities {
-		if e.WorldID != worldID {
+		if e.WorldID != worldID || !s.abroad(e) {
 			continue
 		}
 		dx := e.PositionX - x
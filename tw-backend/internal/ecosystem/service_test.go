@@ -2,7 +2,9 @@ package ecosystem
 
 import (
 	"testing"
+	"time"
 	"tw-backend/internal/ecosystem/state"
+	"tw-backend/internal/world"
 	"tw-backend/internal/worldgen/geography"
 
 	"github.com/google/uuid"
@@ -187,3 +189,117 @@ func TestService_GetEvolutionManager(t *testing.T) {
 	assert.NotNil(t, em)
 	assert.Equal(t, sim.EvolutionManager, em)
 }
+
+func TestService_Tick_AdvancesWorldClock(t *testing.T) {
+	sim := NewService(999)
+	worldID := uuid.New()
+	clock := sim.EnsureWorldClock(worldID, world.EarthCircumferenceMeters)
+	assert.Same(t, clock, sim.EnsureWorldClock(worldID, 0), "Existing clock is reused")
+	assert.Equal(t, world.DefaultDayLength, clock.DayLength())
+
+	sim.Tick()
+	sim.Tick()
+	assert.Equal(t, int64(2), clock.Ticks())
+}
+
+func TestService_GetEntitiesAt_NocturnalOnlyAtNight(t *testing.T) {
+	sim := NewService(999)
+	worldID := uuid.New()
+	clock := world.NewClock(24*time.Hour, time.Minute)
+	sim.SetWorldClock(worldID, clock)
+
+	scorpion := sim.Spawner.CreateEntity(state.SpeciesScorpion, 1)
+	scorpion.WorldID = worldID
+	rabbit := sim.Spawner.CreateEntity(state.SpeciesRabbit, 1)
+	rabbit.WorldID = worldID
+	sim.Entities[scorpion.EntityID] = scorpion
+	sim.Entities[rabbit.EntityID] = rabbit
+
+	// Midnight: both abroad
+	assert.Len(t, sim.GetEntitiesAt(worldID, 0, 0, 10), 2)
+
+	// Noon: nocturnal scorpion is hidden
+	clock.Set(12 * time.Hour)
+	found := sim.GetEntitiesAt(worldID, 0, 0, 10)
+	require.Len(t, found, 1)
+	assert.Equal(t, state.SpeciesRabbit, found[0].Species)
+}
+
+func TestService_SightRange_NightVision(t *testing.T) {
+	sim := NewService(999)
+	worldID := uuid.New()
+	sim.SetWorldClock(worldID, world.NewClock(24*time.Hour, time.Minute)) // Midnight
+
+	owl := &state.LivingEntityState{WorldID: worldID, NightVision: 1.0}
+	rabbit := &state.LivingEntityState{WorldID: worldID}
+
+	assert.InDelta(t, 20.0, sim.SightRange(owl, 20), 0.001, "NightVision creature sees normally in darkness")
+	assert.Less(t, sim.SightRange(rabbit, 20), 20.0, "Darkness shrinks sight without NightVision")
+}
+
+func TestService_Tick_PredatorsHuntBySight(t *testing.T) {
+	hunt := func(gameTime time.Duration, nightVision float64) bool {
+		sim := NewService(999)
+		worldID := uuid.New()
+		clock := world.NewClock(24*time.Hour, time.Minute)
+		clock.Set(gameTime)
+		sim.SetWorldClock(worldID, clock)
+
+		wolf := &state.LivingEntityState{
+			EntityID: uuid.New(), Species: state.SpeciesWolf, Diet: state.DietCarnivore,
+			WorldID: worldID, NightVision: nightVision, Needs: state.NeedState{Hunger: 60},
+		}
+		rabbit := &state.LivingEntityState{
+			EntityID: uuid.New(), Species: state.SpeciesRabbit, Diet: state.DietHerbivore,
+			WorldID: worldID, PositionX: 30,
+		}
+		sim.Entities[wolf.EntityID] = wolf
+		sim.Entities[rabbit.EntityID] = rabbit
+
+		sim.Tick()
+		_, alive := sim.Entities[rabbit.EntityID]
+		return !alive
+	}
+
+	assert.True(t, hunt(12*time.Hour, 0), "At noon the rabbit 30m away is in sight")
+	assert.False(t, hunt(0, 0), "At midnight it is lost in the dark")
+	assert.True(t, hunt(0, 1.0), "Night vision hunts in the dark")
+}
+
+func TestService_Tick_RemovesPreyVisitedFirst(t *testing.T) {
+	// Map order decides whether prey takes its turn before its hunter;
+	// either way a caught rabbit must be gone after the tick
+	for i := 0; i < 50; i++ {
+		sim := NewService(int64(i))
+		wolf := &state.LivingEntityState{
+			EntityID: uuid.New(), Species: state.SpeciesWolf, Diet: state.DietCarnivore,
+			Needs: state.NeedState{Hunger: 60},
+		}
+		rabbit := &state.LivingEntityState{
+			EntityID: uuid.New(), Species: state.SpeciesRabbit, Diet: state.DietHerbivore,
+		}
+		sim.Entities[wolf.EntityID] = wolf
+		sim.Entities[rabbit.EntityID] = rabbit
+
+		_, _, _, deaths := sim.TickWithStats()
+		require.Equal(t, 1, deaths)
+		_, alive := sim.Entities[rabbit.EntityID]
+		require.False(t, alive, "The caught rabbit is removed")
+	}
+}
+
+func TestService_NoClock_TimelessWorld(t *testing.T) {
+	sim := NewService(999)
+	worldID := uuid.New()
+
+	scorpion := sim.Spawner.CreateEntity(state.SpeciesScorpion, 1)
+	scorpion.WorldID = worldID
+	sim.Entities[scorpion.EntityID] = scorpion
+
+	assert.Equal(t, 1.0, sim.LightLevel(worldID), "A world without a clock is always lit")
+	assert.Len(t, sim.GetEntitiesAt(worldID, 0, 0, 10), 1, "and every creature is abroad")
+
+	wolf := &state.LivingEntityState{WorldID: worldID, Diet: state.DietCarnivore}
+	far := &state.LivingEntityState{WorldID: worldID, Diet: state.DietHerbivore, PositionX: 10 * HuntRange}
+	assert.True(t, sim.spots(wolf, far), "Without a clock prey is caught at any distance, as before sight hunting")
+}
@@ -69,6 +69,7 @@ type StepConfig struct {
 	SimulateGeology      bool
 	SimulateDiseases     bool
 	GlobalTempMod        float64
+	MutationPulse        bool // Revive stagnant species with a variance boost
 	EventHandler         EventHandler
 	TurningPointDetector TurningPointDetector // Optional: check for turning points after each sub-step
 }
@@ -122,9 +123,10 @@ func ApplyPeriodicSpeciation(popSim *population.PopulationSimulator, config Step
 		return 0, 0
 	}
 
-	// Atmospheric oxygen
+	// Atmospheric oxygen (and the wildfires it drives)
 	popSim.UpdateOxygenLevel()
 	popSim.ApplyOxygenEffects()
+	popSim.ApplyWildfires()
 
 	// Speciation
 	newSpecies = popSim.CheckSpeciation()
@@ -132,6 +134,11 @@ func ApplyPeriodicSpeciation(popSim *population.PopulationSimulator, config Step
 	// Migration
 	migrants = popSim.ApplyMigrationCycle()
 
+	// Evolutionary dead-ends (logged to popSim.Events)
+	if popSim.CurrentYear%population.StagnationCheckInterval == 0 {
+		popSim.ApplyStagnationCheck(config.MutationPulse)
+	}
+
 	return newSpecies, migrants
 }
 
@@ -150,36 +157,71 @@ func ApplyDiseaseUpdate(
 		return 0
 	}
 
-	outbreakCount := 0
+	// Check for spontaneous outbreaks in a stable species order, then for
+	// pathogens jumping between species that meet
+	speciesData := DiseaseSpeciesData(popSim)
+	outbreakCount := len(diseaseSystem.CheckSpontaneousOutbreaks(popSim.CurrentYear, speciesData))
+	outbreakCount += len(diseaseSystem.CheckZoonoticJumps(popSim.CurrentYear, speciesData))
+
+	// Update all active outbreaks
+	diseaseSystem.Update(popSim.CurrentYear, speciesData)
+
+	return outbreakCount
+}
 
-	// Build species data for disease system
+// DiseaseSpeciesData aggregates each living species across biomes for the
+// disease system. Density is the species' share of its biomes' combined capacity.
+// Carnivores and omnivores prey on the herbivores they share a biome with.
+func DiseaseSpeciesData(popSim *population.PopulationSimulator) map[uuid.UUID]pathogen.SpeciesInfo {
 	speciesData := make(map[uuid.UUID]pathogen.SpeciesInfo)
+	capacity := make(map[uuid.UUID]int64)
 	for _, biome := range popSim.Biomes {
+		var herbivores []uuid.UUID
+		for _, sp := range biome.Species {
+			if sp.Count > 0 && sp.Diet == population.DietHerbivore {
+				herbivores = append(herbivores, sp.SpeciesID)
+			}
+		}
+
 		for _, sp := range biome.Species {
-			if sp.Count > 0 {
-				speciesData[sp.SpeciesID] = pathogen.SpeciesInfo{
-					Population:        sp.Count,
+			if sp.Count <= 0 {
+				continue
+			}
+			info, exists := speciesData[sp.SpeciesID]
+			if !exists {
+				info = pathogen.SpeciesInfo{
+					ID:                sp.SpeciesID,
+					Name:              sp.Name,
 					DiseaseResistance: float32(sp.Traits.DiseaseResistance),
 					DietType:          string(sp.Diet),
-					Density:           float64(sp.Count) / float64(biome.CarryingCapacity+1),
 				}
-
-				// Check for spontaneous outbreaks
-				_, outbreak := diseaseSystem.CheckSpontaneousOutbreak(
-					sp.SpeciesID, sp.Name, sp.Count,
-					float64(sp.Count)/float64(biome.CarryingCapacity+1),
-				)
-				if outbreak != nil {
-					outbreakCount++
+				if sp.GeneticCode != nil {
+					info.Genes = sp.GeneticCode.DefinedGenes[:]
+				}
+			}
+			info.Population += sp.Count
+			capacity[sp.SpeciesID] += biome.CarryingCapacity
+			info.Density = float64(info.Population) / float64(capacity[sp.SpeciesID]+1)
+			info.BiomeIDs = append(info.BiomeIDs, biome.BiomeID)
+			if sp.Diet == population.DietCarnivore || sp.Diet == population.DietOmnivore {
+				for _, prey := range herbivores {
+					info.Prey = appendUniqueID(info.Prey, prey)
 				}
 			}
+			speciesData[sp.SpeciesID] = info
 		}
 	}
+	return speciesData
+}
 
-	// Update all active outbreaks
-	diseaseSystem.Update(popSim.CurrentYear, speciesData)
-
-	return outbreakCount
+// appendUniqueID appends id to ids unless it's already there
+func appendUniqueID(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
+	for _, x := range ids {
+		if x == id {
+			return ids
+		}
+	}
+	return append(ids, id)
 }
 
 // ShouldUpdateGeology returns true if geology should be updated this year
@@ -3,6 +3,7 @@ package ecosystem
 import (
 	"math/rand"
 	"time"
+	"tw-backend/internal/ecosystem/population"
 	"tw-backend/internal/ecosystem/state"
 	"tw-backend/internal/npc/genetics"
 	"tw-backend/internal/worldgen/geography"
@@ -40,12 +41,15 @@ func (s *Spawner) SpawnEntitiesForBiome(biome geography.BiomeType, count int) []
 // CreateEntity initializes a new living entity with default stats for its species
 func (s *Spawner) CreateEntity(species state.Species, generation int) *state.LivingEntityState {
 	// Basic default
+	nocturnal, nightVision := getActivityForSpecies(species)
 	return &state.LivingEntityState{
-		EntityID:   uuid.New(),
-		Species:    species,
-		Diet:       getDietForSpecies(species),
-		Age:        0,
-		Generation: generation,
+		EntityID:    uuid.New(),
+		Species:     species,
+		Diet:        getDietForSpecies(species),
+		Nocturnal:   nocturnal,
+		NightVision: nightVision,
+		Age:         0,
+		Generation:  generation,
 		Needs: state.NeedState{
 			Hunger:           0,
 			Thirst:           0,
@@ -89,3 +93,83 @@ func getDietForSpecies(s state.Species) state.DietType {
 		return state.DietHerbivore
 	}
 }
+
+// getActivityForSpecies returns whether a species is night-only and how well it sees in the dark
+func getActivityForSpecies(s state.Species) (nocturnal bool, nightVision float64) {
+	switch s {
+	case state.SpeciesScorpion:
+		return true, 1.0
+	case state.SpeciesWolf:
+		return false, 0.8
+	case state.SpeciesHawk, state.SpeciesVulture:
+		return false, 0.0
+	default:
+		return false, 0.3
+	}
+}
+
+// CreateFromSpecies initializes a creature of a simulated species, named for
+// it and linked to its record so it looks the way the species has evolved
+func (s *Spawner) CreateFromSpecies(sp *population.SpeciesPopulation) *state.LivingEntityState {
+	e := s.CreateEntity(state.Species(sp.Name), 1)
+	id := sp.SpeciesID
+	e.SpeciesID = &id
+	e.Diet = state.DietType(sp.Diet)
+	e.NightVision = sp.Traits.NightVision
+	return e
+}
+
+// SpeciesTraits returns the evolved traits that characterize a spawned
+// species, starting from its diet's defaults
+func SpeciesTraits(s state.Species) population.EvolvableTraits {
+	diet := population.DietType(getDietForSpecies(s))
+	t := population.DefaultTraitsForDiet(diet)
+	_, t.NightVision = getActivityForSpecies(s)
+
+	switch s {
+	case state.SpeciesLizard:
+		t.Size, t.Strength, t.Speed, t.Covering, t.HeatResistance, t.Camouflage = 0.4, 1.0, 5.0, population.CoveringScales, 0.9, 0.8
+	case state.SpeciesScorpion:
+		t.Size, t.Strength, t.Covering, t.VenomPotency, t.HeatResistance = 0.1, 1.0, population.CoveringShell, 0.8, 0.9
+	case state.SpeciesVulture:
+		t.Size, t.Covering, t.Social, t.Display = 1.5, population.CoveringFeathers, 0.6, 0.2
+	case state.SpeciesDeer:
+		t.Size, t.Speed, t.Covering, t.Display, t.Social = 2.5, 7.5, population.CoveringFur, 0.6, 0.8
+	case state.SpeciesWolf:
+		t.Size, t.Strength, t.Speed, t.Covering, t.Social, t.ColdResistance = 2.0, 4.0, 6.5, population.CoveringFur, 0.9, 0.8
+	case state.SpeciesBear:
+		t.Size, t.Strength, t.Speed, t.Covering, t.ColdResistance, t.Social = 5.0, 8.0, 4.0, population.CoveringFur, 0.8, 0.1
+	case state.SpeciesRabbit:
+		t.Size, t.Speed, t.Covering, t.Camouflage = 0.2, 7.5, population.CoveringFur, 0.5
+	case state.SpeciesHawk:
+		t.Size, t.Strength, t.Speed, t.Covering, t.Social, t.Display = 0.8, 2.0, 9.0, population.CoveringFeathers, 0.1, 0.5
+	case state.SpeciesBison:
+		t.Size, t.Strength, t.Speed, t.Covering, t.ColdResistance, t.Social = 6.0, 7.5, 4.0, population.CoveringFur, 0.8, 0.9
+	case state.SpeciesCactus:
+		t.Size, t.FloraGrowth, t.Covering, t.Display = 1.5, population.FloraSucculent, population.CoveringSkin, 0.6
+	case state.SpeciesFern:
+		t.Size, t.FloraGrowth, t.Covering = 0.8, population.FloraPerennial, population.CoveringNone
+	case state.SpeciesOak:
+		t.Size, t.FloraGrowth, t.Covering = 8.0, population.FloraDeciduous, population.CoveringBark
+	case state.SpeciesGrass:
+		t.Size, t.FloraGrowth, t.Covering = 0.3, population.FloraPerennial, population.CoveringNone
+	case state.SpeciesKelp:
+		t.Size, t.FloraGrowth, t.Covering = 4.0, population.FloraAquatic, population.CoveringNone
+	case state.SpeciesCyanobacteria, state.SpeciesStromatolite:
+		t.Size, t.FloraGrowth, t.Covering = 0.1, population.FloraAquatic, population.CoveringNone
+	case state.SpeciesEdiacaran, state.SpeciesDickinsonia, state.SpeciesCharnia:
+		t.Size, t.Speed, t.Covering, t.Social = 0.5, 0.1, population.CoveringNone, 0.0
+	}
+	return t
+}
+
+// DescribeSpecies returns the prose appearance of a built-in spawned species
+func DescribeSpecies(s state.Species) string {
+	return population.DescribeAppearance(SpeciesTraits(s), population.DietType(getDietForSpecies(s)))
+}
+
+// DescribeSimulatedSpecies returns the prose appearance of a simulated
+// species, from the traits it has evolved
+func DescribeSimulatedSpecies(sp *population.SpeciesPopulation) string {
+	return population.DescribeAppearance(sp.Traits, sp.Diet)
+}
@@ -2,10 +2,13 @@ package ecosystem
 
 import (
 	"testing"
+	"tw-backend/internal/ecosystem/population"
 	"tw-backend/internal/ecosystem/state"
 	"tw-backend/internal/worldgen/geography"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSpawner_SpawnEntitiesForBiome(t *testing.T) {
@@ -53,3 +56,35 @@ func TestSpawner_DietAssignment(t *testing.T) {
 	rabbit := spawner.CreateEntity(state.SpeciesRabbit, 1)
 	assert.Equal(t, state.DietHerbivore, rabbit.Diet)
 }
+
+func TestDescribeSpecies_StablePerSpecies(t *testing.T) {
+	species := []state.Species{state.SpeciesRabbit, state.SpeciesWolf, state.SpeciesBear,
+		state.SpeciesHawk, state.SpeciesScorpion, state.SpeciesOak, state.SpeciesCactus}
+
+	seen := make(map[string]state.Species)
+	for _, s := range species {
+		desc := DescribeSpecies(s)
+		assert.Equal(t, desc, DescribeSpecies(s), "description of %s should be stable", s)
+		if other, dup := seen[desc]; dup {
+			t.Errorf("%s and %s share description %q", s, other, desc)
+		}
+		seen[desc] = s
+	}
+
+	assert.Contains(t, DescribeSpecies(state.SpeciesScorpion), "venomous")
+	assert.Contains(t, DescribeSpecies(state.SpeciesOak), "tree")
+}
+
+func TestSpawner_CreateFromSpecies(t *testing.T) {
+	traits := population.DefaultTraitsForDiet(population.DietCarnivore)
+	traits.NightVision = 0.9
+	record := &population.SpeciesPopulation{SpeciesID: uuid.New(), Name: "Dusk Stalker", Diet: population.DietCarnivore, Traits: traits}
+
+	e := NewSpawner(1).CreateFromSpecies(record)
+
+	assert.Equal(t, state.Species("Dusk Stalker"), e.Species)
+	require.NotNil(t, e.SpeciesID)
+	assert.Equal(t, record.SpeciesID, *e.SpeciesID)
+	assert.Equal(t, state.DietCarnivore, e.Diet)
+	assert.Equal(t, 0.9, e.NightVision)
+}
@@ -51,12 +51,17 @@ const (
 type LivingEntityState struct {
 	EntityID   uuid.UUID    `json:"entity_id"`
 	Species    Species      `json:"species"`
+	SpeciesID  *uuid.UUID   `json:"species_id,omitempty"` // Simulated species' population record; nil for built-in species
 	Diet       DietType     `json:"diet"`
 	Age        int64        `json:"age"` // In ticks
 	Generation int          `json:"generation"`
 	Needs      NeedState    `json:"needs"`
 	DNA        genetics.DNA `json:"dna"`
 
+	// Day/night activity
+	Nocturnal   bool    `json:"nocturnal,omitempty"`    // Only active at night
+	NightVision float64 `json:"night_vision,omitempty"` // 0.0 to 1.0, sight retained in darkness
+
 	// Location info for game integration
 	WorldID   uuid.UUID `json:"world_id"`
 	PositionX float64   `json:"position_x"`
@@ -70,6 +75,12 @@ type LivingEntityState struct {
 	Parent2ID *uuid.UUID `json:"parent2_id,omitempty"`
 }
 
+// IsActive reports whether the entity is abroad at this time of day.
+// Nocturnal creatures stay hidden while the sun is up.
+func (e *LivingEntityState) IsActive(isNight bool) bool {
+	return !e.Nocturnal || isNight
+}
+
 // DecisionLog records an AI decision
 type DecisionLog struct {
 	Timestamp int64  `json:"timestamp"` // Unix timestamp or Tick count
@@ -0,0 +1,32 @@
+package ecosystem
+
+import (
+	"math"
+
+	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/geography"
+)
+
+// TerrainCostOptions configures TerrainCost
+type TerrainCostOptions struct {
+	SeaLevel     float64 // meters; cells below are impassable water
+	MaxClimb     float64 // meters of elevation change per step; steeper steps are impassable
+	ClimbPenalty float64 // Extra cost per meter of elevation change
+}
+
+// TerrainCost returns a PathCostFunc over heightmap: a step costs 1 plus
+// ClimbPenalty per meter climbed or descended, and steps into water or
+// steeper than MaxClimb are impassable.
+func TerrainCost(heightmap *geography.SphereHeightmap, opts TerrainCostOptions) PathCostFunc {
+	return func(from, to spatial.Coordinate) float64 {
+		toElev := heightmap.Get(to)
+		if toElev < opts.SeaLevel {
+			return math.Inf(1)
+		}
+		climb := math.Abs(toElev - heightmap.Get(from))
+		if climb > opts.MaxClimb {
+			return math.Inf(1)
+		}
+		return 1 + climb*opts.ClimbPenalty
+	}
+}
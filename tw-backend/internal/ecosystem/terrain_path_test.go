@@ -0,0 +1,170 @@
+package ecosystem
+
+import (
+	"testing"
+
+	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pathTestResolution = 16
+
+// flatWorld returns a topology and a heightmap 10m above sea level everywhere
+func flatWorld() (*spatial.CubeSphereTopology, *geography.SphereHeightmap) {
+	topology := spatial.NewCubeSphereTopology(pathTestResolution)
+	heightmap := geography.NewSphereHeightmap(topology)
+	for face := 0; face < 6; face++ {
+		for x := 0; x < pathTestResolution; x++ {
+			for y := 0; y < pathTestResolution; y++ {
+				heightmap.Set(spatial.Coordinate{Face: face, X: x, Y: y}, 10)
+			}
+		}
+	}
+	return topology, heightmap
+}
+
+// requireConnected checks path runs from start to end in single steps
+func requireConnected(t *testing.T, topology spatial.Topology, path []spatial.Coordinate, start, end spatial.Coordinate) {
+	t.Helper()
+	require.NotEmpty(t, path)
+	assert.Equal(t, start, path[0])
+	assert.Equal(t, end, path[len(path)-1])
+	for i := 1; i < len(path); i++ {
+		adjacent := false
+		for _, dir := range pathDirections {
+			if topology.GetNeighbor(path[i-1], dir) == path[i] {
+				adjacent = true
+			}
+		}
+		require.True(t, adjacent, "step %d: %v is not next to %v", i, path[i], path[i-1])
+	}
+}
+
+func TestFindPath_UniformIsShortest(t *testing.T) {
+	topology, _ := flatWorld()
+	start := spatial.Coordinate{Face: 0, X: 2, Y: 5}
+	end := spatial.Coordinate{Face: 0, X: 12, Y: 9}
+
+	path := FindPath(topology, start, end)
+	requireConnected(t, topology, path, start, end)
+	assert.Len(t, path, 15, "10 steps east and 4 south, plus the start")
+}
+
+func TestFindPath_RoutesAroundRidge(t *testing.T) {
+	topology, heightmap := flatWorld()
+
+	// A 3km ridge across face 0 at X=8, open only near the bottom edge
+	ridge := make(map[spatial.Coordinate]bool)
+	for y := 0; y < 13; y++ {
+		c := spatial.Coordinate{Face: 0, X: 8, Y: y}
+		heightmap.Set(c, 3000)
+		ridge[c] = true
+	}
+	start := spatial.Coordinate{Face: 0, X: 4, Y: 6}
+	end := spatial.Coordinate{Face: 0, X: 12, Y: 6}
+
+	// Ignoring terrain, the path goes straight over
+	direct := FindPath(topology, start, end)
+	requireConnected(t, topology, direct, start, end)
+	assert.Contains(t, direct, spatial.Coordinate{Face: 0, X: 8, Y: 6})
+
+	cost := TerrainCost(heightmap, TerrainCostOptions{SeaLevel: 0, MaxClimb: 500, ClimbPenalty: 0.01})
+	path := FindPath(topology, start, end, WithCost(cost))
+	requireConnected(t, topology, path, start, end)
+	for _, c := range path {
+		assert.False(t, ridge[c], "path crosses the ridge at %v", c)
+	}
+	assert.Greater(t, len(path), len(direct), "going around is longer than going over")
+}
+
+func TestFindPath_ClimbPenaltyAvoidsHills(t *testing.T) {
+	topology, heightmap := flatWorld()
+	hill := spatial.Coordinate{Face: 0, X: 8, Y: 8}
+	heightmap.Set(hill, 300) // Climbable, but costly
+
+	start := spatial.Coordinate{Face: 0, X: 5, Y: 8}
+	end := spatial.Coordinate{Face: 0, X: 11, Y: 8}
+
+	cost := TerrainCost(heightmap, TerrainCostOptions{SeaLevel: 0, MaxClimb: 500, ClimbPenalty: 0.1})
+	path := FindPath(topology, start, end, WithCost(cost))
+	requireConnected(t, topology, path, start, end)
+	assert.NotContains(t, path, hill)
+	assert.Len(t, path, 9, "a two-step detour beats climbing 290m up and down")
+}
+
+func TestFindPath_WaterIsImpassable(t *testing.T) {
+	topology, heightmap := flatWorld()
+	island := spatial.Coordinate{Face: 0, X: 10, Y: 10}
+	for _, dir := range pathDirections {
+		heightmap.Set(topology.GetNeighbor(island, dir), -50)
+	}
+
+	cost := TerrainCost(heightmap, TerrainCostOptions{SeaLevel: 0, MaxClimb: 500})
+	path := FindPath(topology, spatial.Coordinate{Face: 0, X: 2, Y: 2}, island, WithCost(cost))
+	assert.Nil(t, path, "the island is cut off by water")
+}
+
+// bfsSteps returns the fewest steps between two cells, searching breadth-first
+func bfsSteps(topology spatial.Topology, start, end spatial.Coordinate) int {
+	steps := map[spatial.Coordinate]int{start: 0}
+	frontier := []spatial.Coordinate{start}
+	for len(frontier) > 0 {
+		current := frontier[0]
+		frontier = frontier[1:]
+		if current == end {
+			return steps[current]
+		}
+		for _, dir := range pathDirections {
+			next := topology.GetNeighbor(current, dir)
+			if _, seen := steps[next]; !seen {
+				steps[next] = steps[current] + 1
+				frontier = append(frontier, next)
+			}
+		}
+	}
+	return -1
+}
+
+func TestFindPath_CrossesFaceSeams(t *testing.T) {
+	topology, _ := flatWorld()
+
+	// Left face to right face: the straight route runs across the front face
+	start := spatial.Coordinate{Face: 2, X: 4, Y: 7}
+	end := spatial.Coordinate{Face: 3, X: 11, Y: 7}
+
+	path := FindPath(topology, start, end)
+	requireConnected(t, topology, path, start, end)
+
+	seams := 0
+	seen := make(map[spatial.Coordinate]bool)
+	for i, c := range path {
+		assert.False(t, seen[c], "cell %v visited twice", c)
+		seen[c] = true
+		if i > 0 && c.Face != path[i-1].Face {
+			seams++
+		}
+	}
+	assert.GreaterOrEqual(t, seams, 2, "the path should cross at least two face boundaries")
+	assert.Equal(t, bfsSteps(topology, start, end), len(path)-1, "A* should find a shortest path across seams")
+}
+
+func TestFindPath_ShortestEverywhere(t *testing.T) {
+	// An overestimating heuristic shows up as a path longer than BFS finds
+	const resolution = 8
+	topology := spatial.NewCubeSphereTopology(resolution)
+	starts := []spatial.Coordinate{{Face: 0, X: 0, Y: 0}, {Face: 4, X: 4, Y: 4}, {Face: 2, X: 7, Y: 1}}
+	for _, start := range starts {
+		for face := 0; face < 6; face++ {
+			for x := 0; x < resolution; x++ {
+				for y := 0; y < resolution; y++ {
+					end := spatial.Coordinate{Face: face, X: x, Y: y}
+					path := FindPath(topology, start, end)
+					require.Equal(t, bfsSteps(topology, start, end), len(path)-1, "%v -> %v", start, end)
+				}
+			}
+		}
+	}
+}
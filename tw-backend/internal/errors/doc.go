@@ -4,6 +4,7 @@
 //
 //   - AppError: Application-level error with HTTP context, error code, and message
 //   - ErrorResponse: JSON structure for API error responses
+//   - Translator: Looks up localized messages by error code
 //
 // # Usage
 //
@@ -19,6 +20,21 @@
 //	    return errors.Wrap(errors.ErrInternalServer, "failed to query users", err)
 //	}
 //
+// Wrapping several causes at once (errors.Is matches any of them):
+//
+//	return errors.WrapMany(errors.ErrInternalServer, "batch insert failed", rowErrs...)
+//
+// Matching errors: AppErrors compare by Code, so dynamically built errors
+// match their sentinel anywhere in the chain:
+//
+//	if errors.Is(err, errors.ErrNotFound) { ... }    // true for errors.NewNotFound(...)
+//	if appErr, ok := errors.AsAppError(err); ok { status = appErr.HTTPStatus }
+//
+// Naming the fields that failed validation (sent to clients as "fields"):
+//
+//	return errors.NewValidationError(map[string]string{"name": "name is required"})
+//	return errors.ErrInvalidInput.WithField("world_id", "unknown world") // copy; sentinel unchanged
+//
 // Creating custom errors:
 //
 //	return errors.New("CUSTOM_ERROR", "Something went wrong", http.StatusBadRequest)
@@ -32,6 +48,15 @@
 //	    }
 //	}
 //
+// The causes of an AppError are listed in the response's "details" array.
+//
+// # Localization
+//
+// RespondWithLocalizedError translates messages by code into the locale set
+// with WithLocale, else the Accept-Language header, using the translator set
+// with SetTranslator (e.g. a MemoryTranslator). The code is never translated,
+// and codes without a translation keep their default message.
+//
 // # Error Categories
 //
 // Domain-specific errors are defined in domain.go:
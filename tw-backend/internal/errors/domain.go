@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
 )
 
@@ -57,6 +58,7 @@ var (
 	ErrTargetNotFound      = &AppError{Code: "TARGET_NOT_FOUND", Message: "Target not found", HTTPStatus: http.StatusNotFound}
 	ErrTargetOutOfRange    = &AppError{Code: "TARGET_OUT_OF_RANGE", Message: "Target is out of range", HTTPStatus: http.StatusBadRequest}
 	ErrCooldown            = &AppError{Code: "ACTION_COOLDOWN", Message: "Action is on cooldown", HTTPStatus: http.StatusTooManyRequests}
+	ErrLocked              = &AppError{Code: "LOCKED", Message: "It's locked", HTTPStatus: http.StatusForbidden}
 )
 
 // Inventory errors
@@ -100,6 +102,14 @@ func NewInvalidInput(format string, args ...any) error {
 	}
 }
 
+// NewValidationError returns a 422 error naming each invalid field and what
+// is wrong with it
+func NewValidationError(fields map[string]string) error {
+	appErr := *ErrValidation
+	appErr.Fields = maps.Clone(fields)
+	return &appErr
+}
+
 // NewInternalError returns an AppError for internal errors
 func NewInternalError(format string, args ...any) error {
 	return &AppError{
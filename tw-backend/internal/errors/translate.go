@@ -0,0 +1,148 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Translator looks up a localized message for an error code
+type Translator interface {
+	// Translate returns the message for code in locale (e.g. "fr", "pt-BR"),
+	// or false if there is none
+	Translate(code, locale string) (string, bool)
+}
+
+var (
+	translatorMu sync.RWMutex
+	translator   Translator
+)
+
+// SetTranslator sets the translator used by RespondWithLocalizedError.
+// Nil disables translation.
+func SetTranslator(t Translator) {
+	translatorMu.Lock()
+	defer translatorMu.Unlock()
+	translator = t
+}
+
+func currentTranslator() Translator {
+	translatorMu.RLock()
+	defer translatorMu.RUnlock()
+	return translator
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context carrying the caller's preferred locale, which
+// takes precedence over the Accept-Language header
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale set by WithLocale
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeKey{}).(string)
+	return locale, ok && locale != ""
+}
+
+// RequestLocales lists the request's preferred locales, most preferred first:
+// the context locale, then Accept-Language tags by descending quality
+func RequestLocales(r *http.Request) []string {
+	var locales []string
+	if locale, ok := LocaleFromContext(r.Context()); ok {
+		locales = append(locales, locale)
+	}
+	return append(locales, parseAcceptLanguage(r.Header.Get("Accept-Language"))...)
+}
+
+// parseAcceptLanguage returns the tags of an Accept-Language header ordered by
+// quality, dropping wildcards and tags with q=0
+func parseAcceptLanguage(header string) []string {
+	type tag struct {
+		locale  string
+		quality float64
+	}
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		tags = append(tags, tag{locale: locale, quality: quality})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	locales := make([]string, len(tags))
+	for i, t := range tags {
+		locales[i] = t.locale
+	}
+	return locales
+}
+
+// translate returns the message for code in the first locale that has one
+func translate(code string, locales []string) (message, locale string, ok bool) {
+	t := currentTranslator()
+	if t == nil || code == "" {
+		return "", "", false
+	}
+	for _, locale := range locales {
+		if message, ok := t.Translate(code, locale); ok {
+			return message, locale, true
+		}
+	}
+	return "", "", false
+}
+
+// MemoryTranslator is an in-memory Translator. A regional locale falls back
+// to its base language, so "pt-BR" uses "pt" messages when it has none.
+type MemoryTranslator struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string // locale -> code -> message
+}
+
+// NewMemoryTranslator creates an empty translator
+func NewMemoryTranslator() *MemoryTranslator {
+	return &MemoryTranslator{messages: make(map[string]map[string]string)}
+}
+
+// Add registers the message for code in locale
+func (t *MemoryTranslator) Add(locale, code, message string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	locale = strings.ToLower(locale)
+	if t.messages[locale] == nil {
+		t.messages[locale] = make(map[string]string)
+	}
+	t.messages[locale][code] = message
+}
+
+// Translate implements Translator
+func (t *MemoryTranslator) Translate(code, locale string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	locale = strings.ToLower(locale)
+	if message, ok := t.messages[locale][code]; ok {
+		return message, true
+	}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		message, ok := t.messages[base][code]
+		return message, ok
+	}
+	return "", false
+}
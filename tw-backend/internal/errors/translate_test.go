@@ -0,0 +1,152 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func frenchTranslator() *MemoryTranslator {
+	t := NewMemoryTranslator()
+	t.Add("fr", ErrUserNotFound.Code, "Utilisateur introuvable")
+	t.Add("fr", ErrDatabaseTimeout.Code, "Délai de la base de données dépassé")
+	t.Add("pt-BR", ErrUserNotFound.Code, "Usuário não encontrado")
+	return t
+}
+
+// localizedResponse responds to a request with the given Accept-Language
+func localizedResponse(t *testing.T, acceptLanguage string, err error) (*httptest.ResponseRecorder, ErrorResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	recorder := httptest.NewRecorder()
+	RespondWithLocalizedError(recorder, req, err)
+
+	var response ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return recorder, response
+}
+
+func TestMemoryTranslator_Translate(t *testing.T) {
+	tr := frenchTranslator()
+
+	tests := []struct {
+		code, locale, want string
+		ok                 bool
+	}{
+		{ErrUserNotFound.Code, "fr", "Utilisateur introuvable", true},
+		{ErrUserNotFound.Code, "FR-ca", "Utilisateur introuvable", true},
+		{ErrUserNotFound.Code, "pt-BR", "Usuário não encontrado", true},
+		{ErrUserNotFound.Code, "pt", "", false},
+		{ErrUserNotFound.Code, "de", "", false},
+		{ErrWorldFull.Code, "fr", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := tr.Translate(tt.code, tt.locale)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Translate(%s, %s) = %q, %v, want %q, %v", tt.code, tt.locale, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := parseAcceptLanguage("en;q=0.5, fr-CA , de;q=0, *;q=0.1, fr;q=0.9")
+	want := []string{"fr-CA", "fr", "en"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseAcceptLanguage() = %v, want %v", got, want)
+	}
+	if got := parseAcceptLanguage(""); len(got) != 0 {
+		t.Errorf("parseAcceptLanguage(\"\") = %v, want none", got)
+	}
+}
+
+func TestRespondWithLocalizedError_TranslatesMessageKeepsCode(t *testing.T) {
+	SetTranslator(frenchTranslator())
+	defer SetTranslator(nil)
+
+	err := WrapMany(ErrUserNotFound, "no user with id 7", Wrap(ErrDatabaseTimeout, "lookup timed out", nil))
+	recorder, response := localizedResponse(t, "de, fr-CA;q=0.8", err)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("status = %v, want %v", recorder.Code, http.StatusNotFound)
+	}
+	if response.Error.Code != "USER_NOT_FOUND" {
+		t.Errorf("code = %v, want USER_NOT_FOUND", response.Error.Code)
+	}
+	if response.Error.Message != "Utilisateur introuvable" {
+		t.Errorf("message = %q, want the French translation", response.Error.Message)
+	}
+	if got := recorder.Header().Get("Content-Language"); got != "fr-CA" {
+		t.Errorf("Content-Language = %q, want fr-CA", got)
+	}
+	if len(response.Error.Details) != 1 || response.Error.Details[0].Message != "Délai de la base de données dépassé" {
+		t.Errorf("details = %+v, want the translated cause", response.Error.Details)
+	}
+}
+
+func TestRespondWithLocalizedError_FallsBackToDefaultMessage(t *testing.T) {
+	SetTranslator(frenchTranslator())
+	defer SetTranslator(nil)
+
+	// Unknown locale
+	recorder, response := localizedResponse(t, "ja", ErrUserNotFound)
+	if response.Error.Message != ErrUserNotFound.Message {
+		t.Errorf("message = %q, want default %q", response.Error.Message, ErrUserNotFound.Message)
+	}
+	if got := recorder.Header().Get("Content-Language"); got != "" {
+		t.Errorf("Content-Language = %q, want unset", got)
+	}
+
+	// Known locale without this code
+	_, response = localizedResponse(t, "fr", ErrWorldFull)
+	if response.Error.Message != ErrWorldFull.Message || response.Error.Code != ErrWorldFull.Code {
+		t.Errorf("response = %+v, want default message and code", response.Error)
+	}
+
+	// No header at all
+	_, response = localizedResponse(t, "", NewNotFound("world %d not found", 9))
+	if response.Error.Message != "world 9 not found" {
+		t.Errorf("message = %q, want the original message", response.Error.Message)
+	}
+}
+
+func TestRespondWithLocalizedError_ContextLocaleWins(t *testing.T) {
+	SetTranslator(frenchTranslator())
+	defer SetTranslator(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr")
+	req = req.WithContext(WithLocale(req.Context(), "pt-BR"))
+	recorder := httptest.NewRecorder()
+	RespondWithLocalizedError(recorder, req, ErrUserNotFound)
+
+	var response ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Error.Message != "Usuário não encontrado" {
+		t.Errorf("message = %q, want the context locale's translation", response.Error.Message)
+	}
+}
+
+func TestRespondWithError_IgnoresTranslator(t *testing.T) {
+	SetTranslator(frenchTranslator())
+	defer SetTranslator(nil)
+
+	recorder := httptest.NewRecorder()
+	RespondWithError(recorder, ErrUserNotFound)
+
+	var response ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Error.Message != ErrUserNotFound.Message {
+		t.Errorf("message = %q, want default %q", response.Error.Message, ErrUserNotFound.Message)
+	}
+}
@@ -4,27 +4,89 @@ import (
 	"encoding/json"
 	stdErrors "errors"
 	"fmt"
+	"log"
+	"maps"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // AppError represents an application-level error with HTTP context
 type AppError struct {
-	Code       string `json:"code"`    // Machine-readable code (e.g., "AUTH_INVALID_CREDENTIALS")
-	Message    string `json:"message"` // Human-readable message
-	HTTPStatus int    `json:"-"`       // HTTP status code (not serialized)
-	Err        error  `json:"-"`       // Underlying error (not serialized)
+	Code       string  `json:"code"`    // Machine-readable code (e.g., "AUTH_INVALID_CREDENTIALS")
+	Message    string  `json:"message"` // Human-readable message
+	HTTPStatus int     `json:"-"`       // HTTP status code (not serialized)
+	Err        error   `json:"-"`       // Underlying error (not serialized)
+	Errs       []error `json:"-"`       // Further underlying errors, e.g. each failed row of a batch (not serialized)
+
+	// Fields gives machine-readable context, e.g. which request field failed
+	// validation. Sent to clients under "fields".
+	Fields map[string]string `json:"-"`
+
+	// RetryAfter tells clients when to try again, e.g. after rate limiting.
+	// Sent as the Retry-After header (whole seconds, rounded up).
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *AppError) Error() string {
+	causes := e.Unwrap()
+	if len(causes) == 0 {
+		return e.Message
+	}
+	msgs := make([]string, len(causes))
+	for i, cause := range causes {
+		msgs[i] = cause.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Message, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the underlying errors so errors.Is and errors.As match any cause
+func (e *AppError) Unwrap() []error {
+	var causes []error
 	if e.Err != nil {
-		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+		causes = append(causes, e.Err)
 	}
-	return e.Message
+	for _, err := range e.Errs {
+		if err != nil {
+			causes = append(causes, err)
+		}
+	}
+	return causes
 }
 
-// Unwrap returns the underlying error for error chain support
-func (e *AppError) Unwrap() error {
-	return e.Err
+// Is reports whether target is an AppError with the same Code, so errors built
+// with New, Wrap or NewNotFound match their sentinel through errors.Is
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code != "" && e.Code == t.Code
+}
+
+// WithField returns a copy of the error with a field added, leaving the
+// receiver (typically a package-level sentinel) untouched
+func (e *AppError) WithField(key, value string) *AppError {
+	clone := *e
+	clone.Fields = make(map[string]string, len(e.Fields)+1)
+	maps.Copy(clone.Fields, e.Fields)
+	clone.Fields[key] = value
+	return &clone
+}
+
+// WithRetryAfter returns a copy of the error telling clients to retry after
+// d, leaving the receiver untouched
+func (e *AppError) WithRetryAfter(d time.Duration) *AppError {
+	clone := *e
+	clone.RetryAfter = d
+	return &clone
+}
+
+// AsAppError finds the first AppError in err's chain, e.g. to read its HTTPStatus
+func AsAppError(err error) (*AppError, bool) {
+	var appErr *AppError
+	if stdErrors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
 }
 
 // Common error templates
@@ -35,6 +97,7 @@ var (
 	ErrNotFound       = &AppError{Code: "NOT_FOUND", Message: "Not found", HTTPStatus: http.StatusNotFound}
 	ErrConflict       = &AppError{Code: "CONFLICT", Message: "Conflict", HTTPStatus: http.StatusConflict}
 	ErrInternalServer = &AppError{Code: "INTERNAL_ERROR", Message: "Internal server error", HTTPStatus: http.StatusInternalServerError}
+	ErrValidation     = &AppError{Code: "VALIDATION_FAILED", Message: "Validation failed", HTTPStatus: http.StatusUnprocessableEntity}
 )
 
 // Wrap creates a new error wrapping the original with a custom message
@@ -47,6 +110,22 @@ func Wrap(base *AppError, message string, err error) *AppError {
 	}
 }
 
+// WrapMany creates a new error wrapping several causes with a custom message.
+// Nil causes are dropped.
+func WrapMany(base *AppError, message string, causes ...error) error {
+	appErr := &AppError{
+		Code:       base.Code,
+		Message:    message,
+		HTTPStatus: base.HTTPStatus,
+	}
+	for _, err := range causes {
+		if err != nil {
+			appErr.Errs = append(appErr.Errs, err)
+		}
+	}
+	return appErr
+}
+
 // New creates a new AppError with custom values
 func New(code string, message string, httpStatus int) *AppError {
 	return &AppError{
@@ -59,15 +138,43 @@ func New(code string, message string, httpStatus int) *AppError {
 // ErrorResponse represents the JSON error response structure
 type ErrorResponse struct {
 	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
+		Code    string            `json:"code"`
+		Message string            `json:"message"`
+		Details []ErrorDetail     `json:"details,omitempty"`
+		Fields  map[string]string `json:"fields,omitempty"`
 	} `json:"error"`
 }
 
-// RespondWithError writes an error response to the HTTP writer
+// ErrorDetail describes one underlying cause of an error response
+type ErrorDetail struct {
+	Code    string `json:"code,omitempty"` // Set when the cause is an AppError
+	Message string `json:"message"`
+}
+
+// RespondWithError writes an error response to the HTTP writer.
+// The AppError causes of an AppError are flattened into the response's
+// details; other causes, e.g. driver errors, are only logged.
 func RespondWithError(w http.ResponseWriter, err error) {
-	var appErr *AppError
-	if !stdErrors.As(err, &appErr) {
+	respondWithError(w, err, nil)
+}
+
+// RespondWithLocalizedError is RespondWithError with messages translated into
+// the request's locale (see RequestLocales and SetTranslator). Codes without a
+// translation keep their default message; the code itself is never translated.
+func RespondWithLocalizedError(w http.ResponseWriter, r *http.Request, err error) {
+	respondWithError(w, err, RequestLocales(r))
+}
+
+func respondWithError(w http.ResponseWriter, err error, locales []string) {
+	var details []ErrorDetail
+	appErr, ok := AsAppError(err)
+	if ok {
+		var hidden []error
+		details, hidden = flattenCauses(appErr.Unwrap())
+		for _, cause := range hidden {
+			log.Printf("[ERROR] %s: %v", appErr.Code, cause)
+		}
+	} else {
 		// If not an AppError, treat as internal server error
 		appErr = &AppError{
 			Code:       "UNKNOWN_ERROR",
@@ -80,8 +187,48 @@ func RespondWithError(w http.ResponseWriter, err error) {
 	response := ErrorResponse{}
 	response.Error.Code = appErr.Code
 	response.Error.Message = appErr.Message
+	response.Error.Details = details
+	response.Error.Fields = appErr.Fields
+
+	if message, locale, ok := translate(appErr.Code, locales); ok {
+		response.Error.Message = message
+		w.Header().Set("Content-Language", locale)
+	}
+	for i, detail := range details {
+		if message, _, ok := translate(detail.Code, locales); ok {
+			details[i].Message = message
+		}
+	}
+
+	if appErr.RetryAfter > 0 {
+		seconds := (appErr.RetryAfter + time.Second - 1) / time.Second
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(seconds), 10))
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(appErr.HTTPStatus)
 	_ = json.NewEncoder(w).Encode(response) // Error intentionally ignored - response already committed
 }
+
+// flattenCauses lists an error chain's AppErrors depth-first, each by its
+// code and message followed by its own causes; joined errors contribute their
+// parts. Any other error is returned in hidden instead, since its text may
+// expose internals such as SQL or connection strings.
+func flattenCauses(causes []error) (details []ErrorDetail, hidden []error) {
+	for _, cause := range causes {
+		var more []ErrorDetail
+		var moreHidden []error
+		switch c := cause.(type) {
+		case *AppError:
+			details = append(details, ErrorDetail{Code: c.Code, Message: c.Message})
+			more, moreHidden = flattenCauses(c.Unwrap())
+		case interface{ Unwrap() []error }:
+			more, moreHidden = flattenCauses(c.Unwrap())
+		default:
+			moreHidden = []error{cause}
+		}
+		details = append(details, more...)
+		hidden = append(hidden, moreHidden...)
+	}
+	return details, hidden
+}
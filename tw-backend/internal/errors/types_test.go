@@ -3,9 +3,12 @@ package errors
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAppError_Error(t *testing.T) {
@@ -50,8 +53,8 @@ func TestAppError_Unwrap(t *testing.T) {
 		Err:     underlying,
 	}
 
-	if got := appErr.Unwrap(); got != underlying {
-		t.Errorf("AppError.Unwrap() = %v, want %v", got, underlying)
+	if got := appErr.Unwrap(); len(got) != 1 || got[0] != underlying {
+		t.Errorf("AppError.Unwrap() = %v, want [%v]", got, underlying)
 	}
 
 	// Test with nil underlying error
@@ -82,6 +85,85 @@ func TestWrap(t *testing.T) {
 	}
 }
 
+func TestWrapMany(t *testing.T) {
+	rowErr := errors.New("row 2: duplicate key")
+	timeout := Wrap(ErrDatabaseTimeout, "row 3 timed out", nil)
+	err := WrapMany(ErrInternalServer, "batch insert failed", rowErr, nil, timeout)
+
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("WrapMany() = %T, want *AppError", err)
+	}
+	if appErr.Code != ErrInternalServer.Code || appErr.HTTPStatus != ErrInternalServer.HTTPStatus {
+		t.Errorf("WrapMany() = %s/%d, want %s/%d", appErr.Code, appErr.HTTPStatus, ErrInternalServer.Code, ErrInternalServer.HTTPStatus)
+	}
+	if got := appErr.Unwrap(); len(got) != 2 {
+		t.Errorf("WrapMany() causes = %v, want 2 with nil dropped", got)
+	}
+	if want := "batch insert failed: row 2: duplicate key; row 3 timed out"; err.Error() != want {
+		t.Errorf("WrapMany() Error() = %q, want %q", err.Error(), want)
+	}
+
+	if !errors.Is(err, rowErr) {
+		t.Error("errors.Is should match the first cause")
+	}
+	dbErr := WrapMany(ErrInternalServer, "batch insert failed", rowErr, ErrDatabaseTimeout)
+	if !errors.Is(dbErr, ErrDatabaseTimeout) {
+		t.Error("errors.Is should match any cause")
+	}
+	if errors.Is(dbErr, ErrDatabaseConnection) {
+		t.Error("errors.Is should not match an error that isn't a cause")
+	}
+	// Single-cause Wrap still matches through the chain
+	if !errors.Is(Wrap(ErrInternalServer, "query failed", ErrDatabaseTimeout), ErrDatabaseTimeout) {
+		t.Error("errors.Is should match a Wrap cause")
+	}
+}
+
+func TestAppError_IsMatchesCode(t *testing.T) {
+	notFound := NewNotFound("character %s not found", "abc")
+	if !errors.Is(notFound, ErrNotFound) {
+		t.Error("NewNotFound() should match ErrNotFound")
+	}
+	if errors.Is(notFound, ErrUserNotFound) {
+		t.Error("NewNotFound() should not match a sentinel with another code")
+	}
+	if errors.Is(notFound, errors.New("Not found")) {
+		t.Error("AppError should not match a plain error with the same text")
+	}
+
+	// Sentinel two levels deep: fmt wrapper -> Wrap -> dynamic AppError
+	inner := New(ErrUserNotFound.Code, "no user with id 7", http.StatusNotFound)
+	chain := fmt.Errorf("loading profile: %w", Wrap(ErrInternalServer, "lookup failed", inner))
+	if !errors.Is(chain, ErrUserNotFound) {
+		t.Error("errors.Is should find the sentinel deep in the chain")
+	}
+	if !errors.Is(chain, ErrInternalServer) {
+		t.Error("errors.Is should match the outer AppError too")
+	}
+}
+
+func TestAsAppError(t *testing.T) {
+	chain := fmt.Errorf("handler: %w", fmt.Errorf("service: %w", NewInvalidInput("bad name %q", "")))
+
+	appErr, ok := AsAppError(chain)
+	if !ok {
+		t.Fatal("AsAppError() should find the AppError")
+	}
+	if appErr.HTTPStatus != http.StatusBadRequest {
+		t.Errorf("AsAppError() HTTPStatus = %v, want %v", appErr.HTTPStatus, http.StatusBadRequest)
+	}
+
+	var viaStd *AppError
+	if !errors.As(chain, &viaStd) || viaStd != appErr {
+		t.Error("errors.As should extract the same *AppError")
+	}
+
+	if _, ok := AsAppError(errors.New("plain")); ok {
+		t.Error("AsAppError() should not find an AppError in a plain error")
+	}
+}
+
 func TestNew(t *testing.T) {
 	appErr := New("CUSTOM_CODE", "Custom message", http.StatusTeapot)
 
@@ -132,6 +214,57 @@ func TestRespondWithError_AppError(t *testing.T) {
 	}
 }
 
+func TestRespondWithError_FlattensCauses(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	nested := Wrap(ErrDatabaseTimeout, "row 3 timed out", errors.New("context deadline exceeded"))
+	joined := errors.Join(errors.New("row 4: bad email"), errors.New("row 5: bad email"))
+	err := WrapMany(ErrInvalidInput, "batch insert failed", errors.New("row 2: duplicate key"), nested, joined)
+	RespondWithError(recorder, err)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("RespondWithError() status = %v, want %v", recorder.Code, http.StatusBadRequest)
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	// Only AppErrors are shown; the plain errors' text is logged instead
+	want := []ErrorDetail{
+		{Code: "DATABASE_TIMEOUT", Message: "row 3 timed out"},
+	}
+	if len(response.Error.Details) != len(want) {
+		t.Fatalf("RespondWithError() details = %+v, want %+v", response.Error.Details, want)
+	}
+	for i := range want {
+		if response.Error.Details[i] != want[i] {
+			t.Errorf("RespondWithError() detail %d = %+v, want %+v", i, response.Error.Details[i], want[i])
+		}
+	}
+}
+
+func TestRespondWithError_HidesDriverErrors(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	dbErr := errors.New(`pq: password authentication failed for user "game"`)
+	RespondWithError(recorder, Wrap(ErrInternalServer, "Failed to check existing characters", dbErr))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("RespondWithError() status = %v, want %v", recorder.Code, http.StatusInternalServerError)
+	}
+	if strings.Contains(recorder.Body.String(), "pq:") {
+		t.Errorf("RespondWithError() body = %s, exposes the driver error", recorder.Body.String())
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Error.Details) != 0 {
+		t.Errorf("RespondWithError() details = %+v, want none", response.Error.Details)
+	}
+}
+
 func TestRespondWithError_NonAppError(t *testing.T) {
 	recorder := httptest.NewRecorder()
 
@@ -151,6 +284,9 @@ func TestRespondWithError_NonAppError(t *testing.T) {
 	if response.Error.Code != "UNKNOWN_ERROR" {
 		t.Errorf("RespondWithError() response code = %v, want %v", response.Error.Code, "UNKNOWN_ERROR")
 	}
+	if len(response.Error.Details) != 0 {
+		t.Errorf("RespondWithError() details = %+v, want none for unexpected errors", response.Error.Details)
+	}
 }
 
 func TestPredefinedErrors(t *testing.T) {
@@ -165,6 +301,7 @@ func TestPredefinedErrors(t *testing.T) {
 		{ErrNotFound, "NOT_FOUND", http.StatusNotFound},
 		{ErrConflict, "CONFLICT", http.StatusConflict},
 		{ErrInternalServer, "INTERNAL_ERROR", http.StatusInternalServerError},
+		{ErrValidation, "VALIDATION_FAILED", http.StatusUnprocessableEntity},
 	}
 
 	for _, tt := range tests {
@@ -178,3 +315,87 @@ func TestPredefinedErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestRespondWithError_RetryAfter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	RespondWithError(recorder, ErrAuthRateLimited.WithRetryAfter(2500*time.Millisecond))
+
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusTooManyRequests)
+	}
+	if got := recorder.Header().Get("Retry-After"); got != "3" {
+		t.Errorf("Retry-After = %q, want 3 (rounded up)", got)
+	}
+	if ErrAuthRateLimited.RetryAfter != 0 {
+		t.Error("WithRetryAfter() should leave the sentinel untouched")
+	}
+
+	recorder = httptest.NewRecorder()
+	RespondWithError(recorder, ErrAuthRateLimited)
+	if got := recorder.Header().Get("Retry-After"); got != "" {
+		t.Errorf("Retry-After = %q, want none without a delay", got)
+	}
+}
+
+func TestWithField_LeavesSentinelUntouched(t *testing.T) {
+	first := ErrInvalidInput.WithField("name", "is required")
+	second := first.WithField("world_id", "is required")
+
+	if ErrInvalidInput.Fields != nil {
+		t.Errorf("sentinel Fields = %v, want nil", ErrInvalidInput.Fields)
+	}
+	if len(first.Fields) != 1 || first.Fields["name"] != "is required" {
+		t.Errorf("first.Fields = %v, want only name", first.Fields)
+	}
+	if len(second.Fields) != 2 || second.Fields["world_id"] != "is required" {
+		t.Errorf("second.Fields = %v, want name and world_id", second.Fields)
+	}
+	if first == ErrInvalidInput || second == first {
+		t.Error("WithField() should return a new error")
+	}
+	if !errors.Is(second, ErrInvalidInput) {
+		t.Error("WithField() copy should still match its sentinel")
+	}
+
+	// A validation error built from a map doesn't alias it or the sentinel
+	fields := map[string]string{"name": "too long"}
+	err := NewValidationError(fields)
+	fields["name"] = "changed"
+	appErr, _ := AsAppError(err)
+	appErr.WithField("role", "unknown")
+	if appErr.Fields["name"] != "too long" || len(appErr.Fields) != 1 {
+		t.Errorf("NewValidationError() Fields = %v, want a private copy", appErr.Fields)
+	}
+	if ErrValidation.Fields != nil {
+		t.Errorf("ErrValidation.Fields = %v, want nil", ErrValidation.Fields)
+	}
+}
+
+func TestRespondWithError_ValidationFields(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	RespondWithError(recorder, NewValidationError(map[string]string{
+		"name":     "name must not exceed 50 characters",
+		"world_id": "world_id is required",
+	}))
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("RespondWithError() status = %v, want %v", recorder.Code, http.StatusUnprocessableEntity)
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Error.Code != "VALIDATION_FAILED" {
+		t.Errorf("response code = %v, want VALIDATION_FAILED", response.Error.Code)
+	}
+	if response.Error.Fields["world_id"] != "world_id is required" || len(response.Error.Fields) != 2 {
+		t.Errorf("response fields = %v, want name and world_id", response.Error.Fields)
+	}
+
+	// Errors without fields omit the object entirely
+	recorder = httptest.NewRecorder()
+	RespondWithError(recorder, ErrNotFound)
+	if strings.Contains(recorder.Body.String(), "fields") {
+		t.Errorf("response body = %s, want no fields", recorder.Body.String())
+	}
+}
@@ -28,12 +28,29 @@
 //	}
 //	store.AppendEvent(ctx, event)
 //
+//	// Append only if no one else has written since we loaded 3 events
+//	err := store.AppendEventExpectingVersion(ctx, event, 3) // ErrConcurrencyConflict otherwise
+//
 //	// Retrieve events for an aggregate
 //	events, _ := store.GetEventsByAggregate(ctx, playerID, 0)
 //
 // # Features
 //
-//   - Projections: Build read-optimized views from event streams
-//   - Replay: Reconstruct state by replaying events
+//   - Projections: Build read-optimized views from event streams, live via
+//     Subscribe (LISTEN/NOTIFY) instead of polling
+//   - Replay: Reconstruct state by replaying events, or up to a moment with
+//     ReplayUntil
+//   - Snapshots: LoadAggregate restores the latest snapshot and replays only
+//     the events after it
 //   - Versioning: Handle event schema evolution with upcasters
+//
+// # Schema Upcasting
+//
+// Events carry a SchemaVersion for their payload. Register an upcaster per
+// (event type, version) and PostgresEventStore migrates older payloads on
+// every read, chaining until no upcaster matches. Stored rows are unchanged.
+//
+//	store.RegisterUpcaster("PlayerMoved", 1, func(p json.RawMessage) (json.RawMessage, int, error) {
+//	    return addZ(p), 2, nil // v1 -> v2
+//	})
 package eventstore
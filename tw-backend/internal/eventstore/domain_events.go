@@ -0,0 +1,84 @@
+package eventstore
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain event types published for other services (e.g. quests) to react to
+const (
+	EventTypeCombatResolved   EventType = "combat_resolved"
+	EventTypeSpeciesExtinct   EventType = "species_extinct"
+	EventTypeSapienceAchieved EventType = "sapience_achieved"
+	EventTypeSkillLevelUp     EventType = "skill_level_up"
+)
+
+// Aggregates the domain events are appended to
+const (
+	// AggregateCombatant holds the attacks an entity has made, keyed by
+	// CombatantAggregateID
+	AggregateCombatant AggregateType = "Combatant"
+	// AggregateSpecies holds a simulated species' milestones, keyed by species ID
+	AggregateSpecies AggregateType = "Species"
+	// AggregateSkillSheet holds a character's skill milestones, keyed by
+	// SkillSheetAggregateID
+	AggregateSkillSheet AggregateType = "SkillSheet"
+)
+
+// CombatantAggregateID is the aggregate ID of an entity's attacks. It's
+// prefixed so it can't collide with the entity's own aggregate, such as a
+// Character keyed by the same ID.
+func CombatantAggregateID(entityID uuid.UUID) string {
+	return "combatant:" + entityID.String()
+}
+
+// SkillSheetAggregateID is the aggregate ID of a character's skill sheet,
+// prefixed apart from the Character aggregate
+func SkillSheetAggregateID(charID uuid.UUID) string {
+	return "skills:" + charID.String()
+}
+
+// CombatResolvedPayload is the payload of a combat_resolved event
+type CombatResolvedPayload struct {
+	AttackerID     uuid.UUID `json:"attacker_id"`
+	TargetID       uuid.UUID `json:"target_id"`
+	Outcome        string    `json:"outcome"`
+	Critical       bool      `json:"critical"`
+	Fumble         bool      `json:"fumble"`
+	Damage         int       `json:"damage"`
+	Effects        []string  `json:"effects,omitempty"`
+	TargetHP       int       `json:"target_hp"`
+	TargetDefeated bool      `json:"target_defeated"`
+	ResolvedAt     time.Time `json:"resolved_at"`
+}
+
+// SpeciesExtinctPayload is the payload of a species_extinct event
+type SpeciesExtinctPayload struct {
+	WorldID        uuid.UUID `json:"world_id"`
+	SpeciesID      uuid.UUID `json:"species_id"`
+	Name           string    `json:"name"`
+	Diet           string    `json:"diet"`
+	PeakPopulation int64     `json:"peak_population"`
+	ExistedFrom    int64     `json:"existed_from"`
+	ExistedUntil   int64     `json:"existed_until"`
+	Cause          string    `json:"cause"`
+}
+
+// SapienceAchievedPayload is the payload of a sapience_achieved event
+type SapienceAchievedPayload struct {
+	WorldID       uuid.UUID `json:"world_id"`
+	SpeciesID     uuid.UUID `json:"species_id"`
+	Name          string    `json:"name"`
+	Year          int64     `json:"year"`
+	Score         float64   `json:"score"`
+	MagicAssisted bool      `json:"magic_assisted"`
+}
+
+// SkillLevelUpPayload is the payload of a skill_level_up event
+type SkillLevelUpPayload struct {
+	CharacterID uuid.UUID `json:"character_id"`
+	Skill       string    `json:"skill"`
+	Level       int       `json:"level"`
+	LeveledAt   time.Time `json:"leveled_at"`
+}
@@ -38,3 +38,26 @@ func (pm *ProjectionManager) ProjectEvent(ctx context.Context, event Event) erro
 	}
 	return nil
 }
+
+// Follow subscribes to store from fromPosition and projects each event as it
+// arrives, instead of polling. It returns the position of the last event
+// projected, to resume from, when ctx is cancelled, the subscription ends or
+// a projection fails.
+func (pm *ProjectionManager) Follow(ctx context.Context, store EventStore, fromPosition int64) (int64, error) {
+	// Ends the subscription if a projection fails
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := store.Subscribe(subCtx, fromPosition)
+	if err != nil {
+		return fromPosition, err
+	}
+	position := fromPosition
+	for event := range events {
+		if err := pm.ProjectEvent(ctx, event); err != nil {
+			return position, err
+		}
+		position = event.GlobalPosition
+	}
+	return position, ctx.Err()
+}
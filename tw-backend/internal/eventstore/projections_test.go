@@ -73,3 +73,46 @@ func TestProjectionManager_ProjectEvent(t *testing.T) {
 		assert.Equal(t, int64(1), model.TickCount)
 	})
 }
+
+// streamingStore serves a fixed stream to subscribers
+type streamingStore struct {
+	memEventStore
+	stream       chan Event
+	fromPosition int64
+}
+
+func (s *streamingStore) Subscribe(_ context.Context, fromPosition int64) (<-chan Event, error) {
+	s.fromPosition = fromPosition
+	return s.stream, nil
+}
+
+func TestProjectionManager_Follow(t *testing.T) {
+	model := &TestReadModel{}
+	pm := NewProjectionManager()
+	pm.RegisterProjection(&TestProjection{model: model})
+
+	t.Run("projects streamed events and reports the last position", func(t *testing.T) {
+		store := &streamingStore{stream: make(chan Event, 3)}
+		store.stream <- Event{ID: "evt-1", EventType: "WorldCreated", Payload: json.RawMessage(`{"name": "Streamed"}`), GlobalPosition: 11}
+		store.stream <- Event{ID: "evt-2", EventType: "WorldTicked", Payload: json.RawMessage(`{}`), GlobalPosition: 12}
+		close(store.stream)
+
+		position, err := pm.Follow(context.Background(), store, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), store.fromPosition)
+		assert.Equal(t, int64(12), position)
+		assert.Equal(t, "Streamed", model.WorldName)
+		assert.Equal(t, int64(1), model.TickCount)
+	})
+
+	t.Run("stops at the event a projection rejects", func(t *testing.T) {
+		store := &streamingStore{stream: make(chan Event, 2)}
+		store.stream <- Event{ID: "evt-3", EventType: "WorldTicked", Payload: json.RawMessage(`{}`), GlobalPosition: 13}
+		store.stream <- Event{ID: "evt-bad", EventType: "WorldCreated", Payload: json.RawMessage(`not json`), GlobalPosition: 14}
+		close(store.stream)
+
+		position, err := pm.Follow(context.Background(), store, 12)
+		assert.Error(t, err)
+		assert.Equal(t, int64(13), position, "Resume from the last projected event")
+	})
+}
@@ -0,0 +1,208 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"tw-backend/internal/nats/subjects"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPublishTimeout bounds how long a publish may wait on the store, so a
+// slow database can't hold up the events queued behind it
+const DefaultPublishTimeout = 2 * time.Second
+
+// DefaultPublishQueue is how many events may wait to be written before
+// Publish starts dropping them
+const DefaultPublishQueue = 256
+
+// MessagePublisher sends raw messages to a broker, e.g. *nats.Conn
+type MessagePublisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// Publisher appends domain events to the event store and, when a broker is
+// set, mirrors them to subjects.DomainEvent subjects.
+//
+// Publish only queues the event; a background goroutine writes it, so the
+// game loop never waits on the store or broker. Publishing is best-effort:
+// write failures are logged, each write is cut short after the timeout, and
+// nothing is retried.
+type Publisher struct {
+	store EventStore
+	queue chan publishJob
+	wg    sync.WaitGroup // Events queued but not yet written
+	done  chan struct{}
+
+	mu      sync.Mutex
+	broker  MessagePublisher
+	timeout time.Duration
+	closed  bool
+
+	versions map[string]int64 // Last version appended per aggregate; writer goroutine only
+}
+
+// publishJob is an event waiting for the writer goroutine
+type publishJob struct {
+	ctx           context.Context
+	aggregateType AggregateType
+	aggregateID   string
+	eventType     EventType
+	payload       []byte
+	queuedAt      time.Time
+}
+
+// NewPublisher creates a publisher appending to store and starts its writer
+func NewPublisher(store EventStore) *Publisher {
+	p := &Publisher{
+		store:    store,
+		queue:    make(chan publishJob, DefaultPublishQueue),
+		done:     make(chan struct{}),
+		timeout:  DefaultPublishTimeout,
+		versions: make(map[string]int64),
+	}
+	go p.run()
+	return p
+}
+
+// SetBroker mirrors published events to broker. Nil stops mirroring.
+func (p *Publisher) SetBroker(broker MessagePublisher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.broker = broker
+}
+
+// SetTimeout changes how long writing an event may wait on the store
+func (p *Publisher) SetTimeout(timeout time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeout = timeout
+}
+
+// Publish queues an event with the given payload for an aggregate. It fails
+// only when the payload can't be marshaled, the queue is full, or the
+// publisher is closed; errors writing the event are logged by the writer.
+func (p *Publisher) Publish(ctx context.Context, aggregateType AggregateType, aggregateID string, eventType EventType, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return fmt.Errorf("failed to publish %s event: publisher closed", eventType)
+	}
+
+	job := publishJob{
+		// The caller's request may end before the event is written
+		ctx:           context.WithoutCancel(ctx),
+		aggregateType: aggregateType,
+		aggregateID:   aggregateID,
+		eventType:     eventType,
+		payload:       data,
+		queuedAt:      time.Now(),
+	}
+	p.wg.Add(1)
+	select {
+	case p.queue <- job:
+		return nil
+	default:
+		p.wg.Done()
+		return fmt.Errorf("failed to publish %s event: queue full", eventType)
+	}
+}
+
+// Flush waits until every event queued so far has been written
+func (p *Publisher) Flush() {
+	p.wg.Wait()
+}
+
+// Close writes the events still queued and stops the writer. Publishing
+// after Close fails.
+func (p *Publisher) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.done
+		return
+	}
+	p.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+	<-p.done
+}
+
+// run writes queued events in order until the queue is closed
+func (p *Publisher) run() {
+	defer close(p.done)
+	for job := range p.queue {
+		if err := p.write(job); err != nil {
+			log.Printf("[EVENTS] %v", err)
+		}
+		p.wg.Done()
+	}
+}
+
+// write appends one event to the store, then mirrors it to the broker. The
+// event is still sent to the broker when the store fails; the returned
+// error reports whichever step failed.
+func (p *Publisher) write(job publishJob) error {
+	p.mu.Lock()
+	broker, timeout := p.broker, p.timeout
+	p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(job.ctx, timeout)
+	defer cancel()
+
+	event := Event{
+		ID:            uuid.New().String(),
+		EventType:     job.eventType,
+		AggregateID:   job.aggregateID,
+		AggregateType: job.aggregateType,
+		Version:       p.nextVersion(ctx, job.aggregateID),
+		Timestamp:     job.queuedAt,
+		Payload:       job.payload,
+	}
+
+	var storeErr error
+	if err := p.store.AppendEvent(ctx, event); err != nil {
+		storeErr = fmt.Errorf("failed to append %s event: %w", job.eventType, err)
+		// The store may hold more than we think, e.g. another writer got
+		// there first; read the version again next time
+		delete(p.versions, job.aggregateID)
+	} else {
+		p.versions[job.aggregateID] = event.Version
+	}
+
+	if broker != nil {
+		msg, err := json.Marshal(event)
+		if err == nil {
+			err = broker.Publish(subjects.DomainEvent(string(job.eventType)), msg)
+		}
+		if err != nil && storeErr == nil {
+			return fmt.Errorf("failed to publish %s event: %w", job.eventType, err)
+		}
+	}
+	return storeErr
+}
+
+// nextVersion returns the version for an aggregate's next event, reading the
+// latest stored version the first time an aggregate is seen (writer only)
+func (p *Publisher) nextVersion(ctx context.Context, aggregateID string) int64 {
+	if last, ok := p.versions[aggregateID]; ok {
+		return last + 1
+	}
+	var last int64
+	if events, err := p.store.GetEventsByAggregate(ctx, aggregateID, 0); err == nil {
+		for _, e := range events {
+			last = max(last, e.Version)
+		}
+	}
+	return last + 1
+}
@@ -0,0 +1,214 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memEventStore is an in-memory EventStore enforcing unique aggregate versions
+type memEventStore struct {
+	events    []Event
+	snapshots []Snapshot
+	err       error
+}
+
+func (m *memEventStore) AppendEvent(_ context.Context, event Event) error {
+	if m.err != nil {
+		return m.err
+	}
+	for _, e := range m.events {
+		if e.AggregateID == event.AggregateID && e.Version == event.Version {
+			return fmt.Errorf("duplicate version %d for %s", event.Version, event.AggregateID)
+		}
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *memEventStore) AppendEventExpectingVersion(ctx context.Context, event Event, expectedVersion int) error {
+	current, _ := m.GetEventsByAggregate(ctx, event.AggregateID, 0)
+	if len(current) != expectedVersion {
+		return &ConcurrencyConflictError{AggregateID: event.AggregateID, ExpectedVersion: expectedVersion, ActualVersion: len(current)}
+	}
+	return m.AppendEvent(ctx, event)
+}
+
+func (m *memEventStore) AppendEvents(ctx context.Context, events []Event) error {
+	for _, event := range events {
+		if err := m.AppendEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memEventStore) GetEventsByAggregate(_ context.Context, aggregateID string, fromVersion int64) ([]Event, error) {
+	var out []Event
+	for _, e := range m.events {
+		if e.AggregateID == aggregateID && e.Version >= fromVersion {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memEventStore) GetEventsByType(_ context.Context, eventType EventType, _, _ time.Time) ([]Event, error) {
+	var out []Event
+	for _, e := range m.events {
+		if e.EventType == eventType {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memEventStore) GetAllEvents(_ context.Context, _ time.Time, _ int) ([]Event, error) {
+	return m.events, nil
+}
+
+func (m *memEventStore) SaveSnapshot(_ context.Context, snapshot Snapshot) error {
+	m.snapshots = append(m.snapshots, snapshot)
+	return nil
+}
+
+func (m *memEventStore) GetLatestSnapshot(_ context.Context, aggregateID string) (*Snapshot, error) {
+	var latest *Snapshot
+	for i, s := range m.snapshots {
+		if s.AggregateID == aggregateID && (latest == nil || s.Version > latest.Version) {
+			latest = &m.snapshots[i]
+		}
+	}
+	return latest, nil
+}
+
+func (m *memEventStore) Subscribe(_ context.Context, _ int64) (<-chan Event, error) {
+	return nil, errors.New("memEventStore does not support subscriptions")
+}
+
+// recordingBroker records messages published to it
+type recordingBroker struct {
+	mu       sync.Mutex
+	subjects []string
+}
+
+func (b *recordingBroker) Publish(subject string, _ []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subjects = append(b.subjects, subject)
+	return nil
+}
+
+func TestPublisher_AppendsVersionedEvents(t *testing.T) {
+	speciesID := uuid.New().String()
+	store := &memEventStore{events: []Event{
+		{ID: uuid.New().String(), EventType: EventTypeSapienceAchieved, AggregateID: speciesID, AggregateType: AggregateSpecies, Version: 1},
+	}}
+	pub := NewPublisher(store)
+	ctx := context.Background()
+
+	payload := SpeciesExtinctPayload{SpeciesID: uuid.MustParse(speciesID), Name: "Ash Beetle", Cause: "volcanic_winter"}
+	require.NoError(t, pub.Publish(ctx, AggregateSpecies, speciesID, EventTypeSpeciesExtinct, payload))
+	pub.Flush()
+
+	events, err := store.GetEventsByType(ctx, EventTypeSpeciesExtinct, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(2), events[0].Version, "Versions continue from the stored aggregate")
+	assert.Equal(t, AggregateSpecies, events[0].AggregateType)
+
+	var got SpeciesExtinctPayload
+	require.NoError(t, json.Unmarshal(events[0].Payload, &got))
+	assert.Equal(t, payload, got)
+
+	other := uuid.New().String()
+	require.NoError(t, pub.Publish(ctx, AggregateCombatant, other, EventTypeCombatResolved, CombatResolvedPayload{}))
+	require.NoError(t, pub.Publish(ctx, AggregateCombatant, other, EventTypeCombatResolved, CombatResolvedPayload{}))
+	pub.Flush()
+	combat, err := store.GetEventsByAggregate(ctx, other, 0)
+	require.NoError(t, err)
+	require.Len(t, combat, 2)
+	assert.Equal(t, []int64{1, 2}, []int64{combat[0].Version, combat[1].Version})
+}
+
+func TestPublisher_MirrorsToBrokerWhenStoreFails(t *testing.T) {
+	store := &memEventStore{err: errors.New("database unavailable")}
+	broker := &recordingBroker{}
+	pub := NewPublisher(store)
+	pub.SetBroker(broker)
+
+	aggregateID := uuid.New().String()
+	require.NoError(t, pub.Publish(context.Background(), AggregateCombatant, aggregateID, EventTypeCombatResolved, CombatResolvedPayload{}))
+	pub.Flush()
+	assert.Empty(t, store.events)
+	assert.Equal(t, []string{"events.combat_resolved"}, broker.subjects, "Consumers still hear about the event")
+
+	// The failed version isn't consumed once the store recovers
+	store.err = nil
+	require.NoError(t, pub.Publish(context.Background(), AggregateCombatant, aggregateID, EventTypeCombatResolved, CombatResolvedPayload{}))
+	pub.Flush()
+	assert.Equal(t, int64(1), store.events[0].Version)
+}
+
+func TestPublisher_FailedAppendRereadsVersion(t *testing.T) {
+	store := &memEventStore{}
+	pub := NewPublisher(store)
+	ctx := context.Background()
+	aggregateID := uuid.New().String()
+
+	require.NoError(t, pub.Publish(ctx, AggregateCombatant, aggregateID, EventTypeCombatResolved, CombatResolvedPayload{}))
+	pub.Flush()
+
+	// Another writer takes version 2, so the cached next version clashes
+	store.events = append(store.events, Event{ID: uuid.New().String(), AggregateID: aggregateID, Version: 2})
+	require.NoError(t, pub.Publish(ctx, AggregateCombatant, aggregateID, EventTypeCombatResolved, CombatResolvedPayload{}))
+	pub.Flush()
+	require.Len(t, store.events, 2, "The clashing event is dropped")
+
+	require.NoError(t, pub.Publish(ctx, AggregateCombatant, aggregateID, EventTypeCombatResolved, CombatResolvedPayload{}))
+	pub.Flush()
+	require.Len(t, store.events, 3)
+	assert.Equal(t, int64(3), store.events[2].Version, "Versions continue from the store after a failure")
+}
+
+// blockingStore holds every append until released
+type blockingStore struct {
+	memEventStore
+	release chan struct{}
+}
+
+func (b *blockingStore) AppendEvent(ctx context.Context, event Event) error {
+	<-b.release
+	return b.memEventStore.AppendEvent(ctx, event)
+}
+
+func TestPublisher_DoesNotWaitOnStore(t *testing.T) {
+	store := &blockingStore{release: make(chan struct{})}
+	pub := NewPublisher(store)
+	ctx := context.Background()
+
+	start := time.Now()
+	var dropped error
+	queued := 0
+	for ; queued < DefaultPublishQueue+2; queued++ {
+		if dropped = pub.Publish(ctx, AggregateCombatant, uuid.New().String(), EventTypeCombatResolved, CombatResolvedPayload{}); dropped != nil {
+			break
+		}
+	}
+	assert.Less(t, time.Since(start), time.Second, "Publish returns without waiting for the store")
+	require.Error(t, dropped, "A full queue drops events instead of blocking")
+	assert.Contains(t, dropped.Error(), "queue full")
+
+	close(store.release)
+	pub.Close()
+	assert.Len(t, store.events, queued, "Close writes what was queued")
+	assert.Error(t, pub.Publish(ctx, AggregateCombatant, uuid.New().String(), EventTypeCombatResolved, CombatResolvedPayload{}))
+}
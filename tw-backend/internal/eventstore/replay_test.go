@@ -3,6 +3,8 @@ package eventstore
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -72,3 +74,76 @@ func TestPostgresReplayEngine_RewindToTimestamp(t *testing.T) {
 		assert.Equal(t, int64(2), got[1].Version)
 	})
 }
+
+// playerPosition is a test read model rebuilt from PlayerMoved events
+type playerPosition struct {
+	X, Y float64
+}
+
+func (p *playerPosition) apply(e Event) error {
+	return json.Unmarshal(e.Payload, p)
+}
+
+func TestPostgresReplayEngine_ReplayUntil(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	moved := func(version int64, at time.Time, x, y float64) Event {
+		return Event{
+			ID:            fmt.Sprintf("move-%d", version),
+			EventType:     "PlayerMoved",
+			AggregateID:   "player-1",
+			AggregateType: "Player",
+			Version:       version,
+			Timestamp:     at,
+			Payload:       json.RawMessage(fmt.Sprintf(`{"x":%g,"y":%g}`, x, y)),
+		}
+	}
+
+	// Stored out of order; versions 3 and 4 share a timestamp
+	store := &memEventStore{events: []Event{
+		moved(1, t0, 0, 0),
+		moved(4, t0.Add(2*time.Minute), 30, 40),
+		moved(2, t0.Add(time.Minute), 10, 10),
+		moved(3, t0.Add(2*time.Minute), 20, 20),
+		moved(5, t0.Add(3*time.Minute), 50, 50),
+	}}
+	replay := NewPostgresReplayEngine(store)
+
+	t.Run("replays to a mid-history timestamp", func(t *testing.T) {
+		var pos playerPosition
+		applied, err := replay.ReplayUntil(ctx, "player-1", t0.Add(150*time.Second), pos.apply)
+		require.NoError(t, err)
+		assert.Equal(t, 4, applied)
+		assert.Equal(t, playerPosition{X: 30, Y: 40}, pos, "Equal timestamps apply in version order")
+	})
+
+	t.Run("includes events exactly at until", func(t *testing.T) {
+		var versions []int64
+		applied, err := replay.ReplayUntil(ctx, "player-1", t0.Add(time.Minute), func(e Event) error {
+			versions = append(versions, e.Version)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+		assert.Equal(t, []int64{1, 2}, versions)
+	})
+
+	t.Run("until before the first event applies nothing", func(t *testing.T) {
+		var pos playerPosition
+		applied, err := replay.ReplayUntil(ctx, "player-1", t0.Add(-time.Hour), pos.apply)
+		require.NoError(t, err)
+		assert.Zero(t, applied)
+		assert.Equal(t, playerPosition{}, pos)
+	})
+
+	t.Run("stops at a failing event", func(t *testing.T) {
+		applied, err := replay.ReplayUntil(ctx, "player-1", t0.Add(time.Hour), func(e Event) error {
+			if e.Version == 3 {
+				return errors.New("corrupt")
+			}
+			return nil
+		})
+		assert.ErrorContains(t, err, "move-3")
+		assert.Equal(t, 2, applied)
+	})
+}
@@ -0,0 +1,66 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Snapshot is an aggregate's serialized state as of Version, so loading it
+// only needs the events after that version.
+type Snapshot struct {
+	AggregateID   string          `json:"aggregate_id"`
+	AggregateType AggregateType   `json:"aggregate_type"`
+	Version       int64           `json:"version"` // Version of the last event included
+	Payload       json.RawMessage `json:"payload"`
+	Timestamp     time.Time       `json:"timestamp"`
+}
+
+// SaveSnapshot stores a snapshot, replacing any existing one at the same
+// version.
+func (s *PostgresEventStore) SaveSnapshot(ctx context.Context, snapshot Snapshot) error {
+	query := `
+		INSERT INTO snapshots (aggregate_id, aggregate_type, version, payload, timestamp)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (aggregate_id, version) DO UPDATE
+		SET aggregate_type = EXCLUDED.aggregate_type, payload = EXCLUDED.payload, timestamp = EXCLUDED.timestamp
+	`
+	_, err := s.pool.Exec(ctx, query,
+		snapshot.AggregateID,
+		snapshot.AggregateType,
+		snapshot.Version,
+		snapshot.Payload,
+		snapshot.Timestamp,
+	)
+	return err
+}
+
+// GetLatestSnapshot returns the aggregate's highest-version snapshot, or nil
+// if it has none.
+func (s *PostgresEventStore) GetLatestSnapshot(ctx context.Context, aggregateID string) (*Snapshot, error) {
+	query := `
+		SELECT aggregate_id, aggregate_type, version, payload, timestamp
+		FROM snapshots
+		WHERE aggregate_id = $1
+		ORDER BY version DESC
+		LIMIT 1
+	`
+	var snap Snapshot
+	err := s.pool.QueryRow(ctx, query, aggregateID).Scan(
+		&snap.AggregateID,
+		&snap.AggregateType,
+		&snap.Version,
+		&snap.Payload,
+		&snap.Timestamp,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
@@ -0,0 +1,195 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// counterState is a test aggregate that sums the "n" of each event
+type counterState struct {
+	Total  int `json:"total"`
+	Events int `json:"events"`
+}
+
+func (c *counterState) apply(snapshot *Snapshot, events []Event) error {
+	if snapshot != nil {
+		if err := json.Unmarshal(snapshot.Payload, c); err != nil {
+			return err
+		}
+	}
+	for _, e := range events {
+		var payload struct {
+			N int `json:"n"`
+		}
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return err
+		}
+		c.Total += payload.N
+		c.Events++
+	}
+	return nil
+}
+
+// seedCounter appends count events with n = version to aggregateID
+func seedCounter(t testing.TB, store *memEventStore, aggregateID string, count int) {
+	t.Helper()
+	for v := 1; v <= count; v++ {
+		err := store.AppendEvent(context.Background(), Event{
+			ID:            fmt.Sprintf("%s-%d", aggregateID, v),
+			EventType:     "Counted",
+			AggregateID:   aggregateID,
+			AggregateType: "Counter",
+			Version:       int64(v),
+			Timestamp:     time.Now(),
+			Payload:       json.RawMessage(fmt.Sprintf(`{"n":%d}`, v)),
+		})
+		require.NoError(t, err)
+	}
+}
+
+// snapshotCounter saves the counter's state as of version
+func snapshotCounter(t testing.TB, store *memEventStore, aggregateID string, version int) {
+	t.Helper()
+	var state counterState
+	for v := 1; v <= version; v++ {
+		state.Total += v
+		state.Events++
+	}
+	payload, err := json.Marshal(state)
+	require.NoError(t, err)
+	require.NoError(t, store.SaveSnapshot(context.Background(), Snapshot{
+		AggregateID:   aggregateID,
+		AggregateType: "Counter",
+		Version:       int64(version),
+		Payload:       payload,
+		Timestamp:     time.Now(),
+	}))
+}
+
+func TestPostgresReplayEngine_LoadAggregate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replays every event without a snapshot", func(t *testing.T) {
+		store := &memEventStore{}
+		seedCounter(t, store, "counter-1", 10)
+
+		var state counterState
+		version, err := NewPostgresReplayEngine(store).LoadAggregate(ctx, "counter-1", state.apply)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), version)
+		assert.Equal(t, 55, state.Total)
+		assert.Equal(t, 10, state.Events)
+	})
+
+	t.Run("replays only events after the latest snapshot", func(t *testing.T) {
+		store := &memEventStore{}
+		seedCounter(t, store, "counter-2", 10)
+		snapshotCounter(t, store, "counter-2", 4)
+		snapshotCounter(t, store, "counter-2", 8)
+
+		var replayed []int64
+		var state counterState
+		version, err := NewPostgresReplayEngine(store).LoadAggregate(ctx, "counter-2", func(snapshot *Snapshot, events []Event) error {
+			require.NotNil(t, snapshot)
+			assert.Equal(t, int64(8), snapshot.Version)
+			for _, e := range events {
+				replayed = append(replayed, e.Version)
+			}
+			return state.apply(snapshot, events)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{9, 10}, replayed)
+		assert.Equal(t, int64(10), version)
+		assert.Equal(t, 55, state.Total, "Snapshot plus tail matches a full replay")
+	})
+
+	t.Run("snapshot with no newer events", func(t *testing.T) {
+		store := &memEventStore{}
+		seedCounter(t, store, "counter-3", 5)
+		snapshotCounter(t, store, "counter-3", 5)
+
+		var state counterState
+		version, err := NewPostgresReplayEngine(store).LoadAggregate(ctx, "counter-3", state.apply)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), version)
+		assert.Equal(t, 15, state.Total)
+	})
+
+	t.Run("unknown aggregate", func(t *testing.T) {
+		var state counterState
+		version, err := NewPostgresReplayEngine(&memEventStore{}).LoadAggregate(ctx, "missing", state.apply)
+		require.NoError(t, err)
+		assert.Zero(t, version)
+		assert.Zero(t, state.Events)
+	})
+}
+
+func TestPostgresEventStore_Snapshots(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	store := NewPostgresEventStore(pool)
+	ctx := context.Background()
+
+	none, err := store.GetLatestSnapshot(ctx, "snap-agg")
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	for _, v := range []int64{10, 20} {
+		require.NoError(t, store.SaveSnapshot(ctx, Snapshot{
+			AggregateID:   "snap-agg",
+			AggregateType: "Player",
+			Version:       v,
+			Payload:       json.RawMessage(fmt.Sprintf(`{"version":%d}`, v)),
+			Timestamp:     time.Now().UTC(),
+		}))
+	}
+
+	latest, err := store.GetLatestSnapshot(ctx, "snap-agg")
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, int64(20), latest.Version)
+	assert.JSONEq(t, `{"version":20}`, string(latest.Payload))
+}
+
+const benchStreamLength = 10_000
+
+// BenchmarkLoadAggregate_FullReplay rebuilds a 10k-event aggregate from scratch
+func BenchmarkLoadAggregate_FullReplay(b *testing.B) {
+	store := &memEventStore{}
+	seedCounter(b, store, "bench", benchStreamLength)
+	replay := NewPostgresReplayEngine(store)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var state counterState
+		if _, err := replay.LoadAggregate(ctx, "bench", state.apply); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLoadAggregate_SnapshotAndTail rebuilds the same aggregate from a
+// snapshot 100 events behind the head
+func BenchmarkLoadAggregate_SnapshotAndTail(b *testing.B) {
+	store := &memEventStore{}
+	seedCounter(b, store, "bench", benchStreamLength)
+	snapshotCounter(b, store, "bench", benchStreamLength-100)
+	replay := NewPostgresReplayEngine(store)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var state counterState
+		if _, err := replay.LoadAggregate(ctx, "bench", state.apply); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
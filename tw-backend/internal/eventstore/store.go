@@ -2,35 +2,215 @@ package eventstore
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// DefaultMaxAggregateEvents caps how many events GetEventsByAggregate returns
+// in one fetch. Generous so existing aggregates replay unchanged.
+const DefaultMaxAggregateEvents = 100_000
+
+// ErrAggregateTooLarge is returned (wrapped in *AggregateTooLargeError) when an
+// aggregate has more events than the store will fetch at once. Callers should
+// replay from a snapshot version instead.
+var ErrAggregateTooLarge = errors.New("aggregate exceeds max events per fetch")
+
+// AggregateTooLargeError reports which aggregate exceeded the fetch limit
+type AggregateTooLargeError struct {
+	AggregateID string
+	FromVersion int64
+	Limit       int
+}
+
+func (e *AggregateTooLargeError) Error() string {
+	return fmt.Sprintf("aggregate %s has more than %d events from version %d; replay from a snapshot instead",
+		e.AggregateID, e.Limit, e.FromVersion)
+}
+
+// Is lets errors.Is match ErrAggregateTooLarge
+func (e *AggregateTooLargeError) Is(target error) bool {
+	return target == ErrAggregateTooLarge
+}
+
+// ErrConcurrencyConflict is returned (wrapped in *ConcurrencyConflictError)
+// when an append expected a different number of events in the aggregate,
+// i.e. another writer appended first. Reload the aggregate and retry.
+var ErrConcurrencyConflict = errors.New("aggregate version conflict")
+
+// ConcurrencyConflictError reports the version an append expected and the one
+// it found
+type ConcurrencyConflictError struct {
+	AggregateID     string
+	ExpectedVersion int
+	ActualVersion   int
+}
+
+func (e *ConcurrencyConflictError) Error() string {
+	return fmt.Sprintf("aggregate %s is at version %d, expected %d",
+		e.AggregateID, e.ActualVersion, e.ExpectedVersion)
+}
+
+// Is lets errors.Is match ErrConcurrencyConflict
+func (e *ConcurrencyConflictError) Is(target error) bool {
+	return target == ErrConcurrencyConflict
+}
+
 // EventStore defines the methods for storing and retrieving events.
 type EventStore interface {
 	AppendEvent(ctx context.Context, event Event) error
+	// AppendEventExpectingVersion appends only if the aggregate currently has
+	// expectedVersion events, otherwise it returns ErrConcurrencyConflict
+	AppendEventExpectingVersion(ctx context.Context, event Event, expectedVersion int) error
+	// AppendEvents appends a batch atomically, assigning each event the next
+	// version of its aggregate
+	AppendEvents(ctx context.Context, events []Event) error
 	GetEventsByAggregate(ctx context.Context, aggregateID string, fromVersion int64) ([]Event, error)
 	GetEventsByType(ctx context.Context, eventType EventType, fromTimestamp, toTimestamp time.Time) ([]Event, error)
 	GetAllEvents(ctx context.Context, fromTimestamp time.Time, limit int) ([]Event, error)
+	SaveSnapshot(ctx context.Context, snapshot Snapshot) error
+	// GetLatestSnapshot returns nil (and no error) if the aggregate has none
+	GetLatestSnapshot(ctx context.Context, aggregateID string) (*Snapshot, error)
+	// Subscribe streams events after fromPosition (a GlobalPosition), first
+	// those already stored and then new ones as they are appended. The
+	// channel closes when ctx is cancelled.
+	Subscribe(ctx context.Context, fromPosition int64) (<-chan Event, error)
 }
 
 // PostgresEventStore implements EventStore using PostgreSQL.
 type PostgresEventStore struct {
-	pool *pgxpool.Pool
+	pool               *pgxpool.Pool
+	maxAggregateEvents int
+	schemas            *SchemaRegistry
 }
 
 // NewPostgresEventStore creates a new PostgresEventStore.
 func NewPostgresEventStore(pool *pgxpool.Pool) *PostgresEventStore {
-	return &PostgresEventStore{pool: pool}
+	return &PostgresEventStore{
+		pool:               pool,
+		maxAggregateEvents: DefaultMaxAggregateEvents,
+		schemas:            NewSchemaRegistry(),
+	}
+}
+
+// SetMaxAggregateEvents sets the most events GetEventsByAggregate will return.
+// Zero or negative disables the limit.
+func (s *PostgresEventStore) SetMaxAggregateEvents(limit int) {
+	s.maxAggregateEvents = limit
+}
+
+// RegisterUpcaster migrates eventType payloads stored at fromVersion. fn
+// returns the migrated payload and its new schema version; upcasters are
+// chained on every read so events always come back at the latest schema.
+func (s *PostgresEventStore) RegisterUpcaster(eventType string, fromVersion int, fn func(json.RawMessage) (json.RawMessage, int, error)) {
+	s.schemas.Register(EventType(eventType), fromVersion, fn)
 }
 
+const insertEventQuery = `
+	INSERT INTO events (id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, schema_version, metadata)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+// AppendEvent appends without checking the aggregate's version; concurrent
+// writers can interleave. Use AppendEventExpectingVersion when that matters.
 func (s *PostgresEventStore) AppendEvent(ctx context.Context, event Event) error {
-	query := `
-		INSERT INTO events (id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	`
-	_, err := s.pool.Exec(ctx, query,
+	_, err := s.pool.Exec(ctx, insertEventQuery, insertEventArgs(event)...)
+	return err
+}
+
+// AppendEventExpectingVersion appends event only if the aggregate currently
+// has expectedVersion events. The check and insert run in one transaction
+// holding a per-aggregate advisory lock, so of two racing appends with the
+// same expectation exactly one succeeds; the other gets
+// *ConcurrencyConflictError (matching ErrConcurrencyConflict).
+func (s *PostgresEventStore) AppendEventExpectingVersion(ctx context.Context, event Event, expectedVersion int) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // No-op after commit
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", event.AggregateID); err != nil {
+		return err
+	}
+	var actual int
+	if err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM events WHERE aggregate_id = $1", event.AggregateID).Scan(&actual); err != nil {
+		return err
+	}
+	if actual != expectedVersion {
+		return &ConcurrencyConflictError{AggregateID: event.AggregateID, ExpectedVersion: expectedVersion, ActualVersion: actual}
+	}
+
+	if _, err := tx.Exec(ctx, insertEventQuery, insertEventArgs(event)...); err != nil {
+		// A plain AppendEvent can still race us to the same version; the
+		// aggregate then has at least one more event than expected
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return &ConcurrencyConflictError{AggregateID: event.AggregateID, ExpectedVersion: expectedVersion, ActualVersion: expectedVersion + 1}
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// AppendEvents writes events in one transaction: either all are stored or,
+// if any insert fails, none are. Each event gets the next version of its
+// aggregate in batch order (caller-set versions are ignored) and, once
+// committed, events is updated with the assigned versions. The sequence
+// column preserves batch order for queries ordered by timestamp.
+func (s *PostgresEventStore) AppendEvents(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // No-op after commit
+
+	batch := slices.Clone(events)
+	lastVersion := make(map[string]int64)
+	for i := range batch {
+		aggregateID := batch[i].AggregateID
+		last, ok := lastVersion[aggregateID]
+		if !ok {
+			// Serialize with other batches and version-checked appends
+			if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", aggregateID); err != nil {
+				return err
+			}
+			if err := tx.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM events WHERE aggregate_id = $1", aggregateID).Scan(&last); err != nil {
+				return err
+			}
+		}
+		batch[i].Version = last + 1
+		lastVersion[aggregateID] = batch[i].Version
+
+		if _, err := tx.Exec(ctx, insertEventQuery, insertEventArgs(batch[i])...); err != nil {
+			return fmt.Errorf("append event %d of %d (%s): %w", i+1, len(batch), batch[i].ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	copy(events, batch)
+	return nil
+}
+
+// insertEventArgs returns insertEventQuery's arguments, storing a missing
+// schema version as 1
+func insertEventArgs(event Event) []any {
+	schemaVersion := event.SchemaVersion
+	if schemaVersion < 1 {
+		schemaVersion = 1
+	}
+	return []any{
 		event.ID,
 		event.EventType,
 		event.AggregateID,
@@ -38,19 +218,28 @@ func (s *PostgresEventStore) AppendEvent(ctx context.Context, event Event) error
 		event.Version,
 		event.Timestamp,
 		event.Payload,
+		schemaVersion,
 		event.Metadata,
-	)
-	return err
+	}
 }
 
+// GetEventsByAggregate returns an aggregate's events from fromVersion onward.
+// If more than the configured maximum exist it returns *AggregateTooLargeError
+// (matching ErrAggregateTooLarge) rather than loading them all.
 func (s *PostgresEventStore) GetEventsByAggregate(ctx context.Context, aggregateID string, fromVersion int64) ([]Event, error) {
 	query := `
-		SELECT id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, metadata
+		SELECT id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, schema_version, metadata, sequence
 		FROM events
 		WHERE aggregate_id = $1 AND version >= $2
 		ORDER BY version ASC
 	`
-	rows, err := s.pool.Query(ctx, query, aggregateID, fromVersion)
+	args := []any{aggregateID, fromVersion}
+	if s.maxAggregateEvents > 0 {
+		// Fetch one past the limit to detect overflow without loading the rest
+		query += " LIMIT $3"
+		args = append(args, s.maxAggregateEvents+1)
+	}
+	rows, err := s.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -58,17 +247,10 @@ func (s *PostgresEventStore) GetEventsByAggregate(ctx context.Context, aggregate
 
 	var events []Event
 	for rows.Next() {
-		var e Event
-		err := rows.Scan(
-			&e.ID,
-			&e.EventType,
-			&e.AggregateID,
-			&e.AggregateType,
-			&e.Version,
-			&e.Timestamp,
-			&e.Payload,
-			&e.Metadata,
-		)
+		if s.maxAggregateEvents > 0 && len(events) == s.maxAggregateEvents {
+			return nil, &AggregateTooLargeError{AggregateID: aggregateID, FromVersion: fromVersion, Limit: s.maxAggregateEvents}
+		}
+		e, err := s.scanEvent(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -77,12 +259,33 @@ func (s *PostgresEventStore) GetEventsByAggregate(ctx context.Context, aggregate
 	return events, rows.Err()
 }
 
+// scanEvent reads the current row and upcasts it to its latest schema
+func (s *PostgresEventStore) scanEvent(rows pgx.Rows) (Event, error) {
+	var e Event
+	err := rows.Scan(
+		&e.ID,
+		&e.EventType,
+		&e.AggregateID,
+		&e.AggregateType,
+		&e.Version,
+		&e.Timestamp,
+		&e.Payload,
+		&e.SchemaVersion,
+		&e.Metadata,
+		&e.GlobalPosition,
+	)
+	if err != nil {
+		return Event{}, err
+	}
+	return s.schemas.Upcast(e)
+}
+
 func (s *PostgresEventStore) GetEventsByType(ctx context.Context, eventType EventType, fromTimestamp, toTimestamp time.Time) ([]Event, error) {
 	query := `
-		SELECT id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, metadata
+		SELECT id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, schema_version, metadata, sequence
 		FROM events
 		WHERE event_type = $1 AND timestamp >= $2 AND timestamp <= $3
-		ORDER BY timestamp ASC
+		ORDER BY timestamp ASC, sequence ASC
 	`
 	rows, err := s.pool.Query(ctx, query, eventType, fromTimestamp, toTimestamp)
 	if err != nil {
@@ -92,17 +295,7 @@ func (s *PostgresEventStore) GetEventsByType(ctx context.Context, eventType Even
 
 	var events []Event
 	for rows.Next() {
-		var e Event
-		err := rows.Scan(
-			&e.ID,
-			&e.EventType,
-			&e.AggregateID,
-			&e.AggregateType,
-			&e.Version,
-			&e.Timestamp,
-			&e.Payload,
-			&e.Metadata,
-		)
+		e, err := s.scanEvent(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -113,10 +306,10 @@ func (s *PostgresEventStore) GetEventsByType(ctx context.Context, eventType Even
 
 func (s *PostgresEventStore) GetAllEvents(ctx context.Context, fromTimestamp time.Time, limit int) ([]Event, error) {
 	query := `
-		SELECT id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, metadata
+		SELECT id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, schema_version, metadata, sequence
 		FROM events
 		WHERE timestamp >= $1
-		ORDER BY timestamp ASC
+		ORDER BY timestamp ASC, sequence ASC
 		LIMIT $2
 	`
 	rows, err := s.pool.Query(ctx, query, fromTimestamp, limit)
@@ -127,17 +320,7 @@ func (s *PostgresEventStore) GetAllEvents(ctx context.Context, fromTimestamp tim
 
 	var events []Event
 	for rows.Next() {
-		var e Event
-		err := rows.Scan(
-			&e.ID,
-			&e.EventType,
-			&e.AggregateID,
-			&e.AggregateType,
-			&e.Version,
-			&e.Timestamp,
-			&e.Payload,
-			&e.Metadata,
-		)
+		e, err := s.scanEvent(rows)
 		if err != nil {
 			return nil, err
 		}
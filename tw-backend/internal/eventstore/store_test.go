@@ -3,7 +3,10 @@ package eventstore
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -45,14 +48,45 @@ func setupTestDB(t *testing.T) *pgxpool.Pool {
 			version BIGINT NOT NULL,
 			timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
 			payload JSONB NOT NULL,
+			schema_version INT NOT NULL DEFAULT 1,
 			metadata JSONB,
 			CONSTRAINT unique_aggregate_version UNIQUE (aggregate_id, version)
 		)
 	`)
 	require.NoError(t, err)
+	_, err = pool.Exec(ctx, "ALTER TABLE events ADD COLUMN IF NOT EXISTS schema_version INT NOT NULL DEFAULT 1")
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, "ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence BIGSERIAL")
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `
+		CREATE OR REPLACE FUNCTION notify_event_appended() RETURNS trigger AS $$
+		BEGIN
+			PERFORM pg_notify('events_appended', '');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS events_notify_appended ON events;
+		CREATE TRIGGER events_notify_appended
+			AFTER INSERT ON events
+			FOR EACH STATEMENT EXECUTE FUNCTION notify_event_appended();
+	`)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS snapshots (
+			aggregate_id TEXT NOT NULL,
+			aggregate_type TEXT NOT NULL,
+			version BIGINT NOT NULL,
+			payload JSONB NOT NULL,
+			timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+			PRIMARY KEY (aggregate_id, version)
+		)
+	`)
+	require.NoError(t, err)
 
 	// Clean up events table before test
-	_, err = pool.Exec(ctx, "TRUNCATE TABLE events")
+	_, err = pool.Exec(ctx, "TRUNCATE TABLE events, snapshots")
 	require.NoError(t, err)
 
 	return pool
@@ -150,6 +184,188 @@ func TestPostgresEventStore_GetEventsByAggregate(t *testing.T) {
 	})
 }
 
+func TestPostgresEventStore_GetEventsByAggregate_MaxEvents(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	store := NewPostgresEventStore(pool)
+	store.SetMaxAggregateEvents(3)
+	ctx := context.Background()
+
+	aggID := "agg-hot"
+	for i, id := range []string{
+		"123e4567-e89b-12d3-a456-426614174020",
+		"123e4567-e89b-12d3-a456-426614174021",
+		"123e4567-e89b-12d3-a456-426614174022",
+		"123e4567-e89b-12d3-a456-426614174023",
+	} {
+		err := store.AppendEvent(ctx, Event{ID: id, EventType: "T", AggregateID: aggID, AggregateType: "A",
+			Version: int64(i + 1), Timestamp: time.Now().UTC(), Payload: json.RawMessage(`{}`)})
+		require.NoError(t, err)
+	}
+
+	t.Run("under the limit fetches normally", func(t *testing.T) {
+		got, err := store.GetEventsByAggregate(ctx, aggID, 2)
+		assert.NoError(t, err)
+		assert.Len(t, got, 3) // Versions 2-4
+	})
+
+	t.Run("over the limit returns ErrAggregateTooLarge", func(t *testing.T) {
+		got, err := store.GetEventsByAggregate(ctx, aggID, 0)
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, ErrAggregateTooLarge)
+
+		var tooLarge *AggregateTooLargeError
+		require.ErrorAs(t, err, &tooLarge)
+		assert.Equal(t, aggID, tooLarge.AggregateID)
+		assert.Equal(t, 3, tooLarge.Limit)
+	})
+
+	t.Run("zero disables the limit", func(t *testing.T) {
+		store.SetMaxAggregateEvents(0)
+		got, err := store.GetEventsByAggregate(ctx, aggID, 0)
+		assert.NoError(t, err)
+		assert.Len(t, got, 4)
+	})
+}
+
+func TestAggregateTooLargeError(t *testing.T) {
+	err := fmt.Errorf("load character: %w", &AggregateTooLargeError{AggregateID: "agg-1", FromVersion: 10, Limit: 500})
+
+	assert.True(t, errors.Is(err, ErrAggregateTooLarge))
+	assert.Contains(t, err.Error(), "agg-1")
+	assert.Contains(t, err.Error(), "more than 500 events from version 10")
+}
+
+func TestConcurrencyConflictError(t *testing.T) {
+	err := fmt.Errorf("save world: %w", &ConcurrencyConflictError{AggregateID: "world-1", ExpectedVersion: 3, ActualVersion: 4})
+
+	assert.True(t, errors.Is(err, ErrConcurrencyConflict))
+	assert.False(t, errors.Is(err, ErrAggregateTooLarge))
+	assert.Contains(t, err.Error(), "world-1 is at version 4, expected 3")
+}
+
+func TestPostgresEventStore_AppendEventExpectingVersion(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	store := NewPostgresEventStore(pool)
+	ctx := context.Background()
+
+	aggID := "world-occ"
+	newEvent := func(id string, version int64) Event {
+		return Event{
+			ID:            id,
+			EventType:     "WorldTicked",
+			AggregateID:   aggID,
+			AggregateType: "World",
+			Version:       version,
+			Timestamp:     time.Now().UTC(),
+			Payload:       json.RawMessage(`{}`),
+		}
+	}
+
+	t.Run("appends at the expected version", func(t *testing.T) {
+		require.NoError(t, store.AppendEventExpectingVersion(ctx, newEvent("123e4567-e89b-12d3-a456-426614174200", 1), 0))
+	})
+
+	t.Run("rejects a stale expectation", func(t *testing.T) {
+		err := store.AppendEventExpectingVersion(ctx, newEvent("123e4567-e89b-12d3-a456-426614174201", 1), 0)
+		var conflict *ConcurrencyConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, 0, conflict.ExpectedVersion)
+		assert.Equal(t, 1, conflict.ActualVersion)
+	})
+
+	t.Run("exactly one of two concurrent appends succeeds", func(t *testing.T) {
+		ids := []string{"123e4567-e89b-12d3-a456-426614174202", "123e4567-e89b-12d3-a456-426614174203"}
+		start := make(chan struct{})
+		errs := make([]error, len(ids))
+		var wg sync.WaitGroup
+		for i, id := range ids {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				errs[i] = store.AppendEventExpectingVersion(ctx, newEvent(id, 2), 1)
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		var succeeded int
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+			} else {
+				assert.ErrorIs(t, err, ErrConcurrencyConflict)
+			}
+		}
+		assert.Equal(t, 1, succeeded)
+
+		got, err := store.GetEventsByAggregate(ctx, aggID, 0)
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+}
+
+func TestPostgresEventStore_AppendEvents(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	store := NewPostgresEventStore(pool)
+	ctx := context.Background()
+
+	// Every event shares a timestamp, so only the sequence orders them
+	at := time.Now().UTC()
+	newEvent := func(id, aggID string, eventType EventType) Event {
+		return Event{ID: id, EventType: eventType, AggregateID: aggID, AggregateType: "Combatant", Timestamp: at, Payload: json.RawMessage(`{}`)}
+	}
+
+	t.Run("assigns sequential versions and preserves order", func(t *testing.T) {
+		require.NoError(t, store.AppendEvent(ctx, Event{
+			ID: "123e4567-e89b-12d3-a456-426614174300", EventType: "CombatStarted", AggregateID: "fighter-a",
+			AggregateType: "Combatant", Version: 1, Timestamp: at, Payload: json.RawMessage(`{}`),
+		}))
+
+		batch := []Event{
+			newEvent("123e4567-e89b-12d3-a456-426614174301", "fighter-a", "DamageDealt"),
+			newEvent("123e4567-e89b-12d3-a456-426614174302", "fighter-b", "DurabilityLost"),
+			newEvent("123e4567-e89b-12d3-a456-426614174303", "fighter-a", "StatusApplied"),
+		}
+		require.NoError(t, store.AppendEvents(ctx, batch))
+		assert.Equal(t, []int64{2, 1, 3}, []int64{batch[0].Version, batch[1].Version, batch[2].Version})
+
+		all, err := store.GetAllEvents(ctx, at, 10)
+		require.NoError(t, err)
+		var types []EventType
+		for _, e := range all {
+			types = append(types, e.EventType)
+		}
+		assert.Equal(t, []EventType{"CombatStarted", "DamageDealt", "DurabilityLost", "StatusApplied"}, types)
+	})
+
+	t.Run("mid-batch constraint violation commits nothing", func(t *testing.T) {
+		batch := []Event{
+			newEvent("123e4567-e89b-12d3-a456-426614174310", "fighter-c", "DamageDealt"),
+			newEvent("123e4567-e89b-12d3-a456-426614174311", "fighter-c", "DurabilityLost"),
+			newEvent("123e4567-e89b-12d3-a456-426614174310", "fighter-c", "StatusApplied"), // duplicate ID
+		}
+		err := store.AppendEvents(ctx, batch)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "event 3 of 3")
+		assert.Zero(t, batch[0].Version, "Versions are only assigned on commit")
+
+		var count int
+		require.NoError(t, pool.QueryRow(ctx, "SELECT COUNT(*) FROM events WHERE aggregate_id = $1", "fighter-c").Scan(&count))
+		assert.Zero(t, count)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		assert.NoError(t, store.AppendEvents(ctx, nil))
+	})
+}
+
 func TestPostgresEventStore_GetEventsByType(t *testing.T) {
 	pool := setupTestDB(t)
 	defer pool.Close()
@@ -208,3 +424,58 @@ func TestPostgresEventStore_GetAllEvents(t *testing.T) {
 		assert.Equal(t, events[1].ID, got[1].ID)
 	})
 }
+
+func TestPostgresEventStore_UpcastsOnRead(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	store := NewPostgresEventStore(pool)
+	ctx := context.Background()
+
+	// Stored before the v2/v3 schema existed
+	aggID := "player-upcast"
+	require.NoError(t, store.AppendEvent(ctx, Event{
+		ID:            "123e4567-e89b-12d3-a456-426614174100",
+		EventType:     "PlayerMoved",
+		AggregateID:   aggID,
+		AggregateType: "Player",
+		Version:       1,
+		Timestamp:     time.Now().UTC(),
+		Payload:       json.RawMessage(`{"x":100,"y":200}`),
+		SchemaVersion: 1,
+	}))
+
+	for from, fn := range playerMovedUpcasters() {
+		store.RegisterUpcaster("PlayerMoved", from, fn)
+	}
+
+	t.Run("GetEventsByAggregate", func(t *testing.T) {
+		events, err := store.GetEventsByAggregate(ctx, aggID, 0)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assertPlayerMovedV3(t, events[0])
+	})
+
+	t.Run("GetEventsByType", func(t *testing.T) {
+		events, err := store.GetEventsByType(ctx, "PlayerMoved", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assertPlayerMovedV3(t, events[0])
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		events, err := NewPostgresReplayEngine(store).ReplayEvents(ctx, aggID, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assertPlayerMovedV3(t, events[0])
+	})
+
+	t.Run("stored payload is unchanged", func(t *testing.T) {
+		var version int
+		var payload json.RawMessage
+		err := pool.QueryRow(ctx, "SELECT schema_version, payload FROM events WHERE aggregate_id=$1", aggID).Scan(&version, &payload)
+		require.NoError(t, err)
+		assert.Equal(t, 1, version)
+		assert.JSONEq(t, `{"x":100,"y":200}`, string(payload))
+	})
+}
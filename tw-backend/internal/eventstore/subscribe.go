@@ -0,0 +1,127 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// EventsChannel is the LISTEN/NOTIFY channel a trigger on the events table
+// signals after every insert (see migration 000050).
+const EventsChannel = "events_appended"
+
+const (
+	// subscribeBatchSize is how many events a subscription reads per query
+	subscribeBatchSize = 500
+	// subscribePollInterval bounds how long a subscription waits for a
+	// notification before checking for new events anyway
+	subscribePollInterval = 500 * time.Millisecond
+	// subscribeGapTimeout is how long a subscription holds back events behind
+	// a gap in positions, waiting for the transaction that owns the missing
+	// position to commit. Rolled-back inserts leave permanent gaps.
+	subscribeGapTimeout = time.Second
+)
+
+// Subscribe streams every event with a GlobalPosition after fromPosition, in
+// position order: first the stored ones, then new ones as they're appended.
+// It listens for notifications before the catch-up query so appends in
+// between aren't missed. The channel closes when ctx is cancelled or the
+// subscription's connection fails; resubscribe from the last position seen.
+func (s *PostgresEventStore) Subscribe(ctx context.Context, fromPosition int64) (<-chan Event, error) {
+	pooled, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// LISTEN state belongs to the connection, so take it out of the pool
+	conn := pooled.Hijack()
+	if _, err := conn.Exec(ctx, "LISTEN "+EventsChannel); err != nil {
+		_ = conn.Close(context.Background())
+		return nil, err
+	}
+
+	out := make(chan Event)
+	go s.stream(ctx, conn, fromPosition, out)
+	return out, nil
+}
+
+// stream delivers events after position to out until ctx is done
+func (s *PostgresEventStore) stream(ctx context.Context, conn *pgx.Conn, position int64, out chan<- Event) {
+	defer close(out)
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	// A gap is a position not yet visible, possibly from an uncommitted
+	// append. Once one has been waited on for subscribeGapTimeout, every
+	// position seen by then is released even if gaps remain.
+	var gapSince time.Time
+	var gapReleaseThrough, releaseThrough int64
+
+	for {
+		events, err := s.eventsAfter(ctx, conn, position)
+		if err != nil {
+			return
+		}
+
+		delivered := 0
+		for _, e := range events {
+			if e.GlobalPosition != position+1 && e.GlobalPosition > releaseThrough {
+				if gapSince.IsZero() {
+					gapSince = time.Now()
+					gapReleaseThrough = events[len(events)-1].GlobalPosition
+				}
+				if time.Since(gapSince) < subscribeGapTimeout {
+					break
+				}
+				releaseThrough = gapReleaseThrough
+				gapSince = time.Time{}
+			}
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+			position = e.GlobalPosition
+			delivered++
+		}
+		if delivered == subscribeBatchSize {
+			continue // More may be stored already
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, subscribePollInterval)
+		_, err = conn.WaitForNotification(waitCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			return
+		}
+	}
+}
+
+// eventsAfter reads the next batch of events after position
+func (s *PostgresEventStore) eventsAfter(ctx context.Context, conn *pgx.Conn, position int64) ([]Event, error) {
+	query := `
+		SELECT id, event_type, aggregate_id, aggregate_type, version, timestamp, payload, schema_version, metadata, sequence
+		FROM events
+		WHERE sequence > $1
+		ORDER BY sequence ASC
+		LIMIT $2
+	`
+	rows, err := conn.Query(ctx, query, position, subscribeBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		e, err := s.scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,123 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// numberedEvent is the nth event of aggID
+func numberedEvent(aggID string, n int) Event {
+	return Event{
+		ID:            uuid.New().String(),
+		EventType:     "Counted",
+		AggregateID:   aggID,
+		AggregateType: "Counter",
+		Version:       int64(n),
+		Timestamp:     time.Now().UTC(),
+		Payload:       json.RawMessage(fmt.Sprintf(`{"n":%d}`, n)),
+	}
+}
+
+// appendNumbered appends the nth event of aggID, returning its ID
+func appendNumbered(t *testing.T, store *PostgresEventStore, aggID string, n int) string {
+	t.Helper()
+	event := numberedEvent(aggID, n)
+	require.NoError(t, store.AppendEvent(context.Background(), event))
+	return event.ID
+}
+
+// receive reads count events from ch, failing if they don't arrive in time
+func receive(t *testing.T, ch <-chan Event, count int) []Event {
+	t.Helper()
+	var got []Event
+	timeout := time.After(10 * time.Second)
+	for len(got) < count {
+		select {
+		case e, ok := <-ch:
+			require.True(t, ok, "subscription closed after %d of %d events", len(got), count)
+			got = append(got, e)
+		case <-timeout:
+			t.Fatalf("received %d of %d events", len(got), count)
+		}
+	}
+	return got
+}
+
+func TestPostgresEventStore_Subscribe(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	store := NewPostgresEventStore(pool)
+
+	// Subscribe from the first event's position; the sequence isn't reset by TRUNCATE
+	appendNumbered(t, store, "sub-agg", 1)
+	stored, err := store.GetEventsByAggregate(context.Background(), "sub-agg", 0)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	start := stored[0].GlobalPosition
+	require.Positive(t, start)
+
+	t.Run("catches up then follows live appends in order", func(t *testing.T) {
+		// Stored before subscribing
+		want := []string{appendNumbered(t, store, "sub-agg", 2)}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch, err := store.Subscribe(ctx, start)
+		require.NoError(t, err)
+
+		// Appended while the subscriber is attached
+		live := make([]Event, 100)
+		for i := range live {
+			live[i] = numberedEvent("sub-agg", i+3)
+			want = append(want, live[i].ID)
+		}
+		appendErr := make(chan error, 1)
+		go func() {
+			for _, e := range live {
+				if err := store.AppendEvent(context.Background(), e); err != nil {
+					appendErr <- err
+					return
+				}
+			}
+			appendErr <- nil
+		}()
+
+		got := receive(t, ch, 101)
+		require.NoError(t, <-appendErr)
+
+		for i, e := range got {
+			assert.Equal(t, want[i], e.ID, "event %d out of order", i)
+			if i > 0 {
+				assert.Greater(t, e.GlobalPosition, got[i-1].GlobalPosition)
+			}
+		}
+	})
+
+	t.Run("closes when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch, err := store.Subscribe(ctx, start)
+		require.NoError(t, err)
+		receive(t, ch, 1)
+		cancel()
+
+		deadline := time.After(5 * time.Second)
+		for {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					return
+				}
+			case <-deadline:
+				t.Fatal("subscription did not close after cancel")
+			}
+		}
+	})
+}
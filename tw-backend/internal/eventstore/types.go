@@ -13,14 +13,16 @@ type AggregateType string
 
 // Event represents a fact that has happened in the system.
 type Event struct {
-	ID            string          `json:"id"`
-	EventType     EventType       `json:"event_type"`
-	AggregateID   string          `json:"aggregate_id"`
-	AggregateType AggregateType   `json:"aggregate_type"`
-	Version       int64           `json:"version"`
-	Timestamp     time.Time       `json:"timestamp"`
-	Payload       json.RawMessage `json:"payload"`
-	Metadata      map[string]any  `json:"metadata,omitempty"`
+	ID             string          `json:"id"`
+	EventType      EventType       `json:"event_type"`
+	AggregateID    string          `json:"aggregate_id"`
+	AggregateType  AggregateType   `json:"aggregate_type"`
+	Version        int64           `json:"version"`
+	Timestamp      time.Time       `json:"timestamp"`
+	Payload        json.RawMessage `json:"payload"`
+	SchemaVersion  int             `json:"schema_version,omitempty"`  // Payload schema; 0 is stored as 1
+	GlobalPosition int64           `json:"global_position,omitempty"` // Store-assigned order across all aggregates; set on read
+	Metadata       map[string]any  `json:"metadata,omitempty"`
 }
 
 // Command represents a request to perform an action.
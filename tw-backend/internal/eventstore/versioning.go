@@ -1,6 +1,10 @@
 package eventstore
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
 
 // Upcaster transforms an event from an older version to a newer version.
 type Upcaster interface {
@@ -51,3 +55,63 @@ func (vm *VersioningManager) UpcastEvent(event Event) (Event, error) {
 
 	return currentEvent, nil
 }
+
+// SchemaUpcastFunc migrates a payload from one schema version, returning the
+// new payload and the schema version it now conforms to.
+type SchemaUpcastFunc func(payload json.RawMessage) (json.RawMessage, int, error)
+
+// SchemaRegistry migrates event payloads to their latest schema version on
+// read. Unlike VersioningManager the event type stays the same; each upcaster
+// handles one (event type, schema version) pair and they are chained until no
+// upcaster matches. Events without a schema version are treated as version 1.
+type SchemaRegistry struct {
+	mu        sync.RWMutex
+	upcasters map[EventType]map[int]SchemaUpcastFunc
+}
+
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{
+		upcasters: make(map[EventType]map[int]SchemaUpcastFunc),
+	}
+}
+
+// Register adds the upcaster for eventType payloads at fromVersion, replacing
+// any existing one.
+func (r *SchemaRegistry) Register(eventType EventType, fromVersion int, fn SchemaUpcastFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.upcasters[eventType] == nil {
+		r.upcasters[eventType] = make(map[int]SchemaUpcastFunc)
+	}
+	r.upcasters[eventType][fromVersion] = fn
+}
+
+// Upcast walks event from its stored schema version up to the latest one that
+// has registered upcasters. On error the original event is returned.
+func (r *SchemaRegistry) Upcast(event Event) (Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	current := event
+	if current.SchemaVersion < 1 {
+		current.SchemaVersion = 1
+	}
+	for {
+		fn, ok := r.upcasters[current.EventType][current.SchemaVersion]
+		if !ok {
+			return current, nil
+		}
+		payload, version, err := fn(current.Payload)
+		if err != nil {
+			return event, fmt.Errorf("failed to upcast event %s (%s) from schema v%d: %w",
+				event.ID, event.EventType, current.SchemaVersion, err)
+		}
+		// A non-advancing upcaster would loop forever
+		if version <= current.SchemaVersion {
+			return event, fmt.Errorf("upcaster for %s v%d returned v%d; versions must increase",
+				event.EventType, current.SchemaVersion, version)
+		}
+		current.Payload = payload
+		current.SchemaVersion = version
+	}
+}
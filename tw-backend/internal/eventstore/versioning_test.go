@@ -72,3 +72,87 @@ func TestVersioningManager_UpcastEvent(t *testing.T) {
 		assert.Equal(t, event, upcasted)
 	})
 }
+
+// playerMovedUpcasters migrates PlayerMoved payloads:
+// v1 {"x","y"} -> v2 adds "z" -> v3 nests them under "position"
+func playerMovedUpcasters() map[int]SchemaUpcastFunc {
+	return map[int]SchemaUpcastFunc{
+		1: func(payload json.RawMessage) (json.RawMessage, int, error) {
+			var p map[string]any
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, 0, err
+			}
+			p["z"] = 0
+			out, err := json.Marshal(p)
+			return out, 2, err
+		},
+		2: func(payload json.RawMessage) (json.RawMessage, int, error) {
+			var p map[string]any
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, 0, err
+			}
+			out, err := json.Marshal(map[string]any{"position": p})
+			return out, 3, err
+		},
+	}
+}
+
+// assertPlayerMovedV3 checks an event was upcast from the v1 payload {"x":100,"y":200}
+func assertPlayerMovedV3(t *testing.T, event Event) {
+	t.Helper()
+	assert.Equal(t, 3, event.SchemaVersion)
+	assert.JSONEq(t, `{"position":{"x":100,"y":200,"z":0}}`, string(event.Payload))
+}
+
+func TestSchemaRegistry_Upcast(t *testing.T) {
+	registry := NewSchemaRegistry()
+	for from, fn := range playerMovedUpcasters() {
+		registry.Register("PlayerMoved", from, fn)
+	}
+	v1 := Event{ID: "evt-v1", EventType: "PlayerMoved", SchemaVersion: 1, Payload: json.RawMessage(`{"x":100,"y":200}`)}
+
+	t.Run("chains upcasters to the latest version", func(t *testing.T) {
+		upcasted, err := registry.Upcast(v1)
+		require.NoError(t, err)
+		assertPlayerMovedV3(t, upcasted)
+	})
+
+	t.Run("treats unversioned events as v1", func(t *testing.T) {
+		legacy := v1
+		legacy.SchemaVersion = 0
+		upcasted, err := registry.Upcast(legacy)
+		require.NoError(t, err)
+		assertPlayerMovedV3(t, upcasted)
+	})
+
+	t.Run("starts from the stored version", func(t *testing.T) {
+		v2 := Event{ID: "evt-v2", EventType: "PlayerMoved", SchemaVersion: 2, Payload: json.RawMessage(`{"x":100,"y":200,"z":0}`)}
+		upcasted, err := registry.Upcast(v2)
+		require.NoError(t, err)
+		assertPlayerMovedV3(t, upcasted)
+	})
+
+	t.Run("leaves other event types alone", func(t *testing.T) {
+		other := Event{ID: "evt-other", EventType: "ItemPickedUp", SchemaVersion: 1, Payload: json.RawMessage(`{}`)}
+		upcasted, err := registry.Upcast(other)
+		require.NoError(t, err)
+		assert.Equal(t, other, upcasted)
+	})
+
+	t.Run("returns the original event on failure", func(t *testing.T) {
+		broken := Event{ID: "evt-broken", EventType: "PlayerMoved", SchemaVersion: 1, Payload: json.RawMessage(`not json`)}
+		upcasted, err := registry.Upcast(broken)
+		assert.Error(t, err)
+		assert.Equal(t, broken, upcasted)
+	})
+}
+
+func TestSchemaRegistry_RejectsNonAdvancingUpcaster(t *testing.T) {
+	registry := NewSchemaRegistry()
+	registry.Register("Looping", 1, func(payload json.RawMessage) (json.RawMessage, int, error) {
+		return payload, 1, nil
+	})
+
+	_, err := registry.Upcast(Event{ID: "evt-loop", EventType: "Looping", SchemaVersion: 1, Payload: json.RawMessage(`{}`)})
+	assert.ErrorContains(t, err, "versions must increase")
+}
@@ -0,0 +1,191 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/spatial"
+)
+
+const (
+	// SayRadius is how far spoken words carry (meters)
+	SayRadius = 30.0
+	// WhisperRange is how close a whisper's recipient must be (meters)
+	WhisperRange = 5.0
+	// OverhearRadius is how far away a whisper can be overheard (meters)
+	OverhearRadius = 15.0
+	// TalkRange is how close an NPC must be to talk to (meters)
+	TalkRange = 10.0
+	// CombatBroadcastRadius is how far away combat can be witnessed (meters)
+	CombatBroadcastRadius = 50.0
+)
+
+// catastropheMessages describe what players caught in a catastrophe see
+var catastropheMessages = map[ecosystem.GeologicalEventType]string{
+	ecosystem.EventVolcanicWinter: "🌋 The ground heaves as a volcano erupts nearby, raining ash across the land!",
+	ecosystem.EventAsteroidImpact: "☄️ A blinding flash splits the sky as an asteroid slams into the earth nearby!",
+	ecosystem.EventFloodBasalt:    "🌋 Fissures tear open and floods of lava pour across the land!",
+}
+
+// broadcastCatastrophe tells the players within a catastrophe's affected
+// area what has struck them
+func (p *GameProcessor) broadcastCatastrophe(worldID uuid.UUID, site ecosystem.CatastropheSite) {
+	msg, ok := catastropheMessages[site.Type]
+	if !ok {
+		return
+	}
+	p.broadcastGameMessageToArea(worldID, spatial.Position{X: site.X, Y: site.Y}, site.Radius, "catastrophe", msg, map[string]interface{}{
+		"event":  string(site.Type),
+		"x":      site.X,
+		"y":      site.Y,
+		"radius": site.Radius,
+	})
+}
+
+// syncHubPosition copies a character's stored position into the hub's spatial index
+// so area broadcasts reach them at their real location
+func (p *GameProcessor) syncHubPosition(ctx context.Context, charID uuid.UUID) {
+	if p.Hub == nil || p.authRepo == nil {
+		return
+	}
+	char, err := p.authRepo.GetCharacter(ctx, charID)
+	if err != nil || char == nil {
+		return
+	}
+	p.Hub.UpdateCharacterPosition(charID, char.PositionX, char.PositionY)
+}
+
+// broadcastGameMessageToArea sends a game message to clients near a point in a world
+func (p *GameProcessor) broadcastGameMessageToArea(worldID uuid.UUID, center spatial.Position, radius float64, msgType, text string, metadata map[string]interface{}, filters ...websocket.RecipientFilter) {
+	if p.Hub == nil {
+		return
+	}
+	p.Hub.BroadcastToArea(worldID, center, radius, websocket.MessageTypeGameMessage, websocket.GameMessageData{
+		ID:        uuid.New().String(),
+		Type:      msgType,
+		Text:      text,
+		Timestamp: time.Now(),
+		Metadata:  metadata,
+	}, filters...)
+}
+
+// excludeCharacter filters out a single character (usually the sender)
+func excludeCharacter(charID uuid.UUID) websocket.RecipientFilter {
+	return func(c *websocket.Client, _ spatial.Position) bool {
+		return c.CharacterID != charID
+	}
+}
+
+// lineOfSightFilter drops recipients whose view of center is blocked by a
+// colliding world entity (walls, boulders, trees)
+func (p *GameProcessor) lineOfSightFilter(ctx context.Context, worldID uuid.UUID, center spatial.Position, radius float64) websocket.RecipientFilter {
+	if p.worldEntityService == nil {
+		return nil
+	}
+	entities, err := p.worldEntityService.GetEntitiesAt(ctx, worldID, center.X, center.Y, radius)
+	if err != nil || len(entities) == 0 {
+		return nil
+	}
+
+	return func(_ *websocket.Client, pos spatial.Position) bool {
+		for _, e := range entities {
+			if !e.Collision {
+				continue
+			}
+			if segmentIntersectsCircle(center, pos, spatial.Position{X: e.X, Y: e.Y}, e.CollisionRadius()) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// segmentIntersectsCircle reports whether the segment a-b passes through a circle.
+// Circles containing either endpoint don't block, so an observer standing
+// beside an obstacle still sees past it.
+func segmentIntersectsCircle(a, b, c spatial.Position, r float64) bool {
+	rSq := r * r
+	if distSq(a, c) <= rSq || distSq(b, c) <= rSq {
+		return false
+	}
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lenSq := dx*dx + dy*dy
+	if lenSq == 0 {
+		return false
+	}
+	// Project circle center onto the segment
+	t := ((c.X-a.X)*dx + (c.Y-a.Y)*dy) / lenSq
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+	closest := spatial.Position{X: a.X + t*dx, Y: a.Y + t*dy}
+	return distSq(closest, c) <= rSq
+}
+
+func distSq(a, b spatial.Position) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
+
+// broadcastCombatEvent shows a combat event to everyone with line-of-sight to the actor
+func (p *GameProcessor) broadcastCombatEvent(evtType string, data map[string]interface{}) {
+	actorID, _ := data["actor_id"].(uuid.UUID)
+	targetID, _ := data["target_id"].(uuid.UUID)
+
+	actorName := p.combatantName(actorID)
+	targetName := p.combatantName(targetID)
+
+	var msg string
+	switch evtType {
+	case "miss":
+		msg = fmt.Sprintf("%s misses %s.", actorName, targetName)
+	case "combat_action":
+		actionType, _ := data["type"].(string)
+		msg = fmt.Sprintf("%s performs %s on %s.", actorName, actionType, targetName)
+	default:
+		return
+	}
+
+	log.Printf("[COMBAT-EVENT] %s: %s", evtType, msg)
+	p.broadcastCombatMessage(actorID, "combat_event", msg, data)
+}
+
+// broadcastCombatMessage sends msg to everyone near the character at centerID
+// who has line-of-sight to them and passes the extra filters
+func (p *GameProcessor) broadcastCombatMessage(centerID uuid.UUID, msgType, msg string, data map[string]interface{}, extra ...websocket.RecipientFilter) {
+	if p.Hub == nil {
+		return
+	}
+	client, ok := p.Hub.GetClientByCharacter(centerID)
+	if !ok {
+		return
+	}
+	center, ok := p.Hub.GetCharacterPosition(centerID)
+	if !ok {
+		return
+	}
+
+	filters := extra
+	if los := p.lineOfSightFilter(context.Background(), client.WorldID, center, CombatBroadcastRadius); los != nil {
+		filters = append(filters, los)
+	}
+	p.broadcastGameMessageToArea(client.WorldID, center, CombatBroadcastRadius, msgType, msg, data, filters...)
+}
+
+// combatantName resolves a display name for a combatant, falling back to its ID
+func (p *GameProcessor) combatantName(id uuid.UUID) string {
+	if p.Hub != nil {
+		if c, ok := p.Hub.GetClientByCharacter(id); ok && c.Username != "" {
+			return c.Username
+		}
+	}
+	return id.String()
+}
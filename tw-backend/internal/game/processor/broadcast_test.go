@@ -0,0 +1,99 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/spatial"
+)
+
+// addHubClient registers a raw hub client at a position in a world
+func addHubClient(hub *websocket.Hub, worldID uuid.UUID, x, y float64) *websocket.Client {
+	c := &websocket.Client{CharacterID: uuid.New(), WorldID: worldID, Send: make(chan []byte, 16)}
+	hub.Clients[c.CharacterID] = c
+	hub.UpdateCharacterPosition(c.CharacterID, x, y)
+	return c
+}
+
+func TestHandleSay_InWorld_OnlyReachesNearbyPlayers(t *testing.T) {
+	proc, client, _, _ := setupTest(t)
+	hub := websocket.NewHub(proc)
+	proc.SetHub(hub)
+
+	worldID := uuid.New()
+	client.WorldID = worldID
+	hub.UpdateCharacterPosition(client.CharacterID, 0, 0)
+
+	near := addHubClient(hub, worldID, 10, 0)
+	far := addHubClient(hub, worldID, SayRadius+50, 0)
+
+	message := "Over here!"
+	err := proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "say", Message: &message})
+	require.NoError(t, err)
+
+	require.Len(t, near.Send, 1, "Player within earshot hears the speech")
+	var msg websocket.ServerMessage
+	require.NoError(t, json.Unmarshal(<-near.Send, &msg))
+	assert.Equal(t, websocket.MessageTypeGameMessage, msg.Type)
+	assert.Len(t, far.Send, 0, "Player out of range hears nothing")
+}
+
+func TestSegmentIntersectsCircle(t *testing.T) {
+	a := spatial.Position{X: 0, Y: 0}
+	b := spatial.Position{X: 10, Y: 0}
+
+	assert.True(t, segmentIntersectsCircle(a, b, spatial.Position{X: 5, Y: 0.5}, 1), "Obstacle on the line blocks")
+	assert.False(t, segmentIntersectsCircle(a, b, spatial.Position{X: 5, Y: 3}, 1), "Obstacle off to the side does not block")
+	assert.False(t, segmentIntersectsCircle(a, b, spatial.Position{X: 15, Y: 0}, 1), "Obstacle beyond the target does not block")
+	assert.False(t, segmentIntersectsCircle(a, b, spatial.Position{X: 0.2, Y: 0}, 1), "Obstacle at the observer does not block")
+}
+
+func TestBroadcastCombatEvent_ReachesWitnessesInRange(t *testing.T) {
+	proc, _, _, _ := setupTest(t)
+	hub := websocket.NewHub(proc)
+	proc.SetHub(hub)
+
+	worldID := uuid.New()
+	attacker := addHubClient(hub, worldID, 0, 0)
+	witness := addHubClient(hub, worldID, 20, 0)
+	distant := addHubClient(hub, worldID, CombatBroadcastRadius+100, 0)
+
+	proc.broadcastCombatEvent("miss", map[string]interface{}{
+		"actor_id":  attacker.CharacterID,
+		"target_id": uuid.New(),
+	})
+
+	assert.Len(t, attacker.Send, 1)
+	assert.Len(t, witness.Send, 1)
+	assert.Len(t, distant.Send, 0)
+}
+
+func TestBroadcastCatastrophe_ReachesPlayersInAffectedArea(t *testing.T) {
+	proc, _, _, _ := setupTest(t)
+	hub := websocket.NewHub(proc)
+	proc.SetHub(hub)
+
+	worldID := uuid.New()
+	caught := addHubClient(hub, worldID, 102, 100)
+	distant := addHubClient(hub, worldID, 150, 100)
+	elsewhere := addHubClient(hub, uuid.New(), 100, 100)
+
+	proc.broadcastCatastrophe(worldID, ecosystem.CatastropheSite{Type: ecosystem.EventVolcanicWinter, X: 100, Y: 100, Radius: 4})
+
+	require.Len(t, caught.Send, 1, "A player inside the affected area sees the eruption")
+	var msg struct {
+		Data websocket.GameMessageData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(<-caught.Send, &msg))
+	assert.Equal(t, "catastrophe", msg.Data.Type)
+	assert.Contains(t, msg.Data.Text, "volcano")
+	assert.Len(t, distant.Send, 0, "A player outside the affected area sees nothing")
+	assert.Len(t, elsewhere.Send, 0, "Nor does a player in another world")
+}
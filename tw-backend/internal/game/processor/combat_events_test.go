@@ -0,0 +1,134 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/internal/combat/action"
+	"tw-backend/internal/combat/config"
+	"tw-backend/internal/eventstore"
+)
+
+// memEventStore is an in-memory event store that can be made to fail
+type memEventStore struct {
+	events []eventstore.Event
+	err    error
+}
+
+func (m *memEventStore) AppendEvent(_ context.Context, event eventstore.Event) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *memEventStore) AppendEventExpectingVersion(ctx context.Context, event eventstore.Event, _ int) error {
+	return m.AppendEvent(ctx, event)
+}
+
+func (m *memEventStore) AppendEvents(ctx context.Context, events []eventstore.Event) error {
+	for _, event := range events {
+		if err := m.AppendEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memEventStore) GetEventsByAggregate(_ context.Context, aggregateID string, _ int64) ([]eventstore.Event, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var out []eventstore.Event
+	for _, e := range m.events {
+		if e.AggregateID == aggregateID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memEventStore) GetEventsByType(_ context.Context, _ eventstore.EventType, _, _ time.Time) ([]eventstore.Event, error) {
+	return m.events, m.err
+}
+
+func (m *memEventStore) GetAllEvents(_ context.Context, _ time.Time, _ int) ([]eventstore.Event, error) {
+	return m.events, m.err
+}
+
+func (m *memEventStore) SaveSnapshot(_ context.Context, _ eventstore.Snapshot) error {
+	return m.err
+}
+
+func (m *memEventStore) GetLatestSnapshot(_ context.Context, _ string) (*eventstore.Snapshot, error) {
+	return nil, m.err
+}
+
+func (m *memEventStore) Subscribe(_ context.Context, _ int64) (<-chan eventstore.Event, error) {
+	return nil, errors.New("memEventStore does not support subscriptions")
+}
+
+// queueInstantAttack puts two combatants in combat and queues an attack that
+// resolves on the next tick
+func queueInstantAttack(t *testing.T, proc *GameProcessor, attackerID, targetID uuid.UUID) {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Reaction.NormalAttackMs = 1
+	cfg.Reaction.MinimumMs = 1
+	proc.combatService.SetReactionModel(action.NewReactionModel(cfg))
+
+	proc.combatService.JoinCombat(&action.Combatant{EntityID: attackerID, MaxHP: 50, CurrentHP: 50, MaxStamina: 100, CurrentStamina: 100})
+	proc.combatService.JoinCombat(&action.Combatant{EntityID: targetID, MaxHP: 50, CurrentHP: 50, MaxStamina: 100, CurrentStamina: 100})
+	require.NoError(t, proc.combatService.QueueAttack(attackerID, targetID))
+	time.Sleep(5 * time.Millisecond)
+}
+
+func TestTick_PublishesCombatResolved(t *testing.T) {
+	proc, attacker, target, _, _ := setupCombatants(t)
+	store := &memEventStore{}
+	pub := eventstore.NewPublisher(store)
+	proc.SetEventPublisher(pub)
+
+	queueInstantAttack(t, proc, attacker.CharacterID, target.CharacterID)
+	proc.Tick(100 * time.Millisecond)
+	pub.Flush()
+
+	require.Len(t, store.events, 1)
+	event := store.events[0]
+	assert.Equal(t, eventstore.EventTypeCombatResolved, event.EventType)
+	assert.Equal(t, eventstore.AggregateCombatant, event.AggregateType)
+	assert.Equal(t, eventstore.CombatantAggregateID(attacker.CharacterID), event.AggregateID)
+	assert.Equal(t, int64(1), event.Version)
+
+	var payload eventstore.CombatResolvedPayload
+	require.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.Equal(t, attacker.CharacterID, payload.AttackerID)
+	assert.Equal(t, target.CharacterID, payload.TargetID)
+	assert.NotEmpty(t, payload.Outcome)
+	assert.False(t, payload.ResolvedAt.IsZero())
+}
+
+func TestTick_EventStoreFailureDoesNotBlockCombat(t *testing.T) {
+	proc, attacker, target, _, _ := setupCombatants(t)
+	store := &memEventStore{err: errors.New("database unavailable")}
+	pub := eventstore.NewPublisher(store)
+	proc.SetEventPublisher(pub)
+
+	queueInstantAttack(t, proc, attacker.CharacterID, target.CharacterID)
+	proc.Tick(100 * time.Millisecond)
+	pub.Flush()
+
+	assert.Empty(t, store.events)
+	attackerMsgs := drainGameMessages(t, attacker)
+	require.NotEmpty(t, attackerMsgs, "The attack is still rendered to the attacker")
+	assert.Equal(t, "combat", attackerMsgs[0].Type)
+	assert.NotEmpty(t, drainGameMessages(t, target))
+}
@@ -0,0 +1,150 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"tw-backend/internal/combat/action"
+	"tw-backend/internal/eventstore"
+	"tw-backend/internal/game/formatter"
+	"tw-backend/internal/game/services/combat"
+)
+
+// deliverAttackResult tells the attacker and target how an attack went,
+// shows nearby witnesses a third-person account, and announces a kill to the area
+func (p *GameProcessor) deliverAttackResult(result *combat.AttackResult) {
+	if p.Hub == nil || result == nil {
+		return
+	}
+	attackerName := p.combatantName(result.AttackerID)
+	targetName := p.combatantName(result.TargetID)
+	metadata := map[string]interface{}{"result": result}
+
+	if attacker, ok := p.Hub.GetClientByCharacter(result.AttackerID); ok {
+		attacker.SendGameMessage("combat", formatAttackForAttacker(result, targetName), metadata)
+	}
+	if target, ok := p.Hub.GetClientByCharacter(result.TargetID); ok {
+		target.SendGameMessage("combat", formatAttackForTarget(result, attackerName), metadata)
+	}
+
+	// Participants already got their own view
+	p.broadcastCombatMessage(result.AttackerID, "combat_event", formatAttackForWitness(result, attackerName, targetName), metadata,
+		excludeCharacter(result.AttackerID), excludeCharacter(result.TargetID))
+
+	if result.TargetDefeated {
+		msg := fmt.Sprintf("%s has been slain by %s!", targetName, attackerName)
+		log.Printf("[COMBAT-EVENT] death: %s", msg)
+		p.broadcastCombatMessage(result.AttackerID, "combat_death", msg, metadata)
+	}
+}
+
+// publishAttackResult publishes a combat_resolved event on the attacker's
+// Combatant aggregate. Best-effort: a failure is logged and combat carries on.
+func (p *GameProcessor) publishAttackResult(result *combat.AttackResult, resolvedAt time.Time) {
+	if p.events == nil || result == nil {
+		return
+	}
+	effects := make([]string, 0, len(result.Effects))
+	for _, effect := range result.Effects {
+		effects = append(effects, string(effect))
+	}
+	payload := eventstore.CombatResolvedPayload{
+		AttackerID:     result.AttackerID,
+		TargetID:       result.TargetID,
+		Outcome:        string(result.Outcome),
+		Critical:       result.Critical,
+		Fumble:         result.Fumble,
+		Damage:         result.Damage,
+		Effects:        effects,
+		TargetHP:       result.TargetHP,
+		TargetDefeated: result.TargetDefeated,
+		ResolvedAt:     resolvedAt,
+	}
+	err := p.events.Publish(context.Background(), eventstore.AggregateCombatant, eventstore.CombatantAggregateID(result.AttackerID), eventstore.EventTypeCombatResolved, payload)
+	if err != nil {
+		log.Printf("[COMBAT-EVENT] failed to publish attack by %s: %v", result.AttackerID, err)
+	}
+}
+
+// formatAttackForAttacker renders an attack from the attacker's point of view
+func formatAttackForAttacker(r *combat.AttackResult, targetName string) string {
+	target := formatter.Target(targetName)
+	switch {
+	case r.Outcome == action.OutcomeMiss:
+		return fmt.Sprintf("You miss %s.", target)
+	case r.Fumble:
+		return fmt.Sprintf("You fumble your attack on %s!", target)
+	}
+
+	verb := "hit"
+	if r.Critical {
+		verb = "critically hit"
+	}
+	msg := fmt.Sprintf("You %s %s for %s damage.", verb, target, formatter.Damage(r.Damage))
+	msg += formatEffects(r.Effects, "They are")
+	switch {
+	case r.TargetDefeated:
+		msg += fmt.Sprintf(" %s is defeated!", target)
+	case r.TargetMaxHP > 0:
+		msg += fmt.Sprintf(" (%s: %d/%d HP)", targetName, r.TargetHP, r.TargetMaxHP)
+	}
+	if r.WeaponBroke != nil {
+		msg += fmt.Sprintf(" Your %s breaks!", r.WeaponBroke.Name)
+	}
+	return msg
+}
+
+// formatAttackForTarget renders an attack from the target's point of view
+func formatAttackForTarget(r *combat.AttackResult, attackerName string) string {
+	attacker := formatter.Target(attackerName)
+	switch {
+	case r.Outcome == action.OutcomeMiss:
+		return fmt.Sprintf("%s misses you.", attacker)
+	case r.Fumble:
+		return fmt.Sprintf("%s fumbles an attack on you.", attacker)
+	}
+
+	verb := "hits"
+	if r.Critical {
+		verb = "critically hits"
+	}
+	msg := fmt.Sprintf("%s %s you for %s damage.", attacker, verb, formatter.Damage(r.Damage))
+	msg += formatEffects(r.Effects, "You are")
+	if r.TargetDefeated {
+		return msg + " You have been defeated!"
+	}
+	if r.TargetMaxHP > 0 {
+		msg += fmt.Sprintf(" (%d/%d HP)", r.TargetHP, r.TargetMaxHP)
+	}
+	return msg
+}
+
+// formatAttackForWitness renders an attack for bystanders
+func formatAttackForWitness(r *combat.AttackResult, attackerName, targetName string) string {
+	switch {
+	case r.Outcome == action.OutcomeMiss:
+		return fmt.Sprintf("%s misses %s.", attackerName, targetName)
+	case r.Fumble:
+		return fmt.Sprintf("%s fumbles an attack on %s.", attackerName, targetName)
+	case r.Critical:
+		return fmt.Sprintf("%s lands a crushing blow on %s!", attackerName, targetName)
+	default:
+		return fmt.Sprintf("%s hits %s.", attackerName, targetName)
+	}
+}
+
+// formatEffects describes status effects an attack applied, e.g. " They are stunned!"
+func formatEffects(effects []action.EffectType, subject string) string {
+	msg := ""
+	for _, effect := range effects {
+		switch effect {
+		case action.EffectStun:
+			msg += fmt.Sprintf(" %s stunned!", subject)
+		default:
+			msg += fmt.Sprintf(" %s afflicted with %s!", subject, effect)
+		}
+	}
+	return msg
+}
@@ -0,0 +1,128 @@
+package processor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/combat/action"
+	"tw-backend/internal/combat/damage"
+	"tw-backend/internal/game/services/combat"
+)
+
+// drainGameMessages decodes every game message waiting on a hub client
+func drainGameMessages(t *testing.T, c *websocket.Client) []websocket.GameMessageData {
+	t.Helper()
+	var out []websocket.GameMessageData
+	for len(c.Send) > 0 {
+		var msg struct {
+			Type string                    `json:"type"`
+			Data websocket.GameMessageData `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(<-c.Send, &msg))
+		out = append(out, msg.Data)
+	}
+	return out
+}
+
+func setupCombatants(t *testing.T) (*GameProcessor, *websocket.Client, *websocket.Client, *websocket.Client, *websocket.Client) {
+	t.Helper()
+	proc, _, _, _ := setupTest(t)
+	hub := websocket.NewHub(proc)
+	proc.SetHub(hub)
+
+	worldID := uuid.New()
+	attacker := addHubClient(hub, worldID, 0, 0)
+	attacker.Username = "Alice"
+	target := addHubClient(hub, worldID, 2, 0)
+	target.Username = "Bob"
+	witness := addHubClient(hub, worldID, 20, 0)
+	witness.Username = "Carol"
+	distant := addHubClient(hub, worldID, CombatBroadcastRadius+100, 0)
+	return proc, attacker, target, witness, distant
+}
+
+func TestDeliverAttackResult_RendersBothSides(t *testing.T) {
+	proc, attacker, target, witness, distant := setupCombatants(t)
+
+	proc.deliverAttackResult(&combat.AttackResult{
+		AttackerID:  attacker.CharacterID,
+		TargetID:    target.CharacterID,
+		Outcome:     action.OutcomeHit,
+		Damage:      12,
+		TargetHP:    38,
+		TargetMaxHP: 50,
+	})
+
+	attackerMsgs := drainGameMessages(t, attacker)
+	require.Len(t, attackerMsgs, 1)
+	assert.Equal(t, "combat", attackerMsgs[0].Type)
+	assert.Contains(t, attackerMsgs[0].Text, "You hit")
+	assert.Contains(t, attackerMsgs[0].Text, "Bob: 38/50 HP")
+	assert.NotNil(t, attackerMsgs[0].Metadata["result"], "Clients get the structured result too")
+
+	targetMsgs := drainGameMessages(t, target)
+	require.Len(t, targetMsgs, 1)
+	assert.Contains(t, targetMsgs[0].Text, "hits you for")
+	assert.Contains(t, targetMsgs[0].Text, "(38/50 HP)")
+
+	witnessMsgs := drainGameMessages(t, witness)
+	require.Len(t, witnessMsgs, 1)
+	assert.Equal(t, "Alice hits Bob.", witnessMsgs[0].Text)
+
+	assert.Empty(t, drainGameMessages(t, distant))
+}
+
+func TestDeliverAttackResult_KillingBlowNotifiesArea(t *testing.T) {
+	proc, attacker, target, witness, distant := setupCombatants(t)
+
+	proc.deliverAttackResult(&combat.AttackResult{
+		AttackerID:     attacker.CharacterID,
+		TargetID:       target.CharacterID,
+		Outcome:        action.OutcomeHit,
+		Critical:       true,
+		Damage:         40,
+		TargetHP:       0,
+		TargetMaxHP:    50,
+		TargetDefeated: true,
+	})
+
+	attackerMsgs := drainGameMessages(t, attacker)
+	require.Len(t, attackerMsgs, 2)
+	assert.Contains(t, attackerMsgs[0].Text, "You critically hit")
+	assert.Contains(t, attackerMsgs[0].Text, "is defeated!")
+
+	targetMsgs := drainGameMessages(t, target)
+	require.Len(t, targetMsgs, 2)
+	assert.Contains(t, targetMsgs[0].Text, "You have been defeated!")
+
+	witnessMsgs := drainGameMessages(t, witness)
+	require.Len(t, witnessMsgs, 2)
+	assert.Equal(t, "Alice lands a crushing blow on Bob!", witnessMsgs[0].Text)
+
+	// Everyone nearby sees the death announcement
+	for _, msgs := range [][]websocket.GameMessageData{attackerMsgs, targetMsgs, witnessMsgs} {
+		assert.Equal(t, "combat_death", msgs[1].Type)
+		assert.Equal(t, "Bob has been slain by Alice!", msgs[1].Text)
+	}
+	assert.Empty(t, drainGameMessages(t, distant))
+}
+
+func TestFormatAttack_Miss(t *testing.T) {
+	result := &combat.AttackResult{Outcome: action.OutcomeMiss}
+
+	assert.Contains(t, formatAttackForAttacker(result, "Bob"), "You miss")
+	assert.Contains(t, formatAttackForTarget(result, "Alice"), "misses you")
+	assert.Equal(t, "Alice misses Bob.", formatAttackForWitness(result, "Alice", "Bob"))
+}
+
+func TestFormatAttack_WeaponBreaks(t *testing.T) {
+	result := &combat.AttackResult{Outcome: action.OutcomeHit, Damage: 12, WeaponBroke: &damage.WeaponBroke{Name: "Rusty Dagger"}}
+
+	assert.Contains(t, formatAttackForAttacker(result, "Bob"), "Your Rusty Dagger breaks!")
+	assert.NotContains(t, formatAttackForTarget(result, "Alice"), "breaks")
+}
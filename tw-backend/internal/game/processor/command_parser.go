@@ -1,11 +1,17 @@
 package processor
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/game/services/look"
+	gamemap "tw-backend/internal/game/services/map"
+
+	"github.com/google/uuid"
 )
 
 // CommandParser parses raw text commands into structured CommandData
@@ -28,7 +34,8 @@ func NewCommandParser() *CommandParser {
 			"northwest": {"nw"},
 			"up":        {"u"},
 			"down":      {"d", "dn"},
-			"look":      {"l", "examine", "inspect", "view", "ex"},
+			"look":      {"l", "view"},
+			"examine":   {"ex", "inspect", "x"},
 			"say":       {"speak"},
 			"whisper":   {"psst"},
 			"tell":      {"message", "msg", "pm"},
@@ -41,11 +48,14 @@ func NewCommandParser() *CommandParser {
 			"inventory": {"inv", "i", "items", "bag"},
 			"craft":     {"make", "build", "forge"},
 			"use":       {"consume", "activate", "apply"},
+			"equip":     {"wield", "wear"},
+			"unequip":   {"remove", "unwield"},
 			"reply":     {"r"},
 			"lobby":     {"exit", "leave", "hub"},
 			"create":    nil,
 			"weather":   {"climate", "forecast"},
 			"ecosystem": {"eco"},
+			"fossils":   {"fossil"},
 			"world":     nil,
 			"fly":       nil,
 		},
@@ -118,7 +128,22 @@ func (p *CommandParser) ParseText(text string) *websocket.CommandData {
 			cmd.Target = &target
 		}
 
-	case "look", "get", "push", "drop", "attack", "talk", "craft", "use", "open", "face":
+	case "look", "examine":
+		// Format: look [closely] [target], examine [target]
+		// Examining, or looking closely, asks for a more detailed look
+		if action == "examine" || (len(args) > 0 && strings.EqualFold(args[0], "closely")) {
+			if action == "look" {
+				args = args[1:]
+			}
+			level := look.DetailDetailed
+			cmd.DetailLevel = &level
+		}
+		if len(args) > 0 {
+			target := p.cleanTarget(strings.Join(args, " "))
+			cmd.Target = &target
+		}
+
+	case "get", "push", "drop", "attack", "talk", "craft", "use", "equip", "unequip", "open", "face":
 		// Format: <action> <target>
 		// Join all args as target (handles multi-word targets like "iron sword")
 		if len(args) > 0 {
@@ -179,6 +204,13 @@ func (p *CommandParser) ParseText(text string) *websocket.CommandData {
 			cmd.Target = &target
 		}
 
+	case "fossils":
+		// Format: fossils [--cause <cause>] [--from <year>] [--to <year>] [--biome <id>]
+		if len(args) > 0 {
+			target := strings.Join(args, " ")
+			cmd.Target = &target
+		}
+
 	case "fly":
 		// Format: fly <height>
 		if len(args) >= 1 {
@@ -237,6 +269,15 @@ type SimulationConfig struct {
 	SimulateGeology  bool
 	SimulateLife     bool
 	SimulateDiseases bool
+	Fresh            bool // Discard existing geology and restart from year 0
+	ProgressSteps    int  // Number of progress reports per run (0 = default)
+	Atmosphere       string
+	CO2              *float64 // Starting CO2 in atm (nil = preset)
+	N2               *float64 // Starting N2 in atm (nil = preset)
+	O2               *float64 // Starting O2 in atm (nil = preset)
+	Greenhouse       float64  // °C per CO2 doubling (0 = default)
+	MutationPulse    bool     // Revive stagnant species with a variance boost
+	Verbosity        ecosystem.LogLevel
 }
 
 // ParseSimulationArgs parses simulation command arguments into a config struct.
@@ -248,6 +289,13 @@ type SimulationConfig struct {
 //   - --water-level <value>: Set water level (e.g., "high", "90%")
 //   - --epoch <name>: Label the epoch (e.g., "Jurassic")
 //   - --goal <name>: Set simulation goal (e.g., "sapience")
+//   - --fresh: Restart from year 0 instead of continuing an existing world
+//   - --progress-steps <n>: Report progress n times per run (default 10)
+//   - --atmosphere <preset>: Starting atmosphere (earth, venus, mars)
+//   - --co2 <atm>, --n2 <atm>, --o2 <atm>: Override the preset's starting gases
+//   - --greenhouse <°C>: Greenhouse sensitivity per CO2 doubling (default 3)
+//   - --mutation-pulse: Boost variance of species stuck in evolutionary stagnation
+//   - --verbosity <level>: quiet, info (default), verbose, or debug
 func ParseSimulationArgs(argsStr string) *SimulationConfig {
 	argsStr = strings.TrimSpace(argsStr)
 	if argsStr == "" {
@@ -278,6 +326,7 @@ func ParseSimulationArgs(argsStr string) *SimulationConfig {
 		SimulateGeology:  true,
 		SimulateLife:     true,
 		SimulateDiseases: true,
+		Verbosity:        ecosystem.LogLevelInfo,
 	}
 
 	// Parse flags (remaining arguments)
@@ -315,6 +364,61 @@ func ParseSimulationArgs(argsStr string) *SimulationConfig {
 				i++
 				config.Goal = parts[i]
 			}
+
+		case "--fresh":
+			config.Fresh = true
+
+		case "--progress-steps":
+			if i+1 < len(parts) {
+				i++
+				if steps, err := strconv.Atoi(parts[i]); err == nil && steps > 0 {
+					config.ProgressSteps = steps
+				}
+			}
+
+		case "--atmosphere":
+			if i+1 < len(parts) {
+				i++
+				config.Atmosphere = parts[i]
+			}
+
+		case "--co2", "--n2", "--o2":
+			if i+1 < len(parts) {
+				i++
+				mass, err := strconv.ParseFloat(parts[i], 64)
+				if err != nil || mass < 0 {
+					continue
+				}
+				switch arg {
+				case "--co2":
+					config.CO2 = &mass
+				case "--n2":
+					config.N2 = &mass
+				case "--o2":
+					config.O2 = &mass
+				}
+			}
+
+		case "--greenhouse":
+			if i+1 < len(parts) {
+				i++
+				if sensitivity, err := strconv.ParseFloat(parts[i], 64); err == nil && sensitivity > 0 {
+					config.Greenhouse = sensitivity
+				}
+			}
+
+		case "--mutation-pulse":
+			config.MutationPulse = true
+
+		case "--verbosity":
+			if i+1 < len(parts) {
+				i++
+				level, err := ecosystem.ParseVerbosity(parts[i])
+				if err != nil {
+					return nil
+				}
+				config.Verbosity = level
+			}
 		}
 	}
 
@@ -330,3 +434,84 @@ func parseYears(s string) (int64, error) {
 	}
 	return years, nil
 }
+
+// ParseFossilArgs parses fossils command arguments into a fossil record filter.
+// Input format: "[--key value]..."
+// Supported flags:
+//   - --cause <cause>: Extinction cause (e.g., "population_collapse")
+//   - --from <year>: Only species still alive at or after this year
+//   - --to <year>: Only species that appeared at or before this year
+//   - --biome <id>: Only species with fossils in this biome
+//
+// Returns an error for unknown flags or malformed values.
+func ParseFossilArgs(argsStr string) (ecosystem.FossilFilter, error) {
+	var filter ecosystem.FossilFilter
+	parts := strings.Fields(argsStr)
+
+	for i := 0; i < len(parts); i++ {
+		flag := parts[i]
+		if i+1 >= len(parts) {
+			return filter, fmt.Errorf("%s needs a value", flag)
+		}
+		i++
+		value := parts[i]
+
+		switch flag {
+		case "--cause":
+			filter.Cause = strings.ToLower(value)
+		case "--from", "--to":
+			year, err := parseYears(value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid year for %s: %s", flag, value)
+			}
+			if flag == "--from" {
+				filter.FromYear = &year
+			} else {
+				filter.ToYear = &year
+			}
+		case "--biome":
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid biome id: %s", value)
+			}
+			filter.BiomeID = &id
+		default:
+			return filter, fmt.Errorf("unknown flag: %s", flag)
+		}
+	}
+
+	return filter, nil
+}
+
+// ParseWorldMapArgs parses world map command arguments into aggregation options.
+// Input format: "[--key value]..."
+// Supported flags:
+//   - --biome <mode>: dominant (default), mode, or center
+//   - --elevation <mode>: center (default), mean, min, or max
+func ParseWorldMapArgs(argsStr string) (gamemap.WorldMapOptions, error) {
+	opts := gamemap.DefaultWorldMapOptions()
+	parts := strings.Fields(argsStr)
+
+	for i := 0; i < len(parts); i++ {
+		flag := parts[i]
+		if i+1 >= len(parts) {
+			return opts, fmt.Errorf("%s needs a value", flag)
+		}
+		i++
+
+		var err error
+		switch flag {
+		case "--biome":
+			opts.Biome, err = gamemap.ParseBiomeAggregation(parts[i])
+		case "--elevation":
+			opts.Elevation, err = gamemap.ParseElevationAggregation(parts[i])
+		default:
+			err = fmt.Errorf("unknown flag: %s", flag)
+		}
+		if err != nil {
+			return opts, err
+		}
+	}
+
+	return opts, nil
+}
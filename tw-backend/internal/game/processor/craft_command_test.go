@@ -0,0 +1,222 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/economy/crafting"
+	apperrors "tw-backend/internal/errors"
+	"tw-backend/internal/game/services/entity"
+	"tw-backend/internal/game/services/inventory"
+	"tw-backend/internal/skills"
+	"tw-backend/internal/worldentity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCraftingRepo knows a single recipe
+type fakeCraftingRepo struct {
+	MockCraftingRepo
+	recipe *crafting.Recipe
+}
+
+func (r *fakeCraftingRepo) GetRecipe(recipeID uuid.UUID) (*crafting.Recipe, error) {
+	return r.recipe, nil
+}
+
+func (r *fakeCraftingRepo) SearchRecipes(query string, filters crafting.RecipeFilters) ([]*crafting.Recipe, error) {
+	if query == r.recipe.Name {
+		return []*crafting.Recipe{r.recipe}, nil
+	}
+	return nil, nil
+}
+
+// recordingInventoryRepo holds a fixed set of items and records what is
+// taken from and added to it
+type recordingInventoryRepo struct {
+	fakeInventoryRepo
+	removed map[uuid.UUID]int
+	added   map[uuid.UUID]map[string]interface{}
+}
+
+func (r *recordingInventoryRepo) RemoveItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int) error {
+	r.removed[itemID] += quantity
+	return nil
+}
+
+func (r *recordingInventoryRepo) AddItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int, metadata map[string]interface{}) error {
+	r.added[itemID] = metadata
+	return nil
+}
+
+// fakeSkillsRepo stores skills' XP
+type fakeSkillsRepo struct {
+	skills []skills.Skill
+}
+
+func (r *fakeSkillsRepo) GetSkills(ctx context.Context, characterID uuid.UUID) ([]skills.Skill, error) {
+	return r.skills, nil
+}
+
+func (r *fakeSkillsRepo) UpdateSkill(ctx context.Context, characterID uuid.UUID, skillName string, xp float64) error {
+	for i := range r.skills {
+		if r.skills[i].Name == skillName {
+			r.skills[i].XP = xp
+			return nil
+		}
+	}
+	r.skills = append(r.skills, skills.Skill{Name: skillName, XP: xp})
+	return nil
+}
+
+// xp returns the stored XP in a skill
+func (r *fakeSkillsRepo) xp(skillName string) float64 {
+	for _, s := range r.skills {
+		if s.Name == skillName {
+			return s.XP
+		}
+	}
+	return 0
+}
+
+// xpForLevel is the total XP needed to reach a skill level, with a little
+// to spare so rounding can't leave it a level short
+func xpForLevel(level int) float64 {
+	xp := 0.5
+	for l := 1; l <= level; l++ {
+		xp += skills.CalculateXPNeeded(l)
+	}
+	return xp
+}
+
+var (
+	ironIngot = uuid.New()
+	leather   = uuid.New()
+	hide      = uuid.New()
+)
+
+func swordRecipe() *crafting.Recipe {
+	return &crafting.Recipe{
+		RecipeID: uuid.New(),
+		Name:     "iron sword",
+		Ingredients: []crafting.Ingredient{
+			{ResourceID: ironIngot, Quantity: 3},
+			{ResourceID: leather, Quantity: 1, Substitute: []uuid.UUID{hide}},
+		},
+		Output:          crafting.ItemOutput{ItemID: uuid.New(), Quantity: 1},
+		RequiredStation: &crafting.CraftingStation{StationType: "forge", MinStationTier: 1},
+		RequiredSkill:   "smithing",
+	}
+}
+
+func forge() *worldentity.WorldEntity {
+	return &worldentity.WorldEntity{
+		ID:         uuid.New(),
+		Name:       "forge",
+		EntityType: worldentity.EntityTypeStatic,
+		Metadata:   map[string]interface{}{crafting.MetaStationType: "forge"},
+	}
+}
+
+// setupCraftTest gives the character the items, places the entities beside
+// them and teaches the recipe
+func setupCraftTest(t *testing.T, recipe *crafting.Recipe, items []inventory.InventoryItem, entities ...*worldentity.WorldEntity) (*GameProcessor, *mockClient, *recordingInventoryRepo) {
+	t.Helper()
+	proc, client, _ := setupOpenTest(t, nil, entities...)
+
+	inv := &recordingInventoryRepo{
+		fakeInventoryRepo: fakeInventoryRepo{items: items},
+		removed:           make(map[uuid.UUID]int),
+		added:             make(map[uuid.UUID]map[string]interface{}),
+	}
+	proc.inventoryService = inventory.NewService(entity.NewService(), inv)
+	proc.craftingService = crafting.NewService(&fakeCraftingRepo{recipe: recipe}, proc.inventoryService, proc.worldEntityService)
+	return proc, client, inv
+}
+
+func craftCommand(target string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "craft", Target: &target}
+}
+
+func TestHandleCraft_ConsumesIngredients(t *testing.T) {
+	recipe := swordRecipe()
+	proc, client, inv := setupCraftTest(t, recipe, []inventory.InventoryItem{
+		{ItemID: ironIngot, Quantity: 2},
+		{ItemID: ironIngot, Quantity: 2},
+		{ItemID: hide, Quantity: 1},
+	}, forge())
+	proc.skillsRepo = &fakeSkillsRepo{skills: []skills.Skill{{Name: skills.SkillSmithing, XP: xpForLevel(45)}}}
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, craftCommand("iron sword")))
+
+	require.Len(t, client.messages, 1)
+	assert.Equal(t, "crafting_success", client.messages[0].Type)
+	assert.Contains(t, client.messages[0].Text, "crafted iron sword x1")
+	assert.Equal(t, crafting.QualityGood, client.messages[0].Metadata["quality"])
+
+	// Ingot stacks are pooled, and hide stands in for leather
+	assert.Equal(t, map[uuid.UUID]int{ironIngot: 3, hide: 1}, inv.removed)
+	require.Contains(t, inv.added, recipe.Output.ItemID)
+	assert.Equal(t, crafting.QualityGood, inv.added[recipe.Output.ItemID]["quality"])
+}
+
+func TestHandleCraft_MissingIngredients(t *testing.T) {
+	proc, client, inv := setupCraftTest(t, swordRecipe(), []inventory.InventoryItem{
+		{ItemID: ironIngot, Quantity: 3},
+	}, forge())
+
+	err := proc.ProcessCommand(context.Background(), client, craftCommand("iron sword"))
+
+	require.ErrorIs(t, err, apperrors.ErrMissingIngredients)
+	assert.Contains(t, err.Error(), "don't have the ingredients")
+	assert.Empty(t, inv.removed, "nothing is consumed by a failed craft")
+	assert.Empty(t, inv.added)
+	assert.Empty(t, client.messages)
+}
+
+func TestHandleCraft_NoRoomKeepsIngredients(t *testing.T) {
+	items := []inventory.InventoryItem{
+		{ItemID: ironIngot, Quantity: 3},
+		{ItemID: leather, Quantity: 1},
+	}
+	for len(items) < inventory.MaxSlots {
+		items = append(items, inventory.InventoryItem{ItemID: uuid.New(), Quantity: 1})
+	}
+	proc, client, inv := setupCraftTest(t, swordRecipe(), items, forge())
+
+	err := proc.ProcessCommand(context.Background(), client, craftCommand("iron sword"))
+
+	require.ErrorIs(t, err, apperrors.ErrInventoryFull)
+	assert.Empty(t, inv.removed, "nothing is consumed when the output can't be carried")
+	assert.Empty(t, inv.added)
+	assert.Empty(t, client.messages)
+}
+
+func TestHandleCraft_MissingStation(t *testing.T) {
+	anvil := forge()
+	anvil.Name = "anvil"
+	anvil.Metadata[crafting.MetaStationType] = "anvil"
+	proc, client, inv := setupCraftTest(t, swordRecipe(), []inventory.InventoryItem{
+		{ItemID: ironIngot, Quantity: 3},
+		{ItemID: leather, Quantity: 1},
+	}, anvil)
+
+	err := proc.ProcessCommand(context.Background(), client, craftCommand("iron sword"))
+
+	require.ErrorIs(t, err, apperrors.ErrCraftingStationNeeded)
+	assert.Contains(t, err.Error(), "need a forge nearby")
+	assert.Empty(t, inv.removed)
+	assert.Empty(t, client.messages)
+}
+
+func TestHandleCraft_UnknownRecipe(t *testing.T) {
+	proc, client, _ := setupCraftTest(t, swordRecipe(), nil)
+
+	err := proc.ProcessCommand(context.Background(), client, craftCommand("airship"))
+
+	require.ErrorIs(t, err, apperrors.ErrRecipeNotFound)
+	assert.Contains(t, err.Error(), "don't know how to craft 'airship'")
+}
@@ -12,7 +12,10 @@ import (
 	"tw-backend/cmd/game-server/websocket"
 	"tw-backend/internal/auth"
 	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
 	"tw-backend/internal/ecosystem/state"
+	"tw-backend/internal/game/services/look"
+	"tw-backend/internal/worldgen/geography"
 )
 
 func TestHandleEcosystem_Status(t *testing.T) {
@@ -305,3 +308,100 @@ func TestHandleEcosystem_Lineage(t *testing.T) {
 	assert.Contains(t, lastMsg.Text, "Parent 1:")
 	assert.Contains(t, lastMsg.Text, "Parent 2:")
 }
+
+func TestHandleEcosystem_Diagnose(t *testing.T) {
+	// Setup
+	mockAuthRepo := auth.NewMockRepository()
+	mockWorldRepo := NewMockWorldRepository()
+	ecoSvc := ecosystem.NewService(time.Now().Unix())
+
+	proc := NewGameProcessor(mockAuthRepo, mockWorldRepo, nil, nil, nil, nil, nil, nil, nil, nil, ecoSvc, nil, nil, nil, nil, nil, nil)
+
+	charID := uuid.New()
+	userID := uuid.New()
+	worldID := uuid.New()
+	mockAuthRepo.CreateCharacter(context.Background(), &auth.Character{
+		CharacterID: charID,
+		UserID:      userID,
+		WorldID:     worldID,
+	})
+
+	// A running world with one starving herbivore
+	runner := ecosystem.NewSimulationRunner(ecosystem.DefaultConfig(worldID), nil, nil)
+	runner.InitializePopulationSimulator(1)
+	sim := runner.GetPopulationSimulator()
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.AddSpecies(&population.SpeciesPopulation{SpeciesID: uuid.New(), Name: "Woolly Grazer", Count: 1000,
+		Diet: population.DietHerbivore, Traits: population.DefaultTraitsForDiet(population.DietHerbivore)})
+	sim.Biomes[biome.BiomeID] = biome
+	sim.SimulateYear()
+	proc.worldRunners = map[uuid.UUID]*ecosystem.SimulationRunner{worldID: runner}
+
+	client := &mockClient{
+		UserID:      userID,
+		CharacterID: charID,
+	}
+
+	err := proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Text: "ecosystem diagnose woolly"})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, client.messages)
+	lastMsg := client.messages[len(client.messages)-1]
+	assert.Contains(t, lastMsg.Text, "Diagnosis: Woolly Grazer")
+	assert.Contains(t, lastMsg.Text, "food availability")
+
+	// Unknown species
+	err = proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Text: "ecosystem diagnose unicorn"})
+	require.NoError(t, err)
+	lastMsg = client.messages[len(client.messages)-1]
+	assert.Contains(t, lastMsg.Text, "Species not found")
+}
+
+func TestHandleEcosystem_SpawnSimulatedSpecies(t *testing.T) {
+	mockAuthRepo := auth.NewMockRepository()
+	ecoSvc := ecosystem.NewService(time.Now().Unix())
+	lookSvc := look.NewLookService(nil, nil, nil, nil, nil, nil, ecoSvc)
+	proc := NewGameProcessor(mockAuthRepo, NewMockWorldRepository(), nil, lookSvc, nil, nil, nil, nil, nil, nil, ecoSvc, nil, nil, nil, nil, nil, nil)
+
+	charID, userID, worldID := uuid.New(), uuid.New(), uuid.New()
+	mockAuthRepo.CreateCharacter(context.Background(), &auth.Character{
+		CharacterID: charID,
+		UserID:      userID,
+		WorldID:     worldID,
+		PositionX:   5,
+		PositionY:   5,
+	})
+
+	// A running world whose grazer has evolved a shell
+	runner := ecosystem.NewSimulationRunner(ecosystem.DefaultConfig(worldID), nil, nil)
+	runner.InitializePopulationSimulator(1)
+	traits := population.DefaultTraitsForDiet(population.DietHerbivore)
+	traits.Size, traits.Covering = 9.0, population.CoveringShell
+	record := &population.SpeciesPopulation{SpeciesID: uuid.New(), Name: "Shellback", Count: 1000,
+		Diet: population.DietHerbivore, Traits: traits}
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.AddSpecies(record)
+	runner.GetPopulationSimulator().Biomes[biome.BiomeID] = biome
+	proc.worldRunners = map[uuid.UUID]*ecosystem.SimulationRunner{worldID: runner}
+
+	client := &mockClient{UserID: userID, CharacterID: charID}
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Text: "ecosystem spawn shellback"}))
+	require.NotEmpty(t, client.messages)
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "Spawned Shellback")
+
+	var spawned *state.LivingEntityState
+	for _, e := range ecoSvc.Entities {
+		spawned = e
+	}
+	require.NotNil(t, spawned)
+	require.NotNil(t, spawned.SpeciesID)
+	assert.Equal(t, record.SpeciesID, *spawned.SpeciesID)
+	assert.Equal(t, worldID, spawned.WorldID)
+
+	// Looking at it reads the simulated species' traits
+	char, err := mockAuthRepo.GetCharacter(context.Background(), charID)
+	require.NoError(t, err)
+	desc, err := lookSvc.DescribeEntity(context.Background(), char, "Shellback")
+	require.NoError(t, err)
+	assert.Contains(t, desc, ecosystem.DescribeSimulatedSpecies(record))
+}
@@ -3,12 +3,17 @@ package processor
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
+	"sort"
 	"strings"
 	"time"
 	"tw-backend/cmd/game-server/websocket"
 	"tw-backend/internal/ai/behaviortree"
+	"tw-backend/internal/ecosystem/population"
 	"tw-backend/internal/ecosystem/state"
+
+	"github.com/google/uuid"
 )
 
 // handleEcosystem handles ecosystem debug and interaction commands
@@ -51,8 +56,15 @@ func (p *GameProcessor) handleEcosystem(ctx context.Context, client websocket.Ga
 			return nil
 		}
 		return p.handleEcosystemBreed(ctx, client, *cmd.Message)
+	case "diagnose":
+		// Example: ecosystem diagnose woolly grazer
+		if cmd.Message == nil {
+			client.SendGameMessage("error", "Usage: ecosystem diagnose <species name or id>", nil)
+			return nil
+		}
+		return p.handleEcosystemDiagnose(ctx, client, *cmd.Message)
 	default:
-		client.SendGameMessage("error", "Unknown ecosystem command. Try 'status', 'spawn', 'log', 'lineage', or 'breed'.", nil)
+		client.SendGameMessage("error", "Unknown ecosystem command. Try 'status', 'spawn', 'log', 'lineage', 'breed', or 'diagnose'.", nil)
 		return nil
 	}
 }
@@ -84,6 +96,7 @@ func (p *GameProcessor) handleEcosystemSpawn(ctx context.Context, client websock
 
 	// Validate Species
 	var sp state.Species
+	var ent *state.LivingEntityState
 	switch strings.ToLower(speciesStr) {
 	case "rabbit":
 		sp = state.SpeciesRabbit
@@ -111,12 +124,19 @@ func (p *GameProcessor) handleEcosystemSpawn(ctx context.Context, client websock
 	case "charnia":
 		sp = state.SpeciesCharnia
 	default:
-		client.SendGameMessage("error", fmt.Sprintf("Unknown species '%s'. Try: precambrian, cyanobacteria, stromatolite, ediacaran, dickinsonia, charnia, rabbit, wolf, deer", speciesStr), nil)
-		return nil
+		// Not built in: a species the world's population has evolved
+		record := p.simulatedSpeciesNamed(ctx, client.GetCharacterID(), speciesStr)
+		if record == nil {
+			client.SendGameMessage("error", fmt.Sprintf("Unknown species '%s'. Try: precambrian, cyanobacteria, stromatolite, ediacaran, dickinsonia, charnia, rabbit, wolf, deer, or a simulated species' name", speciesStr), nil)
+			return nil
+		}
+		ent = p.ecosystemService.Spawner.CreateFromSpecies(record)
 	}
 
 	// Create entity
-	ent := p.ecosystemService.Spawner.CreateEntity(sp, 1)
+	if ent == nil {
+		ent = p.ecosystemService.Spawner.CreateEntity(sp, 1)
+	}
 
 	// Assign location from player
 	char, err := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
@@ -137,7 +157,7 @@ func (p *GameProcessor) handleEcosystemSpawn(ctx context.Context, client websock
 		p.ecosystemService.Behaviors[ent.EntityID] = behaviortree.NewHerbivoreTree()
 	}
 
-	client.SendGameMessage("system", fmt.Sprintf("Spawned %s at your location.", sp), nil)
+	client.SendGameMessage("system", fmt.Sprintf("Spawned %s at your location.", ent.Species), nil)
 	return nil
 }
 
@@ -351,3 +371,126 @@ func (p *GameProcessor) handleEcosystemSpawnPrecambrian(ctx context.Context, cli
 	client.SendGameMessage("system", sb.String(), nil)
 	return nil
 }
+
+// handleEcosystemDiagnose explains why a simulated species is growing or
+// shrinking in each biome it lives in
+func (p *GameProcessor) handleEcosystemDiagnose(ctx context.Context, client websocket.GameClient, query string) error {
+	char, err := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	if err != nil || char == nil {
+		client.SendGameMessage("error", "Could not get character info", nil)
+		return nil
+	}
+
+	// Diagnostics aren't part of a snapshot copy, so read the live simulator
+	// under the runner's lock
+	var report string
+	found := false
+	ok, err := p.viewPopulation(ctx, char.WorldID, func(sim *population.PopulationSimulator) {
+		speciesID, name, ok := findSimulatedSpecies(sim, query)
+		if !ok {
+			return
+		}
+		found = true
+
+		// Stable biome order so repeated diagnoses read the same
+		biomes := make([]*population.BiomePopulation, 0)
+		for _, biome := range sim.Biomes {
+			if _, ok := biome.Species[speciesID]; ok {
+				biomes = append(biomes, biome)
+			}
+		}
+		sort.Slice(biomes, func(i, j int) bool {
+			return biomes[i].BiomeID.String() < biomes[j].BiomeID.String()
+		})
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== Diagnosis: %s (year %d) ===\n", name, sim.CurrentYear))
+		for _, biome := range biomes {
+			sb.WriteString(formatSpeciesDiagnosis(biome, biome.DiagnoseSpecies(speciesID)))
+		}
+		report = sb.String()
+	})
+	if err != nil || !ok {
+		client.SendGameMessage("error", "No population simulation for this world. Run 'world simulate' or 'world run' first.", nil)
+		return nil
+	}
+	if !found {
+		client.SendGameMessage("error", fmt.Sprintf("Species not found: %s", query), nil)
+		return nil
+	}
+
+	client.SendGameMessage("system", report, nil)
+	return nil
+}
+
+// simulatedSpeciesNamed finds a simulated species of the character's world by
+// name or ID (see findSimulatedSpecies), returning a copy of its record
+func (p *GameProcessor) simulatedSpeciesNamed(ctx context.Context, charID uuid.UUID, query string) *population.SpeciesPopulation {
+	char, err := p.authRepo.GetCharacter(ctx, charID)
+	if err != nil || char == nil {
+		return nil
+	}
+	var record *population.SpeciesPopulation
+	if _, err := p.viewPopulation(ctx, char.WorldID, func(sim *population.PopulationSimulator) {
+		if id, _, ok := findSimulatedSpecies(sim, query); ok {
+			record = speciesRecord(sim, id)
+		}
+	}); err != nil {
+		return nil
+	}
+	return record
+}
+
+// findSimulatedSpecies matches a species by ID prefix, exact name, or name
+// substring (case-insensitive), in that order of preference
+func findSimulatedSpecies(sim *population.PopulationSimulator, query string) (uuid.UUID, string, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var partialID uuid.UUID
+	var partialName string
+	for _, biome := range sim.Biomes {
+		for id, sp := range biome.Species {
+			name := strings.ToLower(sp.Name)
+			if name == query || strings.HasPrefix(id.String(), query) {
+				return id, sp.Name, true
+			}
+			if partialName == "" && strings.Contains(name, query) {
+				partialID, partialName = id, sp.Name
+			}
+		}
+	}
+	return partialID, partialName, partialName != ""
+}
+
+// formatSpeciesDiagnosis renders one biome's diagnosis, largest factor first
+func formatSpeciesDiagnosis(biome *population.BiomePopulation, d population.SpeciesDiagnosis) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n[%s %s] ", biome.BiomeType, biome.BiomeID.String()[:8]))
+	if !d.Available() {
+		sb.WriteString(fmt.Sprintf("%d individuals, not simulated yet\n", d.EndCount))
+		return sb.String()
+	}
+
+	trend := "stable"
+	switch {
+	case d.EndCount > d.StartCount:
+		trend = "thriving"
+	case d.EndCount < d.StartCount:
+		trend = "declining"
+	}
+	sb.WriteString(fmt.Sprintf("%d → %d (%s)\n", d.StartCount, d.EndCount, trend))
+	sb.WriteString(fmt.Sprintf("  Food availability: %.0f%%, seasonal modifier: ×%.2f\n", d.FoodAvailability*100, d.SeasonalModifier))
+
+	for _, f := range d.Factors {
+		sign := "+"
+		if f.Impact < 0 {
+			sign = "-"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %-18s %8.0f (%2.0f%%)\n", sign, strings.ReplaceAll(string(f.Kind), "_", " "), math.Abs(f.Impact), f.Share*100))
+	}
+	if f, ok := d.DominantNegative(); ok && d.EndCount < d.StartCount {
+		sb.WriteString(fmt.Sprintf("  Main pressure: %s\n", strings.ReplaceAll(string(f.Kind), "_", " ")))
+	} else if f, ok := d.DominantPositive(); ok && d.EndCount > d.StartCount {
+		sb.WriteString(fmt.Sprintf("  Main driver: %s\n", strings.ReplaceAll(string(f.Kind), "_", " ")))
+	}
+	return sb.String()
+}
@@ -0,0 +1,112 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/character"
+	"tw-backend/internal/combat/damage"
+	apperrors "tw-backend/internal/errors"
+	"tw-backend/internal/game/services/combat"
+	"tw-backend/internal/game/services/inventory"
+	"tw-backend/internal/skills"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupEquipTest gives a character with the given Slashing skill a sword
+// that needs 20 of it
+func setupEquipTest(t *testing.T, slashing int) (*GameProcessor, *mockClient) {
+	t.Helper()
+	sword := inventory.InventoryItem{ItemID: uuid.New(), Name: "Iron Sword", Quantity: 1, Metadata: map[string]interface{}{
+		inventory.MetaWeaponType:    string(damage.WeaponSlashing),
+		inventory.MetaDamage:        20,
+		inventory.MetaSkillRequired: 20,
+	}}
+	proc, client, _ := setupOpenTest(t, []inventory.InventoryItem{sword})
+	proc.inventoryService.SetSkillLookup(proc.skillLevel)
+	proc.skillsRepo = &fakeSkillsRepo{skills: []skills.Skill{{Name: skills.SkillSlashing, XP: xpForLevel(slashing)}}}
+	return proc, client
+}
+
+func TestParseText_Equip(t *testing.T) {
+	parser := NewCommandParser()
+
+	cmd := parser.ParseText("wield the iron sword")
+	require.NotNil(t, cmd)
+	assert.Equal(t, "equip", cmd.Action)
+	require.NotNil(t, cmd.Target)
+	assert.Equal(t, "iron sword", *cmd.Target)
+
+	cmd = parser.ParseText("remove iron sword")
+	require.NotNil(t, cmd)
+	assert.Equal(t, "unequip", cmd.Action)
+}
+
+func TestHandleEquip_WieldsWeapon(t *testing.T) {
+	proc, client := setupEquipTest(t, 30)
+	target := "iron sword"
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "equip", Target: &target}))
+
+	require.NotEmpty(t, client.messages)
+	assert.Equal(t, "You wield the Iron Sword.", client.messages[0].Text)
+	equipment, err := proc.inventoryService.Equipped(context.Background(), client.GetCharacterID())
+	require.NoError(t, err)
+	require.NotNil(t, equipment.Weapon())
+	assert.Equal(t, 20, equipment.Weapon().BaseDamage)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "unequip", Target: &target}))
+	equipment, err = proc.inventoryService.Equipped(context.Background(), client.GetCharacterID())
+	require.NoError(t, err)
+	assert.Nil(t, equipment.Weapon())
+}
+
+func TestHandleEquip_UnderSkilled(t *testing.T) {
+	proc, client := setupEquipTest(t, 10)
+	target := "iron sword"
+
+	err := proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "equip", Target: &target})
+
+	assert.ErrorIs(t, err, apperrors.ErrInsufficientSkill)
+	assert.Equal(t, "You need 20 Slashing to use the Iron Sword.", err.Error())
+}
+
+func TestRefreshEquipment_KeepsWeaponWear(t *testing.T) {
+	proc, client := setupEquipTest(t, 30)
+	ctx := context.Background()
+	charID := client.GetCharacterID()
+	target := "iron sword"
+	require.NoError(t, proc.ProcessCommand(ctx, client, &websocket.CommandData{Action: "equip", Target: &target}))
+
+	proc.combatService.JoinCombatFromCharacter(&character.Character{
+		ID:       charID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	proc.refreshEquipment(ctx, charID)
+	weapon := proc.combatService.Combatant(charID).Weapon
+	require.NotNil(t, weapon)
+
+	// A hit wore the sword down
+	proc.recordWeaponWear(&combat.AttackResult{AttackerID: charID, WeaponID: weapon.WeaponID, WeaponDurability: weapon.MaxDurability - 3})
+
+	// Re-arming for the next attack keeps the wear
+	proc.refreshEquipment(ctx, charID)
+	rearmed := proc.combatService.Combatant(charID).Weapon
+	assert.Equal(t, weapon.MaxDurability-3, rearmed.Durability)
+	assert.Equal(t, weapon.MaxDurability, rearmed.MaxDurability)
+}
+
+func TestHandleEquip_NoInventoryService(t *testing.T) {
+	proc, client := setupEquipTest(t, 30)
+	proc.inventoryService = nil
+	target := "iron sword"
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "equip", Target: &target}))
+
+	require.NotEmpty(t, client.messages)
+	assert.Equal(t, "Inventory unavailable.", client.messages[0].Text)
+}
@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/errors"
+	"tw-backend/internal/game/services/combat"
+	"tw-backend/internal/game/services/inventory"
+
+	"github.com/google/uuid"
+)
+
+// handleEquip equips a carried weapon, armor or accessory
+func (p *GameProcessor) handleEquip(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
+	if cmd.Target == nil || *cmd.Target == "" {
+		return errors.NewInvalidInput("Equip what? (usage: equip <item>)")
+	}
+
+	if p.inventoryService == nil {
+		client.SendGameMessage("error", "Inventory unavailable.", nil)
+		return nil
+	}
+
+	item, err := p.inventoryService.Equip(ctx, client.GetCharacterID(), *cmd.Target)
+	if err != nil {
+		return err
+	}
+
+	verb := "put on"
+	if item.EquippedSlot == inventory.SlotWeapon || item.EquippedSlot == inventory.SlotOffhand {
+		verb = "wield"
+	}
+	client.SendGameMessage("action", fmt.Sprintf("You %s the %s.", verb, item.Name), map[string]interface{}{
+		"item_id": item.ItemID.String(),
+		"slot":    string(item.EquippedSlot),
+	})
+	p.refreshEquipment(ctx, client.GetCharacterID())
+	p.sendStateUpdate(client)
+	return nil
+}
+
+// handleUnequip returns an equipped item to the pack
+func (p *GameProcessor) handleUnequip(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
+	if cmd.Target == nil || *cmd.Target == "" {
+		return errors.NewInvalidInput("Unequip what? (usage: unequip <item>)")
+	}
+
+	if p.inventoryService == nil {
+		client.SendGameMessage("error", "Inventory unavailable.", nil)
+		return nil
+	}
+
+	item, err := p.inventoryService.Unequip(ctx, client.GetCharacterID(), *cmd.Target)
+	if err != nil {
+		return err
+	}
+
+	client.SendGameMessage("action", fmt.Sprintf("You put away the %s.", item.Name), map[string]interface{}{
+		"item_id": item.ItemID.String(),
+	})
+	p.refreshEquipment(ctx, client.GetCharacterID())
+	p.sendStateUpdate(client)
+	return nil
+}
+
+// refreshEquipment gives a character in combat the weapon, armor and bonuses
+// they have equipped. Characters not in combat are ignored.
+func (p *GameProcessor) refreshEquipment(ctx context.Context, charID uuid.UUID) {
+	if p.inventoryService == nil || p.combatService == nil {
+		return
+	}
+	equipment, err := p.inventoryService.Equipped(ctx, charID)
+	if err != nil {
+		return
+	}
+	p.combatService.SetEquipment(charID, equipment.Weapon(), equipment.Armor(), equipment.Bonuses())
+}
+
+// recordWeaponWear saves the durability a hit left a character's weapon
+// with, so the next refreshEquipment arms them with the worn weapon.
+// Best-effort: a failure is logged and combat carries on.
+func (p *GameProcessor) recordWeaponWear(result *combat.AttackResult) {
+	if p.inventoryService == nil || result == nil || result.WeaponID == uuid.Nil {
+		return
+	}
+	if err := p.inventoryService.RecordDurability(context.Background(), result.AttackerID, result.WeaponID, result.WeaponDurability); err != nil {
+		log.Printf("[COMBAT] failed to record wear on %s's weapon: %v", result.AttackerID, err)
+	}
+}
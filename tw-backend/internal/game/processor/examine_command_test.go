@@ -0,0 +1,123 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/game/services/look"
+	"tw-backend/internal/skills"
+	"tw-backend/internal/worldentity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseText_Examine(t *testing.T) {
+	parser := NewCommandParser()
+
+	cmd := parser.ParseText("examine the statue")
+	require.NotNil(t, cmd)
+	assert.Equal(t, "examine", cmd.Action)
+	require.NotNil(t, cmd.Target)
+	assert.Equal(t, "statue", *cmd.Target)
+	require.NotNil(t, cmd.DetailLevel)
+	assert.Equal(t, look.DetailDetailed, *cmd.DetailLevel)
+
+	cmd = parser.ParseText("look closely at the statue")
+	require.NotNil(t, cmd)
+	assert.Equal(t, "look", cmd.Action)
+	require.NotNil(t, cmd.Target)
+	assert.Equal(t, "statue", *cmd.Target)
+	require.NotNil(t, cmd.DetailLevel)
+	assert.Equal(t, look.DetailDetailed, *cmd.DetailLevel)
+
+	cmd = parser.ParseText("look statue")
+	require.NotNil(t, cmd)
+	assert.Nil(t, cmd.DetailLevel, "a plain look is basic")
+}
+
+// setupExamineTest stands a statue with a secret a few metres from the
+// character, out of arm's reach
+func setupExamineTest(t *testing.T, perception int) (*GameProcessor, *mockClient) {
+	t.Helper()
+	statue := &worldentity.WorldEntity{
+		ID:          uuid.New(),
+		Name:        "statue",
+		EntityType:  worldentity.EntityTypeStatic,
+		Description: "A weathered stone statue.",
+		Details:     "Its plinth bears a half-worn inscription.",
+		Metadata: map[string]interface{}{
+			look.MetaSecret:       "A seam in the plinth hints at a hidden compartment.",
+			look.MetaPerceptionDC: skills.DifficultyMedium,
+		},
+	}
+	proc, client, _ := setupOpenTest(t, nil, statue)
+	statue.X += 4
+
+	proc.lookService = look.NewLookService(nil, nil, nil, nil, nil, proc.worldEntityService, nil)
+	proc.skillsRepo = &fakeSkillsRepo{skills: []skills.Skill{{Name: skills.SkillPerception, XP: xpForLevel(perception)}}}
+	return proc, client
+}
+
+func examineCommand(target string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "examine", Target: &target}
+}
+
+func TestHandleLook_ExamineRevealsMoreEachTime(t *testing.T) {
+	proc, client := setupExamineTest(t, skills.DifficultyMedium)
+	target := "statue"
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "look", Target: &target}))
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, examineCommand("statue")))
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, examineCommand("statue")))
+	require.Len(t, client.messages, 3)
+
+	glance, examined, again := client.messages[0], client.messages[1], client.messages[2]
+	assert.Equal(t, look.DetailBasic, glance.Metadata["detail_level"])
+	assert.Equal(t, "A weathered stone statue.", glance.Text)
+
+	assert.Equal(t, look.DetailDetailed, examined.Metadata["detail_level"])
+	assert.Contains(t, examined.Text, "inscription")
+	assert.NotContains(t, examined.Text, "hidden compartment")
+
+	assert.Equal(t, look.DetailDeep, again.Metadata["detail_level"])
+	assert.Contains(t, again.Text, examined.Text)
+	assert.Contains(t, again.Text, "hidden compartment")
+}
+
+func TestHandleLook_ClientCannotRequestDeepLook(t *testing.T) {
+	proc, client := setupExamineTest(t, skills.DifficultyMedium)
+	target, deep := "statue", look.DetailDeep
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "examine", Target: &target, DetailLevel: &deep}))
+
+	require.Len(t, client.messages, 1)
+	assert.Equal(t, look.DetailDetailed, client.messages[0].Metadata["detail_level"])
+	assert.NotContains(t, client.messages[0].Text, "hidden compartment")
+}
+
+func TestHandleLook_KeenEyeExaminesDeeply(t *testing.T) {
+	proc, client := setupExamineTest(t, skills.DifficultyHard)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, examineCommand("statue")))
+
+	require.Len(t, client.messages, 1)
+	assert.Equal(t, look.DetailDeep, client.messages[0].Metadata["detail_level"])
+	assert.Contains(t, client.messages[0].Text, "hidden compartment")
+}
+
+func TestHandleLook_LowPerceptionMissesSecrets(t *testing.T) {
+	proc, client := setupExamineTest(t, 10)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, proc.ProcessCommand(context.Background(), client, examineCommand("statue")))
+	}
+
+	require.Len(t, client.messages, 3)
+	last := client.messages[2]
+	assert.Equal(t, look.DetailDeep, last.Metadata["detail_level"])
+	assert.Contains(t, last.Text, "inscription")
+	assert.NotContains(t, last.Text, "hidden compartment")
+}
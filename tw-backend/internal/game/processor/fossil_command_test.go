@@ -0,0 +1,108 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/auth"
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
+)
+
+// stubFossilStore records the last query and answers from a fixed record
+type stubFossilStore struct {
+	record     []*population.ExtinctSpecies
+	lastFilter ecosystem.FossilFilter
+}
+
+func (s *stubFossilStore) SaveFossils(_ context.Context, _ uuid.UUID, fossils []*population.ExtinctSpecies) error {
+	s.record = append(s.record, fossils...)
+	return nil
+}
+
+func (s *stubFossilStore) GetFossilRecord(_ context.Context, _ uuid.UUID, filter ecosystem.FossilFilter) ([]*population.ExtinctSpecies, error) {
+	s.lastFilter = filter
+	return ecosystem.FilterFossils(s.record, filter), nil
+}
+
+func newFossilTestProcessor(t *testing.T) (*GameProcessor, *mockClient, uuid.UUID) {
+	t.Helper()
+	mockAuthRepo := auth.NewMockRepository()
+	ecoSvc := ecosystem.NewService(time.Now().Unix())
+	proc := NewGameProcessor(mockAuthRepo, NewMockWorldRepository(), nil, nil, nil, nil, nil, nil, nil, nil, ecoSvc, nil, nil, nil, nil, nil, nil)
+
+	charID, userID, worldID := uuid.New(), uuid.New(), uuid.New()
+	mockAuthRepo.CreateCharacter(context.Background(), &auth.Character{
+		CharacterID: charID,
+		UserID:      userID,
+		WorldID:     worldID,
+	})
+	return proc, &mockClient{UserID: userID, CharacterID: charID}, worldID
+}
+
+func TestParseFossilArgs(t *testing.T) {
+	filter, err := ParseFossilArgs("--cause Population_Collapse --from 100 --to 5000")
+	require.NoError(t, err)
+	assert.Equal(t, "population_collapse", filter.Cause)
+	require.NotNil(t, filter.FromYear)
+	require.NotNil(t, filter.ToYear)
+	assert.Equal(t, int64(100), *filter.FromYear)
+	assert.Equal(t, int64(5000), *filter.ToYear)
+	assert.Nil(t, filter.BiomeID)
+
+	_, err = ParseFossilArgs("--from ancient")
+	assert.Error(t, err)
+	_, err = ParseFossilArgs("--cause")
+	assert.Error(t, err)
+	_, err = ParseFossilArgs("--colour red")
+	assert.Error(t, err)
+}
+
+func TestHandleFossils_QueriesStore(t *testing.T) {
+	proc, client, _ := newFossilTestProcessor(t)
+	store := &stubFossilStore{record: []*population.ExtinctSpecies{
+		{SpeciesID: uuid.New(), Name: "Early Grazer", Diet: population.DietHerbivore, ExistedFrom: 0, ExistedUntil: 900, ExtinctionCause: "population_collapse"},
+		{SpeciesID: uuid.New(), Name: "Ash Beetle", Diet: population.DietHerbivore, ExistedFrom: 200, ExistedUntil: 2000, ExtinctionCause: "volcanic_winter"},
+		{SpeciesID: uuid.New(), Name: "Late Grazer", Diet: population.DietHerbivore, ExistedFrom: 3000, ExistedUntil: 4000, ExtinctionCause: "population_collapse"},
+	}}
+	proc.SetFossilStore(store)
+
+	err := proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Text: "fossils --cause population_collapse --from 1000"})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, client.messages)
+	lastMsg := client.messages[len(client.messages)-1]
+	assert.Equal(t, "population_collapse", store.lastFilter.Cause)
+	assert.Contains(t, lastMsg.Text, "Late Grazer")
+	assert.NotContains(t, lastMsg.Text, "Early Grazer")
+	assert.NotContains(t, lastMsg.Text, "Ash Beetle")
+
+	err = proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Text: "fossils --cause asteroid_impact"})
+	require.NoError(t, err)
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "No fossils match")
+}
+
+func TestHandleFossils_FallsBackToLiveRun(t *testing.T) {
+	proc, client, worldID := newFossilTestProcessor(t)
+
+	runner := ecosystem.NewSimulationRunner(ecosystem.DefaultConfig(worldID), nil, nil)
+	runner.InitializePopulationSimulator(1)
+	sim := runner.GetPopulationSimulator()
+	sim.FossilRecord.Extinct = append(sim.FossilRecord.Extinct, &population.ExtinctSpecies{
+		SpeciesID: uuid.New(), Name: "Lost Browser", Diet: population.DietHerbivore,
+		ExistedFrom: 10, ExistedUntil: 50, ExtinctionCause: "population_collapse",
+	})
+	proc.worldRunners = map[uuid.UUID]*ecosystem.SimulationRunner{worldID: runner}
+
+	err := proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Text: "fossils"})
+	require.NoError(t, err)
+	lastMsg := client.messages[len(client.messages)-1]
+	assert.Contains(t, lastMsg.Text, "Lost Browser")
+	assert.Contains(t, lastMsg.Text, "population collapse")
+}
@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
+
+	"github.com/google/uuid"
+)
+
+// maxFossilsShown caps the fossils command output
+const maxFossilsShown = 25
+
+// handleFossils lists the world's extinct species, read from the fossil store
+// so the record is available without a live simulation
+func (p *GameProcessor) handleFossils(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
+	char, err := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	if err != nil || char == nil {
+		client.SendGameMessage("error", "Could not get character info", nil)
+		return nil
+	}
+
+	var args string
+	if cmd.Target != nil {
+		args = *cmd.Target
+	}
+	filter, err := ParseFossilArgs(args)
+	if err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("%v. Usage: fossils [--cause <cause>] [--from <year>] [--to <year>] [--biome <id>]", err), nil)
+		return nil
+	}
+
+	fossils, err := p.fossilRecord(ctx, char.WorldID, filter)
+	if err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("Could not read the fossil record: %v", err), nil)
+		return nil
+	}
+	if len(fossils) == 0 {
+		client.SendGameMessage("system", "No fossils match.", nil)
+		return nil
+	}
+
+	client.SendGameMessage("system", formatFossilRecord(fossils), nil)
+	return nil
+}
+
+// fossilRecord queries the fossil store, falling back to the in-memory
+// record of the current simulation when no store is configured
+func (p *GameProcessor) fossilRecord(ctx context.Context, worldID uuid.UUID, filter ecosystem.FossilFilter) ([]*population.ExtinctSpecies, error) {
+	if p.fossilStore != nil {
+		return p.fossilStore.GetFossilRecord(ctx, worldID, filter)
+	}
+	sim, err := p.SimulationPopulation(ctx, worldID)
+	if err != nil || sim == nil || sim.FossilRecord == nil {
+		return nil, nil
+	}
+	return ecosystem.FilterFossils(sim.FossilRecord.Extinct, filter), nil
+}
+
+// formatFossilRecord renders one line per extinct species, oldest first
+func formatFossilRecord(fossils []*population.ExtinctSpecies) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("=== Fossil Record (%d species) ===\n", len(fossils)))
+	for i, ext := range fossils {
+		if i >= maxFossilsShown {
+			sb.WriteString(fmt.Sprintf("...and %d more extinct species\n", len(fossils)-maxFossilsShown))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("† %s (%s) — years %d to %d, peak %d, cause: %s\n",
+			ext.Name, ext.Diet, ext.ExistedFrom, ext.ExistedUntil, ext.PeakPopulation,
+			strings.ReplaceAll(ext.ExtinctionCause, "_", " ")))
+	}
+	return sb.String()
+}
@@ -0,0 +1,153 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/auth"
+	apperrors "tw-backend/internal/errors"
+	"tw-backend/internal/game/services/entity"
+	"tw-backend/internal/game/services/inventory"
+	"tw-backend/internal/worldentity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupGetTest lays the entities beside a character already carrying the
+// items
+func setupGetTest(t *testing.T, items []inventory.InventoryItem, entities ...*worldentity.WorldEntity) (*GameProcessor, *mockClient, *fakeWorldEntityRepo, *recordingInventoryRepo) {
+	t.Helper()
+	proc, client, repo := setupOpenTest(t, nil, entities...)
+	inv := &recordingInventoryRepo{
+		fakeInventoryRepo: fakeInventoryRepo{items: items},
+		removed:           make(map[uuid.UUID]int),
+		added:             make(map[uuid.UUID]map[string]interface{}),
+	}
+	proc.inventoryService = inventory.NewService(entity.NewService(), inv)
+	return proc, client, repo, inv
+}
+
+func weighted(name string, weight float64) *worldentity.WorldEntity {
+	return &worldentity.WorldEntity{
+		ID:         uuid.New(),
+		Name:       name,
+		EntityType: worldentity.EntityTypeItem,
+		Metadata:   map[string]interface{}{inventory.MetaWeight: weight},
+	}
+}
+
+func getCommand(target string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "get", Target: &target}
+}
+
+func TestHandleGet_CarriesWeight(t *testing.T) {
+	pebble := weighted("pebble", 0.1)
+	proc, client, repo, inv := setupGetTest(t, nil, pebble)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, getCommand("pebble")))
+
+	assert.NotContains(t, repo.entities, pebble.ID)
+	require.Contains(t, inv.added, pebble.ID)
+	assert.Equal(t, 0.1, inv.added[pebble.ID][inventory.MetaWeight])
+}
+
+func TestHandleGet_TooHeavy(t *testing.T) {
+	// Already carrying 90kg of a human's 100kg maximum
+	load := []inventory.InventoryItem{{ItemID: uuid.New(), Quantity: 3, Metadata: map[string]interface{}{inventory.MetaWeight: 30.0}}}
+	boulder := weighted("boulder", 20)
+	proc, client, repo, inv := setupGetTest(t, load, boulder)
+
+	err := proc.ProcessCommand(context.Background(), client, getCommand("boulder"))
+
+	require.ErrorIs(t, err, apperrors.ErrInventoryFull)
+	assert.Contains(t, err.Error(), "too heavy")
+	assert.Contains(t, repo.entities, boulder.ID, "the boulder stays where it was")
+	assert.Empty(t, inv.added)
+}
+
+func TestHandleInventory_ShowsEncumbrance(t *testing.T) {
+	load := []inventory.InventoryItem{{ItemID: uuid.New(), Name: "anvil", Quantity: 1, Metadata: map[string]interface{}{inventory.MetaWeight: 60.0}}}
+	proc, client, _, _ := setupGetTest(t, load)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "inventory"}))
+
+	require.NotEmpty(t, client.messages)
+	assert.Contains(t, client.messages[0].Text, "Carrying 60.0 of 50 kg.")
+	assert.Contains(t, client.messages[0].Text, "You are encumbered.")
+}
+
+func TestHandleDirection_EncumbranceCostsStamina(t *testing.T) {
+	ctx := context.Background()
+	light, lightClient, _, _ := setupGetTest(t, nil)
+	// Twice a human's 50kg carry capacity
+	load := []inventory.InventoryItem{{ItemID: uuid.New(), Name: "anvil", Quantity: 2, Metadata: map[string]interface{}{inventory.MetaWeight: 50.0}}}
+	heavy, heavyClient, _, _ := setupGetTest(t, load)
+
+	require.NoError(t, light.ProcessCommand(ctx, lightClient, &websocket.CommandData{Action: "north"}))
+	require.NoError(t, heavy.ProcessCommand(ctx, heavyClient, &websocket.CommandData{Action: "north"}))
+
+	lightPool := light.characterStamina(ctx, lightClient.GetCharacterID())
+	heavyPool := heavy.characterStamina(ctx, heavyClient.GetCharacterID())
+	assert.Equal(t, 1, lightPool.Max()-lightPool.Current())
+	assert.Equal(t, 2, heavyPool.Max()-heavyPool.Current(), "double the load, double the cost")
+}
+
+func TestHandleDirection_TooExhausted(t *testing.T) {
+	ctx := context.Background()
+	proc, client, _, _ := setupGetTest(t, nil)
+	before, err := proc.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	require.NoError(t, err)
+	startY := before.PositionY
+	proc.characterStamina(ctx, client.GetCharacterID()).SetCurrent(0)
+
+	require.NoError(t, proc.ProcessCommand(ctx, client, &websocket.CommandData{Action: "north"}))
+
+	require.NotEmpty(t, client.messages)
+	assert.Equal(t, "error", client.messages[0].Type)
+	assert.Contains(t, client.messages[0].Text, "too exhausted")
+	after, err := proc.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	require.NoError(t, err)
+	assert.Equal(t, startY, after.PositionY)
+}
+
+func TestTick_EncumbranceSlowsStaminaRegen(t *testing.T) {
+	ctx := context.Background()
+	load := []inventory.InventoryItem{{ItemID: uuid.New(), Name: "anvil", Quantity: 2, Metadata: map[string]interface{}{inventory.MetaWeight: 50.0}}}
+	proc, client, _, _ := setupGetTest(t, load)
+
+	heavy := proc.updateEncumbrance(ctx, client.GetCharacterID())
+	light := proc.characterStamina(ctx, uuid.New())
+	heavy.SetCurrent(0)
+	light.SetCurrent(0)
+
+	// Short ticks add up to whole points
+	for i := 0; i < 100; i++ {
+		proc.regenerateStamina(100 * time.Millisecond)
+	}
+	assert.Greater(t, light.Current(), 0)
+	assert.InDelta(t, light.Current()/2, heavy.Current(), 1, "twice the load, half the recovery")
+}
+
+func TestHandleAttack_EncumbranceSlowsBothFighters(t *testing.T) {
+	ctx := context.Background()
+	// Everyone here carries twice their capacity
+	load := []inventory.InventoryItem{{ItemID: uuid.New(), Name: "anvil", Quantity: 2, Metadata: map[string]interface{}{inventory.MetaWeight: 50.0}}}
+	proc, client, _, _ := setupGetTest(t, load)
+	attacker, err := proc.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	require.NoError(t, err)
+
+	target := addHubClient(proc.Hub, attacker.WorldID, attacker.PositionX+1, attacker.PositionY)
+	target.Username = "Bob"
+	require.NoError(t, proc.authRepo.CreateCharacter(ctx, &auth.Character{CharacterID: target.CharacterID, WorldID: attacker.WorldID, Name: "Bob"}))
+
+	require.NoError(t, proc.ProcessCommand(ctx, client, &websocket.CommandData{Action: "attack", Target: &target.Username}))
+
+	require.NotNil(t, proc.combatService.Combatant(attacker.CharacterID))
+	require.NotNil(t, proc.combatService.Combatant(target.CharacterID))
+	assert.Equal(t, 2.0, proc.combatService.Combatant(attacker.CharacterID).Encumbrance)
+	assert.Equal(t, 2.0, proc.combatService.Combatant(target.CharacterID).Encumbrance)
+}
@@ -100,9 +100,16 @@ var CommandRegistry = map[string]CommandMetadata{
 	// Interaction
 	"look": {
 		Name:        "look",
-		Description: "Look around or examine a specific target.",
-		Usage:       "look [target]",
-		Aliases:     []string{"l", "examine", "inspect", "view", "ex"},
+		Description: "Look around or at a specific target.",
+		Usage:       "look [closely] [target]",
+		Aliases:     []string{"l", "view"},
+		Category:    "Interaction",
+	},
+	"examine": {
+		Name:        "examine",
+		Description: "Look closely at your surroundings or a target. Examining again, or a keen eye, reveals hidden details.",
+		Usage:       "examine [target]",
+		Aliases:     []string{"ex", "inspect", "x"},
 		Category:    "Interaction",
 	},
 	"get": {
@@ -133,18 +140,32 @@ var CommandRegistry = map[string]CommandMetadata{
 	},
 	"push": {
 		Name:        "push",
-		Description: "Push or move an object.",
+		Description: "Push an object a meter away. Heavy ones take a Might check.",
 		Usage:       "push <object>",
 		Aliases:     []string{"pull", "move"},
 		Category:    "Interaction",
 	},
 	"use": {
 		Name:        "use",
-		Description: "Use an item or object.",
+		Description: "Use a potion, food or other consumable.",
 		Usage:       "use <item>",
 		Aliases:     []string{"consume", "activate", "apply"},
 		Category:    "Interaction",
 	},
+	"equip": {
+		Name:        "equip",
+		Description: "Equip a weapon, armor or accessory from your inventory.",
+		Usage:       "equip <item>",
+		Aliases:     []string{"wield", "wear"},
+		Category:    "Interaction",
+	},
+	"unequip": {
+		Name:        "unequip",
+		Description: "Put an equipped item back in your inventory.",
+		Usage:       "unequip <item>",
+		Aliases:     []string{"remove", "unwield"},
+		Category:    "Interaction",
+	},
 	"craft": {
 		Name:        "craft",
 		Description: "Craft an item.",
@@ -231,16 +252,26 @@ var CommandRegistry = map[string]CommandMetadata{
 				Description: "Run a fast-forward simulation of the world.",
 				Usage:       "world simulate [years] [flags]  (default: 1,000,000 years)",
 				Flags: map[string]string{
-					"--geology":             "Simulate geology (tectonics, erosion, climate)",
-					"--weather":             "Simulate weather (requires geology)",
-					"--life":                "Simulate life (populations, evolution, biomes)",
-					"--disease":             "Simulate diseases (requires life)",
-					"--sapience":            "Detect sapience (requires life)",
-					"--migration":           "Simulate migration (requires life)",
-					"--all":                 "Enable all subsystems (default if no flags)",
-					"--seed <number>":       "Custom random seed (default: random 1-12 digit)",
-					"--water-level <level>": "Set water level (high, low, medium, %, or meters)",
-					"--moons <count>":       "Number of moons (0=none, 1+, omit=random). Affects tidal stress, axial stability, impact shielding",
+					"--geology":              "Simulate geology (tectonics, erosion, climate)",
+					"--weather":              "Simulate weather (requires geology)",
+					"--life":                 "Simulate life (populations, evolution, biomes)",
+					"--disease":              "Simulate diseases (requires life)",
+					"--sapience":             "Detect sapience (requires life)",
+					"--migration":            "Simulate migration (requires life)",
+					"--all":                  "Enable all subsystems (default if no flags)",
+					"--seed <number>":        "World seed, or 'random' (default: derived from the world ID, shared with 'world run')",
+					"--water-level <level>":  "Set water level (high, low, medium, %, or meters)",
+					"--moons <count>":        "Number of moons (0=none, 1+, omit=random). Affects tidal stress, axial stability, impact shielding",
+					"--fresh":                "Restart from year 0 (default: continue an already-simulated world toward the requested total)",
+					"--mutation-pulse":       "Boost trait variance of species stuck in evolutionary stagnation",
+					"--naming-theme <theme>": "How duplicate species names are told apart: classic (II, III) or epithet (of the Taiga)",
+					"--progress-steps <n>":   "Number of progress updates during the run (default: 10)",
+					"--verbosity <level>":    "Message and log detail: quiet (summary only), info (default), verbose (every event), or debug",
+					"--atmosphere <preset>":  "Starting atmosphere: earth (default), venus, or mars",
+					"--co2 <atm>":            "Override the preset's starting CO2 (atm)",
+					"--n2 <atm>":             "Override the preset's starting N2 (atm)",
+					"--o2 <atm>":             "Override the preset's starting O2 (atm)",
+					"--greenhouse <°C>":      "Greenhouse sensitivity in °C per CO2 doubling (default: 3)",
 				},
 			},
 			"info": {
@@ -253,6 +284,16 @@ var CommandRegistry = map[string]CommandMetadata{
 				Description: "Reset the world state.",
 				Usage:       "world reset",
 			},
+			"save": {
+				Name:        "save",
+				Description: "Save the world's geology, moons and population.",
+				Usage:       "world save",
+			},
+			"load": {
+				Name:        "load",
+				Description: "Restore the world from its last save.",
+				Usage:       "world load",
+			},
 			"run": {
 				Name:        "run",
 				Description: "Start the continuous simulation.",
@@ -268,6 +309,20 @@ var CommandRegistry = map[string]CommandMetadata{
 				Description: "Set the simulation speed.",
 				Usage:       "world speed <speed>",
 			},
+			"map": {
+				Name:        "map",
+				Description: "Show the full world map.",
+				Usage:       "world map [flags]",
+				Flags: map[string]string{
+					"--biome <mode>":     "How a tile's biome is chosen: dominant (default, favors water to keep coastlines), mode, or center",
+					"--elevation <mode>": "How a tile's elevation is computed: center (default), mean, min, or max",
+				},
+			},
+			"lineage": {
+				Name:        "lineage",
+				Description: "Show what a species evolved from and what evolved from it.",
+				Usage:       "world lineage <species name or id>",
+			},
 		},
 	},
 	"ecosystem": {
@@ -287,8 +342,20 @@ var CommandRegistry = map[string]CommandMetadata{
 				Description: "Show ecosystem status.",
 				Usage:       "ecosystem status",
 			},
+			"diagnose": {
+				Name:        "diagnose",
+				Description: "Explain why a simulated species is thriving or declining.",
+				Usage:       "ecosystem diagnose <species>",
+			},
 		},
 	},
+	"fossils": {
+		Name:        "fossils",
+		Description: "List extinct species from the world's fossil record.",
+		Usage:       "fossils [--cause <cause>] [--from <year>] [--to <year>] [--biome <id>]",
+		Aliases:     []string{"fossil"},
+		Category:    "World Management",
+	},
 	"weather": {
 		Name:        "weather",
 		Description: "Check the weather.",
@@ -19,7 +19,12 @@ func TestGetHelpText(t *testing.T) {
 		{
 			name:     "Command Help - Simple",
 			args:     []string{"look"},
-			contains: []string{"Command: look", "Usage: look [target]", "Aliases: l, examine"},
+			contains: []string{"Command: look", "Usage: look [closely] [target]", "Aliases: l, view"},
+		},
+		{
+			name:     "Command Help - Examine",
+			args:     []string{"examine"},
+			contains: []string{"Command: examine", "Usage: examine [target]", "Aliases: ex, inspect, x"},
 		},
 		{
 			name:     "Command Help - With Subcommand",
@@ -0,0 +1,60 @@
+package processor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/worldgen/geography"
+)
+
+func TestHandleWorldLineage(t *testing.T) {
+	proc, client, worldID := newFossilTestProcessor(t)
+
+	runner := ecosystem.NewSimulationRunner(ecosystem.DefaultConfig(worldID), nil, nil)
+	runner.InitializePopulationSimulator(1)
+	sim := runner.GetPopulationSimulator()
+	sim.CurrentYear = 5000
+
+	// Proto Grazer -> Marsh Grazer (extinct) -> Reed Grazer
+	founderID, bridgeID, heirID := uuid.New(), uuid.New(), uuid.New()
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.Species[founderID] = &population.SpeciesPopulation{SpeciesID: founderID, Name: "Proto Grazer", Diet: population.DietHerbivore, Count: 100}
+	biome.Species[heirID] = &population.SpeciesPopulation{SpeciesID: heirID, Name: "Reed Grazer", Diet: population.DietHerbivore, Count: 100,
+		AncestorID: &bridgeID, CreatedYear: 3000}
+	sim.Biomes[biome.BiomeID] = biome
+	sim.FossilRecord.Extinct = append(sim.FossilRecord.Extinct, &population.ExtinctSpecies{
+		SpeciesID: bridgeID, Name: "Marsh Grazer", Diet: population.DietHerbivore,
+		ExistedFrom: 1000, ExistedUntil: 4000, AncestorID: &founderID,
+	})
+	proc.worldRunners = map[uuid.UUID]*ecosystem.SimulationRunner{worldID: runner}
+
+	lastText := func(text string) string {
+		require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Text: text}))
+		require.NotEmpty(t, client.messages)
+		return client.messages[len(client.messages)-1].Text
+	}
+
+	out := lastText("world lineage Reed Grazer")
+	assert.Contains(t, out, "Lineage of Reed Grazer")
+	assert.Contains(t, out, "† Marsh Grazer")
+	assert.Less(t, strings.Index(out, "Proto Grazer"), strings.Index(out, "Marsh Grazer"), "lineage should run root first")
+	assert.Contains(t, out, "No descendants")
+
+	out = lastText("world lineage proto")
+	assert.Contains(t, out, "Descendants (2)")
+	assert.Contains(t, out, "Reed Grazer")
+
+	out = lastText("world lineage grazer")
+	assert.Contains(t, out, "matches several species")
+
+	out = lastText("world lineage Unicorn")
+	assert.Contains(t, out, "no species named 'Unicorn'")
+}
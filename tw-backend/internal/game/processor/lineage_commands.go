@@ -0,0 +1,117 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/ecosystem/population"
+
+	"github.com/google/uuid"
+)
+
+// maxDescendantsShown caps the descendants listed by world lineage
+const maxDescendantsShown = 25
+
+// handleWorldLineage shows what a species evolved from and what evolved
+// from it, read from the tree of life of the world's simulation
+func (p *GameProcessor) handleWorldLineage(ctx context.Context, client websocket.GameClient, query string) error {
+	char, err := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	if err != nil || char == nil {
+		client.SendGameMessage("error", "Could not get character info", nil)
+		return nil
+	}
+
+	sim, err := p.SimulationPopulation(ctx, char.WorldID)
+	if err != nil || sim == nil {
+		client.SendGameMessage("error", "No simulation for this world yet. Try: world simulate", nil)
+		return nil
+	}
+
+	tree := sim.BuildPhylogeneticTree(char.WorldID)
+	node, err := findLineageSpecies(tree, query)
+	if err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("%v. Usage: world lineage <species name or id>", err), nil)
+		return nil
+	}
+
+	client.SendGameMessage("system", formatLineage(tree, node), nil)
+	return nil
+}
+
+// findLineageSpecies looks a species up by ID, exact name or, failing
+// those, a name fragment that matches only one species
+func findLineageSpecies(tree *population.PhylogeneticTree, query string) (*population.PhylogeneticNode, error) {
+	query = strings.TrimSpace(query)
+	if id, err := uuid.Parse(query); err == nil {
+		if node := tree.GetNode(id); node != nil {
+			return node, nil
+		}
+	}
+
+	var exact, partial []*population.PhylogeneticNode
+	lower := strings.ToLower(query)
+	for _, node := range tree.Nodes {
+		name := strings.ToLower(node.Name)
+		switch {
+		case name == lower:
+			exact = append(exact, node)
+		case strings.Contains(name, lower):
+			partial = append(partial, node)
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no species named '%s' in the tree of life", query)
+	case 1:
+		return matches[0], nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, m.Name)
+	}
+	return nil, fmt.Errorf("'%s' matches several species: %s", query, strings.Join(names, ", "))
+}
+
+// formatLineage renders a species' ancestry, root first, and its
+// descendants; extinct species are marked †
+func formatLineage(tree *population.PhylogeneticTree, node *population.PhylogeneticNode) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("=== Lineage of %s ===\n", node.Name))
+
+	for i, step := range tree.Lineage(node.SpeciesID) {
+		sb.WriteString(fmt.Sprintf("%s%s\n", strings.Repeat("  ", i), lineageEntry(step)))
+	}
+
+	descendants := tree.Descendants(node.SpeciesID)
+	if len(descendants) == 0 {
+		sb.WriteString("No descendants.\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("\nDescendants (%d):\n", len(descendants)))
+	for i, desc := range descendants {
+		if i >= maxDescendantsShown {
+			sb.WriteString(fmt.Sprintf("...and %d more\n", len(descendants)-maxDescendantsShown))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("- %s\n", lineageEntry(desc)))
+	}
+	return sb.String()
+}
+
+// lineageEntry is one species' line: name, diet and span
+func lineageEntry(node *population.PhylogeneticNode) string {
+	if node.IsExtant() {
+		return fmt.Sprintf("%s (%s) — since year %d", node.Name, node.Diet, node.OriginYear)
+	}
+	return fmt.Sprintf("† %s (%s) — years %d to %d", node.Name, node.Diet, node.OriginYear, node.ExtinctionYear)
+}
@@ -2,12 +2,19 @@ package processor
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"tw-backend/cmd/game-server/websocket"
 	"tw-backend/internal/errors"
 	"tw-backend/internal/game/services/inventory"
+	"tw-backend/internal/player"
 	"tw-backend/internal/worldentity"
+
+	"github.com/google/uuid"
 )
 
 // handleGetObject attempts to pick up a world entity
@@ -55,12 +62,7 @@ func (p *GameProcessor) handleGetObject(ctx context.Context, client websocket.Ga
 		return nil
 	}
 
-	// Remove from world
-	if err := p.worldEntityService.Delete(ctx, entity.ID); err != nil {
-		return fmt.Errorf("failed to pick up item: %w", err)
-	}
-
-	// Add to inventory
+	// Check it fits before taking it from the world
 	invItem := inventory.Item{
 		ID:          entity.ID,
 		Name:        entity.Name,
@@ -70,15 +72,162 @@ func (p *GameProcessor) handleGetObject(ctx context.Context, client websocket.Ga
 		"name":        invItem.Name,
 		"description": invItem.Description,
 	}
+	if weight, ok := entity.Metadata[inventory.MetaWeight]; ok {
+		metadata[inventory.MetaWeight] = weight
+	}
+	if err := p.inventoryService.CheckRoom(ctx, charID, invItem.ID, inventory.WeightOf(metadata)); err != nil {
+		return err
+	}
+
+	// Remove from world
+	if err := p.worldEntityService.Delete(ctx, entity.ID); err != nil {
+		return fmt.Errorf("failed to pick up item: %w", err)
+	}
+
+	// Add to inventory
 	if err := p.inventoryService.AddItem(ctx, charID, invItem.ID, 1, metadata); err != nil {
 		return errors.NewInternalError("failed to add item to inventory: %v", err)
 	}
 
 	client.SendGameMessage("action", fmt.Sprintf("You pick up the %s.", entity.Name), nil)
+	p.updateEncumbrance(ctx, charID)
 	p.sendStateUpdate(client)
 	return nil
 }
 
+// handleOpen opens a door or container. A locked one needs its key in the
+// character's inventory, enough Might to force it outright, or a Might check,
+// which can't be retried for StrainCooldown after failing. Opening a
+// container turns its contents into items lying beside it.
+func (p *GameProcessor) handleOpen(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
+	if cmd.Target == nil || *cmd.Target == "" {
+		return stdErrors.New("target required for open command")
+	}
+	target := *cmd.Target
+	charID := client.GetCharacterID()
+
+	authChar, err := p.authRepo.GetCharacter(ctx, charID)
+	if err != nil {
+		client.SendGameMessage("error", "Failed to find your character.", nil)
+		return nil
+	}
+
+	if p.worldEntityService == nil {
+		client.SendGameMessage("error", "World interaction unavailable.", nil)
+		return nil
+	}
+
+	entity, err := p.worldEntityService.GetEntityByName(ctx, authChar.WorldID, target)
+	if err != nil || entity == nil {
+		return errors.Wrap(errors.ErrItemNotFound, fmt.Sprintf("You don't see any '%s' here.", target), err)
+	}
+
+	// Check distance
+	dx := entity.X - authChar.PositionX
+	dy := entity.Y - authChar.PositionY
+	if dx*dx+dy*dy > 9.0 { // 3 meters squared
+		client.SendGameMessage("error", fmt.Sprintf("You are too far away from the %s.", entity.Name), nil)
+		return nil
+	}
+
+	opener := p.opener(ctx, charID)
+	wait := p.strainWait(charID, entity.ID)
+	if wait == 0 {
+		opener.Force = func(difficulty int) bool {
+			return p.mightCheck(ctx, charID, entity.ID, difficulty)
+		}
+	}
+
+	result, err := p.worldEntityService.Open(ctx, entity, opener)
+	switch {
+	case stdErrors.Is(err, worldentity.ErrNotOpenable):
+		client.SendGameMessage("error", fmt.Sprintf("You can't open the %s.", entity.Name), nil)
+		return nil
+	case stdErrors.Is(err, worldentity.ErrAlreadyOpen):
+		client.SendGameMessage("info", fmt.Sprintf("The %s is already open.", entity.Name), nil)
+		return nil
+	case stdErrors.Is(err, worldentity.ErrLocked):
+		if wait > 0 && entity.ForceDC() > 0 {
+			return errors.Wrap(errors.ErrLocked, fmt.Sprintf("The %s is locked, and you're still catching your breath. Try again in %s.", entity.Name, waitText(wait)), nil)
+		}
+		if entity.Forceable() {
+			return errors.Wrap(errors.ErrLocked, fmt.Sprintf("The %s is locked, and won't give.", entity.Name), nil)
+		}
+		return errors.Wrap(errors.ErrLocked, fmt.Sprintf("The %s is locked.", entity.Name), nil)
+	case err != nil && result == nil:
+		return errors.NewInternalError("failed to open %s: %v", entity.Name, err)
+	}
+
+	var msg string
+	switch {
+	case result.Unlocked:
+		msg = fmt.Sprintf("You unlock the %s with your key and open it.", entity.Name)
+	case result.Forced:
+		msg = fmt.Sprintf("You force the %s open.", entity.Name)
+	default:
+		msg = fmt.Sprintf("You open the %s.", entity.Name)
+	}
+	if len(result.Revealed) > 0 {
+		names := make([]string, len(result.Revealed))
+		for i, item := range result.Revealed {
+			names[i] = item.Name
+		}
+		msg += fmt.Sprintf(" Inside you find: %s.", strings.Join(names, ", "))
+	} else if entity.OpenableKind() == worldentity.OpenableContainer {
+		msg += " It's empty."
+	}
+	client.SendGameMessage("action", msg, nil)
+	if err != nil {
+		// Opened, but some contents couldn't be placed
+		return errors.NewInternalError("%v", err)
+	}
+
+	p.sendStateUpdate(client)
+	return nil
+}
+
+// opener describes a character trying a lock: the items they carry, and
+// their Might to force it outright. handleOpen adds the forcing roll. Without
+// an inventory service the character carries no keys.
+func (p *GameProcessor) opener(ctx context.Context, charID uuid.UUID) worldentity.Opener {
+	var opener worldentity.Opener
+	if p.inventoryService != nil {
+		if items, err := p.inventoryService.GetInventory(ctx, charID); err == nil {
+			for _, item := range items {
+				opener.KeyItemIDs = append(opener.KeyItemIDs, item.ItemID)
+			}
+		}
+	}
+	opener.Strength = p.baseAttributes(ctx, charID).Might
+	return opener
+}
+
+// carryCapacity looks up how much a character carries unencumbered, from
+// their Might
+func (p *GameProcessor) carryCapacity(ctx context.Context, charID uuid.UUID) (float64, bool) {
+	if p.characterRepo == nil {
+		return 0, false
+	}
+	char, err := p.characterRepo.Load(ctx, charID)
+	if err != nil || char == nil {
+		return 0, false
+	}
+	return inventory.CarryCapacity(char.BaseAttrs.Might), true
+}
+
+// encumbrance returns the penalty a character's load puts on their stamina
+// and reactions
+func (p *GameProcessor) encumbrance(ctx context.Context, charID uuid.UUID) float64 {
+	if p.inventoryService == nil {
+		return 1
+	}
+	load, err := p.inventoryService.Load(ctx, charID)
+	if err != nil {
+		return 1
+	}
+	return player.EncumbrancePenalty(load)
+}
+
 // handlePushObject attempts to push/move a world entity
 func (p *GameProcessor) handlePushObject(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
 	if cmd.Target == nil || *cmd.Target == "" {
@@ -122,7 +271,49 @@ func (p *GameProcessor) handlePushObject(ctx context.Context, client websocket.G
 		return nil
 	}
 
-	// TODO: Implement actual pushing logic (moving entity position)
+	// Check distance
+	dx := entity.X - char.PositionX
+	dy := entity.Y - char.PositionY
+	dist := math.Sqrt(dx*dx + dy*dy)
+	if dist > 3.0 {
+		client.SendGameMessage("error", fmt.Sprintf("You are too far away from the %s.", entity.Name), nil)
+		return nil
+	}
+
+	// Heavy objects take a Might check to shift
+	if dc := entity.PushDC(); dc > 0 {
+		if wait := p.strainWait(charID, entity.ID); wait > 0 {
+			client.SendGameMessage("error", fmt.Sprintf("You're still catching your breath. Try the %s again in %s.", entity.Name, waitText(wait)), nil)
+			return nil
+		}
+		if !p.mightCheck(ctx, charID, entity.ID, dc) {
+			client.SendGameMessage("action", fmt.Sprintf("You strain against the %s, but it won't budge.", entity.Name), nil)
+			return nil
+		}
+	}
+
+	// Shove it PushDistance directly away; straight ahead if standing on it
+	if dist == 0 {
+		dx, dy, dist = 0, 1, 1
+	}
+	entity.X += dx / dist * PushDistance
+	entity.Y += dy / dist * PushDistance
+	if err := p.worldEntityService.Update(ctx, entity); err != nil {
+		return errors.NewInternalError("failed to push %s: %v", entity.Name, err)
+	}
+
 	client.SendGameMessage("action", fmt.Sprintf("You push the %s.", entity.Name), nil)
 	return nil
 }
+
+// PushDistance is how far a push moves an object, in meters
+const PushDistance = 1.0
+
+// waitText phrases a cooldown for players, in whole seconds
+func waitText(wait time.Duration) string {
+	secs := int(math.Ceil(wait.Seconds()))
+	if secs == 1 {
+		return "1 second"
+	}
+	return fmt.Sprintf("%d seconds", secs)
+}
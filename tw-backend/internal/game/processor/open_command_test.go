@@ -0,0 +1,375 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"tw-backend/cmd/game-server/websocket"
+	apperrors "tw-backend/internal/errors"
+	"tw-backend/internal/game/services/entity"
+	"tw-backend/internal/game/services/inventory"
+	"tw-backend/internal/worldentity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWorldEntityRepo keeps entities in memory, so opening one sticks
+type fakeWorldEntityRepo struct {
+	MockWorldEntityRepo
+	mu       sync.Mutex
+	entities map[uuid.UUID]*worldentity.WorldEntity
+}
+
+func newFakeWorldEntityRepo(entities ...*worldentity.WorldEntity) *fakeWorldEntityRepo {
+	r := &fakeWorldEntityRepo{entities: make(map[uuid.UUID]*worldentity.WorldEntity)}
+	for _, e := range entities {
+		r.entities[e.ID] = e
+	}
+	return r
+}
+
+func (r *fakeWorldEntityRepo) Create(ctx context.Context, e *worldentity.WorldEntity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entities[e.ID] = e
+	return nil
+}
+
+func (r *fakeWorldEntityRepo) Update(ctx context.Context, e *worldentity.WorldEntity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *e
+	r.entities[e.ID] = &copied
+	return nil
+}
+
+func (r *fakeWorldEntityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entities, id)
+	return nil
+}
+
+func (r *fakeWorldEntityRepo) GetByID(ctx context.Context, id uuid.UUID) (*worldentity.WorldEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.entities[id]
+	if !ok {
+		return nil, errors.New("entity not found")
+	}
+	copied := *e
+	return &copied, nil
+}
+
+func (r *fakeWorldEntityRepo) GetByWorldID(ctx context.Context, worldID uuid.UUID) ([]*worldentity.WorldEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*worldentity.WorldEntity
+	for _, e := range r.entities {
+		if e.WorldID == worldID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeWorldEntityRepo) GetByName(ctx context.Context, worldID uuid.UUID, name string) (*worldentity.WorldEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.entities {
+		if e.WorldID == worldID && e.Name == name {
+			copied := *e
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeInventoryRepo holds a fixed set of items
+type fakeInventoryRepo struct {
+	MockInventoryRepo
+	items []inventory.InventoryItem
+}
+
+func (r *fakeInventoryRepo) GetInventory(ctx context.Context, charID uuid.UUID) ([]inventory.InventoryItem, error) {
+	return r.items, nil
+}
+
+func (r *fakeInventoryRepo) SetEquippedSlot(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, slot inventory.Slot) error {
+	for i := range r.items {
+		if r.items[i].ItemID == itemID {
+			r.items[i].EquippedSlot = slot
+		}
+	}
+	return nil
+}
+
+func (r *fakeInventoryRepo) SetMetadata(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, metadata map[string]interface{}) error {
+	for i := range r.items {
+		if r.items[i].ItemID == itemID {
+			r.items[i].Metadata = metadata
+		}
+	}
+	return nil
+}
+
+// setupOpenTest places entities in the character's world, beside them, and
+// gives the character the inventory items
+func setupOpenTest(t *testing.T, items []inventory.InventoryItem, entities ...*worldentity.WorldEntity) (*GameProcessor, *mockClient, *fakeWorldEntityRepo) {
+	t.Helper()
+	proc, client, authRepo, _ := setupTest(t)
+	char, err := authRepo.GetCharacter(context.Background(), client.GetCharacterID())
+	require.NoError(t, err)
+
+	for _, e := range entities {
+		e.WorldID = char.WorldID
+		e.X, e.Y = char.PositionX+1, char.PositionY
+	}
+	repo := newFakeWorldEntityRepo(entities...)
+	proc.worldEntityService = worldentity.NewService(repo)
+	proc.inventoryService = inventory.NewService(entity.NewService(), &fakeInventoryRepo{items: items})
+	return proc, client, repo
+}
+
+func openCommand(target string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "open", Target: &target}
+}
+
+func lockedChest(keyID uuid.UUID) *worldentity.WorldEntity {
+	return &worldentity.WorldEntity{
+		ID:           uuid.New(),
+		Name:         "chest",
+		EntityType:   worldentity.EntityTypeStatic,
+		Interactable: true,
+		Metadata: map[string]interface{}{
+			worldentity.MetaOpenable:  worldentity.OpenableContainer,
+			worldentity.MetaLocked:    true,
+			worldentity.MetaKeyItemID: keyID.String(),
+			worldentity.MetaContents: []interface{}{
+				map[string]interface{}{"name": "gold coin", "description": "A worn coin."},
+				map[string]interface{}{"name": "dagger"},
+			},
+		},
+	}
+}
+
+func TestHandleOpen_LockedWithKey(t *testing.T) {
+	keyID := uuid.New()
+	chest := lockedChest(keyID)
+	proc, client, repo := setupOpenTest(t, []inventory.InventoryItem{{ItemID: keyID, Name: "brass key", Quantity: 1}}, chest)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, openCommand("chest")))
+
+	require.NotEmpty(t, client.messages)
+	assert.Equal(t, "action", client.messages[0].Type)
+	assert.Contains(t, client.messages[0].Text, "unlock the chest with your key")
+	assert.Contains(t, client.messages[0].Text, "gold coin, dagger")
+
+	opened := repo.entities[chest.ID]
+	assert.True(t, opened.IsOpen())
+	assert.False(t, opened.IsLockedShut())
+	assert.NotContains(t, opened.Metadata, worldentity.MetaContents, "contents are only revealed once")
+
+	// The contents now lie beside the chest, ready to pick up
+	for _, name := range []string{"gold coin", "dagger"} {
+		item, err := repo.GetByName(context.Background(), chest.WorldID, name)
+		require.NoError(t, err)
+		require.NotNil(t, item, "%s should be lootable", name)
+		assert.Equal(t, worldentity.EntityTypeItem, item.EntityType)
+		assert.Equal(t, chest.X, item.X)
+	}
+}
+
+func TestHandleOpen_LockedWithoutKey(t *testing.T) {
+	chest := lockedChest(uuid.New())
+	proc, client, repo := setupOpenTest(t, []inventory.InventoryItem{{ItemID: uuid.New(), Name: "wrong key", Quantity: 1}}, chest)
+
+	err := proc.ProcessCommand(context.Background(), client, openCommand("chest"))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, apperrors.ErrLocked))
+	assert.Contains(t, err.Error(), "The chest is locked")
+	assert.False(t, repo.entities[chest.ID].IsOpen())
+	assert.Len(t, repo.entities, 1, "nothing is revealed")
+}
+
+func TestHandleOpen_WithoutInventoryService(t *testing.T) {
+	chest := lockedChest(uuid.New())
+	proc, client, repo := setupOpenTest(t, nil, chest)
+	proc.inventoryService = nil
+
+	err := proc.ProcessCommand(context.Background(), client, openCommand("chest"))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, apperrors.ErrLocked), "a character without an inventory has no keys")
+	assert.False(t, repo.entities[chest.ID].IsOpen())
+}
+
+func TestHandleOpen_ForcedByStrength(t *testing.T) {
+	door := &worldentity.WorldEntity{
+		ID:        uuid.New(),
+		Name:      "door",
+		Collision: true,
+		Metadata: map[string]interface{}{
+			worldentity.MetaOpenable:      worldentity.OpenableDoor,
+			worldentity.MetaLocked:        true,
+			worldentity.MetaKeyItemID:     uuid.New().String(),
+			worldentity.MetaForceStrength: 50.0, // The human template's Might
+		},
+	}
+	proc, client, repo := setupOpenTest(t, nil, door)
+	proc.rng = rolling(1) // Would fail if it were rolled
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, openCommand("door")))
+
+	require.NotEmpty(t, client.messages)
+	assert.Contains(t, client.messages[0].Text, "You force the door open")
+	assert.True(t, repo.entities[door.ID].IsOpen())
+	assert.False(t, repo.entities[door.ID].Collision, "an open door no longer blocks the way")
+}
+
+func forceableDoor() *worldentity.WorldEntity {
+	return &worldentity.WorldEntity{
+		ID:        uuid.New(),
+		Name:      "door",
+		Collision: true,
+		Metadata: map[string]interface{}{
+			worldentity.MetaOpenable:      worldentity.OpenableDoor,
+			worldentity.MetaLocked:        true,
+			worldentity.MetaForceStrength: 90.0,
+			worldentity.MetaForceDC:       70.0,
+		},
+	}
+}
+
+func TestHandleOpen_ForcedByCheck(t *testing.T) {
+	door := forceableDoor()
+	proc, client, repo := setupOpenTest(t, nil, door)
+	proc.rng = rolling(60) // 60 + Might 50 / 5 = 70
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, openCommand("door")))
+
+	require.NotEmpty(t, client.messages)
+	assert.Contains(t, client.messages[0].Text, "You force the door open")
+	assert.True(t, repo.entities[door.ID].IsOpen())
+}
+
+func TestHandleOpen_FailsToForce(t *testing.T) {
+	door := forceableDoor()
+	proc, client, repo := setupOpenTest(t, nil, door)
+	proc.rng = rolling(59)
+
+	err := proc.ProcessCommand(context.Background(), client, openCommand("door"))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, apperrors.ErrLocked))
+	assert.Equal(t, "The door is locked, and won't give.", err.Error())
+	assert.False(t, repo.entities[door.ID].IsOpen())
+}
+
+func TestHandleOpen_FailedForceCoolsDown(t *testing.T) {
+	door := forceableDoor()
+	proc, client, repo := setupOpenTest(t, nil, door)
+	proc.rng = rolling(59)
+	require.Error(t, proc.ProcessCommand(context.Background(), client, openCommand("door")))
+
+	// A natural 100 would succeed, but there's no roll until the cooldown ends
+	proc.rng = rolling(100)
+	err := proc.ProcessCommand(context.Background(), client, openCommand("door"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, apperrors.ErrLocked))
+	assert.Contains(t, err.Error(), "still catching your breath")
+	assert.False(t, repo.entities[door.ID].IsOpen())
+
+	key := strainKey{client.GetCharacterID(), door.ID}
+	proc.strained[key] = proc.strained[key].Add(-StrainCooldown)
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, openCommand("door")))
+	assert.True(t, repo.entities[door.ID].IsOpen())
+}
+
+func pushCommand(target string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "push", Target: &target}
+}
+
+func TestHandlePush_MovesObjectAway(t *testing.T) {
+	crate := &worldentity.WorldEntity{ID: uuid.New(), Name: "crate", EntityType: worldentity.EntityTypeItem}
+	proc, client, repo := setupOpenTest(t, nil, crate)
+	startX := crate.X
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, pushCommand("crate")))
+
+	require.NotEmpty(t, client.messages)
+	assert.Equal(t, "You push the crate.", client.messages[0].Text)
+	assert.InDelta(t, startX+PushDistance, repo.entities[crate.ID].X, 1e-9, "pushed directly away")
+}
+
+func TestHandlePush_HeavyObjectTakesMight(t *testing.T) {
+	boulder := &worldentity.WorldEntity{
+		ID:         uuid.New(),
+		Name:       "boulder",
+		EntityType: worldentity.EntityTypeResource,
+		Metadata:   map[string]interface{}{worldentity.MetaPushDC: 80.0},
+	}
+	proc, client, repo := setupOpenTest(t, nil, boulder)
+	startX := boulder.X
+	proc.rng = rolling(69) // 69 + Might 50 / 5 = 79
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, pushCommand("boulder")))
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "won't budge")
+	assert.Equal(t, startX, repo.entities[boulder.ID].X)
+
+	proc.rng = rolling(100)
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, pushCommand("boulder")))
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "catching your breath")
+	assert.Equal(t, startX, repo.entities[boulder.ID].X, "no retry until the cooldown ends")
+
+	key := strainKey{client.GetCharacterID(), boulder.ID}
+	proc.strained[key] = proc.strained[key].Add(-StrainCooldown)
+	proc.rng = rolling(70)
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, pushCommand("boulder")))
+	assert.Equal(t, "You push the boulder.", client.messages[len(client.messages)-1].Text)
+	assert.InDelta(t, startX+PushDistance, repo.entities[boulder.ID].X, 1e-9)
+}
+
+// fixedRoll is a rand.Source whose d100 always comes up the same
+type fixedRoll int
+
+func (f fixedRoll) Int63() int64 { return int64(f-1) << 32 } // Intn keeps the top 31 bits
+func (f fixedRoll) Seed(int64)   {}
+
+func rolling(n int) *rand.Rand {
+	return rand.New(fixedRoll(n))
+}
+
+func TestHandleOpen_AlreadyOpen(t *testing.T) {
+	door := &worldentity.WorldEntity{
+		ID:   uuid.New(),
+		Name: "door",
+		Metadata: map[string]interface{}{
+			worldentity.MetaOpenable: worldentity.OpenableDoor,
+			worldentity.MetaOpen:     true,
+		},
+	}
+	proc, client, _ := setupOpenTest(t, nil, door)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, openCommand("door")))
+
+	require.Len(t, client.messages, 1)
+	assert.Equal(t, "info", client.messages[0].Type)
+	assert.Contains(t, client.messages[0].Text, "The door is already open")
+}
+
+func TestHandleOpen_NotFound(t *testing.T) {
+	proc, client, _ := setupOpenTest(t, nil)
+
+	err := proc.ProcessCommand(context.Background(), client, openCommand("chest"))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, apperrors.ErrItemNotFound))
+	assert.Contains(t, err.Error(), "You don't see any 'chest' here")
+}
@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"math/rand"
 
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,9 +19,12 @@ import (
 	"tw-backend/cmd/game-server/websocket"
 	"tw-backend/internal/auth"
 	"tw-backend/internal/character"
+	"tw-backend/internal/combat/damage"
+	"tw-backend/internal/combat/effects"
 	"tw-backend/internal/economy/crafting"
 	"tw-backend/internal/ecosystem"
 	apperrors "tw-backend/internal/errors"
+	"tw-backend/internal/eventstore"
 	"tw-backend/internal/game/constants"
 	"tw-backend/internal/game/formatter"
 	"tw-backend/internal/game/services/combat"
@@ -54,6 +60,9 @@ type GameProcessor struct {
 	weatherService     *weather.Service
 	mapService         *gamemap.Service
 	skillsRepo         skills.Repository
+	skillsService      *skills.Service // Built over skillsRepo on first use unless set
+	skillsOnce         sync.Once
+	rng                *rand.Rand // Rolls skill checks for tests; nil uses the shared, concurrency-safe source
 	worldEntityService *worldentity.Service
 	ecosystemService   *ecosystem.Service
 	combatService      *combat.Service
@@ -68,9 +77,28 @@ type GameProcessor struct {
 	// worldRunners stores async simulation runners per world
 	worldRunners map[uuid.UUID]*ecosystem.SimulationRunner
 
+	// worldsMu guards worldGeology and worldRunners, which websocket
+	// commands write while API requests read them
+	worldsMu sync.RWMutex
+
 	// Persistence
 	simSnapshotRepo *ecosystem.SimulationSnapshotRepository
 	runnerStateRepo *ecosystem.RunnerStateRepository
+	fossilStore     ecosystem.FossilStore
+
+	// events publishes combat and ecosystem events for other services
+	events *eventstore.Publisher
+
+	sapientMu sync.Mutex // Serializes creating sapient species' envoys
+
+	// stamina tracks characters' stamina outside combat
+	stamina   map[uuid.UUID]*staminaPool
+	staminaMu sync.Mutex
+
+	// strained holds when characters last failed to force or shift an
+	// object, for StrainCooldown
+	strained map[strainKey]time.Time
+	strainMu sync.Mutex
 }
 
 // NewGameProcessor creates a new game processor
@@ -96,7 +124,7 @@ func NewGameProcessor(
 	// Create map service with available dependencies
 	mapSvc := gamemap.NewService(worldRepo, skillsRepo, entityService, lookService, worldEntityService, ecosystemService)
 
-	return &GameProcessor{
+	p := &GameProcessor{
 		authRepo:           authRepo,
 		worldRepo:          worldRepo,
 		characterRepo:      characterRepo,
@@ -118,6 +146,17 @@ func NewGameProcessor(
 		simSnapshotRepo:    simSnapshotRepo,
 		runnerStateRepo:    runnerStateRepo,
 	}
+	if inventoryService != nil {
+		inventoryService.SetCapacityLookup(p.carryCapacity)
+		inventoryService.SetSkillLookup(p.skillLevel)
+	}
+	if combatService != nil {
+		combatService.SetSkillLookup(p.combatSkills)
+	}
+	if lookService != nil {
+		lookService.SetSpeciesLookup(p.simulatedSpecies)
+	}
+	return p
 }
 
 // SetHub sets the websocket hub
@@ -125,11 +164,57 @@ func (p *GameProcessor) SetHub(hub *websocket.Hub) {
 	p.Hub = hub
 }
 
+// SetFossilStore sets where extinct species are archived during simulation
+func (p *GameProcessor) SetFossilStore(store ecosystem.FossilStore) {
+	p.fossilStore = store
+}
+
+// SetEventPublisher sets where combat and ecosystem events are published
+func (p *GameProcessor) SetEventPublisher(publisher *eventstore.Publisher) {
+	p.events = publisher
+}
+
+// SetSkillsService sets the service that reads and awards skill XP, e.g. to
+// share its XP curve with the skills API. Call it before commands arrive.
+func (p *GameProcessor) SetSkillsService(service *skills.Service) {
+	p.skillsService = service
+}
+
+// skillService returns the skills service, building one over skillsRepo if
+// none was set. Nil without a skills repository.
+func (p *GameProcessor) skillService() *skills.Service {
+	if p.skillsService == nil && p.skillsRepo == nil {
+		return nil
+	}
+	p.skillsOnce.Do(func() {
+		if p.skillsService == nil {
+			p.skillsService = skills.NewService(p.skillsRepo)
+		}
+	})
+	return p.skillsService
+}
+
 // OnClientConnected is called when a client connects to the WebSocket
 // It sends initial game state including the map
 func (p *GameProcessor) OnClientConnected(ctx context.Context, client websocket.GameClient) {
+	// Place the character in the hub's spatial index for area broadcasts
+	p.syncHubPosition(ctx, client.GetCharacterID())
+
 	// Send initial map update so minimap is visible immediately
 	p.sendMapUpdate(ctx, client)
+
+	// Rejoin any fight still in progress from a previous connection
+	if p.combatService != nil && p.combatService.Reconnect(client.GetCharacterID()) {
+		client.SendGameMessage("combat", "You rejoin the fight!", nil)
+	}
+}
+
+// OnClientDisconnected is called when a character's connection drops.
+// Combat state is held for a grace period so a reconnect can resume it.
+func (p *GameProcessor) OnClientDisconnected(ctx context.Context, client websocket.GameClient) {
+	if p.combatService != nil {
+		p.combatService.MarkDisconnected(client.GetCharacterID(), time.Now())
+	}
 }
 
 // ProcessCommand processes a game command from a client
@@ -200,7 +285,7 @@ func (p *GameProcessor) ProcessCommand(ctx context.Context, client websocket.Gam
 		return p.handleEnter(ctx, client, cmd)
 
 	// Observation
-	case "look", "l":
+	case "look", "l", "examine":
 		return p.handleLook(ctx, client, cmd)
 
 	// Communication
@@ -242,12 +327,18 @@ func (p *GameProcessor) ProcessCommand(ctx context.Context, client websocket.Gam
 		return p.handleCraft(ctx, client, cmd)
 	case "use":
 		return p.handleUse(ctx, client, cmd)
+	case "equip":
+		return p.handleEquip(ctx, client, cmd)
+	case "unequip":
+		return p.handleUnequip(ctx, client, cmd)
 	case "lobby":
 		return p.handleLobby(ctx, client)
 	case "weather":
 		return p.handleWeather(ctx, client, cmd)
 	case "ecosystem":
 		return p.handleEcosystem(ctx, client, cmd)
+	case "fossils":
+		return p.handleFossils(ctx, client, cmd)
 	case "world":
 		return p.handleWorld(ctx, client, cmd)
 	case "fly":
@@ -294,13 +385,13 @@ func (p *GameProcessor) handleHelp(_ context.Context, client websocket.GameClien
 // Watchers can move long distances using format: "<direction> <distance>" (e.g., "w 500")
 func (p *GameProcessor) handleDirection(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData, direction string) error {
 	charID := client.GetCharacterID()
+	char, err := p.authRepo.GetCharacter(ctx, charID)
+	watcher := err == nil && char != nil && char.Role == "watcher"
 
 	// Check if watcher with distance specified
 	distance := 1 // Default movement distance
 	if cmd != nil && cmd.Target != nil {
-		// Check if character is a watcher
-		char, err := p.authRepo.GetCharacter(ctx, charID)
-		if err == nil && char != nil && char.Role == "watcher" {
+		if watcher {
 			// Parse distance from target
 			if parsedDist, parseErr := strconv.Atoi(*cmd.Target); parseErr == nil && parsedDist > 0 {
 				distance = parsedDist
@@ -309,7 +400,7 @@ func (p *GameProcessor) handleDirection(ctx context.Context, client websocket.Ga
 					distance = 10000
 				}
 			}
-		} else if cmd.Target != nil {
+		} else {
 			// Non-watcher tried to specify distance
 			client.SendGameMessage("info", "Only watchers can travel great distances instantly.", nil)
 		}
@@ -325,57 +416,39 @@ func (p *GameProcessor) handleDirection(ctx context.Context, client websocket.Ga
 		}
 		client.SendGameMessage("movement", msg, nil)
 	} else {
-		// Normal single-step movement
+		// Normal single-step movement. Walking costs stamina, more under a
+		// heavy load; watchers only observe, and don't tire.
+		var pool *staminaPool
+		cost := 0
+		if !watcher {
+			pool = p.updateEncumbrance(ctx, charID)
+			cost = player.CalculateEncumberedMovementCost(float64(distance), player.MoveWalk, pool.Encumbrance())
+			if err := player.ValidateMovement(pool.Current(), cost); err != nil {
+				client.SendGameMessage("error", "You're too exhausted to move. Rest a moment.", nil)
+				return nil
+			}
+		}
+
 		msg, err := p.spatialService.HandleMovementCommand(ctx, charID, direction)
 		if err != nil {
 			client.SendGameMessage("error", err.Error(), nil)
 			return nil
 		}
+		if pool != nil {
+			_ = pool.Consume(cost) // Validated above
+		}
 		client.SendGameMessage("movement", msg, nil)
 	}
 
+	// Keep area broadcasts in step with the new position
+	p.syncHubPosition(ctx, charID)
+
 	// Send map update after movement
 	p.sendMapUpdate(ctx, client)
 
 	return nil
 }
 
-// getDirectionVector returns the x,y offset for a direction
-func (p *GameProcessor) getDirectionVector(direction string) (int, int) {
-	switch direction {
-	case "north":
-		return 0, 1
-	case "south":
-		return 0, -1
-	case "east":
-		return 1, 0
-	case "west":
-		return -1, 0
-	case "northeast":
-		return 1, 1
-	case "northwest":
-		return -1, 1
-	case "southeast":
-		return 1, -1
-	case "southwest":
-		return -1, -1
-	default:
-		return 0, 0
-	}
-}
-
-// handleOpen opens doors or containers
-func (p *GameProcessor) handleOpen(_ context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
-	if cmd.Target == nil {
-		return errors.New("target required for open command")
-	}
-
-	// TODO: Integrate with world system to check if door/container exists and is locked
-	client.SendGameMessage("action", fmt.Sprintf("You open the %s.", *cmd.Target), nil)
-	p.sendStateUpdate(client)
-	return nil
-}
-
 // handleEnter enters through portals, doorways, or archways
 func (p *GameProcessor) handleEnter(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
 	if cmd.Target == nil || strings.TrimSpace(*cmd.Target) == "" {
@@ -502,53 +575,39 @@ func (p *GameProcessor) handleSay(_ context.Context, client websocket.GameClient
 	senderUsername := client.GetUsername()
 	senderCharID := client.GetCharacterID()
 
-	// Get all clients in the lobby
-	lobbyClients := p.Hub.GetClientsByWorldID(constants.LobbyWorldID)
-
 	// Send to sender with special formatting
 	formattedMessage := fmt.Sprintf("You say, %s", formatter.Format(fmt.Sprintf("'%s'", message), formatter.StyleGreen))
-	client.SendGameMessage("speech_self", formattedMessage, map[string]interface{}{
+	metadata := map[string]interface{}{
 		"sender_id":   senderCharID.String(),
 		"sender_name": senderUsername,
 		"message":     message,
-	})
+	}
+	client.SendGameMessage("speech_self", formattedMessage, metadata)
+
+	formattedSpeech := fmt.Sprintf("%s says, %s",
+		formatter.Format(senderUsername, formatter.StyleCyan),
+		formatter.Format(fmt.Sprintf("'%s'", message), formatter.StyleGreen))
+
+	// In worlds, speech only carries to players within earshot
+	worldID := client.GetWorldID()
+	if !constants.IsLobby(worldID) {
+		if center, ok := p.Hub.GetCharacterPosition(senderCharID); ok {
+			p.broadcastGameMessageToArea(worldID, center, SayRadius, "speech", formattedSpeech, metadata, excludeCharacter(senderCharID))
+		}
+		return nil
+	}
 
 	// Broadcast to all other players in lobby
+	lobbyClients := p.Hub.GetClientsByWorldID(constants.LobbyWorldID)
 	for _, c := range lobbyClients {
 		if c.GetCharacterID() != senderCharID {
-			formattedSpeech := fmt.Sprintf("%s says, %s",
-				formatter.Format(senderUsername, formatter.StyleCyan),
-				formatter.Format(fmt.Sprintf("'%s'", message), formatter.StyleGreen))
-			c.SendGameMessage("speech", formattedSpeech, map[string]interface{}{
-				"sender_id":   senderCharID.String(),
-				"sender_name": senderUsername,
-				"message":     message,
-			})
+			c.SendGameMessage("speech", formattedSpeech, metadata)
 		}
 	}
 
 	return nil
 }
 
-// handleWhisper sends a private message to a nearby player (5m range)
-func (p *GameProcessor) handleWhisper(_ context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
-	if cmd.Recipient == nil {
-		return errors.New("recipient required for whisper command")
-	}
-	if cmd.Message == nil {
-		return errors.New("message required for whisper command")
-	}
-
-	// TODO: Calculate distance to recipient, check 5m range
-	// TODO: Proximity-based clarity - closer players can overhear more clearly
-	// TODO: Perception stat affects who can overhear
-	client.SendGameMessage("whisper", fmt.Sprintf("You whisper to %s: %s", *cmd.Recipient, *cmd.Message), map[string]interface{}{
-		"recipient": *cmd.Recipient,
-		"message":   *cmd.Message,
-	})
-	return nil
-}
-
 // handleTell sends a private message to any online player
 func (p *GameProcessor) handleTell(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
 	// Validate message is not empty
@@ -735,25 +794,51 @@ func (p *GameProcessor) handleLook(ctx context.Context, client websocket.GameCli
 	// SpatialService has helper for this.
 	orientation := p.spatialService.GetDirectionName(char.OrientationX, char.OrientationY, char.OrientationZ)
 
-	// If specific target (not room/here)
-	if target != "" && strings.ToLower(target) != "here" && strings.ToLower(target) != "room" {
-		description, err := p.lookService.DescribeEntity(ctx, char, target)
-		if err != nil {
-			client.SendGameMessage("error", fmt.Sprintf("You don't see any '%s' here.", target), nil)
-			return nil
+	isRoom := target == "" || strings.ToLower(target) == "here" || strings.ToLower(target) == "room"
+
+	// Examining looks deeper each time, and deeper still with a keen eye.
+	// A client can ask for at most a detailed look; only repeated
+	// examination and perception go deeper.
+	detailLevel := look.DetailBasic
+	if cmd.DetailLevel != nil {
+		detailLevel = max(look.DetailBasic, min(look.DetailDetailed, *cmd.DetailLevel))
+	} else if cmd.Action == "examine" {
+		detailLevel = look.DetailDetailed
+	}
+	perception := p.skillLevel(ctx, charID, skills.SkillPerception)
+	if detailLevel > look.DetailBasic {
+		examined := target
+		if isRoom {
+			examined = ""
 		}
-		client.SendGameMessage("area_description", description, nil)
-		return nil
+		detailLevel = p.lookService.ExamineLevel(char, examined, detailLevel, perception)
 	}
 
-	// Describe Room
 	dc := look.DescribeContext{
 		WorldID:     worldID,
 		Character:   char,
 		Orientation: orientation,
-		DetailLevel: 1, // Default to basic
+		DetailLevel: detailLevel,
+		Perception:  perception,
 	}
 
+	// If specific target (not room/here)
+	if !isRoom {
+		description, err := p.lookService.DescribeTarget(ctx, dc, target)
+		if err != nil {
+			client.SendGameMessage("error", fmt.Sprintf("You don't see any '%s' here.", target), nil)
+			return nil
+		}
+		client.SendGameMessage("area_description", description, map[string]interface{}{
+			"detail_level": detailLevel,
+		})
+		if detailLevel > look.DetailBasic {
+			p.awardXP(ctx, client, charID, skills.SkillPerception, ExamineXP*skills.ChallengeFactor(perception, p.examineDifficulty(ctx, worldID, target)))
+		}
+		return nil
+	}
+
+	// Describe Room
 	description, err := p.lookService.Describe(ctx, dc)
 	if err != nil {
 		log.Printf("[PROCESSOR] Failed to describe room: %v", err)
@@ -763,7 +848,11 @@ func (p *GameProcessor) handleLook(ctx context.Context, client websocket.GameCli
 	client.SendGameMessage("area_description", description, map[string]interface{}{
 		"character_id": charID.String(),
 		"world_id":     worldID.String(),
+		"detail_level": detailLevel,
 	})
+	if detailLevel > look.DetailBasic {
+		p.awardXP(ctx, client, charID, skills.SkillPerception, ExamineXP*skills.ChallengeFactor(perception, skills.DifficultyMedium))
+	}
 
 	// Also send map update when looking at the room
 	p.sendMapUpdate(ctx, client)
@@ -811,6 +900,7 @@ func (p *GameProcessor) handleDrop(ctx context.Context, client websocket.GameCli
 	}
 
 	client.SendGameMessage("system", fmt.Sprintf("You drop the %s.", item.Name), nil)
+	p.updateEncumbrance(ctx, charID)
 	p.sendStateUpdate(client)
 	return nil
 }
@@ -828,11 +918,22 @@ func (p *GameProcessor) handleInventory(ctx context.Context, client websocket.Ga
 	}
 
 	var itemNames []string
+	weight := 0.0
 	for _, i := range items {
-		itemNames = append(itemNames, fmt.Sprintf("- %s (%d)", i.Name, i.Quantity))
+		line := fmt.Sprintf("- %s (%d)", i.Name, i.Quantity)
+		if i.EquippedSlot != "" {
+			line += fmt.Sprintf(" [%s]", i.EquippedSlot)
+		}
+		itemNames = append(itemNames, line)
+		weight += i.TotalWeight()
 	}
 
 	msg := fmt.Sprintf("Inventory:\n%s", strings.Join(itemNames, "\n"))
+	capacity := p.inventoryService.CarryCapacity(ctx, charID)
+	msg += fmt.Sprintf("\nCarrying %.1f of %.0f kg.", weight, capacity)
+	if weight > capacity {
+		msg += " You are encumbered."
+	}
 	client.SendGameMessage("system", msg, nil)
 	p.sendStateUpdate(client)
 	return nil
@@ -840,35 +941,23 @@ func (p *GameProcessor) handleInventory(ctx context.Context, client websocket.Ga
 
 // Tick processes periodic game updates (combat)
 func (p *GameProcessor) Tick(dt time.Duration) {
+	p.regenerateStamina(dt)
+
 	events := p.combatService.Tick(dt)
 	for _, evt := range events {
-		// Broadcast combat events
-		// precise broadcasting would require mapped locations, here we broadcast to world or participants
-		// For P0, we broadcast to the participants
-
-		data := evt.Data
-		actorIDStr, _ := data["actor_id"].(uuid.UUID)
-		targetIDStr, _ := data["target_id"].(uuid.UUID)
-
-		// Simplify: Find clients for these IDs and send message
-		// In real impl, we'd use p.Hub.GetClientByCharacterID() if it existed, or loop
-
-		// Construct message
-		var msg string
+		if evt.Result == nil {
+			// Witnesses near the actor see the event, unless terrain blocks their view
+			p.broadcastCombatEvent(evt.Type, evt.Data)
+			continue
+		}
+		// Attacks are rendered per participant from the structured result; the
+		// miss and death events that follow one carry the same result
 		if evt.Type == "combat_action" {
-			actionType := data["type"].(string)
-			msg = fmt.Sprintf("Combat: %s performs %s on %s", actorIDStr, actionType, targetIDStr)
+			p.publishAttackResult(evt.Result, evt.Timestamp)
+			p.deliverAttackResult(evt.Result)
+			p.awardVictoryXP(evt.Result)
+			p.recordWeaponWear(evt.Result)
 		}
-
-		// Send to world (spammy but works for P0 verification)
-		// Better: Send to Hub broadcast for that world? We don't have event location easily available in event yet
-		// We can get location from actor if we looked them up.
-
-		// For now, just log and try to notify players if possible
-		log.Printf("[COMBAT-EVENT] %s: %s", evt.Type, msg)
-
-		// Broadcast to all clients (global broadcast for debug/proof)
-		// p.Hub.Broadcast(websocket.GameMessage{Type: "combat_event", Payload: msg})
 	}
 }
 
@@ -900,8 +989,11 @@ func (p *GameProcessor) handleAttack(ctx context.Context, client websocket.GameC
 		}
 	}
 
-	// Ensure attacker is in combat state
+	// Ensure attacker is in combat state with their gear; a heavy load slows
+	// their reactions, as it does a player target's below
 	p.combatService.JoinCombatFromCharacter(attackerChar)
+	p.combatService.SetEncumbrance(attackerID, p.encumbrance(ctx, attackerID))
+	p.refreshEquipment(ctx, attackerID)
 
 	// fast lookup: is target a player?
 	// Get clients in same world
@@ -947,8 +1039,17 @@ func (p *GameProcessor) handleAttack(ctx context.Context, client websocket.GameC
 	}
 
 	if targetChar != nil {
-		// Target is a player
+		// Target is a player, who must be in sight: darkness shortens it
+		if tAuth, err := p.authRepo.GetCharacter(ctx, targetClientID); err == nil && tAuth != nil {
+			dist := math.Hypot(tAuth.PositionX-authChar.PositionX, tAuth.PositionY-authChar.PositionY)
+			if msg := p.outOfSight(authChar.WorldID, targetChar.Name, dist); msg != "" {
+				client.SendGameMessage("error", msg, nil)
+				return nil
+			}
+		}
 		p.combatService.JoinCombatFromCharacter(targetChar)
+		p.combatService.SetEncumbrance(targetClientID, p.encumbrance(ctx, targetClientID))
+		p.refreshEquipment(ctx, targetClientID)
 		err := p.combatService.QueueAttack(attackerID, targetClientID)
 		if err != nil {
 			client.SendGameMessage("error", fmt.Sprintf("Failed to attack: %v", err), nil)
@@ -966,35 +1067,15 @@ func (p *GameProcessor) handleAttack(ctx context.Context, client websocket.GameC
 			client.SendGameMessage("error", fmt.Sprintf("You cannot attack %s.", npcEntity.Name), nil)
 			return nil
 		}
+		dist := math.Hypot(npcEntity.X-authChar.PositionX, npcEntity.Y-authChar.PositionY)
+		if msg := p.outOfSight(authChar.WorldID, npcEntity.Name, dist); msg != "" {
+			client.SendGameMessage("error", msg, nil)
+			return nil
+		}
 
-		// Join combat using EntityID (NPC) - specialized method needed or generic JoinCombat?
-		// combat.Service.JoinCombat takes *action.Combatant. We need to construct one for NPC.
-		// For now, assuming mock behavior is sufficient or we create a simple combatant.
-		// BUT combatService.JoinCombat is what we have.
-		// And we need QueueAttack (attackerID, targetID).
-		// targetID is npcEntity.ID.
-
-		// We need to ensure NPC is in combat.
-		// We'll trust CombatService/Resolver to handle "non-joined" entities implicitly or we join them.
-
-		// Using JoinCombat manually for NPC:
-		/*
-			combatant := &action.Combatant{
-				EntityID: npcEntity.ID,
-				MaxHP: 100, // Default/Placeholder
-				CurrentHP: 100,
-				// ...
-			}
-			p.combatService.JoinCombat(combatant)
-		*/
-
-		// For this fix, let's just QueueAttack. If QueueAttack requires destination combatant to exist,
-		// we might fail unless we implement "JoinCombatFromEntity".
-		// MockCombatRepo/Service ignores logic?
-		// Real CombatService QueueAttack checks "if attacker == nil". Does it check target?
-		// "queueAction := action.NewCombatAction(attackerID, targetID ...)"
-		// It doesn't seem to validate target existence in resolver immediately?
-
+		// NPCs have no attributes of their own yet; they fight with the
+		// human template, as characters without a sheet do
+		p.combatService.JoinCombatFromNPC(npcEntity.ID, character.GetSpeciesTemplate(character.SpeciesHuman).BaseAttrs)
 		err := p.combatService.QueueAttack(attackerID, npcEntity.ID)
 		if err != nil {
 			client.SendGameMessage("error", fmt.Sprintf("Failed to attack: %v", err), nil)
@@ -1007,36 +1088,85 @@ func (p *GameProcessor) handleAttack(ctx context.Context, client websocket.GameC
 	return fmt.Errorf("target '%s' not found", *cmd.Target)
 }
 
+// handleTalk talks to a nearby NPC. "talk innkeeper 2" picks option 2 of
+// the innkeeper's current dialogue node.
 func (p *GameProcessor) handleTalk(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
 	if cmd.Target == nil {
 		return errors.New("target required for talk")
 	}
 
 	charID := client.GetCharacterID()
-	var msg string
+	npc, rest := p.nearbyNPC(ctx, charID, strings.TrimSpace(*cmd.Target))
+	if npc == nil {
+		client.SendGameMessage("error", fmt.Sprintf("Nobody named %s hears you.", *cmd.Target), nil)
+		return nil
+	}
+	msg := rest
 	if cmd.Message != nil {
 		msg = *cmd.Message
 	}
-	resp, err := p.interactionService.ProcessDialogue(ctx, charID, *cmd.Target, msg)
+
+	resp, err := p.interactionService.Talk(ctx, charID, npc, msg)
 	if err != nil {
-		// Fallback for non-interactive or missing targets
-		client.SendGameMessage("error", fmt.Sprintf("Nobody named %s hears you.", *cmd.Target), nil)
-		return nil
+		return fmt.Errorf("failed to talk to %s: %w", npc.Name, err)
 	}
 
 	formattedDialogue := fmt.Sprintf("%s says: %s",
-		formatter.Format(*cmd.Target, formatter.StyleYellow),
+		formatter.Format(npc.Name, formatter.StyleYellow),
 		formatter.Format(fmt.Sprintf("'%s'", resp.Text), formatter.StyleGreen))
+	for i, option := range resp.Options {
+		formattedDialogue += fmt.Sprintf("\n  %d. %s", i+1, option)
+	}
 
 	client.SendGameMessage("dialogue", formattedDialogue, map[string]interface{}{
 		"npcName":   resp.NPCName,
 		"npcID":     resp.NPCID,
 		"dialogue":  resp.Text,
 		"available": resp.Options,
+		"nodeID":    resp.NodeID,
+		"ended":     resp.Ended,
 	})
 	return nil
 }
 
+// nearbyNPC finds an NPC within TalkRange whose name begins target, and
+// returns it with the rest of target, which is what's said to it. Only world
+// entities are found: scripted NPCs and sapient species' envoys. Economy
+// merchants (internal/economy/npc) aren't among them: nothing creates one
+// yet, and a Merchant has only a settlement ID, not a position. Whatever
+// first creates merchants should place each as an NPC entity whose ID is
+// its NPCID, and talk will find it like any other.
+func (p *GameProcessor) nearbyNPC(ctx context.Context, charID uuid.UUID, target string) (*worldentity.WorldEntity, string) {
+	char, err := p.authRepo.GetCharacter(ctx, charID)
+	if err != nil || char == nil || p.worldEntityService == nil {
+		return nil, ""
+	}
+	entities, err := p.worldEntityService.GetEntitiesAt(ctx, char.WorldID, char.PositionX, char.PositionY, TalkRange)
+	if err != nil {
+		return nil, ""
+	}
+
+	lower := strings.ToLower(target)
+	var best *worldentity.WorldEntity
+	for _, e := range entities {
+		if e.EntityType != worldentity.EntityTypeNPC {
+			continue
+		}
+		name := strings.ToLower(e.Name)
+		if lower != name && !strings.HasPrefix(lower, name+" ") {
+			continue
+		}
+		// Prefer the longest name, so "old tom" beats "old"
+		if best == nil || len(e.Name) > len(best.Name) {
+			best = e
+		}
+	}
+	if best == nil {
+		return nil, ""
+	}
+	return best, strings.TrimSpace(target[len(best.Name):])
+}
+
 func (p *GameProcessor) handleCraft(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
 	if cmd.Target == nil || *cmd.Target == "" {
 		return apperrors.NewInvalidInput("What do you want to craft? (usage: craft <item>)")
@@ -1048,45 +1178,162 @@ func (p *GameProcessor) handleCraft(ctx context.Context, client websocket.GameCl
 	// 1. Find Recipe
 	recipe, err := p.craftingService.FindRecipeByName(ctx, recipeName)
 	if err != nil {
-		client.SendGameMessage("crafting_error", fmt.Sprintf("You don't know how to craft '%s'.", recipeName), nil)
-		return nil // Not a system error, just user error
+		return apperrors.Wrap(apperrors.ErrRecipeNotFound, fmt.Sprintf("You don't know how to craft '%s'.", recipeName), nil)
 	}
 
-	// 2. Attempt Craft
-	// Note: We're not passing a specific station yet (nil).
-	// Future: Check nearby entities to find the best matching station.
-	// For now, assume hand-crafting or auto-finding station.
-	// To find station: p.worldEntityService.GetEntitiesAt(...)
+	// 2. Find a suitable station within reach, if the recipe needs one
+	var stationID *uuid.UUID
+	if recipe.RequiredStation != nil {
+		stationID = p.nearbyStation(ctx, charID, recipe.RequiredStation)
+		if stationID == nil {
+			return apperrors.Wrap(apperrors.ErrCraftingStationNeeded, fmt.Sprintf("You need a %s nearby to craft %s.", recipe.RequiredStation.StationType, recipeName), nil)
+		}
+	}
 
-	result, err := p.craftingService.Craft(ctx, charID, recipe.RecipeID, nil)
+	// 3. Attempt Craft
+	result, err := p.craftingService.Craft(ctx, charID, recipe.RecipeID, stationID, p.skillLevel(ctx, charID, recipe.RequiredSkill))
 	if err != nil {
-		// Differentiate errors?
-		client.SendGameMessage("crafting_error", fmt.Sprintf("Failed to craft %s: %v", recipeName, err), nil)
-		return nil
+		switch {
+		case errors.Is(err, apperrors.ErrMissingIngredients):
+			return apperrors.Wrap(apperrors.ErrMissingIngredients, fmt.Sprintf("You don't have the ingredients to craft %s.", recipeName), nil)
+		case errors.Is(err, apperrors.ErrCraftingStationNeeded):
+			return apperrors.Wrap(apperrors.ErrCraftingStationNeeded, fmt.Sprintf("You need a %s nearby to craft %s.", recipe.RequiredStation.StationType, recipeName), nil)
+		}
+		return err
 	}
 
-	// 3. Success
+	// 4. Success
 	client.SendGameMessage("crafting_success", fmt.Sprintf("You successfully crafted %s x%d!", recipeName, result.Item.Quantity), map[string]interface{}{
 		"item_id":  result.Item.ItemID.String(),
 		"quantity": result.Item.Quantity,
 		"quality":  result.Item.Quality,
 	})
+	level := p.skillLevel(ctx, charID, recipe.RequiredSkill)
+	p.awardXP(ctx, client, charID, recipe.RequiredSkill, CraftXP*skills.ChallengeFactor(level, recipe.MinSkillLevel))
 
 	p.sendStateUpdate(client)
 	return nil
 }
 
-func (p *GameProcessor) handleUse(_ context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
-	if cmd.Target == nil {
-		return errors.New("item required for use")
+// nearbyStation returns the first world entity within reach of the character
+// that satisfies a recipe's station requirement
+func (p *GameProcessor) nearbyStation(ctx context.Context, charID uuid.UUID, required *crafting.CraftingStation) *uuid.UUID {
+	char, err := p.authRepo.GetCharacter(ctx, charID)
+	if err != nil || char == nil || p.worldEntityService == nil {
+		return nil
+	}
+	entities, err := p.worldEntityService.GetEntitiesAt(ctx, char.WorldID, char.PositionX, char.PositionY, 5.0)
+	if err != nil {
+		return nil
+	}
+	for _, e := range entities {
+		if required.SatisfiedBy(e) {
+			id := e.ID
+			return &id
+		}
+	}
+	return nil
+}
+
+// skillLevel returns the character's level in a skill, or 0 if it isn't
+// known. Recipes name skills in lower case, so the match ignores case.
+func (p *GameProcessor) skillLevel(ctx context.Context, charID uuid.UUID, name string) int {
+	if p.skillsRepo == nil || name == "" {
+		return 0
+	}
+	sheet, err := p.skillService().GetSkillSheet(ctx, charID)
+	if err != nil {
+		return 0
+	}
+	for skillName, skill := range sheet.Skills {
+		if strings.EqualFold(skillName, name) {
+			return skill.Level
+		}
+	}
+	return 0
+}
+
+// combatSkills returns a character's skill with a weapon (unarmed fights as
+// bludgeoning) and at dodging, for the combat to-hit roll
+func (p *GameProcessor) combatSkills(charID uuid.UUID, weapon *damage.Weapon) (accuracy, evasion int) {
+	kind := damage.WeaponBludgeoning
+	if weapon != nil {
+		kind = weapon.Type
+	}
+	ctx := context.Background()
+	return p.skillLevel(ctx, charID, inventory.WeaponSkill(kind)), p.skillLevel(ctx, charID, skills.SkillDodge)
+}
+
+// skillCheck rolls a check for gated actions (forcing a lock, persuading,
+// spotting the hidden): a skill level and an attribute against a difficulty
+func (p *GameProcessor) skillCheck(level, attributeVal, difficulty int) skills.CheckResult {
+	return skills.Check(level, skills.AttributeModifier(attributeVal), difficulty, p.rng)
+}
+
+// handleUse consumes a potion, food or other consumable, applying its
+// effects. Items of a type share a cooldown.
+func (p *GameProcessor) handleUse(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
+	if cmd.Target == nil || *cmd.Target == "" {
+		return apperrors.NewInvalidInput("Use what? (usage: use <item>)")
 	}
 
-	// TODO: Integrate with item use system
-	client.SendGameMessage("system", fmt.Sprintf("You use the %s.", *cmd.Target), nil)
+	charID := client.GetCharacterID()
+	inCombat := p.combatService != nil && p.combatService.InCombat(charID)
+	item, consumable, err := p.inventoryService.Consume(ctx, charID, *cmd.Target, func(item inventory.InventoryItem, c effects.Consumable) error {
+		return p.checkConsumable(ctx, charID, item, c, inCombat)
+	})
+	if err != nil {
+		return err
+	}
+
+	healed, restored := 0, 0
+	if p.combatService != nil {
+		healed, restored = p.combatService.ApplyConsumable(charID, consumable, time.Now())
+	}
+	// Outside combat stamina is the character's own pool
+	if !inCombat && consumable.Stamina > 0 {
+		restored = p.characterStamina(ctx, charID).Restore(consumable.Stamina)
+	}
+
+	msg := fmt.Sprintf("You use the %s.", item.Name)
+	if healed > 0 {
+		msg += fmt.Sprintf(" You recover %d HP.", healed)
+	}
+	if restored > 0 {
+		msg += fmt.Sprintf(" You recover %d stamina.", restored)
+	}
+	if buff := consumable.Buff; buff != nil {
+		change := "rises"
+		if buff.Modifier < 0 {
+			change = "falls"
+		}
+		msg += fmt.Sprintf(" Your %s %s.", buff.Stat, change)
+	}
+	client.SendGameMessage("action", msg, map[string]interface{}{
+		"item_id":          item.ItemID.String(),
+		"healed":           healed,
+		"stamina_restored": restored,
+	})
 	p.sendStateUpdate(client)
 	return nil
 }
 
+// checkConsumable fails if nothing a consumable does can take effect on the
+// character now, so using it would waste it. HP is only tracked in combat;
+// outside it, stamina goes to the character's pool and only buffs apply.
+func (p *GameProcessor) checkConsumable(ctx context.Context, charID uuid.UUID, item inventory.InventoryItem, c effects.Consumable, inCombat bool) error {
+	if inCombat || (c.Buff != nil && p.combatService != nil) {
+		return nil
+	}
+	if c.Stamina > 0 {
+		if pool := p.characterStamina(ctx, charID); pool.Current() < pool.Max() {
+			return nil
+		}
+		return apperrors.Wrap(apperrors.ErrInvalidCommand, fmt.Sprintf("You're already rested; the %s would be wasted.", item.Name), nil)
+	}
+	return apperrors.Wrap(apperrors.ErrInvalidCommand, fmt.Sprintf("You aren't wounded; the %s would be wasted.", item.Name), nil)
+}
+
 // sendStateUpdate sends the current game state to the client
 func (p *GameProcessor) sendStateUpdate(client websocket.GameClient) {
 	// Get character from database for live position
@@ -1099,13 +1346,14 @@ func (p *GameProcessor) sendStateUpdate(client websocket.GameClient) {
 		posX = char.PositionX
 		posY = char.PositionY
 	}
+	stamina := p.characterStamina(ctx, charID)
 
-	// TODO: Get actual HP/Stamina/Focus from character stats when implemented
+	// TODO: Get actual HP/Focus from character stats when implemented
 	state := &websocket.StateUpdateData{
 		HP:         100,
 		MaxHP:      100,
-		Stamina:    80,
-		MaxStamina: 100,
+		Stamina:    stamina.Current(),
+		MaxStamina: stamina.Max(),
 		Focus:      60,
 		MaxFocus:   100,
 		Position: websocket.Position{
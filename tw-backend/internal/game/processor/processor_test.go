@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,9 +11,11 @@ import (
 	"tw-backend/internal/auth"
 	"tw-backend/internal/character"
 	"tw-backend/internal/economy/crafting"
+	apperrors "tw-backend/internal/errors"
 	"tw-backend/internal/game/constants"
 	"tw-backend/internal/game/services/combat"
 	"tw-backend/internal/game/services/entity"
+	"tw-backend/internal/game/services/interaction"
 	"tw-backend/internal/game/services/inventory"
 	"tw-backend/internal/game/services/look"
 	"tw-backend/internal/player"
@@ -71,11 +74,13 @@ func (m *MockInterviewRepository) SaveAnswer(ctx context.Context, interviewID uu
 // MockWorldRepository for testing
 type MockWorldRepository struct {
 	worlds map[uuid.UUID]*repository.World
+	states map[uuid.UUID]*repository.WorldState
 }
 
 func NewMockWorldRepository() *MockWorldRepository {
 	return &MockWorldRepository{
 		worlds: make(map[uuid.UUID]*repository.World),
+		states: make(map[uuid.UUID]*repository.WorldState),
 	}
 }
 
@@ -119,6 +124,15 @@ func (m *MockWorldRepository) DeleteWorld(ctx context.Context, worldID uuid.UUID
 	return nil
 }
 
+func (m *MockWorldRepository) SaveWorldState(ctx context.Context, worldID uuid.UUID, state *repository.WorldState) error {
+	m.states[worldID] = state
+	return nil
+}
+
+func (m *MockWorldRepository) LoadWorldState(ctx context.Context, worldID uuid.UUID) (*repository.WorldState, error) {
+	return m.states[worldID], nil
+}
+
 // Mock client for testing
 type mockClient struct {
 	CharacterID  uuid.UUID
@@ -127,6 +141,7 @@ type mockClient struct {
 	WorldID      uuid.UUID
 	messages     []websocket.GameMessageData
 	stateUpdates int
+	mu           sync.Mutex // world simulate streams progress from another goroutine
 }
 
 func (m *mockClient) GetCharacterID() uuid.UUID {
@@ -150,6 +165,8 @@ func (m *mockClient) GetUsername() string {
 }
 
 func (m *mockClient) SendGameMessage(msgType, text string, metadata map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.messages = append(m.messages, websocket.GameMessageData{
 		Type:     msgType,
 		Text:     text,
@@ -264,7 +281,9 @@ func setupTest(t *testing.T) (*GameProcessor, *mockClient, *auth.MockRepository,
 
 	mockCharRepo := &MockCharacterRepo{}
 
-	proc := NewGameProcessor(mockAuthRepo, mockWorldRepo, mockCharRepo, lookService, entityService, interviewService, spatialService, nil, nil, worldEntityService, nil, combatService, inventoryService, nil, craftingService, nil, nil)
+	interactionService := interaction.NewService(interviewService)
+
+	proc := NewGameProcessor(mockAuthRepo, mockWorldRepo, mockCharRepo, lookService, entityService, interviewService, spatialService, nil, nil, worldEntityService, nil, combatService, inventoryService, interactionService, craftingService, nil, nil)
 
 	// Create and set up the hub
 	hub := websocket.NewHub(proc)
@@ -322,37 +341,8 @@ func TestCardinalDirections(t *testing.T) {
 	}
 }
 
-// TestHandleOpen tests the open command - not supported in lobby
-func TestHandleOpen_Door(t *testing.T) {
-	processor, client, _, _ := setupTest(t)
-	target := "door"
-	cmd := &websocket.CommandData{
-		Action: "open",
-		Target: &target,
-	}
-
-	err := processor.ProcessCommand(context.Background(), client, cmd)
-
-	require.NoError(t, err)
-	require.Len(t, client.messages, 1)
-	assert.Contains(t, client.messages[0].Text, "You open the door")
-}
-
-func TestHandleOpen_Container(t *testing.T) {
-	processor, client, _, _ := setupTest(t)
-	target := "chest"
-	cmd := &websocket.CommandData{
-		Action: "open",
-		Target: &target,
-	}
-
-	err := processor.ProcessCommand(context.Background(), client, cmd)
-
-	require.NoError(t, err)
-	require.Len(t, client.messages, 1)
-	assert.Contains(t, client.messages[0].Text, "You open the chest")
-}
-
+// TestHandleOpen tests the open command; see open_command_test.go for doors
+// and containers
 func TestHandleOpen_NoTarget(t *testing.T) {
 	processor, client, _, _ := setupTest(t)
 	cmd := &websocket.CommandData{
@@ -477,9 +467,10 @@ func TestHandleSay_NoMessage(t *testing.T) {
 	assert.Contains(t, client.messages[0].Text, "What do you want to say")
 }
 
-// TestHandleWhisper tests the whisper command - not supported in lobby
+// TestHandleWhisper tests the whisper command
 func TestHandleWhisper_ToNearbyPlayer(t *testing.T) {
-	processor, client, _, _ := setupTest(t)
+	processor, client, authRepo, _ := setupTest(t)
+	bob := addWhisperPlayer(t, processor, authRepo, "Bob", 7, 5)
 	recipient := "Bob"
 	message := "psst, secret"
 	cmd := &websocket.CommandData{
@@ -496,6 +487,7 @@ func TestHandleWhisper_ToNearbyPlayer(t *testing.T) {
 	// It sends "whisper" type
 	assert.Equal(t, "whisper", client.messages[0].Type)
 	assert.Contains(t, client.messages[0].Text, "You whisper to Bob")
+	require.Len(t, bob.Send, 1, "Bob hears the whisper")
 }
 
 func TestHandleWhisper_NoRecipient(t *testing.T) {
@@ -752,9 +744,10 @@ func TestHandleAttack_NoTarget(t *testing.T) {
 	assert.Contains(t, err.Error(), "target required")
 }
 
-// TestHandleTalk tests the talk command - not supported in lobby
+// TestHandleTalk tests the talk command
 func TestHandleTalk(t *testing.T) {
-	processor, client, _, _ := setupTest(t)
+	merchant := &worldentity.WorldEntity{ID: uuid.New(), Name: "merchant", EntityType: worldentity.EntityTypeNPC}
+	processor, client, _ := setupOpenTest(t, nil, merchant)
 	target := "merchant"
 	cmd := &websocket.CommandData{
 		Action: "talk",
@@ -825,7 +818,7 @@ func TestHandleCraft_NoTarget(t *testing.T) {
 	assert.Len(t, client.messages, 0)
 }
 
-// TestHandleUse tests the use command - not supported in lobby
+// TestHandleUse tests the use command with an item the character lacks
 func TestHandleUse(t *testing.T) {
 	processor, client, _, _ := setupTest(t)
 	target := "potion"
@@ -836,9 +829,8 @@ func TestHandleUse(t *testing.T) {
 
 	err := processor.ProcessCommand(context.Background(), client, cmd)
 
-	require.NoError(t, err)
-	require.Len(t, client.messages, 1)
-	assert.Contains(t, client.messages[0].Text, "use the potion")
+	assert.ErrorIs(t, err, apperrors.ErrItemNotFound)
+	assert.Len(t, client.messages, 0)
 }
 
 func TestHandleUse_NoTarget(t *testing.T) {
@@ -850,7 +842,7 @@ func TestHandleUse_NoTarget(t *testing.T) {
 	err := processor.ProcessCommand(context.Background(), client, cmd)
 
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "item required")
+	assert.Contains(t, err.Error(), "Use what?")
 }
 
 // TestInvalidCommand tests unknown commands
@@ -1031,6 +1023,14 @@ func (m *MockInventoryRepo) RemoveItem(ctx context.Context, charID uuid.UUID, it
 	return nil
 }
 
+func (m *MockInventoryRepo) SetEquippedSlot(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, slot inventory.Slot) error {
+	return nil
+}
+
+func (m *MockInventoryRepo) SetMetadata(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, metadata map[string]interface{}) error {
+	return nil
+}
+
 func (m *MockInventoryRepo) GetInventory(ctx context.Context, charID uuid.UUID) ([]inventory.InventoryItem, error) {
 	// Return a sword for testing
 	return []inventory.InventoryItem{
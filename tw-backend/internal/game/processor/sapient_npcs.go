@@ -0,0 +1,76 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"tw-backend/internal/worldentity"
+)
+
+// MetaSpeciesID links an NPC to the simulated species it speaks for
+const MetaSpeciesID = "species_id"
+
+// envoyOffset is how far from the player a species' envoy appears, inside
+// TalkRange so it can be spoken to at once
+const envoyOffset = 2.0
+
+// ensureSapientEnvoy makes a species that reached sapience addressable: the
+// first time, an NPC envoy named for the species appears near (x, y). With no
+// scripted dialogue, talk improvises its lines from the description. Returns
+// the envoy, or nil if the world has no entity service.
+func (p *GameProcessor) ensureSapientEnvoy(ctx context.Context, worldID, speciesID uuid.UUID, name string, x, y float64) (*worldentity.WorldEntity, error) {
+	if p.worldEntityService == nil {
+		return nil, nil
+	}
+	p.sapientMu.Lock()
+	defer p.sapientMu.Unlock()
+
+	entities, err := p.worldEntityService.GetEntitiesInWorld(ctx, worldID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look for %s's envoy: %w", name, err)
+	}
+	for _, e := range entities {
+		if e.EntityType == worldentity.EntityTypeNPC && e.Metadata[MetaSpeciesID] == speciesID.String() {
+			return e, nil
+		}
+	}
+
+	envoy := &worldentity.WorldEntity{
+		ID:           uuid.New(),
+		WorldID:      worldID,
+		EntityType:   worldentity.EntityTypeNPC,
+		Name:         name,
+		Description:  fmt.Sprintf("An envoy of the %s, a people newly awakened to thought.", name),
+		X:            x + envoyOffset,
+		Y:            y,
+		Interactable: true,
+		Metadata:     map[string]interface{}{MetaSpeciesID: speciesID.String()},
+	}
+	if err := p.worldEntityService.Create(ctx, envoy); err != nil {
+		return nil, fmt.Errorf("failed to create %s's envoy: %w", name, err)
+	}
+	return envoy, nil
+}
+
+// greetSapientSpecies places a newly sapient species' envoy beside the first
+// player found in the world, so background runs make them addressable too.
+// Nobody online means the envoy waits for the next sapience report.
+func (p *GameProcessor) greetSapientSpecies(worldID, speciesID uuid.UUID, name string) {
+	if p.Hub == nil {
+		return
+	}
+	ctx := context.Background()
+	for _, c := range p.Hub.GetClientsByWorldID(worldID) {
+		char, err := p.authRepo.GetCharacter(ctx, c.GetCharacterID())
+		if err != nil || char == nil {
+			continue
+		}
+		if _, err := p.ensureSapientEnvoy(ctx, worldID, speciesID, name, char.PositionX, char.PositionY); err != nil {
+			log.Printf("[SAPIENCE] %v", err)
+		}
+		return
+	}
+}
@@ -0,0 +1,119 @@
+package processor
+
+import (
+	"fmt"
+	"sync"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/ecosystem"
+)
+
+// maxProgressEvents caps the recent events carried by one sim_progress message
+const maxProgressEvents = 10
+
+// simProgressStream sends world simulate's structured sim_progress messages,
+// which let the client draw a live timeline of a long run.
+//
+// Messages are sent from a goroutine of its own so a slow client never holds
+// up the simulation. Updates pile up in a single slot: if the client hasn't
+// taken the last one by the time the next is ready, the two are coalesced
+// into the newer, so years always arrive in increasing order.
+type simProgressStream struct {
+	client  websocket.GameClient
+	terrain func() ecosystem.GeologyStats
+
+	mu     sync.Mutex
+	events []string // Noted since the last update
+
+	pending   chan map[string]interface{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newSimProgressStream starts streaming progress to client. terrain, if set,
+// is read on the simulation's goroutine at each update. Close must be called
+// once the run ends.
+func newSimProgressStream(client websocket.GameClient, terrain func() ecosystem.GeologyStats) *simProgressStream {
+	s := &simProgressStream{
+		client:  client,
+		terrain: terrain,
+		pending: make(chan map[string]interface{}, 1),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// run delivers updates until Close
+func (s *simProgressStream) run() {
+	defer close(s.done)
+	for metadata := range s.pending {
+		s.client.SendGameMessage("sim_progress", fmt.Sprintf("Year %d of %d (%d%%)",
+			metadata["year"], metadata["target_year"], metadata["percent"]), metadata)
+	}
+}
+
+// noteEvent records an event for the next update, keeping only the latest
+// maxProgressEvents
+func (s *simProgressStream) noteEvent(msg string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, msg)
+	if len(s.events) > maxProgressEvents {
+		s.events = s.events[len(s.events)-maxProgressEvents:]
+	}
+}
+
+// publish queues an update without waiting for the client. An update still
+// queued is replaced, its events carried over.
+func (s *simProgressStream) publish(update ecosystem.ProgressUpdate) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	events := s.events
+	s.events = nil
+	s.mu.Unlock()
+
+	select {
+	case stale := <-s.pending:
+		// publish is only called from the simulation, so nothing else can
+		// fill the slot before the send below
+		if older, ok := stale["events"].([]string); ok {
+			events = append(older, events...)
+		}
+	default:
+	}
+	if len(events) > maxProgressEvents {
+		events = events[len(events)-maxProgressEvents:]
+	}
+
+	metadata := map[string]interface{}{
+		"year":        update.Year,
+		"start_year":  update.StartYear,
+		"target_year": update.TargetYear,
+		"percent":     update.Percent,
+		"events":      events,
+	}
+	if update.HasStats {
+		metadata["population"] = update.Population
+		metadata["species"] = update.Species
+		metadata["extinct"] = update.Extinct
+	}
+	if s.terrain != nil {
+		metadata["terrain"] = s.terrain()
+	}
+	s.pending <- metadata
+}
+
+// Close sends any queued update and waits for it to go out, so progress
+// always precedes the run's summary. Safe to call more than once.
+func (s *simProgressStream) Close() {
+	s.closeOnce.Do(func() {
+		close(s.pending)
+		<-s.done
+	})
+}
@@ -0,0 +1,50 @@
+package processor
+
+import (
+	"testing"
+
+	"tw-backend/internal/ecosystem"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowClient holds every message until released, like a client whose
+// connection has stalled
+type slowClient struct {
+	mockClient
+	release chan struct{}
+}
+
+func (c *slowClient) SendGameMessage(msgType, text string, metadata map[string]interface{}) {
+	<-c.release
+	c.mockClient.SendGameMessage(msgType, text, metadata)
+}
+
+func TestSimProgressStream_CoalescesForSlowClient(t *testing.T) {
+	client := &slowClient{release: make(chan struct{})}
+	stream := newSimProgressStream(client, nil)
+
+	// None of these may block, though the client takes nothing
+	for year := int64(100); year <= 1000; year += 100 {
+		stream.noteEvent("event")
+		stream.publish(ecosystem.ProgressUpdate{Year: year, TargetYear: 1000, Percent: int(year / 10)})
+	}
+	close(client.release)
+	stream.Close()
+
+	var years []int64
+	for _, m := range client.messages {
+		require.Equal(t, "sim_progress", m.Type)
+		years = append(years, m.Metadata["year"].(int64))
+	}
+	require.NotEmpty(t, years)
+	assert.Less(t, len(years), 10, "stalled updates should be coalesced")
+	assert.Equal(t, int64(1000), years[len(years)-1], "the latest update should always go out")
+	for i := 1; i < len(years); i++ {
+		assert.Greater(t, years[i], years[i-1])
+	}
+
+	last := client.messages[len(client.messages)-1]
+	assert.LessOrEqual(t, len(last.Metadata["events"].([]string)), maxProgressEvents)
+}
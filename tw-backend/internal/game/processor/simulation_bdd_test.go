@@ -3,6 +3,7 @@ package processor_test
 import (
 	"testing"
 
+	"tw-backend/internal/ecosystem"
 	"tw-backend/internal/game/processor"
 
 	"github.com/stretchr/testify/assert"
@@ -94,6 +95,86 @@ func TestBDD_WorldSimulate_GoalFlag(t *testing.T) {
 	assert.Equal(t, "sapience", config.Goal, "Goal should be parsed")
 }
 
+// -----------------------------------------------------------------------------
+// Scenario: Fresh Flag
+// -----------------------------------------------------------------------------
+// Given: Command with and without --fresh
+// When: ParseSimulationArgs is called
+// Then: Fresh should only be set when requested (continuing is the default)
+func TestBDD_WorldSimulate_FreshFlag(t *testing.T) {
+	config := processor.ParseSimulationArgs("2000000 --fresh")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.True(t, config.Fresh, "--fresh should request a restart from year 0")
+
+	config = processor.ParseSimulationArgs("2000000")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.False(t, config.Fresh, "Continuing an existing world should be the default")
+}
+
+// -----------------------------------------------------------------------------
+// Scenario: Progress Granularity
+// -----------------------------------------------------------------------------
+// Given: Command with --progress-steps
+// When: ParseSimulationArgs is called
+// Then: ProgressSteps should be set, and invalid values left at the default
+func TestBDD_WorldSimulate_ProgressStepsFlag(t *testing.T) {
+	config := processor.ParseSimulationArgs("1000000 --progress-steps 4")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.Equal(t, 4, config.ProgressSteps, "ProgressSteps should be 4")
+
+	config = processor.ParseSimulationArgs("1000000 --progress-steps zero")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.Equal(t, 0, config.ProgressSteps, "Invalid granularity should fall back to the default")
+}
+
+// -----------------------------------------------------------------------------
+// Scenario: Atmosphere Configuration
+// -----------------------------------------------------------------------------
+// Given: Command with --atmosphere and --greenhouse
+// When: ParseSimulationArgs is called
+// Then: The preset and sensitivity should be set, and invalid sensitivity ignored
+func TestBDD_WorldSimulate_AtmosphereFlags(t *testing.T) {
+	config := processor.ParseSimulationArgs("1000000 --atmosphere venus --greenhouse 8")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.Equal(t, "venus", config.Atmosphere)
+	assert.Equal(t, 8.0, config.Greenhouse)
+
+	config = processor.ParseSimulationArgs("1000000 --greenhouse -2")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.Zero(t, config.Greenhouse, "Non-positive sensitivity should fall back to the default")
+
+	config = processor.ParseSimulationArgs("1000000 --atmosphere mars --co2 0.5 --n2 0.02 --o2 0")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	require.NotNil(t, config.CO2)
+	require.NotNil(t, config.N2)
+	require.NotNil(t, config.O2)
+	assert.Equal(t, 0.5, *config.CO2)
+	assert.Equal(t, 0.02, *config.N2)
+	assert.Zero(t, *config.O2, "A zero mass is a valid override")
+
+	config = processor.ParseSimulationArgs("1000000 --co2 -1 --n2 lots")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.Nil(t, config.CO2, "Negative masses should be ignored")
+	assert.Nil(t, config.N2, "Unparseable masses should be ignored")
+	assert.Nil(t, config.O2, "Unset gases keep the preset")
+}
+
+// -----------------------------------------------------------------------------
+// Scenario: Mutation Pulse
+// -----------------------------------------------------------------------------
+// Given: Command with --mutation-pulse
+// When: ParseSimulationArgs is called
+// Then: MutationPulse should be enabled (and stay off by default)
+func TestBDD_WorldSimulate_MutationPulseFlag(t *testing.T) {
+	config := processor.ParseSimulationArgs("1000000 --mutation-pulse")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.True(t, config.MutationPulse)
+
+	config = processor.ParseSimulationArgs("1000000")
+	require.NotNil(t, config, "ParseSimulationArgs should return a config")
+	assert.False(t, config.MutationPulse, "Mutation pulse should be opt-in")
+}
+
 // -----------------------------------------------------------------------------
 // Scenario: Combined Flags
 // -----------------------------------------------------------------------------
@@ -184,3 +265,15 @@ func TestBDD_WorldSimulate_WhitespaceInput(t *testing.T) {
 
 	assert.Nil(t, config, "Whitespace-only input should return nil config")
 }
+
+func TestBDD_WorldSimulate_VerbosityFlag(t *testing.T) {
+	config := processor.ParseSimulationArgs("1000000 --verbosity quiet")
+	require.NotNil(t, config)
+	assert.Equal(t, ecosystem.LogLevelWarn, config.Verbosity)
+
+	config = processor.ParseSimulationArgs("1000000")
+	require.NotNil(t, config)
+	assert.Equal(t, ecosystem.LogLevelInfo, config.Verbosity, "Info should be the default")
+
+	assert.Nil(t, processor.ParseSimulationArgs("1000000 --verbosity shouting"), "Unknown verbosity should be rejected")
+}
@@ -0,0 +1,117 @@
+package processor
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
+)
+
+// Read-only simulation state accessors used by the REST API (api.SimulationStateProvider).
+// Each returns nil when the world has no simulation state.
+
+// SimulationGeology returns geology stats from the in-memory world geology,
+// read under the runner's lock while one is simulating the world
+func (p *GameProcessor) SimulationGeology(ctx context.Context, worldID uuid.UUID) (*ecosystem.GeologyStats, error) {
+	if runner := p.getRunner(worldID); runner != nil {
+		if stats, ok := runner.GeologyStats(); ok {
+			return &stats, nil
+		}
+	}
+	geology := p.geologyFor(worldID)
+	if geology == nil && p.mapService != nil {
+		geology = p.mapService.GetWorldGeology(worldID)
+	}
+	if geology == nil || !geology.IsInitialized() {
+		return nil, nil
+	}
+	stats := geology.GetStats()
+	return &stats, nil
+}
+
+// viewPopulation calls fn with the world's population simulator: the live
+// one under the runner's lock, or else the last persisted snapshot. False
+// when the world has neither.
+func (p *GameProcessor) viewPopulation(ctx context.Context, worldID uuid.UUID, fn func(*population.PopulationSimulator)) (bool, error) {
+	if runner := p.getRunner(worldID); runner != nil && runner.ViewPopulation(fn) {
+		return true, nil
+	}
+	if p.simSnapshotRepo == nil {
+		return false, nil
+	}
+	sim, err := p.simSnapshotRepo.LoadSnapshot(ctx, worldID)
+	if err != nil || sim == nil {
+		return false, err
+	}
+	fn(sim)
+	return true, nil
+}
+
+// simulatedSpecies returns a copy of a world's record of a simulated species,
+// for the look service to describe its creatures by
+func (p *GameProcessor) simulatedSpecies(ctx context.Context, worldID, speciesID uuid.UUID) (*population.SpeciesPopulation, bool) {
+	var record *population.SpeciesPopulation
+	if _, err := p.viewPopulation(ctx, worldID, func(sim *population.PopulationSimulator) {
+		record = speciesRecord(sim, speciesID)
+	}); err != nil {
+		return nil, false
+	}
+	return record, record != nil
+}
+
+// speciesRecord returns a copy of a species' population in the biome where
+// it is most numerous, so its traits don't depend on map order. Nil if no
+// biome has it.
+func speciesRecord(sim *population.PopulationSimulator, speciesID uuid.UUID) *population.SpeciesPopulation {
+	var best *population.SpeciesPopulation
+	var bestBiome uuid.UUID
+	for biomeID, biome := range sim.Biomes {
+		sp, ok := biome.Species[speciesID]
+		if !ok {
+			continue
+		}
+		if best == nil || sp.Count > best.Count || (sp.Count == best.Count && biomeID.String() < bestBiome.String()) {
+			best, bestBiome = sp, biomeID
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	record := *best
+	return &record
+}
+
+// SimulationPopulation returns a copy of the live population simulator, safe
+// to read while the runner ticks, falling back to the last persisted snapshot
+// when no runner is active
+func (p *GameProcessor) SimulationPopulation(ctx context.Context, worldID uuid.UUID) (*population.PopulationSimulator, error) {
+	if runner := p.getRunner(worldID); runner != nil {
+		sim, err := runner.SnapshotPopulation()
+		if err != nil || sim != nil {
+			return sim, err
+		}
+	}
+	if p.simSnapshotRepo == nil {
+		return nil, nil
+	}
+	return p.simSnapshotRepo.LoadSnapshot(ctx, worldID)
+}
+
+// SimulationTimeline returns up to limit recent runner events. Events are only
+// kept in memory, so a world with only a persisted snapshot has an empty timeline.
+func (p *GameProcessor) SimulationTimeline(ctx context.Context, worldID uuid.UUID, limit int) ([]ecosystem.RunnerEvent, error) {
+	if runner := p.getRunner(worldID); runner != nil {
+		events := runner.GetRecentEvents(limit)
+		if events == nil {
+			events = []ecosystem.RunnerEvent{}
+		}
+		return events, nil
+	}
+	sim, err := p.SimulationPopulation(ctx, worldID)
+	if err != nil || sim == nil {
+		return nil, err
+	}
+	return []ecosystem.RunnerEvent{}, nil
+}
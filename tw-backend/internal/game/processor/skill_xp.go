@@ -0,0 +1,99 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/combat/damage"
+	"tw-backend/internal/eventstore"
+	"tw-backend/internal/game/services/combat"
+	"tw-backend/internal/game/services/inventory"
+	"tw-backend/internal/game/services/look"
+	"tw-backend/internal/skills"
+
+	"github.com/google/uuid"
+)
+
+// Base XP for the actions that teach skills, before diminishing returns
+const (
+	CraftXP         = 20.0 // Crafting skill, per successful craft
+	CombatVictoryXP = 50.0 // Weapon skill, per foe defeated
+	ExamineXP       = 5.0  // Perception, per examination
+)
+
+// awardXP awards a character XP in a skill, telling their client (or, if
+// client is nil, their connection if they have one) and publishing a
+// skill_level_up event if they reach a new level. Best-effort: failures are
+// logged and the action carries on.
+func (p *GameProcessor) awardXP(ctx context.Context, client websocket.GameClient, charID uuid.UUID, skill string, amount float64) {
+	service := p.skillService()
+	if service == nil {
+		return
+	}
+	_, lvl, err := service.AwardXP(ctx, charID, skill, amount)
+	if err != nil {
+		log.Printf("[SKILLS] failed to award %s XP to %s: %v", skill, charID, err)
+		return
+	}
+	if lvl == nil {
+		return
+	}
+
+	if client == nil && p.Hub != nil {
+		if c, ok := p.Hub.GetClientByCharacter(charID); ok {
+			client = c
+		}
+	}
+	if client != nil {
+		client.SendGameMessage("skill_level_up", fmt.Sprintf("Your %s skill has increased to %d!", lvl.SkillName, lvl.NewLevel), map[string]interface{}{
+			"skill": lvl.SkillName,
+			"level": lvl.NewLevel,
+		})
+	}
+
+	if p.events == nil {
+		return
+	}
+	payload := eventstore.SkillLevelUpPayload{
+		CharacterID: charID,
+		Skill:       lvl.SkillName,
+		Level:       lvl.NewLevel,
+		LeveledAt:   lvl.Timestamp,
+	}
+	if err := p.events.Publish(ctx, eventstore.AggregateSkillSheet, eventstore.SkillSheetAggregateID(charID), eventstore.EventTypeSkillLevelUp, payload); err != nil {
+		log.Printf("[SKILLS] failed to publish level up for %s: %v", charID, err)
+	}
+}
+
+// awardVictoryXP gives a character who defeated a foe XP in the weapon they
+// fought with. NPCs don't learn.
+func (p *GameProcessor) awardVictoryXP(result *combat.AttackResult) {
+	if result == nil || !result.TargetDefeated {
+		return
+	}
+	ctx := context.Background()
+	if char, err := p.authRepo.GetCharacter(ctx, result.AttackerID); err != nil || char == nil {
+		return
+	}
+
+	kind := damage.WeaponBludgeoning // Fists
+	if attacker := p.combatService.Combatant(result.AttackerID); attacker != nil && attacker.Weapon != nil && !attacker.Weapon.IsBroken() {
+		kind = attacker.Weapon.Type
+	}
+	p.awardXP(ctx, nil, result.AttackerID, inventory.WeaponSkill(kind), CombatVictoryXP)
+}
+
+// examineDifficulty is how hard a target is to examine: a world entity's
+// perception DC, or medium for anything else
+func (p *GameProcessor) examineDifficulty(ctx context.Context, worldID uuid.UUID, target string) int {
+	if target == "" || p.worldEntityService == nil {
+		return skills.DifficultyMedium
+	}
+	entity, err := p.worldEntityService.GetEntityByName(ctx, worldID, target)
+	if err != nil || entity == nil {
+		return skills.DifficultyMedium
+	}
+	return look.PerceptionDC(entity)
+}
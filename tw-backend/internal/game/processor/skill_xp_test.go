@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/character"
+	"tw-backend/internal/combat/damage"
+	"tw-backend/internal/eventstore"
+	"tw-backend/internal/game/services/combat"
+	"tw-backend/internal/game/services/inventory"
+	"tw-backend/internal/skills"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCraft_GrantsCraftingXP(t *testing.T) {
+	recipe := swordRecipe()
+	recipe.MinSkillLevel = 9
+	proc, client, _ := setupCraftTest(t, recipe, []inventory.InventoryItem{
+		{ItemID: ironIngot, Quantity: 3},
+		{ItemID: leather, Quantity: 1},
+	}, forge())
+	// A few XP short of Smithing 10
+	repo := &fakeSkillsRepo{skills: []skills.Skill{{Name: skills.SkillSmithing, XP: xpForLevel(10) - 5}}}
+	proc.skillsRepo = repo
+	store := &memEventStore{}
+	pub := eventstore.NewPublisher(store)
+	proc.SetEventPublisher(pub)
+	before := repo.xp(skills.SkillSmithing)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, craftCommand("iron sword")))
+	pub.Flush()
+
+	assert.InDelta(t, before+CraftXP, repo.xp(skills.SkillSmithing), 1e-9, "a recipe at the crafter's level gives full XP")
+	require.Len(t, client.messages, 2)
+	levelUp := client.messages[1]
+	assert.Equal(t, "skill_level_up", levelUp.Type)
+	assert.Equal(t, "Your Smithing skill has increased to 10!", levelUp.Text)
+	assert.Equal(t, 10, levelUp.Metadata["level"])
+
+	// Published on the skill sheet, apart from the Character aggregate
+	require.Len(t, store.events, 1)
+	assert.Equal(t, eventstore.EventTypeSkillLevelUp, store.events[0].EventType)
+	assert.Equal(t, "skills:"+client.GetCharacterID().String(), store.events[0].AggregateID)
+}
+
+func TestHandleLook_ExamineGrantsPerceptionXP(t *testing.T) {
+	proc, client := setupExamineTest(t, 10)
+	repo := proc.skillsRepo.(*fakeSkillsRepo)
+	before := repo.xp(skills.SkillPerception)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, examineCommand("statue")))
+	first := repo.xp(skills.SkillPerception) - before
+	assert.InDelta(t, ExamineXP, first, 1e-9, "the statue is a challenge for a novice")
+
+	// Staring at it over and over teaches less and less
+	for i := 0; i < 10; i++ {
+		require.NoError(t, proc.ProcessCommand(context.Background(), client, examineCommand("statue")))
+	}
+	last := repo.xp(skills.SkillPerception)
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, examineCommand("statue")))
+	assert.Less(t, repo.xp(skills.SkillPerception)-last, first)
+
+	// A plain look teaches nothing
+	last = repo.xp(skills.SkillPerception)
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, lookCommand("statue")))
+	assert.Equal(t, last, repo.xp(skills.SkillPerception))
+}
+
+func TestAwardVictoryXP_GrantsWeaponXP(t *testing.T) {
+	proc, client, _, _ := setupTest(t)
+	repo := &fakeSkillsRepo{}
+	proc.skillsRepo = repo
+	charID := client.GetCharacterID()
+
+	proc.combatService.JoinCombatFromCharacter(&character.Character{ID: charID, SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100}})
+	sword := &damage.Weapon{Name: "Iron Sword", Type: damage.WeaponSlashing, BaseDamage: 20, Durability: 100, MaxDurability: 100}
+	proc.combatService.SetEquipment(charID, sword, nil, damage.Bonuses{})
+
+	proc.awardVictoryXP(&combat.AttackResult{AttackerID: charID, TargetDefeated: true})
+	assert.Equal(t, CombatVictoryXP, repo.xp(skills.SkillSlashing))
+
+	proc.awardVictoryXP(&combat.AttackResult{AttackerID: charID})
+	assert.Equal(t, CombatVictoryXP, repo.xp(skills.SkillSlashing), "only a win teaches")
+}
+
+func lookCommand(target string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "look", Target: &target}
+}
@@ -0,0 +1,77 @@
+package processor
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tw-backend/internal/character"
+	"tw-backend/internal/player"
+)
+
+// staminaPool is a character's stamina outside combat
+type staminaPool struct {
+	*player.StaminaManager
+	regenRate float64 // Per second, from Endurance
+	resting   float64 // Seconds rested toward the next point regained
+}
+
+// characterStamina returns a character's stamina pool, full on first use.
+// Without event-sourced attributes it falls back to the human template, as
+// attacks do.
+func (p *GameProcessor) characterStamina(ctx context.Context, charID uuid.UUID) *staminaPool {
+	p.staminaMu.Lock()
+	pool, ok := p.stamina[charID]
+	p.staminaMu.Unlock()
+	if ok {
+		return pool
+	}
+
+	attrs := character.GetSpeciesTemplate(character.SpeciesHuman).BaseAttrs
+	if p.characterRepo != nil {
+		if char, err := p.characterRepo.Load(ctx, charID); err == nil && char != nil {
+			attrs = char.BaseAttrs
+		}
+	}
+	pool = &staminaPool{
+		StaminaManager: player.NewStaminaManager(character.CalculateSecondaryAttributes(attrs).MaxStamina),
+		regenRate:      player.CalculateRegenRate(attrs.Endurance),
+	}
+
+	p.staminaMu.Lock()
+	defer p.staminaMu.Unlock()
+	if existing, ok := p.stamina[charID]; ok {
+		return existing
+	}
+	if p.stamina == nil {
+		p.stamina = make(map[uuid.UUID]*staminaPool)
+	}
+	p.stamina[charID] = pool
+	return pool
+}
+
+// updateEncumbrance re-weighs a character's load after it changes, so their
+// movement cost and regeneration follow it
+func (p *GameProcessor) updateEncumbrance(ctx context.Context, charID uuid.UUID) *staminaPool {
+	pool := p.characterStamina(ctx, charID)
+	pool.SetEncumbrance(p.encumbrance(ctx, charID))
+	return pool
+}
+
+// regenerateStamina restores stamina over dt, more slowly under a heavy load.
+// Ticks are shorter than a point takes, so rest accumulates until one is due.
+func (p *GameProcessor) regenerateStamina(dt time.Duration) {
+	p.staminaMu.Lock()
+	defer p.staminaMu.Unlock()
+	for _, pool := range p.stamina {
+		if pool.Current() >= pool.Max() {
+			pool.resting = 0
+			continue
+		}
+		pool.resting += dt.Seconds()
+		if pool.Regenerate(pool.resting, pool.regenRate) > 0 {
+			pool.resting = 0
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tw-backend/internal/character"
+)
+
+// StrainCooldown is how long a character who failed to force or shift an
+// object must rest before trying it again. Natural 96+ always succeeds, so
+// without it anyone could spam the command until the dice came up.
+const StrainCooldown = 30 * time.Second
+
+// strainKey is one character's attempts on one object
+type strainKey struct {
+	charID, entityID uuid.UUID
+}
+
+// strainWait returns how much longer charID must wait before straining at
+// entityID again, or 0 if they can try now
+func (p *GameProcessor) strainWait(charID, entityID uuid.UUID) time.Duration {
+	p.strainMu.Lock()
+	defer p.strainMu.Unlock()
+	failed, ok := p.strained[strainKey{charID, entityID}]
+	if !ok {
+		return 0
+	}
+	if wait := StrainCooldown - time.Since(failed); wait > 0 {
+		return wait
+	}
+	delete(p.strained, strainKey{charID, entityID})
+	return 0
+}
+
+// noteStrainFailure starts charID's cooldown on entityID
+func (p *GameProcessor) noteStrainFailure(charID, entityID uuid.UUID) {
+	p.strainMu.Lock()
+	defer p.strainMu.Unlock()
+	if p.strained == nil {
+		p.strained = make(map[strainKey]time.Time)
+	}
+	p.strained[strainKey{charID, entityID}] = time.Now()
+}
+
+// mightCheck rolls a Might check against difficulty for an attempt on
+// entityID, starting the retry cooldown if it fails
+func (p *GameProcessor) mightCheck(ctx context.Context, charID, entityID uuid.UUID, difficulty int) bool {
+	if p.skillCheck(0, p.baseAttributes(ctx, charID).Might, difficulty).Success {
+		return true
+	}
+	p.noteStrainFailure(charID, entityID)
+	return false
+}
+
+// baseAttributes returns a character's attributes, falling back to the
+// human template without event-sourced data as attacks do
+func (p *GameProcessor) baseAttributes(ctx context.Context, charID uuid.UUID) character.Attributes {
+	if p.characterRepo != nil {
+		if char, err := p.characterRepo.Load(ctx, charID); err == nil && char != nil {
+			return char.BaseAttrs
+		}
+	}
+	return character.GetSpeciesTemplate(character.SpeciesHuman).BaseAttrs
+}
@@ -0,0 +1,114 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/game/services/interaction"
+	"tw-backend/internal/worldentity"
+)
+
+func talkCommand(target string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "talk", Target: &target}
+}
+
+// ferryman has a two-node dialogue tree
+func ferryman() *worldentity.WorldEntity {
+	return &worldentity.WorldEntity{
+		ID:         uuid.New(),
+		Name:       "old ferryman",
+		EntityType: worldentity.EntityTypeNPC,
+		Metadata: map[string]interface{}{
+			interaction.MetaDialogue: interaction.DialogueTree{
+				Start: "greet",
+				Nodes: map[string]interaction.DialogueNode{
+					"greet": {Text: "Crossing? One silver.", Options: []interaction.DialogueOption{
+						{Text: "Where does it go?", Next: "route"},
+						{Text: "No thanks"},
+					}},
+					"route": {Text: "To the marsh village and back.", Options: []interaction.DialogueOption{
+						{Text: "Back to the fare", Next: "greet"},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func lastDialogue(t *testing.T, client *mockClient) websocket.GameMessageData {
+	t.Helper()
+	require.NotEmpty(t, client.messages)
+	msg := client.messages[len(client.messages)-1]
+	require.Equal(t, "dialogue", msg.Type)
+	return msg
+}
+
+func TestHandleTalk_DialogueTree(t *testing.T) {
+	npc := ferryman()
+	proc, client, _ := setupOpenTest(t, nil, npc)
+	ctx := context.Background()
+
+	require.NoError(t, proc.ProcessCommand(ctx, client, talkCommand("old ferryman")))
+	msg := lastDialogue(t, client)
+	assert.Equal(t, "greet", msg.Metadata["nodeID"])
+	assert.Equal(t, npc.ID.String(), msg.Metadata["npcID"])
+	assert.Equal(t, []string{"Where does it go?", "No thanks"}, msg.Metadata["available"])
+	assert.Contains(t, msg.Text, "1. Where does it go?")
+
+	// Whatever follows the NPC's name picks an option
+	require.NoError(t, proc.ProcessCommand(ctx, client, talkCommand("old ferryman 1")))
+	msg = lastDialogue(t, client)
+	assert.Equal(t, "route", msg.Metadata["nodeID"])
+	assert.Equal(t, "To the marsh village and back.", msg.Metadata["dialogue"])
+
+	require.NoError(t, proc.ProcessCommand(ctx, client, talkCommand("old ferryman back")))
+	assert.Equal(t, "greet", lastDialogue(t, client).Metadata["nodeID"])
+
+	require.NoError(t, proc.ProcessCommand(ctx, client, talkCommand("old ferryman no thanks")))
+	assert.Equal(t, true, lastDialogue(t, client).Metadata["ended"])
+}
+
+func TestHandleTalk_NobodyNearby(t *testing.T) {
+	far := ferryman()
+	notNPC := &worldentity.WorldEntity{ID: uuid.New(), Name: "statue", EntityType: worldentity.EntityTypeStatic}
+	proc, client, _ := setupOpenTest(t, nil, far, notNPC)
+	far.X += TalkRange + 5 // Out of earshot
+
+	for _, target := range []string{"old ferryman", "statue", "ghost"} {
+		client.messages = nil
+		require.NoError(t, proc.ProcessCommand(context.Background(), client, talkCommand(target)))
+		require.Len(t, client.messages, 1)
+		assert.Equal(t, "error", client.messages[0].Type, target)
+		assert.Contains(t, client.messages[0].Text, "hears you")
+	}
+}
+
+func TestHandleTalk_SapientSpeciesEnvoy(t *testing.T) {
+	proc, client, repo := setupOpenTest(t, nil)
+	ctx := context.Background()
+	char, err := proc.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	require.NoError(t, err)
+
+	speciesID := uuid.New()
+	envoy, err := proc.ensureSapientEnvoy(ctx, char.WorldID, speciesID, "Thinker", char.PositionX, char.PositionY)
+	require.NoError(t, err)
+	require.NotNil(t, envoy)
+	assert.Equal(t, speciesID.String(), envoy.Metadata[MetaSpeciesID])
+
+	// Reaching sapience again doesn't send a second envoy
+	again, err := proc.ensureSapientEnvoy(ctx, char.WorldID, speciesID, "Thinker", char.PositionX, char.PositionY)
+	require.NoError(t, err)
+	assert.Equal(t, envoy.ID, again.ID)
+	all, err := repo.GetByWorldID(ctx, char.WorldID)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	require.NoError(t, proc.ProcessCommand(ctx, client, talkCommand("thinker hello")))
+	msg := lastDialogue(t, client)
+	assert.Equal(t, envoy.ID.String(), msg.Metadata["npcID"])
+}
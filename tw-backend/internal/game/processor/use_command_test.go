@@ -0,0 +1,101 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/character"
+	apperrors "tw-backend/internal/errors"
+	"tw-backend/internal/game/services/inventory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useCommand(target string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "use", Target: &target}
+}
+
+func TestHandleUse_HealingPotion(t *testing.T) {
+	potion := inventory.InventoryItem{ItemID: uuid.New(), Name: "Healing Potion", Quantity: 2, Metadata: map[string]interface{}{
+		inventory.MetaItemType: "potion",
+		inventory.MetaHeal:     25.0,
+	}}
+	proc, client, _, inv := setupGetTest(t, []inventory.InventoryItem{potion})
+
+	// Wounded in a fight
+	charID := client.GetCharacterID()
+	proc.combatService.JoinCombatFromCharacter(&character.Character{
+		ID:       charID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	combatant := proc.combatService.Combatant(charID)
+	require.NotNil(t, combatant)
+	combatant.CurrentHP = 50
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, useCommand("healing potion")))
+
+	require.NotEmpty(t, client.messages)
+	assert.Equal(t, "You use the Healing Potion. You recover 25 HP.", client.messages[0].Text)
+	assert.Equal(t, 75, combatant.CurrentHP)
+	assert.Equal(t, 1, inv.removed[potion.ItemID], "the potion is used up")
+
+	// Drinking the second straight away is too soon
+	err := proc.ProcessCommand(context.Background(), client, useCommand("healing potion"))
+	assert.ErrorIs(t, err, apperrors.ErrCooldown)
+	assert.Equal(t, 75, combatant.CurrentHP)
+	assert.Equal(t, 1, inv.removed[potion.ItemID])
+}
+
+func TestHandleUse_NotConsumable(t *testing.T) {
+	rope := inventory.InventoryItem{ItemID: uuid.New(), Name: "Rope", Quantity: 1, Metadata: map[string]interface{}{}}
+	proc, client, _, inv := setupGetTest(t, []inventory.InventoryItem{rope})
+
+	err := proc.ProcessCommand(context.Background(), client, useCommand("rope"))
+
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCommand)
+	assert.Equal(t, "You can't use the Rope.", err.Error())
+	assert.Empty(t, inv.removed)
+}
+
+func TestHandleUse_RestoresStaminaOutOfCombat(t *testing.T) {
+	bread := inventory.InventoryItem{ItemID: uuid.New(), Name: "Bread", Quantity: 2, Metadata: map[string]interface{}{
+		inventory.MetaItemType: "food",
+		inventory.MetaStamina:  15.0,
+	}}
+	proc, client, _, inv := setupGetTest(t, []inventory.InventoryItem{bread})
+	ctx := context.Background()
+
+	// Full after resting, so eating would do nothing
+	err := proc.ProcessCommand(ctx, client, useCommand("bread"))
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCommand)
+	assert.Empty(t, inv.removed, "the bread is kept")
+
+	// Tired from travelling
+	pool := proc.characterStamina(ctx, client.GetCharacterID())
+	require.NoError(t, pool.Consume(20))
+	before := pool.Current()
+
+	require.NoError(t, proc.ProcessCommand(ctx, client, useCommand("bread")))
+
+	assert.Equal(t, before+15, pool.Current())
+	assert.Equal(t, 1, inv.removed[bread.ItemID])
+	require.NotEmpty(t, client.messages)
+	assert.Equal(t, "You use the Bread. You recover 15 stamina.", client.messages[0].Text)
+}
+
+func TestHandleUse_HealingPotionOutOfCombatIsKept(t *testing.T) {
+	potion := inventory.InventoryItem{ItemID: uuid.New(), Name: "Healing Potion", Quantity: 1, Metadata: map[string]interface{}{
+		inventory.MetaItemType: "potion",
+		inventory.MetaHeal:     25.0,
+	}}
+	proc, client, _, inv := setupGetTest(t, []inventory.InventoryItem{potion})
+
+	err := proc.ProcessCommand(context.Background(), client, useCommand("healing potion"))
+
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCommand)
+	assert.Equal(t, "You aren't wounded; the Healing Potion would be wasted.", err.Error())
+	assert.Empty(t, inv.removed, "the potion is kept")
+}
@@ -36,6 +36,14 @@ func (m *MockWeatherRepo) GetAnnualPrecipitation(ctx context.Context, cellID uui
 	return 0, nil
 }
 
+func (m *MockWeatherRepo) SavePressureField(ctx context.Context, worldID uuid.UUID, field *weather.PressureField) error {
+	return nil
+}
+
+func (m *MockWeatherRepo) GetPressureField(ctx context.Context, worldID uuid.UUID) (*weather.PressureField, error) {
+	return nil, nil
+}
+
 func (m *MockWeatherRepo) InitialiseValues(ctx context.Context, worldID uuid.UUID) error {
 	return nil
 }
@@ -0,0 +1,138 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/skills"
+	"tw-backend/internal/spatial"
+)
+
+// handleWhisper sends a private message to a player within WhisperRange.
+// Bystanders within OverhearRadius catch some of it, more the closer and
+// more perceptive they are.
+func (p *GameProcessor) handleWhisper(ctx context.Context, client websocket.GameClient, cmd *websocket.CommandData) error {
+	if cmd.Recipient == nil {
+		return errors.New("recipient required for whisper command")
+	}
+	if cmd.Message == nil {
+		return errors.New("message required for whisper command")
+	}
+
+	message := strings.TrimSpace(*cmd.Message)
+	recipientName := strings.TrimSpace(*cmd.Recipient)
+	senderUsername := client.GetUsername()
+	senderCharID := client.GetCharacterID()
+
+	sender, err := p.authRepo.GetCharacter(ctx, senderCharID)
+	if err != nil || sender == nil {
+		client.SendGameMessage("error", "Failed to find your character.", nil)
+		return nil
+	}
+	senderPos := spatial.Position{X: sender.PositionX, Y: sender.PositionY}
+
+	// Find the recipient among the players in the sender's world
+	var recipient *websocket.Client
+	if p.Hub != nil {
+		for _, c := range p.Hub.GetClientsByWorldID(sender.WorldID) {
+			if c.CharacterID != senderCharID && strings.EqualFold(c.GetUsername(), recipientName) {
+				recipient = c
+				break
+			}
+		}
+	}
+	if recipient == nil {
+		client.SendGameMessage("error", fmt.Sprintf("You don't see %s here.", recipientName), nil)
+		return nil
+	}
+
+	recipientChar, err := p.authRepo.GetCharacter(ctx, recipient.CharacterID)
+	if err != nil || recipientChar == nil {
+		client.SendGameMessage("error", fmt.Sprintf("You don't see %s here.", recipientName), nil)
+		return nil
+	}
+	distance, err := p.spatialService.Distance(ctx, sender.WorldID, senderPos, spatial.Position{X: recipientChar.PositionX, Y: recipientChar.PositionY})
+	if err != nil {
+		return fmt.Errorf("failed to measure whisper distance: %w", err)
+	}
+	if distance > WhisperRange {
+		client.SendGameMessage("error", fmt.Sprintf("%s is too far away to hear a whisper.", recipient.GetUsername()), nil)
+		return nil
+	}
+
+	client.SendGameMessage("whisper", fmt.Sprintf("You whisper to %s: %s", recipient.GetUsername(), message), map[string]interface{}{
+		"recipient": recipient.GetUsername(),
+		"message":   message,
+	})
+	recipient.SendGameMessage("whisper", fmt.Sprintf("%s whispers to you, '%s'", senderUsername, message), map[string]interface{}{
+		"sender_id":   senderCharID.String(),
+		"sender_name": senderUsername,
+		"message":     message,
+	})
+
+	p.overhearWhisper(ctx, sender.WorldID, senderPos, client, recipient, message)
+	return nil
+}
+
+// overhearWhisper lets bystanders within OverhearRadius of the sender catch
+// a garbled version of a whisper. Their positions come from the hub's index.
+func (p *GameProcessor) overhearWhisper(ctx context.Context, worldID uuid.UUID, center spatial.Position, sender websocket.GameClient, recipient *websocket.Client, message string) {
+	for _, id := range p.Hub.SpatialIndex.QueryRadius(center, OverhearRadius) {
+		if id == sender.GetCharacterID() || id == recipient.CharacterID {
+			continue
+		}
+		bystander, ok := p.Hub.GetClientByCharacter(id)
+		if !ok || bystander.WorldID != worldID {
+			continue
+		}
+		pos, ok := p.Hub.GetCharacterPosition(id)
+		if !ok {
+			continue
+		}
+		distance, err := p.spatialService.Distance(ctx, worldID, center, pos)
+		if err != nil || distance > OverhearRadius {
+			continue
+		}
+
+		clarity := overhearClarity(distance, p.skillLevel(ctx, id, skills.SkillPerception))
+		heard := garbleWhisper(message, clarity)
+		text := fmt.Sprintf("You overhear %s whisper to %s, '%s'", sender.GetUsername(), recipient.GetUsername(), heard)
+		if strings.Trim(heard, ". ") == "" {
+			text = fmt.Sprintf("You notice %s whisper something to %s.", sender.GetUsername(), recipient.GetUsername())
+		}
+		bystander.SendGameMessage("whisper_overheard", text, map[string]interface{}{
+			"sender_name":    sender.GetUsername(),
+			"recipient_name": recipient.GetUsername(),
+			"message":        heard,
+			"clarity":        clarity,
+		})
+	}
+}
+
+// overhearClarity is the share of a whisper a bystander makes out, from 0
+// to 1. It falls from full at WhisperRange to nothing at OverhearRadius, and
+// a perception of 0 halves it.
+func overhearClarity(distance float64, perception int) float64 {
+	proximity := 1 - (distance-WhisperRange)/(OverhearRadius-WhisperRange)
+	proximity = math.Max(0, math.Min(1, proximity))
+	acuity := 0.5 + math.Max(0, math.Min(100, float64(perception)))/200
+	return proximity * acuity
+}
+
+// garbleWhisper keeps a clarity share of a message's words, spread evenly
+// through it, and replaces the rest with "..."
+func garbleWhisper(message string, clarity float64) string {
+	words := strings.Fields(message)
+	for i := range words {
+		if math.Floor(float64(i+1)*clarity) == math.Floor(float64(i)*clarity) {
+			words[i] = "..."
+		}
+	}
+	return strings.Join(words, " ")
+}
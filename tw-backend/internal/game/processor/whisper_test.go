@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/auth"
+	"tw-backend/internal/game/constants"
+)
+
+// addWhisperPlayer puts a named player in the lobby at a position, both in
+// the hub and in the character store
+func addWhisperPlayer(t *testing.T, proc *GameProcessor, authRepo *auth.MockRepository, name string, x, y float64) *websocket.Client {
+	t.Helper()
+	c := addHubClient(proc.Hub, constants.LobbyWorldID, x, y)
+	c.Username = name
+	require.NoError(t, authRepo.CreateCharacter(context.Background(), &auth.Character{
+		CharacterID: c.CharacterID,
+		WorldID:     constants.LobbyWorldID,
+		Name:        name,
+		CreatedAt:   time.Now(),
+		PositionX:   x,
+		PositionY:   y,
+	}))
+	return c
+}
+
+// receivedGameMessage decodes the next game message sent to a hub client
+func receivedGameMessage(t *testing.T, c *websocket.Client) websocket.GameMessageData {
+	t.Helper()
+	require.NotEmpty(t, c.Send)
+	var msg struct {
+		Data websocket.GameMessageData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(<-c.Send, &msg))
+	return msg.Data
+}
+
+func whisperCommand(recipient, message string) *websocket.CommandData {
+	return &websocket.CommandData{Action: "whisper", Recipient: &recipient, Message: &message}
+}
+
+// The sender stands at (5, 5) in the lobby
+
+func TestHandleWhisper_InRangeDelivered(t *testing.T) {
+	proc, client, authRepo, _ := setupTest(t)
+	bob := addWhisperPlayer(t, proc, authRepo, "Bob", 8, 9)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, whisperCommand("bob", "meet me at dawn")))
+
+	require.Len(t, client.messages, 1)
+	assert.Equal(t, "whisper", client.messages[0].Type)
+	msg := receivedGameMessage(t, bob)
+	assert.Equal(t, "whisper", msg.Type)
+	assert.Contains(t, msg.Text, "whispers to you, 'meet me at dawn'")
+}
+
+func TestHandleWhisper_OutOfRange(t *testing.T) {
+	proc, client, authRepo, _ := setupTest(t)
+	bob := addWhisperPlayer(t, proc, authRepo, "Bob", 5+WhisperRange+1, 5)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, whisperCommand("Bob", "meet me at dawn")))
+
+	require.Len(t, client.messages, 1)
+	assert.Equal(t, "error", client.messages[0].Type)
+	assert.Contains(t, client.messages[0].Text, "too far away")
+	assert.Empty(t, bob.Send, "Bob hears nothing")
+}
+
+func TestHandleWhisper_UnknownRecipient(t *testing.T) {
+	proc, client, _, _ := setupTest(t)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, whisperCommand("Nobody", "hello")))
+
+	require.Len(t, client.messages, 1)
+	assert.Equal(t, "error", client.messages[0].Type)
+	assert.Contains(t, client.messages[0].Text, "You don't see Nobody here")
+}
+
+func TestHandleWhisper_BystanderOverhearsPartially(t *testing.T) {
+	proc, client, authRepo, _ := setupTest(t)
+	addWhisperPlayer(t, proc, authRepo, "Bob", 6, 5)
+	// A quarter of the way from WhisperRange to OverhearRadius, with no
+	// perception, a bystander makes out three words in eight
+	eve := addWhisperPlayer(t, proc, authRepo, "Eve", 5, 5+WhisperRange+(OverhearRadius-WhisperRange)/4)
+	faraway := addWhisperPlayer(t, proc, authRepo, "Mallory", 5, 5+OverhearRadius+5)
+
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, whisperCommand("Bob", "the key is under the third stone")))
+
+	msg := receivedGameMessage(t, eve)
+	assert.Equal(t, "whisper_overheard", msg.Type)
+	assert.Equal(t, "... ... is ... ... third ...", msg.Metadata["message"])
+	assert.Contains(t, msg.Text, "You overhear")
+	assert.InDelta(t, 0.375, msg.Metadata["clarity"], 0.01)
+	assert.Empty(t, faraway.Send, "Out of earshot entirely")
+}
+
+func TestOverhearClarity(t *testing.T) {
+	assert.Equal(t, 1.0, overhearClarity(WhisperRange, 100))
+	assert.Equal(t, 0.5, overhearClarity(0, 0), "No perception halves clarity")
+	assert.Equal(t, 0.0, overhearClarity(OverhearRadius, 100))
+	assert.Greater(t, overhearClarity(8, 60), overhearClarity(8, 20), "Sharper ears hear more")
+	assert.Greater(t, overhearClarity(7, 50), overhearClarity(12, 50), "Closer hears more")
+}
+
+func TestGarbleWhisper(t *testing.T) {
+	assert.Equal(t, "one two three four", garbleWhisper("one two three four", 1))
+	assert.Equal(t, "... two ... four", garbleWhisper("one two three four", 0.5))
+	assert.Equal(t, "... ... ... ...", garbleWhisper("one two three four", 0))
+}
@@ -0,0 +1,108 @@
+package processor
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tw-backend/internal/game/services/look"
+	"tw-backend/internal/world"
+)
+
+// Metadata keys on a world persisting its day/night clock across restarts
+const (
+	MetaClockDayLength = "clock_day_length_ms"
+	MetaClockGameTime  = "clock_game_time_ms"
+)
+
+// ClockSaveInterval is how often the server records world clocks, bounding
+// how much of the day a crash loses
+const ClockSaveInterval = time.Minute
+
+// RestoreWorldClocks restarts the day/night clock of every world that had
+// one, at the time SaveWorldClocks last recorded
+func (p *GameProcessor) RestoreWorldClocks(ctx context.Context) error {
+	if p.ecosystemService == nil {
+		return nil
+	}
+	worlds, err := p.worldRepo.ListWorlds(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list worlds: %w", err)
+	}
+	for _, w := range worlds {
+		dayLength, ok := metaMillis(w.Metadata[MetaClockDayLength])
+		if !ok {
+			continue
+		}
+		gameTime, _ := metaMillis(w.Metadata[MetaClockGameTime])
+		clock := world.NewClock(dayLength, world.DefaultTickDuration)
+		clock.Set(gameTime)
+		p.ecosystemService.SetWorldClock(w.ID, clock)
+	}
+	return nil
+}
+
+// SaveWorldClocks records each world clock's day length and time on its
+// world, for RestoreWorldClocks
+func (p *GameProcessor) SaveWorldClocks(ctx context.Context) error {
+	if p.ecosystemService == nil {
+		return nil
+	}
+	var errs []error
+	for worldID, clock := range p.ecosystemService.WorldClocks() {
+		w, err := p.worldRepo.GetWorld(ctx, worldID)
+		if err != nil || w == nil {
+			errs = append(errs, fmt.Errorf("world %s: %v", worldID, err))
+			continue
+		}
+		if w.Metadata == nil {
+			w.Metadata = make(map[string]interface{})
+		}
+		w.Metadata[MetaClockDayLength] = clock.DayLength().Milliseconds()
+		w.Metadata[MetaClockGameTime] = clock.GameTime().Milliseconds()
+		if err := p.worldRepo.UpdateWorld(ctx, w); err != nil {
+			errs = append(errs, fmt.Errorf("world %s: %w", worldID, err))
+		}
+	}
+	return stdErrors.Join(errs...)
+}
+
+// metaMillis reads a duration stored in milliseconds, as decoded from JSON
+// or set in Go
+func metaMillis(raw interface{}) (time.Duration, bool) {
+	switch v := raw.(type) {
+	case float64: // Decoded from JSON
+		return time.Duration(v) * time.Millisecond, true
+	case int64:
+		return time.Duration(v) * time.Millisecond, true
+	case int:
+		return time.Duration(v) * time.Millisecond, true
+	}
+	return 0, false
+}
+
+// sightRadius is how far a character can see in a world right now, which
+// also bounds what they can attack
+func (p *GameProcessor) sightRadius(worldID uuid.UUID) float64 {
+	if p.lookService == nil {
+		return look.BaseLookRadius
+	}
+	return p.lookService.LookRadius(worldID)
+}
+
+// outOfSight explains why a target dist meters away can't be attacked, or
+// returns "" if it is in sight
+func (p *GameProcessor) outOfSight(worldID uuid.UUID, name string, dist float64) string {
+	if dist <= p.sightRadius(worldID) {
+		return ""
+	}
+	if p.ecosystemService != nil {
+		if clock := p.ecosystemService.GetWorldClock(worldID); clock != nil && clock.IsNight() {
+			return fmt.Sprintf("You can't make out %s in the dark.", name)
+		}
+	}
+	return fmt.Sprintf("%s is too far away to see.", name)
+}
@@ -0,0 +1,76 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/cmd/game-server/websocket"
+	"tw-backend/internal/auth"
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/game/constants"
+	"tw-backend/internal/game/services/look"
+	"tw-backend/internal/world"
+)
+
+// withWorldClock gives the processor an ecosystem whose lobby clock reads
+// gameTime, and a look service that sees by it
+func withWorldClock(proc *GameProcessor, worldRepo *MockWorldRepository, authRepo *auth.MockRepository, gameTime time.Duration) *world.Clock {
+	proc.ecosystemService = ecosystem.NewService(1)
+	proc.lookService = look.NewLookService(worldRepo, nil, nil, nil, authRepo, nil, proc.ecosystemService)
+	clock := world.NewClock(24*time.Hour, time.Minute)
+	clock.Set(gameTime)
+	proc.ecosystemService.SetWorldClock(constants.LobbyWorldID, clock)
+	return clock
+}
+
+func TestWorldClocks_SurviveRestart(t *testing.T) {
+	ctx := context.Background()
+	proc, _, authRepo, worldRepo := setupTest(t)
+	withWorldClock(proc, worldRepo, authRepo, 0)
+	clock := world.NewClock(36*time.Hour, time.Minute)
+	clock.Set(7 * time.Hour)
+	proc.ecosystemService.SetWorldClock(constants.LobbyWorldID, clock)
+
+	require.NoError(t, proc.SaveWorldClocks(ctx))
+
+	// A new server over the same worlds picks the day up where it was
+	restarted, _, _, _ := setupTest(t)
+	restarted.worldRepo = worldRepo
+	restarted.ecosystemService = ecosystem.NewService(2)
+	require.NoError(t, restarted.RestoreWorldClocks(ctx))
+
+	restored := restarted.ecosystemService.GetWorldClock(constants.LobbyWorldID)
+	require.NotNil(t, restored)
+	assert.Equal(t, 36*time.Hour, restored.DayLength())
+	assert.Equal(t, 7*time.Hour, restored.GameTime())
+}
+
+func TestHandleAttack_DarknessLimitsSight(t *testing.T) {
+	ctx := context.Background()
+	proc, client, authRepo, worldRepo := setupTest(t)
+	clock := withWorldClock(proc, worldRepo, authRepo, 0) // Midnight
+	attacker, err := authRepo.GetCharacter(ctx, client.GetCharacterID())
+	require.NoError(t, err)
+
+	// 10m away: in sight by day, lost in the dark without night vision
+	target := addHubClient(proc.Hub, attacker.WorldID, attacker.PositionX+10, attacker.PositionY)
+	target.Username = "Bob"
+	require.NoError(t, authRepo.CreateCharacter(ctx, &auth.Character{
+		CharacterID: target.CharacterID, WorldID: attacker.WorldID, Name: "Bob",
+		PositionX: attacker.PositionX + 10, PositionY: attacker.PositionY,
+	}))
+	attack := &websocket.CommandData{Action: "attack", Target: &target.Username}
+
+	require.NoError(t, proc.ProcessCommand(ctx, client, attack))
+	require.NotEmpty(t, client.messages)
+	assert.Equal(t, "You can't make out Bob in the dark.", client.messages[len(client.messages)-1].Text)
+	assert.Nil(t, proc.combatService.Combatant(target.CharacterID))
+
+	clock.Set(12 * time.Hour)
+	require.NoError(t, proc.ProcessCommand(ctx, client, attack))
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "You attack Bob")
+}
@@ -2,10 +2,10 @@ package processor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
-	"math/rand"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +16,8 @@ import (
 	"tw-backend/internal/ecosystem/pathogen"
 	"tw-backend/internal/ecosystem/population"
 	"tw-backend/internal/ecosystem/sapience"
+	"tw-backend/internal/ecosystem/simulation"
+	"tw-backend/internal/repository"
 	"tw-backend/internal/worldgen/astronomy"
 	"tw-backend/internal/worldgen/geography"
 	"tw-backend/internal/worldgen/weather"
@@ -43,6 +45,10 @@ func (p *GameProcessor) handleWorld(ctx context.Context, client websocket.GameCl
 		return p.handleWorldInfo(ctx, client)
 	case "reset":
 		return p.handleWorldReset(ctx, client)
+	case "save":
+		return p.handleWorldSave(ctx, client)
+	case "load":
+		return p.handleWorldLoad(ctx, client)
 	case "run":
 		return p.handleWorldRun(ctx, client)
 	case "pause":
@@ -54,9 +60,19 @@ func (p *GameProcessor) handleWorld(ctx context.Context, client websocket.GameCl
 		}
 		return p.handleWorldSpeed(ctx, client, *cmd.Message)
 	case "map":
-		return p.handleWorldMap(ctx, client)
+		var args string
+		if cmd.Message != nil {
+			args = *cmd.Message
+		}
+		return p.handleWorldMap(ctx, client, args)
+	case "lineage":
+		if cmd.Message == nil {
+			client.SendGameMessage("error", "Usage: world lineage <species>", nil)
+			return nil
+		}
+		return p.handleWorldLineage(ctx, client, *cmd.Message)
 	default:
-		client.SendGameMessage("error", "Unknown world command. Try: 'simulate', 'info', 'reset', 'run', 'pause', 'speed', 'map'", nil)
+		client.SendGameMessage("error", "Unknown world command. Try: 'simulate', 'info', 'reset', 'save', 'load', 'run', 'pause', 'speed', 'map', 'lineage'", nil)
 		return nil
 	}
 }
@@ -71,7 +87,16 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 	years := int64(1_000_000)
 	var seedFlag int64 = 0
 	var moonsFlag int = -1 // -1 means random, >= 0 means override
+	progressSteps := ecosystem.DefaultProgressSteps
 	var epochFlag, goalFlag, waterLevelFlag string
+	freshFlag := false     // Restart from year 0 instead of continuing existing geology
+	mutationPulse := false // Revive stagnant species with a variance boost
+	namingTheme := population.NamingThemeClassic
+	verbosity := ecosystem.LogLevelInfo // Client messages and log detail
+
+	// Atmosphere overrides (preset first, then individual values)
+	var atmospherePreset string
+	var co2Flag, n2Flag, o2Flag, greenhouseFlag *float64
 
 	// Subsystem flags - all false by default, enabled explicitly or via "no flags = all"
 	enableGeology := false
@@ -142,13 +167,70 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 				waterLevelFlag = args[i+1]
 				i++
 			}
+		case "--fresh":
+			freshFlag = true
+		case "--mutation-pulse":
+			mutationPulse = true
+		case "--naming-theme":
+			if i+1 < len(args) {
+				theme, err := population.ParseNamingTheme(args[i+1])
+				if err != nil {
+					client.SendGameMessage("error", err.Error(), nil)
+					return nil
+				}
+				namingTheme = theme
+				i++
+			}
+		case "--verbosity":
+			if i+1 < len(args) {
+				level, err := ecosystem.ParseVerbosity(args[i+1])
+				if err != nil {
+					client.SendGameMessage("error", err.Error(), nil)
+					return nil
+				}
+				verbosity = level
+				i++
+			}
+		case "--progress-steps":
+			if i+1 < len(args) {
+				if parsed, err := strconv.Atoi(args[i+1]); err == nil && parsed > 0 {
+					progressSteps = parsed
+				}
+				i++
+			}
 		case "--seed":
 			if i+1 < len(args) {
-				if parsed, err := strconv.ParseInt(args[i+1], 10, 64); err == nil {
+				if args[i+1] == "random" {
+					seedFlag = ecosystem.RandomSeed()
+				} else if parsed, err := strconv.ParseInt(args[i+1], 10, 64); err == nil {
 					seedFlag = parsed
 				}
 				i++
 			}
+		case "--atmosphere":
+			if i+1 < len(args) {
+				atmospherePreset = args[i+1]
+				i++
+			}
+		case "--co2", "--n2", "--o2", "--greenhouse":
+			if i+1 < len(args) {
+				parsed, err := strconv.ParseFloat(args[i+1], 64)
+				if err != nil {
+					client.SendGameMessage("error", fmt.Sprintf("Invalid value for %s: %s", arg, args[i+1]), nil)
+					return nil
+				}
+				switch arg {
+				case "--co2":
+					co2Flag = &parsed
+				case "--n2":
+					n2Flag = &parsed
+				case "--o2":
+					o2Flag = &parsed
+				case "--greenhouse":
+					greenhouseFlag = &parsed
+				}
+				i++
+			}
 		// Legacy flags (for backward compatibility)
 		case "--only-geology":
 			enableGeology = true
@@ -168,6 +250,9 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 		}
 	}
 
+	// Routine chatter honours --verbosity; errors and the summary always go out
+	msgs := simMessenger{client: client, verbosity: verbosity}
+
 	// If no subsystem flags set, enable all (full simulation)
 	if !anyFlagSet {
 		enableGeology, enableWeather, enableLife = true, true, true
@@ -186,11 +271,71 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 		enableGeology = true
 	}
 
-	// Generate random seed if not provided
-	if seedFlag == 0 {
-		seedFlag = rand.Int63n(999_999_999_999) + 1 // 1 to 12 digits
+	// Resolve atmosphere config before doing any work so bad values fail fast
+	atmConfig, err := atmosphere.ConfigForPreset(atmospherePreset, 0)
+	if err != nil {
+		client.SendGameMessage("error", err.Error(), nil)
+		return nil
+	}
+	if co2Flag != nil {
+		atmConfig.CO2Mass = *co2Flag
+	}
+	if n2Flag != nil {
+		atmConfig.N2Mass = *n2Flag
+	}
+	if o2Flag != nil {
+		atmConfig.O2Mass = *o2Flag
+	}
+	if greenhouseFlag != nil {
+		atmConfig.DegreesPerDoubling = *greenhouseFlag
+	}
+	if err := atmConfig.Validate(); err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("Invalid atmosphere: %v", err), nil)
+		return nil
+	}
+
+	// Get current world for context
+	char, _ := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	if char == nil {
+		client.SendGameMessage("error", "Could not get character", nil)
+		return nil
 	}
 
+	// Get world for circumference/seed
+	world, err := p.worldRepo.GetWorld(ctx, char.WorldID)
+	if err != nil {
+		client.SendGameMessage("error", "Could not get world info", nil)
+		return nil
+	}
+
+	// The simulation hands its population to the world's runner when it
+	// finishes, which must not be ticking meanwhile
+	if runner := p.getRunner(char.WorldID); runner != nil && runner.GetState() == ecosystem.RunnerRunning {
+		client.SendGameMessage("error", "The simulation is running. Use 'world pause' before simulating.", nil)
+		return nil
+	}
+
+	// --fresh discards any accumulated geology and life so the run starts
+	// from year 0
+	if freshFlag {
+		p.setGeology(char.WorldID, nil)
+		p.resetRunner(char.WorldID)
+	}
+
+	// An already-simulated world continues from its current year toward the
+	// requested total instead of recomputing everything from scratch.
+	startYear := int64(0)
+	if existing := p.geologyFor(char.WorldID); existing != nil && existing.IsInitialized() {
+		startYear = existing.TotalYearsSimulated
+		if startYear >= years {
+			client.SendGameMessage("system", fmt.Sprintf("World already simulated to year %d. Request a larger total or use --fresh to restart.", startYear), nil)
+			return nil
+		}
+	}
+
+	// Shared with the async runner so both paths build the same world
+	seedFlag = p.worldSeed(char.WorldID, seedFlag)
+
 	// Map old variable names for compatibility with rest of code
 	simulateGeology := enableGeology
 	simulateLife := enableLife
@@ -227,51 +372,50 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 
 	// Display natural satellites configuration if specified
 	if moonsFlag >= 0 {
-		client.SendGameMessage("system", fmt.Sprintf("🌙 Natural Satellites: %d moons configured", moonsFlag), nil)
+		msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🌙 Natural Satellites: %d moons configured", moonsFlag))
 	}
 
-	// Get current world for context
-	char, _ := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
-	if char == nil {
-		client.SendGameMessage("error", "Could not get character", nil)
-		return nil
-	}
-
-	// Get world for circumference/seed
-	world, err := p.worldRepo.GetWorld(ctx, char.WorldID)
-	if err != nil {
-		client.SendGameMessage("error", "Could not get world info", nil)
-		return nil
+	if startYear > 0 {
+		client.SendGameMessage("system", fmt.Sprintf("⏩ Continuing from year %d to year %d (%d years). Use --fresh to restart.",
+			startYear, years, years-startYear), nil)
 	}
 
 	// Initialize geology if not exists
-	geology, exists := p.worldGeology[char.WorldID]
-	if !exists {
+	geology := p.geologyFor(char.WorldID)
+	if geology == nil {
 		// Default circumference if not set (Earth-like: 40,000 km = 40,000,000 m)
-		circumference := 40_000_000.0
+		circumference := ecosystem.DefaultCircumference
 		if world.Circumference != nil {
 			circumference = *world.Circumference
+			if err := ecosystem.ValidateCircumference(circumference); err != nil {
+				msgs.send(ecosystem.LogLevelWarn, fmt.Sprintf("⚠️ Invalid world size (%v); simulating an Earth-sized world instead.", err))
+			}
 		}
 
 		// Use seedFlag (always set - either user-provided or random)
 		geology = ecosystem.NewWorldGeology(char.WorldID, seedFlag, circumference)
-		p.worldGeology[char.WorldID] = geology
+		p.setGeology(char.WorldID, geology)
 	}
 
 	// Initialize terrain if first simulation
 	if !geology.IsInitialized() {
-		client.SendGameMessage("system", "Initializing world geology...", nil)
+		msgs.send(ecosystem.LogLevelInfo, "Initializing world geology...")
 		geology.InitializeGeology()
-		client.SendGameMessage("system", "Geology initialized with tectonic plates and terrain.", nil)
+		msgs.send(ecosystem.LogLevelInfo, "Geology initialized with tectonic plates and terrain.")
 
 		// Spawn initial creatures based on generated biomes
 		if len(geology.Biomes) > 0 && simulateLife {
-			client.SendGameMessage("system", "Spawning initial life forms...", nil)
+			msgs.send(ecosystem.LogLevelInfo, "Spawning initial life forms...")
 			p.ecosystemService.SpawnBiomes(char.WorldID, geology.Biomes)
-			client.SendGameMessage("system", fmt.Sprintf("Spawned %d entities across %d biomes.", len(p.ecosystemService.Entities), len(geology.Biomes)), nil)
+			msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("Spawned %d entities across %d biomes.", len(p.ecosystemService.Entities), len(geology.Biomes)))
 		}
 	}
 
+	// Start the live day/night clock; day length follows planet size
+	if p.ecosystemService != nil {
+		p.ecosystemService.EnsureWorldClock(char.WorldID, geology.Circumference)
+	}
+
 	// Register geology with map service for minimap biome rendering
 	if p.mapService != nil {
 		p.mapService.SetWorldGeology(char.WorldID, geology)
@@ -287,6 +431,8 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 
 	// Set satellites in geology for map retrieval
 	geology.Satellites = satellites
+	// Close or massive moons knead the interior, driving extra volcanism
+	geology.SetTidalHeating(ecosystem.TidalHeatingFromStress(astronomy.CalculateTidalStress(satellites)))
 
 	// Handle Water Level Override
 	if waterLevelFlag != "" {
@@ -319,39 +465,51 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 		// Regenerate dynamic features immediately
 		geology.Rivers = geography.GenerateRivers(geology.Heightmap, geology.SeaLevel, geology.Seed)
 		geology.Biomes = geography.AssignBiomes(geology.Heightmap, geology.SeaLevel, geology.Seed, 0.0)
-		client.SendGameMessage("system", fmt.Sprintf("🌊 Water level set to %.0fm (%s)", newSeaLevel, waterLevelFlag), nil)
+		msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🌊 Water level set to %.0fm (%s)", newSeaLevel, waterLevelFlag))
 	}
 
 	// Use population-based simulation for efficiency
 	if enableLife {
-		client.SendGameMessage("system", fmt.Sprintf("Starting population simulation of %d years...", years), nil)
+		msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("Starting population simulation of %d years...", years))
 	} else {
-		client.SendGameMessage("system", fmt.Sprintf("Starting geology-only simulation of %d years...", years), nil)
+		msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("Starting geology-only simulation of %d years...", years))
 	}
 
 	// Report epoch and goal if specified
 	if epochFlag != "" {
 		epoch := population.EpochType(epochFlag)
-		client.SendGameMessage("system", fmt.Sprintf("🌍 Starting in epoch: %s", population.GetEpochDescription(epoch)), nil)
+		msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🌍 Starting in epoch: %s", population.GetEpochDescription(epoch)))
 	}
 	var evolutionGoal population.EvolutionGoal
 	if goalFlag != "" {
 		evolutionGoal = population.EvolutionGoal(goalFlag)
-		client.SendGameMessage("system", fmt.Sprintf("🎯 Evolution goal: %s", goalFlag), nil)
+		msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🎯 Evolution goal: %s", goalFlag))
 	}
 
-	// Create seed from world ID (for population sim)
-	seed := int64(char.WorldID[0])<<56 | int64(char.WorldID[1])<<48 |
-		int64(char.WorldID[2])<<40 | int64(char.WorldID[3])<<32 |
-		int64(char.WorldID[4])<<24 | int64(char.WorldID[5])<<16 |
-		int64(char.WorldID[6])<<8 | int64(char.WorldID[7])
+	// Life and disease share the world seed
+	seed := seedFlag
 
 	// Initialize population simulator only if life is enabled
 	var popSim *population.PopulationSimulator
 	var biomesByType map[geography.BiomeType][]*geography.Biome
 
+	// A continuing run carries on the life the world already has, which the
+	// runner kept from the last run
+	carriedLife := false
+	if enableLife && startYear > 0 {
+		if runner := p.getRunner(char.WorldID); runner != nil {
+			if existing := runner.GetPopulationSimulator(); existing != nil && len(existing.Biomes) > 0 {
+				popSim = existing
+				carriedLife = true
+			}
+		}
+	}
+
 	if enableLife {
-		popSim = population.NewPopulationSimulator(char.WorldID, seed)
+		if popSim == nil {
+			popSim = population.NewPopulationSimulator(char.WorldID, seed)
+			popSim.SetNamingTheme(namingTheme)
+		}
 		_ = evolutionGoal // Will be used in the evolution loop below
 
 		// Assign biomes (part of life system)
@@ -371,8 +529,11 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 	}
 
 	// Create populations for each biome type (sample up to 2 per type)
-	// Only runs when life simulation is enabled
-	if enableLife && popSim != nil {
+	// Only runs when life simulation is enabled and starts afresh
+	if carriedLife {
+		_, species, _ := popSim.GetStats()
+		msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🧬 Continuing life from year %d: %d species across %d biome instances.", popSim.CurrentYear, species, len(popSim.Biomes)))
+	} else if enableLife && popSim != nil {
 		for biomeType, biomes := range biomesByType {
 			// Take up to 2 biomes of each type
 			count := 2
@@ -404,7 +565,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 
 				floraSpecies := &population.SpeciesPopulation{
 					SpeciesID:     uuid.New(),
-					Name:          fmt.Sprintf("%s %s", biomeType, population.GenerateSpeciesName(floraTraits, population.DietPhotosynthetic, biomeType)),
+					Name:          popSim.UniqueSpeciesName(fmt.Sprintf("%s %s", biomeType, population.GenerateSpeciesName(floraTraits, population.DietPhotosynthetic, biomeType)), biomeType),
 					Count:         startingFlora,
 					Traits:        floraTraits,
 					TraitVariance: 0.3,
@@ -430,7 +591,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 
 				herbSpecies := &population.SpeciesPopulation{
 					SpeciesID:     uuid.New(),
-					Name:          fmt.Sprintf("%s %s", biomeType, population.GenerateSpeciesName(herbTraits, population.DietHerbivore, biomeType)),
+					Name:          popSim.UniqueSpeciesName(fmt.Sprintf("%s %s", biomeType, population.GenerateSpeciesName(herbTraits, population.DietHerbivore, biomeType)), biomeType),
 					Count:         200,
 					Traits:        herbTraits,
 					TraitVariance: 0.3,
@@ -457,7 +618,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 
 				carnSpecies := &population.SpeciesPopulation{
 					SpeciesID:     uuid.New(),
-					Name:          fmt.Sprintf("%s %s", biomeType, population.GenerateSpeciesName(carnTraits, population.DietCarnivore, biomeType)),
+					Name:          popSim.UniqueSpeciesName(fmt.Sprintf("%s %s", biomeType, population.GenerateSpeciesName(carnTraits, population.DietCarnivore, biomeType)), biomeType),
 					Count:         50,
 					Traits:        carnTraits,
 					TraitVariance: 0.3,
@@ -471,13 +632,16 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 			}
 		}
 
-		client.SendGameMessage("system", fmt.Sprintf("Simulating %d biome types with %d total biome instances...", len(biomesByType), len(popSim.Biomes)), nil)
+		ecosystem.SetBiomeClimates(popSim, geology.Biomes)
+		msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("Simulating %d biome types with %d total biome instances...", len(biomesByType), len(popSim.Biomes)))
 	}
+	climateTempMod := 0.0 // Temperature modifier the biome climates were sampled at
 
-	// Initialize geographic systems for regional isolation tracking (life only)
-	if enableLife && popSim != nil {
+	// Initialize geographic systems for regional isolation tracking (life
+	// only; carried life kept its own)
+	if enableLife && popSim != nil && !carriedLife {
 		popSim.InitializeGeographicSystems(char.WorldID, seed)
-		client.SendGameMessage("system", "🗺️ Geographic systems initialized: Hex grid, Regions, Tectonics", nil)
+		msgs.send(ecosystem.LogLevelInfo, "🗺️ Geographic systems initialized: Hex grid, Regions, Tectonics")
 	}
 
 	// Track statistics
@@ -493,16 +657,29 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 	climateDriver.ObliquityStability = obliquityStability
 
 	// Initialize Atmospheric Composition (Carbon-Silicate Cycle)
-	// Early Earth: High CO2 to compensate for faint young Sun
-	// Modern Earth: Low CO2 after billions of years of weathering
-	atm := atmosphere.NewAtmosphere(0) // Start at year 0
+	// Default is early Earth: High CO2 to compensate for faint young Sun;
+	// --atmosphere/--co2/--greenhouse select other worlds (validated above)
+	atm, err := atmosphere.NewAtmosphereWithConfig(0, atmConfig) // Start at year 0
+	if err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("Invalid atmosphere: %v", err), nil)
+		return nil
+	}
 
-	progressInterval := years / 10
-	// Cap progress interval to 10M years for better responsiveness on long simulations
-	if progressInterval > 10_000_000 {
-		progressInterval = 10_000_000
+	// Structured progress for the client's timeline; text progress still
+	// honours --verbosity
+	stream := newSimProgressStream(client, geology.GetStats)
+	defer stream.Close()
+	msgs.progress = stream
+	progress := ecosystem.NewProgressTracker(clientProgressReporter{client: client, verbosity: verbosity, stream: stream}, startYear, years, progressSteps)
+	if popSim != nil {
+		progress.SetStatsSource(popSim.GetStats)
+	}
+	// Geology steps drive the tracker when simulated; life-only runs observe
+	// each year themselves
+	if simulateGeology {
+		progress.TrackGeology(geology)
+		defer geology.SetProgressFunc(0, nil)
 	}
-	lastProgress := int64(0)
 
 	// Track event frequencies
 	eventCounts := make(map[ecosystem.GeologicalEventType]int)
@@ -521,10 +698,10 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 		phyloTree = population.NewPhylogeneticTree(char.WorldID)
 		turningPointMgr = ecosystem.NewTurningPointManager(char.WorldID)
 
-		// Add initial species to phylogenetic tree
+		// Add initial species (or carried life's survivors) to phylogenetic tree
 		for _, biome := range popSim.Biomes {
 			for _, sp := range biome.Species {
-				phyloTree.AddRoot(sp, 0)
+				phyloTree.AddRoot(sp, sp.CreatedYear)
 			}
 		}
 	}
@@ -539,29 +716,55 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 	// Initialize simulation logger (file-based, no DB required)
 	simLogger, err := ecosystem.NewSimulationLogger(ecosystem.SimulationLoggerConfig{
 		WorldID:    char.WorldID,
-		Verbosity:  ecosystem.LogLevelInfo, // Log major events only
+		Verbosity:  verbosity, // Major events only unless --verbosity says otherwise
 		FileOutput: true,
 	})
 	if err != nil {
-		client.SendGameMessage("system", fmt.Sprintf("⚠️ Logger init failed: %v (continuing without logging)", err), nil)
+		msgs.send(ecosystem.LogLevelWarn, fmt.Sprintf("⚠️ Logger init failed: %v (continuing without logging)", err))
 		simLogger = nil
 	} else {
 		defer simLogger.Close()
 	}
 
 	if enableLife {
-		client.SendGameMessage("system", "🧪 V2 Systems initialized: Pathogens, Cascades, Sapience, Phylogeny", nil)
+		msgs.send(ecosystem.LogLevelInfo, "🧪 V2 Systems initialized: Pathogens, Cascades, Sapience, Phylogeny")
 	}
 
 	// Run simulation year by year (fast!)
 	// Run simulation year by year (fast!) or with larger steps
-	year := int64(0)
+	year := startYear
 	iterationCount := int64(0) // Debug counter
+	fossilsReported := 0       // Extinctions already sent as verbose detail
+	if popSim != nil {
+		fossilsReported = len(popSim.FossilRecord.Extinct)
+	}
+	// Extinctions are archived as they happen so the record outlives the run
+	var fossilArchiver *ecosystem.FossilArchiver
+	fossilArchiveFailed := false
+	if popSim != nil && p.fossilStore != nil {
+		fossilArchiver = ecosystem.NewFossilArchiver(p.fossilStore, char.WorldID)
+	}
+	// Extinctions and sapience are published for other services, as the
+	// background runner does
+	var lifeEvents *ecosystem.LifeEventPublisher
+	if popSim != nil && p.events != nil {
+		lifeEvents = ecosystem.NewLifeEventPublisher(p.events, char.WorldID, popSim)
+	}
 
 	// Performance profiling
 	var totalCarbonTime, totalEventTime, totalGeologyTime, totalOtherTime time.Duration
 	var profileSamples int64
 
+	// Land rainfall feeding silicate weathering, refreshed with the biomes
+	// every 10M years; by elapsed years, since steps needn't land on a multiple
+	var globalPrecip float64
+	precipKnown := false
+	var lastPrecipYear int64
+
+	// Moons drift under tides over deep time; any that break up become rings
+	lastOrbitYear := year
+	var rings *astronomy.RingSystem
+
 	for year < years {
 		// Calculate adaptive step size at the START of the loop
 		// Default to 1 year (required if life is enabled for reproduction/death cycles)
@@ -573,21 +776,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 			// GEOLOGY-ONLY OPTIMIZATION: Use aggressive stepping throughout
 			// Since we don't need year-by-year resolution for biology,
 			// we can use much larger steps even in later eons
-			if heat > 4.0 {
-				// Hadean era (year 0-500M): Molten/Violent
-				// AGGRESSIVE: Use 100k year steps
-				stepSize = 100_000
-			} else if heat > 1.5 {
-				// Archean/Proterozoic (year 500M-3B): Cooling
-				// AGGRESSIVE for geology-only: Use 100k year steps (was 10k)
-				// Surface features still don't need fine resolution
-				stepSize = 100_000
-			} else {
-				// Phanerozoic/Modern (year 3B+): Stable
-				// Use 10k year steps for geology-only (was 100)
-				// Only need fine resolution when biology is active
-				stepSize = 10_000
-			}
+			stepSize = ecosystem.GeologyOnlyStepSize(year)
 
 			// Ensure we don't overshoot the end
 			if year+stepSize > years {
@@ -601,16 +790,8 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 		}
 
 		// Progress reporting
-		if year-lastProgress >= progressInterval && progressInterval > 0 {
-			percent := (year * 100) / years
-			if popSim != nil {
-				totalPop, totalSpecies, totalExtinct := popSim.GetStats()
-				client.SendGameMessage("system", fmt.Sprintf("⏳ Progress: %d%% (Year %d, Pop: %d, Species: %d, Extinct: %d)",
-					percent, year, totalPop, totalSpecies, totalExtinct), nil)
-			} else {
-				client.SendGameMessage("system", fmt.Sprintf("⏳ Progress: %d%% (Year %d)", percent, year), nil)
-			}
-			lastProgress = year
+		if !simulateGeology {
+			progress.Observe(year)
 		}
 
 		// Simulate population dynamics + evolution + speciation
@@ -653,61 +834,70 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 				if o2Change < 0 {
 					direction = "falling"
 				}
-				client.SendGameMessage("system", fmt.Sprintf("🌬️ Atmospheric oxygen %s: %.1f%%", direction, newO2*100), nil)
+				msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🌬️ Atmospheric oxygen %s: %.1f%%", direction, newO2*100))
+			}
+
+			// High oxygen drives wildfires, which burn flora and return carbon to the atmosphere
+			if fires := popSim.ApplyWildfires(); fires.Fires > 0 {
+				atm.AddCO2(fires.CO2Released)
+				if fires.FloraBurned > 1000 {
+					msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🔥 Wildfires swept %d biomes, burning %d plants", fires.Fires, fires.FloraBurned))
+				}
 			}
 
 			newSpecies := popSim.CheckSpeciation()
 			if newSpecies > 0 {
-				client.SendGameMessage("system", fmt.Sprintf("🧬 %d new species evolved through speciation", newSpecies), nil)
+				msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🧬 %d new species evolved through speciation", newSpecies))
 				// TODO: Add speciation events to phylogenetic tree when CheckSpeciation returns parent/child info
 			}
 
 			// Allow species to migrate between biomes
 			migrants := popSim.ApplyMigrationCycle()
 			if migrants > 100 {
-				client.SendGameMessage("system", fmt.Sprintf("🦋 %d individuals migrated to new biomes", migrants), nil)
+				msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🦋 %d individuals migrated to new biomes", migrants))
+			}
+
+			// Evolutionary dead-ends: species whose variance collapsed long ago
+			if popSim.CurrentYear%population.StagnationCheckInterval == 0 {
+				if stagnant := popSim.ApplyStagnationCheck(mutationPulse); len(stagnant) > 0 {
+					verb := "stagnating"
+					if mutationPulse {
+						verb = "revived by a mutation pulse"
+					}
+					msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🪨 %d species %s (oldest: %s, unchanged %d years)",
+						len(stagnant), verb, stagnant[0].Name, stagnant[0].YearsStatic))
+				}
 			}
 
 			// V2: Pathogen simulation - check for outbreaks every 10k years
 			if simulateDiseases && simulateLife {
-				speciesData := make(map[uuid.UUID]pathogen.SpeciesInfo)
-				for _, biome := range popSim.Biomes {
-					for _, sp := range biome.Species {
-						if sp.Count > 0 {
-							speciesData[sp.SpeciesID] = pathogen.SpeciesInfo{
-								Population:        sp.Count,
-								DiseaseResistance: float32(sp.Traits.DiseaseResistance),
-								DietType:          string(sp.Diet),
-								Density:           float64(sp.Count) / float64(biome.CarryingCapacity+1),
-							}
-							// Check for spontaneous outbreaks
-							newPathogen, outbreak := diseaseSystem.CheckSpontaneousOutbreak(
-								sp.SpeciesID, sp.Name, sp.Count,
-								float64(sp.Count)/float64(biome.CarryingCapacity+1),
-							)
-							if outbreak != nil {
-								totalOutbreaks++
-								// CalculateR0 needs density and resistance params
-								density := float32(sp.Count) / float32(biome.CarryingCapacity+1)
-								r0 := newPathogen.CalculateR0(density, float32(sp.Traits.DiseaseResistance))
-								client.SendGameMessage("system", fmt.Sprintf("🦠 OUTBREAK: %s (%s) in %s! R₀: %.1f",
-									newPathogen.Name, newPathogen.Type, sp.Name, r0), nil)
-								// Log to simulation logger
-								if simLogger != nil {
-									simLogger.LogPathogenOutbreakV2(ctx, popSim.CurrentYear, newPathogen.Name, string(newPathogen.Type), string(newPathogen.Transmission), sp.Name, r0, newPathogen.Virulence, outbreak.PeakInfected)
-								}
-							}
-						}
+				// Check for spontaneous outbreaks in a stable species order so a seed reproduces them
+				speciesData := simulation.DiseaseSpeciesData(popSim)
+				for _, started := range diseaseSystem.CheckSpontaneousOutbreaks(popSim.CurrentYear, speciesData) {
+					totalOutbreaks++
+					newPathogen := started.Pathogen
+					speciesName := speciesData[started.SpeciesID].Name
+					msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🦠 OUTBREAK: %s (%s) in %s! R₀: %.1f",
+						newPathogen.Name, newPathogen.Type, speciesName, started.R0))
+					// Log to simulation logger
+					if simLogger != nil {
+						simLogger.LogPathogenOutbreakV2(ctx, popSim.CurrentYear, newPathogen.Name, string(newPathogen.Type), string(newPathogen.Transmission), speciesName, started.R0, newPathogen.Virulence, started.Outbreak.PeakInfected)
 					}
 				}
+				// Pathogens jump to species that meet the infected, above all to their hunters
+				for _, jump := range diseaseSystem.CheckZoonoticJumps(popSim.CurrentYear, speciesData) {
+					totalOutbreaks++
+					msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🦠 ZOONOTIC JUMP: %s spread from %s to %s (virulence %.2f)",
+						jump.Pathogen.Name, speciesData[jump.SourceSpeciesID].Name, speciesData[jump.TargetSpeciesID].Name, jump.Pathogen.Virulence))
+				}
 				// Update all active outbreaks
 				diseaseSystem.Update(popSim.CurrentYear, speciesData)
 				// Report pandemic events
 				for _, pandemic := range diseaseSystem.GetPandemics() {
 					// Report if this is a large pandemic
 					if pandemic.TotalDeaths > 1000 && pandemic.EndYear == popSim.CurrentYear {
-						client.SendGameMessage("system", fmt.Sprintf("☠️ PANDEMIC: %s killed %d across multiple populations",
-							pandemic.PathogenID, pandemic.TotalDeaths), nil)
+						msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("☠️ PANDEMIC: %s killed %d across multiple populations",
+							pandemic.PathogenID, pandemic.TotalDeaths))
 					}
 				}
 			}
@@ -723,7 +913,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 								Social:        sp.Traits.Social,
 								ToolUse:       sp.Traits.Intelligence * 0.8, // Infer tool use from intelligence
 								Communication: sp.Traits.Social * 0.7,       // Infer from social
-								MagicAffinity: 0.0,                          // Default, no magic affinity trait
+								MagicAffinity: sp.MagicAffinity(),
 								Population:    sp.Count,
 								Generation:    sp.Generation,
 							}
@@ -732,11 +922,19 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 								if candidate.Level == sapience.SapienceSapient {
 									sapienceAchieved = true
 									newSapientSpecies = append(newSapientSpecies, sp.SpeciesID) // Track for turning points
-									client.SendGameMessage("system", fmt.Sprintf("🧠 SAPIENCE ACHIEVED! %s has become sapient! (Score: %.2f)",
-										sp.Name, candidate.Score), nil)
+									if lifeEvents != nil {
+										lifeEvents.PublishSapience(ctx, candidate)
+									}
+									msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🧠 SAPIENCE ACHIEVED! %s has become sapient! (Score: %.2f)",
+										sp.Name, candidate.Score))
+									if envoy, err := p.ensureSapientEnvoy(ctx, char.WorldID, sp.SpeciesID, sp.Name, char.PositionX, char.PositionY); err != nil {
+										log.Printf("[SAPIENCE] %v", err)
+									} else if envoy != nil {
+										msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🗣️ An envoy of the %s approaches. Use 'talk %s' to speak with them.", sp.Name, strings.ToLower(envoy.Name)))
+									}
 								} else if candidate.Level == sapience.SapienceProtoSapient {
-									client.SendGameMessage("system", fmt.Sprintf("🔮 Proto-sapience detected: %s shows early signs (Score: %.2f)",
-										sp.Name, candidate.Score), nil)
+									msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🔮 Proto-sapience detected: %s shows early signs (Score: %.2f)",
+										sp.Name, candidate.Score))
 								}
 							}
 						}
@@ -744,6 +942,53 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 				}
 			}
 
+			// V2: Culture - sapient species advance through cultural stages
+			if sapienceAchieved && simulateLife {
+				sapients := make(map[uuid.UUID]sapience.SapientSpecies)
+				for _, id := range sapienceDetector.SapientSpecies {
+					sapients[id] = sapience.SapientSpecies{SpeciesID: id}
+				}
+				for _, biome := range popSim.Biomes {
+					for _, sp := range biome.Species {
+						sapient, ok := sapients[sp.SpeciesID]
+						if !ok || sp.Count <= 0 {
+							continue
+						}
+						sapient.Name = sp.Name
+						sapient.Traits = sapience.SpeciesTraits{
+							Intelligence:  sp.Traits.Intelligence,
+							Social:        sp.Traits.Social,
+							ToolUse:       sp.Traits.Intelligence * 0.8,
+							Communication: sp.Traits.Social * 0.7,
+							MagicAffinity: sp.MagicAffinity(),
+							Population:    sapient.Traits.Population + sp.Count,
+							Generation:    sp.Generation,
+						}
+						sapients[sp.SpeciesID] = sapient
+					}
+				}
+				for _, id := range sapienceDetector.SapientSpecies {
+					sapient := sapients[id]
+					if sapient.Traits.Population <= 0 {
+						continue
+					}
+					// Plague is what most often derails a civilization
+					sapient.Stability = 1.0
+					if diseaseSystem != nil {
+						infected, _ := diseaseSystem.GetImpact(id)
+						sapient.Stability = math.Max(0, 1-2*float64(infected)/float64(sapient.Traits.Population))
+					}
+					sapienceDetector.AdvanceCulture(sapient, popSim.CurrentYear)
+				}
+				for _, ev := range sapienceDetector.TakeCultureEvents() {
+					if ev.Arcane != "" {
+						msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("✨ %s has reached arcane %s (Year %d)", ev.SpeciesName, ev.Arcane, ev.Year))
+					} else {
+						msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🏛️ %s has entered the %s age (Year %d)", ev.SpeciesName, ev.Stage, ev.Year))
+					}
+				}
+			}
+
 			// V2: Extinction cascade - check for cascades when species go extinct
 			// Build ecological relationships from population data (simplified)
 			for _, biome := range popSim.Biomes {
@@ -792,18 +1037,15 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 							result := cascadeSim.CalculateCascade(sp.SpeciesID, sp.Name, popSim.CurrentYear, 3)
 							if result != nil && result.TotalAffected > 0 {
 								totalCascades++
-								client.SendGameMessage("system", fmt.Sprintf("💀 EXTINCTION CASCADE: %s extinction affects %d other species",
-									sp.Name, result.TotalAffected), nil)
+								msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("💀 EXTINCTION CASCADE: %s extinction affects %d other species",
+									sp.Name, result.TotalAffected))
 
-								// Apply cascade effects to populations
-								for affectedID, impact := range result.PopulationChanges {
+								// Apply cascade effects to populations: each change is a
+								// multiplier, below 1 for declines and above for booms
+								for affectedID, change := range result.PopulationChanges {
 									for _, b := range popSim.Biomes {
 										if affected, ok := b.Species[affectedID]; ok {
-											deaths := int64(float32(affected.Count) * impact)
-											affected.Count -= deaths
-											if affected.Count < 0 {
-												affected.Count = 0
-											}
+											affected.Count = max(0, int64(float32(affected.Count)*change))
 										}
 									}
 								}
@@ -838,15 +1080,23 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 				// - Weathering removes CO2 (proportional to temp × precipitation × CO2)
 				// - Negative feedback: Warming → More weathering → Less CO2 → Cooling
 
-				// Calculate volcanic CO2 emissions (source)
-				heat := ecosystem.GetPlanetaryHeat(year)
+				// Calculate volcanic CO2 emissions (source), including the
+				// volcanism tidal heating from the moons drives
+				heat := geology.PlanetaryHeat()
 				volcanicRate := atmosphere.CalculateVolcanicOutgassing(heat)
 
 				// Calculate weathering CO2 removal (sink)
 				geoStats := geology.GetStats()
+				if !precipKnown || year-lastPrecipYear >= 10_000_000 {
+					// Wetter continents weather faster, closing the climate feedback loop
+					climate := weather.GenerateInitialClimate(geology.Heightmap, geology.SeaLevel, geology.Seed+year, 0)
+					globalPrecip = weather.GlobalAveragePrecipitation(climate, geology.Heightmap.Width)
+					precipKnown = true
+					lastPrecipYear = year
+				}
 				weatheringRate := atmosphere.CalculateWeatheringRate(
 					geoStats.AverageTemperature,
-					1000.0, // TODO: Get actual global average precipitation from weather system
+					globalPrecip,
 					geoStats.LandPercent/100.0,
 					atm.CO2Mass,
 				)
@@ -888,19 +1138,57 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 			eventTempMod, _, _ := geoManager.GetEnvironmentModifiers()
 			totalTempMod := eventTempMod + climateDriver.GetGeothermalOffset() + climateDriver.GetGreenhouseOffset()
 			phaseEvent := geology.SimulateGeology(stepSize, totalTempMod)
+			if popSim != nil {
+				popSim.TemperatureAnomaly = totalTempMod - climateTempMod
+			}
 
 			// MANUALLY TRIGGER BIOME GENERATION
 			// Refactored to occur here instead of inside SimulateGeology to prevent memory leaks in geology-only runs.
 			// Only update biomes if life is being simulated (to feed populations), or very rarely.
 			// 10M year interval matches the previous internal logic but is now conditional.
 			if simulateLife && year%10_000_000 == 0 {
-				geology.UpdateBiomes(totalTempMod)
+				geology.AxialTilt = climateDriver.GetObliquity() // Chaotic without a large moon
+				geology.Biomes = geology.UpdateBiomesInto(geology.Biomes, nil, totalTempMod)
+				if popSim != nil {
+					ecosystem.SetBiomeClimates(popSim, geology.Biomes)
+					climateTempMod = totalTempMod
+					popSim.TemperatureAnomaly = 0
+				}
 			}
 
 			// Log phase transition events (e.g., Great Deluge)
 			if phaseEvent != nil {
-				client.SendGameMessage("system", fmt.Sprintf("🌊 %s: %s (Year %d)",
-					phaseEvent.Type, phaseEvent.Description, phaseEvent.Year), nil)
+				msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🌊 %s: %s (Year %d)",
+					phaseEvent.Type, phaseEvent.Description, phaseEvent.Year))
+			}
+
+			// === MOON ORBITS ===
+			// Tidal recession is slow, so every 1M years is plenty
+			if year%1_000_000 == 0 && year > lastOrbitYear && len(satellites) > 0 {
+				orbitEvents := astronomy.EvolveOrbits(satellites, year-lastOrbitYear, astronomy.EarthMassKg)
+				lastOrbitYear = year
+				for _, ev := range orbitEvents {
+					switch ev.Type {
+					case astronomy.OrbitBreakup:
+						msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("💫 %s crossed the Roche limit and broke apart into rings (Year %d)", ev.Satellite.Name, year))
+					case astronomy.OrbitEscape:
+						msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🌑 %s drifted beyond the Hill sphere and was lost (Year %d)", ev.Satellite.Name, year))
+					}
+				}
+				if newRings := astronomy.GenerateRings(seedFlag+year, astronomy.EarthMassKg, satellites); newRings != nil {
+					if rings != nil {
+						newRings.Mass += rings.Mass
+						newRings.DestroyedMoons = append(rings.DestroyedMoons, newRings.DestroyedMoons...)
+					}
+					rings = newRings
+				}
+				satellites = astronomy.RemoveLostMoons(satellites, orbitEvents)
+				geology.Satellites = satellites
+
+				// A receding moon loosens its hold on the axis and its tides weaken
+				climateDriver.ObliquityStability = astronomy.CalculateObliquityStability(satellites, astronomy.EarthMassKg)
+				geoManager.ImpactShielding = astronomy.CalculateImpactShieldingWithRings(satellites, rings)
+				geology.SetTidalHeating(ecosystem.TidalHeatingFromStress(astronomy.CalculateTidalStress(satellites)))
 			}
 
 			geologyTime := time.Since(geologyStart)
@@ -940,14 +1228,16 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 					geologicalEvents++
 					eventCounts[e.Type]++
 					// Log the event
-					client.SendGameMessage("system", fmt.Sprintf("⚠️ GEOLOGICAL EVENT: %s (severity: %.0f%%)", e.Type, e.Severity*100), nil)
-					geology.ApplyEvent(e)
+					msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("⚠️ GEOLOGICAL EVENT: %s (severity: %.0f%%)", e.Type, e.Severity*100))
+					for _, site := range geology.ApplyEvent(e) {
+						p.broadcastCatastrophe(char.WorldID, site)
+					}
 
 					// Apply extinction event to populations based on event type
 					if simulateLife {
 						deaths := popSim.ApplyExtinctionEvent(eventType, e.Severity)
 						if deaths > 100 {
-							client.SendGameMessage("system", fmt.Sprintf("   💀 %d organisms perished", deaths), nil)
+							msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("   💀 %d organisms perished", deaths))
 						}
 					}
 				}
@@ -958,9 +1248,9 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 					transitioned := popSim.ApplyBiomeTransitions(eventType, e.Severity)
 					if transitioned > 0 {
 						if e.Type == ecosystem.EventWarming || e.Type == ecosystem.EventGreenhouseSpike {
-							client.SendGameMessage("system", fmt.Sprintf("   🌡️ %d biomes warming! Climate recovery in progress", transitioned), nil)
+							msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("   🌡️ %d biomes warming! Climate recovery in progress", transitioned))
 						} else {
-							client.SendGameMessage("system", fmt.Sprintf("   🌍 %d biomes shifted due to climate change", transitioned), nil)
+							msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("   🌍 %d biomes shifted due to climate change", transitioned))
 						}
 					}
 				}
@@ -982,7 +1272,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 						} else {
 							status = "moderate"
 						}
-						client.SendGameMessage("system", fmt.Sprintf("   🗺️ Continental configuration: %s (%.0f%%)", status, newFrag*100), nil)
+						msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("   🗺️ Continental configuration: %s (%.0f%%)", status, newFrag*100))
 					}
 				}
 			}
@@ -994,7 +1284,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 				// Apply isolation effects (gigantism/dwarfism) to isolated regions
 				isolationAffected := popSim.ApplyIsolationEffects()
 				if isolationAffected > 0 && year%100000 == 0 {
-					client.SendGameMessage("system", fmt.Sprintf("🏝️ Island effects: %d species affected by isolation", isolationAffected), nil)
+					msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🏝️ Island effects: %d species affected by isolation", isolationAffected))
 				}
 			}
 
@@ -1002,7 +1292,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 			if simulateLife && popSim != nil && year%100000 == 0 && year > 0 {
 				migrations := popSim.ApplyRegionalMigration()
 				if migrations > 0 {
-					client.SendGameMessage("system", fmt.Sprintf("🌍 Regional migration: %d species expanded to new regions", migrations), nil)
+					msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🌍 Regional migration: %d species expanded to new regions", migrations))
 				}
 			}
 
@@ -1031,7 +1321,7 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 				)
 
 				if tp != nil {
-					client.SendGameMessage("system", fmt.Sprintf("🔮 TURNING POINT: %s - %s", tp.Title, tp.Description), nil)
+					msgs.send(ecosystem.LogLevelInfo, fmt.Sprintf("🔮 TURNING POINT: %s - %s", tp.Title, tp.Description))
 					if simLogger != nil {
 						simLogger.LogTurningPoint(ctx, popSim.CurrentYear, string(tp.Trigger), "auto_resolved")
 					}
@@ -1048,6 +1338,21 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 			}
 		}
 
+		// Per-event detail for verbose runs
+		if popSim != nil && msgs.verbosity <= ecosystem.LogLevelDebug {
+			fossilsReported = msgs.sendEventDetails(popSim, fossilsReported)
+		}
+		if fossilArchiver != nil {
+			// Failed saves are retried next step; warn only once
+			if _, err := fossilArchiver.Archive(ctx, popSim); err != nil && !fossilArchiveFailed {
+				fossilArchiveFailed = true
+				msgs.send(ecosystem.LogLevelWarn, fmt.Sprintf("⚠️ Failed to archive fossil record: %v", err))
+			}
+		}
+		if lifeEvents != nil {
+			lifeEvents.PublishExtinctions(ctx, popSim)
+		}
+
 		year += stepSize
 
 		// Debug: Log iteration count every 100 iterations
@@ -1061,23 +1366,35 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 		}
 	}
 
+	stream.Close()
+
 	// Update biomes one last time to ensure final map state is correct
 	// Calculate final temp mod
 	eventTempMod, _, _ := geoManager.GetEnvironmentModifiers()
 	finalTempMod := eventTempMod + climateDriver.GetGeothermalOffset() + climateDriver.GetGreenhouseOffset()
-	geology.UpdateBiomes(finalTempMod)
+	geology.AxialTilt = climateDriver.GetObliquity()
+	geology.Biomes = geology.UpdateBiomesInto(geology.Biomes, nil, finalTempMod)
 
 	// Get final statistics
 	geoStats := geology.GetStats()
 	var totalPop, totalSpecies, totalExtinct int64
 	if popSim != nil {
 		totalPop, totalSpecies, totalExtinct = popSim.GetStats()
+
+		// The world's runner keeps the population, so 'world save' stores it
+		// and 'world run' carries it on
+		runner := p.getOrCreateRunner(char.WorldID)
+		runner.SetGeology(geology)
+		runner.RestorePopulationSimulator(popSim)
 	}
 
 	// Build summary
 	var sb strings.Builder
 	sb.WriteString("=== Simulation Complete ===\n")
-	sb.WriteString(fmt.Sprintf("Years Simulated: %d\n", years))
+	sb.WriteString(fmt.Sprintf("Years Simulated: %d\n", years-startYear))
+	if startYear > 0 {
+		sb.WriteString(fmt.Sprintf("World Age: %d years\n", geoStats.YearsSimulated))
+	}
 	if popSim != nil {
 		sb.WriteString(fmt.Sprintf("Total Population: %d\n", totalPop))
 		sb.WriteString(fmt.Sprintf("Living Species: %d\n", totalSpecies))
@@ -1098,6 +1415,11 @@ func (p *GameProcessor) handleWorldSimulate(ctx context.Context, client websocke
 		sb.WriteString(fmt.Sprintf("Extinction Cascades: %d\n", totalCascades))
 		if sapienceAchieved {
 			sb.WriteString("Sapience: ACHIEVED! 🧠\n")
+			for _, id := range sapienceDetector.SapientSpecies {
+				if culture := sapienceDetector.Culture(id); culture != nil {
+					sb.WriteString(fmt.Sprintf("Culture: %s, %s age\n", culture.SpeciesName, culture.Stage))
+				}
+			}
 		} else {
 			progress := sapienceDetector.CalculateSapienceProgress()
 			sb.WriteString(fmt.Sprintf("Sapience Progress: %.0f%%\n", progress*100))
@@ -1255,7 +1577,7 @@ func (p *GameProcessor) handleWorldInfo(ctx context.Context, client websocket.Ga
 	sb.WriteString(fmt.Sprintf("Entities: %d\n", len(p.ecosystemService.Entities)))
 
 	// Show terrain stats if geology has been simulated
-	if geology, exists := p.worldGeology[char.WorldID]; exists && geology.IsInitialized() {
+	if geology := p.geologyFor(char.WorldID); geology != nil && geology.IsInitialized() {
 		geoStats := geology.GetStats()
 		sb.WriteString("--- Terrain ---\n")
 		sb.WriteString(fmt.Sprintf("Tectonic Plates: %d\n", geoStats.PlateCount))
@@ -1309,14 +1631,12 @@ func (p *GameProcessor) handleWorldReset(ctx context.Context, client websocket.G
 	worldID := char.WorldID
 
 	// Stop and remove async runner if it exists
-	if runner := p.getRunner(worldID); runner != nil {
-		runner.Stop()
-		delete(p.worldRunners, worldID)
+	if p.resetRunner(worldID) {
 		client.SendGameMessage("system", "⏹️ Async simulation stopped.", nil)
 	}
 
 	// Clear geology for this world
-	delete(p.worldGeology, worldID)
+	p.setGeology(worldID, nil)
 
 	// Clear map service geology cache
 	if p.mapService != nil {
@@ -1335,6 +1655,127 @@ func (p *GameProcessor) handleWorldReset(ctx context.Context, client websocket.G
 	return nil
 }
 
+// handleWorldSave saves the world's geology (satellites included) and the
+// runner's population, from 'world simulate' or 'world run', to the world
+// repository
+func (p *GameProcessor) handleWorldSave(ctx context.Context, client websocket.GameClient) error {
+	char, err := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	if err != nil {
+		client.SendGameMessage("error", "Could not get character info", nil)
+		return nil
+	}
+	worldID := char.WorldID
+
+	store, ok := p.worldRepo.(repository.WorldStateStore)
+	if !ok {
+		client.SendGameMessage("error", "World saving is not available on this server", nil)
+		return nil
+	}
+
+	geology := p.geologyFor(worldID)
+	if geology == nil || !geology.IsInitialized() {
+		client.SendGameMessage("system", "Nothing to save yet. Use 'world simulate <years>' to generate terrain.", nil)
+		return nil
+	}
+
+	data, err := geology.Serialize()
+	if err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("Failed to save world: %v", err), nil)
+		return nil
+	}
+	state := &repository.WorldState{
+		Year:    geology.TotalYearsSimulated,
+		Geology: data,
+		SavedAt: time.Now(),
+	}
+
+	if runner := p.getRunner(worldID); runner != nil {
+		// A running simulation would change under the snapshot
+		if runner.GetState() == ecosystem.RunnerRunning {
+			client.SendGameMessage("error", "The simulation is running. Use 'world pause' before saving.", nil)
+			return nil
+		}
+		if popSim := runner.GetPopulationSimulator(); popSim != nil {
+			if state.Population, err = json.Marshal(popSim); err != nil {
+				client.SendGameMessage("error", fmt.Sprintf("Failed to save world: %v", err), nil)
+				return nil
+			}
+		}
+	}
+
+	if err := store.SaveWorldState(ctx, worldID, state); err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("Failed to save world: %v", err), nil)
+		return nil
+	}
+	client.SendGameMessage("system", fmt.Sprintf("💾 World saved at year %d. Use 'world load' to restore it.", state.Year), nil)
+	return nil
+}
+
+// handleWorldLoad restores the world's last save, replacing its geology, map
+// and async runner. Worlds never simulated in this session load too.
+func (p *GameProcessor) handleWorldLoad(ctx context.Context, client websocket.GameClient) error {
+	char, err := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
+	if err != nil {
+		client.SendGameMessage("error", "Could not get character info", nil)
+		return nil
+	}
+	worldID := char.WorldID
+
+	store, ok := p.worldRepo.(repository.WorldStateStore)
+	if !ok {
+		client.SendGameMessage("error", "World saving is not available on this server", nil)
+		return nil
+	}
+
+	state, err := store.LoadWorldState(ctx, worldID)
+	if err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("Failed to load world: %v", err), nil)
+		return nil
+	}
+	if state == nil {
+		client.SendGameMessage("system", "This world has no save. Use 'world save' first.", nil)
+		return nil
+	}
+
+	// Decode everything before touching the live world, so a bad save
+	// leaves it as it was
+	geology, err := ecosystem.LoadWorldGeology(state.Geology)
+	if err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("Failed to load world: %v", err), nil)
+		return nil
+	}
+	var popSim *population.PopulationSimulator
+	if state.Population != nil {
+		popSim = &population.PopulationSimulator{}
+		if err := json.Unmarshal(state.Population, popSim); err != nil {
+			client.SendGameMessage("error", fmt.Sprintf("Failed to load world: %v", err), nil)
+			return nil
+		}
+	}
+
+	// The old runner simulates the replaced geology
+	if runner := p.takeRunner(worldID); runner != nil {
+		runner.Stop()
+	}
+
+	p.setGeology(worldID, geology)
+	if p.mapService != nil {
+		p.mapService.SetWorldGeology(worldID, geology)
+	}
+
+	runner := p.getOrCreateRunner(worldID)
+	runner.SetGeology(geology)
+	if popSim != nil {
+		runner.RestorePopulationSimulator(popSim)
+	}
+	// After the restore, which rebuilds the climate the moons act on
+	runner.ConfigureSatellitePhysics(geology.Satellites)
+
+	client.SendGameMessage("system", fmt.Sprintf("📂 World loaded from year %d (saved %s). Use 'world run' to continue.",
+		state.Year, state.SavedAt.Format(time.RFC1123)), nil)
+	return nil
+}
+
 // handleWorldRun starts or resumes the async simulation runner
 func (p *GameProcessor) handleWorldRun(ctx context.Context, client websocket.GameClient) error {
 	char, _ := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
@@ -1422,7 +1863,13 @@ func (p *GameProcessor) handleWorldSpeed(ctx context.Context, client websocket.G
 }
 
 // handleWorldMap sends full world map data to the client for the world map modal
-func (p *GameProcessor) handleWorldMap(ctx context.Context, client websocket.GameClient) error {
+func (p *GameProcessor) handleWorldMap(ctx context.Context, client websocket.GameClient, argsStr string) error {
+	opts, err := ParseWorldMapArgs(argsStr)
+	if err != nil {
+		client.SendGameMessage("error", fmt.Sprintf("%v. Usage: world map [--biome dominant|mode|center] [--elevation center|mean|min|max]", err), nil)
+		return nil
+	}
+
 	char, err := p.authRepo.GetCharacter(ctx, client.GetCharacterID())
 	if err != nil || char == nil {
 		client.SendGameMessage("error", "Could not get character", nil)
@@ -1435,7 +1882,7 @@ func (p *GameProcessor) handleWorldMap(ctx context.Context, client websocket.Gam
 	}
 
 	// Get aggregated world map data (64x64 grid by default)
-	mapData, err := p.mapService.GetWorldMapData(ctx, char, 64)
+	mapData, err := p.mapService.GetWorldMapDataWithOptions(ctx, char, 64, opts)
 	if err != nil {
 		client.SendGameMessage("error", fmt.Sprintf("Failed to generate world map: %v", err), nil)
 		return nil
@@ -1462,6 +1909,9 @@ func (p *GameProcessor) handleWorldMap(ctx context.Context, client websocket.Gam
 		"world_name":   mapData.WorldName,
 		"is_simulated": mapData.IsSimulated,
 
+		"biome_aggregation":     mapData.BiomeAggregation,
+		"elevation_aggregation": mapData.ElevationAggregation,
+
 		// Planetary stats
 		"simulated_years": mapData.SimulatedYears,
 		"avg_temperature": mapData.AvgTemperature,
@@ -1497,6 +1947,11 @@ func (p *GameProcessor) handleWorldMap(ctx context.Context, client websocket.Gam
 // getOrCreateRunner gets an existing runner or creates a new one for the world
 // now initialized with V2 population simulator and persistence
 func (p *GameProcessor) getOrCreateRunner(worldID uuid.UUID) *ecosystem.SimulationRunner {
+	// Before locking: the seed reads the world's geology
+	seed := p.worldSeed(worldID, 0)
+
+	p.worldsMu.Lock()
+	defer p.worldsMu.Unlock()
 	if p.worldRunners == nil {
 		p.worldRunners = make(map[uuid.UUID]*ecosystem.SimulationRunner)
 	}
@@ -1504,20 +1959,20 @@ func (p *GameProcessor) getOrCreateRunner(worldID uuid.UUID) *ecosystem.Simulati
 		return runner
 	}
 
-	// Create config
+	// Create config, seeded the same way as "world simulate"
 	config := ecosystem.DefaultConfig(worldID)
+	config.Seed = seed
 	// Pass repositories
 	runner := ecosystem.NewSimulationRunner(config, p.simSnapshotRepo, p.runnerStateRepo)
-
-	// Initialize Simulator (Load from DB or Create New)
-	// Use world ID as seed part 1
-	seed := int64(worldID[0])<<56 | int64(worldID[1])<<48 |
-		int64(worldID[2])<<40 | int64(worldID[3])<<32 |
-		int64(worldID[4])<<24 | int64(worldID[5])<<16 |
-		int64(worldID[6])<<8 | int64(worldID[7])
+	if p.fossilStore != nil {
+		runner.SetFossilStore(p.fossilStore)
+	}
 
 	// Initialize (this handles loading snapshot if available)
-	runner.InitializePopulationSimulator(seed)
+	runner.InitializePopulationSimulator(runner.Seed())
+	if p.events != nil {
+		runner.SetEventPublisher(p.events)
+	}
 
 	// Configure satellite physics (Natural Satellites Phase 4)
 	// Look up cached world data to get satellites
@@ -1529,6 +1984,12 @@ func (p *GameProcessor) getOrCreateRunner(worldID uuid.UUID) *ecosystem.Simulati
 
 	// Set handlers
 	runner.SetEventBroadcastHandler(func(event ecosystem.RunnerEvent) {
+		// Newly sapient species send an envoy players can talk to. Off the
+		// tick: placing it touches the entity store.
+		if event.Type == "sapience" && event.SpeciesID != nil {
+			go p.greetSapientSpecies(worldID, *event.SpeciesID, event.SpeciesName)
+		}
+
 		// Broadcast to all watchers in this world
 		// We can get clients from Hub
 		if p.Hub != nil {
@@ -1548,18 +2009,130 @@ func (p *GameProcessor) getOrCreateRunner(worldID uuid.UUID) *ecosystem.Simulati
 		}
 	})
 
+	// Background runs report progress toward their target year in the logs
+	runner.SetProgressReporter(ecosystem.LogProgressReporter{WorldID: worldID}, ecosystem.DefaultProgressSteps)
+
 	p.worldRunners[worldID] = runner
 	return runner
 }
 
+// simMessenger gates world simulate's system messages by verbosity
+type simMessenger struct {
+	client    websocket.GameClient
+	verbosity ecosystem.LogLevel
+	progress  *simProgressStream // Collects events for sim_progress, once the run starts
+}
+
+// send delivers msg when its level meets the run's verbosity. Events are
+// also passed on to the next sim_progress message whatever the verbosity.
+func (m simMessenger) send(level ecosystem.LogLevel, msg string) {
+	if level == ecosystem.LogLevelInfo {
+		m.progress.noteEvent(msg)
+	}
+	if level >= m.verbosity {
+		m.client.SendGameMessage("system", msg, nil)
+	}
+}
+
+// sendEventDetails sends the simulator's event log for the step and each
+// extinction since the first `reported` fossils, returning the new count
+func (m simMessenger) sendEventDetails(popSim *population.PopulationSimulator, reported int) int {
+	for _, event := range popSim.Events {
+		m.send(ecosystem.LogLevelDebug, fmt.Sprintf("📜 Year %d: %s", popSim.CurrentYear, event))
+	}
+	fossils := popSim.FossilRecord.Extinct
+	for _, ext := range fossils[min(reported, len(fossils)):] {
+		m.send(ecosystem.LogLevelDebug, fmt.Sprintf("💀 Year %d: %s went extinct (%s)", ext.ExistedUntil, ext.Name, ext.ExtinctionCause))
+	}
+	return len(fossils)
+}
+
+// clientProgressReporter sends simulation progress to the requesting client
+type clientProgressReporter struct {
+	client    websocket.GameClient
+	verbosity ecosystem.LogLevel // Progress is routine; quiet runs skip it
+	stream    *simProgressStream // Always gets the update
+}
+
+// ReportProgress streams the update as sim_progress and sends it as a system
+// message
+func (r clientProgressReporter) ReportProgress(update ecosystem.ProgressUpdate) {
+	r.stream.publish(update)
+	if ecosystem.LogLevelInfo < r.verbosity {
+		return
+	}
+	if update.HasStats {
+		r.client.SendGameMessage("system", fmt.Sprintf("⏳ Progress: %d%% (Year %d, Pop: %d, Species: %d, Extinct: %d)",
+			update.Percent, update.Year, update.Population, update.Species, update.Extinct), nil)
+		return
+	}
+	r.client.SendGameMessage("system", fmt.Sprintf("⏳ Progress: %d%% (Year %d)", update.Percent, update.Year), nil)
+}
+
+// worldSeed picks the seed for a world's simulation: an explicit override, then
+// the seed of geology already simulated for the world (so continuing runs and
+// the async runner match it), then the seed derived from the world ID
+func (p *GameProcessor) worldSeed(worldID uuid.UUID, override int64) int64 {
+	if override != 0 {
+		return override
+	}
+	if existing := p.geologyFor(worldID); existing != nil && existing.IsInitialized() && existing.Seed != 0 {
+		return existing.Seed
+	}
+	return ecosystem.WorldSeed(worldID)
+}
+
+// resetRunner stops and removes the world's async runner, clearing its
+// population and species names so the next runner doesn't restore them.
+// Reports whether there was one.
+func (p *GameProcessor) resetRunner(worldID uuid.UUID) bool {
+	runner := p.takeRunner(worldID)
+	if runner == nil {
+		return false
+	}
+	runner.Stop()
+	runner.ResetPopulation()
+	return true
+}
+
 // getRunner retrieves an existing runner for the world (nil if not exists)
 func (p *GameProcessor) getRunner(worldID uuid.UUID) *ecosystem.SimulationRunner {
-	if p.worldRunners == nil {
-		return nil
-	}
+	p.worldsMu.RLock()
+	defer p.worldsMu.RUnlock()
 	return p.worldRunners[worldID]
 }
 
+// takeRunner removes the world's runner from the map and returns it (nil if
+// there was none). The caller stops it.
+func (p *GameProcessor) takeRunner(worldID uuid.UUID) *ecosystem.SimulationRunner {
+	p.worldsMu.Lock()
+	defer p.worldsMu.Unlock()
+	runner := p.worldRunners[worldID]
+	delete(p.worldRunners, worldID)
+	return runner
+}
+
+// geologyFor returns the world's in-memory geology (nil if not simulated)
+func (p *GameProcessor) geologyFor(worldID uuid.UUID) *ecosystem.WorldGeology {
+	p.worldsMu.RLock()
+	defer p.worldsMu.RUnlock()
+	return p.worldGeology[worldID]
+}
+
+// setGeology stores the world's geology; nil clears it
+func (p *GameProcessor) setGeology(worldID uuid.UUID, geology *ecosystem.WorldGeology) {
+	p.worldsMu.Lock()
+	defer p.worldsMu.Unlock()
+	if geology == nil {
+		delete(p.worldGeology, worldID)
+		return
+	}
+	if p.worldGeology == nil {
+		p.worldGeology = make(map[uuid.UUID]*ecosystem.WorldGeology)
+	}
+	p.worldGeology[worldID] = geology
+}
+
 // getSeasonFromYear calculates season from simulated year for weather simulation
 func (p *GameProcessor) getSeasonFromYear(simulatedYear int64) weather.Season {
 	// Cycle through seasons: 4 seasons per year
@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -9,7 +10,10 @@ import (
 	"tw-backend/cmd/game-server/websocket"
 	"tw-backend/internal/auth"
 	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/eventstore"
 	"tw-backend/internal/repository" // Added import
+	"tw-backend/internal/worldgen/geography"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -139,3 +143,393 @@ func TestHandleWorld_Simulate_Default(t *testing.T) {
 		assert.True(t, foundSpawnMsg, "Should report spawning if entities exist")
 	}
 }
+
+// newSimulateTestProcessor builds a processor with a single character in a
+// 40,000 km world and returns the pieces needed to drive "world simulate".
+func newSimulateTestProcessor(t *testing.T) (*GameProcessor, *mockClient, uuid.UUID) {
+	t.Helper()
+	mockAuthRepo := auth.NewMockRepository()
+	mockWorldRepo := NewMockWorldRepository()
+	ecoSvc := ecosystem.NewService(time.Now().Unix())
+
+	proc := NewGameProcessor(mockAuthRepo, mockWorldRepo, nil, nil, nil, nil, nil, nil, nil, nil, ecoSvc, nil, nil, nil, nil, nil, nil)
+
+	charID := uuid.New()
+	userID := uuid.New()
+	worldID := uuid.New()
+	circ := 40000000.0
+
+	mockWorldRepo.CreateWorld(context.Background(), &repository.World{
+		ID:            worldID,
+		Name:          "Test World",
+		Circumference: &circ,
+	})
+	mockAuthRepo.CreateCharacter(context.Background(), &auth.Character{
+		CharacterID: charID,
+		UserID:      userID,
+		WorldID:     worldID,
+	})
+
+	return proc, &mockClient{UserID: userID, CharacterID: charID}, worldID
+}
+
+func runWorldSimulate(t *testing.T, proc *GameProcessor, client *mockClient, args string) {
+	t.Helper()
+	target := "simulate"
+	cmd := &websocket.CommandData{Action: "world", Target: &target, Message: &args}
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, cmd))
+}
+
+func runWorldCommand(t *testing.T, proc *GameProcessor, client *mockClient, subCmd string) {
+	t.Helper()
+	cmd := &websocket.CommandData{Action: "world", Target: &subCmd}
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, cmd))
+}
+
+// TestHandleWorld_Simulate_ContinuesFromPriorYear verifies that a second
+// simulate on an already-simulated world picks up where the first left off.
+func TestHandleWorld_Simulate_ContinuesFromPriorYear(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+
+	runWorldSimulate(t, proc, client, "100 --only-geology")
+	first := proc.worldGeology[worldID]
+	require.NotNil(t, first)
+	require.Equal(t, int64(100), first.TotalYearsSimulated)
+
+	client.messages = nil
+	runWorldSimulate(t, proc, client, "250 --only-geology")
+
+	second := proc.worldGeology[worldID]
+	assert.Same(t, first, second, "Geology state should be preserved between runs")
+	assert.Equal(t, int64(250), second.TotalYearsSimulated, "Should continue to the requested total, not add a full run")
+
+	foundContinue := false
+	for _, m := range client.messages {
+		if strings.Contains(m.Text, "Continuing from year 100") {
+			foundContinue = true
+		}
+	}
+	assert.True(t, foundContinue, "Should report continuation from the prior year")
+}
+
+// TestHandleWorld_Simulate_ContinuesPopulatedWorld verifies that continuing a
+// world with life evolves the population it already has rather than seeding
+// new life from year 0
+func TestHandleWorld_Simulate_ContinuesPopulatedWorld(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+
+	runWorldSimulate(t, proc, client, "20")
+	runner := proc.getRunner(worldID)
+	require.NotNil(t, runner)
+	first := runner.GetPopulationSimulator()
+	require.NotNil(t, first)
+	require.Equal(t, int64(20), first.CurrentYear)
+	speciesIDs := make(map[uuid.UUID]bool)
+	for _, biome := range first.Biomes {
+		for id := range biome.Species {
+			speciesIDs[id] = true
+		}
+	}
+	require.NotEmpty(t, speciesIDs)
+
+	client.messages = nil
+	runWorldSimulate(t, proc, client, "40")
+
+	second := proc.getRunner(worldID).GetPopulationSimulator()
+	assert.Same(t, first, second, "the continuation should reuse the world's population")
+	assert.Equal(t, int64(40), second.CurrentYear, "life should carry on from year 20")
+	carried := 0
+	for _, biome := range second.Biomes {
+		for id := range biome.Species {
+			if speciesIDs[id] {
+				carried++
+			}
+		}
+	}
+	assert.Positive(t, carried, "species from the first run should live on")
+
+	foundCarried := false
+	for _, m := range client.messages {
+		if strings.Contains(m.Text, "Continuing life from year 20") {
+			foundCarried = true
+		}
+	}
+	assert.True(t, foundCarried, "should report the life carried over")
+}
+
+// TestHandleWorld_Simulate_AlreadyAtTarget verifies that requesting a total
+// the world has already reached does no work.
+func TestHandleWorld_Simulate_AlreadyAtTarget(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+
+	runWorldSimulate(t, proc, client, "200 --only-geology")
+	client.messages = nil
+	runWorldSimulate(t, proc, client, "100 --only-geology")
+
+	assert.Equal(t, int64(200), proc.worldGeology[worldID].TotalYearsSimulated)
+	require.NotEmpty(t, client.messages)
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "already simulated to year 200")
+}
+
+// TestHandleWorld_Simulate_FreshRestarts verifies that --fresh discards the
+// accumulated geology and restarts from year zero.
+func TestHandleWorld_Simulate_FreshRestarts(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+
+	runWorldSimulate(t, proc, client, "300 --only-geology")
+	first := proc.worldGeology[worldID]
+	require.Equal(t, int64(300), first.TotalYearsSimulated)
+
+	runWorldSimulate(t, proc, client, "150 --only-geology --fresh")
+
+	second := proc.worldGeology[worldID]
+	assert.NotSame(t, first, second, "--fresh should build new geology")
+	assert.Equal(t, int64(150), second.TotalYearsSimulated, "--fresh should simulate from year 0")
+}
+
+// TestHandleWorld_Simulate_StreamsProgress verifies that a run streams
+// sim_progress messages in increasing-year order ahead of its summary
+func TestHandleWorld_Simulate_StreamsProgress(t *testing.T) {
+	proc, client, _ := newSimulateTestProcessor(t)
+
+	runWorldSimulate(t, proc, client, "2000000 --only-geology --progress-steps 10")
+
+	var years []int64
+	summary := -1
+	for i, m := range client.messages {
+		switch {
+		case m.Type == "sim_progress":
+			assert.Equal(t, -1, summary, "progress should precede the summary")
+			year, ok := m.Metadata["year"].(int64)
+			require.True(t, ok, "year should be set")
+			years = append(years, year)
+			assert.Contains(t, m.Metadata, "terrain")
+			assert.Equal(t, int64(2000000), m.Metadata["target_year"])
+		case strings.Contains(m.Text, "Simulation Complete"):
+			summary = i
+		}
+	}
+	require.NotEmpty(t, years, "should stream progress")
+	for i := 1; i < len(years); i++ {
+		assert.Greater(t, years[i], years[i-1], "progress years should increase")
+	}
+}
+
+// worldInfoTerrain runs "world info" and returns its text up to the async
+// runner's section
+func worldInfoTerrain(t *testing.T, proc *GameProcessor, client *mockClient) string {
+	t.Helper()
+	client.messages = nil
+	target := "info"
+	require.NoError(t, proc.ProcessCommand(context.Background(), client, &websocket.CommandData{Action: "world", Target: &target}))
+	require.NotEmpty(t, client.messages)
+	info, _, _ := strings.Cut(client.messages[len(client.messages)-1].Text, "--- Async Simulation ---")
+	return info
+}
+
+// TestHandleWorld_SaveResetLoad verifies that a saved world survives a reset
+func TestHandleWorld_SaveResetLoad(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+
+	runWorldSimulate(t, proc, client, "300 --only-geology --moons 2")
+	// Give the world a population to save
+	biome := population.NewBiomePopulation(uuid.New(), geography.BiomeGrassland)
+	biome.AddSpecies(&population.SpeciesPopulation{
+		SpeciesID: uuid.New(), Name: "Plains Grazer", Count: 500,
+		Diet: population.DietHerbivore, Traits: population.DefaultTraitsForDiet(population.DietHerbivore),
+	})
+	proc.getOrCreateRunner(worldID).GetPopulationSimulator().Biomes[biome.BiomeID] = biome
+	before := worldInfoTerrain(t, proc, client)
+	satellites := proc.worldGeology[worldID].Satellites
+
+	runWorldCommand(t, proc, client, "save")
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "World saved at year 300")
+
+	runWorldCommand(t, proc, client, "reset")
+	require.NotContains(t, proc.worldGeology, worldID)
+	assert.Contains(t, worldInfoTerrain(t, proc, client), "Not yet simulated")
+
+	runWorldCommand(t, proc, client, "load")
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "World loaded from year 300")
+
+	loaded := proc.worldGeology[worldID]
+	require.NotNil(t, loaded)
+	assert.Equal(t, int64(300), loaded.TotalYearsSimulated)
+	assert.Equal(t, satellites, loaded.Satellites)
+	assert.Equal(t, before, worldInfoTerrain(t, proc, client), "world info should match the saved world")
+
+	runner := proc.getRunner(worldID)
+	require.NotNil(t, runner, "load should set up the runner")
+	assert.Same(t, loaded, runner.GetGeology())
+	assert.NotNil(t, runner.GetPopulationSimulator())
+
+	// The loaded world keeps simulating
+	year := runner.GetCurrentYear()
+	require.NoError(t, runner.Step(1))
+	assert.Greater(t, runner.GetCurrentYear(), year)
+	assert.NotEqual(t, ecosystem.RunnerError, runner.GetState())
+}
+
+// TestHandleWorld_SaveKeepsSimulatedLife verifies that 'world save' stores
+// the population 'world simulate' evolved
+func TestHandleWorld_SaveKeepsSimulatedLife(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+
+	runWorldSimulate(t, proc, client, "20")
+	runner := proc.getRunner(worldID)
+	require.NotNil(t, runner, "simulate should hand its population to the runner")
+	simulated := runner.GetPopulationSimulator()
+	require.NotNil(t, simulated)
+	assert.Equal(t, int64(20), simulated.CurrentYear)
+	_, species, _ := simulated.GetStats()
+	require.Positive(t, species)
+
+	runWorldCommand(t, proc, client, "save")
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "World saved at year 20")
+
+	state, err := proc.worldRepo.(repository.WorldStateStore).LoadWorldState(context.Background(), worldID)
+	require.NoError(t, err)
+	require.NotNil(t, state.Population, "the simulated population should be saved")
+	var saved population.PopulationSimulator
+	require.NoError(t, json.Unmarshal(state.Population, &saved))
+	assert.Equal(t, int64(20), saved.CurrentYear)
+	assert.Len(t, saved.Biomes, len(simulated.Biomes))
+}
+
+// TestHandleWorld_LoadWithoutSave verifies that loading a world that was
+// never saved (or simulated) changes nothing
+func TestHandleWorld_LoadWithoutSave(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+
+	runWorldCommand(t, proc, client, "load")
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "no save")
+	assert.NotContains(t, proc.worldGeology, worldID)
+	assert.Nil(t, proc.getRunner(worldID))
+
+	runWorldCommand(t, proc, client, "save")
+	assert.Contains(t, client.messages[len(client.messages)-1].Text, "Nothing to save")
+}
+
+// TestWorldSeed_SyncAndAsyncMatch verifies that "world simulate" and the async
+// runner derive the same seed for a world
+func TestWorldSeed_SyncAndAsyncMatch(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+	asyncProc, _, _ := newSimulateTestProcessor(t)
+
+	// Async first, on a processor that has never simulated the world
+	runner := asyncProc.getOrCreateRunner(worldID)
+
+	runWorldSimulate(t, proc, client, "100 --geology")
+	geology := proc.worldGeology[worldID]
+	require.NotNil(t, geology)
+
+	assert.Equal(t, ecosystem.WorldSeed(worldID), geology.Seed, "sync simulate should derive the seed from the world ID")
+	assert.Equal(t, geology.Seed, runner.Seed(), "async runner should derive the same seed")
+}
+
+// TestWorldSeed_UserSeedOverridesBoth verifies that a --seed given to
+// "world simulate" is also used by the runner started afterwards
+func TestWorldSeed_UserSeedOverridesBoth(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+
+	runWorldSimulate(t, proc, client, "100 --geology --seed 987654321")
+	require.NotNil(t, proc.worldGeology[worldID])
+	assert.Equal(t, int64(987654321), proc.worldGeology[worldID].Seed)
+
+	runner := proc.getOrCreateRunner(worldID)
+	assert.Equal(t, int64(987654321), runner.Seed())
+
+	// Continuing without --seed keeps the user's seed
+	runWorldSimulate(t, proc, client, "200 --geology")
+	assert.Equal(t, int64(987654321), proc.worldGeology[worldID].Seed)
+}
+
+func systemMessages(client *mockClient) []string {
+	var texts []string
+	for _, msg := range client.messages {
+		if msg.Type == "system" {
+			texts = append(texts, msg.Text)
+		}
+	}
+	return texts
+}
+
+func TestWorldSimulate_QuietSuppressesChatter(t *testing.T) {
+	proc, client, _ := newSimulateTestProcessor(t)
+	runWorldSimulate(t, proc, client, "1000 --geology")
+	chatty := systemMessages(client)
+
+	proc, client, _ = newSimulateTestProcessor(t)
+	runWorldSimulate(t, proc, client, "1000 --geology --verbosity quiet")
+	quiet := systemMessages(client)
+
+	assert.Less(t, len(quiet), len(chatty), "quiet should send fewer messages")
+	for _, text := range quiet {
+		assert.NotContains(t, text, "Progress:", "quiet should skip progress updates")
+		assert.NotContains(t, text, "Starting geology-only simulation", "quiet should skip routine notices")
+	}
+	require.NotEmpty(t, quiet)
+	assert.Contains(t, quiet[len(quiet)-1], "=== Simulation Complete ===", "quiet should still report the summary")
+}
+
+func TestWorldSimulate_UnknownVerbosity(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+	runWorldSimulate(t, proc, client, "100 --geology --verbosity shouting")
+
+	require.NotEmpty(t, client.messages)
+	last := client.messages[len(client.messages)-1]
+	assert.Equal(t, "error", last.Type)
+	assert.Contains(t, last.Text, "unknown verbosity")
+	assert.Nil(t, proc.worldGeology[worldID], "an invalid flag should not start a run")
+}
+
+func TestSimMessenger_VerboseSendsEventDetails(t *testing.T) {
+	popSim := population.NewPopulationSimulator(uuid.New(), 1)
+	popSim.CurrentYear = 500
+	popSim.Events = []string{"Wildfires burned 2 biomes"}
+	popSim.FossilRecord.Extinct = append(popSim.FossilRecord.Extinct,
+		&population.ExtinctSpecies{Name: "Old Grazer", ExistedUntil: 100, ExtinctionCause: "starvation"},
+		&population.ExtinctSpecies{Name: "Shelled Drifter", ExistedUntil: 499, ExtinctionCause: "predation"},
+	)
+
+	verbose := &mockClient{}
+	reported := simMessenger{client: verbose, verbosity: ecosystem.LogLevelDebug}.sendEventDetails(popSim, 1)
+	assert.Equal(t, 2, reported)
+	texts := systemMessages(verbose)
+	require.Len(t, texts, 2, "one message per event and per new extinction")
+	assert.Contains(t, texts[0], "Wildfires burned 2 biomes")
+	assert.Contains(t, texts[1], "Shelled Drifter went extinct (predation)")
+
+	info := &mockClient{}
+	simMessenger{client: info, verbosity: ecosystem.LogLevelInfo}.sendEventDetails(popSim, 1)
+	assert.Empty(t, info.messages, "per-event detail is for verbose runs only")
+}
+
+// TestHandleWorld_SimulatePublishesExtinctions verifies that "world simulate"
+// publishes its extinctions for other services, as the runner does
+func TestHandleWorld_SimulatePublishesExtinctions(t *testing.T) {
+	proc, client, worldID := newSimulateTestProcessor(t)
+	store := &memEventStore{}
+	pub := eventstore.NewPublisher(store)
+	proc.SetEventPublisher(pub)
+
+	runWorldSimulate(t, proc, client, "500")
+	pub.Flush()
+
+	runner := proc.getRunner(worldID)
+	require.NotNil(t, runner)
+	extinct := runner.GetPopulationSimulator().FossilRecord.Extinct
+	require.NotEmpty(t, extinct, "500 years should see some species die out")
+
+	var published []eventstore.Event
+	for _, event := range store.events {
+		if event.EventType == eventstore.EventTypeSpeciesExtinct {
+			published = append(published, event)
+		}
+	}
+	require.Len(t, published, len(extinct), "Each extinction is published exactly once")
+	var payload eventstore.SpeciesExtinctPayload
+	require.NoError(t, json.Unmarshal(published[0].Payload, &payload))
+	assert.Equal(t, worldID, payload.WorldID)
+	assert.Equal(t, extinct[0].SpeciesID, payload.SpeciesID)
+}
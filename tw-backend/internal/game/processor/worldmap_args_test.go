@@ -0,0 +1,33 @@
+package processor_test
+
+import (
+	"testing"
+
+	"tw-backend/internal/game/processor"
+	gamemap "tw-backend/internal/game/services/map"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// -----------------------------------------------------------------------------
+// Scenario: World Map Aggregation Flags
+// -----------------------------------------------------------------------------
+// Given: A world map command string
+// When: ParseWorldMapArgs is called
+// Then: Aggregation options default to dominant/center and follow the flags
+func TestBDD_WorldMap_ParseArgs(t *testing.T) {
+	opts, err := processor.ParseWorldMapArgs("")
+	require.NoError(t, err)
+	assert.Equal(t, gamemap.DefaultWorldMapOptions(), opts)
+
+	opts, err = processor.ParseWorldMapArgs("--biome mode --elevation max")
+	require.NoError(t, err)
+	assert.Equal(t, gamemap.BiomeMode, opts.Biome)
+	assert.Equal(t, gamemap.ElevationMax, opts.Elevation)
+
+	for _, bad := range []string{"--biome average", "--elevation", "--zoom 2"} {
+		_, err := processor.ParseWorldMapArgs(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -3,34 +3,143 @@ package combat
 import (
 	"fmt"
 	"log"
+	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
 	"tw-backend/internal/character"
 	"tw-backend/internal/combat/action"
+	"tw-backend/internal/combat/config"
+	"tw-backend/internal/combat/damage"
+	"tw-backend/internal/combat/effects"
 	"tw-backend/internal/game/services/entity"
 )
 
+// CriticalStunDuration is how long a critical hit stuns its target
+const CriticalStunDuration = 1 * time.Second
+
 // CombatEvent represents an event occurring during combat resolution
 type CombatEvent struct {
-	Type      string                 `json:"type"` // attack_start, damage, death
+	Type      string                 `json:"type"` // combat_action, miss, death
 	Timestamp time.Time              `json:"timestamp"`
 	Data      map[string]interface{} `json:"data"`
+	Result    *AttackResult          `json:"result,omitempty"` // Set on resolved attacks and their miss and death events
+}
+
+// AttackResult is the structured outcome of one resolved attack, for rendering
+// to the attacker, the target and witnesses
+type AttackResult struct {
+	AttackerID     uuid.UUID           `json:"attacker_id"`
+	TargetID       uuid.UUID           `json:"target_id"`
+	Outcome        action.HitOutcome   `json:"outcome"`
+	Critical       bool                `json:"critical"`
+	Fumble         bool                `json:"fumble"`
+	Damage         int                 `json:"damage"`
+	Blocked        int                 `json:"blocked"`
+	Effects        []action.EffectType `json:"effects,omitempty"`
+	TargetHP       int                 `json:"target_hp"`
+	TargetMaxHP    int                 `json:"target_max_hp"` // 0 when the target isn't a tracked combatant
+	TargetDefeated bool                `json:"target_defeated"`
+	WeaponBroke    *damage.WeaponBroke `json:"weapon_broke,omitempty"` // Set when this hit broke the attacker's weapon
+
+	// The attacker's weapon and the durability it has left, when the hit wore one
+	WeaponID         uuid.UUID `json:"weapon_id,omitempty"`
+	WeaponDurability int       `json:"weapon_durability,omitempty"`
 }
 
-// Service manages the combat system
+// DefaultNPCSkill is the weapon and dodge skill NPCs fight with, having no
+// skill sheet of their own
+const DefaultNPCSkill = 25
+
+// SkillLookup returns a character's to-hit skills: their level with a weapon
+// (nil fights unarmed) and at dodging
+type SkillLookup func(characterID uuid.UUID, weapon *damage.Weapon) (accuracy, evasion int)
+
+// DefaultDisconnectGrace is how long a disconnected character stays in combat
+// before being removed, giving the client time to reconnect
+const DefaultDisconnectGrace = 2 * time.Minute
+
+// Service manages the combat system.
+// Combat state is keyed by character ID, not by connection, so a client that
+// reconnects within the grace period rejoins its ongoing fight.
 type Service struct {
 	resolver      *action.CombatResolver
 	entityService *entity.Service
+
+	// disconnected tracks when each combatant's connection dropped
+	disconnected    map[uuid.UUID]time.Time
+	disconnectGrace time.Duration
+	mu              sync.Mutex
+
+	// attributes feed damage calculation for characters in combat
+	attributes map[uuid.UUID]character.Attributes
+	// effects holds each entity's temporary stat modifiers, e.g. from potions
+	effects map[uuid.UUID]*effects.EffectManager
+	// damageRoll returns the 1-100 roll for damage and criticals
+	damageRoll func() int
+	// reaction sets how long queued actions take to execute
+	reaction *action.ReactionModel
+	// skills finds characters' accuracy and evasion; nil counts them as 0
+	skills SkillLookup
+	// config sets to-hit, dodging and reaction times
+	config *config.CombatConfig
 }
 
-// NewService creates a new combat service
+// NewService creates a new combat service using the default combat config
 func NewService(entityService *entity.Service) *Service {
+	return NewServiceWithConfig(entityService, config.Default())
+}
+
+// NewServiceWithConfig creates a combat service whose to-hit rolls, area
+// damage, dodging and reaction times follow cfg
+func NewServiceWithConfig(entityService *entity.Service, cfg *config.CombatConfig) *Service {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	return &Service{
-		resolver:      action.NewCombatResolver(),
-		entityService: entityService,
+		resolver:        action.NewCombatResolverWithConfig(cfg),
+		entityService:   entityService,
+		disconnected:    make(map[uuid.UUID]time.Time),
+		disconnectGrace: DefaultDisconnectGrace,
+		attributes:      make(map[uuid.UUID]character.Attributes),
+		effects:         make(map[uuid.UUID]*effects.EffectManager),
+		damageRoll:      func() int { return rng.Intn(100) + 1 },
+		reaction:        action.NewReactionModel(cfg),
+		config:          cfg,
+	}
+}
+
+// SetDisconnectGrace changes how long disconnected characters are kept in combat
+func (s *Service) SetDisconnectGrace(grace time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disconnectGrace = grace
+}
+
+// SetReactionModel changes the reaction-time rule-set for newly queued actions
+func (s *Service) SetReactionModel(model *action.ReactionModel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reaction = model
+}
+
+// SetSkillLookup sets how characters' accuracy and evasion are found when
+// they join combat or change weapons
+func (s *Service) SetSkillLookup(lookup SkillLookup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skills = lookup
+}
+
+// combatSkills returns a character's accuracy with a weapon and evasion
+func (s *Service) combatSkills(characterID uuid.UUID, weapon *damage.Weapon) (accuracy, evasion int) {
+	s.mu.Lock()
+	lookup := s.skills
+	s.mu.Unlock()
+	if lookup == nil {
+		return 0, 0
 	}
+	return lookup(characterID, weapon)
 }
 
 // JoinCombat adds an entity to the combat session
@@ -38,8 +147,31 @@ func (s *Service) JoinCombat(combatant *action.Combatant) {
 	s.resolver.AddCombatant(combatant)
 }
 
-// JoinCombatFromCharacter creates a combatant from a character and joins combat
+// InCombat reports whether the entity is currently a combatant
+func (s *Service) InCombat(entityID uuid.UUID) bool {
+	return s.resolver.GetCombatant(entityID) != nil
+}
+
+// Combatant returns an entity's combatant, or nil if it isn't in combat
+func (s *Service) Combatant(entityID uuid.UUID) *action.Combatant {
+	return s.resolver.GetCombatant(entityID)
+}
+
+// JoinCombatFromCharacter creates a combatant from a character and joins combat.
+// A character already in combat keeps its existing HP, stamina and state, but
+// picks up its current attributes and skills (see RefreshCharacter).
 func (s *Service) JoinCombatFromCharacter(char *character.Character) {
+	if s.InCombat(char.ID) {
+		s.RefreshCharacter(char)
+		return
+	}
+
+	s.mu.Lock()
+	s.attributes[char.ID] = char.BaseAttrs
+	s.mu.Unlock()
+
+	// Unarmed until SetEquipment arms them
+	accuracy, evasion := s.combatSkills(char.ID, nil)
 	combatant := &action.Combatant{
 		EntityID:       char.ID,
 		MaxHP:          char.SecAttrs.MaxHP,
@@ -47,15 +179,183 @@ func (s *Service) JoinCombatFromCharacter(char *character.Character) {
 		MaxStamina:     char.SecAttrs.MaxStamina,
 		CurrentStamina: char.SecAttrs.MaxStamina, // TODO: Load from persistence
 		Agility:        char.BaseAttrs.Agility,
+		Accuracy:       accuracy,
+		Evasion:        evasion,
 		CombatState:    action.StateIdle,
+		Defense:        action.NewDefenseProfile(s.config), // Dodges by Agility
 	}
 	s.JoinCombat(combatant)
 }
 
+// JoinCombatFromNPC creates a combatant for an NPC with the given
+// attributes and joins combat. NPCs fight at DefaultNPCSkill. An NPC already
+// in combat keeps its existing combatant.
+func (s *Service) JoinCombatFromNPC(npcID uuid.UUID, attrs character.Attributes) {
+	if s.InCombat(npcID) {
+		return
+	}
+
+	s.mu.Lock()
+	s.attributes[npcID] = attrs
+	s.mu.Unlock()
+
+	secondary := character.CalculateSecondaryAttributes(attrs)
+	s.JoinCombat(&action.Combatant{
+		EntityID:       npcID,
+		MaxHP:          secondary.MaxHP,
+		CurrentHP:      secondary.MaxHP,
+		MaxStamina:     secondary.MaxStamina,
+		CurrentStamina: secondary.MaxStamina,
+		Agility:        attrs.Agility,
+		Accuracy:       DefaultNPCSkill,
+		Evasion:        DefaultNPCSkill,
+		CombatState:    action.StateIdle,
+		Defense:        action.NewDefenseProfile(s.config),
+	})
+}
+
+// RefreshCharacter applies a character's current base and secondary
+// attributes and skills to its combatant, e.g. after leveling or a mutation
+// mid-fight.
+// Current HP and stamina are kept, capped at the new maximums.
+func (s *Service) RefreshCharacter(char *character.Character) {
+	combatant := s.resolver.GetCombatant(char.ID)
+	if combatant == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.attributes[char.ID] = char.BaseAttrs
+	s.mu.Unlock()
+	accuracy, evasion := s.combatSkills(char.ID, combatant.Weapon)
+
+	combatant.MaxHP = char.SecAttrs.MaxHP
+	combatant.CurrentHP = min(combatant.CurrentHP, combatant.MaxHP)
+	combatant.MaxStamina = char.SecAttrs.MaxStamina
+	combatant.CurrentStamina = min(combatant.CurrentStamina, combatant.MaxStamina)
+	combatant.Agility = char.BaseAttrs.Agility
+	combatant.Accuracy = accuracy
+	combatant.Evasion = evasion
+}
+
+// SetEquipment arms a character's combatant with their equipped weapon and
+// armor, either of which may be nil, and their equipment's stat bonuses.
+// Their accuracy follows their skill with the weapon. Entities not in combat
+// are ignored.
+func (s *Service) SetEquipment(entityID uuid.UUID, weapon *damage.Weapon, armor *damage.Armor, bonuses damage.Bonuses) {
+	if !s.InCombat(entityID) {
+		return
+	}
+	accuracy, _ := s.combatSkills(entityID, weapon)
+	s.resolver.UpdateCombatant(entityID, func(combatant *action.Combatant) {
+		combatant.Weapon = weapon
+		combatant.Armor = armor
+		combatant.Bonuses = bonuses
+		combatant.Accuracy = accuracy
+	})
+}
+
+// SetEncumbrance sets how much a combatant's load slows their reactions (see
+// player.EncumbrancePenalty). Entities not in combat are ignored.
+func (s *Service) SetEncumbrance(entityID uuid.UUID, penalty float64) {
+	if combatant := s.resolver.GetCombatant(entityID); combatant != nil {
+		combatant.Encumbrance = penalty
+	}
+}
+
+// ApplyConsumable applies what a consumed item does to an entity: its buff
+// lasts whether or not they are fighting, while HP and stamina are only
+// restored to a combatant (outside combat, stamina is the caller's to
+// restore). Returns the HP and stamina actually restored.
+func (s *Service) ApplyConsumable(entityID uuid.UUID, c effects.Consumable, now time.Time) (healed, restored int) {
+	if c.Buff != nil {
+		s.mu.Lock()
+		s.effectManager(entityID).ApplyModifier(entityID, *c.Buff, now)
+		s.mu.Unlock()
+	}
+
+	s.resolver.UpdateCombatant(entityID, func(combatant *action.Combatant) {
+		if combatant.CombatState == action.StateDefeated {
+			return
+		}
+		if c.Heal > 0 {
+			healed = min(c.Heal, combatant.MaxHP-combatant.CurrentHP)
+			combatant.CurrentHP += healed
+		}
+		if c.Stamina > 0 {
+			restored = min(c.Stamina, combatant.MaxStamina-combatant.CurrentStamina)
+			combatant.CurrentStamina += restored
+		}
+	})
+	return healed, restored
+}
+
+// ApplyStun stuns an entity, extending any stun it already has; its queued
+// actions wait out the stun
+func (s *Service) ApplyStun(entityID uuid.UUID, duration time.Duration, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.effectManager(entityID).ApplyStun(entityID, duration, now)
+}
+
+// ApplySlow slows an entity's reactions by multiplier, replacing any slow it
+// already has
+func (s *Service) ApplySlow(entityID uuid.UUID, multiplier float64, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.effectManager(entityID).ApplySlow(entityID, multiplier, now)
+}
+
+// effectManager returns an entity's effect manager, creating it on first use.
+// Callers must hold s.mu.
+func (s *Service) effectManager(entityID uuid.UUID) *effects.EffectManager {
+	manager, ok := s.effects[entityID]
+	if !ok {
+		manager = effects.NewManager()
+		s.effects[entityID] = manager
+	}
+	return manager
+}
+
+// reactionModifiers combines a combatant's own status effects with the stun
+// and slow its effect manager tracks
+func (s *Service) reactionModifiers(combatant *action.Combatant, now time.Time) action.ReactionModifiers {
+	mods := action.StatusReactionModifiers(combatant, now)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	manager, ok := s.effects[combatant.EntityID]
+	if !ok {
+		return mods
+	}
+	mods.Speed *= manager.GetSpeedMultiplier(now)
+	if remaining := manager.StunRemaining(now); remaining > mods.StunRemaining {
+		mods.StunRemaining = remaining
+	}
+	return mods
+}
+
+// buffedAttributes returns an entity's attributes with their active stat
+// modifiers applied. The caller holds s.mu.
+func (s *Service) buffedAttributes(entityID uuid.UUID, attrs character.Attributes, now time.Time) character.Attributes {
+	manager, ok := s.effects[entityID]
+	if !ok {
+		return attrs
+	}
+	seen := make(map[string]bool)
+	for _, mod := range manager.Modifiers {
+		if seen[mod.Stat] {
+			continue
+		}
+		seen[mod.Stat] = true
+		if field := attrs.Field(mod.Stat); field != nil {
+			*field = manager.CalculateStat(mod.Stat, *field, now)
+		}
+	}
+	return attrs
+}
+
 // QueueAttack queues an attack action
 func (s *Service) QueueAttack(attackerID, targetID uuid.UUID) error {
-	// Calculate reaction time based on agility (placeholder logic)
-	// Base 2 seconds, reduced by agility
 	attacker := s.resolver.GetCombatant(attackerID)
 	if attacker == nil {
 		// Initialize combatant if not found?
@@ -63,12 +363,13 @@ func (s *Service) QueueAttack(attackerID, targetID uuid.UUID) error {
 		return fmt.Errorf("attacker not found in combat")
 	}
 
-	// Reaction time: 2000ms - (Agility * 10ms), min 500ms
-	agilityMod := time.Duration(attacker.Agility*10) * time.Millisecond
-	reactionTime := 2*time.Second - agilityMod
-	if reactionTime < 500*time.Millisecond {
-		reactionTime = 500 * time.Millisecond
-	}
+	// Reaction time follows the rule-set: shorter with agility, scaled by
+	// slow/haste and pushed back by any stun
+	s.mu.Lock()
+	model := s.reaction
+	s.mu.Unlock()
+	mods := s.reactionModifiers(attacker, time.Now())
+	reactionTime := model.ReactionTime(action.ActionAttack, action.AttackNormal, attacker.Agility, mods)
 
 	queueAction := action.NewCombatAction(attackerID, targetID, action.ActionAttack, reactionTime)
 	s.resolver.Queue.Enqueue(queueAction)
@@ -76,17 +377,67 @@ func (s *Service) QueueAttack(attackerID, targetID uuid.UUID) error {
 	return nil
 }
 
+// MarkDisconnected starts the grace period for a character whose connection dropped.
+// Characters not in combat are ignored.
+func (s *Service) MarkDisconnected(characterID uuid.UUID, at time.Time) {
+	if !s.InCombat(characterID) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disconnected[characterID] = at
+}
+
+// Reconnect cancels a pending disconnect and reports whether the character
+// is still in combat
+func (s *Service) Reconnect(characterID uuid.UUID) bool {
+	s.mu.Lock()
+	delete(s.disconnected, characterID)
+	s.mu.Unlock()
+	return s.InCombat(characterID)
+}
+
+// ExpireDisconnected removes characters whose grace period has elapsed by now,
+// along with their queued actions, and returns their IDs
+func (s *Service) ExpireDisconnected(now time.Time) []uuid.UUID {
+	s.mu.Lock()
+	var expired []uuid.UUID
+	for id, at := range s.disconnected {
+		if now.Sub(at) >= s.disconnectGrace {
+			expired = append(expired, id)
+			delete(s.disconnected, id)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, id := range expired {
+		s.removeCombatant(id)
+		log.Printf("[COMBAT] Removed %s after disconnect grace period", id)
+	}
+	return expired
+}
+
+// removeCombatant takes an entity out of combat, dropping its queued actions
+// and the attributes it fought with. Its buffs outlast the fight.
+func (s *Service) removeCombatant(id uuid.UUID) {
+	s.resolver.RemoveCombatant(id)
+	s.mu.Lock()
+	delete(s.attributes, id)
+	delete(s.disconnected, id)
+	s.mu.Unlock()
+}
+
 // Tick processes one tick of the combat simulation
 func (s *Service) Tick(dt time.Duration) []CombatEvent {
 	now := time.Now()
+	s.ExpireDisconnected(now)
+
 	resolved := s.resolver.ProcessTick(now)
 
 	var events []CombatEvent
+	var defeated []uuid.UUID
 
 	for _, act := range resolved {
-		// Logic to apply damage would go here (Phase 7.2)
-		// For now, we generate events indicating what happened
-
 		evt := CombatEvent{
 			Type:      "combat_action",
 			Timestamp: now,
@@ -96,12 +447,149 @@ func (s *Service) Tick(dt time.Duration) []CombatEvent {
 				"target_id": act.TargetID,
 				"type":      string(act.ActionType),
 				"resolved":  true,
+				"outcome":   string(act.Outcome),
 			},
 		}
+		var result *AttackResult
+		if act.ActionType == action.ActionAttack {
+			result = s.resolveAttack(act, now)
+			evt.Result = result
+			evt.Data["damage"] = result.Damage
+			evt.Data["critical"] = result.Critical
+		}
 		events = append(events, evt)
 
+		if act.Outcome == action.OutcomeMiss {
+			events = append(events, CombatEvent{
+				Type:      "miss",
+				Timestamp: now,
+				Data: map[string]interface{}{
+					"action_id": act.ActionID,
+					"actor_id":  act.ActorID,
+					"target_id": act.TargetID,
+				},
+				Result: result, // nil unless the miss was an attack
+			})
+		}
+
+		if result != nil && result.TargetDefeated {
+			defeated = append(defeated, act.TargetID)
+			events = append(events, CombatEvent{
+				Type:      "death",
+				Timestamp: now,
+				Data: map[string]interface{}{
+					"actor_id":  act.ActorID,
+					"target_id": act.TargetID,
+				},
+				Result: result,
+			})
+		}
+
 		log.Printf("[COMBAT] Action resolved: %s -> %s (%s)", act.ActorID, act.TargetID, act.ActionType)
 	}
 
+	// The fight is over for the defeated: they leave combat, and join it
+	// afresh (at full HP) the next time they fight
+	for _, id := range defeated {
+		s.removeCombatant(id)
+	}
+
 	return events
 }
+
+// resolveAttack applies a resolved attack's damage and effects to its target.
+// Both sides enter combat whether or not the attack lands.
+func (s *Service) resolveAttack(act *action.CombatAction, now time.Time) *AttackResult {
+	result := &AttackResult{
+		AttackerID: act.ActorID,
+		TargetID:   act.TargetID,
+		Outcome:    act.Outcome,
+	}
+
+	attacker := s.resolver.GetCombatant(act.ActorID)
+	target := s.resolver.GetCombatant(act.TargetID)
+	enterCombat(attacker)
+	enterCombat(target)
+	if target != nil {
+		result.TargetHP = target.CurrentHP
+		result.TargetMaxHP = target.MaxHP
+	}
+
+	// Nothing more happens to a target that is already down
+	if act.Outcome != action.OutcomeHit || (target != nil && target.CombatState == action.StateDefeated) {
+		return result
+	}
+
+	s.mu.Lock()
+	attrs := s.buffedAttributes(act.ActorID, s.attributes[act.ActorID], now)
+	s.mu.Unlock()
+
+	// Equipment sharpens the attack and blunts it on the way in
+	weapon := damage.Unarmed()
+	armed := false
+	var attackBonuses, defenseBonuses damage.Bonuses
+	var armor *damage.Armor
+	if attacker != nil {
+		if attacker.Weapon != nil && !attacker.Weapon.IsBroken() {
+			weapon = attacker.Weapon
+			armed = true
+		}
+		attackBonuses = attacker.Bonuses
+	}
+	if target != nil {
+		armor = target.Armor
+		defenseBonuses = target.Bonuses
+	}
+	dmg := damage.CalculateEquippedDamage(attrs, weapon, 0, attackBonuses, armor, defenseBonuses, s.damageRoll(), false)
+	result.Critical = dmg.IsCritical
+	result.Fumble = dmg.IsFumble
+	result.Damage = dmg.FinalDamage
+	result.Blocked = dmg.Blocked
+
+	// Every hit wears the weapon that landed it
+	if armed {
+		s.resolver.UpdateCombatant(attacker.EntityID, func(attacker *action.Combatant) {
+			if attacker.Weapon == weapon {
+				act.WeaponBroke = damage.ReduceDurability(weapon, 1).Broke
+				result.WeaponID = weapon.WeaponID
+				result.WeaponDurability = weapon.Durability
+			}
+		})
+		result.WeaponBroke = act.WeaponBroke
+	}
+
+	if target == nil {
+		return result
+	}
+
+	s.resolver.UpdateCombatant(target.EntityID, func(target *action.Combatant) {
+		target.CurrentHP = max(target.CurrentHP-dmg.FinalDamage, 0)
+		result.TargetHP = target.CurrentHP
+
+		if dmg.IsCritical && target.CurrentHP > 0 {
+			target.StatusEffects = append(target.StatusEffects, action.StatusEffect{
+				EffectType: action.EffectStun,
+				ExpiresAt:  now.Add(CriticalStunDuration),
+			})
+			result.Effects = append(result.Effects, action.EffectStun)
+		}
+
+		if target.CurrentHP == 0 {
+			result.TargetDefeated = action.TransitionState(target, action.StateDefeated) == nil
+		}
+	})
+
+	if result.TargetDefeated {
+		// The defeated can't act on anything they had queued
+		s.resolver.Queue.RemoveActor(target.EntityID)
+	}
+
+	return result
+}
+
+// enterCombat moves an idle combatant into combat
+func enterCombat(c *action.Combatant) {
+	if c != nil && c.CombatState == action.StateIdle {
+		_ = action.TransitionState(c, action.StateInCombat)
+	}
+}
@@ -6,8 +6,13 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"tw-backend/internal/character"
+	"tw-backend/internal/combat/action"
+	"tw-backend/internal/combat/config"
+	"tw-backend/internal/combat/damage"
+	"tw-backend/internal/combat/effects"
 	"tw-backend/internal/game/services/entity"
 )
 
@@ -85,3 +90,515 @@ func TestCombatService_JoinAndAttack(t *testing.T) {
 	// Queue implementation might hide length.
 	// Assuming we can't easily peek, we rely on `QueueAttack` success.
 }
+
+func TestCombatService_MissEmitsEventAndCostsStamina(t *testing.T) {
+	svc := NewService(entity.NewService())
+	svc.resolver.SetHitChanceFunc(func(attacker, target *action.Combatant) float64 { return 0 })
+
+	attackerID := uuid.New()
+	targetID := uuid.New()
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:       attackerID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:       targetID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+
+	// Queue an attack that is already due so Tick resolves it immediately
+	act := action.NewCombatAction(attackerID, targetID, action.ActionAttack, 0)
+	act.ExecuteAt = time.Now().Add(-time.Millisecond)
+	svc.resolver.Queue.Enqueue(act)
+
+	events := svc.Tick(100 * time.Millisecond)
+
+	var missEvents int
+	for _, evt := range events {
+		if evt.Type == "miss" {
+			missEvents++
+			assert.Equal(t, attackerID, evt.Data["actor_id"])
+			assert.Equal(t, targetID, evt.Data["target_id"])
+			require.NotNil(t, evt.Result, "The miss carries its attack's result")
+			assert.Equal(t, action.OutcomeMiss, evt.Result.Outcome)
+		}
+	}
+	assert.Equal(t, 1, missEvents, "A missed attack should emit a miss event")
+	assert.Equal(t, 100-action.StaminaCostNormalAttack, svc.resolver.GetCombatant(attackerID).CurrentStamina)
+	assert.Equal(t, 100, svc.resolver.GetCombatant(targetID).CurrentHP, "A miss deals no damage")
+}
+
+func TestCombatService_JoinSetsAccuracyAndEvasion(t *testing.T) {
+	svc := NewService(entity.NewService())
+	sword := &damage.Weapon{Name: "Sword", Type: damage.WeaponSlashing, BaseDamage: 20, Durability: 10, MaxDurability: 10}
+	svc.SetSkillLookup(func(id uuid.UUID, weapon *damage.Weapon) (int, int) {
+		if weapon == sword {
+			return 60, 30
+		}
+		return 15, 30
+	})
+
+	charID := uuid.New()
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:       charID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	combatant := svc.Combatant(charID)
+	assert.Equal(t, 15, combatant.Accuracy, "Unarmed until equipped")
+	assert.Equal(t, 30, combatant.Evasion)
+
+	svc.SetEquipment(charID, sword, nil, damage.Bonuses{})
+	assert.Equal(t, 60, combatant.Accuracy, "Accuracy follows the weapon's skill")
+
+	npcID := uuid.New()
+	svc.JoinCombatFromNPC(npcID, character.GetSpeciesTemplate(character.SpeciesHuman).BaseAttrs)
+	npc := svc.Combatant(npcID)
+	require.NotNil(t, npc)
+	assert.Equal(t, DefaultNPCSkill, npc.Accuracy)
+	assert.Equal(t, DefaultNPCSkill, npc.Evasion)
+	assert.Positive(t, npc.MaxHP)
+}
+
+func TestCombatService_JoiningCharactersDodge(t *testing.T) {
+	svc := NewService(entity.NewService())
+	svc.resolver.SetHitChanceFunc(func(attacker, target *action.Combatant) float64 { return 1 })
+	svc.resolver.SetSeed(7)
+	svc.damageRoll = func() int { return 50 }
+
+	attackerID, targetID := uuid.New(), uuid.New()
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:       attackerID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 1000},
+	})
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:        targetID,
+		BaseAttrs: character.Attributes{Agility: 100},
+		SecAttrs:  character.SecondaryAttributes{MaxHP: 10000, MaxStamina: 1000},
+	})
+	target := svc.Combatant(targetID)
+	require.NotNil(t, target.Defense, "Characters join able to dodge")
+	assert.InDelta(t, 0.3, target.Defense.DodgeChance(target), 1e-9, "100 Agility dodges 30% of hits")
+
+	dodged := 0
+	for range 100 {
+		dueAttack(svc, attackerID, targetID)
+		for _, evt := range svc.Tick(100 * time.Millisecond) {
+			if evt.Type == "combat_action" && evt.Result.Outcome == action.OutcomeMiss {
+				dodged++
+			}
+		}
+	}
+	assert.InDelta(t, 30, dodged, 15, "About 30 of 100 certain hits are dodged")
+}
+
+func TestCombatService_ResolverUsesServiceConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.BaseHitChance, cfg.MinHitChance, cfg.MaxHitChance = 0, 0, 0
+	svc := NewServiceWithConfig(entity.NewService(), cfg)
+
+	attackerID, targetID := uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{attackerID, targetID} {
+		svc.JoinCombatFromCharacter(&character.Character{
+			ID:       id,
+			SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+		})
+	}
+	for range 5 {
+		dueAttack(svc, attackerID, targetID)
+		for _, evt := range svc.Tick(100 * time.Millisecond) {
+			assert.NotEqual(t, action.OutcomeHit, evt.Result.Outcome, "No attack can hit at a 0% hit chance")
+		}
+	}
+	assert.Equal(t, 100, svc.Combatant(targetID).CurrentHP)
+}
+
+func TestCombatService_ReconnectPreservesCombat(t *testing.T) {
+	svc := NewService(entity.NewService())
+
+	attacker := &character.Character{ID: uuid.New(), SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100}}
+	target := &character.Character{ID: uuid.New(), SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100}}
+	svc.JoinCombatFromCharacter(attacker)
+	svc.JoinCombatFromCharacter(target)
+	assert.NoError(t, svc.QueueAttack(attacker.ID, target.ID))
+
+	// Mid-fight damage and state
+	combatant := svc.resolver.GetCombatant(attacker.ID)
+	combatant.CurrentHP = 40
+	combatant.CombatState = action.StateInCombat
+
+	// Connection drops, then the client reconnects within the grace period
+	disconnectedAt := time.Now()
+	svc.MarkDisconnected(attacker.ID, disconnectedAt)
+	assert.Empty(t, svc.ExpireDisconnected(disconnectedAt.Add(DefaultDisconnectGrace/2)))
+	assert.True(t, svc.Reconnect(attacker.ID), "Reconnect should rejoin the ongoing fight")
+
+	// Attacking again after the reconnect must not reset the combatant
+	svc.JoinCombatFromCharacter(attacker)
+	combatant = svc.resolver.GetCombatant(attacker.ID)
+	assert.Equal(t, 40, combatant.CurrentHP)
+	assert.Equal(t, action.StateInCombat, combatant.CombatState)
+	assert.Equal(t, 1, svc.resolver.Queue.Len(), "Queued attack survives the reconnect")
+
+	// The cancelled disconnect never expires
+	assert.Empty(t, svc.ExpireDisconnected(disconnectedAt.Add(2*DefaultDisconnectGrace)))
+	assert.True(t, svc.InCombat(attacker.ID))
+}
+
+func TestCombatService_LogoutClearsCombatAfterGrace(t *testing.T) {
+	svc := NewService(entity.NewService())
+	svc.SetDisconnectGrace(30 * time.Second)
+
+	attacker := &character.Character{ID: uuid.New(), SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100}}
+	target := &character.Character{ID: uuid.New(), SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100}}
+	svc.JoinCombatFromCharacter(attacker)
+	svc.JoinCombatFromCharacter(target)
+	assert.NoError(t, svc.QueueAttack(attacker.ID, target.ID))
+
+	loggedOutAt := time.Now()
+	svc.MarkDisconnected(attacker.ID, loggedOutAt)
+
+	assert.Empty(t, svc.ExpireDisconnected(loggedOutAt.Add(29*time.Second)), "Still within grace")
+	assert.True(t, svc.InCombat(attacker.ID))
+
+	expired := svc.ExpireDisconnected(loggedOutAt.Add(30 * time.Second))
+	assert.Equal(t, []uuid.UUID{attacker.ID}, expired)
+	assert.False(t, svc.InCombat(attacker.ID))
+	assert.True(t, svc.InCombat(target.ID), "Opponent stays in combat")
+	assert.Equal(t, 0, svc.resolver.Queue.Len(), "Logged-out character's queued actions are dropped")
+	assert.False(t, svc.Reconnect(attacker.ID), "A later login starts fresh")
+}
+
+// dueAttack queues an attack that Tick resolves immediately
+func dueAttack(svc *Service, attackerID, targetID uuid.UUID) {
+	act := action.NewCombatAction(attackerID, targetID, action.ActionAttack, 0)
+	act.ExecuteAt = time.Now().Add(-time.Millisecond)
+	svc.resolver.Queue.Enqueue(act)
+}
+
+func TestCombatService_AttackProducesStructuredResult(t *testing.T) {
+	svc := NewService(entity.NewService())
+	svc.resolver.SetHitChanceFunc(func(attacker, target *action.Combatant) float64 { return 1 })
+	svc.damageRoll = func() int { return 50 }
+
+	attackerID, targetID := uuid.New(), uuid.New()
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:        attackerID,
+		BaseAttrs: character.Attributes{Might: 50},
+		SecAttrs:  character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:       targetID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	dueAttack(svc, attackerID, targetID)
+
+	events := svc.Tick(100 * time.Millisecond)
+	require.Len(t, events, 1)
+	result := events[0].Result
+	require.NotNil(t, result, "Resolved attacks carry a structured result")
+
+	assert.Equal(t, attackerID, result.AttackerID)
+	assert.Equal(t, targetID, result.TargetID)
+	assert.Equal(t, action.OutcomeHit, result.Outcome)
+	assert.Positive(t, result.Damage)
+	assert.Equal(t, 100-result.Damage, result.TargetHP)
+	assert.Equal(t, 100, result.TargetMaxHP)
+	assert.False(t, result.TargetDefeated)
+
+	target := svc.resolver.GetCombatant(targetID)
+	assert.Equal(t, result.TargetHP, target.CurrentHP, "Damage is applied to the target")
+	assert.Equal(t, action.StateInCombat, target.CombatState)
+	assert.Equal(t, action.StateInCombat, svc.resolver.GetCombatant(attackerID).CombatState)
+}
+
+func TestCombatService_KillingBlowDefeatsTarget(t *testing.T) {
+	svc := NewService(entity.NewService())
+	svc.resolver.SetHitChanceFunc(func(attacker, target *action.Combatant) float64 { return 1 })
+	svc.damageRoll = func() int { return 50 }
+
+	attackerID, targetID := uuid.New(), uuid.New()
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:        attackerID,
+		BaseAttrs: character.Attributes{Might: 50},
+		SecAttrs:  character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:       targetID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	target := svc.resolver.GetCombatant(targetID)
+	target.CurrentHP = 1
+
+	// The target's own pending attack should never land
+	svc.resolver.Queue.Enqueue(action.NewCombatAction(targetID, attackerID, action.ActionAttack, time.Minute))
+	dueAttack(svc, attackerID, targetID)
+
+	events := svc.Tick(100 * time.Millisecond)
+	require.Len(t, events, 2)
+	assert.Equal(t, "combat_action", events[0].Type)
+	assert.Equal(t, "death", events[1].Type)
+	assert.Equal(t, targetID, events[1].Data["target_id"])
+
+	result := events[0].Result
+	require.NotNil(t, result)
+	assert.True(t, result.TargetDefeated)
+	assert.Equal(t, 0, result.TargetHP)
+
+	assert.Equal(t, action.StateDefeated, target.CombatState)
+	assert.Equal(t, 0, target.CurrentHP)
+	assert.Equal(t, 0, svc.resolver.Queue.Len(), "Defeated target's queued actions are dropped")
+	assert.False(t, svc.InCombat(targetID), "The defeated leave combat")
+	assert.True(t, svc.InCombat(attackerID))
+}
+
+func TestCombatService_DefeatedCharacterCanFightAgain(t *testing.T) {
+	svc := NewService(entity.NewService())
+	svc.resolver.SetHitChanceFunc(func(attacker, target *action.Combatant) float64 { return 1 })
+	svc.damageRoll = func() int { return 50 }
+
+	attacker := &character.Character{
+		ID:        uuid.New(),
+		BaseAttrs: character.Attributes{Might: 50},
+		SecAttrs:  character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	}
+	target := &character.Character{
+		ID:       uuid.New(),
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	}
+	svc.JoinCombatFromCharacter(attacker)
+	svc.JoinCombatFromCharacter(target)
+	svc.resolver.GetCombatant(target.ID).CurrentHP = 1
+	dueAttack(svc, attacker.ID, target.ID)
+	svc.Tick(100 * time.Millisecond)
+	require.False(t, svc.InCombat(target.ID))
+
+	// Rejoining starts a fresh fight, and the rejoined character can attack
+	svc.JoinCombatFromCharacter(target)
+	rejoined := svc.resolver.GetCombatant(target.ID)
+	require.NotNil(t, rejoined)
+	assert.Equal(t, 100, rejoined.CurrentHP)
+	assert.Equal(t, action.StateIdle, rejoined.CombatState)
+
+	dueAttack(svc, target.ID, attacker.ID)
+	events := svc.Tick(100 * time.Millisecond)
+	require.NotEmpty(t, events)
+	require.NotNil(t, events[0].Result)
+	assert.Equal(t, target.ID, events[0].Result.AttackerID)
+	assert.Equal(t, action.OutcomeHit, events[0].Result.Outcome)
+}
+
+func TestCombatService_EquippedWeaponRaisesDamage(t *testing.T) {
+	attack := func(weapon *damage.Weapon) int {
+		svc := NewService(entity.NewService())
+		svc.resolver.SetHitChanceFunc(func(attacker, target *action.Combatant) float64 { return 1 })
+		svc.damageRoll = func() int { return 50 }
+
+		attackerID, targetID := uuid.New(), uuid.New()
+		svc.JoinCombatFromCharacter(&character.Character{
+			ID:        attackerID,
+			BaseAttrs: character.Attributes{Might: 50},
+			SecAttrs:  character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+		})
+		svc.JoinCombatFromCharacter(&character.Character{
+			ID:       targetID,
+			SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+		})
+		svc.SetEquipment(attackerID, weapon, nil, damage.Bonuses{})
+		dueAttack(svc, attackerID, targetID)
+
+		events := svc.Tick(100 * time.Millisecond)
+		require.Len(t, events, 1)
+		require.NotNil(t, events[0].Result)
+		return events[0].Result.Damage
+	}
+
+	fists := attack(nil)
+	sword := attack(&damage.Weapon{Name: "Iron Sword", Type: damage.WeaponSlashing, BaseDamage: 20, Durability: 100, MaxDurability: 100})
+	assert.Greater(t, sword, fists)
+}
+
+func TestCombatService_HitsWearWeapon(t *testing.T) {
+	svc := NewService(entity.NewService())
+	svc.resolver.SetHitChanceFunc(func(attacker, target *action.Combatant) float64 { return 1 })
+	svc.damageRoll = func() int { return 50 }
+
+	attackerID, targetID := uuid.New(), uuid.New()
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:        attackerID,
+		BaseAttrs: character.Attributes{Might: 50},
+		SecAttrs:  character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:       targetID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 1000, MaxStamina: 100},
+	})
+	dagger := &damage.Weapon{WeaponID: uuid.New(), Name: "Rusty Dagger", Type: damage.WeaponPiercing, BaseDamage: 8, Durability: 2, MaxDurability: 50}
+	svc.SetEquipment(attackerID, dagger, nil, damage.Bonuses{})
+
+	dueAttack(svc, attackerID, targetID)
+	events := svc.Tick(100 * time.Millisecond)
+	require.NotEmpty(t, events)
+	assert.Equal(t, 1, dagger.Durability, "A hit wears the weapon")
+	assert.Nil(t, events[0].Result.WeaponBroke)
+	assert.Equal(t, dagger.WeaponID, events[0].Result.WeaponID, "The result reports the wear to record")
+	assert.Equal(t, 1, events[0].Result.WeaponDurability)
+
+	dueAttack(svc, attackerID, targetID)
+	events = svc.Tick(100 * time.Millisecond)
+	require.NotEmpty(t, events)
+	assert.True(t, dagger.IsBroken())
+	require.NotNil(t, events[0].Result.WeaponBroke, "The hit that breaks the weapon reports it")
+	assert.Equal(t, "Rusty Dagger", events[0].Result.WeaponBroke.Name)
+
+	// Broken, it no longer wears: the attacker fights unarmed
+	dueAttack(svc, attackerID, targetID)
+	events = svc.Tick(100 * time.Millisecond)
+	require.NotEmpty(t, events)
+	assert.Nil(t, events[0].Result.WeaponBroke)
+	assert.Equal(t, 0, dagger.Durability)
+}
+
+func TestCombatService_ApplyConsumable(t *testing.T) {
+	svc := NewService(entity.NewService())
+	svc.resolver.SetHitChanceFunc(func(attacker, target *action.Combatant) float64 { return 1 })
+	svc.damageRoll = func() int { return 50 }
+
+	attackerID, targetID := uuid.New(), uuid.New()
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:        attackerID,
+		BaseAttrs: character.Attributes{Might: 50},
+		SecAttrs:  character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	svc.JoinCombatFromCharacter(&character.Character{
+		ID:       targetID,
+		SecAttrs: character.SecondaryAttributes{MaxHP: 100, MaxStamina: 100},
+	})
+	attacker := svc.resolver.GetCombatant(attackerID)
+	attacker.CurrentHP, attacker.CurrentStamina = 90, 40
+
+	healed, restored := svc.ApplyConsumable(attackerID, effects.Consumable{Heal: 25, Stamina: 20}, time.Now())
+	assert.Equal(t, 10, healed, "healing stops at max HP")
+	assert.Equal(t, 20, restored)
+	assert.Equal(t, 100, attacker.CurrentHP)
+	assert.Equal(t, 60, attacker.CurrentStamina)
+
+	dueAttack(svc, attackerID, targetID)
+	events := svc.Tick(100 * time.Millisecond)
+	require.Len(t, events, 1)
+	unbuffed := events[0].Result.Damage
+
+	might := &effects.ModifierDefinition{Source: "potion of might", Stat: character.AttrMight, Modifier: 50, Duration: time.Minute}
+	svc.ApplyConsumable(attackerID, effects.Consumable{Buff: might}, time.Now())
+	dueAttack(svc, attackerID, targetID)
+	events = svc.Tick(100 * time.Millisecond)
+	require.Len(t, events, 1)
+	assert.Greater(t, events[0].Result.Damage, unbuffed, "the Might buff hits harder")
+}
+
+func TestCombatService_RejoinUsesCurrentAttributes(t *testing.T) {
+	svc := NewService(entity.NewService())
+
+	char := &character.Character{
+		ID:        uuid.New(),
+		Name:      "Fighter",
+		BaseAttrs: character.Attributes{Vitality: 10, Endurance: 10, Might: 10, Agility: 20},
+	}
+	char.RecalculateSecondaryAttributes()
+	svc.JoinCombatFromCharacter(char)
+
+	combatant := svc.resolver.GetCombatant(char.ID)
+	require.NotNil(t, combatant)
+	assert.Equal(t, 100, combatant.MaxHP)
+	combatant.CurrentHP = 80 // Wounded before the change
+
+	_, err := char.SetBaseAttribute(character.AttrVitality, 30, "level up")
+	require.NoError(t, err)
+	_, err = char.SetBaseAttribute(character.AttrAgility, 60, "level up")
+	require.NoError(t, err)
+	svc.JoinCombatFromCharacter(char)
+
+	assert.Equal(t, 300, combatant.MaxHP, "MaxHP should follow the raised Vitality")
+	assert.Equal(t, 80, combatant.CurrentHP, "Current HP should be kept")
+	assert.Equal(t, 60, combatant.Agility)
+	assert.Equal(t, 60, svc.attributes[char.ID].Agility, "Damage rolls should use the new attributes")
+
+	_, err = char.SetBaseAttribute(character.AttrVitality, 5, "curse")
+	require.NoError(t, err)
+	svc.RefreshCharacter(char)
+	assert.Equal(t, 50, combatant.MaxHP)
+	assert.Equal(t, 50, combatant.CurrentHP, "Current HP should be capped at the new maximum")
+}
+
+func TestCombatService_QueueAttackUsesReactionModel(t *testing.T) {
+	svc := NewService(entity.NewService())
+	cfg := config.Default()
+	cfg.Reaction.NormalAttackMs = 4000
+	svc.SetReactionModel(action.NewReactionModel(cfg))
+
+	attackerID, targetID := uuid.New(), uuid.New()
+	svc.JoinCombat(&action.Combatant{EntityID: attackerID, Agility: 0, CurrentHP: 10, CurrentStamina: 100})
+	svc.JoinCombat(&action.Combatant{EntityID: targetID, CurrentHP: 10})
+
+	require.NoError(t, svc.QueueAttack(attackerID, targetID))
+	queued := svc.resolver.Queue.Peek()
+	require.NotNil(t, queued)
+	assert.Equal(t, 4000*time.Millisecond, queued.ReactionTime)
+
+	// A stun pushes the next attack back by its remaining duration
+	svc.resolver.Queue.RemoveActor(attackerID)
+	attacker := svc.resolver.GetCombatant(attackerID)
+	attacker.StatusEffects = append(attacker.StatusEffects, action.StatusEffect{
+		EffectType: action.EffectStun,
+		ExpiresAt:  time.Now().Add(2 * time.Second),
+	})
+	require.NoError(t, svc.QueueAttack(attackerID, targetID))
+	queued = svc.resolver.Queue.Peek()
+	require.NotNil(t, queued)
+	assert.Greater(t, queued.ReactionTime, 5*time.Second)
+}
+
+func TestCombatService_EffectManagerStatusesSlowReactions(t *testing.T) {
+	svc := NewService(entity.NewService())
+	cfg := config.Default()
+	cfg.Reaction.NormalAttackMs = 4000
+	svc.SetReactionModel(action.NewReactionModel(cfg))
+
+	attackerID, targetID := uuid.New(), uuid.New()
+	svc.JoinCombat(&action.Combatant{EntityID: attackerID, Agility: 0, CurrentHP: 10, CurrentStamina: 100})
+	svc.JoinCombat(&action.Combatant{EntityID: targetID, CurrentHP: 10})
+
+	// A slow from the effects manager scales the attack's reaction time
+	svc.ApplySlow(attackerID, 2.0, time.Now())
+	require.NoError(t, svc.QueueAttack(attackerID, targetID))
+	queued := svc.resolver.Queue.Peek()
+	require.NotNil(t, queued)
+	assert.Equal(t, 8000*time.Millisecond, queued.ReactionTime)
+
+	// and a stun from it pushes the attack back by its remaining duration
+	svc.resolver.Queue.RemoveActor(attackerID)
+	svc.ApplyStun(attackerID, 3*time.Second, time.Now())
+	require.NoError(t, svc.QueueAttack(attackerID, targetID))
+	queued = svc.resolver.Queue.Peek()
+	require.NotNil(t, queued)
+	assert.Greater(t, queued.ReactionTime, 10*time.Second)
+}
+
+func TestCombatService_DefaultReactionTime(t *testing.T) {
+	// The default rule-set keeps the original 2000ms - Agility*10ms, 500ms floor
+	for _, agility := range []int{0, 1, 30, 50, 77, 100, 150, 200} {
+		svc := NewService(entity.NewService())
+		attackerID, targetID := uuid.New(), uuid.New()
+		svc.JoinCombat(&action.Combatant{EntityID: attackerID, Agility: agility, CurrentHP: 10, CurrentStamina: 100})
+		svc.JoinCombat(&action.Combatant{EntityID: targetID, CurrentHP: 10})
+
+		require.NoError(t, svc.QueueAttack(attackerID, targetID))
+		queued := svc.resolver.Queue.Peek()
+		require.NotNil(t, queued)
+
+		want := 2*time.Second - time.Duration(agility*10)*time.Millisecond
+		if want < 500*time.Millisecond {
+			want = 500 * time.Millisecond
+		}
+		assert.Equal(t, want, queued.ReactionTime, "agility %d", agility)
+	}
+}
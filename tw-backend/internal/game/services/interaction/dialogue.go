@@ -0,0 +1,85 @@
+package interaction
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"tw-backend/internal/worldentity"
+)
+
+// MetaDialogue is the NPC metadata key holding its DialogueTree
+const MetaDialogue = "dialogue"
+
+// DialogueTree is an NPC's scripted conversation. A conversation opens at
+// Start and moves between nodes as the player picks options.
+type DialogueTree struct {
+	Start    string                  `json:"start"`
+	Nodes    map[string]DialogueNode `json:"nodes"`
+	Farewell string                  `json:"farewell,omitempty"` // Said when an option ends the conversation
+}
+
+// DialogueNode is one thing an NPC says, and the replies open to the player.
+// A node without options ends the conversation.
+type DialogueNode struct {
+	Text    string           `json:"text"`
+	Options []DialogueOption `json:"options,omitempty"`
+}
+
+// DialogueOption is a reply the player can pick
+type DialogueOption struct {
+	Text string `json:"text"`
+	Next string `json:"next,omitempty"` // Node it leads to; empty ends the conversation
+}
+
+// DialogueTreeOf reads an NPC's scripted dialogue from its metadata, as
+// decoded from JSON or set in Go. It returns false if the NPC has none, or
+// the tree's start node is missing.
+func DialogueTreeOf(npc *worldentity.WorldEntity) (*DialogueTree, bool) {
+	raw, ok := npc.Metadata[MetaDialogue]
+	if !ok {
+		return nil, false
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false
+	}
+	var tree DialogueTree
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, false
+	}
+	if _, ok := tree.Nodes[tree.Start]; !ok {
+		return nil, false
+	}
+	return &tree, true
+}
+
+// chooseOption matches what the player said to one of a node's options: its
+// number, or the start of its text
+func chooseOption(options []DialogueOption, message string) (DialogueOption, bool) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return DialogueOption{}, false
+	}
+	if n, err := strconv.Atoi(message); err == nil {
+		if n >= 1 && n <= len(options) {
+			return options[n-1], true
+		}
+		return DialogueOption{}, false
+	}
+	lower := strings.ToLower(message)
+	for _, opt := range options {
+		if strings.HasPrefix(strings.ToLower(opt.Text), lower) {
+			return opt, true
+		}
+	}
+	return DialogueOption{}, false
+}
+
+func optionTexts(options []DialogueOption) []string {
+	texts := make([]string, len(options))
+	for i, opt := range options {
+		texts[i] = opt.Text
+	}
+	return texts
+}
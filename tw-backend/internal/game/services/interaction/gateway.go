@@ -0,0 +1,79 @@
+package interaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+
+	"tw-backend/internal/ai/gateway"
+	"tw-backend/internal/nats/subjects"
+	"tw-backend/internal/worldentity"
+)
+
+// DefaultLineTimeout bounds how long a player waits for a generated line
+const DefaultLineTimeout = 5 * time.Second
+
+// Requester is the part of *nats.Conn used to ask the AI gateway for a line
+type Requester interface {
+	RequestWithContext(ctx context.Context, subj string, data []byte) (*nats.Msg, error)
+}
+
+// GatewayLines generates unscripted NPC lines through the AI gateway
+type GatewayLines struct {
+	nc      Requester
+	timeout time.Duration
+}
+
+// NewGatewayLines creates a LineGenerator that asks the AI gateway over NATS
+func NewGatewayLines(nc Requester) *GatewayLines {
+	return &GatewayLines{nc: nc, timeout: DefaultLineTimeout}
+}
+
+// GenerateLine asks the gateway what the NPC says in reply to message
+func (g *GatewayLines) GenerateLine(ctx context.Context, npc *worldentity.WorldEntity, message string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	data, err := json.Marshal(gateway.AIRequest{
+		ID:     uuid.New().String(),
+		Prompt: dialoguePrompt(npc, message),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal dialogue request: %w", err)
+	}
+	msg, err := g.nc.RequestWithContext(ctx, subjects.AIRequestDialogue, data)
+	if err != nil {
+		return "", fmt.Errorf("request dialogue: %w", err)
+	}
+
+	var resp gateway.AIResponse
+	if err := json.Unmarshal(msg.Data, &resp); err != nil {
+		return "", fmt.Errorf("unmarshal dialogue response: %w", err)
+	}
+	if resp.Error != "" {
+		return "", errors.New(resp.Error)
+	}
+	return strings.Trim(strings.TrimSpace(resp.Response), `"`), nil
+}
+
+// dialoguePrompt asks for a short in-character reply
+func dialoguePrompt(npc *worldentity.WorldEntity, message string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "You are %s, a character in a fantasy world.\n", npc.Name)
+	if npc.Description != "" {
+		fmt.Fprintf(&b, "Description: %s\n", npc.Description)
+	}
+	if message == "" {
+		b.WriteString("A traveler approaches you.\n")
+	} else {
+		fmt.Fprintf(&b, "A traveler says to you: %q\n", message)
+	}
+	b.WriteString("Reply in character in one or two sentences. Output ONLY what you say.")
+	return b.String()
+}
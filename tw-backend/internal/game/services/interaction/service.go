@@ -2,49 +2,126 @@ package interaction
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 
 	"tw-backend/internal/world/interview"
+	"tw-backend/internal/worldentity"
 )
 
+// fallbackLine is said by an NPC with no script when no line can be generated
+const fallbackLine = "Hmm? I've nothing to say to you."
+
+// LineGenerator improvises what an NPC says when it has no scripted dialogue
+type LineGenerator interface {
+	GenerateLine(ctx context.Context, npc *worldentity.WorldEntity, message string) (string, error)
+}
+
 // Service manages interactions between characters and NPCs/World
 type Service struct {
 	interviewService *interview.InterviewService
+	lines            LineGenerator
+
+	// conversations tracks where each character is in a scripted dialogue
+	conversations map[uuid.UUID]conversation
+	mu            sync.Mutex
+}
+
+// conversation is a character's place in an NPC's dialogue tree
+type conversation struct {
+	npcID  uuid.UUID
+	nodeID string
 }
 
 func NewService(interviewService *interview.InterviewService) *Service {
 	return &Service{
 		interviewService: interviewService,
+		conversations:    make(map[uuid.UUID]conversation),
 	}
 }
 
+// SetLineGenerator sets how NPCs without scripted dialogue reply, e.g. via
+// the AI gateway. Without one they fall back to a stock line.
+func (s *Service) SetLineGenerator(lines LineGenerator) {
+	s.lines = lines
+}
+
 // Response represents a dialogue response
 type Response struct {
 	Text    string   `json:"text"`
 	Options []string `json:"options"`
 	NPCName string   `json:"npc_name"`
 	NPCID   string   `json:"npc_id"`
+	NodeID  string   `json:"node_id,omitempty"` // Empty for unscripted lines
+	Ended   bool     `json:"ended,omitempty"`   // The conversation is over
 }
 
-// ProcessDialogue handles a player talking to an NPC
-func (s *Service) ProcessDialogue(ctx context.Context, charID uuid.UUID, targetName string, message string) (*Response, error) {
-	// P0 Implementation: Simple echo or hardcoded response
-	// Future: Integrate with InterviewService for LLM, or Scripted Dialogue
+// Talk handles a character talking to an NPC. With a scripted dialogue tree,
+// talking to an NPC opens at the tree's start; after that the message picks
+// one of the current node's options, by number or text, and the NPC answers
+// with the node it leads to. A message matching no option repeats the
+// current node. Talking to another NPC abandons the conversation.
+//
+// An NPC without a script improvises a reply to the message.
+func (s *Service) Talk(ctx context.Context, charID uuid.UUID, npc *worldentity.WorldEntity, message string) (*Response, error) {
+	tree, scripted := DialogueTreeOf(npc)
+	if !scripted {
+		return s.improvise(ctx, npc, message), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	// Check if target is "System" or similar for special handling?
+	nodeID := tree.Start
+	if conv, ok := s.conversations[charID]; ok && conv.npcID == npc.ID {
+		if current, ok := tree.Nodes[conv.nodeID]; ok {
+			nodeID = conv.nodeID
+			if choice, ok := chooseOption(current.Options, message); ok {
+				if choice.Next == "" {
+					delete(s.conversations, charID)
+					farewell := tree.Farewell
+					if farewell == "" {
+						farewell = "Farewell."
+					}
+					return &Response{Text: farewell, NPCName: npc.Name, NPCID: npc.ID.String(), Ended: true}, nil
+				}
+				if _, ok := tree.Nodes[choice.Next]; ok {
+					nodeID = choice.Next
+				}
+			}
+		}
+	}
 
-	// For P0, we just mock a response or use the interview service if appropriate context matches?
-	// The InterviewService is for world creation interviews.
-	// We might need a separate DialogueService or expand InterviewService.
-	// For this task "Integrate NPC Dialogue", let's assume valid mock integration first.
+	node := tree.Nodes[nodeID]
+	resp := &Response{
+		Text:    node.Text,
+		Options: optionTexts(node.Options),
+		NPCName: npc.Name,
+		NPCID:   npc.ID.String(),
+		NodeID:  nodeID,
+	}
+	if len(node.Options) == 0 {
+		delete(s.conversations, charID)
+		resp.Ended = true
+	} else {
+		s.conversations[charID] = conversation{npcID: npc.ID, nodeID: nodeID}
+	}
+	return resp, nil
+}
 
+// improvise generates an unscripted reply, falling back to a stock line
+func (s *Service) improvise(ctx context.Context, npc *worldentity.WorldEntity, message string) *Response {
+	text := fallbackLine
+	if s.lines != nil {
+		if line, err := s.lines.GenerateLine(ctx, npc, message); err == nil && line != "" {
+			text = line
+		}
+	}
 	return &Response{
-		Text:    fmt.Sprintf("Hello! I heard you say: '%s'", message),
-		Options: []string{"Ask about quest", "Goodbye"},
-		NPCName: targetName,
-		NPCID:   "npc-" + strings.ToLower(targetName),
-	}, nil
+		Text:    text,
+		Options: []string{},
+		NPCName: npc.Name,
+		NPCID:   npc.ID.String(),
+	}
 }
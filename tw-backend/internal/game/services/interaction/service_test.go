@@ -2,23 +2,172 @@ package interaction
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/internal/ai/gateway"
+	"tw-backend/internal/nats/subjects"
+	"tw-backend/internal/worldentity"
 )
 
-func TestProcessDialogue(t *testing.T) {
-	svc := NewService(nil) // Mock dependencies if needed later
+// innkeeper has a two-node dialogue tree, decoded from JSON as it would be
+// when loaded from the database
+func innkeeper(t *testing.T) *worldentity.WorldEntity {
+	t.Helper()
+	var tree map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"start": "greet",
+		"farewell": "Safe travels.",
+		"nodes": {
+			"greet": {
+				"text": "Welcome to the Prancing Pony!",
+				"options": [{"text": "Any work going?", "next": "work"}, {"text": "Goodbye"}]
+			},
+			"work": {
+				"text": "Rats in the cellar. Deal with them and there's a meal in it.",
+				"options": [{"text": "Tell me again", "next": "greet"}, {"text": "I'll do it"}]
+			}
+		}
+	}`), &tree))
+	return &worldentity.WorldEntity{
+		ID:         uuid.New(),
+		Name:       "Innkeeper",
+		EntityType: worldentity.EntityTypeNPC,
+		Metadata:   map[string]interface{}{MetaDialogue: tree},
+	}
+}
 
+func TestTalk_WalksDialogueTree(t *testing.T) {
+	svc := NewService(nil)
 	ctx := context.Background()
 	charID := uuid.New()
-	target := "Guard"
-	msg := "Hello there"
-
-	resp, err := svc.ProcessDialogue(ctx, charID, target, msg)
-	assert.NoError(t, err)
-	assert.NotNil(t, resp)
-	assert.Contains(t, resp.Text, "I heard you say")
-	assert.Equal(t, target, resp.NPCName)
+	npc := innkeeper(t)
+
+	resp, err := svc.Talk(ctx, charID, npc, "")
+	require.NoError(t, err)
+	assert.Equal(t, "greet", resp.NodeID)
+	assert.Equal(t, "Welcome to the Prancing Pony!", resp.Text)
+	assert.Equal(t, []string{"Any work going?", "Goodbye"}, resp.Options)
+	assert.Equal(t, npc.ID.String(), resp.NPCID)
+
+	// Picking an option by number moves to the node it leads to
+	resp, err = svc.Talk(ctx, charID, npc, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "work", resp.NodeID)
+	assert.Contains(t, resp.Text, "Rats in the cellar")
+	assert.Equal(t, []string{"Tell me again", "I'll do it"}, resp.Options)
+
+	// Something that's not an option repeats the current node
+	resp, err = svc.Talk(ctx, charID, npc, "what about dragons?")
+	require.NoError(t, err)
+	assert.Equal(t, "work", resp.NodeID)
+
+	// Options match by the start of their text, and can lead back
+	resp, err = svc.Talk(ctx, charID, npc, "tell me")
+	require.NoError(t, err)
+	assert.Equal(t, "greet", resp.NodeID)
+
+	// An option without a next node ends the conversation
+	resp, err = svc.Talk(ctx, charID, npc, "goodbye")
+	require.NoError(t, err)
+	assert.True(t, resp.Ended)
+	assert.Equal(t, "Safe travels.", resp.Text)
+	assert.Empty(t, resp.Options)
+
+	// Talking again starts over
+	resp, err = svc.Talk(ctx, charID, npc, "2")
+	require.NoError(t, err)
+	assert.Equal(t, "greet", resp.NodeID)
+}
+
+func TestTalk_ConversationsArePerPlayerAndNPC(t *testing.T) {
+	svc := NewService(nil)
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+	npc := innkeeper(t)
+
+	_, _ = svc.Talk(ctx, alice, npc, "")
+	resp, _ := svc.Talk(ctx, alice, npc, "1")
+	require.Equal(t, "work", resp.NodeID)
+
+	// Bob's conversation is his own
+	resp, _ = svc.Talk(ctx, bob, npc, "1")
+	assert.Equal(t, "greet", resp.NodeID)
+
+	// Alice talking to someone else abandons her place with the innkeeper
+	other := innkeeper(t)
+	_, _ = svc.Talk(ctx, alice, other, "")
+	resp, _ = svc.Talk(ctx, alice, npc, "1")
+	assert.Equal(t, "greet", resp.NodeID)
+}
+
+type fakeLines struct {
+	line string
+	err  error
+}
+
+func (f fakeLines) GenerateLine(ctx context.Context, npc *worldentity.WorldEntity, message string) (string, error) {
+	return f.line, f.err
+}
+
+func TestTalk_UnscriptedNPCImprovises(t *testing.T) {
+	ctx := context.Background()
+	guard := &worldentity.WorldEntity{ID: uuid.New(), Name: "Guard", EntityType: worldentity.EntityTypeNPC}
+
+	svc := NewService(nil)
+	resp, err := svc.Talk(ctx, uuid.New(), guard, "Hello there")
+	require.NoError(t, err)
+	assert.Equal(t, fallbackLine, resp.Text, "Without a generator the NPC has a stock line")
+
+	svc.SetLineGenerator(fakeLines{line: "Move along, citizen."})
+	resp, err = svc.Talk(ctx, uuid.New(), guard, "Hello there")
+	require.NoError(t, err)
+	assert.Equal(t, "Move along, citizen.", resp.Text)
+	assert.Empty(t, resp.NodeID)
+	assert.Equal(t, "Guard", resp.NPCName)
+
+	svc.SetLineGenerator(fakeLines{err: errors.New("gateway down")})
+	resp, err = svc.Talk(ctx, uuid.New(), guard, "Hello there")
+	require.NoError(t, err)
+	assert.Equal(t, fallbackLine, resp.Text)
+}
+
+// fakeRequester answers dialogue requests as the AI gateway would
+type fakeRequester struct {
+	subject string
+	request gateway.AIRequest
+	reply   gateway.AIResponse
+}
+
+func (f *fakeRequester) RequestWithContext(ctx context.Context, subj string, data []byte) (*nats.Msg, error) {
+	f.subject = subj
+	if err := json.Unmarshal(data, &f.request); err != nil {
+		return nil, err
+	}
+	f.reply.ID = f.request.ID
+	out, _ := json.Marshal(f.reply)
+	return &nats.Msg{Subject: subj, Data: out}, nil
+}
+
+func TestGatewayLines(t *testing.T) {
+	nc := &fakeRequester{reply: gateway.AIResponse{Response: ` "Fresh bread, two coppers!" `}}
+	baker := &worldentity.WorldEntity{Name: "Baker", Description: "A flour-dusted woman."}
+
+	line, err := NewGatewayLines(nc).GenerateLine(context.Background(), baker, "What's for sale?")
+	require.NoError(t, err)
+	assert.Equal(t, "Fresh bread, two coppers!", line)
+	assert.Equal(t, subjects.AIRequestDialogue, nc.subject)
+	assert.Contains(t, nc.request.Prompt, "You are Baker")
+	assert.Contains(t, nc.request.Prompt, "A flour-dusted woman.")
+	assert.Contains(t, nc.request.Prompt, `"What's for sale?"`)
+
+	nc.reply = gateway.AIResponse{Error: "model unavailable"}
+	_, err = NewGatewayLines(nc).GenerateLine(context.Background(), baker, "")
+	assert.EqualError(t, err, "model unavailable")
 }
@@ -0,0 +1,139 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"tw-backend/internal/combat/effects"
+	"tw-backend/internal/errors"
+)
+
+// Item metadata keys describing consumables
+const (
+	MetaItemType = "item_type" // e.g. "potion", "food"; items of a type share a cooldown
+	MetaHeal     = "heal"      // HP restored
+	MetaStamina  = "stamina"   // Stamina restored
+	MetaBuff     = "buff"      // Temporary stat change; see the buff keys below
+	MetaCooldown = "cooldown"  // Seconds before another item of the type can be used
+)
+
+// Keys of the MetaBuff map
+const (
+	BuffStat        = "stat"         // Attribute name, e.g. character.AttrMight
+	BuffAmount      = "amount"       // Flat bonus, or percent with BuffPercent
+	BuffPercent     = "percent"      // bool
+	BuffDuration    = "duration"     // Seconds; DefaultBuffDuration if left out
+	BuffStackPolicy = "stack_policy" // effects.StackPolicy; refresh if left out
+	BuffMaxStacks   = "max_stacks"
+)
+
+const (
+	// DefaultUseCooldown is how long after using a consumable another of its
+	// type can be used, if it doesn't record a cooldown
+	DefaultUseCooldown = 10 * time.Second
+	// DefaultBuffDuration is how long a buff lasts if it doesn't record a
+	// duration
+	DefaultBuffDuration = time.Minute
+)
+
+// Consumable returns what consuming an item does, or false if it can't be
+// consumed
+func (i InventoryItem) Consumable() (effects.Consumable, bool) {
+	c := effects.Consumable{
+		Heal:    metaInt(i.Metadata, MetaHeal, 0),
+		Stamina: metaInt(i.Metadata, MetaStamina, 0),
+	}
+	if buff, ok := i.Metadata[MetaBuff].(map[string]interface{}); ok {
+		if stat, _ := buff[BuffStat].(string); stat != "" {
+			percent, _ := buff[BuffPercent].(bool)
+			policy, _ := buff[BuffStackPolicy].(string)
+			if policy == "" {
+				policy = string(effects.PolicyRefresh)
+			}
+			c.Buff = &effects.ModifierDefinition{
+				Source:      i.Name,
+				Stat:        stat,
+				Modifier:    metaInt(buff, BuffAmount, 0),
+				IsPercent:   percent,
+				Duration:    metaSeconds(buff, BuffDuration, DefaultBuffDuration),
+				StackPolicy: effects.StackPolicy(policy),
+				MaxStacks:   metaInt(buff, BuffMaxStacks, 0),
+			}
+		}
+	}
+	return c, !c.IsEmpty()
+}
+
+// UseCooldown returns how long after using an item another of its type can
+// be used
+func (i InventoryItem) UseCooldown() time.Duration {
+	return metaSeconds(i.Metadata, MetaCooldown, DefaultUseCooldown)
+}
+
+// cooldownKey is what an item's use cooldown is shared by: its type, or its
+// name if it has none
+func (i InventoryItem) cooldownKey() string {
+	if kind, _ := i.Metadata[MetaItemType].(string); kind != "" {
+		return strings.ToLower(kind)
+	}
+	return strings.ToLower(i.Name)
+}
+
+// Consume uses up one of a carried consumable and returns what it does,
+// leaving applying that to the caller. It fails with ErrItemNotFound if the
+// character has none, ErrInvalidCommand if the item isn't consumable, and
+// ErrCooldown if they used one of its type too recently. A non-nil check
+// runs before the item is used up, and its error (e.g. because the effect
+// can't apply now) keeps the item and its cooldown.
+func (s *Service) Consume(ctx context.Context, charID uuid.UUID, itemName string, check func(InventoryItem, effects.Consumable) error) (InventoryItem, effects.Consumable, error) {
+	items, err := s.repo.GetInventory(ctx, charID)
+	if err != nil {
+		return InventoryItem{}, effects.Consumable{}, err
+	}
+	item, ok := findItem(items, itemName, false)
+	if !ok {
+		return InventoryItem{}, effects.Consumable{}, errors.Wrap(errors.ErrItemNotFound, fmt.Sprintf("You aren't carrying any '%s'.", itemName), nil)
+	}
+	consumable, ok := item.Consumable()
+	if !ok {
+		return InventoryItem{}, effects.Consumable{}, errors.Wrap(errors.ErrInvalidCommand, fmt.Sprintf("You can't use the %s.", item.Name), nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	key := item.cooldownKey()
+	if ready, ok := s.cooldowns[charID][key]; ok && now.Before(ready) {
+		wait := int(math.Ceil(ready.Sub(now).Seconds()))
+		return InventoryItem{}, effects.Consumable{}, errors.Wrap(errors.ErrCooldown, fmt.Sprintf("You must wait %ds before using another %s.", wait, key), nil).
+			WithRetryAfter(ready.Sub(now))
+	}
+	if check != nil {
+		if err := check(item, consumable); err != nil {
+			return InventoryItem{}, effects.Consumable{}, err
+		}
+	}
+
+	if err := s.repo.RemoveItem(ctx, charID, item.ItemID, 1); err != nil {
+		return InventoryItem{}, effects.Consumable{}, err
+	}
+	if s.cooldowns[charID] == nil {
+		s.cooldowns[charID] = make(map[string]time.Time)
+	}
+	s.cooldowns[charID][key] = now.Add(item.UseCooldown())
+	return item, consumable, nil
+}
+
+// metaSeconds reads a duration in seconds from metadata
+func metaSeconds(metadata map[string]interface{}, key string, def time.Duration) time.Duration {
+	seconds := metaFloat(metadata, key, -1)
+	if seconds < 0 {
+		return def
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
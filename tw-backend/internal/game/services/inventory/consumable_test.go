@@ -0,0 +1,115 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/internal/character"
+	"tw-backend/internal/combat/effects"
+	apperrors "tw-backend/internal/errors"
+)
+
+// consumableTestService gives a character two healing potions, a mana
+// potion, a loaf of bread and a sword, on a clock the test controls
+func consumableTestService() (*Service, *MockRepository, uuid.UUID, *time.Time) {
+	charID := uuid.New()
+	repo := &MockRepository{items: map[uuid.UUID][]InventoryItem{
+		charID: {
+			{ItemID: uuid.New(), Name: "Healing Potion", Quantity: 2, Metadata: map[string]interface{}{
+				MetaItemType: "potion", MetaHeal: 25.0, MetaCooldown: 30.0,
+			}},
+			{ItemID: uuid.New(), Name: "Potion of Might", Quantity: 1, Metadata: map[string]interface{}{
+				MetaItemType: "potion",
+				MetaBuff:     map[string]interface{}{BuffStat: character.AttrMight, BuffAmount: 10.0, BuffDuration: 120.0},
+			}},
+			{ItemID: uuid.New(), Name: "Bread", Quantity: 1, Metadata: map[string]interface{}{
+				MetaItemType: "food", MetaStamina: 15.0,
+			}},
+			{ItemID: uuid.New(), Name: "Sword", Quantity: 1, Metadata: map[string]interface{}{}},
+		},
+	}}
+	svc := NewService(nil, repo)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	return svc, repo, charID, &now
+}
+
+func TestService_ConsumeUsesUpItem(t *testing.T) {
+	svc, repo, charID, _ := consumableTestService()
+
+	item, consumable, err := svc.Consume(context.Background(), charID, "healing potion", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Healing Potion", item.Name)
+	assert.Equal(t, 25, consumable.Heal)
+	assert.Equal(t, 1, repo.items[charID][0].Quantity, "one of the two potions is used up")
+
+	_, consumable, err = svc.Consume(context.Background(), charID, "Bread", nil)
+	require.NoError(t, err, "food doesn't share the potions' cooldown")
+	assert.Equal(t, 15, consumable.Stamina)
+	for _, left := range repo.items[charID] {
+		assert.NotEqual(t, "Bread", left.Name, "the last loaf is gone")
+	}
+}
+
+func TestService_ConsumeCooldown(t *testing.T) {
+	svc, _, charID, now := consumableTestService()
+	ctx := context.Background()
+
+	_, _, err := svc.Consume(ctx, charID, "Healing Potion", nil)
+	require.NoError(t, err)
+
+	_, _, err = svc.Consume(ctx, charID, "Healing Potion", nil)
+	assert.ErrorIs(t, err, apperrors.ErrCooldown)
+	assert.Equal(t, "You must wait 30s before using another potion.", err.Error())
+	_, _, err = svc.Consume(ctx, charID, "Potion of Might", nil)
+	assert.ErrorIs(t, err, apperrors.ErrCooldown, "potions share a cooldown")
+
+	_, _, err = svc.Consume(ctx, uuid.New(), "Healing Potion", nil)
+	assert.ErrorIs(t, err, apperrors.ErrItemNotFound, "other characters have their own inventory")
+
+	*now = now.Add(30 * time.Second)
+	_, consumable, err := svc.Consume(ctx, charID, "Potion of Might", nil)
+	require.NoError(t, err)
+	require.NotNil(t, consumable.Buff)
+	assert.Equal(t, effects.ModifierDefinition{
+		Source:      "Potion of Might",
+		Stat:        character.AttrMight,
+		Modifier:    10,
+		Duration:    2 * time.Minute,
+		StackPolicy: effects.PolicyRefresh,
+	}, *consumable.Buff)
+}
+
+func TestService_ConsumeRejectsOtherItems(t *testing.T) {
+	svc, repo, charID, _ := consumableTestService()
+
+	_, _, err := svc.Consume(context.Background(), charID, "Sword", nil)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCommand)
+	assert.Equal(t, "You can't use the Sword.", err.Error())
+	assert.Len(t, repo.items[charID], 4, "nothing is used up")
+
+	_, _, err = svc.Consume(context.Background(), charID, "Elixir", nil)
+	assert.ErrorIs(t, err, apperrors.ErrItemNotFound)
+}
+
+func TestService_ConsumeCheckKeepsItem(t *testing.T) {
+	svc, repo, charID, _ := consumableTestService()
+	ctx := context.Background()
+	wasted := apperrors.Wrap(apperrors.ErrInvalidCommand, "It would be wasted.", nil)
+
+	_, _, err := svc.Consume(ctx, charID, "Healing Potion", func(item InventoryItem, c effects.Consumable) error {
+		assert.Equal(t, "Healing Potion", item.Name)
+		assert.Equal(t, 25, c.Heal)
+		return wasted
+	})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCommand)
+	assert.Equal(t, 2, repo.items[charID][0].Quantity, "the potion is kept")
+
+	_, _, err = svc.Consume(ctx, charID, "Healing Potion", nil)
+	assert.NoError(t, err, "a refused use doesn't start the cooldown")
+}
@@ -0,0 +1,325 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"tw-backend/internal/combat/damage"
+	"tw-backend/internal/errors"
+	"tw-backend/internal/skills"
+)
+
+// Slot is where an equipped item is worn or held
+type Slot string
+
+const (
+	SlotWeapon    Slot = "weapon"
+	SlotOffhand   Slot = "offhand" // Shields, daggers; blocked by a two-handed weapon
+	SlotArmor     Slot = "armor"
+	SlotAccessory Slot = "accessory"
+)
+
+// Item metadata keys describing equipment
+const (
+	MetaSlot          = "slot"           // Slot the item equips to; weapons and armor may leave it out
+	MetaTwoHanded     = "two_handed"     // bool; the weapon also takes the offhand
+	MetaWeaponType    = "weapon_type"    // damage.WeaponType
+	MetaDamage        = "damage"         // Weapon base damage
+	MetaArmorType     = "armor_type"     // damage.ArmorType
+	MetaDurability    = "durability"     // Weapon or armor durability left
+	MetaMaxDurability = "max_durability" // Durability when new; the durability if left out
+	MetaSkill         = "skill"          // Skill needed to use the item; weapons default to their type's
+	MetaSkillRequired = "skill_required" // Level of that skill needed to equip it
+	MetaBonuses       = "bonuses"        // Attribute name -> bonus while equipped
+	MetaDamageBonus   = "damage_bonus"   // Added to the wearer's weapon damage
+	MetaDefense       = "defense"        // Share of incoming damage blocked, 0-1
+)
+
+// defaultDurability is the durability of equipment that doesn't record one
+const defaultDurability = 100
+
+// SkillLookup returns a character's level in a skill
+type SkillLookup func(ctx context.Context, charID uuid.UUID, skill string) int
+
+// weaponSkills is the skill each weapon type is used with
+var weaponSkills = map[damage.WeaponType]string{
+	damage.WeaponSlashing:    skills.SkillSlashing,
+	damage.WeaponPiercing:    skills.SkillPiercing,
+	damage.WeaponBludgeoning: skills.SkillBludgeoning,
+	damage.WeaponRanged:      skills.SkillPiercing,
+}
+
+// WeaponSkill returns the skill a type of weapon is used with
+func WeaponSkill(kind damage.WeaponType) string {
+	return weaponSkills[kind]
+}
+
+// SlotOf returns the slot an item equips to, or "" if it can't be equipped
+func (i InventoryItem) SlotOf() Slot {
+	if slot, _ := i.Metadata[MetaSlot].(string); slot != "" {
+		return Slot(slot)
+	}
+	if _, ok := i.Metadata[MetaWeaponType].(string); ok {
+		return SlotWeapon
+	}
+	if _, ok := i.Metadata[MetaArmorType].(string); ok {
+		return SlotArmor
+	}
+	return ""
+}
+
+// TwoHanded reports whether a weapon needs both hands
+func (i InventoryItem) TwoHanded() bool {
+	twoHanded, _ := i.Metadata[MetaTwoHanded].(bool)
+	return twoHanded
+}
+
+// RequiredSkill returns the skill needed to use an item and the level
+// needed to equip it. A level of 0 means anyone can.
+func (i InventoryItem) RequiredSkill() (string, int) {
+	level := metaInt(i.Metadata, MetaSkillRequired, 0)
+	if skill, _ := i.Metadata[MetaSkill].(string); skill != "" {
+		return skill, level
+	}
+	if kind, ok := i.Metadata[MetaWeaponType].(string); ok {
+		return WeaponSkill(damage.WeaponType(kind)), level
+	}
+	if i.SlotOf() == SlotArmor {
+		return skills.SkillDefense, level
+	}
+	return "", level
+}
+
+// Bonuses returns the stat bonuses an item gives while equipped
+func (i InventoryItem) Bonuses() damage.Bonuses {
+	b := damage.Bonuses{
+		Damage:  metaInt(i.Metadata, MetaDamageBonus, 0),
+		Defense: metaFloat(i.Metadata, MetaDefense, 0),
+	}
+	if attrs, ok := i.Metadata[MetaBonuses].(map[string]interface{}); ok {
+		b.Attributes = make(map[string]int, len(attrs))
+		for attr := range attrs {
+			b.Attributes[attr] = metaInt(attrs, attr, 0)
+		}
+	}
+	return b
+}
+
+// Equipment is what a character has equipped, by slot
+type Equipment map[Slot]InventoryItem
+
+// Weapon returns the equipped weapon for combat, or nil to fight unarmed
+func (e Equipment) Weapon() *damage.Weapon {
+	item, ok := e[SlotWeapon]
+	if !ok {
+		return nil
+	}
+	kind, _ := item.Metadata[MetaWeaponType].(string)
+	if kind == "" {
+		kind = string(damage.WeaponBludgeoning)
+	}
+	durability, maxDurability := item.durability()
+	return &damage.Weapon{
+		WeaponID:      item.ItemID,
+		Name:          item.Name,
+		Type:          damage.WeaponType(kind),
+		BaseDamage:    metaInt(item.Metadata, MetaDamage, damage.Unarmed().BaseDamage),
+		Durability:    durability,
+		MaxDurability: maxDurability,
+	}
+}
+
+// Armor returns the equipped armor for combat, or nil if there is none
+func (e Equipment) Armor() *damage.Armor {
+	item, ok := e[SlotArmor]
+	if !ok {
+		return nil
+	}
+	kind, _ := item.Metadata[MetaArmorType].(string)
+	durability, maxDurability := item.durability()
+	return &damage.Armor{
+		ArmorID:       item.ItemID,
+		Name:          item.Name,
+		Type:          damage.ArmorType(kind),
+		Durability:    durability,
+		MaxDurability: maxDurability,
+	}
+}
+
+// durability returns an item's durability left and when new
+func (i InventoryItem) durability() (current, maximum int) {
+	maximum = metaInt(i.Metadata, MetaMaxDurability, metaInt(i.Metadata, MetaDurability, defaultDurability))
+	return metaInt(i.Metadata, MetaDurability, maximum), maximum
+}
+
+// RecordDurability saves the durability a character's item has left, e.g.
+// after wear in combat, keeping its durability when new
+func (s *Service) RecordDurability(ctx context.Context, charID, itemID uuid.UUID, durability int) error {
+	items, err := s.repo.GetInventory(ctx, charID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.ItemID != itemID {
+			continue
+		}
+		_, maxDurability := item.durability()
+		metadata := make(map[string]interface{}, len(item.Metadata)+2)
+		for key, value := range item.Metadata {
+			metadata[key] = value
+		}
+		metadata[MetaDurability] = durability
+		metadata[MetaMaxDurability] = maxDurability
+		return s.repo.SetMetadata(ctx, charID, itemID, metadata)
+	}
+	return errors.Wrap(errors.ErrItemNotFound, "You aren't carrying that.", nil)
+}
+
+// Bonuses returns the combined stat bonuses of everything equipped
+func (e Equipment) Bonuses() damage.Bonuses {
+	var total damage.Bonuses
+	for _, item := range e {
+		total = total.Add(item.Bonuses())
+	}
+	return total
+}
+
+// SetSkillLookup sets how characters' skill levels are found for equipment
+// requirements. Without one every skill counts as 0.
+func (s *Service) SetSkillLookup(lookup SkillLookup) {
+	s.skills = lookup
+}
+
+// Equipped returns what a character has equipped
+func (s *Service) Equipped(ctx context.Context, charID uuid.UUID) (Equipment, error) {
+	items, err := s.repo.GetInventory(ctx, charID)
+	if err != nil {
+		return nil, err
+	}
+	equipment := make(Equipment)
+	for _, item := range items {
+		if item.EquippedSlot != "" {
+			equipment[item.EquippedSlot] = item
+		}
+	}
+	return equipment, nil
+}
+
+// Equip moves a carried item into its slot, unequipping whatever was there.
+// A two-handed weapon also clears the offhand, and nothing can go in the
+// offhand while one is held. It fails with ErrCannotEquip for an item that
+// doesn't equip, and ErrInsufficientSkill if the character lacks the skill.
+func (s *Service) Equip(ctx context.Context, charID uuid.UUID, itemName string) (InventoryItem, error) {
+	items, err := s.repo.GetInventory(ctx, charID)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	item, ok := findItem(items, itemName, false)
+	if !ok {
+		return InventoryItem{}, errors.Wrap(errors.ErrItemNotFound, fmt.Sprintf("You aren't carrying any '%s'.", itemName), nil)
+	}
+
+	slot := item.SlotOf()
+	switch slot {
+	case SlotWeapon, SlotOffhand, SlotArmor, SlotAccessory:
+	default:
+		return InventoryItem{}, errors.Wrap(errors.ErrCannotEquip, fmt.Sprintf("You can't equip the %s.", item.Name), nil)
+	}
+	if item.EquippedSlot != "" {
+		return InventoryItem{}, errors.Wrap(errors.ErrCannotEquip, fmt.Sprintf("You already have the %s equipped.", item.Name), nil)
+	}
+
+	if skill, level := item.RequiredSkill(); level > 0 {
+		have := 0
+		if s.skills != nil && skill != "" {
+			have = s.skills(ctx, charID, skill)
+		}
+		if have < level {
+			return InventoryItem{}, errors.Wrap(errors.ErrInsufficientSkill, fmt.Sprintf("You need %d %s to use the %s.", level, skill, item.Name), nil)
+		}
+	}
+
+	// Make room: the slot itself, and both hands for a two-handed weapon
+	equipment := make(Equipment)
+	for _, other := range items {
+		if other.EquippedSlot != "" {
+			equipment[other.EquippedSlot] = other
+		}
+	}
+	if held, ok := equipment[SlotWeapon]; ok && slot == SlotOffhand && held.TwoHanded() {
+		return InventoryItem{}, errors.Wrap(errors.ErrCannotEquip, fmt.Sprintf("Your %s needs both hands.", held.Name), nil)
+	}
+	freed := []Slot{slot}
+	if slot == SlotWeapon && item.TwoHanded() {
+		freed = append(freed, SlotOffhand)
+	}
+	for _, f := range freed {
+		if other, ok := equipment[f]; ok {
+			if err := s.repo.SetEquippedSlot(ctx, charID, other.ItemID, ""); err != nil {
+				return InventoryItem{}, err
+			}
+		}
+	}
+
+	if err := s.repo.SetEquippedSlot(ctx, charID, item.ItemID, slot); err != nil {
+		return InventoryItem{}, err
+	}
+	item.EquippedSlot = slot
+	return item, nil
+}
+
+// Unequip returns an equipped item to the pack
+func (s *Service) Unequip(ctx context.Context, charID uuid.UUID, itemName string) (InventoryItem, error) {
+	items, err := s.repo.GetInventory(ctx, charID)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	item, ok := findItem(items, itemName, true)
+	if !ok {
+		return InventoryItem{}, errors.Wrap(errors.ErrItemNotFound, fmt.Sprintf("You don't have any '%s' equipped.", itemName), nil)
+	}
+	if err := s.repo.SetEquippedSlot(ctx, charID, item.ItemID, ""); err != nil {
+		return InventoryItem{}, err
+	}
+	item.EquippedSlot = ""
+	return item, nil
+}
+
+// findItem finds a carried item by name, ignoring case. With equipped set,
+// only equipped items match.
+func findItem(items []InventoryItem, name string, equipped bool) (InventoryItem, bool) {
+	for _, item := range items {
+		if equipped && item.EquippedSlot == "" {
+			continue
+		}
+		if strings.EqualFold(item.Name, name) {
+			return item, true
+		}
+	}
+	return InventoryItem{}, false
+}
+
+// metaInt reads a whole number from metadata
+func metaInt(metadata map[string]interface{}, key string, def int) int {
+	switch v := metadata[key].(type) {
+	case float64: // Decoded from JSON
+		return int(v)
+	case int:
+		return v
+	}
+	return def
+}
+
+// metaFloat reads a number from metadata
+func metaFloat(metadata map[string]interface{}, key string, def float64) float64 {
+	switch v := metadata[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	}
+	return def
+}
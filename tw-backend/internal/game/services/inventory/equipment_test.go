@@ -0,0 +1,148 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/internal/character"
+	"tw-backend/internal/combat/damage"
+	apperrors "tw-backend/internal/errors"
+	"tw-backend/internal/skills"
+)
+
+// equipmentTestService gives a character a sword, a greatsword, a shield and
+// a loaf of bread, with the given Slashing skill
+func equipmentTestService(slashing int) (*Service, *MockRepository, uuid.UUID) {
+	charID := uuid.New()
+	repo := &MockRepository{items: map[uuid.UUID][]InventoryItem{
+		charID: {
+			{ItemID: uuid.New(), Name: "Iron Sword", Quantity: 1, Metadata: map[string]interface{}{
+				MetaWeaponType: string(damage.WeaponSlashing), MetaDamage: 20, MetaSkillRequired: 10,
+				MetaBonuses: map[string]interface{}{character.AttrMight: 5.0},
+			}},
+			{ItemID: uuid.New(), Name: "Greatsword", Quantity: 1, Metadata: map[string]interface{}{
+				MetaWeaponType: string(damage.WeaponSlashing), MetaDamage: 35, MetaTwoHanded: true,
+			}},
+			{ItemID: uuid.New(), Name: "Shield", Quantity: 1, Metadata: map[string]interface{}{
+				MetaSlot: string(SlotOffhand), MetaDefense: 0.2,
+			}},
+			{ItemID: uuid.New(), Name: "Bread", Quantity: 1, Metadata: map[string]interface{}{}},
+		},
+	}}
+	svc := NewService(nil, repo)
+	svc.SetSkillLookup(func(ctx context.Context, id uuid.UUID, skill string) int {
+		if id == charID && skill == skills.SkillSlashing {
+			return slashing
+		}
+		return 0
+	})
+	return svc, repo, charID
+}
+
+func TestService_EquipWeapon(t *testing.T) {
+	svc, _, charID := equipmentTestService(20)
+	ctx := context.Background()
+
+	item, err := svc.Equip(ctx, charID, "iron sword")
+	require.NoError(t, err)
+	assert.Equal(t, SlotWeapon, item.EquippedSlot)
+
+	equipment, err := svc.Equipped(ctx, charID)
+	require.NoError(t, err)
+	weapon := equipment.Weapon()
+	require.NotNil(t, weapon)
+	assert.Equal(t, "Iron Sword", weapon.Name)
+	assert.Equal(t, damage.WeaponSlashing, weapon.Type)
+	assert.Equal(t, 20, weapon.BaseDamage)
+	assert.Equal(t, 5, equipment.Bonuses().Attributes[character.AttrMight])
+
+	_, err = svc.Unequip(ctx, charID, "Iron Sword")
+	require.NoError(t, err)
+	equipment, err = svc.Equipped(ctx, charID)
+	require.NoError(t, err)
+	assert.Nil(t, equipment.Weapon(), "back to fists")
+}
+
+func TestService_EquipRejectsUnderSkilled(t *testing.T) {
+	svc, _, charID := equipmentTestService(5)
+
+	_, err := svc.Equip(context.Background(), charID, "Iron Sword")
+	assert.ErrorIs(t, err, apperrors.ErrInsufficientSkill)
+	assert.Equal(t, "You need 10 Slashing to use the Iron Sword.", err.Error())
+
+	_, err = svc.Equip(context.Background(), charID, "Greatsword")
+	assert.NoError(t, err, "the greatsword needs no skill")
+}
+
+func TestService_EquipTwoHandedTakesOffhand(t *testing.T) {
+	svc, _, charID := equipmentTestService(20)
+	ctx := context.Background()
+
+	_, err := svc.Equip(ctx, charID, "Shield")
+	require.NoError(t, err)
+	_, err = svc.Equip(ctx, charID, "Greatsword")
+	require.NoError(t, err)
+
+	equipment, err := svc.Equipped(ctx, charID)
+	require.NoError(t, err)
+	assert.Contains(t, equipment, SlotWeapon)
+	assert.NotContains(t, equipment, SlotOffhand, "the greatsword needs both hands")
+
+	_, err = svc.Equip(ctx, charID, "Shield")
+	assert.ErrorIs(t, err, apperrors.ErrCannotEquip)
+	assert.Equal(t, "Your Greatsword needs both hands.", err.Error())
+
+	// A one-handed weapon takes the greatsword's place, freeing the offhand
+	_, err = svc.Equip(ctx, charID, "Iron Sword")
+	require.NoError(t, err)
+	_, err = svc.Equip(ctx, charID, "Shield")
+	require.NoError(t, err)
+	equipment, err = svc.Equipped(ctx, charID)
+	require.NoError(t, err)
+	assert.Equal(t, "Iron Sword", equipment[SlotWeapon].Name)
+	assert.InDelta(t, 0.2, equipment.Bonuses().Defense, 1e-9)
+}
+
+func TestService_EquipRejectsOrdinaryItems(t *testing.T) {
+	svc, _, charID := equipmentTestService(20)
+
+	_, err := svc.Equip(context.Background(), charID, "Bread")
+	assert.ErrorIs(t, err, apperrors.ErrCannotEquip)
+
+	_, err = svc.Equip(context.Background(), charID, "Lantern")
+	assert.ErrorIs(t, err, apperrors.ErrItemNotFound)
+}
+
+func TestService_RecordDurabilityKeepsMaximum(t *testing.T) {
+	svc, _, charID := equipmentTestService(20)
+	ctx := context.Background()
+
+	item, err := svc.Equip(ctx, charID, "iron sword")
+	require.NoError(t, err)
+	equipment, err := svc.Equipped(ctx, charID)
+	require.NoError(t, err)
+	weapon := equipment.Weapon()
+	assert.Equal(t, defaultDurability, weapon.Durability, "Unrecorded durability is full")
+	assert.Equal(t, defaultDurability, weapon.MaxDurability)
+
+	require.NoError(t, svc.RecordDurability(ctx, charID, item.ItemID, 40))
+
+	equipment, err = svc.Equipped(ctx, charID)
+	require.NoError(t, err)
+	weapon = equipment.Weapon()
+	assert.Equal(t, 40, weapon.Durability, "Wear is read back")
+	assert.Equal(t, defaultDurability, weapon.MaxDurability, "The maximum is kept")
+	assert.Equal(t, "Iron Sword", weapon.Name, "Other metadata is kept")
+
+	require.NoError(t, svc.RecordDurability(ctx, charID, item.ItemID, 0))
+	equipment, err = svc.Equipped(ctx, charID)
+	require.NoError(t, err)
+	assert.True(t, equipment.Weapon().IsBroken())
+
+	err = svc.RecordDurability(ctx, charID, uuid.New(), 10)
+	assert.ErrorIs(t, err, apperrors.ErrItemNotFound)
+}
@@ -10,24 +10,32 @@ import (
 
 // Repository handles inventory persistence
 type Repository interface {
+	// AddItem adds to the character's stack of the item, starting a new
+	// stack with the given metadata if they have none
 	AddItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int, metadata map[string]interface{}) error
 	RemoveItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int) error
 	GetInventory(ctx context.Context, charID uuid.UUID) ([]InventoryItem, error)
+	// SetEquippedSlot equips an item to a slot, or unequips it with ""
+	SetEquippedSlot(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, slot Slot) error
+	// SetMetadata replaces an item's metadata, e.g. to record wear
+	SetMetadata(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, metadata map[string]interface{}) error
 }
 
 // InventoryItem represents an item in an inventory
 type InventoryItem struct {
-	ID          uuid.UUID              `json:"id"`
-	CharacterID uuid.UUID              `json:"character_id"`
-	ItemID      uuid.UUID              `json:"item_id"`
-	Quantity    int                    `json:"quantity"`
-	Metadata    map[string]interface{} `json:"metadata"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	ID           uuid.UUID              `json:"id"`
+	CharacterID  uuid.UUID              `json:"character_id"`
+	ItemID       uuid.UUID              `json:"item_id"`
+	Quantity     int                    `json:"quantity"`
+	Metadata     map[string]interface{} `json:"metadata"`
+	EquippedSlot Slot                   `json:"equipped_slot,omitempty"` // Empty when not equipped
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
 
 	// Enriched data
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"` // Of one unit, in kg
 }
 
 // PostgresRepository implements Repository using PostgreSQL
@@ -40,6 +48,24 @@ func NewPostgresRepository(db *pgxpool.Pool) *PostgresRepository {
 }
 
 func (r *PostgresRepository) AddItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int, metadata map[string]interface{}) error {
+	// Stack onto a held item, keeping that stack's metadata
+	stackQuery := `
+		UPDATE character_inventory
+		SET quantity = quantity + $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM character_inventory
+			WHERE character_id = $1 AND item_id = $2
+			LIMIT 1
+		)
+	`
+	tag, err := r.db.Exec(ctx, stackQuery, charID, itemID, quantity)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
 	query := `
 		INSERT INTO character_inventory (id, character_id, item_id, quantity, metadata)
 		VALUES ($1, $2, $3, $4, $5)
@@ -49,7 +75,7 @@ func (r *PostgresRepository) AddItem(ctx context.Context, charID uuid.UUID, item
 		metadata = make(map[string]interface{})
 	}
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		uuid.New(),
 		charID,
 		itemID,
@@ -60,20 +86,46 @@ func (r *PostgresRepository) AddItem(ctx context.Context, charID uuid.UUID, item
 }
 
 func (r *PostgresRepository) RemoveItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int) error {
-	// Simple removal: delete row if exists.
-	// Future: decrement quantity and delete if <= 0
+	// A stack that runs out is deleted; a larger one is decremented
+	deleteQuery := `
+		DELETE FROM character_inventory
+		WHERE character_id = $1 AND item_id = $2 AND quantity <= $3
+	`
+	if _, err := r.db.Exec(ctx, deleteQuery, charID, itemID, quantity); err != nil {
+		return err
+	}
+	updateQuery := `
+		UPDATE character_inventory
+		SET quantity = quantity - $3, updated_at = CURRENT_TIMESTAMP
+		WHERE character_id = $1 AND item_id = $2
+	`
+	_, err := r.db.Exec(ctx, updateQuery, charID, itemID, quantity)
+	return err
+}
+
+func (r *PostgresRepository) SetEquippedSlot(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, slot Slot) error {
+	query := `
+		UPDATE character_inventory
+		SET equipped_slot = NULLIF($3, ''), updated_at = CURRENT_TIMESTAMP
+		WHERE character_id = $1 AND item_id = $2
+	`
+	_, err := r.db.Exec(ctx, query, charID, itemID, string(slot))
+	return err
+}
+
+func (r *PostgresRepository) SetMetadata(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, metadata map[string]interface{}) error {
 	query := `
-		DELETE FROM character_inventory 
+		UPDATE character_inventory
+		SET metadata = $3, updated_at = CURRENT_TIMESTAMP
 		WHERE character_id = $1 AND item_id = $2
 	`
-	// Note: limits removal to fully matching item_id
-	_, err := r.db.Exec(ctx, query, charID, itemID)
+	_, err := r.db.Exec(ctx, query, charID, itemID, metadata)
 	return err
 }
 
 func (r *PostgresRepository) GetInventory(ctx context.Context, charID uuid.UUID) ([]InventoryItem, error) {
 	query := `
-		SELECT id, character_id, item_id, quantity, metadata, created_at, updated_at
+		SELECT id, character_id, item_id, quantity, metadata, COALESCE(equipped_slot, ''), created_at, updated_at
 		FROM character_inventory
 		WHERE character_id = $1
 	`
@@ -94,6 +146,7 @@ func (r *PostgresRepository) GetInventory(ctx context.Context, charID uuid.UUID)
 			&i.ItemID,
 			&i.Quantity,
 			&i.Metadata,
+			&i.EquippedSlot,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		)
@@ -108,6 +161,7 @@ func (r *PostgresRepository) GetInventory(ctx context.Context, charID uuid.UUID)
 		if desc, ok := i.Metadata["description"].(string); ok {
 			i.Description = desc
 		}
+		i.Weight = WeightOf(i.Metadata)
 
 		items = append(items, i)
 	}
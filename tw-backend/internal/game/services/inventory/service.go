@@ -3,9 +3,13 @@ package inventory
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
+	"tw-backend/internal/errors"
 	"tw-backend/internal/game/services/entity"
 )
 
@@ -18,20 +22,101 @@ type Item struct {
 type Service struct {
 	entityService *entity.Service
 	repo          Repository
+	capacity      CapacityLookup
+	skills        SkillLookup
+
+	// cooldowns holds when each character can next use each type of
+	// consumable
+	cooldowns map[uuid.UUID]map[string]time.Time
+	now       func() time.Time
+	mu        sync.Mutex
 }
 
 func NewService(entityService *entity.Service, repo Repository) *Service {
 	return &Service{
 		entityService: entityService,
 		repo:          repo,
+		cooldowns:     make(map[uuid.UUID]map[string]time.Time),
+		now:           time.Now,
 	}
 }
 
-// AddItem adds an item to a character's inventory
+// SetCapacityLookup sets how characters' carry capacities are found. Without
+// one every character has DefaultCarryCapacity.
+func (s *Service) SetCapacityLookup(lookup CapacityLookup) {
+	s.capacity = lookup
+}
+
+// AddItem adds an item to a character's inventory. It fails with
+// ErrInventoryFull if every slot is taken by other items, or the item's
+// weight (see MetaWeight) would take the character past MaxLoad.
 func (s *Service) AddItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int, metadata map[string]interface{}) error {
+	if err := s.CheckRoom(ctx, charID, itemID, WeightOf(metadata)*float64(quantity)); err != nil {
+		return err
+	}
 	return s.repo.AddItem(ctx, charID, itemID, quantity, metadata)
 }
 
+// CheckRoom reports with ErrInventoryFull if a character can't take on the
+// given weight of an item. An item they already hold stacks, so needs no
+// free slot.
+func (s *Service) CheckRoom(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, weight float64) error {
+	items, err := s.repo.GetInventory(ctx, charID)
+	if err != nil {
+		return err
+	}
+	held := slices.ContainsFunc(items, func(item InventoryItem) bool { return item.ItemID == itemID })
+	if !held && len(items) >= MaxSlots {
+		return errors.Wrap(errors.ErrInventoryFull, "You can't carry any more items.", nil)
+	}
+	if weight > 0 && totalWeight(items)+weight > MaxLoad*s.CarryCapacity(ctx, charID) {
+		return errors.Wrap(errors.ErrInventoryFull, "That is too heavy for you to carry.", nil)
+	}
+	return nil
+}
+
+// TotalWeight returns the weight of everything a character carries, in kg
+func (s *Service) TotalWeight(ctx context.Context, charID uuid.UUID) (float64, error) {
+	items, err := s.repo.GetInventory(ctx, charID)
+	if err != nil {
+		return 0, err
+	}
+	return totalWeight(items), nil
+}
+
+// CarryCapacity returns how much a character carries before becoming
+// encumbered, in kg
+func (s *Service) CarryCapacity(ctx context.Context, charID uuid.UUID) float64 {
+	if s.capacity != nil {
+		if capacity, ok := s.capacity(ctx, charID); ok {
+			return capacity
+		}
+	}
+	return DefaultCarryCapacity
+}
+
+// Load returns a character's carried weight as a share of their carry
+// capacity: above 1 they are encumbered
+func (s *Service) Load(ctx context.Context, charID uuid.UUID) (float64, error) {
+	weight, err := s.TotalWeight(ctx, charID)
+	if err != nil {
+		return 0, err
+	}
+	capacity := s.CarryCapacity(ctx, charID)
+	if capacity <= 0 {
+		return MaxLoad, nil
+	}
+	return weight / capacity, nil
+}
+
+func totalWeight(items []InventoryItem) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.TotalWeight()
+	}
+	return total
+}
+
 // RemoveItem removes an item from inventory by ID
 func (s *Service) RemoveItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int) error {
 	return s.repo.RemoveItem(ctx, charID, itemID, quantity)
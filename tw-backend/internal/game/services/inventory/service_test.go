@@ -6,6 +6,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "tw-backend/internal/errors"
 )
 
 // MockRepository
@@ -17,6 +20,12 @@ func (m *MockRepository) AddItem(ctx context.Context, charID uuid.UUID, itemID u
 	if m.items == nil {
 		m.items = make(map[uuid.UUID][]InventoryItem)
 	}
+	for i := range m.items[charID] {
+		if m.items[charID][i].ItemID == itemID {
+			m.items[charID][i].Quantity += quantity
+			return nil
+		}
+	}
 	m.items[charID] = append(m.items[charID], InventoryItem{
 		CharacterID: charID,
 		ItemID:      itemID,
@@ -28,13 +37,42 @@ func (m *MockRepository) AddItem(ctx context.Context, charID uuid.UUID, itemID u
 }
 
 func (m *MockRepository) RemoveItem(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, quantity int) error {
-	return nil // Mock implementation
+	items := m.items[charID][:0]
+	for _, item := range m.items[charID] {
+		if item.ItemID == itemID {
+			item.Quantity -= quantity
+			if item.Quantity <= 0 {
+				continue
+			}
+		}
+		items = append(items, item)
+	}
+	m.items[charID] = items
+	return nil
 }
 
 func (m *MockRepository) GetInventory(ctx context.Context, charID uuid.UUID) ([]InventoryItem, error) {
 	return m.items[charID], nil
 }
 
+func (m *MockRepository) SetEquippedSlot(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, slot Slot) error {
+	for i := range m.items[charID] {
+		if m.items[charID][i].ItemID == itemID {
+			m.items[charID][i].EquippedSlot = slot
+		}
+	}
+	return nil
+}
+
+func (m *MockRepository) SetMetadata(ctx context.Context, charID uuid.UUID, itemID uuid.UUID, metadata map[string]interface{}) error {
+	for i := range m.items[charID] {
+		if m.items[charID][i].ItemID == itemID {
+			m.items[charID][i].Metadata = metadata
+		}
+	}
+	return nil
+}
+
 func TestService_AddItem(t *testing.T) {
 	mockRepo := &MockRepository{}
 	svc := NewService(nil, mockRepo)
@@ -71,3 +109,79 @@ func TestInventoryService_GetInventory(t *testing.T) {
 	assert.Len(t, items, 1)
 	assert.Equal(t, "Shield", items[0].Name)
 }
+
+func TestService_TotalWeight(t *testing.T) {
+	mockRepo := &MockRepository{}
+	svc := NewService(nil, mockRepo)
+	ctx := context.Background()
+	charID := uuid.New()
+
+	mockRepo.items = map[uuid.UUID][]InventoryItem{
+		charID: {
+			{ItemID: uuid.New(), Quantity: 3, Metadata: map[string]interface{}{MetaWeight: 2.0}},
+			{ItemID: uuid.New(), Quantity: 2}, // No recorded weight
+		},
+	}
+
+	weight, err := svc.TotalWeight(ctx, charID)
+	assert.NoError(t, err)
+	assert.InDelta(t, 6+2*DefaultItemWeight, weight, 0.001)
+
+	load, err := svc.Load(ctx, charID)
+	assert.NoError(t, err)
+	assert.InDelta(t, weight/DefaultCarryCapacity, load, 0.001)
+}
+
+func TestService_AddItem_TooHeavy(t *testing.T) {
+	mockRepo := &MockRepository{}
+	svc := NewService(nil, mockRepo)
+	svc.SetCapacityLookup(func(ctx context.Context, charID uuid.UUID) (float64, bool) {
+		return CarryCapacity(20), true
+	})
+	ctx := context.Background()
+	charID := uuid.New()
+
+	// Past capacity is allowed, but encumbering
+	err := svc.AddItem(ctx, charID, uuid.New(), 1, map[string]interface{}{"name": "Anvil", MetaWeight: 30.0})
+	assert.NoError(t, err)
+
+	err = svc.AddItem(ctx, charID, uuid.New(), 1, map[string]interface{}{"name": "Boulder", MetaWeight: 15.0})
+	assert.ErrorIs(t, err, apperrors.ErrInventoryFull)
+	assert.Contains(t, err.Error(), "too heavy")
+
+	items, _ := mockRepo.GetInventory(ctx, charID)
+	assert.Len(t, items, 1, "the boulder isn't added")
+}
+
+func TestService_AddItem_OutOfSlots(t *testing.T) {
+	mockRepo := &MockRepository{}
+	svc := NewService(nil, mockRepo)
+	ctx := context.Background()
+	charID := uuid.New()
+
+	for i := 0; i < MaxSlots; i++ {
+		assert.NoError(t, svc.AddItem(ctx, charID, uuid.New(), 1, map[string]interface{}{"name": "Feather", MetaWeight: 0.0}))
+	}
+
+	err := svc.AddItem(ctx, charID, uuid.New(), 1, map[string]interface{}{"name": "Feather", MetaWeight: 0.0})
+	assert.ErrorIs(t, err, apperrors.ErrInventoryFull)
+}
+
+func TestService_AddItem_StacksWhenOutOfSlots(t *testing.T) {
+	mockRepo := &MockRepository{}
+	svc := NewService(nil, mockRepo)
+	ctx := context.Background()
+	charID := uuid.New()
+
+	arrows := uuid.New()
+	require.NoError(t, svc.AddItem(ctx, charID, arrows, 10, map[string]interface{}{"name": "Arrow", MetaWeight: 0.0}))
+	for i := 1; i < MaxSlots; i++ {
+		require.NoError(t, svc.AddItem(ctx, charID, uuid.New(), 1, map[string]interface{}{"name": "Feather", MetaWeight: 0.0}))
+	}
+
+	assert.NoError(t, svc.AddItem(ctx, charID, arrows, 5, map[string]interface{}{"name": "Arrow", MetaWeight: 0.0}), "more of a held item needs no slot")
+
+	items, _ := mockRepo.GetInventory(ctx, charID)
+	assert.Len(t, items, MaxSlots)
+	assert.Equal(t, 15, items[0].Quantity)
+}
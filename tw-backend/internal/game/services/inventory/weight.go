@@ -0,0 +1,65 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// MetaWeight is the item metadata key holding the weight of one unit, in kg
+const MetaWeight = "weight"
+
+const (
+	// DefaultItemWeight is the weight of an item that doesn't record one
+	DefaultItemWeight = 0.5
+	// MaxSlots is how many separate stacks an inventory holds
+	MaxSlots = 40
+	// CarryCapacityPerMight is how many kg a character carries unencumbered
+	// per point of Might
+	CarryCapacityPerMight = 1.0
+	// DefaultCarryCapacity is the carry capacity of a character whose Might
+	// isn't known: a human's
+	DefaultCarryCapacity = 50 * CarryCapacityPerMight
+	// MaxLoad is the heaviest load a character can carry, as a multiple of
+	// their carry capacity. Between the two they are encumbered.
+	MaxLoad = 2.0
+)
+
+// CapacityLookup returns a character's carry capacity in kg, or false if it
+// isn't known
+type CapacityLookup func(ctx context.Context, charID uuid.UUID) (float64, bool)
+
+// CarryCapacity is how much a character with the given Might carries before
+// becoming encumbered
+func CarryCapacity(might int) float64 {
+	return float64(might) * CarryCapacityPerMight
+}
+
+// UnitWeight returns the weight of one of an item
+func (i InventoryItem) UnitWeight() float64 {
+	if i.Weight > 0 {
+		return i.Weight
+	}
+	return WeightOf(i.Metadata)
+}
+
+// TotalWeight returns the weight of an item's whole stack
+func (i InventoryItem) TotalWeight() float64 {
+	return i.UnitWeight() * float64(i.Quantity)
+}
+
+// WeightOf reads the weight of one unit from item or world entity metadata,
+// defaulting to DefaultItemWeight
+func WeightOf(metadata map[string]interface{}) float64 {
+	switch v := metadata[MetaWeight].(type) {
+	case float64: // Decoded from JSON
+		if v >= 0 {
+			return v
+		}
+	case int:
+		if v >= 0 {
+			return float64(v)
+		}
+	}
+	return DefaultItemWeight
+}
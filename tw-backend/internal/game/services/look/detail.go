@@ -0,0 +1,130 @@
+package look
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"tw-backend/internal/auth"
+	"tw-backend/internal/ecosystem/state"
+	"tw-backend/internal/skills"
+	"tw-backend/internal/worldentity"
+)
+
+// Detail levels for DescribeContext.DetailLevel. Each level shows everything
+// the one below does, and more.
+const (
+	DetailBasic    = 1 // What a glance shows
+	DetailDetailed = 2 // Close details, nearby objects and creatures' condition
+	DetailDeep     = 3 // Hidden features and environmental clues, for the perceptive
+)
+
+// Metadata keys for world entity features only a perceptive character finds
+const (
+	MetaHidden       = "hidden"        // bool; the entity is only noticed on a deep look
+	MetaSecret       = "secret"        // string; more detail revealed on a deep look
+	MetaPerceptionDC = "perception_dc" // Perception needed for either; unset is skills.DifficultyMedium
+)
+
+// TrackingDifficulty is the perception needed to read tracks and behaviour
+const TrackingDifficulty = skills.DifficultyEasy
+
+// examination is a character's run of examinations of one target from one spot
+type examination struct {
+	target string
+	x, y   float64
+	count  int
+}
+
+// ExamineLevel returns the detail level a character examines a target at.
+// It starts from the requested level and goes one deeper for each repeated
+// examination of the same target from the same spot, and one deeper for a
+// character with at least skills.DifficultyHard perception.
+func (s *LookService) ExamineLevel(char *auth.Character, target string, requested, perception int) int {
+	return examineLevel(requested, s.noteExamination(char, target), perception)
+}
+
+func examineLevel(requested, count, perception int) int {
+	level := requested + count - 1
+	if perception >= skills.DifficultyHard {
+		level++
+	}
+	return max(DetailBasic, min(DetailDeep, level))
+}
+
+// noteExamination records that a character examined a target and returns how
+// many times in a row they have, here
+func (s *LookService) noteExamination(char *auth.Character, target string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.examinations == nil {
+		s.examinations = make(map[uuid.UUID]examination)
+	}
+
+	target = strings.ToLower(strings.TrimSpace(target))
+	ex := s.examinations[char.CharacterID]
+	if ex.target != target || ex.x != char.PositionX || ex.y != char.PositionY {
+		ex = examination{target: target, x: char.PositionX, y: char.PositionY}
+	}
+	ex.count++
+	s.examinations[char.CharacterID] = ex
+	return ex.count
+}
+
+// PerceptionDC returns the perception needed to find an entity's hidden
+// features
+func PerceptionDC(e *worldentity.WorldEntity) int {
+	switch v := e.Metadata[MetaPerceptionDC].(type) {
+	case float64: // Decoded from JSON
+		return int(v)
+	case int:
+		return v
+	}
+	return skills.DifficultyMedium
+}
+
+// perceives reports whether a deep enough look by a perceptive enough
+// character finds an entity's hidden features
+func (dc DescribeContext) perceives(e *worldentity.WorldEntity) bool {
+	return dc.DetailLevel >= DetailDeep && dc.Perception >= PerceptionDC(e)
+}
+
+// notices reports whether the character sees an entity at all; a hidden one
+// must be perceived
+func (dc DescribeContext) notices(e *worldentity.WorldEntity) bool {
+	if hidden, _ := e.Metadata[MetaHidden].(bool); hidden {
+		return dc.perceives(e)
+	}
+	return true
+}
+
+// readsTracks reports whether the character picks up environmental clues
+func (dc DescribeContext) readsTracks() bool {
+	return dc.DetailLevel >= DetailDeep && dc.Perception >= TrackingDifficulty
+}
+
+// describeCondition lists a creature's needs and age
+func describeCondition(e *state.LivingEntityState) string {
+	return fmt.Sprintf("Hunger %.0f/100, thirst %.0f/100, energy %.0f/100.\nGeneration %d, %d ticks old.",
+		e.Needs.Hunger, e.Needs.Thirst, e.Needs.Energy, e.Generation, e.Age)
+}
+
+// describeBehaviour reads what a creature has been doing from its last
+// decision
+func describeBehaviour(e *state.LivingEntityState) string {
+	if len(e.Logs) == 0 {
+		return ""
+	}
+	last := e.Logs[len(e.Logs)-1]
+	return fmt.Sprintf("Signs nearby suggest it has been trying to %s.", strings.ToLower(last.Action))
+}
+
+// compassDirection names the eight-point direction of an offset, with north
+// along +Y
+func compassDirection(dx, dy float64) string {
+	names := []string{"east", "northeast", "north", "northwest", "west", "southwest", "south", "southeast"}
+	octant := int(math.Round(math.Atan2(dy, dx)/(math.Pi/4))+8) % 8
+	return names[octant]
+}
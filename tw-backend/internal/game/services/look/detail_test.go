@@ -0,0 +1,196 @@
+package look
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tw-backend/internal/auth"
+	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/state"
+	"tw-backend/internal/repository"
+	"tw-backend/internal/skills"
+	"tw-backend/internal/worldentity"
+)
+
+// entityRepo holds a world's entities in memory
+type entityRepo struct {
+	worldentity.Repository
+	entities []*worldentity.WorldEntity
+}
+
+func (r *entityRepo) GetByWorldID(ctx context.Context, worldID uuid.UUID) ([]*worldentity.WorldEntity, error) {
+	return r.entities, nil
+}
+
+func (r *entityRepo) GetByName(ctx context.Context, worldID uuid.UUID, name string) (*worldentity.WorldEntity, error) {
+	for _, e := range r.entities {
+		if strings.EqualFold(e.Name, name) {
+			return e, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+// missingWorldRepo knows no worlds, so rooms get the fallback description
+type missingWorldRepo struct {
+	repository.WorldRepository
+}
+
+func (missingWorldRepo) GetWorld(ctx context.Context, worldID uuid.UUID) (*repository.World, error) {
+	return nil, errors.New("not found")
+}
+
+// statue stands 5m from the character, beyond arm's reach
+func statue() *worldentity.WorldEntity {
+	return &worldentity.WorldEntity{
+		ID:          uuid.New(),
+		Name:        "statue",
+		Description: "A weathered stone statue.",
+		Details:     "Its plinth bears a half-worn inscription.",
+		X:           15,
+		Y:           10,
+		Metadata: map[string]interface{}{
+			MetaSecret:       "A seam in the plinth hints at a hidden compartment.",
+			MetaPerceptionDC: 40,
+		},
+	}
+}
+
+func hiddenLever() *worldentity.WorldEntity {
+	return &worldentity.WorldEntity{
+		ID:          uuid.New(),
+		Name:        "lever",
+		Description: "A small iron lever, tucked behind the statue.",
+		X:           14,
+		Y:           10,
+		Metadata:    map[string]interface{}{MetaHidden: true},
+	}
+}
+
+func detailTestService(entities ...*worldentity.WorldEntity) (*LookService, *auth.Character) {
+	s := &LookService{
+		worldRepo:          missingWorldRepo{},
+		worldEntityService: worldentity.NewService(&entityRepo{entities: entities}),
+	}
+	char := &auth.Character{CharacterID: uuid.New(), WorldID: uuid.New(), PositionX: 10, PositionY: 10}
+	return s, char
+}
+
+func TestDescribeTarget_DeeperLevelsRevealMore(t *testing.T) {
+	s, char := detailTestService(statue())
+
+	var previous string
+	for level := DetailBasic; level <= DetailDeep; level++ {
+		dc := DescribeContext{WorldID: char.WorldID, Character: char, DetailLevel: level, Perception: skills.DifficultyMedium}
+		desc, err := s.DescribeTarget(context.Background(), dc, "statue")
+		require.NoError(t, err)
+
+		assert.Contains(t, desc, previous, "level %d keeps what level %d showed", level, level-1)
+		assert.Greater(t, len(desc), len(previous), "level %d shows more than level %d", level, level-1)
+		previous = desc
+	}
+	assert.Contains(t, previous, "hidden compartment")
+}
+
+func TestDescribeTarget_PerceptionGatesHiddenFeatures(t *testing.T) {
+	s, char := detailTestService(statue(), hiddenLever())
+	deep := func(perception int) DescribeContext {
+		return DescribeContext{WorldID: char.WorldID, Character: char, DetailLevel: DetailDeep, Perception: perception}
+	}
+
+	desc, err := s.DescribeTarget(context.Background(), deep(10), "statue")
+	require.NoError(t, err)
+	assert.Contains(t, desc, "inscription", "close details need no perception")
+	assert.NotContains(t, desc, "hidden compartment")
+
+	_, err = s.DescribeTarget(context.Background(), deep(10), "lever")
+	assert.Error(t, err, "an unperceptive character doesn't find the lever")
+
+	desc, err = s.DescribeTarget(context.Background(), deep(skills.DifficultyMedium), "lever")
+	require.NoError(t, err)
+	assert.Contains(t, desc, "iron lever")
+
+	_, err = s.DescribeTarget(context.Background(), DescribeContext{WorldID: char.WorldID, Character: char, DetailLevel: DetailDetailed, Perception: 100}, "lever")
+	assert.Error(t, err, "hidden things need a deep look, however perceptive")
+}
+
+func TestDescribe_DeepLookFindsHiddenThingsAndTracks(t *testing.T) {
+	s, char := detailTestService(statue(), hiddenLever())
+	eco := ecosystem.NewService(0)
+	s.ecosystemService = eco
+
+	// A wolf out of sight to the north
+	wolf := eco.Spawner.CreateEntity(state.SpeciesWolf, 1)
+	wolf.WorldID = char.WorldID
+	wolf.PositionX, wolf.PositionY = char.PositionX, char.PositionY+BaseLookRadius+5
+	eco.Entities[wolf.EntityID] = wolf
+
+	describe := func(level, perception int) string {
+		desc, err := s.Describe(context.Background(), DescribeContext{WorldID: char.WorldID, Character: char, DetailLevel: level, Perception: perception})
+		require.NoError(t, err)
+		return desc
+	}
+
+	basic := describe(DetailBasic, 100)
+	assert.NotContains(t, basic, "statue")
+
+	detailed := describe(DetailDetailed, 100)
+	assert.Contains(t, detailed, "There is a statue here.")
+	assert.NotContains(t, detailed, "lever")
+	assert.NotContains(t, detailed, "tracks")
+
+	deep := describe(DetailDeep, 100)
+	assert.Contains(t, deep, "There is a statue here.")
+	assert.Contains(t, deep, "You spot a lever")
+	assert.Contains(t, deep, "You notice wolf tracks leading north.")
+
+	unperceptive := describe(DetailDeep, 0)
+	assert.Contains(t, unperceptive, "There is a statue here.")
+	assert.NotContains(t, unperceptive, "lever")
+	assert.NotContains(t, unperceptive, "tracks")
+}
+
+func TestDescribeTarget_CreatureCondition(t *testing.T) {
+	eco := ecosystem.NewService(0)
+	s := &LookService{ecosystemService: eco}
+	char := &auth.Character{WorldID: uuid.New(), PositionX: 10, PositionY: 10}
+
+	rabbit := eco.Spawner.CreateEntity(state.SpeciesRabbit, 1)
+	rabbit.WorldID = char.WorldID
+	rabbit.PositionX, rabbit.PositionY = 12, 10
+	rabbit.Logs = []state.DecisionLog{{Action: "Eat", Reason: "Hunger > 50"}}
+	eco.Entities[rabbit.EntityID] = rabbit
+
+	describe := func(level, perception int) string {
+		desc, err := s.DescribeTarget(context.Background(), DescribeContext{WorldID: char.WorldID, Character: char, DetailLevel: level, Perception: perception}, "rabbit")
+		require.NoError(t, err)
+		return desc
+	}
+
+	assert.NotContains(t, describe(DetailBasic, 100), "Hunger")
+	assert.Contains(t, describe(DetailDetailed, 100), "Hunger")
+	assert.Contains(t, describe(DetailDeep, 100), "trying to eat")
+	assert.NotContains(t, describe(DetailDeep, 0), "trying to eat")
+}
+
+func TestExamineLevel(t *testing.T) {
+	s := &LookService{}
+	char := &auth.Character{CharacterID: uuid.New(), PositionX: 1, PositionY: 1}
+
+	assert.Equal(t, DetailDetailed, s.ExamineLevel(char, "statue", DetailDetailed, 0))
+	assert.Equal(t, DetailDeep, s.ExamineLevel(char, "Statue", DetailDetailed, 0), "examining again looks deeper")
+	assert.Equal(t, DetailDeep, s.ExamineLevel(char, "statue", DetailDetailed, 0), "but no deeper than deep")
+
+	assert.Equal(t, DetailDetailed, s.ExamineLevel(char, "lever", DetailDetailed, 0), "a new target starts afresh")
+	char.PositionX = 2
+	assert.Equal(t, DetailDetailed, s.ExamineLevel(char, "lever", DetailDetailed, 0), "so does moving")
+
+	keen := &auth.Character{CharacterID: uuid.New()}
+	assert.Equal(t, DetailDeep, s.ExamineLevel(keen, "statue", DetailDetailed, skills.DifficultyHard), "a keen eye looks deeper at once")
+}
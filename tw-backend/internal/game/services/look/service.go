@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"tw-backend/internal/auth"
 	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
+	"tw-backend/internal/ecosystem/state"
 	"tw-backend/internal/game/services/entity"
 	"tw-backend/internal/repository"
+	"tw-backend/internal/world"
 	"tw-backend/internal/world/interview"
 	"tw-backend/internal/worldentity"
 	"tw-backend/internal/worldgen/orchestrator"
@@ -20,6 +24,9 @@ import (
 	"tw-backend/internal/game/formatter"
 )
 
+// BaseLookRadius is how far a character can see in full daylight
+const BaseLookRadius = 20.0
+
 // LookService definition
 type LookService struct {
 	worldRepo          repository.WorldRepository
@@ -34,8 +41,19 @@ type LookService struct {
 	worldCache    map[uuid.UUID]*orchestrator.GeneratedWorld
 	generator     *orchestrator.GeneratorService
 	interviewRepo InterviewRepository
+
+	// examinations tracks repeated examinations, which look deeper
+	examinations map[uuid.UUID]examination
+	// species finds simulated species' records; nil describes every creature
+	// as its built-in species
+	species SpeciesLookup
+	mu      sync.Mutex
 }
 
+// SpeciesLookup returns a world's record of a simulated species, with the
+// traits it has evolved
+type SpeciesLookup func(ctx context.Context, worldID, speciesID uuid.UUID) (*population.SpeciesPopulation, bool)
+
 // InterviewRepository interface (same as before to decouple)
 type InterviewRepository interface {
 	GetConfigurationByWorldID(ctx context.Context, worldID uuid.UUID) (*interview.WorldConfiguration, error)
@@ -62,15 +80,25 @@ func NewLookService(
 		authRepo:           authRepo,
 		worldCache:         make(map[uuid.UUID]*orchestrator.GeneratedWorld),
 		generator:          orchestrator.NewGeneratorService(),
+		examinations:       make(map[uuid.UUID]examination),
 	}
 }
 
+// SetSpeciesLookup sets how creatures of simulated species find their
+// species' record, whose traits describe them
+func (s *LookService) SetSpeciesLookup(lookup SpeciesLookup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.species = lookup
+}
+
 // DescribeContext holds all data needed for a look operation
 type DescribeContext struct {
 	WorldID     uuid.UUID
 	Character   *auth.Character
 	Orientation string // "North", "South", etc.
 	DetailLevel int    // 1=Basic, 2=Detailed, 3=Deep
+	Perception  int    // Perception skill level, which gates what a deep look finds
 }
 
 // Describe generates the description
@@ -93,8 +121,24 @@ func (s *LookService) Describe(ctx context.Context, dc DescribeContext) (string,
 		envDesc = s.generateEnvironmentDescription(ctx, dc.WorldID, genData, dc.Character)
 	}
 
+	// Darkness note (independent of cached world data)
+	if darkDesc := s.getDarknessDescription(dc.WorldID); darkDesc != "" {
+		if envDesc != "" {
+			envDesc += " "
+		}
+		envDesc += darkDesc
+	}
+
 	// 4. Get Entities (NPCs, Items)
-	entityDesc := s.generateEntityDescription(ctx, dc.WorldID, dc.Character)
+	entityDesc := s.generateEntityDescription(ctx, dc)
+
+	// 5. Get Clues (Tracks), on a deep look
+	if clueDesc := s.generateClueDescription(dc); clueDesc != "" {
+		if entityDesc != "" {
+			entityDesc += "\n"
+		}
+		entityDesc += clueDesc
+	}
 
 	// Combine
 	fullDesc := baseDesc
@@ -111,17 +155,26 @@ func (s *LookService) Describe(ctx context.Context, dc DescribeContext) (string,
 	return fullDesc, nil
 }
 
-// DescribeEntity generates a description for a specific target (self or entity)
+// DescribeEntity generates a basic description for a specific target (self or entity)
 func (s *LookService) DescribeEntity(ctx context.Context, char *auth.Character, targetName string) (string, error) {
+	return s.DescribeTarget(ctx, DescribeContext{WorldID: char.WorldID, Character: char, DetailLevel: DetailBasic}, targetName)
+}
+
+// DescribeTarget describes a specific target at the context's detail level
+func (s *LookService) DescribeTarget(ctx context.Context, dc DescribeContext, targetName string) (string, error) {
+	char := dc.Character
+
 	// 1. Check for Self
 	targetLower := strings.ToLower(targetName)
 	if targetLower == "self" || targetLower == "me" || targetLower == "myself" || strings.EqualFold(char.Name, targetName) {
 		return s.describeSelf(char), nil
 	}
 
+	radius := s.LookRadius(char.WorldID)
+
 	// 2. Check for Entities (in-memory)
 	if s.entityService != nil {
-		entities, err := s.entityService.GetEntitiesAt(ctx, char.WorldID, char.PositionX, char.PositionY, 20.0)
+		entities, err := s.entityService.GetEntitiesAt(ctx, char.WorldID, char.PositionX, char.PositionY, radius)
 		if err == nil {
 			for _, e := range entities {
 				if strings.EqualFold(e.Name, targetName) {
@@ -134,8 +187,8 @@ func (s *LookService) DescribeEntity(ctx context.Context, char *auth.Character,
 	// 3. Check for WorldEntity objects (database-persisted static objects)
 	if s.worldEntityService != nil {
 		worldEntity, err := s.worldEntityService.GetEntityByName(ctx, char.WorldID, targetName)
-		if err == nil && worldEntity != nil {
-			return s.describeWorldEntity(char, worldEntity), nil
+		if err == nil && worldEntity != nil && dc.notices(worldEntity) {
+			return s.describeWorldEntity(dc, worldEntity), nil
 		}
 	}
 
@@ -143,11 +196,11 @@ func (s *LookService) DescribeEntity(ctx context.Context, char *auth.Character,
 	if s.ecosystemService != nil {
 		// ecosystem entities don't have unique names yet, so we match by Species
 		// Get nearby entities
-		ecoEntities := s.ecosystemService.GetEntitiesAt(char.WorldID, char.PositionX, char.PositionY, 20.0)
+		ecoEntities := s.ecosystemService.GetEntitiesAt(char.WorldID, char.PositionX, char.PositionY, radius)
 		for _, e := range ecoEntities {
 			// Check if target name matches species (e.g. "rabbit")
 			if strings.EqualFold(string(e.Species), targetName) {
-				return fmt.Sprintf("You see a %s.\nIt looks healthy and alert.", e.Species), nil
+				return s.describeCreature(ctx, dc, e), nil
 			}
 		}
 	}
@@ -167,7 +220,7 @@ func (s *LookService) DescribeEntity(ctx context.Context, char *auth.Character,
 				dx := targetChar.PositionX - char.PositionX
 				dy := targetChar.PositionY - char.PositionY
 				distSq := dx*dx + dy*dy
-				if distSq <= 400 { // 20^2
+				if distSq <= radius*radius {
 					return s.describeCharacter(ctx, targetChar)
 				}
 			}
@@ -272,27 +325,61 @@ func (s *LookService) describeEntityObject(e *entity.Entity) string {
 }
 
 // describeWorldEntity describes a database-persisted world entity (static objects like statues)
-func (s *LookService) describeWorldEntity(char *auth.Character, e *worldentity.WorldEntity) string {
+func (s *LookService) describeWorldEntity(dc DescribeContext, e *worldentity.WorldEntity) string {
 	// Calculate distance to entity
-	dx := e.X - char.PositionX
-	dy := e.Y - char.PositionY
+	dx := e.X - dc.Character.PositionX
+	dy := e.Y - dc.Character.PositionY
 	distSq := dx*dx + dy*dy
 
 	// Format the name
 	name := formatter.Format(e.Name, formatter.StyleYellow)
 
-	// If very close (within 2 meters), show detailed description
-	if distSq <= 4 && e.Details != "" {
-		return fmt.Sprintf("%s\n\n%s", e.Description, e.Details)
+	desc := e.Description
+	if desc == "" {
+		// Fallback if no description
+		desc = fmt.Sprintf("You see %s here.", name)
 	}
 
-	// Otherwise just show the basic description
-	if e.Description != "" {
-		return e.Description
+	// If very close (within 2 meters) or looking closely, show detailed description
+	if e.Details != "" && (distSq <= 4 || dc.DetailLevel >= DetailDetailed) {
+		desc = fmt.Sprintf("%s\n\n%s", desc, e.Details)
+	}
+
+	// A deep look by a perceptive character finds what is hidden
+	if secret, _ := e.Metadata[MetaSecret].(string); secret != "" && dc.perceives(e) {
+		desc = fmt.Sprintf("%s\n\n%s", desc, secret)
+	}
+
+	return desc
+}
+
+// describeCreature describes an ecosystem creature, with its condition when
+// looking closely and its recent behaviour to a tracker
+func (s *LookService) describeCreature(ctx context.Context, dc DescribeContext, e *state.LivingEntityState) string {
+	desc := fmt.Sprintf("You see a %s.\n%s\nIt looks healthy and alert.", e.Species, s.appearance(ctx, e))
+	if dc.DetailLevel >= DetailDetailed {
+		desc += "\n" + describeCondition(e)
+	}
+	if dc.readsTracks() {
+		if behaviour := describeBehaviour(e); behaviour != "" {
+			desc += "\n" + behaviour
+		}
 	}
+	return desc
+}
 
-	// Fallback if no description
-	return fmt.Sprintf("You see %s here.", name)
+// appearance describes how a creature looks: from its simulated species'
+// evolved traits when it belongs to one, else from its built-in species
+func (s *LookService) appearance(ctx context.Context, e *state.LivingEntityState) string {
+	s.mu.Lock()
+	lookup := s.species
+	s.mu.Unlock()
+	if e.SpeciesID != nil && lookup != nil {
+		if sp, ok := lookup(ctx, e.WorldID, *e.SpeciesID); ok {
+			return ecosystem.DescribeSimulatedSpecies(sp)
+		}
+	}
+	return ecosystem.DescribeSpecies(e.Species)
 }
 
 // generateBaseDescription uses the world gen logic
@@ -377,11 +464,33 @@ func (s *LookService) getWeatherDescription(state *weather.WeatherState) string
 	}
 }
 
-func (s *LookService) generateEntityDescription(ctx context.Context, worldID uuid.UUID, char *auth.Character) string {
+// LookRadius returns how far a character can see in the world right now.
+// Characters have no night vision, so darkness shrinks the radius.
+func (s *LookService) LookRadius(worldID uuid.UUID) float64 {
+	if s.ecosystemService == nil {
+		return BaseLookRadius
+	}
+	return BaseLookRadius * world.VisibilityMultiplier(s.ecosystemService.LightLevel(worldID), 0)
+}
+
+func (s *LookService) getDarknessDescription(worldID uuid.UUID) string {
+	if s.ecosystemService == nil {
+		return ""
+	}
+	clock := s.ecosystemService.GetWorldClock(worldID)
+	if clock == nil || !clock.IsNight() {
+		return ""
+	}
+	return "It is night; darkness limits how far you can see."
+}
+
+func (s *LookService) generateEntityDescription(ctx context.Context, dc DescribeContext) string {
 	var descriptions []string
+	worldID, char := dc.WorldID, dc.Character
+	radius := s.LookRadius(worldID)
 
 	if s.entityService != nil {
-		entities, err := s.entityService.GetEntitiesAt(ctx, worldID, char.PositionX, char.PositionY, 20.0)
+		entities, err := s.entityService.GetEntitiesAt(ctx, worldID, char.PositionX, char.PositionY, radius)
 		if err == nil && len(entities) > 0 {
 			for _, e := range entities {
 				descriptions = append(descriptions, fmt.Sprintf("A %s is here.", e.Name))
@@ -390,15 +499,51 @@ func (s *LookService) generateEntityDescription(ctx context.Context, worldID uui
 	}
 
 	if s.ecosystemService != nil {
-		ecoEntities := s.ecosystemService.GetEntitiesAt(worldID, char.PositionX, char.PositionY, 20.0)
+		ecoEntities := s.ecosystemService.GetEntitiesAt(worldID, char.PositionX, char.PositionY, radius)
 		for _, e := range ecoEntities {
 			descriptions = append(descriptions, fmt.Sprintf("A %s is here.", e.Species))
 		}
 	}
 
+	// Looking closely picks out objects, and a deep look what is hidden
+	if s.worldEntityService != nil && dc.DetailLevel >= DetailDetailed {
+		worldEntities, err := s.worldEntityService.GetEntitiesAt(ctx, worldID, char.PositionX, char.PositionY, radius)
+		if err == nil {
+			for _, e := range worldEntities {
+				if hidden, _ := e.Metadata[MetaHidden].(bool); hidden {
+					if dc.notices(e) {
+						descriptions = append(descriptions, fmt.Sprintf("You spot a %s, hidden from casual eyes.", e.Name))
+					}
+					continue
+				}
+				descriptions = append(descriptions, fmt.Sprintf("There is a %s here.", e.Name))
+			}
+		}
+	}
+
 	return strings.Join(descriptions, "\n")
 }
 
+// generateClueDescription reads tracks left by creatures just out of sight,
+// for a perceptive character looking deeply
+func (s *LookService) generateClueDescription(dc DescribeContext) string {
+	if s.ecosystemService == nil || !dc.readsTracks() {
+		return ""
+	}
+	char := dc.Character
+	radius := s.LookRadius(dc.WorldID)
+
+	var clues []string
+	for _, e := range s.ecosystemService.GetEntitiesAt(dc.WorldID, char.PositionX, char.PositionY, radius*2) {
+		dx, dy := e.PositionX-char.PositionX, e.PositionY-char.PositionY
+		if dx*dx+dy*dy <= radius*radius {
+			continue // In sight, so already described
+		}
+		clues = append(clues, fmt.Sprintf("You notice %s tracks leading %s.", e.Species, compassDirection(dx, dy)))
+	}
+	return strings.Join(clues, "\n")
+}
+
 // Helper methods from old service
 
 func (s *LookService) getWorldData(ctx context.Context, worldID uuid.UUID, config *interview.WorldConfiguration) (*orchestrator.GeneratedWorld, error) {
@@ -3,6 +3,7 @@ package look
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -10,8 +11,10 @@ import (
 
 	"tw-backend/internal/auth"
 	"tw-backend/internal/ecosystem"
+	"tw-backend/internal/ecosystem/population"
 	"tw-backend/internal/ecosystem/state"
 	"tw-backend/internal/game/services/entity"
+	"tw-backend/internal/world"
 )
 
 func TestDescribeEntity_Self(t *testing.T) {
@@ -98,5 +101,70 @@ func TestDescribeEntity_Ecosystem(t *testing.T) {
 	desc, err := s.DescribeEntity(context.Background(), char, "Rabbit")
 	require.NoError(t, err)
 	assert.Contains(t, desc, "You see a rabbit.")
+	assert.Contains(t, desc, ecosystem.DescribeSpecies(state.SpeciesRabbit))
 	assert.Contains(t, desc, "healthy and alert")
 }
+
+func TestDescribeEntity_SimulatedSpeciesUsesItsTraits(t *testing.T) {
+	mockEcosystem := ecosystem.NewService(0)
+	s := NewLookService(nil, nil, nil, nil, nil, nil, mockEcosystem)
+
+	worldID := uuid.New()
+	char := &auth.Character{WorldID: worldID, PositionX: 10, PositionY: 10}
+
+	// A herbivore that has evolved into a huge armoured grazer
+	traits := population.DefaultTraitsForDiet(population.DietHerbivore)
+	traits.Size, traits.Covering = 9.0, population.CoveringShell
+	record := &population.SpeciesPopulation{SpeciesID: uuid.New(), Name: "Shellback", Diet: population.DietHerbivore, Traits: traits}
+	s.SetSpeciesLookup(func(_ context.Context, w, id uuid.UUID) (*population.SpeciesPopulation, bool) {
+		return record, w == worldID && id == record.SpeciesID
+	})
+
+	e := mockEcosystem.Spawner.CreateFromSpecies(record)
+	e.WorldID, e.PositionX, e.PositionY = worldID, 12, 10
+	mockEcosystem.Entities[e.EntityID] = e
+
+	desc, err := s.DescribeEntity(context.Background(), char, "Shellback")
+	require.NoError(t, err)
+	assert.Contains(t, desc, "You see a Shellback.")
+	assert.Contains(t, desc, ecosystem.DescribeSimulatedSpecies(record))
+
+	// As the species evolves, so does how its creatures look
+	before := desc
+	record.Traits.Covering = population.CoveringFur
+	desc, err = s.DescribeEntity(context.Background(), char, "Shellback")
+	require.NoError(t, err)
+	assert.NotEqual(t, before, desc)
+	assert.Contains(t, desc, ecosystem.DescribeSimulatedSpecies(record))
+}
+
+func TestLookRadius_ShrinksAtNight(t *testing.T) {
+	eco := ecosystem.NewService(0)
+	s := &LookService{ecosystemService: eco}
+	worldID := uuid.New()
+
+	// No clock: always daylight
+	assert.InDelta(t, BaseLookRadius, s.LookRadius(worldID), 0.001)
+
+	clock := world.NewClock(24*time.Hour, time.Minute)
+	eco.SetWorldClock(worldID, clock)
+	clock.Set(12 * time.Hour)
+	assert.InDelta(t, BaseLookRadius, s.LookRadius(worldID), 0.001, "Full radius at noon")
+
+	clock.Set(0)
+	assert.Less(t, s.LookRadius(worldID), BaseLookRadius, "Radius shrinks at midnight")
+
+	// A rabbit 10m away is visible by day but lost in the dark
+	rabbit := eco.Spawner.CreateEntity(state.SpeciesRabbit, 1)
+	rabbit.WorldID = worldID
+	rabbit.PositionX = 10
+	eco.Entities[rabbit.EntityID] = rabbit
+	char := &auth.Character{WorldID: worldID}
+
+	_, err := s.DescribeEntity(context.Background(), char, "rabbit")
+	assert.Error(t, err, "Rabbit is out of sight at night")
+
+	clock.Set(12 * time.Hour)
+	_, err = s.DescribeEntity(context.Background(), char, "rabbit")
+	assert.NoError(t, err, "Rabbit is visible at noon")
+}
@@ -14,7 +14,9 @@ import (
 	"tw-backend/internal/game/services/look"
 	"tw-backend/internal/repository"
 	"tw-backend/internal/skills"
+	"tw-backend/internal/spatial"
 	"tw-backend/internal/worldentity"
+	"tw-backend/internal/worldgen/geography"
 	"tw-backend/internal/worldgen/orchestrator"
 
 	"github.com/google/uuid"
@@ -194,15 +196,16 @@ func (s *Service) GetMapData(ctx context.Context, char *auth.Character) (*MapDat
 
 	// Get world bounds for boundary checking
 	var minX, minY, maxX, maxY float64 = 0, 0, 10, 10 // Default lobby bounds
+	var circumference float64                         // Set for spherical worlds, which wrap
 	if s.worldRepo != nil {
 		world, err := s.worldRepo.GetWorld(ctx, char.WorldID)
 		if err == nil && world != nil {
 			// Check for spherical world first (has Circumference but no BoundsMin/Max)
 			if world.Circumference != nil && *world.Circumference > 0 {
-				// Spherical world: longitude wraps around, latitude is half
-				minX, minY = 0, 0
-				maxX = *world.Circumference
-				maxY = *world.Circumference / 2
+				// Spherical world: longitude wraps around, latitude spans pole to pole
+				circumference = *world.Circumference
+				minX, minY = 0, -circumference/4
+				maxX, maxY = circumference, circumference/4
 			} else if world.BoundsMin != nil && world.BoundsMax != nil {
 				// Bounded world
 				minX, minY = world.BoundsMin.X, world.BoundsMin.Y
@@ -261,9 +264,17 @@ func (s *Service) GetMapData(ctx context.Context, char *auth.Character) (*MapDat
 			tileX := int(math.Round(char.PositionX)) + (dx * stride)
 			tileY := int(math.Round(char.PositionY)) + (dy * stride)
 
+			// Where the tile lies on the world. On a sphere, tiles past the
+			// meridian or a pole show the far side; tileX/tileY stay continuous
+			// around the player for rendering.
+			worldX, worldY := float64(tileX), float64(tileY)
+			if circumference > 0 {
+				worldX, worldY, _, _ = spatial.WrapSurfacePosition(worldX, worldY, circumference)
+			}
+
 			// Check if tile is out of bounds
-			outOfBounds := float64(tileX) < minX || float64(tileX) > maxX ||
-				float64(tileY) < minY || float64(tileY) > maxY
+			outOfBounds := worldX < minX || worldX > maxX ||
+				worldY < minY || worldY > maxY
 
 			tile := &MapTile{
 				X:           tileX,
@@ -283,7 +294,7 @@ func (s *Service) GetMapData(ctx context.Context, char *auth.Character) (*MapDat
 			if hasWorldData && worldData.Geography != nil {
 				hm := worldData.Geography.Heightmap
 				// Convert world coordinates to heightmap grid indices
-				gridX, gridY := worldToGrid(float64(tileX), float64(tileY), minX, minY, maxX, maxY, hm.Width, hm.Height)
+				gridX, gridY := worldToGrid(worldX, worldY, minX, minY, maxX, maxY, hm.Width, hm.Height)
 
 				tile.Elevation = hm.Get(gridX, gridY)
 
@@ -297,7 +308,7 @@ func (s *Service) GetMapData(ctx context.Context, char *auth.Character) (*MapDat
 				hm := geo.Heightmap
 				if hm != nil {
 					// Convert world coordinates to heightmap grid indices
-					gridX, gridY := worldToGrid(float64(tileX), float64(tileY), minX, minY, maxX, maxY, hm.Width, hm.Height)
+					gridX, gridY := worldToGrid(worldX, worldY, minX, minY, maxX, maxY, hm.Width, hm.Height)
 
 					tile.Elevation = hm.Get(gridX, gridY)
 
@@ -314,7 +325,7 @@ func (s *Service) GetMapData(ctx context.Context, char *auth.Character) (*MapDat
 				// Use stride as search radius? No, just look at the specific tile point.
 				// For high altitude, we might miss entities if they aren't exactly on the sampled tile.
 				// But aggregating entities is complex. For now, just show ents at sample points.
-				entities, err := s.entityService.GetEntitiesAt(ctx, char.WorldID, float64(tileX), float64(tileY), 1.0)
+				entities, err := s.entityService.GetEntitiesAt(ctx, char.WorldID, worldX, worldY, 1.0)
 				if err == nil && len(entities) > 0 {
 					for _, e := range entities {
 						tile.Entities = append(tile.Entities, MapEntity{
@@ -329,7 +340,7 @@ func (s *Service) GetMapData(ctx context.Context, char *auth.Character) (*MapDat
 			// Get world entities at this tile (database-backed static objects)
 			// Use 0.5 radius to only match entities at this exact tile position
 			if s.worldEntityService != nil {
-				worldEntities, err := s.worldEntityService.GetEntitiesAt(ctx, char.WorldID, float64(tileX), float64(tileY), 0.5)
+				worldEntities, err := s.worldEntityService.GetEntitiesAt(ctx, char.WorldID, worldX, worldY, 0.5)
 				if err == nil && len(worldEntities) > 0 {
 					for _, we := range worldEntities {
 						tile.Entities = append(tile.Entities, MapEntity{
@@ -344,7 +355,7 @@ func (s *Service) GetMapData(ctx context.Context, char *auth.Character) (*MapDat
 
 			// Get ecosystem entities (living creatures)
 			if s.ecosystemService != nil {
-				ecoEntities := s.ecosystemService.GetEntitiesAt(char.WorldID, float64(tileX), float64(tileY), 0.5)
+				ecoEntities := s.ecosystemService.GetEntitiesAt(char.WorldID, worldX, worldY, 0.5)
 				for _, e := range ecoEntities {
 					glyph := "❓"
 					switch e.Species {
@@ -518,12 +529,25 @@ func (s *Service) computeOcclusion(grid [][]*MapTile, radius int, playerAlt floa
 // GetWorldMapData returns aggregated world map data for full world display
 // The world is divided into a grid of regions, each with a dominant biome
 func (s *Service) GetWorldMapData(ctx context.Context, char *auth.Character, gridSize int) (*WorldMapData, error) {
+	return s.GetWorldMapDataWithOptions(ctx, char, gridSize, DefaultWorldMapOptions())
+}
+
+// GetWorldMapDataWithOptions returns world map data with the given biome and
+// elevation aggregation; empty options fall back to the defaults
+func (s *Service) GetWorldMapDataWithOptions(ctx context.Context, char *auth.Character, gridSize int, opts WorldMapOptions) (*WorldMapData, error) {
 	if gridSize <= 0 {
 		gridSize = 64 // Default to 64x64 grid
 	}
+	defaults := DefaultWorldMapOptions()
+	if opts.Biome == "" {
+		opts.Biome = defaults.Biome
+	}
+	if opts.Elevation == "" {
+		opts.Elevation = defaults.Elevation
+	}
 
 	// Check cache first
-	cacheKey := fmt.Sprintf("%s:%d", char.WorldID, gridSize)
+	cacheKey := fmt.Sprintf("%s:%d:%s:%s", char.WorldID, gridSize, opts.Biome, opts.Elevation)
 	if cached, ok := s.worldMapCache.Load(cacheKey); ok {
 		if data, ok := cached.(*WorldMapData); ok {
 			// Copy-on-Read: Create shallow copy to avoid mutating shared cache.
@@ -541,13 +565,17 @@ func (s *Service) GetWorldMapData(ctx context.Context, char *auth.Character, gri
 	}
 
 	// Determine world bounds
-	var worldWidth, worldHeight float64
+	var minX, minY, worldWidth, worldHeight float64
+	spherical := false
 	if world.Circumference != nil && *world.Circumference > 0 {
 		// Spherical world
+		spherical = true
 		worldWidth = *world.Circumference
 		worldHeight = *world.Circumference / 2 // -90 to +90 degrees = half circumference
+		minY = -worldHeight / 2
 	} else if world.BoundsMin != nil && world.BoundsMax != nil {
 		// Bounded world
+		minX, minY = world.BoundsMin.X, world.BoundsMin.Y
 		worldWidth = world.BoundsMax.X - world.BoundsMin.X
 		worldHeight = world.BoundsMax.Y - world.BoundsMin.Y
 	} else {
@@ -578,15 +606,15 @@ func (s *Service) GetWorldMapData(ctx context.Context, char *auth.Character, gri
 	geo := s.getWorldGeology(char.WorldID)
 
 	tiles := make([]WorldMapTile, 0, gridCols*gridRows)
-	playerGridX := int(char.PositionX / regionWidth)
-	playerGridY := int(char.PositionY / regionHeight)
+	playerGridX := int((char.PositionX - minX) / regionWidth)
+	playerGridY := int((char.PositionY - minY) / regionHeight)
 
 	// Generate aggregated tiles
 	for gy := 0; gy < gridRows; gy++ {
 		for gx := 0; gx < gridCols; gx++ {
 			// Calculate center of this region in world coordinates
-			centerX := (float64(gx) + 0.5) * regionWidth
-			centerY := (float64(gy) + 0.5) * regionHeight
+			centerX := minX + (float64(gx)+0.5)*regionWidth
+			centerY := minY + (float64(gy)+0.5)*regionHeight
 
 			biome := "default"
 			elevation := 0.0
@@ -596,10 +624,8 @@ func (s *Service) GetWorldMapData(ctx context.Context, char *auth.Character, gri
 				hm := geo.Heightmap
 				if hm.Width > 0 && hm.Height > 0 {
 					// Convert grid position to heightmap indices
-					hmX, hmY := worldToGrid(centerX, centerY, 0, 0, worldWidth, worldHeight, hm.Width, hm.Height)
+					hmX, hmY := worldToGrid(centerX, centerY, minX, minY, minX+worldWidth, minY+worldHeight, hm.Width, hm.Height)
 					if hmX >= 0 && hmX < hm.Width && hmY >= 0 && hmY < hm.Height {
-						elevation = hm.Get(hmX, hmY)
-
 						// Calculate sample radius for aggregation based on zoom level
 						// Higher zoom-out = larger regions to aggregate
 						sampleRadius := 0
@@ -610,9 +636,9 @@ func (s *Service) GetWorldMapData(ctx context.Context, char *auth.Character, gri
 							}
 						}
 
-						// Use weighted aggregation if zoomed out, else single point
-						if sampleRadius > 0 {
-							biome = aggregateRegionBiome(geo, hmX, hmY, sampleRadius)
+						// Aggregate if zoomed out, else single point
+						if sampleRadius > 0 && opts.Biome != BiomeCenter {
+							biome = aggregateRegionBiome(geo, hmX, hmY, sampleRadius, spherical, opts.Biome)
 						} else {
 							// Direct lookup for 1:1 or zoomed in
 							idx := hmY*hm.Width + hmX
@@ -620,6 +646,11 @@ func (s *Service) GetWorldMapData(ctx context.Context, char *auth.Character, gri
 								biome = string(geo.Biomes[idx].Type)
 							}
 						}
+						if sampleRadius > 0 && opts.Elevation != ElevationCenter {
+							elevation = aggregateRegionElevation(hm, hmX, hmY, sampleRadius, spherical, opts.Elevation)
+						} else {
+							elevation = hm.Get(hmX, hmY)
+						}
 					}
 				}
 			}
@@ -646,6 +677,9 @@ func (s *Service) GetWorldMapData(ctx context.Context, char *auth.Character, gri
 		WorldID:     char.WorldID,
 		WorldName:   world.Name,
 		IsSimulated: geo != nil && geo.IsInitialized(),
+
+		BiomeAggregation:     opts.Biome,
+		ElevationAggregation: opts.Elevation,
 	}
 
 	// Add simulation summary data if available
@@ -719,49 +753,66 @@ func (s *Service) GetWorldMapData(ctx context.Context, char *auth.Character, gri
 	return result, nil
 }
 
-// aggregateRegionBiome determines the dominant biome in a region with weighted voting
-// Water biomes get 1.5x weight to preserve coastlines during zoom-out
-// This prevents thin coastal strips from disappearing when the world is aggregated
-func aggregateRegionBiome(geo *ecosystem.WorldGeology, hmX, hmY, sampleRadius int) string {
-	if geo == nil || geo.Heightmap == nil {
-		return "default"
-	}
-
-	hm := geo.Heightmap
-	votes := make(map[string]float64)
-
-	// Sample all cells in the region
+// forEachRegionCell calls fn with the heightmap index of every cell within
+// sampleRadius of (hmX, hmY). On spherical worlds, samples wrap across the
+// meridian and over the poles; otherwise cells outside the map are skipped.
+func forEachRegionCell(hm *geography.Heightmap, hmX, hmY, sampleRadius int, spherical bool, fn func(idx int)) {
 	for dy := -sampleRadius; dy <= sampleRadius; dy++ {
 		for dx := -sampleRadius; dx <= sampleRadius; dx++ {
 			x := hmX + dx
 			y := hmY + dy
 
+			if spherical {
+				// Past a pole: reflect the row onto the opposite meridian
+				if y < 0 {
+					y = -y - 1
+					x += hm.Width / 2
+				} else if y >= hm.Height {
+					y = 2*hm.Height - y - 1
+					x += hm.Width / 2
+				}
+				x = ((x % hm.Width) + hm.Width) % hm.Width
+			}
+
 			// Bounds check
 			if x < 0 || x >= hm.Width || y < 0 || y >= hm.Height {
 				continue
 			}
+			fn(y*hm.Width + x)
+		}
+	}
+}
 
-			idx := y*hm.Width + x
-			if idx >= 0 && idx < len(geo.Biomes) {
-				biome := string(geo.Biomes[idx].Type)
+// aggregateRegionBiome determines the most common biome in a region.
+// In BiomeDominant mode water biomes get 1.5x weight to preserve coastlines
+// during zoom-out, so thin coastal strips don't disappear when the world is
+// aggregated; BiomeMode counts every cell equally.
+func aggregateRegionBiome(geo *ecosystem.WorldGeology, hmX, hmY, sampleRadius int, spherical bool, mode BiomeAggregation) string {
+	if geo == nil || geo.Heightmap == nil {
+		return "default"
+	}
 
-				// Water biomes get 1.5x weight to preserve coastlines
-				// Use case-insensitive comparison for biome type matching
-				weight := 1.0
-				biomeLower := strings.ToLower(biome)
-				if biomeLower == "ocean" {
-					weight = 1.5
-				}
-				votes[biome] += weight
-			}
+	votes := make(map[string]float64)
+	forEachRegionCell(geo.Heightmap, hmX, hmY, sampleRadius, spherical, func(idx int) {
+		if idx >= len(geo.Biomes) {
+			return
 		}
-	}
+		biome := string(geo.Biomes[idx].Type)
 
-	// Find biome with highest weighted vote
+		// Use case-insensitive comparison for biome type matching
+		weight := 1.0
+		if mode == BiomeDominant && strings.ToLower(biome) == "ocean" {
+			weight = 1.5
+		}
+		votes[biome] += weight
+	})
+
+	// Find biome with highest weighted vote; ties go to the name sorting first
+	// so the same terrain always renders the same
 	maxVote := 0.0
 	dominant := "default"
 	for biome, vote := range votes {
-		if vote > maxVote {
+		if vote > maxVote || (vote == maxVote && biome < dominant) {
 			maxVote = vote
 			dominant = biome
 		}
@@ -769,3 +820,30 @@ func aggregateRegionBiome(geo *ecosystem.WorldGeology, hmX, hmY, sampleRadius in
 
 	return dominant
 }
+
+// aggregateRegionElevation reduces a region's elevations to their mean,
+// minimum, or maximum
+func aggregateRegionElevation(hm *geography.Heightmap, hmX, hmY, sampleRadius int, spherical bool, mode ElevationAggregation) float64 {
+	var sum float64
+	count := 0
+	minElev, maxElev := math.Inf(1), math.Inf(-1)
+	forEachRegionCell(hm, hmX, hmY, sampleRadius, spherical, func(idx int) {
+		elev := hm.Elevations[idx]
+		sum += elev
+		count++
+		minElev = math.Min(minElev, elev)
+		maxElev = math.Max(maxElev, elev)
+	})
+	if count == 0 {
+		return hm.Get(hmX, hmY)
+	}
+
+	switch mode {
+	case ElevationMin:
+		return minElev
+	case ElevationMax:
+		return maxElev
+	default:
+		return sum / float64(count)
+	}
+}
@@ -1,6 +1,9 @@
 package gamemap
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/google/uuid"
 )
 
@@ -66,13 +69,65 @@ type MapData struct {
 	IsSimulated   bool          `json:"is_simulated"` // False for lobby/unsimulated worlds
 }
 
+// BiomeAggregation selects how a world map tile's biome is chosen from the
+// heightmap cells it covers
+type BiomeAggregation string
+
+const (
+	BiomeDominant BiomeAggregation = "dominant" // Most common, water weighted 1.5x to keep coastlines
+	BiomeMode     BiomeAggregation = "mode"     // Most common, unweighted
+	BiomeCenter   BiomeAggregation = "center"   // The cell at the region's center
+)
+
+// ElevationAggregation selects how a world map tile's elevation is computed
+// from the heightmap cells it covers
+type ElevationAggregation string
+
+const (
+	ElevationCenter ElevationAggregation = "center" // The cell at the region's center
+	ElevationMean   ElevationAggregation = "mean"
+	ElevationMin    ElevationAggregation = "min"
+	ElevationMax    ElevationAggregation = "max"
+)
+
+// WorldMapOptions controls how heightmap cells are aggregated into world map tiles
+type WorldMapOptions struct {
+	Biome     BiomeAggregation
+	Elevation ElevationAggregation
+}
+
+// DefaultWorldMapOptions returns dominant-biome, center-sampled elevation
+func DefaultWorldMapOptions() WorldMapOptions {
+	return WorldMapOptions{Biome: BiomeDominant, Elevation: ElevationCenter}
+}
+
+// ParseBiomeAggregation validates a biome aggregation name
+func ParseBiomeAggregation(s string) (BiomeAggregation, error) {
+	switch mode := BiomeAggregation(strings.ToLower(s)); mode {
+	case BiomeDominant, BiomeMode, BiomeCenter:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown biome aggregation %q (want dominant, mode, or center)", s)
+	}
+}
+
+// ParseElevationAggregation validates an elevation aggregation name
+func ParseElevationAggregation(s string) (ElevationAggregation, error) {
+	switch mode := ElevationAggregation(strings.ToLower(s)); mode {
+	case ElevationCenter, ElevationMean, ElevationMin, ElevationMax:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown elevation aggregation %q (want center, mean, min, or max)", s)
+	}
+}
+
 // WorldMapTile represents an aggregated tile for the full world map
 // Each tile represents a region of the world (e.g., 100x100 world units)
 type WorldMapTile struct {
 	GridX        int     `json:"grid_x"`              // Grid X position (0-based)
 	GridY        int     `json:"grid_y"`              // Grid Y position (0-based)
 	Biome        string  `json:"biome"`               // Dominant biome in this region
-	AvgElevation float64 `json:"avg_elevation"`       // Average elevation
+	AvgElevation float64 `json:"avg_elevation"`       // Elevation, aggregated per WorldMapOptions
 	IsPlayer     bool    `json:"is_player,omitempty"` // Player is in this region
 }
 
@@ -89,6 +144,9 @@ type WorldMapData struct {
 	WorldName   string         `json:"world_name,omitempty"`
 	IsSimulated bool           `json:"is_simulated"` // False for lobby/unsimulated worlds
 
+	BiomeAggregation     BiomeAggregation     `json:"biome_aggregation"`
+	ElevationAggregation ElevationAggregation `json:"elevation_aggregation"`
+
 	// Simulation summary data (populated after simulation)
 	AvgTemperature float64 `json:"avg_temperature,omitempty"` // Average temperature in Celsius
 	MaxElevation   float64 `json:"max_elevation,omitempty"`   // Maximum elevation in meters
@@ -586,6 +586,182 @@ func TestBDD_WorldMap_PortalDisplay(t *testing.T) {
 	assert.True(t, portal.Active)
 }
 
+// -----------------------------------------------------------------------------
+// Scenario: Spherical Wraparound
+// -----------------------------------------------------------------------------
+// Given: A spherical world and a player near the meridian or a pole
+// When: The mini-map is generated
+// Then: Tiles past the edge show the far side of the globe instead of void
+func TestBDD_WorldMap_SphericalWraparound(t *testing.T) {
+	// Circumference 1000: X in [0, 1000), poles at Y = ±250
+	mockRepo := &MockWorldRepo{
+		World: &repository.World{
+			ID:            uuid.New(),
+			Name:          "Round World",
+			Circumference: floatPtr(1000.0),
+		},
+	}
+	svc := gamemap.NewService(mockRepo, nil, nil, nil, nil, nil)
+
+	// 10x10 geology: the last column is desert, the middle column ocean
+	hm := &geography.Heightmap{Width: 10, Height: 10, Elevations: make([]float64, 100)}
+	biomes := make([]geography.Biome, 100)
+	for i := range biomes {
+		switch i % 10 {
+		case 9:
+			biomes[i] = geography.Biome{Type: geography.BiomeDesert}
+		case 5:
+			biomes[i] = geography.Biome{Type: geography.BiomeOcean}
+		default:
+			biomes[i] = geography.Biome{Type: geography.BiomeGrassland}
+		}
+	}
+	svc.SetWorldGeology(mockRepo.World.ID, &ecosystem.WorldGeology{Heightmap: hm, Biomes: biomes})
+
+	tileAt := func(data *gamemap.MapData, x, y int) *gamemap.MapTile {
+		for i := range data.Tiles {
+			if data.Tiles[i].X == x && data.Tiles[i].Y == y {
+				return &data.Tiles[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("West of the meridian", func(t *testing.T) {
+		char := &auth.Character{CharacterID: uuid.New(), WorldID: mockRepo.World.ID, PositionX: 2, PositionY: 0}
+		data, err := svc.GetMapData(context.Background(), char)
+		require.NoError(t, err)
+
+		for _, tile := range data.Tiles {
+			assert.False(t, tile.OutOfBounds, "Tile (%d, %d) should wrap, not be void", tile.X, tile.Y)
+		}
+		west := tileAt(data, -2, 0)
+		require.NotNil(t, west)
+		assert.Equal(t, string(geography.BiomeDesert), west.Biome, "X=-2 is X=998 on the far side of the meridian")
+	})
+
+	t.Run("Over the north pole", func(t *testing.T) {
+		char := &auth.Character{CharacterID: uuid.New(), WorldID: mockRepo.World.ID, PositionX: 2, PositionY: 248}
+		data, err := svc.GetMapData(context.Background(), char)
+		require.NoError(t, err)
+
+		for _, tile := range data.Tiles {
+			assert.False(t, tile.OutOfBounds, "Tile (%d, %d) should wrap, not be void", tile.X, tile.Y)
+		}
+		here := tileAt(data, 2, 248)
+		beyond := tileAt(data, 2, 252)
+		require.NotNil(t, here)
+		require.NotNil(t, beyond)
+		assert.Equal(t, string(geography.BiomeGrassland), here.Biome)
+		assert.Equal(t, string(geography.BiomeOcean), beyond.Biome, "Past the pole lies the opposite meridian (X=502)")
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Scenario: Configurable Aggregation
+// -----------------------------------------------------------------------------
+// Given: Varied terrain where each world map tile covers a 3x3 block of cells
+// When: The world map is requested with different aggregation options
+// Then: Biome and elevation are summarized per the chosen mode
+func TestBDD_WorldMap_AggregationModes(t *testing.T) {
+	mockRepo := &MockWorldRepo{
+		World: &repository.World{
+			ID:        uuid.New(),
+			Name:      "Patchwork World",
+			BoundsMin: &repository.Vector3{X: 0, Y: 0},
+			BoundsMax: &repository.Vector3{X: 1000, Y: 1000},
+		},
+	}
+	svc := gamemap.NewService(mockRepo, nil, nil, nil, nil, nil)
+
+	// 30x30 heightmap, 10x10 grid: each tile samples the 3x3 block around
+	// its center. Every block reads (column within block):
+	//   Ocean  Grassland Grassland
+	//   Ocean  Desert    Grassland   <- Desert is the center cell
+	//   Ocean  Grassland Grassland
+	// and elevation rises with the square of the column: 0, 100, 400
+	const hmSize = 30
+	hm := &geography.Heightmap{Width: hmSize, Height: hmSize, Elevations: make([]float64, hmSize*hmSize)}
+	biomes := make([]geography.Biome, hmSize*hmSize)
+	for y := 0; y < hmSize; y++ {
+		for x := 0; x < hmSize; x++ {
+			i := y*hmSize + x
+			col := x % 3
+			switch {
+			case col == 0:
+				biomes[i] = geography.Biome{Type: geography.BiomeOcean}
+			case col == 1 && y%3 == 1:
+				biomes[i] = geography.Biome{Type: geography.BiomeDesert}
+			default:
+				biomes[i] = geography.Biome{Type: geography.BiomeGrassland}
+			}
+			hm.Elevations[i] = float64(col*col) * 100
+		}
+	}
+	svc.SetWorldGeology(mockRepo.World.ID, &ecosystem.WorldGeology{Heightmap: hm, Biomes: biomes})
+	char := &auth.Character{CharacterID: uuid.New(), WorldID: mockRepo.World.ID}
+
+	mapWith := func(opts gamemap.WorldMapOptions) *gamemap.WorldMapData {
+		data, err := svc.GetWorldMapDataWithOptions(context.Background(), char, 10, opts)
+		require.NoError(t, err)
+		require.Len(t, data.Tiles, 100)
+		return data
+	}
+
+	t.Run("Dominant and mode pick the most common biome", func(t *testing.T) {
+		for _, mode := range []gamemap.BiomeAggregation{gamemap.BiomeDominant, gamemap.BiomeMode} {
+			data := mapWith(gamemap.WorldMapOptions{Biome: mode})
+			assert.Equal(t, mode, data.BiomeAggregation)
+			for _, tile := range data.Tiles {
+				assert.Equal(t, string(geography.BiomeGrassland), tile.Biome,
+					"%s: 5 grassland cells outvote 3 ocean (even weighted) and 1 desert", mode)
+			}
+		}
+	})
+
+	t.Run("Center samples the middle cell", func(t *testing.T) {
+		data := mapWith(gamemap.WorldMapOptions{Biome: gamemap.BiomeCenter})
+		for _, tile := range data.Tiles {
+			assert.Equal(t, string(geography.BiomeDesert), tile.Biome)
+		}
+	})
+
+	t.Run("Mean elevation differs from the center sample", func(t *testing.T) {
+		center := mapWith(gamemap.WorldMapOptions{Elevation: gamemap.ElevationCenter})
+		mean := mapWith(gamemap.WorldMapOptions{Elevation: gamemap.ElevationMean})
+		lowest := mapWith(gamemap.WorldMapOptions{Elevation: gamemap.ElevationMin})
+		highest := mapWith(gamemap.WorldMapOptions{Elevation: gamemap.ElevationMax})
+
+		for i := range center.Tiles {
+			assert.InDelta(t, 100.0, center.Tiles[i].AvgElevation, 1e-9)
+			assert.InDelta(t, 500.0/3, mean.Tiles[i].AvgElevation, 1e-9)
+			assert.InDelta(t, 0.0, lowest.Tiles[i].AvgElevation, 1e-9)
+			assert.InDelta(t, 400.0, highest.Tiles[i].AvgElevation, 1e-9)
+		}
+	})
+
+	t.Run("Default options", func(t *testing.T) {
+		data, err := svc.GetWorldMapData(context.Background(), char, 10)
+		require.NoError(t, err)
+		assert.Equal(t, gamemap.BiomeDominant, data.BiomeAggregation)
+		assert.Equal(t, gamemap.ElevationCenter, data.ElevationAggregation)
+	})
+}
+
+func TestParseAggregationModes(t *testing.T) {
+	biome, err := gamemap.ParseBiomeAggregation("Mode")
+	require.NoError(t, err)
+	assert.Equal(t, gamemap.BiomeMode, biome)
+	_, err = gamemap.ParseBiomeAggregation("average")
+	assert.Error(t, err)
+
+	elev, err := gamemap.ParseElevationAggregation("max")
+	require.NoError(t, err)
+	assert.Equal(t, gamemap.ElevationMax, elev)
+	_, err = gamemap.ParseElevationAggregation("median")
+	assert.Error(t, err)
+}
+
 // =============================================================================
 // Mock Implementations
 // =============================================================================
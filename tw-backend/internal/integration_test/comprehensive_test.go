@@ -112,7 +112,7 @@ func TestComprehensiveIntegration(t *testing.T) {
 	defer redisClient.Close()
 
 	// 2. Setup Services
-	sessionManager := auth.NewSessionManager(redisClient)
+	sessionManager := auth.NewSessionManager(redisClient, auth.DefaultSessionPolicy())
 	rateLimiter := auth.NewRateLimiter(redisClient)
 
 	authRepo := auth.NewMockRepository()
@@ -175,7 +175,7 @@ func TestComprehensiveIntegration(t *testing.T) {
 
 		// Broadcast to area 1
 		start := time.Now()
-		hub.BroadcastToArea(center1, 100.0, "area_msg", map[string]string{"msg": "hello area 1"})
+		hub.BroadcastToArea(uuid.Nil, center1, 100.0, "area_msg", map[string]string{"msg": "hello area 1"})
 		duration := time.Since(start)
 
 		t.Logf("Broadcast to area 1 took %v", duration)
@@ -183,7 +183,7 @@ func TestComprehensiveIntegration(t *testing.T) {
 
 		// Broadcast to area 2
 		start = time.Now()
-		hub.BroadcastToArea(center2, 100.0, "area_msg", map[string]string{"msg": "hello area 2"})
+		hub.BroadcastToArea(uuid.Nil, center2, 100.0, "area_msg", map[string]string{"msg": "hello area 2"})
 		duration = time.Since(start)
 
 		t.Logf("Broadcast to area 2 took %v", duration)
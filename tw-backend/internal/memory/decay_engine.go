@@ -8,13 +8,14 @@ import (
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog/log"
 
+	"tw-backend/internal/nats/subjects"
 	"tw-backend/internal/repository"
 )
 
 const (
-	BaseDecayRate      = 0.05 // 5% per day? Adjust as needed.
+	BaseDecayRate       = 0.05 // 5% per day? Adjust as needed.
 	ImportanceThreshold = 0.2
-	SummaryTemplate    = "Memory faded into a vague recollection."
+	SummaryTemplate     = "Memory faded into a vague recollection."
 )
 
 type DecayEngine struct {
@@ -36,7 +37,9 @@ type DayTick struct {
 
 // StartDecayJob listens for the daily tick and runs the decay simulation.
 func (e *DecayEngine) StartDecayJob(ctx context.Context) error {
-	_, err := e.nc.Subscribe("world.tick.day", func(msg *nats.Msg) {
+	// One decay cycle per tick, however many engine instances are running
+	queue := subjects.QueueGroup(subjects.MemoryDecayQueue)
+	_, err := e.nc.QueueSubscribe(subjects.WorldTickDay, queue, func(msg *nats.Msg) {
 		var tick DayTick
 		if err := json.Unmarshal(msg.Data, &tick); err != nil {
 			log.Error().Err(err).Msg("DecayEngine: failed to unmarshal tick")
@@ -0,0 +1,71 @@
+// Package broadcast defines the payloads the world service publishes for
+// game servers to deliver to players
+package broadcast
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tw-backend/internal/spatial"
+
+	"github.com/google/uuid"
+)
+
+// MessageTypeGameMessage is the websocket message type game servers show
+// to players as text
+const MessageTypeGameMessage = "game_message"
+
+// GameMessage is the payload of a game_message, as the game server's
+// websocket.GameMessageData encodes it
+type GameMessage struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"` // e.g. "weather", "system"
+	Text      string    `json:"text"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewGameMessage builds a game message of msgType with the given text
+func NewGameMessage(msgType, text string) GameMessage {
+	return GameMessage{ID: uuid.New().String(), Type: msgType, Text: text, Timestamp: time.Now()}
+}
+
+// AreaBroadcast is published on subjects.WorldBroadcastArea. Game servers
+// deliver it to every player in the world within Radius of Center.
+type AreaBroadcast struct {
+	WorldID uuid.UUID        `json:"world_id"`
+	Center  spatial.Position `json:"center"`
+	Radius  float64          `json:"radius"`
+	Type    string           `json:"type"` // Websocket message type, e.g. "game_message"
+	Data    json.RawMessage  `json:"data"` // Websocket message payload, passed through as is
+}
+
+// NewAreaBroadcast builds a broadcast of data, which must marshal to JSON
+func NewAreaBroadcast(worldID uuid.UUID, center spatial.Position, radius float64, msgType string, data interface{}) (*AreaBroadcast, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("area broadcast: marshal data: %w", err)
+	}
+	return &AreaBroadcast{
+		WorldID: worldID,
+		Center:  center,
+		Radius:  radius,
+		Type:    msgType,
+		Data:    raw,
+	}, nil
+}
+
+// DecodeAreaBroadcast parses a published broadcast
+func DecodeAreaBroadcast(payload []byte) (*AreaBroadcast, error) {
+	var b AreaBroadcast
+	if err := json.Unmarshal(payload, &b); err != nil {
+		return nil, fmt.Errorf("area broadcast: unmarshal: %w", err)
+	}
+	if b.Type == "" {
+		return nil, fmt.Errorf("area broadcast: missing type")
+	}
+	if b.Radius < 0 {
+		return nil, fmt.Errorf("area broadcast: negative radius %v", b.Radius)
+	}
+	return &b, nil
+}
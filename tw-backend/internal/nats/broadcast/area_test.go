@@ -0,0 +1,39 @@
+package broadcast
+
+import (
+	"encoding/json"
+	"testing"
+
+	"tw-backend/internal/spatial"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAreaBroadcast_RoundTrip(t *testing.T) {
+	worldID := uuid.New()
+	sent, err := NewAreaBroadcast(worldID, spatial.Position{X: 10, Y: -5}, 250, "game_message",
+		map[string]string{"text": "The ground shakes"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(sent)
+	require.NoError(t, err)
+	received, err := DecodeAreaBroadcast(payload)
+	require.NoError(t, err)
+
+	assert.Equal(t, worldID, received.WorldID)
+	assert.Equal(t, spatial.Position{X: 10, Y: -5}, received.Center)
+	assert.Equal(t, 250.0, received.Radius)
+	assert.Equal(t, "game_message", received.Type)
+	assert.JSONEq(t, `{"text":"The ground shakes"}`, string(received.Data))
+}
+
+func TestDecodeAreaBroadcast_Invalid(t *testing.T) {
+	_, err := DecodeAreaBroadcast([]byte("not json"))
+	assert.Error(t, err)
+	_, err = DecodeAreaBroadcast([]byte(`{"radius": 10}`))
+	assert.Error(t, err, "type is required")
+	_, err = DecodeAreaBroadcast([]byte(`{"type": "x", "radius": -1}`))
+	assert.Error(t, err)
+}
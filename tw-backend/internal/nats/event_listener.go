@@ -10,6 +10,7 @@ import (
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog/log"
 
+	"tw-backend/internal/nats/subjects"
 	"tw-backend/internal/service"
 )
 
@@ -37,7 +38,9 @@ type NewCoords struct {
 }
 
 func (l *EventListener) ListenForMove() error {
-	_, err := l.nc.Subscribe("spatial.command.move", func(msg *nats.Msg) {
+	// Spatial service instances share a queue group so each move applies once
+	queue := subjects.QueueGroup(subjects.SpatialServiceQueue)
+	_, err := l.nc.QueueSubscribe(subjects.SpatialMove, queue, func(msg *nats.Msg) {
 		var cmd MoveCommand
 		if err := json.Unmarshal(msg.Data, &cmd); err != nil {
 			log.Error().Err(err).Msg("Failed to unmarshal move command")
@@ -0,0 +1,123 @@
+// Package subjects centralizes the NATS subjects and queue groups shared by
+// the services, so publishers and subscribers can't drift apart.
+package subjects
+
+import (
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+// Subjects published and subscribed across services
+const (
+	// AuthLogin carries login requests to the auth service (request/reply)
+	AuthLogin = "auth.login"
+	// AuthRefresh carries refresh-token exchanges to the auth service (request/reply)
+	AuthRefresh = "auth.refresh"
+	// AuthLogout carries logouts to the auth service (request/reply)
+	AuthLogout = "auth.logout"
+
+	// AIRequestAll matches every AI generation request
+	AIRequestAll = "ai.request.>"
+	// AIRequestDecision asks the AI gateway to pick an NPC action
+	AIRequestDecision = "ai.request.decision"
+	// AIResponseDecisionAll matches AI responses to NPC decision requests
+	AIResponseDecisionAll = "ai.response.decision.*"
+	// AIRequestDialogue asks the AI gateway for an unscripted NPC line (request/reply)
+	AIRequestDialogue = "ai.request.dialogue"
+
+	// NPCDecideAction asks the desire engine to choose an NPC's next action
+	NPCDecideAction = "npc.command.decide_action"
+
+	// SpatialMove moves an entity to new coordinates
+	SpatialMove = "spatial.command.move"
+	// SpatialAction carries a free-form NPC action for the spatial service
+	SpatialAction = "spatial.command.action"
+
+	// WorldCreate asks any world service instance to create and run a world
+	// (request/reply); see WorldPause for commands to a running world
+	WorldCreate = "world.create"
+
+	// WorldTickDay fires once per in-game day
+	WorldTickDay = "world.tick.day"
+	// WorldBroadcastArea carries area broadcasts from the world service to game servers
+	WorldBroadcastArea = "world.broadcast.area"
+
+	// DomainEventAll matches every domain event mirrored from the event store
+	DomainEventAll = "events.>"
+
+	aiResponsePrefix  = "ai.response."
+	worldTickPrefix   = "world.tick."
+	worldPausePrefix  = "world.pause."
+	worldResumePrefix = "world.resume."
+	worldQueryPrefix  = "world.query."
+	domainEventPrefix = "events."
+)
+
+// AIResponse is the subject an AI request's response is published on
+func AIResponse(requestID string) string {
+	return aiResponsePrefix + requestID
+}
+
+// WorldTick is the subject a world's ticks are published on
+func WorldTick(worldID uuid.UUID) string {
+	return worldTickPrefix + worldID.String()
+}
+
+// WorldPause, WorldResume and WorldQuery are the subjects a world's pause,
+// resume and status requests are sent on (request/reply). Only the world
+// service instance running the world subscribes to them.
+func WorldPause(worldID uuid.UUID) string {
+	return worldPausePrefix + worldID.String()
+}
+
+// WorldResume is the subject a world's resume requests are sent on
+func WorldResume(worldID uuid.UUID) string {
+	return worldResumePrefix + worldID.String()
+}
+
+// WorldQuery is the subject a world's status requests are sent on
+func WorldQuery(worldID uuid.UUID) string {
+	return worldQueryPrefix + worldID.String()
+}
+
+// DomainEvent is the subject events of a type are mirrored on, e.g. "events.combat_resolved"
+func DomainEvent(eventType string) string {
+	return domainEventPrefix + eventType
+}
+
+// Default queue groups. Instances of a service subscribe in the same group so
+// NATS delivers each message to only one of them.
+const (
+	AuthServiceQueue    = "auth-service"
+	AIGatewayQueue      = "ai-gateway"
+	DesireEngineQueue   = "desire-engine"
+	SpatialServiceQueue = "spatial-service"
+	MemoryDecayQueue    = "memory-decay"
+	WorldServiceQueue   = "world-service"
+)
+
+// QueueGroupEnv overrides a process's queue group. A process in its own group
+// receives a copy of every message, e.g. for a shadow deployment.
+const QueueGroupEnv = "NATS_QUEUE_GROUP"
+
+// QueueGroup returns the queue group this process should join: the
+// NATS_QUEUE_GROUP override if set, otherwise defaultGroup
+func QueueGroup(defaultGroup string) string {
+	if group := os.Getenv(QueueGroupEnv); group != "" {
+		return group
+	}
+	return defaultGroup
+}
+
+// Subscriber is the part of *nats.Conn used by subscribers that must see
+// every message, such as each game server fanning out to its own players
+type Subscriber interface {
+	Subscribe(subj string, cb nats.MsgHandler) (*nats.Subscription, error)
+}
+
+// QueueSubscriber is the part of *nats.Conn used for load-balanced subscriptions
+type QueueSubscriber interface {
+	QueueSubscribe(subj, queue string, cb nats.MsgHandler) (*nats.Subscription, error)
+}
@@ -0,0 +1,114 @@
+package subjects
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subjectMatches applies NATS wildcard rules: * matches one token, > the rest
+func subjectMatches(pattern, subject string) bool {
+	p := strings.Split(pattern, ".")
+	s := strings.Split(subject, ".")
+	for i, token := range p {
+		if token == ">" {
+			return len(s) > i
+		}
+		if i >= len(s) || (token != "*" && token != s[i]) {
+			return false
+		}
+	}
+	return len(p) == len(s)
+}
+
+func TestSubjects_MatchWireFormat(t *testing.T) {
+	// These strings are the contract with already-deployed publishers
+	assert.Equal(t, "auth.login", AuthLogin)
+	assert.Equal(t, "npc.command.decide_action", NPCDecideAction)
+	assert.Equal(t, "spatial.command.move", SpatialMove)
+	assert.Equal(t, "spatial.command.action", SpatialAction)
+	assert.Equal(t, "world.tick.day", WorldTickDay)
+	assert.Equal(t, "world.create", WorldCreate)
+	assert.Equal(t, "world.broadcast.area", WorldBroadcastArea)
+	assert.Equal(t, "ai.response.abc", AIResponse("abc"))
+
+	worldID := uuid.New()
+	assert.Equal(t, "world.tick."+worldID.String(), WorldTick(worldID))
+	assert.Equal(t, "world.pause."+worldID.String(), WorldPause(worldID))
+	assert.Equal(t, "world.resume."+worldID.String(), WorldResume(worldID))
+	assert.Equal(t, "world.query."+worldID.String(), WorldQuery(worldID))
+}
+
+func TestSubjects_SubscriptionsCoverPublishers(t *testing.T) {
+	// The desire engine publishes decisions the gateway must receive
+	assert.True(t, subjectMatches(AIRequestAll, AIRequestDecision))
+	assert.True(t, subjectMatches(AIRequestAll, AIRequestDialogue))
+	assert.True(t, subjectMatches(AIRequestAll, "ai.request.123"))
+	// The gateway answers decision requests on ai.response.<id>
+	assert.True(t, subjectMatches(AIResponseDecisionAll, AIResponse("decision.npc-1")))
+	assert.False(t, subjectMatches(AIResponseDecisionAll, AIResponse("dialogue.npc-1")))
+	// Domain event consumers see every mirrored event type
+	assert.Equal(t, "events.combat_resolved", DomainEvent("combat_resolved"))
+	assert.True(t, subjectMatches(DomainEventAll, DomainEvent("species_extinct")))
+}
+
+func TestQueueGroup(t *testing.T) {
+	t.Setenv(QueueGroupEnv, "")
+	assert.Equal(t, AuthServiceQueue, QueueGroup(AuthServiceQueue))
+
+	t.Setenv(QueueGroupEnv, "auth-canary")
+	assert.Equal(t, "auth-canary", QueueGroup(AuthServiceQueue))
+}
+
+// Integration test: requires a NATS server (NATS_URL, default localhost:4222)
+func TestQueueGroup_SubscribersSplitMessages(t *testing.T) {
+	url := os.Getenv("NATS_URL")
+	if url == "" {
+		url = nats.DefaultURL
+	}
+	nc, err := nats.Connect(url, nats.Timeout(time.Second))
+	if err != nil {
+		t.Skipf("Skipping integration test: NATS not available: %v", err)
+	}
+	defer nc.Close()
+
+	subject := "test.queue." + uuid.NewString()
+	const total = 50
+	var first, second int32
+	var wg sync.WaitGroup
+	wg.Add(total)
+
+	for _, counter := range []*int32{&first, &second} {
+		_, err := nc.QueueSubscribe(subject, AuthServiceQueue, func(*nats.Msg) {
+			atomic.AddInt32(counter, 1)
+			wg.Done()
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, nc.Flush())
+
+	for i := 0; i < total; i++ {
+		require.NoError(t, nc.Publish(subject, []byte("msg")))
+	}
+
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for messages")
+	}
+
+	// Each message is delivered to exactly one member of the group
+	assert.Equal(t, int32(total), atomic.LoadInt32(&first)+atomic.LoadInt32(&second))
+	assert.Positive(t, atomic.LoadInt32(&first), "Both subscribers share the load")
+	assert.Positive(t, atomic.LoadInt32(&second), "Both subscribers share the load")
+}
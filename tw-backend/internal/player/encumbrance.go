@@ -0,0 +1,9 @@
+package player
+
+// EncumbrancePenalty is the factor a load slows a character by. Load is the
+// carried weight as a share of carry capacity: up to 1 there's no penalty,
+// and past it the penalty grows with the load, so a character carrying
+// twice their capacity pays double stamina to move.
+func EncumbrancePenalty(load float64) float64 {
+	return max(1, load)
+}
@@ -27,6 +27,12 @@ const (
 
 // CalculateMovementCost calculates the stamina cost for a given distance and mode
 func CalculateMovementCost(distance float64, mode MovementType) int {
+	return CalculateEncumberedMovementCost(distance, mode, 1)
+}
+
+// CalculateEncumberedMovementCost calculates the stamina cost for a given
+// distance and mode, scaled by an encumbrance penalty (see EncumbrancePenalty)
+func CalculateEncumberedMovementCost(distance float64, mode MovementType, encumbrance float64) int {
 	var multiplier float64
 	switch mode {
 	case MoveWalk:
@@ -42,7 +48,7 @@ func CalculateMovementCost(distance float64, mode MovementType) int {
 	}
 
 	// Round up to nearest integer
-	return int(math.Ceil(distance * multiplier))
+	return int(math.Ceil(distance * multiplier * max(1, encumbrance)))
 }
 
 // ValidateMovement checks if the character has enough stamina
@@ -53,10 +59,11 @@ func ValidateMovement(currentStamina int, cost int) error {
 	return nil
 }
 
-// Move attempts to move the character, consuming stamina and returning events
+// Move attempts to move the character, consuming stamina and returning events.
+// An encumbered character pays more stamina to move.
 func Move(sm *StaminaManager, charID uuid.UUID, fromX, fromY, fromZ, toX, toY, toZ float64, mode MovementType) (*PlayerMovedEvent, *StaminaChangedEvent, error) {
 	distance := math.Sqrt(math.Pow(toX-fromX, 2) + math.Pow(toY-fromY, 2) + math.Pow(toZ-fromZ, 2))
-	cost := CalculateMovementCost(distance, mode)
+	cost := CalculateEncumberedMovementCost(distance, mode, sm.Encumbrance())
 
 	oldStamina := sm.Current()
 	if err := sm.Consume(cost); err != nil {
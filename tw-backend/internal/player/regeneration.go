@@ -10,8 +10,8 @@ func CalculateRegenRate(endurance int) float64 {
 	return float64(endurance) / 10.0
 }
 
-// Regenerate adds stamina based on elapsed time and rate
-// Returns the amount actually added
+// Regenerate adds stamina based on elapsed time and rate, slowed by any
+// encumbrance. Returns the amount actually added
 func (sm *StaminaManager) Regenerate(elapsedSeconds float64, ratePerSecond float64) int {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -20,7 +20,7 @@ func (sm *StaminaManager) Regenerate(elapsedSeconds float64, ratePerSecond float
 		return 0
 	}
 
-	amountToAdd := int(math.Floor(elapsedSeconds * ratePerSecond))
+	amountToAdd := int(math.Floor(elapsedSeconds * ratePerSecond / max(1, sm.encumbrance)))
 	if amountToAdd <= 0 {
 		return 0
 	}
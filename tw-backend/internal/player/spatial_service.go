@@ -109,7 +109,8 @@ func (s *SpatialService) CalculateNewPosition(ctx context.Context, char *auth.Ch
 	log.Printf("[SPATIAL] World %s shape=%s BoundsMin=%v BoundsMax=%v", world.ID, world.Shape, world.BoundsMin, world.BoundsMax)
 
 	// Check if world is bounded (Cube or has bounds defined)
-	if world.Shape == repository.WorldShapeCube || (world.BoundsMin != nil && world.BoundsMax != nil) {
+	circumference, spherical := sphericalCircumference(world)
+	if !spherical {
 		newX, newY, err := calculateBoundedPosition(char.PositionX, char.PositionY, dx, dy, world)
 		if err != nil {
 			return char.PositionX, char.PositionY, err.Error(), fmt.Errorf("blocked")
@@ -128,12 +129,16 @@ func (s *SpatialService) CalculateNewPosition(ctx context.Context, char *auth.Ch
 	}
 
 	// Default to Spherical Movement (Wrap around)
-	circumference := 10000.0
-	if world.Circumference != nil && *world.Circumference > 0 {
-		circumference = *world.Circumference
-	}
 	dims := worldspatial.NewWorldDimensions(circumference)
-	newX, newY, message := calculateSphericalPosition(char.PositionX, char.PositionY, dx, dy, "", dims) // Empty dirName as we formulate message caller-side or here
+	var newX, newY float64
+	var message string
+	if dx != 0 && math.Hypot(dx, dy) > 1 {
+		// Long moves off the meridian follow a great circle; a due north or
+		// south move already does
+		newX, newY, message = calculateGreatCirclePosition(char.PositionX, char.PositionY, dx, dy, dims)
+	} else {
+		newX, newY, message = calculateSphericalPosition(char.PositionX, char.PositionY, dx, dy, "", dims) // Empty dirName as we formulate message caller-side or here
+	}
 
 	// Check WorldEntity collisions for spherical worlds too
 	if s.entityService != nil {
@@ -147,6 +152,50 @@ func (s *SpatialService) CalculateNewPosition(ctx context.Context, char *auth.Ch
 	return newX, newY, message, nil
 }
 
+// sphericalCircumference returns the circumference of a world whose
+// positions wrap around a sphere. Worlds are spherical unless they are cubes
+// or bounded; those without a circumference default to 10km.
+func sphericalCircumference(world *repository.World) (float64, bool) {
+	if world.Shape == repository.WorldShapeCube || (world.BoundsMin != nil && world.BoundsMax != nil) {
+		return 0, false
+	}
+	if world.Circumference != nil && *world.Circumference > 0 {
+		return *world.Circumference, true
+	}
+	return 10000.0, true
+}
+
+// SurfaceCircumference reports the circumference of a spherical world, for
+// worldentity.Service's spatial index. It returns false for bounded worlds
+// and worlds it can't find.
+func (s *SpatialService) SurfaceCircumference(ctx context.Context, worldID uuid.UUID) (float64, bool) {
+	world, err := s.worldRepo.GetWorld(ctx, worldID)
+	if err != nil || world == nil {
+		return 0, false
+	}
+	return sphericalCircumference(world)
+}
+
+// Distance returns the distance in meters between two positions in a world:
+// along the surface for a spherical world, in a straight line otherwise
+func (s *SpatialService) Distance(ctx context.Context, worldID uuid.UUID, a, b spatial.Position) (float64, error) {
+	world, err := s.worldRepo.GetWorld(ctx, worldID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get world: %w", err)
+	}
+	if world == nil {
+		return 0, fmt.Errorf("world %s not found", worldID)
+	}
+
+	circumference, spherical := sphericalCircumference(world)
+	if !spherical {
+		return math.Hypot(b.X-a.X, b.Y-a.Y), nil
+	}
+	// Positions are longitude and latitude in meters along the surface
+	toDegrees := 360 / circumference
+	return s.CalculateDistance(a.Y*toDegrees, a.X*toDegrees, b.Y*toDegrees, b.X*toDegrees, circumference/(2*math.Pi)), nil
+}
+
 // GetPortalLocation returns a deterministic location on the world perimeter for a given target world ID
 func (s *SpatialService) GetPortalLocation(world *repository.World, targetID uuid.UUID) (float64, float64) {
 	// Defaults if bounds are missing
@@ -457,50 +506,47 @@ func getDirectionName(dx, dy float64) string {
 }
 
 func calculateSphericalPosition(lon, lat, dx, dy float64, dirName string, dims worldspatial.WorldDimensions) (float64, float64, string) {
-	message := ""
-
 	// --- Meter-Based Movement Logic ---
 	// We treat the world as a rectangle in meters where:
 	// X: [0, Circumference] (Longitude)
 	// Y: [-QuarterCircumference, QuarterCircumference] (Latitude -90 to 90 degrees converted to meters)
-	// QuarterCircumference = Circumference / 4
-
-	circumference := dims.CircumferenceM
-	quarterCircumference := circumference / 4.0
-
-	// 1. Apply movement in meters
-	newX := lon + dx
-	newY := lat + dy
-
-	// 2. Handle Pole Crossing (Y axis)
-	// If Y goes beyond the poles (quarterCircumference), we flip longitude and adjust Y
-	if newY > quarterCircumference {
-		// Crossed North Pole
-		overshoot := newY - quarterCircumference
-		newY = quarterCircumference - overshoot
-		newX += circumference / 2.0 // Flip to opposite side of globe
+	// Crossing a pole flips to the opposite meridian; X wraps around the globe.
+	newX, newY, pole, circled := spatial.WrapSurfacePosition(lon+dx, lat+dy, dims.CircumferenceM)
+
+	message := ""
+	switch pole {
+	case spatial.NorthPole:
 		message += " You cross the North Pole and the world spins beneath you."
-	} else if newY < -quarterCircumference {
-		// Crossed South Pole
-		overshoot := -quarterCircumference - newY
-		newY = -quarterCircumference + overshoot
-		newX += circumference / 2.0 // Flip to opposite side of globe
+	case spatial.SouthPole:
 		message += " You cross the South Pole and the world spins beneath you."
 	}
-
-	// 3. Handle Circumnavigation (X axis)
-	// Wrap X within [0, Circumference)
-	// We use a loop for robustness against large jumps, or modulo
-	if newX < 0 {
-		newX = math.Mod(newX, circumference)
-		if newX < 0 {
-			newX += circumference
-		}
-		message += " You've circled back around the world."
-	} else if newX >= circumference {
-		newX = math.Mod(newX, circumference)
+	if circled {
 		message += " You've circled back around the world."
 	}
 
 	return newX, newY, message
 }
+
+// calculateGreatCirclePosition moves (dx, dy) meters from (lon, lat) along a
+// great circle rather than across the X/Y rectangle, where a move east or west
+// would cover ever less ground towards the poles. The route is stepped a
+// degree of arc at a time, turning to the great circle's bearing after each
+// step, so crossing the meridian is noticed however far the move goes.
+func calculateGreatCirclePosition(lon, lat, dx, dy float64, dims worldspatial.WorldDimensions) (float64, float64, string) {
+	latDeg := lat / dims.MetersPerDegreeY
+	lonDeg := lon / dims.MetersPerDegreeX
+	bearing := math.Atan2(dx, dy) * 180 / math.Pi
+
+	for remaining := math.Hypot(dx, dy); remaining > 0; {
+		step := math.Min(remaining, dims.MetersPerDegreeY)
+		latDeg, lonDeg, bearing = spatial.GreatCircleDestination(latDeg, lonDeg, bearing, step, dims.RadiusM)
+		remaining -= step
+	}
+
+	newX, newY, _, circled := spatial.WrapSurfacePosition(lonDeg*dims.MetersPerDegreeX, latDeg*dims.MetersPerDegreeY, dims.CircumferenceM)
+	message := ""
+	if circled {
+		message = " You've circled back around the world."
+	}
+	return newX, newY, message
+}
@@ -2,6 +2,8 @@ package player
 
 import (
 	"testing"
+
+	"tw-backend/internal/spatial"
 	worldspatial "tw-backend/internal/world/spatial"
 
 	"github.com/stretchr/testify/assert"
@@ -50,3 +52,30 @@ func TestCalculateSphericalPosition_MeterBased(t *testing.T) {
 	assert.InDelta(t, startY, ny, 0.1, "Y should stay same")
 	assert.Contains(t, msg, "circled back around")
 }
+
+func TestCalculateGreatCirclePosition(t *testing.T) {
+	circumference := 40000000.0
+	dims := worldspatial.NewWorldDimensions(circumference)
+
+	// Along the equator a great circle is just the X axis
+	nx, ny, msg := calculateGreatCirclePosition(1000, 0, -500, 0, dims)
+	assert.InDelta(t, 500.0, nx, 0.01)
+	assert.InDelta(t, 0.0, ny, 0.01)
+	assert.Empty(t, msg)
+
+	// West 500 km at 60N covers 500 km of ground, bending towards the
+	// equator, instead of half that along the shrunken parallel
+	startX, startY := 1000000.0, 60*dims.MetersPerDegreeY
+	nx, ny, _ = calculateGreatCirclePosition(startX, startY, -500000, 0, dims)
+	assert.Less(t, ny, startY, "a great circle heading west bends towards the equator")
+	assert.Less(t, nx, startX-500000, "longitude should change by more than the distance at high latitude")
+	travelled := spatial.GreatCircleDistance(startY/dims.MetersPerDegreeY, startX/dims.MetersPerDegreeX,
+		ny/dims.MetersPerDegreeY, nx/dims.MetersPerDegreeX, dims.RadiusM)
+	assert.InDelta(t, 500000, travelled, 1.0)
+
+	// All the way round the equator lands back at the start
+	nx, ny, msg = calculateGreatCirclePosition(1000, 0, -circumference, 0, dims)
+	assert.InDelta(t, 1000.0, nx, 1.0)
+	assert.InDelta(t, 0.0, ny, 1.0)
+	assert.Contains(t, msg, "circled back around")
+}
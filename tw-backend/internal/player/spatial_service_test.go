@@ -7,6 +7,7 @@ import (
 
 	"tw-backend/internal/auth"
 	"tw-backend/internal/repository"
+	"tw-backend/internal/spatial"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -546,3 +547,32 @@ func TestCheckPortalProximity(t *testing.T) {
 	allowed, _ = svc.CheckPortalProximity(0, 0, 1000, 1000, true)
 	assert.True(t, allowed)
 }
+
+func TestDistance(t *testing.T) {
+	mockWorld := new(MockWorldRepository)
+	svc := NewSpatialService(new(MockAuthRepository), mockWorld, nil)
+
+	circumference := 10000.0
+	sphere := &repository.World{ID: uuid.New(), Circumference: &circumference}
+	bounded := &repository.World{
+		ID:        uuid.New(),
+		BoundsMin: &repository.Vector3{X: 0, Y: 0},
+		BoundsMax: &repository.Vector3{X: 100, Y: 100},
+	}
+	mockWorld.On("GetWorld", mock.Anything, sphere.ID).Return(sphere, nil)
+	mockWorld.On("GetWorld", mock.Anything, bounded.ID).Return(bounded, nil)
+
+	d, err := svc.Distance(context.Background(), bounded.ID, spatial.Position{X: 0, Y: 0}, spatial.Position{X: 3, Y: 4})
+	assert.NoError(t, err)
+	assert.InDelta(t, 5.0, d, 1e-9)
+
+	// Near the equator short distances are much as on a plane
+	d, err = svc.Distance(context.Background(), sphere.ID, spatial.Position{X: 100, Y: 0}, spatial.Position{X: 103, Y: 4})
+	assert.NoError(t, err)
+	assert.InDelta(t, 5.0, d, 0.01)
+
+	// Across the meridian the short way round
+	d, err = svc.Distance(context.Background(), sphere.ID, spatial.Position{X: circumference - 2, Y: 0}, spatial.Position{X: 2, Y: 0})
+	assert.NoError(t, err)
+	assert.InDelta(t, 4.0, d, 0.01)
+}
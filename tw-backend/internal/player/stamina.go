@@ -10,6 +10,7 @@ type StaminaManager struct {
 	mu             sync.RWMutex
 	currentStamina int
 	maxStamina     int
+	encumbrance    float64 // Penalty from carried weight; see EncumbrancePenalty
 }
 
 // NewStaminaManager creates a new manager initialized to max stamina
@@ -17,6 +18,7 @@ func NewStaminaManager(maxStamina int) *StaminaManager {
 	return &StaminaManager{
 		currentStamina: maxStamina,
 		maxStamina:     maxStamina,
+		encumbrance:    1,
 	}
 }
 
@@ -46,6 +48,36 @@ func (sm *StaminaManager) Consume(amount int) error {
 	return nil
 }
 
+// Restore adds stamina up to the max, e.g. from food or a potion. Returns
+// the amount actually added
+func (sm *StaminaManager) Restore(amount int) int {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	added := min(amount, sm.maxStamina-sm.currentStamina)
+	if added <= 0 {
+		return 0
+	}
+	sm.currentStamina += added
+	return added
+}
+
+// Encumbrance returns the penalty the character's load puts on movement
+// cost and regeneration
+func (sm *StaminaManager) Encumbrance() float64 {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return max(1, sm.encumbrance)
+}
+
+// SetEncumbrance sets the penalty from the character's load, e.g. after
+// picking something up
+func (sm *StaminaManager) SetEncumbrance(penalty float64) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.encumbrance = penalty
+}
+
 // SetCurrent sets the current stamina directly (e.g. from event replay)
 func (sm *StaminaManager) SetCurrent(amount int) {
 	sm.mu.Lock()
@@ -43,6 +43,19 @@ func TestStaminaManager_Consume(t *testing.T) {
 	assert.Equal(t, 50, sm.Current()) // Should not change
 }
 
+func TestStaminaManager_Restore(t *testing.T) {
+	sm := NewStaminaManager(100)
+	assert.NoError(t, sm.Consume(30))
+
+	assert.Equal(t, 20, sm.Restore(20))
+	assert.Equal(t, 90, sm.Current())
+
+	// Capped at max
+	assert.Equal(t, 10, sm.Restore(50))
+	assert.Equal(t, 100, sm.Current())
+	assert.Equal(t, 0, sm.Restore(5))
+}
+
 func TestCalculateRegenRate(t *testing.T) {
 	// Endurance 50 -> 5.0 per second
 	rate := CalculateRegenRate(50)
@@ -97,3 +110,29 @@ func TestMove(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, 90, sm.Current()) // Should not change
 }
+
+func TestMove_EncumbranceRaisesCost(t *testing.T) {
+	charID := uuid.New()
+	previous := 0
+	for _, load := range []float64{0.5, 1.0, 1.5, 2.0} {
+		sm := NewStaminaManager(100)
+		sm.SetEncumbrance(EncumbrancePenalty(load))
+
+		moved, _, err := Move(sm, charID, 0, 0, 0, 10, 0, 0, MoveWalk)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, moved.StaminaCost, previous, "load %.1f", load)
+		if load > 1 {
+			assert.Greater(t, moved.StaminaCost, previous, "past capacity, load %.1f costs more", load)
+		}
+		previous = moved.StaminaCost
+	}
+	assert.Equal(t, 20, previous, "twice capacity doubles the cost")
+}
+
+func TestStaminaManager_EncumbranceSlowsRegeneration(t *testing.T) {
+	sm := NewStaminaManager(100)
+	sm.Consume(50)
+	sm.SetEncumbrance(EncumbrancePenalty(2.0))
+
+	assert.Equal(t, 5, sm.Regenerate(2.0, 5.0), "half the usual 10")
+}
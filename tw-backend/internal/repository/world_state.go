@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// WorldState is a world's simulation as saved by "world save"
+type WorldState struct {
+	Year       int64  // Years of geology simulated
+	Geology    []byte // WorldGeology.Serialize, satellites included
+	Population []byte // JSON PopulationSimulator, nil if the world had none
+	SavedAt    time.Time
+}
+
+// WorldStateStore is implemented by world repositories that can keep one
+// saved simulation per world
+type WorldStateStore interface {
+	// SaveWorldState stores a world's state, replacing any earlier save
+	SaveWorldState(ctx context.Context, worldID uuid.UUID, state *WorldState) error
+	// LoadWorldState returns a world's saved state, or nil if it has none
+	LoadWorldState(ctx context.Context, worldID uuid.UUID) (*WorldState, error)
+}
+
+func (r *PostgresWorldRepository) SaveWorldState(ctx context.Context, worldID uuid.UUID, state *WorldState) error {
+	query := `
+		INSERT INTO world_saved_state (world_id, year, geology, population, saved_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (world_id) DO UPDATE SET
+			year = EXCLUDED.year,
+			geology = EXCLUDED.geology,
+			population = EXCLUDED.population,
+			saved_at = EXCLUDED.saved_at
+	`
+	_, err := r.db.Exec(ctx, query, worldID, state.Year, state.Geology, state.Population, state.SavedAt)
+	return err
+}
+
+func (r *PostgresWorldRepository) LoadWorldState(ctx context.Context, worldID uuid.UUID) (*WorldState, error) {
+	query := `
+		SELECT year, geology, population, saved_at
+		FROM world_saved_state
+		WHERE world_id = $1
+	`
+	var state WorldState
+	err := r.db.QueryRow(ctx, query, worldID).Scan(&state.Year, &state.Geology, &state.Population, &state.SavedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
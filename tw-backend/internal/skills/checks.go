@@ -1,8 +1,8 @@
 package skills
 
 import (
+	"math"
 	"math/rand"
-	"time"
 )
 
 // CheckResult represents the outcome of a skill check
@@ -14,81 +14,66 @@ type CheckResult struct {
 	Margin   int  // Total - Difficulty
 }
 
-// PerformCheck executes a skill check
-// Roll: d100 + skillLevel + (attributeVal / 5)
-// Critical Success: Natural 96-100
-// Critical Failure: Natural 1-5
-func PerformCheck(skillLevel int, attributeVal int, difficulty int) CheckResult {
-	rand.Seed(time.Now().UnixNano()) // Ensure randomness
-	roll := rand.Intn(100) + 1       // 1-100
-
-	// Criticals based on natural roll
-	if roll >= 96 {
-		return CheckResult{
-			Success:  true,
-			Critical: true,
-			Roll:     roll,
-			Total:    roll + skillLevel + (attributeVal / 5),
-			Margin:   (roll + skillLevel + (attributeVal / 5)) - difficulty,
-		}
-	}
-	if roll <= 5 {
-		return CheckResult{
-			Success:  false,
-			Critical: true,
-			Roll:     roll,
-			Total:    roll + skillLevel + (attributeVal / 5),
-			Margin:   (roll + skillLevel + (attributeVal / 5)) - difficulty,
-		}
-	}
+// CriticalThresholds are the natural rolls that decide a check whatever the
+// modifiers
+type CriticalThresholds struct {
+	Success int // Natural rolls at or above this always succeed
+	Failure int // Natural rolls at or below this always fail
+}
 
-	total := roll + skillLevel + (attributeVal / 5)
-	success := total >= difficulty
+// DefaultCriticals are used by Check: a natural 96-100 always succeeds and
+// a natural 1-5 always fails
+var DefaultCriticals = CriticalThresholds{Success: 96, Failure: 5}
 
-	return CheckResult{
-		Success:  success,
-		Critical: false,
-		Roll:     roll,
-		Total:    total,
-		Margin:   total - difficulty,
-	}
+// AttributeModifier is what an attribute adds to a check: a fifth of it
+func AttributeModifier(attributeVal int) float64 {
+	return float64(attributeVal) / 5
 }
 
-// PerformCheckWithSynergy executes a skill check including synergy bonuses
-func PerformCheckWithSynergy(skillLevel int, attributeVal int, synergyBonus int, difficulty int) CheckResult {
-	// We can reuse logic but we need to inject the synergy into the total.
-	// However, criticals are based on natural roll, so synergy doesn't affect crit chance, only success.
+// Check rolls d100 + level + attributeMod against a difficulty, such as
+// DifficultyMedium, with the DefaultCriticals. A nil rng uses the shared
+// source; pass one for repeatable rolls.
+func Check(level int, attributeMod float64, difficulty int, rng *rand.Rand) CheckResult {
+	return DefaultCriticals.Check(level, attributeMod, difficulty, rng)
+}
 
-	rand.Seed(time.Now().UnixNano())
-	roll := rand.Intn(100) + 1
+// Check rolls d100 + level + attributeMod against a difficulty, with these
+// critical thresholds. Fractions of attributeMod are dropped.
+func (c CriticalThresholds) Check(level int, attributeMod float64, difficulty int, rng *rand.Rand) CheckResult {
+	var roll int
+	if rng != nil {
+		roll = rng.Intn(100) + 1
+	} else {
+		roll = rand.Intn(100) + 1
+	}
 
-	if roll >= 96 {
-		return CheckResult{
-			Success:  true,
-			Critical: true,
-			Roll:     roll,
-			Total:    roll + skillLevel + (attributeVal / 5) + synergyBonus,
-			Margin:   (roll + skillLevel + (attributeVal / 5) + synergyBonus) - difficulty,
-		}
+	total := roll + level + int(math.Floor(attributeMod))
+	result := CheckResult{
+		Success: total >= difficulty,
+		Roll:    roll,
+		Total:   total,
+		Margin:  total - difficulty,
 	}
-	if roll <= 5 {
-		return CheckResult{
-			Success:  false,
-			Critical: true,
-			Roll:     roll,
-			Total:    roll + skillLevel + (attributeVal / 5) + synergyBonus,
-			Margin:   (roll + skillLevel + (attributeVal / 5) + synergyBonus) - difficulty,
-		}
+	switch {
+	case roll >= c.Success:
+		result.Success, result.Critical = true, true
+	case roll <= c.Failure:
+		result.Success, result.Critical = false, true
 	}
+	return result
+}
 
-	total := roll + skillLevel + (attributeVal / 5) + synergyBonus
-	success := total >= difficulty
+// PerformCheck executes a skill check
+// Roll: d100 + skillLevel + (attributeVal / 5)
+// Critical Success: Natural 96-100
+// Critical Failure: Natural 1-5
+func PerformCheck(skillLevel int, attributeVal int, difficulty int) CheckResult {
+	return Check(skillLevel, AttributeModifier(attributeVal), difficulty, nil)
+}
 
-	return CheckResult{
-		Success:  success,
-		Critical: false,
-		Roll:     roll,
-		Total:    total,
-		Margin:   total - difficulty,
-	}
+// PerformCheckWithSynergy executes a skill check including synergy bonuses.
+// Criticals are based on the natural roll, so synergy doesn't affect crit
+// chance, only success.
+func PerformCheckWithSynergy(skillLevel int, attributeVal int, synergyBonus int, difficulty int) CheckResult {
+	return Check(skillLevel+synergyBonus, AttributeModifier(attributeVal), difficulty, nil)
 }
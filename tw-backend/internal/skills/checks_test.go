@@ -1,6 +1,7 @@
 package skills
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -80,3 +81,79 @@ func TestCheckLogic(t *testing.T) {
 	assert.True(t, res.Success)
 	assert.Equal(t, 45, res.Total)
 }
+
+// fixedRoll is a rand.Source whose d100 always comes up the same
+type fixedRoll int
+
+func (f fixedRoll) Int63() int64 { return int64(f-1) << 32 } // Intn keeps the top 31 bits
+func (f fixedRoll) Seed(int64)   {}
+
+func rolling(n int) *rand.Rand {
+	return rand.New(fixedRoll(n))
+}
+
+func TestCheck_LevelVersusDifficulty(t *testing.T) {
+	tests := []struct {
+		name       string
+		roll       int
+		level      int
+		difficulty int
+		success    bool
+		margin     int
+	}{
+		{"novice against an easy task", 20, 5, DifficultyEasy, false, -5},
+		{"novice gets lucky", 25, 5, DifficultyEasy, true, 0},
+		{"journeyman against a medium task", 30, 25, DifficultyMedium, true, 5},
+		{"journeyman against a very hard task", 60, 25, DifficultyVeryHard, false, -5},
+		{"master against a very hard task", 20, 75, DifficultyVeryHard, true, 5},
+		{"master against an easy task", 6, 75, DifficultyEasy, true, 51},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Check(tt.level, 0, tt.difficulty, rolling(tt.roll))
+			assert.Equal(t, tt.roll, res.Roll)
+			assert.Equal(t, tt.success, res.Success)
+			assert.False(t, res.Critical)
+			assert.Equal(t, tt.margin, res.Margin)
+		})
+	}
+}
+
+func TestCheck_Margin(t *testing.T) {
+	// 40 + 20 + Might 53 (10.6, rounded down to 10) = 70 vs 50
+	res := Check(20, AttributeModifier(53), DifficultyMedium, rolling(40))
+	assert.Equal(t, 70, res.Total)
+	assert.Equal(t, 20, res.Margin)
+	assert.True(t, res.Success)
+
+	// A penalty counts against the total
+	res = Check(20, -2.5, DifficultyMedium, rolling(40))
+	assert.Equal(t, 57, res.Total)
+	assert.Equal(t, 7, res.Margin)
+}
+
+func TestCheck_Criticals(t *testing.T) {
+	res := Check(0, 0, 200, rolling(100))
+	assert.True(t, res.Success, "a natural 100 beats any difficulty")
+	assert.True(t, res.Critical)
+	assert.Equal(t, -100, res.Margin, "the margin is still the real one")
+
+	res = Check(100, 20, DifficultyEasy, rolling(5))
+	assert.False(t, res.Success, "a natural 5 fails any difficulty")
+	assert.True(t, res.Critical)
+
+	// Tighter thresholds
+	strict := CriticalThresholds{Success: 100, Failure: 1}
+	res = strict.Check(0, 0, 200, rolling(96))
+	assert.False(t, res.Success)
+	assert.False(t, res.Critical)
+	res = strict.Check(100, 0, DifficultyEasy, rolling(5))
+	assert.True(t, res.Success)
+	assert.False(t, res.Critical)
+}
+
+func TestCheck_RepeatableWithSeed(t *testing.T) {
+	a := Check(30, 5, DifficultyMedium, rand.New(rand.NewSource(42)))
+	b := Check(30, 5, DifficultyMedium, rand.New(rand.NewSource(42)))
+	assert.Equal(t, a, b)
+}
@@ -56,3 +56,24 @@ func (drt *DiminishingReturnsTracker) CalculateDiminishingReturn(actionID string
 		return 0.1
 	}
 }
+
+// TrivialMargin is how far below a character's skill level an action's
+// difficulty must be for it to count as trivial
+const TrivialMargin = 20
+
+// trivialFactor is the share of XP a trivial action still gives
+const trivialFactor = 0.1
+
+// ChallengeFactor is the XP multiplier for an action of a difficulty at a
+// skill level: full XP for actions at or above the level, falling to 10% for
+// trivial ones TrivialMargin or more below it
+func ChallengeFactor(level, difficulty int) float64 {
+	gap := level - difficulty
+	switch {
+	case gap <= 0:
+		return 1.0
+	case gap >= TrivialMargin:
+		return trivialFactor
+	}
+	return 1.0 - (1.0-trivialFactor)*float64(gap)/TrivialMargin
+}
@@ -7,6 +7,12 @@ import (
 
 const BaseXP = 100.0
 
+// MaxLevel is the highest level a skill can reach
+const MaxLevel = 100
+
+// XPCurve returns the XP needed to advance to a level from the one below it
+type XPCurve func(level int) float64
+
 // CalculateXPNeeded returns the total XP needed to reach the next level
 // Formula: BaseXP * (currentLevel^1.5)
 func CalculateXPNeeded(currentLevel int) float64 {
@@ -16,18 +22,31 @@ func CalculateXPNeeded(currentLevel int) float64 {
 	return BaseXP * math.Pow(float64(currentLevel), 1.5)
 }
 
+// LevelForXP returns the level a skill reaches with a total amount of XP on
+// a curve
+func LevelForXP(totalXP float64, curve XPCurve) int {
+	skill := Skill{}
+	skill.addXP(totalXP, curve)
+	return skill.Level
+}
+
 // AddXP adds XP to a skill and handles leveling up
 // Returns true if leveled up, and the new level
 func (s *Skill) AddXP(amount float64) (bool, int) {
+	return s.addXP(amount, CalculateXPNeeded)
+}
+
+// addXP is AddXP on any XP curve
+func (s *Skill) addXP(amount float64, curve XPCurve) (bool, int) {
 	s.XP += amount
 	leveledUp := false
 
 	// Check for level up
 	// We loop in case multiple levels are gained at once (unlikely but possible with big XP drops)
 	for {
-		// Cost to reach Level N = Base * (N^1.5).
+		// Cost to reach Level N, by default Base * (N^1.5).
 
-		cost := CalculateXPNeeded(s.Level + 1)
+		cost := curve(s.Level + 1)
 
 		if s.XP >= cost {
 			s.XP -= cost
@@ -37,8 +56,8 @@ func (s *Skill) AddXP(amount float64) (bool, int) {
 			break
 		}
 
-		if s.Level >= 100 {
-			s.Level = 100
+		if s.Level >= MaxLevel {
+			s.Level = MaxLevel
 			s.XP = 0 // Cap at 100
 			break
 		}
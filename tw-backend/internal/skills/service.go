@@ -3,18 +3,45 @@ package skills
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // Service handles business logic for skills
 type Service struct {
-	repo Repository
+	repo  Repository
+	curve XPCurve
+
+	// recent tracks each character's recent XP awards, so repeating an
+	// action quickly teaches less each time
+	recent map[uuid.UUID]*DiminishingReturnsTracker
+	mu     sync.Mutex
 }
 
 // NewService creates a new skills service
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{
+		repo:   repo,
+		curve:  CalculateXPNeeded,
+		recent: make(map[uuid.UUID]*DiminishingReturnsTracker),
+	}
+}
+
+// SetXPCurve changes how much XP each level costs. Stored XP is kept, so
+// levels are recalculated on the new curve.
+func (s *Service) SetXPCurve(curve XPCurve) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.curve = curve
+}
+
+func (s *Service) xpCurve() XPCurve {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.curve
 }
 
 // GetSkillSheet retrieves the full skill sheet for a character
@@ -49,8 +76,8 @@ func (s *Service) GetSkillSheet(ctx context.Context, characterID uuid.UUID) (*Sk
 			// Reset XP to 0 for calculation purposes? No, AddXP adds to existing.
 			// Let's manually simulate level up loop:
 			currentXP := skill.XP
-			skill.XP = 0           // Reset for calculation
-			skill.AddXP(currentXP) // Re-apply all XP to calculate level
+			skill.XP = 0                        // Reset for calculation
+			skill.addXP(currentXP, s.xpCurve()) // Re-apply all XP to calculate level
 
 			sheet.Skills[storedSkill.Name] = skill
 		}
@@ -61,21 +88,80 @@ func (s *Service) GetSkillSheet(ctx context.Context, characterID uuid.UUID) (*Sk
 
 // GainXP adds XP to a skill and persists it
 func (s *Service) GainXP(ctx context.Context, characterID uuid.UUID, skillName string, amount float64) (*SkillIncreasedEvent, *SkillLeveledUpEvent, error) {
-	sheet, err := s.GetSkillSheet(ctx, characterID)
+	name, ok := CanonicalName(skillName)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	stored, err := s.repo.GetSkills(ctx, characterID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, fmt.Errorf("failed to get stored skills: %w", err)
+	}
+	totalXP := 0.0
+	for _, skill := range stored {
+		if skill.Name == name {
+			totalXP = skill.XP
+		}
 	}
 
-	pm := NewProgressionManager(sheet)
-	inc, lvl := pm.GainXP(skillName, amount)
+	// Persist the new total XP
+	if err := s.repo.UpdateSkill(ctx, characterID, name, totalXP+amount); err != nil {
+		return nil, nil, fmt.Errorf("failed to persist skill update: %w", err)
+	}
 
-	if inc != nil {
-		// Persist the new total XP
-		newTotalXP := sheet.Skills[skillName].XP
-		if err := s.repo.UpdateSkill(ctx, characterID, skillName, newTotalXP); err != nil {
-			return nil, nil, fmt.Errorf("failed to persist skill update: %w", err)
+	curve := s.xpCurve()
+	now := time.Now()
+	inc := &SkillIncreasedEvent{
+		CharacterID: characterID,
+		SkillName:   name,
+		OldValue:    LevelForXP(totalXP, curve),
+		NewValue:    LevelForXP(totalXP+amount, curve),
+		XPGained:    amount,
+		Timestamp:   now,
+	}
+	var lvl *SkillLeveledUpEvent
+	if inc.NewValue > inc.OldValue {
+		lvl = &SkillLeveledUpEvent{
+			CharacterID: characterID,
+			SkillName:   name,
+			NewLevel:    inc.NewValue,
+			Timestamp:   now,
 		}
 	}
-
 	return inc, lvl, nil
 }
+
+// AwardXP awards a character XP in a skill for an action, like GainXP, but
+// with diminishing returns: the same skill used again and again within a
+// minute earns less each time. Scale amount by ChallengeFactor so trivial
+// actions earn little to begin with. The level-up event is nil unless the
+// award crossed a level threshold.
+func (s *Service) AwardXP(ctx context.Context, characterID uuid.UUID, skillName string, amount float64) (*SkillIncreasedEvent, *SkillLeveledUpEvent, error) {
+	name, ok := CanonicalName(skillName)
+	if !ok || amount <= 0 {
+		return nil, nil, nil
+	}
+
+	s.mu.Lock()
+	tracker, ok := s.recent[characterID]
+	if !ok {
+		tracker = NewDiminishingReturnsTracker()
+		s.recent[characterID] = tracker
+	}
+	factor := tracker.CalculateDiminishingReturn(name)
+	s.mu.Unlock()
+
+	return s.GainXP(ctx, characterID, name, amount*factor)
+}
+
+// CanonicalName returns the properly cased name of a skill, matching
+// regardless of case (recipes name skills in lower case), or false if there
+// is no such skill
+func CanonicalName(skillName string) (string, bool) {
+	for name := range NewSkillSheet(uuid.Nil).Skills {
+		if strings.EqualFold(name, skillName) {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,128 @@
+package skills
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepo stores total XP per character and skill
+type memoryRepo struct {
+	xp map[uuid.UUID]map[string]float64
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{xp: make(map[uuid.UUID]map[string]float64)}
+}
+
+func (r *memoryRepo) GetSkills(ctx context.Context, characterID uuid.UUID) ([]Skill, error) {
+	var stored []Skill
+	for name, xp := range r.xp[characterID] {
+		stored = append(stored, Skill{Name: name, XP: xp})
+	}
+	return stored, nil
+}
+
+func (r *memoryRepo) UpdateSkill(ctx context.Context, characterID uuid.UUID, skillName string, xp float64) error {
+	if r.xp[characterID] == nil {
+		r.xp[characterID] = make(map[string]float64)
+	}
+	r.xp[characterID][skillName] = xp
+	return nil
+}
+
+func TestService_GainXPAccumulates(t *testing.T) {
+	repo := newMemoryRepo()
+	svc := NewService(repo)
+	charID := uuid.New()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, _, err := svc.GainXP(ctx, charID, SkillSmithing, 40)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 120.0, repo.xp[charID][SkillSmithing], "total XP is stored, not what's left over after leveling")
+
+	sheet, err := svc.GetSkillSheet(ctx, charID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sheet.Skills[SkillSmithing].Level)
+	assert.Equal(t, 20.0, sheet.Skills[SkillSmithing].XP)
+
+	inc, _, err := svc.GainXP(ctx, charID, "smithing", 10)
+	require.NoError(t, err, "skill names match regardless of case")
+	require.NotNil(t, inc)
+	assert.Equal(t, SkillSmithing, inc.SkillName)
+	assert.Equal(t, 130.0, repo.xp[charID][SkillSmithing])
+}
+
+func TestService_AwardXPLevelsUpAtThreshold(t *testing.T) {
+	svc := NewService(newMemoryRepo())
+	charID := uuid.New()
+	ctx := context.Background()
+
+	_, lvl, err := svc.AwardXP(ctx, charID, SkillPerception, 99)
+	require.NoError(t, err)
+	assert.Nil(t, lvl, "99 XP is short of level 1")
+
+	inc, lvl, err := svc.AwardXP(ctx, charID, SkillPerception, 1)
+	require.NoError(t, err)
+	require.NotNil(t, lvl, "100 XP reaches level 1")
+	assert.Equal(t, SkillPerception, lvl.SkillName)
+	assert.Equal(t, 1, lvl.NewLevel)
+	assert.Equal(t, 0, inc.OldValue)
+	assert.Equal(t, 1, inc.NewValue)
+
+	_, _, err = svc.AwardXP(ctx, charID, "Juggling", 10)
+	assert.NoError(t, err, "unknown skills are ignored")
+}
+
+func TestService_AwardXPDiminishesOnRepeats(t *testing.T) {
+	svc := NewService(newMemoryRepo())
+	charID := uuid.New()
+	ctx := context.Background()
+
+	// A trivial action for a master, repeated
+	amount := 10 * ChallengeFactor(80, DifficultyEasy)
+	var gains []float64
+	for i := 0; i < 12; i++ {
+		inc, _, err := svc.AwardXP(ctx, charID, SkillPerception, amount)
+		require.NoError(t, err)
+		gains = append(gains, inc.XPGained)
+	}
+
+	assert.Equal(t, 1.0, gains[0], "a trivial action earns a tenth to begin with")
+	for i := 1; i < len(gains); i++ {
+		assert.LessOrEqual(t, gains[i], gains[i-1], "repeat %d earns no more than the last", i)
+	}
+	assert.InDelta(t, 0.1, gains[len(gains)-1], 1e-9, "grinding earns next to nothing")
+
+	inc, _, err := svc.AwardXP(ctx, charID, SkillSmithing, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, inc.XPGained, "other skills are unaffected")
+}
+
+func TestService_SetXPCurve(t *testing.T) {
+	repo := newMemoryRepo()
+	svc := NewService(repo)
+	svc.SetXPCurve(func(level int) float64 { return 10 })
+	charID := uuid.New()
+
+	_, lvl, err := svc.GainXP(context.Background(), charID, SkillCooking, 35)
+	require.NoError(t, err)
+	require.NotNil(t, lvl)
+	assert.Equal(t, 3, lvl.NewLevel)
+
+	sheet, err := svc.GetSkillSheet(context.Background(), charID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sheet.Skills[SkillCooking].Level)
+}
+
+func TestChallengeFactor(t *testing.T) {
+	assert.Equal(t, 1.0, ChallengeFactor(10, DifficultyMedium), "hard for a novice")
+	assert.Equal(t, 1.0, ChallengeFactor(50, DifficultyMedium), "matched")
+	assert.InDelta(t, 0.55, ChallengeFactor(60, DifficultyMedium), 1e-9)
+	assert.Equal(t, 0.1, ChallengeFactor(90, DifficultyMedium), "trivial")
+}
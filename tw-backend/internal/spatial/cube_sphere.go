@@ -14,27 +14,9 @@ const (
 	FaceBottom = 5 // -Y
 )
 
-// Edge identifiers
-const (
-	EdgeNorth = iota // y = 0
-	EdgeSouth        // y = resolution - 1
-	EdgeEast         // x = resolution - 1
-	EdgeWest         // x = 0
-)
-
-// faceTransition describes how to transition from one face to another
-type faceTransition struct {
-	targetFace int
-	// transform maps (x, y) from source edge to (newX, newY) on target edge
-	// rotationType: 0=none, 1=90°CW, 2=180°, 3=90°CCW
-	rotationType int
-}
-
 // CubeSphereTopology implements Topology for a cube-sphere projection
 type CubeSphereTopology struct {
 	resolution int
-	// faceConnections[sourceFace][edge] = transition
-	faceConnections [6][4]faceTransition
 }
 
 // NewCubeSphereTopology creates a new cube sphere topology with the given resolution per face
@@ -43,55 +25,9 @@ func NewCubeSphereTopology(resolution int) *CubeSphereTopology {
 		resolution = 64
 	}
 
-	t := &CubeSphereTopology{
+	return &CubeSphereTopology{
 		resolution: resolution,
 	}
-	t.initFaceConnections()
-	return t
-}
-
-// initFaceConnections sets up the face adjacency lookup table
-// Face layout (unwrapped net):
-//
-//	         [4: Top]
-//	[2: Left][0: Front][3: Right][1: Back]
-//	         [5: Bottom]
-func (t *CubeSphereTopology) initFaceConnections() {
-	// Front face (0) connections
-	t.faceConnections[FaceFront][EdgeNorth] = faceTransition{FaceTop, 0}
-	t.faceConnections[FaceFront][EdgeSouth] = faceTransition{FaceBottom, 0}
-	t.faceConnections[FaceFront][EdgeEast] = faceTransition{FaceRight, 0}
-	t.faceConnections[FaceFront][EdgeWest] = faceTransition{FaceLeft, 0}
-
-	// Back face (1) connections
-	t.faceConnections[FaceBack][EdgeNorth] = faceTransition{FaceTop, 2}
-	t.faceConnections[FaceBack][EdgeSouth] = faceTransition{FaceBottom, 2}
-	t.faceConnections[FaceBack][EdgeEast] = faceTransition{FaceLeft, 0}
-	t.faceConnections[FaceBack][EdgeWest] = faceTransition{FaceRight, 0}
-
-	// Left face (2) connections
-	t.faceConnections[FaceLeft][EdgeNorth] = faceTransition{FaceTop, 3}
-	t.faceConnections[FaceLeft][EdgeSouth] = faceTransition{FaceBottom, 1}
-	t.faceConnections[FaceLeft][EdgeEast] = faceTransition{FaceFront, 0}
-	t.faceConnections[FaceLeft][EdgeWest] = faceTransition{FaceBack, 0}
-
-	// Right face (3) connections
-	t.faceConnections[FaceRight][EdgeNorth] = faceTransition{FaceTop, 1}
-	t.faceConnections[FaceRight][EdgeSouth] = faceTransition{FaceBottom, 3}
-	t.faceConnections[FaceRight][EdgeEast] = faceTransition{FaceBack, 0}
-	t.faceConnections[FaceRight][EdgeWest] = faceTransition{FaceFront, 0}
-
-	// Top face (4) connections
-	t.faceConnections[FaceTop][EdgeNorth] = faceTransition{FaceBack, 2}
-	t.faceConnections[FaceTop][EdgeSouth] = faceTransition{FaceFront, 0}
-	t.faceConnections[FaceTop][EdgeEast] = faceTransition{FaceRight, 3}
-	t.faceConnections[FaceTop][EdgeWest] = faceTransition{FaceLeft, 1}
-
-	// Bottom face (5) connections
-	t.faceConnections[FaceBottom][EdgeNorth] = faceTransition{FaceFront, 0}
-	t.faceConnections[FaceBottom][EdgeSouth] = faceTransition{FaceBack, 2}
-	t.faceConnections[FaceBottom][EdgeEast] = faceTransition{FaceRight, 1}
-	t.faceConnections[FaceBottom][EdgeWest] = faceTransition{FaceLeft, 3}
 }
 
 // Resolution returns the grid size of each face
@@ -99,125 +35,153 @@ func (t *CubeSphereTopology) Resolution() int {
 	return t.resolution
 }
 
-// GetNeighbor returns the coordinate one step in the given direction
+// GetNeighbor returns the coordinate one step in the given direction.
+// Face layout (unwrapped net):
+//
+//	         [4: Top]
+//	[2: Left][0: Front][3: Right][1: Back]
+//	         [5: Bottom]
+//
+// Steps off a face are projected from the face's plane back onto the cube,
+// so every edge and corner lands on the geometrically adjacent cell. This
+// holds for diagonal steps across a seam too.
+//
+// Only three cells meet at a cube corner, one per face, and each is already
+// a cardinal neighbor of the other two. A diagonal step from a corner cell
+// out over the corner therefore has no cell to land on, and returns coord
+// unchanged.
 func (t *CubeSphereTopology) GetNeighbor(coord Coordinate, direction Direction) Coordinate {
 	dx, dy := DirectionDelta(direction)
 	newX := coord.X + dx
 	newY := coord.Y + dy
 
+	inX := newX >= 0 && newX < t.resolution
+	inY := newY >= 0 && newY < t.resolution
+
 	// Check if we stay within the same face
-	if newX >= 0 && newX < t.resolution && newY >= 0 && newY < t.resolution {
+	if inX && inY {
 		return Coordinate{Face: coord.Face, X: newX, Y: newY}
 	}
-
-	// Determine which edge we're crossing
-	var edge int
-	var edgePos int
-
-	if newY < 0 {
-		edge = EdgeNorth
-		edgePos = newX
-		newY = 0
-	} else if newY >= t.resolution {
-		edge = EdgeSouth
-		edgePos = newX
-		newY = t.resolution - 1
-	} else if newX >= t.resolution {
-		edge = EdgeEast
-		edgePos = newY
-		newX = t.resolution - 1
-	} else { // newX < 0
-		edge = EdgeWest
-		edgePos = newY
-		newX = 0
+	if !inX && !inY {
+		return coord
 	}
 
-	trans := t.faceConnections[coord.Face][edge]
-	targetX, targetY := t.transformCoordinate(edge, edgePos, trans.rotationType)
-
-	return Coordinate{Face: trans.targetFace, X: targetX, Y: targetY}
+	u, v := t.cellCenter(newX, newY)
+	x, y, z := faceVector(coord.Face, u, v)
+	return t.FromVector(x, y, z)
 }
 
-// transformCoordinate applies rotation and maps edge position to target face coordinates
-func (t *CubeSphereTopology) transformCoordinate(sourceEdge, edgePos, rotationType int) (int, int) {
-	max := t.resolution - 1
-
-	// First, determine where on the target face we enter based on source edge and rotation
-	// This is complex because each rotation affects how we enter the target face
-
-	switch rotationType {
-	case 0: // No rotation
-		switch sourceEdge {
-		case EdgeNorth:
-			return edgePos, max // Enter from south edge of target
-		case EdgeSouth:
-			return edgePos, 0 // Enter from north edge of target
-		case EdgeEast:
-			return 0, edgePos // Enter from west edge of target
-		case EdgeWest:
-			return max, edgePos // Enter from east edge of target
+// Neighbors8 returns the cells adjacent to coord in all eight directions,
+// cardinals first. A corner cell has only seven (see GetNeighbor).
+func (t *CubeSphereTopology) Neighbors8(coord Coordinate) []Coordinate {
+	neighbors := make([]Coordinate, 0, 8)
+	for _, dirs := range [][]Direction{CardinalDirections, DiagonalDirections} {
+		for _, d := range dirs {
+			next := t.GetNeighbor(coord, d)
+			if next != coord {
+				neighbors = append(neighbors, next)
+			}
 		}
-	case 1: // 90° clockwise
-		switch sourceEdge {
-		case EdgeNorth:
-			return max, edgePos // Enter from east edge
-		case EdgeSouth:
-			return 0, max - edgePos // Enter from west edge
-		case EdgeEast:
-			return edgePos, 0 // Enter from north edge
-		case EdgeWest:
-			return max - edgePos, max // Enter from south edge
+	}
+	return neighbors
+}
+
+// Step moves one cell in the given heading and returns the heading expressed
+// in the frame of the face it arrives on. Crossing onto a rotated face (e.g.
+// over a pole) turns the heading so that repeated steps follow a great circle:
+// walking north from the front face over the top arrives on the back face
+// heading south. A diagonal heading turns with the seam it crosses.
+func (t *CubeSphereTopology) Step(coord Coordinate, heading Direction) (Coordinate, Direction) {
+	next := t.GetNeighbor(coord, heading)
+	if next.Face == coord.Face {
+		return next, heading
+	}
+
+	dx, dy := DirectionDelta(heading)
+	if dx != 0 && dy != 0 {
+		// Only one component leaves the face; the face is rotated as it is
+		// for a cardinal step over the same seam
+		cardinal := East
+		switch {
+		case coord.Y+dy < 0:
+			cardinal = North
+		case coord.Y+dy >= t.resolution:
+			cardinal = South
+		case dx < 0:
+			cardinal = West
 		}
-	case 2: // 180° rotation
-		switch sourceEdge {
-		case EdgeNorth:
-			return max - edgePos, 0 // Enter from north edge, reversed
-		case EdgeSouth:
-			return max - edgePos, max // Enter from south edge, reversed
-		case EdgeEast:
-			return max, max - edgePos // Enter from east edge, reversed
-		case EdgeWest:
-			return 0, max - edgePos // Enter from west edge, reversed
+		_, turned := t.Step(coord, cardinal)
+		return next, rotateDirection(heading, directionIndex(turned)-directionIndex(cardinal))
+	}
+
+	// The new heading is the one whose reverse step leads back
+	for _, d := range CardinalDirections {
+		if t.GetNeighbor(next, oppositeDirection(d)) == coord {
+			return next, d
 		}
-	case 3: // 90° counter-clockwise
-		switch sourceEdge {
-		case EdgeNorth:
-			return 0, max - edgePos // Enter from west edge
-		case EdgeSouth:
-			return max, edgePos // Enter from east edge
-		case EdgeEast:
-			return max - edgePos, max // Enter from south edge
-		case EdgeWest:
-			return edgePos, 0 // Enter from north edge
+	}
+	return next, heading
+}
+
+// compass lists the directions clockwise from north, eighth turns apart
+var compass = []Direction{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+// directionIndex returns d's position on the compass, or -1
+func directionIndex(d Direction) int {
+	for i, c := range compass {
+		if c == d {
+			return i
 		}
 	}
+	return -1
+}
 
-	// Fallback (shouldn't reach here)
-	return edgePos, 0
+// rotateDirection turns d clockwise by the given number of eighth turns
+func rotateDirection(d Direction, eighths int) Direction {
+	i := directionIndex(d)
+	if i < 0 {
+		return d
+	}
+	return compass[((i+eighths)%8+8)%8]
 }
 
-// ToSphere converts a face coordinate to a unit sphere vector (x, y, z)
-// Uses normalized cube mapping to reduce distortion
-func (t *CubeSphereTopology) ToSphere(coord Coordinate) (x, y, z float64) {
-	// Convert grid coordinates to [-1, 1] range
-	u := (float64(coord.X)+0.5)/float64(t.resolution)*2 - 1
-	v := (float64(coord.Y)+0.5)/float64(t.resolution)*2 - 1
+// oppositeDirection returns the direction opposite d
+func oppositeDirection(d Direction) Direction {
+	return rotateDirection(d, 4)
+}
+
+// cellCenter converts grid coordinates (possibly just off the face) to
+// the face's [-1, 1] plane coordinates
+func (t *CubeSphereTopology) cellCenter(x, y int) (u, v float64) {
+	u = (float64(x)+0.5)/float64(t.resolution)*2 - 1
+	v = (float64(y)+0.5)/float64(t.resolution)*2 - 1
+	return u, v
+}
 
-	// Map to cube face
-	switch coord.Face {
+// faceVector maps plane coordinates on a face to an (unnormalized) cube point
+func faceVector(face int, u, v float64) (x, y, z float64) {
+	switch face {
 	case FaceFront: // +Z
-		x, y, z = u, -v, 1
+		return u, -v, 1
 	case FaceBack: // -Z
-		x, y, z = -u, -v, -1
+		return -u, -v, -1
 	case FaceLeft: // -X
-		x, y, z = -1, -v, u
+		return -1, -v, u
 	case FaceRight: // +X
-		x, y, z = 1, -v, -u
+		return 1, -v, -u
 	case FaceTop: // +Y
-		x, y, z = u, 1, v
+		return u, 1, v
 	case FaceBottom: // -Y
-		x, y, z = u, -1, -v
+		return u, -1, -v
 	}
+	return 0, 0, 0
+}
+
+// ToSphere converts a face coordinate to a unit sphere vector (x, y, z)
+// Uses normalized cube mapping to reduce distortion
+func (t *CubeSphereTopology) ToSphere(coord Coordinate) (x, y, z float64) {
+	u, v := t.cellCenter(coord.X, coord.Y)
+	x, y, z = faceVector(coord.Face, u, v)
 
 	// Normalize to unit sphere
 	mag := math.Sqrt(x*x + y*y + z*z)
@@ -283,8 +247,8 @@ func (t *CubeSphereTopology) FromVector(x, y, z float64) Coordinate {
 	return Coordinate{Face: face, X: gridX, Y: gridY}
 }
 
-// Distance returns the physical distance (great circle) between two coordinates
-// Returns distance as a fraction of the sphere's circumference (0 to 0.5)
+// Distance returns the great-circle distance between two coordinates' cell
+// centers, as an angle in radians (0 to π)
 func (t *CubeSphereTopology) Distance(a, b Coordinate) float64 {
 	if a == b {
 		return 0
@@ -261,3 +261,312 @@ func TestCubeSphereTopology_ContractTests(t *testing.T) {
 		ct.TestNeighborReversibility()
 	})
 }
+
+func TestCubeSphereTopology_GetNeighbor_EdgesAreAdjacent(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+	maxStep := 2.5 * math.Pi / float64(2*res) // A few cell widths on the unit sphere
+
+	for face := 0; face < 6; face++ {
+		for i := 0; i < res; i++ {
+			edges := map[Direction]Coordinate{
+				North: {Face: face, X: i, Y: 0},
+				South: {Face: face, X: i, Y: res - 1},
+				East:  {Face: face, X: res - 1, Y: i},
+				West:  {Face: face, X: 0, Y: i},
+			}
+			for dir, start := range edges {
+				got := topo.GetNeighbor(start, dir)
+				if got.Face == face {
+					t.Errorf("GetNeighbor(%v, %s) = %v, should leave the face", start, dir, got)
+				}
+				if d := topo.Distance(start, got); d > maxStep {
+					t.Errorf("GetNeighbor(%v, %s) = %v is %.3f rad away, want an adjacent cell", start, dir, got, d)
+				}
+			}
+		}
+	}
+}
+
+func TestCubeSphereTopology_Step_WestwardWrapsToOrigin(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+	start := Coordinate{Face: FaceFront, X: 5, Y: res / 2}
+
+	coord, heading := start, West
+	faces := make(map[int]bool)
+	for i := 0; i < 4*res; i++ {
+		coord, heading = topo.Step(coord, heading)
+		faces[coord.Face] = true
+		if heading != West {
+			t.Fatalf("Step %d: heading turned to %s along the equator", i, heading)
+		}
+	}
+
+	if coord != start {
+		t.Errorf("After circling the equator got %v, want %v", coord, start)
+	}
+	for _, face := range []int{FaceFront, FaceLeft, FaceBack, FaceRight} {
+		if !faces[face] {
+			t.Errorf("Equatorial walk should cross face %d", face)
+		}
+	}
+}
+
+func TestCubeSphereTopology_Step_OverThePole(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+	start := Coordinate{Face: FaceFront, X: 5, Y: res / 2}
+
+	// Half a great circle over the north pole
+	coord, heading := start, North
+	for i := 0; i < 2*res; i++ {
+		coord, heading = topo.Step(coord, heading)
+	}
+
+	if coord.Face != FaceBack {
+		t.Fatalf("Crossing the pole should land on the antipodal face %d, got %v", FaceBack, coord)
+	}
+	if heading != South {
+		t.Errorf("Heading after the pole = %s, want %s", heading, South)
+	}
+	// The back face is mirrored east-west, so the same grid line reads as res-1-X
+	if coord.X != res-1-start.X {
+		t.Errorf("Landed at %v, want X=%d on the far side", coord, res-1-start.X)
+	}
+
+	// The rest of the meridian brings us home
+	for i := 0; i < 2*res; i++ {
+		coord, heading = topo.Step(coord, heading)
+	}
+	if coord != start || heading != North {
+		t.Errorf("After a full meridian got %v heading %s, want %v heading %s", coord, heading, start, North)
+	}
+}
+
+func TestCubeSphereTopology_GetNeighbor_DiagonalAcrossSeams(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+
+	isCardinalNeighbor := func(a, b Coordinate) bool {
+		for _, d := range CardinalDirections {
+			if topo.GetNeighbor(a, d) == b {
+				return true
+			}
+		}
+		return false
+	}
+
+	for face := 0; face < 6; face++ {
+		for i := 1; i < res-1; i++ {
+			edges := []struct {
+				start Coordinate
+				out   Direction
+				diags []Direction
+			}{
+				{Coordinate{Face: face, X: i, Y: 0}, North, []Direction{NorthEast, NorthWest}},
+				{Coordinate{Face: face, X: i, Y: res - 1}, South, []Direction{SouthEast, SouthWest}},
+				{Coordinate{Face: face, X: res - 1, Y: i}, East, []Direction{NorthEast, SouthEast}},
+				{Coordinate{Face: face, X: 0, Y: i}, West, []Direction{NorthWest, SouthWest}},
+			}
+			for _, edge := range edges {
+				for _, dir := range edge.diags {
+					got := topo.GetNeighbor(edge.start, dir)
+					if got.Face == face {
+						t.Errorf("GetNeighbor(%v, %s) = %v, should leave the face", edge.start, dir, got)
+						continue
+					}
+
+					// The diagonal cell lies between the two cardinal steps it combines
+					dx, dy := DirectionDelta(dir)
+					along := East
+					if dx < 0 {
+						along = West
+					}
+					if edge.out == East || edge.out == West {
+						along = South
+						if dy < 0 {
+							along = North
+						}
+					}
+					across := topo.GetNeighbor(edge.start, edge.out)
+					beside := topo.GetNeighbor(edge.start, along)
+					if !isCardinalNeighbor(across, got) || !isCardinalNeighbor(beside, got) {
+						t.Errorf("GetNeighbor(%v, %s) = %v, want the cell adjacent to both %v and %v",
+							edge.start, dir, got, across, beside)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCubeSphereTopology_GetNeighbor_DiagonalOverCorner(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+
+	corners := map[Direction]Coordinate{
+		NorthWest: {X: 0, Y: 0},
+		NorthEast: {X: res - 1, Y: 0},
+		SouthEast: {X: res - 1, Y: res - 1},
+		SouthWest: {X: 0, Y: res - 1},
+	}
+	for face := 0; face < 6; face++ {
+		for dir, corner := range corners {
+			corner.Face = face
+			if got := topo.GetNeighbor(corner, dir); got != corner {
+				t.Errorf("GetNeighbor(%v, %s) = %v, want no move over the cube corner", corner, dir, got)
+			}
+		}
+	}
+}
+
+func TestCubeSphereTopology_Neighbors8(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+	maxStep := 2.5 * math.Pi / float64(2*res)
+
+	tests := []struct {
+		name  string
+		coord Coordinate
+		want  int
+	}{
+		{"interior", Coordinate{Face: FaceFront, X: 8, Y: 8}, 8},
+		{"seam", Coordinate{Face: FaceFront, X: res - 1, Y: 8}, 8},
+		{"pole seam", Coordinate{Face: FaceTop, X: 8, Y: 0}, 8},
+		{"face corner", Coordinate{Face: FaceFront, X: res - 1, Y: 0}, 7},
+		{"bottom corner", Coordinate{Face: FaceBottom, X: 0, Y: res - 1}, 7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := topo.Neighbors8(tc.coord)
+			if len(got) != tc.want {
+				t.Fatalf("Neighbors8(%v) returned %d cells, want %d: %v", tc.coord, len(got), tc.want, got)
+			}
+			seen := make(map[Coordinate]bool)
+			for _, n := range got {
+				if n == tc.coord || seen[n] {
+					t.Errorf("Neighbors8(%v) = %v, want distinct cells other than the start", tc.coord, got)
+				}
+				seen[n] = true
+				if d := topo.Distance(tc.coord, n); d > maxStep {
+					t.Errorf("Neighbor %v of %v is %.3f rad away, want an adjacent cell", n, tc.coord, d)
+				}
+			}
+		})
+	}
+}
+
+func TestCubeSphereTopology_Step_DiagonalTurnsWithSeam(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+
+	// A diagonal step over any seam, reversed in the new face's frame, leads back
+	for face := 0; face < 6; face++ {
+		for i := 1; i < res-1; i++ {
+			starts := map[Coordinate][]Direction{
+				{Face: face, X: i, Y: 0}:       {NorthEast, NorthWest},
+				{Face: face, X: i, Y: res - 1}: {SouthEast, SouthWest},
+				{Face: face, X: res - 1, Y: i}: {NorthEast, SouthEast},
+				{Face: face, X: 0, Y: i}:       {NorthWest, SouthWest},
+			}
+			for start, dirs := range starts {
+				for _, dir := range dirs {
+					next, heading := topo.Step(start, dir)
+					if back, _ := topo.Step(next, oppositeDirection(heading)); back != start {
+						t.Errorf("Step(%v, %s) = %v heading %s, but stepping back lands on %v",
+							start, dir, next, heading, back)
+					}
+				}
+			}
+		}
+	}
+
+	// The front and top faces share a frame; from the top onto the back,
+	// north turns to south, so north-west turns to south-east
+	_, heading := topo.Step(Coordinate{Face: FaceFront, X: 8, Y: 0}, NorthWest)
+	if heading != NorthWest {
+		t.Errorf("Front to top heading = %s, want %s (the faces share a frame)", heading, NorthWest)
+	}
+	_, heading = topo.Step(Coordinate{Face: FaceTop, X: 8, Y: 0}, NorthWest)
+	if heading != SouthEast {
+		t.Errorf("Top to back heading = %s, want %s", heading, SouthEast)
+	}
+}
+
+func TestCubeSphereTopology_GreatCircleDistance(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+	cells := []Coordinate{
+		{Face: FaceFront, X: 3, Y: 7},
+		{Face: FaceTop, X: 0, Y: 0},
+		{Face: FaceRight, X: 15, Y: 9},
+		{Face: FaceBottom, X: 8, Y: 2},
+		{Face: FaceBack, X: 12, Y: 15},
+	}
+
+	for _, a := range cells {
+		for _, b := range cells {
+			ab, ba := topo.GreatCircleDistance(a, b), topo.GreatCircleDistance(b, a)
+			if math.Abs(ab-ba) > 1e-9 {
+				t.Errorf("GreatCircleDistance(%v, %v) = %f but reversed = %f", a, b, ab, ba)
+			}
+			if ab > float64(2*res)+1e-9 {
+				t.Errorf("GreatCircleDistance(%v, %v) = %f exceeds half a great circle", a, b, ab)
+			}
+		}
+	}
+
+	// One step east along the equator is about a cell
+	center := Coordinate{Face: FaceFront, X: res / 2, Y: res / 2}
+	if d := topo.GreatCircleDistance(center, topo.GetNeighbor(center, East)); d < 0.5 || d > 1.5 {
+		t.Errorf("Neighbor distance = %f cells, want about 1", d)
+	}
+
+	// The back face is the front mirrored through the center, so the
+	// antipode of front (x, y) is back (x, res-1-y)
+	for _, a := range cells {
+		if a.Face != FaceFront {
+			continue
+		}
+		antipode := Coordinate{Face: FaceBack, X: a.X, Y: res - 1 - a.Y}
+		if d := topo.GreatCircleDistance(a, antipode); math.Abs(d-float64(2*res)) > 1e-6 {
+			t.Errorf("GreatCircleDistance(%v, %v) = %f, want the maximum %d", a, antipode, d, 2*res)
+		}
+	}
+}
+
+func TestCubeSphereTopology_Bearing(t *testing.T) {
+	res := 16
+	topo := NewCubeSphereTopology(res)
+	center := Coordinate{Face: FaceFront, X: res / 2, Y: res / 2}
+
+	tests := []struct {
+		dir  Direction
+		want float64
+	}{
+		{North, 0},
+		{East, math.Pi / 2},
+		{South, math.Pi},
+		{West, 3 * math.Pi / 2},
+	}
+	for _, tc := range tests {
+		to := center
+		for i := 0; i < 3; i++ {
+			to = topo.GetNeighbor(to, tc.dir)
+		}
+		got := topo.Bearing(center, to)
+		// Off the exact centerline the bearing is slightly skewed
+		diff := math.Abs(math.Remainder(got-tc.want, 2*math.Pi))
+		if diff > 0.2 {
+			t.Errorf("Bearing %s = %.3f rad, want about %.3f", tc.dir, got, tc.want)
+		}
+	}
+
+	// Towards the top face's pole is due north from anywhere on the front
+	top := Coordinate{Face: FaceTop, X: res / 2, Y: res / 2}
+	if got := topo.Bearing(Coordinate{Face: FaceFront, X: res / 2, Y: 3}, top); math.Abs(math.Remainder(got, 2*math.Pi)) > 0.1 {
+		t.Errorf("Bearing to the pole = %.3f rad, want about 0", got)
+	}
+}
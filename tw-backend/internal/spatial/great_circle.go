@@ -25,3 +25,72 @@ func GreatCircleDistance(lat1, lon1, lat2, lon2, radius float64) float64 {
 
 	return radius * c
 }
+
+// InitialBearing returns the compass bearing, in degrees clockwise from north
+// over [0, 360), at which the great circle from the first point sets out
+// towards the second
+func InitialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	lat1Rad := degToRad(lat1)
+	lat2Rad := degToRad(lat2)
+	dLon := degToRad(lon2 - lon1)
+
+	y := math.Sin(dLon) * math.Cos(lat2Rad)
+	x := math.Cos(lat1Rad)*math.Sin(lat2Rad) - math.Sin(lat1Rad)*math.Cos(lat2Rad)*math.Cos(dLon)
+
+	return math.Mod(radToDeg(math.Atan2(y, x))+360, 360)
+}
+
+// GreatCircleDestination returns the point reached by travelling distance
+// along a great circle from (lat, lon) with the given initial bearing, and
+// the bearing on arrival. Angles are in degrees; distance is in the same
+// units as radius. The longitude is not wrapped: it differs from lon by at
+// most 180 degrees, so steps can be chained to follow a route around the
+// globe.
+func GreatCircleDestination(lat, lon, bearing, distance, radius float64) (newLat, newLon, finalBearing float64) {
+	latRad := degToRad(lat)
+	bearingRad := degToRad(bearing)
+	delta := distance / radius // Angular distance
+
+	sinLat2 := math.Sin(latRad)*math.Cos(delta) + math.Cos(latRad)*math.Sin(delta)*math.Cos(bearingRad)
+	lat2Rad := math.Asin(math.Max(-1, math.Min(1, sinLat2)))
+	dLon := math.Atan2(
+		math.Sin(bearingRad)*math.Sin(delta)*math.Cos(latRad),
+		math.Cos(delta)-math.Sin(latRad)*sinLat2,
+	)
+
+	newLat = radToDeg(lat2Rad)
+	newLon = lon + radToDeg(dLon)
+
+	// Arriving, we face directly away from where we came from
+	finalBearing = math.Mod(InitialBearing(newLat, newLon, lat, lon)+180, 360)
+	return newLat, newLon, finalBearing
+}
+
+// GreatCircleDistance returns the great-circle distance between two
+// coordinates' cell centers in cells, measured along the equator's scale: a
+// full great circle is 4 * Resolution cells, so antipodal cells are
+// 2 * Resolution apart.
+func (t *CubeSphereTopology) GreatCircleDistance(a, b Coordinate) float64 {
+	return t.Distance(a, b) * float64(2*t.resolution) / math.Pi
+}
+
+// Bearing returns the initial bearing of the great circle from a to b, in
+// radians clockwise from north over [0, 2π). North is towards the top face's
+// pole (+Y). At a pole, where every direction is south (or north), bearings
+// are measured from north on the pole face's grid.
+func (t *CubeSphereTopology) Bearing(a, b Coordinate) float64 {
+	ax, ay, az := t.ToSphere(a)
+	bx, by, bz := t.ToSphere(b)
+	from := Vector3D{X: ax, Y: ay, Z: az}
+	to := Vector3D{X: bx, Y: by, Z: bz}
+
+	east := Vector3D{Y: 1}.Cross(from)
+	if east.Length() < 1e-12 {
+		// At a pole: both pole faces' grids run east along +X
+		east = Vector3D{X: 1}
+	}
+	east = east.Normalize()
+	north := from.Cross(east)
+
+	return math.Mod(math.Atan2(to.Dot(east), to.Dot(north))+2*math.Pi, 2*math.Pi)
+}
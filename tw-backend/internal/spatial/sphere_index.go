@@ -0,0 +1,180 @@
+package spatial
+
+import (
+	"math"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// SphereIndex is a spatial index for a spherical world, bucketing entities by
+// the cube-sphere face and cell beneath them. Positions are in meters as on
+// the world's surface: X runs east along [0, circumference), Y north over
+// [-circumference/4, circumference/4].
+//
+// Unlike SpatialGrid, distances are great-circle distances over the sphere,
+// so queries wrap across the meridian and over the poles, and a query near a
+// cube edge picks up entities on the neighboring faces.
+// Performance: O(k) where k = entities in the cells a query's cap touches
+type SphereIndex struct {
+	topology *CubeSphereTopology
+	radius   float64 // Sphere radius in meters
+
+	// Map of cube cell to entities in that cell
+	cells map[Coordinate]map[uuid.UUID]sphereEntry
+	// Map of entity ID to current cube cell
+	entityToCell map[uuid.UUID]Coordinate
+	mu           sync.RWMutex
+}
+
+// sphereEntry is an indexed entity's position, kept alongside its unit
+// vector so queries only need a dot product per entity
+type sphereEntry struct {
+	pos Position
+	vec Vector3D
+}
+
+// NewSphereIndex creates an index for a sphere of the given circumference in
+// meters, with cells roughly cellSize meters across
+func NewSphereIndex(circumference, cellSize float64) *SphereIndex {
+	if cellSize <= 0 {
+		cellSize = 100.0 // Default 100m cells, as SpatialGrid
+	}
+	// Four faces span the equator
+	resolution := int(math.Ceil(circumference / (4 * cellSize)))
+
+	return &SphereIndex{
+		topology:     NewCubeSphereTopology(resolution),
+		radius:       circumference / (2 * math.Pi),
+		cells:        make(map[Coordinate]map[uuid.UUID]sphereEntry),
+		entityToCell: make(map[uuid.UUID]Coordinate),
+	}
+}
+
+// toVector converts a surface position in meters to a unit vector
+func (s *SphereIndex) toVector(pos Position) Vector3D {
+	lat := pos.Y / s.radius
+	lon := pos.X / s.radius
+	x, y, z := ToCartesian(radToDeg(lat), radToDeg(lon), 1)
+	return Vector3D{X: x, Y: y, Z: z}
+}
+
+// Insert adds or updates an entity's position in the index
+// Complexity: O(1)
+func (s *SphereIndex) Insert(entityID uuid.UUID, pos Position) {
+	vec := s.toVector(pos)
+	newCell := s.topology.FromVector(vec.X, vec.Y, vec.Z)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Remove from old cell if entity already exists
+	if oldCell, exists := s.entityToCell[entityID]; exists && oldCell != newCell {
+		delete(s.cells[oldCell], entityID)
+		if len(s.cells[oldCell]) == 0 {
+			delete(s.cells, oldCell)
+		}
+	}
+
+	if s.cells[newCell] == nil {
+		s.cells[newCell] = make(map[uuid.UUID]sphereEntry)
+	}
+	s.cells[newCell][entityID] = sphereEntry{pos: pos, vec: vec}
+	s.entityToCell[entityID] = newCell
+}
+
+// Remove removes an entity from the index
+// Complexity: O(1)
+func (s *SphereIndex) Remove(entityID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cell, exists := s.entityToCell[entityID]; exists {
+		delete(s.cells[cell], entityID)
+		if len(s.cells[cell]) == 0 {
+			delete(s.cells, cell)
+		}
+		delete(s.entityToCell, entityID)
+	}
+}
+
+// QueryRadius returns all entities within radius meters of center, measured
+// along the surface.
+//
+// The cells under the query's cap are found by a flood fill from the center's
+// cell over Neighbors8, which follows the cube's seams, so a cap spanning
+// several faces is covered. A cell is explored while its center lies within
+// radius plus half a cell diagonal of the query's center, the furthest any of
+// its points can be.
+// Complexity: O(c + k) where c = cells under the cap, k = entities in them
+func (s *SphereIndex) QueryRadius(center Position, radius float64) []uuid.UUID {
+	results := make([]uuid.UUID, 0)
+	if radius < 0 {
+		return results
+	}
+
+	centerVec := s.toVector(center)
+	startCell := s.topology.FromVector(centerVec.X, centerVec.Y, centerVec.Z)
+
+	angle := radius / s.radius
+	minDot := math.Cos(math.Min(angle, math.Pi))
+	// A cell is at most 2/resolution across on the face plane, and the
+	// gnomonic projection never stretches angles
+	reach := angle + math.Sqrt2/float64(s.topology.Resolution())
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	visited := map[Coordinate]bool{startCell: true}
+	queue := []Coordinate{startCell}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		for entityID, entry := range s.cells[cell] {
+			if entry.vec.Dot(centerVec) >= minDot {
+				results = append(results, entityID)
+			}
+		}
+
+		for _, next := range s.topology.Neighbors8(cell) {
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			x, y, z := s.topology.ToSphere(next)
+			if math.Acos(math.Max(-1, math.Min(1, centerVec.Dot(Vector3D{X: x, Y: y, Z: z})))) <= reach {
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return results
+}
+
+// GetPosition returns the position of an entity if it exists in the index
+func (s *SphereIndex) GetPosition(entityID uuid.UUID) (Position, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cell, exists := s.entityToCell[entityID]
+	if !exists {
+		return Position{}, false
+	}
+	entry, found := s.cells[cell][entityID]
+	return entry.pos, found
+}
+
+// Count returns the total number of entities in the index
+func (s *SphereIndex) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entityToCell)
+}
+
+// SurfaceDistance returns the great-circle distance in meters between two
+// positions on the index's sphere
+func (s *SphereIndex) SurfaceDistance(a, b Position) float64 {
+	dot := s.toVector(a).Dot(s.toVector(b))
+	return s.radius * math.Acos(math.Max(-1, math.Min(1, dot)))
+}
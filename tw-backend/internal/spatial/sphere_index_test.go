@@ -0,0 +1,158 @@
+package spatial
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCircumference = 100000.0 // A 100km world: cells of 100m give 250 per face edge
+
+// randomSurfacePosition picks a position uniformly over the sphere
+func randomSurfacePosition(r *rand.Rand, circumference float64) Position {
+	v := RandomPointOnSphere(r.Int63())
+	lat, lon := ToLatLon(v.X, v.Y, v.Z, 1)
+	if lon < 0 {
+		lon += 360
+	}
+	return Position{X: lon / 360 * circumference, Y: lat / 360 * circumference}
+}
+
+// bruteForceRadius checks every entity, as a linear scan would
+func bruteForceRadius(index *SphereIndex, entities map[uuid.UUID]Position, center Position, radius float64) []uuid.UUID {
+	var ids []uuid.UUID
+	for id, pos := range entities {
+		if index.SurfaceDistance(center, pos) <= radius {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func sortedIDs(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestSphereIndex_InsertMoveRemove(t *testing.T) {
+	index := NewSphereIndex(testCircumference, 100)
+	id := uuid.New()
+
+	index.Insert(id, Position{X: 500, Y: 500})
+	assert.Equal(t, 1, index.Count())
+	assert.Len(t, index.QueryRadius(Position{X: 510, Y: 500}, 50), 1)
+
+	// Moving takes the entity out of its old cell
+	index.Insert(id, Position{X: 30000, Y: -8000})
+	assert.Equal(t, 1, index.Count())
+	assert.Empty(t, index.QueryRadius(Position{X: 510, Y: 500}, 50))
+	assert.Len(t, index.QueryRadius(Position{X: 30000, Y: -8050}, 60), 1)
+	pos, ok := index.GetPosition(id)
+	assert.True(t, ok)
+	assert.Equal(t, Position{X: 30000, Y: -8000}, pos)
+
+	index.Remove(id)
+	assert.Zero(t, index.Count())
+	assert.Empty(t, index.QueryRadius(Position{X: 30000, Y: -8000}, 1000))
+}
+
+func TestSphereIndex_QueryRadiusWraps(t *testing.T) {
+	index := NewSphereIndex(testCircumference, 100)
+	quarter := testCircumference / 4
+
+	acrossMeridian := uuid.New()
+	index.Insert(acrossMeridian, Position{X: testCircumference - 20, Y: 0})
+	overPole := uuid.New()
+	index.Insert(overPole, Position{X: testCircumference / 2, Y: quarter - 30})
+
+	// 40m east of the meridian reaches 20m west of it
+	assert.Equal(t, []uuid.UUID{acrossMeridian}, index.QueryRadius(Position{X: 20, Y: 0}, 50))
+	// Across the north pole to the opposite meridian is 30m + 30m
+	assert.Equal(t, []uuid.UUID{overPole}, index.QueryRadius(Position{X: 0, Y: quarter - 30}, 70))
+	assert.Empty(t, index.QueryRadius(Position{X: 0, Y: quarter - 30}, 50))
+}
+
+func TestSphereIndex_MatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	index := NewSphereIndex(testCircumference, 400) // Coarse cells keep the wide queries quick
+
+	entities := make(map[uuid.UUID]Position)
+	for i := 0; i < 5000; i++ {
+		id := uuid.New()
+		entities[id] = randomSurfacePosition(r, testCircumference)
+		index.Insert(id, entities[id])
+	}
+
+	// Query centers spread over the sphere, plus ones on cube edges and
+	// corners, where a cap spills over onto other faces
+	centers := []Position{
+		{X: testCircumference / 8, Y: 0},                          // Front/right edge on the equator
+		{X: testCircumference / 8, Y: 0.0980 * testCircumference}, // Near a cube corner
+		{X: 0, Y: testCircumference / 4},                          // North pole
+		{X: 0, Y: 0},                                              // Meridian
+	}
+	for i := 0; i < 40; i++ {
+		centers = append(centers, randomSurfacePosition(r, testCircumference))
+	}
+
+	for _, center := range centers {
+		for _, radius := range []float64{0, 75, 400, 2500, 12000} {
+			got := index.QueryRadius(center, radius)
+			want := bruteForceRadius(index, entities, center, radius)
+			require.Equal(t, sortedIDs(want), sortedIDs(got), "center %v radius %.0f", center, radius)
+		}
+	}
+
+	// Moving everyone keeps the index in step
+	for id := range entities {
+		entities[id] = randomSurfacePosition(r, testCircumference)
+		index.Insert(id, entities[id])
+	}
+	for _, center := range centers[:10] {
+		got := index.QueryRadius(center, 2500)
+		want := bruteForceRadius(index, entities, center, 2500)
+		require.Equal(t, sortedIDs(want), sortedIDs(got), "after moving, center %v", center)
+	}
+}
+
+// Benchmarks comparing the index with a linear scan at 10k entities
+
+func populateSphereIndex() (*SphereIndex, map[uuid.UUID]Position) {
+	r := rand.New(rand.NewSource(7))
+	index := NewSphereIndex(testCircumference, 100)
+	entities := make(map[uuid.UUID]Position, 10000)
+	for i := 0; i < 10000; i++ {
+		id := uuid.New()
+		entities[id] = randomSurfacePosition(r, testCircumference)
+		index.Insert(id, entities[id])
+	}
+	return index, entities
+}
+
+func BenchmarkSphereIndex_QueryRadius(b *testing.B) {
+	index, _ := populateSphereIndex()
+	center := Position{X: testCircumference / 8, Y: 0}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = index.QueryRadius(center, 500)
+	}
+}
+
+func BenchmarkSphereIndex_LinearScan(b *testing.B) {
+	index, entities := populateSphereIndex()
+	center := Position{X: testCircumference / 8, Y: 0}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = bruteForceRadius(index, entities, center, 500)
+	}
+}
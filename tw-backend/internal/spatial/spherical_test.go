@@ -132,6 +132,26 @@ func TestGreatCircleDistance(t *testing.T) {
 	}
 }
 
+func TestGreatCircleDestination(t *testing.T) {
+	quarter := (2 * math.Pi * EarthRadius) / 4
+
+	// Due east along the equator stays on it
+	lat, lon, bearing := GreatCircleDestination(0, 10, 90, quarter, EarthRadius)
+	assert.InDelta(t, 0.0, lat, 1e-9)
+	assert.InDelta(t, 100.0, lon, 1e-9)
+	assert.InDelta(t, 90.0, bearing, 1e-9)
+
+	// Due west from 60N follows a great circle, not the parallel: it bends
+	// towards the equator and arrives heading south of west
+	lat, lon, bearing = GreatCircleDestination(60, 0, 270, 500000, EarthRadius)
+	assert.Less(t, lat, 60.0)
+	assert.Less(t, lon, 0.0)
+	assert.Greater(t, bearing, 180.0)
+	assert.Less(t, bearing, 270.0)
+	assert.InDelta(t, 500000, GreatCircleDistance(60, 0, lat, lon, EarthRadius), 1.0)
+	assert.InDelta(t, 270.0, InitialBearing(60, 0, lat, lon), 1e-6)
+}
+
 func TestCoordinateNormalization(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -192,3 +212,42 @@ func TestCoordinateNormalization(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapSurfacePosition(t *testing.T) {
+	const c = 4000.0 // Poles at y = ±1000
+
+	tests := []struct {
+		name         string
+		x, y         float64
+		wantX, wantY float64
+		wantPole     Pole
+		wantCircled  bool
+	}{
+		{"Inside", 100, 200, 100, 200, NoPole, false},
+		{"West past the meridian", -10, 0, 3990, 0, NoPole, true},
+		{"East past the meridian", 4010, 0, 10, 0, NoPole, true},
+		{"Over the north pole", 100, 1050, 2100, 950, NorthPole, false},
+		{"Over the south pole", 3000, -1050, 1000, -950, SouthPole, false},
+		{"Full meridian loop", 100, 200 + c, 100, 200, NoPole, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, pole, circled := WrapSurfacePosition(tt.x, tt.y, c)
+			assert.InDelta(t, tt.wantX, x, Epsilon, "X mismatch")
+			assert.InDelta(t, tt.wantY, y, Epsilon, "Y mismatch")
+			assert.Equal(t, tt.wantPole, pole)
+			assert.Equal(t, tt.wantCircled, circled)
+		})
+	}
+}
+
+func TestWrapSurfacePosition_WestwardWalkReturnsToStart(t *testing.T) {
+	const c = 1000.0
+	x, y := 250.0, 120.0
+	for i := 0; i < int(c); i++ {
+		x, y, _, _ = WrapSurfacePosition(x-1, y, c)
+	}
+	assert.InDelta(t, 250.0, x, Epsilon)
+	assert.InDelta(t, 120.0, y, Epsilon)
+}
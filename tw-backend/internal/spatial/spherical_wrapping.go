@@ -1,5 +1,7 @@
 package spatial
 
+import "math"
+
 // NormalizeCoordinates handles spherical wrapping (longitude wrapping and pole crossing)
 func NormalizeCoordinates(lat, lon float64) (newLat, newLon float64) {
 	newLat = lat
@@ -28,3 +30,45 @@ func NormalizeCoordinates(lat, lon float64) (newLat, newLon float64) {
 
 	return newLat, newLon
 }
+
+// Pole identifies which pole a surface move crossed, if any
+type Pole int
+
+const (
+	NoPole Pole = iota
+	NorthPole
+	SouthPole
+)
+
+// WrapSurfacePosition wraps a position in meters on a spherical world.
+// X runs east along [0, circumference); Y runs north over
+// [-circumference/4, circumference/4]. Going past a pole reflects Y and moves
+// X to the opposite meridian, so walking far enough in any direction returns
+// to the start. circled reports whether X wrapped across the meridian.
+func WrapSurfacePosition(x, y, circumference float64) (newX, newY float64, pole Pole, circled bool) {
+	quarter := circumference / 4
+	circled = x < 0 || x >= circumference
+
+	// Distance along the meridian measured from the south pole; one full
+	// meridian loop is a whole circumference
+	t := math.Mod(y+quarter, circumference)
+	if t < 0 {
+		t += circumference
+	}
+	if t > circumference/2 {
+		// Over a pole onto the far side of the globe
+		t = circumference - t
+		x += circumference / 2
+		pole = SouthPole
+		if y > 0 {
+			pole = NorthPole
+		}
+	}
+	newY = t - quarter
+
+	newX = math.Mod(x, circumference)
+	if newX < 0 {
+		newX += circumference
+	}
+	return newX, newY, pole, circled
+}
@@ -136,6 +136,12 @@ func DirectionDelta(d Direction) (dx, dy int) {
 	}
 }
 
+// CardinalDirections are the four directions along grid lines
+var CardinalDirections = []Direction{North, East, South, West}
+
+// DiagonalDirections are the four directions between grid lines
+var DiagonalDirections = []Direction{NorthEast, SouthEast, SouthWest, NorthWest}
+
 // Topology abstracts world surface adjacency, allowing simulations
 // to work independently of the underlying grid shape.
 type Topology interface {
@@ -143,10 +149,22 @@ type Topology interface {
 	// Handles cross-face transitions and coordinate rotation.
 	GetNeighbor(coord Coordinate, direction Direction) Coordinate
 
+	// Neighbors8 returns the distinct cells adjacent to coord, diagonals
+	// included (8-connectivity).
+	Neighbors8(coord Coordinate) []Coordinate
+
 	// Distance returns the physical distance (great circle approximation)
 	// between two coordinates on the sphere.
 	Distance(a, b Coordinate) float64
 
+	// GreatCircleDistance returns the great-circle distance between two
+	// coordinates in cells, from 0 to 2 * Resolution for antipodal cells.
+	GreatCircleDistance(a, b Coordinate) float64
+
+	// Bearing returns the initial bearing of the great circle from a to b,
+	// in radians clockwise from north.
+	Bearing(a, b Coordinate) float64
+
 	// ToSphere converts a face coordinate to a unit sphere vector (x, y, z).
 	// Uses normalized cube mapping: v / ||v|| where v = (u, v, 1).
 	ToSphere(coord Coordinate) (x, y, z float64)
@@ -95,6 +95,7 @@ func (v *Validator) ValidateOneOf(value, fieldName string, allowed []string) err
 // ValidationErrors represents multiple validation errors
 type ValidationErrors struct {
 	Errors []string
+	Fields map[string]string // First error per field, for errors added with AddField
 }
 
 func (ve *ValidationErrors) Error() string {
@@ -107,6 +108,21 @@ func (ve *ValidationErrors) Add(err error) {
 	}
 }
 
+// AddField records err against the request field it came from.
+// Only the first error for each field is kept in Fields.
+func (ve *ValidationErrors) AddField(field string, err error) {
+	if err == nil {
+		return
+	}
+	ve.Add(err)
+	if ve.Fields == nil {
+		ve.Fields = make(map[string]string)
+	}
+	if _, ok := ve.Fields[field]; !ok {
+		ve.Fields[field] = err.Error()
+	}
+}
+
 func (ve *ValidationErrors) HasErrors() bool {
 	return len(ve.Errors) > 0
 }
@@ -98,6 +98,25 @@ func TestValidationErrors(t *testing.T) {
 	assert.Equal(t, assert.AnError.Error(), ve.Error())
 }
 
+func TestValidationErrors_AddField(t *testing.T) {
+	v := New()
+	ve := &ValidationErrors{}
+
+	ve.AddField("name", v.ValidateRequired("ok", "name"))
+	assert.False(t, ve.HasErrors())
+	assert.Nil(t, ve.Fields)
+
+	ve.AddField("name", v.ValidateRequired("", "name"))
+	ve.AddField("name", v.ValidateStringLength("", "name", 1, 50))
+	ve.AddField("role", v.ValidateOneOf("king", "role", []string{"player"}))
+
+	assert.Len(t, ve.Errors, 3)
+	assert.Equal(t, map[string]string{
+		"name": "name is required",
+		"role": "role must be one of: player",
+	}, ve.Fields, "Each field keeps its first error")
+}
+
 // Command-specific validation tests
 
 func TestValidateCommandText(t *testing.T) {
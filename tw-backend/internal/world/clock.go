@@ -0,0 +1,120 @@
+package world
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// EarthCircumferenceMeters is the reference circumference for a 24-hour day
+const EarthCircumferenceMeters = 40_075_000.0
+
+// DefaultTickDuration is the game time that elapses per clock tick
+const DefaultTickDuration = time.Minute
+
+// MinLightLevel is the residual starlight at midnight (0.0-1.0)
+const MinLightLevel = 0.1
+
+// DayLengthForCircumference derives a day length from planet size, assuming the
+// same equatorial surface speed as Earth: larger worlds rotate more slowly.
+func DayLengthForCircumference(circumferenceMeters float64) time.Duration {
+	if circumferenceMeters <= 0 {
+		return DefaultDayLength
+	}
+	return time.Duration(float64(DefaultDayLength) * circumferenceMeters / EarthCircumferenceMeters)
+}
+
+// LightLevel returns ambient light (MinLightLevel-1.0) for a sun position.
+// Light peaks at noon (0.5) and bottoms out at midnight (0.0/1.0).
+func LightLevel(sunPosition float64) float64 {
+	// Cosine curve: -1 at midnight, +1 at noon
+	elevation := -math.Cos(2 * math.Pi * sunPosition)
+	// Full daylight once the sun is comfortably above the horizon
+	light := 0.5 + elevation
+	return math.Max(MinLightLevel, math.Min(1.0, light))
+}
+
+// VisibilityMultiplier scales sight range for the given light level.
+// NightVision (0.0-1.0) recovers the light lost to darkness, so a creature
+// with NightVision 1.0 sees as well at midnight as at noon.
+func VisibilityMultiplier(lightLevel, nightVision float64) float64 {
+	nightVision = math.Max(0, math.Min(1.0, nightVision))
+	effective := lightLevel + (1.0-lightLevel)*nightVision
+	return math.Max(MinLightLevel, math.Min(1.0, effective))
+}
+
+// Clock tracks within-day time for a single world, advanced by ticks
+type Clock struct {
+	mu           sync.RWMutex
+	dayLength    time.Duration
+	tickDuration time.Duration
+	ticks        int64
+}
+
+// NewClock creates a clock starting at midnight.
+// Non-positive durations fall back to DefaultDayLength and DefaultTickDuration.
+func NewClock(dayLength, tickDuration time.Duration) *Clock {
+	if dayLength <= 0 {
+		dayLength = DefaultDayLength
+	}
+	if tickDuration <= 0 {
+		tickDuration = DefaultTickDuration
+	}
+	return &Clock{
+		dayLength:    dayLength,
+		tickDuration: tickDuration,
+	}
+}
+
+// Advance moves the clock forward by the given number of ticks
+func (c *Clock) Advance(ticks int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ticks += ticks
+}
+
+// Set moves the clock to an absolute game time (used for restores and tests)
+func (c *Clock) Set(gameTime time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ticks = int64(gameTime / c.tickDuration)
+}
+
+// Ticks returns the number of ticks elapsed
+func (c *Clock) Ticks() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ticks
+}
+
+// DayLength returns the length of a full day on this world
+func (c *Clock) DayLength() time.Duration {
+	return c.dayLength
+}
+
+// GameTime returns total in-game time elapsed
+func (c *Clock) GameTime() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Duration(c.ticks) * c.tickDuration
+}
+
+// SunPosition returns the current sun position (0.0-1.0)
+func (c *Clock) SunPosition() float64 {
+	return CalculateSunPosition(c.GameTime(), c.dayLength)
+}
+
+// TimeOfDay returns the descriptive time of day
+func (c *Clock) TimeOfDay() TimeOfDay {
+	return GetTimeOfDay(c.SunPosition())
+}
+
+// IsNight reports whether it is currently night
+func (c *Clock) IsNight() bool {
+	return c.TimeOfDay() == TimeOfDayNight
+}
+
+// LightLevel returns the current ambient light (MinLightLevel-1.0)
+func (c *Clock) LightLevel() float64 {
+	return LightLevel(c.SunPosition())
+}
@@ -0,0 +1,44 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock_AdvancesWithTicks(t *testing.T) {
+	clock := NewClock(24*time.Hour, time.Minute)
+	assert.Equal(t, TimeOfDayNight, clock.TimeOfDay(), "Clock starts at midnight")
+
+	clock.Advance(12 * 60) // 12 hours of one-minute ticks
+	assert.Equal(t, int64(720), clock.Ticks())
+	assert.Equal(t, 12*time.Hour, clock.GameTime())
+	assert.InDelta(t, 0.5, clock.SunPosition(), 0.001)
+	assert.Equal(t, TimeOfDayNoon, clock.TimeOfDay())
+	assert.False(t, clock.IsNight())
+
+	clock.Advance(12 * 60) // Back to midnight
+	assert.True(t, clock.IsNight())
+	assert.InDelta(t, MinLightLevel, clock.LightLevel(), 0.001)
+}
+
+func TestDayLengthForCircumference(t *testing.T) {
+	assert.Equal(t, DefaultDayLength, DayLengthForCircumference(EarthCircumferenceMeters))
+	assert.Equal(t, 2*DefaultDayLength, DayLengthForCircumference(2*EarthCircumferenceMeters))
+	assert.Equal(t, DefaultDayLength, DayLengthForCircumference(0), "Unknown size falls back to default")
+}
+
+func TestLightLevel(t *testing.T) {
+	assert.InDelta(t, 1.0, LightLevel(0.5), 0.001, "Noon is fully lit")
+	assert.InDelta(t, MinLightLevel, LightLevel(0.0), 0.001, "Midnight is starlight only")
+	assert.Less(t, LightLevel(0.25), 1.0, "Dawn is dimmer than noon")
+	assert.Greater(t, LightLevel(0.25), MinLightLevel, "Dawn is brighter than midnight")
+}
+
+func TestVisibilityMultiplier(t *testing.T) {
+	assert.InDelta(t, 1.0, VisibilityMultiplier(1.0, 0), 0.001)
+	assert.InDelta(t, MinLightLevel, VisibilityMultiplier(MinLightLevel, 0), 0.001)
+	assert.InDelta(t, 1.0, VisibilityMultiplier(MinLightLevel, 1.0), 0.001, "Full NightVision sees normally in darkness")
+	assert.Greater(t, VisibilityMultiplier(MinLightLevel, 0.5), VisibilityMultiplier(MinLightLevel, 0))
+}
@@ -15,6 +15,15 @@ const (
 // DefaultSeasonLength is 90 game-days
 const DefaultSeasonLength = 90 * 24 * time.Hour
 
+// CalculateYear returns how many whole years of four seasons have elapsed,
+// i.e. the current year counting from 0
+func CalculateYear(gameTime time.Duration, seasonLength time.Duration) int64 {
+	if seasonLength <= 0 {
+		return 0
+	}
+	return int64(gameTime / (seasonLength * 4))
+}
+
 // CalculateSeason calculates the current season and progress (0.0-1.0)
 func CalculateSeason(gameTime time.Duration, seasonLength time.Duration) (Season, float64) {
 	if seasonLength <= 0 {
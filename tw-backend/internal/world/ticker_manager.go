@@ -11,6 +11,8 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"tw-backend/internal/eventstore"
+	"tw-backend/internal/nats/broadcast"
+	"tw-backend/internal/nats/subjects"
 	"tw-backend/internal/spatial"
 	"tw-backend/internal/worldgen/weather"
 )
@@ -22,7 +24,7 @@ type NATSPublisher interface {
 
 // AreaBroadcaster interface for broadcasting messages to spatial areas
 type AreaBroadcaster interface {
-	BroadcastToArea(center spatial.Position, radius float64, msgType string, data interface{})
+	BroadcastToArea(worldID uuid.UUID, center spatial.Position, radius float64, msgType string, data interface{})
 }
 
 // TickerManager manages world tickers
@@ -40,6 +42,7 @@ type ticker struct {
 	worldID             uuid.UUID
 	worldName           string
 	stopCh              chan struct{}
+	done                chan struct{} // Closed when the ticker goroutine exits
 	tickInterval        time.Duration
 	dilationFactor      float64
 	version             int64         // Event version counter
@@ -50,6 +53,10 @@ type ticker struct {
 
 const DefaultTickInterval = 100 * time.Millisecond
 
+// WeatherBroadcastRadius is how far from its cell a change in the weather
+// is noticed
+const WeatherBroadcastRadius = 50.0
+
 // NewTickerManager creates a new ticker manager
 func NewTickerManager(registry *Registry, eventStore eventstore.EventStore, natsPublisher NATSPublisher, weatherService *weather.Service, broadcaster AreaBroadcaster) *TickerManager {
 	return &TickerManager{
@@ -77,6 +84,7 @@ func (tm *TickerManager) SpawnTicker(worldID uuid.UUID, worldName string, dilati
 		worldID:        worldID,
 		worldName:      worldName,
 		stopCh:         make(chan struct{}),
+		done:           make(chan struct{}),
 		tickInterval:   DefaultTickInterval,
 		dilationFactor: dilationFactor,
 		version:        0,
@@ -135,13 +143,16 @@ func (tm *TickerManager) SpawnTicker(worldID uuid.UUID, worldName string, dilati
 	isResume := err == nil && !existingWorld.PausedAt.IsZero()
 
 	// Start ticker goroutine
-	if isResume {
-		// Perform catch-up, then run normal ticker
-		go tm.performCatchupThenRun(t, existingWorld)
-	} else {
-		// Normal startup
-		go tm.runTicker(t)
-	}
+	go func() {
+		defer close(t.done)
+		if isResume {
+			// Perform catch-up, then run normal ticker
+			tm.performCatchupThenRun(t, existingWorld)
+		} else {
+			// Normal startup
+			tm.runTicker(t)
+		}
+	}()
 
 	return nil
 }
@@ -160,8 +171,10 @@ func (tm *TickerManager) StopTicker(worldID uuid.UUID) error {
 	delete(tm.tickers, worldID)
 	tm.mu.Unlock()
 
-	// Signal stop
+	// Signal stop, and wait out any tick in progress so the pause is
+	// recorded after the last tick
 	close(t.stopCh)
+	<-t.done
 
 	// Update registry and record pause time
 	var tickCount int64
@@ -288,8 +301,7 @@ func (tm *TickerManager) tick(t *ticker) {
 			SeasonProgress: seasonProgress,
 		}
 		data, _ := json.Marshal(broadcast)
-		subject := fmt.Sprintf("world.tick.%s", t.worldID)
-		if err := tm.natsPublisher.Publish(subject, data); err != nil {
+		if err := tm.natsPublisher.Publish(subjects.WorldTick(t.worldID), data); err != nil {
 			log.Error().Err(err).Str("world_id", t.worldID.String()).Msg("Failed to publish tick to NATS")
 		}
 	}
@@ -341,32 +353,35 @@ func (tm *TickerManager) tick(t *ticker) {
 		if err != nil {
 			log.Error().Err(err).Str("world_id", t.worldID.String()).Msg("Failed to update weather")
 		} else {
+			// Fronts and storms move over the diurnal and seasonal base
+			if t.lastWeatherGameTime > 0 {
+				stormEmotes, err := tm.weatherService.SimulateWeatherStep(context.Background(), t.worldID, newGameTime-t.lastWeatherGameTime)
+				if err != nil {
+					log.Error().Err(err).Str("world_id", t.worldID.String()).Msg("Failed to simulate weather systems")
+				}
+				for cellID, emote := range stormEmotes {
+					emotes[cellID] = emote
+				}
+			}
 			t.lastWeatherGameTime = newGameTime
 
-			// Broadcast emotes
+			// Storms and weather changes are heard only around their cell
 			if tm.broadcaster != nil && len(emotes) > 0 {
-				// We need cell locations to broadcast to area.
-				// Emotes map is cellID -> text.
-				// We need cellID -> location.
-				// WeatherService has this internally but doesn't expose it easily in return.
-				// Iterate all cells? Or WeatherService should return map[cellID]struct{Text, Location}?
-				// Re-fetching per cell is expensive if we don't have location.
-				// But we can get GeographyCell?
-				// WeatherService could just broadcast itself if we passed broadcaster to it?
-				// Or return `[]WeatherEvent` where event has Location.
-
-				// For now, let's assume we can't broadcast efficiently without location.
-				// I'll update WeatherService to return map[uuid.UUID]WeatherEvent
-				// type WeatherEvent struct { Text string; Location geography.Point }
-				// Checking WeatherService return... currently map[uuid.UUID]string.
-
-				// Quick fix: Retrieve location from WeatherService? No method.
-				// Iterate stateCache? No access.
-				// I should update WeatherService.UpdateWorldWeather to return more info.
-
-				// Since I'm in TickerManager, I'll defer this and update WeatherService return type in next step.
-				// For this file update, I'll comment the broadcast part or use placeholder.
+				tm.broadcastWeather(t.worldID, emotes)
 			}
 		}
 	}
 }
+
+// broadcastWeather sends each emote to the players around its weather cell
+func (tm *TickerManager) broadcastWeather(worldID uuid.UUID, emotes map[uuid.UUID]string) {
+	locations := tm.weatherService.CellLocations(worldID)
+	for cellID, emote := range emotes {
+		loc, ok := locations[cellID]
+		if !ok {
+			continue
+		}
+		tm.broadcaster.BroadcastToArea(worldID, spatial.Position{X: loc.X, Y: loc.Y}, WeatherBroadcastRadius,
+			broadcast.MessageTypeGameMessage, broadcast.NewGameMessage("weather", emote))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"tw-backend/internal/eventstore"
+	"tw-backend/internal/nats/broadcast"
 	"tw-backend/internal/spatial"
 	"tw-backend/internal/worldgen/geography"
 	"tw-backend/internal/worldgen/weather"
@@ -27,6 +28,15 @@ func (m *MockEventStore) AppendEvent(ctx context.Context, event eventstore.Event
 	return nil
 }
 
+func (m *MockEventStore) AppendEventExpectingVersion(ctx context.Context, event eventstore.Event, expectedVersion int) error {
+	return m.AppendEvent(ctx, event)
+}
+
+func (m *MockEventStore) AppendEvents(ctx context.Context, events []eventstore.Event) error {
+	m.events = append(m.events, events...)
+	return nil
+}
+
 func (m *MockEventStore) GetEventsByAggregate(ctx context.Context, aggregateID string, fromVersion int64) ([]eventstore.Event, error) {
 	return nil, nil
 }
@@ -39,6 +49,18 @@ func (m *MockEventStore) GetAllEvents(ctx context.Context, fromTimestamp time.Ti
 	return nil, nil
 }
 
+func (m *MockEventStore) SaveSnapshot(ctx context.Context, snapshot eventstore.Snapshot) error {
+	return nil
+}
+
+func (m *MockEventStore) GetLatestSnapshot(ctx context.Context, aggregateID string) (*eventstore.Snapshot, error) {
+	return nil, nil
+}
+
+func (m *MockEventStore) Subscribe(ctx context.Context, fromPosition int64) (<-chan eventstore.Event, error) {
+	return nil, nil
+}
+
 func TestTickerManager_SpawnTicker(t *testing.T) {
 	registry := NewRegistry()
 	eventStore := &MockEventStore{}
@@ -274,13 +296,26 @@ func (m *MockWeatherRepository) GetAnnualPrecipitation(ctx context.Context, cell
 	return args.Get(0).(float64), args.Error(1)
 }
 
+func (m *MockWeatherRepository) SavePressureField(ctx context.Context, worldID uuid.UUID, field *weather.PressureField) error {
+	args := m.Called(ctx, worldID, field)
+	return args.Error(0)
+}
+
+func (m *MockWeatherRepository) GetPressureField(ctx context.Context, worldID uuid.UUID) (*weather.PressureField, error) {
+	args := m.Called(ctx, worldID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*weather.PressureField), args.Error(1)
+}
+
 // MockAreaBroadcaster
 type MockAreaBroadcaster struct {
 	mock.Mock
 }
 
-func (m *MockAreaBroadcaster) BroadcastToArea(center spatial.Position, radius float64, msgType string, data interface{}) {
-	m.Called(center, radius, msgType, data)
+func (m *MockAreaBroadcaster) BroadcastToArea(worldID uuid.UUID, center spatial.Position, radius float64, msgType string, data interface{}) {
+	m.Called(worldID, center, radius, msgType, data)
 }
 
 func TestTickerManager_WeatherIntegration(t *testing.T) {
@@ -289,6 +324,7 @@ func TestTickerManager_WeatherIntegration(t *testing.T) {
 	weatherRepo := &MockWeatherRepository{}
 	weatherService := weather.NewService(weatherRepo)
 	broadcaster := &MockAreaBroadcaster{}
+	broadcaster.On("BroadcastToArea", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
 
 	tm := NewTickerManager(registry, eventStore, nil, weatherService, broadcaster)
 	defer tm.StopAll()
@@ -309,6 +345,8 @@ func TestTickerManager_WeatherIntegration(t *testing.T) {
 
 	// Expect SaveWeatherState to be called eventually
 	weatherRepo.On("SaveWeatherState", mock.Anything, mock.Anything).Return(nil)
+	weatherRepo.On("GetPressureField", mock.Anything, worldID).Return(nil, nil).Maybe()
+	weatherRepo.On("SavePressureField", mock.Anything, worldID, mock.Anything).Return(nil).Maybe()
 
 	// Spawn ticker with high dilation to trigger update quickly
 	// Default weather update interval is 30 game minutes.
@@ -338,3 +376,29 @@ func TestTickerManager_WeatherIntegration(t *testing.T) {
 
 	tm.StopTicker(worldID)
 }
+
+func TestTickerManager_BroadcastWeather_AroundItsCell(t *testing.T) {
+	weatherService := weather.NewService(&MockWeatherRepository{})
+	broadcaster := &MockAreaBroadcaster{}
+	tm := NewTickerManager(NewRegistry(), &MockEventStore{}, nil, weatherService, broadcaster)
+
+	worldID := uuid.New()
+	stormCell, calmCell := uuid.New(), uuid.New()
+	weatherService.InitializeWorldWeather(context.Background(), worldID, nil, []*weather.GeographyCell{
+		{CellID: stormCell, Location: geography.Point{X: 40, Y: 70}},
+		{CellID: calmCell, Location: geography.Point{X: 900, Y: 900}},
+	})
+
+	var sent broadcast.GameMessage
+	broadcaster.On("BroadcastToArea", worldID, spatial.Position{X: 40, Y: 70}, WeatherBroadcastRadius, broadcast.MessageTypeGameMessage, mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.Get(4).(broadcast.GameMessage) }).Once()
+
+	tm.broadcastWeather(worldID, map[uuid.UUID]string{
+		stormCell:  "A storm rolls in.",
+		uuid.New(): "Weather over a cell the service doesn't know.",
+	})
+
+	broadcaster.AssertExpectations(t)
+	assert.Equal(t, "weather", sent.Type)
+	assert.Equal(t, "A storm rolls in.", sent.Text)
+}
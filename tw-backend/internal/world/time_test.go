@@ -63,6 +63,17 @@ func TestTimeOfDayDescriptors(t *testing.T) {
 	}
 }
 
+func TestYearCalculation(t *testing.T) {
+	seasonLength := 90 * 24 * time.Hour
+	yearLength := seasonLength * 4
+
+	assert.Equal(t, int64(0), CalculateYear(0, seasonLength))
+	assert.Equal(t, int64(0), CalculateYear(yearLength-time.Hour, seasonLength))
+	assert.Equal(t, int64(1), CalculateYear(yearLength, seasonLength))
+	assert.Equal(t, int64(3), CalculateYear(3*yearLength+seasonLength, seasonLength))
+	assert.Equal(t, int64(0), CalculateYear(yearLength, 0))
+}
+
 func TestSeasonCalculation(t *testing.T) {
 	seasonLength := 90 * 24 * time.Hour // 90 days
 	yearLength := seasonLength * 4
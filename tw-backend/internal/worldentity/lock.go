@@ -0,0 +1,217 @@
+package worldentity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Metadata keys for doors and containers. Entity.Locked is separate: it
+// pins an entity in place, while a locked door or container can't be opened.
+const (
+	MetaOpenable      = "openable"       // OpenableDoor or OpenableContainer
+	MetaOpen          = "open"           // bool
+	MetaLocked        = "locked"         // bool
+	MetaKeyItemID     = "key_item_id"    // Inventory item ID of the key
+	MetaForceStrength = "force_strength" // Might that forces the lock outright; unset if none does
+	MetaForceDC       = "force_dc"       // Difficulty of a Might check to force the lock; unset if it can't be rolled
+	MetaContents      = "contents"       // [{"name", "description"}], revealed on opening
+)
+
+// Kinds of openable entity
+const (
+	OpenableDoor      = "door"
+	OpenableContainer = "container"
+)
+
+var (
+	ErrNotOpenable = errors.New("entity cannot be opened")
+	ErrAlreadyOpen = errors.New("entity is already open")
+	ErrLocked      = errors.New("entity is locked")
+)
+
+// Opener is who is trying to open a lock
+type Opener struct {
+	KeyItemIDs []uuid.UUID // Items they carry
+	Strength   int         // Might, for forcing the lock outright
+	// Force, if set, rolls a check to force a lock with a ForceDC that
+	// Strength alone doesn't open
+	Force func(difficulty int) bool
+}
+
+// OpenResult describes how an entity was opened
+type OpenResult struct {
+	Unlocked bool           // With its key
+	Forced   bool           // By strength
+	Revealed []*WorldEntity // Container contents, now lootable items
+}
+
+// OpenableKind returns OpenableDoor, OpenableContainer, or "" if the entity
+// can't be opened
+func (e *WorldEntity) OpenableKind() string {
+	if e.Metadata == nil {
+		return ""
+	}
+	kind, _ := e.Metadata[MetaOpenable].(string)
+	return kind
+}
+
+// IsOpen reports whether a door or container is open
+func (e *WorldEntity) IsOpen() bool {
+	open, _ := e.Metadata[MetaOpen].(bool)
+	return open
+}
+
+// IsLockedShut reports whether a door or container is locked
+func (e *WorldEntity) IsLockedShut() bool {
+	locked, _ := e.Metadata[MetaLocked].(bool)
+	return locked
+}
+
+// KeyItemID returns the ID of the item that unlocks the entity, if any
+func (e *WorldEntity) KeyItemID() (uuid.UUID, bool) {
+	raw, _ := e.Metadata[MetaKeyItemID].(string)
+	id, err := uuid.Parse(raw)
+	return id, err == nil
+}
+
+// ForceStrength returns the Might that forces the entity's lock outright,
+// or 0 if none does
+func (e *WorldEntity) ForceStrength() int {
+	return metaInt(e.Metadata[MetaForceStrength])
+}
+
+// ForceDC returns the difficulty of the check to force the entity's lock, or
+// 0 if it can't be forced by a roll
+func (e *WorldEntity) ForceDC() int {
+	return metaInt(e.Metadata[MetaForceDC])
+}
+
+// Forceable reports whether the entity's lock can be forced at all
+func (e *WorldEntity) Forceable() bool {
+	return e.ForceStrength() > 0 || e.ForceDC() > 0
+}
+
+// metaInt reads a number from metadata, as decoded from JSON or set in Go
+func metaInt(raw interface{}) int {
+	switch v := raw.(type) {
+	case float64: // Decoded from JSON
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+// forces reports whether the opener forces the entity's lock: outright with
+// enough Might, or else by a check against its ForceDC
+func (o Opener) forces(e *WorldEntity) bool {
+	if strength := e.ForceStrength(); strength > 0 && o.Strength >= strength {
+		return true
+	}
+	if dc := e.ForceDC(); dc > 0 && o.Force != nil {
+		return o.Force(dc)
+	}
+	return false
+}
+
+// Open opens a door or container. A locked one opens if the opener carries
+// its key, or manages to force it; otherwise ErrLocked is returned.
+// An open door no longer blocks movement. A container's contents are placed
+// beside it as lootable items, once.
+func (s *Service) Open(ctx context.Context, entity *WorldEntity, opener Opener) (*OpenResult, error) {
+	kind := entity.OpenableKind()
+	if kind != OpenableDoor && kind != OpenableContainer {
+		return nil, ErrNotOpenable
+	}
+	if entity.IsOpen() {
+		return nil, ErrAlreadyOpen
+	}
+
+	result := &OpenResult{}
+	if entity.IsLockedShut() {
+		keyID, hasLock := entity.KeyItemID()
+		for _, id := range opener.KeyItemIDs {
+			if hasLock && id == keyID {
+				result.Unlocked = true
+				break
+			}
+		}
+		if !result.Unlocked {
+			if !opener.forces(entity) {
+				return nil, ErrLocked
+			}
+			result.Forced = true
+		}
+	}
+
+	// Work on a copy so a failed update leaves the cached entity untouched
+	opened := *entity
+	opened.Metadata = make(map[string]interface{}, len(entity.Metadata))
+	for k, v := range entity.Metadata {
+		opened.Metadata[k] = v
+	}
+	opened.Metadata[MetaOpen] = true
+	opened.Metadata[MetaLocked] = false
+	if kind == OpenableDoor {
+		opened.Collision = false
+	}
+	contents := containerContents(opened.Metadata[MetaContents])
+	delete(opened.Metadata, MetaContents)
+
+	if err := s.Update(ctx, &opened); err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", entity.Name, err)
+	}
+	*entity = opened
+
+	for _, item := range contents {
+		loot := &WorldEntity{
+			ID:           uuid.New(),
+			WorldID:      entity.WorldID,
+			EntityType:   EntityTypeItem,
+			Name:         item.name,
+			Description:  item.description,
+			X:            entity.X,
+			Y:            entity.Y,
+			Z:            entity.Z,
+			Interactable: true,
+		}
+		if err := s.Create(ctx, loot); err != nil {
+			return result, fmt.Errorf("failed to reveal %s: %w", item.name, err)
+		}
+		result.Revealed = append(result.Revealed, loot)
+	}
+	return result, nil
+}
+
+type containerItem struct {
+	name, description string
+}
+
+// containerContents reads MetaContents, as decoded from JSON or set in Go
+func containerContents(raw interface{}) []containerItem {
+	var entries []map[string]interface{}
+	switch v := raw.(type) {
+	case []map[string]interface{}:
+		entries = v
+	case []interface{}:
+		for _, e := range v {
+			if m, ok := e.(map[string]interface{}); ok {
+				entries = append(entries, m)
+			}
+		}
+	}
+
+	var items []containerItem
+	for _, e := range entries {
+		name, _ := e["name"].(string)
+		if name == "" {
+			continue
+		}
+		description, _ := e["description"].(string)
+		items = append(items, containerItem{name: name, description: description})
+	}
+	return items
+}
@@ -6,24 +6,65 @@ import (
 	"math"
 	"sync"
 
+	"tw-backend/internal/spatial"
+
 	"github.com/google/uuid"
 )
 
+// indexCellSize is the rough width in meters of a spatial index cell
+const indexCellSize = 100.0
+
+// SurfaceLookup reports the circumference of a spherical world, or false for
+// a world whose positions are flat
+type SurfaceLookup func(ctx context.Context, worldID uuid.UUID) (circumference float64, ok bool)
+
 // Service manages world entities with caching
 type Service struct {
 	repo  Repository
 	cache map[uuid.UUID][]*WorldEntity // In-memory cache per world
 	mu    sync.RWMutex
+
+	surface SurfaceLookup
+	// Spatial index per spherical world; nil for a world known to be flat
+	indexes map[uuid.UUID]*worldIndex
+	// Changes made to worlds whose index is being built
+	building map[uuid.UUID]*indexBuild
+}
+
+// worldIndex is a spherical world's entities, indexed by position
+type worldIndex struct {
+	grid     *spatial.SphereIndex
+	entities map[uuid.UUID]*WorldEntity
+}
+
+// indexBuild records the entities created, updated or deleted while a
+// world's index is built from a snapshot, to replay onto it
+type indexBuild struct {
+	changed map[uuid.UUID]*WorldEntity
+	deleted map[uuid.UUID]bool
 }
 
 // NewService creates a new WorldEntity service
 func NewService(repo Repository) *Service {
 	return &Service{
-		repo:  repo,
-		cache: make(map[uuid.UUID][]*WorldEntity),
+		repo:     repo,
+		cache:    make(map[uuid.UUID][]*WorldEntity),
+		indexes:  make(map[uuid.UUID]*worldIndex),
+		building: make(map[uuid.UUID]*indexBuild),
 	}
 }
 
+// SetSurfaceLookup lets GetEntitiesAt use a spatial index for spherical
+// worlds, where distances are measured over the sphere. Without it, every
+// world is scanned linearly with flat distances.
+func (s *Service) SetSurfaceLookup(lookup SurfaceLookup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.surface = lookup
+	s.indexes = make(map[uuid.UUID]*worldIndex)
+	s.building = make(map[uuid.UUID]*indexBuild)
+}
+
 // Create adds a new entity to the world
 func (s *Service) Create(ctx context.Context, entity *WorldEntity) error {
 	if err := s.repo.Create(ctx, entity); err != nil {
@@ -31,6 +72,7 @@ func (s *Service) Create(ctx context.Context, entity *WorldEntity) error {
 	}
 	// Invalidate cache for this world
 	s.invalidateCache(entity.WorldID)
+	s.indexEntity(entity)
 	return nil
 }
 
@@ -62,8 +104,22 @@ func (s *Service) GetEntitiesInWorld(ctx context.Context, worldID uuid.UUID) ([]
 	return entities, nil
 }
 
-// GetEntitiesAt returns entities within radius of position
+// GetEntitiesAt returns entities within radius of position. In a spherical
+// world (see SetSurfaceLookup) the radius is measured over the sphere and the
+// world's spatial index is queried instead of every entity.
 func (s *Service) GetEntitiesAt(ctx context.Context, worldID uuid.UUID, x, y, radius float64) ([]*WorldEntity, error) {
+	if index, err := s.worldIndex(ctx, worldID); err != nil {
+		return nil, err
+	} else if index != nil {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		var result []*WorldEntity
+		for _, id := range index.grid.QueryRadius(spatial.Position{X: x, Y: y}, radius) {
+			result = append(result, index.entities[id])
+		}
+		return result, nil
+	}
+
 	// Use cached entities if available for small radius queries
 	entities, err := s.GetEntitiesInWorld(ctx, worldID)
 	if err != nil {
@@ -147,6 +203,7 @@ func (s *Service) Update(ctx context.Context, entity *WorldEntity) error {
 		return err
 	}
 	s.invalidateCache(entity.WorldID)
+	s.indexEntity(entity)
 	return nil
 }
 
@@ -162,9 +219,104 @@ func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 	s.invalidateCache(entity.WorldID)
+
+	s.mu.Lock()
+	if index := s.indexes[entity.WorldID]; index != nil {
+		index.grid.Remove(id)
+		delete(index.entities, id)
+	}
+	if build := s.building[entity.WorldID]; build != nil {
+		delete(build.changed, id)
+		build.deleted[id] = true
+	}
+	s.mu.Unlock()
 	return nil
 }
 
+// worldIndex returns a spherical world's spatial index, building it on first
+// use, or nil for a flat world or when there's no SurfaceLookup. Entities
+// created, updated or deleted while the index is built from the repository
+// are replayed onto it before it's registered.
+func (s *Service) worldIndex(ctx context.Context, worldID uuid.UUID) (*worldIndex, error) {
+	s.mu.Lock()
+	index, known := s.indexes[worldID]
+	lookup := s.surface
+	if known || lookup == nil {
+		s.mu.Unlock()
+		return index, nil
+	}
+	// Concurrent builds of one world share a record of its changes
+	build := s.building[worldID]
+	if build == nil {
+		build = &indexBuild{changed: make(map[uuid.UUID]*WorldEntity), deleted: make(map[uuid.UUID]bool)}
+		s.building[worldID] = build
+	}
+	s.mu.Unlock()
+
+	var entities []*WorldEntity
+	circumference, ok := lookup(ctx, worldID)
+	if ok {
+		var err error
+		if entities, err = s.repo.GetByWorldID(ctx, worldID); err != nil {
+			s.mu.Lock()
+			if s.building[worldID] == build {
+				delete(s.building, worldID)
+			}
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, raced := s.indexes[worldID]; raced {
+		return existing, nil
+	}
+	if ok {
+		index = &worldIndex{
+			grid:     spatial.NewSphereIndex(circumference, indexCellSize),
+			entities: make(map[uuid.UUID]*WorldEntity, len(entities)),
+		}
+		for _, e := range entities {
+			index.insert(e)
+		}
+		for _, e := range build.changed {
+			index.insert(e)
+		}
+		for id := range build.deleted {
+			index.grid.Remove(id)
+			delete(index.entities, id)
+		}
+	}
+	// A ClearCache during the build discards its changes; don't register an
+	// index that missed them
+	if s.building[worldID] == build {
+		delete(s.building, worldID)
+		s.indexes[worldID] = index
+	}
+	return index, nil
+}
+
+// insert adds or moves an entity in the index
+func (index *worldIndex) insert(e *WorldEntity) {
+	index.grid.Insert(e.ID, spatial.Position{X: e.X, Y: e.Y})
+	index.entities[e.ID] = e
+}
+
+// indexEntity moves a created or updated entity in its world's index, if the
+// world has one, or records it for an index being built
+func (s *Service) indexEntity(entity *WorldEntity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if index := s.indexes[entity.WorldID]; index != nil {
+		index.insert(entity)
+	}
+	if build := s.building[entity.WorldID]; build != nil {
+		build.changed[entity.ID] = entity
+		delete(build.deleted, entity.ID)
+	}
+}
+
 // invalidateCache clears the cache for a world
 func (s *Service) invalidateCache(worldID uuid.UUID) {
 	s.mu.Lock()
@@ -176,5 +328,7 @@ func (s *Service) invalidateCache(worldID uuid.UUID) {
 func (s *Service) ClearCache() {
 	s.mu.Lock()
 	s.cache = make(map[uuid.UUID][]*WorldEntity)
+	s.indexes = make(map[uuid.UUID]*worldIndex)
+	s.building = make(map[uuid.UUID]*indexBuild)
 	s.mu.Unlock()
 }
@@ -243,6 +243,83 @@ func TestGetEntitiesAt(t *testing.T) {
 	assert.Equal(t, "statue", result[0].Name)
 }
 
+// Test GetEntitiesAt on a spherical world uses the index, wraps across the
+// meridian and follows entities as they move
+func TestGetEntitiesAt_SphericalWorld(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewService(mockRepo)
+
+	worldID := uuid.New()
+	flatID := uuid.New()
+	ctx := context.Background()
+	service.SetSurfaceLookup(func(_ context.Context, id uuid.UUID) (float64, bool) {
+		return 10000, id == worldID
+	})
+
+	statue := &WorldEntity{ID: uuid.New(), WorldID: worldID, Name: "statue", X: 9998, Y: 0}
+	bench := &WorldEntity{ID: uuid.New(), WorldID: worldID, Name: "bench", X: 50, Y: 50}
+	mockRepo.On("GetByWorldID", ctx, worldID).Return([]*WorldEntity{statue, bench}, nil)
+	mockRepo.On("GetByWorldID", ctx, flatID).Return([]*WorldEntity{
+		{ID: uuid.New(), WorldID: flatID, Name: "edge", X: 9998, Y: 0},
+	}, nil)
+
+	// 4m east of the meridian reaches 2m west of it
+	result, err := service.GetEntitiesAt(ctx, worldID, 2, 0, 5)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "statue", result[0].Name)
+
+	// Flat worlds don't wrap
+	result, err = service.GetEntitiesAt(ctx, flatID, 2, 0, 5)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	// Moving the bench moves it in the index
+	moved := *bench
+	moved.X, moved.Y = 3, 1
+	mockRepo.On("Update", ctx, &moved).Return(nil)
+	assert.NoError(t, service.Update(ctx, &moved))
+
+	result, err = service.GetEntitiesAt(ctx, worldID, 2, 0, 5)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	result, err = service.GetEntitiesAt(ctx, worldID, 50, 50, 5)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	mockRepo.AssertNumberOfCalls(t, "GetByWorldID", 2) // The index isn't rebuilt on update
+}
+
+func TestGetEntitiesAt_SphericalWorld_KeepsChangesMadeDuringBuild(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewService(mockRepo)
+
+	worldID := uuid.New()
+	ctx := context.Background()
+	service.SetSurfaceLookup(func(_ context.Context, id uuid.UUID) (float64, bool) {
+		return 10000, true
+	})
+
+	statue := &WorldEntity{ID: uuid.New(), WorldID: worldID, Name: "statue", X: 2, Y: 0}
+	bench := &WorldEntity{ID: uuid.New(), WorldID: worldID, Name: "bench", X: 3, Y: 0}
+	fountain := &WorldEntity{ID: uuid.New(), WorldID: worldID, Name: "fountain", X: 1, Y: 0}
+	mockRepo.On("Create", ctx, fountain).Return(nil)
+	mockRepo.On("Delete", ctx, bench.ID).Return(nil)
+	mockRepo.On("GetByID", ctx, bench.ID).Return(bench, nil)
+	// The fountain is placed and the bench removed after the snapshot is read
+	mockRepo.On("GetByWorldID", ctx, worldID).Return([]*WorldEntity{statue, bench}, nil).Once().Run(func(mock.Arguments) {
+		assert.NoError(t, service.Create(ctx, fountain))
+		assert.NoError(t, service.Delete(ctx, bench.ID))
+	})
+
+	result, err := service.GetEntitiesAt(ctx, worldID, 2, 0, 5)
+	assert.NoError(t, err)
+	names := make([]string, 0, len(result))
+	for _, e := range result {
+		names = append(names, e.Name)
+	}
+	assert.ElementsMatch(t, []string{"statue", "fountain"}, names)
+}
+
 // Test CollisionRadius with custom metadata
 func TestCollisionRadius_CustomMetadata(t *testing.T) {
 	entity := &WorldEntity{
@@ -344,3 +421,54 @@ func TestCaching_InvalidatesOnCreate(t *testing.T) {
 	// Should have been called twice
 	mockRepo.AssertNumberOfCalls(t, "GetByWorldID", 2)
 }
+
+// Test Open refuses locks the opener can neither unlock nor force
+func TestOpen_LockedAndTooWeak(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewService(mockRepo)
+	ctx := context.Background()
+
+	gate := &WorldEntity{ID: uuid.New(), Name: "gate", Metadata: map[string]interface{}{
+		MetaOpenable:      OpenableDoor,
+		MetaLocked:        true,
+		MetaForceStrength: 80.0,
+	}}
+	_, err := service.Open(ctx, gate, Opener{KeyItemIDs: []uuid.UUID{uuid.New()}, Strength: 50})
+	assert.ErrorIs(t, err, ErrLocked)
+
+	statue := &WorldEntity{ID: uuid.New(), Name: "statue"}
+	_, err = service.Open(ctx, statue, Opener{Strength: 100})
+	assert.ErrorIs(t, err, ErrNotOpenable)
+
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestOpen_ForceRollAgainstDC(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := NewService(mockRepo)
+	ctx := context.Background()
+
+	gate := &WorldEntity{ID: uuid.New(), Name: "gate", Metadata: map[string]interface{}{
+		MetaOpenable:      OpenableDoor,
+		MetaLocked:        true,
+		MetaForceStrength: 80.0,
+		MetaForceDC:       60.0,
+	}}
+	var rolledAgainst int
+	_, err := service.Open(ctx, gate, Opener{Strength: 50, Force: func(difficulty int) bool {
+		rolledAgainst = difficulty
+		return false
+	}})
+	assert.ErrorIs(t, err, ErrLocked, "a failed roll leaves it shut")
+	assert.Equal(t, 60, rolledAgainst, "the roll is against the DC, not the Might threshold")
+	mockRepo.AssertNotCalled(t, "Update")
+
+	// Enough Might forces it without a roll
+	mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+	result, err := service.Open(ctx, gate, Opener{Strength: 80, Force: func(int) bool {
+		t.Fatal("no roll is needed")
+		return false
+	}})
+	assert.NoError(t, err)
+	assert.True(t, result.Forced)
+}
@@ -75,3 +75,12 @@ func (e *WorldEntity) GetGlyph() string {
 		return "?"
 	}
 }
+
+// MetaPushDC is the difficulty of a Might check to shift a heavy entity;
+// unset if it moves freely
+const MetaPushDC = "push_dc"
+
+// PushDC returns the difficulty of shifting the entity, or 0 if anyone can
+func (e *WorldEntity) PushDC() int {
+	return metaInt(e.Metadata[MetaPushDC])
+}
@@ -0,0 +1,121 @@
+package astronomy
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Orbital evolution constants
+const (
+	// LunarRecessionRate is how fast Earth's Moon drifts away today (m/year)
+	LunarRecessionRate = 0.038
+
+	// planetDayLength is the planet's rotation period in seconds. Moons
+	// outside the synchronous orbit it sets recede; moons inside spiral in.
+	planetDayLength = 86400.0
+)
+
+// OrbitEventType is what happened to a satellite whose orbit evolved too far
+type OrbitEventType string
+
+const (
+	// OrbitBreakup means the moon fell inside the Roche limit and was torn apart
+	OrbitBreakup OrbitEventType = "breakup"
+	// OrbitEscape means the moon drifted past the Hill sphere and was lost
+	OrbitEscape OrbitEventType = "escape"
+)
+
+// OrbitEvent flags a satellite lost during EvolveOrbits
+type OrbitEvent struct {
+	Type      OrbitEventType
+	Satellite Satellite
+}
+
+// SynchronousOrbit returns the distance in meters at which a moon's period
+// matches the planet's day
+func SynchronousOrbit(planetMass float64) float64 {
+	return math.Cbrt(GravitationalConstant * planetMass * planetDayLength * planetDayLength / (4 * math.Pi * math.Pi))
+}
+
+// HillSphere returns the distance in meters beyond which the star's pull
+// wins and a moon escapes (HillSphereLimit for an Earth-mass planet)
+func HillSphere(planetMass float64) float64 {
+	return HillSphereLimit * math.Cbrt(planetMass/EarthMassKg)
+}
+
+// EvolveOrbits advances the satellites' orbits by years of tidal exchange
+// with the planet, updating Distance and Period in place.
+//
+// Tides raised on the planet pull a moon beyond the synchronous orbit
+// outward (Luna recedes 3.8 cm/year) and drag one inside it inward (Phobos).
+// The rate scales with the moon's mass and falls steeply with distance:
+// da/dt ∝ m / √M × a^(-11/2). That's integrated exactly, so one long step
+// matches many short ones.
+//
+// Returns an event for each moon now inside the Roche limit (to break up,
+// see GenerateRings) or beyond the Hill sphere (lost). Those moons stay in
+// the slice; RemoveLostMoons drops them.
+func EvolveOrbits(satellites []Satellite, years int64, planetMass float64) []OrbitEvent {
+	if years <= 0 || planetMass <= 0 {
+		return nil
+	}
+
+	syncOrbit := SynchronousOrbit(planetMass)
+	rocheLimit := RocheLimit(planetMass)
+	hillSphere := HillSphere(planetMass)
+
+	var events []OrbitEvent
+	for i := range satellites {
+		sat := &satellites[i]
+		if sat.Distance <= 0 {
+			continue
+		}
+
+		// Work in units of the Moon's distance to keep the powers in range
+		x := sat.Distance / MoonDistanceMeters
+		rate := LunarRecessionRate / MoonDistanceMeters * (sat.Mass / MoonMassKg) * math.Sqrt(EarthMassKg/planetMass)
+		change := 6.5 * rate * float64(years)
+		if sat.Distance < syncOrbit {
+			change = -change
+		}
+
+		// a^(13/2) changes linearly with time
+		next := math.Pow(x, 6.5) + change
+		if next <= 0 {
+			// Crashed all the way down; it breaks up long before this
+			sat.Distance = PlanetRadiusForMass(planetMass)
+		} else {
+			sat.Distance = math.Pow(next, 1/6.5) * MoonDistanceMeters
+		}
+		sat.Period = 2 * math.Pi * math.Sqrt(math.Pow(sat.Distance, 3)/(GravitationalConstant*planetMass))
+
+		switch {
+		case sat.Distance < rocheLimit:
+			events = append(events, OrbitEvent{Type: OrbitBreakup, Satellite: *sat})
+		case sat.Distance > hillSphere:
+			events = append(events, OrbitEvent{Type: OrbitEscape, Satellite: *sat})
+		}
+	}
+	return events
+}
+
+// RemoveLostMoons returns the satellites not flagged by events
+func RemoveLostMoons(satellites []Satellite, events []OrbitEvent) []Satellite {
+	if len(events) == 0 {
+		return satellites
+	}
+
+	lost := make(map[uuid.UUID]bool, len(events))
+	for _, event := range events {
+		lost[event.Satellite.ID] = true
+	}
+
+	survivors := make([]Satellite, 0, len(satellites))
+	for _, sat := range satellites {
+		if !lost[sat.ID] {
+			survivors = append(survivors, sat)
+		}
+	}
+	return survivors
+}
@@ -0,0 +1,80 @@
+package astronomy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEvolveOrbits_LunaRecedesAtModernRate verifies the Moon today drifts ~3.8 cm/year
+func TestEvolveOrbits_LunaRecedesAtModernRate(t *testing.T) {
+	moons := []Satellite{{ID: uuid.New(), Mass: MoonMass, Distance: MoonDistance}}
+	EvolveOrbits(moons, 1_000, EarthMass)
+	assert.InDelta(t, MoonDistance+38, moons[0].Distance, 0.1)
+}
+
+// TestEvolveOrbits_CloseMoonRecedes verifies a close moon beyond the
+// synchronous orbit moves outward, fast at first
+func TestEvolveOrbits_CloseMoonRecedes(t *testing.T) {
+	start := 60_000e3 // 60,000 km, outside the synchronous orbit
+	require.Greater(t, start, SynchronousOrbit(EarthMass))
+
+	moons := []Satellite{{ID: uuid.New(), Mass: MoonMass, Distance: start}}
+	events := EvolveOrbits(moons, 1_000_000, EarthMass)
+	assert.Empty(t, events)
+	assert.Greater(t, moons[0].Distance, start)
+	assert.Greater(t, moons[0].Period, 0.0)
+
+	// One long step matches many short ones
+	stepped := []Satellite{{ID: uuid.New(), Mass: MoonMass, Distance: start}}
+	for i := 0; i < 10; i++ {
+		EvolveOrbits(stepped, 100_000, EarthMass)
+	}
+	assert.InDelta(t, moons[0].Distance, stepped[0].Distance, moons[0].Distance*1e-9)
+}
+
+// TestEvolveOrbits_DistantMoonLosesStabilizingHold verifies a receding moon's
+// hold on the axial tilt weakens over deep time
+func TestEvolveOrbits_DistantMoonLosesStabilizingHold(t *testing.T) {
+	moons := []Satellite{{ID: uuid.New(), Mass: MoonMass, Distance: 1.05 * MoonDistance}}
+	require.Equal(t, 1.0, CalculateObliquityStability(moons, EarthMass))
+
+	start := moons[0].Distance
+	EvolveOrbits(moons, 500_000_000, EarthMass)
+	assert.Greater(t, moons[0].Distance, start)
+	assert.Equal(t, 0.1, CalculateObliquityStability(moons, EarthMass),
+		"A moon that drifted away should no longer steady the axis")
+}
+
+// TestEvolveOrbits_InnerMoonBreaksUp verifies a moon inside the synchronous
+// orbit spirals in and is flagged for breakup at the Roche limit
+func TestEvolveOrbits_InnerMoonBreaksUp(t *testing.T) {
+	phobos := Satellite{ID: uuid.New(), Name: "Doomed", Mass: MoonMass * 0.1, Distance: 30_000e3}
+	outer := Satellite{ID: uuid.New(), Name: "Outer", Mass: MoonMass, Distance: MoonDistance}
+	moons := []Satellite{phobos, outer}
+
+	events := EvolveOrbits(moons, 10_000_000, EarthMass)
+	require.Len(t, events, 1)
+	assert.Equal(t, OrbitBreakup, events[0].Type)
+	assert.Equal(t, phobos.ID, events[0].Satellite.ID)
+	assert.Less(t, moons[0].Distance, RocheLimit(EarthMass))
+
+	// The debris forms rings, and the moon is gone
+	require.NotNil(t, GenerateRings(1, EarthMass, moons))
+	survivors := RemoveLostMoons(moons, events)
+	require.Len(t, survivors, 1)
+	assert.Equal(t, outer.ID, survivors[0].ID)
+}
+
+// TestEvolveOrbits_EscapesHillSphere verifies a moon past the Hill sphere is lost
+func TestEvolveOrbits_EscapesHillSphere(t *testing.T) {
+	moons := []Satellite{{ID: uuid.New(), Mass: MoonMass, Distance: HillSphere(EarthMass) * 1.01}}
+	events := EvolveOrbits(moons, 1_000, EarthMass)
+	require.Len(t, events, 1)
+	assert.Equal(t, OrbitEscape, events[0].Type)
+	assert.Empty(t, RemoveLostMoons(moons, events))
+
+	assert.Nil(t, EvolveOrbits(moons, 0, EarthMass), "No time, no change")
+}
@@ -54,16 +54,24 @@ func CalculateTidalStress(moons []Satellite) float64 {
 //   - If total moon mass / planet mass > 1% → 1.0 (Stable, like Earth)
 //   - Otherwise → 0.1 (Chaotic, like Mars)
 //
+// The torque that steadies the axis falls off with distance cubed, so a moon
+// beyond Earth's Moon's distance counts for less of its mass. As a moon
+// recedes over deep time (see EvolveOrbits) its hold on the axis weakens.
+//
 // Returns 0.1 for planets with no moons or small moons.
 func CalculateObliquityStability(moons []Satellite, planetMass float64) float64 {
 	if len(moons) == 0 || planetMass <= 0 {
 		return 0.1 // Chaotic without stabilizing moon
 	}
 
-	// Sum total moon mass
+	// Sum total moon mass, weighted by distance
 	var totalMoonMass float64
 	for _, moon := range moons {
-		totalMoonMass += moon.Mass
+		weight := 1.0
+		if moon.Distance > MoonDistanceMeters {
+			weight = math.Pow(MoonDistanceMeters/moon.Distance, 3)
+		}
+		totalMoonMass += moon.Mass * weight
 	}
 
 	// Check if total moon mass exceeds stability threshold
@@ -100,6 +108,20 @@ func CalculateImpactShielding(moons []Satellite) float64 {
 	return shielding
 }
 
+// CalculateImpactShieldingWithRings adds the shielding of a ring system to
+// CalculateImpactShielding. Ring material sweeps up small impactors: 0.05 per
+// Moon-mass of material at full density, capped at 0.10. A nil ring system
+// adds nothing.
+func CalculateImpactShieldingWithRings(moons []Satellite, rings *RingSystem) float64 {
+	shielding := CalculateImpactShielding(moons)
+	if rings == nil {
+		return shielding
+	}
+
+	ringShielding := ringShieldingPerLuna * (rings.Mass / MoonMassKg) * rings.AverageDensity()
+	return shielding + math.Min(ringShielding, maxRingShielding)
+}
+
 // CalculateTotalTidalHeating estimates tidal heating in watts.
 //
 // Tidal heating occurs when a moon's orbit causes tidal flexing of the planet.
@@ -0,0 +1,142 @@
+package astronomy
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// Ring system constants
+const (
+	// ringInnerFactor is the inner edge of a ring system in planet radii;
+	// material any closer falls into the atmosphere (Saturn's D ring: ~1.1)
+	ringInnerFactor = 1.1
+
+	// ringShieldingPerLuna is the impact shielding per Moon-mass of ring
+	// material at full density
+	ringShieldingPerLuna = 0.05
+
+	// maxRingShielding caps the shielding a ring system adds
+	maxRingShielding = 0.10
+)
+
+// RingBand is one concentric band of a ring system
+type RingBand struct {
+	// InnerRadius from planet center in meters
+	InnerRadius float64 `json:"inner_radius"`
+	// OuterRadius from planet center in meters
+	OuterRadius float64 `json:"outer_radius"`
+	// Density is how opaque the band is (0.0 = empty gap, 1.0 = solid sheet)
+	Density float64 `json:"density"`
+}
+
+// RingSystem is the debris of moons torn apart inside the Roche limit
+type RingSystem struct {
+	// InnerRadius from planet center in meters
+	InnerRadius float64 `json:"inner_radius"`
+	// OuterRadius from planet center in meters (the Roche limit)
+	OuterRadius float64 `json:"outer_radius"`
+	// Mass of ring material in kilograms
+	Mass float64 `json:"mass"`
+	// Bands from innermost to outermost, with gaps between them
+	Bands []RingBand `json:"bands"`
+	// DestroyedMoons are the IDs of the satellites that became the rings
+	DestroyedMoons []uuid.UUID `json:"destroyed_moons"`
+}
+
+// PlanetRadiusForMass estimates a rocky planet's radius in meters, assuming
+// Earth's density: radius ∝ mass^(1/3)
+func PlanetRadiusForMass(planetMass float64) float64 {
+	return EarthRadiusMeters * math.Cbrt(planetMass/EarthMassKg)
+}
+
+// RocheLimit returns the distance in meters inside which tidal forces tear
+// a satellite apart (RocheLimitFactor × planet radius)
+func RocheLimit(planetMass float64) float64 {
+	return RocheLimitFactor * PlanetRadiusForMass(planetMass)
+}
+
+// GenerateRings forms a ring system from every satellite orbiting inside the
+// Roche limit. Those moons are destroyed into ring material; use
+// SurvivingMoons to drop them from the satellite list.
+//
+// The rings span from just above the planet to the Roche limit, split into
+// 3-5 bands of varying density separated by gaps (like Saturn's Cassini
+// Division). The seed makes the band layout reproducible.
+//
+// Returns nil if no satellite is inside the Roche limit.
+func GenerateRings(seed int64, planetMass float64, satellites []Satellite) *RingSystem {
+	rocheLimit := RocheLimit(planetMass)
+
+	rings := &RingSystem{
+		InnerRadius: ringInnerFactor * PlanetRadiusForMass(planetMass),
+		OuterRadius: rocheLimit,
+	}
+	for _, sat := range satellites {
+		if sat.Distance < rocheLimit {
+			rings.Mass += sat.Mass
+			rings.DestroyedMoons = append(rings.DestroyedMoons, sat.ID)
+		}
+	}
+	if len(rings.DestroyedMoons) == 0 {
+		return nil
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	count := 3 + rng.Intn(3)
+	width := (rings.OuterRadius - rings.InnerRadius) / float64(count)
+	for i := 0; i < count; i++ {
+		// Each band fills most of its slot; the rest is a gap cleared by resonances
+		start := rings.InnerRadius + width*float64(i)
+		fill := 0.6 + rng.Float64()*0.35
+		rings.Bands = append(rings.Bands, RingBand{
+			InnerRadius: start,
+			OuterRadius: start + width*fill,
+			Density:     0.2 + rng.Float64()*0.8,
+		})
+	}
+
+	return rings
+}
+
+// SurvivingMoons returns the satellites that weren't destroyed to form rings
+func SurvivingMoons(satellites []Satellite, rings *RingSystem) []Satellite {
+	if rings == nil {
+		return satellites
+	}
+
+	destroyed := make(map[uuid.UUID]bool, len(rings.DestroyedMoons))
+	for _, id := range rings.DestroyedMoons {
+		destroyed[id] = true
+	}
+
+	survivors := make([]Satellite, 0, len(satellites))
+	for _, sat := range satellites {
+		if !destroyed[sat.ID] {
+			survivors = append(survivors, sat)
+		}
+	}
+	return survivors
+}
+
+// AverageDensity returns the mean band density weighted by band width
+func (r *RingSystem) AverageDensity() float64 {
+	var weighted, width float64
+	for _, band := range r.Bands {
+		w := band.OuterRadius - band.InnerRadius
+		weighted += band.Density * w
+		width += w
+	}
+	if width == 0 {
+		return 0
+	}
+	return weighted / width
+}
+
+// FormRings turns any moons inside the Roche limit into a ring system,
+// removing them from Satellites. Rings is nil when no moon was close enough.
+func (ps *PlanetarySystem) FormRings() {
+	ps.Rings = GenerateRings(ps.Seed, ps.PlanetMass, ps.Satellites)
+	ps.Satellites = SurvivingMoons(ps.Satellites, ps.Rings)
+}
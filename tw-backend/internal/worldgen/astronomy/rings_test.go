@@ -0,0 +1,92 @@
+package astronomy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRocheLimit verifies the limit for an Earth-mass planet
+func TestRocheLimit(t *testing.T) {
+	assert.InDelta(t, EarthRadius, PlanetRadiusForMass(EarthMass), 1.0)
+	assert.InDelta(t, RocheLimitFactor*EarthRadius, RocheLimit(EarthMass), 1.0)
+
+	// Heavier planets are bigger at the same density, so their limit is farther out
+	assert.Greater(t, RocheLimit(8*EarthMass), RocheLimit(EarthMass))
+}
+
+// TestGenerateRings_MoonInsideRocheLimit verifies a moon within the Roche
+// limit is torn into rings and removed from the satellite list
+func TestGenerateRings_MoonInsideRocheLimit(t *testing.T) {
+	doomed := Satellite{ID: uuid.New(), Name: "Doomed", Mass: MoonMass * 0.5, Distance: 2.0 * EarthRadius}
+	safe := Satellite{ID: uuid.New(), Name: "Safe", Mass: MoonMass, Distance: MoonDistance}
+	satellites := []Satellite{doomed, safe}
+
+	rings := GenerateRings(42, EarthMass, satellites)
+	require.NotNil(t, rings, "A moon inside the Roche limit should form rings")
+
+	assert.Equal(t, []uuid.UUID{doomed.ID}, rings.DestroyedMoons)
+	assert.Equal(t, doomed.Mass, rings.Mass, "Ring material comes from the destroyed moon")
+	assert.InDelta(t, RocheLimit(EarthMass), rings.OuterRadius, 1.0)
+	assert.Greater(t, rings.InnerRadius, EarthRadius, "Rings sit above the planet's surface")
+
+	// Bands stay inside the ring system, in order, with gaps between them
+	require.GreaterOrEqual(t, len(rings.Bands), 3)
+	require.LessOrEqual(t, len(rings.Bands), 5)
+	prevOuter := rings.InnerRadius
+	for _, band := range rings.Bands {
+		assert.GreaterOrEqual(t, band.InnerRadius, prevOuter)
+		assert.Greater(t, band.OuterRadius, band.InnerRadius)
+		assert.LessOrEqual(t, band.OuterRadius, rings.OuterRadius)
+		assert.Greater(t, band.Density, 0.0)
+		assert.LessOrEqual(t, band.Density, 1.0)
+		prevOuter = band.OuterRadius
+	}
+
+	survivors := SurvivingMoons(satellites, rings)
+	require.Len(t, survivors, 1)
+	assert.Equal(t, safe.ID, survivors[0].ID)
+
+	// Same seed, same rings
+	assert.Equal(t, rings, GenerateRings(42, EarthMass, satellites))
+}
+
+// TestGenerateRings_NoMoonInsideRocheLimit verifies ordinary moons leave no rings
+func TestGenerateRings_NoMoonInsideRocheLimit(t *testing.T) {
+	moons := GenerateMoons(7, EarthMass, SatelliteConfig{Override: true, Count: 3})
+	rings := GenerateRings(7, EarthMass, moons)
+	assert.Nil(t, rings, "Generated moons all orbit beyond the Roche limit")
+	assert.Equal(t, moons, SurvivingMoons(moons, rings))
+
+	assert.Nil(t, GenerateRings(7, EarthMass, nil))
+}
+
+// TestPlanetarySystem_FormRings verifies the system drops the destroyed moon
+func TestPlanetarySystem_FormRings(t *testing.T) {
+	ps := NewPlanetarySystem(EarthMass, EarthRadius, 3)
+	ps.Satellites = []Satellite{
+		{ID: uuid.New(), Mass: MoonMass, Distance: 1.5 * EarthRadius},
+		{ID: uuid.New(), Mass: MoonMass, Distance: MoonDistance},
+	}
+
+	ps.FormRings()
+	require.NotNil(t, ps.Rings)
+	assert.Equal(t, 1, ps.MoonCount())
+	assert.Equal(t, MoonDistance, ps.Satellites[0].Distance)
+}
+
+// TestCalculateImpactShieldingWithRings verifies ring material adds shielding
+func TestCalculateImpactShieldingWithRings(t *testing.T) {
+	moons := []Satellite{{ID: uuid.New(), Mass: MoonMass, Distance: MoonDistance}}
+	moonOnly := CalculateImpactShielding(moons)
+
+	assert.Equal(t, moonOnly, CalculateImpactShieldingWithRings(moons, nil))
+
+	light := &RingSystem{Mass: MoonMass * 0.5, Bands: []RingBand{{InnerRadius: 1, OuterRadius: 2, Density: 1}}}
+	assert.InDelta(t, moonOnly+0.025, CalculateImpactShieldingWithRings(moons, light), 1e-9)
+
+	heavy := &RingSystem{Mass: MoonMass * 10, Bands: []RingBand{{InnerRadius: 1, OuterRadius: 2, Density: 1}}}
+	assert.InDelta(t, moonOnly+maxRingShielding, CalculateImpactShieldingWithRings(moons, heavy), 1e-9, "Ring shielding is capped")
+}
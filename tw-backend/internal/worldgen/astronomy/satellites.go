@@ -71,6 +71,8 @@ type PlanetarySystem struct {
 	PlanetRadius float64
 	// Satellites is the list of natural satellites
 	Satellites []Satellite
+	// Rings is the ring system, if any (see FormRings)
+	Rings *RingSystem
 	// Seed used for generation
 	Seed int64
 }
@@ -164,3 +164,47 @@ func TestClassifyBiome_TemperatureShiftChangesResult(t *testing.T) {
 	assert.Contains(t, []BiomeType{BiomeTaiga, BiomeTundra}, coldBiome,
 		"Cold temperature should produce cold biome")
 }
+
+// TestClassifyBiomeBlended_ForestGrasslandEcotone verifies a cell just past
+// the temperate forest/grassland rainfall boundary blends both biomes.
+func TestClassifyBiomeBlended_ForestGrasslandEcotone(t *testing.T) {
+	// Temperate forest needs moisture > 0.6, i.e. more than 1200mm/year
+	edge := ClassifyBiomeBlended(15.0, 1260.0, 0.5, 200.0, 0.0)
+	assert.Equal(t, BiomeDeciduousForest, edge.Primary)
+	assert.Equal(t, BiomeGrassland, edge.Secondary)
+	assert.InDelta(t, 0.35, edge.Blend, 0.01, "60mm from the boundary is 0.3 of the ecotone width")
+
+	// The grassland side mirrors it
+	other := ClassifyBiomeBlended(15.0, 1140.0, 0.5, 200.0, 0.0)
+	assert.Equal(t, BiomeGrassland, other.Primary)
+	assert.Equal(t, BiomeDeciduousForest, other.Secondary)
+	assert.InDelta(t, edge.Blend, other.Blend, 0.01)
+
+	// Closer to the boundary blends more
+	closer := ClassifyBiomeBlended(15.0, 1210.0, 0.5, 200.0, 0.0)
+	assert.Greater(t, closer.Blend, edge.Blend)
+	assert.LessOrEqual(t, closer.Blend, 0.5)
+
+	// The hard label is unchanged
+	assert.Equal(t, ClassifyBiome(15.0, 1260.0, 0.5, 200.0, 0.0), edge.Primary)
+}
+
+// TestClassifyBiomeBlended_PureCells verifies cells far from any boundary,
+// and ocean, don't blend.
+func TestClassifyBiomeBlended_PureCells(t *testing.T) {
+	deep := ClassifyBiomeBlended(15.0, 1600.0, 0.5, 200.0, 0.0)
+	assert.Equal(t, BiomeBlend{Primary: BiomeDeciduousForest, Secondary: BiomeDeciduousForest}, deep)
+
+	ocean := ClassifyBiomeBlended(15.0, 1200.0, 0.5, -100.0, 0.0)
+	assert.Equal(t, BiomeBlend{Primary: BiomeOcean, Secondary: BiomeOcean}, ocean)
+
+	// A coastal cell doesn't blend with the sea
+	coast := ClassifyBiomeBlended(15.0, 1600.0, 0.5, 10.0, 0.0)
+	assert.Zero(t, coast.Blend)
+
+	// Temperature boundaries count too: 19°C is 1°C from tropical
+	warm := ClassifyBiomeBlended(19.0, 440.0, 0.5, 200.0, 0.0)
+	assert.Equal(t, BiomeDesert, warm.Primary)
+	assert.Equal(t, BiomeGrassland, warm.Secondary, "warmer, the same rain makes savanna")
+	assert.InDelta(t, 0.5*(1-1.0/3.0), warm.Blend, 0.01)
+}
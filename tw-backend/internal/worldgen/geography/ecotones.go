@@ -0,0 +1,95 @@
+package geography
+
+// Ecotone widths: how far on either side of a biome boundary the two biomes
+// mix. A cell this far from every boundary is pure.
+const (
+	EcotoneTemperatureWidth = 3.0   // °C
+	EcotoneRainfallWidth    = 200.0 // mm/year
+	EcotoneDrainageWidth    = 0.1   // drainage factor
+	EcotoneElevationWidth   = 200.0 // meters
+)
+
+// ecotoneSearchSteps and ecotoneRefineSteps set how finely
+// ClassifyBiomeBlended locates a boundary
+const (
+	ecotoneSearchSteps = 16
+	ecotoneRefineSteps = 8
+)
+
+// BiomeBlend is a cell's biome with the neighboring biome it shades into
+type BiomeBlend struct {
+	Primary   BiomeType // What ClassifyBiome returns
+	Secondary BiomeType // Nearest other biome; Primary when the cell is pure
+	Blend     float64   // Share of Secondary: 0 (pure) to 0.5 (on the boundary)
+}
+
+// ClassifyBiomeBlended classifies a cell like ClassifyBiome, and also finds
+// the nearest biome boundary in temperature, rainfall, drainage and
+// elevation space, each measured in its ecotone width. The closer the
+// boundary, the more the biome across it blends in, reaching an even mix on
+// the boundary itself. Coastlines aren't ecotones: ocean is never blended.
+func ClassifyBiomeBlended(tempC, rainfallMM, drainage, elevation, seaLevel float64) BiomeBlend {
+	primary := ClassifyBiome(tempC, rainfallMM, drainage, elevation, seaLevel)
+	result := BiomeBlend{Primary: primary, Secondary: primary}
+	if primary == BiomeOcean {
+		return result
+	}
+
+	// Each axis shifts one input by a fraction of its ecotone width
+	axes := []func(f float64) BiomeType{
+		func(f float64) BiomeType {
+			return ClassifyBiome(tempC+f*EcotoneTemperatureWidth, rainfallMM, drainage, elevation, seaLevel)
+		},
+		func(f float64) BiomeType {
+			return ClassifyBiome(tempC, rainfallMM+f*EcotoneRainfallWidth, drainage, elevation, seaLevel)
+		},
+		func(f float64) BiomeType {
+			return ClassifyBiome(tempC, rainfallMM, drainage+f*EcotoneDrainageWidth, elevation, seaLevel)
+		},
+		func(f float64) BiomeType {
+			return ClassifyBiome(tempC, rainfallMM, drainage, elevation+f*EcotoneElevationWidth, seaLevel)
+		},
+	}
+
+	nearest := 1.0
+	for _, classify := range axes {
+		for _, sign := range []float64{1, -1} {
+			dist, other, ok := findBiomeBoundary(primary, func(f float64) BiomeType { return classify(sign * f) })
+			if ok && dist < nearest {
+				nearest, result.Secondary = dist, other
+			}
+		}
+	}
+	if result.Secondary != primary {
+		result.Blend = 0.5 * (1 - nearest)
+	}
+	return result
+}
+
+// findBiomeBoundary walks classify from 0 to 1 and returns where it first
+// leaves primary for a land biome, and that biome
+func findBiomeBoundary(primary BiomeType, classify func(f float64) BiomeType) (float64, BiomeType, bool) {
+	for step := 1; step <= ecotoneSearchSteps; step++ {
+		hi := float64(step) / ecotoneSearchSteps
+		other := classify(hi)
+		if other == primary {
+			continue
+		}
+		if other == BiomeOcean {
+			return 0, "", false
+		}
+
+		// Narrow down the crossing between the last two samples
+		lo := float64(step-1) / ecotoneSearchSteps
+		for i := 0; i < ecotoneRefineSteps; i++ {
+			mid := (lo + hi) / 2
+			if classify(mid) == primary {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		return hi, other, true
+	}
+	return 0, "", false
+}
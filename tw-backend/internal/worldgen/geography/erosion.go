@@ -49,6 +49,16 @@ func ApplyThermalErosion(hm *Heightmap, iterations int, seed int64) {
 	}
 }
 
+// ApplyGlacialErosion scours rock from under advancing ice. scour holds the
+// depth removed at each cell, row-major like Elevations.
+func ApplyGlacialErosion(hm *Heightmap, scour []float64) {
+	for i, depth := range scour {
+		if depth > 0 {
+			hm.Elevations[i] -= depth
+		}
+	}
+}
+
 // ApplyHydraulicErosion simulates rain and water flow to carve valleys
 func ApplyHydraulicErosion(hm *Heightmap, drops int, seed int64) {
 	r := rand.New(rand.NewSource(seed))
@@ -75,3 +75,21 @@ func TestApplyHydraulicErosion_Slope(t *testing.T) {
 		t.Error("Hydraulic erosion resulted in no changes to the heightmap")
 	}
 }
+
+func TestApplyGlacialErosion_ScoursOnlyUnderIce(t *testing.T) {
+	hm := NewHeightmap(4, 4)
+	for i := range hm.Elevations {
+		hm.Elevations[i] = 500
+	}
+	scour := make([]float64, 16)
+	scour[1*4+2] = 30
+
+	ApplyGlacialErosion(hm, scour)
+
+	if got := hm.Get(2, 1); got != 470 {
+		t.Errorf("Expected scoured cell at 470, got %f", got)
+	}
+	if got := hm.Get(1, 1); got != 500 {
+		t.Errorf("Ice-free cell should be untouched, got %f", got)
+	}
+}
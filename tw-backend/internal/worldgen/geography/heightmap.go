@@ -155,6 +155,16 @@ func ApplyThermalErosionSpherical(hm *SphereHeightmap, topology spatial.Topology
 	}
 }
 
+// ApplyGlacialErosionSpherical scours rock from under advancing ice, lowering
+// each cell in scour by its depth
+func ApplyGlacialErosionSpherical(hm *SphereHeightmap, scour map[spatial.Coordinate]float64) {
+	for coord, depth := range scour {
+		if depth > 0 {
+			hm.Set(coord, hm.Get(coord)-depth)
+		}
+	}
+}
+
 // ApplyHydraulicErosionSpherical simulates water erosion on a sphere
 func ApplyHydraulicErosionSpherical(hm *SphereHeightmap, topology spatial.Topology, numDrops int, seed int64) {
 	// Simplified hydraulic erosion - trace water droplets downhill
@@ -0,0 +1,181 @@
+package geography
+
+import (
+	"container/heap"
+	"math"
+
+	"tw-backend/internal/spatial"
+)
+
+const (
+	// DeltaSedimentDepth is how much (meters) a river mouth builds up
+	DeltaSedimentDepth = 30.0
+
+	// DeltaRadius is how many cells out to sea a delta fans
+	DeltaRadius = 3
+
+	// DeltaMaxAboveSea caps delta land this many meters above sea level
+	DeltaMaxAboveSea = 5.0
+
+	// MaxLakeCells stops a basin fill that would flood too much of the map;
+	// a depression that big is left dry (an inland sea needs more water
+	// than one river brings)
+	MaxLakeCells = 2000
+)
+
+// Lake is water filling a closed depression up to its spill point
+type Lake struct {
+	Cells   []spatial.Coordinate // Flooded cells
+	Level   float64              // Water surface elevation (the spill point)
+	Depth   float64              // Deepest point below the surface (meters)
+	Outlet  spatial.Coordinate   // Rim cell the lake overflows through
+	Inflows int                  // Rivers feeding the lake
+}
+
+// Contains reports whether coord is under the lake
+func (l *Lake) Contains(coord spatial.Coordinate) bool {
+	for _, c := range l.Cells {
+		if c == coord {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateHydrologySpherical traces rivers like GenerateRiversSpherical and
+// returns the lakes they fill: a river ending in a closed depression fills
+// it to the spill point. Flow and filling cross cube face seams through the
+// topology.
+func GenerateHydrologySpherical(hm *SphereHeightmap, seaLevel float64, seed int64) ([]SphericalRiverPath, []Lake) {
+	rivers := GenerateRiversSpherical(hm, seaLevel, seed)
+
+	var lakes []Lake
+	for _, river := range rivers {
+		mouth := river.Points[len(river.Points)-1]
+		if hm.Get(mouth) <= seaLevel {
+			continue
+		}
+		if i := lakeContaining(lakes, mouth); i >= 0 {
+			lakes[i].Inflows++
+			continue
+		}
+		if lake, ok := fillBasin(hm, mouth); ok {
+			lake.Inflows = 1
+			lakes = append(lakes, lake)
+		}
+	}
+	return rivers, lakes
+}
+
+// lakeContaining returns the index of the lake covering coord, or -1
+func lakeContaining(lakes []Lake, coord spatial.Coordinate) int {
+	for i := range lakes {
+		if lakes[i].Contains(coord) {
+			return i
+		}
+	}
+	return -1
+}
+
+// depositDelta fans sediment out to sea from a river mouth, thickest at the
+// mouth and thinning with distance. Deltas build up to just above sea level.
+func depositDelta(hm *SphereHeightmap, mouth spatial.Coordinate, seaLevel float64) {
+	topology := hm.Topology()
+	dist := map[spatial.Coordinate]int{mouth: 0}
+	queue := []spatial.Coordinate{mouth}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		deposit := DeltaSedimentDepth * (1 - float64(dist[cell])/float64(DeltaRadius+1))
+		elev := hm.Get(cell)
+		if limit := seaLevel + DeltaMaxAboveSea; elev < limit {
+			hm.Set(cell, math.Min(elev+deposit, limit))
+		}
+
+		if dist[cell] == DeltaRadius {
+			continue
+		}
+		// Sediment spreads through the water, so the fan opens seaward
+		for _, dir := range []spatial.Direction{spatial.North, spatial.South, spatial.East, spatial.West} {
+			next := topology.GetNeighbor(cell, dir)
+			if _, seen := dist[next]; !seen && hm.Get(next) <= seaLevel {
+				dist[next] = dist[cell] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// fillBasin floods the depression around bottom up to its spill point: the
+// lowest rim cell beyond which the ground falls away again. It fails if
+// bottom isn't in a depression or the lake would take more than
+// MaxLakeCells.
+func fillBasin(hm *SphereHeightmap, bottom spatial.Coordinate) (Lake, bool) {
+	topology := hm.Topology()
+	level := hm.Get(bottom)
+	basin := []spatial.Coordinate{bottom}
+	seen := map[spatial.Coordinate]bool{bottom: true}
+	rim := &cellQueue{}
+
+	push := func(c spatial.Coordinate) {
+		for _, dir := range []spatial.Direction{spatial.North, spatial.South, spatial.East, spatial.West} {
+			next := topology.GetNeighbor(c, dir)
+			if !seen[next] {
+				seen[next] = true
+				heap.Push(rim, cellElevation{next, hm.Get(next)})
+			}
+		}
+	}
+	push(bottom)
+
+	// Priority flood: raise the water over the lowest rim cell until the
+	// next one is lower than the water, which means it's over the pass
+	spilled := false
+	for rim.Len() > 0 {
+		next := heap.Pop(rim).(cellElevation)
+		if next.elev < level {
+			spilled = true
+			break
+		}
+		if len(basin) >= MaxLakeCells {
+			return Lake{}, false
+		}
+		level = next.elev
+		basin = append(basin, next.coord)
+		push(next.coord)
+	}
+	if !spilled {
+		return Lake{}, false // Nowhere lower to spill: the whole world is one basin
+	}
+
+	// The last cell raised is the pass; everything below it is under water
+	lake := Lake{Level: level, Outlet: basin[len(basin)-1]}
+	for _, c := range basin {
+		if depth := level - hm.Get(c); depth > 0 {
+			lake.Cells = append(lake.Cells, c)
+			lake.Depth = math.Max(lake.Depth, depth)
+		}
+	}
+	return lake, len(lake.Cells) > 0
+}
+
+// cellElevation is a cell waiting on the rim of a filling basin
+type cellElevation struct {
+	coord spatial.Coordinate
+	elev  float64
+}
+
+// cellQueue is a min-heap of rim cells by elevation
+type cellQueue []cellElevation
+
+func (q cellQueue) Len() int            { return len(q) }
+func (q cellQueue) Less(i, j int) bool  { return q[i].elev < q[j].elev }
+func (q cellQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *cellQueue) Push(x interface{}) { *q = append(*q, x.(cellElevation)) }
+func (q *cellQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,122 @@
+package geography
+
+import (
+	"math"
+	"testing"
+
+	"tw-backend/internal/spatial"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bowlWorld returns an ocean world whose face 0 is land sloping down into a
+// closed basin at its center, with the sea level
+func bowlWorld(resolution int) (*SphereHeightmap, float64) {
+	topology := spatial.NewCubeSphereTopology(resolution)
+	hm := NewSphereHeightmap(topology)
+	center := resolution / 2
+	for face := 0; face < 6; face++ {
+		for y := 0; y < resolution; y++ {
+			for x := 0; x < resolution; x++ {
+				coord := spatial.Coordinate{Face: face, X: x, Y: y}
+				if face != 0 {
+					hm.Set(coord, -1000)
+					continue
+				}
+				dist := math.Hypot(float64(x-center), float64(y-center))
+				hm.Set(coord, 600+100*dist)
+			}
+		}
+	}
+	return hm, 0
+}
+
+func TestGenerateHydrologySpherical_ClosedBasinFillsLake(t *testing.T) {
+	hm, seaLevel := bowlWorld(16)
+	rivers, lakes := GenerateHydrologySpherical(hm, seaLevel, 7)
+	require.NotEmpty(t, rivers)
+	require.Len(t, lakes, 1, "every river drains into the one basin")
+
+	lake := lakes[0]
+	assert.Equal(t, len(rivers), lake.Inflows)
+	assert.Greater(t, lake.Depth, 0.0)
+	assert.Greater(t, lake.Level, seaLevel)
+	assert.True(t, lake.Contains(spatial.Coordinate{Face: 0, X: 8, Y: 8}), "the basin floor is under water")
+
+	// Plausible shoreline: everything under the surface is lake, and every
+	// cell around the lake stands at or above it
+	topology := hm.Topology()
+	for _, c := range lake.Cells {
+		assert.Less(t, hm.Get(c), lake.Level)
+		for _, dir := range []spatial.Direction{spatial.North, spatial.South, spatial.East, spatial.West} {
+			next := topology.GetNeighbor(c, dir)
+			if !lake.Contains(next) {
+				assert.GreaterOrEqual(t, hm.Get(next), lake.Level, "water would leak out at %v", next)
+			}
+		}
+	}
+
+	// It spills over the lowest point of the rim, which stays dry
+	assert.False(t, lake.Contains(lake.Outlet))
+	assert.Equal(t, lake.Level, hm.Get(lake.Outlet))
+}
+
+func TestFillBasin_CrossesFaces(t *testing.T) {
+	topology := spatial.NewCubeSphereTopology(8)
+	hm := NewSphereHeightmap(topology)
+	for face := 0; face < 6; face++ {
+		for y := 0; y < 8; y++ {
+			for x := 0; x < 8; x++ {
+				hm.Set(spatial.Coordinate{Face: face, X: x, Y: y}, 1000)
+			}
+		}
+	}
+
+	// A two-cell hollow straddling the seam, and a pit further on for the
+	// lake to spill into
+	west := spatial.Coordinate{Face: 0, X: 7, Y: 4}
+	east := topology.GetNeighbor(west, spatial.East)
+	require.NotEqual(t, west.Face, east.Face)
+	hm.Set(west, 500)
+	hm.Set(east, 500)
+	hm.Set(topology.GetNeighbor(topology.GetNeighbor(east, spatial.East), spatial.East), 0)
+
+	lake, ok := fillBasin(hm, west)
+	require.True(t, ok)
+	assert.ElementsMatch(t, []spatial.Coordinate{west, east}, lake.Cells)
+	assert.Equal(t, 1000.0, lake.Level)
+	assert.Equal(t, 500.0, lake.Depth)
+}
+
+func TestFillBasin_NoDepression(t *testing.T) {
+	hm, _ := bowlWorld(16)
+	_, ok := fillBasin(hm, spatial.Coordinate{Face: 0, X: 8, Y: 12})
+	assert.False(t, ok, "a slope holds no water")
+}
+
+func TestDepositDelta(t *testing.T) {
+	topology := spatial.NewCubeSphereTopology(16)
+	hm := NewSphereHeightmap(topology)
+	for face := 0; face < 6; face++ {
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 16; x++ {
+				hm.Set(spatial.Coordinate{Face: face, X: x, Y: y}, -100)
+			}
+		}
+	}
+	mouth := spatial.Coordinate{Face: 0, X: 8, Y: 8}
+	depositDelta(hm, mouth, 0)
+
+	// Thickest at the mouth, thinning out to sea
+	assert.Equal(t, -100+DeltaSedimentDepth, hm.Get(mouth))
+	assert.Less(t, hm.Get(spatial.Coordinate{Face: 0, X: 8, Y: 10}), hm.Get(spatial.Coordinate{Face: 0, X: 8, Y: 9}))
+	assert.Greater(t, hm.Get(spatial.Coordinate{Face: 0, X: 8, Y: 8 + DeltaRadius}), -100.0)
+	assert.Equal(t, -100.0, hm.Get(spatial.Coordinate{Face: 0, X: 8, Y: 9 + DeltaRadius}), "beyond the fan")
+
+	// In shallow water the delta builds just above the surface
+	shallow := spatial.Coordinate{Face: 3, X: 8, Y: 8}
+	hm.Set(shallow, -10)
+	depositDelta(hm, shallow, 0)
+	assert.Equal(t, DeltaMaxAboveSea, hm.Get(shallow))
+}
@@ -113,7 +113,8 @@ type SphericalRiverPath struct {
 }
 
 // GenerateRiversSpherical creates river paths on a spherical heightmap
-// Uses topology-aware neighbor lookups for proper cross-face water flow
+// Uses topology-aware neighbor lookups for proper cross-face water flow.
+// Rivers reaching the sea build a delta fan at their mouth.
 func GenerateRiversSpherical(hm *SphereHeightmap, seaLevel float64, seed int64) []SphericalRiverPath {
 	var rivers []SphericalRiverPath
 	r := rand.New(rand.NewSource(seed))
@@ -150,6 +151,11 @@ func GenerateRiversSpherical(hm *SphereHeightmap, seaLevel float64, seed int64)
 					current := hm.Get(coord)
 					hm.Set(coord, current-20)
 				}
+
+				// Sediment settles where the river slows into the sea
+				if mouth := path[len(path)-1]; hm.Get(mouth) <= seaLevel {
+					depositDelta(hm, mouth, seaLevel)
+				}
 			}
 		}
 	}
@@ -195,3 +195,21 @@ func TestSphereHeightmap_ToFlatHeightmap_SamplesAllFaces(t *testing.T) {
 	// This test will fail if fewer than 4 faces are found
 	t.Logf("Faces found in flat output: %v", facesFound)
 }
+
+func TestApplyGlacialErosionSpherical_ScoursOnlyUnderIce(t *testing.T) {
+	topo := spatial.NewCubeSphereTopology(8)
+	hm := NewSphereHeightmap(topo)
+	iced := spatial.Coordinate{Face: 4, X: 3, Y: 3}
+	bare := spatial.Coordinate{Face: 4, X: 5, Y: 3}
+	hm.Set(iced, 800)
+	hm.Set(bare, 800)
+
+	ApplyGlacialErosionSpherical(hm, map[spatial.Coordinate]float64{iced: 25})
+
+	if got := hm.Get(iced); got != 775 {
+		t.Errorf("Expected scoured cell at 775, got %f", got)
+	}
+	if got := hm.Get(bare); got != 800 {
+		t.Errorf("Ice-free cell should be untouched, got %f", got)
+	}
+}
@@ -3,6 +3,9 @@ package geography
 import (
 	"log"
 	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"tw-backend/internal/debug"
@@ -144,15 +147,26 @@ func ReassignPlateRegions(plates []TectonicPlate, topology spatial.Topology) {
 // ComputeBoundaryCache pre-computes all cells that are at plate boundaries.
 // This is expensive but only needs to run when plates are reassigned.
 // After this, SimulateTectonicsWithCache can process only boundary cells.
+// The work is sharded across runtime.NumCPU() goroutines.
 func ComputeBoundaryCache(plates []TectonicPlate, topology spatial.Topology) *BoundaryCache {
+	return ComputeBoundaryCacheWorkers(plates, topology, runtime.NumCPU())
+}
+
+// ComputeBoundaryCacheWorkers is ComputeBoundaryCache with a set number of
+// goroutines, which the cache keeps for SimulateTectonicsWithCache. One
+// worker runs serially; any count gives the same cache and elevations.
+func ComputeBoundaryCacheWorkers(plates []TectonicPlate, topology spatial.Topology, workers int) *BoundaryCache {
 	resolution := topology.Resolution()
 	totalCells := 6 * resolution * resolution
+	if workers < 1 {
+		workers = 1
+	}
 
 	cache := &BoundaryCache{
-		Cells:      make([]BoundaryCell, 0, totalCells/10), // Expect ~10% boundaries
 		PlateGrid:  make([]int, totalCells),
 		Resolution: resolution,
 		Valid:      true,
+		Workers:    workers,
 	}
 
 	// Initialize with -1 (no plate)
@@ -170,12 +184,46 @@ func ComputeBoundaryCache(plates []TectonicPlate, topology spatial.Topology) *Bo
 		}
 	}
 
-	// Find all boundary cells
-	directions := []spatial.Direction{spatial.North, spatial.South, spatial.East, spatial.West}
+	// Find all boundary cells, each worker scanning a contiguous run of
+	// cells so the shards join back up in serial order
+	shards := make([][]BoundaryCell, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			shards[w] = findBoundaryCells(plates, topology, cache.PlateGrid, totalCells*w/workers, totalCells*(w+1)/workers)
+		}(w)
+	}
+	wg.Wait()
+
+	cache.Cells = make([]BoundaryCell, 0, totalCells/10) // Expect ~10% boundaries
+	for _, shard := range shards {
+		cache.Cells = append(cache.Cells, shard...)
+	}
+	if workers > 1 {
+		cache.waves = scheduleBoundaryWaves(cache.Cells, topology)
+	}
+
+	if debug.Is(debug.Perf | debug.Geology) {
+		log.Printf("[BOUNDARY CACHE] Built cache with %d boundary cells out of %d total (%.1f%%)",
+			len(cache.Cells), totalCells, float64(len(cache.Cells))/float64(totalCells)*100)
+	}
+
+	return cache
+}
+
+// findBoundaryCells returns the boundary cells with grid indexes in
+// [start, end), one per differing neighbor, in index order
+func findBoundaryCells(plates []TectonicPlate, topology spatial.Topology, plateGrid []int, start, end int) []BoundaryCell {
+	resolution := topology.Resolution()
 	resSq := resolution * resolution
+	totalCells := len(plateGrid)
+	directions := []spatial.Direction{spatial.North, spatial.South, spatial.East, spatial.West}
 
-	for idx := 0; idx < totalCells; idx++ {
-		currentPlateIdx := cache.PlateGrid[idx]
+	var cells []BoundaryCell
+	for idx := start; idx < end; idx++ {
+		currentPlateIdx := plateGrid[idx]
 		if currentPlateIdx == -1 {
 			continue
 		}
@@ -196,7 +244,7 @@ func ComputeBoundaryCache(plates []TectonicPlate, topology spatial.Topology) *Bo
 
 			var neighborPlateIdx int
 			if nIdx >= 0 && nIdx < totalCells {
-				neighborPlateIdx = cache.PlateGrid[nIdx]
+				neighborPlateIdx = plateGrid[nIdx]
 			} else {
 				neighborPlateIdx = -1
 			}
@@ -209,7 +257,7 @@ func ComputeBoundaryCache(plates []TectonicPlate, topology spatial.Topology) *Bo
 			neighborPlate := plates[neighborPlateIdx]
 			boundaryType := CalculateBoundaryType(currentPlate, neighborPlate)
 
-			cache.Cells = append(cache.Cells, BoundaryCell{
+			cells = append(cells, BoundaryCell{
 				Coord:        coord,
 				PlateIdx:     currentPlateIdx,
 				NeighborIdx:  neighborPlateIdx,
@@ -217,24 +265,46 @@ func ComputeBoundaryCache(plates []TectonicPlate, topology spatial.Topology) *Bo
 			})
 		}
 	}
-
-	if debug.Is(debug.Perf | debug.Geology) {
-		log.Printf("[BOUNDARY CACHE] Built cache with %d boundary cells out of %d total (%.1f%%)",
-			len(cache.Cells), totalCells, float64(len(cache.Cells))/float64(totalCells)*100)
-	}
-
-	return cache
+	return cells
 }
 
 // SimulateTectonicsWithCache uses a pre-computed boundary cache for fast processing.
 // Only iterates over boundary cells instead of all cells - typically 90% faster.
+// Caches built with several workers run in parallel waves (see
+// scheduleBoundaryWaves), matching the serial result exactly.
 func SimulateTectonicsWithCache(plates []TectonicPlate, heightmap *SphereHeightmap, cache *BoundaryCache, topology spatial.Topology, scaleFactor float64) *SphereHeightmap {
 	if debug.Is(debug.Perf) {
 		defer debug.Time(debug.Perf, "SimulateTectonicsWithCache")()
 	}
 
-	// Process only cached boundary cells
-	for _, bc := range cache.Cells {
+	if cache.Workers <= 1 || cache.waves == nil {
+		applyBoundaryCells(plates, heightmap, cache.Cells, topology, scaleFactor)
+		return heightmap
+	}
+
+	for _, wave := range cache.waves {
+		// Runs in a wave touch disjoint cells, so workers can take them in any order
+		var next int64 = -1
+		var wg sync.WaitGroup
+		for w := 0; w < cache.Workers && w < len(wave); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := atomic.AddInt64(&next, 1); i < int64(len(wave)); i = atomic.AddInt64(&next, 1) {
+					run := wave[i]
+					applyBoundaryCells(plates, heightmap, cache.Cells[run.start:run.end], topology, scaleFactor)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	return heightmap
+}
+
+// applyBoundaryCells applies each boundary cell's elevation change in order
+func applyBoundaryCells(plates []TectonicPlate, heightmap *SphereHeightmap, cells []BoundaryCell, topology spatial.Topology, scaleFactor float64) {
+	for _, bc := range cells {
 		currentPlate := plates[bc.PlateIdx]
 		neighborPlate := plates[bc.NeighborIdx]
 
@@ -247,8 +317,76 @@ func SimulateTectonicsWithCache(plates []TectonicPlate, heightmap *SphereHeightm
 
 		applyBoundaryEffectSpherical(heightmap, bc.Coord, elevationDelta, topology)
 	}
+}
 
-	return heightmap
+// boundaryBandsPerFace is how many bands of rows scheduleBoundaryWaves
+// cuts each cube face into
+const boundaryBandsPerFace = 4
+
+// cellRun is a contiguous run of BoundaryCache.Cells
+type cellRun struct {
+	start, end int
+}
+
+// scheduleBoundaryWaves splits the boundary cells into runs of whole bands
+// of rows and groups the runs into waves. Each cell's update reads and
+// writes itself and its four neighbors, so runs in the same wave must not
+// share any of those cells, and two runs that do must keep their serial
+// order: a run goes in the wave after the latest earlier run it overlaps.
+// That keeps every cell's updates in serial order, so the floating point
+// result is identical to a serial pass. The price is that chained bands
+// limit a pass to a few runs at a time.
+func scheduleBoundaryWaves(cells []BoundaryCell, topology spatial.Topology) [][]cellRun {
+	resolution := topology.Resolution()
+	resSq := resolution * resolution
+
+	// Neighboring bands overlap, so a face's bands chain one after another
+	// and more of them only adds waves. Bands need at least two rows so that
+	// only neighbors overlap.
+	bandRows := resolution / boundaryBandsPerFace
+	if bandRows < 2 {
+		bandRows = 2
+	}
+	band := func(c spatial.Coordinate) int { return c.Face*resolution + c.Y/bandRows }
+
+	// lastWave[cell] is the latest wave (1-based) that touches the cell
+	lastWave := make([]int, 6*resSq)
+	directions := []spatial.Direction{spatial.North, spatial.South, spatial.East, spatial.West}
+	var footprint []int
+	var waves [][]cellRun
+
+	for start := 0; start < len(cells); {
+		end := start + 1
+		for end < len(cells) && band(cells[end].Coord) == band(cells[start].Coord) {
+			end++
+		}
+
+		footprint = footprint[:0]
+		wave := 0
+		for _, bc := range cells[start:end] {
+			touched := [5]spatial.Coordinate{bc.Coord}
+			for i, dir := range directions {
+				touched[i+1] = topology.GetNeighbor(bc.Coord, dir)
+			}
+			for _, c := range touched {
+				idx := c.Face*resSq + c.Y*resolution + c.X
+				footprint = append(footprint, idx)
+				if lastWave[idx] > wave {
+					wave = lastWave[idx]
+				}
+			}
+		}
+		for _, idx := range footprint {
+			lastWave[idx] = wave + 1
+		}
+
+		if wave == len(waves) {
+			waves = append(waves, nil)
+		}
+		waves[wave] = append(waves[wave], cellRun{start, end})
+		start = end
+	}
+	return waves
 }
 
 // SimulateTectonics calculates elevation based on plate interactions on a sphere.
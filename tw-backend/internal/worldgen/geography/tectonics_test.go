@@ -1,11 +1,13 @@
 package geography
 
 import (
+	"runtime"
 	"testing"
 
 	"tw-backend/internal/spatial"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGeneratePlates(t *testing.T) {
@@ -79,3 +81,51 @@ func TestSimulateTectonics(t *testing.T) {
 	}
 	assert.True(t, hasChanges, "Tectonic simulation should produce elevation changes")
 }
+
+// tectonicsWorld returns plates and a noise heightmap for a fixed seed
+func tectonicsWorld(resolution int, seed int64) ([]TectonicPlate, *SphereHeightmap, spatial.Topology) {
+	topology := spatial.NewCubeSphereTopology(resolution)
+	plates := GeneratePlates(12, topology, seed)
+	hm := GenerateHeightmap(plates, NewSphereHeightmap(topology), topology, seed, 1.0, 1.0)
+	return plates, hm, topology
+}
+
+func TestSimulateTectonicsWithCache_ParallelMatchesSerial(t *testing.T) {
+	plates, serialHm, topology := tectonicsWorld(64, 42)
+	_, parallelHm, _ := tectonicsWorld(64, 42)
+
+	serial := ComputeBoundaryCacheWorkers(plates, topology, 1)
+	require.NotEmpty(t, serial.Cells)
+	for _, workers := range []int{2, 3, 8} {
+		parallel := ComputeBoundaryCacheWorkers(plates, topology, workers)
+		require.Equal(t, serial.Cells, parallel.Cells, "%d workers", workers)
+		require.Equal(t, serial.PlateGrid, parallel.PlateGrid)
+	}
+
+	// Several steps, so later ones start from already-overlapping updates
+	parallel := ComputeBoundaryCacheWorkers(plates, topology, 8)
+	for step := 0; step < 5; step++ {
+		SimulateTectonicsWithCache(plates, serialHm, serial, topology, 1.0)
+		SimulateTectonicsWithCache(plates, parallelHm, parallel, topology, 1.0)
+	}
+	for face := 0; face < 6; face++ {
+		assert.Equal(t, serialHm.GetFace(face).Elevations, parallelHm.GetFace(face).Elevations, "face %d", face)
+	}
+}
+
+func benchmarkSimulateTectonicsWithCache(b *testing.B, workers int) {
+	plates, hm, topology := tectonicsWorld(256, 42)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache := ComputeBoundaryCacheWorkers(plates, topology, workers)
+		SimulateTectonicsWithCache(plates, hm, cache, topology, 1.0)
+	}
+}
+
+func BenchmarkSimulateTectonicsWithCache_Serial(b *testing.B) {
+	benchmarkSimulateTectonicsWithCache(b, 1)
+}
+
+func BenchmarkSimulateTectonicsWithCache_Parallel(b *testing.B) {
+	benchmarkSimulateTectonicsWithCache(b, runtime.NumCPU())
+}
@@ -61,6 +61,9 @@ type BoundaryCache struct {
 	PlateGrid  []int // -1 = no plate, otherwise plate index
 	Resolution int
 	Valid      bool // Set to false when plates move and cache needs rebuild
+	Workers    int  // Goroutines SimulateTectonicsWithCache shards across (1 = serial)
+
+	waves [][]cellRun // Parallel schedule for Workers > 1
 }
 
 // NewBoundaryCache creates an empty boundary cache
@@ -0,0 +1,167 @@
+package underground
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// CaveEntranceDepth is how close (meters) a chamber's roof must come to the
+// surface to open an entrance
+const CaveEntranceDepth = 10.0
+
+// CaveEntrance is where a cave system opens onto the surface
+type CaveEntrance struct {
+	NodeID uuid.UUID // Chamber the entrance leads into
+	X, Y   int       // Column of the entrance (heightmap pixel)
+	Z      float64   // Surface elevation at the entrance
+}
+
+// CaveSystem is a set of caves joined into one traversable network
+type CaveSystem struct {
+	ID        uuid.UUID
+	CaveIDs   []uuid.UUID    // Caves merged into this system
+	NodeIDs   []uuid.UUID    // Every chamber in the system
+	Min, Max  Vector3        // Bounding volume
+	Entrances []CaveEntrance // Openings to the surface (none if sealed)
+}
+
+// CaveNetwork is the chamber graph over a set of caves, split into
+// connected systems. Chambers are linked by their cave's passages and by
+// overlapping chambers of other caves.
+type CaveNetwork struct {
+	Systems   []*CaveSystem
+	nodes     map[uuid.UUID]CaveNode
+	adjacency map[uuid.UUID][]uuid.UUID
+	systemOf  map[uuid.UUID]*CaveSystem
+}
+
+// BuildCaveGraph merges caves whose chambers overlap into connected systems
+// and finds where they reach the surface. Systems keep the order of their
+// first cave. columns may be nil, in which case no entrances are found.
+func BuildCaveGraph(caves []*Cave, columns *ColumnGrid) *CaveNetwork {
+	network := &CaveNetwork{
+		nodes:     make(map[uuid.UUID]CaveNode),
+		adjacency: make(map[uuid.UUID][]uuid.UUID),
+		systemOf:  make(map[uuid.UUID]*CaveSystem),
+	}
+
+	// Union-find over cave indexes
+	parent := make([]int, len(caves))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for _, cave := range caves {
+		for _, node := range cave.Nodes {
+			network.nodes[node.ID] = node
+		}
+		for _, edge := range cave.Passages {
+			network.link(edge.FromNodeID, edge.ToNodeID)
+		}
+	}
+
+	for i := 0; i < len(caves); i++ {
+		for j := i + 1; j < len(caves); j++ {
+			for _, a := range caves[i].Nodes {
+				for _, b := range caves[j].Nodes {
+					if chambersOverlap(a, b) {
+						network.link(a.ID, b.ID)
+						parent[find(j)] = find(i)
+					}
+				}
+			}
+		}
+	}
+
+	// Group caves by root, in input order
+	byRoot := make(map[int]*CaveSystem)
+	for i, cave := range caves {
+		root := find(i)
+		system, ok := byRoot[root]
+		if !ok {
+			system = &CaveSystem{ID: uuid.New()}
+			byRoot[root] = system
+			network.Systems = append(network.Systems, system)
+		}
+		system.addCave(cave, columns)
+		for _, node := range cave.Nodes {
+			network.systemOf[node.ID] = system
+		}
+	}
+
+	return network
+}
+
+// link adds an undirected edge between two chambers
+func (n *CaveNetwork) link(a, b uuid.UUID) {
+	n.adjacency[a] = append(n.adjacency[a], b)
+	n.adjacency[b] = append(n.adjacency[b], a)
+}
+
+// Neighbors returns the chambers reachable in one step from nodeID
+func (n *CaveNetwork) Neighbors(nodeID uuid.UUID) []uuid.UUID {
+	return n.adjacency[nodeID]
+}
+
+// Node returns the chamber with the given ID
+func (n *CaveNetwork) Node(nodeID uuid.UUID) (CaveNode, bool) {
+	node, ok := n.nodes[nodeID]
+	return node, ok
+}
+
+// SystemOf returns the system containing a chamber, or nil if unknown
+func (n *CaveNetwork) SystemOf(nodeID uuid.UUID) *CaveSystem {
+	return n.systemOf[nodeID]
+}
+
+// addCave folds a cave's chambers into the system's bounds and entrances
+func (s *CaveSystem) addCave(cave *Cave, columns *ColumnGrid) {
+	if len(cave.Nodes) == 0 {
+		s.CaveIDs = append(s.CaveIDs, cave.ID)
+		return
+	}
+
+	minX, minY, minZ, maxX, maxY, maxZ := cave.Bounds()
+	if len(s.NodeIDs) == 0 {
+		s.Min = Vector3{X: minX, Y: minY, Z: minZ}
+		s.Max = Vector3{X: maxX, Y: maxY, Z: maxZ}
+	} else {
+		s.Min = Vector3{X: math.Min(s.Min.X, minX), Y: math.Min(s.Min.Y, minY), Z: math.Min(s.Min.Z, minZ)}
+		s.Max = Vector3{X: math.Max(s.Max.X, maxX), Y: math.Max(s.Max.Y, maxY), Z: math.Max(s.Max.Z, maxZ)}
+	}
+	s.CaveIDs = append(s.CaveIDs, cave.ID)
+
+	for _, node := range cave.Nodes {
+		s.NodeIDs = append(s.NodeIDs, node.ID)
+		if columns == nil {
+			continue
+		}
+		x, y := int(math.Round(node.Position.X)), int(math.Round(node.Position.Y))
+		col := columns.Get(x, y)
+		if col == nil {
+			continue
+		}
+		if roof := node.Position.Z + node.Height/2; col.Surface-roof <= CaveEntranceDepth {
+			s.Entrances = append(s.Entrances, CaveEntrance{NodeID: node.ID, X: x, Y: y, Z: col.Surface})
+		}
+	}
+}
+
+// chambersOverlap reports whether two chambers share space: their
+// footprints intersect and their vertical extents overlap
+func chambersOverlap(a, b CaveNode) bool {
+	dx := a.Position.X - b.Position.X
+	dy := a.Position.Y - b.Position.Y
+	if math.Sqrt(dx*dx+dy*dy) > a.Radius+b.Radius {
+		return false
+	}
+	return math.Abs(a.Position.Z-b.Position.Z) <= (a.Height+b.Height)/2
+}
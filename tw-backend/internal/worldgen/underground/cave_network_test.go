@@ -0,0 +1,81 @@
+package underground
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// surfaceGrid returns a grid with flat ground at elevation
+func surfaceGrid(width, height int, elevation float64) *ColumnGrid {
+	grid := NewColumnGrid(width, height)
+	for _, col := range grid.AllColumns() {
+		col.Surface = elevation
+	}
+	return grid
+}
+
+func TestBuildCaveGraph_IntersectingCavesMerge(t *testing.T) {
+	grid := surfaceGrid(60, 60, 100)
+
+	// A karst cave with a chamber just under the surface at (10, 10)
+	karst := NewCave("karst", 0)
+	mouth := karst.AddNode(Vector3{X: 10, Y: 10, Z: 92}, 4, 6)
+	deep := karst.AddNode(Vector3{X: 20, Y: 10, Z: 50}, 5, 8)
+	karst.Connect(mouth, deep, 2)
+
+	// A lava tube whose chamber cuts into the deep karst chamber and that
+	// surfaces on its own at (30, 12)
+	tube := NewCave("lava_tube", 0)
+	crossing := tube.AddNode(Vector3{X: 24, Y: 11, Z: 53}, 3, 4)
+	vent := tube.AddNode(Vector3{X: 30, Y: 12, Z: 96}, 2, 4)
+	tube.Connect(crossing, vent, 1)
+
+	// A sealed cave far away
+	sealed := NewCave("karst", 0)
+	sealedNode := sealed.AddNode(Vector3{X: 50, Y: 50, Z: -200}, 5, 5)
+
+	network := BuildCaveGraph([]*Cave{karst, tube, sealed}, grid)
+	require.Len(t, network.Systems, 2)
+
+	merged := network.Systems[0]
+	assert.Equal(t, []uuid.UUID{karst.ID, tube.ID}, merged.CaveIDs)
+	assert.Len(t, merged.NodeIDs, 4)
+	require.Len(t, merged.Entrances, 2)
+	assert.Equal(t, CaveEntrance{NodeID: mouth, X: 10, Y: 10, Z: 100}, merged.Entrances[0])
+	assert.Equal(t, CaveEntrance{NodeID: vent, X: 30, Y: 12, Z: 100}, merged.Entrances[1])
+
+	// The overlap is a passage between the caves
+	assert.Contains(t, network.Neighbors(deep), crossing)
+	assert.Contains(t, network.Neighbors(crossing), deep)
+	assert.Equal(t, []uuid.UUID{deep}, network.Neighbors(mouth))
+	assert.Same(t, merged, network.SystemOf(vent))
+
+	// Bounds cover both caves
+	assert.LessOrEqual(t, merged.Min.X, 6.0)
+	assert.GreaterOrEqual(t, merged.Max.X, 32.0)
+	assert.LessOrEqual(t, merged.Min.Z, 46.0)
+	assert.GreaterOrEqual(t, merged.Max.Z, 98.0)
+
+	lone := network.Systems[1]
+	assert.Equal(t, sealed.ID, lone.CaveIDs[0])
+	assert.Empty(t, lone.Entrances, "too deep to reach the surface")
+	assert.Empty(t, network.Neighbors(sealedNode))
+	node, ok := network.Node(sealedNode)
+	require.True(t, ok)
+	assert.Equal(t, -200.0, node.Position.Z)
+}
+
+func TestBuildCaveGraph_StackedChambersDontTouch(t *testing.T) {
+	// Same footprint, but one chamber far below the other
+	upper := NewCave("karst", 0)
+	upper.AddNode(Vector3{X: 5, Y: 5, Z: 0}, 5, 10)
+	lower := NewCave("karst", 0)
+	lower.AddNode(Vector3{X: 5, Y: 5, Z: -100}, 5, 10)
+
+	network := BuildCaveGraph([]*Cave{upper, lower}, nil)
+	assert.Len(t, network.Systems, 2)
+	assert.Empty(t, network.Systems[0].Entrances, "no columns, no entrances")
+}
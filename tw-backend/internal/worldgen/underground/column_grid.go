@@ -13,7 +13,11 @@ type ColumnGrid struct {
 	mu      sync.RWMutex
 }
 
-// NewColumnGrid creates a new column grid with the given dimensions.
+// defaultBedrock is a new column's bedrock elevation, 10km down
+const defaultBedrock = -10000.0
+
+// NewColumnGrid creates a new column grid with the given dimensions. Columns
+// start dry, with the water table at bedrock.
 func NewColumnGrid(width, height int) *ColumnGrid {
 	grid := &ColumnGrid{
 		Width:   width,
@@ -28,10 +32,12 @@ func NewColumnGrid(width, height int) *ColumnGrid {
 				X:         x,
 				Y:         y,
 				Surface:   0,
-				Bedrock:   -10000, // Default 10km depth
+				Bedrock:   defaultBedrock,
 				Strata:    []StrataLayer{},
 				Voids:     []VoidSpace{},
 				Resources: []Deposit{},
+
+				WaterTable: defaultBedrock, // At Bedrock: dry until SimulateGroundwater fills it
 			}
 		}
 	}
@@ -0,0 +1,110 @@
+package underground
+
+import "math"
+
+// Groundwater constants. Rainfall is 0-1 normalized, as in SimulateCaveFormation.
+const (
+	// GroundwaterRechargeRate is meters of water per year soaking into the
+	// ground at full rainfall
+	GroundwaterRechargeRate = 0.2
+
+	// GroundwaterDrainageRate sets how fast an aquifer drains toward the sea;
+	// drainage scales with porosity, since open rock conducts water better
+	GroundwaterDrainageRate = 0.05
+
+	// DefaultAquiferPorosity is used for columns without strata
+	DefaultAquiferPorosity = 0.1
+
+	// SpringDepth is how close (meters) the water table must come to the
+	// surface for water to seep out
+	SpringDepth = 1.0
+
+	// MinSpringSlope is how much lower (meters) a neighboring column must be
+	// for a spring to flow out of the hillside rather than pool
+	MinSpringSlope = 1.0
+)
+
+// Spring is where the water table meets a sloping surface. Its water can
+// feed a river starting at (X, Y).
+type Spring struct {
+	X, Y      int     // Column (heightmap pixel)
+	Elevation float64 // Surface elevation at the spring
+	Flow      float64 // Water seeping out (meters per year over the column)
+}
+
+// SimulateGroundwater moves each column's water table over years. Rainfall
+// (0-1 normalized, per column; missing values default to 0.5) soaks into the
+// ground and raises it; the aquifer drains toward the sea and lowers it.
+// Porosity comes from the column's strata: porous rock holds more water per
+// meter, so it rises slower, but drains faster.
+//
+// The table settles where recharge balances drainage and is capped at the
+// surface; ocean columns stay saturated at sea level. The change is
+// integrated exactly, so one long step matches many short ones.
+//
+// Returns the springs: land columns whose water table reaches the surface
+// with lower ground beside them.
+func SimulateGroundwater(columns *ColumnGrid, rainfall []float64, seaLevel float64, years int64) []Spring {
+	if years <= 0 {
+		return nil
+	}
+
+	recharge := make(map[*WorldColumn]float64)
+	for _, col := range columns.AllColumns() {
+		if col.Surface <= seaLevel {
+			col.WaterTable = seaLevel
+			continue
+		}
+
+		rain := 0.5 // Default
+		if idx := col.Y*columns.Width + col.X; idx < len(rainfall) {
+			rain = rainfall[idx]
+		}
+		r := rain * GroundwaterRechargeRate
+		recharge[col] = r
+
+		// dh/dt = r/p - k·p·(h - sea), relaxing toward equilibrium
+		porosity := col.aquiferPorosity()
+		k := GroundwaterDrainageRate * porosity
+		equilibrium := seaLevel + r/(porosity*k)
+		table := math.Max(col.WaterTable, col.Bedrock)
+		table = equilibrium + (table-equilibrium)*math.Exp(-k*float64(years))
+		col.WaterTable = math.Min(table, col.Surface)
+	}
+
+	var springs []Spring
+	for _, col := range columns.AllColumns() {
+		r, land := recharge[col]
+		if !land || col.Surface-col.WaterTable > SpringDepth || !columns.hasLowerNeighbor(col) {
+			continue
+		}
+		springs = append(springs, Spring{X: col.X, Y: col.Y, Elevation: col.Surface, Flow: r})
+	}
+	return springs
+}
+
+// aquiferPorosity averages the porosity of the column's strata, weighted by
+// thickness
+func (c *WorldColumn) aquiferPorosity() float64 {
+	var weighted, thickness float64
+	for i := range c.Strata {
+		t := c.Strata[i].Thickness()
+		weighted += c.Strata[i].Porosity * t
+		thickness += t
+	}
+	if thickness <= 0 || weighted <= 0 {
+		return DefaultAquiferPorosity
+	}
+	return weighted / thickness
+}
+
+// hasLowerNeighbor reports whether a bordering column sits at least
+// MinSpringSlope below col
+func (g *ColumnGrid) hasLowerNeighbor(col *WorldColumn) bool {
+	for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		if n := g.Get(col.X+d[0], col.Y+d[1]); n != nil && col.Surface-n.Surface >= MinSpringSlope {
+			return true
+		}
+	}
+	return false
+}
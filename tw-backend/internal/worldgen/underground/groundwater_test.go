@@ -0,0 +1,82 @@
+package underground
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aquiferGrid returns a grid of sandstone hills at elevation
+func aquiferGrid(width, height int, elevation float64) *ColumnGrid {
+	grid := NewColumnGrid(width, height)
+	for _, col := range grid.AllColumns() {
+		col.Surface = elevation
+		col.AddStratum("sandstone", elevation, elevation-500, 4, 0, 0.25)
+	}
+	return grid
+}
+
+func TestSimulateGroundwater_RainforestVsDesert(t *testing.T) {
+	grid := aquiferGrid(2, 1, 500)
+	rainforest, desert := grid.Get(0, 0), grid.Get(1, 0)
+	assert.Equal(t, rainforest.Bedrock, rainforest.WaterTable, "dry before any rain")
+
+	rainfall := []float64{1.0, 0.05}
+	SimulateGroundwater(grid, rainfall, 0, 10_000)
+
+	assert.Greater(t, rainforest.WaterTable, desert.WaterTable)
+	assert.Greater(t, desert.WaterTable, 0.0, "even a little rain keeps the table above the sea")
+	assert.Less(t, rainforest.WaterTable, rainforest.Surface)
+
+	// Dry out the rainforest and its table falls back
+	before := rainforest.WaterTable
+	SimulateGroundwater(grid, []float64{0.05, 0.05}, 0, 10_000)
+	assert.Less(t, rainforest.WaterTable, before)
+	assert.InDelta(t, desert.WaterTable, rainforest.WaterTable, 1e-6)
+}
+
+func TestSimulateGroundwater_StepSizeIndependent(t *testing.T) {
+	long, short := aquiferGrid(1, 1, 500), aquiferGrid(1, 1, 500)
+	SimulateGroundwater(long, []float64{0.8}, 0, 100)
+	for i := 0; i < 10; i++ {
+		SimulateGroundwater(short, []float64{0.8}, 0, 10)
+	}
+	assert.InDelta(t, long.Get(0, 0).WaterTable, short.Get(0, 0).WaterTable, 1e-6)
+}
+
+func TestSimulateGroundwater_PorosityFromStrata(t *testing.T) {
+	grid := NewColumnGrid(2, 1)
+	for _, col := range grid.AllColumns() {
+		col.Surface = 1000
+	}
+	grid.Get(0, 0).AddStratum("sandstone", 1000, 0, 4, 0, 0.3)
+	grid.Get(1, 0).AddStratum("granite", 1000, 0, 8, 0, 0.05)
+
+	SimulateGroundwater(grid, []float64{0.5, 0.5}, 0, 100_000)
+
+	// Tight granite can't drain what falls on it, so its table backs up higher
+	assert.Greater(t, grid.Get(1, 0).WaterTable, grid.Get(0, 0).WaterTable)
+}
+
+func TestSimulateGroundwater_SpringsOnSlopes(t *testing.T) {
+	// A low, soaked ridge next to the sea, and an inland plateau
+	grid := aquiferGrid(4, 1, 20)
+	grid.Get(0, 0).Surface = -50 // Sea
+	grid.Get(1, 0).Surface = 10  // Coastal slope
+	grid.Get(2, 0).Surface = 20  // Ridge, higher than both sides
+	grid.Get(3, 0).Surface = 20  // Flat plateau top
+
+	springs := SimulateGroundwater(grid, []float64{0, 1, 1, 1}, 0, 100_000)
+
+	assert.Equal(t, 0.0, grid.Get(0, 0).WaterTable, "the sea is saturated at sea level")
+	for x := 1; x < 4; x++ {
+		col := grid.Get(x, 0)
+		require.Equal(t, col.Surface, col.WaterTable, "the table is capped at the surface")
+	}
+
+	// The plateau's only neighbor is as high as it, so its water pools instead
+	require.Len(t, springs, 2)
+	assert.Equal(t, Spring{X: 1, Y: 0, Elevation: 10, Flow: GroundwaterRechargeRate}, springs[0])
+	assert.Equal(t, 2, springs[1].X)
+}
@@ -0,0 +1,131 @@
+package underground
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// MineralType is a kind of ore vein
+type MineralType string
+
+const (
+	MineralIron   MineralType = "iron"
+	MineralCopper MineralType = "copper"
+	MineralGold   MineralType = "gold"
+	MineralGems   MineralType = "gems"
+)
+
+// MineralHeatReach is how many columns from magma or a plate boundary its
+// hydrothermal fluids enrich the rock
+const MineralHeatReach = 5
+
+// mineralSpec sets a mineral's rarity and vein size
+type mineralSpec struct {
+	Type     MineralType
+	Chance   float64 // Base chance of a vein per column
+	Quantity float64 // Typical vein size (units)
+	Metal    bool    // Metals concentrate around heat; gems less so
+}
+
+// mineralSpecs lists the minerals from common to rare
+var mineralSpecs = []mineralSpec{
+	{MineralIron, 0.02, 5000, true},
+	{MineralCopper, 0.01, 2000, true},
+	{MineralGold, 0.002, 200, true},
+	{MineralGems, 0.003, 50, false},
+}
+
+// mineralProfile is how a world composition shapes its ore
+type mineralProfile struct {
+	Metals       float64 // Metal vein chance multiplier
+	Gems         float64 // Gem vein chance multiplier
+	HeatBonus    float64 // Extra metal chance at full heat (×)
+	GemMinDepth  float64 // meters below the surface
+	GemDepthSpan float64 // meters
+}
+
+// mineralProfiles by composition; unknown compositions use "continental"
+var mineralProfiles = map[string]mineralProfile{
+	"volcanic":    {Metals: 1.5, Gems: 0.5, HeatBonus: 6, GemMinDepth: 100, GemDepthSpan: 900},
+	"continental": {Metals: 1.0, Gems: 1.0, HeatBonus: 2, GemMinDepth: 100, GemDepthSpan: 900},
+	"oceanic":     {Metals: 0.4, Gems: 0.3, HeatBonus: 1, GemMinDepth: 100, GemDepthSpan: 900},
+	"ancient":     {Metals: 0.8, Gems: 2.5, HeatBonus: 2, GemMinDepth: 1000, GemDepthSpan: 2000},
+}
+
+// GenerateMineralDeposits seeds ore veins (iron, copper, gold, gems) into
+// the columns' resources and returns them. Veins are likelier, higher grade
+// and larger near heat: columns with magma and, if given, tectonic
+// boundaries, out to MineralHeatReach columns. The composition shifts the
+// balance: "volcanic" worlds favor metals around their magma, "ancient"
+// worlds favor gems deep in old rock, "oceanic" worlds are poor in both.
+//
+// Each deposit has a Grade (0-1) and a Quantity that ExtractResource draws
+// down.
+func GenerateMineralDeposits(columns *ColumnGrid, composition string, seed int64, boundaries ...TectonicBoundary) []Deposit {
+	rng := rand.New(rand.NewSource(seed))
+	profile, ok := mineralProfiles[composition]
+	if !ok {
+		profile = mineralProfiles["continental"]
+	}
+
+	heat := mineralHeat(columns, boundaries)
+	var deposits []Deposit
+	for _, col := range columns.AllColumns() {
+		h := heat[col.Y*columns.Width+col.X]
+		for _, spec := range mineralSpecs {
+			chance := spec.Chance * profile.Gems * (1 + h)
+			depth := profile.GemMinDepth + rng.Float64()*profile.GemDepthSpan
+			if spec.Metal {
+				chance = spec.Chance * profile.Metals * (1 + profile.HeatBonus*h)
+				depth = 20 + rng.Float64()*(500+1500*h) // Hydrothermal veins reach deeper
+			}
+			if rng.Float64() >= chance {
+				continue
+			}
+
+			deposit := Deposit{
+				ID:       uuid.New(),
+				Type:     string(spec.Type),
+				DepthZ:   math.Max(col.Surface-depth, col.Bedrock),
+				Grade:    math.Min(0.1+rng.Float64()*0.5+0.4*h, 1),
+				Quantity: spec.Quantity * (0.5 + rng.Float64()) * (1 + h),
+			}
+			col.Resources = append(col.Resources, deposit)
+			deposits = append(deposits, deposit)
+		}
+	}
+	return deposits
+}
+
+// mineralHeat returns each column's closeness (0-1, [y*width+x]) to magma
+// or a plate boundary, falling off linearly to zero at MineralHeatReach
+func mineralHeat(columns *ColumnGrid, boundaries []TectonicBoundary) []float64 {
+	heat := make([]float64, columns.Width*columns.Height)
+	stamp := func(cx, cy int, intensity float64) {
+		for dy := -MineralHeatReach; dy <= MineralHeatReach; dy++ {
+			for dx := -MineralHeatReach; dx <= MineralHeatReach; dx++ {
+				x, y := cx+dx, cy+dy
+				if x < 0 || x >= columns.Width || y < 0 || y >= columns.Height {
+					continue
+				}
+				dist := math.Sqrt(float64(dx*dx + dy*dy))
+				h := intensity * (1 - dist/(MineralHeatReach+1))
+				if idx := y*columns.Width + x; h > heat[idx] {
+					heat[idx] = h
+				}
+			}
+		}
+	}
+
+	for _, col := range columns.AllColumns() {
+		if col.Magma != nil {
+			stamp(col.X, col.Y, 1)
+		}
+	}
+	for _, b := range boundaries {
+		stamp(b.X, b.Y, b.Intensity)
+	}
+	return heat
+}
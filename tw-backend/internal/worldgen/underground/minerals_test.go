@@ -0,0 +1,114 @@
+package underground
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hotspotGrid returns a basalt grid with a magma column at (20, 20)
+func hotspotGrid() *ColumnGrid {
+	grid := NewColumnGrid(41, 41)
+	for _, col := range grid.AllColumns() {
+		col.Surface = 500
+		col.AddStratum("basalt", 500, -3000, 6, 0, 0.1)
+	}
+	grid.Get(20, 20).Magma = &MagmaInfo{TopZ: -2000, BottomZ: -4000, Temperature: 1400}
+	return grid
+}
+
+// metalVeinsNear counts metal deposits within radius columns of (cx, cy)
+func metalVeinsNear(grid *ColumnGrid, cx, cy, radius int) int {
+	count := 0
+	for _, col := range grid.AllColumns() {
+		dx, dy := col.X-cx, col.Y-cy
+		if dx*dx+dy*dy > radius*radius {
+			continue
+		}
+		for _, d := range col.Resources {
+			if d.Type != string(MineralGems) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestGenerateMineralDeposits_VolcanicMetalsNearHotspots(t *testing.T) {
+	volcanic, oceanic := hotspotGrid(), hotspotGrid()
+	GenerateMineralDeposits(volcanic, "volcanic", 7)
+	GenerateMineralDeposits(oceanic, "oceanic", 7)
+
+	volcanicNear := metalVeinsNear(volcanic, 20, 20, MineralHeatReach)
+	assert.Greater(t, volcanicNear, 2*metalVeinsNear(oceanic, 20, 20, MineralHeatReach))
+
+	// The hotspot's surroundings are richer than a same-sized patch far away
+	assert.Greater(t, volcanicNear, 2*metalVeinsNear(volcanic, 5, 5, MineralHeatReach))
+}
+
+func TestGenerateMineralDeposits_TectonicBoundaries(t *testing.T) {
+	quiet, active := NewColumnGrid(41, 41), NewColumnGrid(41, 41)
+	var boundaries []TectonicBoundary
+	for y := 0; y < 41; y++ {
+		boundaries = append(boundaries, TectonicBoundary{X: 20, Y: y, BoundaryType: "convergent", Intensity: 1})
+	}
+
+	assert.Greater(t,
+		len(GenerateMineralDeposits(active, "continental", 3, boundaries...)),
+		len(GenerateMineralDeposits(quiet, "continental", 3)))
+}
+
+func TestGenerateMineralDeposits_AncientGemsRunDeep(t *testing.T) {
+	avgGemDepth := func(composition string) (float64, int) {
+		grid := NewColumnGrid(60, 60)
+		for _, col := range grid.AllColumns() {
+			col.Surface = 0
+		}
+		sum, n := 0.0, 0
+		for _, d := range GenerateMineralDeposits(grid, composition, 11) {
+			if d.Type == string(MineralGems) {
+				sum += -d.DepthZ
+				n++
+			}
+		}
+		if n == 0 {
+			return 0, 0
+		}
+		return sum / float64(n), n
+	}
+
+	ancientDepth, ancientGems := avgGemDepth("ancient")
+	continentalDepth, continentalGems := avgGemDepth("continental")
+	require.NotZero(t, continentalGems)
+	assert.Greater(t, ancientGems, continentalGems)
+	assert.Greater(t, ancientDepth, 2*continentalDepth)
+}
+
+func TestGenerateMineralDeposits_Mineable(t *testing.T) {
+	grid := hotspotGrid()
+	deposits := GenerateMineralDeposits(grid, "volcanic", 1)
+	require.NotEmpty(t, deposits)
+
+	for _, d := range deposits {
+		assert.Greater(t, d.Grade, 0.0)
+		assert.LessOrEqual(t, d.Grade, 1.0)
+		assert.Greater(t, d.Quantity, 0.0)
+		assert.Less(t, d.DepthZ, 500.0, "veins are underground")
+	}
+
+	// A vein in the grid can be mined out
+	col := grid.Get(20, 20)
+	for _, c := range grid.AllColumns() {
+		if len(c.Resources) > 0 {
+			col = c
+			break
+		}
+	}
+	vein := &col.Resources[0]
+	total := vein.Quantity
+	got, ok := ExtractResource(vein, total+1)
+	assert.True(t, ok)
+	assert.Equal(t, total, got)
+	assert.Zero(t, vein.Quantity)
+}
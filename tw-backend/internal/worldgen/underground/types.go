@@ -12,6 +12,9 @@ type WorldColumn struct {
 	Voids     []VoidSpace   // Caves/tunnels intersecting this column
 	Resources []Deposit     // Minerals, fossils, oil at various depths
 	Magma     *MagmaInfo    // Active magma (nil if none)
+	Ice       float64       // Glacial ice on the surface (meters thick)
+
+	WaterTable float64 // Top of the saturated zone (elevation; at Bedrock when dry)
 }
 
 // StrataLayer represents a geological layer at a specific depth range.
@@ -53,6 +56,7 @@ type Deposit struct {
 	Type       string         // "iron", "gold", "coal", "fossil", "oil"
 	DepthZ     float64        // Center depth
 	Quantity   float64        // Remaining amount (units vary by type)
+	Grade      float64        // Ore concentration 0-1 (mineral veins only)
 	Discovered bool           // Player has found this
 	Source     *OrganicSource // For fossils/oil, tracks origin
 }
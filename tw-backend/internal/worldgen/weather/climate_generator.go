@@ -28,61 +28,122 @@ type ClimateData struct {
 //
 // Returns a slice of ClimateData, one per grid cell (row-major order).
 func GenerateInitialClimate(heightmap *geography.Heightmap, seaLevel float64, seed int64, globalTempMod float64) []ClimateData {
+	return GenerateInitialClimateWithTilt(heightmap, seaLevel, seed, globalTempMod, DefaultAxialTilt)
+}
+
+// GenerateInitialClimateWithTilt is GenerateInitialClimate for a planet
+// with the given axial tilt (degrees). Tilt sets each latitude's
+// Seasonality (see SeasonalAmplitude), and shifts annual temperatures: a
+// more tilted planet has warmer poles and a cooler equator than an
+// Earth-like one. At DefaultAxialTilt it matches GenerateInitialClimate.
+func GenerateInitialClimateWithTilt(heightmap *geography.Heightmap, seaLevel float64, seed int64, globalTempMod, axialTilt float64) []ClimateData {
 	width := heightmap.Width
 	height := heightmap.Height
 	climateData := make([]ClimateData, width*height)
 
-	// Use Perlin noise for moisture patterns (same as old biomes.go)
-	noise := geography.NewPerlinGenerator(seed)
-
+	sampler := NewClimateSamplerWithTilt(seed, axialTilt)
 	for y := 0; y < height; y++ {
 		for x := 0; x < width; x++ {
-			idx := y*width + x
-			elevation := heightmap.Get(x, y)
-
-			// Calculate latitude factor: 0 at equator, 1 at poles
-			// normalizedY: 0.0 (top) to 1.0 (bottom)
-			// latitude: 0.0 (center/equator) to 1.0 (edges/poles)
-			normalizedY := float64(y) / float64(height)
-			latitude := math.Abs(normalizedY-0.5) * 2.0
-
-			// Temperature from latitude and elevation
-			temp := calculateTemperatureFromLatitude(latitude, elevation, seaLevel, globalTempMod)
-
-			// Moisture from Perlin noise (same algorithm as old biomes.go)
-			n := noise.Noise2D(float64(x)*0.05, float64(y)*0.05)
-			moisture := (n + 1.0) / 2.0 // Normalize to 0-1
-
-			// Convert moisture factor to rainfall (mm/year)
-			// Using 2000mm as "very wet" baseline (matches ClassifyBiome)
-			rainfall := moisture * 2000.0
-
-			// Seasonality: higher at poles and in continental interiors
-			// Simplified model based on latitude
-			seasonality := latitude * 0.8 // 0.0 at equator, 0.8 at poles
-
-			// Soil drainage: simplified model
-			// Higher elevation = better drainage, ocean = 0
-			drainage := 0.5 // Default moderate
-			if elevation <= seaLevel {
-				drainage = 0.0 // Ocean/flooded
-			} else {
-				altitudeAboveSea := elevation - seaLevel
-				drainage = math.Min(1.0, 0.3+altitudeAboveSea/5000.0)
-			}
-
-			climateData[idx] = ClimateData{
-				Temperature:    temp,
-				AnnualRainfall: rainfall,
-				Seasonality:    seasonality,
-				SoilDrainage:   drainage,
-			}
+			climateData[y*width+x] = sampler.ClimateAt(heightmap, seaLevel, globalTempMod, x, y)
 		}
 	}
 
 	return climateData
 }
 
+// ClimateSampler computes climate one cell at a time, matching
+// GenerateInitialClimateWithTilt for the same seed and tilt. Use it to
+// refresh a few cells without rebuilding the whole map. It is not safe for
+// concurrent use.
+type ClimateSampler struct {
+	noise     *geography.PerlinGenerator // Moisture patterns (same as old biomes.go)
+	axialTilt float64                    // degrees
+	seasons   map[float64]seasonProfile  // By latitude (degrees)
+}
+
+// seasonProfile is what axial tilt does to a latitude's climate
+type seasonProfile struct {
+	tempShift   float64 // Annual mean °C relative to an Earth-like tilt
+	seasonality float64 // 0-1
+}
+
+// NewClimateSampler creates a sampler with the moisture noise for seed and
+// an Earth-like tilt
+func NewClimateSampler(seed int64) *ClimateSampler {
+	return NewClimateSamplerWithTilt(seed, DefaultAxialTilt)
+}
+
+// NewClimateSamplerWithTilt creates a sampler for a planet with the given
+// axial tilt (degrees)
+func NewClimateSamplerWithTilt(seed int64, axialTilt float64) *ClimateSampler {
+	return &ClimateSampler{
+		noise:     geography.NewPerlinGenerator(seed),
+		axialTilt: axialTilt,
+		seasons:   make(map[float64]seasonProfile),
+	}
+}
+
+// season returns the tilt's effect at latitude (degrees), computing each
+// latitude once
+func (s *ClimateSampler) season(latitude float64) seasonProfile {
+	if p, ok := s.seasons[latitude]; ok {
+		return p
+	}
+	p := seasonProfile{
+		seasonality: math.Min(1, SeasonalAmplitude(latitude, s.axialTilt)/fullSeasonality),
+	}
+	if s.axialTilt != DefaultAxialTilt {
+		p.tempShift = seasonalTempPerInsolation *
+			(annualInsolation(latitude, s.axialTilt) - annualInsolation(latitude, DefaultAxialTilt))
+	}
+	s.seasons[latitude] = p
+	return p
+}
+
+// ClimateAt returns the climate of cell (x, y) of the heightmap
+func (s *ClimateSampler) ClimateAt(heightmap *geography.Heightmap, seaLevel, globalTempMod float64, x, y int) ClimateData {
+	elevation := heightmap.Get(x, y)
+
+	// Calculate latitude factor: 0 at equator, 1 at poles
+	// normalizedY: 0.0 (top) to 1.0 (bottom)
+	// latitude: 0.0 (center/equator) to 1.0 (edges/poles)
+	normalizedY := float64(y) / float64(heightmap.Height)
+	latitude := math.Abs(normalizedY-0.5) * 2.0
+
+	// Temperature from latitude and elevation, shifted by the tilt
+	season := s.season(latitude * 90)
+	temp := calculateTemperatureFromLatitude(latitude, elevation, seaLevel, globalTempMod) + season.tempShift
+
+	// Moisture from Perlin noise (same algorithm as old biomes.go)
+	n := s.noise.Noise2D(float64(x)*0.05, float64(y)*0.05)
+	moisture := (n + 1.0) / 2.0 // Normalize to 0-1
+
+	// Convert moisture factor to rainfall (mm/year)
+	// Using 2000mm as "very wet" baseline (matches ClassifyBiome)
+	rainfall := moisture * 2000.0
+
+	// Seasonality: the yearly swing the tilt gives this latitude
+	// (~0.05 at the equator, ~0.8 at the poles for an Earth-like tilt)
+	seasonality := season.seasonality
+
+	// Soil drainage: simplified model
+	// Higher elevation = better drainage, ocean = 0
+	drainage := 0.5 // Default moderate
+	if elevation <= seaLevel {
+		drainage = 0.0 // Ocean/flooded
+	} else {
+		altitudeAboveSea := elevation - seaLevel
+		drainage = math.Min(1.0, 0.3+altitudeAboveSea/5000.0)
+	}
+
+	return ClimateData{
+		Temperature:    temp,
+		AnnualRainfall: rainfall,
+		Seasonality:    seasonality,
+		SoilDrainage:   drainage,
+	}
+}
+
 // calculateTemperatureFromLatitude computes temperature from geographic position.
 // This is the physics model extracted from the old biomes.go.
 func calculateTemperatureFromLatitude(latitude, elevation, seaLevel, globalTempMod float64) float64 {
@@ -120,3 +181,31 @@ func GetClimateAt(climateMap []ClimateData, width, x, y int) ClimateData {
 	}
 	return climateMap[idx]
 }
+
+// GlobalAveragePrecipitation returns the mean annual rainfall (mm/year) over
+// land, where silicate weathering happens. Cells are weighted by area: rows
+// of the equirectangular map shrink toward the poles. Returns 0 with no land.
+func GlobalAveragePrecipitation(climateMap []ClimateData, width int) float64 {
+	if width <= 0 || len(climateMap) < width {
+		return 0
+	}
+	height := len(climateMap) / width
+
+	var rainfall, area float64
+	for y := 0; y < height; y++ {
+		lat := (0.5 - (float64(y)+0.5)/float64(height)) * math.Pi
+		weight := math.Cos(lat)
+		for x := 0; x < width; x++ {
+			cell := climateMap[y*width+x]
+			if cell.SoilDrainage <= 0 { // Ocean
+				continue
+			}
+			rainfall += cell.AnnualRainfall * weight
+			area += weight
+		}
+	}
+	if area == 0 {
+		return 0
+	}
+	return rainfall / area
+}
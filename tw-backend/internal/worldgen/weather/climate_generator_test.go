@@ -118,3 +118,53 @@ func TestCalculateTemperatureFromLatitude(t *testing.T) {
 	// 30 - (3000/1000)*6.5 = 30 - 19.5 = 10.5
 	assert.InDelta(t, 10.5, highAltTemp, 0.1, "High altitude should reduce temp by lapse rate")
 }
+
+// TestGlobalAveragePrecipitation verifies only land counts, weighted by area.
+func TestGlobalAveragePrecipitation(t *testing.T) {
+	const width, height = 4, 4
+	climate := make([]ClimateData, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell := ClimateData{AnnualRainfall: 3000} // Ocean rain doesn't weather rock
+			if x < 2 {
+				cell = ClimateData{AnnualRainfall: 1000, SoilDrainage: 0.5}
+			}
+			climate[y*width+x] = cell
+		}
+	}
+	assert.InDelta(t, 1000.0, GlobalAveragePrecipitation(climate, width), 1e-9)
+
+	// Wet polar rows weigh less than a dry equatorial band of the same size
+	for x := 0; x < 2; x++ {
+		climate[0*width+x].AnnualRainfall = 2000
+		climate[3*width+x].AnnualRainfall = 2000
+		climate[1*width+x].AnnualRainfall = 0
+		climate[2*width+x].AnnualRainfall = 0
+	}
+	avg := GlobalAveragePrecipitation(climate, width)
+	assert.Greater(t, avg, 0.0)
+	assert.Less(t, avg, 1000.0)
+
+	// A water world has no weathering surface
+	ocean := []ClimateData{{AnnualRainfall: 1500}, {AnnualRainfall: 1500}}
+	assert.Zero(t, GlobalAveragePrecipitation(ocean, 2))
+	assert.Zero(t, GlobalAveragePrecipitation(nil, 4))
+}
+
+// TestClimateSampler_MatchesGenerateInitialClimate verifies single cells agree with the full map.
+func TestClimateSampler_MatchesGenerateInitialClimate(t *testing.T) {
+	hm := geography.NewHeightmap(12, 8)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 12; x++ {
+			hm.Set(x, y, float64(x*300-1000))
+		}
+	}
+
+	climate := GenerateInitialClimate(hm, 0, 99, -3)
+	sampler := NewClimateSampler(99)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 12; x++ {
+			assert.Equal(t, GetClimateAt(climate, 12, x, y), sampler.ClimateAt(hm, 0, -3, x, y), "cell %d,%d", x, y)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package weather
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultWeatherCellSize is the width of a weather grid cell (meters)
+	DefaultWeatherCellSize = 50_000.0
+
+	// SeaLevelPressure is standard sea level pressure (hPa)
+	SeaLevelPressure = 1013.25
+	// BeltPressureAnomaly is how far the latitude belts sit above (highs) or
+	// below (lows) standard pressure (hPa)
+	BeltPressureAnomaly = 8.0
+
+	// StormPressureDrop is the depth of a newly formed storm (hPa)
+	StormPressureDrop = 30.0
+	// StormThreshold is how deep a low must be to count as a storm (hPa)
+	StormThreshold = 15.0
+	// SystemDissipation is the depth below which a system fades out (hPa)
+	SystemDissipation = 2.0
+	// SystemRadius is a new system's radius (cells)
+	SystemRadius = 2.0
+	// MaxWeatherSystems caps the systems alive at once
+	MaxWeatherSystems = 16
+
+	// StormSpawnRate is the chance per day of a storm forming over the
+	// oceans of the convergence zones (e-folding)
+	StormSpawnRate = 0.5
+	// SystemSteering is the share of the prevailing wind systems drift at
+	SystemSteering = 0.8
+	// OceanDecayDays and LandDecayDays are how long (e-folding) a system
+	// lasts over open water and once it comes ashore
+	OceanDecayDays = 6.0
+	LandDecayDays  = 1.5
+
+	// GradientWindFactor is extra wind (m/s) per hPa of pressure change
+	// across a cell
+	GradientWindFactor = 1.5
+	// OceanEvaporationRate is how fast the air over water approaches
+	// saturation (per day, e-folding)
+	OceanEvaporationRate = 1.0
+	// PrecipitationEfficiency is rainfall (mm/day) per unit of moisture
+	// (0-100) per unit of lift
+	PrecipitationEfficiency = 0.6
+	// MoisturePerMM is how much moisture 1 mm of rain removes from the air
+	MoisturePerMM = 0.5
+)
+
+// WeatherSystem is a moving high or low pressure center
+type WeatherSystem struct {
+	ID      uuid.UUID
+	X, Y    float64 // Grid position (cells); X wraps around
+	Anomaly float64 // hPa from the belt pressure; negative is a low
+	Radius  float64 // cells
+}
+
+// IsStorm reports whether the system is a low deep enough to be a storm
+func (ws WeatherSystem) IsStorm() bool {
+	return ws.Anomaly <= -StormThreshold
+}
+
+// PressureField is a world's moving weather: the pressure systems and the
+// moisture the wind carries, on the flat grid of its geography cells. It's
+// kept between ticks and advanced by Step.
+type PressureField struct {
+	Width, Height int
+	CellSize      float64   // meters
+	Pressure      []float64 // hPa, row-major
+	Moisture      []float64 // 0-100 humidity of the air, row-major
+	Systems       []WeatherSystem
+	Elapsed       time.Duration // Simulated time so far
+	Seed          int64
+
+	cells []*GeographyCell // Row-major; nil where the grid has no cell
+}
+
+// NewPressureField lays a pressure field over cells, by their Location
+func NewPressureField(cells []*GeographyCell, seed int64) *PressureField {
+	f := &PressureField{CellSize: DefaultWeatherCellSize, Seed: seed}
+	f.index(cells)
+	f.Pressure = make([]float64, f.Width*f.Height)
+	f.Moisture = make([]float64, f.Width*f.Height)
+	for idx, cell := range f.cells {
+		f.Pressure[idx] = f.beltPressure(idx / f.Width)
+		f.Moisture[idx] = 20
+		if cell != nil && cell.IsWater() {
+			f.Moisture[idx] = 60
+		}
+	}
+	return f
+}
+
+// Fits reports whether the field lies over a grid the shape of cells', so a
+// saved field can carry on over them
+func (f *PressureField) Fits(cells []*GeographyCell) bool {
+	grid := &PressureField{}
+	grid.index(cells)
+	size := grid.Width * grid.Height
+	return grid.Width == f.Width && grid.Height == f.Height && len(f.Pressure) == size && len(f.Moisture) == size
+}
+
+// index builds the grid from the cells' locations
+func (f *PressureField) index(cells []*GeographyCell) {
+	for _, cell := range cells {
+		f.Width = max(f.Width, int(cell.Location.X)+1)
+		f.Height = max(f.Height, int(cell.Location.Y)+1)
+	}
+	f.cells = make([]*GeographyCell, f.Width*f.Height)
+	for _, cell := range cells {
+		if x, y := int(cell.Location.X), int(cell.Location.Y); x >= 0 && y >= 0 {
+			f.cells[y*f.Width+x] = cell
+		}
+	}
+}
+
+// rowLatitude is the latitude (degrees) of a grid row, north at row 0
+func (f *PressureField) rowLatitude(y float64) float64 {
+	return 90 - (y+0.5)/float64(f.Height)*180
+}
+
+// beltPressure is the pressure of row y's latitude belt without systems
+func (f *PressureField) beltPressure(y int) float64 {
+	if GetPressureAtLatitude(f.rowLatitude(float64(y))) == PressureLow {
+		return SeaLevelPressure - BeltPressureAnomaly
+	}
+	return SeaLevelPressure + BeltPressureAnomaly
+}
+
+// heading returns the grid step (cells per cell of travel) a wind carries
+// air along; Direction is where the wind blows toward, 0 = north (row 0)
+func heading(w Wind) (dx, dy float64) {
+	rad := w.Direction * math.Pi / 180
+	return math.Sin(rad), -math.Cos(rad)
+}
+
+// Step advances the field by dt: systems drift with the prevailing wind and
+// weaken (fast over land), storms form over convergence-zone oceans, and the
+// wind carries moisture downwind, raining it out where lows and rising
+// ground lift it. It returns each cell's weather. Temperatures come from
+// prior states where there are any (see UpdateWorldWeather), else from the
+// cell's base temperature and elevation.
+func (f *PressureField) Step(cells []*GeographyCell, dt time.Duration, prior map[uuid.UUID]*WeatherState) []*WeatherState {
+	if len(f.cells) != f.Width*f.Height || len(f.cells) == 0 {
+		f.index(cells)
+	}
+	days := dt.Hours() / 24
+	rng := rand.New(rand.NewSource(f.Seed ^ int64(f.Elapsed)))
+
+	f.moveSystems(dt, days)
+	f.spawnStorm(rng, days)
+	f.updatePressure()
+	winds := f.winds()
+	precip := f.advectMoisture(winds, dt, days)
+	f.Elapsed += dt
+
+	now := time.Unix(0, 0).Add(f.Elapsed)
+	var states []*WeatherState
+	for idx, cell := range f.cells {
+		if cell == nil {
+			continue
+		}
+		temp := cell.Temperature - cell.Elevation/1000*6.5
+		if old, ok := prior[cell.CellID]; ok && old != nil {
+			temp = old.Temperature
+		}
+		weatherType := DetermineWeatherState(temp, precip[idx], f.Moisture[idx], winds[idx].Speed)
+		states = append(states, &WeatherState{
+			CellID:        cell.CellID,
+			Timestamp:     now,
+			State:         weatherType,
+			Temperature:   temp,
+			Precipitation: precip[idx],
+			Wind:          winds[idx],
+			Humidity:      f.Moisture[idx],
+			Visibility:    CalculateVisibility(weatherType),
+		})
+	}
+	return states
+}
+
+// moveSystems steers each system with the prevailing wind at its latitude
+// and weakens it, dropping the ones that have faded out
+func (f *PressureField) moveSystems(dt time.Duration, days float64) {
+	alive := f.Systems[:0]
+	for _, ws := range f.Systems {
+		wind := CalculateWind(f.rowLatitude(ws.Y), 0, SeasonSpring)
+		dx, dy := heading(wind)
+		dist := wind.Speed * SystemSteering * dt.Seconds() / f.CellSize
+		ws.X = math.Mod(ws.X+dx*dist+float64(f.Width), float64(f.Width))
+		ws.Y = math.Max(0, math.Min(ws.Y+dy*dist, float64(f.Height-1)))
+
+		decay := OceanDecayDays
+		if cell := f.cells[int(ws.Y)*f.Width+int(ws.X)]; cell != nil && !cell.IsWater() {
+			decay = LandDecayDays
+		}
+		ws.Anomaly *= math.Exp(-days / decay)
+		if math.Abs(ws.Anomaly) >= SystemDissipation {
+			alive = append(alive, ws)
+		}
+	}
+	f.Systems = alive
+}
+
+// spawnStorm may form a storm over the ocean in a convergence zone, where
+// the belt pressure is low and rising air feeds it
+func (f *PressureField) spawnStorm(rng *rand.Rand, days float64) {
+	if len(f.Systems) >= MaxWeatherSystems || rng.Float64() >= 1-math.Exp(-StormSpawnRate*days) {
+		return
+	}
+	for try := 0; try < 20; try++ {
+		idx := rng.Intn(len(f.cells))
+		cell := f.cells[idx]
+		y := idx / f.Width
+		if cell == nil || !cell.IsOcean || f.beltPressure(y) > SeaLevelPressure {
+			continue
+		}
+		f.Systems = append(f.Systems, WeatherSystem{
+			ID:      uuid.New(),
+			X:       float64(idx % f.Width),
+			Y:       float64(y),
+			Anomaly: -StormPressureDrop * (0.8 + 0.4*rng.Float64()),
+			Radius:  SystemRadius,
+		})
+		return
+	}
+}
+
+// updatePressure sums the belts and the systems' pressure anomalies
+func (f *PressureField) updatePressure() {
+	for idx := range f.Pressure {
+		x, y := float64(idx%f.Width), float64(idx/f.Width)
+		p := f.beltPressure(idx / f.Width)
+		for _, ws := range f.Systems {
+			dx := math.Abs(x - ws.X)
+			dx = math.Min(dx, float64(f.Width)-dx) // X wraps
+			dy := y - ws.Y
+			p += ws.Anomaly * math.Exp(-(dx*dx+dy*dy)/(2*ws.Radius*ws.Radius))
+		}
+		f.Pressure[idx] = p
+	}
+}
+
+// anomaly is how far the systems push a cell's pressure from its belt's
+func (f *PressureField) anomaly(idx int) float64 {
+	return f.Pressure[idx] - f.beltPressure(idx/f.Width)
+}
+
+// winds returns each cell's wind: the prevailing wind for its latitude,
+// stronger where systems make the pressure change steeply (the belts' own
+// gradients are what drive the prevailing wind)
+func (f *PressureField) winds() []Wind {
+	winds := make([]Wind, len(f.Pressure))
+	for idx := range winds {
+		x, y := idx%f.Width, idx/f.Width
+		east := f.anomaly(y*f.Width + (x+1)%f.Width)
+		west := f.anomaly(y*f.Width + (x-1+f.Width)%f.Width)
+		north, south := f.anomaly(idx), f.anomaly(idx)
+		if y > 0 {
+			north = f.anomaly(idx - f.Width)
+		}
+		if y < f.Height-1 {
+			south = f.anomaly(idx + f.Width)
+		}
+		gradient := math.Hypot(east-west, south-north) / 2
+
+		wind := CalculateWind(f.rowLatitude(float64(y)), 0, SeasonSpring)
+		wind.Speed += gradient * GradientWindFactor
+		winds[idx] = wind
+	}
+	return winds
+}
+
+// advectMoisture carries moisture downwind, tops it up over water and rains
+// out what lows and upslope winds lift, returning precipitation (mm/day)
+func (f *PressureField) advectMoisture(winds []Wind, dt time.Duration, days float64) []float64 {
+	moisture := make([]float64, len(f.Moisture))
+	precip := make([]float64, len(f.Moisture))
+	for idx := range moisture {
+		x, y := idx%f.Width, idx/f.Width
+
+		// The air here came from upwind
+		dx, dy := heading(winds[idx])
+		dist := winds[idx].Speed * dt.Seconds() / f.CellSize
+		ux, uy := float64(x)-dx*dist, float64(y)-dy*dist
+		m, upwind := f.sampleMoisture(ux, uy)
+
+		cell := f.cells[idx]
+		if cell != nil && cell.IsWater() {
+			m += (100 - m) * (1 - math.Exp(-OceanEvaporationRate*days))
+		}
+
+		// Lows lift air; so does ground rising under the wind
+		lift := math.Max(0, -f.anomaly(idx)) / StormPressureDrop
+		if cell != nil && upwind != nil && cell.Elevation > upwind.Elevation {
+			lift += math.Min((cell.Elevation-upwind.Elevation)/1000, 1)
+		}
+		if m > 80 {
+			lift += (m - 80) / 20 // Saturated air rains regardless
+		}
+
+		rain := m * lift * PrecipitationEfficiency
+		m = math.Max(0, m-rain*MoisturePerMM*days)
+		moisture[idx] = math.Min(m, 100)
+		precip[idx] = rain
+	}
+	f.Moisture = moisture
+	return precip
+}
+
+// sampleMoisture interpolates moisture at a grid position, and returns the
+// nearest cell there
+func (f *PressureField) sampleMoisture(x, y float64) (float64, *GeographyCell) {
+	y = math.Max(0, math.Min(y, float64(f.Height-1)))
+	x = math.Mod(x+float64(f.Width), float64(f.Width))
+	x0, y0 := int(x), int(y)
+	x1, y1 := (x0+1)%f.Width, min(y0+1, f.Height-1)
+	tx, ty := x-float64(x0), y-float64(y0)
+
+	at := func(x, y int) float64 { return f.Moisture[y*f.Width+x] }
+	top := at(x0, y0)*(1-tx) + at(x1, y0)*tx
+	bottom := at(x0, y1)*(1-tx) + at(x1, y1)*tx
+
+	nx, ny := int(math.Round(x))%f.Width, int(math.Round(y))
+	return top*(1-ty) + bottom*ty, f.cells[ny*f.Width+nx]
+}
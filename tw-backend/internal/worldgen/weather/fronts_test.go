@@ -0,0 +1,216 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"tw-backend/internal/worldgen/geography"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// gridCells returns a width x height grid; ocean says which cells are water
+func gridCells(width, height int, ocean func(x, y int) bool) []*GeographyCell {
+	var cells []*GeographyCell
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cells = append(cells, &GeographyCell{
+				CellID:      uuid.New(),
+				Location:    geography.Point{X: float64(x), Y: float64(y)},
+				IsOcean:     ocean(x, y),
+				Temperature: 15,
+			})
+		}
+	}
+	return cells
+}
+
+// lowestNear returns the lowest-pressure cell within radius of (cx, cy)
+func lowestNear(f *PressureField, cx, cy, radius int) (int, int) {
+	bestX, bestY, best := cx, cy, math.Inf(1)
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			x, y := (cx+dx+f.Width)%f.Width, cy+dy
+			if y < 0 || y >= f.Height {
+				continue
+			}
+			if p := f.Pressure[y*f.Width+x]; p < best {
+				bestX, bestY, best = x, y, p
+			}
+		}
+	}
+	return bestX, bestY
+}
+
+func TestPressureField_StormMovesAcrossCells(t *testing.T) {
+	cells := gridCells(40, 18, func(x, y int) bool { return true })
+	f := NewPressureField(cells, 1)
+	// A storm in the westerlies (row 3 is 55°N)
+	f.Systems = append(f.Systems, WeatherSystem{ID: uuid.New(), X: 10, Y: 3, Anomaly: -StormPressureDrop, Radius: SystemRadius})
+	f.updatePressure()
+
+	x, y := lowestNear(f, 10, 3, 0)
+	startX := x
+	for step := 0; step < 10; step++ {
+		states := f.Step(cells, time.Hour, nil)
+		nx, ny := lowestNear(f, x, y, 3)
+
+		// It drifts a cell or so at a time rather than jumping
+		assert.LessOrEqual(t, (nx-x+f.Width)%f.Width, 1, "step %d", step)
+		assert.LessOrEqual(t, ny-y, 1, "step %d", step)
+		x, y = nx, ny
+
+		// The storm is over the cells under its center, not far away
+		assert.Equal(t, WeatherStorm, states[y*f.Width+x].State, "step %d", step)
+		assert.NotEqual(t, WeatherStorm, states[y*f.Width+(x+20)%f.Width].State, "step %d", step)
+	}
+	assert.GreaterOrEqual(t, (x-startX+f.Width)%f.Width, 4, "the westerlies carry it east")
+}
+
+func TestPressureField_StormsWeakenOverLand(t *testing.T) {
+	sea := NewPressureField(gridCells(20, 18, func(x, y int) bool { return true }), 1)
+	land := NewPressureField(gridCells(20, 18, func(x, y int) bool { return false }), 1)
+	for _, f := range []*PressureField{sea, land} {
+		f.Systems = []WeatherSystem{{ID: uuid.New(), X: 5, Y: 5, Anomaly: -StormPressureDrop, Radius: SystemRadius}}
+		for i := 0; i < 8; i++ {
+			f.Step(f.cells, 6*time.Hour, nil)
+		}
+	}
+	require.NotEmpty(t, sea.Systems)
+	assert.True(t, sea.Systems[0].IsStorm(), "two days at sea barely dent it")
+	if len(land.Systems) > 0 {
+		assert.Greater(t, land.Systems[0].Anomaly, sea.Systems[0].Anomaly)
+		assert.False(t, land.Systems[0].IsStorm())
+	}
+}
+
+func TestPressureField_RainCarriedDownwind(t *testing.T) {
+	// Ocean to the west; the westerlies blow onshore over a ridge at x=10
+	cells := gridCells(40, 18, func(x, y int) bool { return x < 5 })
+	for _, cell := range cells {
+		if cell.Location.X == 10 {
+			cell.Elevation = 2000
+		}
+	}
+	f := NewPressureField(cells, 1)
+
+	var states []*WeatherState
+	for i := 0; i < 12; i++ {
+		states = f.Step(cells, time.Hour, nil)
+	}
+	const row = 5
+	at := func(x int) int { return row*f.Width + x }
+
+	// Moist sea air has reached the coast, not yet the far interior
+	assert.Greater(t, f.Moisture[at(7)], f.Moisture[at(30)])
+
+	// Rising over the ridge wrings the rain out on its windward side
+	assert.Greater(t, states[at(10)].Precipitation, states[at(12)].Precipitation)
+}
+
+func TestService_SimulateWeatherStep(t *testing.T) {
+	repo := &MockRepository{}
+	repo.On("SaveWeatherState", mock.Anything, mock.Anything).Return(nil)
+	service := NewService(repo)
+	ctx := context.Background()
+	worldID := uuid.New()
+	repo.On("GetPressureField", mock.Anything, worldID).Return(nil, nil)
+	repo.On("SavePressureField", mock.Anything, worldID, mock.Anything).Return(nil)
+
+	_, err := service.SimulateWeatherStep(ctx, worldID, time.Hour)
+	assert.Error(t, err, "no geography yet")
+	assert.Nil(t, service.PressureField(worldID))
+
+	cells := gridCells(10, 6, func(x, y int) bool { return x < 5 })
+	service.InitializeWorldWeather(ctx, worldID, nil, cells)
+
+	_, err = service.SimulateWeatherStep(ctx, worldID, time.Hour)
+	require.NoError(t, err)
+	field := service.PressureField(worldID)
+	require.NotNil(t, field)
+	field.Systems = append(field.Systems, WeatherSystem{ID: uuid.New(), X: 2, Y: 2, Anomaly: -StormPressureDrop, Radius: SystemRadius})
+
+	// The field, and the storm in it, carry over to the next tick
+	_, err = service.SimulateWeatherStep(ctx, worldID, time.Hour)
+	require.NoError(t, err)
+	assert.Same(t, field, service.PressureField(worldID))
+	assert.Equal(t, 2*time.Hour, field.Elapsed)
+	assert.NotEmpty(t, field.Systems)
+
+	// Every cell has local weather to describe
+	for _, cell := range cells {
+		state, err := service.GetCurrentWeather(ctx, worldID, cell.CellID)
+		require.NoError(t, err)
+		require.NotNil(t, state)
+		assert.NotEmpty(t, state.State)
+	}
+}
+
+func TestService_SimulateWeatherStep_PressureFieldSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	worldID := uuid.New()
+	cells := gridCells(10, 6, func(x, y int) bool { return x < 5 })
+
+	// Keep the saved field as the database would: as JSON
+	var saved []byte
+	repo := &MockRepository{}
+	repo.On("SaveWeatherState", mock.Anything, mock.Anything).Return(nil)
+	repo.On("GetPressureField", mock.Anything, worldID).Return(nil, nil).Once()
+	repo.On("SavePressureField", mock.Anything, worldID, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		var err error
+		saved, err = json.Marshal(args.Get(2).(*PressureField))
+		require.NoError(t, err)
+	})
+
+	service := NewService(repo)
+	service.InitializeWorldWeather(ctx, worldID, nil, cells)
+	_, err := service.SimulateWeatherStep(ctx, worldID, time.Hour)
+	require.NoError(t, err)
+	field := service.PressureField(worldID)
+	field.Systems = append(field.Systems, WeatherSystem{ID: uuid.New(), X: 2, Y: 2, Anomaly: -StormPressureDrop, Radius: SystemRadius})
+	_, err = service.SimulateWeatherStep(ctx, worldID, time.Hour)
+	require.NoError(t, err)
+
+	var stored PressureField
+	require.NoError(t, json.Unmarshal(saved, &stored))
+	repo.On("GetPressureField", mock.Anything, worldID).Return(&stored, nil).Once()
+
+	// A restarted service picks the storm up where it was
+	restarted := NewService(repo)
+	restarted.InitializeWorldWeather(ctx, worldID, nil, cells)
+	_, err = restarted.SimulateWeatherStep(ctx, worldID, time.Hour)
+	require.NoError(t, err)
+	resumed := restarted.PressureField(worldID)
+	assert.Same(t, &stored, resumed)
+	assert.Equal(t, 3*time.Hour, resumed.Elapsed)
+	require.NotEmpty(t, resumed.Systems)
+	assert.Equal(t, field.Systems[0].ID, resumed.Systems[0].ID)
+}
+
+func TestService_SimulateWeatherStep_IgnoresFieldOfAnotherShape(t *testing.T) {
+	ctx := context.Background()
+	worldID := uuid.New()
+	stale := NewPressureField(gridCells(4, 4, func(x, y int) bool { return true }), 1)
+	stale.Elapsed = 10 * time.Hour
+
+	repo := &MockRepository{}
+	repo.On("SaveWeatherState", mock.Anything, mock.Anything).Return(nil)
+	repo.On("GetPressureField", mock.Anything, worldID).Return(stale, nil)
+	repo.On("SavePressureField", mock.Anything, worldID, mock.Anything).Return(nil)
+
+	service := NewService(repo)
+	service.InitializeWorldWeather(ctx, worldID, nil, gridCells(10, 6, func(x, y int) bool { return x < 5 }))
+	_, err := service.SimulateWeatherStep(ctx, worldID, time.Hour)
+	require.NoError(t, err)
+
+	field := service.PressureField(worldID)
+	assert.NotSame(t, stale, field)
+	assert.Equal(t, 10, field.Width)
+	assert.Equal(t, time.Hour, field.Elapsed)
+}
@@ -191,11 +191,24 @@ func GeneratePressureMap(
 	climateData SphereClimateMap,
 	dayOfYear int,
 	seaLevel float64,
+) map[spatial.Coordinate]float64 {
+	return GeneratePressureMapWithTilt(sphereMap, topology, climateData, dayOfYear, seaLevel, DefaultAxialTilt)
+}
+
+// GeneratePressureMapWithTilt is GeneratePressureMap for a planet with the
+// given axial tilt (degrees): each cell's temperature swings with the
+// season as SeasonalTemperature describes for its latitude.
+func GeneratePressureMapWithTilt(
+	sphereMap *geography.SphereHeightmap,
+	topology spatial.Topology,
+	climateData SphereClimateMap,
+	dayOfYear int,
+	seaLevel float64,
+	axialTilt float64,
 ) map[spatial.Coordinate]float64 {
 	pressureMap := make(map[spatial.Coordinate]float64)
 
 	faceSize := sphereMap.Resolution()
-	declination := CalculateSolarDeclination(dayOfYear)
 
 	for face := 0; face < 6; face++ {
 		for y := 0; y < faceSize; y++ {
@@ -209,10 +222,9 @@ func GeneratePressureMap(
 				// Get base temperature from climate data
 				baseTemp := climateData[face][idx].Temperature
 
-				// Apply seasonal temperature modifier
+				// Apply the seasonal swing around the annual mean
 				lat := GetLatitudeFromCoord(topology, coord)
-				tempMod := GetSeasonalTemperatureModifier(lat, declination)
-				temp := baseTemp + tempMod
+				temp := baseTemp + SeasonalTemperature(lat, float64(dayOfYear), axialTilt)
 
 				// Calculate pressure
 				pressure := CalculateSurfacePressure(isLand, temp)
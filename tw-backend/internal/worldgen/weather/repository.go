@@ -3,6 +3,9 @@ package weather
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +16,9 @@ type Repository interface {
 	GetWeatherState(ctx context.Context, cellID uuid.UUID, timestamp int64) (*WeatherState, error)
 	GetWeatherHistory(ctx context.Context, cellID uuid.UUID, days int) ([]*WeatherState, error)
 	GetAnnualPrecipitation(ctx context.Context, cellID uuid.UUID, year int) (float64, error)
+	SavePressureField(ctx context.Context, worldID uuid.UUID, field *PressureField) error
+	// GetPressureField returns nil, nil for a world with no saved field
+	GetPressureField(ctx context.Context, worldID uuid.UUID) (*PressureField, error)
 }
 
 // PostgresRepository implements Repository for PostgreSQL
@@ -62,3 +68,34 @@ func (r *PostgresRepository) GetAnnualPrecipitation(ctx context.Context, cellID
 	err := r.DB.QueryRowContext(ctx, query, cellID, year).Scan(&total)
 	return total, err
 }
+
+func (r *PostgresRepository) SavePressureField(ctx context.Context, worldID uuid.UUID, field *PressureField) error {
+	data, err := json.Marshal(field)
+	if err != nil {
+		return fmt.Errorf("marshal pressure field: %w", err)
+	}
+	query := `
+		INSERT INTO weather_pressure_fields (world_id, field, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (world_id) DO UPDATE SET field = EXCLUDED.field, updated_at = NOW()
+	`
+	_, err = r.DB.ExecContext(ctx, query, worldID, data)
+	return err
+}
+
+func (r *PostgresRepository) GetPressureField(ctx context.Context, worldID uuid.UUID) (*PressureField, error) {
+	var data []byte
+	err := r.DB.QueryRowContext(ctx, `SELECT field FROM weather_pressure_fields WHERE world_id = $1`, worldID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var field PressureField
+	if err := json.Unmarshal(data, &field); err != nil {
+		return nil, fmt.Errorf("unmarshal pressure field: %w", err)
+	}
+	return &field, nil
+}
@@ -267,3 +267,103 @@ func CalculatePressureGradientWind(
 		Z: gradientZ * windScale,
 	}
 }
+
+// seasonalTempPerInsolation converts daily insolation (1 = the equator at
+// equinox) into °C. Land and sea damp the raw swing; 25°C per unit puts an
+// Earth-like pole about ±16°C around its annual mean.
+const seasonalTempPerInsolation = 25.0
+
+// fullSeasonality is the seasonal half-swing (°C) that counts as
+// ClimateData.Seasonality 1
+const fullSeasonality = 20.0
+
+// insolationSamples is how many days SeasonalAmplitude and the annual mean
+// sample across the year
+const insolationSamples = 73
+
+// SeasonalTemperature returns how far a latitude's temperature sits above
+// (positive) or below (negative) its annual mean on a day of the year, for
+// a planet with the given obliquity (axial tilt, degrees).
+//
+// The swing follows daily insolation: how high the sun climbs and how long
+// it stays up (see DayLength). The equator barely changes, while the poles
+// run from midnight sun to polar night, so their swing is many times
+// larger. More tilt means harder seasons; an untilted world has none. A
+// world whose obliquity wanders chaotically (no large moon, see
+// astronomy.CalculateOrbitalStateWithStability) sees its seasons wander
+// with it.
+func SeasonalTemperature(latitude, dayOfYear, obliquity float64) float64 {
+	q := dailyInsolation(latitude, solarDeclination(dayOfYear, obliquity))
+	return seasonalTempPerInsolation * (q - annualInsolation(latitude, obliquity))
+}
+
+// SeasonalAmplitude returns half the yearly temperature swing (°C) at a
+// latitude: the summer peak of SeasonalTemperature less the winter trough,
+// halved
+func SeasonalAmplitude(latitude, obliquity float64) float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i := 0; i < insolationSamples; i++ {
+		day := 365.0 * float64(i) / insolationSamples
+		q := dailyInsolation(latitude, solarDeclination(day, obliquity))
+		lo, hi = math.Min(lo, q), math.Max(hi, q)
+	}
+	return seasonalTempPerInsolation * (hi - lo) / 2
+}
+
+// DayLength returns the hours of daylight at a latitude on a day of the
+// year, from 0 (polar night) through 12 (equinox, or anywhere on the
+// equator) to 24 (midnight sun).
+//
+// Formula: cos(h0) = -tan(φ) * tan(δ), day length = 24 * h0 / π
+func DayLength(latitude, dayOfYear, obliquity float64) float64 {
+	return 24 * sunsetHourAngle(latitude, solarDeclination(dayOfYear, obliquity)) / math.Pi
+}
+
+// solarDeclination is CalculateSolarDeclinationWithTilt for a fractional
+// day, with the tilt folded into [0, 90]
+func solarDeclination(dayOfYear, obliquity float64) float64 {
+	obliquity = math.Mod(math.Abs(obliquity), 180)
+	if obliquity > 90 {
+		obliquity = 180 - obliquity // Retrograde spin: same seasons
+	}
+	return obliquity * math.Sin(2*math.Pi/365.0*(dayOfYear+284))
+}
+
+// sunsetHourAngle returns the hour angle (radians, 0 to π) at which the sun
+// sets at latitude for declination
+func sunsetHourAngle(latitude, declination float64) float64 {
+	phi := math.Max(-90, math.Min(90, latitude)) * math.Pi / 180
+	delta := declination * math.Pi / 180
+
+	cosH := -math.Tan(phi) * math.Tan(delta)
+	switch {
+	case cosH >= 1:
+		return 0 // Polar night
+	case cosH <= -1:
+		return math.Pi // Midnight sun
+	}
+	return math.Acos(cosH)
+}
+
+// dailyInsolation returns the day's mean top-of-atmosphere sunlight at
+// latitude, relative to the equator at equinox (1.0)
+func dailyInsolation(latitude, declination float64) float64 {
+	phi := math.Max(-90, math.Min(90, latitude)) * math.Pi / 180
+	delta := declination * math.Pi / 180
+	h0 := sunsetHourAngle(latitude, declination)
+
+	q := h0*math.Sin(phi)*math.Sin(delta) + math.Cos(phi)*math.Cos(delta)*math.Sin(h0)
+	return math.Max(0, q)
+}
+
+// annualInsolation returns the year's mean dailyInsolation at latitude.
+// Tilt moves sunlight poleward: the more tilted the planet, the warmer its
+// poles and the cooler its equator over a year.
+func annualInsolation(latitude, obliquity float64) float64 {
+	sum := 0.0
+	for i := 0; i < insolationSamples; i++ {
+		day := 365.0 * float64(i) / insolationSamples
+		sum += dailyInsolation(latitude, solarDeclination(day, obliquity))
+	}
+	return sum / insolationSamples
+}
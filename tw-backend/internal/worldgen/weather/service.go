@@ -2,12 +2,14 @@ package weather
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"sync"
 	"time"
 
 	"tw-backend/internal/spatial"
+	"tw-backend/internal/worldgen/geography"
 
 	"github.com/google/uuid"
 )
@@ -19,14 +21,18 @@ type Service struct {
 	geoCache   map[uuid.UUID][]*GeographyCell            // worldID -> cells
 	cacheMutex sync.RWMutex
 	topology   spatial.Topology // Optional: nil = flat mode
+
+	// pressureFields keeps each world's moving weather between ticks
+	pressureFields map[uuid.UUID]*PressureField
 }
 
 // NewService creates a new weather service
 func NewService(repo Repository) *Service {
 	return &Service{
-		repo:       repo,
-		stateCache: make(map[uuid.UUID]map[uuid.UUID]*WeatherState),
-		geoCache:   make(map[uuid.UUID][]*GeographyCell),
+		repo:           repo,
+		stateCache:     make(map[uuid.UUID]map[uuid.UUID]*WeatherState),
+		geoCache:       make(map[uuid.UUID][]*GeographyCell),
+		pressureFields: make(map[uuid.UUID]*PressureField),
 	}
 }
 
@@ -54,12 +60,74 @@ func (s *Service) UpdateWorldWeather(ctx context.Context, worldID uuid.UUID, cur
 	// Calculate new states
 	newStates := UpdateWeather(cells, currentTime, season)
 
-	// Persist states and detect changes
-	emotes := make(map[uuid.UUID]string)
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	return s.storeStates(ctx, worldID, newStates)
+}
+
+// SimulateWeatherStep advances a world's moving weather by dt: pressure
+// systems drift with the prevailing winds, storms form over the oceans of
+// the convergence zones, and the wind carries rain downwind (see
+// PressureField.Step). Each cell's weather is saved and cached for
+// GetCurrentWeather. The pressure field is saved too, and carries over to
+// the next call, or the first one after a restart.
+// Returns emotes for cells whose weather changed noticeably.
+func (s *Service) SimulateWeatherStep(ctx context.Context, worldID uuid.UUID, dt time.Duration) (map[uuid.UUID]string, error) {
+	s.cacheMutex.RLock()
+	cells, ok := s.geoCache[worldID]
+	s.cacheMutex.RUnlock()
+
+	if !ok || len(cells) == 0 {
+		return nil, fmt.Errorf("no geography data found for world %s", worldID)
+	}
 
 	s.cacheMutex.Lock()
 	defer s.cacheMutex.Unlock()
 
+	field, ok := s.pressureFields[worldID]
+	if !ok {
+		var err error
+		if field, err = s.loadPressureField(ctx, worldID, cells); err != nil {
+			return nil, err
+		}
+		s.pressureFields[worldID] = field
+	}
+	newStates := field.Step(cells, dt, s.stateCache[worldID])
+	if err := s.repo.SavePressureField(ctx, worldID, field); err != nil {
+		return nil, fmt.Errorf("failed to save pressure field: %w", err)
+	}
+
+	return s.storeStates(ctx, worldID, newStates)
+}
+
+// loadPressureField returns the world's saved pressure field, or a new one
+// if none was saved or the saved one no longer fits its cells
+func (s *Service) loadPressureField(ctx context.Context, worldID uuid.UUID, cells []*GeographyCell) (*PressureField, error) {
+	field, err := s.repo.GetPressureField(ctx, worldID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pressure field: %w", err)
+	}
+	if field != nil && field.Fits(cells) {
+		return field, nil
+	}
+	return NewPressureField(cells, int64(binary.BigEndian.Uint64(worldID[:8]))), nil
+}
+
+// PressureField returns a world's pressure field, or nil before its first
+// SimulateWeatherStep
+func (s *Service) PressureField(worldID uuid.UUID) *PressureField {
+	s.cacheMutex.RLock()
+	defer s.cacheMutex.RUnlock()
+	return s.pressureFields[worldID]
+}
+
+// storeStates saves and caches new states, returning emotes for the cells
+// whose weather changed. The caller holds cacheMutex.
+func (s *Service) storeStates(ctx context.Context, worldID uuid.UUID, newStates []*WeatherState) (map[uuid.UUID]string, error) {
+	// Persist states and detect changes
+	emotes := make(map[uuid.UUID]string)
+
 	worldCache, ok := s.stateCache[worldID]
 	if !ok {
 		worldCache = make(map[uuid.UUID]*WeatherState)
@@ -103,6 +171,18 @@ func (s *Service) GetCurrentWeather(ctx context.Context, worldID, cellID uuid.UU
 	return nil, nil // Or specific error
 }
 
+// CellLocations returns where each of a world's weather cells lies
+func (s *Service) CellLocations(worldID uuid.UUID) map[uuid.UUID]geography.Point {
+	s.cacheMutex.RLock()
+	defer s.cacheMutex.RUnlock()
+
+	locations := make(map[uuid.UUID]geography.Point, len(s.geoCache[worldID]))
+	for _, cell := range s.geoCache[worldID] {
+		locations[cell.CellID] = cell.Location
+	}
+	return locations
+}
+
 // InitializeWorldWeather loads initial weather states and geography into the cache
 func (s *Service) InitializeWorldWeather(ctx context.Context, worldID uuid.UUID, states []*WeatherState, cells []*GeographyCell) {
 	s.cacheMutex.Lock()
@@ -40,6 +40,19 @@ func (m *MockRepository) GetAnnualPrecipitation(ctx context.Context, cellID uuid
 	return args.Get(0).(float64), args.Error(1)
 }
 
+func (m *MockRepository) SavePressureField(ctx context.Context, worldID uuid.UUID, field *PressureField) error {
+	args := m.Called(ctx, worldID, field)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetPressureField(ctx context.Context, worldID uuid.UUID) (*PressureField, error) {
+	args := m.Called(ctx, worldID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PressureField), args.Error(1)
+}
+
 func TestService_InitializeWorldWeather(t *testing.T) {
 	repo := &MockRepository{}
 	service := NewService(repo)
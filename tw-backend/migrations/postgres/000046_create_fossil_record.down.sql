@@ -0,0 +1 @@
+DROP TABLE IF EXISTS fossil_record;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS fossil_record (
+    world_id UUID NOT NULL REFERENCES worlds(id) ON DELETE CASCADE,
+    species_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    diet VARCHAR(32) NOT NULL,
+    traits JSONB NOT NULL,
+    peak_population BIGINT NOT NULL DEFAULT 0,
+    existed_from BIGINT NOT NULL,
+    existed_until BIGINT NOT NULL,
+    extinction_cause VARCHAR(64) NOT NULL,
+    extinction_details TEXT NOT NULL DEFAULT '',
+    fossil_biomes JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (world_id, species_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fossil_record_world_era ON fossil_record(world_id, existed_from, existed_until);
+CREATE INDEX IF NOT EXISTS idx_fossil_record_world_cause ON fossil_record(world_id, extinction_cause);
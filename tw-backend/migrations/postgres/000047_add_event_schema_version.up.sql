@@ -0,0 +1,2 @@
+-- Payload schema version, used to upcast older events on read
+ALTER TABLE events ADD COLUMN IF NOT EXISTS schema_version INT NOT NULL DEFAULT 1;
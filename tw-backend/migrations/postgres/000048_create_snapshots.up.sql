@@ -0,0 +1,9 @@
+-- Aggregate snapshots, so rehydration only replays events after the snapshot
+CREATE TABLE IF NOT EXISTS snapshots (
+    aggregate_id TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    version BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (aggregate_id, version)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_timestamp_sequence;
+ALTER TABLE events DROP COLUMN IF EXISTS sequence;
@@ -0,0 +1,4 @@
+-- Global insertion order, so events written in one batch keep their order
+-- when queried by timestamp
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence BIGSERIAL;
+CREATE INDEX IF NOT EXISTS idx_events_timestamp_sequence ON events(timestamp, sequence);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS events_notify_appended ON events;
+DROP FUNCTION IF EXISTS notify_event_appended();
@@ -0,0 +1,13 @@
+-- Wake event subscribers (LISTEN events_appended) after each insert statement.
+-- Notifications are sent on commit, so subscribers only see committed events.
+CREATE OR REPLACE FUNCTION notify_event_appended() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('events_appended', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS events_notify_appended ON events;
+CREATE TRIGGER events_notify_appended
+    AFTER INSERT ON events
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_event_appended();
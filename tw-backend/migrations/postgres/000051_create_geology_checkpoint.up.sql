@@ -0,0 +1,7 @@
+-- Latest geology checkpoint per world, so geology-only runs resume after a restart
+CREATE TABLE IF NOT EXISTS world_geology_checkpoint (
+    world_id UUID PRIMARY KEY REFERENCES worlds(id) ON DELETE CASCADE,
+    year BIGINT NOT NULL,
+    data BYTEA NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+-- One saved simulation per world, written by "world save" and read by "world load"
+CREATE TABLE IF NOT EXISTS world_saved_state (
+    world_id UUID PRIMARY KEY REFERENCES worlds(id) ON DELETE CASCADE,
+    year BIGINT NOT NULL,
+    geology BYTEA NOT NULL,
+    population BYTEA,
+    saved_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1 @@
+ALTER TABLE character_inventory ADD COLUMN IF NOT EXISTS equipped_slot TEXT;
@@ -0,0 +1,7 @@
+-- Each world's moving weather (pressure systems, storms, airborne moisture),
+-- kept between world-service restarts
+CREATE TABLE IF NOT EXISTS weather_pressure_fields (
+    world_id UUID PRIMARY KEY REFERENCES worlds(id) ON DELETE CASCADE,
+    field JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);